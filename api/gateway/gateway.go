@@ -14,6 +14,7 @@ import (
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/api/namespace"
 	"github.com/prysmaticlabs/prysm/runtime"
 	"github.com/rs/cors"
 	"google.golang.org/grpc"
@@ -48,6 +49,8 @@ type config struct {
 	gatewayAddr                  string
 	remoteAddr                   string
 	allowedOrigins               []string
+	allowCredentials             bool
+	namespaces                   namespace.Set
 	apiMiddlewareEndpointFactory apimiddleware.EndpointFactory
 	muxHandler                   MuxHandler
 	pbHandlers                   []*PbMux
@@ -71,7 +74,8 @@ func New(ctx context.Context, opts ...Option) (*Gateway, error) {
 	g := &Gateway{
 		ctx: ctx,
 		cfg: &config{
-			router: mux.NewRouter(),
+			router:           mux.NewRouter(),
+			allowCredentials: true,
 		},
 	}
 	for _, opt := range opts {
@@ -79,6 +83,14 @@ func New(ctx context.Context, opts ...Option) (*Gateway, error) {
 			return nil, err
 		}
 	}
+	if g.cfg.allowCredentials {
+		for _, o := range g.cfg.allowedOrigins {
+			if strings.TrimSpace(o) == "*" {
+				return nil, errors.New("cross origin requests with credentials cannot be allowed from any origin, " +
+					"list the allowed origins or disable credentials")
+			}
+		}
+	}
 	return g, nil
 }
 
@@ -108,7 +120,7 @@ func (g *Gateway) Start() {
 		}
 	}
 
-	corsMux := g.corsMiddleware(g.cfg.router)
+	corsMux := g.corsMiddleware(g.namespaceMiddleware(g.cfg.router))
 
 	if g.cfg.apiMiddlewareEndpointFactory != nil && !g.cfg.apiMiddlewareEndpointFactory.IsNil() {
 		g.registerApiMiddleware()
@@ -170,13 +182,29 @@ func (g *Gateway) corsMiddleware(h http.Handler) http.Handler {
 	c := cors.New(cors.Options{
 		AllowedOrigins:   g.cfg.allowedOrigins,
 		AllowedMethods:   []string{http.MethodPost, http.MethodGet, http.MethodDelete, http.MethodOptions},
-		AllowCredentials: true,
+		AllowCredentials: g.cfg.allowCredentials,
 		MaxAge:           600,
 		AllowedHeaders:   []string{"*"},
 	})
 	return c.Handler(h)
 }
 
+// namespaceMiddleware answers requests to API namespaces which are not enabled in the gateway as if
+// their routes did not exist. Requests outside of any namespace are always served.
+func (g *Gateway) namespaceMiddleware(h http.Handler) http.Handler {
+	if g.cfg.namespaces == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n, ok := namespace.FromHTTPPath(r.URL.Path); ok && !g.cfg.namespaces.Contains(n) {
+			log.WithField("namespace", n).Debugf("Rejected request to disabled API namespace: %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
 const swaggerDir = "proto/prysm/v1alpha1/"
 
 // SwaggerServer returns swagger specification files located under "/swagger/"
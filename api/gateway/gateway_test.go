@@ -11,6 +11,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/prysmaticlabs/prysm/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/api/namespace"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
@@ -126,3 +127,33 @@ func TestGateway_NilHandler_NotFoundHandlerRegistered(t *testing.T) {
 	g.cfg.router.ServeHTTP(writer, &http.Request{Method: "GET", Host: "localhost", URL: &url.URL{Path: "/foo"}})
 	assert.Equal(t, http.StatusNotFound, writer.Code)
 }
+
+func TestGateway_NamespaceMiddleware(t *testing.T) {
+	g, err := New(context.Background(), WithNamespaces(namespace.Set{namespace.Beacon: true}))
+	require.NoError(t, err)
+	h := g.namespaceMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := map[string]int{
+		"/eth/v1/beacon/genesis":            http.StatusOK,
+		"/internal/eth/v1/beacon/genesis":   http.StatusOK,
+		"/eth/v1/validator/duties/proposer": http.StatusNotFound,
+		"/eth/v1alpha1/node/version":        http.StatusNotFound,
+		"/swagger/index.html":               http.StatusOK,
+	}
+	for path, want := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, want, w.Code, path)
+	}
+}
+
+func TestGateway_CredentialsFromAnyOrigin(t *testing.T) {
+	_, err := New(context.Background(), WithAllowedOrigins([]string{"http://localhost:4200", "*"}))
+	assert.ErrorContains(t, "cannot be allowed from any origin", err)
+
+	g, err := New(context.Background(), WithAllowedOrigins([]string{"*"}), WithAllowCredentials(false))
+	require.NoError(t, err)
+	assert.Equal(t, false, g.cfg.allowCredentials)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gorilla/mux"
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/prysmaticlabs/prysm/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/api/namespace"
 )
 
 type Option func(g *Gateway) error
@@ -59,6 +60,22 @@ func WithAllowedOrigins(origins []string) Option {
 	}
 }
 
+// WithAllowCredentials allows disabling credentials, such as cookies, in cross origin requests.
+func WithAllowCredentials(allow bool) Option {
+	return func(g *Gateway) error {
+		g.cfg.allowCredentials = allow
+		return nil
+	}
+}
+
+// WithNamespaces restricts the routes served by the gateway to the given API namespaces.
+func WithNamespaces(namespaces namespace.Set) Option {
+	return func(g *Gateway) error {
+		g.cfg.namespaces = namespaces
+		return nil
+	}
+}
+
 // WithRemoteCert allows adding a custom certificate to the gateway,
 func WithRemoteCert(cert string) Option {
 	return func(g *Gateway) error {
@@ -0,0 +1,19 @@
+package grpc
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StaleHeadErrorMessage starts the message of the Unavailable error returned by a beacon node
+// refusing to serve validator duties because its head is too far behind the current slot.
+const StaleHeadErrorMessage = "beacon node head is stale"
+
+// IsStaleHeadError returns true if the error was returned by a beacon node whose head is too far
+// behind the current slot to serve validators.
+func IsStaleHeadError(err error) bool {
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.Unavailable && strings.HasPrefix(st.Message(), StaleHeadErrorMessage)
+}
@@ -0,0 +1,18 @@
+package grpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsStaleHeadError(t *testing.T) {
+	assert.Equal(t, true, IsStaleHeadError(status.Errorf(codes.Unavailable, "%s: head slot 1", StaleHeadErrorMessage)))
+	assert.Equal(t, false, IsStaleHeadError(status.Error(codes.Internal, StaleHeadErrorMessage)))
+	assert.Equal(t, false, IsStaleHeadError(status.Error(codes.Unavailable, "Syncing to latest head, not ready to respond")))
+	assert.Equal(t, false, IsStaleHeadError(errors.New(StaleHeadErrorMessage)))
+	assert.Equal(t, false, IsStaleHeadError(nil))
+}
@@ -0,0 +1,167 @@
+// Package namespace groups the gRPC services and HTTP routes of the beacon node API into namespaces, which can be
+// enabled and protected with an authentication token independently from each other.
+package namespace
+
+import (
+	"crypto/subtle"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Namespace is a group of API endpoints.
+type Namespace string
+
+const (
+	// Beacon contains the standard beacon chain, config and events endpoints.
+	Beacon Namespace = "beacon"
+	// Node contains the standard node endpoints.
+	Node Namespace = "node"
+	// Validator contains the endpoints used by validator clients.
+	Validator Namespace = "validator"
+	// Debug contains the debug endpoints.
+	Debug Namespace = "debug"
+	// Prysm contains the remaining Prysm specific endpoints.
+	Prysm Namespace = "prysm"
+)
+
+// All lists every namespace of the API.
+var All = []Namespace{Beacon, Node, Validator, Debug, Prysm}
+
+// grpcServices maps gRPC service names to their namespace. Services missing from the map, such as the
+// reflection service, are not part of any namespace.
+var grpcServices = map[string]Namespace{
+	"ethereum.eth.service.BeaconChain":          Beacon,
+	"ethereum.eth.service.Events":               Beacon,
+	"ethereum.eth.service.BeaconNode":           Node,
+	"ethereum.eth.service.BeaconValidator":      Validator,
+	"ethereum.eth.service.BeaconDebug":          Debug,
+	"ethereum.eth.v1alpha1.BeaconNodeValidator": Validator,
+	"ethereum.eth.v1alpha1.Debug":               Debug,
+	"ethereum.eth.v1alpha1.BeaconChain":         Prysm,
+	"ethereum.eth.v1alpha1.Node":                Prysm,
+	"ethereum.eth.v1alpha1.Health":              Prysm,
+}
+
+// httpRoutes maps HTTP path prefixes to their namespace. The longest matching prefix wins.
+var httpRoutes = map[string]Namespace{
+	"/eth/v1alpha1/validator": Validator,
+	"/eth/v1alpha2/validator": Validator,
+	"/eth/v1alpha1/debug":     Debug,
+	"/eth/v1alpha1/":          Prysm,
+	"/eth/v1alpha2/":          Prysm,
+	"/eth/v1/beacon":          Beacon,
+	"/eth/v2/beacon":          Beacon,
+	"/eth/v1/config":          Beacon,
+	"/eth/v1/events":          Beacon,
+	"/eth/v1/node":            Node,
+	"/eth/v1/validator":       Validator,
+	"/eth/v2/validator":       Validator,
+	"/eth/v1/debug":           Debug,
+	"/eth/v2/debug":           Debug,
+}
+
+// httpPrefixes holds the keys of httpRoutes from the longest to the shortest.
+var httpPrefixes = func() []string {
+	p := make([]string, 0, len(httpRoutes))
+	for k := range httpRoutes {
+		p = append(p, k)
+	}
+	sort.Slice(p, func(i, j int) bool { return len(p[i]) > len(p[j]) })
+	return p
+}()
+
+// Set is a set of namespaces.
+type Set map[Namespace]bool
+
+// ParseSet parses a comma-separated list of namespace names. Names are case-insensitive and an empty list
+// gives an empty set.
+func ParseSet(list string) (Set, error) {
+	s := make(Set)
+	for _, n := range strings.Split(list, ",") {
+		n = strings.ToLower(strings.TrimSpace(n))
+		if n == "" {
+			continue
+		}
+		if !isKnown(Namespace(n)) {
+			return nil, errors.Errorf("unknown API namespace %q, possible values are %s", n, Names())
+		}
+		s[Namespace(n)] = true
+	}
+	return s, nil
+}
+
+// Contains returns true if the namespace is part of the set.
+func (s Set) Contains(n Namespace) bool {
+	return s[n]
+}
+
+// Missing returns the namespaces of the set which are missing from the other set.
+func (s Set) Missing(other Set) []Namespace {
+	missing := make([]Namespace, 0)
+	for _, n := range All {
+		if s[n] && !other[n] {
+			missing = append(missing, n)
+		}
+	}
+	return missing
+}
+
+// String returns the comma-separated names of the namespaces of the set.
+func (s Set) String() string {
+	names := make([]string, 0, len(s))
+	for _, n := range All {
+		if s[n] {
+			names = append(names, string(n))
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// Names returns the comma-separated names of all namespaces.
+func Names() string {
+	names := make([]string, len(All))
+	for i, n := range All {
+		names[i] = string(n)
+	}
+	return strings.Join(names, ",")
+}
+
+// FromGRPCMethod returns the namespace of a full gRPC method name such as
+// "/ethereum.eth.v1alpha1.Node/GetVersion". It returns false if the method is not part of any namespace.
+func FromGRPCMethod(fullMethod string) (Namespace, bool) {
+	service := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(service, "/"); i >= 0 {
+		service = service[:i]
+	}
+	n, ok := grpcServices[service]
+	return n, ok
+}
+
+// FromHTTPPath returns the namespace of an HTTP request path. Internal gateway paths are matched like the
+// public paths they serve. It returns false if the path is not part of any namespace.
+func FromHTTPPath(path string) (Namespace, bool) {
+	path = strings.TrimPrefix(path, "/internal")
+	for _, p := range httpPrefixes {
+		if strings.HasPrefix(path, p) {
+			return httpRoutes[p], true
+		}
+	}
+	return "", false
+}
+
+// Authorized returns true if the value of an authorization header holds the expected bearer token.
+func Authorized(header, token string) bool {
+	got := strings.TrimPrefix(strings.TrimSpace(header), "Bearer ")
+	return token != "" && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+func isKnown(n Namespace) bool {
+	for _, k := range All {
+		if k == n {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package namespace
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestParseSet(t *testing.T) {
+	s, err := ParseSet("Beacon, node,,validator")
+	require.NoError(t, err)
+	assert.DeepEqual(t, Set{Beacon: true, Node: true, Validator: true}, s)
+	assert.Equal(t, false, s.Contains(Debug))
+	assert.Equal(t, "beacon,node,validator", s.String())
+
+	s, err = ParseSet("")
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(s))
+
+	_, err = ParseSet("beacon,admin")
+	assert.ErrorContains(t, "unknown API namespace \"admin\"", err)
+}
+
+func TestSet_Missing(t *testing.T) {
+	s := Set{Beacon: true, Debug: true, Prysm: true}
+	assert.DeepEqual(t, []Namespace{Debug, Prysm}, s.Missing(Set{Beacon: true}))
+	assert.DeepEqual(t, []Namespace{}, s.Missing(s))
+}
+
+func TestFromGRPCMethod(t *testing.T) {
+	tests := map[string]Namespace{
+		"/ethereum.eth.v1alpha1.BeaconNodeValidator/GetDuties":    Validator,
+		"/ethereum.eth.v1alpha1.Node/GetVersion":                  Prysm,
+		"/ethereum.eth.v1alpha1.Debug/GetBeaconState":             Debug,
+		"/ethereum.eth.service.BeaconChain/GetBlockV2":            Beacon,
+		"/ethereum.eth.service.Events/StreamEvents":               Beacon,
+		"/ethereum.eth.service.BeaconNode/GetSyncStatus":          Node,
+		"/ethereum.eth.service.BeaconValidator/GetAttesterDuties": Validator,
+	}
+	for method, want := range tests {
+		n, ok := FromGRPCMethod(method)
+		assert.Equal(t, true, ok, method)
+		assert.Equal(t, want, n, method)
+	}
+	_, ok := FromGRPCMethod("/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo")
+	assert.Equal(t, false, ok)
+}
+
+func TestFromHTTPPath(t *testing.T) {
+	tests := map[string]Namespace{
+		"/eth/v1alpha1/validator/duties":      Validator,
+		"/eth/v1alpha1/beacon/chainhead":      Prysm,
+		"/eth/v1alpha1/debug/state":           Debug,
+		"/eth/v1/beacon/genesis":              Beacon,
+		"/internal/eth/v1/beacon/genesis":     Beacon,
+		"/eth/v1/config/spec":                 Beacon,
+		"/eth/v1/node/syncing":                Node,
+		"/eth/v1/validator/duties/attester/1": Validator,
+		"/eth/v2/debug/beacon/states/head":    Debug,
+		"/internal/eth/v1/events":             Beacon,
+	}
+	for path, want := range tests {
+		n, ok := FromHTTPPath(path)
+		assert.Equal(t, true, ok, path)
+		assert.Equal(t, want, n, path)
+	}
+	_, ok := FromHTTPPath("/swagger/index.html")
+	assert.Equal(t, false, ok)
+}
+
+func TestAuthorized(t *testing.T) {
+	assert.Equal(t, true, Authorized("Bearer secret", "secret"))
+	assert.Equal(t, true, Authorized("secret", "secret"))
+	assert.Equal(t, false, Authorized("Bearer other", "secret"))
+	assert.Equal(t, false, Authorized("", ""))
+}
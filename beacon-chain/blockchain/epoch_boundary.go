@@ -0,0 +1,252 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async"
+	"github.com/prysmaticlabs/prysm/async/event"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// maxEpochBoundaryWorkers is the maximum number of branches advanced across an epoch boundary at once.
+const maxEpochBoundaryWorkers = 4
+
+// Results of the comparison of the advanced branches with the branch the head descends from.
+const (
+	predictionHeaviest = "heaviest"
+	predictionAdvanced = "advanced"
+	predictionMissed   = "missed"
+)
+
+// boundaryBranch is a fork choice tip whose state is advanced to the start of the next epoch.
+type boundaryBranch struct {
+	root   [32]byte
+	slot   types.Slot
+	weight uint64
+}
+
+// boundaryPrediction records the branches advanced for an epoch, heaviest first.
+type boundaryPrediction struct {
+	epoch    types.Epoch
+	branches []*boundaryBranch
+}
+
+// This routine advances the states of the heaviest branches to the start of the next epoch during
+// the last slot of every epoch, so that whichever branch wins, attestation production and validation
+// do not process the epoch transition on the spot. At the start of the following epoch, the advanced
+// branches are compared with the branch the head descends from.
+func (s *Service) spawnEpochBoundaryRoutine(stateFeed *event.Feed) {
+	if s.cfg.EpochBoundaryBranches <= 0 {
+		return
+	}
+	// Wait for state to be initialized.
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := stateFeed.Subscribe(stateChannel)
+	go func() {
+		select {
+		case <-s.ctx.Done():
+			stateSub.Unsubscribe()
+			return
+		case <-stateChannel:
+			stateSub.Unsubscribe()
+			break
+		}
+
+		for s.genesisTime.IsZero() {
+			if s.ctx.Err() != nil {
+				return
+			}
+			time.Sleep(1 * time.Second)
+		}
+
+		// Tick a third into every slot, after the block of the slot is expected to be processed.
+		secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+		offset := time.Duration(secondsPerSlot) * time.Second / 3
+		ticker := slots.NewSlotTickerWithOffset(s.genesisTime, offset, secondsPerSlot)
+		defer ticker.Done()
+		var prediction *boundaryPrediction
+		for {
+			select {
+			case <-s.ctx.Done():
+				log.Debug("Context closed, exiting routine")
+				return
+			case slot := <-ticker.C():
+				if prediction != nil && slots.ToEpoch(slot) >= prediction.epoch {
+					if err := s.recordBoundaryPrediction(s.ctx, prediction); err != nil {
+						log.WithError(err).Debug("Could not record epoch boundary branch prediction")
+					}
+					prediction = nil
+				}
+				if !slots.IsEpochEnd(slot) {
+					continue
+				}
+				p, err := s.advanceEpochBoundaryBranches(s.ctx, slots.ToEpoch(slot)+1)
+				if err != nil {
+					log.WithError(err).Error("Could not advance epoch boundary branches")
+					continue
+				}
+				prediction = p
+			}
+		}
+	}()
+}
+
+// boundaryBranches returns up to the configured number of fork choice tips viable as the head at
+// the start of the given epoch, heaviest first. Only the tips of the ending epoch are kept, along
+// with the current head which is always advanced.
+func (s *Service) boundaryBranches(epoch types.Epoch) ([]*boundaryBranch, error) {
+	prevEpochStart, err := slots.EpochStart(epoch.Sub(1))
+	if err != nil {
+		return nil, err
+	}
+	weights := make(map[[32]byte]uint64)
+	for _, n := range s.ForkChoicer().ForkChoiceNodes() {
+		weights[bytesutil.ToBytes32(n.Root)] = n.Weight
+	}
+	headRoot := s.headRoot()
+	roots, tipSlots := s.ForkChoicer().Tips()
+	branches := make([]*boundaryBranch, 0, len(roots))
+	for i, r := range roots {
+		if r != headRoot && tipSlots[i] < prevEpochStart {
+			continue
+		}
+		branches = append(branches, &boundaryBranch{root: r, slot: tipSlots[i], weight: weights[r]})
+	}
+	sort.SliceStable(branches, func(i, j int) bool {
+		// The current head goes first on ties, as it is the most likely to win.
+		if branches[i].weight == branches[j].weight {
+			return branches[i].root == headRoot
+		}
+		return branches[i].weight > branches[j].weight
+	})
+	if len(branches) > s.cfg.EpochBoundaryBranches {
+		branches = branches[:s.cfg.EpochBoundaryBranches]
+	}
+	return branches, nil
+}
+
+// advanceEpochBoundaryBranches advances the states of the heaviest branches to the start of the
+// given epoch concurrently, and saves them in the checkpoint state cache used to validate the
+// attestations of the epoch. Processing the slots also fills the skip slot cache used to produce
+// attestations.
+func (s *Service) advanceEpochBoundaryBranches(ctx context.Context, epoch types.Epoch) (*boundaryPrediction, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.advanceEpochBoundaryBranches")
+	defer span.End()
+
+	branches, err := s.boundaryBranches(epoch)
+	if err != nil {
+		return nil, err
+	}
+	epochStart, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := make(chan struct{}, maxEpochBoundaryWorkers)
+	var wg sync.WaitGroup
+	for _, b := range branches {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(b *boundaryBranch) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			start := time.Now()
+			if err := s.advanceBoundaryBranch(ctx, b.root, epoch, epochStart); err != nil {
+				log.WithError(err).WithField("root", fmt.Sprintf("%#x", b.root)).Debug("Could not advance branch to epoch boundary")
+				return
+			}
+			epochBoundaryBranchesAdvanced.Inc()
+			log.WithFields(logrus.Fields{
+				"root":    fmt.Sprintf("%#x", b.root),
+				"slot":    b.slot,
+				"weight":  b.weight,
+				"elapsed": time.Since(start),
+			}).Debug("Advanced branch to epoch boundary")
+		}(b)
+	}
+	wg.Wait()
+	return &boundaryPrediction{epoch: epoch, branches: branches}, nil
+}
+
+// advanceBoundaryBranch advances the state of the given block root to the start slot of the epoch
+// and saves it in the checkpoint state cache, unless it is cached already.
+func (s *Service) advanceBoundaryBranch(ctx context.Context, root [32]byte, epoch types.Epoch, epochStart types.Slot) error {
+	c := &ethpb.Checkpoint{Epoch: epoch, Root: root[:]}
+	// Shares the lock of getAttPreState, so that attestations targeting the branch wait for the
+	// advanced state rather than processing the same slots.
+	lock := async.NewMultilock(string(c.Root) + strconv.FormatUint(uint64(c.Epoch), 10 /* base 10 */))
+	lock.Lock()
+	defer lock.Unlock()
+	cached, err := s.checkpointStateCache.StateByCheckpoint(c)
+	if err != nil {
+		return errors.Wrap(err, "could not get cached checkpoint state")
+	}
+	if cached != nil && !cached.IsNil() {
+		return nil
+	}
+	st, err := s.cfg.StateGen.StateByRoot(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not get branch state")
+	}
+	st, err = transition.ProcessSlotsIfPossible(ctx, st.Copy(), epochStart)
+	if err != nil {
+		return errors.Wrapf(err, "could not process slots up to epoch %d", epoch)
+	}
+	return s.checkpointStateCache.AddCheckpointState(c, st)
+}
+
+// recordBoundaryPrediction compares the advanced branches with the branch the current head
+// descends from at the epoch boundary.
+func (s *Service) recordBoundaryPrediction(ctx context.Context, p *boundaryPrediction) error {
+	if len(p.branches) == 0 {
+		return nil
+	}
+	headRoot := s.headRoot()
+	result, err := s.boundaryPredictionResult(ctx, p, headRoot)
+	if err != nil {
+		return err
+	}
+	epochBoundaryPredictions.WithLabelValues(result).Inc()
+	if result == predictionMissed {
+		log.WithFields(logrus.Fields{
+			"epoch":    p.epoch,
+			"headRoot": fmt.Sprintf("%#x", headRoot),
+		}).Debug("Head does not descend from any branch advanced to the epoch boundary")
+	}
+	return nil
+}
+
+// boundaryPredictionResult returns whether the given head descends from the heaviest advanced
+// branch, from another advanced branch, or from none of them.
+func (s *Service) boundaryPredictionResult(ctx context.Context, p *boundaryPrediction, headRoot [32]byte) (string, error) {
+	for i, b := range p.branches {
+		ancestor, err := s.ancestor(ctx, headRoot[:], b.slot)
+		if err != nil {
+			return "", err
+		}
+		if bytesutil.ToBytes32(ancestor) != b.root {
+			continue
+		}
+		if i == 0 {
+			return predictionHeaviest, nil
+		}
+		return predictionAdvanced, nil
+	}
+	return predictionMissed, nil
+}
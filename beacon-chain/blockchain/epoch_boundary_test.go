@@ -0,0 +1,111 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+// setupEpochBoundaryBranches returns a service whose fork choice has a tip for every given slot,
+// all children of the genesis block.
+func setupEpochBoundaryBranches(t *testing.T, branches int, tipSlots ...types.Slot) (*Service, [][32]byte) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service, err := NewService(ctx,
+		WithDatabase(beaconDB),
+		WithStateGen(stategen.New(beaconDB)),
+		WithForkChoiceStore(protoarray.New()),
+		WithEpochBoundaryBranches(branches),
+	)
+	require.NoError(t, err)
+	genesisState, _ := util.DeterministicGenesisState(t, 64)
+	service.SetGenesisTime(time.Now())
+	require.NoError(t, service.saveGenesisData(ctx, genesisState))
+	genesisRoot := service.headRoot()
+
+	roots := make([][32]byte, len(tipSlots))
+	for i, slot := range tipSlots {
+		roots[i] = [32]byte{byte(i + 1)}
+		st := genesisState.Copy()
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, beaconDB.SaveState(ctx, st, roots[i]))
+		cp := &ethpb.Checkpoint{Root: genesisRoot[:]}
+		fcState, blkRoot, err := prepareForkchoiceState(ctx, slot, roots[i], genesisRoot, params.BeaconConfig().ZeroHash, cp, cp)
+		require.NoError(t, err)
+		require.NoError(t, service.cfg.ForkChoiceStore.InsertNode(ctx, fcState, blkRoot))
+	}
+	return service, roots
+}
+
+func TestService_boundaryBranches(t *testing.T) {
+	service, roots := setupEpochBoundaryBranches(t, 2, 10, 40, 50, 60)
+
+	// Tips of previous epochs are not viable.
+	branches, err := service.boundaryBranches(2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(branches))
+	for _, b := range branches {
+		assert.NotEqual(t, roots[0], b.root)
+		assert.Equal(t, true, b.slot >= 32)
+	}
+
+	// The head is kept even when its slot is in a previous epoch, and goes first on ties.
+	service.head = &head{root: roots[0]}
+	branches, err = service.boundaryBranches(2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(branches))
+	assert.Equal(t, roots[0], branches[0].root)
+}
+
+func TestService_advanceEpochBoundaryBranches(t *testing.T) {
+	service, roots := setupEpochBoundaryBranches(t, 2, 29, 30, 31)
+
+	p, err := service.advanceEpochBoundaryBranches(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(1), p.epoch)
+	require.Equal(t, 2, len(p.branches))
+
+	advanced := 0
+	for _, r := range roots {
+		st, err := service.checkpointStateCache.StateByCheckpoint(&ethpb.Checkpoint{Epoch: 1, Root: r[:]})
+		require.NoError(t, err)
+		if st == nil {
+			continue
+		}
+		advanced++
+		assert.Equal(t, params.BeaconConfig().SlotsPerEpoch, st.Slot())
+	}
+	assert.Equal(t, 2, advanced)
+}
+
+func TestService_boundaryPredictionResult(t *testing.T) {
+	service, roots := setupEpochBoundaryBranches(t, 2, 30, 31, 31)
+	p := &boundaryPrediction{
+		epoch: 1,
+		branches: []*boundaryBranch{
+			{root: roots[0], slot: 30},
+			{root: roots[1], slot: 31},
+		},
+	}
+	ctx := context.Background()
+
+	result, err := service.boundaryPredictionResult(ctx, p, roots[0])
+	require.NoError(t, err)
+	assert.Equal(t, predictionHeaviest, result)
+	result, err = service.boundaryPredictionResult(ctx, p, roots[1])
+	require.NoError(t, err)
+	assert.Equal(t, predictionAdvanced, result)
+	result, err = service.boundaryPredictionResult(ctx, p, roots[2])
+	require.NoError(t, err)
+	assert.Equal(t, predictionMissed, result)
+}
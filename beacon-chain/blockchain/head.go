@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
@@ -118,10 +119,11 @@ func (s *Service) saveHead(ctx context.Context, newHeadRoot [32]byte, headBlock
 			},
 		})
 
-		if err := s.saveOrphanedAtts(ctx, oldHeadRoot, newHeadRoot); err != nil {
+		if err := s.saveOrphanedOperations(ctx, oldHeadRoot, newHeadRoot, headState); err != nil {
 			return err
 		}
 		reorgCount.Inc()
+		s.trackReorg(ctx, oldHeadRoot, headSlot, newHeadRoot, newHeadSlot)
 	}
 
 	// Cache the new head info.
@@ -264,11 +266,8 @@ func (s *Service) notifyNewHeadEvent(
 	previousDutyDependentRoot := s.originBlockRoot[:]
 	currentDutyDependentRoot := s.originBlockRoot[:]
 
-	var previousDutyEpoch types.Epoch
 	currentDutyEpoch := slots.ToEpoch(newHeadSlot)
-	if currentDutyEpoch > 0 {
-		previousDutyEpoch = currentDutyEpoch.Sub(1)
-	}
+	previousDutyEpoch := slots.PrevEpoch(currentDutyEpoch)
 	currentDutySlot, err := slots.EpochStart(currentDutyEpoch)
 	if err != nil {
 		return errors.Wrap(err, "could not get duty slot")
@@ -308,9 +307,11 @@ func (s *Service) notifyNewHeadEvent(
 	return nil
 }
 
-// This saves the attestations between `orphanedRoot` and the common ancestor root that is derived using `newHeadRoot`.
-// It also filters out the attestations that is one epoch older as a defense so invalid attestations don't flow into the attestation pool.
-func (s *Service) saveOrphanedAtts(ctx context.Context, orphanedRoot [32]byte, newHeadRoot [32]byte) error {
+// This saves the operations between `orphanedRoot` and the common ancestor root that is derived using `newHeadRoot`
+// back into the operation pools. It filters out the attestations that is one epoch older as a defense so invalid
+// attestations don't flow into the attestation pool, and only reinserts the slashings and exits that are still valid
+// against the new head state.
+func (s *Service) saveOrphanedOperations(ctx context.Context, orphanedRoot [32]byte, newHeadRoot [32]byte, headState state.BeaconState) error {
 	commonAncestorRoot, err := s.ForkChoicer().CommonAncestorRoot(ctx, newHeadRoot, orphanedRoot)
 	switch {
 	// Exit early if there's no common ancestor and root doesn't exist, there would be nothing to save.
@@ -349,7 +350,42 @@ func (s *Service) saveOrphanedAtts(ctx context.Context, orphanedRoot [32]byte, n
 			}
 			saveOrphanedAttCount.Inc()
 		}
+		s.saveOrphanedSlashingsAndExits(ctx, headState, orphanedBlk.Block())
 		orphanedRoot = bytesutil.ToBytes32(orphanedBlk.Block().ParentRoot())
 	}
 	return nil
 }
+
+// This reinserts the slashings and voluntary exits of an orphaned block into their pools. Operations that are no
+// longer valid against the new head state, for example because the new chain included them as well, are dropped.
+func (s *Service) saveOrphanedSlashingsAndExits(ctx context.Context, headState state.BeaconState, blk interfaces.BeaconBlock) {
+	for _, ps := range blk.Body().ProposerSlashings() {
+		s.cfg.SlashingPool.UnmarkIncludedProposerSlashing(ps)
+		if err := s.cfg.SlashingPool.InsertProposerSlashing(ctx, headState, ps); err != nil {
+			log.WithError(err).Debug("Could not save orphaned proposer slashing")
+			continue
+		}
+		saveOrphanedSlashingCount.Inc()
+	}
+	for _, as := range blk.Body().AttesterSlashings() {
+		s.cfg.SlashingPool.UnmarkIncludedAttesterSlashing(as)
+		if err := s.cfg.SlashingPool.InsertAttesterSlashing(ctx, headState, as); err != nil {
+			log.WithError(err).Debug("Could not save orphaned attester slashing")
+			continue
+		}
+		saveOrphanedSlashingCount.Inc()
+	}
+	for _, e := range blk.Body().VoluntaryExits() {
+		val, err := headState.ValidatorAtIndexReadOnly(e.Exit.ValidatorIndex)
+		if err != nil {
+			log.WithError(err).Debug("Could not save orphaned voluntary exit")
+			continue
+		}
+		if err := blocks.VerifyExitAndSignature(val, headState.Slot(), headState.Fork(), e, headState.GenesisValidatorsRoot()); err != nil {
+			log.WithError(err).Debug("Could not save orphaned voluntary exit")
+			continue
+		}
+		s.cfg.ExitPool.InsertVoluntaryExit(ctx, headState, e)
+		saveOrphanedExitCount.Inc()
+	}
+}
@@ -133,6 +133,16 @@ func TestSaveHead_Different_Reorg(t *testing.T) {
 	assert.DeepEqual(t, newHeadSignedBlock, service.headBlock().Proto(), "Head did not change")
 	assert.DeepSSZEqual(t, headState.CloneInnerState(), service.headState(ctx).CloneInnerState(), "Head did not change")
 	require.LogsContain(t, hook, "Chain reorg occurred")
+
+	// The common ancestor of both heads is unknown.
+	reorgs := service.ChainReorgs()
+	require.Equal(t, 1, len(reorgs))
+	assert.Equal(t, types.Slot(1), reorgs[0].Slot)
+	assert.Equal(t, oldRoot, reorgs[0].OldHeadRoot)
+	assert.Equal(t, newRoot, reorgs[0].NewHeadRoot)
+	assert.Equal(t, [32]byte{}, reorgs[0].CommonAncestorRoot)
+	assert.Equal(t, uint64(0), reorgs[0].Depth)
+	assert.Equal(t, uint64(1), reorgs[0].Distance)
 }
 
 func TestCacheJustifiedStateBalances_CanCache(t *testing.T) {
@@ -265,7 +275,7 @@ func TestSaveOrphanedAtts_NoCommonAncestor(t *testing.T) {
 		util.SaveBlock(t, ctx, beaconDB, blk)
 	}
 
-	require.NoError(t, service.saveOrphanedAtts(ctx, r3, r4))
+	require.NoError(t, service.saveOrphanedOperations(ctx, r3, r4, st))
 	require.Equal(t, 0, service.cfg.AttPool.AggregatedAttestationCount())
 }
 
@@ -321,7 +331,7 @@ func TestSaveOrphanedAtts(t *testing.T) {
 		util.SaveBlock(t, ctx, beaconDB, blk)
 	}
 
-	require.NoError(t, service.saveOrphanedAtts(ctx, r3, r4))
+	require.NoError(t, service.saveOrphanedOperations(ctx, r3, r4, st))
 	require.Equal(t, 3, service.cfg.AttPool.AggregatedAttestationCount())
 	wantAtts := []*ethpb.Attestation{
 		blk3.Block.Body.Attestations[0],
@@ -335,6 +345,102 @@ func TestSaveOrphanedAtts(t *testing.T) {
 	require.DeepEqual(t, wantAtts, atts)
 }
 
+func TestSaveOrphanedOperations_SlashingsAndExits(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	bc := params.BeaconConfig().Copy()
+	bc.ShardCommitteePeriod = 0 // Required for voluntary exits to be valid at genesis.
+	params.OverrideBeaconConfig(bc)
+
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := setupBeaconChain(t, beaconDB)
+	service.genesisTime = time.Now().Add(time.Duration(-2*int64(params.BeaconConfig().SecondsPerSlot)) * time.Second)
+
+	// Chain setup
+	// 0 -- 1
+	//  \-2
+	st, keys := util.DeterministicGenesisState(t, 64)
+	blkG, err := util.GenerateFullBlock(st, keys, util.DefaultBlockGenConfig(), 0)
+	require.NoError(t, err)
+	rG, err := blkG.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	conf := util.DefaultBlockGenConfig()
+	conf.NumProposerSlashings = 1
+	conf.NumAttesterSlashings = 1
+	conf.NumVoluntaryExits = 1
+	blk1, err := util.GenerateFullBlock(st, keys, conf, 1)
+	require.NoError(t, err)
+	blk1.Block.ParentRoot = rG[:]
+	r1, err := blk1.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	blk2 := util.NewBeaconBlock()
+	blk2.Block.Slot = 2
+	blk2.Block.ParentRoot = rG[:]
+	r2, err := blk2.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	ojc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	ofc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	for _, blk := range []*ethpb.SignedBeaconBlock{blkG, blk1, blk2} {
+		r, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		state, blkRoot, err := prepareForkchoiceState(ctx, blk.Block.Slot, r, bytesutil.ToBytes32(blk.Block.ParentRoot), [32]byte{}, ojc, ofc)
+		require.NoError(t, err)
+		require.NoError(t, service.ForkChoicer().InsertNode(ctx, state, blkRoot))
+		util.SaveBlock(t, ctx, beaconDB, blk)
+	}
+
+	// The orphaned block was processed before the reorg, so its operations were marked as included.
+	service.cfg.ExitPool.MarkIncluded(blk1.Block.Body.VoluntaryExits[0])
+	service.cfg.SlashingPool.MarkIncludedProposerSlashing(blk1.Block.Body.ProposerSlashings[0])
+	service.cfg.SlashingPool.MarkIncludedAttesterSlashing(blk1.Block.Body.AttesterSlashings[0])
+
+	require.NoError(t, service.saveOrphanedOperations(ctx, r1, r2, st))
+	require.DeepEqual(t, blk1.Block.Body.ProposerSlashings, service.cfg.SlashingPool.PendingProposerSlashings(ctx, st, true))
+	require.DeepEqual(t, blk1.Block.Body.AttesterSlashings, service.cfg.SlashingPool.PendingAttesterSlashings(ctx, st, true))
+	require.DeepEqual(t, blk1.Block.Body.VoluntaryExits, service.cfg.ExitPool.PendingExits(st, 1, true))
+}
+
+func TestSaveOrphanedOperations_DropsInvalidSlashingsAndExits(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	bc := params.BeaconConfig().Copy()
+	bc.ShardCommitteePeriod = 0 // Required for voluntary exits to be valid at genesis.
+	params.OverrideBeaconConfig(bc)
+
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := setupBeaconChain(t, beaconDB)
+	service.genesisTime = time.Now().Add(time.Duration(-2*int64(params.BeaconConfig().SecondsPerSlot)) * time.Second)
+
+	st, keys := util.DeterministicGenesisState(t, 64)
+	conf := util.DefaultBlockGenConfig()
+	conf.NumProposerSlashings = 1
+	conf.NumVoluntaryExits = 1
+	blk, err := util.GenerateFullBlock(st, keys, conf, 1)
+	require.NoError(t, err)
+	wb, err := wrapper.WrappedSignedBeaconBlock(blk)
+	require.NoError(t, err)
+
+	// The new chain included the same operations, so they are no longer valid against its head state.
+	headState := st.Copy()
+	slashedIdx := blk.Block.Body.ProposerSlashings[0].Header_1.Header.ProposerIndex
+	v, err := headState.ValidatorAtIndex(slashedIdx)
+	require.NoError(t, err)
+	v.Slashed = true
+	require.NoError(t, headState.UpdateValidatorAtIndex(slashedIdx, v))
+	exitedIdx := blk.Block.Body.VoluntaryExits[0].Exit.ValidatorIndex
+	v, err = headState.ValidatorAtIndex(exitedIdx)
+	require.NoError(t, err)
+	v.ExitEpoch = 1
+	require.NoError(t, headState.UpdateValidatorAtIndex(exitedIdx, v))
+
+	service.saveOrphanedSlashingsAndExits(ctx, headState, wb.Block())
+	require.Equal(t, 0, len(service.cfg.SlashingPool.PendingProposerSlashings(ctx, headState, true)))
+	require.Equal(t, 0, len(service.cfg.ExitPool.PendingExits(headState, 1, true)))
+}
+
 func TestSaveOrphanedAtts_CanFilter(t *testing.T) {
 	ctx := context.Background()
 	beaconDB := testDB.SetupDB(t)
@@ -380,7 +486,7 @@ func TestSaveOrphanedAtts_CanFilter(t *testing.T) {
 		util.SaveBlock(t, ctx, beaconDB, blk)
 	}
 
-	require.NoError(t, service.saveOrphanedAtts(ctx, r2, r4))
+	require.NoError(t, service.saveOrphanedOperations(ctx, r2, r4, st))
 	require.Equal(t, 0, service.cfg.AttPool.AggregatedAttestationCount())
 }
 
@@ -439,7 +545,7 @@ func TestSaveOrphanedAtts_NoCommonAncestor_DoublyLinkedTrie(t *testing.T) {
 		util.SaveBlock(t, ctx, beaconDB, blk)
 	}
 
-	require.NoError(t, service.saveOrphanedAtts(ctx, r3, r4))
+	require.NoError(t, service.saveOrphanedOperations(ctx, r3, r4, st))
 	require.Equal(t, 0, service.cfg.AttPool.AggregatedAttestationCount())
 }
 
@@ -499,7 +605,7 @@ func TestSaveOrphanedAtts_DoublyLinkedTrie(t *testing.T) {
 		util.SaveBlock(t, ctx, beaconDB, blk)
 	}
 
-	require.NoError(t, service.saveOrphanedAtts(ctx, r3, r4))
+	require.NoError(t, service.saveOrphanedOperations(ctx, r3, r4, st))
 	require.Equal(t, 3, service.cfg.AttPool.AggregatedAttestationCount())
 	wantAtts := []*ethpb.Attestation{
 		blk3.Block.Body.Attestations[0],
@@ -563,7 +669,7 @@ func TestSaveOrphanedAtts_CanFilter_DoublyLinkedTrie(t *testing.T) {
 		util.SaveBlock(t, ctx, beaconDB, blk)
 	}
 
-	require.NoError(t, service.saveOrphanedAtts(ctx, r2, r4))
+	require.NoError(t, service.saveOrphanedOperations(ctx, r2, r4, st))
 	require.Equal(t, 0, service.cfg.AttPool.AggregatedAttestationCount())
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
 )
 
 var (
@@ -111,10 +112,32 @@ var (
 		Name: "beacon_reorgs_total",
 		Help: "Count the number of times beacon chain has a reorg",
 	})
+	reorgDepth = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "beacon_reorg_depth_slots",
+			Help:    "The number of slots between the old head of a reorg and its common ancestor with the new head",
+			Buckets: []float64{1, 2, 3, 4, 8, 16, 32, 64},
+		},
+	)
+	reorgDistance = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "beacon_reorg_distance_slots",
+			Help:    "The number of slots between the old and the new head of a reorg",
+			Buckets: []float64{1, 2, 3, 4, 8, 16, 32, 64},
+		},
+	)
 	saveOrphanedAttCount = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "saved_orphaned_att_total",
 		Help: "Count the number of times an orphaned attestation is saved",
 	})
+	saveOrphanedSlashingCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "saved_orphaned_slashing_total",
+		Help: "Count the number of times an orphaned slashing is saved",
+	})
+	saveOrphanedExitCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "saved_orphaned_exit_total",
+		Help: "Count the number of times an orphaned voluntary exit is saved",
+	})
 	attestationInclusionDelay = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "attestation_inclusion_delay_slots",
@@ -162,6 +185,15 @@ var (
 		Name: "missed_payload_id_filled_count",
 		Help: "",
 	})
+	epochBoundaryBranchesAdvanced = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "epoch_boundary_branches_advanced_total",
+		Help: "The number of fork choice branches whose state was advanced ahead of an epoch boundary",
+	})
+	epochBoundaryPredictions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "epoch_boundary_branch_predictions_total",
+		Help: "The branch the head descends from after an epoch boundary, compared with the branches advanced ahead of it: " +
+			"heaviest if it was the heaviest advanced branch, advanced if it was another advanced branch, missed otherwise",
+	}, []string{"result"})
 )
 
 // reportSlotMetrics reports slot related metrics.
@@ -177,7 +209,7 @@ func reportSlotMetrics(stateSlot, headSlot, clockSlot types.Slot, finalizedCheck
 
 // reportEpochMetrics reports epoch related metrics.
 func reportEpochMetrics(ctx context.Context, postState, headState state.BeaconState) error {
-	currentEpoch := types.Epoch(postState.Slot() / params.BeaconConfig().SlotsPerEpoch)
+	currentEpoch := slots.ToEpoch(postState.Slot())
 
 	// Validator instances
 	pendingInstances := 0
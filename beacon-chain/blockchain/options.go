@@ -14,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 )
 
@@ -27,6 +28,22 @@ func WithMaxGoroutines(x int) Option {
 	}
 }
 
+// WithBlocksRetentionEpochs to prune finalized blocks and states older than the given number of epochs.
+func WithBlocksRetentionEpochs(e types.Epoch) Option {
+	return func(s *Service) error {
+		s.cfg.BlocksRetentionEpochs = e
+		return nil
+	}
+}
+
+// WithEpochBoundaryBranches to advance the states of the given number of heaviest branches ahead of every epoch boundary.
+func WithEpochBoundaryBranches(n int) Option {
+	return func(s *Service) error {
+		s.cfg.EpochBoundaryBranches = n
+		return nil
+	}
+}
+
 // WithWeakSubjectivityCheckpoint for checkpoint sync.
 func WithWeakSubjectivityCheckpoint(c *ethpb.Checkpoint) Option {
 	return func(s *Service) error {
@@ -161,3 +178,12 @@ func WithFinalizedStateAtStartUp(st state.BeaconState) Option {
 		return nil
 	}
 }
+
+// WithReadOnlyDatabase for a service serving a database opened read-only. The head block of the database is the
+// head of the chain and nothing is saved to the database on stop.
+func WithReadOnlyDatabase() Option {
+	return func(s *Service) error {
+		s.cfg.ReadOnlyDatabase = true
+		return nil
+	}
+}
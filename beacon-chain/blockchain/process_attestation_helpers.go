@@ -73,6 +73,20 @@ func verifyAttTargetEpoch(_ context.Context, genesisTime, nowTime uint64, c *eth
 	return nil
 }
 
+// verifyAttSlotInPast validates the attestation slot is before the current slot. Attestations can
+// only affect the fork choice of subsequent slots, so that the votes of a slot are applied at once
+// on the next slot tick rather than as they arrive.
+func verifyAttSlotInPast(genesisTime, nowTime uint64, slot types.Slot) error {
+	if nowTime < genesisTime {
+		return fmt.Errorf("attestation slot %d is not before current time, genesis has not happened", slot)
+	}
+	currentSlot := types.Slot((nowTime - genesisTime) / params.BeaconConfig().SecondsPerSlot)
+	if slot >= currentSlot {
+		return fmt.Errorf("attestation slot %d is not before current slot %d", slot, currentSlot)
+	}
+	return nil
+}
+
 // verifyBeaconBlock verifies beacon head block is known and not from the future.
 func (s *Service) verifyBeaconBlock(ctx context.Context, data *ethpb.AttestationData) error {
 	r := bytesutil.ToBytes32(data.BeaconBlockRoot)
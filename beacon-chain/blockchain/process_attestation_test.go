@@ -419,6 +419,23 @@ func TestAttEpoch_NotMatch(t *testing.T) {
 	assert.ErrorContains(t, "target epoch 0 does not match current epoch 2 or prev epoch 1", err)
 }
 
+func TestAttSlotInPast_PreviousSlot(t *testing.T) {
+	nowTime := 3 * params.BeaconConfig().SecondsPerSlot
+	require.NoError(t, verifyAttSlotInPast(0, nowTime, 2))
+	require.NoError(t, verifyAttSlotInPast(0, nowTime, 0))
+}
+
+func TestAttSlotInPast_CurrentSlot(t *testing.T) {
+	// An attestation only counts from the next slot, even at the very end of its own slot.
+	nowTime := 3*params.BeaconConfig().SecondsPerSlot - 1
+	assert.ErrorContains(t, "attestation slot 2 is not before current slot 2", verifyAttSlotInPast(0, nowTime, 2))
+	assert.ErrorContains(t, "attestation slot 3 is not before current slot 2", verifyAttSlotInPast(0, nowTime, 3))
+}
+
+func TestAttSlotInPast_BeforeGenesis(t *testing.T) {
+	assert.ErrorContains(t, "genesis has not happened", verifyAttSlotInPast(100, 10, 0))
+}
+
 func TestVerifyBeaconBlock_NoBlock(t *testing.T) {
 	ctx := context.Background()
 	opts := testServiceOptsWithDB(t)
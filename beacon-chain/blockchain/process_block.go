@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	coreTime "github.com/prysmaticlabs/prysm/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/types"
@@ -93,7 +94,9 @@ var initialSyncBlockCacheSize = uint64(2 * params.BeaconConfig().SlotsPerEpoch)
 //            ancestor_at_finalized_slot = get_ancestor(store, store.justified_checkpoint.root, finalized_slot)
 //            if ancestor_at_finalized_slot != store.finalized_checkpoint.root:
 //                store.justified_checkpoint = state.current_justified_checkpoint
-func (s *Service) onBlock(ctx context.Context, signed interfaces.SignedBeaconBlock, blockRoot [32]byte) error {
+func (s *Service) onBlock(
+	ctx context.Context, signed interfaces.SignedBeaconBlock, blockRoot [32]byte, verified *transition.BlockVerification,
+) error {
 	ctx, span := trace.StartSpan(ctx, "blockChain.onBlock")
 	defer span.End()
 	if err := wrapper.BeaconBlockIsNil(signed); err != nil {
@@ -116,7 +119,7 @@ func (s *Service) onBlock(ctx context.Context, signed interfaces.SignedBeaconBlo
 	if err != nil {
 		return err
 	}
-	postState, err := transition.ExecuteStateTransition(ctx, preState, signed)
+	postState, err := transition.ExecuteStateTransitionWithVerification(ctx, preState, signed, verified)
 	if err != nil {
 		return invalidBlock{error: err}
 	}
@@ -362,7 +365,7 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeac
 		}
 		sigSet.Join(set)
 	}
-	verify, err := sigSet.Verify()
+	verify, err := signing.VerifySet(signing.BlockMessage, sigSet)
 	if err != nil {
 		return invalidBlock{error: err}
 	}
@@ -472,10 +475,12 @@ func (s *Service) handleEpochBoundary(ctx context.Context, postState state.Beaco
 	} else if postState.Slot() >= s.nextEpochBoundarySlot {
 		s.headLock.RLock()
 		st := s.head.state
+		headRoot := s.head.root
 		s.headLock.RUnlock()
 		if err := reportEpochMetrics(ctx, postState, st); err != nil {
 			return err
 		}
+		s.checkSyncCommitteePeriodBoundary(headRoot[:], st, postState)
 
 		var err error
 		s.nextEpochBoundarySlot, err = slots.EpochStart(coreTime.NextEpoch(postState))
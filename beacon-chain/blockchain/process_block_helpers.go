@@ -18,6 +18,7 @@ import (
 	mathutil "github.com/prysmaticlabs/prysm/math"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime/version"
 	"github.com/prysmaticlabs/prysm/time/slots"
 	"go.opencensus.io/trace"
 )
@@ -165,9 +166,45 @@ func (s *Service) updateFinalized(ctx context.Context, cp *ethpb.Checkpoint) err
 	if err := s.cfg.StateGen.MigrateToCold(ctx, fRoot); err != nil {
 		return errors.Wrap(err, "could not migrate to cold")
 	}
+	if err := s.saveFinalizedSyncCommittee(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save finalized sync committee")
+	}
+	s.schedulePruning(cp)
 	return nil
 }
 
+// saveFinalizedSyncCommittee stores the current sync committee of the finalized state. This is done
+// once per sync committee period, so light clients can be bootstrapped from any finalized checkpoint.
+func (s *Service) saveFinalizedSyncCommittee(ctx context.Context, cp *ethpb.Checkpoint) error {
+	if cp.Epoch < params.BeaconConfig().AltairForkEpoch {
+		return nil
+	}
+	if s.cfg.BeaconDB.HasSyncCommittee(ctx, slots.SyncCommitteePeriod(cp.Epoch)) {
+		return nil
+	}
+	st, err := s.cfg.StateGen.StateByRoot(ctx, bytesutil.ToBytes32(cp.Root))
+	if err != nil {
+		return err
+	}
+	if st == nil || st.IsNil() {
+		return errors.New("nil finalized state")
+	}
+	if st.Version() == version.Phase0 {
+		return nil
+	}
+	// The finalized block may precede the checkpoint epoch, in which case its state
+	// belongs to an earlier sync committee period.
+	period := slots.SyncCommitteePeriod(slots.ToEpoch(st.Slot()))
+	if s.cfg.BeaconDB.HasSyncCommittee(ctx, period) {
+		return nil
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return err
+	}
+	return s.cfg.BeaconDB.SaveSyncCommittee(ctx, period, committee)
+}
+
 // ancestor returns the block root of an ancestry block from the input block root.
 //
 // Spec pseudocode definition:
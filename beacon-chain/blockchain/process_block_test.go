@@ -135,7 +135,7 @@ func TestStore_OnBlock_ProtoArray(t *testing.T) {
 			assert.NoError(t, err)
 			wsb, err := wrapper.WrappedSignedBeaconBlock(tt.blk)
 			require.NoError(t, err)
-			err = service.onBlock(ctx, wsb, root)
+			err = service.onBlock(ctx, wsb, root, nil /* verified */)
 			assert.ErrorContains(t, tt.wantErrString, err)
 		})
 	}
@@ -231,7 +231,7 @@ func TestStore_OnBlock_DoublyLinkedTree(t *testing.T) {
 			assert.NoError(t, err)
 			wsb, err := wrapper.WrappedSignedBeaconBlock(tt.blk)
 			require.NoError(t, err)
-			err = service.onBlock(ctx, wsb, root)
+			err = service.onBlock(ctx, wsb, root, nil /* verified */)
 			assert.ErrorContains(t, tt.wantErrString, err)
 		})
 	}
@@ -1179,7 +1179,7 @@ func TestOnBlock_CanFinalize_WithOnTick(t *testing.T) {
 		wsb, err := wrapper.WrappedSignedBeaconBlock(blk)
 		require.NoError(t, err)
 		require.NoError(t, fcs.NewSlot(ctx, i))
-		require.NoError(t, service.onBlock(ctx, wsb, r))
+		require.NoError(t, service.onBlock(ctx, wsb, r, nil /* verified */))
 		testState, err = service.cfg.StateGen.StateByRoot(ctx, r)
 		require.NoError(t, err)
 	}
@@ -1227,7 +1227,7 @@ func TestOnBlock_CanFinalize(t *testing.T) {
 		require.NoError(t, err)
 		wsb, err := wrapper.WrappedSignedBeaconBlock(blk)
 		require.NoError(t, err)
-		require.NoError(t, service.onBlock(ctx, wsb, r))
+		require.NoError(t, service.onBlock(ctx, wsb, r, nil /* verified */))
 		testState, err = service.cfg.StateGen.StateByRoot(ctx, r)
 		require.NoError(t, err)
 	}
@@ -1262,7 +1262,7 @@ func TestOnBlock_NilBlock(t *testing.T) {
 	service, err := NewService(ctx, opts...)
 	require.NoError(t, err)
 
-	err = service.onBlock(ctx, nil, [32]byte{})
+	err = service.onBlock(ctx, nil, [32]byte{}, nil /* verified */)
 	require.Equal(t, true, IsInvalidBlock(err))
 }
 
@@ -1292,7 +1292,7 @@ func TestOnBlock_InvalidSignature(t *testing.T) {
 	require.NoError(t, err)
 	wsb, err := wrapper.WrappedSignedBeaconBlock(blk)
 	require.NoError(t, err)
-	err = service.onBlock(ctx, wsb, r)
+	err = service.onBlock(ctx, wsb, r, nil /* verified */)
 	require.Equal(t, true, IsInvalidBlock(err))
 }
 
@@ -1329,7 +1329,7 @@ func TestOnBlock_CallNewPayloadAndForkchoiceUpdated(t *testing.T) {
 		require.NoError(t, err)
 		wsb, err := wrapper.WrappedSignedBeaconBlock(blk)
 		require.NoError(t, err)
-		require.NoError(t, service.onBlock(ctx, wsb, r))
+		require.NoError(t, service.onBlock(ctx, wsb, r, nil /* verified */))
 		testState, err = service.cfg.StateGen.StateByRoot(ctx, r)
 		require.NoError(t, err)
 	}
@@ -1753,19 +1753,19 @@ func TestOnBlock_ProcessBlocksParallel(t *testing.T) {
 		var wg sync.WaitGroup
 		wg.Add(4)
 		go func() {
-			require.NoError(t, service.onBlock(ctx, wsb1, r1))
+			require.NoError(t, service.onBlock(ctx, wsb1, r1, nil /* verified */))
 			wg.Done()
 		}()
 		go func() {
-			require.NoError(t, service.onBlock(ctx, wsb2, r2))
+			require.NoError(t, service.onBlock(ctx, wsb2, r2, nil /* verified */))
 			wg.Done()
 		}()
 		go func() {
-			require.NoError(t, service.onBlock(ctx, wsb3, r3))
+			require.NoError(t, service.onBlock(ctx, wsb3, r3, nil /* verified */))
 			wg.Done()
 		}()
 		go func() {
-			require.NoError(t, service.onBlock(ctx, wsb4, r4))
+			require.NoError(t, service.onBlock(ctx, wsb4, r4, nil /* verified */))
 			wg.Done()
 		}()
 		wg.Wait()
@@ -1797,3 +1797,36 @@ func Test_verifyBlkFinalizedSlot_invalidBlock(t *testing.T) {
 	err = service.verifyBlkFinalizedSlot(wb)
 	require.Equal(t, true, IsInvalidBlock(err))
 }
+
+func TestSaveFinalizedSyncCommittee(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service, err := NewService(ctx, WithDatabase(beaconDB), WithStateGen(stategen.New(beaconDB)))
+	require.NoError(t, err)
+
+	// Checkpoints before the Altair fork are ignored.
+	require.NoError(t, service.saveFinalizedSyncCommittee(ctx, &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)}))
+	assert.Equal(t, false, beaconDB.HasSyncCommittee(ctx, 0))
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	epoch := cfg.EpochsPerSyncCommitteePeriod + 1
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch))))
+	root := bytesutil.ToBytes32([]byte{'a'})
+	require.NoError(t, beaconDB.SaveState(ctx, st, root))
+
+	require.NoError(t, service.saveFinalizedSyncCommittee(ctx, &ethpb.Checkpoint{Epoch: epoch, Root: root[:]}))
+	assert.Equal(t, true, beaconDB.HasSyncCommittee(ctx, 1))
+	committee, err := beaconDB.SyncCommittee(ctx, 1)
+	require.NoError(t, err)
+	wanted, err := st.CurrentSyncCommittee()
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, wanted, committee)
+
+	// The state is not needed again once the period's committee is stored.
+	require.NoError(t, service.saveFinalizedSyncCommittee(ctx, &ethpb.Checkpoint{Epoch: epoch + 1, Root: []byte{'b'}}))
+}
@@ -0,0 +1,75 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// pruneEpochsPerPass bounds the number of epochs of blocks and states pruned in a single pass, so
+// that catching up on a long backlog, such as on the first run with a retention period, holds the
+// database for a short time at once.
+const pruneEpochsPerPass = 32
+
+// schedulePruning hands the finalized checkpoint to the pruning routine without waiting for it, so
+// that finalization and block import never wait on pruning. The checkpoint is dropped if the
+// routine has not picked up the previous one yet, the next checkpoint prunes further anyway.
+func (s *Service) schedulePruning(cp *ethpb.Checkpoint) {
+	if s.cfg.BlocksRetentionEpochs == 0 {
+		return
+	}
+	select {
+	case s.pruneCheckpoints <- cp:
+	default:
+	}
+}
+
+// spawnPruningRoutine prunes the blocks and states beyond the retention period of every finalized
+// checkpoint handed over by schedulePruning, in the background.
+func (s *Service) spawnPruningRoutine() {
+	go func() {
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case cp := <-s.pruneCheckpoints:
+				if err := s.pruneBeyondRetention(s.ctx, cp); err != nil {
+					// Pruning is best effort, it is retried on the next finalized checkpoint.
+					log.WithError(err).Error("Could not prune blocks and states beyond the retention period")
+				}
+			}
+		}
+	}()
+}
+
+// pruneBeyondRetention deletes the blocks and states older than the configured number of epochs
+// before the finalized checkpoint, in passes of at most pruneEpochsPerPass epochs. Nothing is pruned
+// when no retention period is configured. It must only be called from the pruning routine.
+func (s *Service) pruneBeyondRetention(ctx context.Context, cp *ethpb.Checkpoint) error {
+	retention := s.cfg.BlocksRetentionEpochs
+	if retention == 0 || cp.Epoch <= retention {
+		return nil
+	}
+	target, err := slots.EpochStart(cp.Epoch - retention)
+	if err != nil {
+		return err
+	}
+	passSlots := types.Slot(pruneEpochsPerPass).Mul(uint64(params.BeaconConfig().SlotsPerEpoch))
+	for s.prunedBefore < target {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		next := s.prunedBefore.Add(uint64(passSlots))
+		if next > target {
+			next = target
+		}
+		if err := s.cfg.BeaconDB.PruneBefore(ctx, next); err != nil {
+			return err
+		}
+		s.prunedBefore = next
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// pruneRecordingDB records the slots blocks and states are pruned before.
+type pruneRecordingDB struct {
+	db.HeadAccessDatabase
+	prunedBefore []types.Slot
+}
+
+func (d *pruneRecordingDB) PruneBefore(_ context.Context, slot types.Slot) error {
+	d.prunedBefore = append(d.prunedBefore, slot)
+	return nil
+}
+
+func TestService_pruneBeyondRetention(t *testing.T) {
+	beaconDB := &pruneRecordingDB{}
+	s := &Service{cfg: &config{BeaconDB: beaconDB}}
+	ctx := context.Background()
+
+	// Nothing is pruned without a retention period.
+	require.NoError(t, s.pruneBeyondRetention(ctx, &ethpb.Checkpoint{Epoch: 100}))
+	assert.Equal(t, 0, len(beaconDB.prunedBefore))
+
+	// A backlog is pruned in bounded passes.
+	s.cfg.BlocksRetentionEpochs = 10
+	require.NoError(t, s.pruneBeyondRetention(ctx, &ethpb.Checkpoint{Epoch: 10 + 2*pruneEpochsPerPass + 1}))
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	passSlots := types.Slot(pruneEpochsPerPass) * slotsPerEpoch
+	assert.DeepEqual(t, []types.Slot{passSlots, 2 * passSlots, 2*passSlots + slotsPerEpoch}, beaconDB.prunedBefore)
+
+	// Only the new epochs are pruned on the next finalized checkpoint.
+	require.NoError(t, s.pruneBeyondRetention(ctx, &ethpb.Checkpoint{Epoch: 10 + 2*pruneEpochsPerPass + 2}))
+	assert.Equal(t, 2*passSlots+2*slotsPerEpoch, beaconDB.prunedBefore[len(beaconDB.prunedBefore)-1])
+}
+
+func TestService_schedulePruning(t *testing.T) {
+	s := &Service{
+		cfg:              &config{BlocksRetentionEpochs: 10},
+		pruneCheckpoints: make(chan *ethpb.Checkpoint, 1),
+	}
+	// Scheduling never waits on the pruning routine.
+	s.schedulePruning(&ethpb.Checkpoint{Epoch: 20})
+	s.schedulePruning(&ethpb.Checkpoint{Epoch: 21})
+	cp := <-s.pruneCheckpoints
+	assert.Equal(t, types.Epoch(20), cp.Epoch)
+
+	// Nothing is scheduled without a retention period.
+	s.cfg.BlocksRetentionEpochs = 0
+	s.schedulePruning(&ethpb.Checkpoint{Epoch: 22})
+	assert.Equal(t, 0, len(s.pruneCheckpoints))
+}
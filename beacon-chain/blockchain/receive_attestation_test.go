@@ -123,6 +123,44 @@ func TestProcessAttestations_Ok(t *testing.T) {
 	require.LogsDoNotContain(t, hook, "Could not process attestation for fork choice")
 }
 
+func TestProcessAttestations_DefersCurrentSlotAttestations(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	opts := testServiceOptsWithDB(t)
+	opts = append(opts, WithAttestationPool(attestations.NewPool()))
+
+	service, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+	// Genesis is in slot 0, the slot of the attestations.
+	service.genesisTime = prysmTime.Now()
+	genesisState, pks := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, genesisState.SetGenesisTime(uint64(prysmTime.Now().Unix())))
+	require.NoError(t, service.saveGenesisData(ctx, genesisState))
+	atts, err := util.NewAttestationUtil().GenerateAttestations(genesisState, pks, 1, 0, false)
+	require.NoError(t, err)
+	tRoot := bytesutil.ToBytes32(atts[0].Data.Target.Root)
+	copied := genesisState.Copy()
+	copied, err = transition.ProcessSlots(ctx, copied, 1)
+	require.NoError(t, err)
+	require.NoError(t, service.cfg.BeaconDB.SaveState(ctx, copied, tRoot))
+	ofc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	ojc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	state, blkRoot, err := prepareForkchoiceState(ctx, 0, tRoot, tRoot, params.BeaconConfig().ZeroHash, ojc, ofc)
+	require.NoError(t, err)
+	require.NoError(t, service.cfg.ForkChoiceStore.InsertNode(ctx, state, blkRoot))
+	require.NoError(t, service.cfg.AttPool.SaveForkchoiceAttestations(atts))
+
+	// Attestations of the current slot are kept in the pool.
+	service.processAttestations(ctx)
+	require.Equal(t, len(atts), len(service.cfg.AttPool.ForkchoiceAttestations()))
+
+	// They are processed once their slot is in the past.
+	service.genesisTime = prysmTime.Now().Add(-1 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	service.processAttestations(ctx)
+	require.Equal(t, 0, len(service.cfg.AttPool.ForkchoiceAttestations()))
+	require.LogsDoNotContain(t, hook, "Could not process attestation for fork choice")
+}
+
 func TestNotifyEngineIfChangedHead(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctx := context.Background()
@@ -219,7 +257,7 @@ func TestService_ProcessAttestationsAndUpdateHead(t *testing.T) {
 	require.NoError(t, err)
 	wsb, err := wrapper.WrappedSignedBeaconBlock(blk)
 	require.NoError(t, err)
-	require.NoError(t, service.onBlock(ctx, wsb, tRoot))
+	require.NoError(t, service.onBlock(ctx, wsb, tRoot, nil /* verified */))
 	copied, err = service.cfg.StateGen.StateByRoot(ctx, tRoot)
 	require.NoError(t, err)
 	require.Equal(t, 2, fcs.NodeCount())
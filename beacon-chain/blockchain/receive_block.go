@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
@@ -21,6 +22,7 @@ var epochsSinceFinalitySaveHotStateDB = types.Epoch(100)
 // BlockReceiver interface defines the methods of chain service for receiving and processing new blocks.
 type BlockReceiver interface {
 	ReceiveBlock(ctx context.Context, block interfaces.SignedBeaconBlock, blockRoot [32]byte) error
+	ReceiveVerifiedBlock(ctx context.Context, block interfaces.SignedBeaconBlock, blockRoot [32]byte, verified *transition.BlockVerification) error
 	ReceiveBlockBatch(ctx context.Context, blocks []interfaces.SignedBeaconBlock, blkRoots [][32]byte) error
 	HasBlock(ctx context.Context, root [32]byte) bool
 }
@@ -36,13 +38,22 @@ type SlashingReceiver interface {
 //   2. Apply fork choice to the processed block
 //   3. Save latest head info
 func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.SignedBeaconBlock, blockRoot [32]byte) error {
+	return s.ReceiveVerifiedBlock(ctx, block, blockRoot, nil /* verified */)
+}
+
+// ReceiveVerifiedBlock performs the same operations as ReceiveBlock on a block whose signatures were
+// partially verified by the caller, for example during gossip validation. The signatures recorded in
+// the verification are not verified again during the state transition.
+func (s *Service) ReceiveVerifiedBlock(
+	ctx context.Context, block interfaces.SignedBeaconBlock, blockRoot [32]byte, verified *transition.BlockVerification,
+) error {
 	ctx, span := trace.StartSpan(ctx, "blockChain.ReceiveBlock")
 	defer span.End()
 	receivedTime := time.Now()
 	blockCopy := block.Copy()
 
 	// Apply state transition on the new block.
-	if err := s.onBlock(ctx, blockCopy, blockRoot); err != nil {
+	if err := s.onBlock(ctx, blockCopy, blockRoot, verified); err != nil {
 		err := errors.Wrap(err, "could not process block")
 		tracing.AnnotateError(span, err)
 		return err
@@ -0,0 +1,133 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// maxTrackedReorgs is the number of most recent reorgs kept by the reorg tracker.
+const maxTrackedReorgs = 256
+
+// ChainReorg describes a change of head to a block which does not descend from the previous head.
+type ChainReorg struct {
+	Slot               types.Slot // slot of the new head.
+	OldHeadSlot        types.Slot
+	OldHeadRoot        [32]byte
+	NewHeadRoot        [32]byte
+	CommonAncestorRoot [32]byte // zero if the common ancestor of both heads is unknown.
+	// Depth is the number of slots from the old head back to the common ancestor, the
+	// number of slots whose blocks were orphaned. It is zero if the common ancestor is unknown.
+	Depth uint64
+	// Distance is the number of slots between the old and the new head.
+	Distance uint64
+	Time     time.Time
+}
+
+// reorgTracker keeps the most recent reorgs of the chain, oldest first.
+type reorgTracker struct {
+	lock   sync.RWMutex
+	reorgs []*ChainReorg
+}
+
+func newReorgTracker() *reorgTracker {
+	return &reorgTracker{reorgs: make([]*ChainReorg, 0, maxTrackedReorgs)}
+}
+
+// add records a reorg, dropping the oldest one if the tracker is full. A nil tracker records nothing.
+func (t *reorgTracker) add(r *ChainReorg) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if len(t.reorgs) == maxTrackedReorgs {
+		copy(t.reorgs, t.reorgs[1:])
+		t.reorgs = t.reorgs[:maxTrackedReorgs-1]
+	}
+	t.reorgs = append(t.reorgs, r)
+}
+
+// list returns a copy of the tracked reorgs, oldest first.
+func (t *reorgTracker) list() []*ChainReorg {
+	if t == nil {
+		return []*ChainReorg{}
+	}
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	reorgs := make([]*ChainReorg, len(t.reorgs))
+	for i, r := range t.reorgs {
+		cp := *r
+		reorgs[i] = &cp
+	}
+	return reorgs
+}
+
+// trackReorg records the reorg from the old head to the new head in the reorg tracker and metrics.
+func (s *Service) trackReorg(ctx context.Context, oldHeadRoot [32]byte, oldHeadSlot types.Slot, newHeadRoot [32]byte, newHeadSlot types.Slot) {
+	r := &ChainReorg{
+		Slot:        newHeadSlot,
+		OldHeadSlot: oldHeadSlot,
+		OldHeadRoot: oldHeadRoot,
+		NewHeadRoot: newHeadRoot,
+		Distance:    slots.AbsoluteValueSlotDifference(newHeadSlot, oldHeadSlot),
+		Time:        time.Now(),
+	}
+	ancestorRoot, ancestorSlot, err := s.commonAncestor(ctx, oldHeadRoot, newHeadRoot)
+	if err != nil {
+		log.WithError(err).Debug("Could not determine the depth of the chain reorg")
+	} else {
+		r.CommonAncestorRoot = ancestorRoot
+		r.Depth = uint64(oldHeadSlot.SubSlot(ancestorSlot))
+		reorgDepth.Observe(float64(r.Depth))
+	}
+	reorgDistance.Observe(float64(r.Distance))
+	s.reorgs.add(r)
+}
+
+// commonAncestor returns the root and slot of the common ancestor of the two given blocks.
+func (s *Service) commonAncestor(ctx context.Context, root1, root2 [32]byte) ([32]byte, types.Slot, error) {
+	root, err := s.ForkChoicer().CommonAncestorRoot(ctx, root1, root2)
+	if err != nil {
+		if errors.Is(err, forkchoice.ErrUnknownCommonAncestor) {
+			return [32]byte{}, 0, err
+		}
+		return [32]byte{}, 0, errors.Wrap(err, "could not get common ancestor root")
+	}
+	blk, err := s.getBlock(ctx, root)
+	if err != nil {
+		return [32]byte{}, 0, err
+	}
+	return root, blk.Block().Slot(), nil
+}
+
+// ChainReorgs returns the most recent reorgs of the chain seen by the node since it started,
+// oldest first.
+func (s *Service) ChainReorgs() []*ChainReorg {
+	return s.reorgs.list()
+}
+
+// ChainReorgsHandler lists the most recent reorgs of the chain, one per line and oldest first.
+func (s *Service) ChainReorgsHandler(w http.ResponseWriter, _ *http.Request) {
+	buf := new(bytes.Buffer)
+	for _, r := range s.ChainReorgs() {
+		if _, err := fmt.Fprintf(buf, "time=%s slot=%d depth=%d distance=%d old_head_slot=%d old_head=%#x new_head=%#x common_ancestor=%#x\n",
+			r.Time.UTC().Format(time.RFC3339), r.Slot, r.Depth, r.Distance, r.OldHeadSlot,
+			r.OldHeadRoot, r.NewHeadRoot, r.CommonAncestorRoot); err != nil {
+			log.WithError(err).Error("Failed to render chain reorgs page")
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.WithError(err).Error("Failed to render chain reorgs page")
+	}
+}
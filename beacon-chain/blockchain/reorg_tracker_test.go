@@ -0,0 +1,107 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestReorgTracker_Bounded(t *testing.T) {
+	tracker := newReorgTracker()
+	for i := 0; i < maxTrackedReorgs+10; i++ {
+		tracker.add(&ChainReorg{Slot: types.Slot(i)})
+	}
+	reorgs := tracker.list()
+	require.Equal(t, maxTrackedReorgs, len(reorgs))
+	assert.Equal(t, types.Slot(10), reorgs[0].Slot)
+	assert.Equal(t, types.Slot(maxTrackedReorgs+9), reorgs[maxTrackedReorgs-1].Slot)
+
+	// The returned reorgs are copies.
+	reorgs[0].Slot = 0
+	assert.Equal(t, types.Slot(10), tracker.list()[0].Slot)
+
+	var nilTracker *reorgTracker
+	nilTracker.add(&ChainReorg{})
+	assert.Equal(t, 0, len(nilTracker.list()))
+}
+
+func TestSaveHead_TracksReorgDepth(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := setupBeaconChain(t, beaconDB)
+	ojc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	ofc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+
+	// saveBlock saves a block at the given slot with the given parent, and inserts it in fork choice.
+	saveBlock := func(slot types.Slot, parentRoot [32]byte, graffiti byte) [32]byte {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parentRoot[:]
+		b.Block.Body.Graffiti = bytesutil.PadTo([]byte{graffiti}, 32)
+		wsb := util.SaveBlock(t, ctx, beaconDB, b)
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		st, blkRoot, err := prepareForkchoiceState(ctx, slot, root, parentRoot, [32]byte{}, ojc, ofc)
+		require.NoError(t, err)
+		require.NoError(t, service.cfg.ForkChoiceStore.InsertNode(ctx, st, blkRoot))
+		service.head = &head{slot: slot, root: root, block: wsb}
+		return root
+	}
+
+	ancestorRoot := saveBlock(0, [32]byte{}, 'a')
+	saveBlock(1, ancestorRoot, 'b')
+	oldRoot := saveBlock(2, service.headRoot(), 'c')
+	oldHead := service.head
+	oldHeadState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, oldHeadState.SetSlot(2))
+	oldHead.state = oldHeadState
+
+	newHead := util.NewBeaconBlock()
+	newHead.Block.Slot = 3
+	newHead.Block.ParentRoot = ancestorRoot[:]
+	wsb := util.SaveBlock(t, ctx, beaconDB, newHead)
+	newRoot, err := newHead.Block.HashTreeRoot()
+	require.NoError(t, err)
+	st, blkRoot, err := prepareForkchoiceState(ctx, 3, newRoot, ancestorRoot, [32]byte{}, ojc, ofc)
+	require.NoError(t, err)
+	require.NoError(t, service.cfg.ForkChoiceStore.InsertNode(ctx, st, blkRoot))
+	service.head = oldHead
+
+	headState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, headState.SetSlot(3))
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 3, Root: newRoot[:]}))
+	require.NoError(t, beaconDB.SaveState(ctx, headState, newRoot))
+	require.NoError(t, service.saveHead(ctx, newRoot, wsb, headState))
+
+	reorgs := service.ChainReorgs()
+	require.Equal(t, 1, len(reorgs))
+	assert.Equal(t, types.Slot(3), reorgs[0].Slot)
+	assert.Equal(t, types.Slot(2), reorgs[0].OldHeadSlot)
+	assert.Equal(t, oldRoot, reorgs[0].OldHeadRoot)
+	assert.Equal(t, newRoot, reorgs[0].NewHeadRoot)
+	assert.Equal(t, ancestorRoot, reorgs[0].CommonAncestorRoot)
+	assert.Equal(t, uint64(2), reorgs[0].Depth)
+	assert.Equal(t, uint64(1), reorgs[0].Distance)
+
+	rec := httptest.NewRecorder()
+	service.ChainReorgsHandler(rec, httptest.NewRequest(http.MethodGet, "/chain/reorgs", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Equal(t, 1, len(lines))
+	assert.Equal(t, true, strings.Contains(lines[0], fmt.Sprintf("slot=3 depth=2 distance=1 old_head_slot=2 old_head=%#x", oldRoot)), lines[0])
+	assert.Equal(t, true, strings.HasSuffix(lines[0], fmt.Sprintf("common_ancestor=%#x", ancestorRoot)), lines[0])
+}
@@ -65,6 +65,9 @@ type Service struct {
 	justifiedBalances       *stateBalanceCache
 	wsVerifier              *WeakSubjectivityVerifier
 	processAttestationsLock sync.Mutex
+	reorgs                  *reorgTracker
+	pruneCheckpoints        chan *ethpb.Checkpoint
+	prunedBefore            types.Slot
 }
 
 // config options for the service.
@@ -88,6 +91,9 @@ type config struct {
 	BlockFetcher            powchain.POWBlockFetcher
 	FinalizedStateAtStartUp state.BeaconState
 	ExecutionEngineCaller   powchain.EngineCaller
+	BlocksRetentionEpochs   types.Epoch
+	EpochBoundaryBranches   int
+	ReadOnlyDatabase        bool
 }
 
 // NewService instantiates a new block service instance that will
@@ -100,6 +106,8 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		boundaryRoots:        [][32]byte{},
 		checkpointStateCache: cache.NewCheckpointStateCache(),
 		initSyncBlocks:       make(map[[32]byte]interfaces.SignedBeaconBlock),
+		reorgs:               newReorgTracker(),
+		pruneCheckpoints:     make(chan *ethpb.Checkpoint, 1),
 		cfg:                  &config{},
 	}
 	for _, opt := range opts {
@@ -136,12 +144,17 @@ func (s *Service) Start() {
 	}
 	s.spawnProcessAttestationsRoutine(s.cfg.StateNotifier.StateFeed())
 	s.fillMissingPayloadIDRoutine(s.ctx, s.cfg.StateNotifier.StateFeed())
+	s.spawnEpochBoundaryRoutine(s.cfg.StateNotifier.StateFeed())
+	s.spawnPruningRoutine()
 }
 
 // Stop the blockchain service's main event loop and associated goroutines.
 func (s *Service) Stop() error {
 	defer s.cancel()
 
+	if s.cfg.ReadOnlyDatabase {
+		return nil
+	}
 	// lock before accessing s.head, s.head.state, s.head.state.FinalizedCheckpoint().Root
 	s.headLock.RLock()
 	if s.cfg.StateGen != nil && s.head != nil && s.head.state != nil {
@@ -302,6 +315,26 @@ func (s *Service) initializeHeadFromDB(ctx context.Context) error {
 		return errors.Wrap(err, "could not get finalized state from db")
 	}
 
+	if s.cfg.ReadOnlyDatabase {
+		// No block is processed on top of a read-only database, so its head block stays the head.
+		headBlock, err := s.cfg.BeaconDB.HeadBlock(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve head block")
+		}
+		if err := wrapper.BeaconBlockIsNil(headBlock); err == nil {
+			headRoot, err := headBlock.Block().HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "could not hash head block")
+			}
+			headState, err := s.cfg.StateGen.StateByRoot(ctx, headRoot)
+			if err != nil {
+				return errors.Wrap(err, "could not retrieve head state")
+			}
+			s.setHead(headRoot, headBlock, headState)
+			return nil
+		}
+	}
+
 	if flags.Get().HeadSync {
 		headBlock, err := s.cfg.BeaconDB.HeadBlock(ctx)
 		if err != nil {
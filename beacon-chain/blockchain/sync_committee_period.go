@@ -0,0 +1,186 @@
+package blockchain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	coreTime "github.com/prysmaticlabs/prysm/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// syncCommitteePeriodSummary is the outcome of the consistency checks run at a sync committee period boundary.
+type syncCommitteePeriodSummary struct {
+	period        uint64
+	promoted      bool
+	promotedCheck bool
+	cached        bool
+	localMembers  int
+	failures      []string
+}
+
+// checkSyncCommitteePeriodBoundary runs the sync committee consistency checks when the post state is the first
+// processed state of a new sync committee period. Failures are logged and sent as a warning event, they never
+// fail block processing.
+func (s *Service) checkSyncCommitteePeriodBoundary(headRoot []byte, headState, postState state.BeaconState) {
+	if postState == nil || postState.Version() == version.Phase0 {
+		return
+	}
+	currentEpoch := coreTime.CurrentEpoch(postState)
+	period := slots.SyncCommitteePeriod(currentEpoch)
+	// Both sync committees are set from scratch at the Altair fork, nothing is promoted then.
+	if currentEpoch <= params.BeaconConfig().AltairForkEpoch {
+		return
+	}
+	if headState != nil && !headState.IsNil() && slots.SyncCommitteePeriod(coreTime.CurrentEpoch(headState)) >= period {
+		return
+	}
+	summary, err := syncCommitteePeriodChecks(headRoot, headState, postState)
+	if err != nil {
+		log.WithError(err).Error("Could not check sync committee period boundary")
+		return
+	}
+	logSyncCommitteePeriodSummary(postState, summary)
+	if len(summary.failures) == 0 {
+		return
+	}
+	s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.SyncCommitteePeriodWarning,
+		Data: &statefeed.SyncCommitteePeriodWarningData{
+			Period:   summary.period,
+			Slot:     postState.Slot(),
+			Failures: summary.failures,
+		},
+	})
+}
+
+// syncCommitteePeriodChecks verifies that the previous head's next sync committee was promoted to the current
+// sync committee, that the sync committee cache was rotated to the new period and that the sync subnets of the
+// local validators match their positions in the new committee.
+func syncCommitteePeriodChecks(headRoot []byte, headState, postState state.BeaconState) (*syncCommitteePeriodSummary, error) {
+	summary := &syncCommitteePeriodSummary{
+		period: slots.SyncCommitteePeriod(coreTime.CurrentEpoch(postState)),
+	}
+	committee, err := postState.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+
+	// The previous head is only comparable if the post state descends from it and it was in the previous period.
+	if headState != nil && !headState.IsNil() && headState.Version() != version.Phase0 &&
+		slots.SyncCommitteePeriod(coreTime.CurrentEpoch(headState))+1 == summary.period &&
+		headState.Slot() < postState.Slot() {
+		ancestorRoot, err := helpers.BlockRootAtSlot(postState, headState.Slot())
+		if err == nil && bytes.Equal(ancestorRoot, headRoot) {
+			summary.promotedCheck = true
+			next, err := headState.NextSyncCommittee()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get next sync committee")
+			}
+			nextRoot, err := next.HashTreeRoot()
+			if err != nil {
+				return nil, err
+			}
+			currentRoot, err := committee.HashTreeRoot()
+			if err != nil {
+				return nil, err
+			}
+			summary.promoted = nextRoot == currentRoot
+			if !summary.promoted {
+				summary.failures = append(summary.failures, fmt.Sprintf(
+					"current sync committee %#x is not the next sync committee %#x of the previous period",
+					currentRoot[:8], nextRoot[:8]))
+			}
+		}
+	}
+
+	summary.cached, err = helpers.IsSyncCommitteeCached(postState)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check sync committee cache")
+	}
+	if !summary.cached {
+		summary.failures = append(summary.failures, "sync committee cache was not rotated to the new period")
+	}
+
+	startEpoch, err := slots.SyncCommitteePeriodStartEpoch(coreTime.CurrentEpoch(postState))
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(committee.Pubkeys))
+	for _, pubkey := range committee.Pubkeys {
+		if seen[string(pubkey)] {
+			continue
+		}
+		seen[string(pubkey)] = true
+		subnets, _, ok, _ := cache.SyncSubnetIDs.GetSyncCommitteeSubnets(pubkey, startEpoch)
+		if !ok {
+			continue
+		}
+		summary.localMembers++
+		if expected := syncSubnetsOfPubkey(pubkey, committee); !sameSubnets(subnets, expected) {
+			summary.failures = append(summary.failures, fmt.Sprintf(
+				"validator %#x is subscribed to sync subnets %v instead of %v", pubkey[:8], subnets, expected))
+		}
+	}
+	return summary, nil
+}
+
+// syncSubnetsOfPubkey returns the sync subnets of every position of the public key in the committee.
+func syncSubnetsOfPubkey(pubkey []byte, committee *ethpb.SyncCommittee) []uint64 {
+	subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	var subnets []uint64
+	for i, k := range committee.Pubkeys {
+		if !bytes.Equal(k, pubkey) {
+			continue
+		}
+		subnet := uint64(i) / subCommitteeSize
+		if len(subnets) == 0 || subnets[len(subnets)-1] != subnet {
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets
+}
+
+// sameSubnets returns true if both lists hold the same subnets, regardless of order and duplicates.
+func sameSubnets(a, b []uint64) bool {
+	inA := make(map[uint64]bool, len(a))
+	for _, s := range a {
+		inA[s] = true
+	}
+	inB := make(map[uint64]bool, len(b))
+	for _, s := range b {
+		if !inA[s] {
+			return false
+		}
+		inB[s] = true
+	}
+	return len(inA) == len(inB)
+}
+
+func logSyncCommitteePeriodSummary(postState state.BeaconState, summary *syncCommitteePeriodSummary) {
+	fields := logrus.Fields{
+		"period":       summary.period,
+		"slot":         postState.Slot(),
+		"cacheRotated": summary.cached,
+		"localMembers": summary.localMembers,
+	}
+	if summary.promotedCheck {
+		fields["committeePromoted"] = summary.promoted
+	}
+	if len(summary.failures) == 0 {
+		log.WithFields(fields).Info("Sync committee period boundary checks passed")
+		return
+	}
+	for _, f := range summary.failures {
+		log.WithFields(fields).Warn("Sync committee period boundary check failed: " + f)
+	}
+}
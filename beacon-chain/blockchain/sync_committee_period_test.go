@@ -0,0 +1,139 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// syncCommitteePeriodStates returns the head state at the last slot of the first sync committee period, its
+// block root and the state of the next block, at the first slot of the second period.
+func syncCommitteePeriodStates(t *testing.T, stateRootByte byte) (state.BeaconState, [32]byte, state.BeaconState) {
+	headState, _ := util.DeterministicGenesisStateAltair(t, 64)
+	boundarySlot, err := slots.EpochStart(params.BeaconConfig().EpochsPerSyncCommitteePeriod)
+	require.NoError(t, err)
+	require.NoError(t, headState.SetSlot(boundarySlot-1))
+	header := &ethpb.BeaconBlockHeader{
+		Slot:       boundarySlot - 1,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  bytesutil.PadTo([]byte{stateRootByte}, 32),
+		BodyRoot:   make([]byte, 32),
+	}
+	require.NoError(t, headState.SetLatestBlockHeader(header))
+	headRoot, err := header.HashTreeRoot()
+	require.NoError(t, err)
+
+	postState := headState.Copy()
+	roots := postState.BlockRoots()
+	roots[uint64(boundarySlot-1)%uint64(len(roots))] = headRoot[:]
+	require.NoError(t, postState.SetBlockRoots(roots))
+	require.NoError(t, postState.SetSlot(boundarySlot))
+	next, err := headState.NextSyncCommittee()
+	require.NoError(t, err)
+	require.NoError(t, postState.SetCurrentSyncCommittee(next))
+	return headState, headRoot, postState
+}
+
+func TestSyncCommitteePeriodChecks_OK(t *testing.T) {
+	defer cache.SyncSubnetIDs.EmptyAllCaches()
+	headState, headRoot, postState := syncCommitteePeriodStates(t, 1)
+	require.NoError(t, helpers.UpdateSyncCommitteeCache(headState))
+	committee, err := postState.CurrentSyncCommittee()
+	require.NoError(t, err)
+	pubkey := committee.Pubkeys[0]
+	startEpoch := params.BeaconConfig().EpochsPerSyncCommitteePeriod
+	cache.SyncSubnetIDs.AddSyncCommitteeSubnets(pubkey, startEpoch, syncSubnetsOfPubkey(pubkey, committee), time.Minute)
+
+	summary, err := syncCommitteePeriodChecks(headRoot[:], headState, postState)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), summary.period)
+	assert.Equal(t, true, summary.promotedCheck)
+	assert.Equal(t, true, summary.promoted)
+	assert.Equal(t, true, summary.cached)
+	assert.Equal(t, 1, summary.localMembers)
+	assert.Equal(t, 0, len(summary.failures))
+}
+
+func TestSyncCommitteePeriodChecks_Failures(t *testing.T) {
+	defer cache.SyncSubnetIDs.EmptyAllCaches()
+	headState, headRoot, postState := syncCommitteePeriodStates(t, 2)
+	committee, err := postState.CurrentSyncCommittee()
+	require.NoError(t, err)
+	pubkeys := make([][]byte, len(committee.Pubkeys))
+	copy(pubkeys, committee.Pubkeys)
+	pubkeys[0] = bytesutil.PadTo([]byte{'a'}, 48)
+	changed := &ethpb.SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: committee.AggregatePubkey,
+	}
+	require.NoError(t, postState.SetCurrentSyncCommittee(changed))
+	startEpoch := params.BeaconConfig().EpochsPerSyncCommitteePeriod
+	cache.SyncSubnetIDs.AddSyncCommitteeSubnets(pubkeys[0], startEpoch, []uint64{1}, time.Minute)
+
+	summary, err := syncCommitteePeriodChecks(headRoot[:], headState, postState)
+	require.NoError(t, err)
+	assert.Equal(t, true, summary.promotedCheck)
+	assert.Equal(t, false, summary.promoted)
+	assert.Equal(t, false, summary.cached)
+	assert.Equal(t, 1, summary.localMembers)
+	require.Equal(t, 3, len(summary.failures))
+
+	// The previous head is not compared when the post state does not descend from it.
+	summary, err = syncCommitteePeriodChecks([]byte{'a'}, headState, postState)
+	require.NoError(t, err)
+	assert.Equal(t, false, summary.promotedCheck)
+	assert.Equal(t, 2, len(summary.failures))
+}
+
+func TestService_CheckSyncCommitteePeriodBoundary_SendsWarning(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	headState, headRoot, postState := syncCommitteePeriodStates(t, 3)
+	notifier := &mock.MockStateNotifier{RecordEvents: true}
+	srv := &Service{cfg: &config{StateNotifier: notifier}}
+
+	// Nothing is checked within a period.
+	srv.checkSyncCommitteePeriodBoundary(headRoot[:], headState, headState)
+	require.Equal(t, 0, len(notifier.ReceivedEvents()))
+
+	srv.checkSyncCommitteePeriodBoundary(headRoot[:], headState, postState)
+	events := notifier.ReceivedEvents()
+	require.Equal(t, 1, len(events))
+	assert.Equal(t, statefeed.SyncCommitteePeriodWarning, int(events[0].Type))
+	data, ok := events[0].Data.(*statefeed.SyncCommitteePeriodWarningData)
+	require.Equal(t, true, ok)
+	assert.Equal(t, uint64(1), data.Period)
+	assert.Equal(t, postState.Slot(), data.Slot)
+	assert.Equal(t, 1, len(data.Failures))
+}
+
+func TestSyncSubnetsOfPubkey(t *testing.T) {
+	subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	pubkeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range pubkeys {
+		pubkeys[i] = bytesutil.PadTo([]byte{byte(i % 2)}, 48)
+	}
+	pubkeys[subCommitteeSize*2] = bytesutil.PadTo([]byte{'a'}, 48)
+	committee := &ethpb.SyncCommittee{Pubkeys: pubkeys}
+
+	assert.DeepEqual(t, []uint64{2}, syncSubnetsOfPubkey(pubkeys[subCommitteeSize*2], committee))
+	assert.Equal(t, params.BeaconConfig().SyncCommitteeSubnetCount, uint64(len(syncSubnetsOfPubkey(pubkeys[1], committee))))
+	assert.Equal(t, 0, len(syncSubnetsOfPubkey([]byte{'b'}, committee)))
+	assert.Equal(t, true, sameSubnets([]uint64{1, 2, 2}, []uint64{2, 1}))
+	assert.Equal(t, false, sameSubnets([]uint64{1}, []uint64{1, 2}))
+}
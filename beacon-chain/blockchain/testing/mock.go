@@ -16,6 +16,7 @@ import (
 	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
@@ -51,6 +52,7 @@ type ChainService struct {
 	VerifyBlkDescendantErr      error
 	stateNotifier               statefeed.Notifier
 	BlocksReceived              []interfaces.SignedBeaconBlock
+	BlockVerifications          []*transition.BlockVerification
 	SyncCommitteeIndices        []types.CommitteeIndex
 	blockNotifier               blockfeed.Notifier
 	opNotifier                  opfeed.Notifier
@@ -252,6 +254,14 @@ func (s *ChainService) ReceiveBlock(ctx context.Context, block interfaces.Signed
 	return nil
 }
 
+// ReceiveVerifiedBlock mocks ReceiveVerifiedBlock method in chain service.
+func (s *ChainService) ReceiveVerifiedBlock(
+	ctx context.Context, block interfaces.SignedBeaconBlock, root [32]byte, verified *transition.BlockVerification,
+) error {
+	s.BlockVerifications = append(s.BlockVerifications, verified)
+	return s.ReceiveBlock(ctx, block, root)
+}
+
 // HeadSlot mocks HeadSlot method in chain service.
 func (s *ChainService) HeadSlot() types.Slot {
 	if s.State == nil {
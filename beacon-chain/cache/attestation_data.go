@@ -29,6 +29,10 @@ var (
 		Name: "attestation_cache_hit",
 		Help: "The number of attestation data requests that are present in the cache.",
 	})
+	attestationCacheStale = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "attestation_cache_stale",
+		Help: "The number of attestation data requests whose cached data was computed from another head.",
+	})
 	attestationCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "attestation_cache_size",
 		Help: "The number of attestation data in the attestations cache",
@@ -40,7 +44,9 @@ var (
 // data to resolve via Get.
 var ErrAlreadyInProgress = errors.New("already in progress")
 
-// AttestationCache is used to store the cached results of an AttestationData request.
+// AttestationCache is used to store the cached results of an AttestationData request. The
+// attestation data of a slot is the same for every committee but for its committee index, so
+// a single result is kept per slot, along with the head block root it was computed from.
 type AttestationCache struct {
 	cache      *cache.FIFO
 	lock       sync.RWMutex
@@ -56,8 +62,9 @@ func NewAttestationCache() *AttestationCache {
 }
 
 // Get waits for any in progress calculation to complete before returning a
-// cached response, if any.
-func (c *AttestationCache) Get(ctx context.Context, req *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+// cached response, if any. A response computed from a head block root other than
+// the given one is stale, and is not returned.
+func (c *AttestationCache) Get(ctx context.Context, req *ethpb.AttestationDataRequest, headRoot [32]byte) (*ethpb.AttestationData, error) {
 	if req == nil {
 		return nil, errors.New("nil attestation data request")
 	}
@@ -96,8 +103,11 @@ func (c *AttestationCache) Get(ctx context.Context, req *ethpb.AttestationDataRe
 	}
 
 	if exists && item != nil && item.(*attestationReqResWrapper).res != nil {
-		attestationCacheHit.Inc()
-		return ethpb.CopyAttestationData(item.(*attestationReqResWrapper).res), nil
+		if item.(*attestationReqResWrapper).headRoot == headRoot {
+			attestationCacheHit.Inc()
+			return ethpb.CopyAttestationData(item.(*attestationReqResWrapper).res), nil
+		}
+		attestationCacheStale.Inc()
 	}
 	attestationCacheMiss.Inc()
 	return nil, nil
@@ -132,13 +142,15 @@ func (c *AttestationCache) MarkNotInProgress(req *ethpb.AttestationDataRequest)
 	return nil
 }
 
-// Put the response in the cache.
-func (c *AttestationCache) Put(_ context.Context, req *ethpb.AttestationDataRequest, res *ethpb.AttestationData) error {
+// Put the response computed from the given head block root in the cache, replacing
+// any response of the same slot computed from another head.
+func (c *AttestationCache) Put(_ context.Context, req *ethpb.AttestationDataRequest, headRoot [32]byte, res *ethpb.AttestationData) error {
 	data := &attestationReqResWrapper{
 		req,
 		res,
+		headRoot,
 	}
-	if err := c.cache.AddIfNotPresent(data); err != nil {
+	if err := c.cache.Add(data); err != nil {
 		return err
 	}
 	trim(c.cache, maxCacheSize)
@@ -166,6 +178,7 @@ func reqToKey(req *ethpb.AttestationDataRequest) (string, error) {
 }
 
 type attestationReqResWrapper struct {
-	req *ethpb.AttestationDataRequest
-	res *ethpb.AttestationData
+	req      *ethpb.AttestationDataRequest
+	res      *ethpb.AttestationData
+	headRoot [32]byte
 }
@@ -19,7 +19,7 @@ func TestAttestationCache_RoundTrip(t *testing.T) {
 		Slot:           1,
 	}
 
-	response, err := c.Get(ctx, req)
+	response, err := c.Get(ctx, req, [32]byte{1})
 	assert.NoError(t, err)
 	assert.Equal(t, (*ethpb.AttestationData)(nil), response)
 
@@ -29,13 +29,37 @@ func TestAttestationCache_RoundTrip(t *testing.T) {
 		Target: &ethpb.Checkpoint{Epoch: 5, Root: make([]byte, 32)},
 	}
 
-	assert.NoError(t, c.Put(ctx, req, res))
+	assert.NoError(t, c.Put(ctx, req, [32]byte{1}, res))
 	assert.NoError(t, c.MarkNotInProgress(req))
 
-	response, err = c.Get(ctx, req)
+	response, err = c.Get(ctx, req, [32]byte{1})
 	assert.NoError(t, err)
 
 	if !proto.Equal(response, res) {
 		t.Error("Expected equal protos to return from cache")
 	}
 }
+
+func TestAttestationCache_HeadChange(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewAttestationCache()
+
+	req := &ethpb.AttestationDataRequest{Slot: 1}
+	res := &ethpb.AttestationData{BeaconBlockRoot: []byte{1}}
+	assert.NoError(t, c.Put(ctx, req, [32]byte{1}, res))
+
+	// Data computed from a previous head is not served.
+	response, err := c.Get(ctx, req, [32]byte{2})
+	assert.NoError(t, err)
+	assert.Equal(t, (*ethpb.AttestationData)(nil), response)
+
+	// Data computed from the new head replaces it.
+	res2 := &ethpb.AttestationData{BeaconBlockRoot: []byte{2}}
+	assert.NoError(t, c.Put(ctx, req, [32]byte{2}, res2))
+	response, err = c.Get(ctx, req, [32]byte{2})
+	assert.NoError(t, err)
+	assert.DeepEqual(t, res2, response)
+	response, err = c.Get(ctx, req, [32]byte{1})
+	assert.NoError(t, err)
+	assert.Equal(t, (*ethpb.AttestationData)(nil), response)
+}
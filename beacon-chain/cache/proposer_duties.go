@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+)
+
+var (
+	// maxProposerDutiesCacheSize defines the max number of epochs the proposer duties cache can contain.
+	// Proposer duties are requested for the current and next epochs by validator clients, and for
+	// arbitrary past epochs by explorers and analysis tools, which usually scan a range of epochs.
+	maxProposerDutiesCacheSize = 64
+
+	// Metrics.
+	proposerDutiesCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "proposer_duties_cache_miss",
+		Help: "The number of proposer duties requests that aren't present in the cache.",
+	})
+	proposerDutiesCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "proposer_duties_cache_hit",
+		Help: "The number of proposer duties requests that are present in the cache.",
+	})
+)
+
+// proposerDutiesKey identifies the proposer assignments of an epoch. The assignments only depend on
+// the chain up to the dependent root, the root of the last block before the epoch starts.
+type proposerDutiesKey struct {
+	epoch         types.Epoch
+	dependentRoot [32]byte
+}
+
+// ProposerDutiesCache is a struct with 1 queue for looking up the proposer assignments of an epoch
+// by epoch and dependent root.
+type ProposerDutiesCache struct {
+	cache *lru.Cache
+	lock  sync.RWMutex
+}
+
+// NewProposerDutiesCache creates a new proposer duties cache for storing/accessing the proposer
+// assignments of epochs.
+func NewProposerDutiesCache() *ProposerDutiesCache {
+	return &ProposerDutiesCache{
+		cache: lruwrpr.New(maxProposerDutiesCacheSize),
+	}
+}
+
+// ProposerDuties returns the slots assigned to each proposer of the epoch under the given dependent
+// root. Returns nil if the assignments are not cached.
+func (c *ProposerDutiesCache) ProposerDuties(epoch types.Epoch, dependentRoot [32]byte) map[types.ValidatorIndex][]types.Slot {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	item, exists := c.cache.Get(proposerDutiesKey{epoch: epoch, dependentRoot: dependentRoot})
+	if exists && item != nil {
+		proposerDutiesCacheHit.Inc()
+		return item.(map[types.ValidatorIndex][]types.Slot)
+	}
+	proposerDutiesCacheMiss.Inc()
+	return nil
+}
+
+// AddProposerDuties adds the slots assigned to each proposer of the epoch under the given dependent
+// root to the cache. This method also evicts the least recently used epoch if the cache is full.
+func (c *ProposerDutiesCache) AddProposerDuties(epoch types.Epoch, dependentRoot [32]byte, duties map[types.ValidatorIndex][]types.Slot) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cache.Add(proposerDutiesKey{epoch: epoch, dependentRoot: dependentRoot}, duties)
+}
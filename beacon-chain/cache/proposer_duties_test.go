@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"testing"
+
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestProposerDutiesCache_ProposerDuties(t *testing.T) {
+	c := NewProposerDutiesCache()
+	root := [32]byte{'A'}
+	duties := map[types.ValidatorIndex][]types.Slot{1: {32, 35}, 7: {33}}
+
+	assert.Equal(t, 0, len(c.ProposerDuties(1, root)), "Expected duties not to exist in empty cache")
+
+	c.AddProposerDuties(1, root, duties)
+	assert.DeepEqual(t, duties, c.ProposerDuties(1, root))
+	assert.Equal(t, 0, len(c.ProposerDuties(2, root)), "Expected duties of another epoch not to exist")
+	assert.Equal(t, 0, len(c.ProposerDuties(1, [32]byte{'B'})), "Expected duties of another dependent root not to exist")
+}
+
+func TestProposerDutiesCache_MaxSize(t *testing.T) {
+	c := NewProposerDutiesCache()
+	for i := 0; i <= maxProposerDutiesCacheSize; i++ {
+		c.AddProposerDuties(types.Epoch(i), [32]byte{}, map[types.ValidatorIndex][]types.Slot{0: {0}})
+	}
+	require.Equal(t, maxProposerDutiesCacheSize, c.cache.Len())
+	assert.Equal(t, 0, len(c.ProposerDuties(0, [32]byte{})), "Expected least recently used epoch to be evicted")
+	assert.Equal(t, 1, len(c.ProposerDuties(types.Epoch(maxProposerDutiesCacheSize), [32]byte{})))
+}
@@ -110,8 +110,7 @@ func VerifySyncCommitteeSig(s state.BeaconState, syncKeys []bls.PublicKey, syncS
 	if err != nil {
 		return err
 	}
-	sszBytes := p2pType.SSZBytes(pbr)
-	r, err := signing.ComputeSigningRoot(&sszBytes, d)
+	r, err := SyncAggregateSigningRoot(bytesutil.ToBytes32(pbr), d)
 	if err != nil {
 		return err
 	}
@@ -119,7 +118,59 @@ func VerifySyncCommitteeSig(s state.BeaconState, syncKeys []bls.PublicKey, syncS
 	if err != nil {
 		return err
 	}
-	if !sig.Eth2FastAggregateVerify(syncKeys, r) {
+	if !signing.Eth2FastAggregateVerify(signing.SyncAggregateMessage, sig, syncKeys, r) {
+		return errors.New("invalid sync committee signature")
+	}
+	return nil
+}
+
+// SyncAggregateParticipantPubkeys returns the public keys of the members of the sync committee
+// that participated in the sync aggregate.
+func SyncAggregateParticipantPubkeys(committee *ethpb.SyncCommittee, sync *ethpb.SyncAggregate) ([]bls.PublicKey, error) {
+	if committee == nil {
+		return nil, errors.New("nil sync committee")
+	}
+	if sync == nil {
+		return nil, errors.New("nil sync aggregate")
+	}
+	if sync.SyncCommitteeBits.Len() > uint64(len(committee.Pubkeys)) {
+		return nil, errors.New("bits length exceeds committee length")
+	}
+	pubKeys := make([]bls.PublicKey, 0, sync.SyncCommitteeBits.Count())
+	for _, i := range sync.SyncCommitteeBits.BitIndices() {
+		pubKey, err := bls.PublicKeyFromBytes(committee.Pubkeys[i])
+		if err != nil {
+			return nil, err
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return pubKeys, nil
+}
+
+// SyncAggregateSigningRoot returns the root sync committee members sign over for a block root
+// under the given sync committee domain.
+func SyncAggregateSigningRoot(blockRoot [32]byte, domain []byte) ([32]byte, error) {
+	sszBytes := p2pType.SSZBytes(blockRoot[:])
+	return signing.ComputeSigningRoot(&sszBytes, domain)
+}
+
+// VerifySyncAggregate verifies the sync aggregate signature against the sync committee and the
+// block root it signs over, without requiring a beacon state. The domain must be the sync
+// committee domain of the epoch in which the block root was signed.
+func VerifySyncAggregate(committee *ethpb.SyncCommittee, sync *ethpb.SyncAggregate, blockRoot [32]byte, domain []byte) error {
+	pubKeys, err := SyncAggregateParticipantPubkeys(committee, sync)
+	if err != nil {
+		return err
+	}
+	r, err := SyncAggregateSigningRoot(blockRoot, domain)
+	if err != nil {
+		return err
+	}
+	sig, err := bls.SignatureFromBytes(sync.SyncCommitteeSignature)
+	if err != nil {
+		return err
+	}
+	if !signing.Eth2FastAggregateVerify(signing.SyncAggregateMessage, sig, pubKeys, r) {
 		return errors.New("invalid sync committee signature")
 	}
 	return nil
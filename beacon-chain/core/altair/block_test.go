@@ -240,6 +240,55 @@ func Test_VerifySyncCommitteeSig(t *testing.T) {
 	require.NoError(t, altair.VerifySyncCommitteeSig(beaconState, pks, aggregatedSig))
 }
 
+func Test_VerifySyncAggregate(t *testing.T) {
+	beaconState, privKeys := util.DeterministicGenesisStateAltair(t, params.BeaconConfig().MaxValidatorsPerCommittee)
+	require.NoError(t, beaconState.SetSlot(1))
+	committee, err := altair.NextSyncCommittee(context.Background(), beaconState)
+	require.NoError(t, err)
+	indices, err := altair.NextSyncCommitteeIndices(context.Background(), beaconState)
+	require.NoError(t, err)
+
+	blockRoot := bytesutil.ToBytes32([]byte("block root"))
+	domain, err := signing.Domain(beaconState.Fork(), 0, params.BeaconConfig().DomainSyncCommittee, beaconState.GenesisValidatorsRoot())
+	require.NoError(t, err)
+	signingRoot, err := altair.SyncAggregateSigningRoot(blockRoot, domain)
+	require.NoError(t, err)
+
+	syncBits := bitfield.NewBitvector512()
+	for i := range syncBits {
+		syncBits[i] = 0xAA
+	}
+	sigs := make([]bls.Signature, 0, len(indices))
+	for i, indice := range indices {
+		if syncBits.BitAt(uint64(i)) {
+			sigs = append(sigs, privKeys[indice].Sign(signingRoot[:]))
+		}
+	}
+	syncAggregate := &ethpb.SyncAggregate{
+		SyncCommitteeBits:      syncBits,
+		SyncCommitteeSignature: bls.AggregateSignatures(sigs).Marshal(),
+	}
+
+	pubKeys, err := altair.SyncAggregateParticipantPubkeys(committee, syncAggregate)
+	require.NoError(t, err)
+	require.Equal(t, len(sigs), len(pubKeys))
+	require.NoError(t, altair.VerifySyncAggregate(committee, syncAggregate, blockRoot, domain))
+
+	wrongRoot := bytesutil.ToBytes32([]byte("wrong root"))
+	require.ErrorContains(t, "invalid sync committee signature", altair.VerifySyncAggregate(committee, syncAggregate, wrongRoot, domain))
+	syncAggregate.SyncCommitteeBits = bitfield.NewBitvector512()
+	require.ErrorContains(t, "invalid sync committee signature", altair.VerifySyncAggregate(committee, syncAggregate, blockRoot, domain))
+
+	// An empty aggregate is valid when signed with the point at infinity.
+	infinity := make([]byte, fieldparams.BLSSignatureLength)
+	infinity[0] = 0xC0
+	syncAggregate.SyncCommitteeSignature = infinity
+	require.NoError(t, altair.VerifySyncAggregate(committee, syncAggregate, blockRoot, domain))
+
+	require.ErrorContains(t, "nil sync committee", altair.VerifySyncAggregate(nil, syncAggregate, blockRoot, domain))
+	require.ErrorContains(t, "nil sync aggregate", altair.VerifySyncAggregate(committee, nil, blockRoot, domain))
+}
+
 func Test_ApplySyncRewardsPenalties(t *testing.T) {
 	beaconState, _ := util.DeterministicGenesisStateAltair(t, params.BeaconConfig().MaxValidatorsPerCommittee)
 	beaconState, err := altair.ApplySyncRewardsPenalties(context.Background(), beaconState,
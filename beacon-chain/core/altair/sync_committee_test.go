@@ -2,11 +2,13 @@ package altair_test
 
 import (
 	"context"
+	"encoding/hex"
 	"testing"
 	"time"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	coreTime "github.com/prysmaticlabs/prysm/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	stateAltair "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
 	v2 "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
@@ -16,6 +18,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
 )
 
@@ -128,6 +131,49 @@ func TestSyncCommitteeIndices_DifferentPeriods(t *testing.T) {
 	require.DeepNotEqual(t, got1, got2)
 }
 
+func TestSyncCommitteeIndices_MatchFixtures(t *testing.T) {
+	for _, cfg := range []*params.BeaconChainConfig{params.MainnetConfig(), params.MinimalSpecConfig()} {
+		t.Run(cfg.PresetBase, func(t *testing.T) {
+			params.SetupTestConfigCleanup(t)
+			params.OverrideBeaconConfig(cfg.Copy())
+
+			fixtures, err := util.GenerateSyncCommitteeFixtures(4, 256)
+			require.NoError(t, err)
+			for _, f := range fixtures {
+				validators := make([]*ethpb.Validator, len(f.EffectiveBalances))
+				for i, b := range f.EffectiveBalances {
+					validators[i] = &ethpb.Validator{
+						ExitEpoch:        params.BeaconConfig().FarFutureEpoch,
+						EffectiveBalance: b,
+					}
+				}
+				// Use the fixture seed as randao mix, so the committees differ between fixtures.
+				mix, err := hex.DecodeString(f.Seed)
+				require.NoError(t, err)
+				mixes := make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector)
+				for i := range mixes {
+					mixes[i] = mix
+				}
+				st, err := stateAltair.InitializeFromProto(&ethpb.BeaconStateAltair{
+					Validators:  validators,
+					RandaoMixes: mixes,
+				})
+				require.NoError(t, err)
+
+				got, err := altair.NextSyncCommitteeIndices(context.Background(), st)
+				require.NoError(t, err)
+				seed, err := helpers.Seed(st, coreTime.NextEpoch(st), params.BeaconConfig().DomainSyncCommittee)
+				require.NoError(t, err)
+				active, err := helpers.ActiveValidatorIndices(context.Background(), st, coreTime.NextEpoch(st))
+				require.NoError(t, err)
+				want, err := util.ComputeSyncCommitteeIndices(seed, active, f.EffectiveBalances)
+				require.NoError(t, err)
+				assert.DeepEqual(t, want, got)
+			}
+		})
+	}
+}
+
 func TestSyncCommittee_CanGet(t *testing.T) {
 	getState := func(t *testing.T, count uint64) state.BeaconState {
 		validators := make([]*ethpb.Validator, count)
@@ -244,42 +244,69 @@ func ProcessEth1DataReset(state state.BeaconState) (state.BeaconState, error) {
 func ProcessEffectiveBalanceUpdates(state state.BeaconState) (state.BeaconState, error) {
 	effBalanceInc := params.BeaconConfig().EffectiveBalanceIncrement
 	maxEffBalance := params.BeaconConfig().MaxEffectiveBalance
+
+	bals := state.Balances()
+	dirty, err := effectiveBalanceDirtySet(state, bals)
+	if err != nil {
+		return nil, err
+	}
+	// Fast path: no balance moved past the hysteresis thresholds this epoch, which is the
+	// common case on a healthy network. Leave the validator registry untouched so it is
+	// neither copied nor re-hashed.
+	if len(dirty) == 0 {
+		return state, nil
+	}
+
+	for _, idx := range dirty {
+		val, err := state.ValidatorAtIndex(idx)
+		if err != nil {
+			return nil, err
+		}
+		balance := bals[idx]
+		effectiveBal := maxEffBalance
+		if effectiveBal > balance-balance%effBalanceInc {
+			effectiveBal = balance - balance%effBalanceInc
+		}
+		if effectiveBal == val.EffectiveBalance {
+			continue
+		}
+		val.EffectiveBalance = effectiveBal
+		if err := state.UpdateValidatorAtIndex(idx, val); err != nil {
+			return nil, err
+		}
+	}
+
+	return state, nil
+}
+
+// effectiveBalanceDirtySet returns the indices of validators whose balance crossed one of the
+// hysteresis thresholds relative to their current effective balance. Only these validators
+// can have their effective balance updated in ProcessEffectiveBalanceUpdates. The set is
+// computed with a read-only pass over the registry.
+func effectiveBalanceDirtySet(st state.ReadOnlyBeaconState, bals []uint64) ([]types.ValidatorIndex, error) {
+	effBalanceInc := params.BeaconConfig().EffectiveBalanceIncrement
 	hysteresisInc := effBalanceInc / params.BeaconConfig().HysteresisQuotient
 	downwardThreshold := hysteresisInc * params.BeaconConfig().HysteresisDownwardMultiplier
 	upwardThreshold := hysteresisInc * params.BeaconConfig().HysteresisUpwardMultiplier
 
-	bals := state.Balances()
-
-	// Update effective balances with hysteresis.
-	validatorFunc := func(idx int, val *ethpb.Validator) (bool, *ethpb.Validator, error) {
-		if val == nil {
-			return false, nil, fmt.Errorf("validator %d is nil in state", idx)
+	var dirty []types.ValidatorIndex
+	if err := st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		if val == nil || val.IsNil() {
+			return fmt.Errorf("validator %d is nil in state", idx)
 		}
 		if idx >= len(bals) {
-			return false, nil, fmt.Errorf("validator index exceeds validator length in state %d >= %d", idx, len(state.Balances()))
+			return fmt.Errorf("validator index exceeds validator length in state %d >= %d", idx, len(bals))
 		}
 		balance := bals[idx]
-
-		if balance+downwardThreshold < val.EffectiveBalance || val.EffectiveBalance+upwardThreshold < balance {
-			effectiveBal := maxEffBalance
-			if effectiveBal > balance-balance%effBalanceInc {
-				effectiveBal = balance - balance%effBalanceInc
-			}
-			if effectiveBal != val.EffectiveBalance {
-				newVal := ethpb.CopyValidator(val)
-				newVal.EffectiveBalance = effectiveBal
-				return true, newVal, nil
-			}
-			return false, val, nil
+		effectiveBalance := val.EffectiveBalance()
+		if balance+downwardThreshold < effectiveBalance || effectiveBalance+upwardThreshold < balance {
+			dirty = append(dirty, types.ValidatorIndex(idx))
 		}
-		return false, val, nil
-	}
-
-	if err := state.ApplyToEveryValidator(validatorFunc); err != nil {
+		return nil
+	}); err != nil {
 		return nil, err
 	}
-
-	return state, nil
+	return dirty, nil
 }
 
 // ProcessSlashingsReset processes the total slashing balances updates during epoch processing.
@@ -455,3 +455,100 @@ func TestProcessSlashings_BadValue(t *testing.T) {
 	_, err = epoch.ProcessSlashings(s, params.BeaconConfig().ProportionalSlashingMultiplier)
 	require.ErrorContains(t, "addition overflows", err)
 }
+
+func TestProcessEffectiveBalanceUpdates_Hysteresis(t *testing.T) {
+	maxEffBal := params.BeaconConfig().MaxEffectiveBalance
+	inc := params.BeaconConfig().EffectiveBalanceIncrement
+	hysteresisInc := inc / params.BeaconConfig().HysteresisQuotient
+	downward := hysteresisInc * params.BeaconConfig().HysteresisDownwardMultiplier
+	upward := hysteresisInc * params.BeaconConfig().HysteresisUpwardMultiplier
+
+	tests := []struct {
+		name          string
+		effective     uint64
+		balance       uint64
+		wantEffective uint64
+	}{
+		{name: "unchanged balance", effective: maxEffBal, balance: maxEffBal, wantEffective: maxEffBal},
+		{name: "small decrease within threshold", effective: maxEffBal, balance: maxEffBal - downward, wantEffective: maxEffBal},
+		{name: "decrease past threshold", effective: maxEffBal, balance: maxEffBal - downward - 1, wantEffective: maxEffBal - inc},
+		{name: "balance above max", effective: maxEffBal, balance: maxEffBal + 2*inc, wantEffective: maxEffBal},
+		{name: "small increase within threshold", effective: maxEffBal - inc, balance: maxEffBal - inc + upward, wantEffective: maxEffBal - inc},
+		{name: "increase past threshold", effective: maxEffBal - 2*inc, balance: maxEffBal - 2*inc + upward + 1, wantEffective: maxEffBal - inc},
+		{name: "ejected to zero", effective: 16 * inc, balance: 0, wantEffective: 0},
+	}
+	vals := make([]*ethpb.Validator, len(tests))
+	bals := make([]uint64, len(tests))
+	for i, tt := range tests {
+		vals[i] = &ethpb.Validator{EffectiveBalance: tt.effective, ExitEpoch: params.BeaconConfig().FarFutureEpoch}
+		bals[i] = tt.balance
+	}
+	s, err := v1.InitializeFromProto(&ethpb.BeaconState{Validators: vals, Balances: bals})
+	require.NoError(t, err)
+	newState, err := epoch.ProcessEffectiveBalanceUpdates(s)
+	require.NoError(t, err)
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newState.ValidatorAtIndexReadOnly(types.ValidatorIndex(i))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEffective, v.EffectiveBalance())
+		})
+	}
+}
+
+func TestProcessEffectiveBalanceUpdates_NoChangesKeepsRegistry(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 64)
+	before, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	validators := st.Validators()
+
+	newState, err := epoch.ProcessEffectiveBalanceUpdates(st)
+	require.NoError(t, err)
+	after, err := newState.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+	assert.DeepSSZEqual(t, validators, newState.Validators())
+}
+
+func TestProcessEffectiveBalanceUpdates_BalancesShorterThanValidators(t *testing.T) {
+	s, err := v1.InitializeFromProto(&ethpb.BeaconState{
+		Validators: []*ethpb.Validator{{EffectiveBalance: params.BeaconConfig().MaxEffectiveBalance}},
+		Balances:   []uint64{},
+	})
+	require.NoError(t, err)
+	_, err = epoch.ProcessEffectiveBalanceUpdates(s)
+	require.ErrorContains(t, "validator index exceeds validator length in state", err)
+}
+
+func BenchmarkProcessEffectiveBalanceUpdates(b *testing.B) {
+	count := 100000
+	vals := make([]*ethpb.Validator, count)
+	bals := make([]uint64, count)
+	for i := 0; i < count; i++ {
+		vals[i] = &ethpb.Validator{
+			EffectiveBalance: params.BeaconConfig().MaxEffectiveBalance,
+			ExitEpoch:        params.BeaconConfig().FarFutureEpoch,
+		}
+		bals[i] = params.BeaconConfig().MaxEffectiveBalance
+		// Move a small fraction of balances past the downward threshold, as happens
+		// with inactivity leaks or slashings on an otherwise healthy network.
+		if i%100 == 0 {
+			bals[i] -= params.BeaconConfig().EffectiveBalanceIncrement
+		}
+	}
+	st, err := util.NewBeaconState()
+	require.NoError(b, err)
+	require.NoError(b, st.SetValidators(vals))
+	require.NoError(b, st.SetBalances(bals))
+	_, err = st.HashTreeRoot(context.Background())
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		s := st.Copy()
+		b.StartTimer()
+		_, err := epoch.ProcessEffectiveBalanceUpdates(s)
+		require.NoError(b, err)
+	}
+}
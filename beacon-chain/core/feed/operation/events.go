@@ -19,6 +19,12 @@ const (
 
 	// SyncCommitteeContributionReceived is sent after a sync committee contribution object has been received.
 	SyncCommitteeContributionReceived
+
+	// SyncCommitteeMessageReceived is sent after a sync committee message object has been received from gossip.
+	SyncCommitteeMessageReceived
+
+	// DutyResultsReceived is sent after duty outcomes have been reported by a validator client over RPC.
+	DutyResultsReceived
 )
 
 // UnAggregatedAttReceivedData is the data sent with UnaggregatedAttReceived events.
@@ -44,3 +50,15 @@ type SyncCommitteeContributionReceivedData struct {
 	// Contribution is the sync committee contribution object.
 	Contribution *ethpb.SignedContributionAndProof
 }
+
+// SyncCommitteeMessageReceivedData is the data sent with SyncCommitteeMessageReceived events.
+type SyncCommitteeMessageReceivedData struct {
+	// Message is the sync committee message object.
+	Message *ethpb.SyncCommitteeMessage
+}
+
+// DutyResultsReceivedData is the data sent with DutyResultsReceived events.
+type DutyResultsReceivedData struct {
+	// Results are the reported duty outcomes.
+	Results []*ethpb.DutyResult
+}
@@ -26,6 +26,8 @@ const (
 	FinalizedCheckpoint
 	// NewHead of the chain event.
 	NewHead
+	// SyncCommitteePeriodWarning is sent when a consistency check fails at a sync committee period boundary.
+	SyncCommitteePeriodWarning
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -59,3 +61,13 @@ type InitializedData struct {
 	// GenesisValidatorsRoot represents state.validators.HashTreeRoot().
 	GenesisValidatorsRoot []byte
 }
+
+// SyncCommitteePeriodWarningData is the data sent with SyncCommitteePeriodWarning events.
+type SyncCommitteePeriodWarningData struct {
+	// Period is the sync committee period which just started.
+	Period uint64
+	// Slot of the first block processed in the period.
+	Slot types.Slot
+	// Failures describes the checks which failed.
+	Failures []string
+}
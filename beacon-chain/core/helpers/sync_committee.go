@@ -173,6 +173,23 @@ func UpdateSyncCommitteeCache(st state.BeaconState) error {
 	return syncCommitteeCache.UpdatePositionsInCommittee(combineRootAndSlot(prevBlockRoot[:], uint64(header.Slot)), st)
 }
 
+// IsSyncCommitteeCached returns true if the sync committee positions of the current period of the
+// state are in the sync committee cache.
+func IsSyncCommitteeCached(st state.BeaconState) (bool, error) {
+	root, err := syncPeriodBoundaryRoot(st)
+	if err != nil {
+		return false, err
+	}
+	_, err = syncCommitteeCache.CurrentPeriodIndexPosition(root, 0)
+	if err == cache.ErrNonExistingSyncCommitteeKey {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Loop through `pubKeys` for matching `pubKey` and get the indices where it matches.
 func findSubCommitteeIndices(pubKey []byte, pubKeys [][]byte) []types.CommitteeIndex {
 	var indices []types.CommitteeIndex
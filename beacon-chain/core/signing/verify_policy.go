@@ -0,0 +1,167 @@
+package signing
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+)
+
+// MessageType is the kind of consensus message a signature set belongs to.
+type MessageType string
+
+const (
+	// AttestationMessage is an unaggregated attestation received over gossip.
+	AttestationMessage MessageType = "attestation"
+	// AggregateMessage is a signed aggregate and proof received over gossip.
+	AggregateMessage MessageType = "aggregate"
+	// SyncCommitteeMessage is a sync committee message received over gossip.
+	SyncCommitteeMessage MessageType = "sync_committee_message"
+	// SyncContributionMessage is a signed sync committee contribution and proof received over gossip.
+	SyncContributionMessage MessageType = "sync_contribution"
+	// BlockMessage is the signature set collected while processing a block.
+	BlockMessage MessageType = "block"
+	// SyncAggregateMessage is the sync aggregate included in a block.
+	SyncAggregateMessage MessageType = "sync_aggregate"
+)
+
+// Path is a signature verification method.
+type Path string
+
+const (
+	// FastAggregateVerifyPath verifies each signature of a set on its own against its, possibly
+	// aggregated, public key.
+	FastAggregateVerifyPath Path = "fast_aggregate_verify"
+	// AggregateVerifyPath aggregates the signatures of a set and verifies them at once against the
+	// distinct messages of the set.
+	AggregateVerifyPath Path = "aggregate_verify"
+	// BatchPath verifies the signatures of a set together using random linear combinations.
+	BatchPath Path = "batch"
+)
+
+var (
+	signatureVerificationsCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "signature_verifications_total",
+		Help: "The number of signature set verifications, by message type, verification path and result.",
+	}, []string{"type", "path", "result"})
+	signatureVerificationFallbacksCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "signature_verification_fallbacks_total",
+		Help: "The number of signature set verifications retried on another path after a failure.",
+	}, []string{"type", "from", "to"})
+)
+
+// verifyPolicy maps each message type to the path used to verify its signatures. Message types
+// missing from the policy are batch verified.
+var verifyPolicy = map[MessageType]Path{
+	AttestationMessage:      BatchPath,
+	AggregateMessage:        BatchPath,
+	SyncCommitteeMessage:    BatchPath,
+	SyncContributionMessage: BatchPath,
+	BlockMessage:            BatchPath,
+	SyncAggregateMessage:    FastAggregateVerifyPath,
+}
+
+// PathFor returns the verification path of the given message type.
+func PathFor(t MessageType) Path {
+	if p, ok := verifyPolicy[t]; ok {
+		return p
+	}
+	return BatchPath
+}
+
+// SetPathFor overrides the verification path of the given message type and returns a function
+// restoring the previous policy. It is meant for tests and experiments with new verification paths.
+func SetPathFor(t MessageType, p Path) func() {
+	prev, ok := verifyPolicy[t]
+	verifyPolicy[t] = p
+	return func() {
+		if ok {
+			verifyPolicy[t] = prev
+		} else {
+			delete(verifyPolicy, t)
+		}
+	}
+}
+
+// VerifySet verifies the signature set of a message using the path of its message type. If the
+// path errors or rejects the set, the signatures are verified again one by one so a failing
+// optimization never rejects valid signatures.
+func VerifySet(t MessageType, set *bls.SignatureBatch) (bool, error) {
+	p := PathFor(t)
+	verified, err := verifyWithPath(p, set)
+	if p != FastAggregateVerifyPath && (err != nil || !verified) {
+		return VerifyFallback(t, p, set)
+	}
+	ObserveVerification(t, p, verified, err)
+	return verified, err
+}
+
+// VerifyFallback verifies each signature of the set on its own after the given path failed to
+// verify it, for example once a set joined with other messages failed batch verification.
+func VerifyFallback(t MessageType, from Path, set *bls.SignatureBatch) (bool, error) {
+	signatureVerificationFallbacksCount.WithLabelValues(string(t), string(from), string(FastAggregateVerifyPath)).Inc()
+	verified, err := verifyWithPath(FastAggregateVerifyPath, set)
+	ObserveVerification(t, FastAggregateVerifyPath, verified, err)
+	return verified, err
+}
+
+// Eth2FastAggregateVerify verifies a signature of the given public keys over a single message, as
+// defined by the eth2 specification, and records it against the message type.
+func Eth2FastAggregateVerify(t MessageType, sig bls.Signature, pubKeys []bls.PublicKey, msg [32]byte) bool {
+	verified := sig.Eth2FastAggregateVerify(pubKeys, msg)
+	ObserveVerification(t, FastAggregateVerifyPath, verified, nil)
+	return verified
+}
+
+// ObserveVerification records the result of a signature verification performed on the given path,
+// for callers verifying sets outside of this package.
+func ObserveVerification(t MessageType, p Path, verified bool, err error) {
+	result := "valid"
+	switch {
+	case err != nil:
+		result = "error"
+	case !verified:
+		result = "invalid"
+	}
+	signatureVerificationsCount.WithLabelValues(string(t), string(p), result).Inc()
+}
+
+func verifyWithPath(p Path, set *bls.SignatureBatch) (bool, error) {
+	if len(set.Signatures) != len(set.PublicKeys) || len(set.Signatures) != len(set.Messages) {
+		return false, errors.Errorf("signature set has %d signatures, %d public keys and %d messages",
+			len(set.Signatures), len(set.PublicKeys), len(set.Messages))
+	}
+	switch p {
+	case FastAggregateVerifyPath:
+		for i := range set.Signatures {
+			sig, err := bls.SignatureFromBytes(set.Signatures[i])
+			if err != nil {
+				return false, errors.Wrap(err, "could not convert bytes to signature")
+			}
+			if !sig.FastAggregateVerify([]bls.PublicKey{set.PublicKeys[i]}, set.Messages[i]) {
+				return false, nil
+			}
+		}
+		return true, nil
+	case AggregateVerifyPath:
+		if len(set.Signatures) == 0 {
+			return true, nil
+		}
+		seen := make(map[[32]byte]bool, len(set.Messages))
+		for _, m := range set.Messages {
+			if seen[m] {
+				return false, errors.New("aggregate verification requires distinct messages")
+			}
+			seen[m] = true
+		}
+		sigs, err := bls.MultipleSignaturesFromBytes(set.Signatures)
+		if err != nil {
+			return false, errors.Wrap(err, "could not convert bytes to signatures")
+		}
+		return bls.AggregateSignatures(sigs).AggregateVerify(set.PublicKeys, set.Messages), nil
+	case BatchPath:
+		return set.Verify()
+	default:
+		return false, errors.Errorf("unknown signature verification path %q", p)
+	}
+}
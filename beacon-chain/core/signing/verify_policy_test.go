@@ -0,0 +1,77 @@
+package signing
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func signatureSet(t *testing.T, valid bool, msgs ...[32]byte) *bls.SignatureBatch {
+	set := bls.NewSet()
+	for _, m := range msgs {
+		sk, err := bls.RandKey()
+		require.NoError(t, err)
+		signer := sk
+		if !valid {
+			signer, err = bls.RandKey()
+			require.NoError(t, err)
+		}
+		set.Signatures = append(set.Signatures, signer.Sign(m[:]).Marshal())
+		set.PublicKeys = append(set.PublicKeys, sk.PublicKey())
+		set.Messages = append(set.Messages, m)
+	}
+	return set
+}
+
+func TestVerifySet_Paths(t *testing.T) {
+	for _, p := range []Path{FastAggregateVerifyPath, AggregateVerifyPath, BatchPath} {
+		t.Run(string(p), func(t *testing.T) {
+			defer SetPathFor(AttestationMessage, p)()
+			assert.Equal(t, p, PathFor(AttestationMessage))
+
+			verified, err := VerifySet(AttestationMessage, signatureSet(t, true, [32]byte{'a'}, [32]byte{'b'}))
+			require.NoError(t, err)
+			assert.Equal(t, true, verified)
+
+			verified, err = VerifySet(AttestationMessage, signatureSet(t, false, [32]byte{'a'}, [32]byte{'b'}))
+			require.NoError(t, err)
+			assert.Equal(t, false, verified)
+		})
+	}
+	assert.Equal(t, BatchPath, PathFor(AttestationMessage))
+	assert.Equal(t, BatchPath, PathFor("unknown"))
+}
+
+func TestVerifySet_FallsBackOnFailure(t *testing.T) {
+	defer SetPathFor(AggregateMessage, AggregateVerifyPath)()
+	_, err := verifyWithPath(AggregateVerifyPath, signatureSet(t, true, [32]byte{'a'}, [32]byte{'a'}))
+	assert.ErrorContains(t, "aggregate verification requires distinct messages", err)
+
+	// Aggregate verification cannot verify repeated messages, the set is verified one signature at a time.
+	verified, err := VerifySet(AggregateMessage, signatureSet(t, true, [32]byte{'a'}, [32]byte{'a'}))
+	require.NoError(t, err)
+	assert.Equal(t, true, verified)
+}
+
+func TestVerifySet_Malformed(t *testing.T) {
+	set := signatureSet(t, true, [32]byte{'a'})
+	set.Messages = nil
+	_, err := VerifySet(BlockMessage, set)
+	assert.ErrorContains(t, "signature set has 1 signatures, 1 public keys and 0 messages", err)
+}
+
+func TestEth2FastAggregateVerify(t *testing.T) {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	msg := [32]byte{'a'}
+	sig := sk.Sign(msg[:])
+	assert.Equal(t, true, Eth2FastAggregateVerify(SyncAggregateMessage, sig, []bls.PublicKey{sk.PublicKey()}, msg))
+	assert.Equal(t, false, Eth2FastAggregateVerify(SyncAggregateMessage, sig, []bls.PublicKey{sk.PublicKey()}, [32]byte{'b'}))
+	// An empty aggregate is valid for an infinite signature.
+	infinite, err := bls.SignatureFromBytes(common.InfiniteSignature[:])
+	require.NoError(t, err)
+	assert.Equal(t, true, Eth2FastAggregateVerify(SyncAggregateMessage, infinite, nil, msg))
+}
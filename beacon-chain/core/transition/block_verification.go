@@ -0,0 +1,67 @@
+package transition
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+)
+
+// ErrBlockVerificationMismatch is returned when a block verification is used for a block other
+// than the block it was created for.
+var ErrBlockVerificationMismatch = errors.New("block verification does not belong to the block")
+
+// BlockVerification records the signatures of a block which the caller already verified, such as
+// during gossip validation, so that the state transition does not verify them a second time.
+// Attestation signatures are never skipped, as they are not verified before a block is imported.
+type BlockVerification struct {
+	// BlockRoot is the root of the verified block. The verification is rejected for any other block.
+	BlockRoot [32]byte
+	// Signature is the signature of the verified block. As the block root does not cover the
+	// signature, the verification is rejected for a copy of the block carrying another signature.
+	Signature []byte
+	// ProposerSignature is true if the block signature was verified against the proposer public key.
+	ProposerSignature bool
+	// RandaoReveal is true if the randao reveal was verified against the proposer public key.
+	RandaoReveal bool
+}
+
+// NewBlockVerification returns an empty verification of the block, on which the verified
+// signatures can be recorded.
+func NewBlockVerification(signed interfaces.SignedBeaconBlock) (*BlockVerification, error) {
+	root, err := signed.Block().HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	return &BlockVerification{BlockRoot: root, Signature: bytesutil.SafeCopyBytes(signed.Signature())}, nil
+}
+
+// verifyProposerSignature returns true if the proposer signature of the block must be verified.
+func (v *BlockVerification) verifyProposerSignature() bool {
+	return v == nil || !v.ProposerSignature
+}
+
+// verifyRandaoReveal returns true if the randao reveal of the block must be verified.
+func (v *BlockVerification) verifyRandaoReveal() bool {
+	return v == nil || !v.RandaoReveal
+}
+
+// checkBlock ensures the verification was created for the block being processed, so verified
+// signatures of a block are never assumed for another one.
+func (v *BlockVerification) checkBlock(signed interfaces.SignedBeaconBlock) error {
+	if v == nil {
+		return nil
+	}
+	root, err := signed.Block().HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if root != v.BlockRoot {
+		return errors.Wrapf(ErrBlockVerificationMismatch, "verification of %#x used for block %#x", v.BlockRoot, root)
+	}
+	if !bytes.Equal(signed.Signature(), v.Signature) {
+		return errors.Wrapf(ErrBlockVerificationMismatch, "verification of %#x used for a copy with another signature", root)
+	}
+	return nil
+}
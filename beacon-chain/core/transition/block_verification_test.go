@@ -0,0 +1,125 @@
+package transition_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+// signedBlockForVerification returns a pre-state and its next block, whose proposer signature or
+// randao reveal is invalid when requested.
+func signedBlockForVerification(t *testing.T, badProposerSig, badRandao bool) (state.BeaconState, interfaces.SignedBeaconBlock) {
+	ctx := context.Background()
+	beaconState, privKeys := util.DeterministicGenesisState(t, 100)
+	nextSlotState, err := transition.ProcessSlots(ctx, beaconState.Copy(), beaconState.Slot()+1)
+	require.NoError(t, err)
+	parentRoot, err := nextSlotState.LatestBlockHeader().HashTreeRoot()
+	require.NoError(t, err)
+	proposerIdx, err := helpers.BeaconProposerIndex(ctx, nextSlotState)
+	require.NoError(t, err)
+	epoch := time.CurrentEpoch(nextSlotState)
+	if badRandao {
+		epoch++
+	}
+	randaoReveal, err := util.RandaoReveal(nextSlotState, epoch, privKeys)
+	require.NoError(t, err)
+
+	block := util.NewBeaconBlock()
+	block.Block.ProposerIndex = proposerIdx
+	block.Block.Slot = nextSlotState.Slot()
+	block.Block.ParentRoot = parentRoot[:]
+	block.Block.Body.RandaoReveal = randaoReveal
+	wsb, err := wrapper.WrappedSignedBeaconBlock(block)
+	require.NoError(t, err)
+	stateRoot, err := transition.CalculateStateRoot(ctx, beaconState, wsb)
+	require.NoError(t, err)
+	block.Block.StateRoot = stateRoot[:]
+
+	sig, err := util.BlockSignature(beaconState, block.Block, privKeys)
+	require.NoError(t, err)
+	block.Signature = sig.Marshal()
+	if badProposerSig {
+		block.Signature = privKeys[0].Sign(make([]byte, 32)).Marshal()
+	}
+	wsb, err = wrapper.WrappedSignedBeaconBlock(block)
+	require.NoError(t, err)
+	return beaconState, wsb
+}
+
+func TestExecuteStateTransitionWithVerification_SkipsVerifiedSignatures(t *testing.T) {
+	ctx := context.Background()
+
+	st, blk := signedBlockForVerification(t, true /* bad proposer signature */, false)
+	_, err := transition.ExecuteStateTransition(ctx, st.Copy(), blk)
+	require.ErrorContains(t, "signature in block failed to verify", err)
+	verified, err := transition.NewBlockVerification(blk)
+	require.NoError(t, err)
+	verified.ProposerSignature = true
+	_, err = transition.ExecuteStateTransitionWithVerification(ctx, st.Copy(), blk, verified)
+	require.NoError(t, err)
+
+	st, blk = signedBlockForVerification(t, false, true /* bad randao reveal */)
+	verified, err = transition.NewBlockVerification(blk)
+	require.NoError(t, err)
+	verified.RandaoReveal = true
+	_, err = transition.ExecuteStateTransitionWithVerification(ctx, st.Copy(), blk, verified)
+	require.NoError(t, err)
+}
+
+func TestExecuteStateTransitionWithVerification_VerifiesUnrecordedSignatures(t *testing.T) {
+	ctx := context.Background()
+
+	// The proposer signature being verified does not vouch for the randao reveal.
+	st, blk := signedBlockForVerification(t, false, true /* bad randao reveal */)
+	verified, err := transition.NewBlockVerification(blk)
+	require.NoError(t, err)
+	verified.ProposerSignature = true
+	_, err = transition.ExecuteStateTransitionWithVerification(ctx, st.Copy(), blk, verified)
+	require.ErrorContains(t, "signature in block failed to verify", err)
+
+	// An empty verification verifies every signature.
+	st, blk = signedBlockForVerification(t, true /* bad proposer signature */, false)
+	verified, err = transition.NewBlockVerification(blk)
+	require.NoError(t, err)
+	_, err = transition.ExecuteStateTransitionWithVerification(ctx, st.Copy(), blk, verified)
+	require.ErrorContains(t, "signature in block failed to verify", err)
+}
+
+func TestExecuteStateTransitionWithVerification_RejectsOtherBlock(t *testing.T) {
+	st, blk := signedBlockForVerification(t, true /* bad proposer signature */, false)
+	verified := &transition.BlockVerification{BlockRoot: [32]byte{'a'}, ProposerSignature: true, RandaoReveal: true}
+	_, err := transition.ExecuteStateTransitionWithVerification(context.Background(), st, blk, verified)
+	require.ErrorIs(t, err, transition.ErrBlockVerificationMismatch)
+}
+
+func TestExecuteStateTransitionWithVerification_RejectsOtherSignature(t *testing.T) {
+	st, blk := signedBlockForVerification(t, true /* bad proposer signature */, false)
+	verified, err := transition.NewBlockVerification(blk)
+	require.NoError(t, err)
+	verified.ProposerSignature = true
+	// The same block, verified with another signature.
+	verified.Signature = make([]byte, len(verified.Signature))
+	_, err = transition.ExecuteStateTransitionWithVerification(context.Background(), st, blk, verified)
+	require.ErrorIs(t, err, transition.ErrBlockVerificationMismatch)
+}
+
+func TestNewBlockVerification(t *testing.T) {
+	_, blk := signedBlockForVerification(t, false, false)
+	verified, err := transition.NewBlockVerification(blk)
+	require.NoError(t, err)
+	root, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, root, verified.BlockRoot)
+	assert.DeepEqual(t, blk.Signature(), verified.Signature)
+	assert.Equal(t, false, verified.ProposerSignature)
+	assert.Equal(t, false, verified.RandaoReveal)
+}
@@ -14,6 +14,7 @@ import (
 	e "github.com/prysmaticlabs/prysm/beacon-chain/core/epoch"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/execution"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/config/params"
@@ -48,6 +49,19 @@ func ExecuteStateTransition(
 	ctx context.Context,
 	state state.BeaconState,
 	signed interfaces.SignedBeaconBlock,
+) (state.BeaconState, error) {
+	return ExecuteStateTransitionWithVerification(ctx, state, signed, nil /* verified */)
+}
+
+// ExecuteStateTransitionWithVerification executes the state transition like ExecuteStateTransition,
+// except that the block signatures recorded as verified are not verified again. A nil verification
+// verifies every signature. The verification must have been created for the given block, and the
+// signatures of the block attestations are always verified.
+func ExecuteStateTransitionWithVerification(
+	ctx context.Context,
+	state state.BeaconState,
+	signed interfaces.SignedBeaconBlock,
+	verified *BlockVerification,
 ) (state.BeaconState, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
@@ -55,16 +69,23 @@ func ExecuteStateTransition(
 	if err := wrapper.BeaconBlockIsNil(signed); err != nil {
 		return nil, err
 	}
+	if err := verified.checkBlock(signed); err != nil {
+		return nil, err
+	}
 
 	ctx, span := trace.StartSpan(ctx, "core.state.ExecuteStateTransition")
 	defer span.End()
 	var err error
 
-	set, postState, err := ExecuteStateTransitionNoVerifyAnySig(ctx, state, signed)
+	set, postState, err := executeStateTransitionNoVerifyAnySig(ctx, state, signed, verified)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not execute state transition")
 	}
-	valid, err := set.Verify()
+	// Every signature of the block may have been verified already.
+	if len(set.Signatures) == 0 {
+		return postState, nil
+	}
+	valid, err := signing.VerifySet(signing.BlockMessage, set)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not batch verify signature")
 	}
@@ -44,6 +44,17 @@ func ExecuteStateTransitionNoVerifyAnySig(
 	ctx context.Context,
 	st state.BeaconState,
 	signed interfaces.SignedBeaconBlock,
+) (*bls.SignatureBatch, state.BeaconState, error) {
+	return executeStateTransitionNoVerifyAnySig(ctx, st, signed, nil /* verified */)
+}
+
+// executeStateTransitionNoVerifyAnySig executes the state transition without verifying any signature,
+// leaving the signatures already verified by the caller out of the returned signature set.
+func executeStateTransitionNoVerifyAnySig(
+	ctx context.Context,
+	st state.BeaconState,
+	signed interfaces.SignedBeaconBlock,
+	verified *BlockVerification,
 ) (*bls.SignatureBatch, state.BeaconState, error) {
 	if ctx.Err() != nil {
 		return nil, nil, ctx.Err()
@@ -65,7 +76,7 @@ func ExecuteStateTransitionNoVerifyAnySig(
 	}
 
 	// Execute per block transition.
-	set, st, err := ProcessBlockNoVerifyAnySig(ctx, st, signed)
+	set, st, err := processBlockNoVerifyAnySig(ctx, st, signed, verified)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not process block")
 	}
@@ -157,6 +168,17 @@ func ProcessBlockNoVerifyAnySig(
 	ctx context.Context,
 	st state.BeaconState,
 	signed interfaces.SignedBeaconBlock,
+) (*bls.SignatureBatch, state.BeaconState, error) {
+	return processBlockNoVerifyAnySig(ctx, st, signed, nil /* verified */)
+}
+
+// processBlockNoVerifyAnySig processes the block without verifying any signature. The proposer and
+// randao signatures already verified by the caller are not added to the returned signature set.
+func processBlockNoVerifyAnySig(
+	ctx context.Context,
+	st state.BeaconState,
+	signed interfaces.SignedBeaconBlock,
+	verified *BlockVerification,
 ) (*bls.SignatureBatch, state.BeaconState, error) {
 	ctx, span := trace.StartSpan(ctx, "core.state.ProcessBlockNoVerifyAnySig")
 	defer span.End()
@@ -174,24 +196,29 @@ func ProcessBlockNoVerifyAnySig(
 		return nil, nil, err
 	}
 
-	bSet, err := b.BlockSignatureBatch(st, blk.ProposerIndex(), signed.Signature(), blk.HashTreeRoot)
-	if err != nil {
-		tracing.AnnotateError(span, err)
-		return nil, nil, errors.Wrap(err, "could not retrieve block signature set")
+	// Merge beacon block, randao and attestations signatures into a set.
+	set := bls.NewSet()
+	if verified.verifyProposerSignature() {
+		bSet, err := b.BlockSignatureBatch(st, blk.ProposerIndex(), signed.Signature(), blk.HashTreeRoot)
+		if err != nil {
+			tracing.AnnotateError(span, err)
+			return nil, nil, errors.Wrap(err, "could not retrieve block signature set")
+		}
+		set.Join(bSet)
 	}
-	rSet, err := b.RandaoSignatureBatch(ctx, st, signed.Block().Body().RandaoReveal())
-	if err != nil {
-		tracing.AnnotateError(span, err)
-		return nil, nil, errors.Wrap(err, "could not retrieve randao signature set")
+	if verified.verifyRandaoReveal() {
+		rSet, err := b.RandaoSignatureBatch(ctx, st, signed.Block().Body().RandaoReveal())
+		if err != nil {
+			tracing.AnnotateError(span, err)
+			return nil, nil, errors.Wrap(err, "could not retrieve randao signature set")
+		}
+		set.Join(rSet)
 	}
 	aSet, err := b.AttestationSignatureBatch(ctx, st, signed.Block().Body().Attestations())
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not retrieve attestation signature set")
 	}
-
-	// Merge beacon block, randao and attestations signatures into a set.
-	set := bls.NewSet()
-	set.Join(bSet).Join(rSet).Join(aSet)
+	set.Join(aSet)
 
 	return set, st, nil
 }
@@ -31,6 +31,8 @@ type ReadOnlyDatabase interface {
 	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
 	FinalizedChildBlock(ctx context.Context, blockRoot [32]byte) (interfaces.SignedBeaconBlock, error)
 	HighestRootsBelowSlot(ctx context.Context, slot types.Slot) (types.Slot, [][32]byte, error)
+	BlockRootsIncludingOperation(ctx context.Context, operationRoot [32]byte, since types.Slot) ([][32]byte, error)
+	CanonicalBlocks(ctx context.Context, fromSlot, toSlot types.Slot) (BlockIterator, error)
 	// State related methods.
 	State(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
@@ -56,6 +58,23 @@ type ReadOnlyDatabase interface {
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillBlockRoot(ctx context.Context) ([32]byte, error)
+	// Sync committee operations.
+	SyncCommittee(ctx context.Context, period uint64) (*ethpb.SyncCommittee, error)
+	HasSyncCommittee(ctx context.Context, period uint64) bool
+	// Peer scoring operations.
+	PeerRecords(ctx context.Context) ([]*ethpb.PeerRecord, error)
+}
+
+// BlockIterator iterates over beacon blocks in increasing slot order.
+type BlockIterator interface {
+	// Next advances the iterator to the next block, returning false once the blocks are exhausted or an error occurred.
+	Next() bool
+	// Block returns the current block.
+	Block() interfaces.SignedBeaconBlock
+	// Root returns the root of the current block.
+	Root() [32]byte
+	// Err returns the error that stopped the iteration, if any.
+	Err() error
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -70,8 +89,10 @@ type NoHeadAccessDatabase interface {
 	// State related methods.
 	SaveState(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot [32]byte) error
 	SaveStates(ctx context.Context, states []state.ReadOnlyBeaconState, blockRoots [][32]byte) error
+	SaveArchivedState(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot [32]byte) error
 	DeleteState(ctx context.Context, blockRoot [32]byte) error
 	DeleteStates(ctx context.Context, blockRoots [][32]byte) error
+	PruneBefore(ctx context.Context, slot types.Slot) error
 	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
 	SaveStateSummaries(ctx context.Context, summaries []*ethpb.StateSummary) error
 	// Checkpoint operations.
@@ -84,9 +105,14 @@ type NoHeadAccessDatabase interface {
 	SavePowchainData(ctx context.Context, data *ethpb.ETH1ChainData) error
 	// Run any required database migrations.
 	RunMigrations(ctx context.Context) error
+	CompletedMigrations(ctx context.Context) ([]string, error)
 	// Fee reicipients operations.
 	SaveFeeRecipientsByValidatorIDs(ctx context.Context, ids []types.ValidatorIndex, addrs []common.Address) error
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []types.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
+	// Sync committee operations.
+	SaveSyncCommittee(ctx context.Context, period uint64, committee *ethpb.SyncCommittee) error
+	// Peer scoring operations.
+	SavePeerRecords(ctx context.Context, records []*ethpb.PeerRecord) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint types.Slot) error
 }
@@ -0,0 +1,77 @@
+package kv
+
+import (
+	"fmt"
+
+	ssz "github.com/prysmaticlabs/fastssz"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+)
+
+// blockCodec describes how the signed blocks of a fork are stored: their SSZ encoding prefixed
+// by the key of the fork. Legacy blocks from before Altair are stored without key.
+type blockCodec struct {
+	key      []byte
+	newBlock func() ssz.Unmarshaler
+}
+
+// blockCodecs holds the codec of every block version the database can store. Supporting the
+// blocks of a new fork only requires registering a codec with a key of its own.
+var blockCodecs = map[int]*blockCodec{}
+
+func init() {
+	registerBlockCodec(version.Phase0, nil, func() ssz.Unmarshaler {
+		return &ethpb.SignedBeaconBlock{}
+	})
+	registerBlockCodec(version.Altair, altairKey, func() ssz.Unmarshaler {
+		return &ethpb.SignedBeaconBlockAltair{}
+	})
+	registerBlockCodec(version.Bellatrix, bellatrixKey, func() ssz.Unmarshaler {
+		return &ethpb.SignedBeaconBlockBellatrix{}
+	})
+	registerBlockCodec(version.BellatrixBlind, bellatrixBlindKey, func() ssz.Unmarshaler {
+		return &ethpb.SignedBlindedBeaconBlockBellatrix{}
+	})
+}
+
+// registerBlockCodec adds the codec of a block version, panicking if the version or key is
+// already registered, as blocks of different versions could no longer be told apart.
+func registerBlockCodec(v int, key []byte, newBlock func() ssz.Unmarshaler) {
+	if _, ok := blockCodecs[v]; ok {
+		panic(fmt.Sprintf("block codec already registered for version %s", version.String(v)))
+	}
+	for existingVersion, c := range blockCodecs {
+		if string(c.key) == string(key) {
+			panic(fmt.Sprintf(
+				"block codec key %q of version %s already used by version %s",
+				key, version.String(v), version.String(existingVersion),
+			))
+		}
+	}
+	blockCodecs[v] = &blockCodec{key: key, newBlock: newBlock}
+}
+
+// blockCodecForEncoding detects the codec of a stored block from the longest registered key
+// prefixing its encoding, and returns it along with the SSZ encoding of the block. Encodings
+// without any key are legacy phase 0 blocks.
+func blockCodecForEncoding(enc []byte) (*blockCodec, []byte) {
+	var match *blockCodec
+	for _, c := range blockCodecs {
+		if len(c.key) == 0 || !hasKey(enc, c.key) {
+			continue
+		}
+		if match == nil || len(c.key) > len(match.key) {
+			match = c
+		}
+	}
+	if match == nil {
+		return blockCodecs[version.Phase0], enc
+	}
+	return match, enc[len(match.key):]
+}
+
+// encode prefixes the SSZ encoding of a block with the key of the codec.
+func (c *blockCodec) encode(obj []byte) []byte {
+	enc := make([]byte, 0, len(c.key)+len(obj))
+	return append(append(enc, c.key...), obj...)
+}
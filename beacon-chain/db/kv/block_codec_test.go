@@ -0,0 +1,100 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/snappy"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestBlockCodecs_RoundTrip(t *testing.T) {
+	blocks := map[int]func() (interfaces.SignedBeaconBlock, error){
+		version.Phase0: func() (interfaces.SignedBeaconBlock, error) {
+			b := util.NewBeaconBlock()
+			b.Block.Slot = 1
+			return wrapper.WrappedSignedBeaconBlock(b)
+		},
+		version.Altair: func() (interfaces.SignedBeaconBlock, error) {
+			b := util.NewBeaconBlockAltair()
+			b.Block.Slot = 2
+			return wrapper.WrappedSignedBeaconBlock(b)
+		},
+		version.Bellatrix: func() (interfaces.SignedBeaconBlock, error) {
+			b := util.NewBeaconBlockBellatrix()
+			b.Block.Slot = 3
+			return wrapper.WrappedSignedBeaconBlock(b)
+		},
+		version.BellatrixBlind: func() (interfaces.SignedBeaconBlock, error) {
+			b := util.NewBlindedBeaconBlockBellatrix()
+			b.Block.Slot = 4
+			return wrapper.WrappedSignedBeaconBlock(b)
+		},
+	}
+	for v := range blockCodecs {
+		newBlock, ok := blocks[v]
+		require.Equal(t, true, ok, "No test block for registered version %s", version.String(v))
+		t.Run(version.String(v), func(t *testing.T) {
+			ctx := context.Background()
+			blk, err := newBlock()
+			require.NoError(t, err)
+			enc, err := marshalBlock(ctx, blk)
+			require.NoError(t, err)
+			decoded, err := unmarshalBlock(ctx, enc)
+			require.NoError(t, err)
+			assert.Equal(t, v, decoded.Version())
+			assert.DeepSSZEqual(t, blk.Proto(), decoded.Proto())
+		})
+	}
+}
+
+func TestBlockCodecForEncoding(t *testing.T) {
+	obj, err := util.NewBeaconBlock().MarshalSSZ()
+	require.NoError(t, err)
+	for v, c := range blockCodecs {
+		detected, detectedObj := blockCodecForEncoding(c.encode(obj))
+		assert.Equal(t, c, detected, "Wrong codec detected for version %s", version.String(v))
+		assert.DeepEqual(t, obj, detectedObj)
+	}
+}
+
+func TestUnmarshalBlock_LegacyEncoding(t *testing.T) {
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 5
+	obj, err := b.MarshalSSZ()
+	require.NoError(t, err)
+
+	// Blocks from before Altair were stored without any key.
+	decoded, err := unmarshalBlock(context.Background(), snappy.Encode(nil, obj))
+	require.NoError(t, err)
+	assert.Equal(t, version.Phase0, decoded.Version())
+	assert.DeepSSZEqual(t, b, decoded.Proto())
+}
+
+func TestRegisterBlockCodec_Conflicts(t *testing.T) {
+	newBlock := func() ssz.Unmarshaler {
+		return &ethpb.SignedBeaconBlock{}
+	}
+	assertPanics(t, func() {
+		registerBlockCodec(version.Altair, []byte("unused"), newBlock)
+	})
+	assertPanics(t, func() {
+		registerBlockCodec(version.BellatrixBlind+1, altairKey, newBlock)
+	})
+	_, ok := blockCodecs[version.BellatrixBlind+1]
+	assert.Equal(t, false, ok)
+}
+
+func assertPanics(t *testing.T, fn func()) {
+	defer func() {
+		assert.NotNil(t, recover(), "Expected a panic")
+	}()
+	fn()
+}
@@ -0,0 +1,97 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// Each entry of the operation root index is the big endian slot of the including block followed by its root.
+const operationInclusionEntryLength = 8 + 32
+
+// BlockRootsIncludingOperation returns the roots of the blocks at or after the given slot which include
+// the proposer slashing, attester slashing or voluntary exit with the given hash tree root.
+// The blocks are not necessarily canonical.
+func (s *Store) BlockRootsIncludingOperation(ctx context.Context, operationRoot [32]byte, since types.Slot) ([][32]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.BlockRootsIncludingOperation")
+	defer span.End()
+	roots := make([][32]byte, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		entries := tx.Bucket(blockOperationRootIndicesBucket).Get(operationRoot[:])
+		for i := 0; i+operationInclusionEntryLength <= len(entries); i += operationInclusionEntryLength {
+			if bytesutil.BytesToSlotBigEndian(entries[i:i+8]) < since {
+				continue
+			}
+			roots = append(roots, bytesutil.ToBytes32(entries[i+8:i+operationInclusionEntryLength]))
+		}
+		return nil
+	})
+	return roots, err
+}
+
+// blockOperationRoots returns the hash tree roots of the slashings and voluntary exits included in the block.
+func blockOperationRoots(ctx context.Context, blk interfaces.BeaconBlock) ([][]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.blockOperationRoots")
+	defer span.End()
+	body := blk.Body()
+	roots := make([][]byte, 0, len(body.ProposerSlashings())+len(body.AttesterSlashings())+len(body.VoluntaryExits()))
+	for _, sl := range body.ProposerSlashings() {
+		r, err := sl.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash proposer slashing")
+		}
+		roots = append(roots, r[:])
+	}
+	for _, sl := range body.AttesterSlashings() {
+		r, err := sl.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash attester slashing")
+		}
+		roots = append(roots, r[:])
+	}
+	for _, e := range body.VoluntaryExits() {
+		r, err := e.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash voluntary exit")
+		}
+		roots = append(roots, r[:])
+	}
+	return roots, nil
+}
+
+// indexBlockOperations records the block as including each of the given operation roots.
+func indexBlockOperations(tx *bolt.Tx, slot types.Slot, blockRoot []byte, operationRoots [][]byte) error {
+	if len(operationRoots) == 0 {
+		return nil
+	}
+	bkt := tx.Bucket(blockOperationRootIndicesBucket)
+	entry := append(bytesutil.SlotToBytesBigEndian(slot), blockRoot...)
+	for _, r := range operationRoots {
+		existing := bkt.Get(r)
+		if hasOperationInclusionEntry(existing, entry) {
+			continue
+		}
+		updated := make([]byte, 0, len(existing)+len(entry))
+		updated = append(updated, existing...)
+		updated = append(updated, entry...)
+		if err := bkt.Put(r, updated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasOperationInclusionEntry(entries, entry []byte) bool {
+	for i := 0; i+operationInclusionEntryLength <= len(entries); i += operationInclusionEntryLength {
+		if bytes.Equal(entries[i:i+operationInclusionEntryLength], entry) {
+			return true
+		}
+	}
+	return false
+}
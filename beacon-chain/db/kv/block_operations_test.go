@@ -0,0 +1,60 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestStore_BlockRootsIncludingOperation(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	exit := &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 3}, Signature: make([]byte, 96)}
+	exitRoot, err := exit.HashTreeRoot()
+	require.NoError(t, err)
+	slashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{ProposerIndex: 5}}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{ProposerIndex: 5, StateRoot: make([]byte, 32)}}),
+	}
+	slashingRoot, err := slashing.HashTreeRoot()
+	require.NoError(t, err)
+
+	b1 := util.NewBeaconBlock()
+	b1.Block.Slot = 5
+	b1.Block.Body.VoluntaryExits = []*ethpb.SignedVoluntaryExit{exit}
+	b1.Block.Body.ProposerSlashings = []*ethpb.ProposerSlashing{slashing}
+	wsb1, err := wrapper.WrappedSignedBeaconBlock(b1)
+	require.NoError(t, err)
+	r1, err := b1.Block.HashTreeRoot()
+	require.NoError(t, err)
+	b2 := util.NewBeaconBlockAltair()
+	b2.Block.Slot = 9
+	b2.Block.Body.VoluntaryExits = []*ethpb.SignedVoluntaryExit{exit}
+	wsb2, err := wrapper.WrappedSignedBeaconBlock(b2)
+	require.NoError(t, err)
+	r2, err := b2.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	require.NoError(t, db.SaveBlock(ctx, wsb1))
+	require.NoError(t, db.SaveBlocks(ctx, []interfaces.SignedBeaconBlock{wsb1, wsb2}))
+
+	roots, err := db.BlockRootsIncludingOperation(ctx, exitRoot, 0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{r1, r2}, roots)
+	roots, err = db.BlockRootsIncludingOperation(ctx, exitRoot, 6)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{r2}, roots)
+	roots, err = db.BlockRootsIncludingOperation(ctx, slashingRoot, 0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{r1}, roots)
+	roots, err = db.BlockRootsIncludingOperation(ctx, [32]byte{'a'}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(roots))
+}
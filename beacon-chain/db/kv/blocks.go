@@ -8,7 +8,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/golang/snappy"
 	"github.com/pkg/errors"
-	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
@@ -17,7 +16,6 @@ import (
 	"github.com/prysmaticlabs/prysm/container/slice"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
-	"github.com/prysmaticlabs/prysm/runtime/version"
 	"github.com/prysmaticlabs/prysm/time/slots"
 	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
@@ -281,6 +279,7 @@ func (s *Store) SaveBlocks(ctx context.Context, blocks []interfaces.SignedBeacon
 	blockRoots := make([][]byte, len(blocks))
 	encodedBlocks := make([][]byte, len(blocks))
 	indicesForBlocks := make([]map[string][]byte, len(blocks))
+	operationRoots := make([][][]byte, len(blocks))
 	for i, blk := range blocks {
 		blockRoot, err := blk.Block().HashTreeRoot()
 		if err != nil {
@@ -294,6 +293,10 @@ func (s *Store) SaveBlocks(ctx context.Context, blocks []interfaces.SignedBeacon
 		encodedBlocks[i] = enc
 		indicesByBucket := createBlockIndicesFromBlock(ctx, blk.Block())
 		indicesForBlocks[i] = indicesByBucket
+		operationRoots[i], err = blockOperationRoots(ctx, blk.Block())
+		if err != nil {
+			return err
+		}
 	}
 	return s.db.Update(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
@@ -304,6 +307,9 @@ func (s *Store) SaveBlocks(ctx context.Context, blocks []interfaces.SignedBeacon
 			if err := updateValueForIndices(ctx, indicesForBlocks[i], blockRoots[i], tx); err != nil {
 				return errors.Wrap(err, "could not update DB indices")
 			}
+			if err := indexBlockOperations(tx, blk.Block().Slot(), blockRoots[i], operationRoots[i]); err != nil {
+				return errors.Wrap(err, "could not update operation indices")
+			}
 			s.blockCache.Set(string(blockRoots[i]), blk, int64(len(encodedBlocks[i])))
 			if err := bkt.Put(blockRoots[i], encodedBlocks[i]); err != nil {
 				return err
@@ -760,50 +766,23 @@ func unmarshalBlock(_ context.Context, enc []byte) (interfaces.SignedBeaconBlock
 	if err != nil {
 		return nil, err
 	}
-	var rawBlock ssz.Unmarshaler
-	switch {
-	case hasAltairKey(enc):
-		// Marshal block bytes to altair beacon block.
-		rawBlock = &ethpb.SignedBeaconBlockAltair{}
-		if err := rawBlock.UnmarshalSSZ(enc[len(altairKey):]); err != nil {
-			return nil, err
-		}
-	case hasBellatrixKey(enc):
-		rawBlock = &ethpb.SignedBeaconBlockBellatrix{}
-		if err := rawBlock.UnmarshalSSZ(enc[len(bellatrixKey):]); err != nil {
-			return nil, err
-		}
-	case hasBellatrixBlindKey(enc):
-		rawBlock = &ethpb.SignedBlindedBeaconBlockBellatrix{}
-		if err := rawBlock.UnmarshalSSZ(enc[len(bellatrixBlindKey):]); err != nil {
-			return nil, err
-		}
-	default:
-		// Marshal block bytes to phase 0 beacon block.
-		rawBlock = &ethpb.SignedBeaconBlock{}
-		if err := rawBlock.UnmarshalSSZ(enc); err != nil {
-			return nil, err
-		}
+	codec, obj := blockCodecForEncoding(enc)
+	rawBlock := codec.newBlock()
+	if err := rawBlock.UnmarshalSSZ(obj); err != nil {
+		return nil, err
 	}
 	return wrapper.WrappedSignedBeaconBlock(rawBlock)
 }
 
 // marshal versioned beacon block from struct type down to bytes.
 func marshalBlock(_ context.Context, blk interfaces.SignedBeaconBlock) ([]byte, error) {
+	codec, ok := blockCodecs[blk.Version()]
+	if !ok {
+		return nil, errors.New("Unknown block version")
+	}
 	obj, err := blk.MarshalSSZ()
 	if err != nil {
 		return nil, err
 	}
-	switch blk.Version() {
-	case version.BellatrixBlind:
-		return snappy.Encode(nil, append(bellatrixBlindKey, obj...)), nil
-	case version.Bellatrix:
-		return snappy.Encode(nil, append(bellatrixKey, obj...)), nil
-	case version.Altair:
-		return snappy.Encode(nil, append(altairKey, obj...)), nil
-	case version.Phase0:
-		return snappy.Encode(nil, obj), nil
-	default:
-		return nil, errors.New("Unknown block version")
-	}
+	return snappy.Encode(nil, codec.encode(obj)), nil
 }
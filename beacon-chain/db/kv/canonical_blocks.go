@@ -0,0 +1,198 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// canonicalBlocksBatchSize is the number of slots whose blocks are read in a single transaction
+// by the canonical block iterator.
+const canonicalBlocksBatchSize = types.Slot(64)
+
+// canonicalBlockIterator walks the canonical blocks of a slot range in batches. Blocks at or below
+// the finalized block are canonical if they are part of the finalized block roots index, blocks above
+// it if they are ancestors of the head block.
+type canonicalBlockIterator struct {
+	ctx           context.Context
+	store         *Store
+	next          types.Slot
+	to            types.Slot
+	done          bool
+	genesisRoot   [32]byte
+	finalizedRoot [32]byte
+	finalizedSlot types.Slot
+	originSlot    types.Slot
+	headChain     map[[32]byte]bool
+	blocks        []interfaces.SignedBeaconBlock
+	roots         [][32]byte
+	pos           int
+	err           error
+}
+
+// CanonicalBlocks returns an iterator over the canonical blocks between fromSlot and toSlot, inclusive,
+// in increasing slot order. The non-finalized part of the chain follows the head block saved in the
+// database, so only finalized blocks are returned until a head block was saved.
+func (s *Store) CanonicalBlocks(ctx context.Context, fromSlot, toSlot types.Slot) (iface.BlockIterator, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.CanonicalBlocks")
+	defer span.End()
+	if toSlot < fromSlot {
+		return nil, errInvalidSlotRange
+	}
+	it := &canonicalBlockIterator{
+		ctx:       ctx,
+		store:     s,
+		next:      fromSlot,
+		to:        toSlot,
+		headChain: make(map[[32]byte]bool),
+		pos:       -1,
+	}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		it.genesisRoot = bytesutil.ToBytes32(bkt.Get(genesisBlockRootKey))
+		if originRoot := bkt.Get(originCheckpointBlockRootKey); originRoot != nil {
+			origin, err := unmarshalBlock(ctx, bkt.Get(originRoot))
+			if err != nil {
+				return errors.Wrap(err, "could not get origin checkpoint block")
+			}
+			it.originSlot = origin.Block().Slot()
+		}
+
+		it.finalizedRoot = it.genesisRoot
+		if enc := tx.Bucket(checkpointBucket).Get(finalizedCheckpointKey); enc != nil {
+			cp := &ethpb.Checkpoint{}
+			if err := decode(ctx, enc, cp); err != nil {
+				return err
+			}
+			if enc := bkt.Get(cp.Root); enc != nil {
+				finalized, err := unmarshalBlock(ctx, enc)
+				if err != nil {
+					return errors.Wrap(err, "could not get finalized block")
+				}
+				it.finalizedRoot = bytesutil.ToBytes32(cp.Root)
+				it.finalizedSlot = finalized.Block().Slot()
+			}
+		}
+
+		// Walk the head block's ancestry down to the finalized block or the start of the range.
+		root := bkt.Get(headBlockRootKey)
+		for root != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			enc := bkt.Get(root)
+			if enc == nil {
+				break
+			}
+			blk, err := unmarshalBlock(ctx, enc)
+			if err != nil {
+				return err
+			}
+			if blk.Block().Slot() <= it.finalizedSlot || blk.Block().Slot() < fromSlot {
+				break
+			}
+			it.headChain[bytesutil.ToBytes32(root)] = true
+			root = blk.Block().ParentRoot()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// Next advances the iterator to the next canonical block.
+func (it *canonicalBlockIterator) Next() bool {
+	for it.pos+1 >= len(it.blocks) {
+		if it.err != nil || it.done {
+			return false
+		}
+		it.err = it.loadBatch()
+	}
+	it.pos++
+	return true
+}
+
+// Block returns the current block.
+func (it *canonicalBlockIterator) Block() interfaces.SignedBeaconBlock {
+	return it.blocks[it.pos]
+}
+
+// Root returns the root of the current block.
+func (it *canonicalBlockIterator) Root() [32]byte {
+	return it.roots[it.pos]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *canonicalBlockIterator) Err() error {
+	return it.err
+}
+
+// loadBatch reads the canonical blocks of the next batch of slots with a single cursor scan of the slot index.
+func (it *canonicalBlockIterator) loadBatch() error {
+	if it.ctx.Err() != nil {
+		return it.ctx.Err()
+	}
+	start, end := it.next, it.to
+	if it.to-it.next >= canonicalBlocksBatchSize {
+		end = it.next + canonicalBlocksBatchSize - 1
+	}
+	it.done = end == it.to
+	it.next = end + 1
+	it.blocks = it.blocks[:0]
+	it.roots = it.roots[:0]
+	it.pos = -1
+
+	return it.store.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		finalizedIndex := tx.Bucket(finalizedBlockRootsIndexBucket)
+		max := bytesutil.SlotToBytesBigEndian(end)
+		c := tx.Bucket(blockSlotIndicesBucket).Cursor()
+		for k, v := c.Seek(bytesutil.SlotToBytesBigEndian(start)); k != nil && bytes.Compare(k, max) <= 0; k, v = c.Next() {
+			slot := bytesutil.BytesToSlotBigEndian(k)
+			roots, err := splitRoots(v)
+			if err != nil {
+				return errors.Wrapf(err, "corrupt value in block slot index for slot=%d", slot)
+			}
+			for _, r := range roots {
+				if !it.isCanonical(finalizedIndex, r, slot) {
+					continue
+				}
+				blk, err := unmarshalBlock(it.ctx, bkt.Get(r[:]))
+				if err != nil {
+					return err
+				}
+				it.blocks = append(it.blocks, blk)
+				it.roots = append(it.roots, r)
+				// There is a single canonical block per slot.
+				break
+			}
+		}
+		return nil
+	})
+}
+
+func (it *canonicalBlockIterator) isCanonical(finalizedIndex *bolt.Bucket, root [32]byte, slot types.Slot) bool {
+	switch {
+	case slot > it.finalizedSlot:
+		return it.headChain[root]
+	case slot == it.finalizedSlot:
+		return root == it.finalizedRoot
+	case root == it.genesisRoot:
+		return true
+	case slot < it.originSlot:
+		// Blocks older than the origin checkpoint are backfilled from the canonical chain only.
+		return true
+	default:
+		return finalizedIndex.Get(root[:]) != nil
+	}
+}
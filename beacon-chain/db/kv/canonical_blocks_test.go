@@ -0,0 +1,73 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestStore_CanonicalBlocks(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+
+	canonical := makeBlocks(t, 0, slotsPerEpoch*3, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, canonical))
+	// Forks below and above the finalized checkpoint.
+	finalizedFork := makeBlocks(t, 10, 5, bytesutil.ToBytes32(sszRootOrDie(t, canonical[5])))
+	require.NoError(t, db.SaveBlocks(ctx, finalizedFork))
+	headFork := makeBlocks(t, slotsPerEpoch*2+10, 5, bytesutil.ToBytes32(sszRootOrDie(t, canonical[slotsPerEpoch*2+5])))
+	require.NoError(t, db.SaveBlocks(ctx, headFork))
+
+	finalizedRoot := bytesutil.ToBytes32(sszRootOrDie(t, canonical[slotsPerEpoch]))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, finalizedRoot))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}))
+
+	collect := func(from, to types.Slot) [][32]byte {
+		it, err := db.CanonicalBlocks(ctx, from, to)
+		require.NoError(t, err)
+		roots := make([][32]byte, 0)
+		for it.Next() {
+			assert.Equal(t, it.Root(), bytesutil.ToBytes32(sszRootOrDie(t, it.Block())))
+			roots = append(roots, it.Root())
+		}
+		require.NoError(t, it.Err())
+		return roots
+	}
+
+	// Without a head block, only the finalized part of the chain is canonical.
+	roots := collect(0, types.Slot(slotsPerEpoch*3))
+	require.Equal(t, int(slotsPerEpoch)+1, len(roots))
+	for i, r := range roots {
+		assert.Equal(t, bytesutil.ToBytes32(sszRootOrDie(t, canonical[i])), r)
+	}
+
+	headRoot := bytesutil.ToBytes32(sszRootOrDie(t, canonical[len(canonical)-1]))
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: canonical[len(canonical)-1].Block().Slot(), Root: headRoot[:]}))
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, headRoot))
+
+	roots = collect(0, types.Slot(slotsPerEpoch*3))
+	require.Equal(t, len(canonical), len(roots))
+	for i, r := range roots {
+		assert.Equal(t, bytesutil.ToBytes32(sszRootOrDie(t, canonical[i])), r)
+	}
+
+	roots = collect(types.Slot(slotsPerEpoch*2), types.Slot(slotsPerEpoch*2+20))
+	require.Equal(t, 21, len(roots))
+	for i, r := range roots {
+		assert.Equal(t, bytesutil.ToBytes32(sszRootOrDie(t, canonical[int(slotsPerEpoch*2)-1+i])), r)
+	}
+
+	_, err = db.CanonicalBlocks(ctx, 2, 1)
+	require.ErrorIs(t, err, errInvalidSlotRange)
+}
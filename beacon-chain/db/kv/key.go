@@ -2,24 +2,24 @@ package kv
 
 import "bytes"
 
-// In order for an encoding to be Altair compatible, it must be prefixed with altair key.
-func hasAltairKey(enc []byte) bool {
-	if len(altairKey) >= len(enc) {
+// hasKey returns true if the encoding is prefixed by the key.
+func hasKey(enc, key []byte) bool {
+	if len(key) >= len(enc) {
 		return false
 	}
-	return bytes.Equal(enc[:len(altairKey)], altairKey)
+	return bytes.Equal(enc[:len(key)], key)
+}
+
+// In order for an encoding to be Altair compatible, it must be prefixed with altair key.
+func hasAltairKey(enc []byte) bool {
+	return hasKey(enc, altairKey)
 }
 
 func hasBellatrixKey(enc []byte) bool {
-	if len(bellatrixKey) >= len(enc) {
-		return false
-	}
-	return bytes.Equal(enc[:len(bellatrixKey)], bellatrixKey)
+	return hasKey(enc, bellatrixKey)
 }
 
-func hasBellatrixBlindKey(enc []byte) bool {
-	if len(bellatrixBlindKey) >= len(enc) {
-		return false
-	}
-	return bytes.Equal(enc[:len(bellatrixBlindKey)], bellatrixBlindKey)
+// In order for a state encoding to be a diff against a snapshot state, it must be prefixed with state diff key.
+func hasStateDiffKey(enc []byte) bool {
+	return hasKey(enc, stateDiffKey)
 }
@@ -57,6 +57,43 @@ var (
 // would be approximately 2MB
 var BlockCacheSize = int64(1 << 21)
 
+// buckets of the beacon node database, which are created when it is opened for writing.
+var buckets = [][]byte{
+	attestationsBucket,
+	blocksBucket,
+	stateBucket,
+	proposerSlashingsBucket,
+	attesterSlashingsBucket,
+	voluntaryExitsBucket,
+	chainMetadataBucket,
+	checkpointBucket,
+	powchainBucket,
+	stateSummaryBucket,
+	stateValidatorsBucket,
+	// Indices buckets.
+	attestationHeadBlockRootBucket,
+	attestationSourceRootIndicesBucket,
+	attestationSourceEpochIndicesBucket,
+	attestationTargetRootIndicesBucket,
+	attestationTargetEpochIndicesBucket,
+	blockSlotIndicesBucket,
+	stateSlotIndicesBucket,
+	blockParentRootIndicesBucket,
+	finalizedBlockRootsIndexBucket,
+	blockRootValidatorHashesBucket,
+	blockOperationRootIndicesBucket,
+	stateDiffDependentsBucket,
+	// State management service bucket.
+	newStateServiceCompatibleBucket,
+	// Migrations
+	migrationsBucket,
+
+	feeRecipientBucket,
+	registrationBucket,
+	syncCommitteeBucket,
+	peerRecordsBucket,
+}
+
 // blockedBuckets represents the buckets that we want to restrict
 // from our metrics fetching for performance reasons. For a detailed
 // summary, it can be read in https://github.com/prysmaticlabs/prysm/issues/8274.
@@ -71,6 +108,9 @@ var blockedBuckets = [][]byte{
 // Config for the bolt db kv store.
 type Config struct {
 	InitialMMapSize int
+	// ReadOnly opens an existing database without write access. Bolt does not allow a read-only
+	// store to be opened while another process has the database open for writing.
+	ReadOnly bool
 }
 
 // Store defines an implementation of the Prysm Database interface
@@ -82,6 +122,7 @@ type Store struct {
 	validatorEntryCache *ristretto.Cache
 	stateSummaryCache   *stateSummaryCache
 	ctx                 context.Context
+	readOnly            bool
 }
 
 // KVStoreDatafilePath is the canonical construction of a full
@@ -95,16 +136,22 @@ func KVStoreDatafilePath(dirPath string) string {
 // path specified, creates the kv-buckets based on the schema, and stores
 // an open connection db object as a property of the Store struct.
 func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, error) {
-	hasDir, err := file.HasDir(dirPath)
-	if err != nil {
-		return nil, err
-	}
-	if !hasDir {
-		if err := file.MkdirAll(dirPath); err != nil {
+	datafile := KVStoreDatafilePath(dirPath)
+	if config.ReadOnly {
+		if !file.FileExists(datafile) {
+			return nil, errors.Errorf("no database found at %s", datafile)
+		}
+	} else {
+		hasDir, err := file.HasDir(dirPath)
+		if err != nil {
 			return nil, err
 		}
+		if !hasDir {
+			if err := file.MkdirAll(dirPath); err != nil {
+				return nil, err
+			}
+		}
 	}
-	datafile := KVStoreDatafilePath(dirPath)
 	log.Infof("Opening Bolt DB at %s", datafile)
 	boltDB, err := bolt.Open(
 		datafile,
@@ -112,6 +159,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		&bolt.Options{
 			Timeout:         1 * time.Second,
 			InitialMmapSize: config.InitialMMapSize,
+			ReadOnly:        config.ReadOnly,
 		},
 	)
 	if err != nil {
@@ -146,40 +194,21 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		validatorEntryCache: validatorCache,
 		stateSummaryCache:   newStateSummaryCache(),
 		ctx:                 ctx,
+		readOnly:            config.ReadOnly,
 	}
-	if err := kv.db.Update(func(tx *bolt.Tx) error {
-		return createBuckets(
-			tx,
-			attestationsBucket,
-			blocksBucket,
-			stateBucket,
-			proposerSlashingsBucket,
-			attesterSlashingsBucket,
-			voluntaryExitsBucket,
-			chainMetadataBucket,
-			checkpointBucket,
-			powchainBucket,
-			stateSummaryBucket,
-			stateValidatorsBucket,
-			// Indices buckets.
-			attestationHeadBlockRootBucket,
-			attestationSourceRootIndicesBucket,
-			attestationSourceEpochIndicesBucket,
-			attestationTargetRootIndicesBucket,
-			attestationTargetEpochIndicesBucket,
-			blockSlotIndicesBucket,
-			stateSlotIndicesBucket,
-			blockParentRootIndicesBucket,
-			finalizedBlockRootsIndexBucket,
-			blockRootValidatorHashesBucket,
-			// State management service bucket.
-			newStateServiceCompatibleBucket,
-			// Migrations
-			migrationsBucket,
-
-			feeRecipientBucket,
-			registrationBucket,
-		)
+	if config.ReadOnly {
+		// Buckets can't be created in a read-only store, so it must have been opened for writing by
+		// a node of the same version before.
+		if err := kv.db.View(func(tx *bolt.Tx) error {
+			return checkBuckets(tx, buckets...)
+		}); err != nil {
+			if closeErr := boltDB.Close(); closeErr != nil {
+				log.WithError(closeErr).Error("Could not close database")
+			}
+			return nil, err
+		}
+	} else if err := kv.db.Update(func(tx *bolt.Tx) error {
+		return createBuckets(tx, buckets...)
 	}); err != nil {
 		return nil, err
 	}
@@ -204,8 +233,10 @@ func (s *Store) Close() error {
 	prometheus.Unregister(createBoltCollector(s.db))
 
 	// Before DB closes, we should dump the cached state summary objects to DB.
-	if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
-		return err
+	if !s.readOnly {
+		if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
+			return err
+		}
 	}
 
 	return s.db.Close()
@@ -225,6 +256,15 @@ func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
 	return nil
 }
 
+func checkBuckets(tx *bolt.Tx, buckets ...[]byte) error {
+	for _, bucket := range buckets {
+		if tx.Bucket(bucket) == nil {
+			return errors.Errorf("database has no %s bucket, open it once without read-only access to create it", bucket)
+		}
+	}
+	return nil
+}
+
 // createBoltCollector returns a prometheus collector specifically configured for boltdb.
 func createBoltCollector(db *bolt.DB) prometheus.Collector {
 	return prombolt.New("boltDB", db, blockedBuckets...)
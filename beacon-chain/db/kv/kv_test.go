@@ -4,7 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/prysm/testing/require"
+	bolt "go.etcd.io/bbolt"
 )
 
 // setupDB instantiates and returns a Store instance.
@@ -16,3 +18,60 @@ func setupDB(t testing.TB) *Store {
 	})
 	return db
 }
+
+func TestStore_CompletedMigrations(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	require.NoError(t, db.RunMigrations(ctx))
+	completed, err := db.CompletedMigrations(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []string{string(migrationBlockSlotIndex0Key)}, completed)
+	unknown, err := db.UnknownMigrations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(unknown))
+
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(migrationsBucket).Put([]byte("future_migration"), migrationCompleted)
+	}))
+	unknown, err = db.UnknownMigrations(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []string{"future_migration"}, unknown)
+}
+
+func TestStore_ReadOnly(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := NewKVStore(ctx, dir, &Config{})
+	require.NoError(t, err)
+	addr := common.HexToAddress("0x1234")
+	require.NoError(t, db.SaveDepositContractAddress(ctx, addr))
+	require.NoError(t, db.Close())
+
+	db, err = NewKVStore(ctx, dir, &Config{ReadOnly: true})
+	require.NoError(t, err)
+	got, err := db.DepositContractAddress(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, addr.Bytes(), got)
+	require.ErrorIs(t, db.SaveGenesisBlockRoot(ctx, [32]byte{'a'}), bolt.ErrDatabaseReadOnly)
+	require.NoError(t, db.Close())
+}
+
+func TestStore_ReadOnly_NoDatabase(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewKVStore(context.Background(), dir, &Config{ReadOnly: true})
+	require.ErrorContains(t, "no database found", err)
+}
+
+func TestStore_ReadOnly_MissingBucket(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := NewKVStore(ctx, dir, &Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		return tx.DeleteBucket(peerRecordsBucket)
+	}))
+	require.NoError(t, db.Close())
+
+	_, err = NewKVStore(ctx, dir, &Config{ReadOnly: true})
+	require.ErrorContains(t, "database has no peer-records bucket", err)
+}
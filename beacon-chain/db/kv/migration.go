@@ -1,6 +1,7 @@
 package kv
 
 import (
+	"bytes"
 	"context"
 
 	bolt "go.etcd.io/bbolt"
@@ -14,6 +15,15 @@ var migrations = []migration{
 	migrateArchivedIndex,
 	migrateBlockSlotIndex,
 	migrateStateValidators,
+	migrateStateDiffs,
+}
+
+// migrationKeys are the keys marking the migrations known to this version as completed.
+var migrationKeys = [][]byte{
+	migrationArchivedIndex0Key,
+	migrationBlockSlotIndex0Key,
+	migrationStateValidatorsKey,
+	migrationStateDiffsKey,
 }
 
 // RunMigrations defined in the migrations array.
@@ -25,3 +35,41 @@ func (s *Store) RunMigrations(ctx context.Context) error {
 	}
 	return nil
 }
+
+// CompletedMigrations returns the keys of the migrations applied to the database,
+// which describe the version of its schema.
+func (s *Store) CompletedMigrations(_ context.Context) ([]string, error) {
+	completed := make([]string, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(migrationsBucket).ForEach(func(k, v []byte) error {
+			if bytes.Equal(v, migrationCompleted) {
+				completed = append(completed, string(k))
+			}
+			return nil
+		})
+	})
+	return completed, err
+}
+
+// UnknownMigrations returns the completed migrations of the database which this version does not know,
+// meaning that the database schema was last upgraded by a more recent version.
+func (s *Store) UnknownMigrations(ctx context.Context) ([]string, error) {
+	completed, err := s.CompletedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unknown := make([]string, 0)
+	for _, k := range completed {
+		known := false
+		for _, mk := range migrationKeys {
+			if k == string(mk) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			unknown = append(unknown, k)
+		}
+	}
+	return unknown, nil
+}
@@ -0,0 +1,133 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/config/features"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/monitoring/progress"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/schollz/progressbar/v3"
+	bolt "go.etcd.io/bbolt"
+)
+
+var migrationStateDiffsKey = []byte("migration_state_diffs")
+
+// migrateStateDiffs rewrites the finalized states stored in full as periodic snapshots and
+// diffs against them. The genesis state is kept in full.
+func migrateStateDiffs(ctx context.Context, db *bolt.DB) error {
+	if !features.Get().EnableStateDiffs {
+		return nil
+	}
+	var roots [][]byte
+	if err := db.View(func(tx *bolt.Tx) error {
+		mb := tx.Bucket(migrationsBucket)
+		if b := mb.Get(migrationStateDiffsKey); bytes.Equal(b, migrationCompleted) {
+			return nil
+		}
+		// State diffs are computed on encodings stripped of their validator entries.
+		if !features.Get().EnableHistoricalSpaceRepresentation && !bytes.Equal(mb.Get(migrationStateValidatorsKey), migrationCompleted) {
+			log.Warn("State diffs require the historical state representation, skipping the migration of archived states")
+			return nil
+		}
+		var err error
+		roots, err = finalizedStateRoots(ctx, tx)
+		return err
+	}); err != nil {
+		return err
+	}
+	if roots == nil {
+		return nil
+	}
+
+	log.Infof("Performing a one-time migration of %d archived states to snapshots and diffs", len(roots))
+	bar := progress.InitializeProgressBar(len(roots), "Migrating archived states to snapshots and diffs.")
+	for batchIndex := 0; batchIndex < len(roots); batchIndex += batchSize {
+		end := batchIndex + batchSize
+		if end > len(roots) {
+			end = len(roots)
+		}
+		if err := db.Update(performStateDiffMigration(ctx, bar, roots[batchIndex:end])); err != nil {
+			return err
+		}
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(migrationsBucket).Put(migrationStateDiffsKey, migrationCompleted)
+	}); err != nil {
+		return err
+	}
+	log.Info("Migration of archived states done")
+	return nil
+}
+
+// finalizedStateRoots returns the block roots of the states stored up to the finalized slot, in
+// increasing slot order and excluding the genesis state.
+func finalizedStateRoots(ctx context.Context, tx *bolt.Tx) ([][]byte, error) {
+	genesisRoot := tx.Bucket(blocksBucket).Get(genesisBlockRootKey)
+	var finalizedSlot types.Slot
+	if enc := tx.Bucket(checkpointBucket).Get(finalizedCheckpointKey); enc != nil {
+		finalized := &ethpb.Checkpoint{}
+		if err := decode(ctx, enc, finalized); err != nil {
+			return nil, err
+		}
+		var err error
+		finalizedSlot, err = slots.EpochStart(finalized.Epoch)
+		if err != nil {
+			return nil, err
+		}
+	}
+	roots := make([][]byte, 0)
+	c := tx.Bucket(stateSlotIndicesBucket).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if bytesutil.BytesToSlotBigEndian(k) > finalizedSlot {
+			break
+		}
+		splitRoots, err := splitRoots(v)
+		if err != nil {
+			return nil, err
+		}
+		for _, root := range splitRoots {
+			if bytes.Equal(root[:], genesisRoot) {
+				continue
+			}
+			roots = append(roots, bytesutil.SafeCopyBytes(root[:]))
+		}
+	}
+	return roots, nil
+}
+
+func performStateDiffMigration(ctx context.Context, bar *progressbar.ProgressBar, roots [][]byte) func(tx *bolt.Tx) error {
+	return func(tx *bolt.Tx) error {
+		stateBkt := tx.Bucket(stateBucket)
+		for _, root := range roots {
+			if err := bar.Add(1); err != nil {
+				return err
+			}
+			// Snapshots of a previously interrupted migration are kept as is.
+			if tx.Bucket(stateDiffDependentsBucket).Get(root) != nil ||
+				bytes.Equal(tx.Bucket(chainMetadataBucket).Get(lastStateSnapshotKey), root) {
+				continue
+			}
+			enc := stateBkt.Get(root)
+			if enc == nil {
+				continue
+			}
+			dec, err := snappy.Decode(nil, enc)
+			if err != nil {
+				return err
+			}
+			if hasStateDiffKey(dec) {
+				continue
+			}
+			if err := saveArchivedStateEncoding(ctx, tx, root, dec); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,56 @@
+package kv
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// PeerRecords returns all the persisted peer scoring records.
+func (s *Store) PeerRecords(ctx context.Context) ([]*ethpb.PeerRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PeerRecords")
+	defer span.End()
+	records := make([]*ethpb.PeerRecord, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(peerRecordsBucket).ForEach(func(_, enc []byte) error {
+			record := &ethpb.PeerRecord{}
+			if err := decode(ctx, enc, record); err != nil {
+				return err
+			}
+			records = append(records, record)
+			return nil
+		})
+	})
+	return records, err
+}
+
+// SavePeerRecords replaces the persisted peer scoring records with the given records.
+func (s *Store) SavePeerRecords(ctx context.Context, records []*ethpb.PeerRecord) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SavePeerRecords")
+	defer span.End()
+	encs := make([][]byte, len(records))
+	for i, record := range records {
+		enc, err := encode(ctx, record)
+		if err != nil {
+			return err
+		}
+		encs[i] = enc
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(peerRecordsBucket); err != nil {
+			return err
+		}
+		bkt, err := tx.CreateBucket(peerRecordsBucket)
+		if err != nil {
+			return err
+		}
+		for i, record := range records {
+			if err := bkt.Put([]byte(record.PeerId), encs[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,40 @@
+package kv
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestStore_PeerRecords_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	records, err := db.PeerRecords(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(records))
+
+	saved := []*ethpb.PeerRecord{
+		{PeerId: "a", Address: "/ip4/1.1.1.1/tcp/13000", BadResponses: 3, ConnectionCount: 2, LastSeen: 100},
+		{PeerId: "b", GossipScore: -10.5, BehaviourPenalty: 2, LastSeen: 200},
+	}
+	require.NoError(t, db.SavePeerRecords(ctx, saved))
+	records, err = db.PeerRecords(ctx)
+	require.NoError(t, err)
+	sort.Slice(records, func(i, j int) bool { return records[i].PeerId < records[j].PeerId })
+	require.Equal(t, 2, len(records))
+	for i := range saved {
+		assert.DeepEqual(t, saved[i], records[i])
+	}
+
+	// Saving replaces the previously stored records.
+	require.NoError(t, db.SavePeerRecords(ctx, saved[1:]))
+	records, err = db.PeerRecords(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.DeepEqual(t, saved[1], records[0])
+}
@@ -0,0 +1,162 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// pruneBatchSize is the number of block slot index entries pruned within a single db transaction,
+// so pruning a long history does not hold the db lock for its whole duration.
+const pruneBatchSize = 256
+
+// PruneBefore deletes the blocks with a slot lower than the given slot, along with their states and
+// state summaries. Only finalized history can be pruned: an error is returned if the slot is beyond
+// the start of the finalized epoch. The genesis, origin checkpoint, backfill and finalized blocks
+// are always kept, as is the finalized block roots index so the canonical chain remains traceable.
+func (s *Store) PruneBefore(ctx context.Context, slot types.Slot) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PruneBefore")
+	defer span.End()
+
+	finalized, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	finalizedSlot, err := slots.EpochStart(finalized.Epoch)
+	if err != nil {
+		return err
+	}
+	if slot > finalizedSlot {
+		return errors.Wrapf(ErrDeleteJustifiedAndFinalized, "cannot prune before slot %d beyond finalized slot %d", slot, finalizedSlot)
+	}
+	validatorMigrationOver, err := s.isStateValidatorMigrationOver()
+	if err != nil {
+		return err
+	}
+
+	// The genesis block is never pruned, so pruning starts from the slot after it.
+	start := types.Slot(1)
+	pruned := 0
+	for start < slot {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var n int
+		err := s.db.Update(func(tx *bolt.Tx) error {
+			var err error
+			start, n, err = s.pruneBatch(ctx, tx, start, slot, finalized, validatorMigrationOver)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		pruned += n
+	}
+	if pruned > 0 {
+		log.WithField("count", pruned).WithField("beforeSlot", slot).Debug("Pruned blocks and states")
+	}
+	return nil
+}
+
+// pruneBatch prunes the blocks of up to pruneBatchSize block slot index entries in [start, end). It returns
+// the slot pruning should resume from and the number of blocks pruned.
+func (s *Store) pruneBatch(
+	ctx context.Context,
+	tx *bolt.Tx,
+	start, end types.Slot,
+	finalized *ethpb.Checkpoint,
+	validatorMigrationOver bool,
+) (types.Slot, int, error) {
+	blocksBkt := tx.Bucket(blocksBucket)
+	kept := [][]byte{
+		blocksBkt.Get(genesisBlockRootKey),
+		blocksBkt.Get(originCheckpointBlockRootKey),
+		blocksBkt.Get(backfillBlockRootKey),
+		finalized.Root,
+	}
+	isKept := func(root []byte) bool {
+		for _, k := range kept {
+			if bytes.Equal(root, k) {
+				return true
+			}
+		}
+		return false
+	}
+
+	type slotRoots struct {
+		key   []byte
+		roots []byte
+	}
+	entries := make([]slotRoots, 0, pruneBatchSize)
+	c := tx.Bucket(blockSlotIndicesBucket).Cursor()
+	next := end
+	for k, v := c.Seek(bytesutil.SlotToBytesBigEndian(start)); k != nil; k, v = c.Next() {
+		slot := bytesutil.BytesToSlotBigEndian(k)
+		if slot >= end {
+			break
+		}
+		if len(entries) == pruneBatchSize {
+			next = slot
+			break
+		}
+		// Keys and values are only valid for the life of the transaction and must not be
+		// modified while iterating, so they are copied.
+		entries = append(entries, slotRoots{key: bytesutil.SafeCopyBytes(k), roots: bytesutil.SafeCopyBytes(v)})
+	}
+
+	pruned := 0
+	for _, e := range entries {
+		remaining := make([]byte, 0)
+		for i := 0; i+hashLength <= len(e.roots); i += hashLength {
+			root := e.roots[i : i+hashLength]
+			if isKept(root) {
+				remaining = append(remaining, root...)
+				continue
+			}
+			if err := s.pruneBlock(ctx, tx, root, validatorMigrationOver); err != nil {
+				return 0, 0, errors.Wrapf(err, "could not prune block %#x", root)
+			}
+			pruned++
+		}
+		bkt := tx.Bucket(blockSlotIndicesBucket)
+		if len(remaining) == 0 {
+			if err := bkt.Delete(e.key); err != nil {
+				return 0, 0, err
+			}
+			continue
+		}
+		if err := bkt.Put(e.key, remaining); err != nil {
+			return 0, 0, err
+		}
+	}
+	return next, pruned, nil
+}
+
+// pruneBlock deletes the block with the given root along with its state and state summary.
+func (s *Store) pruneBlock(ctx context.Context, tx *bolt.Tx, root []byte, validatorMigrationOver bool) error {
+	if tx.Bucket(stateBucket).Get(root) != nil {
+		slot, err := s.slotByBlockRoot(ctx, tx, root)
+		if err != nil {
+			return err
+		}
+		if err := s.deleteStateAtSlot(ctx, tx, root, slot, validatorMigrationOver); err != nil {
+			return err
+		}
+	}
+	s.stateSummaryCache.delete(bytesutil.ToBytes32(root))
+	if err := tx.Bucket(stateSummaryBucket).Delete(root); err != nil {
+		return err
+	}
+	if err := tx.Bucket(blockParentRootIndicesBucket).Delete(root); err != nil {
+		return err
+	}
+	s.blockCache.Del(string(root))
+	return tx.Bucket(blocksBucket).Delete(root)
+}
@@ -0,0 +1,80 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestStore_PruneBefore(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	genesis := util.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	wsb, err := wrapper.WrappedSignedBeaconBlock(genesis)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, wsb))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisRoot))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, genesisRoot))
+
+	// Build a chain of a block per slot over four epochs, with a state and state summary saved for every block.
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	roots := make(map[types.Slot][32]byte)
+	parent := genesisRoot
+	for slot := types.Slot(1); slot <= 4*slotsPerEpoch; slot++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parent[:]
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		wsb, err := wrapper.WrappedSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, db.SaveBlocks(ctx, []interfaces.SignedBeaconBlock{wsb}))
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, db.SaveState(ctx, st, r))
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: r[:]}))
+		roots[slot] = r
+		parent = r
+	}
+	finalizedSlot := 3 * slotsPerEpoch
+	finalizedRoot := roots[finalizedSlot]
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: finalizedRoot[:]}))
+
+	err = db.PruneBefore(ctx, finalizedSlot+1)
+	require.ErrorIs(t, err, ErrDeleteJustifiedAndFinalized)
+
+	pruneSlot := 2 * slotsPerEpoch
+	require.NoError(t, db.PruneBefore(ctx, pruneSlot))
+	for slot, r := range roots {
+		pruned := slot < pruneSlot
+		assert.Equal(t, !pruned, db.HasBlock(ctx, r), "unexpected block at slot %d", slot)
+		assert.Equal(t, !pruned, db.HasState(ctx, r), "unexpected state at slot %d", slot)
+		assert.Equal(t, !pruned, db.HasStateSummary(ctx, r), "unexpected state summary at slot %d", slot)
+	}
+	assert.Equal(t, true, db.HasBlock(ctx, genesisRoot))
+	assert.Equal(t, true, db.HasState(ctx, genesisRoot))
+
+	blks, err := db.BlocksBySlot(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(blks))
+	blks, err = db.BlocksBySlot(ctx, pruneSlot)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(blks))
+
+	// Pruning is idempotent.
+	require.NoError(t, db.PruneBefore(ctx, pruneSlot))
+}
@@ -30,12 +30,12 @@ import (
 func (s *Store) State(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.State")
 	defer span.End()
-	enc, err := s.stateBytes(ctx, blockRoot)
+	dec, err := s.stateBytes(ctx, blockRoot)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(enc) == 0 {
+	if len(dec) == 0 {
 		return nil, nil
 	}
 	// get the validator entries of the state
@@ -44,7 +44,7 @@ func (s *Store) State(ctx context.Context, blockRoot [32]byte) (state.BeaconStat
 		return nil, valErr
 	}
 
-	return s.unmarshalState(ctx, enc, valEntries)
+	return s.unmarshalDecodedState(ctx, dec, valEntries)
 }
 
 // StateOrError is just like State(), except it only returns a non-error response
@@ -143,6 +143,10 @@ func (s *Store) SaveStates(ctx context.Context, states []state.ReadOnlyBeaconSta
 			if err := updateValueForIndices(ctx, indicesByBucket, rt[:], tx); err != nil {
 				return errors.Wrap(err, "could not update DB indices")
 			}
+			// The state may already be stored as an archived state diff or snapshot.
+			if err := deleteStateDiffRelations(ctx, tx, rt[:]); err != nil {
+				return errors.Wrap(err, "could not update state diffs")
+			}
 			if err := bucket.Put(rt[:], multipleEncs[i]); err != nil {
 				return err
 			}
@@ -213,104 +217,102 @@ func (s *Store) saveStatesEfficientInternal(ctx context.Context, tx *bolt.Tx, bl
 		if err := updateValueForIndices(ctx, indicesByBucket, rt[:], tx); err != nil {
 			return errors.Wrap(err, "could not update DB indices")
 		}
-
-		// There is a gap when the states that are passed are used outside this
-		// thread. But while storing the state object, we should not store the
-		// validator entries.To bring the gap closer, we empty the validators
-		// just before Put() and repopulate that state with original validators.
-		// look at issue https://github.com/prysmaticlabs/prysm/issues/9262.
-		switch rawType := states[i].InnerStateUnsafe().(type) {
-		case *ethpb.BeaconState:
-			var pbState *ethpb.BeaconState
-			var err error
-			if features.Get().EnableNativeState {
-				pbState, err = statenative.ProtobufBeaconStatePhase0(rawType)
-			} else {
-				pbState, err = v1.ProtobufBeaconState(rawType)
-			}
-			if err != nil {
-				return err
-			}
-			if pbState == nil {
-				return errors.New("nil state")
-			}
-			valEntries := pbState.Validators
-			pbState.Validators = make([]*ethpb.Validator, 0)
-			encodedState, err := encode(ctx, pbState)
-			if err != nil {
-				return err
-			}
-			if err := bucket.Put(rt[:], encodedState); err != nil {
-				return err
-			}
-			pbState.Validators = valEntries
-			if err := valIdxBkt.Put(rt[:], validatorKeys[i]); err != nil {
-				return err
-			}
-		case *ethpb.BeaconStateAltair:
-			var pbState *ethpb.BeaconStateAltair
-			var err error
-			if features.Get().EnableNativeState {
-				pbState, err = statenative.ProtobufBeaconStateAltair(rawType)
-			} else {
-				pbState, err = v2.ProtobufBeaconState(rawType)
-			}
-			if err != nil {
-				return err
-			}
-			if pbState == nil {
-				return errors.New("nil state")
-			}
-			valEntries := pbState.Validators
-			pbState.Validators = make([]*ethpb.Validator, 0)
-			rawObj, err := pbState.MarshalSSZ()
-			if err != nil {
-				return err
-			}
-			encodedState := snappy.Encode(nil, append(altairKey, rawObj...))
-			if err := bucket.Put(rt[:], encodedState); err != nil {
-				return err
-			}
-			pbState.Validators = valEntries
-			if err := valIdxBkt.Put(rt[:], validatorKeys[i]); err != nil {
-				return err
-			}
-		case *ethpb.BeaconStateBellatrix:
-			var pbState *ethpb.BeaconStateBellatrix
-			var err error
-			if features.Get().EnableNativeState {
-				pbState, err = statenative.ProtobufBeaconStateBellatrix(rawType)
-			} else {
-				pbState, err = v3.ProtobufBeaconState(rawType)
-			}
-			if err != nil {
-				return err
-			}
-			if pbState == nil {
-				return errors.New("nil state")
-			}
-			valEntries := pbState.Validators
-			pbState.Validators = make([]*ethpb.Validator, 0)
-			rawObj, err := pbState.MarshalSSZ()
-			if err != nil {
-				return err
-			}
-			encodedState := snappy.Encode(nil, append(bellatrixKey, rawObj...))
-			if err := bucket.Put(rt[:], encodedState); err != nil {
-				return err
-			}
-			pbState.Validators = valEntries
-			if err := valIdxBkt.Put(rt[:], validatorKeys[i]); err != nil {
-				return err
-			}
-		default:
-			return errors.New("invalid state type")
+		enc, err := marshalStateWithoutValidators(states[i])
+		if err != nil {
+			return err
+		}
+		// The state may already be stored as an archived state diff or snapshot.
+		if err := deleteStateDiffRelations(ctx, tx, rt[:]); err != nil {
+			return errors.Wrap(err, "could not update state diffs")
+		}
+		if err := bucket.Put(rt[:], snappy.Encode(nil, enc)); err != nil {
+			return err
+		}
+		if err := valIdxBkt.Put(rt[:], validatorKeys[i]); err != nil {
+			return err
 		}
 	}
 	// store the validator entries separately to save space.
 	return s.storeValidatorEntriesSeparately(ctx, tx, validatorsEntries)
 }
 
+// marshalStateWithoutValidators returns the uncompressed encoding of the state, stripped of its
+// validator entries which are stored separately.
+func marshalStateWithoutValidators(st state.ReadOnlyBeaconState) ([]byte, error) {
+	// There is a gap when the states that are passed are used outside this
+	// thread. But while storing the state object, we should not store the
+	// validator entries.To bring the gap closer, we empty the validators
+	// just before encoding and repopulate that state with original validators.
+	// look at issue https://github.com/prysmaticlabs/prysm/issues/9262.
+	switch rawType := st.InnerStateUnsafe().(type) {
+	case *ethpb.BeaconState:
+		var pbState *ethpb.BeaconState
+		var err error
+		if features.Get().EnableNativeState {
+			pbState, err = statenative.ProtobufBeaconStatePhase0(rawType)
+		} else {
+			pbState, err = v1.ProtobufBeaconState(rawType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if pbState == nil {
+			return nil, errors.New("nil state")
+		}
+		valEntries := pbState.Validators
+		pbState.Validators = make([]*ethpb.Validator, 0)
+		rawObj, err := pbState.MarshalSSZ()
+		pbState.Validators = valEntries
+		return rawObj, err
+	case *ethpb.BeaconStateAltair:
+		var pbState *ethpb.BeaconStateAltair
+		var err error
+		if features.Get().EnableNativeState {
+			pbState, err = statenative.ProtobufBeaconStateAltair(rawType)
+		} else {
+			pbState, err = v2.ProtobufBeaconState(rawType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if pbState == nil {
+			return nil, errors.New("nil state")
+		}
+		valEntries := pbState.Validators
+		pbState.Validators = make([]*ethpb.Validator, 0)
+		rawObj, err := pbState.MarshalSSZ()
+		pbState.Validators = valEntries
+		if err != nil {
+			return nil, err
+		}
+		return append(altairKey, rawObj...), nil
+	case *ethpb.BeaconStateBellatrix:
+		var pbState *ethpb.BeaconStateBellatrix
+		var err error
+		if features.Get().EnableNativeState {
+			pbState, err = statenative.ProtobufBeaconStateBellatrix(rawType)
+		} else {
+			pbState, err = v3.ProtobufBeaconState(rawType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if pbState == nil {
+			return nil, errors.New("nil state")
+		}
+		valEntries := pbState.Validators
+		pbState.Validators = make([]*ethpb.Validator, 0)
+		rawObj, err := pbState.MarshalSSZ()
+		pbState.Validators = valEntries
+		if err != nil {
+			return nil, err
+		}
+		return append(bellatrixKey, rawObj...), nil
+	default:
+		return nil, errors.New("invalid state type")
+	}
+}
+
 func (s *Store) storeValidatorEntriesSeparately(ctx context.Context, tx *bolt.Tx, validatorsEntries map[string]*ethpb.Validator) error {
 	valBkt := tx.Bucket(stateValidatorsBucket)
 	for hashStr, validatorEntry := range validatorsEntries {
@@ -397,44 +399,52 @@ func (s *Store) DeleteState(ctx context.Context, blockRoot [32]byte) error {
 		if err != nil {
 			return err
 		}
-		indicesByBucket := createStateIndicesFromStateSlot(ctx, slot)
-		if err := deleteValueForIndices(ctx, indicesByBucket, blockRoot[:], tx); err != nil {
-			return errors.Wrap(err, "could not delete root for DB indices")
-		}
-
 		ok, err := s.isStateValidatorMigrationOver()
 		if err != nil {
 			return err
 		}
-		if ok {
-			// remove the validator entry keys for the corresponding state.
-			idxBkt := tx.Bucket(blockRootValidatorHashesBucket)
-			compressedValidatorHashes := idxBkt.Get(blockRoot[:])
-			err = idxBkt.Delete(blockRoot[:])
-			if err != nil {
-				return err
-			}
+		return s.deleteStateAtSlot(ctx, tx, blockRoot[:], slot, ok)
+	})
+}
 
-			// remove the respective validator entries from the cache.
-			if len(compressedValidatorHashes) == 0 {
-				return errors.Errorf("invalid compressed validator keys length")
-			}
-			validatorHashes, sErr := snappy.Decode(nil, compressedValidatorHashes)
-			if sErr != nil {
-				return errors.Wrap(sErr, "failed to uncompress validator keys")
-			}
-			if len(validatorHashes)%hashLength != 0 {
-				return errors.Errorf("invalid validator keys length: %d", len(validatorHashes))
-			}
-			for i := 0; i < len(validatorHashes); i += hashLength {
-				key := validatorHashes[i : i+hashLength]
-				s.validatorEntryCache.Del(key)
-				validatorEntryCacheDelete.Inc()
-			}
+// deleteStateAtSlot removes the state of the given block root along with its slot index entry and,
+// once the state validator migration is over, its validator entry keys.
+func (s *Store) deleteStateAtSlot(ctx context.Context, tx *bolt.Tx, blockRoot []byte, slot types.Slot, validatorMigrationOver bool) error {
+	indicesByBucket := createStateIndicesFromStateSlot(ctx, slot)
+	if err := deleteValueForIndices(ctx, indicesByBucket, blockRoot, tx); err != nil {
+		return errors.Wrap(err, "could not delete root for DB indices")
+	}
+
+	if validatorMigrationOver {
+		// remove the validator entry keys for the corresponding state.
+		idxBkt := tx.Bucket(blockRootValidatorHashesBucket)
+		compressedValidatorHashes := idxBkt.Get(blockRoot)
+		if err := idxBkt.Delete(blockRoot); err != nil {
+			return err
 		}
 
-		return bkt.Delete(blockRoot[:])
-	})
+		// remove the respective validator entries from the cache.
+		if len(compressedValidatorHashes) == 0 {
+			return errors.Errorf("invalid compressed validator keys length")
+		}
+		validatorHashes, sErr := snappy.Decode(nil, compressedValidatorHashes)
+		if sErr != nil {
+			return errors.Wrap(sErr, "failed to uncompress validator keys")
+		}
+		if len(validatorHashes)%hashLength != 0 {
+			return errors.Errorf("invalid validator keys length: %d", len(validatorHashes))
+		}
+		for i := 0; i < len(validatorHashes); i += hashLength {
+			key := validatorHashes[i : i+hashLength]
+			s.validatorEntryCache.Del(key)
+			validatorEntryCacheDelete.Inc()
+		}
+	}
+
+	if err := deleteStateDiffRelations(ctx, tx, blockRoot); err != nil {
+		return errors.Wrap(err, "could not update state diffs")
+	}
+	return tx.Bucket(stateBucket).Delete(blockRoot)
 }
 
 // DeleteStates by block roots.
@@ -452,13 +462,16 @@ func (s *Store) DeleteStates(ctx context.Context, blockRoots [][32]byte) error {
 }
 
 // unmarshal state from marshaled proto state bytes to versioned state struct type.
-func (s *Store) unmarshalState(_ context.Context, enc []byte, validatorEntries []*ethpb.Validator) (state.BeaconState, error) {
-	var err error
-	enc, err = snappy.Decode(nil, enc)
+func (s *Store) unmarshalState(ctx context.Context, enc []byte, validatorEntries []*ethpb.Validator) (state.BeaconState, error) {
+	dec, err := snappy.Decode(nil, enc)
 	if err != nil {
 		return nil, err
 	}
+	return s.unmarshalDecodedState(ctx, dec, validatorEntries)
+}
 
+// unmarshal state from uncompressed state bytes to versioned state struct type.
+func (s *Store) unmarshalDecodedState(_ context.Context, enc []byte, validatorEntries []*ethpb.Validator) (state.BeaconState, error) {
 	switch {
 	case hasBellatrixKey(enc):
 		// Marshal state bytes to altair beacon state.
@@ -613,7 +626,8 @@ func (s *Store) validatorEntries(ctx context.Context, blockRoot [32]byte) ([]*et
 	return validatorEntries, err
 }
 
-// retrieves and assembles the state information from multiple buckets.
+// retrieves and assembles the uncompressed state information from multiple buckets,
+// applying state diffs to their snapshot.
 func (s *Store) stateBytes(ctx context.Context, blockRoot [32]byte) ([]byte, error) {
 	_, span := trace.StartSpan(ctx, "BeaconDB.stateBytes")
 	defer span.End()
@@ -624,13 +638,15 @@ func (s *Store) stateBytes(ctx context.Context, blockRoot [32]byte) ([]byte, err
 		if len(stBytes) == 0 {
 			return nil
 		}
-		// Due to https://github.com/boltdb/bolt/issues/204, we need to
-		// allocate a byte slice separately in the transaction or there
-		// is the possibility of a panic when accessing that particular
-		// area of memory.
-		dst = make([]byte, len(stBytes))
-		copy(dst, stBytes)
-		return nil
+		// Decoding allocates a byte slice separately in the transaction, which is
+		// needed due to https://github.com/boltdb/bolt/issues/204 as there is the
+		// possibility of a panic when accessing that particular area of memory.
+		dec, err := snappy.Decode(nil, stBytes)
+		if err != nil {
+			return err
+		}
+		dst, err = resolveStateEncoding(tx, dec)
+		return err
 	})
 	return dst, err
 }
@@ -655,8 +671,16 @@ func (s *Store) slotByBlockRoot(ctx context.Context, tx *bolt.Tx, blockRoot []by
 			if enc == nil {
 				return 0, errors.New("state enc can't be nil")
 			}
+			dec, err := snappy.Decode(nil, enc)
+			if err != nil {
+				return 0, err
+			}
+			dec, err = resolveStateEncoding(tx, dec)
+			if err != nil {
+				return 0, err
+			}
 			// no need to construct the validator entries as it is not used here.
-			s, err := s.unmarshalState(ctx, enc, nil)
+			s, err := s.unmarshalDecodedState(ctx, dec, nil)
 			if err != nil {
 				return 0, err
 			}
@@ -0,0 +1,275 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// archivedStatesPerSnapshot is the number of archived states sharing a full snapshot: the
+	// snapshot itself followed by the states stored as a diff against it.
+	archivedStatesPerSnapshot = 32
+	// stateDiffMergeGap is the largest number of unchanged bytes between two changed byte ranges
+	// for which the ranges are merged into a single patch, to limit the per-patch overhead.
+	stateDiffMergeGap = 8
+)
+
+var errMissingStateSnapshot = errors.New("could not find the snapshot of a state diff")
+
+// SaveArchivedState stores a finalized state at an archived point. With state diffs enabled, the
+// state is stored as a diff against the most recent full snapshot state, and every
+// archivedStatesPerSnapshot archived states a new full snapshot is stored instead.
+func (s *Store) SaveArchivedState(ctx context.Context, st state.ReadOnlyBeaconState, blockRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveArchivedState")
+	defer span.End()
+	if !features.Get().EnableStateDiffs {
+		return s.SaveState(ctx, st, blockRoot)
+	}
+	// State diffs are computed on encodings stripped of their validator entries.
+	ok, err := s.isStateValidatorMigrationOver()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return s.SaveState(ctx, st, blockRoot)
+	}
+	if st == nil || st.IsNil() {
+		return errors.New("nil state")
+	}
+	validatorKeys, validatorsEntries, err := getValidators([]state.ReadOnlyBeaconState{st})
+	if err != nil {
+		return err
+	}
+	enc, err := marshalStateWithoutValidators(st)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(stateBucket).Get(blockRoot[:]) != nil {
+			// States are deterministic, an archived state never needs to be stored twice.
+			return nil
+		}
+		indicesByBucket := createStateIndicesFromStateSlot(ctx, st.Slot())
+		if err := updateValueForIndices(ctx, indicesByBucket, blockRoot[:], tx); err != nil {
+			return errors.Wrap(err, "could not update DB indices")
+		}
+		if err := saveArchivedStateEncoding(ctx, tx, blockRoot[:], enc); err != nil {
+			return err
+		}
+		if err := tx.Bucket(blockRootValidatorHashesBucket).Put(blockRoot[:], validatorKeys[0]); err != nil {
+			return err
+		}
+		return s.storeValidatorEntriesSeparately(ctx, tx, validatorsEntries)
+	})
+}
+
+// saveArchivedStateEncoding stores the uncompressed state encoding as a diff against the last
+// snapshot, or as a new snapshot once the last snapshot has enough dependent diffs.
+func saveArchivedStateEncoding(ctx context.Context, tx *bolt.Tx, blockRoot, enc []byte) error {
+	bkt := tx.Bucket(stateBucket)
+	metaBkt := tx.Bucket(chainMetadataBucket)
+	snapshotRoot := bytesutil.SafeCopyBytes(metaBkt.Get(lastStateSnapshotKey))
+	if snapshotRoot != nil {
+		snapshot, err := stateSnapshotEncoding(tx, snapshotRoot)
+		if err != nil {
+			return err
+		}
+		dependents, err := splitRoots(tx.Bucket(stateDiffDependentsBucket).Get(snapshotRoot))
+		if err != nil {
+			return err
+		}
+		if snapshot != nil && len(dependents) < archivedStatesPerSnapshot-1 {
+			return saveStateDiff(ctx, tx, snapshotRoot, snapshot, blockRoot, enc)
+		}
+	}
+	if err := bkt.Put(blockRoot, snappy.Encode(nil, enc)); err != nil {
+		return err
+	}
+	return metaBkt.Put(lastStateSnapshotKey, blockRoot)
+}
+
+// stateSnapshotEncoding returns the uncompressed encoding of a snapshot state, or nil if the
+// state does not exist or is itself a diff.
+func stateSnapshotEncoding(tx *bolt.Tx, blockRoot []byte) ([]byte, error) {
+	enc := tx.Bucket(stateBucket).Get(blockRoot)
+	if enc == nil {
+		return nil, nil
+	}
+	dec, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, err
+	}
+	if hasStateDiffKey(dec) {
+		return nil, nil
+	}
+	return dec, nil
+}
+
+// saveStateDiff stores the encoding of a state as a diff against the encoding of a snapshot state.
+func saveStateDiff(ctx context.Context, tx *bolt.Tx, snapshotRoot, snapshot, blockRoot, enc []byte) error {
+	diff, err := proto.Marshal(computeStateDiff(snapshotRoot, snapshot, enc))
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(stateBucket).Put(blockRoot, snappy.Encode(nil, append(stateDiffKey, diff...))); err != nil {
+		return err
+	}
+	dependentsByBucket := map[string][]byte{string(stateDiffDependentsBucket): snapshotRoot}
+	return updateValueForIndices(ctx, dependentsByBucket, blockRoot, tx)
+}
+
+// resolveStateEncoding returns the uncompressed encoding of a state, applying it to its snapshot
+// if the encoding is a state diff.
+func resolveStateEncoding(tx *bolt.Tx, dec []byte) ([]byte, error) {
+	if !hasStateDiffKey(dec) {
+		return dec, nil
+	}
+	diff, err := unmarshalStateDiff(dec)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := stateSnapshotEncoding(tx, diff.BaseRoot)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, errors.Wrapf(errMissingStateSnapshot, "no snapshot with blockroot=%#x", diff.BaseRoot)
+	}
+	return applyStateDiff(snapshot, diff)
+}
+
+// deleteStateDiffRelations keeps the archived states consistent before the state of the given
+// block root is deleted. A diff is removed from the dependents of its snapshot, while the dependents
+// of a snapshot are re-based onto the first of them, which becomes a full snapshot.
+func deleteStateDiffRelations(ctx context.Context, tx *bolt.Tx, blockRoot []byte) error {
+	// The root may point into the db memory which is remapped as the transaction writes.
+	blockRoot = bytesutil.SafeCopyBytes(blockRoot)
+	bkt := tx.Bucket(stateBucket)
+	enc := bkt.Get(blockRoot)
+	if enc == nil {
+		return nil
+	}
+	dec, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return err
+	}
+	if hasStateDiffKey(dec) {
+		diff, err := unmarshalStateDiff(dec)
+		if err != nil {
+			return err
+		}
+		dependentsByBucket := map[string][]byte{string(stateDiffDependentsBucket): diff.BaseRoot}
+		return deleteValueForIndices(ctx, dependentsByBucket, blockRoot, tx)
+	}
+
+	depBkt := tx.Bucket(stateDiffDependentsBucket)
+	dependents, err := splitRoots(depBkt.Get(blockRoot))
+	if err != nil {
+		return err
+	}
+	if len(dependents) == 0 {
+		return nil
+	}
+	// Resolve all the dependents before any of them is rewritten.
+	resolved := make([][]byte, len(dependents))
+	for i, root := range dependents {
+		dependentDec, err := snappy.Decode(nil, bkt.Get(root[:]))
+		if err != nil {
+			return err
+		}
+		diff, err := unmarshalStateDiff(dependentDec)
+		if err != nil {
+			return err
+		}
+		resolved[i], err = applyStateDiff(dec, diff)
+		if err != nil {
+			return err
+		}
+	}
+	if err := depBkt.Delete(blockRoot); err != nil {
+		return err
+	}
+	newSnapshotRoot := dependents[0][:]
+	if err := bkt.Put(newSnapshotRoot, snappy.Encode(nil, resolved[0])); err != nil {
+		return err
+	}
+	for i := 1; i < len(dependents); i++ {
+		if err := saveStateDiff(ctx, tx, newSnapshotRoot, resolved[0], dependents[i][:], resolved[i]); err != nil {
+			return err
+		}
+	}
+	metaBkt := tx.Bucket(chainMetadataBucket)
+	if bytes.Equal(metaBkt.Get(lastStateSnapshotKey), blockRoot) {
+		return metaBkt.Put(lastStateSnapshotKey, newSnapshotRoot)
+	}
+	return nil
+}
+
+// computeStateDiff returns the byte ranges of the target encoding which differ from the
+// snapshot encoding. Ranges separated by a few unchanged bytes are merged into a single patch.
+func computeStateDiff(snapshotRoot, snapshot, target []byte) *ethpb.StateDiff {
+	diff := &ethpb.StateDiff{
+		BaseRoot: bytesutil.SafeCopyBytes(snapshotRoot),
+		Length:   uint64(len(target)),
+	}
+	start, last := -1, -1
+	for i := 0; i < len(target); i++ {
+		// Bytes beyond the snapshot encoding are compared to the zeroed bytes a diff is applied to.
+		var b byte
+		if i < len(snapshot) {
+			b = snapshot[i]
+		}
+		if b == target[i] {
+			continue
+		}
+		if start >= 0 && i-last > stateDiffMergeGap {
+			diff.Offsets = append(diff.Offsets, uint64(start))
+			diff.Patches = append(diff.Patches, target[start:last+1])
+			start = -1
+		}
+		if start < 0 {
+			start = i
+		}
+		last = i
+	}
+	if start >= 0 {
+		diff.Offsets = append(diff.Offsets, uint64(start))
+		diff.Patches = append(diff.Patches, target[start:last+1])
+	}
+	return diff
+}
+
+// applyStateDiff returns the encoding obtained by patching the snapshot encoding with the diff.
+func applyStateDiff(snapshot []byte, diff *ethpb.StateDiff) ([]byte, error) {
+	if len(diff.Offsets) != len(diff.Patches) {
+		return nil, errors.Errorf("state diff has %d offsets for %d patches", len(diff.Offsets), len(diff.Patches))
+	}
+	enc := make([]byte, diff.Length)
+	copy(enc, snapshot)
+	for i, offset := range diff.Offsets {
+		patch := diff.Patches[i]
+		if offset+uint64(len(patch)) > diff.Length {
+			return nil, errors.Errorf("state diff patch at offset %d exceeds length %d", offset, diff.Length)
+		}
+		copy(enc[offset:], patch)
+	}
+	return enc, nil
+}
+
+func unmarshalStateDiff(dec []byte) (*ethpb.StateDiff, error) {
+	diff := &ethpb.StateDiff{}
+	if err := proto.Unmarshal(dec[len(stateDiffKey):], diff); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal state diff")
+	}
+	return diff, nil
+}
@@ -0,0 +1,293 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/features"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStateDiff_ComputeApply(t *testing.T) {
+	snapshot := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	tests := []struct {
+		name    string
+		target  []byte
+		patches int
+	}{
+		{
+			name:    "identical",
+			target:  snapshot,
+			patches: 0,
+		},
+		{
+			name:    "close changes are merged",
+			target:  []byte{1, 0, 3, 4, 0, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+			patches: 1,
+		},
+		{
+			name:    "distant changes",
+			target:  []byte{0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 0},
+			patches: 2,
+		},
+		{
+			name:    "shorter",
+			target:  snapshot[:10],
+			patches: 0,
+		},
+		{
+			name:    "longer",
+			target:  append(append([]byte{}, snapshot...), 0, 0, 21, 22),
+			patches: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := computeStateDiff([]byte{'A'}, snapshot, tt.target)
+			assert.Equal(t, tt.patches, len(diff.Patches))
+			enc, err := applyStateDiff(snapshot, diff)
+			require.NoError(t, err)
+			assert.DeepEqual(t, tt.target, enc)
+		})
+	}
+}
+
+func TestStateDiff_ApplyInvalid(t *testing.T) {
+	_, err := applyStateDiff([]byte{1, 2}, &ethpb.StateDiff{Length: 2, Offsets: []uint64{1}})
+	assert.ErrorContains(t, "1 offsets for 0 patches", err)
+	_, err = applyStateDiff([]byte{1, 2}, &ethpb.StateDiff{Length: 2, Offsets: []uint64{1}, Patches: [][]byte{{3, 4}}})
+	assert.ErrorContains(t, "exceeds length", err)
+}
+
+func TestStore_SaveArchivedState_Disabled(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(1))
+	r := [32]byte{'A'}
+
+	require.NoError(t, db.SaveArchivedState(ctx, st, r))
+	assert.Equal(t, false, isStateDiff(t, db, r))
+	savedS, err := db.State(ctx, r)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, st.InnerStateUnsafe(), savedS.InnerStateUnsafe())
+}
+
+func TestStore_SaveArchivedState_SnapshotsAndDiffs(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableHistoricalSpaceRepresentation: true,
+		EnableStateDiffs:                    true,
+	})
+	defer resetCfg()
+	db := setupDB(t)
+	ctx := context.Background()
+
+	states, roots := archivedStates(t, archivedStatesPerSnapshot+1)
+	for i := range states {
+		require.NoError(t, db.SaveArchivedState(ctx, states[i], roots[i]))
+	}
+	// Saving an archived state again leaves it untouched.
+	require.NoError(t, db.SaveArchivedState(ctx, states[1], roots[1]))
+
+	for i := range states {
+		// Every archivedStatesPerSnapshot-th state is a full snapshot.
+		assert.Equal(t, i%archivedStatesPerSnapshot != 0, isStateDiff(t, db, roots[i]))
+		assert.Equal(t, true, db.HasState(ctx, roots[i]))
+		savedS, err := db.State(ctx, roots[i])
+		require.NoError(t, err)
+		require.DeepSSZEqual(t, states[i].InnerStateUnsafe(), savedS.InnerStateUnsafe())
+	}
+	highest, err := db.HighestSlotStatesBelow(ctx, states[2].Slot()+1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(highest))
+	assert.Equal(t, states[2].Slot(), highest[0].Slot())
+}
+
+func TestStore_DeleteState_RebasesStateDiffs(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableHistoricalSpaceRepresentation: true,
+		EnableStateDiffs:                    true,
+	})
+	defer resetCfg()
+	db := setupDB(t)
+	ctx := context.Background()
+
+	states, roots := archivedStates(t, 4)
+	for i := range states {
+		require.NoError(t, db.SaveArchivedState(ctx, states[i], roots[i]))
+	}
+	// Deleting a diff keeps the other diffs of the snapshot.
+	require.NoError(t, db.DeleteState(ctx, roots[2]))
+	assert.Equal(t, false, db.HasState(ctx, roots[2]))
+
+	// Deleting the snapshot turns its first remaining diff into a snapshot.
+	require.NoError(t, db.DeleteState(ctx, roots[0]))
+	assert.Equal(t, false, db.HasState(ctx, roots[0]))
+	assert.Equal(t, false, isStateDiff(t, db, roots[1]))
+	assert.Equal(t, true, isStateDiff(t, db, roots[3]))
+	for _, i := range []int{1, 3} {
+		savedS, err := db.State(ctx, roots[i])
+		require.NoError(t, err)
+		require.DeepSSZEqual(t, states[i].InnerStateUnsafe(), savedS.InnerStateUnsafe())
+	}
+
+	// New archived states are diffs against the new snapshot.
+	st, r := archivedState(t, 5)
+	require.NoError(t, db.SaveArchivedState(ctx, st, r))
+	assert.Equal(t, true, isStateDiff(t, db, r))
+	savedS, err := db.State(ctx, r)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, st.InnerStateUnsafe(), savedS.InnerStateUnsafe())
+}
+
+func TestStore_SaveState_OverwritesStateDiffs(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableHistoricalSpaceRepresentation: true,
+		EnableStateDiffs:                    true,
+	})
+	defer resetCfg()
+	db := setupDB(t)
+	ctx := context.Background()
+
+	states, roots := archivedStates(t, 4)
+	for i := range states {
+		require.NoError(t, db.SaveArchivedState(ctx, states[i], roots[i]))
+	}
+	// Overwriting a diff removes it from the dependents of its snapshot.
+	require.NoError(t, db.SaveState(ctx, states[2], roots[2]))
+	assert.Equal(t, false, isStateDiff(t, db, roots[2]))
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		dependents, err := splitRoots(tx.Bucket(stateDiffDependentsBucket).Get(roots[0][:]))
+		require.NoError(t, err)
+		assert.DeepEqual(t, [][32]byte{roots[1], roots[3]}, dependents)
+		return nil
+	}))
+
+	// Overwriting the snapshot re-bases its remaining diffs first.
+	require.NoError(t, db.SaveState(ctx, states[0], roots[0]))
+	assert.Equal(t, false, isStateDiff(t, db, roots[0]))
+	assert.Equal(t, false, isStateDiff(t, db, roots[1]))
+	assert.Equal(t, true, isStateDiff(t, db, roots[3]))
+	for i := range states {
+		savedS, err := db.State(ctx, roots[i])
+		require.NoError(t, err)
+		require.DeepSSZEqual(t, states[i].InnerStateUnsafe(), savedS.InnerStateUnsafe())
+	}
+}
+
+func TestMigrateStateDiffs(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableHistoricalSpaceRepresentation: true,
+	})
+	db := setupDB(t)
+	ctx := context.Background()
+
+	states, roots := archivedStates(t, 4)
+	genesis := util.NewBeaconBlock()
+	roots[0] = saveTestBlock(t, db, genesis)
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, roots[0]))
+	for i := range states {
+		require.NoError(t, db.SaveState(ctx, states[i], roots[i]))
+	}
+	finalized := util.NewBeaconBlock()
+	finalized.Block.Slot = states[2].Slot()
+	finalized.Block.ParentRoot = roots[0][:]
+	finalizedRoot := saveTestBlock(t, db, finalized)
+	require.NoError(t, db.SaveState(ctx, states[2], finalizedRoot))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: finalizedRoot[:]}))
+
+	// Nothing is migrated without the feature.
+	require.NoError(t, migrateStateDiffs(ctx, db.db))
+	for _, r := range roots {
+		assert.Equal(t, false, isStateDiff(t, db, r))
+	}
+
+	resetCfg()
+	resetCfg = features.InitWithReset(&features.Flags{
+		EnableHistoricalSpaceRepresentation: true,
+		EnableStateDiffs:                    true,
+	})
+	defer resetCfg()
+	require.NoError(t, migrateStateDiffs(ctx, db.db))
+	completed, err := db.CompletedMigrations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, containsString(completed, string(migrationStateDiffsKey)))
+
+	// The genesis state is kept in full, the first migrated state becomes the snapshot
+	// and the states beyond the finalized slot are left untouched.
+	assert.Equal(t, false, isStateDiff(t, db, roots[0]))
+	assert.Equal(t, false, isStateDiff(t, db, roots[1]))
+	assert.Equal(t, true, isStateDiff(t, db, roots[2]))
+	assert.Equal(t, true, isStateDiff(t, db, finalizedRoot))
+	assert.Equal(t, false, isStateDiff(t, db, roots[3]))
+	for i := range states {
+		savedS, err := db.State(ctx, roots[i])
+		require.NoError(t, err)
+		require.DeepSSZEqual(t, states[i].InnerStateUnsafe(), savedS.InnerStateUnsafe())
+	}
+}
+
+// archivedStates returns states one epoch apart, differing in their balances and block roots.
+func archivedStates(t *testing.T, count int) ([]state.BeaconState, [][32]byte) {
+	states := make([]state.BeaconState, count)
+	roots := make([][32]byte, count)
+	for i := 0; i < count; i++ {
+		states[i], roots[i] = archivedState(t, i)
+	}
+	return states, roots
+}
+
+func archivedState(t *testing.T, i int) (state.BeaconState, [32]byte) {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	slot := types.Slot(i * 32)
+	require.NoError(t, st.SetSlot(slot))
+	require.NoError(t, st.SetValidators(validators(8)))
+	balances := make([]uint64, 8)
+	for j := range balances {
+		balances[j] = 32_000_000_000 + uint64(i*j)
+	}
+	require.NoError(t, st.SetBalances(balances))
+	require.NoError(t, st.UpdateBlockRootAtIndex(uint64(slot)%uint64(len(st.BlockRoots())), [32]byte{byte(i + 1)}))
+	return st, [32]byte{'A', byte(i)}
+}
+
+func saveTestBlock(t *testing.T, db *Store, b *ethpb.SignedBeaconBlock) [32]byte {
+	wsb, err := wrapper.WrappedSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(context.Background(), wsb))
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	return root
+}
+
+func isStateDiff(t *testing.T, db *Store, blockRoot [32]byte) bool {
+	var diff bool
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		dec, err := snappy.Decode(nil, tx.Bucket(stateBucket).Get(blockRoot[:]))
+		if err != nil {
+			return err
+		}
+		diff = hasStateDiffKey(dec)
+		return nil
+	}))
+	return diff
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SyncCommittee returns the sync committee stored for the given sync committee period,
+// or nil if no committee was recorded for that period.
+func (s *Store) SyncCommittee(ctx context.Context, period uint64) (*ethpb.SyncCommittee, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SyncCommittee")
+	defer span.End()
+	var committee *ethpb.SyncCommittee
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(syncCommitteeBucket).Get(bytesutil.Uint64ToBytesBigEndian(period))
+		if enc == nil {
+			return nil
+		}
+		committee = &ethpb.SyncCommittee{}
+		return decode(ctx, enc, committee)
+	})
+	return committee, err
+}
+
+// HasSyncCommittee checks if a sync committee is stored for the given sync committee period.
+func (s *Store) HasSyncCommittee(ctx context.Context, period uint64) bool {
+	_, span := trace.StartSpan(ctx, "BeaconDB.HasSyncCommittee")
+	defer span.End()
+	var exists bool
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		exists = tx.Bucket(syncCommitteeBucket).Get(bytesutil.Uint64ToBytesBigEndian(period)) != nil
+		return nil
+	}); err != nil { // This view never returns an error, but we'll handle anyway for sanity.
+		panic(err)
+	}
+	return exists
+}
+
+// SaveSyncCommittee stores the sync committee of the given sync committee period.
+func (s *Store) SaveSyncCommittee(ctx context.Context, period uint64, committee *ethpb.SyncCommittee) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveSyncCommittee")
+	defer span.End()
+	enc, err := encode(ctx, committee)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(syncCommitteeBucket).Put(bytesutil.Uint64ToBytesBigEndian(period), enc)
+	})
+}
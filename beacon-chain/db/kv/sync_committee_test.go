@@ -0,0 +1,32 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestStore_SyncCommittee_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	committee := &ethpb.SyncCommittee{
+		Pubkeys:         [][]byte{bytesutil.PadTo([]byte{'a'}, 48), bytesutil.PadTo([]byte{'b'}, 48)},
+		AggregatePubkey: bytesutil.PadTo([]byte{'c'}, 48),
+	}
+
+	assert.Equal(t, false, db.HasSyncCommittee(ctx, 3))
+	retrieved, err := db.SyncCommittee(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpb.SyncCommittee)(nil), retrieved)
+
+	require.NoError(t, db.SaveSyncCommittee(ctx, 3, committee))
+	assert.Equal(t, true, db.HasSyncCommittee(ctx, 3))
+	assert.Equal(t, false, db.HasSyncCommittee(ctx, 4))
+	retrieved, err = db.SyncCommittee(ctx, 3)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, committee, retrieved)
+}
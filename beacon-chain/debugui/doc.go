@@ -0,0 +1,5 @@
+// Package debugui defines a minimal, read-only HTML view of the beacon node's
+// runtime data, such as recent blocks, connected peers, the fork choice head and
+// operation pool sizes. It is meant to be served on the monitoring port of local
+// devnet nodes, where deploying an external block explorer is not worth the effort.
+package debugui
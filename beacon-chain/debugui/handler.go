@@ -0,0 +1,318 @@
+package debugui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// DefaultRecentBlocks is the number of most recent slots rendered when the
+// config does not specify one.
+const DefaultRecentBlocks = 32
+
+// Path is the route under which the debug UI is served on the monitoring port.
+const Path = "/debug/ui"
+
+// Config defines the data sources used to render the debug UI. Every field
+// is optional, sections with a missing data source are simply not rendered.
+type Config struct {
+	HeadFetcher         blockchain.HeadFetcher
+	FinalizationFetcher blockchain.FinalizationFetcher
+	BeaconDB            db.ReadOnlyDatabase
+	PeersProvider       p2p.PeersProvider
+	AttestationPool     attestations.Pool
+	ExitPool            voluntaryexits.PoolManager
+	SlashingPool        slashings.PoolManager
+	SyncCommitteePool   synccommittee.Pool
+	RecentBlocks        uint64
+}
+
+// Server renders the debug UI pages.
+type Server struct {
+	cfg *Config
+}
+
+// NewServer returns a debug UI server backed by the provided data sources.
+func NewServer(cfg *Config) *Server {
+	if cfg.RecentBlocks == 0 {
+		cfg.RecentBlocks = DefaultRecentBlocks
+	}
+	return &Server{cfg: cfg}
+}
+
+type headInfo struct {
+	Slot               types.Slot
+	Root               string
+	JustifiedEpoch     types.Epoch
+	JustifiedRoot      string
+	FinalizedEpoch     types.Epoch
+	FinalizedRoot      string
+	HasFinalizationGap bool
+}
+
+type blockInfo struct {
+	Slot          types.Slot
+	Root          string
+	ParentRoot    string
+	ProposerIndex types.ValidatorIndex
+	Version       string
+	Attestations  int
+	Deposits      int
+	Exits         int
+}
+
+type peerInfo struct {
+	ID        string
+	Address   string
+	Direction string
+	HeadSlot  types.Slot
+	Finalized types.Epoch
+}
+
+type poolInfo struct {
+	AggregatedAttestations   int
+	UnaggregatedAttestations int
+	ForkchoiceAttestations   int
+	VoluntaryExits           int
+	AttesterSlashings        int
+	ProposerSlashings        int
+	SyncCommitteeMessages    int
+	SyncContributions        int
+}
+
+type pageData struct {
+	Version string
+	Head    *headInfo
+	Blocks  []*blockInfo
+	Peers   []*peerInfo
+	Pools   *poolInfo
+	Errors  []string
+}
+
+// Handler renders the debug UI page using the data sources available at request time.
+func (s *Server) Handler(w http.ResponseWriter, r *http.Request) {
+	data := s.collect(r.Context())
+	buf := new(bytes.Buffer)
+	if err := pageTemplate.Execute(buf, data); err != nil {
+		log.WithError(err).Error("Failed to render debug UI page")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.WithError(err).Error("Failed to write debug UI page")
+	}
+}
+
+func (s *Server) collect(ctx context.Context) *pageData {
+	data := &pageData{Version: version.Version()}
+	if s.cfg.HeadFetcher != nil {
+		h, err := s.head(ctx)
+		if err != nil {
+			data.Errors = append(data.Errors, err.Error())
+		}
+		data.Head = h
+	}
+	if s.cfg.BeaconDB != nil && s.cfg.HeadFetcher != nil {
+		blks, err := s.recentBlocks(ctx)
+		if err != nil {
+			data.Errors = append(data.Errors, err.Error())
+		}
+		data.Blocks = blks
+	}
+	if s.cfg.PeersProvider != nil {
+		data.Peers = s.peers()
+	}
+	data.Pools = s.pools(ctx)
+	return data
+}
+
+func (s *Server) head(ctx context.Context) (*headInfo, error) {
+	h := &headInfo{Slot: s.cfg.HeadFetcher.HeadSlot()}
+	root, err := s.cfg.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return h, errors.Wrap(err, "could not get head root")
+	}
+	h.Root = fmt.Sprintf("%#x", root)
+	if s.cfg.FinalizationFetcher == nil {
+		return h, nil
+	}
+	if cp := s.cfg.FinalizationFetcher.CurrentJustifiedCheckpt(); cp != nil {
+		h.JustifiedEpoch = cp.Epoch
+		h.JustifiedRoot = fmt.Sprintf("%#x", cp.Root)
+	}
+	if cp := s.cfg.FinalizationFetcher.FinalizedCheckpt(); cp != nil {
+		h.FinalizedEpoch = cp.Epoch
+		h.FinalizedRoot = fmt.Sprintf("%#x", cp.Root)
+		// Flag long periods of non-finality which are the most common devnet failure.
+		h.HasFinalizationGap = slots.ToEpoch(h.Slot) > cp.Epoch+2
+	}
+	return h, nil
+}
+
+func (s *Server) recentBlocks(ctx context.Context) ([]*blockInfo, error) {
+	end := s.cfg.HeadFetcher.HeadSlot()
+	start := types.Slot(0)
+	if uint64(end) > s.cfg.RecentBlocks {
+		start = end - types.Slot(s.cfg.RecentBlocks)
+	}
+	f := filters.NewFilter().SetStartSlot(start).SetEndSlot(end)
+	blks, roots, err := s.cfg.BeaconDB.Blocks(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve recent blocks")
+	}
+	infos := make([]*blockInfo, 0, len(blks))
+	for i, b := range blks {
+		if b == nil || b.IsNil() {
+			continue
+		}
+		blk := b.Block()
+		body := blk.Body()
+		infos = append(infos, &blockInfo{
+			Slot:          blk.Slot(),
+			Root:          fmt.Sprintf("%#x", roots[i]),
+			ParentRoot:    fmt.Sprintf("%#x", blk.ParentRoot()),
+			ProposerIndex: blk.ProposerIndex(),
+			Version:       version.String(b.Version()),
+			Attestations:  len(body.Attestations()),
+			Deposits:      len(body.Deposits()),
+			Exits:         len(body.VoluntaryExits()),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Slot > infos[j].Slot
+	})
+	return infos, nil
+}
+
+func (s *Server) peers() []*peerInfo {
+	status := s.cfg.PeersProvider.Peers()
+	connected := status.Connected()
+	infos := make([]*peerInfo, 0, len(connected))
+	for _, pid := range connected {
+		info := &peerInfo{ID: pid.String()}
+		if addr, err := status.Address(pid); err == nil && addr != nil {
+			info.Address = addr.String()
+		}
+		if dir, err := status.Direction(pid); err == nil {
+			info.Direction = dir.String()
+		}
+		if cs, err := status.ChainState(pid); err == nil && cs != nil {
+			info.HeadSlot = cs.HeadSlot
+			info.Finalized = cs.FinalizedEpoch
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+func (s *Server) pools(ctx context.Context) *poolInfo {
+	p := &poolInfo{}
+	if s.cfg.AttestationPool != nil {
+		p.AggregatedAttestations = s.cfg.AttestationPool.AggregatedAttestationCount()
+		p.UnaggregatedAttestations = s.cfg.AttestationPool.UnaggregatedAttestationCount()
+		p.ForkchoiceAttestations = s.cfg.AttestationPool.ForkchoiceAttestationCount()
+	}
+	if s.cfg.HeadFetcher != nil {
+		headSlot := s.cfg.HeadFetcher.HeadSlot()
+		if s.cfg.SyncCommitteePool != nil {
+			if msgs, err := s.cfg.SyncCommitteePool.SyncCommitteeMessages(headSlot); err == nil {
+				p.SyncCommitteeMessages = len(msgs)
+			}
+			if contributions, err := s.cfg.SyncCommitteePool.SyncCommitteeContributions(headSlot); err == nil {
+				p.SyncContributions = len(contributions)
+			}
+		}
+		// Pending exits and slashings are filtered against the head state, so only
+		// render them when it is cheaply available.
+		if s.cfg.ExitPool != nil || s.cfg.SlashingPool != nil {
+			st, err := s.cfg.HeadFetcher.HeadState(ctx)
+			if err == nil && st != nil && !st.IsNil() {
+				if s.cfg.ExitPool != nil {
+					p.VoluntaryExits = len(s.cfg.ExitPool.PendingExits(st, headSlot, true /* no limit */))
+				}
+				if s.cfg.SlashingPool != nil {
+					p.AttesterSlashings = len(s.cfg.SlashingPool.PendingAttesterSlashings(ctx, st, true /* no limit */))
+					p.ProposerSlashings = len(s.cfg.SlashingPool.PendingProposerSlashings(ctx, st, true /* no limit */))
+				}
+			}
+		}
+	}
+	return p
+}
+
+var pageTemplate = template.Must(template.New("debugui").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="12">
+<title>Prysm beacon node</title>
+<style>
+body { font-family: monospace; margin: 1em 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 2px 8px; text-align: left; }
+.warn { color: #b00; }
+</style>
+</head>
+<body>
+<h1>Prysm beacon node</h1>
+<p>{{.Version}}</p>
+{{range .Errors}}<p class="warn">{{.}}</p>
+{{end}}
+{{with .Head}}
+<h2>Fork choice head</h2>
+<table>
+<tr><th>Head slot</th><td>{{.Slot}}</td></tr>
+<tr><th>Head root</th><td>{{.Root}}</td></tr>
+<tr><th>Justified</th><td>{{.JustifiedEpoch}} {{.JustifiedRoot}}</td></tr>
+<tr><th>Finalized</th><td{{if .HasFinalizationGap}} class="warn"{{end}}>{{.FinalizedEpoch}} {{.FinalizedRoot}}</td></tr>
+</table>
+{{end}}
+{{with .Pools}}
+<h2>Operation pools</h2>
+<table>
+<tr><th>Aggregated attestations</th><td>{{.AggregatedAttestations}}</td></tr>
+<tr><th>Unaggregated attestations</th><td>{{.UnaggregatedAttestations}}</td></tr>
+<tr><th>Fork choice attestations</th><td>{{.ForkchoiceAttestations}}</td></tr>
+<tr><th>Voluntary exits</th><td>{{.VoluntaryExits}}</td></tr>
+<tr><th>Attester slashings</th><td>{{.AttesterSlashings}}</td></tr>
+<tr><th>Proposer slashings</th><td>{{.ProposerSlashings}}</td></tr>
+<tr><th>Sync committee messages (head slot)</th><td>{{.SyncCommitteeMessages}}</td></tr>
+<tr><th>Sync contributions (head slot)</th><td>{{.SyncContributions}}</td></tr>
+</table>
+{{end}}
+<h2>Recent blocks</h2>
+<table>
+<tr><th>Slot</th><th>Root</th><th>Parent</th><th>Proposer</th><th>Fork</th><th>Atts</th><th>Deposits</th><th>Exits</th></tr>
+{{range .Blocks}}<tr><td>{{.Slot}}</td><td>{{.Root}}</td><td>{{.ParentRoot}}</td><td>{{.ProposerIndex}}</td><td>{{.Version}}</td><td>{{.Attestations}}</td><td>{{.Deposits}}</td><td>{{.Exits}}</td></tr>
+{{end}}
+</table>
+<h2>Peers ({{len .Peers}})</h2>
+<table>
+<tr><th>Peer</th><th>Address</th><th>Direction</th><th>Head slot</th><th>Finalized epoch</th></tr>
+{{range .Peers}}<tr><td>{{.ID}}</td><td>{{.Address}}</td><td>{{.Direction}}</td><td>{{.HeadSlot}}</td><td>{{.Finalized}}</td></tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
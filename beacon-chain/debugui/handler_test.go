@@ -0,0 +1,112 @@
+package debugui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestServer_Handler(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+
+	var headRoot [32]byte
+	for i := types.Slot(1); i <= 4; i++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = i
+		b.Block.ProposerIndex = types.ValidatorIndex(i + 100)
+		wsb := util.SaveBlock(t, ctx, beaconDB, b)
+		r, err := wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		headRoot = r
+	}
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(4))
+
+	pool := attestations.NewPool()
+	att := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b1101},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: headRoot[:],
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+	require.NoError(t, pool.SaveAggregatedAttestation(att))
+
+	chain := &mock.ChainService{
+		State:                      st,
+		Root:                       headRoot[:],
+		FinalizedCheckPoint:        &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+		CurrentJustifiedCheckPoint: &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+	}
+	s := NewServer(&Config{
+		HeadFetcher:         chain,
+		FinalizationFetcher: chain,
+		BeaconDB:            beaconDB,
+		PeersProvider:       &mockp2p.MockPeersProvider{},
+		AttestationPool:     pool,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, Path, nil)
+	w := httptest.NewRecorder()
+	s.Handler(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+
+	body := w.Body.String()
+	assert.Equal(t, true, strings.Contains(body, fmt.Sprintf("%#x", headRoot)))
+	assert.Equal(t, true, strings.Contains(body, "<td>104</td>"))
+	assert.Equal(t, true, strings.Contains(body, "Peers (2)"))
+	assert.Equal(t, true, strings.Contains(body, "/ip4/213.202.254.180/tcp/13000"))
+	assert.Equal(t, true, strings.Contains(body, "<tr><th>Aggregated attestations</th><td>1</td></tr>"))
+}
+
+func TestServer_RecentBlocksLimit(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	for i := types.Slot(1); i <= 10; i++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = i
+		util.SaveBlock(t, ctx, beaconDB, b)
+	}
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(10))
+
+	s := NewServer(&Config{
+		HeadFetcher:  &mock.ChainService{State: st},
+		BeaconDB:     beaconDB,
+		RecentBlocks: 3,
+	})
+	blks, err := s.recentBlocks(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(blks))
+	// Most recent blocks are rendered first.
+	assert.Equal(t, types.Slot(10), blks[0].Slot)
+	assert.Equal(t, types.Slot(7), blks[3].Slot)
+}
+
+func TestServer_NoDataSources(t *testing.T) {
+	s := NewServer(&Config{})
+	w := httptest.NewRecorder()
+	s.Handler(w, httptest.NewRequest(http.MethodGet, Path, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, true, strings.Contains(w.Body.String(), "Peers (0)"))
+}
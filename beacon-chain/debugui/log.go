@@ -0,0 +1,5 @@
+package debugui
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "debugui")
@@ -0,0 +1,217 @@
+// Package lightclient implements a light client server, producing from the sync aggregates of
+// processed blocks the updates Altair light clients need to follow the chain. The best update of
+// every sync committee period, as well as the latest finality and optimistic updates, are kept in
+// memory to be served over the API, and the latter are broadcast to the network.
+package lightclient
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxRequestLightClientUpdates is the maximum number of sync committee periods whose best update
+// can be requested at once, which is also the number of periods the service keeps the best update of.
+const MaxRequestLightClientUpdates = 128
+
+// UpdatesFetcher retrieves the light client updates produced by the service. Returned updates
+// are shared and must not be modified.
+type UpdatesFetcher interface {
+	BestUpdates(startPeriod, count uint64) []*ethpbv2.LightClientUpdateWithVersion
+	LatestFinalityUpdate() (*ethpbv2.LightClientFinalityUpdate, ethpbv2.Version)
+	LatestOptimisticUpdate() (*ethpbv2.LightClientOptimisticUpdate, ethpbv2.Version)
+}
+
+// Config for the light client service.
+type Config struct {
+	StateNotifier statefeed.Notifier
+	BeaconDB      db.ReadOnlyDatabase
+	StateGen      stategen.StateManager
+	TimeFetcher   blockchain.TimeFetcher
+	Broadcaster   p2p.Broadcaster
+}
+
+// Service produces light client updates for every processed block carrying a sync aggregate.
+type Service struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	cfg    *Config
+
+	lock             sync.RWMutex
+	bestUpdates      map[uint64]*update
+	finalityUpdate   *update
+	optimisticUpdate *update
+}
+
+// NewService initializes the light client service.
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg.StateNotifier == nil || cfg.BeaconDB == nil || cfg.StateGen == nil {
+		return nil, errors.New("light client service requires a state notifier, a database and a state generator")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:         ctx,
+		cancel:      cancel,
+		cfg:         cfg,
+		bestUpdates: make(map[uint64]*update),
+	}, nil
+}
+
+// Start listening to processed blocks.
+func (s *Service) Start() {
+	log.Info("Light client server is enabled, producing updates from the sync aggregates of processed blocks")
+	go s.run()
+}
+
+// Stop the service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the service.
+func (_ *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case e := <-stateChannel:
+			if e.Type != statefeed.BlockProcessed {
+				continue
+			}
+			data, ok := e.Data.(*statefeed.BlockProcessedData)
+			if !ok {
+				log.Error("Event feed data is not of type *statefeed.BlockProcessedData")
+				continue
+			}
+			if !data.Verified {
+				continue
+			}
+			if err := s.processBlock(s.ctx, data.SignedBlock); err != nil {
+				log.WithError(err).WithField("slot", data.Slot).Debug("Could not produce light client update")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		}
+	}
+}
+
+// processBlock produces the update whose sync aggregate is the one of the given block, and whose
+// attested header is the header of the block's parent.
+func (s *Service) processBlock(ctx context.Context, blk interfaces.SignedBeaconBlock) error {
+	u, err := s.createUpdate(ctx, blk)
+	if err != nil || u == nil {
+		return err
+	}
+
+	s.lock.Lock()
+	period := slots.SyncCommitteePeriod(slots.ToEpoch(u.data.AttestedHeader.Slot))
+	if best, ok := s.bestUpdates[period]; !ok || isBetterUpdate(u, best) {
+		s.bestUpdates[period] = u
+		s.pruneBestUpdates(period)
+	}
+	var newFinality, newOptimistic bool
+	if u.hasFinality && (s.finalityUpdate == nil || u.data.AttestedHeader.Slot > s.finalityUpdate.data.AttestedHeader.Slot) {
+		// A finality update is only gossiped when it finalizes a more recent header.
+		newFinality = s.finalityUpdate == nil || u.data.FinalizedHeader.Slot > s.finalityUpdate.data.FinalizedHeader.Slot
+		s.finalityUpdate = u
+	}
+	if s.optimisticUpdate == nil || u.data.AttestedHeader.Slot > s.optimisticUpdate.data.AttestedHeader.Slot {
+		s.optimisticUpdate = u
+		newOptimistic = true
+	}
+	s.lock.Unlock()
+
+	// Only updates signed at the current slot are gossiped, so none are broadcast during sync.
+	if s.cfg.Broadcaster == nil || s.cfg.TimeFetcher == nil || s.cfg.TimeFetcher.CurrentSlot() > u.data.SignatureSlot+1 {
+		return nil
+	}
+	if newFinality {
+		s.broadcast(u.finalityUpdate())
+	}
+	if newOptimistic {
+		s.broadcast(u.optimisticUpdate())
+	}
+	return nil
+}
+
+// pruneBestUpdates drops the best updates of the periods too old to be requested.
+// The caller must hold the lock.
+func (s *Service) pruneBestUpdates(latest uint64) {
+	if latest < MaxRequestLightClientUpdates {
+		return
+	}
+	for period := range s.bestUpdates {
+		if period <= latest-MaxRequestLightClientUpdates {
+			delete(s.bestUpdates, period)
+		}
+	}
+}
+
+func (s *Service) broadcast(msg proto.Message) {
+	// Publishing waits for peers on the topic, so it is not done on the block processing routine.
+	go func() {
+		if err := s.cfg.Broadcaster.Broadcast(s.ctx, msg); err != nil {
+			log.WithError(err).Debugf("Could not broadcast %T", msg)
+		}
+	}()
+}
+
+// BestUpdates returns the best update of every period of the requested range the service has
+// produced an update for, in period order.
+func (s *Service) BestUpdates(startPeriod, count uint64) []*ethpbv2.LightClientUpdateWithVersion {
+	if count > MaxRequestLightClientUpdates {
+		count = MaxRequestLightClientUpdates
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	updates := make([]*ethpbv2.LightClientUpdateWithVersion, 0)
+	for period := startPeriod; period < startPeriod+count; period++ {
+		if u, ok := s.bestUpdates[period]; ok {
+			updates = append(updates, &ethpbv2.LightClientUpdateWithVersion{Version: u.version, Data: u.data})
+		}
+	}
+	return updates
+}
+
+// LatestFinalityUpdate returns the finality update with the most recent attested header, or nil
+// if no finality update was produced yet.
+func (s *Service) LatestFinalityUpdate() (*ethpbv2.LightClientFinalityUpdate, ethpbv2.Version) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.finalityUpdate == nil {
+		return nil, 0
+	}
+	return s.finalityUpdate.finalityUpdate(), s.finalityUpdate.version
+}
+
+// LatestOptimisticUpdate returns the optimistic update with the most recent attested header, or nil
+// if no update was produced yet.
+func (s *Service) LatestOptimisticUpdate() (*ethpbv2.LightClientOptimisticUpdate, ethpbv2.Version) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.optimisticUpdate == nil {
+		return nil, 0
+	}
+	return s.optimisticUpdate.optimisticUpdate(), s.optimisticUpdate.version
+}
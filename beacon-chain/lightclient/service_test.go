@@ -0,0 +1,196 @@
+package lightclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestService_ProcessBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	s, err := NewService(ctx, &Config{
+		StateNotifier: &mock.MockStateNotifier{},
+		BeaconDB:      beaconDB,
+		StateGen:      stategen.New(beaconDB),
+	})
+	require.NoError(t, err)
+
+	finalized := util.NewBeaconBlockAltair()
+	finalized.Block.Slot = 1
+	util.SaveBlock(t, ctx, beaconDB, finalized)
+	finalizedRoot, err := finalized.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	// attest saves an attested block and its state at the given slot, finalizing the given root,
+	// and returns a child block carrying a sync aggregate with the given number of participants.
+	attest := func(slot types.Slot, finalizedRoot []byte, participants uint64) *ethpb.SignedBeaconBlockAltair {
+		st, _ := util.DeterministicGenesisStateAltair(t, 64)
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Root: finalizedRoot}))
+		stateRoot, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		attested := util.NewBeaconBlockAltair()
+		attested.Block.Slot = slot
+		attested.Block.StateRoot = stateRoot[:]
+		util.SaveBlock(t, ctx, beaconDB, attested)
+		attestedRoot, err := attested.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveState(ctx, st, attestedRoot))
+
+		bits := bitfield.NewBitvector512()
+		for i := uint64(0); i < participants; i++ {
+			bits.SetBitAt(i, true)
+		}
+		child := util.NewBeaconBlockAltair()
+		child.Block.Slot = slot + 1
+		child.Block.ParentRoot = attestedRoot[:]
+		child.Block.Body.SyncAggregate.SyncCommitteeBits = bits
+		return child
+	}
+	process := func(b *ethpb.SignedBeaconBlockAltair) {
+		wsb, err := wrapper.WrappedSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, s.processBlock(ctx, wsb))
+	}
+
+	// Without participants, no update is produced.
+	process(attest(2, make([]byte, 32), 0))
+	assert.Equal(t, 0, len(s.BestUpdates(0, 1)))
+	finalityUpdate, _ := s.LatestFinalityUpdate()
+	assert.Equal(t, (*ethpbv2.LightClientFinalityUpdate)(nil), finalityUpdate)
+
+	// The genesis checkpoint does not indicate finality.
+	process(attest(4, make([]byte, 32), 360))
+	updates := s.BestUpdates(0, 1)
+	require.Equal(t, 1, len(updates))
+	assert.Equal(t, ethpbv2.Version_ALTAIR, updates[0].Version)
+	assert.Equal(t, types.Slot(4), updates[0].Data.AttestedHeader.Slot)
+	assert.Equal(t, types.Slot(5), updates[0].Data.SignatureSlot)
+	assert.Equal(t, nextSyncCommitteeBranchLength, len(updates[0].Data.NextSyncCommitteeBranch))
+	assert.Equal(t, params.BeaconConfig().SyncCommitteeSize, uint64(len(updates[0].Data.NextSyncCommittee.Pubkeys)))
+	finalityUpdate, _ = s.LatestFinalityUpdate()
+	assert.Equal(t, (*ethpbv2.LightClientFinalityUpdate)(nil), finalityUpdate)
+	optimisticUpdate, ver := s.LatestOptimisticUpdate()
+	require.NotNil(t, optimisticUpdate)
+	assert.Equal(t, ethpbv2.Version_ALTAIR, ver)
+	assert.Equal(t, types.Slot(4), optimisticUpdate.AttestedHeader.Slot)
+
+	// An update indicating finality replaces the best update despite fewer participants.
+	process(attest(6, finalizedRoot[:], 350))
+	updates = s.BestUpdates(0, 1)
+	require.Equal(t, 1, len(updates))
+	assert.Equal(t, types.Slot(6), updates[0].Data.AttestedHeader.Slot)
+	finalityUpdate, _ = s.LatestFinalityUpdate()
+	require.NotNil(t, finalityUpdate)
+	assert.Equal(t, types.Slot(1), finalityUpdate.FinalizedHeader.Slot)
+	assert.Equal(t, finalityBranchLength, len(finalityUpdate.FinalityBranch))
+	_, err = finalityUpdate.MarshalSSZ()
+	require.NoError(t, err)
+
+	// A more recent update with less participation is not the best of its period, but is the latest.
+	process(attest(8, finalizedRoot[:], 345))
+	updates = s.BestUpdates(0, 1)
+	assert.Equal(t, types.Slot(6), updates[0].Data.AttestedHeader.Slot)
+	optimisticUpdate, _ = s.LatestOptimisticUpdate()
+	assert.Equal(t, types.Slot(8), optimisticUpdate.AttestedHeader.Slot)
+	_, err = optimisticUpdate.MarshalSSZ()
+	require.NoError(t, err)
+}
+
+func TestIsBetterUpdate(t *testing.T) {
+	period := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	newUpdate := func(participants uint64, attestedSlot, finalizedSlot types.Slot, hasNextSyncCommittee, hasFinality bool) *update {
+		return &update{
+			participants:         participants,
+			hasNextSyncCommittee: hasNextSyncCommittee,
+			hasFinality:          hasFinality,
+			data: &ethpbv2.LightClientUpdate{
+				AttestedHeader:  &ethpbv1.BeaconBlockHeader{Slot: attestedSlot},
+				FinalizedHeader: &ethpbv1.BeaconBlockHeader{Slot: finalizedSlot},
+				SyncAggregate:   &ethpbv1.SyncAggregate{SyncCommitteeBits: bitfield.NewBitvector512()},
+				SignatureSlot:   attestedSlot + 1,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		new, old *update
+		want     bool
+	}{
+		{
+			name: "supermajority",
+			new:  newUpdate(342, 10, 0, false, false),
+			old:  newUpdate(341, 10, 0, true, true),
+			want: true,
+		},
+		{
+			name: "participation without supermajority",
+			new:  newUpdate(200, 10, 0, false, false),
+			old:  newUpdate(300, 10, 0, true, true),
+			want: false,
+		},
+		{
+			name: "next sync committee",
+			new:  newUpdate(400, 10, 0, true, false),
+			old:  newUpdate(500, 10, 0, false, true),
+			want: true,
+		},
+		{
+			name: "finality",
+			new:  newUpdate(400, 10, 0, true, true),
+			old:  newUpdate(500, 10, 0, true, false),
+			want: true,
+		},
+		{
+			name: "sync committee finality",
+			new:  newUpdate(400, period+10, period, true, true),
+			old:  newUpdate(500, period+10, period-1, true, true),
+			want: true,
+		},
+		{
+			name: "participation beyond supermajority",
+			new:  newUpdate(500, 10, 0, true, true),
+			old:  newUpdate(400, 10, 0, true, true),
+			want: true,
+		},
+		{
+			name: "older attested header",
+			new:  newUpdate(400, 12, 0, true, true),
+			old:  newUpdate(400, 10, 0, true, true),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isBetterUpdate(tt.new, tt.old))
+		})
+	}
+}
+
+func TestService_PruneBestUpdates(t *testing.T) {
+	s := &Service{bestUpdates: make(map[uint64]*update)}
+	for period := uint64(0); period < MaxRequestLightClientUpdates+2; period++ {
+		s.bestUpdates[period] = &update{}
+		s.pruneBestUpdates(period)
+	}
+	assert.Equal(t, MaxRequestLightClientUpdates, len(s.bestUpdates))
+	_, ok := s.bestUpdates[1]
+	assert.Equal(t, false, ok)
+	_, ok = s.bestUpdates[2]
+	assert.Equal(t, true, ok)
+}
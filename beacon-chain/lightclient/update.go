@@ -0,0 +1,325 @@
+package lightclient
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+const (
+	// nextSyncCommitteeBranchLength is the depth of the next sync committee in an Altair state tree.
+	nextSyncCommitteeBranchLength = 5
+	// finalityBranchLength is the depth of the finalized checkpoint root in an Altair state tree.
+	finalityBranchLength = 6
+)
+
+// update is a light client update along with the properties used to rank it.
+type update struct {
+	version              ethpbv2.Version
+	data                 *ethpbv2.LightClientUpdate
+	participants         uint64
+	hasNextSyncCommittee bool
+	hasFinality          bool
+}
+
+func (u *update) finalityUpdate() *ethpbv2.LightClientFinalityUpdate {
+	return &ethpbv2.LightClientFinalityUpdate{
+		AttestedHeader:  u.data.AttestedHeader,
+		FinalizedHeader: u.data.FinalizedHeader,
+		FinalityBranch:  u.data.FinalityBranch,
+		SyncAggregate:   u.data.SyncAggregate,
+		SignatureSlot:   u.data.SignatureSlot,
+	}
+}
+
+func (u *update) optimisticUpdate() *ethpbv2.LightClientOptimisticUpdate {
+	return &ethpbv2.LightClientOptimisticUpdate{
+		AttestedHeader: u.data.AttestedHeader,
+		SyncAggregate:  u.data.SyncAggregate,
+		SignatureSlot:  u.data.SignatureSlot,
+	}
+}
+
+// createUpdate builds the update of the given block's sync aggregate, which attests to the block's
+// parent. Nil is returned when the block does not carry a sync aggregate with enough participants.
+//
+// Spec pseudocode definition:
+//   def create_light_client_update(state: BeaconState,
+//                                  block: SignedBeaconBlock,
+//                                  attested_state: BeaconState,
+//                                  finalized_block: Optional[SignedBeaconBlock]) -> LightClientUpdate:
+//    assert compute_epoch_at_slot(attested_state.slot) >= ALTAIR_FORK_EPOCH
+//    assert sum(block.message.body.sync_aggregate.sync_committee_bits) >= MIN_SYNC_COMMITTEE_PARTICIPANTS
+//
+//    assert state.slot == state.latest_block_header.slot
+//    header = state.latest_block_header.copy()
+//    header.state_root = hash_tree_root(state)
+//    assert hash_tree_root(header) == hash_tree_root(block.message)
+//    update_signature_period = compute_sync_committee_period(compute_epoch_at_slot(block.message.slot))
+//
+//    assert attested_state.slot == attested_state.latest_block_header.slot
+//    attested_header = attested_state.latest_block_header.copy()
+//    attested_header.state_root = hash_tree_root(attested_state)
+//    assert hash_tree_root(attested_header) == block.message.parent_root
+//    update_attested_period = compute_sync_committee_period(compute_epoch_at_slot(attested_header.slot))
+//
+//    # `next_sync_committee` is only useful if the message is signed by the current sync committee
+//    if update_attested_period == update_signature_period:
+//        next_sync_committee = attested_state.next_sync_committee
+//        next_sync_committee_branch = compute_merkle_proof_for_state(attested_state, NEXT_SYNC_COMMITTEE_INDEX)
+//    else:
+//        next_sync_committee = SyncCommittee()
+//        next_sync_committee_branch = [Bytes32() for _ in range(floorlog2(NEXT_SYNC_COMMITTEE_INDEX))]
+//
+//    # Indicate finality whenever possible
+//    if finalized_block is not None:
+//        if finalized_block.message.slot != GENESIS_SLOT:
+//            finalized_header = BeaconBlockHeader(...)
+//            assert hash_tree_root(finalized_header) == attested_state.finalized_checkpoint.root
+//        else:
+//            assert attested_state.finalized_checkpoint.root == Bytes32()
+//            finalized_header = BeaconBlockHeader()
+//        finality_branch = compute_merkle_proof_for_state(attested_state, FINALIZED_ROOT_INDEX)
+//    else:
+//        finalized_header = BeaconBlockHeader()
+//        finality_branch = [Bytes32() for _ in range(floorlog2(FINALIZED_ROOT_INDEX))]
+//
+//    return LightClientUpdate(...)
+func (s *Service) createUpdate(ctx context.Context, blk interfaces.SignedBeaconBlock) (*update, error) {
+	if blk.Version() == version.Phase0 {
+		return nil, nil
+	}
+	agg, err := blk.Block().Body().SyncAggregate()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync aggregate")
+	}
+	participants := agg.SyncCommitteeBits.Count()
+	if participants < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return nil, nil
+	}
+
+	parentRoot := bytesutil.ToBytes32(blk.Block().ParentRoot())
+	attestedBlock, err := s.cfg.BeaconDB.Block(ctx, parentRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attested block")
+	}
+	if err := wrapper.BeaconBlockIsNil(attestedBlock); err != nil {
+		return nil, errors.Wrap(err, "could not get attested block")
+	}
+	// The first sync aggregate of the Altair fork attests to a phase 0 block.
+	if attestedBlock.Version() == version.Phase0 {
+		return nil, nil
+	}
+	attestedState, err := s.cfg.StateGen.StateByRoot(ctx, parentRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attested state")
+	}
+	if attestedState == nil || attestedState.IsNil() {
+		return nil, errors.New("nil attested state")
+	}
+	ver, err := updateVersion(attestedState.Version())
+	if err != nil {
+		return nil, err
+	}
+	attestedHeader, err := blockHeader(attestedBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &update{
+		version:      ver,
+		participants: participants,
+		data: &ethpbv2.LightClientUpdate{
+			AttestedHeader:          attestedHeader,
+			NextSyncCommittee:       emptySyncCommittee(),
+			NextSyncCommitteeBranch: emptyBranch(nextSyncCommitteeBranchLength),
+			FinalizedHeader:         emptyHeader(),
+			FinalityBranch:          emptyBranch(finalityBranchLength),
+			SyncAggregate: &ethpbv1.SyncAggregate{
+				SyncCommitteeBits:      bytesutil.SafeCopyBytes(agg.SyncCommitteeBits),
+				SyncCommitteeSignature: bytesutil.SafeCopyBytes(agg.SyncCommitteeSignature),
+			},
+			SignatureSlot: blk.Block().Slot(),
+		},
+	}
+
+	if slots.SyncCommitteePeriod(slots.ToEpoch(attestedHeader.Slot)) == slots.SyncCommitteePeriod(slots.ToEpoch(blk.Block().Slot())) {
+		committee, err := attestedState.NextSyncCommittee()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get next sync committee")
+		}
+		branch, err := attestedState.NextSyncCommitteeProof(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute next sync committee proof")
+		}
+		u.data.NextSyncCommittee = &ethpbv2.SyncCommittee{
+			Pubkeys:         committee.Pubkeys,
+			AggregatePubkey: committee.AggregatePubkey,
+		}
+		u.data.NextSyncCommitteeBranch = branch
+		u.hasNextSyncCommittee = true
+	}
+
+	// Finality is only indicated when the finalized block is known, which is not the case of the
+	// genesis checkpoint, whose root is zero.
+	finalizedRoot := bytesutil.ToBytes32(attestedState.FinalizedCheckpoint().Root)
+	if finalizedRoot != params.BeaconConfig().ZeroHash {
+		finalizedBlock, err := s.cfg.BeaconDB.Block(ctx, finalizedRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get finalized block")
+		}
+		if wrapper.BeaconBlockIsNil(finalizedBlock) == nil {
+			finalizedHeader, err := blockHeader(finalizedBlock)
+			if err != nil {
+				return nil, err
+			}
+			branch, err := attestedState.FinalizedRootProof(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not compute finalized root proof")
+			}
+			u.data.FinalizedHeader = finalizedHeader
+			u.data.FinalityBranch = branch
+			u.hasFinality = true
+		}
+	}
+	return u, nil
+}
+
+// isBetterUpdate returns true if the new update should replace the old one as the best update of
+// a sync committee period.
+//
+// Spec pseudocode definition:
+//   def is_better_update(new_update: LightClientUpdate, old_update: LightClientUpdate) -> bool:
+//    # Compare supermajority (> 2/3) sync committee participation
+//    max_active_participants = len(new_update.sync_aggregate.sync_committee_bits)
+//    new_num_active_participants = sum(new_update.sync_aggregate.sync_committee_bits)
+//    old_num_active_participants = sum(old_update.sync_aggregate.sync_committee_bits)
+//    new_has_supermajority = new_num_active_participants * 3 >= max_active_participants * 2
+//    old_has_supermajority = old_num_active_participants * 3 >= max_active_participants * 2
+//    if new_has_supermajority != old_has_supermajority:
+//        return new_has_supermajority > old_has_supermajority
+//    if not new_has_supermajority and new_num_active_participants != old_num_active_participants:
+//        return new_num_active_participants > old_num_active_participants
+//
+//    # Compare presence of relevant sync committee
+//    new_has_relevant_sync_committee = is_sync_committee_update(new_update) and (...)
+//    old_has_relevant_sync_committee = is_sync_committee_update(old_update) and (...)
+//    if new_has_relevant_sync_committee != old_has_relevant_sync_committee:
+//        return new_has_relevant_sync_committee
+//
+//    # Compare indication of any finality
+//    new_has_finality = is_finality_update(new_update)
+//    old_has_finality = is_finality_update(old_update)
+//    if new_has_finality != old_has_finality:
+//        return new_has_finality
+//
+//    # Compare sync committee finality
+//    if new_has_finality:
+//        new_has_sync_committee_finality = (...)
+//        old_has_sync_committee_finality = (...)
+//        if new_has_sync_committee_finality != old_has_sync_committee_finality:
+//            return new_has_sync_committee_finality
+//
+//    # Tiebreaker 1: Sync committee participation beyond supermajority
+//    if new_num_active_participants != old_num_active_participants:
+//        return new_num_active_participants > old_num_active_participants
+//
+//    # Tiebreaker 2: Prefer older data (fewer changes to best)
+//    if new_update.attested_header.slot != old_update.attested_header.slot:
+//        return new_update.attested_header.slot < old_update.attested_header.slot
+//    return new_update.signature_slot < old_update.signature_slot
+func isBetterUpdate(newUpdate, oldUpdate *update) bool {
+	maxParticipants := newUpdate.data.SyncAggregate.SyncCommitteeBits.Len()
+	newSupermajority := newUpdate.participants*3 >= maxParticipants*2
+	oldSupermajority := oldUpdate.participants*3 >= maxParticipants*2
+	if newSupermajority != oldSupermajority {
+		return newSupermajority
+	}
+	if !newSupermajority && newUpdate.participants != oldUpdate.participants {
+		return newUpdate.participants > oldUpdate.participants
+	}
+
+	// Updates only carry a next sync committee when the attested and signature periods match.
+	if newUpdate.hasNextSyncCommittee != oldUpdate.hasNextSyncCommittee {
+		return newUpdate.hasNextSyncCommittee
+	}
+
+	if newUpdate.hasFinality != oldUpdate.hasFinality {
+		return newUpdate.hasFinality
+	}
+	if newUpdate.hasFinality {
+		newCommitteeFinality := sameSyncCommitteePeriod(newUpdate.data.FinalizedHeader, newUpdate.data.AttestedHeader)
+		oldCommitteeFinality := sameSyncCommitteePeriod(oldUpdate.data.FinalizedHeader, oldUpdate.data.AttestedHeader)
+		if newCommitteeFinality != oldCommitteeFinality {
+			return newCommitteeFinality
+		}
+	}
+
+	if newUpdate.participants != oldUpdate.participants {
+		return newUpdate.participants > oldUpdate.participants
+	}
+	if newUpdate.data.AttestedHeader.Slot != oldUpdate.data.AttestedHeader.Slot {
+		return newUpdate.data.AttestedHeader.Slot < oldUpdate.data.AttestedHeader.Slot
+	}
+	return newUpdate.data.SignatureSlot < oldUpdate.data.SignatureSlot
+}
+
+func sameSyncCommitteePeriod(a, b *ethpbv1.BeaconBlockHeader) bool {
+	return slots.SyncCommitteePeriod(slots.ToEpoch(a.Slot)) == slots.SyncCommitteePeriod(slots.ToEpoch(b.Slot))
+}
+
+func blockHeader(blk interfaces.SignedBeaconBlock) (*ethpbv1.BeaconBlockHeader, error) {
+	header, err := blk.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block header")
+	}
+	return migration.V1Alpha1SignedHeaderToV1(header).Message, nil
+}
+
+func updateVersion(v int) (ethpbv2.Version, error) {
+	switch v {
+	case version.Altair:
+		return ethpbv2.Version_ALTAIR, nil
+	case version.Bellatrix:
+		return ethpbv2.Version_BELLATRIX, nil
+	default:
+		return 0, errors.Errorf("unsupported state version %s", version.String(v))
+	}
+}
+
+func emptyHeader() *ethpbv1.BeaconBlockHeader {
+	return &ethpbv1.BeaconBlockHeader{
+		ParentRoot: make([]byte, fieldparams.RootLength),
+		StateRoot:  make([]byte, fieldparams.RootLength),
+		BodyRoot:   make([]byte, fieldparams.RootLength),
+	}
+}
+
+func emptySyncCommittee() *ethpbv2.SyncCommittee {
+	pubkeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &ethpbv2.SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+	}
+}
+
+func emptyBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
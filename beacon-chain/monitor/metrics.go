@@ -90,4 +90,74 @@ var (
 			"validator_index",
 		},
 	)
+	// arrivalDelayGauge used to track the delay between the deadline of a
+	// message and the moment it was seen
+	arrivalDelayGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "arrival_delay_seconds",
+			Help:      "Delay between the slot deadline and the arrival of the latest message, negative when early",
+		},
+		[]string{
+			"validator_index",
+			"kind",
+		},
+	)
+	// consistentlyLateGauge used to flag validators whose messages are
+	// consistently late
+	consistentlyLateGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "consistently_late",
+			Help:      "Set to 1 when most recent messages of the validator arrived after the slot deadline",
+		},
+		[]string{
+			"validator_index",
+			"kind",
+		},
+	)
+	// dutyResultsCounter used to track the duty outcomes reported by the
+	// validator client
+	dutyResultsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "monitor",
+			Name:      "duty_results_total",
+			Help:      "Number of duty outcomes reported by the validator client",
+		},
+		[]string{
+			"validator_index",
+			"duty",
+			"outcome",
+		},
+	)
+	// syncAggregateParticipationGauge used to track the fraction of the sync
+	// committee which participated in the latest block
+	syncAggregateParticipationGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "sync_aggregate_participation",
+			Help:      "Fraction of the sync committee bits set in the sync aggregate of the latest block",
+		},
+	)
+	// syncSubcommitteeParticipationGauge used to track the fraction of each
+	// sync subcommittee which participated in the current sync committee period
+	syncSubcommitteeParticipationGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "sync_subcommittee_period_participation",
+			Help:      "Fraction of the subcommittee bits set in the sync aggregates of the current sync committee period",
+		},
+		[]string{
+			"subcommittee",
+		},
+	)
+	// syncPeriodParticipationGauge used to track the fraction of the sync
+	// committee which participated in the current sync committee period
+	syncPeriodParticipationGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "sync_committee_period_participation",
+			Help:      "Fraction of the sync committee bits set in the sync aggregates of the current sync committee period",
+		},
+	)
 )
@@ -0,0 +1,201 @@
+package monitor
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// messageKind is the kind of gossip message whose arrival is tracked.
+type messageKind string
+
+const (
+	attestationKind messageKind = "attestation"
+	syncMessageKind messageKind = "sync_committee_message"
+)
+
+const (
+	// arrivalWindow is the number of most recent arrivals kept per validator and message kind.
+	arrivalWindow = 32
+	// minLateSamples is the minimum number of arrivals in the window before a validator can be flagged.
+	minLateSamples = 8
+	// lateRatioThreshold is the fraction of late arrivals in the window above which a validator is
+	// considered consistently late.
+	lateRatioThreshold = 0.5
+)
+
+// arrivalKey identifies the arrival statistics of a validator for a message kind.
+type arrivalKey struct {
+	idx  types.ValidatorIndex
+	kind messageKind
+}
+
+// arrivalStats keeps the most recent arrival delays of a validator's messages relative to their deadline.
+type arrivalStats struct {
+	delays           []time.Duration
+	totalCount       uint64
+	totalLateCount   uint64
+	consistentlyLate bool
+}
+
+// ValidatorArrival is the arrival report of a tracked validator for a message kind.
+type ValidatorArrival struct {
+	ValidatorIndex   types.ValidatorIndex
+	Kind             string
+	TotalCount       uint64
+	TotalLateCount   uint64
+	WindowLateRatio  float64
+	MeanDelay        time.Duration
+	LastDelay        time.Duration
+	ConsistentlyLate bool
+}
+
+// messageDeadline returns the time at which messages of the given slot are due, one third into the slot for
+// both attestations and sync committee messages.
+func messageDeadline(genesis time.Time, slot types.Slot) time.Time {
+	intervals := params.BeaconConfig().IntervalsPerSlot
+	return slots.StartTime(uint64(genesis.Unix()), slot).Add(slots.DivideSlotBy(int64(intervals)))
+}
+
+// processSyncCommitteeMessage records the arrival of a sync committee message from a tracked validator.
+func (s *Service) processSyncCommitteeMessage(msg *ethpb.SyncCommitteeMessage) {
+	s.RLock()
+	tracked := s.trackedIndex(msg.ValidatorIndex)
+	s.RUnlock()
+	if tracked {
+		s.recordArrival(syncMessageKind, msg.ValidatorIndex, msg.Slot, prysmTime.Now())
+	}
+}
+
+// recordArrival records the delay between the moment a validator's message was seen and the deadline of its
+// slot, and flags validators whose messages are consistently late.
+func (s *Service) recordArrival(kind messageKind, idx types.ValidatorIndex, slot types.Slot, seen time.Time) {
+	if s.config.TimeFetcher == nil {
+		return
+	}
+	delay := seen.Sub(messageDeadline(s.config.TimeFetcher.GenesisTime(), slot))
+
+	s.arrivalLock.Lock()
+	defer s.arrivalLock.Unlock()
+	key := arrivalKey{idx: idx, kind: kind}
+	stats, ok := s.arrivals[key]
+	if !ok {
+		stats = &arrivalStats{}
+		s.arrivals[key] = stats
+	}
+	stats.delays = append(stats.delays, delay)
+	if len(stats.delays) > arrivalWindow {
+		stats.delays = stats.delays[len(stats.delays)-arrivalWindow:]
+	}
+	stats.totalCount++
+	if delay > 0 {
+		stats.totalLateCount++
+	}
+
+	label := fmt.Sprintf("%d", idx)
+	arrivalDelayGauge.WithLabelValues(label, string(kind)).Set(delay.Seconds())
+
+	wasLate := stats.consistentlyLate
+	stats.consistentlyLate = len(stats.delays) >= minLateSamples && stats.windowLateRatio() > lateRatioThreshold
+	if stats.consistentlyLate {
+		consistentlyLateGauge.WithLabelValues(label, string(kind)).Set(1)
+	} else {
+		consistentlyLateGauge.WithLabelValues(label, string(kind)).Set(0)
+	}
+
+	fields := logrus.Fields{
+		"ValidatorIndex": idx,
+		"Kind":           kind,
+		"Slot":           slot,
+		"Delay":          delay,
+		"LateRatio":      fmt.Sprintf("%.2f", stats.windowLateRatio()),
+	}
+	if stats.consistentlyLate && !wasLate {
+		log.WithFields(fields).Warn("Validator messages are consistently late, check the clock and signer load")
+	} else if !stats.consistentlyLate && wasLate {
+		log.WithFields(fields).Info("Validator messages are back on time")
+	}
+}
+
+// windowLateRatio returns the fraction of late arrivals in the window.
+func (a *arrivalStats) windowLateRatio() float64 {
+	if len(a.delays) == 0 {
+		return 0
+	}
+	late := 0
+	for _, d := range a.delays {
+		if d > 0 {
+			late++
+		}
+	}
+	return float64(late) / float64(len(a.delays))
+}
+
+// ArrivalReport returns the arrival statistics of the tracked validators, sorted by validator index and
+// message kind.
+func (s *Service) ArrivalReport() []ValidatorArrival {
+	s.arrivalLock.Lock()
+	defer s.arrivalLock.Unlock()
+	report := make([]ValidatorArrival, 0, len(s.arrivals))
+	for key, stats := range s.arrivals {
+		var sum time.Duration
+		for _, d := range stats.delays {
+			sum += d
+		}
+		report = append(report, ValidatorArrival{
+			ValidatorIndex:   key.idx,
+			Kind:             string(key.kind),
+			TotalCount:       stats.totalCount,
+			TotalLateCount:   stats.totalLateCount,
+			WindowLateRatio:  stats.windowLateRatio(),
+			MeanDelay:        sum / time.Duration(len(stats.delays)),
+			LastDelay:        stats.delays[len(stats.delays)-1],
+			ConsistentlyLate: stats.consistentlyLate,
+		})
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].ValidatorIndex != report[j].ValidatorIndex {
+			return report[i].ValidatorIndex < report[j].ValidatorIndex
+		}
+		return report[i].Kind < report[j].Kind
+	})
+	return report
+}
+
+// ArrivalReportHandler is a handler to serve the /monitor/arrivals page in metrics.
+func (s *Service) ArrivalReportHandler(w http.ResponseWriter, _ *http.Request) {
+	buf := new(bytes.Buffer)
+	if _, err := fmt.Fprintln(buf, "validator_index kind total late window_late_ratio mean_delay last_delay consistently_late"); err != nil {
+		log.WithError(err).Error("Failed to render arrival report page")
+		return
+	}
+	for _, a := range s.ArrivalReport() {
+		if _, err := fmt.Fprintf(buf, "%d %s %d %d %.2f %s %s %t\n",
+			a.ValidatorIndex,
+			a.Kind,
+			a.TotalCount,
+			a.TotalLateCount,
+			a.WindowLateRatio,
+			a.MeanDelay,
+			a.LastDelay,
+			a.ConsistentlyLate,
+		); err != nil {
+			log.WithError(err).Error("Failed to render arrival report page")
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.WithError(err).Error("Failed to render arrival report page")
+	}
+}
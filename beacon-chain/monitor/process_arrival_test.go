@@ -0,0 +1,94 @@
+package monitor
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestMessageDeadline(t *testing.T) {
+	genesis := time.Unix(1000, 0)
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	want := genesis.Add(time.Duration(2*secondsPerSlot)*time.Second + time.Duration(secondsPerSlot)*time.Second/3)
+	require.Equal(t, want, messageDeadline(genesis, 2))
+}
+
+func TestRecordArrival_FlagsConsistentlyLateValidator(t *testing.T) {
+	hook := logTest.NewGlobal()
+	s := setupService(t)
+	genesis := s.config.TimeFetcher.GenesisTime()
+
+	for slot := types.Slot(1); slot < minLateSamples; slot++ {
+		s.recordArrival(attestationKind, 1, slot, messageDeadline(genesis, slot).Add(time.Second))
+	}
+	require.LogsDoNotContain(t, hook, "Validator messages are consistently late")
+
+	s.recordArrival(attestationKind, 1, minLateSamples, messageDeadline(genesis, minLateSamples).Add(time.Second))
+	require.LogsContain(t, hook, "Validator messages are consistently late")
+
+	for slot := types.Slot(minLateSamples + 1); slot < 3*minLateSamples; slot++ {
+		s.recordArrival(attestationKind, 1, slot, messageDeadline(genesis, slot).Add(-time.Second))
+	}
+	require.LogsContain(t, hook, "Validator messages are back on time")
+
+	report := s.ArrivalReport()
+	require.Equal(t, 1, len(report))
+	require.Equal(t, types.ValidatorIndex(1), report[0].ValidatorIndex)
+	require.Equal(t, uint64(3*minLateSamples-1), report[0].TotalCount)
+	require.Equal(t, uint64(minLateSamples), report[0].TotalLateCount)
+	require.Equal(t, -time.Second, report[0].LastDelay)
+	require.Equal(t, false, report[0].ConsistentlyLate)
+}
+
+func TestRecordArrival_KeepsWindow(t *testing.T) {
+	s := setupService(t)
+	genesis := s.config.TimeFetcher.GenesisTime()
+	for slot := types.Slot(1); slot <= 2*arrivalWindow; slot++ {
+		s.recordArrival(syncMessageKind, 2, slot, messageDeadline(genesis, slot))
+	}
+	stats := s.arrivals[arrivalKey{idx: 2, kind: syncMessageKind}]
+	require.Equal(t, arrivalWindow, len(stats.delays))
+	require.Equal(t, uint64(2*arrivalWindow), stats.totalCount)
+	require.Equal(t, uint64(0), stats.totalLateCount)
+}
+
+func TestRecordArrival_NoTimeFetcher(t *testing.T) {
+	s := setupService(t)
+	s.config.TimeFetcher = nil
+	s.recordArrival(attestationKind, 1, 1, time.Now())
+	require.Equal(t, 0, len(s.ArrivalReport()))
+}
+
+func TestProcessSyncCommitteeMessage(t *testing.T) {
+	s := setupService(t)
+	s.config.TimeFetcher = &mock.ChainService{Genesis: time.Now().Add(-time.Hour)}
+	s.processSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 1, ValidatorIndex: 1})
+	s.processSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 1, ValidatorIndex: 3})
+
+	report := s.ArrivalReport()
+	require.Equal(t, 1, len(report))
+	require.Equal(t, string(syncMessageKind), report[0].Kind)
+	require.Equal(t, true, report[0].LastDelay > 0)
+}
+
+func TestArrivalReportHandler(t *testing.T) {
+	s := setupService(t)
+	genesis := s.config.TimeFetcher.GenesisTime()
+	s.recordArrival(syncMessageKind, 12, 3, messageDeadline(genesis, 3).Add(2*time.Second))
+	s.recordArrival(attestationKind, 2, 3, messageDeadline(genesis, 3).Add(-time.Second))
+
+	rec := httptest.NewRecorder()
+	s.ArrivalReportHandler(rec, httptest.NewRequest("GET", "/monitor/arrivals", nil))
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Equal(t, 3, len(lines))
+	require.Equal(t, "2 attestation 1 0 0.00 -1s -1s false", lines[1])
+	require.Equal(t, "12 sync_committee_message 1 1 1.00 2s 2s false", lines[2])
+}
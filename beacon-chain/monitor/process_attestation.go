@@ -14,6 +14,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation"
 	"github.com/prysmaticlabs/prysm/runtime/version"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
 	"github.com/prysmaticlabs/prysm/time/slots"
 	"github.com/sirupsen/logrus"
 )
@@ -180,6 +181,7 @@ func (s *Service) processUnaggregatedAttestation(ctx context.Context, att *ethpb
 		if s.canUpdateAttestedValidator(types.ValidatorIndex(idx), att.Data.Slot) {
 			logFields := logMessageTimelyFlagsForIndex(types.ValidatorIndex(idx), att.Data)
 			log.WithFields(logFields).Info("Processed unaggregated attestation")
+			s.recordArrival(attestationKind, types.ValidatorIndex(idx), att.Data.Slot, prysmTime.Now())
 		}
 	}
 }
@@ -31,6 +31,7 @@ func (s *Service) processBlock(ctx context.Context, b interfaces.SignedBeaconBlo
 
 	s.processSlashings(blk)
 	s.processExitsFromBlock(blk)
+	s.processSyncParticipation(blk)
 
 	root, err := blk.HashTreeRoot()
 	if err != nil {
@@ -0,0 +1,36 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// processDutyResults logs and updates the metrics of the duty outcomes reported by a validator
+// client for tracked validators.
+func (s *Service) processDutyResults(results []*ethpb.DutyResult) {
+	s.RLock()
+	defer s.RUnlock()
+	for _, r := range results {
+		if !s.trackedIndex(r.ValidatorIndex) {
+			continue
+		}
+		duty := strings.ToLower(r.Duty.String())
+		outcome := strings.ToLower(r.Outcome.String())
+		dutyResultsCounter.WithLabelValues(fmt.Sprintf("%d", r.ValidatorIndex), duty, outcome).Inc()
+
+		l := log.WithFields(logrus.Fields{
+			"ValidatorIndex": r.ValidatorIndex,
+			"Slot":           r.Slot,
+			"Duty":           duty,
+			"Outcome":        outcome,
+		})
+		if r.Outcome == ethpb.DutyResult_SUBMITTED {
+			l.Debug("Validator client submitted duty")
+			continue
+		}
+		l.WithField("Reason", r.Reason).Warn("Validator client did not submit duty")
+	}
+}
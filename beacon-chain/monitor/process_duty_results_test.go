@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"testing"
+
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/sirupsen/logrus"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestProcessDutyResults(t *testing.T) {
+	hook := logTest.NewGlobal()
+	logrus.SetLevel(logrus.DebugLevel)
+	s := &Service{
+		TrackedValidators: map[types.ValidatorIndex]bool{
+			1: true,
+			2: true,
+		},
+	}
+
+	s.processDutyResults([]*ethpb.DutyResult{
+		{
+			ValidatorIndex: 1,
+			Slot:           5,
+			Duty:           ethpb.DutyResult_ATTESTATION,
+			Outcome:        ethpb.DutyResult_SUBMITTED,
+		},
+		{
+			ValidatorIndex: 2,
+			Slot:           5,
+			Duty:           ethpb.DutyResult_PROPOSAL,
+			Outcome:        ethpb.DutyResult_SIGNED,
+			Reason:         "beacon node unavailable",
+		},
+		{
+			ValidatorIndex: 3,
+			Slot:           5,
+			Duty:           ethpb.DutyResult_SYNC_COMMITTEE_MESSAGE,
+			Outcome:        ethpb.DutyResult_SKIPPED,
+			Reason:         "could not sign",
+		},
+	})
+	require.LogsContain(t, hook, "\"Validator client submitted duty\" Duty=attestation Outcome=submitted Slot=5 ValidatorIndex=1")
+	require.LogsContain(t, hook, "\"Validator client did not submit duty\" Duty=proposal Outcome=signed Reason=\"beacon node unavailable\" Slot=5 ValidatorIndex=2")
+	require.LogsDoNotContain(t, hook, "ValidatorIndex=3")
+}
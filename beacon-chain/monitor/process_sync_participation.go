@@ -0,0 +1,166 @@
+package monitor
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// syncParticipationPeriods is the number of most recent sync committee periods whose participation is kept.
+const syncParticipationPeriods = 4
+
+// periodParticipation accumulates the sync aggregate bits of all the blocks of a sync committee period.
+type periodParticipation struct {
+	blocks           uint64
+	participants     uint64
+	subcommitteeBits []uint64
+	lowestBlockRatio float64
+}
+
+// SyncPeriodParticipation is the network-wide sync committee participation over a sync committee period,
+// computed from the sync aggregates of the blocks processed in that period.
+type SyncPeriodParticipation struct {
+	Period                    uint64
+	Blocks                    uint64
+	Participation             float64
+	LowestBlockParticipation  float64
+	SubcommitteeParticipation []float64
+}
+
+// processSyncParticipation records the number of sync committee bits set in the sync aggregate of a block,
+// regardless of the tracked validators, so that network-wide sync committee degradation can be told apart
+// from the failures of a tracked validator.
+func (s *Service) processSyncParticipation(blk interfaces.BeaconBlock) {
+	if blk == nil || blk.Body() == nil || blk.Version() == version.Phase0 {
+		return
+	}
+	agg, err := blk.Body().SyncAggregate()
+	if err != nil {
+		log.WithError(err).Error("Could not get SyncAggregate")
+		return
+	}
+	bits := agg.SyncCommitteeBits
+	size := params.BeaconConfig().SyncCommitteeSize
+	subnets := params.BeaconConfig().SyncCommitteeSubnetCount
+	if bits.Len() != size || size == 0 || subnets == 0 {
+		return
+	}
+	subcommitteeSize := size / subnets
+	period := uint64(slots.SyncCommitteePeriod(slots.ToEpoch(blk.Slot())))
+
+	s.syncParticipationLock.Lock()
+	defer s.syncParticipationLock.Unlock()
+	if len(s.syncParticipation) > 0 && period+syncParticipationPeriods <= s.latestSyncParticipationPeriod() {
+		return
+	}
+	stats, ok := s.syncParticipation[period]
+	if !ok {
+		stats = &periodParticipation{subcommitteeBits: make([]uint64, subnets)}
+		s.syncParticipation[period] = stats
+		for p := range s.syncParticipation {
+			if p+syncParticipationPeriods <= period {
+				delete(s.syncParticipation, p)
+			}
+		}
+	}
+	participants := bits.Count()
+	blockRatio := float64(participants) / float64(size)
+	stats.blocks++
+	stats.participants += participants
+	for i := uint64(0); i < subnets; i++ {
+		for j := i * subcommitteeSize; j < (i+1)*subcommitteeSize; j++ {
+			if bits.BitAt(j) {
+				stats.subcommitteeBits[i]++
+			}
+		}
+	}
+	if stats.blocks == 1 || blockRatio < stats.lowestBlockRatio {
+		stats.lowestBlockRatio = blockRatio
+	}
+
+	if period != s.latestSyncParticipationPeriod() {
+		return
+	}
+	syncAggregateParticipationGauge.Set(blockRatio)
+	syncPeriodParticipationGauge.Set(float64(stats.participants) / float64(stats.blocks*size))
+	for i, count := range stats.subcommitteeBits {
+		syncSubcommitteeParticipationGauge.WithLabelValues(fmt.Sprintf("%d", i)).Set(
+			float64(count) / float64(stats.blocks*subcommitteeSize))
+	}
+}
+
+// latestSyncParticipationPeriod returns the most recent sync committee period with recorded participation.
+// The caller must hold the sync participation lock.
+func (s *Service) latestSyncParticipationPeriod() uint64 {
+	var latest uint64
+	for p := range s.syncParticipation {
+		if p > latest {
+			latest = p
+		}
+	}
+	return latest
+}
+
+// SyncParticipationReport returns the network-wide sync committee participation of the most recent sync
+// committee periods, sorted by period.
+func (s *Service) SyncParticipationReport() []SyncPeriodParticipation {
+	size := params.BeaconConfig().SyncCommitteeSize
+	s.syncParticipationLock.Lock()
+	defer s.syncParticipationLock.Unlock()
+	report := make([]SyncPeriodParticipation, 0, len(s.syncParticipation))
+	for period, stats := range s.syncParticipation {
+		subcommitteeSize := size / uint64(len(stats.subcommitteeBits))
+		subcommittees := make([]float64, len(stats.subcommitteeBits))
+		for i, count := range stats.subcommitteeBits {
+			subcommittees[i] = float64(count) / float64(stats.blocks*subcommitteeSize)
+		}
+		report = append(report, SyncPeriodParticipation{
+			Period:                    period,
+			Blocks:                    stats.blocks,
+			Participation:             float64(stats.participants) / float64(stats.blocks*size),
+			LowestBlockParticipation:  stats.lowestBlockRatio,
+			SubcommitteeParticipation: subcommittees,
+		})
+	}
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Period < report[j].Period
+	})
+	return report
+}
+
+// SyncParticipationHandler is a handler to serve the /monitor/sync_participation page in metrics.
+func (s *Service) SyncParticipationHandler(w http.ResponseWriter, _ *http.Request) {
+	buf := new(bytes.Buffer)
+	if _, err := fmt.Fprintln(buf, "period blocks participation lowest_block_participation subcommittee_participation"); err != nil {
+		log.WithError(err).Error("Failed to render sync participation page")
+		return
+	}
+	for _, p := range s.SyncParticipationReport() {
+		subcommittees := make([]string, len(p.SubcommitteeParticipation))
+		for i, ratio := range p.SubcommitteeParticipation {
+			subcommittees[i] = fmt.Sprintf("%.2f", ratio)
+		}
+		if _, err := fmt.Fprintf(buf, "%d %d %.2f %.2f %s\n",
+			p.Period,
+			p.Blocks,
+			p.Participation,
+			p.LowestBlockParticipation,
+			strings.Join(subcommittees, ","),
+		); err != nil {
+			log.WithError(err).Error("Failed to render sync participation page")
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.WithError(err).Error("Failed to render sync participation page")
+	}
+}
@@ -0,0 +1,101 @@
+package monitor
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+// processSyncParticipationAtSlot processes an altair block of the given slot whose sync aggregate has the
+// given sync committee bits set.
+func processSyncParticipationAtSlot(t *testing.T, s *Service, slot types.Slot, indices ...uint64) {
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = slot
+	bits := bitfield.NewBitvector512()
+	for _, idx := range indices {
+		bits.SetBitAt(idx, true)
+	}
+	b.Block.Body.SyncAggregate.SyncCommitteeBits = bits
+	wb, err := wrapper.WrappedBeaconBlock(b.Block)
+	require.NoError(t, err)
+	s.processSyncParticipation(wb)
+}
+
+// subcommitteeIndices returns the first n sync committee indices of a subcommittee.
+func subcommitteeIndices(subcommittee, n uint64) []uint64 {
+	subcommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	indices := make([]uint64, n)
+	for i := range indices {
+		indices[i] = subcommittee*subcommitteeSize + uint64(i)
+	}
+	return indices
+}
+
+func TestProcessSyncParticipation(t *testing.T) {
+	s := setupService(t)
+	subcommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+
+	// Subcommittee 1 is fully offline while the others fully participate, then every subcommittee fully
+	// participates.
+	var indices []uint64
+	for i := uint64(0); i < params.BeaconConfig().SyncCommitteeSubnetCount; i++ {
+		if i != 1 {
+			indices = append(indices, subcommitteeIndices(i, subcommitteeSize)...)
+		}
+	}
+	processSyncParticipationAtSlot(t, s, 1, indices...)
+	processSyncParticipationAtSlot(t, s, 2, append(indices, subcommitteeIndices(1, subcommitteeSize)...)...)
+
+	report := s.SyncParticipationReport()
+	require.Equal(t, 1, len(report))
+	require.Equal(t, uint64(0), report[0].Period)
+	require.Equal(t, uint64(2), report[0].Blocks)
+	require.Equal(t, 0.875, report[0].Participation)
+	require.Equal(t, 0.75, report[0].LowestBlockParticipation)
+	require.DeepEqual(t, []float64{1, 0.5, 1, 1}, report[0].SubcommitteeParticipation)
+}
+
+func TestProcessSyncParticipation_KeepsRecentPeriods(t *testing.T) {
+	s := setupService(t)
+	slotsPerPeriod := types.Slot(params.BeaconConfig().EpochsPerSyncCommitteePeriod) * params.BeaconConfig().SlotsPerEpoch
+
+	for period := types.Slot(0); period <= syncParticipationPeriods; period++ {
+		processSyncParticipationAtSlot(t, s, period*slotsPerPeriod, subcommitteeIndices(0, 1)...)
+	}
+	// A block from a period which is no longer kept is ignored.
+	processSyncParticipationAtSlot(t, s, 0, subcommitteeIndices(0, 1)...)
+
+	report := s.SyncParticipationReport()
+	require.Equal(t, syncParticipationPeriods, len(report))
+	require.Equal(t, uint64(1), report[0].Period)
+	require.Equal(t, uint64(syncParticipationPeriods), report[len(report)-1].Period)
+	for _, p := range report {
+		require.Equal(t, uint64(1), p.Blocks)
+	}
+}
+
+func TestProcessSyncParticipation_Phase0(t *testing.T) {
+	s := setupService(t)
+	wb, err := wrapper.WrappedBeaconBlock(util.NewBeaconBlock().Block)
+	require.NoError(t, err)
+	s.processSyncParticipation(wb)
+	require.Equal(t, 0, len(s.SyncParticipationReport()))
+}
+
+func TestSyncParticipationHandler(t *testing.T) {
+	s := setupService(t)
+	processSyncParticipationAtSlot(t, s, 1, subcommitteeIndices(2, 64)...)
+
+	rec := httptest.NewRecorder()
+	s.SyncParticipationHandler(rec, httptest.NewRequest("GET", "/monitor/sync_participation", nil))
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Equal(t, 2, len(lines))
+	require.Equal(t, "0 1 0.12 0.12 0.00,0.00,0.50,0.00", lines[1])
+}
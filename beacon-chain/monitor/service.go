@@ -62,6 +62,7 @@ type ValidatorMonitorConfig struct {
 	StateNotifier       statefeed.Notifier
 	AttestationNotifier operation.Notifier
 	HeadFetcher         blockchain.HeadFetcher
+	TimeFetcher         blockchain.TimeFetcher
 	StateGen            stategen.StateManager
 }
 
@@ -82,6 +83,14 @@ type Service struct {
 	aggregatedPerformance       map[types.ValidatorIndex]ValidatorAggregatedPerformance
 	trackedSyncCommitteeIndices map[types.ValidatorIndex][]types.CommitteeIndex
 	lastSyncedEpoch             types.Epoch
+
+	// Locks access to arrivals, which is updated while the service lock is held for reading.
+	arrivalLock sync.Mutex
+	arrivals    map[arrivalKey]*arrivalStats
+
+	// Locks access to syncParticipation, which is updated for every processed block.
+	syncParticipationLock sync.Mutex
+	syncParticipation     map[uint64]*periodParticipation
 }
 
 // NewService sets up a new validator monitor service instance when given a list of validator indices to track.
@@ -95,6 +104,8 @@ func NewService(ctx context.Context, config *ValidatorMonitorConfig, tracked []t
 		latestPerformance:           make(map[types.ValidatorIndex]ValidatorLatestPerformance),
 		aggregatedPerformance:       make(map[types.ValidatorIndex]ValidatorAggregatedPerformance),
 		trackedSyncCommitteeIndices: make(map[types.ValidatorIndex][]types.CommitteeIndex),
+		arrivals:                    make(map[arrivalKey]*arrivalStats),
+		syncParticipation:           make(map[uint64]*periodParticipation),
 		isLogging:                   false,
 	}
 	for _, idx := range tracked {
@@ -271,6 +282,20 @@ func (s *Service) monitorRoutine(stateChannel chan *feed.Event, stateSub event.S
 				} else {
 					s.processSyncCommitteeContribution(data.Contribution)
 				}
+			case operation.SyncCommitteeMessageReceived:
+				data, ok := e.Data.(*operation.SyncCommitteeMessageReceivedData)
+				if !ok {
+					log.Error("Event feed data is not of type *operation.SyncCommitteeMessageReceivedData")
+				} else {
+					s.processSyncCommitteeMessage(data.Message)
+				}
+			case operation.DutyResultsReceived:
+				data, ok := e.Data.(*operation.DutyResultsReceivedData)
+				if !ok {
+					log.Error("Event feed data is not of type *operation.DutyResultsReceivedData")
+				} else {
+					s.processDutyResults(data.Results)
+				}
 			}
 		case <-s.ctx.Done():
 			log.Debug("Context closed, exiting goroutine")
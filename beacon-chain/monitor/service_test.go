@@ -91,6 +91,7 @@ func setupService(t *testing.T) *Service {
 			StateGen:            stategen.New(beaconDB),
 			StateNotifier:       chainService.StateNotifier(),
 			HeadFetcher:         chainService,
+			TimeFetcher:         chainService,
 			AttestationNotifier: chainService.OperationNotifier(),
 		},
 
@@ -100,6 +101,8 @@ func setupService(t *testing.T) *Service {
 		aggregatedPerformance:       aggregatedPerformance,
 		trackedSyncCommitteeIndices: trackedSyncCommitteeIndices,
 		lastSyncedEpoch:             0,
+		arrivals:                    make(map[arrivalKey]*arrivalStats),
+		syncParticipation:           make(map[uint64]*periodParticipation),
 	}
 }
 
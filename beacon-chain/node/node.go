@@ -16,8 +16,10 @@ import (
 	"syscall"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
 	apigateway "github.com/prysmaticlabs/prysm/api/gateway"
+	"github.com/prysmaticlabs/prysm/api/namespace"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/builder"
@@ -26,11 +28,13 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/slasherkv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/debugui"
 	interopcoldstart "github.com/prysmaticlabs/prysm/beacon-chain/deterministic-genesis"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/gateway"
+	"github.com/prysmaticlabs/prysm/beacon-chain/lightclient"
 	"github.com/prysmaticlabs/prysm/beacon-chain/monitor"
 	"github.com/prysmaticlabs/prysm/beacon-chain/node/registration"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
@@ -38,7 +42,9 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/reload"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/apimiddleware"
 	"github.com/prysmaticlabs/prysm/beacon-chain/slasher"
@@ -49,16 +55,21 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync/checkpoint"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync/genesis"
 	initialsync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/telemetry"
 	"github.com/prysmaticlabs/prysm/cmd"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/container/slice"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/monitoring/backup"
 	"github.com/prysmaticlabs/prysm/monitoring/prometheus"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/runtime"
+	"github.com/prysmaticlabs/prysm/runtime/crash"
 	"github.com/prysmaticlabs/prysm/runtime/debug"
 	"github.com/prysmaticlabs/prysm/runtime/prereqs"
 	"github.com/prysmaticlabs/prysm/runtime/version"
@@ -111,6 +122,7 @@ type BeaconNode struct {
 	blockchainFlagOpts      []blockchain.Option
 	GenesisInitializer      genesis.Initializer
 	CheckpointInitializer   checkpoint.Initializer
+	readReplica             bool
 }
 
 // New creates a new node instance, sets up configuration options, and registers
@@ -123,6 +135,9 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 	if err := features.ConfigureBeaconChain(cliCtx); err != nil {
 		return nil, err
 	}
+	if err := bls.SetBackend(features.Get().BLSBackend); err != nil {
+		return nil, err
+	}
 	if err := cmd.ConfigureBeaconChain(cliCtx); err != nil {
 		return nil, err
 	}
@@ -156,6 +171,7 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 
 	registry := runtime.NewServiceRegistry()
 
+	syncMessageGracePeriod := types.Slot(cliCtx.Uint64(flags.SyncCommitteeMessageGracePeriod.Name))
 	ctx, cancel := context.WithCancel(cliCtx.Context)
 	beacon := &BeaconNode{
 		cliCtx:                  cliCtx,
@@ -169,11 +185,12 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		attestationPool:         attestations.NewPool(),
 		exitPool:                voluntaryexits.NewPool(),
 		slashingsPool:           slashings.NewPool(),
-		syncCommitteePool:       synccommittee.NewPool(),
+		syncCommitteePool:       synccommittee.NewPool(synccommittee.WithMessageGracePeriod(syncMessageGracePeriod)),
 		slasherBlockHeadersFeed: new(event.Feed),
 		slasherAttestationsFeed: new(event.Feed),
 		serviceFlagOpts:         &serviceFlagOpts{},
 		proposerIdsCache:        cache.NewProposerPayloadIDsCache(),
+		readReplica:             cliCtx.Bool(flags.ReadReplica.Name),
 	}
 
 	for _, opt := range opts {
@@ -206,6 +223,16 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if beacon.readReplica {
+		if err := beacon.registerReadReplicaServices(); err != nil {
+			return nil, err
+		}
+		if err := beacon.registerMonitoring(cliCtx); err != nil {
+			return nil, err
+		}
+		return beacon, nil
+	}
+
 	log.Debugln("Registering P2P Service")
 	if err := beacon.registerP2P(cliCtx); err != nil {
 		return nil, err
@@ -244,6 +271,16 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Backfill Service")
+	if err := beacon.registerBackfillService(bfs); err != nil {
+		return nil, err
+	}
+
+	log.Debugln("Registering Reload Service")
+	if err := beacon.registerReloadService(); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering Slasher Service")
 	if err := beacon.registerSlasherService(); err != nil {
 		return nil, err
@@ -254,6 +291,11 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Light Client Service")
+	if err := beacon.registerLightClientService(); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering RPC Service")
 	if err := beacon.registerRPCService(); err != nil {
 		return nil, err
@@ -269,23 +311,37 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Telemetry Service")
+	if err := beacon.registerTelemetryService(); err != nil {
+		return nil, err
+	}
+
+	if err := beacon.registerMonitoring(cliCtx); err != nil {
+		return nil, err
+	}
+
+	return beacon, nil
+}
+
+// registerMonitoring registers the prometheus service, unless monitoring is disabled, and the collector of the
+// beacon node metrics.
+func (b *BeaconNode) registerMonitoring(cliCtx *cli.Context) error {
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		log.Debugln("Registering Prometheus Service")
-		if err := beacon.registerPrometheusService(cliCtx); err != nil {
-			return nil, err
+		if err := b.registerPrometheusService(cliCtx); err != nil {
+			return err
 		}
 	}
 
 	// db.DatabasePath is the path to the containing directory
 	// db.NewDBFilename expands that to the canonical full path using
 	// the same construction as NewDB()
-	c, err := newBeaconNodePromCollector(db.NewDBFilename(beacon.db.DatabasePath()))
+	c, err := newBeaconNodePromCollector(db.NewDBFilename(b.db.DatabasePath()))
 	if err != nil {
-		return nil, err
+		return err
 	}
-	beacon.collector = c
-
-	return beacon, nil
+	b.collector = c
+	return nil
 }
 
 // StateFeed implements statefeed.Notifier.
@@ -361,6 +417,9 @@ func (b *BeaconNode) startForkChoice() {
 }
 
 func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
+	if b.readReplica {
+		return b.startReadReplicaDB(cliCtx, depositAddress)
+	}
 	baseDir := cliCtx.String(cmd.DataDirFlag.Name)
 	dbPath := filepath.Join(baseDir, kv.BeaconNodeDbDirName)
 	clearDB := cliCtx.Bool(cmd.ClearDB.Name)
@@ -403,6 +462,7 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	if err := d.RunMigrations(b.ctx); err != nil {
 		return err
 	}
+	crash.RegisterSection("database", crash.MigrationsSection(b.ctx, d.CompletedMigrations))
 
 	b.db = d
 
@@ -501,7 +561,18 @@ func (b *BeaconNode) startSlasherDB(cliCtx *cli.Context) error {
 }
 
 func (b *BeaconNode) startStateGen(ctx context.Context, bfs *backfill.Status) error {
-	opts := []stategen.StateGenOption{stategen.WithBackfillStatus(bfs)}
+	opts := []stategen.StateGenOption{
+		stategen.WithBackfillStatus(bfs),
+		stategen.WithHotStateCacheSize(b.cliCtx.Int(flags.HotStateCacheSize.Name)),
+		stategen.WithStateSpill(
+			filepath.Join(b.cliCtx.String(cmd.DataDirFlag.Name), "regen-scratch"),
+			b.cliCtx.Int(flags.RegenMemoryCap.Name),
+		),
+		stategen.WithHotStateSpill(
+			filepath.Join(b.cliCtx.String(cmd.DataDirFlag.Name), "hot-state-scratch"),
+			b.cliCtx.Int(flags.HotStateMemoryCap.Name),
+		),
+	}
 	sg := stategen.New(b.db, opts...)
 
 	cp, err := b.db.FinalizedCheckpoint(ctx)
@@ -539,6 +610,32 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		return err
 	}
 
+	var scoringPolicy p2p.ScoringPolicy
+	if path := cliCtx.String(flags.GossipScoringPolicyFile.Name); path != "" {
+		scoringPolicy, err = p2p.LoadScoringPolicy(path)
+		if err != nil {
+			return err
+		}
+		log.WithField("path", path).Info("Loaded gossip scoring policy")
+	}
+
+	var connectionFilter *p2p.ConnectionFilter
+	if path := cliCtx.String(cmd.P2PFilterFile.Name); path != "" {
+		connectionFilter, err = p2p.LoadConnectionFilter(path)
+		if err != nil {
+			return err
+		}
+		log.WithField("path", path).Info("Loaded peer connection filter")
+	}
+
+	nodeRole, err := nodeRoleFromFlags(cliCtx)
+	if err != nil {
+		return err
+	}
+	if nodeRole != peers.FullNode {
+		log.WithField("role", nodeRole).Info("Running with a restricted node role")
+	}
+
 	svc, err := p2p.NewService(b.ctx, &p2p.Config{
 		NoDiscovery:       cliCtx.Bool(cmd.NoDiscovery.Name),
 		StaticPeers:       slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
@@ -555,10 +652,16 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		MaxPeers:          cliCtx.Uint(cmd.P2PMaxPeers.Name),
 		AllowListCIDR:     cliCtx.String(cmd.P2PAllowList.Name),
 		DenyListCIDR:      slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
+		ConnectionFilter:  connectionFilter,
+		InboundDialRate:   cliCtx.Int(cmd.P2PInboundDialRate.Name),
+		InboundDialBurst:  cliCtx.Int(cmd.P2PInboundDialBurst.Name),
 		EnableUPnP:        cliCtx.Bool(cmd.EnableUPnPFlag.Name),
 		DisableDiscv5:     cliCtx.Bool(flags.DisableDiscv5.Name),
 		StateNotifier:     b,
 		DB:                b.db,
+		ScoringPolicy:     scoringPolicy,
+		CompressionCodec:  features.Get().P2PCompressionCodec,
+		NodeRole:          nodeRole,
 	})
 	if err != nil {
 		return err
@@ -566,6 +669,15 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 	return b.services.RegisterService(svc)
 }
 
+// nodeRoleFromFlags returns the node role set by the node role flag, a full node if unset.
+func nodeRoleFromFlags(cliCtx *cli.Context) (peers.NodeRole, error) {
+	name := cliCtx.String(flags.NodeRole.Name)
+	if name == "" {
+		return peers.FullNode, nil
+	}
+	return peers.ParseNodeRole(name)
+}
+
 func (b *BeaconNode) fetchP2P() p2p.P2P {
 	var p *p2p.Service
 	if err := b.services.FetchService(&p); err != nil {
@@ -584,7 +696,8 @@ func (b *BeaconNode) fetchBuilderService() *builder.Service {
 
 func (b *BeaconNode) registerAttestationPool() error {
 	s, err := attestations.NewService(b.ctx, &attestations.Config{
-		Pool: b.attestationPool,
+		Pool:              b.attestationPool,
+		AggregationOffset: b.cliCtx.Duration(flags.AttestationAggregationOffset.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not register atts pool service")
@@ -677,6 +790,11 @@ func (b *BeaconNode) registerSyncService() error {
 		return err
 	}
 
+	nodeRole, err := nodeRoleFromFlags(b.cliCtx)
+	if err != nil {
+		return err
+	}
+
 	rs := regularsync.NewService(
 		b.ctx,
 		regularsync.WithDatabase(b.db),
@@ -694,6 +812,7 @@ func (b *BeaconNode) registerSyncService() error {
 		regularsync.WithStateGen(b.stateGen),
 		regularsync.WithSlasherAttestationsFeed(b.slasherAttestationsFeed),
 		regularsync.WithSlasherBlockHeadersFeed(b.slasherBlockHeadersFeed),
+		regularsync.WithNodeRole(nodeRole),
 	)
 	return b.services.RegisterService(rs)
 }
@@ -714,6 +833,49 @@ func (b *BeaconNode) registerInitialSyncService() error {
 	return b.services.RegisterService(is)
 }
 
+func (b *BeaconNode) registerBackfillService(bfs *backfill.Status) error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	p2pService := b.fetchP2P()
+	bs := backfill.NewService(b.ctx, &backfill.Config{
+		DB:     b.db,
+		Status: bfs,
+		P2P:    p2pService,
+		Fetch: func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.SignedBeaconBlock, error) {
+			return regularsync.SendBeaconBlocksByRangeRequest(ctx, chainService, p2pService, pid, req, nil)
+		},
+		RetentionEpochs: types.Epoch(b.cliCtx.Uint64(flags.BlocksRetentionEpochs.Name)),
+	})
+	return b.services.RegisterService(bs)
+}
+
+func (b *BeaconNode) registerReloadService() error {
+	var p2pService *p2p.Service
+	if err := b.services.FetchService(&p2pService); err != nil {
+		return err
+	}
+
+	var regSyncService *regularsync.Service
+	if err := b.services.FetchService(&regSyncService); err != nil {
+		return err
+	}
+
+	reloadService := reload.NewService(b.ctx, &reload.Config{
+		ConfigFile:              b.cliCtx.String(cmd.ConfigFileFlag.Name),
+		ConnectionFilterFile:    b.cliCtx.String(cmd.P2PFilterFile.Name),
+		Settings:                reload.SettingsFromCLI(b.cliCtx),
+		PeerLimitSetter:         p2pService,
+		HotStateCacheResizer:    b.stateGen,
+		BlockRateLimiter:        regSyncService,
+		InboundDialLimiter:      p2pService,
+		ConnectionFilterManager: p2pService,
+	})
+	return b.services.RegisterService(reloadService)
+}
+
 func (b *BeaconNode) registerSlasherService() error {
 	if !features.Get().EnableSlasher {
 		return nil
@@ -760,6 +922,22 @@ func (b *BeaconNode) registerRPCService() error {
 		return err
 	}
 
+	var reloadService *reload.Service
+	if err := b.services.FetchService(&reloadService); err != nil {
+		return err
+	}
+
+	var regSyncService *regularsync.Service
+	if err := b.services.FetchService(&regSyncService); err != nil {
+		return err
+	}
+
+	// The connection filter is not part of the P2P interface implemented by the test doubles.
+	var connectionFilterManager *p2p.Service
+	if err := b.services.FetchService(&connectionFilterManager); err != nil {
+		return err
+	}
+
 	var slasherService *slasher.Service
 	if features.Get().EnableSlasher {
 		if err := b.services.FetchService(&slasherService); err != nil {
@@ -767,6 +945,15 @@ func (b *BeaconNode) registerRPCService() error {
 		}
 	}
 
+	var lightClientUpdatesFetcher lightclient.UpdatesFetcher
+	if b.cliCtx.Bool(flags.LightClientServer.Name) {
+		var lightClientService *lightclient.Service
+		if err := b.services.FetchService(&lightClientService); err != nil {
+			return err
+		}
+		lightClientUpdatesFetcher = lightClientService
+	}
+
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
 	genesisStatePath := b.cliCtx.String(flags.InteropGenesisStateFlag.Name)
 	var depositFetcher depositcache.DepositFetcher
@@ -797,6 +984,15 @@ func (b *BeaconNode) registerRPCService() error {
 		maxMsgSize = int(math.Max(float64(maxMsgSize), debugGrpcMaxMsgSize))
 	}
 
+	grpcNamespaces, err := namespace.ParseSet(b.cliCtx.String(flags.GRPCNamespaces.Name))
+	if err != nil {
+		return errors.Wrapf(err, "could not parse --%s", flags.GRPCNamespaces.Name)
+	}
+	authNamespaces, authToken, err := b.apiAuth()
+	if err != nil {
+		return err
+	}
+
 	p2pService := b.fetchP2P()
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
 		Host:                    host,
@@ -809,6 +1005,8 @@ func (b *BeaconNode) registerRPCService() error {
 		Broadcaster:             p2pService,
 		PeersFetcher:            p2pService,
 		PeerManager:             p2pService,
+		GossipStatsProvider:     p2pService,
+		ConnectionFilter:        connectionFilterManager,
 		MetadataProvider:        p2pService,
 		ChainInfoFetcher:        chainService,
 		HeadUpdater:             chainService,
@@ -826,11 +1024,13 @@ func (b *BeaconNode) registerRPCService() error {
 		SlashingsPool:           b.slashingsPool,
 		SlashingChecker:         slasherService,
 		SyncCommitteeObjectPool: b.syncCommitteePool,
+		LightClientUpdates:      lightClientUpdatesFetcher,
 		POWChainService:         web3Service,
 		POWChainInfoFetcher:     web3Service,
 		ChainStartFetcher:       chainStartFetcher,
 		MockEth1Votes:           mockEth1DataVotes,
 		SyncService:             syncService,
+		GossipValidator:         regSyncService,
 		DepositFetcher:          depositFetcher,
 		PendingDepositFetcher:   b.depositCache,
 		BlockNotifier:           b,
@@ -842,6 +1042,14 @@ func (b *BeaconNode) registerRPCService() error {
 		ProposerIdsCache:        b.proposerIdsCache,
 		ExecutionEngineCaller:   web3Service,
 		BlockBuilder:            b.fetchBuilderService(),
+		ConfigReloader:          reloadService,
+		SlowRequestThreshold:    b.cliCtx.Duration(flags.RPCSlowRequestThreshold.Name),
+		MaxHeadStaleness:        types.Slot(b.cliCtx.Uint64(flags.MaxHeadStalenessSlots.Name)),
+		HistoricalStateBudget:   b.cliCtx.Duration(flags.HistoricalStateReconstructionBudget.Name),
+		HistoricalCacheSize:     b.cliCtx.Int(flags.HistoricalStateCacheSize.Name),
+		Namespaces:              grpcNamespaces,
+		AuthNamespaces:          authNamespaces,
+		AuthToken:               authToken,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -849,16 +1057,43 @@ func (b *BeaconNode) registerRPCService() error {
 
 func (b *BeaconNode) registerPrometheusService(cliCtx *cli.Context) error {
 	var additionalHandlers []prometheus.Handler
+	// A read replica has no p2p service.
 	var p *p2p.Service
-	if err := b.services.FetchService(&p); err != nil {
-		panic(err)
+	if !b.readReplica {
+		if err := b.services.FetchService(&p); err != nil {
+			panic(err)
+		}
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p", Handler: p.InfoHandler})
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p/topics", Handler: p.GossipTopicsHandler})
 	}
-	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p", Handler: p.InfoHandler})
 
 	var c *blockchain.Service
 	if err := b.services.FetchService(&c); err != nil {
 		panic(err)
 	}
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/chain/reorgs", Handler: c.ChainReorgsHandler})
+
+	if cliCtx.Bool(flags.EnableDebugUI.Name) && b.readReplica {
+		log.Warn("The debug UI is not served by a read replica")
+	} else if cliCtx.Bool(flags.EnableDebugUI.Name) {
+		ui := debugui.NewServer(&debugui.Config{
+			HeadFetcher:         c,
+			FinalizationFetcher: c,
+			BeaconDB:            b.db,
+			PeersProvider:       p,
+			AttestationPool:     b.attestationPool,
+			ExitPool:            b.exitPool,
+			SlashingPool:        b.slashingsPool,
+			SyncCommitteePool:   b.syncCommitteePool,
+		})
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: debugui.Path, Handler: ui.Handler})
+	}
+
+	var m *monitor.Service
+	if err := b.services.FetchService(&m); err == nil {
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/monitor/arrivals", Handler: m.ArrivalReportHandler})
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/monitor/sync_participation", Handler: m.SyncParticipationHandler})
+	}
 
 	if cliCtx.IsSet(cmd.EnableBackupWebhookFlag.Name) {
 		additionalHandlers = append(
@@ -908,6 +1143,23 @@ func (b *BeaconNode) registerGRPCGateway() error {
 		muxs = append(muxs, gatewayConfig.EthPbMux)
 	}
 
+	httpNamespaces, err := namespace.ParseSet(b.cliCtx.String(flags.HTTPNamespaces.Name))
+	if err != nil {
+		return errors.Wrapf(err, "could not parse --%s", flags.HTTPNamespaces.Name)
+	}
+	grpcNamespaces, err := namespace.ParseSet(b.cliCtx.String(flags.GRPCNamespaces.Name))
+	if err != nil {
+		return errors.Wrapf(err, "could not parse --%s", flags.GRPCNamespaces.Name)
+	}
+	if b.readReplica {
+		httpNamespaces = readReplicaNamespaces(httpNamespaces)
+		grpcNamespaces = readReplicaNamespaces(grpcNamespaces)
+	}
+	if missing := httpNamespaces.Missing(grpcNamespaces); len(missing) > 0 {
+		log.WithField("namespaces", missing).Warnf("HTTP API namespaces are not served over gRPC, "+
+			"their endpoints are unavailable in the gRPC gateway. Add them to --%s", flags.GRPCNamespaces.Name)
+	}
+
 	opts := []apigateway.Option{
 		apigateway.WithGatewayAddr(gatewayAddress),
 		apigateway.WithRemoteAddr(selfAddress),
@@ -916,6 +1168,8 @@ func (b *BeaconNode) registerGRPCGateway() error {
 		apigateway.WithRemoteCert(selfCert),
 		apigateway.WithMaxCallRecvMsgSize(maxCallSize),
 		apigateway.WithAllowedOrigins(allowedOrigins),
+		apigateway.WithAllowCredentials(!b.cliCtx.Bool(flags.GRPCGatewayCorsDisableCredentials.Name)),
+		apigateway.WithNamespaces(httpNamespaces),
 		apigateway.WithTimeout(uint64(timeout)),
 	}
 	if flags.EnableHTTPEthAPI(httpModules) {
@@ -928,6 +1182,30 @@ func (b *BeaconNode) registerGRPCGateway() error {
 	return b.services.RegisterService(g)
 }
 
+// apiAuth returns the API namespaces requiring a bearer token and the token read from the token file.
+func (b *BeaconNode) apiAuth() (namespace.Set, string, error) {
+	authNamespaces, err := namespace.ParseSet(b.cliCtx.String(flags.APIAuthNamespaces.Name))
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "could not parse --%s", flags.APIAuthNamespaces.Name)
+	}
+	if len(authNamespaces) == 0 {
+		return nil, "", nil
+	}
+	tokenFile := b.cliCtx.String(flags.APIAuthTokenFile.Name)
+	if tokenFile == "" {
+		return nil, "", errors.Errorf("--%s requires --%s", flags.APIAuthNamespaces.Name, flags.APIAuthTokenFile.Name)
+	}
+	enc, err := os.ReadFile(tokenFile) // #nosec G304
+	if err != nil {
+		return nil, "", errors.Wrap(err, "could not read API auth token file")
+	}
+	token := strings.TrimSpace(string(enc))
+	if token == "" {
+		return nil, "", errors.Errorf("API auth token file %s is empty", tokenFile)
+	}
+	return authNamespaces, token, nil
+}
+
 func (b *BeaconNode) registerDeterminsticGenesisService() error {
 	genesisTime := b.cliCtx.Uint64(flags.InteropGenesisTimeFlag.Name)
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
@@ -978,6 +1256,7 @@ func (b *BeaconNode) registerValidatorMonitorService() error {
 		AttestationNotifier: b,
 		StateGen:            b.stateGen,
 		HeadFetcher:         chainService,
+		TimeFetcher:         chainService,
 	}
 	svc, err := monitor.NewService(b.ctx, monitorConfig, tracked)
 	if err != nil {
@@ -986,6 +1265,50 @@ func (b *BeaconNode) registerValidatorMonitorService() error {
 	return b.services.RegisterService(svc)
 }
 
+func (b *BeaconNode) registerLightClientService() error {
+	if !b.cliCtx.Bool(flags.LightClientServer.Name) {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc, err := lightclient.NewService(b.ctx, &lightclient.Config{
+		StateNotifier: b,
+		BeaconDB:      b.db,
+		StateGen:      b.stateGen,
+		TimeFetcher:   chainService,
+		Broadcaster:   b.fetchP2P(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not initialize light client service")
+	}
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerTelemetryService() error {
+	endpoint := b.cliCtx.String(flags.TelemetryEndpoint.Name)
+	if endpoint == "" {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc, err := telemetry.NewService(b.ctx, &telemetry.Config{
+		Endpoint:      endpoint,
+		Interval:      b.cliCtx.Duration(flags.TelemetryInterval.Name),
+		DataDir:       b.cliCtx.String(cmd.DataDirFlag.Name),
+		HeadFetcher:   chainService,
+		TimeFetcher:   chainService,
+		PeersProvider: b.fetchP2P(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not initialize telemetry service")
+	}
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerBuilderService() error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -7,9 +7,12 @@ import (
 	"testing"
 
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
 	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/urfave/cli/v2"
@@ -59,10 +62,53 @@ func TestClearDB(t *testing.T) {
 	set.Bool(cmd.ForceClearDB.Name, true, "force clear db")
 
 	context := cli.NewContext(&app, set, nil)
-	_, err = New(context, WithPowchainFlagOptions([]powchain.Option{
+	node, err := New(context, WithPowchainFlagOptions([]powchain.Option{
 		powchain.WithHttpEndpoints([]string{endpoint}),
 	}))
 	require.NoError(t, err)
 
 	require.LogsContain(t, hook, "Removing database")
+	node.Close()
+}
+
+// TestReadReplica tests a read replica opens the database of a beacon node without registering the services
+// connecting to the network.
+func TestReadReplica(t *testing.T) {
+	hook := logTest.NewGlobal()
+
+	tmp := filepath.Join(t.TempDir(), "datadirtest")
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Bool("test-skip-pow", true, "skip pow dial")
+	set.String("datadir", tmp, "node data directory")
+	set.String("p2p-encoding", "ssz", "p2p encoding scheme")
+	set.Bool("demo-config", true, "demo configuration")
+	set.String("deposit-contract", "0x0000000000000000000000000000000000000000", "deposit contract address")
+
+	node, err := New(cli.NewContext(&app, set, nil))
+	require.NoError(t, err)
+	node.Close()
+
+	set.Bool(flags.ReadReplica.Name, true, "read replica")
+	replica, err := New(cli.NewContext(&app, set, nil))
+	require.NoError(t, err)
+	require.LogsContain(t, hook, "Running as a read replica")
+
+	var p2pService *p2p.Service
+	require.ErrorContains(t, "unknown service", replica.services.FetchService(&p2pService))
+	var rpcService *rpc.Service
+	require.NoError(t, replica.services.FetchService(&rpcService))
+
+	replica.Close()
+}
+
+func TestReadReplica_NoDatabase(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String("datadir", t.TempDir(), "node data directory")
+	set.Bool(flags.ReadReplica.Name, true, "read replica")
+
+	_, err := New(cli.NewContext(&app, set, nil))
+	require.ErrorContains(t, "no database found", err)
 }
@@ -0,0 +1,201 @@
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/api/namespace"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/urfave/cli/v2"
+)
+
+// startReadReplicaDB opens the database served by a read replica. Nothing is written to it, so the genesis and
+// checkpoint initializers, the migrations and the clearing of the database are not available.
+func (b *BeaconNode) startReadReplicaDB(cliCtx *cli.Context, depositAddress string) error {
+	if features.Get().EnableSlasher {
+		return errors.New("a read replica can't run a slasher")
+	}
+	if b.GenesisInitializer != nil || b.CheckpointInitializer != nil {
+		return errors.New("a read replica can't initialize its database from a genesis or checkpoint state")
+	}
+	dbPath := cliCtx.String(flags.DBPath.Name)
+	if dbPath == "" {
+		dbPath = filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	}
+	log.WithField("database-path", dbPath).Info("Opening database read-only for read replica")
+
+	d, err := db.NewDB(b.ctx, dbPath, &kv.Config{
+		InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+		ReadOnly:        true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not open read replica database")
+	}
+	b.db = d
+
+	depositCache, err := depositcache.New()
+	if err != nil {
+		return errors.Wrap(err, "could not create deposit cache")
+	}
+	b.depositCache = depositCache
+
+	knownContract, err := b.db.DepositContractAddress(b.ctx)
+	if err != nil {
+		return err
+	}
+	addr := common.HexToAddress(depositAddress)
+	if len(knownContract) > 0 && !bytes.Equal(addr.Bytes(), knownContract) {
+		return fmt.Errorf("database contract is %#x but tried to run with %#x. This likely means "+
+			"you are trying to serve a database of a different network", knownContract, addr.Bytes())
+	}
+	return nil
+}
+
+// registerReadReplicaServices registers the services of a read replica. It does not connect to peers or to an
+// execution node, the blockchain service only loads the head from the database for the API to serve.
+func (b *BeaconNode) registerReadReplicaServices() error {
+	log.Info("Running as a read replica, the validator API namespace is not served")
+
+	log.Debugln("Registering Attestation Pool Service")
+	if err := b.registerAttestationPool(); err != nil {
+		return err
+	}
+
+	log.Debugln("Starting Fork Choice")
+	b.startForkChoice()
+
+	log.Debugln("Registering Blockchain Service")
+	var attService *attestations.Service
+	if err := b.services.FetchService(&attService); err != nil {
+		return err
+	}
+	// skipcq: CRT-D0001
+	opts := append(
+		b.serviceFlagOpts.blockchainFlagOpts,
+		blockchain.WithDatabase(b.db),
+		blockchain.WithDepositCache(b.depositCache),
+		blockchain.WithAttestationPool(b.attestationPool),
+		blockchain.WithExitPool(b.exitPool),
+		blockchain.WithSlashingPool(b.slashingsPool),
+		blockchain.WithStateNotifier(b),
+		blockchain.WithForkChoiceStore(b.forkChoiceStore),
+		blockchain.WithAttestationService(attService),
+		blockchain.WithStateGen(b.stateGen),
+		blockchain.WithFinalizedStateAtStartUp(b.finalizedStateAtStartUp),
+		blockchain.WithProposerIdsCache(b.proposerIdsCache),
+		blockchain.WithReadOnlyDatabase(),
+	)
+	chainService, err := blockchain.NewService(b.ctx, opts...)
+	if err != nil {
+		return errors.Wrap(err, "could not register blockchain service")
+	}
+	if err := b.services.RegisterService(chainService); err != nil {
+		return err
+	}
+
+	log.Debugln("Registering RPC Service")
+	if err := b.registerReadReplicaRPCService(chainService); err != nil {
+		return err
+	}
+
+	log.Debugln("Registering GRPC Gateway Service")
+	return b.registerGRPCGateway()
+}
+
+// registerReadReplicaRPCService registers an RPC service which only has access to the database, the state
+// generator and the blockchain service.
+func (b *BeaconNode) registerReadReplicaRPCService(chainService *blockchain.Service) error {
+	maxMsgSize := b.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
+	enableDebugRPCEndpoints := b.cliCtx.Bool(flags.EnableDebugRPCEndpoints.Name)
+	if enableDebugRPCEndpoints {
+		maxMsgSize = int(math.Max(float64(maxMsgSize), debugGrpcMaxMsgSize))
+	}
+	grpcNamespaces, err := namespace.ParseSet(b.cliCtx.String(flags.GRPCNamespaces.Name))
+	if err != nil {
+		return errors.Wrapf(err, "could not parse --%s", flags.GRPCNamespaces.Name)
+	}
+	authNamespaces, authToken, err := b.apiAuth()
+	if err != nil {
+		return err
+	}
+
+	rpcService := rpc.NewService(b.ctx, &rpc.Config{
+		Host:                    b.cliCtx.String(flags.RPCHost.Name),
+		Port:                    b.cliCtx.String(flags.RPCPort.Name),
+		BeaconMonitoringHost:    b.cliCtx.String(cmd.MonitoringHostFlag.Name),
+		BeaconMonitoringPort:    b.cliCtx.Int(flags.MonitoringPortFlag.Name),
+		CertFlag:                b.cliCtx.String(flags.CertFlag.Name),
+		KeyFlag:                 b.cliCtx.String(flags.KeyFlag.Name),
+		BeaconDB:                b.db,
+		ChainInfoFetcher:        chainService,
+		HeadUpdater:             chainService,
+		HeadFetcher:             chainService,
+		CanonicalFetcher:        chainService,
+		ForkFetcher:             chainService,
+		FinalizationFetcher:     chainService,
+		GenesisTimeFetcher:      chainService,
+		GenesisFetcher:          chainService,
+		OptimisticModeFetcher:   chainService,
+		AttestationsPool:        b.attestationPool,
+		ExitPool:                b.exitPool,
+		SlashingsPool:           b.slashingsPool,
+		SyncCommitteeObjectPool: b.syncCommitteePool,
+		SyncService:             readReplicaSync{},
+		DepositFetcher:          b.depositCache,
+		PendingDepositFetcher:   b.depositCache,
+		BlockNotifier:           b,
+		StateNotifier:           b,
+		OperationNotifier:       b,
+		StateGen:                b.stateGen,
+		EnableDebugRPCEndpoints: enableDebugRPCEndpoints,
+		MaxMsgSize:              maxMsgSize,
+		ProposerIdsCache:        b.proposerIdsCache,
+		SlowRequestThreshold:    b.cliCtx.Duration(flags.RPCSlowRequestThreshold.Name),
+		Namespaces:              readReplicaNamespaces(grpcNamespaces),
+		AuthNamespaces:          authNamespaces,
+		AuthToken:               authToken,
+	})
+	return b.services.RegisterService(rpcService)
+}
+
+// readReplicaNamespaces removes the validator namespace from the API namespaces of a read replica, whose head
+// does not follow the chain.
+func readReplicaNamespaces(s namespace.Set) namespace.Set {
+	replica := make(namespace.Set, len(s))
+	for n := range s {
+		if n != namespace.Validator {
+			replica[n] = true
+		}
+	}
+	return replica
+}
+
+// readReplicaSync reports a read replica as synced, it serves its database as it was when opened.
+type readReplicaSync struct{}
+
+// Initialized --
+func (readReplicaSync) Initialized() bool { return true }
+
+// Syncing --
+func (readReplicaSync) Syncing() bool { return false }
+
+// Synced --
+func (readReplicaSync) Synced() bool { return true }
+
+// Status --
+func (readReplicaSync) Status() error { return nil }
+
+// Resync --
+func (readReplicaSync) Resync() error { return errors.New("a read replica can't resync") }
@@ -0,0 +1,76 @@
+package attestations
+
+import (
+	"context"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// defaultAggregationOffset is the time into each slot at which the pool is aggregated by default, a
+// twelfth of a slot before aggregates are due at two thirds of the slot.
+func defaultAggregationOffset() time.Duration {
+	return 2*slots.DivideSlotBy(3) - slots.DivideSlotBy(12)
+}
+
+// aggregateAttsAtOffset aggregates the unaggregated attestations of the pool at the aggregation offset
+// of every slot, so that block proposals and aggregation duties read from an already aggregated pool
+// instead of aggregating it on demand.
+func (s *Service) aggregateAttsAtOffset() {
+	// The genesis time is only known once the chain has started.
+	waitTicker := time.NewTicker(time.Second)
+	for s.genesisTime == 0 {
+		select {
+		case <-waitTicker.C:
+		case <-s.ctx.Done():
+			waitTicker.Stop()
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+	waitTicker.Stop()
+
+	genesis := time.Unix(int64(s.genesisTime), 0)
+	ticker := slots.NewSlotTickerWithOffset(genesis, s.cfg.AggregationOffset, params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case slot := <-ticker.C():
+			if err := s.aggregateAtts(s.ctx); err != nil {
+				log.WithError(err).WithField("slot", slot).Error("Could not aggregate attestations")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// aggregateAtts aggregates the unaggregated attestations of the pool, recording how long it took and
+// how many attestations it removed from the pool.
+func (s *Service) aggregateAtts(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "Operations.attestations.aggregateAtts")
+	defer span.End()
+
+	start := time.Now()
+	before := s.cfg.Pool.UnaggregatedAttestationCount() + s.cfg.Pool.AggregatedAttestationCount()
+	if err := s.cfg.Pool.AggregateUnaggregatedAttestations(ctx); err != nil {
+		return err
+	}
+	after := s.cfg.Pool.UnaggregatedAttestationCount() + s.cfg.Pool.AggregatedAttestationCount()
+	elapsed := time.Since(start)
+	aggregationDuration.Observe(float64(elapsed.Milliseconds()))
+	if before > after {
+		aggregationSavedAtts.Add(float64(before - after))
+	}
+	s.updateMetrics()
+	log.WithFields(logrus.Fields{
+		"attsBefore": before,
+		"attsAfter":  after,
+		"duration":   elapsed,
+	}).Debug("Aggregated attestation pool")
+	return nil
+}
@@ -0,0 +1,53 @@
+package attestations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestAggregateAtts(t *testing.T) {
+	s, err := NewService(context.Background(), &Config{Pool: NewPool()})
+	require.NoError(t, err)
+
+	priv, err := bls.RandKey()
+	require.NoError(t, err)
+	sig := priv.Sign([]byte("dummy_test_data"))
+	var mockRoot [32]byte
+	att := func(slot types.Slot, bits bitfield.Bitlist) *ethpb.Attestation {
+		return &ethpb.Attestation{
+			Data: &ethpb.AttestationData{
+				Slot:            slot,
+				BeaconBlockRoot: mockRoot[:],
+				Source:          &ethpb.Checkpoint{Root: mockRoot[:]},
+				Target:          &ethpb.Checkpoint{Root: mockRoot[:]},
+			},
+			AggregationBits: bits,
+			Signature:       sig.Marshal(),
+		}
+	}
+	atts := []*ethpb.Attestation{
+		att(0, bitfield.Bitlist{0b1001}),
+		att(0, bitfield.Bitlist{0b1010}),
+		att(0, bitfield.Bitlist{0b1100}),
+		att(1, bitfield.Bitlist{0b1001}),
+	}
+	require.NoError(t, s.cfg.Pool.SaveUnaggregatedAttestations(atts))
+
+	require.NoError(t, s.aggregateAtts(context.Background()))
+	// The attestations with the same data are aggregated, the one left alone stays unaggregated.
+	assert.Equal(t, 1, s.cfg.Pool.AggregatedAttestationCount())
+	assert.Equal(t, 1, s.cfg.Pool.UnaggregatedAttestationCount())
+	assert.DeepEqual(t, bitfield.Bitlist{0b1111}, s.cfg.Pool.AggregatedAttestations()[0].AggregationBits)
+
+	// Aggregating an already aggregated pool changes nothing.
+	require.NoError(t, s.aggregateAtts(context.Background()))
+	assert.Equal(t, 1, s.cfg.Pool.AggregatedAttestationCount())
+	assert.Equal(t, 1, s.cfg.Pool.UnaggregatedAttestationCount())
+}
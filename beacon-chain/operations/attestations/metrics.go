@@ -30,6 +30,15 @@ var (
 		Name: "expired_block_atts_total",
 		Help: "The number of expired and deleted block attestations in the pool.",
 	})
+	aggregationDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "attestation_pool_aggregation_milliseconds",
+		Help:    "Time taken to aggregate the unaggregated attestations of the pool at the aggregation offset of a slot.",
+		Buckets: []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000},
+	})
+	aggregationSavedAtts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "attestation_pool_aggregation_saved_atts_total",
+		Help: "The number of attestations removed from the pool by aggregating them at the aggregation offset of a slot.",
+	})
 )
 
 func (s *Service) updateMetrics() {
@@ -0,0 +1,158 @@
+package attestations
+
+import (
+	"container/heap"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	attaggregation "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation/aggregation/attestations"
+)
+
+// committeeKey identifies the committee the aggregation bits of an attestation refer to. The same
+// bit of two attestations for the same committee stands for the same attester, whatever their data.
+type committeeKey struct {
+	slot  types.Slot
+	index types.CommitteeIndex
+}
+
+// packCandidate is an attestation considered for inclusion in a block.
+type packCandidate struct {
+	att   *ethpb.Attestation
+	bits  *bitfield.Bitlist64
+	key   committeeKey
+	order int
+	// profit is the number of attesters not covered yet as of the last evaluation of the candidate.
+	profit uint64
+}
+
+// packQueue is a max-heap of candidates ordered by profit, then by the highest slot and the
+// highest number of attesters. Remaining ties keep the input order.
+type packQueue []*packCandidate
+
+func (q packQueue) Len() int { return len(q) }
+
+func (q packQueue) Less(i, j int) bool {
+	if q[i].profit != q[j].profit {
+		return q[i].profit > q[j].profit
+	}
+	if q[i].key.slot != q[j].key.slot {
+		return q[i].key.slot > q[j].key.slot
+	}
+	if q[i].bits.Count() != q[j].bits.Count() {
+		return q[i].bits.Count() > q[j].bits.Count()
+	}
+	return q[i].order < q[j].order
+}
+
+func (q packQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *packQueue) Push(x interface{}) { *q = append(*q, x.(*packCandidate)) }
+
+func (q *packQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	c := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return c
+}
+
+// PackAttestations aggregates the attestations sharing the same data and selects up to limit of
+// them for inclusion in a block, maximizing the number of unique attesters covered. Attestations
+// are greedily selected by marginal profit, the number of their attesters not covered by the
+// attestations selected so far, and attestations adding no new attester are left out.
+// Aggregation occurs in-place, clone the input attestations should they need to be preserved.
+func PackAttestations(atts []*ethpb.Attestation, limit uint64) ([]*ethpb.Attestation, error) {
+	aggregated, err := aggregateByData(atts)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := make(packQueue, 0, len(aggregated))
+	for i, att := range aggregated {
+		bits, err := att.AggregationBits.ToBitlist64()
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, &packCandidate{
+			att:    att,
+			bits:   bits,
+			key:    committeeKey{slot: att.Data.Slot, index: att.Data.CommitteeIndex},
+			order:  i,
+			profit: bits.Count(),
+		})
+	}
+	heap.Init(&queue)
+
+	covered := make(map[committeeKey]*bitfield.Bitlist64)
+	packed := make([]*ethpb.Attestation, 0, len(queue))
+	for queue.Len() > 0 && uint64(len(packed)) < limit {
+		c, ok := heap.Pop(&queue).(*packCandidate)
+		if !ok {
+			continue
+		}
+		profit, err := c.marginalProfit(covered[c.key])
+		if err != nil {
+			return nil, err
+		}
+		if profit == 0 {
+			continue
+		}
+		// Profits only decrease as attestations get selected, so a candidate whose profit is
+		// unchanged since its last evaluation is still ahead of all the others.
+		if profit < c.profit {
+			c.profit = profit
+			heap.Push(&queue, c)
+			continue
+		}
+		packed = append(packed, c.att)
+		if cov, ok := covered[c.key]; ok {
+			if err := cov.NoAllocOr(c.bits, cov); err != nil {
+				return nil, err
+			}
+		} else {
+			covered[c.key] = c.bits.Clone()
+		}
+	}
+	return packed, nil
+}
+
+// marginalProfit returns the number of attesters of the candidate outside of the covered ones.
+func (c *packCandidate) marginalProfit(covered *bitfield.Bitlist64) (uint64, error) {
+	if covered == nil {
+		return c.bits.Count(), nil
+	}
+	overlap, err := c.bits.AndCount(covered)
+	if err != nil {
+		return 0, err
+	}
+	return c.bits.Count() - overlap, nil
+}
+
+// aggregateByData aggregates the attestations sharing the same data, keeping the order in which
+// each data first appears.
+func aggregateByData(atts []*ethpb.Attestation) ([]*ethpb.Attestation, error) {
+	dataRoots := make([][32]byte, 0, len(atts))
+	attsByDataRoot := make(map[[32]byte][]*ethpb.Attestation, len(atts))
+	for _, att := range atts {
+		attDataRoot, err := att.Data.HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := attsByDataRoot[attDataRoot]; !ok {
+			dataRoots = append(dataRoots, attDataRoot)
+		}
+		attsByDataRoot[attDataRoot] = append(attsByDataRoot[attDataRoot], att)
+	}
+
+	aggregated := make([]*ethpb.Attestation, 0, len(atts))
+	for _, r := range dataRoots {
+		as, err := attaggregation.Aggregate(attsByDataRoot[r])
+		if err != nil {
+			return nil, err
+		}
+		aggregated = append(aggregated, as...)
+	}
+	return aggregated, nil
+}
@@ -0,0 +1,149 @@
+package attestations
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestPackAttestations(t *testing.T) {
+	priv, err := bls.RandKey()
+	require.NoError(t, err)
+	sig := priv.Sign([]byte("dummy_test_data")).Marshal()
+
+	type testData struct {
+		slot  types.Slot
+		index types.CommitteeIndex
+		head  byte
+		bits  bitfield.Bitlist
+	}
+	getAtts := func(data []testData) []*ethpb.Attestation {
+		atts := make([]*ethpb.Attestation, 0, len(data))
+		for _, d := range data {
+			root := make([]byte, 32)
+			root[0] = d.head
+			atts = append(atts, util.NewAttestationUtil().HydrateAttestation(&ethpb.Attestation{
+				Data: &ethpb.AttestationData{
+					Slot:            d.slot,
+					CommitteeIndex:  d.index,
+					BeaconBlockRoot: root,
+				},
+				AggregationBits: d.bits,
+				Signature:       sig,
+			}))
+		}
+		return atts
+	}
+
+	tests := []struct {
+		name   string
+		inputs []testData
+		limit  uint64
+		want   []testData
+	}{
+		{
+			name:   "no atts",
+			inputs: []testData{},
+			limit:  128,
+			want:   []testData{},
+		},
+		{
+			name: "single att",
+			inputs: []testData{
+				{4, 0, 0, bitfield.Bitlist{0b11100000, 0b1}},
+			},
+			limit: 128,
+			want: []testData{
+				{4, 0, 0, bitfield.Bitlist{0b11100000, 0b1}},
+			},
+		},
+		{
+			name: "ordered by profit then by slot",
+			inputs: []testData{
+				{1, 0, 0, bitfield.Bitlist{0b11000000, 0b1}},
+				{4, 0, 0, bitfield.Bitlist{0b00000011, 0b1}},
+				{2, 0, 0, bitfield.Bitlist{0b11100000, 0b1}},
+			},
+			limit: 128,
+			want: []testData{
+				{2, 0, 0, bitfield.Bitlist{0b11100000, 0b1}},
+				{4, 0, 0, bitfield.Bitlist{0b00000011, 0b1}},
+				{1, 0, 0, bitfield.Bitlist{0b11000000, 0b1}},
+			},
+		},
+		{
+			name: "selected by marginal profit",
+			// Votes for different heads of the same committee are not aggregated, but their bits
+			// stand for the same attesters. Once the first attestation is selected, 0b00001100
+			// brings two new attesters while 0b11001000 brings a single one.
+			inputs: []testData{
+				{1, 0, 1, bitfield.Bitlist{0b11001000, 0b1}},
+				{1, 0, 2, bitfield.Bitlist{0b11000011, 0b1}},
+				{1, 0, 3, bitfield.Bitlist{0b00001100, 0b1}},
+			},
+			limit: 128,
+			want: []testData{
+				{1, 0, 2, bitfield.Bitlist{0b11000011, 0b1}},
+				{1, 0, 3, bitfield.Bitlist{0b00001100, 0b1}},
+			},
+		},
+		{
+			name: "committees do not overlap",
+			inputs: []testData{
+				{1, 0, 1, bitfield.Bitlist{0b11000011, 0b1}},
+				{1, 1, 1, bitfield.Bitlist{0b11000011, 0b1}},
+				{2, 0, 1, bitfield.Bitlist{0b11000011, 0b1}},
+			},
+			limit: 128,
+			want: []testData{
+				{2, 0, 1, bitfield.Bitlist{0b11000011, 0b1}},
+				{1, 0, 1, bitfield.Bitlist{0b11000011, 0b1}},
+				{1, 1, 1, bitfield.Bitlist{0b11000011, 0b1}},
+			},
+		},
+		{
+			name: "aggregated by data",
+			// The first two attestations are aggregated, covering all the attesters of the last one.
+			inputs: []testData{
+				{1, 0, 0, bitfield.Bitlist{0b11000000, 0b1}},
+				{1, 0, 0, bitfield.Bitlist{0b00000011, 0b1}},
+				{1, 0, 1, bitfield.Bitlist{0b01000001, 0b1}},
+			},
+			limit: 128,
+			want: []testData{
+				{1, 0, 0, bitfield.Bitlist{0b11000011, 0b1}},
+			},
+		},
+		{
+			name: "limited",
+			inputs: []testData{
+				{1, 0, 0, bitfield.Bitlist{0b10000000, 0b1}},
+				{2, 0, 0, bitfield.Bitlist{0b11000000, 0b1}},
+				{3, 0, 0, bitfield.Bitlist{0b11100000, 0b1}},
+			},
+			limit: 2,
+			want: []testData{
+				{3, 0, 0, bitfield.Bitlist{0b11100000, 0b1}},
+				{2, 0, 0, bitfield.Bitlist{0b11000000, 0b1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atts, err := PackAttestations(getAtts(tt.inputs), tt.limit)
+			require.NoError(t, err)
+			want := getAtts(tt.want)
+			require.Equal(t, len(want), len(atts))
+			for i := range want {
+				assert.DeepEqual(t, want[i].Data, atts[i].Data)
+				assert.DeepEqual(t, want[i].AggregationBits, atts[i].AggregationBits)
+			}
+		})
+	}
+}
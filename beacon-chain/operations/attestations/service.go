@@ -5,6 +5,7 @@ package attestations
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -28,6 +29,9 @@ type Service struct {
 type Config struct {
 	Pool          Pool
 	pruneInterval time.Duration
+	// AggregationOffset is the time into each slot at which the unaggregated attestations of the
+	// pool are aggregated, defaultAggregationOffset if zero.
+	AggregationOffset time.Duration
 }
 
 // NewService instantiates a new attestation pool service instance that will
@@ -40,6 +44,13 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		cfg.pruneInterval = time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
 	}
 
+	if cfg.AggregationOffset == 0 {
+		cfg.AggregationOffset = defaultAggregationOffset()
+	}
+	if cfg.AggregationOffset < 0 || cfg.AggregationOffset >= time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second {
+		return nil, fmt.Errorf("aggregation offset %s is not within a slot", cfg.AggregationOffset)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	return &Service{
 		cfg:                      cfg,
@@ -53,6 +64,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 func (s *Service) Start() {
 	go s.prepareForkChoiceAtts()
 	go s.pruneAttsPool()
+	go s.aggregateAttsAtOffset()
 }
 
 // Stop the beacon block attestation pool service's main event loop
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 )
@@ -21,3 +23,19 @@ func TestStatus_Error(t *testing.T) {
 	s := &Service{err: err}
 	assert.ErrorContains(t, s.err.Error(), s.Status())
 }
+
+func TestNewService_AggregationOffset(t *testing.T) {
+	s, err := NewService(context.Background(), &Config{})
+	require.NoError(t, err)
+	assert.Equal(t, 7*time.Second, s.cfg.AggregationOffset)
+
+	s, err = NewService(context.Background(), &Config{AggregationOffset: 11 * time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, 11*time.Second, s.cfg.AggregationOffset)
+
+	slot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	_, err = NewService(context.Background(), &Config{AggregationOffset: slot})
+	assert.ErrorContains(t, "is not within a slot", err)
+	_, err = NewService(context.Background(), &Config{AggregationOffset: -time.Second})
+	assert.ErrorContains(t, "is not within a slot", err)
+}
@@ -44,3 +44,13 @@ func (*PoolMock) MarkIncludedAttesterSlashing(_ *ethpb.AttesterSlashing) {
 func (*PoolMock) MarkIncludedProposerSlashing(_ *ethpb.ProposerSlashing) {
 	panic("implement me")
 }
+
+// UnmarkIncludedAttesterSlashing --
+func (*PoolMock) UnmarkIncludedAttesterSlashing(_ *ethpb.AttesterSlashing) {
+	panic("implement me")
+}
+
+// UnmarkIncludedProposerSlashing --
+func (*PoolMock) UnmarkIncludedProposerSlashing(_ *ethpb.ProposerSlashing) {
+	panic("implement me")
+}
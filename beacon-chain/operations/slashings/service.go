@@ -261,6 +261,25 @@ func (p *Pool) MarkIncludedProposerSlashing(ps *ethpb.ProposerSlashing) {
 	numProposerSlashingsIncluded.Inc()
 }
 
+// UnmarkIncludedAttesterSlashing is used when a block including an attester slashing has been orphaned
+// by a reorg, so the slashing can be inserted into the pool again.
+func (p *Pool) UnmarkIncludedAttesterSlashing(as *ethpb.AttesterSlashing) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	slashedVal := slice.IntersectionUint64(as.Attestation_1.AttestingIndices, as.Attestation_2.AttestingIndices)
+	for _, val := range slashedVal {
+		delete(p.included, types.ValidatorIndex(val))
+	}
+}
+
+// UnmarkIncludedProposerSlashing is used when a block including a proposer slashing has been orphaned
+// by a reorg, so the slashing can be inserted into the pool again.
+func (p *Pool) UnmarkIncludedProposerSlashing(ps *ethpb.ProposerSlashing) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.included, ps.Header_1.Header.ProposerIndex)
+}
+
 // this function checks a few items about a validator before proceeding with inserting
 // a proposer/attester slashing into the pool. First, it checks if the validator
 // has been recently included in the pool, then it checks if the validator is slashable.
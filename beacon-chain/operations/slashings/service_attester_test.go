@@ -447,6 +447,18 @@ func TestPool_MarkIncludedAttesterSlashing(t *testing.T) {
 	}
 }
 
+func TestPool_UnmarkIncludedAttesterSlashing(t *testing.T) {
+	p := &Pool{
+		included: map[types.ValidatorIndex]bool{
+			1: true,
+			2: true,
+			3: true,
+		},
+	}
+	p.UnmarkIncludedAttesterSlashing(attesterSlashingForValIdx(1, 3))
+	assert.DeepEqual(t, map[types.ValidatorIndex]bool{2: true}, p.included)
+}
+
 func TestPool_PendingAttesterSlashings(t *testing.T) {
 	type fields struct {
 		pending []*PendingAttesterSlashing
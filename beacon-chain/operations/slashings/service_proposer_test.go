@@ -322,6 +322,17 @@ func TestPool_MarkIncludedProposerSlashing(t *testing.T) {
 	}
 }
 
+func TestPool_UnmarkIncludedProposerSlashing(t *testing.T) {
+	p := &Pool{
+		included: map[types.ValidatorIndex]bool{
+			1: true,
+			2: true,
+		},
+	}
+	p.UnmarkIncludedProposerSlashing(proposerSlashingForValIdx(2))
+	assert.DeepEqual(t, map[types.ValidatorIndex]bool{1: true}, p.included)
+}
+
 func TestPool_PendingProposerSlashings(t *testing.T) {
 	type fields struct {
 		pending []*ethpb.ProposerSlashing
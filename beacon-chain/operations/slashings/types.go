@@ -31,6 +31,8 @@ type PoolManager interface {
 	PendingProposerSlashings(ctx context.Context, state state.ReadOnlyBeaconState, noLimit bool) []*ethpb.ProposerSlashing
 	MarkIncludedAttesterSlashing(as *ethpb.AttesterSlashing)
 	MarkIncludedProposerSlashing(ps *ethpb.ProposerSlashing)
+	UnmarkIncludedAttesterSlashing(as *ethpb.AttesterSlashing)
+	UnmarkIncludedProposerSlashing(ps *ethpb.ProposerSlashing)
 }
 
 // Pool is a concrete implementation of PoolManager.
@@ -6,15 +6,76 @@ import (
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/container/queue"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	synccontribution "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation/aggregation/sync_contribution"
 )
 
 // To give two slots tolerance for objects that arrive earlier.
 // This account for previous slot, current slot, two future slots.
 const syncCommitteeMaxQueueSize = 4
 
+// contributionKey identifies the contributions which can be aggregated together in the pool.
+type contributionKey struct {
+	subcommitteeIndex uint64
+	blockRoot         [32]byte
+}
+
+// slotContributions holds the contributions of a slot, along with the best aggregate of the
+// contributions seen so far per (subcommittee index, block root).
+type slotContributions struct {
+	contributions []*ethpb.SyncCommitteeContribution
+	byKey         map[contributionKey][]*ethpb.SyncCommitteeContribution
+	best          map[contributionKey]*ethpb.SyncCommitteeContribution
+}
+
+func newSlotContributions() *slotContributions {
+	return &slotContributions{
+		contributions: make([]*ethpb.SyncCommitteeContribution, 0),
+		byKey:         make(map[contributionKey][]*ethpb.SyncCommitteeContribution),
+		best:          make(map[contributionKey]*ethpb.SyncCommitteeContribution),
+	}
+}
+
+// insert adds the contribution and updates the best aggregate of its key. The new contribution is
+// greedily aggregated with the previously seen contributions it does not overlap with, and the
+// result replaces the best aggregate if it has a higher participation.
+func (sc *slotContributions) insert(cont *ethpb.SyncCommitteeContribution) error {
+	key := contributionKey{
+		subcommitteeIndex: cont.SubcommitteeIndex,
+		blockRoot:         bytesutil.ToBytes32(cont.BlockRoot),
+	}
+	candidate := cont
+	for _, c := range sc.byKey[key] {
+		if c.AggregationBits.Count() == 0 {
+			continue
+		}
+		overlaps, err := candidate.AggregationBits.Overlaps(c.AggregationBits)
+		if err != nil {
+			return err
+		}
+		if overlaps {
+			continue
+		}
+		aggregated, err := synccontribution.Aggregate([]*ethpb.SyncCommitteeContribution{candidate, c})
+		if err != nil {
+			return errors.Wrap(err, "could not aggregate sync committee contributions")
+		}
+		if len(aggregated) == 1 {
+			candidate = aggregated[0]
+		}
+	}
+	if best, ok := sc.best[key]; !ok || candidate.AggregationBits.Count() > best.AggregationBits.Count() {
+		sc.best[key] = candidate
+	}
+	sc.byKey[key] = append(sc.byKey[key], cont)
+	sc.contributions = append(sc.contributions, cont)
+	return nil
+}
+
 // SaveSyncCommitteeContribution saves a sync committee contribution in to a priority queue.
-// The priority queue is capped at syncCommitteeMaxQueueSize contributions.
+// The best aggregate per (slot, subcommittee index, block root) is maintained as contributions are saved.
+// The priority queue is capped at syncCommitteeMaxQueueSize slots.
 func (s *Store) SaveSyncCommitteeContribution(cont *ethpb.SyncCommitteeContribution) error {
 	if cont == nil {
 		return errNilContribution
@@ -32,24 +93,35 @@ func (s *Store) SaveSyncCommitteeContribution(cont *ethpb.SyncCommitteeContribut
 
 	// Contributions exist in the queue. Append instead of insert new.
 	if item != nil {
-		contributions, ok := item.Value.([]*ethpb.SyncCommitteeContribution)
+		sc, ok := item.Value.(*slotContributions)
 		if !ok {
-			return errors.New("not typed []ethpb.SyncCommitteeContribution")
+			return errors.New("not typed *slotContributions")
 		}
 
-		contributions = append(contributions, copied)
-		savedSyncCommitteeContributionTotal.Inc()
-		return s.contributionCache.Push(&queue.Item{
+		insertErr := sc.insert(copied)
+		// The slot contributions are pushed back even if the contribution could not be inserted.
+		if err := s.contributionCache.Push(&queue.Item{
 			Key:      syncCommitteeKey(cont.Slot),
-			Value:    contributions,
+			Value:    sc,
 			Priority: int64(cont.Slot),
-		})
+		}); err != nil {
+			return err
+		}
+		if insertErr != nil {
+			return insertErr
+		}
+		savedSyncCommitteeContributionTotal.Inc()
+		return nil
 	}
 
 	// Contribution does not exist. Insert new.
+	sc := newSlotContributions()
+	if err := sc.insert(copied); err != nil {
+		return err
+	}
 	if err := s.contributionCache.Push(&queue.Item{
 		Key:      syncCommitteeKey(cont.Slot),
-		Value:    []*ethpb.SyncCommitteeContribution{copied},
+		Value:    sc,
 		Priority: int64(cont.Slot),
 	}); err != nil {
 		return err
@@ -77,14 +149,37 @@ func (s *Store) SyncCommitteeContributions(slot types.Slot) ([]*ethpb.SyncCommit
 		return []*ethpb.SyncCommitteeContribution{}, nil
 	}
 
-	contributions, ok := item.Value.([]*ethpb.SyncCommitteeContribution)
+	sc, ok := item.Value.(*slotContributions)
 	if !ok {
-		return nil, errors.New("not typed []ethpb.SyncCommitteeContribution")
+		return nil, errors.New("not typed *slotContributions")
 	}
 
+	contributions := make([]*ethpb.SyncCommitteeContribution, len(sc.contributions))
+	copy(contributions, sc.contributions)
 	return contributions, nil
 }
 
+// BestSyncCommitteeContribution returns the aggregate with the highest participation of the contributions
+// for the given slot, subcommittee index and block root, or nil if there is none.
+func (s *Store) BestSyncCommitteeContribution(
+	slot types.Slot, subcommitteeIndex uint64, blockRoot [32]byte,
+) (*ethpb.SyncCommitteeContribution, error) {
+	s.contributionLock.RLock()
+	defer s.contributionLock.RUnlock()
+
+	item := s.contributionCache.RetrieveByKey(syncCommitteeKey(slot))
+	if item == nil {
+		return nil, nil
+	}
+
+	sc, ok := item.Value.(*slotContributions)
+	if !ok {
+		return nil, errors.New("not typed *slotContributions")
+	}
+
+	return sc.best[contributionKey{subcommitteeIndex: subcommitteeIndex, blockRoot: blockRoot}], nil
+}
+
 func syncCommitteeKey(slot types.Slot) string {
 	return strconv.FormatUint(uint64(slot), 10)
 }
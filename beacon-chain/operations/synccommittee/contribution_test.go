@@ -3,6 +3,8 @@ package synccommittee
 import (
 	"testing"
 
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/require"
 )
@@ -106,3 +108,208 @@ func TestSyncCommitteeContributionCache_RoundTrip(t *testing.T) {
 		{Slot: 6, SubcommitteeIndex: 1, Signature: []byte{'l'}},
 	}, conts)
 }
+
+func TestSyncCommitteeContributionCache_BestPerKey(t *testing.T) {
+	store := NewStore()
+
+	root := [32]byte{'r'}
+	otherRoot := [32]byte{'o'}
+	sig := bls.NewAggregateSignature().Marshal()
+	conts := []*ethpb.SyncCommitteeContribution{
+		{Slot: 1, SubcommitteeIndex: 0, BlockRoot: root[:], AggregationBits: []byte{0b0001}, Signature: sig},
+		{Slot: 1, SubcommitteeIndex: 0, BlockRoot: root[:], AggregationBits: []byte{0b1001}, Signature: sig},
+		{Slot: 1, SubcommitteeIndex: 0, BlockRoot: root[:], AggregationBits: []byte{0b0110}, Signature: sig},
+		{Slot: 1, SubcommitteeIndex: 0, BlockRoot: otherRoot[:], AggregationBits: []byte{0b0011}, Signature: sig},
+		{Slot: 1, SubcommitteeIndex: 0, BlockRoot: otherRoot[:], AggregationBits: []byte{0b0010}, Signature: sig},
+		{Slot: 1, SubcommitteeIndex: 1, BlockRoot: root[:], AggregationBits: []byte{0b0100}, Signature: sig},
+	}
+	for _, c := range conts {
+		require.NoError(t, store.SaveSyncCommitteeContribution(c))
+	}
+
+	// The last contribution is aggregated with the first one, which it does not overlap with.
+	best, err := store.BestSyncCommitteeContribution(1, 0, root)
+	require.NoError(t, err)
+	require.DeepEqual(t, bitfield.Bitvector128{0b0111}, best.AggregationBits)
+	// A contribution with a lower participation does not replace the best one.
+	best, err = store.BestSyncCommitteeContribution(1, 0, otherRoot)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, conts[3], best)
+	best, err = store.BestSyncCommitteeContribution(1, 1, root)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, conts[5], best)
+
+	best, err = store.BestSyncCommitteeContribution(1, 2, root)
+	require.NoError(t, err)
+	require.Equal(t, (*ethpb.SyncCommitteeContribution)(nil), best)
+	best, err = store.BestSyncCommitteeContribution(2, 0, root)
+	require.NoError(t, err)
+	require.Equal(t, (*ethpb.SyncCommitteeContribution)(nil), best)
+
+	// All the contributions are still retrievable.
+	got, err := store.SyncCommitteeContributions(1)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, conts, got)
+}
+
+func TestSyncCommitteeContributionCache_BestDedupsSubsets(t *testing.T) {
+	root := [32]byte{'r'}
+	sig := bls.NewAggregateSignature().Marshal()
+	tests := []struct {
+		name string
+		cs   []*ethpb.SyncCommitteeContribution
+		// want is the aggregation bits of the best contribution per subcommittee index.
+		want map[uint64]bitfield.Bitvector128
+	}{
+		{
+			name: "single item",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.NewBitvector128()},
+			},
+			want: map[uint64]bitfield.Bitvector128{0: bitfield.NewBitvector128()},
+		},
+		{
+			name: "two items no duplicates",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.Bitvector128{0b10111110, 0x01}},
+				{AggregationBits: bitfield.Bitvector128{0b01111111, 0x01}},
+			},
+			want: map[uint64]bitfield.Bitvector128{0: {0b01111111, 0x01}},
+		},
+		{
+			name: "two items with duplicates",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.Bitvector128{0xba, 0x01}},
+				{AggregationBits: bitfield.Bitvector128{0xba, 0x01}},
+			},
+			want: map[uint64]bitfield.Bitvector128{0: {0xba, 0x01}},
+		},
+		{
+			name: "sorted with duplicates",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.Bitvector128{0b11001111, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b01101101, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b01101101, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b00001111, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b00000011, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b00000001, 0b1}},
+			},
+			want: map[uint64]bitfield.Bitvector128{0: {0b11001111, 0b1}},
+		},
+		{
+			name: "unsorted with duplicates",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.Bitvector128{0b00001111, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b11001111, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b10100101, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b10100101, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b00000001, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b11001111, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b01101101, 0b1}},
+			},
+			want: map[uint64]bitfield.Bitvector128{0: {0b11001111, 0b1}},
+		},
+		{
+			name: "superset replaces subset",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.Bitvector128{0b00000011, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b00001111, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b11001111, 0b1}},
+			},
+			want: map[uint64]bitfield.Bitvector128{0: {0b11001111, 0b1}},
+		},
+		{
+			name: "subset does not replace superset",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.Bitvector128{0b11001111, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b00001111, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b00000001, 0b1}},
+			},
+			want: map[uint64]bitfield.Bitvector128{0: {0b11001111, 0b1}},
+		},
+		{
+			name: "no proper subset, first of equal participation is kept",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.Bitvector128{0b00000101, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b00000011, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b10000001, 0b1}},
+			},
+			want: map[uint64]bitfield.Bitvector128{0: {0b00000101, 0b1}},
+		},
+		{
+			name: "no proper subset (different index)",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.Bitvector128{0b00000101, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b00000011, 0b1}},
+				{SubcommitteeIndex: 1, AggregationBits: bitfield.Bitvector128{0b10000001, 0b1}},
+				{SubcommitteeIndex: 1, AggregationBits: bitfield.Bitvector128{0b00011001, 0b1}},
+			},
+			want: map[uint64]bitfield.Bitvector128{
+				0: {0b00000101, 0b1},
+				1: {0b00011001, 0b1},
+			},
+		},
+		{
+			name: "proper subset (different index)",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{SubcommitteeIndex: 1, AggregationBits: bitfield.Bitvector128{0b00001111, 0b1}},
+				{SubcommitteeIndex: 1, AggregationBits: bitfield.Bitvector128{0b11001111, 0b1}},
+				{SubcommitteeIndex: 1, AggregationBits: bitfield.Bitvector128{0b00001111, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b00000001, 0b1}},
+				{SubcommitteeIndex: 1, AggregationBits: bitfield.Bitvector128{0b00000011, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b00000001, 0b1}},
+				{AggregationBits: bitfield.Bitvector128{0b01101101, 0b1}},
+			},
+			want: map[uint64]bitfield.Bitvector128{
+				0: {0b01101101, 0b1},
+				1: {0b11001111, 0b1},
+			},
+		},
+		{
+			name: "overlapping bits are not aggregated",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.Bitvector128{0b0011}},
+				{AggregationBits: bitfield.Bitvector128{0b0110}},
+			},
+			want: map[uint64]bitfield.Bitvector128{0: {0b0011}},
+		},
+		{
+			name: "non overlapping bits are aggregated",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.Bitvector128{0b01}},
+				{AggregationBits: bitfield.Bitvector128{0b10}},
+			},
+			want: map[uint64]bitfield.Bitvector128{0: {0b11}},
+		},
+		{
+			name: "subset of the aggregate does not replace it",
+			cs: []*ethpb.SyncCommitteeContribution{
+				{AggregationBits: bitfield.Bitvector128{0b0011}},
+				{AggregationBits: bitfield.Bitvector128{0b1100}},
+				{AggregationBits: bitfield.Bitvector128{0b0111}},
+			},
+			want: map[uint64]bitfield.Bitvector128{0: {0b1111}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore()
+			for _, c := range tt.cs {
+				c.Slot = 1
+				c.BlockRoot = root[:]
+				c.Signature = sig
+				require.NoError(t, store.SaveSyncCommitteeContribution(c))
+			}
+			for i, want := range tt.want {
+				best, err := store.BestSyncCommitteeContribution(1, i, root)
+				require.NoError(t, err)
+				require.NotNil(t, best)
+				require.DeepEqual(t, want, best.AggregationBits)
+			}
+			// No contribution is dropped from the pool.
+			got, err := store.SyncCommitteeContributions(1)
+			require.NoError(t, err)
+			require.DeepSSZEqual(t, tt.cs, got)
+		})
+	}
+}
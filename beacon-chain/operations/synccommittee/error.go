@@ -5,4 +5,5 @@ import "github.com/pkg/errors"
 var (
 	errNilMessage      = errors.New("sync committee message is nil")
 	errNilContribution = errors.New("sync committee contribution is nil")
+	errExpiredMessage  = errors.New("sync committee message is older than the retention period")
 )
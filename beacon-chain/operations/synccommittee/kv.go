@@ -3,22 +3,40 @@ package synccommittee
 import (
 	"sync"
 
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/container/queue"
 )
 
 // Store defines the caches for various sync committee objects
 // such as message(un-aggregated) and contribution(aggregated).
 type Store struct {
-	messageLock       sync.RWMutex
-	messageCache      *queue.PriorityQueue
-	contributionLock  sync.RWMutex
-	contributionCache *queue.PriorityQueue
+	messageLock        sync.RWMutex
+	messageCache       *queue.PriorityQueue
+	messageGracePeriod types.Slot
+	messageMinSlot     types.Slot
+	contributionLock   sync.RWMutex
+	contributionCache  *queue.PriorityQueue
+}
+
+// Option for the sync committee store.
+type Option func(s *Store)
+
+// WithMessageGracePeriod sets the number of slots prior to the current slot
+// for which sync committee messages are retained in the store.
+func WithMessageGracePeriod(slots types.Slot) Option {
+	return func(s *Store) {
+		s.messageGracePeriod = slots
+	}
 }
 
 // NewStore initializes a new sync committee store.
-func NewStore() *Store {
-	return &Store{
+func NewStore(opts ...Option) *Store {
+	s := &Store{
 		messageCache:      queue.New(),
 		contributionCache: queue.New(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
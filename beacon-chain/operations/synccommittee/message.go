@@ -1,6 +1,8 @@
 package synccommittee
 
 import (
+	"sort"
+
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/container/queue"
@@ -8,15 +10,22 @@ import (
 )
 
 // SaveSyncCommitteeMessage saves a sync committee message in to a priority queue.
-// The priority queue capped at syncCommitteeMaxQueueSize contributions.
+// The priority queue capped at syncCommitteeMaxQueueSize contributions. Messages for
+// slots that have already been pruned are rejected.
 func (s *Store) SaveSyncCommitteeMessage(msg *ethpb.SyncCommitteeMessage) error {
 	if msg == nil {
+		rejectedSyncCommitteeMessageTotal.WithLabelValues("nil").Inc()
 		return errNilMessage
 	}
 
 	s.messageLock.Lock()
 	defer s.messageLock.Unlock()
 
+	if msg.Slot < s.messageMinSlot {
+		rejectedSyncCommitteeMessageTotal.WithLabelValues("expired").Inc()
+		return errExpiredMessage
+	}
+
 	item, err := s.messageCache.PopByKey(syncCommitteeKey(msg.Slot))
 	if err != nil {
 		return err
@@ -51,9 +60,13 @@ func (s *Store) SaveSyncCommitteeMessage(msg *ethpb.SyncCommitteeMessage) error
 
 	// Trim messages in queue down to syncCommitteeMaxQueueSize.
 	if s.messageCache.Len() > syncCommitteeMaxQueueSize {
-		if _, err := s.messageCache.Pop(); err != nil {
+		item, err := s.messageCache.Pop()
+		if err != nil {
 			return err
 		}
+		if messages, ok := item.Value.([]*ethpb.SyncCommitteeMessage); ok {
+			prunedSyncCommitteeMessageTotal.Add(float64(len(messages)))
+		}
 	}
 
 	return nil
@@ -77,3 +90,57 @@ func (s *Store) SyncCommitteeMessages(slot types.Slot) ([]*ethpb.SyncCommitteeMe
 
 	return messages, nil
 }
+
+// AllSyncCommitteeMessages returns the sync committee messages of all slots in the queue,
+// ordered by slot. Messages are not removed from the queue.
+func (s *Store) AllSyncCommitteeMessages() ([]*ethpb.SyncCommitteeMessage, error) {
+	s.messageLock.RLock()
+	defer s.messageLock.RUnlock()
+
+	items := s.messageCache.Items()
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Priority < items[j].Priority
+	})
+	var messages []*ethpb.SyncCommitteeMessage
+	for _, item := range items {
+		m, ok := item.Value.([]*ethpb.SyncCommitteeMessage)
+		if !ok {
+			return nil, errors.New("not typed []ethpb.SyncCommitteeMessage")
+		}
+		messages = append(messages, m...)
+	}
+	return messages, nil
+}
+
+// PruneSyncCommitteeMessages removes the sync committee messages of slots older than the
+// current slot minus the configured grace period. Messages for those slots are rejected
+// from then on.
+func (s *Store) PruneSyncCommitteeMessages(currentSlot types.Slot) error {
+	s.messageLock.Lock()
+	defer s.messageLock.Unlock()
+
+	minSlot := types.Slot(0)
+	if currentSlot > s.messageGracePeriod {
+		minSlot = currentSlot - s.messageGracePeriod
+	}
+	if minSlot > s.messageMinSlot {
+		s.messageMinSlot = minSlot
+	}
+
+	for s.messageCache.Len() > 0 {
+		item, err := s.messageCache.Pop()
+		if err != nil {
+			return err
+		}
+		// The queue pops the lowest slot first, so every remaining item is retained.
+		if types.Slot(item.Priority) >= s.messageMinSlot {
+			return s.messageCache.Push(item)
+		}
+		messages, ok := item.Value.([]*ethpb.SyncCommitteeMessage)
+		if !ok {
+			return errors.New("not typed []ethpb.SyncCommitteeMessage")
+		}
+		prunedSyncCommitteeMessageTotal.Add(float64(len(messages)))
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package synccommittee
 import (
 	"testing"
 
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/require"
 )
@@ -107,3 +108,64 @@ func TestSyncCommitteeSignatureCache_RoundTrip(t *testing.T) {
 		{Slot: 6, ValidatorIndex: 1, Signature: []byte{'l'}},
 	}, msgs)
 }
+
+func TestSyncCommitteeSignatureCache_Prune(t *testing.T) {
+	store := NewStore()
+	for slot := types.Slot(1); slot <= 3; slot++ {
+		require.NoError(t, store.SaveSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: slot, Signature: []byte{'a'}}))
+	}
+
+	require.NoError(t, store.PruneSyncCommitteeMessages(3))
+	msgs, err := store.SyncCommitteeMessages(2)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(msgs))
+	msgs, err = store.SyncCommitteeMessages(3)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(msgs))
+
+	require.Equal(t, errExpiredMessage, store.SaveSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 2}))
+	require.NoError(t, store.SaveSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 4}))
+}
+
+func TestSyncCommitteeSignatureCache_PruneWithGracePeriod(t *testing.T) {
+	store := NewStore(WithMessageGracePeriod(1))
+	for slot := types.Slot(1); slot <= 3; slot++ {
+		require.NoError(t, store.SaveSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: slot, Signature: []byte{'a'}}))
+	}
+
+	require.NoError(t, store.PruneSyncCommitteeMessages(3))
+	msgs, err := store.SyncCommitteeMessages(1)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(msgs))
+	msgs, err = store.SyncCommitteeMessages(2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(msgs))
+	require.NoError(t, store.SaveSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 2}))
+	require.Equal(t, errExpiredMessage, store.SaveSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 1}))
+
+	// Pruning for an older slot does not lower the retention boundary.
+	require.NoError(t, store.PruneSyncCommitteeMessages(1))
+	require.Equal(t, errExpiredMessage, store.SaveSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 1}))
+}
+
+func TestSyncCommitteeSignatureCache_All(t *testing.T) {
+	store := NewStore()
+	msgs, err := store.AllSyncCommitteeMessages()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(msgs))
+
+	for _, slot := range []types.Slot{3, 1, 2, 1} {
+		require.NoError(t, store.SaveSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: slot, Signature: []byte{'a'}}))
+	}
+	msgs, err = store.AllSyncCommitteeMessages()
+	require.NoError(t, err)
+	require.Equal(t, 4, len(msgs))
+	for i, slot := range []types.Slot{1, 1, 2, 3} {
+		require.Equal(t, slot, msgs[i].Slot)
+	}
+
+	// Listing the messages does not remove them from the store.
+	msgs, err = store.SyncCommitteeMessages(1)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(msgs))
+}
@@ -10,6 +10,14 @@ var (
 		Name: "saved_sync_committee_message_total",
 		Help: "The number of saved sync committee message total.",
 	})
+	rejectedSyncCommitteeMessageTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rejected_sync_committee_message_total",
+		Help: "The number of sync committee messages rejected by the pool.",
+	}, []string{"reason"})
+	prunedSyncCommitteeMessageTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pruned_sync_committee_message_total",
+		Help: "The number of sync committee messages pruned from the pool.",
+	})
 	savedSyncCommitteeContributionTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "saved_sync_committee_contribution_total",
 		Help: "The number of saved sync committee contribution total.",
@@ -15,13 +15,16 @@ type Pool interface {
 	// Methods for Sync Contributions.
 	SaveSyncCommitteeContribution(contr *ethpb.SyncCommitteeContribution) error
 	SyncCommitteeContributions(slot types.Slot) ([]*ethpb.SyncCommitteeContribution, error)
+	BestSyncCommitteeContribution(slot types.Slot, subcommitteeIndex uint64, blockRoot [32]byte) (*ethpb.SyncCommitteeContribution, error)
 
 	// Methods for Sync Committee Messages.
 	SaveSyncCommitteeMessage(sig *ethpb.SyncCommitteeMessage) error
 	SyncCommitteeMessages(slot types.Slot) ([]*ethpb.SyncCommitteeMessage, error)
+	AllSyncCommitteeMessages() ([]*ethpb.SyncCommitteeMessage, error)
+	PruneSyncCommitteeMessages(currentSlot types.Slot) error
 }
 
 // NewPool returns the sync committee store fulfilling the pool interface.
-func NewPool() Pool {
-	return NewStore()
+func NewPool(opts ...Option) Pool {
+	return NewStore(opts...)
 }
@@ -3,6 +3,7 @@ package p2p
 import (
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 )
 
 // Config for the p2p service. These parameters are set from application level flags
@@ -26,6 +27,13 @@ type Config struct {
 	MaxPeers            uint
 	AllowListCIDR       string
 	DenyListCIDR        []string
+	ConnectionFilter    *ConnectionFilter
+	InboundDialRate     int
+	InboundDialBurst    int
 	StateNotifier       statefeed.Notifier
-	DB                  db.ReadOnlyDatabase
+	DB                  db.NoHeadAccessDatabase
+	ScoringPolicy       ScoringPolicy
+	NodeRole            peers.NodeRole
+	// CompressionCodec of the ssz encoding of gossip and req/resp messages, ssz_snappy if empty.
+	CompressionCodec string
 }
@@ -0,0 +1,98 @@
+package p2p
+
+import (
+	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// ConnectionFilter lists CIDR subnets which peer connections are exclusively allowed with, or denied
+// with, in addition to the ones set by the --p2p-allowlist and --p2p-denylist flags. Like the flags,
+// entries may be "public" or "private" to match every public or private subnet. It is applied to
+// both inbound and outbound connections, and can be replaced at runtime, e.g.
+//
+//	allowlist:
+//	  - 10.0.0.0/8
+//	denylist:
+//	  - 10.1.0.0/16
+type ConnectionFilter struct {
+	AllowList []string `yaml:"allowlist"`
+	DenyList  []string `yaml:"denylist"`
+}
+
+// LoadConnectionFilter loads a connection filter from a YAML file.
+func LoadConnectionFilter(path string) (*ConnectionFilter, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read connection filter file")
+	}
+	f := &ConnectionFilter{}
+	if err := yaml.UnmarshalStrict(enc, f); err != nil {
+		return nil, errors.Wrap(err, "could not parse connection filter file")
+	}
+	if _, err := configureFilter(&Config{ConnectionFilter: f}); err != nil {
+		return nil, errors.Wrap(err, "invalid connection filter file")
+	}
+	return f, nil
+}
+
+// Copy returns a deep copy of the connection filter.
+func (f *ConnectionFilter) Copy() *ConnectionFilter {
+	if f == nil {
+		return &ConnectionFilter{}
+	}
+	return &ConnectionFilter{
+		AllowList: append([]string{}, f.AllowList...),
+		DenyList:  append([]string{}, f.DenyList...),
+	}
+}
+
+// ConnectionFilter returns the connection filter currently applied on top of the allow and deny
+// lists set by flags.
+func (s *Service) ConnectionFilter() *ConnectionFilter {
+	s.addrFilterLock.RLock()
+	defer s.addrFilterLock.RUnlock()
+	return s.cfg.ConnectionFilter.Copy()
+}
+
+// SetConnectionFilter replaces the connection filter applied on top of the allow and deny lists set
+// by flags. Connected peers which are no longer allowed are disconnected.
+func (s *Service) SetConnectionFilter(f *ConnectionFilter) error {
+	f = f.Copy()
+	cfg := *s.cfg
+	cfg.ConnectionFilter = f
+	addrFilter, err := configureFilter(&cfg)
+	if err != nil {
+		return errors.Wrap(err, "invalid connection filter")
+	}
+	s.addrFilterLock.Lock()
+	s.cfg.ConnectionFilter = f
+	s.addrFilter = addrFilter
+	s.addrFilterLock.Unlock()
+
+	log.WithFields(logrus.Fields{
+		"allowList": f.AllowList,
+		"denyList":  f.DenyList,
+	}).Info("Updated peer connection filter")
+	if s.host == nil {
+		return nil
+	}
+	for _, conn := range s.host.Network().Conns() {
+		if filterConnections(addrFilter, conn.RemoteMultiaddr()) {
+			continue
+		}
+		log.WithField("peer", conn.RemoteMultiaddr()).Debug("Disconnecting peer no longer allowed by connection filter")
+		if err := s.Disconnect(conn.RemotePeer()); err != nil {
+			log.WithError(err).Error("Could not disconnect peer")
+		}
+	}
+	return nil
+}
+
+func (s *Service) connectionFilter() *multiaddr.Filters {
+	s.addrFilterLock.RLock()
+	defer s.addrFilterLock.RUnlock()
+	return s.addrFilter
+}
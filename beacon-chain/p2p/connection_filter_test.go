@@ -0,0 +1,86 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinms/leakybucket-go"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func writeConnectionFilter(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "filter.yaml")
+	require.NoError(t, file.WriteFile(path, []byte(content)))
+	return path
+}
+
+func TestLoadConnectionFilter(t *testing.T) {
+	path := writeConnectionFilter(t, `
+allowlist:
+  - 10.0.0.0/8
+  - public
+denylist:
+  - 10.1.0.0/16
+`)
+	f, err := LoadConnectionFilter(path)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"10.0.0.0/8", "public"}, f.AllowList)
+	assert.DeepEqual(t, []string{"10.1.0.0/16"}, f.DenyList)
+
+	_, err = LoadConnectionFilter(writeConnectionFilter(t, "allowlist: [\"10.0.0.0\"]\n"))
+	assert.ErrorContains(t, "invalid connection filter file", err)
+	_, err = LoadConnectionFilter(writeConnectionFilter(t, "allow: [\"10.0.0.0/8\"]\n"))
+	assert.ErrorContains(t, "could not parse connection filter file", err)
+	_, err = LoadConnectionFilter(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, "could not read connection filter file", err)
+}
+
+func TestService_SetConnectionFilter(t *testing.T) {
+	cfg := &Config{DenyListCIDR: []string{"192.168.0.0/16"}}
+	s := &Service{
+		cfg:       cfg,
+		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	var err error
+	s.addrFilter, err = configureFilter(cfg)
+	require.NoError(t, err)
+	allowed := func(ip string) bool {
+		addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip, 3000))
+		require.NoError(t, err)
+		return s.InterceptAddrDial("", addr)
+	}
+	assert.Equal(t, true, allowed("10.1.2.3"))
+	assert.Equal(t, true, allowed("212.67.10.122"))
+	assert.Equal(t, false, allowed("192.168.1.1"))
+
+	require.NoError(t, s.SetConnectionFilter(&ConnectionFilter{
+		AllowList: []string{"10.0.0.0/8"},
+		DenyList:  []string{"10.1.0.0/16"},
+	}))
+	assert.Equal(t, true, allowed("10.2.3.4"))
+	assert.Equal(t, false, allowed("10.1.2.3"))
+	assert.Equal(t, false, allowed("212.67.10.122"))
+	assert.DeepEqual(t, []string{"10.0.0.0/8"}, s.ConnectionFilter().AllowList)
+	// The lists set by flags are kept.
+	assert.DeepEqual(t, []string{"192.168.0.0/16"}, cfg.DenyListCIDR)
+
+	// An invalid filter is rejected and the current one is kept.
+	err = s.SetConnectionFilter(&ConnectionFilter{DenyList: []string{"10.0.0.0"}})
+	assert.ErrorContains(t, "invalid connection filter", err)
+	assert.Equal(t, false, allowed("212.67.10.122"))
+
+	require.NoError(t, s.SetConnectionFilter(&ConnectionFilter{}))
+	assert.Equal(t, true, allowed("10.1.2.3"))
+	assert.Equal(t, true, allowed("212.67.10.122"))
+	assert.Equal(t, false, allowed("192.168.1.1"))
+}
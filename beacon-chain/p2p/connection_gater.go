@@ -4,6 +4,7 @@ import (
 	"net"
 	"runtime"
 
+	"github.com/kevinms/leakybucket-go"
 	"github.com/libp2p/go-libp2p-core/control"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -13,10 +14,10 @@ import (
 )
 
 const (
-	// Limit for rate limiter when processing new inbound dials.
+	// Default limit for rate limiter when processing new inbound dials.
 	ipLimit = 4
 
-	// Burst limit for inbound dials.
+	// Default burst limit for inbound dials.
 	ipBurst = 8
 
 	// High watermark buffer signifies the buffer till which
@@ -36,7 +37,7 @@ func (s *Service) InterceptAddrDial(pid peer.ID, m multiaddr.Multiaddr) (allow b
 	if s.peers.IsBad(pid) {
 		return false
 	}
-	return filterConnections(s.addrFilter, m)
+	return filterConnections(s.connectionFilter(), m)
 }
 
 // InterceptAccept checks whether the incidental inbound connection is allowed.
@@ -54,7 +55,7 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 			"reason": "at peer limit"}).Trace("Not accepting inbound dial")
 		return false
 	}
-	return filterConnections(s.addrFilter, n.RemoteMultiaddr())
+	return filterConnections(s.connectionFilter(), n.RemoteMultiaddr())
 }
 
 // InterceptSecured tests whether a given connection, now authenticated,
@@ -73,6 +74,8 @@ func (s *Service) validateDial(addr multiaddr.Multiaddr) bool {
 	if err != nil {
 		return false
 	}
+	s.ipLimiterLock.RLock()
+	defer s.ipLimiterLock.RUnlock()
 	remaining := s.ipLimiter.Remaining(ip.String())
 	if remaining <= 0 {
 		return false
@@ -81,6 +84,30 @@ func (s *Service) validateDial(addr multiaddr.Multiaddr) bool {
 	return true
 }
 
+// SetInboundDialLimits replaces the rate, in dials per second, and the burst of inbound dials
+// accepted from a single ip address. Non-positive values are replaced by the defaults.
+func (s *Service) SetInboundDialLimits(rate, burst int) {
+	limiter := newIPLimiter(rate, burst)
+	s.ipLimiterLock.Lock()
+	s.ipLimiter = limiter
+	s.ipLimiterLock.Unlock()
+	log.WithFields(logrus.Fields{
+		"rate":  limiter.Rate(),
+		"burst": limiter.Capacity(),
+	}).Info("Updated inbound dial rate limits")
+}
+
+// newIPLimiter returns the rate limiter of inbound dials per ip address.
+func newIPLimiter(rate, burst int) *leakybucket.Collector {
+	if rate <= 0 {
+		rate = ipLimit
+	}
+	if burst <= 0 {
+		burst = ipBurst
+	}
+	return leakybucket.NewCollector(float64(rate), int64(burst), true /* deleteEmptyBuckets */)
+}
+
 var privateCIDRList = []string{
 	// Private ip addresses specified by rfc-1918.
 	// See: https://tools.ietf.org/html/rfc1918
@@ -98,61 +125,76 @@ var privateCIDRList = []string{
 // configureFilter looks at the provided allow lists and
 // deny lists to appropriately create a filter.
 func configureFilter(cfg *Config) (*multiaddr.Filters, error) {
+	allowList, denyList := filterLists(cfg)
 	addrFilter := multiaddr.NewFilters()
 	var privErr error
-	switch {
-	case cfg.AllowListCIDR == "public":
-		cfg.DenyListCIDR = append(cfg.DenyListCIDR, privateCIDRList...)
-	case cfg.AllowListCIDR == "private":
-		addrFilter, privErr = privateCIDRFilter(addrFilter, multiaddr.ActionAccept)
-		if privErr != nil {
-			return nil, privErr
-		}
-	case cfg.AllowListCIDR != "":
-		_, ipnet, err := net.ParseCIDR(cfg.AllowListCIDR)
-		if err != nil {
-			return nil, err
-		}
-		addrFilter.AddFilter(*ipnet, multiaddr.ActionAccept)
-	}
-
-	// Configure from provided deny list in the config.
-	if len(cfg.DenyListCIDR) > 0 {
-		for _, cidr := range cfg.DenyListCIDR {
-			// If an entry in the deny list is "private", we iterate through the
-			// private addresses and add them to the filter. Likewise, if the deny
-			// list is "public", then we add all private address to the accept filter,
-			switch {
-			case cidr == "private":
-				addrFilter, privErr = privateCIDRFilter(addrFilter, multiaddr.ActionDeny)
-				if privErr != nil {
-					return nil, privErr
-				}
-				continue
-			case cidr == "public":
-				addrFilter, privErr = privateCIDRFilter(addrFilter, multiaddr.ActionAccept)
-				if privErr != nil {
-					return nil, privErr
-				}
-				continue
+	for _, cidr := range allowList {
+		switch {
+		case cidr == "public":
+			denyList = append(denyList, privateCIDRList...)
+		case cidr == "private":
+			addrFilter, privErr = privateCIDRFilter(addrFilter, multiaddr.ActionAccept)
+			if privErr != nil {
+				return nil, privErr
 			}
+		default:
 			_, ipnet, err := net.ParseCIDR(cidr)
 			if err != nil {
 				return nil, err
 			}
-			// Check if the address already has an action associated with it
-			// If this address was previously accepted, log a warning before placing
-			// it in the deny filter
-			action, _ := addrFilter.ActionForFilter(*ipnet)
-			if action == multiaddr.ActionAccept {
-				log.Warnf("Address %s is in conflict with previous rule.", ipnet.String())
+			addrFilter.AddFilter(*ipnet, multiaddr.ActionAccept)
+		}
+	}
+
+	// Configure from provided deny list in the config.
+	for _, cidr := range denyList {
+		// If an entry in the deny list is "private", we iterate through the
+		// private addresses and add them to the filter. Likewise, if the deny
+		// list is "public", then we add all private address to the accept filter,
+		switch {
+		case cidr == "private":
+			addrFilter, privErr = privateCIDRFilter(addrFilter, multiaddr.ActionDeny)
+			if privErr != nil {
+				return nil, privErr
+			}
+			continue
+		case cidr == "public":
+			addrFilter, privErr = privateCIDRFilter(addrFilter, multiaddr.ActionAccept)
+			if privErr != nil {
+				return nil, privErr
 			}
-			addrFilter.AddFilter(*ipnet, multiaddr.ActionDeny)
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
 		}
+		// Check if the address already has an action associated with it
+		// If this address was previously accepted, log a warning before placing
+		// it in the deny filter
+		action, _ := addrFilter.ActionForFilter(*ipnet)
+		if action == multiaddr.ActionAccept {
+			log.Warnf("Address %s is in conflict with previous rule.", ipnet.String())
+		}
+		addrFilter.AddFilter(*ipnet, multiaddr.ActionDeny)
 	}
 	return addrFilter, nil
 }
 
+// filterLists combines the allow and deny lists set by flags with the ones of the
+// connection filter, which can be changed at runtime.
+func filterLists(cfg *Config) (allowList, denyList []string) {
+	if cfg.AllowListCIDR != "" {
+		allowList = append(allowList, cfg.AllowListCIDR)
+	}
+	denyList = append(denyList, cfg.DenyListCIDR...)
+	if cfg.ConnectionFilter != nil {
+		allowList = append(allowList, cfg.ConnectionFilter.AllowList...)
+		denyList = append(denyList, cfg.ConnectionFilter.DenyList...)
+	}
+	return allowList, denyList
+}
+
 //helper function to either accept or deny all private addresses
 //if a new rule for a private address is in conflict with a previous one, log a warning
 func privateCIDRFilter(addrFilter *multiaddr.Filters, action multiaddr.Action) (*multiaddr.Filters, error) {
@@ -184,7 +226,7 @@ func privateCIDRFilter(addrFilter *multiaddr.Filters, action multiaddr.Action) (
 // filter and decides what to do with them. By default libp2p
 // accepts all incoming dials, so if we have an allow list
 // we will reject all inbound dials except for those in the
-// appropriate ip subnets.
+// appropriate ip subnets which are not denied.
 func filterConnections(f *multiaddr.Filters, a multiaddr.Multiaddr) bool {
 	acceptedNets := f.FiltersForAction(multiaddr.ActionAccept)
 	restrictConns := len(acceptedNets) != 0
@@ -205,7 +247,7 @@ func filterConnections(f *multiaddr.Filters, a multiaddr.Multiaddr) bool {
 				break
 			}
 		}
-		return found
+		return found && !f.AddrBlocked(a)
 	}
 	return !f.AddrBlocked(a)
 }
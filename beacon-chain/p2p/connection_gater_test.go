@@ -95,6 +95,26 @@ func TestService_InterceptBannedIP(t *testing.T) {
 	}
 }
 
+func TestService_SetInboundDialLimits(t *testing.T) {
+	s := &Service{ipLimiter: newIPLimiter(0, 0)}
+	assert.Equal(t, float64(ipLimit), s.ipLimiter.Rate())
+	assert.Equal(t, int64(ipBurst), s.ipLimiter.Capacity())
+
+	multiAddress, err := ma.NewMultiaddr("/ip4/212.67.10.122/tcp/3000")
+	require.NoError(t, err)
+	s.SetInboundDialLimits(1, 2)
+	assert.Equal(t, true, s.validateDial(multiAddress))
+	assert.Equal(t, true, s.validateDial(multiAddress))
+	assert.Equal(t, false, s.validateDial(multiAddress), "Expected dial beyond the burst to be rejected")
+
+	// The new limits apply to ip addresses which were already rate limited.
+	s.SetInboundDialLimits(1, 3)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, true, s.validateDial(multiAddress))
+	}
+	assert.Equal(t, false, s.validateDial(multiAddress))
+}
+
 func TestService_RejectInboundPeersBeyondLimit(t *testing.T) {
 	limit := 20
 	s := &Service{
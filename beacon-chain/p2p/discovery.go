@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/config/params"
 	ecdsaprysm "github.com/prysmaticlabs/prysm/crypto/ecdsa"
 	"github.com/prysmaticlabs/prysm/runtime/version"
@@ -180,6 +181,13 @@ func (s *Service) createListener(
 			localNode.SetStaticIP(hostIP)
 		}
 	}
+	pinned, err := LoadAdvertisement(s.cfg.DataDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load pinned advertisement")
+	}
+	if pinned != nil {
+		applyAdvertisement(localNode, pinned, s.cfg.HostAddress != "" || s.cfg.HostDNS != "")
+	}
 	if s.cfg.HostDNS != "" {
 		host := s.cfg.HostDNS
 		ips, err := net.LookupIP(host)
@@ -237,7 +245,12 @@ func (s *Service) createLocalNode(
 		return nil, errors.Wrap(err, "could not add eth2 fork version entry to enr")
 	}
 	localNode = initializeAttSubnets(localNode)
-	return initializeSyncCommSubnets(localNode), nil
+	localNode = initializeSyncCommSubnets(localNode)
+	// Full nodes do not advertise their role, like the nodes of other clients.
+	if s.cfg != nil && s.cfg.NodeRole != peers.FullNode {
+		localNode.Set(enr.WithEntry(peers.NodeRoleEnrKey, uint8(s.cfg.NodeRole)))
+	}
+	return localNode, nil
 }
 
 func (s *Service) startDiscoveryV5(
@@ -316,7 +329,7 @@ func (s *Service) filterPeer(node *enode.Node) bool {
 // active peers are above our set max peer limit.
 func (s *Service) isPeerAtLimit(inbound bool) bool {
 	numOfConns := len(s.host.Network().Peers())
-	maxPeers := int(s.cfg.MaxPeers)
+	maxPeers := int(s.peers.ConnectedPeerLimit())
 	// If we are measuring the limit for inbound peers
 	// we apply the high watermark buffer.
 	if inbound {
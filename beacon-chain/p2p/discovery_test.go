@@ -147,6 +147,25 @@ func TestMultiAddrsConversion_InvalidIPAddr(t *testing.T) {
 	assert.Equal(t, 0, len(multiAddr), "Invalid ip address converted successfully")
 }
 
+func TestCreateLocalNode_NodeRole(t *testing.T) {
+	ipAddr, pkey := createAddrAndPrivKey(t)
+	s := &Service{
+		cfg:                   &Config{NodeRole: peers.GossipRelayNode},
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+	}
+	node, err := s.createLocalNode(pkey, ipAddr, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, peers.GossipRelayNode, peers.NodeRoleFromENR(node.Node().Record()))
+
+	// Full nodes do not advertise their role.
+	s.cfg.NodeRole = peers.FullNode
+	node, err = s.createLocalNode(pkey, ipAddr, 0, 0)
+	require.NoError(t, err)
+	var role uint8
+	assert.NotNil(t, node.Node().Record().Load(enr.WithEntry(peers.NodeRoleEnrKey, &role)))
+}
+
 func TestMultiAddrConversion_OK(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ipAddr, pkey := createAddrAndPrivKey(t)
@@ -195,6 +214,7 @@ func TestStaticPeering_PeersAreAdded(t *testing.T) {
 	cfg.StaticPeers = staticPeers
 	cfg.StateNotifier = &mock.MockStateNotifier{}
 	cfg.NoDiscovery = true
+	cfg.DataDir = t.TempDir()
 	s, err := NewService(context.Background(), cfg)
 	require.NoError(t, err)
 
@@ -0,0 +1,94 @@
+package encoder
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// SnappyCodecName is the name of the compression used by the ssz_snappy encoding of the specification.
+const SnappyCodecName = "snappy"
+
+// protocolSuffixSSZPrefix precedes the name of the compression in the protocol suffix of ssz encodings.
+const protocolSuffixSSZPrefix = "ssz_"
+
+// Codec compresses the ssz payload of network messages for encodings other than ssz_snappy. Codecs
+// are identified by the protocol suffix ssz_<name>, so only peers using the same codec can exchange
+// gossip messages, while req/resp protocols fall back to ssz_snappy.
+type Codec interface {
+	// Name of the codec, used in the protocol suffix.
+	Name() string
+	// Encode compresses the provided bytes.
+	Encode(b []byte) []byte
+	// Decode decompresses the provided bytes, failing if they decompress to more than maxSize bytes.
+	Decode(b []byte, maxSize uint64) ([]byte, error)
+	// MaxEncodedLen returns the maximum length of the compression of n bytes.
+	MaxEncodedLen(n int) int
+}
+
+var (
+	codecs     = make(map[string]Codec)
+	codecsLock sync.RWMutex
+)
+
+func init() {
+	if err := RegisterCodec(zstdCodec{}); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterCodec makes a codec available to the ssz encodings under its name.
+func RegisterCodec(c Codec) error {
+	codecsLock.Lock()
+	defer codecsLock.Unlock()
+	name := c.Name()
+	if name == "" || strings.Contains(name, "/") {
+		return errors.Errorf("invalid codec name %q", name)
+	}
+	if name == SnappyCodecName {
+		return errors.Errorf("codec %q is reserved for the ssz_snappy encoding", name)
+	}
+	if _, ok := codecs[name]; ok {
+		return errors.Errorf("codec %q is already registered", name)
+	}
+	codecs[name] = c
+	return nil
+}
+
+// CodecNames returns the names of the codecs the ssz encodings can be created with, snappy included.
+func CodecNames() []string {
+	codecsLock.RLock()
+	defer codecsLock.RUnlock()
+	names := make([]string, 0, len(codecs))
+	for name := range codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{SnappyCodecName}, names...)
+}
+
+// NewSszEncoding returns the ssz encoding compressing messages with the named codec. An empty
+// name or snappy returns the ssz_snappy encoding of the specification.
+func NewSszEncoding(codecName string) (NetworkEncoding, error) {
+	if codecName == "" || codecName == SnappyCodecName {
+		return &SszNetworkEncoder{}, nil
+	}
+	codecsLock.RLock()
+	c, ok := codecs[codecName]
+	codecsLock.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("unknown compression codec %q, available codecs are %v", codecName, CodecNames())
+	}
+	return &SszCodecEncoder{codec: c}, nil
+}
+
+// ForProtocol returns the ssz encoding identified by the suffix of the provided protocol ID.
+func ForProtocol(protocolID string) (NetworkEncoding, error) {
+	suffix := protocolID[strings.LastIndex(protocolID, "/")+1:]
+	if !strings.HasPrefix(suffix, protocolSuffixSSZPrefix) {
+		return nil, errors.Errorf("protocol %s does not use an ssz encoding", protocolID)
+	}
+	return NewSszEncoding(strings.TrimPrefix(suffix, protocolSuffixSSZPrefix))
+}
@@ -0,0 +1,173 @@
+//go:build go1.18
+
+package encoder
+
+import (
+	"bytes"
+	"testing"
+
+	fastssz "github.com/prysmaticlabs/fastssz"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// sszMessage is a message which can be sent over req/resp or gossip.
+type sszMessage interface {
+	proto.Message
+	fastssz.Marshaler
+	fastssz.Unmarshaler
+}
+
+func FuzzReadVarint(f *testing.F) {
+	f.Add([]byte{0x00})
+	f.Add([]byte{0xac, 0x02})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01})
+	f.Add([]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r := bytes.NewReader(data)
+		if _, err := readVarint(r); err != nil {
+			return
+		}
+		if read := len(data) - r.Len(); read > maxVarintLength {
+			t.Fatalf("read %d bytes for a varint, more than the max of %d", read, maxVarintLength)
+		}
+	})
+}
+
+func FuzzDecodeSnappy(f *testing.F) {
+	var buf bytes.Buffer
+	_, err := SszNetworkEncoder{}.EncodeGossip(&buf, util.NewBeaconBlock())
+	require.NoError(f, err)
+	f.Add(buf.Bytes(), uint64(len(buf.Bytes())*4))
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0x0f}, uint64(1024))
+
+	f.Fuzz(func(t *testing.T, data []byte, maxSize uint64) {
+		// Bound the limit to keep memory usage reasonable.
+		maxSize %= MaxChunkSize
+		msg, err := DecodeSnappy(data, maxSize)
+		if err != nil {
+			return
+		}
+		if uint64(len(msg)) > maxSize {
+			t.Fatalf("decoded %d bytes, more than the max of %d", len(msg), maxSize)
+		}
+	})
+}
+
+func FuzzDecodeWithMaxLength_Status(f *testing.F) {
+	fuzzDecodeWithMaxLength(f, func() sszMessage { return &ethpb.Status{} }, &ethpb.Status{
+		ForkDigest:     []byte{0xb5, 0x30, 0x3f, 0x2a},
+		FinalizedRoot:  make([]byte, 32),
+		FinalizedEpoch: 3,
+		HeadRoot:       make([]byte, 32),
+		HeadSlot:       100,
+	})
+}
+
+func FuzzDecodeWithMaxLength_BeaconBlocksByRangeRequest(f *testing.F) {
+	fuzzDecodeWithMaxLength(f, func() sszMessage { return &ethpb.BeaconBlocksByRangeRequest{} }, &ethpb.BeaconBlocksByRangeRequest{
+		StartSlot: 64,
+		Count:     64,
+		Step:      1,
+	})
+}
+
+func FuzzDecodeWithMaxLength_MetaData(f *testing.F) {
+	fuzzDecodeWithMaxLength(f, func() sszMessage { return &ethpb.MetaDataV1{} }, &ethpb.MetaDataV1{
+		SeqNumber: 7,
+		Attnets:   make([]byte, 8),
+		Syncnets:  make([]byte, 1),
+	})
+}
+
+func FuzzDecodeWithMaxLength_SignedBeaconBlock(f *testing.F) {
+	fuzzDecodeWithMaxLength(f, func() sszMessage { return &ethpb.SignedBeaconBlock{} }, util.NewBeaconBlock())
+}
+
+func FuzzDecodeWithMaxLength_SignedBeaconBlockAltair(f *testing.F) {
+	fuzzDecodeWithMaxLength(f, func() sszMessage { return &ethpb.SignedBeaconBlockAltair{} }, util.NewBeaconBlockAltair())
+}
+
+func FuzzDecodeWithMaxLength_SignedBeaconBlockBellatrix(f *testing.F) {
+	fuzzDecodeWithMaxLength(f, func() sszMessage { return &ethpb.SignedBeaconBlockBellatrix{} }, util.NewBeaconBlockBellatrix())
+}
+
+func FuzzDecodeGossip_Attestation(f *testing.F) {
+	fuzzDecodeGossip(f, func() sszMessage { return &ethpb.Attestation{} }, &ethpb.Attestation{
+		AggregationBits: []byte{0x03},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	})
+}
+
+func FuzzDecodeGossip_SignedBeaconBlockAltair(f *testing.F) {
+	fuzzDecodeGossip(f, func() sszMessage { return &ethpb.SignedBeaconBlockAltair{} }, util.NewBeaconBlockAltair())
+}
+
+// fuzzDecodeWithMaxLength fuzzes the decoding of a req/resp chunk, from its varint length prefix
+// through the snappy frames to the SSZ message. A chunk which decodes must survive a round trip.
+func fuzzDecodeWithMaxLength(f *testing.F, newMsg func() sszMessage, seed sszMessage) {
+	e := SszNetworkEncoder{}
+	var buf bytes.Buffer
+	_, err := e.EncodeWithMaxLength(&buf, seed)
+	require.NoError(f, err)
+	enc := buf.Bytes()
+	f.Add(enc)
+	// Truncated frames and a length prefix claiming more bytes than sent.
+	f.Add(enc[:len(enc)/2])
+	f.Add(append([]byte{0xff, 0x01}, enc[1:]...))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg := newMsg()
+		if err := e.DecodeWithMaxLength(bytes.NewReader(data), msg); err != nil {
+			return
+		}
+		var out bytes.Buffer
+		if _, err := e.EncodeWithMaxLength(&out, msg); err != nil {
+			t.Fatalf("could not encode decoded message: %v", err)
+		}
+		decoded := newMsg()
+		if err := e.DecodeWithMaxLength(&out, decoded); err != nil {
+			t.Fatalf("could not decode re-encoded message: %v", err)
+		}
+		if !proto.Equal(msg, decoded) {
+			t.Fatalf("message changed after round trip: %v != %v", msg, decoded)
+		}
+	})
+}
+
+// fuzzDecodeGossip fuzzes the decoding of a snappy compressed gossip message. A message which
+// decodes must survive a round trip.
+func fuzzDecodeGossip(f *testing.F, newMsg func() sszMessage, seed sszMessage) {
+	e := SszNetworkEncoder{}
+	var buf bytes.Buffer
+	_, err := e.EncodeGossip(&buf, seed)
+	require.NoError(f, err)
+	f.Add(buf.Bytes())
+	f.Add(buf.Bytes()[:buf.Len()/2])
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg := newMsg()
+		if err := e.DecodeGossip(data, msg); err != nil {
+			return
+		}
+		var out bytes.Buffer
+		if _, err := e.EncodeGossip(&out, msg); err != nil {
+			t.Fatalf("could not encode decoded message: %v", err)
+		}
+		decoded := newMsg()
+		if err := e.DecodeGossip(out.Bytes(), decoded); err != nil {
+			t.Fatalf("could not decode re-encoded message: %v", err)
+		}
+		if !proto.Equal(msg, decoded) {
+			t.Fatalf("message changed after round trip: %v != %v", msg, decoded)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package encoder
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	fastssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/math"
+)
+
+var _ NetworkEncoding = (*SszCodecEncoder)(nil)
+
+// SszCodecEncoder supports p2p networking encoding using SimpleSerialize with the compression of a
+// registered codec. A req/resp chunk is the varint length of the ssz payload, followed by the varint
+// length of its compression and the compressed bytes, a gossip message is the compressed payload.
+type SszCodecEncoder struct {
+	codec Codec
+}
+
+// EncodeGossip the proto gossip message to the io.Writer.
+func (e SszCodecEncoder) EncodeGossip(w io.Writer, msg fastssz.Marshaler) (int, error) {
+	if msg == nil {
+		return 0, nil
+	}
+	b, err := msg.MarshalSSZ()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(len(b)) > MaxGossipSize {
+		return 0, errors.Errorf("gossip message exceeds max gossip size: %d bytes > %d bytes", len(b), MaxGossipSize)
+	}
+	return w.Write(e.codec.Encode(b))
+}
+
+// EncodeWithMaxLength the proto message to the io.Writer. This checks that the encoded message isn't larger
+// than the provided max limit.
+func (e SszCodecEncoder) EncodeWithMaxLength(w io.Writer, msg fastssz.Marshaler) (int, error) {
+	if msg == nil {
+		return 0, nil
+	}
+	b, err := msg.MarshalSSZ()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(len(b)) > MaxChunkSize {
+		return 0, fmt.Errorf(
+			"size of encoded message is %d which is larger than the provided max limit of %d",
+			len(b),
+			MaxChunkSize,
+		)
+	}
+	compressed := e.codec.Encode(b)
+	if _, err := w.Write(proto.EncodeVarint(uint64(len(b)))); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(proto.EncodeVarint(uint64(len(compressed)))); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(compressed); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// DecodeGossip decodes the bytes to the protobuf gossip message provided.
+func (e SszCodecEncoder) DecodeGossip(b []byte, to fastssz.Unmarshaler) error {
+	b, err := e.codec.Decode(b, MaxGossipSize)
+	if err != nil {
+		return err
+	}
+	return doDecode(b, to)
+}
+
+// DecodeWithMaxLength the bytes from io.Reader to the protobuf message provided.
+// This checks that the decoded message isn't larger than the provided max limit.
+func (e SszCodecEncoder) DecodeWithMaxLength(r io.Reader, to fastssz.Unmarshaler) error {
+	msgLen, err := readVarint(r)
+	if err != nil {
+		return err
+	}
+	if msgLen > MaxChunkSize {
+		return fmt.Errorf(
+			"remaining bytes %d goes over the provided max limit of %d",
+			msgLen,
+			MaxChunkSize,
+		)
+	}
+	compressedLen, err := readVarint(r)
+	if err != nil {
+		return err
+	}
+	msgMax, err := e.MaxLength(msgLen)
+	if err != nil {
+		return err
+	}
+	if compressedLen > uint64(msgMax) {
+		return errors.Errorf("compressed length %d is larger than the max encoded length %d", compressedLen, msgMax)
+	}
+	compressed := make([]byte, compressedLen)
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		return err
+	}
+	buf, err := e.codec.Decode(compressed, msgLen)
+	if err != nil {
+		return err
+	}
+	if uint64(len(buf)) != msgLen {
+		return errors.Errorf("decompressed %d bytes instead of %d", len(buf), msgLen)
+	}
+	return doDecode(buf, to)
+}
+
+// ProtocolSuffix returns the appropriate suffix for protocol IDs.
+func (e SszCodecEncoder) ProtocolSuffix() string {
+	return "/" + protocolSuffixSSZPrefix + e.codec.Name()
+}
+
+// MaxLength specifies the maximum possible length of an encoded
+// chunk of data.
+func (e SszCodecEncoder) MaxLength(length uint64) (int, error) {
+	il, err := math.Int(length)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid length provided")
+	}
+	maxLen := e.codec.MaxEncodedLen(il)
+	if maxLen < 0 {
+		return 0, errors.Errorf("max encoded length is negative: %d", maxLen)
+	}
+	return maxLen, nil
+}
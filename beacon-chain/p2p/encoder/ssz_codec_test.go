@@ -0,0 +1,129 @@
+package encoder_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewSszEncoding(t *testing.T) {
+	e, err := encoder.NewSszEncoding("")
+	require.NoError(t, err)
+	assert.Equal(t, "/ssz_snappy", e.ProtocolSuffix())
+	e, err = encoder.NewSszEncoding(encoder.SnappyCodecName)
+	require.NoError(t, err)
+	assert.Equal(t, "/ssz_snappy", e.ProtocolSuffix())
+	e, err = encoder.NewSszEncoding(encoder.ZstdCodecName)
+	require.NoError(t, err)
+	assert.Equal(t, "/ssz_zstd", e.ProtocolSuffix())
+	_, err = encoder.NewSszEncoding("lz4")
+	assert.ErrorContains(t, "unknown compression codec \"lz4\"", err)
+}
+
+func TestForProtocol(t *testing.T) {
+	e, err := encoder.ForProtocol("/eth2/beacon_chain/req/status/1/ssz_snappy")
+	require.NoError(t, err)
+	assert.Equal(t, "/ssz_snappy", e.ProtocolSuffix())
+	e, err = encoder.ForProtocol("/eth2/beacon_chain/req/status/1/ssz_zstd")
+	require.NoError(t, err)
+	assert.Equal(t, "/ssz_zstd", e.ProtocolSuffix())
+	_, err = encoder.ForProtocol("/eth2/beacon_chain/req/status/1/ssz_lz4")
+	assert.ErrorContains(t, "unknown compression codec", err)
+	_, err = encoder.ForProtocol("/ipfs/ping/1.0.0")
+	assert.ErrorContains(t, "does not use an ssz encoding", err)
+}
+
+func TestRegisterCodec_Invalid(t *testing.T) {
+	assert.ErrorContains(t, "is reserved", encoder.RegisterCodec(namedCodec(encoder.SnappyCodecName)))
+	assert.ErrorContains(t, "is already registered", encoder.RegisterCodec(namedCodec(encoder.ZstdCodecName)))
+	assert.ErrorContains(t, "invalid codec name", encoder.RegisterCodec(namedCodec("a/b")))
+	assert.DeepEqual(t, []string{encoder.SnappyCodecName, encoder.ZstdCodecName}, encoder.CodecNames())
+}
+
+func TestSszCodecEncoder_RoundTrip(t *testing.T) {
+	e, err := encoder.NewSszEncoding(encoder.ZstdCodecName)
+	require.NoError(t, err)
+	testRoundTripWithLength(t, e)
+	testRoundTripWithGossip(t, e)
+}
+
+func TestSszCodecEncoder_DecodeWithMultipleChunks(t *testing.T) {
+	e, err := encoder.NewSszEncoding(encoder.ZstdCodecName)
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	st, _ := util.DeterministicGenesisState(t, 100)
+	require.NoError(t, st.SetSlot(42))
+	defer func(size uint64) {
+		encoder.MaxChunkSize = size
+	}(encoder.MaxChunkSize)
+	encoder.MaxChunkSize = uint64(1 << 22)
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 7
+	_, err = e.EncodeWithMaxLength(buf, st.InnerStateUnsafe().(*ethpb.BeaconState))
+	require.NoError(t, err)
+	_, err = e.EncodeWithMaxLength(buf, blk)
+	require.NoError(t, err)
+
+	decodedState := &ethpb.BeaconState{}
+	require.NoError(t, e.DecodeWithMaxLength(buf, decodedState))
+	assert.Equal(t, st.Slot(), decodedState.Slot)
+	decodedBlk := &ethpb.SignedBeaconBlock{}
+	require.NoError(t, e.DecodeWithMaxLength(buf, decodedBlk))
+	if !proto.Equal(blk, decodedBlk) {
+		t.Error("Decoded block is not the same as original")
+	}
+	assert.Equal(t, 0, buf.Len())
+}
+
+func TestSszCodecEncoder_DecodeWithMaxLength(t *testing.T) {
+	buf := new(bytes.Buffer)
+	msg := &ethpb.Fork{
+		PreviousVersion: []byte("fooo"),
+		CurrentVersion:  []byte("barr"),
+		Epoch:           4242,
+	}
+	e, err := encoder.NewSszEncoding(encoder.ZstdCodecName)
+	require.NoError(t, err)
+	_, err = e.EncodeWithMaxLength(buf, msg)
+	require.NoError(t, err)
+	defer func(size uint64) {
+		encoder.MaxChunkSize = size
+	}(encoder.MaxChunkSize)
+	maxChunkSize := uint64(5)
+	encoder.MaxChunkSize = maxChunkSize
+	err = e.DecodeWithMaxLength(buf, &ethpb.Fork{})
+	wanted := fmt.Sprintf("goes over the provided max limit of %d", maxChunkSize)
+	assert.ErrorContains(t, wanted, err)
+}
+
+func TestSszCodecEncoder_DecodeGossipAboveMaxSize(t *testing.T) {
+	e, err := encoder.NewSszEncoding(encoder.ZstdCodecName)
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	_, err = e.EncodeGossip(buf, &ethpb.Fork{
+		PreviousVersion: []byte("fooo"),
+		CurrentVersion:  []byte("barr"),
+		Epoch:           4242,
+	})
+	require.NoError(t, err)
+	defer func(size uint64) {
+		encoder.MaxGossipSize = size
+	}(encoder.MaxGossipSize)
+	encoder.MaxGossipSize = 5
+	err = e.DecodeGossip(buf.Bytes(), &ethpb.Fork{})
+	assert.ErrorContains(t, "zstd message exceeds max size", err)
+}
+
+type namedCodec string
+
+func (c namedCodec) Name() string                            { return string(c) }
+func (namedCodec) Encode(b []byte) []byte                    { return b }
+func (namedCodec) Decode(b []byte, _ uint64) ([]byte, error) { return b, nil }
+func (namedCodec) MaxEncodedLen(n int) int                   { return n }
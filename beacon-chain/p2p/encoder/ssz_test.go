@@ -33,7 +33,7 @@ func TestSszNetworkEncoder_FailsSnappyLength(t *testing.T) {
 	require.ErrorContains(t, "snappy message exceeds max size", err)
 }
 
-func testRoundTripWithLength(t *testing.T, e *encoder.SszNetworkEncoder) {
+func testRoundTripWithLength(t *testing.T, e encoder.NetworkEncoding) {
 	buf := new(bytes.Buffer)
 	msg := &ethpb.Fork{
 		PreviousVersion: []byte("fooo"),
@@ -50,7 +50,7 @@ func testRoundTripWithLength(t *testing.T, e *encoder.SszNetworkEncoder) {
 	}
 }
 
-func testRoundTripWithGossip(t *testing.T, e *encoder.SszNetworkEncoder) {
+func testRoundTripWithGossip(t *testing.T, e encoder.NetworkEncoding) {
 	buf := new(bytes.Buffer)
 	msg := &ethpb.Fork{
 		PreviousVersion: []byte("fooo"),
@@ -0,0 +1,67 @@
+package encoder
+
+import (
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/params"
+)
+
+// ZstdCodecName is the name of the zstd codec, used by the ssz_zstd encoding.
+const ZstdCodecName = "zstd"
+
+// The zstd encoder and decoder are safe for concurrent use with EncodeAll and DecodeAll. Every frame is
+// a single segment so that its header records the decompressed length, even for empty messages, and the
+// decoder never allocates more than the largest message allowed on the network.
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil,
+		zstd.WithEncoderCRC(false),
+		zstd.WithSingleSegment(true),
+		zstd.WithZeroFrames(true),
+	)
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(params.BeaconNetworkConfig().GossipMaxSizeBellatrix))
+)
+
+// zstdCodec trades CPU for a better compression ratio than snappy, for networks where bandwidth is the
+// bottleneck.
+type zstdCodec struct{}
+
+// Name --
+func (zstdCodec) Name() string {
+	return ZstdCodecName
+}
+
+// Encode compresses the bytes in a single frame recording their length.
+func (zstdCodec) Encode(b []byte) []byte {
+	return zstdEncoder.EncodeAll(b, make([]byte, 0, len(b)/2))
+}
+
+// Decode checks the length recorded in the frame header before decompressing the bytes.
+func (zstdCodec) Decode(b []byte, maxSize uint64) ([]byte, error) {
+	var header zstd.Header
+	if err := header.Decode(b); err != nil {
+		return nil, errors.Wrap(err, "could not decode zstd frame header")
+	}
+	if !header.HasFCS {
+		return nil, errors.New("zstd frame does not record its decompressed size")
+	}
+	if header.FrameContentSize > maxSize {
+		return nil, errors.Errorf("zstd message exceeds max size: %d bytes > %d bytes", header.FrameContentSize, maxSize)
+	}
+	decoded, err := zstdDecoder.DecodeAll(b, nil)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(decoded)) > maxSize {
+		return nil, errors.Errorf("zstd message exceeds max size: %d bytes > %d bytes", len(decoded), maxSize)
+	}
+	return decoded, nil
+}
+
+// MaxEncodedLen returns the bound of the zstd reference implementation, ZSTD_COMPRESSBOUND.
+func (zstdCodec) MaxEncodedLen(n int) int {
+	bound := n + n>>8
+	if n < 128<<10 {
+		bound += (128<<10 - n) >> 11
+	}
+	return bound
+}
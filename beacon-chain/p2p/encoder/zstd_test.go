@@ -0,0 +1,29 @@
+package encoder
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestZstdCodec_RoundTrip(t *testing.T) {
+	c := zstdCodec{}
+	for _, b := range [][]byte{{}, {1}, bytes.Repeat([]byte{0xab}, 1<<20)} {
+		encoded := c.Encode(b)
+		assert.Equal(t, true, len(encoded) <= c.MaxEncodedLen(len(b)))
+		decoded, err := c.Decode(encoded, uint64(len(b)))
+		require.NoError(t, err)
+		assert.DeepEqual(t, b, append([]byte{}, decoded...))
+	}
+}
+
+func TestZstdCodec_DecodeAboveMaxSize(t *testing.T) {
+	c := zstdCodec{}
+	encoded := c.Encode(make([]byte, 100))
+	_, err := c.Decode(encoded, 99)
+	assert.ErrorContains(t, "zstd message exceeds max size: 100 bytes > 99 bytes", err)
+	_, err = c.Decode([]byte{1, 2, 3}, 99)
+	assert.ErrorContains(t, "could not decode zstd frame header", err)
+}
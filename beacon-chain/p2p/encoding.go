@@ -0,0 +1,28 @@
+package p2p
+
+import (
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+)
+
+// RPCProtocolIDs returns the protocol IDs of a req/resp topic in order of preference, the configured
+// encoding followed by the ssz_snappy encoding supported by every peer.
+func RPCProtocolIDs(provider EncodingProvider, baseTopic string) []protocol.ID {
+	ids := []protocol.ID{protocol.ID(baseTopic + provider.Encoding().ProtocolSuffix())}
+	snappySuffix := (&encoder.SszNetworkEncoder{}).ProtocolSuffix()
+	if provider.Encoding().ProtocolSuffix() != snappySuffix {
+		ids = append(ids, protocol.ID(baseTopic+snappySuffix))
+	}
+	return ids
+}
+
+// StreamEncoding returns the encoding negotiated for a req/resp stream, identified by the suffix of
+// its protocol ID. The configured encoding is returned for streams without a known ssz encoding.
+func StreamEncoding(provider EncodingProvider, stream network.Stream) encoder.NetworkEncoding {
+	e, err := encoder.ForProtocol(string(stream.Protocol()))
+	if err != nil {
+		return provider.Encoding()
+	}
+	return e
+}
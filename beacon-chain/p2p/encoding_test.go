@@ -0,0 +1,20 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestRPCProtocolIDs(t *testing.T) {
+	assert.DeepEqual(t, []protocol.ID{RPCStatusTopicV1 + "/ssz_snappy"}, RPCProtocolIDs(&Service{}, RPCStatusTopicV1))
+
+	zstdEncoding, err := encoder.NewSszEncoding(encoder.ZstdCodecName)
+	require.NoError(t, err)
+	s := &Service{encoding: zstdEncoding}
+	want := []protocol.ID{RPCStatusTopicV1 + "/ssz_zstd", RPCStatusTopicV1 + "/ssz_snappy"}
+	assert.DeepEqual(t, want, RPCProtocolIDs(s, RPCStatusTopicV1))
+}
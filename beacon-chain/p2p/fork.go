@@ -32,11 +32,11 @@ func (s *Service) currentForkDigest() ([4]byte, error) {
 // local record values for current and next fork version/epoch.
 func (s *Service) compareForkENR(record *enr.Record) error {
 	currentRecord := s.dv5Listener.LocalNode().Node().Record()
-	peerForkENR, err := forkEntry(record)
+	peerForkENR, err := ForkEntry(record)
 	if err != nil {
 		return err
 	}
-	currentForkENR, err := forkEntry(currentRecord)
+	currentForkENR, err := ForkEntry(currentRecord)
 	if err != nil {
 		return err
 	}
@@ -111,9 +111,9 @@ func addForkEntry(
 	return node, nil
 }
 
-// Retrieves an enrForkID from an ENR record by key lookup
+// ForkEntry retrieves an enrForkID from an ENR record by key lookup
 // under the Ethereum consensus EnrKey
-func forkEntry(record *enr.Record) (*pb.ENRForkID, error) {
+func ForkEntry(record *enr.Record) (*pb.ENRForkID, error) {
 	sszEncodedForkEntry := make([]byte, 16)
 	entry := enr.WithEntry(eth2ENRKey, &sszEncodedForkEntry)
 	err := record.Load(entry)
@@ -93,6 +93,7 @@ func TestStartDiscv5_DifferentForkDigests(t *testing.T) {
 	cfg.UDPPort = 14000
 	cfg.TCPPort = 14001
 	cfg.MaxPeers = 30
+	cfg.DataDir = t.TempDir()
 	s, err = NewService(context.Background(), cfg)
 	require.NoError(t, err)
 	s.genesisTime = genesisTime
@@ -184,6 +185,7 @@ func TestStartDiscv5_SameForkDigests_DifferentNextForkData(t *testing.T) {
 	cfg.TCPPort = 14001
 	cfg.MaxPeers = 30
 	cfg.StateNotifier = &mock.MockStateNotifier{}
+	cfg.DataDir = t.TempDir()
 	s, err = NewService(context.Background(), cfg)
 	require.NoError(t, err)
 
@@ -246,7 +248,7 @@ func TestDiscv5_AddRetrieveForkEntryENR(t *testing.T) {
 	want, err := signing.ComputeForkDigest([]byte{0, 0, 0, 0}, genesisValidatorsRoot)
 	require.NoError(t, err)
 
-	resp, err := forkEntry(localNode.Node().Record())
+	resp, err := ForkEntry(localNode.Node().Record())
 	require.NoError(t, err)
 	assert.DeepEqual(t, want[:], resp.CurrentForkDigest)
 	assert.DeepEqual(t, nextForkVersion, resp.NextForkVersion)
@@ -272,7 +274,7 @@ func TestAddForkEntry_Genesis(t *testing.T) {
 	localNode := enode.NewLocalNode(db, pkey)
 	localNode, err = addForkEntry(localNode, time.Now().Add(10*time.Second), bytesutil.PadTo([]byte{'A', 'B', 'C', 'D'}, 32))
 	require.NoError(t, err)
-	forkEntry, err := forkEntry(localNode.Node().Record())
+	forkEntry, err := ForkEntry(localNode.Node().Record())
 	require.NoError(t, err)
 	assert.DeepEqual(t,
 		params.BeaconConfig().GenesisForkVersion, forkEntry.NextForkVersion,
@@ -4,7 +4,6 @@ import (
 	"context"
 	"math"
 	"reflect"
-	"strings"
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -99,26 +98,32 @@ func (s *Service) topicScoreParams(topic string) (*pubsub.TopicScoreParams, erro
 	if err != nil {
 		return nil, err
 	}
-	switch {
-	case strings.Contains(topic, GossipBlockMessage):
-		return defaultBlockTopicParams(), nil
-	case strings.Contains(topic, GossipAggregateAndProofMessage):
-		return defaultAggregateTopicParams(activeValidators), nil
-	case strings.Contains(topic, GossipAttestationMessage):
-		return defaultAggregateSubnetTopicParams(activeValidators), nil
-	case strings.Contains(topic, GossipSyncCommitteeMessage):
-		return defaultSyncSubnetTopicParams(activeValidators), nil
-	case strings.Contains(topic, GossipContributionAndProofMessage):
-		return defaultSyncContributionTopicParams(), nil
-	case strings.Contains(topic, GossipExitMessage):
-		return defaultVoluntaryExitTopicParams(), nil
-	case strings.Contains(topic, GossipProposerSlashingMessage):
-		return defaultProposerSlashingTopicParams(), nil
-	case strings.Contains(topic, GossipAttesterSlashingMessage):
-		return defaultAttesterSlashingTopicParams(), nil
+	var scoreParams *pubsub.TopicScoreParams
+	message := gossipMessageName(topic)
+	switch message {
+	case GossipBlockMessage:
+		scoreParams = defaultBlockTopicParams()
+	case GossipAggregateAndProofMessage:
+		scoreParams = defaultAggregateTopicParams(activeValidators)
+	case GossipAttestationMessage:
+		scoreParams = defaultAggregateSubnetTopicParams(activeValidators)
+	case GossipSyncCommitteeMessage:
+		scoreParams = defaultSyncSubnetTopicParams(activeValidators)
+	case GossipContributionAndProofMessage:
+		scoreParams = defaultSyncContributionTopicParams()
+	case GossipExitMessage:
+		scoreParams = defaultVoluntaryExitTopicParams()
+	case GossipProposerSlashingMessage:
+		scoreParams = defaultProposerSlashingTopicParams()
+	case GossipAttesterSlashingMessage:
+		scoreParams = defaultAttesterSlashingTopicParams()
 	default:
 		return nil, errors.Errorf("unrecognized topic provided for parameter registration: %s", topic)
 	}
+	if s.cfg.ScoringPolicy != nil {
+		return s.cfg.ScoringPolicy.TopicScoreParams(topic, message, scoreParams)
+	}
+	return scoreParams, nil
 }
 
 func (s *Service) retrieveActiveValidators() (uint64, error) {
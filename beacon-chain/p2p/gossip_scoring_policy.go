@@ -0,0 +1,143 @@
+package p2p
+
+import (
+	"strings"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"gopkg.in/yaml.v2"
+)
+
+// ScoringPolicy supplies the peer score parameters of gossip topics, allowing the default
+// parameters to be tuned for networks with different validator counts or committee sizes.
+type ScoringPolicy interface {
+	// TopicScoreParams returns the score parameters of a topic, given the gossip message name of the
+	// topic and the default parameters, which may be nil. Returning nil disables scoring for the topic.
+	TopicScoreParams(topic, message string, defaults *pubsub.TopicScoreParams) (*pubsub.TopicScoreParams, error)
+}
+
+// gossipMessageNames lists the gossip message names recognized in topics. Names which are prefixes of
+// other names are listed last.
+var gossipMessageNames = []string{
+	GossipBlockMessage,
+	GossipAggregateAndProofMessage,
+	GossipAttestationMessage,
+	GossipContributionAndProofMessage,
+	GossipSyncCommitteeMessage,
+	GossipExitMessage,
+	GossipProposerSlashingMessage,
+	GossipAttesterSlashingMessage,
+}
+
+// gossipMessageName returns the gossip message name of the topic, or an empty string if the topic is
+// not recognized.
+func gossipMessageName(topic string) string {
+	for _, name := range gossipMessageNames {
+		if strings.Contains(topic, name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// topicScoreOverrides holds the topic score parameters set in a scoring policy file. Parameters that
+// are not set keep their default value.
+type topicScoreOverrides struct {
+	Disabled                        bool           `yaml:"disabled"`
+	TopicWeight                     *float64       `yaml:"topic_weight"`
+	TimeInMeshWeight                *float64       `yaml:"time_in_mesh_weight"`
+	TimeInMeshQuantum               *time.Duration `yaml:"time_in_mesh_quantum"`
+	TimeInMeshCap                   *float64       `yaml:"time_in_mesh_cap"`
+	FirstMessageDeliveriesWeight    *float64       `yaml:"first_message_deliveries_weight"`
+	FirstMessageDeliveriesDecay     *float64       `yaml:"first_message_deliveries_decay"`
+	FirstMessageDeliveriesCap       *float64       `yaml:"first_message_deliveries_cap"`
+	MeshMessageDeliveriesWeight     *float64       `yaml:"mesh_message_deliveries_weight"`
+	MeshMessageDeliveriesDecay      *float64       `yaml:"mesh_message_deliveries_decay"`
+	MeshMessageDeliveriesCap        *float64       `yaml:"mesh_message_deliveries_cap"`
+	MeshMessageDeliveriesThreshold  *float64       `yaml:"mesh_message_deliveries_threshold"`
+	MeshMessageDeliveriesWindow     *time.Duration `yaml:"mesh_message_deliveries_window"`
+	MeshMessageDeliveriesActivation *time.Duration `yaml:"mesh_message_deliveries_activation"`
+	MeshFailurePenaltyWeight        *float64       `yaml:"mesh_failure_penalty_weight"`
+	MeshFailurePenaltyDecay         *float64       `yaml:"mesh_failure_penalty_decay"`
+	InvalidMessageDeliveriesWeight  *float64       `yaml:"invalid_message_deliveries_weight"`
+	InvalidMessageDeliveriesDecay   *float64       `yaml:"invalid_message_deliveries_decay"`
+}
+
+// fileScoringPolicy is a scoring policy which overrides the default topic score parameters with the
+// parameters set per gossip message name in a YAML file, e.g.
+//
+//	topics:
+//	  sync_committee:
+//	    topic_weight: 0.1
+//	    mesh_message_deliveries_window: 3s
+//	  voluntary_exit:
+//	    disabled: true
+type fileScoringPolicy struct {
+	Topics map[string]*topicScoreOverrides `yaml:"topics"`
+}
+
+// LoadScoringPolicy loads a scoring policy from a YAML file.
+func LoadScoringPolicy(path string) (ScoringPolicy, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read scoring policy file")
+	}
+	policy := &fileScoringPolicy{}
+	if err := yaml.UnmarshalStrict(enc, policy); err != nil {
+		return nil, errors.Wrap(err, "could not parse scoring policy file")
+	}
+	for name, o := range policy.Topics {
+		if gossipMessageName(name) != name {
+			return nil, errors.Errorf("unknown gossip message name %q in scoring policy file", name)
+		}
+		if o == nil {
+			return nil, errors.Errorf("no parameters set for gossip message %q in scoring policy file", name)
+		}
+	}
+	return policy, nil
+}
+
+// TopicScoreParams applies the overrides of the topic's gossip message name to the default parameters.
+func (p *fileScoringPolicy) TopicScoreParams(_, message string, defaults *pubsub.TopicScoreParams) (*pubsub.TopicScoreParams, error) {
+	o, ok := p.Topics[message]
+	if !ok {
+		return defaults, nil
+	}
+	if o.Disabled {
+		return nil, nil
+	}
+	params := &pubsub.TopicScoreParams{}
+	if defaults != nil {
+		*params = *defaults
+	}
+	setFloat := func(dst *float64, v *float64) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	setDuration := func(dst *time.Duration, v *time.Duration) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	setFloat(&params.TopicWeight, o.TopicWeight)
+	setFloat(&params.TimeInMeshWeight, o.TimeInMeshWeight)
+	setDuration(&params.TimeInMeshQuantum, o.TimeInMeshQuantum)
+	setFloat(&params.TimeInMeshCap, o.TimeInMeshCap)
+	setFloat(&params.FirstMessageDeliveriesWeight, o.FirstMessageDeliveriesWeight)
+	setFloat(&params.FirstMessageDeliveriesDecay, o.FirstMessageDeliveriesDecay)
+	setFloat(&params.FirstMessageDeliveriesCap, o.FirstMessageDeliveriesCap)
+	setFloat(&params.MeshMessageDeliveriesWeight, o.MeshMessageDeliveriesWeight)
+	setFloat(&params.MeshMessageDeliveriesDecay, o.MeshMessageDeliveriesDecay)
+	setFloat(&params.MeshMessageDeliveriesCap, o.MeshMessageDeliveriesCap)
+	setFloat(&params.MeshMessageDeliveriesThreshold, o.MeshMessageDeliveriesThreshold)
+	setDuration(&params.MeshMessageDeliveriesWindow, o.MeshMessageDeliveriesWindow)
+	setDuration(&params.MeshMessageDeliveriesActivation, o.MeshMessageDeliveriesActivation)
+	setFloat(&params.MeshFailurePenaltyWeight, o.MeshFailurePenaltyWeight)
+	setFloat(&params.MeshFailurePenaltyDecay, o.MeshFailurePenaltyDecay)
+	setFloat(&params.InvalidMessageDeliveriesWeight, o.InvalidMessageDeliveriesWeight)
+	setFloat(&params.InvalidMessageDeliveriesDecay, o.InvalidMessageDeliveriesDecay)
+	return params, nil
+}
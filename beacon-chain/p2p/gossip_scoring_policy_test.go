@@ -0,0 +1,92 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestGossipMessageName(t *testing.T) {
+	tests := map[string]string{
+		"/eth2/%x/beacon_block":                          GossipBlockMessage,
+		"/eth2/%x/beacon_aggregate_and_proof":            GossipAggregateAndProofMessage,
+		"/eth2/%x/beacon_attestation_3":                  GossipAttestationMessage,
+		"/eth2/%x/sync_committee_2":                      GossipSyncCommitteeMessage,
+		"/eth2/%x/sync_committee_contribution_and_proof": GossipContributionAndProofMessage,
+		"/eth2/%x/voluntary_exit":                        GossipExitMessage,
+		"/eth2/%x/proposer_slashing":                     GossipProposerSlashingMessage,
+		"/eth2/%x/attester_slashing":                     GossipAttesterSlashingMessage,
+		"/eth2/%x/unknown":                               "",
+	}
+	for topic, want := range tests {
+		assert.Equal(t, want, gossipMessageName(topic), topic)
+	}
+}
+
+func writeScoringPolicy(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "scoring.yaml")
+	require.NoError(t, file.WriteFile(path, []byte(content)))
+	return path
+}
+
+func TestLoadScoringPolicy(t *testing.T) {
+	path := writeScoringPolicy(t, `
+topics:
+  sync_committee:
+    topic_weight: 0.25
+    mesh_message_deliveries_window: 3s
+  voluntary_exit:
+    disabled: true
+`)
+	policy, err := LoadScoringPolicy(path)
+	require.NoError(t, err)
+
+	defaults := defaultSyncSubnetTopicParams(1024)
+	require.NotNil(t, defaults)
+	got, err := policy.TopicScoreParams("/eth2/%x/sync_committee_1", GossipSyncCommitteeMessage, defaults)
+	require.NoError(t, err)
+	assert.Equal(t, 0.25, got.TopicWeight)
+	assert.Equal(t, 3*time.Second, got.MeshMessageDeliveriesWindow)
+	assert.Equal(t, defaults.FirstMessageDeliveriesCap, got.FirstMessageDeliveriesCap)
+	assert.NotEqual(t, 0.25, defaults.TopicWeight, "Defaults were modified")
+
+	got, err = policy.TopicScoreParams("/eth2/%x/voluntary_exit", GossipExitMessage, defaultVoluntaryExitTopicParams())
+	require.NoError(t, err)
+	assert.Equal(t, true, got == nil, "Scoring was not disabled")
+
+	blockDefaults := defaultBlockTopicParams()
+	got, err = policy.TopicScoreParams("/eth2/%x/beacon_block", GossipBlockMessage, blockDefaults)
+	require.NoError(t, err)
+	assert.Equal(t, blockDefaults, got)
+}
+
+func TestLoadScoringPolicy_Invalid(t *testing.T) {
+	_, err := LoadScoringPolicy(writeScoringPolicy(t, "topics:\n  sync_committee_42:\n    topic_weight: 1\n"))
+	assert.ErrorContains(t, "unknown gossip message name", err)
+	_, err = LoadScoringPolicy(writeScoringPolicy(t, "topics:\n  sync_committee:\n    topic_wieght: 1\n"))
+	assert.ErrorContains(t, "could not parse scoring policy file", err)
+	_, err = LoadScoringPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, "could not read scoring policy file", err)
+}
+
+func TestService_TopicScoreParams_ScoringPolicy(t *testing.T) {
+	policy, err := LoadScoringPolicy(writeScoringPolicy(t, "topics:\n  sync_committee_contribution_and_proof:\n    topic_weight: 0.5\n"))
+	require.NoError(t, err)
+	s := &Service{
+		ctx:                  context.Background(),
+		cfg:                  &Config{ScoringPolicy: policy},
+		activeValidatorCount: 1024,
+	}
+	got, err := s.topicScoreParams(fmt.Sprintf(SyncContributionAndProofSubnetTopicFormat, []byte{1, 2, 3, 4}) + s.Encoding().ProtocolSuffix())
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, got.TopicWeight)
+	got, err = s.topicScoreParams(fmt.Sprintf(SyncCommitteeSubnetTopicFormat, []byte{1, 2, 3, 4}, 1) + s.Encoding().ProtocolSuffix())
+	require.NoError(t, err)
+	assert.Equal(t, defaultSyncSubnetTopicParams(1024).TopicWeight, got.TopicWeight)
+}
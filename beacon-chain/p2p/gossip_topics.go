@@ -0,0 +1,86 @@
+package p2p
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/network/forks"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// GossipTopics returns the full names of the gossip topics a node subscribed to every subnet joins at
+// the given epoch, with the given fork digest and protocol suffix of the network encoding, e.g.
+// /eth2/afcaaba0/sync_committee_3/ssz_snappy. Light client topics are only published to, and are
+// not part of the list.
+func GossipTopics(epoch types.Epoch, digest [4]byte, suffix string) []string {
+	var topics []string
+	for _, format := range []string{
+		BlockSubnetTopicFormat,
+		AggregateAndProofSubnetTopicFormat,
+		ExitSubnetTopicFormat,
+		ProposerSlashingSubnetTopicFormat,
+		AttesterSlashingSubnetTopicFormat,
+	} {
+		topics = append(topics, fmt.Sprintf(format, digest)+suffix)
+	}
+	for i := uint64(0); i < params.BeaconNetworkConfig().AttestationSubnetCount; i++ {
+		topics = append(topics, fmt.Sprintf(AttestationSubnetTopicFormat, digest, i)+suffix)
+	}
+	if epoch >= params.BeaconConfig().AltairForkEpoch {
+		topics = append(topics, fmt.Sprintf(SyncContributionAndProofSubnetTopicFormat, digest)+suffix)
+		for i := uint64(0); i < params.BeaconConfig().SyncCommitteeSubnetCount; i++ {
+			topics = append(topics, fmt.Sprintf(SyncCommitteeSubnetTopicFormat, digest, i)+suffix)
+		}
+	}
+	return topics
+}
+
+// GossipTopicsHandler lists the gossip topics of the epoch given by the epoch query parameter, the
+// current epoch by default, and whether the node is currently subscribed to each of them.
+func (s *Service) GossipTopicsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.genesisTime.IsZero() || len(s.genesisValidatorsRoot) == 0 {
+		http.Error(w, "genesis is not known yet", http.StatusServiceUnavailable)
+		return
+	}
+	epoch := slots.ToEpoch(slots.Since(s.genesisTime))
+	if e := r.URL.Query().Get("epoch"); e != "" {
+		n, err := strconv.ParseUint(e, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid epoch: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		epoch = types.Epoch(n)
+	}
+	digest, err := forks.ForkDigestFromEpoch(epoch, s.genesisValidatorsRoot)
+	if err != nil {
+		http.Error(w, "could not compute fork digest: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	subscribed := make(map[string]bool)
+	if s.pubsub != nil {
+		for _, topic := range s.pubsub.GetTopics() {
+			subscribed[topic] = true
+		}
+	}
+	buf := new(bytes.Buffer)
+	if _, err := fmt.Fprintf(buf, "epoch=%d digest=%#x\n\n", epoch, digest); err != nil {
+		log.WithError(err).Error("Failed to render gossip topics page")
+		return
+	}
+	for _, topic := range GossipTopics(epoch, digest, s.Encoding().ProtocolSuffix()) {
+		if _, err := fmt.Fprintf(buf, "%s subscribed=%t\n", topic, subscribed[topic]); err != nil {
+			log.WithError(err).Error("Failed to render gossip topics page")
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.WithError(err).Error("Failed to render gossip topics page")
+	}
+}
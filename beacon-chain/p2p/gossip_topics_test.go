@@ -0,0 +1,159 @@
+package p2p
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/network/forks"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// mainnetGenesisValidatorsRoot is the genesis validators root of the mainnet beacon chain.
+var mainnetGenesisValidatorsRoot = bytesutil.PadTo([]byte{
+	0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e,
+	0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95,
+}, 32)
+
+func setupMainnetForks(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.MainnetConfig().Copy()
+	cfg.BellatrixForkEpoch = 144896
+	params.OverrideBeaconConfig(cfg)
+	params.BeaconConfig().InitializeForkSchedule()
+}
+
+func TestGossipTopics_Snapshot(t *testing.T) {
+	setupMainnetForks(t)
+	suffix := encoder.SszNetworkEncoder{}.ProtocolSuffix()
+	tests := []struct {
+		epoch  types.Epoch
+		digest string
+		topics []string
+	}{
+		{
+			epoch:  0,
+			digest: "b5303f2a",
+			topics: []string{
+				"/eth2/b5303f2a/beacon_block/ssz_snappy",
+				"/eth2/b5303f2a/beacon_aggregate_and_proof/ssz_snappy",
+				"/eth2/b5303f2a/voluntary_exit/ssz_snappy",
+				"/eth2/b5303f2a/proposer_slashing/ssz_snappy",
+				"/eth2/b5303f2a/attester_slashing/ssz_snappy",
+				"/eth2/b5303f2a/beacon_attestation_0/ssz_snappy",
+				"/eth2/b5303f2a/beacon_attestation_63/ssz_snappy",
+			},
+		},
+		{
+			epoch:  74240,
+			digest: "afcaaba0",
+			topics: []string{
+				"/eth2/afcaaba0/beacon_block/ssz_snappy",
+				"/eth2/afcaaba0/beacon_attestation_63/ssz_snappy",
+				"/eth2/afcaaba0/sync_committee_contribution_and_proof/ssz_snappy",
+				"/eth2/afcaaba0/sync_committee_0/ssz_snappy",
+				"/eth2/afcaaba0/sync_committee_3/ssz_snappy",
+			},
+		},
+		{
+			epoch:  144896,
+			digest: "4a26c58b",
+			topics: []string{
+				"/eth2/4a26c58b/beacon_block/ssz_snappy",
+				"/eth2/4a26c58b/sync_committee_contribution_and_proof/ssz_snappy",
+				"/eth2/4a26c58b/sync_committee_3/ssz_snappy",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("epoch %d", tt.epoch), func(t *testing.T) {
+			digest, err := forks.ForkDigestFromEpoch(tt.epoch, mainnetGenesisValidatorsRoot)
+			require.NoError(t, err)
+			assert.Equal(t, tt.digest, fmt.Sprintf("%x", digest))
+
+			topics := GossipTopics(tt.epoch, digest, suffix)
+			wanted := 5 + int(params.BeaconNetworkConfig().AttestationSubnetCount)
+			if tt.epoch >= params.BeaconConfig().AltairForkEpoch {
+				wanted += 1 + int(params.BeaconConfig().SyncCommitteeSubnetCount)
+			}
+			assert.Equal(t, wanted, len(topics))
+			set := make(map[string]bool, len(topics))
+			for _, topic := range topics {
+				set[topic] = true
+			}
+			assert.Equal(t, len(topics), len(set), "duplicate topics")
+			for _, topic := range tt.topics {
+				assert.Equal(t, true, set[topic], "missing topic %s", topic)
+			}
+		})
+	}
+}
+
+// Every topic is well formed, and maps back to a registered topic format and message type.
+func TestGossipTopics_Conformance(t *testing.T) {
+	setupMainnetForks(t)
+	suffix := encoder.SszNetworkEncoder{}.ProtocolSuffix()
+	topicRegex := regexp.MustCompile(`^/eth2/([0-9a-f]{8})/([a-z_]+?)(_[0-9]+)?/ssz_snappy$`)
+	for _, epoch := range []types.Epoch{0, params.BeaconConfig().AltairForkEpoch, params.BeaconConfig().BellatrixForkEpoch} {
+		digest, err := forks.ForkDigestFromEpoch(epoch, mainnetGenesisValidatorsRoot)
+		require.NoError(t, err)
+		for _, topic := range GossipTopics(epoch, digest, suffix) {
+			m := topicRegex.FindStringSubmatch(topic)
+			require.Equal(t, 4, len(m), "malformed topic %s", topic)
+			assert.Equal(t, fmt.Sprintf("%x", digest), m[1])
+			format := GossipProtocolAndDigest + m[2]
+			if m[3] != "" {
+				format += "_%d"
+			}
+			assert.NotNil(t, GossipTopicMappings(format, epoch), "topic %s has no message type", topic)
+		}
+	}
+	// Subnet topics are the only ones formatted with an index.
+	for _, format := range AllTopics() {
+		subnet := format == AttestationSubnetTopicFormat || format == SyncCommitteeSubnetTopicFormat
+		assert.Equal(t, true, strings.HasPrefix(format, GossipProtocolAndDigest), format)
+		assert.Equal(t, subnet, strings.HasSuffix(format, "_%d"), format)
+		assert.Equal(t, 1, strings.Count(format, "%x"), format)
+	}
+}
+
+func TestService_GossipTopicsHandler(t *testing.T) {
+	setupMainnetForks(t)
+	s := &Service{
+		cfg:                   &Config{DataDir: t.TempDir()},
+		encoding:              encoder.SszNetworkEncoder{},
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: mainnetGenesisValidatorsRoot,
+	}
+
+	rec := httptest.NewRecorder()
+	s.GossipTopicsHandler(rec, httptest.NewRequest(http.MethodGet, "/p2p/topics?epoch=74240", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	assert.Equal(t, "epoch=74240 digest=0xafcaaba0", lines[0])
+	assert.Equal(t, "/eth2/afcaaba0/beacon_block/ssz_snappy subscribed=false", lines[2])
+	assert.Equal(t, 2+5+64+1+4, len(lines))
+
+	// The current epoch is used by default.
+	rec = httptest.NewRecorder()
+	s.GossipTopicsHandler(rec, httptest.NewRequest(http.MethodGet, "/p2p/topics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, true, strings.HasPrefix(rec.Body.String(), "epoch=0 digest=0xb5303f2a\n"))
+
+	rec = httptest.NewRecorder()
+	s.GossipTopicsHandler(rec, httptest.NewRequest(http.MethodGet, "/p2p/topics?epoch=abc", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	(&Service{}).GossipTopicsHandler(rec, httptest.NewRequest(http.MethodGet, "/p2p/topics", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -0,0 +1,166 @@
+package p2p
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
+
+type messageCounts struct {
+	received   uint64
+	duplicates uint64
+}
+
+type topicDuplicateStats struct {
+	messageCounts
+	peers map[peer.ID]*messageCounts
+}
+
+// gossipTracer is a pubsub raw tracer which counts, per topic and per peer, the gossip messages
+// received for the first time and the duplicates of already seen messages which are ignored.
+// The statistics of a peer are dropped once pubsub removes it.
+type gossipTracer struct {
+	host   peer.ID
+	lock   sync.RWMutex
+	topics map[string]*topicDuplicateStats
+}
+
+var _ = pubsub.RawTracer(&gossipTracer{})
+
+func newGossipTracer(host peer.ID) *gossipTracer {
+	return &gossipTracer{
+		host:   host,
+		topics: make(map[string]*topicDuplicateStats),
+	}
+}
+
+// ValidateMessage is invoked when a message is seen for the first time.
+func (g *gossipTracer) ValidateMessage(msg *pubsub.Message) {
+	if msg.ReceivedFrom == g.host {
+		// Messages published by the node itself are not gossip traffic.
+		return
+	}
+	topic := msg.GetTopic()
+	gossipMessagesReceived.WithLabelValues(topic).Inc()
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	stats := g.topicStats(topic)
+	stats.received++
+	stats.peerStats(msg.ReceivedFrom).received++
+}
+
+// DuplicateMessage is invoked when a message which was already seen is received again and dropped.
+func (g *gossipTracer) DuplicateMessage(msg *pubsub.Message) {
+	topic := msg.GetTopic()
+	gossipMessagesDuplicate.WithLabelValues(topic).Inc()
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	stats := g.topicStats(topic)
+	stats.duplicates++
+	stats.peerStats(msg.ReceivedFrom).duplicates++
+}
+
+// RemovePeer is invoked when a peer disconnects from pubsub.
+func (g *gossipTracer) RemovePeer(p peer.ID) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for _, stats := range g.topics {
+		delete(stats.peers, p)
+	}
+}
+
+// AddPeer is a no-op.
+func (_ *gossipTracer) AddPeer(_ peer.ID, _ protocol.ID) {}
+
+// Join is a no-op.
+func (_ *gossipTracer) Join(_ string) {}
+
+// Leave is a no-op.
+func (_ *gossipTracer) Leave(_ string) {}
+
+// Graft is a no-op.
+func (_ *gossipTracer) Graft(_ peer.ID, _ string) {}
+
+// Prune is a no-op.
+func (_ *gossipTracer) Prune(_ peer.ID, _ string) {}
+
+// DeliverMessage is a no-op.
+func (_ *gossipTracer) DeliverMessage(_ *pubsub.Message) {}
+
+// RejectMessage is a no-op.
+func (_ *gossipTracer) RejectMessage(_ *pubsub.Message, _ string) {}
+
+// ThrottlePeer is a no-op.
+func (_ *gossipTracer) ThrottlePeer(_ peer.ID) {}
+
+// RecvRPC is a no-op.
+func (_ *gossipTracer) RecvRPC(_ *pubsub.RPC) {}
+
+// SendRPC is a no-op.
+func (_ *gossipTracer) SendRPC(_ *pubsub.RPC, _ peer.ID) {}
+
+// DropRPC is a no-op.
+func (_ *gossipTracer) DropRPC(_ *pubsub.RPC, _ peer.ID) {}
+
+// UndeliverableMessage is a no-op.
+func (_ *gossipTracer) UndeliverableMessage(_ *pubsub.Message) {}
+
+// GossipDuplicateStats returns, per topic, the number of gossip messages received for the first time and
+// the number of duplicate messages ignored, along with the share of each connected peer.
+func (s *Service) GossipDuplicateStats() []*pbrpc.GossipTopicDuplicateStats {
+	return s.gossipTracer.stats()
+}
+
+// stats returns the statistics of every topic, sorted by topic, with the peers of each topic
+// sorted by peer id.
+func (g *gossipTracer) stats() []*pbrpc.GossipTopicDuplicateStats {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	res := make([]*pbrpc.GossipTopicDuplicateStats, 0, len(g.topics))
+	for topic, stats := range g.topics {
+		peerStats := make([]*pbrpc.GossipPeerDuplicateStats, 0, len(stats.peers))
+		for pid, counts := range stats.peers {
+			peerStats = append(peerStats, &pbrpc.GossipPeerDuplicateStats{
+				PeerId:     pid.String(),
+				Received:   counts.received,
+				Duplicates: counts.duplicates,
+			})
+		}
+		sort.Slice(peerStats, func(i, j int) bool {
+			return peerStats[i].PeerId < peerStats[j].PeerId
+		})
+		res = append(res, &pbrpc.GossipTopicDuplicateStats{
+			Topic:      topic,
+			Received:   stats.received,
+			Duplicates: stats.duplicates,
+			Peers:      peerStats,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Topic < res[j].Topic
+	})
+	return res
+}
+
+// topicStats must be called with the lock held.
+func (g *gossipTracer) topicStats(topic string) *topicDuplicateStats {
+	stats, ok := g.topics[topic]
+	if !ok {
+		stats = &topicDuplicateStats{peers: make(map[peer.ID]*messageCounts)}
+		g.topics[topic] = stats
+	}
+	return stats
+}
+
+func (t *topicDuplicateStats) peerStats(pid peer.ID) *messageCounts {
+	counts, ok := t.peers[pid]
+	if !ok {
+		counts = &messageCounts{}
+		t.peers[pid] = counts
+	}
+	return counts
+}
@@ -0,0 +1,56 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+)
+
+func TestGossipTracer_Stats(t *testing.T) {
+	self, peerA, peerB := peer.ID("self"), peer.ID("a"), peer.ID("b")
+	blockTopic, attTopic := "/eth2/00000000/beacon_block/ssz_snappy", "/eth2/00000000/beacon_attestation_1/ssz_snappy"
+	msg := func(topic string, from peer.ID) *pubsub.Message {
+		return &pubsub.Message{Message: &pubsubpb.Message{Topic: &topic}, ReceivedFrom: from}
+	}
+
+	g := newGossipTracer(self)
+	g.ValidateMessage(msg(blockTopic, peerA))
+	g.DuplicateMessage(msg(blockTopic, peerB))
+	g.DuplicateMessage(msg(blockTopic, peerB))
+	g.ValidateMessage(msg(attTopic, peerB))
+	g.DuplicateMessage(msg(attTopic, peerA))
+	// Messages published locally are not counted.
+	g.ValidateMessage(msg(attTopic, self))
+
+	want := []*pbrpc.GossipTopicDuplicateStats{
+		{
+			Topic:      attTopic,
+			Received:   1,
+			Duplicates: 1,
+			Peers: []*pbrpc.GossipPeerDuplicateStats{
+				{PeerId: peerA.String(), Duplicates: 1},
+				{PeerId: peerB.String(), Received: 1},
+			},
+		},
+		{
+			Topic:      blockTopic,
+			Received:   1,
+			Duplicates: 2,
+			Peers: []*pbrpc.GossipPeerDuplicateStats{
+				{PeerId: peerA.String(), Received: 1},
+				{PeerId: peerB.String(), Duplicates: 2},
+			},
+		},
+	}
+	assert.DeepSSZEqual(t, want, g.stats())
+
+	// The statistics of a removed peer are dropped, the topic totals are kept.
+	g.RemovePeer(peerB)
+	want[0].Peers = want[0].Peers[:1]
+	want[1].Peers = want[1].Peers[:1]
+	assert.DeepSSZEqual(t, want, g.stats())
+}
@@ -29,6 +29,7 @@ type P2P interface {
 	ConnectionHandler
 	PeersProvider
 	MetadataProvider
+	GossipStatsProvider
 }
 
 // Broadcaster broadcasts messages to peers over the p2p pubsub protocol.
@@ -91,6 +92,17 @@ type PeersProvider interface {
 	Peers() *peers.Status
 }
 
+// GossipStatsProvider returns the statistics of the messages received over gossip.
+type GossipStatsProvider interface {
+	GossipDuplicateStats() []*ethpb.GossipTopicDuplicateStats
+}
+
+// ConnectionFilterManager reads and replaces the peer connection filter at runtime.
+type ConnectionFilterManager interface {
+	ConnectionFilter() *ConnectionFilter
+	SetConnectionFilter(f *ConnectionFilter) error
+}
+
 // MetadataProvider returns the metadata related information for the local peer.
 type MetadataProvider interface {
 	Metadata() metadata.Metadata
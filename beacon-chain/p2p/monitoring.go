@@ -43,6 +43,14 @@ var (
 		Name: "p2p_sync_committee_subnet_attempted_broadcasts",
 		Help: "The number of sync committee that were attempted to be broadcast.",
 	})
+	gossipMessagesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_gossip_received_messages_total",
+		Help: "The number of gossip messages received from peers for the first time, per topic.",
+	}, []string{"topic"})
+	gossipMessagesDuplicate = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_gossip_duplicate_messages_total",
+		Help: "The number of duplicate gossip messages received from peers and ignored, per topic.",
+	}, []string{"topic"})
 )
 
 func (s *Service) updateMetrics() {
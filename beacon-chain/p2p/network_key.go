@@ -0,0 +1,251 @@
+package p2p
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"os"
+	"path"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	ecdsaprysm "github.com/prysmaticlabs/prysm/crypto/ecdsa"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// pendingKeySuffix is appended to the network key path to store a rotated key until it becomes active.
+	pendingKeySuffix = ".pending"
+	// previousKeySuffix is appended to the network key path to keep the key replaced by a rotation.
+	previousKeySuffix = ".previous"
+	// advertisementPath is the file in the data directory pinning the advertised IP address and ports.
+	advertisementPath = "network-advertisement.json"
+)
+
+// pendingNetworkKey is a rotated network key which replaces the current one once its activation time passed.
+type pendingNetworkKey struct {
+	Key        string    `json:"key"`
+	ActivateAt time.Time `json:"activate_at"`
+}
+
+// Advertisement pins the IP address and ports advertised in the node record, for example when the node
+// runs behind a NAT or a sentry. Unset fields keep the values derived from the node's configuration.
+type Advertisement struct {
+	IP      string `json:"ip,omitempty"`
+	TCPPort uint   `json:"tcp_port,omitempty"`
+	UDPPort uint   `json:"udp_port,omitempty"`
+}
+
+// NetworkKeyPath returns the path of the network key used by a node, which is the given key file if set,
+// or the default key file in the data directory.
+func NetworkKeyPath(dataDir, keyFile string) string {
+	if keyFile != "" {
+		return keyFile
+	}
+	return path.Join(dataDir, keyPath)
+}
+
+// LoadNetworkKey reads the network key stored at the given path.
+func LoadNetworkKey(keyFile string) (*ecdsa.PrivateKey, error) {
+	return privKeyFromFile(keyFile)
+}
+
+// SaveNetworkKey writes the network key to the given path, in the format read by the node.
+func SaveNetworkKey(keyFile string, key *ecdsa.PrivateKey) error {
+	enc, err := encodeNetworkKey(key)
+	if err != nil {
+		return err
+	}
+	return file.WriteFile(keyFile, []byte(enc))
+}
+
+// RotateNetworkKey generates a new network key which replaces the key at the given path the first time
+// the node starts after the grace period, leaving time to distribute the new peer ID and ENR.
+func RotateNetworkKey(keyFile string, grace time.Duration, now time.Time) (*ecdsa.PrivateKey, time.Time, error) {
+	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	key, err := ecdsaprysm.ConvertFromInterfacePrivKey(priv)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	enc, err := encodeNetworkKey(key)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	pending := &pendingNetworkKey{Key: enc, ActivateAt: now.Add(grace).UTC()}
+	b, err := json.Marshal(pending)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if err := file.WriteFile(keyFile+pendingKeySuffix, b); err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "could not write pending network key")
+	}
+	return key, pending.ActivateAt, nil
+}
+
+// PendingNetworkKey returns the rotated key waiting to replace the key at the given path, along with the
+// time it becomes active. It returns a nil key if no rotation is pending.
+func PendingNetworkKey(keyFile string) (*ecdsa.PrivateKey, time.Time, error) {
+	pending, err := readPendingNetworkKey(keyFile)
+	if err != nil || pending == nil {
+		return nil, time.Time{}, err
+	}
+	key, err := decodeNetworkKey([]byte(pending.Key))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return key, pending.ActivateAt, nil
+}
+
+// activatePendingNetworkKey replaces the key at the given path by the pending rotated key once its
+// activation time passed. The replaced key is kept next to it.
+func activatePendingNetworkKey(keyFile string, now time.Time) error {
+	pending, err := readPendingNetworkKey(keyFile)
+	if err != nil || pending == nil {
+		return err
+	}
+	if now.Before(pending.ActivateAt) {
+		log.WithField("activateAt", pending.ActivateAt).Info("Network key rotation is pending")
+		return nil
+	}
+	if file.FileExists(keyFile) {
+		if err := os.Rename(keyFile, keyFile+previousKeySuffix); err != nil {
+			return errors.Wrap(err, "could not back up replaced network key")
+		}
+	}
+	if err := file.WriteFile(keyFile, []byte(pending.Key)); err != nil {
+		return errors.Wrap(err, "could not write rotated network key")
+	}
+	if err := os.Remove(keyFile + pendingKeySuffix); err != nil {
+		return errors.Wrap(err, "could not remove pending network key")
+	}
+	log.WithField("previousKey", keyFile+previousKeySuffix).Info("Activated rotated network key")
+	return nil
+}
+
+func readPendingNetworkKey(keyFile string) (*pendingNetworkKey, error) {
+	b, err := os.ReadFile(keyFile + pendingKeySuffix) // #nosec G304
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	pending := &pendingNetworkKey{}
+	if err := json.Unmarshal(b, pending); err != nil {
+		return nil, errors.Wrap(err, "could not decode pending network key")
+	}
+	return pending, nil
+}
+
+func encodeNetworkKey(key *ecdsa.PrivateKey) (string, error) {
+	k, err := ecdsaprysm.ConvertToInterfacePrivkey(key)
+	if err != nil {
+		return "", err
+	}
+	raw, err := k.Raw()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+func decodeNetworkKey(enc []byte) (*ecdsa.PrivateKey, error) {
+	dst := make([]byte, hex.DecodedLen(len(enc)))
+	if _, err := hex.Decode(dst, enc); err != nil {
+		return nil, errors.Wrap(err, "failed to decode hex string")
+	}
+	k, err := crypto.UnmarshalSecp256k1PrivateKey(dst)
+	if err != nil {
+		return nil, err
+	}
+	return ecdsaprysm.ConvertFromInterfacePrivKey(k)
+}
+
+// PeerIDFromNetworkKey returns the libp2p peer ID of a node using the given network key.
+func PeerIDFromNetworkKey(key *ecdsa.PrivateKey) (peer.ID, error) {
+	k, err := ecdsaprysm.ConvertToInterfacePrivkey(key)
+	if err != nil {
+		return "", err
+	}
+	return peer.IDFromPrivateKey(k)
+}
+
+// NodeRecord builds a signed node record advertising the given address for the network key. The record
+// does not include the eth2 fork entry, which the running node adds to the record it advertises.
+func NodeRecord(key *ecdsa.PrivateKey, ip net.IP, tcpPort, udpPort int) (*enode.Node, error) {
+	db, err := enode.OpenDB("")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open node's peer database")
+	}
+	defer db.Close()
+	localNode := enode.NewLocalNode(db, key)
+	localNode.Set(enr.IP(ip))
+	localNode.Set(enr.TCP(tcpPort))
+	localNode.Set(enr.UDP(udpPort))
+	return localNode.Node(), nil
+}
+
+// SaveAdvertisement pins the advertised IP address and ports of the node using the given data directory.
+func SaveAdvertisement(dataDir string, a *Advertisement) error {
+	if a.IP != "" && net.ParseIP(a.IP) == nil {
+		return errors.Errorf("invalid IP address %q", a.IP)
+	}
+	if a.TCPPort > 65535 || a.UDPPort > 65535 {
+		return errors.New("ports must be lower than 65536")
+	}
+	b, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	return file.WriteFile(path.Join(dataDir, advertisementPath), b)
+}
+
+// LoadAdvertisement returns the advertised IP address and ports pinned in the data directory, or nil if
+// none were pinned.
+func LoadAdvertisement(dataDir string) (*Advertisement, error) {
+	b, err := os.ReadFile(path.Join(dataDir, advertisementPath)) // #nosec G304
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	a := &Advertisement{}
+	if err := json.Unmarshal(b, a); err != nil {
+		return nil, errors.Wrap(err, "could not decode pinned advertisement")
+	}
+	return a, nil
+}
+
+// applyAdvertisement sets the pinned address in the local node record. The pinned IP address is ignored
+// if an address is set through the node's flags.
+func applyAdvertisement(localNode *enode.LocalNode, a *Advertisement, hostAddressSet bool) {
+	fields := logrus.Fields{}
+	if a.IP != "" && !hostAddressSet {
+		if ip := net.ParseIP(a.IP); ip != nil {
+			localNode.SetFallbackIP(ip)
+			localNode.SetStaticIP(ip)
+			fields["ip"] = a.IP
+		}
+	}
+	if a.TCPPort != 0 {
+		localNode.Set(enr.TCP(a.TCPPort))
+		fields["tcpPort"] = a.TCPPort
+	}
+	if a.UDPPort != 0 {
+		localNode.Set(enr.UDP(a.UDPPort))
+		localNode.SetFallbackUDP(int(a.UDPPort))
+		fields["udpPort"] = a.UDPPort
+	}
+	log.WithFields(fields).Info("Advertising pinned address in node record")
+}
@@ -0,0 +1,93 @@
+package p2p
+
+import (
+	"net"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestRotateNetworkKey(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{DataDir: dir}
+	keyFile := NetworkKeyPath(dir, "")
+	assert.Equal(t, path.Join(dir, keyPath), keyFile)
+
+	current, err := privKey(cfg)
+	require.NoError(t, err)
+	require.NoError(t, SaveNetworkKey(keyFile, current))
+
+	now := time.Now()
+	rotated, activateAt, err := RotateNetworkKey(keyFile, time.Hour, now)
+	require.NoError(t, err)
+	assert.Equal(t, true, activateAt.Equal(now.Add(time.Hour)))
+	pending, pendingAt, err := PendingNetworkKey(keyFile)
+	require.NoError(t, err)
+	assert.DeepEqual(t, rotated.D, pending.D)
+	assert.Equal(t, true, pendingAt.Equal(activateAt))
+
+	// The current key is kept during the grace period.
+	k, err := privKey(cfg)
+	require.NoError(t, err)
+	assert.DeepEqual(t, current.D, k.D)
+
+	// Once the grace period passed, the rotated key replaces it.
+	require.NoError(t, activatePendingNetworkKey(keyFile, activateAt))
+	k, err = privKey(cfg)
+	require.NoError(t, err)
+	assert.DeepEqual(t, rotated.D, k.D)
+	previous, err := LoadNetworkKey(keyFile + previousKeySuffix)
+	require.NoError(t, err)
+	assert.DeepEqual(t, current.D, previous.D)
+	assert.Equal(t, false, file.FileExists(keyFile+pendingKeySuffix))
+	pending, _, err = PendingNetworkKey(keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, true, pending == nil)
+}
+
+func TestAdvertisement(t *testing.T) {
+	dir := t.TempDir()
+	a, err := LoadAdvertisement(dir)
+	require.NoError(t, err)
+	assert.Equal(t, true, a == nil)
+
+	assert.ErrorContains(t, "invalid IP address", SaveAdvertisement(dir, &Advertisement{IP: "foo"}))
+	assert.ErrorContains(t, "ports must be lower", SaveAdvertisement(dir, &Advertisement{TCPPort: 70000}))
+	want := &Advertisement{IP: "192.0.2.1", TCPPort: 9000}
+	require.NoError(t, SaveAdvertisement(dir, want))
+	a, err = LoadAdvertisement(dir)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, a)
+
+	key, err := privKey(&Config{DataDir: dir})
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	defer db.Close()
+	localNode := enode.NewLocalNode(db, key)
+	applyAdvertisement(localNode, a, false)
+	assert.Equal(t, "192.0.2.1", localNode.Node().IP().String())
+	assert.Equal(t, 9000, localNode.Node().TCP())
+}
+
+func TestNodeRecord(t *testing.T) {
+	key, err := privKey(&Config{DataDir: t.TempDir()})
+	require.NoError(t, err)
+	n, err := NodeRecord(key, net.ParseIP("192.0.2.1"), 13000, 12000)
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.1", n.IP().String())
+	assert.Equal(t, 13000, n.TCP())
+	assert.Equal(t, 12000, n.UDP())
+	parsed, err := enode.Parse(enode.ValidSchemes, n.String())
+	require.NoError(t, err)
+	assert.Equal(t, n.ID(), parsed.ID())
+
+	pid, err := PeerIDFromNetworkKey(key)
+	require.NoError(t, err)
+	assert.NotEqual(t, "", pid.String())
+}
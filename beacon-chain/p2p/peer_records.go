@@ -0,0 +1,35 @@
+package p2p
+
+import (
+	"time"
+
+	prysmTime "github.com/prysmaticlabs/prysm/time"
+)
+
+// peerRecordsSaveInterval is how often the peer scoring history is persisted to the database.
+const peerRecordsSaveInterval = 10 * time.Minute
+
+// restorePeerRecords loads the peer scoring history persisted by a previous run of the node, so
+// that peers penalized before a restart are not given a clean slate.
+func (s *Service) restorePeerRecords() {
+	if s.cfg.DB == nil {
+		return
+	}
+	records, err := s.cfg.DB.PeerRecords(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not load peer records")
+		return
+	}
+	restored := s.peers.RestorePeerRecords(records, prysmTime.Now())
+	log.WithField("peers", restored).Debug("Restored peer records")
+}
+
+// savePeerRecords persists the current peer scoring history.
+func (s *Service) savePeerRecords() {
+	if s.cfg.DB == nil {
+		return
+	}
+	if err := s.cfg.DB.SavePeerRecords(s.ctx, s.peers.PeerRecords()); err != nil {
+		log.WithError(err).Error("Could not save peer records")
+	}
+}
@@ -0,0 +1,60 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestService_PeerRecords_SurviveRestart(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	newService := func() *Service {
+		return &Service{
+			ctx: context.Background(),
+			cfg: &Config{DB: db},
+			peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+				PeerLimit:    30,
+				ScorerParams: &scorers.Config{},
+			}),
+		}
+	}
+
+	pid, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	s := newService()
+	s.peers.Add(nil, pid, nil, network.DirOutbound)
+	s.peers.SetConnectionState(pid, peers.PeerConnected)
+	for i := 0; i < scorers.DefaultBadResponsesThreshold; i++ {
+		s.peers.Scorers().BadResponsesScorer().Increment(pid)
+	}
+	assert.Equal(t, true, s.peers.IsBad(pid))
+	s.savePeerRecords()
+
+	restarted := newService()
+	restarted.restorePeerRecords()
+	assert.Equal(t, true, restarted.peers.IsBad(pid))
+	count, err := restarted.peers.Scorers().BadResponsesScorer().Count(pid)
+	require.NoError(t, err)
+	assert.Equal(t, scorers.DefaultBadResponsesThreshold, count)
+}
+
+func TestService_PeerRecords_NoDB(t *testing.T) {
+	s := &Service{
+		ctx: context.Background(),
+		cfg: &Config{},
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	s.savePeerRecords()
+	s.restorePeerRecords()
+	assert.Equal(t, 0, len(s.peers.All()))
+}
@@ -0,0 +1,115 @@
+package peers
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
+	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
+)
+
+// maxRecentChurnEvents is the number of latest churn events kept for inspection.
+const maxRecentChurnEvents = 256
+
+var peerChurnEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "p2p_peer_churn_events_total",
+	Help: "The number of goodbye messages received and sent, and of peers dropped on errors, by reason.",
+}, []string{"source", "reason"})
+
+type churnKey struct {
+	source pb.PeerChurnEvent_Source
+	reason string
+}
+
+// churnLog counts the peer churn events by source and reason, and keeps the latest of them in a ring.
+type churnLog struct {
+	lock   sync.Mutex
+	counts map[churnKey]uint64
+	recent []*pb.PeerChurnEvent
+	next   int
+}
+
+func newChurnLog() *churnLog {
+	return &churnLog{
+		counts: make(map[churnKey]uint64),
+		recent: make([]*pb.PeerChurnEvent, 0, maxRecentChurnEvents),
+	}
+}
+
+func (c *churnLog) add(event *pb.PeerChurnEvent) {
+	peerChurnEvents.WithLabelValues(event.Source.String(), event.Reason).Inc()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.counts[churnKey{source: event.Source, reason: event.Reason}]++
+	if len(c.recent) < maxRecentChurnEvents {
+		c.recent = append(c.recent, event)
+		return
+	}
+	c.recent[c.next] = event
+	c.next = (c.next + 1) % maxRecentChurnEvents
+}
+
+func (c *churnLog) snapshot() *pb.PeerChurnResponse {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	counts := make([]*pb.PeerChurnCount, 0, len(c.counts))
+	for k, count := range c.counts {
+		counts = append(counts, &pb.PeerChurnCount{Source: k.source, Reason: k.reason, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Source != counts[j].Source {
+			return counts[i].Source < counts[j].Source
+		}
+		return counts[i].Reason < counts[j].Reason
+	})
+	recent := make([]*pb.PeerChurnEvent, 0, len(c.recent))
+	recent = append(recent, c.recent[c.next:]...)
+	recent = append(recent, c.recent[:c.next]...)
+	return &pb.PeerChurnResponse{Counts: counts, RecentEvents: recent}
+}
+
+// RecordGoodbyeReceived records a goodbye message received from the peer.
+func (p *Status) RecordGoodbyeReceived(pid peer.ID, code p2ptypes.RPCGoodbyeCode) {
+	p.churn.add(goodbyeEvent(pid, pb.PeerChurnEvent_GOODBYE_RECEIVED, code))
+}
+
+// RecordGoodbyeSent records a goodbye message sent to the peer.
+func (p *Status) RecordGoodbyeSent(pid peer.ID, code p2ptypes.RPCGoodbyeCode) {
+	p.churn.add(goodbyeEvent(pid, pb.PeerChurnEvent_GOODBYE_SENT, code))
+}
+
+// RecordDisconnectError records the peer being dropped on an error. The reason should come from a
+// small set of values, as it is used as a metric label.
+func (p *Status) RecordDisconnectError(pid peer.ID, reason string) {
+	p.churn.add(&pb.PeerChurnEvent{
+		PeerId:    pid.String(),
+		Source:    pb.PeerChurnEvent_ERROR,
+		Reason:    reason,
+		Timestamp: uint64(prysmTime.Now().Unix()),
+	})
+}
+
+// Churn returns the number of peer churn events by source and reason, along with the latest events,
+// oldest first.
+func (p *Status) Churn() *pb.PeerChurnResponse {
+	return p.churn.snapshot()
+}
+
+func goodbyeEvent(pid peer.ID, source pb.PeerChurnEvent_Source, code p2ptypes.RPCGoodbyeCode) *pb.PeerChurnEvent {
+	// Unknown codes are sent by peers, so they are grouped to keep the number of reasons bounded.
+	reason, ok := p2ptypes.GoodbyeCodeMessages[code]
+	if !ok {
+		reason = "unknown"
+	}
+	return &pb.PeerChurnEvent{
+		PeerId:      pid.String(),
+		Source:      source,
+		GoodbyeCode: uint64(code),
+		Reason:      reason,
+		Timestamp:   uint64(prysmTime.Now().Unix()),
+	}
+}
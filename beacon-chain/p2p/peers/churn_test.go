@@ -0,0 +1,64 @@
+package peers
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
+	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestStatus_Churn(t *testing.T) {
+	p := NewStatus(context.Background(), &StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	res := p.Churn()
+	assert.Equal(t, 0, len(res.Counts))
+	assert.Equal(t, 0, len(res.RecentEvents))
+
+	p.RecordDisconnectError("a", "handshake failed")
+	p.RecordGoodbyeSent("a", p2ptypes.GoodbyeCodeGenericError)
+	p.RecordGoodbyeReceived("b", p2ptypes.GoodbyeCodeTooManyPeers)
+	p.RecordGoodbyeReceived("c", p2ptypes.GoodbyeCodeTooManyPeers)
+	p.RecordGoodbyeReceived("d", 1000)
+
+	res = p.Churn()
+	assert.DeepEqual(t, []*pb.PeerChurnCount{
+		{Source: pb.PeerChurnEvent_GOODBYE_RECEIVED, Reason: "client has too many peers", Count: 2},
+		{Source: pb.PeerChurnEvent_GOODBYE_RECEIVED, Reason: "unknown", Count: 1},
+		{Source: pb.PeerChurnEvent_GOODBYE_SENT, Reason: "fault/error", Count: 1},
+		{Source: pb.PeerChurnEvent_ERROR, Reason: "handshake failed", Count: 1},
+	}, res.Counts)
+	require.Equal(t, 5, len(res.RecentEvents))
+	assert.Equal(t, peer.ID("a").String(), res.RecentEvents[0].PeerId)
+	assert.Equal(t, pb.PeerChurnEvent_ERROR, res.RecentEvents[0].Source)
+	assert.Equal(t, uint64(0), res.RecentEvents[0].GoodbyeCode)
+	assert.Equal(t, uint64(p2ptypes.GoodbyeCodeGenericError), res.RecentEvents[1].GoodbyeCode)
+	assert.Equal(t, uint64(1000), res.RecentEvents[4].GoodbyeCode)
+	assert.NotEqual(t, uint64(0), res.RecentEvents[4].Timestamp)
+}
+
+func TestStatus_ChurnKeepsLatestEvents(t *testing.T) {
+	p := NewStatus(context.Background(), &StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	total := maxRecentChurnEvents + 10
+	for i := 0; i < total; i++ {
+		p.RecordGoodbyeReceived(peer.ID(strconv.Itoa(i)), p2ptypes.GoodbyeCodeClientShutdown)
+	}
+
+	res := p.Churn()
+	require.Equal(t, 1, len(res.Counts))
+	assert.Equal(t, uint64(total), res.Counts[0].Count)
+	require.Equal(t, maxRecentChurnEvents, len(res.RecentEvents))
+	for i, event := range res.RecentEvents {
+		assert.Equal(t, peer.ID(strconv.Itoa(i+10)).String(), event.PeerId)
+	}
+}
@@ -0,0 +1,89 @@
+package peers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// NodeRole is the part of the networking protocol a beacon node serves to its peers, allowing
+// operators to split gossip relaying and serving of syncing peers across tiers of nodes.
+type NodeRole uint8
+
+const (
+	// FullNode relays every gossip topic and serves every req/resp protocol.
+	FullNode NodeRole = iota
+	// GossipRelayNode relays every gossip topic, and does not serve blocks by range to syncing peers.
+	GossipRelayNode
+	// RPCServerNode serves blocks to syncing peers with raised limits, and only subscribes to the
+	// block gossip topic to follow the head of the chain.
+	RPCServerNode
+)
+
+// NodeRoleEnrKey is the key of the ENR entry advertising the role of a node. Nodes without this
+// entry, such as the ones of other clients, are full nodes.
+const NodeRoleEnrKey = "role"
+
+var nodeRoleNames = []string{
+	FullNode:        "full",
+	GossipRelayNode: "gossip-relay",
+	RPCServerNode:   "rpc-server",
+}
+
+// NodeRoleNames lists the names of the node roles.
+func NodeRoleNames() []string {
+	return append([]string{}, nodeRoleNames...)
+}
+
+// ParseNodeRole returns the node role of the given name.
+func ParseNodeRole(name string) (NodeRole, error) {
+	for r, n := range nodeRoleNames {
+		if n == name {
+			return NodeRole(r), nil
+		}
+	}
+	return FullNode, fmt.Errorf("unknown node role %q, wanted one of %s", name, strings.Join(nodeRoleNames, ", "))
+}
+
+// String returns the name of the node role.
+func (r NodeRole) String() string {
+	if int(r) < len(nodeRoleNames) {
+		return nodeRoleNames[r]
+	}
+	return fmt.Sprintf("unknown(%d)", r)
+}
+
+// ServesBlocksByRange returns true if nodes of the role serve syncing peers blocks by range.
+func (r NodeRole) ServesBlocksByRange() bool {
+	return r != GossipRelayNode
+}
+
+// RelaysAllTopics returns true if nodes of the role subscribe to every gossip topic.
+func (r NodeRole) RelaysAllTopics() bool {
+	return r != RPCServerNode
+}
+
+// NodeRoleFromENR returns the role advertised in the given record, a full node if the record has
+// no valid role entry.
+func NodeRoleFromENR(record *enr.Record) NodeRole {
+	if record == nil {
+		return FullNode
+	}
+	var r uint8
+	if err := record.Load(enr.WithEntry(NodeRoleEnrKey, &r)); err != nil || int(r) >= len(nodeRoleNames) {
+		return FullNode
+	}
+	return NodeRole(r)
+}
+
+// NodeRole returns the role advertised by the peer in its ENR, a full node if the ENR of the
+// peer is not known.
+func (p *Status) NodeRole(pid peer.ID) NodeRole {
+	record, err := p.ENR(pid)
+	if err != nil {
+		return FullNode
+	}
+	return NodeRoleFromENR(record)
+}
@@ -0,0 +1,43 @@
+package peers_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestParseNodeRole(t *testing.T) {
+	for _, name := range peers.NodeRoleNames() {
+		role, err := peers.ParseNodeRole(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, role.String())
+	}
+	_, err := peers.ParseNodeRole("archive")
+	assert.ErrorContains(t, "unknown node role \"archive\"", err)
+	assert.Equal(t, "unknown(7)", peers.NodeRole(7).String())
+}
+
+func TestNodeRole_Capabilities(t *testing.T) {
+	assert.Equal(t, true, peers.FullNode.ServesBlocksByRange())
+	assert.Equal(t, true, peers.FullNode.RelaysAllTopics())
+	assert.Equal(t, false, peers.GossipRelayNode.ServesBlocksByRange())
+	assert.Equal(t, true, peers.GossipRelayNode.RelaysAllTopics())
+	assert.Equal(t, true, peers.RPCServerNode.ServesBlocksByRange())
+	assert.Equal(t, false, peers.RPCServerNode.RelaysAllTopics())
+}
+
+func TestNodeRoleFromENR(t *testing.T) {
+	assert.Equal(t, peers.FullNode, peers.NodeRoleFromENR(nil))
+	assert.Equal(t, peers.FullNode, peers.NodeRoleFromENR(&enr.Record{}))
+
+	record := &enr.Record{}
+	record.Set(enr.WithEntry(peers.NodeRoleEnrKey, uint8(peers.RPCServerNode)))
+	assert.Equal(t, peers.RPCServerNode, peers.NodeRoleFromENR(record))
+
+	// Roles unknown to this node are treated as full nodes.
+	record.Set(enr.WithEntry(peers.NodeRoleEnrKey, uint8(100)))
+	assert.Equal(t, peers.FullNode, peers.NodeRoleFromENR(record))
+}
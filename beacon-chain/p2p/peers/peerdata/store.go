@@ -51,6 +51,9 @@ type PeerData struct {
 	ConnState     PeerConnectionState
 	Enr           *enr.Record
 	NextValidTime time.Time
+	// Connection history.
+	ConnectionCount uint64
+	LastSeen        time.Time
 	// Chain related data.
 	MetaData                  metadata.Metadata
 	ChainState                *ethpb.Status
@@ -60,6 +63,10 @@ type PeerData struct {
 	BadResponses         int
 	ProcessedBlocks      uint64
 	BlockProviderUpdated time.Time
+	ReceivedBlocks       uint64
+	MissingBlocks        uint64
+	InvalidBlocks        uint64
+	BlockResponseLatency time.Duration
 	// Gossip Scoring data.
 	TopicScores      map[string]*ethpb.TopicScoreSnapshot
 	GossipScore      float64
@@ -141,6 +141,20 @@ func (s *BlockProviderScorer) IncrementProcessedBlocks(pid peer.ID, cnt uint64)
 	}
 }
 
+// DecrementProcessedBlocks decrements the number of blocks that have been successfully processed, so that a
+// peer which was slow to provide blocks is less likely to be picked next.
+func (s *BlockProviderScorer) DecrementProcessedBlocks(pid peer.ID, cnt uint64) {
+	s.store.Lock()
+	defer s.store.Unlock()
+	defer s.touch(pid)
+
+	peerData := s.store.PeerDataGetOrCreate(pid)
+	if cnt > peerData.ProcessedBlocks {
+		cnt = peerData.ProcessedBlocks
+	}
+	peerData.ProcessedBlocks -= cnt
+}
+
 // Touch updates last access time for a given peer. This allows to detect peers that are
 // stale and boost their scores to increase chances in block fetching participation.
 func (s *BlockProviderScorer) Touch(pid peer.ID, t ...time.Time) {
@@ -149,6 +149,28 @@ func TestScorers_BlockProvider_GettersSetters(t *testing.T) {
 	assert.Equal(t, uint64(64), scorer.ProcessedBlocks("peer1"))
 }
 
+func TestScorers_BlockProvider_DecrementProcessedBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerStatuses := peers.NewStatus(ctx, &peers.StatusConfig{
+		ScorerParams: &scorers.Config{},
+	})
+	scorer := peerStatuses.Scorers().BlockProviderScorer()
+
+	// A new peer is boosted to the max score, until it is penalized.
+	assert.Equal(t, scorer.MaxScore(), scorer.Score("peer1"))
+	scorer.DecrementProcessedBlocks("peer1", 64)
+	assert.Equal(t, 0.0, scorer.Score("peer1"))
+
+	scorer.IncrementProcessedBlocks("peer1", 64)
+	scorer.DecrementProcessedBlocks("peer1", 32)
+	assert.Equal(t, uint64(32), scorer.ProcessedBlocks("peer1"))
+	// The processed blocks never go below zero.
+	scorer.DecrementProcessedBlocks("peer1", 64)
+	assert.Equal(t, uint64(0), scorer.ProcessedBlocks("peer1"))
+}
+
 func TestScorers_BlockProvider_WeightSorted(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -0,0 +1,193 @@
+package scorers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/crypto/rand"
+)
+
+var _ Scorer = (*BlockQualityScorer)(nil)
+
+const (
+	// DefaultBlockQualityLatencyTarget defines the block response latency under which a peer's
+	// latency does not lower its quality score.
+	DefaultBlockQualityLatencyTarget = 500 * time.Millisecond
+	// DefaultBlockQualityDecayInterval defines how often the block counters are halved, so that
+	// quality reflects the recent behaviour of a peer.
+	DefaultBlockQualityDecayInterval = 5 * time.Minute
+	// blockQualityLatencySampleWeight is the weight of a new latency sample in the moving average.
+	blockQualityLatencySampleWeight = 0.2
+)
+
+// BlockQualityScorer represents block quality scoring service. It tracks how fast a peer responds
+// to block requests, and which share of the blocks it serves are missing or invalid, so that
+// sync can prefer high quality peers when requesting blocks.
+type BlockQualityScorer struct {
+	config *BlockQualityScorerConfig
+	store  *peerdata.Store
+}
+
+// BlockQualityScorerConfig holds configuration parameters for block quality scoring service.
+type BlockQualityScorerConfig struct {
+	// LatencyTarget defines the response latency under which latency is not penalized. Above it,
+	// the latency factor of the score is LatencyTarget/latency.
+	LatencyTarget time.Duration
+	// DecayInterval defines how often stats should be decayed.
+	DecayInterval time.Duration
+}
+
+// newBlockQualityScorer creates block quality scoring service.
+func newBlockQualityScorer(store *peerdata.Store, config *BlockQualityScorerConfig) *BlockQualityScorer {
+	if config == nil {
+		config = &BlockQualityScorerConfig{}
+	}
+	scorer := &BlockQualityScorer{
+		config: config,
+		store:  store,
+	}
+	if scorer.config.LatencyTarget == 0 {
+		scorer.config.LatencyTarget = DefaultBlockQualityLatencyTarget
+	}
+	if scorer.config.DecayInterval == 0 {
+		scorer.config.DecayInterval = DefaultBlockQualityDecayInterval
+	}
+	return scorer
+}
+
+// Score returns block quality score of a peer, in the (0; 1] range. Peers without any recorded
+// block responses get the maximum score, so that they are given a chance to serve blocks.
+func (s *BlockQualityScorer) Score(pid peer.ID) float64 {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	return s.score(pid)
+}
+
+// score is a lock-free version of Score.
+func (s *BlockQualityScorer) score(pid peer.ID) float64 {
+	peerData, ok := s.store.PeerData(pid)
+	if !ok {
+		return 1.0
+	}
+	score := 1.0
+	if peerData.BlockResponseLatency > s.config.LatencyTarget {
+		score *= float64(s.config.LatencyTarget) / float64(peerData.BlockResponseLatency)
+	}
+	if total := peerData.ReceivedBlocks + peerData.MissingBlocks; total > 0 {
+		score *= 1.0 - float64(peerData.MissingBlocks)/float64(total)
+	}
+	if peerData.InvalidBlocks > 0 {
+		invalidRate := 1.0
+		if peerData.InvalidBlocks < peerData.ReceivedBlocks {
+			invalidRate = float64(peerData.InvalidBlocks) / float64(peerData.ReceivedBlocks)
+		}
+		score *= 1.0 - invalidRate
+	}
+	return math.Round(score*ScoreRoundingFactor) / ScoreRoundingFactor
+}
+
+// Scores returns block quality scores of the given peers.
+func (s *BlockQualityScorer) Scores(pids []peer.ID) map[peer.ID]float64 {
+	s.store.RLock()
+	defer s.store.RUnlock()
+
+	scores := make(map[peer.ID]float64, len(pids))
+	for _, pid := range pids {
+		scores[pid] = s.score(pid)
+	}
+	return scores
+}
+
+// Params exposes scorer's parameters.
+func (s *BlockQualityScorer) Params() *BlockQualityScorerConfig {
+	return s.config
+}
+
+// RecordResponse records a block response of a peer, carrying a given number of blocks and
+// completed after a given latency.
+func (s *BlockQualityScorer) RecordResponse(pid peer.ID, blocks uint64, latency time.Duration) {
+	s.store.Lock()
+	defer s.store.Unlock()
+
+	peerData := s.store.PeerDataGetOrCreate(pid)
+	peerData.ReceivedBlocks += blocks
+	if peerData.BlockResponseLatency == 0 {
+		peerData.BlockResponseLatency = latency
+		return
+	}
+	delta := float64(latency-peerData.BlockResponseLatency) * blockQualityLatencySampleWeight
+	peerData.BlockResponseLatency += time.Duration(delta)
+}
+
+// RecordMissingBlocks records blocks requested by root which a peer did not return.
+func (s *BlockQualityScorer) RecordMissingBlocks(pid peer.ID, cnt uint64) {
+	s.store.Lock()
+	defer s.store.Unlock()
+	s.store.PeerDataGetOrCreate(pid).MissingBlocks += cnt
+}
+
+// RecordInvalidBlocks records blocks returned by a peer that failed validation.
+func (s *BlockQualityScorer) RecordInvalidBlocks(pid peer.ID, cnt uint64) {
+	s.store.Lock()
+	defer s.store.Unlock()
+	s.store.PeerDataGetOrCreate(pid).InvalidBlocks += cnt
+}
+
+// IsBadPeer states if the peer is to be considered bad.
+// Low block quality is only used to rank sync sources, so this scorer never marks peers as bad.
+func (_ *BlockQualityScorer) IsBadPeer(_ peer.ID) bool {
+	return false
+}
+
+// BadPeers returns the peers that are considered bad.
+// No peers are considered bad by block quality scorer.
+func (_ *BlockQualityScorer) BadPeers() []peer.ID {
+	return []peer.ID{}
+}
+
+// Decay halves block counters of all peers, so that peers can recover from past failures.
+func (s *BlockQualityScorer) Decay() {
+	s.store.Lock()
+	defer s.store.Unlock()
+
+	for _, peerData := range s.store.Peers() {
+		peerData.ReceivedBlocks /= 2
+		peerData.MissingBlocks /= 2
+		peerData.InvalidBlocks /= 2
+	}
+}
+
+// Sorted returns a list of peers sorted by block quality score in descending order. Peers
+// having the same score are returned in random order.
+func (s *BlockQualityScorer) Sorted(r *rand.Rand, pids []peer.ID) []peer.ID {
+	if len(pids) == 0 {
+		return pids
+	}
+	scores := s.Scores(pids)
+	peers := make([]peer.ID, len(pids))
+	copy(peers, pids)
+	r.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+	sort.SliceStable(peers, func(i, j int) bool {
+		return scores[peers[i]] > scores[peers[j]]
+	})
+	return peers
+}
+
+// FormatScorePretty returns full scoring information in a human-readable format.
+func (s *BlockQualityScorer) FormatScorePretty(pid peer.ID) string {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	peerData, ok := s.store.PeerData(pid)
+	if !ok {
+		return "[1.00, no responses]"
+	}
+	return fmt.Sprintf("[%0.2f, latency: %v, blocks: %d, missing: %d, invalid: %d]",
+		s.score(pid), peerData.BlockResponseLatency, peerData.ReceivedBlocks,
+		peerData.MissingBlocks, peerData.InvalidBlocks)
+}
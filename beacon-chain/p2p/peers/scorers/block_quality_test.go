@@ -0,0 +1,125 @@
+package scorers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/crypto/rand"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+)
+
+func TestScorers_BlockQuality_Score(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tests := []struct {
+		name   string
+		update func(scorer *scorers.BlockQualityScorer)
+		want   float64
+	}{
+		{
+			name: "nonexistent peer",
+			want: 1.0,
+		},
+		{
+			name: "fast peer serving all blocks",
+			update: func(scorer *scorers.BlockQualityScorer) {
+				scorer.RecordResponse("peer1", 64, 100*time.Millisecond)
+			},
+			want: 1.0,
+		},
+		{
+			name: "slow peer",
+			update: func(scorer *scorers.BlockQualityScorer) {
+				scorer.RecordResponse("peer1", 64, 2*time.Second)
+			},
+			want: 0.25,
+		},
+		{
+			name: "missing blocks",
+			update: func(scorer *scorers.BlockQualityScorer) {
+				scorer.RecordResponse("peer1", 6, 100*time.Millisecond)
+				scorer.RecordMissingBlocks("peer1", 2)
+			},
+			want: 0.75,
+		},
+		{
+			name: "invalid blocks",
+			update: func(scorer *scorers.BlockQualityScorer) {
+				scorer.RecordResponse("peer1", 10, 100*time.Millisecond)
+				scorer.RecordInvalidBlocks("peer1", 5)
+			},
+			want: 0.5,
+		},
+		{
+			name: "only invalid blocks",
+			update: func(scorer *scorers.BlockQualityScorer) {
+				scorer.RecordInvalidBlocks("peer1", 1)
+			},
+			want: 0.0,
+		},
+		{
+			name: "latency moving average",
+			update: func(scorer *scorers.BlockQualityScorer) {
+				scorer.RecordResponse("peer1", 1, 500*time.Millisecond)
+				scorer.RecordResponse("peer1", 1, 3*time.Second)
+			},
+			want: 0.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peerStatuses := peers.NewStatus(ctx, &peers.StatusConfig{
+				ScorerParams: &scorers.Config{},
+			})
+			scorer := peerStatuses.Scorers().BlockQualityScorer()
+			if tt.update != nil {
+				tt.update(scorer)
+			}
+			assert.Equal(t, tt.want, scorer.Score("peer1"))
+		})
+	}
+}
+
+func TestScorers_BlockQuality_Decay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerStatuses := peers.NewStatus(ctx, &peers.StatusConfig{
+		ScorerParams: &scorers.Config{},
+	})
+	scorer := peerStatuses.Scorers().BlockQualityScorer()
+	scorer.RecordResponse("peer1", 3, 100*time.Millisecond)
+	scorer.RecordMissingBlocks("peer1", 1)
+	assert.Equal(t, 0.75, scorer.Score("peer1"))
+
+	// Missing blocks counter drops to zero, while received blocks remain.
+	scorer.Decay()
+	assert.Equal(t, 1.0, scorer.Score("peer1"))
+}
+
+func TestScorers_BlockQuality_Sorted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerStatuses := peers.NewStatus(ctx, &peers.StatusConfig{
+		ScorerParams: &scorers.Config{},
+	})
+	scorer := peerStatuses.Scorers().BlockQualityScorer()
+	scorer.RecordResponse("slow", 64, 1*time.Second)
+	scorer.RecordResponse("invalid", 64, 100*time.Millisecond)
+	scorer.RecordInvalidBlocks("invalid", 48)
+	scorer.RecordResponse("good", 64, 100*time.Millisecond)
+
+	pids := []peer.ID{"invalid", "slow", "good"}
+	r := rand.NewDeterministicGenerator()
+	assert.DeepEqual(t, []peer.ID{"good", "slow", "invalid"}, scorer.Sorted(r, pids))
+	// Input is not modified.
+	assert.DeepEqual(t, []peer.ID{"invalid", "slow", "good"}, pids)
+	assert.DeepEqual(t, map[peer.ID]float64{"invalid": 0.25, "slow": 0.5, "good": 1.0}, scorer.Scores(pids))
+}
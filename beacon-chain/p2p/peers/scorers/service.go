@@ -34,6 +34,7 @@ type Service struct {
 	scorers struct {
 		badResponsesScorer  *BadResponsesScorer
 		blockProviderScorer *BlockProviderScorer
+		blockQualityScorer  *BlockQualityScorer
 		peerStatusScorer    *PeerStatusScorer
 		gossipScorer        *GossipScorer
 	}
@@ -45,6 +46,7 @@ type Service struct {
 type Config struct {
 	BadResponsesScorerConfig  *BadResponsesScorerConfig
 	BlockProviderScorerConfig *BlockProviderScorerConfig
+	BlockQualityScorerConfig  *BlockQualityScorerConfig
 	PeerStatusScorerConfig    *PeerStatusScorerConfig
 	GossipScorerConfig        *GossipScorerConfig
 }
@@ -61,6 +63,8 @@ func NewService(ctx context.Context, store *peerdata.Store, config *Config) *Ser
 	s.setScorerWeight(s.scorers.badResponsesScorer, 0.3)
 	s.scorers.blockProviderScorer = newBlockProviderScorer(store, config.BlockProviderScorerConfig)
 	s.setScorerWeight(s.scorers.blockProviderScorer, 0.0)
+	s.scorers.blockQualityScorer = newBlockQualityScorer(store, config.BlockQualityScorerConfig)
+	s.setScorerWeight(s.scorers.blockQualityScorer, 0.0)
 	s.scorers.peerStatusScorer = newPeerStatusScorer(store, config.PeerStatusScorerConfig)
 	s.setScorerWeight(s.scorers.peerStatusScorer, 0.3)
 	s.scorers.gossipScorer = newGossipScorer(store, config.GossipScorerConfig)
@@ -82,6 +86,11 @@ func (s *Service) BlockProviderScorer() *BlockProviderScorer {
 	return s.scorers.blockProviderScorer
 }
 
+// BlockQualityScorer exposes block quality scoring service.
+func (s *Service) BlockQualityScorer() *BlockQualityScorer {
+	return s.scorers.blockQualityScorer
+}
+
 // PeerStatusScorer exposes peer chain status scoring service.
 func (s *Service) PeerStatusScorer() *PeerStatusScorer {
 	return s.scorers.peerStatusScorer
@@ -118,6 +127,7 @@ func (s *Service) ScoreNoLock(pid peer.ID) float64 {
 	}
 	score += s.scorers.badResponsesScorer.score(pid) * s.scorerWeight(s.scorers.badResponsesScorer)
 	score += s.scorers.blockProviderScorer.score(pid) * s.scorerWeight(s.scorers.blockProviderScorer)
+	score += s.scorers.blockQualityScorer.score(pid) * s.scorerWeight(s.scorers.blockQualityScorer)
 	score += s.scorers.peerStatusScorer.score(pid) * s.scorerWeight(s.scorers.peerStatusScorer)
 	score += s.scorers.gossipScorer.score(pid) * s.scorerWeight(s.scorers.gossipScorer)
 	return math.Round(score*ScoreRoundingFactor) / ScoreRoundingFactor
@@ -179,6 +189,8 @@ func (s *Service) loop(ctx context.Context) {
 	defer decayBadResponsesStats.Stop()
 	decayBlockProviderStats := time.NewTicker(s.scorers.blockProviderScorer.Params().DecayInterval)
 	defer decayBlockProviderStats.Stop()
+	decayBlockQualityStats := time.NewTicker(s.scorers.blockQualityScorer.Params().DecayInterval)
+	defer decayBlockQualityStats.Stop()
 
 	for {
 		select {
@@ -194,6 +206,12 @@ func (s *Service) loop(ctx context.Context) {
 				return
 			}
 			s.scorers.blockProviderScorer.Decay()
+		case <-decayBlockQualityStats.C:
+			// Exit early if context is canceled.
+			if ctx.Err() != nil {
+				return
+			}
+			s.scorers.blockQualityScorer.Decay()
 		case <-ctx.Done():
 			return
 		}
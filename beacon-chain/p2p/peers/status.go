@@ -19,7 +19,8 @@
 //
 // Peer information is persistent for the run of the service. This allows for collection of useful
 // long-term statistics such as number of bad responses obtained from the peer, giving the basis for
-// decisions to not talk to known-bad peers (by de-scoring them).
+// decisions to not talk to known-bad peers (by de-scoring them). Scoring and connection history can
+// additionally be exported as peer records and restored after a restart, decayed over the downtime.
 package peers
 
 import (
@@ -74,6 +75,12 @@ const (
 	MinBackOffDuration = 100
 	// MaxBackOffDuration maximum amount (in milliseconds) to wait before peer is re-dialed.
 	MaxBackOffDuration = 5000
+
+	// peerRecordExpiry is how long a peer record is kept after the peer was last seen.
+	peerRecordExpiry = 7 * 24 * time.Hour
+	// peerRecordScoreDecay is the fraction of a restored gossip score and behaviour penalty that is
+	// retained for every bad responses decay interval that passed since the peer was last seen.
+	peerRecordScoreDecay = 0.5
 )
 
 // Status is the structure holding the peer status information.
@@ -83,6 +90,7 @@ type Status struct {
 	store     *peerdata.Store
 	ipTracker map[string]uint64
 	rand      *rand.Rand
+	churn     *churnLog
 }
 
 // StatusConfig represents peer status service params.
@@ -105,7 +113,8 @@ func NewStatus(ctx context.Context, config *StatusConfig) *Status {
 		ipTracker: map[string]uint64{},
 		// Random generator used to calculate dial backoff period.
 		// It is ok to use deterministic generator, no need for true entropy.
-		rand: rand.NewDeterministicGenerator(),
+		rand:  rand.NewDeterministicGenerator(),
+		churn: newChurnLog(),
 	}
 }
 
@@ -119,6 +128,13 @@ func (p *Status) MaxPeerLimit() int {
 	return p.store.Config().MaxPeers
 }
 
+// SetPeerLimit updates the maximum amount of concurrent peers the node is expected to be connected to.
+func (p *Status) SetPeerLimit(limit int) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.Config().MaxPeers = maxLimitBuffer + limit
+}
+
 // Add adds a peer.
 // If a peer already exists with this ID its address and direction are updated with the supplied data.
 func (p *Status) Add(record *enr.Record, pid peer.ID, address ma.Multiaddr, direction network.Direction) {
@@ -298,9 +314,104 @@ func (p *Status) SetConnectionState(pid peer.ID, state peerdata.PeerConnectionSt
 	defer p.store.Unlock()
 
 	peerData := p.store.PeerDataGetOrCreate(pid)
+	if state == PeerConnected && peerData.ConnState != PeerConnected {
+		peerData.ConnectionCount++
+	}
+	if state == PeerConnected || peerData.ConnState == PeerConnected {
+		peerData.LastSeen = prysmTime.Now()
+	}
 	peerData.ConnState = state
 }
 
+// PeerRecords returns the scoring and connection history of all the peers that were either
+// connected at some point or have been penalized, so that it can be persisted across restarts.
+func (p *Status) PeerRecords() []*pb.PeerRecord {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	now := prysmTime.Now()
+	records := make([]*pb.PeerRecord, 0)
+	for pid, peerData := range p.store.Peers() {
+		if peerData.ConnectionCount == 0 && peerData.BadResponses == 0 &&
+			peerData.GossipScore >= 0 && peerData.BehaviourPenalty <= 0 {
+			continue
+		}
+		lastSeen := peerData.LastSeen
+		if peerData.ConnState == PeerConnected || lastSeen.IsZero() {
+			lastSeen = now
+		}
+		record := &pb.PeerRecord{
+			PeerId:           pid.String(),
+			BadResponses:     uint64(peerData.BadResponses),
+			GossipScore:      peerData.GossipScore,
+			BehaviourPenalty: peerData.BehaviourPenalty,
+			ConnectionCount:  peerData.ConnectionCount,
+			LastSeen:         lastSeen.Unix(),
+		}
+		if peerData.Address != nil {
+			record.Address = peerData.Address.String()
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// RestorePeerRecords adds the peers of previously persisted records as disconnected peers, with
+// their scoring history decayed by the time elapsed since they were last seen. Records of peers
+// that are already known, that cannot be parsed or that have expired are ignored. The number of
+// restored peers is returned.
+func (p *Status) RestorePeerRecords(records []*pb.PeerRecord, now time.Time) int {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	decayInterval := p.scorers.BadResponsesScorer().Params().DecayInterval
+	restored := 0
+	for _, record := range records {
+		pid, err := peer.Decode(record.PeerId)
+		if err != nil {
+			log.WithError(err).Debug("Could not decode peer id of peer record")
+			continue
+		}
+		if _, ok := p.store.PeerData(pid); ok {
+			continue
+		}
+		lastSeen := time.Unix(record.LastSeen, 0)
+		elapsed := now.Sub(lastSeen)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		if elapsed > peerRecordExpiry {
+			continue
+		}
+		var address ma.Multiaddr
+		if record.Address != "" {
+			address, err = ma.NewMultiaddr(record.Address)
+			if err != nil {
+				log.WithError(err).Debug("Could not parse address of peer record")
+			}
+		}
+		decays := uint64(elapsed / decayInterval)
+		badResponses := uint64(0)
+		if record.BadResponses > decays {
+			badResponses = record.BadResponses - decays
+		}
+		scoreDecay := math.Pow(peerRecordScoreDecay, float64(decays))
+		p.store.SetPeerData(pid, &peerdata.PeerData{
+			Address:          address,
+			Direction:        network.DirUnknown,
+			ConnState:        PeerDisconnected,
+			ConnectionCount:  record.ConnectionCount,
+			LastSeen:         lastSeen,
+			BadResponses:     int(badResponses),
+			GossipScore:      record.GossipScore * scoreDecay,
+			BehaviourPenalty: record.BehaviourPenalty * scoreDecay,
+		})
+		p.addIpToTracker(pid)
+		restored++
+	}
+	return restored
+}
+
 // ConnectionState gets the connection state of the given remote peer.
 // This will error if the peer does not exist.
 func (p *Status) ConnectionState(pid peer.ID) (peerdata.PeerConnectionState, error) {
@@ -669,6 +669,21 @@ func TestConcurrentPeerLimitHolds(t *testing.T) {
 	assert.Equal(t, true, uint64(p.MaxPeerLimit()) > p.ConnectedPeerLimit(), "max peer limit doesnt exceed connected peer limit")
 }
 
+func TestSetPeerLimit(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 1,
+			},
+		},
+	})
+	assert.Equal(t, uint64(30), p.ConnectedPeerLimit())
+	p.SetPeerLimit(50)
+	assert.Equal(t, uint64(50), p.ConnectedPeerLimit())
+	assert.Equal(t, int(float64(50)*peers.InboundRatio), p.InboundLimit())
+}
+
 func TestAtInboundPeerLimit(t *testing.T) {
 	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
 		PeerLimit: 30,
@@ -1025,6 +1040,105 @@ func TestOutbound(t *testing.T) {
 	assert.Equal(t, outbound.Pretty(), result[0].Pretty())
 }
 
+func TestStatus_PeerRecords(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	connected := createPeer(t, p, address, network.DirOutbound, peers.PeerConnected)
+	p.SetConnectionState(connected, peers.PeerDisconnected)
+	p.SetConnectionState(connected, peers.PeerConnected)
+	p.Scorers().BadResponsesScorer().Increment(connected)
+	p.Scorers().GossipScorer().SetGossipData(connected, -20, 4, nil)
+	// Peers which were never connected and never penalized are not recorded.
+	createPeer(t, p, nil, network.DirUnknown, peers.PeerDisconnected)
+
+	records := p.PeerRecords()
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, connected.String(), records[0].PeerId)
+	assert.Equal(t, address.String(), records[0].Address)
+	assert.Equal(t, uint64(1), records[0].BadResponses)
+	assert.Equal(t, float64(-20), records[0].GossipScore)
+	assert.Equal(t, float64(4), records[0].BehaviourPenalty)
+	assert.Equal(t, uint64(2), records[0].ConnectionCount)
+}
+
+func TestStatus_RestorePeerRecords(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold:     4,
+				DecayInterval: time.Hour,
+			},
+		},
+	})
+
+	now := time.Now()
+	offender, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	decayed, err := peer.Decode("16Uiu2HAm7vz9t8eqdNXpBajfq9mAgVHdyv2Gd3WoQC1wsowCsKWp")
+	require.NoError(t, err)
+	expired, err := peer.Decode("16Uiu2HAkvSdYmzyLWHq27VcNJH5udEPXcGLy6KeeVTNvdbrHcbgE")
+	require.NoError(t, err)
+	records := []*pb.PeerRecord{
+		{
+			PeerId:           offender.String(),
+			Address:          "/ip4/213.202.254.180/tcp/13000",
+			BadResponses:     6,
+			GossipScore:      -40,
+			BehaviourPenalty: 8,
+			ConnectionCount:  3,
+			LastSeen:         now.Add(-90 * time.Minute).Unix(),
+		},
+		{
+			PeerId:          decayed.String(),
+			BadResponses:    2,
+			ConnectionCount: 1,
+			LastSeen:        now.Add(-3 * time.Hour).Unix(),
+		},
+		{
+			PeerId:       expired.String(),
+			BadResponses: 6,
+			LastSeen:     now.Add(-30 * 24 * time.Hour).Unix(),
+		},
+		{
+			PeerId: "invalid",
+		},
+	}
+	assert.Equal(t, 2, p.RestorePeerRecords(records, now))
+
+	// A single decay interval passed for the offender.
+	badResponses, err := p.Scorers().BadResponsesScorer().Count(offender)
+	require.NoError(t, err)
+	assert.Equal(t, 5, badResponses)
+	assert.Equal(t, true, p.IsBad(offender))
+	gossipScore, behaviourPenalty, _, err := p.Scorers().GossipScorer().GossipData(offender)
+	require.NoError(t, err)
+	assert.Equal(t, float64(-20), gossipScore)
+	assert.Equal(t, float64(4), behaviourPenalty)
+	address, err := p.Address(offender)
+	require.NoError(t, err)
+	assert.Equal(t, "/ip4/213.202.254.180/tcp/13000", address.String())
+	state, err := p.ConnectionState(offender)
+	require.NoError(t, err)
+	assert.Equal(t, peers.PeerDisconnected, state)
+
+	badResponses, err = p.Scorers().BadResponsesScorer().Count(decayed)
+	require.NoError(t, err)
+	assert.Equal(t, 0, badResponses)
+	assert.Equal(t, false, p.IsBad(decayed))
+
+	_, err = p.ConnectionState(expired)
+	assert.ErrorContains(t, peerdata.ErrPeerUnknown.Error(), err)
+
+	// Known peers are not overwritten.
+	assert.Equal(t, 0, p.RestorePeerRecords(records[:1], now))
+}
+
 // addPeer is a helper to add a peer with a given connection state)
 func addPeer(t *testing.T, p *peers.Status, state peerdata.PeerConnectionState) peer.ID {
 	// Set up some peers with different states
@@ -7,7 +7,6 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
-	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/network/forks"
 )
@@ -61,7 +60,7 @@ func (s *Service) CanSubscribe(topic string) bool {
 		return false
 	}
 
-	if parts[4] != encoder.ProtocolSuffixSSZSnappy {
+	if "/"+parts[4] != s.Encoding().ProtocolSuffix() {
 		return false
 	}
 
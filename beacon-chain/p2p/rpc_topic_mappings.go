@@ -37,6 +37,12 @@ const PingMessageName = "/ping"
 // MetadataMessageName specifies the name for the metadata message topic.
 const MetadataMessageName = "/metadata"
 
+// FinalizedStateManifestMessageName specifies the name for the experimental finalized state manifest topic.
+const FinalizedStateManifestMessageName = "/finalized_state_manifest"
+
+// FinalizedStateChunkMessageName specifies the name for the experimental finalized state chunk topic.
+const FinalizedStateChunkMessageName = "/finalized_state_chunk"
+
 const (
 	// V1 RPC Topics
 	// RPCStatusTopicV1 defines the v1 topic for the status rpc method.
@@ -51,6 +57,10 @@ const (
 	RPCPingTopicV1 = protocolPrefix + PingMessageName + SchemaVersionV1
 	// RPCMetaDataTopicV1 defines the v1 topic for the metadata rpc method.
 	RPCMetaDataTopicV1 = protocolPrefix + MetadataMessageName + SchemaVersionV1
+	// RPCFinalizedStateManifestTopicV1 defines the v1 topic for the finalized state manifest rpc method.
+	RPCFinalizedStateManifestTopicV1 = protocolPrefix + FinalizedStateManifestMessageName + SchemaVersionV1
+	// RPCFinalizedStateChunkTopicV1 defines the v1 topic for the finalized state chunk rpc method.
+	RPCFinalizedStateChunkTopicV1 = protocolPrefix + FinalizedStateChunkMessageName + SchemaVersionV1
 
 	// V2 RPC Topics
 	// RPCBlocksByRangeTopicV2 defines v2 the topic for the blocks by range rpc method.
@@ -83,6 +93,9 @@ var RPCTopicMappings = map[string]interface{}{
 	// RPC Metadata Message
 	RPCMetaDataTopicV1: new(interface{}),
 	RPCMetaDataTopicV2: new(interface{}),
+	// RPC Finalized State Sync Messages
+	RPCFinalizedStateManifestTopicV1: new(p2ptypes.FinalizedStateManifestReq),
+	RPCFinalizedStateChunkTopicV1:    new(p2ptypes.FinalizedStateChunkReq),
 }
 
 // Maps all registered protocol prefixes.
@@ -93,12 +106,14 @@ var protocolMapping = map[string]bool{
 // Maps all the protocol message names for the different rpc
 // topics.
 var messageMapping = map[string]bool{
-	StatusMessageName:              true,
-	GoodbyeMessageName:             true,
-	BeaconBlocksByRangeMessageName: true,
-	BeaconBlocksByRootsMessageName: true,
-	PingMessageName:                true,
-	MetadataMessageName:            true,
+	StatusMessageName:                 true,
+	GoodbyeMessageName:                true,
+	BeaconBlocksByRangeMessageName:    true,
+	BeaconBlocksByRootsMessageName:    true,
+	PingMessageName:                   true,
+	MetadataMessageName:               true,
+	FinalizedStateManifestMessageName: true,
+	FinalizedStateChunkMessageName:    true,
 }
 
 // Maps all the RPC messages which are to updated in altair.
@@ -6,7 +6,6 @@ import (
 	"github.com/kr/pretty"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
-	"github.com/libp2p/go-libp2p-core/protocol"
 	"github.com/pkg/errors"
 	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
@@ -24,7 +23,8 @@ func (s *Service) Send(ctx context.Context, message interface{}, baseTopic strin
 	if err := VerifyTopicMapping(baseTopic, message); err != nil {
 		return nil, err
 	}
-	topic := baseTopic + s.Encoding().ProtocolSuffix()
+	protocols := RPCProtocolIDs(s, baseTopic)
+	topic := string(protocols[0])
 	span.AddAttributes(trace.StringAttribute("topic", topic))
 
 	log.WithFields(logrus.Fields{
@@ -36,7 +36,8 @@ func (s *Service) Send(ctx context.Context, message interface{}, baseTopic strin
 	ctx, cancel := context.WithTimeout(ctx, maxDialTimeout)
 	defer cancel()
 
-	stream, err := s.host.NewStream(ctx, pid, protocol.ID(topic))
+	// Peers not supporting the configured encoding negotiate ssz_snappy instead.
+	stream, err := s.host.NewStream(ctx, pid, protocols...)
 	if err != nil {
 		tracing.AnnotateError(span, err)
 		return nil, err
@@ -47,7 +48,7 @@ func (s *Service) Send(ctx context.Context, message interface{}, baseTopic strin
 		if !ok {
 			return nil, errors.Errorf("%T does not support the ssz marshaller interface", message)
 		}
-		if _, err := s.Encoding().EncodeWithMaxLength(stream, castedMsg); err != nil {
+		if _, err := StreamEncoding(s, stream).EncodeWithMaxLength(stream, castedMsg); err != nil {
 			tracing.AnnotateError(span, err)
 			_err := stream.Reset()
 			_ = _err
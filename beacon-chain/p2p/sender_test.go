@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	testp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/config/params"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -61,3 +62,71 @@ func TestService_Send(t *testing.T) {
 		t.Errorf("Expected identical message to be received. got %v want %v", rcvd, msg)
 	}
 }
+
+func TestService_Send_NegotiatesEncoding(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	zstdEncoding, err := encoder.NewSszEncoding(encoder.ZstdCodecName)
+	require.NoError(t, err)
+	topic := "/testing/1"
+	RPCTopicMappings[topic] = new(ethpb.Fork)
+	defer func() {
+		delete(RPCTopicMappings, topic)
+	}()
+
+	tests := []struct {
+		name       string
+		peerSuffix string
+		encoding   encoder.NetworkEncoding
+	}{
+		{
+			name:       "peer supporting the configured codec",
+			peerSuffix: "/ssz_zstd",
+			encoding:   zstdEncoding,
+		},
+		{
+			name:       "peer only supporting snappy",
+			peerSuffix: "/ssz_snappy",
+			encoding:   &encoder.SszNetworkEncoder{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p1 := testp2p.NewTestP2P(t)
+			p2 := testp2p.NewTestP2P(t)
+			p1.Connect(p2)
+			svc := &Service{
+				host:     p1.BHost,
+				cfg:      &Config{},
+				encoding: zstdEncoding,
+			}
+			msg := &ethpb.Fork{
+				CurrentVersion:  []byte("fooo"),
+				PreviousVersion: []byte("barr"),
+				Epoch:           55,
+			}
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			p2.SetStreamHandler(topic+tt.peerSuffix, func(stream network.Stream) {
+				rcvd := &ethpb.Fork{}
+				require.NoError(t, tt.encoding.DecodeWithMaxLength(stream, rcvd))
+				_, err := tt.encoding.EncodeWithMaxLength(stream, rcvd)
+				require.NoError(t, err)
+				assert.NoError(t, stream.Close())
+				wg.Done()
+			})
+
+			stream, err := svc.Send(context.Background(), msg, topic, p2.BHost.ID())
+			require.NoError(t, err)
+			assert.Equal(t, topic+tt.peerSuffix, string(stream.Protocol()))
+
+			util.WaitTimeout(&wg, 1*time.Second)
+
+			rcvd := &ethpb.Fork{}
+			require.NoError(t, StreamEncoding(svc, stream).DecodeWithMaxLength(stream, rcvd))
+			if !proto.Equal(rcvd, msg) {
+				t.Errorf("Expected identical message to be received. got %v want %v", rcvd, msg)
+			}
+		})
+	}
+}
@@ -67,12 +67,16 @@ type Service struct {
 	cfg                   *Config
 	peers                 *peers.Status
 	addrFilter            *multiaddr.Filters
+	addrFilterLock        sync.RWMutex
 	ipLimiter             *leakybucket.Collector
+	ipLimiterLock         sync.RWMutex
 	privKey               *ecdsa.PrivateKey
+	encoding              encoder.NetworkEncoding
 	metaData              metadata.Metadata
 	pubsub                *pubsub.PubSub
 	joinedTopics          map[string]*pubsub.Topic
 	joinedTopicsLock      sync.Mutex
+	gossipTracer          *gossipTracer
 	subnetsLock           map[uint64]*sync.RWMutex
 	subnetsLockLock       sync.Mutex // Lock access to subnetsLock
 	initializationLock    sync.Mutex
@@ -113,6 +117,11 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		log.WithError(err).Error("Failed to generate p2p private key")
 		return nil, err
 	}
+	s.encoding, err = encoder.NewSszEncoding(s.cfg.CompressionCodec)
+	if err != nil {
+		log.WithError(err).Error("Failed to create network encoding")
+		return nil, err
+	}
 	s.metaData, err = metaDataFromConfig(s.cfg)
 	if err != nil {
 		log.WithError(err).Error("Failed to create peer metadata")
@@ -123,7 +132,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		log.WithError(err).Error("Failed to create address filter")
 		return nil, err
 	}
-	s.ipLimiter = leakybucket.NewCollector(ipLimit, ipBurst, true /* deleteEmptyBuckets */)
+	s.ipLimiter = newIPLimiter(s.cfg.InboundDialRate, s.cfg.InboundDialBurst)
 
 	opts := s.buildOptions(ipAddr, s.privKey)
 	h, err := libp2p.New(opts...)
@@ -134,6 +143,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 
 	s.host = h
 	s.host.RemoveStreamHandler(identify.IDDelta)
+	s.gossipTracer = newGossipTracer(h.ID())
 	// Gossipsub registration is done before we add in any new peers
 	// due to libp2p's gossipsub implementation not taking into
 	// account previously added peers when creating the gossipsub
@@ -150,6 +160,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		pubsub.WithPeerScore(peerScoringParams()),
 		pubsub.WithPeerScoreInspect(s.peerInspector, time.Minute),
 		pubsub.WithGossipSubParams(pubsubGossipParam()),
+		pubsub.WithRawTracer(s.gossipTracer),
 	}
 	// Set the pubsub global parameters that we require.
 	setPubSubParameters()
@@ -187,6 +198,9 @@ func (s *Service) Start() {
 		return
 	}
 
+	// Restore the scoring history of known peers before any are dialed.
+	s.restorePeerRecords()
+
 	// Waits until the state is initialized via an event feed.
 	// Used for fork-related data when connecting peers.
 	s.awaitStateInitialized()
@@ -243,6 +257,7 @@ func (s *Service) Start() {
 		ensurePeerConnections(s.ctx, s.host, peersToWatch...)
 	})
 	async.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
+	async.RunEvery(s.ctx, peerRecordsSaveInterval, s.savePeerRecords)
 	async.RunEvery(s.ctx, params.BeaconNetworkConfig().RespTimeout, s.updateMetrics)
 	async.RunEvery(s.ctx, refreshRate, func() {
 		s.RefreshENR()
@@ -276,6 +291,9 @@ func (s *Service) Start() {
 // Stop the p2p service and terminate all peer connections.
 func (s *Service) Stop() error {
 	defer s.cancel()
+	if s.started {
+		s.savePeerRecords()
+	}
 	s.started = false
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
@@ -306,9 +324,13 @@ func (s *Service) Started() bool {
 	return s.started
 }
 
-// Encoding returns the configured networking encoding.
-func (_ *Service) Encoding() encoder.NetworkEncoding {
-	return &encoder.SszNetworkEncoder{}
+// Encoding returns the configured networking encoding, ssz_snappy unless another compression codec
+// is configured.
+func (s *Service) Encoding() encoder.NetworkEncoding {
+	if s.encoding == nil {
+		return &encoder.SszNetworkEncoder{}
+	}
+	return s.encoding
 }
 
 // PubSub returns the p2p pubsub framework.
@@ -343,6 +365,12 @@ func (s *Service) Connect(pi peer.AddrInfo) error {
 	return s.host.Connect(s.ctx, pi)
 }
 
+// SetMaxPeers updates the maximum number of peers the node connects to at runtime.
+// Connections above the new limit are pruned on the next round of peer status maintenance.
+func (s *Service) SetMaxPeers(limit uint) {
+	s.peers.SetPeerLimit(int(limit))
+}
+
 // Peers returns the peer status interface.
 func (s *Service) Peers() *peers.Status {
 	return s.peers
@@ -103,6 +103,11 @@ func (_ *FakeP2P) MetadataSeq() uint64 {
 	return 0
 }
 
+// GossipDuplicateStats -- fake.
+func (_ *FakeP2P) GossipDuplicateStats() []*ethpb.GossipTopicDuplicateStats {
+	return nil
+}
+
 // SetStreamHandler -- fake.
 func (_ *FakeP2P) SetStreamHandler(_ string, _ network.StreamHandler) {
 
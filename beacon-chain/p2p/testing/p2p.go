@@ -50,8 +50,13 @@ type TestP2P struct {
 
 // NewTestP2P initializes a new p2p test service.
 func NewTestP2P(t *testing.T) *TestP2P {
+	return NewTestP2PWithHost(t, bhost.NewBlankHost(swarmt.GenSwarm(t)))
+}
+
+// NewTestP2PWithHost initializes a new p2p test service on top of the given libp2p host, such as
+// a host of a simulated network.
+func NewTestP2PWithHost(t *testing.T, h host.Host) *TestP2P {
 	ctx := context.Background()
-	h := bhost.NewBlankHost(swarmt.GenSwarm(t))
 	ps, err := pubsub.NewFloodSub(ctx, h,
 		pubsub.WithMessageSigning(false),
 		pubsub.WithStrictSignatureVerification(false),
@@ -371,6 +376,11 @@ func (p *TestP2P) MetadataSeq() uint64 {
 	return p.LocalMetadata.SequenceNumber()
 }
 
+// GossipDuplicateStats mocks the p2p func.
+func (_ *TestP2P) GossipDuplicateStats() []*ethpb.GossipTopicDuplicateStats {
+	return nil
+}
+
 // AddPingMethod mocks the p2p func.
 func (_ *TestP2P) AddPingMethod(_ func(ctx context.Context, id peer.ID) error) {
 	// no-op
@@ -0,0 +1,200 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+)
+
+// StateSyncChunkSize is the number of bytes of the serialized finalized state sent in each chunk
+// of the state sync protocol, except for the last one which holds the remainder.
+const StateSyncChunkSize = 1 << 19
+
+// MaxStateSyncChunks is the maximum number of chunks a finalized state can be split in.
+const MaxStateSyncChunks = 1 << 13
+
+// The fixed part of a manifest: the block root, state root, state size, chunk size and the
+// offset of the chunk hashes.
+const manifestFixedSize = 2*rootLength + 2*8 + 4
+
+// FinalizedStateManifestReq requests the manifest of the finalized state of the block with the given root.
+type FinalizedStateManifestReq [rootLength]byte
+
+// MarshalSSZTo marshals the manifest request with the provided byte slice.
+func (r *FinalizedStateManifestReq) MarshalSSZTo(dst []byte) ([]byte, error) {
+	return append(dst, r[:]...), nil
+}
+
+// MarshalSSZ marshals the manifest request into the serialized object.
+func (r *FinalizedStateManifestReq) MarshalSSZ() ([]byte, error) {
+	return r.MarshalSSZTo(make([]byte, 0, r.SizeSSZ()))
+}
+
+// SizeSSZ returns the size of the serialized representation.
+func (_ *FinalizedStateManifestReq) SizeSSZ() int {
+	return rootLength
+}
+
+// UnmarshalSSZ unmarshals the provided bytes buffer into the manifest request.
+func (r *FinalizedStateManifestReq) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != rootLength {
+		return ssz.ErrSize
+	}
+	copy(r[:], buf)
+	return nil
+}
+
+// FinalizedStateManifest describes how a finalized state is split in chunks for state sync.
+// Each chunk is authenticated by its hash in the manifest, and the whole state by the state
+// root committed to in the finalized block.
+type FinalizedStateManifest struct {
+	BlockRoot   [rootLength]byte
+	StateRoot   [rootLength]byte
+	StateSize   uint64
+	ChunkSize   uint64
+	ChunkHashes [][rootLength]byte
+}
+
+// MarshalSSZTo marshals the manifest with the provided byte slice.
+func (m *FinalizedStateManifest) MarshalSSZTo(dst []byte) ([]byte, error) {
+	if len(m.ChunkHashes) > MaxStateSyncChunks {
+		return nil, errors.Errorf("state sync manifest exceeds max chunks: %d > %d", len(m.ChunkHashes), MaxStateSyncChunks)
+	}
+	dst = append(dst, m.BlockRoot[:]...)
+	dst = append(dst, m.StateRoot[:]...)
+	dst = ssz.MarshalUint64(dst, m.StateSize)
+	dst = ssz.MarshalUint64(dst, m.ChunkSize)
+	dst = ssz.WriteOffset(dst, manifestFixedSize)
+	for _, h := range m.ChunkHashes {
+		dst = append(dst, h[:]...)
+	}
+	return dst, nil
+}
+
+// MarshalSSZ marshals the manifest into the serialized object.
+func (m *FinalizedStateManifest) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// SizeSSZ returns the size of the serialized representation.
+func (m *FinalizedStateManifest) SizeSSZ() int {
+	return manifestFixedSize + len(m.ChunkHashes)*rootLength
+}
+
+// UnmarshalSSZ unmarshals the provided bytes buffer into the manifest.
+func (m *FinalizedStateManifest) UnmarshalSSZ(buf []byte) error {
+	if len(buf) < manifestFixedSize {
+		return ssz.ErrSize
+	}
+	if offset := binary.LittleEndian.Uint32(buf[manifestFixedSize-4 : manifestFixedSize]); offset != manifestFixedSize {
+		return ssz.ErrInvalidVariableOffset
+	}
+	hashes := buf[manifestFixedSize:]
+	if len(hashes)%rootLength != 0 {
+		return ssz.ErrIncorrectByteSize
+	}
+	if len(hashes)/rootLength > MaxStateSyncChunks {
+		return errors.Errorf("state sync manifest exceeds max chunks: %d > %d", len(hashes)/rootLength, MaxStateSyncChunks)
+	}
+	copy(m.BlockRoot[:], buf[0:rootLength])
+	copy(m.StateRoot[:], buf[rootLength:2*rootLength])
+	m.StateSize = ssz.UnmarshallUint64(buf[2*rootLength : 2*rootLength+8])
+	m.ChunkSize = ssz.UnmarshallUint64(buf[2*rootLength+8 : 2*rootLength+16])
+	m.ChunkHashes = make([][rootLength]byte, len(hashes)/rootLength)
+	for i := range m.ChunkHashes {
+		copy(m.ChunkHashes[i][:], hashes[i*rootLength:(i+1)*rootLength])
+	}
+	return nil
+}
+
+// Validate checks that the chunks described by the manifest cover the state exactly and
+// fit within the protocol limits.
+func (m *FinalizedStateManifest) Validate() error {
+	if m.ChunkSize == 0 || m.ChunkSize > StateSyncChunkSize {
+		return errors.Errorf("invalid chunk size %d, expected a value between 1 and %d", m.ChunkSize, StateSyncChunkSize)
+	}
+	if m.StateSize == 0 {
+		return errors.New("empty state")
+	}
+	chunks := (m.StateSize + m.ChunkSize - 1) / m.ChunkSize
+	if chunks > MaxStateSyncChunks {
+		return errors.Errorf("state of %d bytes needs %d chunks, more than the max of %d", m.StateSize, chunks, MaxStateSyncChunks)
+	}
+	if uint64(len(m.ChunkHashes)) != chunks {
+		return errors.Errorf("state of %d bytes needs %d chunks, manifest has %d", m.StateSize, chunks, len(m.ChunkHashes))
+	}
+	return nil
+}
+
+// ChunkLength returns the expected length of the chunk at the given index.
+func (m *FinalizedStateManifest) ChunkLength(index uint64) uint64 {
+	if index == uint64(len(m.ChunkHashes))-1 && m.StateSize%m.ChunkSize != 0 {
+		return m.StateSize % m.ChunkSize
+	}
+	return m.ChunkSize
+}
+
+// FinalizedStateChunkReq requests a chunk of the finalized state of the block with the given root.
+type FinalizedStateChunkReq struct {
+	BlockRoot [rootLength]byte
+	Index     uint64
+}
+
+// MarshalSSZTo marshals the chunk request with the provided byte slice.
+func (r *FinalizedStateChunkReq) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = append(dst, r.BlockRoot[:]...)
+	return ssz.MarshalUint64(dst, r.Index), nil
+}
+
+// MarshalSSZ marshals the chunk request into the serialized object.
+func (r *FinalizedStateChunkReq) MarshalSSZ() ([]byte, error) {
+	return r.MarshalSSZTo(make([]byte, 0, r.SizeSSZ()))
+}
+
+// SizeSSZ returns the size of the serialized representation.
+func (_ *FinalizedStateChunkReq) SizeSSZ() int {
+	return rootLength + 8
+}
+
+// UnmarshalSSZ unmarshals the provided bytes buffer into the chunk request.
+func (r *FinalizedStateChunkReq) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != r.SizeSSZ() {
+		return ssz.ErrSize
+	}
+	copy(r.BlockRoot[:], buf[:rootLength])
+	r.Index = ssz.UnmarshallUint64(buf[rootLength:])
+	return nil
+}
+
+// StateChunk is a chunk of a serialized finalized state.
+type StateChunk []byte
+
+// MarshalSSZTo marshals the state chunk with the provided byte slice.
+func (c *StateChunk) MarshalSSZTo(dst []byte) ([]byte, error) {
+	if len(*c) > StateSyncChunkSize {
+		return nil, errors.Errorf("state chunk exceeds max size: %d > %d", len(*c), StateSyncChunkSize)
+	}
+	return append(dst, *c...), nil
+}
+
+// MarshalSSZ marshals the state chunk into the serialized object.
+func (c *StateChunk) MarshalSSZ() ([]byte, error) {
+	return c.MarshalSSZTo(make([]byte, 0, c.SizeSSZ()))
+}
+
+// SizeSSZ returns the size of the serialized representation.
+func (c *StateChunk) SizeSSZ() int {
+	return len(*c)
+}
+
+// UnmarshalSSZ unmarshals the provided bytes buffer into the state chunk.
+func (c *StateChunk) UnmarshalSSZ(buf []byte) error {
+	if len(buf) > StateSyncChunkSize {
+		return errors.Errorf("expected buffer with length of upto %d but received length %d", StateSyncChunkSize, len(buf))
+	}
+	chunk := make([]byte, len(buf))
+	copy(chunk, buf)
+	*c = chunk
+	return nil
+}
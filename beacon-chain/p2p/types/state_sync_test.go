@@ -0,0 +1,108 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestFinalizedStateManifest_RoundTrip(t *testing.T) {
+	m := &FinalizedStateManifest{
+		BlockRoot:   [32]byte{'a'},
+		StateRoot:   [32]byte{'b'},
+		StateSize:   2*StateSyncChunkSize + 10,
+		ChunkSize:   StateSyncChunkSize,
+		ChunkHashes: [][32]byte{{1}, {2}, {3}},
+	}
+	enc, err := m.MarshalSSZ()
+	require.NoError(t, err)
+	assert.Equal(t, m.SizeSSZ(), len(enc))
+	decoded := &FinalizedStateManifest{}
+	require.NoError(t, decoded.UnmarshalSSZ(enc))
+	assert.DeepEqual(t, m, decoded)
+	require.NoError(t, decoded.Validate())
+	assert.Equal(t, uint64(StateSyncChunkSize), decoded.ChunkLength(1))
+	assert.Equal(t, uint64(10), decoded.ChunkLength(2))
+
+	require.ErrorContains(t, "incorrect byte size", decoded.UnmarshalSSZ(enc[:len(enc)-1]))
+	require.ErrorContains(t, "incorrect size", decoded.UnmarshalSSZ(enc[:manifestFixedSize-1]))
+
+	tooMany := &FinalizedStateManifest{ChunkHashes: make([][32]byte, MaxStateSyncChunks+1)}
+	_, err = tooMany.MarshalSSZ()
+	require.ErrorContains(t, "state sync manifest exceeds max chunks", err)
+}
+
+func TestFinalizedStateManifest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest *FinalizedStateManifest
+		wantErr  string
+	}{
+		{
+			name:     "zero chunk size",
+			manifest: &FinalizedStateManifest{StateSize: 10},
+			wantErr:  "invalid chunk size",
+		},
+		{
+			name:     "chunk size over limit",
+			manifest: &FinalizedStateManifest{StateSize: 10, ChunkSize: StateSyncChunkSize + 1},
+			wantErr:  "invalid chunk size",
+		},
+		{
+			name:     "empty state",
+			manifest: &FinalizedStateManifest{ChunkSize: 1},
+			wantErr:  "empty state",
+		},
+		{
+			name:     "too many chunks",
+			manifest: &FinalizedStateManifest{StateSize: MaxStateSyncChunks + 1, ChunkSize: 1},
+			wantErr:  "more than the max",
+		},
+		{
+			name:     "missing chunk hash",
+			manifest: &FinalizedStateManifest{StateSize: 11, ChunkSize: 5, ChunkHashes: make([][32]byte, 2)},
+			wantErr:  "needs 3 chunks, manifest has 2",
+		},
+		{
+			name:     "ok",
+			manifest: &FinalizedStateManifest{StateSize: 10, ChunkSize: 5, ChunkHashes: make([][32]byte, 2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manifest.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFinalizedStateChunkReq_RoundTrip(t *testing.T) {
+	req := &FinalizedStateChunkReq{BlockRoot: [32]byte{'c'}, Index: 42}
+	enc, err := req.MarshalSSZ()
+	require.NoError(t, err)
+	decoded := &FinalizedStateChunkReq{}
+	require.NoError(t, decoded.UnmarshalSSZ(enc))
+	assert.DeepEqual(t, req, decoded)
+	require.ErrorContains(t, "incorrect size", decoded.UnmarshalSSZ(enc[1:]))
+
+	root := FinalizedStateManifestReq{'d'}
+	enc, err = root.MarshalSSZ()
+	require.NoError(t, err)
+	decodedRoot := FinalizedStateManifestReq{}
+	require.NoError(t, decodedRoot.UnmarshalSSZ(enc))
+	assert.Equal(t, root, decodedRoot)
+}
+
+func TestStateChunk_Limit(t *testing.T) {
+	chunk := StateChunk(make([]byte, StateSyncChunkSize+1))
+	_, err := chunk.MarshalSSZ()
+	require.ErrorContains(t, "state chunk exceeds max size", err)
+	require.ErrorContains(t, "expected buffer with length of upto", chunk.UnmarshalSSZ(make([]byte, StateSyncChunkSize+1)))
+	require.NoError(t, chunk.UnmarshalSSZ(make([]byte, StateSyncChunkSize)))
+	assert.Equal(t, StateSyncChunkSize, chunk.SizeSSZ())
+}
@@ -5,7 +5,6 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"encoding/base64"
-	"encoding/hex"
 	"fmt"
 	"net"
 	"os"
@@ -50,6 +49,10 @@ func privKey(cfg *Config) (*ecdsa.PrivateKey, error) {
 	defaultKeyPath := path.Join(cfg.DataDir, keyPath)
 	privateKeyPath := cfg.PrivateKey
 
+	if err := activatePendingNetworkKey(NetworkKeyPath(cfg.DataDir, privateKeyPath), time.Now()); err != nil {
+		return nil, errors.Wrap(err, "could not activate rotated network key")
+	}
+
 	_, err := os.Stat(defaultKeyPath)
 	defaultKeysExist := !os.IsNotExist(err)
 	if err != nil && defaultKeysExist {
@@ -76,16 +79,7 @@ func privKeyFromFile(path string) (*ecdsa.PrivateKey, error) {
 		log.WithError(err).Error("Error reading private key from file")
 		return nil, err
 	}
-	dst := make([]byte, hex.DecodedLen(len(src)))
-	_, err = hex.Decode(dst, src)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode hex string")
-	}
-	unmarshalledKey, err := crypto.UnmarshalSecp256k1PrivateKey(dst)
-	if err != nil {
-		return nil, err
-	}
-	return ecdsaprysm.ConvertFromInterfacePrivKey(unmarshalledKey)
+	return decodeNetworkKey(src)
 }
 
 // Retrieves node p2p metadata from a set of configuration values
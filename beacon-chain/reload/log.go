@@ -0,0 +1,5 @@
+package reload
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "reload")
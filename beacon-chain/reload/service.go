@@ -0,0 +1,211 @@
+// Package reload applies changes to a safe subset of the beacon node configuration, and to the
+// peer connection filter file, at runtime, triggered by a SIGHUP signal or through the debug API.
+package reload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/sirupsen/logrus"
+)
+
+// Triggers of a configuration reload, recorded in the audit log.
+const (
+	TriggerSignal = "SIGHUP"
+	TriggerAPI    = "api"
+)
+
+var errNoConfigFile = errors.New("no config file to reload from, start the node with --config-file or --p2p-filter-file")
+
+// Reloader reloads the node configuration on demand.
+type Reloader interface {
+	Reload(trigger string) ([]*Change, error)
+}
+
+// PeerLimitSetter updates the maximum number of peers at runtime.
+type PeerLimitSetter interface {
+	SetMaxPeers(limit uint)
+}
+
+// HotStateCacheResizer updates the number of hot states held in memory at runtime.
+type HotStateCacheResizer interface {
+	SetHotStateCacheSize(size int)
+}
+
+// BlockRateLimiter updates the rate limits of incoming block requests at runtime.
+type BlockRateLimiter interface {
+	SetBlockRateLimits(blockBatchLimit, burstFactor int)
+}
+
+// InboundDialLimiter updates the rate limits of inbound dials per ip address at runtime.
+type InboundDialLimiter interface {
+	SetInboundDialLimits(rate, burst int)
+}
+
+// Config for the reload service. Components which are nil are not updated on reload.
+type Config struct {
+	ConfigFile              string
+	ConnectionFilterFile    string
+	Settings                *Settings
+	PeerLimitSetter         PeerLimitSetter
+	HotStateCacheResizer    HotStateCacheResizer
+	BlockRateLimiter        BlockRateLimiter
+	InboundDialLimiter      InboundDialLimiter
+	ConnectionFilterManager p2p.ConnectionFilterManager
+}
+
+// Service reloads the configuration file on SIGHUP and applies the reloadable settings it contains.
+type Service struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	cfg     *Config
+	current *Settings
+	lock    sync.Mutex
+}
+
+// NewService initializes the reload service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	current := cfg.Settings
+	if current == nil {
+		current = &Settings{}
+	}
+	return &Service{
+		ctx:     ctx,
+		cancel:  cancel,
+		cfg:     cfg,
+		current: current,
+	}
+}
+
+// Start listening for SIGHUP signals.
+func (s *Service) Start() {
+	go s.listenForSignals()
+}
+
+// Stop the service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the service.
+func (_ *Service) Status() error {
+	return nil
+}
+
+func (s *Service) listenForSignals() {
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGHUP)
+	defer signal.Stop(sigc)
+	for {
+		select {
+		case <-sigc:
+			if _, err := s.Reload(TriggerSignal); err != nil {
+				log.WithError(err).Error("Could not reload configuration")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// Reload re-reads the configuration file, validates the reloadable settings it contains and
+// applies the ones which changed, then does the same with the connection filter file. Every
+// change is logged with its old and new value.
+func (s *Service) Reload(trigger string) ([]*Change, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.cfg.ConfigFile == "" && s.cfg.ConnectionFilterFile == "" {
+		return nil, errNoConfigFile
+	}
+	next := s.current
+	if s.cfg.ConfigFile != "" {
+		var err error
+		next, err = loadSettings(s.cfg.ConfigFile, s.current)
+		if err != nil {
+			return nil, err
+		}
+		if err := next.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	changes := diff(s.current, next)
+	var filter *p2p.ConnectionFilter
+	if s.cfg.ConnectionFilterFile != "" && s.cfg.ConnectionFilterManager != nil {
+		f, err := p2p.LoadConnectionFilter(s.cfg.ConnectionFilterFile)
+		if err != nil {
+			return nil, err
+		}
+		// Compare with the filter in use, which may have been changed through the API since.
+		old := formatConnectionFilter(s.cfg.ConnectionFilterManager.ConnectionFilter())
+		if n := formatConnectionFilter(f); n != old {
+			filter = f
+			changes = append(changes, &Change{Name: cmd.P2PFilterFile.Name, Old: old, New: n})
+		}
+	}
+	if len(changes) == 0 {
+		log.WithField("trigger", trigger).Info("Reloaded configuration, no changes")
+		return changes, nil
+	}
+	// Log before applying, so the entries are not dropped by a lower verbosity.
+	for _, c := range changes {
+		log.WithFields(logrus.Fields{
+			"trigger":  trigger,
+			"setting":  c.Name,
+			"oldValue": c.Old,
+			"newValue": c.New,
+		}).Info("Changing configuration value")
+	}
+	s.apply(s.current, next)
+	s.current = next
+	if filter != nil {
+		if err := s.cfg.ConnectionFilterManager.SetConnectionFilter(filter); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+func (s *Service) apply(old, next *Settings) {
+	if old.Verbosity != next.Verbosity {
+		// The level has been validated already.
+		level, err := logrus.ParseLevel(next.Verbosity)
+		if err == nil {
+			logrus.SetLevel(level)
+		}
+	}
+	if old.MaxPeers != next.MaxPeers && s.cfg.PeerLimitSetter != nil {
+		s.cfg.PeerLimitSetter.SetMaxPeers(uint(next.MaxPeers))
+	}
+	if old.HotStateCacheSize != next.HotStateCacheSize && s.cfg.HotStateCacheResizer != nil {
+		s.cfg.HotStateCacheResizer.SetHotStateCacheSize(next.HotStateCacheSize)
+	}
+	if old.BlockBatchLimit != next.BlockBatchLimit || old.BlockBatchLimitBurstFactor != next.BlockBatchLimitBurstFactor {
+		globalFlags := *flags.Get()
+		globalFlags.BlockBatchLimit = next.BlockBatchLimit
+		globalFlags.BlockBatchLimitBurstFactor = next.BlockBatchLimitBurstFactor
+		flags.Init(&globalFlags)
+		if s.cfg.BlockRateLimiter != nil {
+			s.cfg.BlockRateLimiter.SetBlockRateLimits(next.BlockBatchLimit, next.BlockBatchLimitBurstFactor)
+		}
+	}
+	if old.InboundDialRate != next.InboundDialRate || old.InboundDialBurst != next.InboundDialBurst {
+		if s.cfg.InboundDialLimiter != nil {
+			s.cfg.InboundDialLimiter.SetInboundDialLimits(next.InboundDialRate, next.InboundDialBurst)
+		}
+	}
+}
+
+func formatConnectionFilter(f *p2p.ConnectionFilter) string {
+	return fmt.Sprintf("allowlist=%v denylist=%v", f.AllowList, f.DenyList)
+}
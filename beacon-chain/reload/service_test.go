@@ -0,0 +1,175 @@
+package reload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/sirupsen/logrus"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+type mockComponents struct {
+	maxPeers          uint
+	hotStateCacheSize int
+	blockBatchLimit   int
+	burstFactor       int
+	dialRate          int
+	dialBurst         int
+	connectionFilter  *p2p.ConnectionFilter
+}
+
+func (m *mockComponents) SetMaxPeers(limit uint) {
+	m.maxPeers = limit
+}
+
+func (m *mockComponents) SetHotStateCacheSize(size int) {
+	m.hotStateCacheSize = size
+}
+
+func (m *mockComponents) SetBlockRateLimits(blockBatchLimit, burstFactor int) {
+	m.blockBatchLimit = blockBatchLimit
+	m.burstFactor = burstFactor
+}
+
+func (m *mockComponents) SetInboundDialLimits(rate, burst int) {
+	m.dialRate = rate
+	m.dialBurst = burst
+}
+
+func (m *mockComponents) ConnectionFilter() *p2p.ConnectionFilter {
+	return m.connectionFilter.Copy()
+}
+
+func (m *mockComponents) SetConnectionFilter(f *p2p.ConnectionFilter) error {
+	m.connectionFilter = f
+	return nil
+}
+
+func defaultSettings() *Settings {
+	return &Settings{
+		Verbosity:                  "info",
+		MaxPeers:                   45,
+		HotStateCacheSize:          32,
+		BlockBatchLimit:            64,
+		BlockBatchLimitBurstFactor: 10,
+		InboundDialRate:            4,
+		InboundDialBurst:           8,
+	}
+}
+
+func setupService(t *testing.T, config string) (*Service, *mockComponents) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0600))
+	m := &mockComponents{}
+	s := NewService(context.Background(), &Config{
+		ConfigFile:           configFile,
+		Settings:             defaultSettings(),
+		PeerLimitSetter:      m,
+		HotStateCacheResizer: m,
+		BlockRateLimiter:     m,
+		InboundDialLimiter:   m,
+	})
+	return s, m
+}
+
+func TestReload_AppliesChanges(t *testing.T) {
+	hook := logTest.NewGlobal()
+	level := logrus.GetLevel()
+	defer logrus.SetLevel(level)
+	resetFlags := flags.Get()
+	defer flags.Init(resetFlags)
+
+	s, m := setupService(t, `
+datadir: /tmp/beacon
+verbosity: debug
+p2p-max-peers: 70
+hot-state-cache-size: 16
+block-batch-limit: 128
+p2p-inbound-dial-burst: 2
+`)
+	changes, err := s.Reload(TriggerAPI)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(changes))
+	assert.DeepEqual(t, &Change{Name: "p2p-max-peers", Old: "45", New: "70"}, changes[1])
+
+	assert.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+	assert.Equal(t, uint(70), m.maxPeers)
+	assert.Equal(t, 16, m.hotStateCacheSize)
+	assert.Equal(t, 128, m.blockBatchLimit)
+	assert.Equal(t, 10, m.burstFactor)
+	assert.Equal(t, 128, flags.Get().BlockBatchLimit)
+	assert.Equal(t, 4, m.dialRate)
+	assert.Equal(t, 2, m.dialBurst)
+	assert.LogsContain(t, hook, "Changing configuration value")
+	assert.LogsContain(t, hook, "oldValue=32")
+
+	// Reloading an unchanged file is a no-op.
+	changes, err = s.Reload(TriggerSignal)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(changes))
+}
+
+func TestReload_InvalidSettingsNotApplied(t *testing.T) {
+	s, m := setupService(t, `
+p2p-max-peers: 70
+hot-state-cache-size: 0
+`)
+	_, err := s.Reload(TriggerAPI)
+	assert.ErrorContains(t, "hot-state-cache-size must be greater than 0", err)
+	assert.Equal(t, uint(0), m.maxPeers)
+	assert.DeepEqual(t, defaultSettings(), s.current)
+
+	s, _ = setupService(t, "verbosity: loud")
+	_, err = s.Reload(TriggerAPI)
+	assert.ErrorContains(t, "invalid verbosity", err)
+}
+
+func TestReload_NoConfigFile(t *testing.T) {
+	s := NewService(context.Background(), &Config{Settings: defaultSettings()})
+	_, err := s.Reload(TriggerSignal)
+	require.ErrorIs(t, err, errNoConfigFile)
+}
+
+func TestReload_ConnectionFilter(t *testing.T) {
+	filterFile := filepath.Join(t.TempDir(), "filter.yaml")
+	require.NoError(t, os.WriteFile(filterFile, []byte("denylist: [\"10.1.0.0/16\"]\n"), 0600))
+	m := &mockComponents{}
+	s := NewService(context.Background(), &Config{
+		ConnectionFilterFile:    filterFile,
+		Settings:                defaultSettings(),
+		ConnectionFilterManager: m,
+	})
+	changes, err := s.Reload(TriggerSignal)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(changes))
+	assert.DeepEqual(t, &Change{
+		Name: "p2p-filter-file",
+		Old:  "allowlist=[] denylist=[]",
+		New:  "allowlist=[] denylist=[10.1.0.0/16]",
+	}, changes[0])
+	assert.DeepEqual(t, []string{"10.1.0.0/16"}, m.connectionFilter.DenyList)
+
+	// Reloading an unchanged file is a no-op.
+	changes, err = s.Reload(TriggerSignal)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(changes))
+
+	// A filter changed through the API is replaced by the file on reload.
+	m.connectionFilter = &p2p.ConnectionFilter{AllowList: []string{"10.0.0.0/8"}}
+	changes, err = s.Reload(TriggerAPI)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(changes))
+	assert.Equal(t, 0, len(m.connectionFilter.AllowList))
+
+	// An invalid file is not applied.
+	require.NoError(t, os.WriteFile(filterFile, []byte("denylist: [\"10.1.0.0\"]\n"), 0600))
+	_, err = s.Reload(TriggerSignal)
+	assert.ErrorContains(t, "invalid connection filter file", err)
+	assert.DeepEqual(t, []string{"10.1.0.0/16"}, m.connectionFilter.DenyList)
+}
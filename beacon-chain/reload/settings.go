@@ -0,0 +1,103 @@
+package reload
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Settings is the subset of the beacon node configuration which can be changed without a restart.
+// Fields are keyed by the name of the command line flag setting them, as in the config file.
+type Settings struct {
+	Verbosity                  string `json:"verbosity"`
+	MaxPeers                   int    `json:"p2p-max-peers"`
+	HotStateCacheSize          int    `json:"hot-state-cache-size"`
+	BlockBatchLimit            int    `json:"block-batch-limit"`
+	BlockBatchLimitBurstFactor int    `json:"block-batch-limit-burst-factor"`
+	InboundDialRate            int    `json:"p2p-inbound-dial-rate"`
+	InboundDialBurst           int    `json:"p2p-inbound-dial-burst"`
+}
+
+// Change describes a setting which was modified by a reload.
+type Change struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// SettingsFromCLI returns the reloadable settings the node was started with.
+func SettingsFromCLI(cliCtx *cli.Context) *Settings {
+	return &Settings{
+		Verbosity:                  cliCtx.String(cmd.VerbosityFlag.Name),
+		MaxPeers:                   cliCtx.Int(cmd.P2PMaxPeers.Name),
+		HotStateCacheSize:          cliCtx.Int(flags.HotStateCacheSize.Name),
+		BlockBatchLimit:            cliCtx.Int(flags.BlockBatchLimit.Name),
+		BlockBatchLimitBurstFactor: cliCtx.Int(flags.BlockBatchLimitBurstFactor.Name),
+		InboundDialRate:            cliCtx.Int(cmd.P2PInboundDialRate.Name),
+		InboundDialBurst:           cliCtx.Int(cmd.P2PInboundDialBurst.Name),
+	}
+}
+
+// loadSettings reads the reloadable settings from a YAML config file. Settings missing
+// from the file keep their current value.
+func loadSettings(path string, current *Settings) (*Settings, error) {
+	enc, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read config file")
+	}
+	next := *current
+	if err := yaml.Unmarshal(enc, &next); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal config file")
+	}
+	return &next, nil
+}
+
+// Validate returns an error if any of the settings cannot be applied.
+func (s *Settings) Validate() error {
+	if _, err := logrus.ParseLevel(s.Verbosity); err != nil {
+		return errors.Wrapf(err, "invalid %s", cmd.VerbosityFlag.Name)
+	}
+	if s.MaxPeers <= 0 {
+		return fmt.Errorf("%s must be greater than 0", cmd.P2PMaxPeers.Name)
+	}
+	if s.HotStateCacheSize <= 0 {
+		return fmt.Errorf("%s must be greater than 0", flags.HotStateCacheSize.Name)
+	}
+	if s.BlockBatchLimit <= 0 {
+		return fmt.Errorf("%s must be greater than 0", flags.BlockBatchLimit.Name)
+	}
+	if s.BlockBatchLimitBurstFactor <= 0 {
+		return fmt.Errorf("%s must be greater than 0", flags.BlockBatchLimitBurstFactor.Name)
+	}
+	if s.InboundDialRate <= 0 {
+		return fmt.Errorf("%s must be greater than 0", cmd.P2PInboundDialRate.Name)
+	}
+	if s.InboundDialBurst <= 0 {
+		return fmt.Errorf("%s must be greater than 0", cmd.P2PInboundDialBurst.Name)
+	}
+	return nil
+}
+
+// diff returns the settings which differ between old and new.
+func diff(old, new *Settings) []*Change {
+	var changes []*Change
+	add := func(name string, o, n interface{}) {
+		if o != n {
+			changes = append(changes, &Change{Name: name, Old: fmt.Sprint(o), New: fmt.Sprint(n)})
+		}
+	}
+	add(cmd.VerbosityFlag.Name, old.Verbosity, new.Verbosity)
+	add(cmd.P2PMaxPeers.Name, old.MaxPeers, new.MaxPeers)
+	add(flags.HotStateCacheSize.Name, old.HotStateCacheSize, new.HotStateCacheSize)
+	add(flags.BlockBatchLimit.Name, old.BlockBatchLimit, new.BlockBatchLimit)
+	add(flags.BlockBatchLimitBurstFactor.Name, old.BlockBatchLimitBurstFactor, new.BlockBatchLimitBurstFactor)
+	add(cmd.P2PInboundDialRate.Name, old.InboundDialRate, new.InboundDialRate)
+	add(cmd.P2PInboundDialBurst.Name, old.InboundDialBurst, new.InboundDialBurst)
+	return changes
+}
@@ -389,6 +389,8 @@ func receiveEvents(eventChan <-chan *sse.Event, w http.ResponseWriter, req *http
 				data = &eventChainReorgJson{}
 			case events.SyncCommitteeContributionTopic:
 				data = &signedContributionAndProofJson{}
+			case events.DutyResultTopic:
+				data = &eventDutyResultJson{}
 			case "error":
 				data = &eventErrorJson{}
 			default:
@@ -135,10 +135,38 @@ type voluntaryExitsPoolResponseJson struct {
 	Data []*signedVoluntaryExitJson `json:"data"`
 }
 
+type lightClientBootstrapResponseJson struct {
+	Version string                    `json:"version" enum:"true"`
+	Data    *lightClientBootstrapJson `json:"data"`
+}
+
+type lightClientUpdatesByRangeResponseJson struct {
+	Data []*lightClientUpdateWithVersionJson `json:"data"`
+}
+
+type lightClientUpdateWithVersionJson struct {
+	Version string                 `json:"version" enum:"true"`
+	Data    *lightClientUpdateJson `json:"data"`
+}
+
+type lightClientFinalityUpdateResponseJson struct {
+	Version string                         `json:"version" enum:"true"`
+	Data    *lightClientFinalityUpdateJson `json:"data"`
+}
+
+type lightClientOptimisticUpdateResponseJson struct {
+	Version string                           `json:"version" enum:"true"`
+	Data    *lightClientOptimisticUpdateJson `json:"data"`
+}
+
 type submitSyncCommitteeSignaturesRequestJson struct {
 	Data []*syncCommitteeMessageJson `json:"data"`
 }
 
+type syncCommitteesPoolResponseJson struct {
+	Data []*syncCommitteeMessageJson `json:"data"`
+}
+
 type identityResponseJson struct {
 	Data *identityJson `json:"data"`
 }
@@ -693,6 +721,36 @@ type syncCommitteeJson struct {
 	AggregatePubkey string   `json:"aggregate_pubkey" hex:"true"`
 }
 
+type lightClientBootstrapJson struct {
+	Header                     *beaconBlockHeaderJson `json:"header"`
+	CurrentSyncCommittee       *syncCommitteeJson     `json:"current_sync_committee"`
+	CurrentSyncCommitteeBranch []string               `json:"current_sync_committee_branch" hex:"true"`
+}
+
+type lightClientUpdateJson struct {
+	AttestedHeader          *beaconBlockHeaderJson `json:"attested_header"`
+	NextSyncCommittee       *syncCommitteeJson     `json:"next_sync_committee"`
+	NextSyncCommitteeBranch []string               `json:"next_sync_committee_branch" hex:"true"`
+	FinalizedHeader         *beaconBlockHeaderJson `json:"finalized_header"`
+	FinalityBranch          []string               `json:"finality_branch" hex:"true"`
+	SyncAggregate           *syncAggregateJson     `json:"sync_aggregate"`
+	SignatureSlot           string                 `json:"signature_slot"`
+}
+
+type lightClientFinalityUpdateJson struct {
+	AttestedHeader  *beaconBlockHeaderJson `json:"attested_header"`
+	FinalizedHeader *beaconBlockHeaderJson `json:"finalized_header"`
+	FinalityBranch  []string               `json:"finality_branch" hex:"true"`
+	SyncAggregate   *syncAggregateJson     `json:"sync_aggregate"`
+	SignatureSlot   string                 `json:"signature_slot"`
+}
+
+type lightClientOptimisticUpdateJson struct {
+	AttestedHeader *beaconBlockHeaderJson `json:"attested_header"`
+	SyncAggregate  *syncAggregateJson     `json:"sync_aggregate"`
+	SignatureSlot  string                 `json:"signature_slot"`
+}
+
 type syncCommitteeValidatorsJson struct {
 	Validators          []string   `json:"validators"`
 	ValidatorAggregates [][]string `json:"validator_aggregates"`
@@ -854,6 +912,15 @@ type eventChainReorgJson struct {
 	ExecutionOptimistic bool   `json:"execution_optimistic"`
 }
 
+type eventDutyResultJson struct {
+	PublicKey      string `json:"public_key" hex:"true"`
+	ValidatorIndex string `json:"validator_index"`
+	Slot           string `json:"slot"`
+	Duty           string `json:"duty" enum:"true"`
+	Outcome        string `json:"outcome" enum:"true"`
+	Reason         string `json:"reason"`
+}
+
 // ---------------
 // Error handling.
 // ---------------
@@ -283,6 +283,10 @@ func (bs *Server) SubmitBlindedBlock(ctx context.Context, req *ethpbv2.SignedBli
 	ctx, span := trace.StartSpan(ctx, "beacon.SubmitBlindedBlock")
 	defer span.End()
 
+	if err := rpchelpers.ValidateBlindedBlockAPI(); err != nil {
+		return nil, err
+	}
+
 	bellatrixBlkContainer, ok := req.Message.(*ethpbv2.SignedBlindedBeaconBlockContainer_BellatrixBlock)
 	if ok {
 		if err := bs.submitBlindedBellatrixBlock(ctx, bellatrixBlkContainer.BellatrixBlock, req.Signature); err != nil {
@@ -321,6 +325,10 @@ func (bs *Server) SubmitBlindedBlockSSZ(ctx context.Context, req *ethpbv2.SSZCon
 	ctx, span := trace.StartSpan(ctx, "beacon.SubmitBlindedBlockSSZ")
 	defer span.End()
 
+	if err := rpchelpers.ValidateBlindedBlockAPI(); err != nil {
+		return &emptypb.Empty{}, err
+	}
+
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return &emptypb.Empty{}, status.Errorf(codes.Internal, "Could not read"+versionHeader+" header")
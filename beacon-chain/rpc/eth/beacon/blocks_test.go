@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
@@ -666,6 +667,9 @@ func TestServer_SubmitBlockSSZ_OK(t *testing.T) {
 }
 
 func TestServer_SubmitBlindedBlockSSZ_OK(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableBlindedBlockAPI: true})
+	defer resetCfg()
+
 	t.Run("Phase 0", func(t *testing.T) {
 		beaconDB := dbTest.SetupDB(t)
 		ctx := context.Background()
@@ -795,7 +799,18 @@ func TestServer_SubmitBlindedBlockSSZ_OK(t *testing.T) {
 	})
 }
 
+func TestSubmitBlindedBlock_Disabled(t *testing.T) {
+	bs := &Server{}
+	_, err := bs.SubmitBlindedBlock(context.Background(), &ethpbv2.SignedBlindedBeaconBlockContainer{})
+	assert.ErrorContains(t, "Blinded block API is disabled", err)
+	_, err = bs.SubmitBlindedBlockSSZ(context.Background(), &ethpbv2.SSZContainer{})
+	assert.ErrorContains(t, "Blinded block API is disabled", err)
+}
+
 func TestSubmitBlindedBlock(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableBlindedBlockAPI: true})
+	defer resetCfg()
+
 	t.Run("Phase 0", func(t *testing.T) {
 		beaconDB := dbTest.SetupDB(t)
 		ctx := context.Background()
@@ -0,0 +1,150 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/lightclient"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetLightClientBootstrap retrieves the sync committee of the period a finalized block belongs to,
+// together with the Merkle branch proving the committee against the block's state root.
+func (bs *Server) GetLightClientBootstrap(ctx context.Context, req *ethpbv2.LightClientBootstrapRequest) (*ethpbv2.LightClientBootstrapResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.GetLightClientBootstrap")
+	defer span.End()
+
+	if len(req.BlockRoot) != fieldparams.RootLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Block root must be %d bytes long", fieldparams.RootLength)
+	}
+	blockRoot := bytesutil.ToBytes32(req.BlockRoot)
+	blk, err := bs.BeaconDB.Block(ctx, blockRoot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get block: %v", err)
+	}
+	if err := wrapper.BeaconBlockIsNil(blk); err != nil {
+		return nil, status.Errorf(codes.NotFound, "Could not find block with root %#x", blockRoot)
+	}
+	if blk.Version() == version.Phase0 {
+		return nil, status.Error(codes.InvalidArgument, "Light client bootstrap is not available before the Altair fork")
+	}
+	if !bs.BeaconDB.IsFinalizedBlock(ctx, blockRoot) {
+		return nil, status.Errorf(codes.NotFound, "Block with root %#x is not finalized", blockRoot)
+	}
+
+	period := slots.SyncCommitteePeriod(slots.ToEpoch(blk.Block().Slot()))
+	committee, err := bs.BeaconDB.SyncCommittee(ctx, period)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get sync committee: %v", err)
+	}
+	if committee == nil {
+		return nil, status.Errorf(codes.NotFound, "Sync committee for period %d is not available", period)
+	}
+
+	st, err := bs.StateGenService.StateByRoot(ctx, blockRoot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get state: %v", err)
+	}
+	stCommittee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get current sync committee from state: %v", err)
+	}
+	if !proto.Equal(committee, stCommittee) {
+		return nil, status.Errorf(codes.Internal, "Stored sync committee for period %d does not match the state", period)
+	}
+	branch, err := st.CurrentSyncCommitteeProof(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute current sync committee proof: %v", err)
+	}
+
+	v1alpha1Header, err := blk.Header()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get block header from block: %v", err)
+	}
+	header := migration.V1Alpha1SignedHeaderToV1(v1alpha1Header)
+
+	var ver ethpbv2.Version
+	switch st.Version() {
+	case version.Altair:
+		ver = ethpbv2.Version_ALTAIR
+	case version.Bellatrix:
+		ver = ethpbv2.Version_BELLATRIX
+	default:
+		return nil, status.Error(codes.Internal, "Unsupported state version")
+	}
+
+	return &ethpbv2.LightClientBootstrapResponse{
+		Version: ver,
+		Data: &ethpbv2.LightClientBootstrap{
+			Header: header.Message,
+			CurrentSyncCommittee: &ethpbv2.SyncCommittee{
+				Pubkeys:         committee.Pubkeys,
+				AggregatePubkey: committee.AggregatePubkey,
+			},
+			CurrentSyncCommitteeBranch: branch,
+		},
+	}, nil
+}
+
+// GetLightClientUpdatesByRange retrieves the best light client update of every sync committee period
+// of the requested range the node produced an update for.
+func (bs *Server) GetLightClientUpdatesByRange(ctx context.Context, req *ethpbv2.LightClientUpdatesByRangeRequest) (*ethpbv2.LightClientUpdatesByRangeResponse, error) {
+	_, span := trace.StartSpan(ctx, "beacon.GetLightClientUpdatesByRange")
+	defer span.End()
+
+	if bs.LightClientUpdates == nil {
+		return nil, status.Error(codes.Unavailable, "Light client server is not enabled")
+	}
+	if req.Count == 0 || req.Count > lightclient.MaxRequestLightClientUpdates {
+		return nil, status.Errorf(codes.InvalidArgument, "Count must be between 1 and %d", lightclient.MaxRequestLightClientUpdates)
+	}
+	return &ethpbv2.LightClientUpdatesByRangeResponse{
+		Data: bs.LightClientUpdates.BestUpdates(req.StartPeriod, req.Count),
+	}, nil
+}
+
+// GetLightClientFinalityUpdate retrieves the latest light client finality update produced by the node.
+func (bs *Server) GetLightClientFinalityUpdate(ctx context.Context, _ *emptypb.Empty) (*ethpbv2.LightClientFinalityUpdateResponse, error) {
+	_, span := trace.StartSpan(ctx, "beacon.GetLightClientFinalityUpdate")
+	defer span.End()
+
+	if bs.LightClientUpdates == nil {
+		return nil, status.Error(codes.Unavailable, "Light client server is not enabled")
+	}
+	update, ver := bs.LightClientUpdates.LatestFinalityUpdate()
+	if update == nil {
+		return nil, status.Error(codes.NotFound, "No light client finality update is available")
+	}
+	return &ethpbv2.LightClientFinalityUpdateResponse{
+		Version: ver,
+		Data:    update,
+	}, nil
+}
+
+// GetLightClientOptimisticUpdate retrieves the latest light client optimistic update produced by the node.
+func (bs *Server) GetLightClientOptimisticUpdate(ctx context.Context, _ *emptypb.Empty) (*ethpbv2.LightClientOptimisticUpdateResponse, error) {
+	_, span := trace.StartSpan(ctx, "beacon.GetLightClientOptimisticUpdate")
+	defer span.End()
+
+	if bs.LightClientUpdates == nil {
+		return nil, status.Error(codes.Unavailable, "Light client server is not enabled")
+	}
+	update, ver := bs.LightClientUpdates.LatestOptimisticUpdate()
+	if update == nil {
+		return nil, status.Error(codes.NotFound, "No light client optimistic update is available")
+	}
+	return &ethpbv2.LightClientOptimisticUpdateResponse{
+		Version: ver,
+		Data:    update,
+	}, nil
+}
@@ -0,0 +1,178 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestServer_GetLightClientBootstrap(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	slot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod)) + 1
+	require.NoError(t, st.SetSlot(slot))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = slot
+	b.Block.StateRoot = stateRoot[:]
+	util.SaveBlock(t, ctx, beaconDB, b)
+	blockRoot, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, st, blockRoot))
+
+	bs := &Server{
+		BeaconDB:        beaconDB,
+		StateGenService: stategen.New(beaconDB),
+	}
+	req := &ethpbv2.LightClientBootstrapRequest{BlockRoot: blockRoot[:]}
+
+	t.Run("not finalized", func(t *testing.T) {
+		_, err := bs.GetLightClientBootstrap(ctx, req)
+		assert.ErrorContains(t, "is not finalized", err)
+	})
+
+	// The genesis block root is always considered finalized.
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, blockRoot))
+
+	t.Run("committee not stored", func(t *testing.T) {
+		_, err := bs.GetLightClientBootstrap(ctx, req)
+		assert.ErrorContains(t, "Sync committee for period 1 is not available", err)
+	})
+
+	committee, err := st.CurrentSyncCommittee()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveSyncCommittee(ctx, 1, committee))
+
+	t.Run("ok", func(t *testing.T) {
+		resp, err := bs.GetLightClientBootstrap(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, ethpbv2.Version_ALTAIR, resp.Version)
+		assert.Equal(t, slot, resp.Data.Header.Slot)
+		assert.DeepEqual(t, stateRoot[:], resp.Data.Header.StateRoot)
+		assert.DeepEqual(t, committee.Pubkeys, resp.Data.CurrentSyncCommittee.Pubkeys)
+		assert.DeepEqual(t, committee.AggregatePubkey, resp.Data.CurrentSyncCommittee.AggregatePubkey)
+		wantBranch, err := st.CurrentSyncCommitteeProof(ctx)
+		require.NoError(t, err)
+		assert.DeepEqual(t, wantBranch, resp.Data.CurrentSyncCommitteeBranch)
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := bs.GetLightClientBootstrap(ctx, &ethpbv2.LightClientBootstrapRequest{BlockRoot: make([]byte, 32)})
+		assert.ErrorContains(t, "Could not find block", err)
+	})
+
+	t.Run("invalid root", func(t *testing.T) {
+		_, err := bs.GetLightClientBootstrap(ctx, &ethpbv2.LightClientBootstrapRequest{BlockRoot: []byte{1}})
+		assert.ErrorContains(t, "Block root must be 32 bytes long", err)
+	})
+
+	t.Run("phase 0 block", func(t *testing.T) {
+		phase0Block := util.NewBeaconBlock()
+		phase0Block.Block.Slot = types.Slot(1)
+		util.SaveBlock(t, ctx, beaconDB, phase0Block)
+		root, err := phase0Block.Block.HashTreeRoot()
+		require.NoError(t, err)
+		_, err = bs.GetLightClientBootstrap(ctx, &ethpbv2.LightClientBootstrapRequest{BlockRoot: root[:]})
+		assert.ErrorContains(t, "not available before the Altair fork", err)
+	})
+}
+
+type mockLightClientUpdates struct {
+	updates          map[uint64]*ethpbv2.LightClientUpdateWithVersion
+	finalityUpdate   *ethpbv2.LightClientFinalityUpdate
+	optimisticUpdate *ethpbv2.LightClientOptimisticUpdate
+}
+
+func (m *mockLightClientUpdates) BestUpdates(startPeriod, count uint64) []*ethpbv2.LightClientUpdateWithVersion {
+	updates := make([]*ethpbv2.LightClientUpdateWithVersion, 0)
+	for period := startPeriod; period < startPeriod+count; period++ {
+		if u, ok := m.updates[period]; ok {
+			updates = append(updates, u)
+		}
+	}
+	return updates
+}
+
+func (m *mockLightClientUpdates) LatestFinalityUpdate() (*ethpbv2.LightClientFinalityUpdate, ethpbv2.Version) {
+	return m.finalityUpdate, ethpbv2.Version_BELLATRIX
+}
+
+func (m *mockLightClientUpdates) LatestOptimisticUpdate() (*ethpbv2.LightClientOptimisticUpdate, ethpbv2.Version) {
+	return m.optimisticUpdate, ethpbv2.Version_BELLATRIX
+}
+
+func TestServer_GetLightClientUpdates(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not enabled", func(t *testing.T) {
+		bs := &Server{}
+		_, err := bs.GetLightClientUpdatesByRange(ctx, &ethpbv2.LightClientUpdatesByRangeRequest{Count: 1})
+		assert.ErrorContains(t, "Light client server is not enabled", err)
+		_, err = bs.GetLightClientFinalityUpdate(ctx, &emptypb.Empty{})
+		assert.ErrorContains(t, "Light client server is not enabled", err)
+		_, err = bs.GetLightClientOptimisticUpdate(ctx, &emptypb.Empty{})
+		assert.ErrorContains(t, "Light client server is not enabled", err)
+	})
+
+	updates := &mockLightClientUpdates{
+		updates: map[uint64]*ethpbv2.LightClientUpdateWithVersion{
+			1: {Version: ethpbv2.Version_ALTAIR, Data: &ethpbv2.LightClientUpdate{SignatureSlot: 1}},
+			3: {Version: ethpbv2.Version_BELLATRIX, Data: &ethpbv2.LightClientUpdate{SignatureSlot: 3}},
+		},
+	}
+	bs := &Server{LightClientUpdates: updates}
+
+	t.Run("no updates yet", func(t *testing.T) {
+		_, err := bs.GetLightClientFinalityUpdate(ctx, &emptypb.Empty{})
+		assert.ErrorContains(t, "No light client finality update is available", err)
+		_, err = bs.GetLightClientOptimisticUpdate(ctx, &emptypb.Empty{})
+		assert.ErrorContains(t, "No light client optimistic update is available", err)
+	})
+
+	t.Run("updates by range", func(t *testing.T) {
+		resp, err := bs.GetLightClientUpdatesByRange(ctx, &ethpbv2.LightClientUpdatesByRangeRequest{StartPeriod: 1, Count: 3})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, ethpbv2.Version_ALTAIR, resp.Data[0].Version)
+		assert.Equal(t, types.Slot(1), resp.Data[0].Data.SignatureSlot)
+		assert.Equal(t, ethpbv2.Version_BELLATRIX, resp.Data[1].Version)
+		assert.Equal(t, types.Slot(3), resp.Data[1].Data.SignatureSlot)
+	})
+
+	t.Run("invalid count", func(t *testing.T) {
+		_, err := bs.GetLightClientUpdatesByRange(ctx, &ethpbv2.LightClientUpdatesByRangeRequest{StartPeriod: 1})
+		assert.ErrorContains(t, "Count must be between 1 and 128", err)
+		_, err = bs.GetLightClientUpdatesByRange(ctx, &ethpbv2.LightClientUpdatesByRangeRequest{StartPeriod: 1, Count: 129})
+		assert.ErrorContains(t, "Count must be between 1 and 128", err)
+	})
+
+	updates.finalityUpdate = &ethpbv2.LightClientFinalityUpdate{SignatureSlot: 5}
+	updates.optimisticUpdate = &ethpbv2.LightClientOptimisticUpdate{SignatureSlot: 6}
+
+	t.Run("finality update", func(t *testing.T) {
+		resp, err := bs.GetLightClientFinalityUpdate(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, ethpbv2.Version_BELLATRIX, resp.Version)
+		assert.Equal(t, types.Slot(5), resp.Data.SignatureSlot)
+	})
+
+	t.Run("optimistic update", func(t *testing.T) {
+		resp, err := bs.GetLightClientOptimisticUpdate(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, ethpbv2.Version_BELLATRIX, resp.Version)
+		assert.Equal(t, types.Slot(6), resp.Data.SignatureSlot)
+	})
+}
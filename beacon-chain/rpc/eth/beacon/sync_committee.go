@@ -168,6 +168,35 @@ func extractSyncSubcommittees(st state.BeaconState, committee *ethpbalpha.SyncCo
 	return subcommittees, nil
 }
 
+// ListPoolSyncCommitteeSignatures retrieves sync committee messages known by the node but not
+// necessarily aggregated into any contribution. Allows filtering by slot.
+func (bs *Server) ListPoolSyncCommitteeSignatures(ctx context.Context, req *ethpbv2.SyncCommitteesPoolRequest) (*ethpbv2.SyncCommitteesPoolResponse, error) {
+	_, span := trace.StartSpan(ctx, "beacon.ListPoolSyncCommitteeSignatures")
+	defer span.End()
+
+	var msgs []*ethpbalpha.SyncCommitteeMessage
+	var err error
+	if req.Slot != nil {
+		msgs, err = bs.SyncCommitteePool.SyncCommitteeMessages(*req.Slot)
+	} else {
+		msgs, err = bs.SyncCommitteePool.AllSyncCommitteeMessages()
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get sync committee messages: %v", err)
+	}
+
+	data := make([]*ethpbv2.SyncCommitteeMessage, len(msgs))
+	for i, msg := range msgs {
+		data[i] = &ethpbv2.SyncCommitteeMessage{
+			Slot:            msg.Slot,
+			BeaconBlockRoot: msg.BlockRoot,
+			ValidatorIndex:  msg.ValidatorIndex,
+			Signature:       msg.Signature,
+		}
+	}
+	return &ethpbv2.SyncCommitteesPoolResponse{Data: data}, nil
+}
+
 // SubmitPoolSyncCommitteeSignatures submits sync committee signature objects to the node.
 func (bs *Server) SubmitPoolSyncCommitteeSignatures(ctx context.Context, req *ethpbv2.SubmitPoolSyncCommitteeSignatures) (*empty.Empty, error) {
 	ctx, span := trace.StartSpan(ctx, "beacon.SubmitPoolSyncCommitteeSignatures")
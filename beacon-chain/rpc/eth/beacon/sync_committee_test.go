@@ -400,3 +400,45 @@ func TestValidateSyncCommitteeMessage(t *testing.T) {
 		assert.ErrorContains(t, "invalid signature length", err)
 	})
 }
+
+func TestListPoolSyncCommitteeSignatures(t *testing.T) {
+	pool := synccommittee.NewStore()
+	root := bytesutil.PadTo([]byte("root"), 32)
+	sig := bytesutil.PadTo([]byte("sig"), 96)
+	for _, slot := range []types.Slot{2, 1, 2} {
+		require.NoError(t, pool.SaveSyncCommitteeMessage(&ethpbalpha.SyncCommitteeMessage{
+			Slot:           slot,
+			BlockRoot:      root,
+			ValidatorIndex: types.ValidatorIndex(slot),
+			Signature:      sig,
+		}))
+	}
+	s := &Server{SyncCommitteePool: pool}
+
+	t.Run("all", func(t *testing.T) {
+		resp, err := s.ListPoolSyncCommitteeSignatures(context.Background(), &ethpbv2.SyncCommitteesPoolRequest{})
+		require.NoError(t, err)
+		require.Equal(t, 3, len(resp.Data))
+		assert.Equal(t, types.Slot(1), resp.Data[0].Slot)
+		assert.Equal(t, types.Slot(2), resp.Data[1].Slot)
+		assert.Equal(t, types.Slot(2), resp.Data[2].Slot)
+		assert.DeepEqual(t, root, resp.Data[0].BeaconBlockRoot)
+		assert.DeepEqual(t, sig, resp.Data[0].Signature)
+		assert.Equal(t, types.ValidatorIndex(1), resp.Data[0].ValidatorIndex)
+	})
+	t.Run("by slot", func(t *testing.T) {
+		slot := types.Slot(2)
+		resp, err := s.ListPoolSyncCommitteeSignatures(context.Background(), &ethpbv2.SyncCommitteesPoolRequest{Slot: &slot})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(resp.Data))
+		for _, msg := range resp.Data {
+			assert.Equal(t, slot, msg.Slot)
+		}
+	})
+	t.Run("empty slot", func(t *testing.T) {
+		slot := types.Slot(3)
+		resp, err := s.ListPoolSyncCommitteeSignatures(context.Background(), &ethpbv2.SyncCommitteesPoolRequest{Slot: &slot})
+		require.NoError(t, err)
+		require.Equal(t, 0, len(resp.Data))
+	})
+}
@@ -33,6 +33,8 @@ const (
 	ChainReorgTopic = "chain_reorg"
 	// SyncCommitteeContributionTopic represents a new sync committee contribution event topic.
 	SyncCommitteeContributionTopic = "contribution_and_proof"
+	// DutyResultTopic represents a duty outcome reported by a validator client event topic.
+	DutyResultTopic = "duty_result"
 )
 
 var casesHandled = map[string]bool{
@@ -43,6 +45,7 @@ var casesHandled = map[string]bool{
 	FinalizedCheckpointTopic:       true,
 	ChainReorgTopic:                true,
 	SyncCommitteeContributionTopic: true,
+	DutyResultTopic:                true,
 }
 
 // StreamEvents allows requesting all events from a set of topics defined in the Ethereum consensus API standard.
@@ -178,6 +181,20 @@ func handleBlockOperationEvents(
 		}
 		v2Data := migration.V1Alpha1SignedContributionAndProofToV2(contributionData.Contribution)
 		return streamData(stream, SyncCommitteeContributionTopic, v2Data)
+	case operation.DutyResultsReceived:
+		if _, ok := requestedTopics[DutyResultTopic]; !ok {
+			return nil
+		}
+		resultsData, ok := event.Data.(*operation.DutyResultsReceivedData)
+		if !ok {
+			return nil
+		}
+		for _, r := range resultsData.Results {
+			if err := streamData(stream, DutyResultTopic, r); err != nil {
+				return err
+			}
+		}
+		return nil
 	default:
 		return nil
 	}
@@ -232,6 +232,42 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 			feed: srv.OperationNotifier.OperationFeed(),
 		})
 	})
+	t.Run(DutyResultTopic, func(t *testing.T) {
+		ctx := context.Background()
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+
+		wantedResult := &eth.DutyResult{
+			PublicKey:      make([]byte, 48),
+			ValidatorIndex: 1,
+			Slot:           2,
+			Duty:           eth.DutyResult_AGGREGATION,
+			Outcome:        eth.DutyResult_SKIPPED,
+			Reason:         "could not sign",
+		}
+		genericResponse, err := anypb.New(wantedResult)
+		require.NoError(t, err)
+
+		wantedMessage := &gateway.EventSource{
+			Event: DutyResultTopic,
+			Data:  genericResponse,
+		}
+
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{DutyResultTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: operation.DutyResultsReceived,
+				Data: &operation.DutyResultsReceivedData{
+					Results: []*eth.DutyResult{wantedResult},
+				},
+			},
+			feed: srv.OperationNotifier.OperationFeed(),
+		})
+	})
 }
 
 func TestStreamEvents_StateEvents(t *testing.T) {
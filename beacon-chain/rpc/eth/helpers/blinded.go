@@ -0,0 +1,16 @@
+package helpers
+
+import (
+	"github.com/prysmaticlabs/prysm/config/features"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ValidateBlindedBlockAPI returns an error if the endpoints producing and publishing blinded blocks
+// have not been enabled with the corresponding feature flag.
+func ValidateBlindedBlockAPI() error {
+	if !features.Get().EnableBlindedBlockAPI {
+		return status.Error(codes.Unimplemented, "Blinded block API is disabled, enable it with --enable-blinded-block-api")
+	}
+	return nil
+}
@@ -295,11 +295,14 @@ func (ns *Server) GetSyncStatus(ctx context.Context, _ *emptypb.Empty) (*ethpb.S
 //    "206":
 //      description: Node is syncing but can serve incomplete data
 //    "503":
-//      description: Node not initialized or having issues
+//      description: Node not initialized, having issues or in maintenance mode
 func (ns *Server) GetHealth(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
 	ctx, span := trace.StartSpan(ctx, "node.GetHealth")
 	defer span.End()
 
+	if ns.Maintenance.Enabled() {
+		return &emptypb.Empty{}, status.Error(codes.Unavailable, "Node is in maintenance mode")
+	}
 	if ns.SyncChecker.Synced() {
 		return &emptypb.Empty{}, nil
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/maintenance"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	"google.golang.org/grpc"
 )
@@ -24,4 +25,5 @@ type Server struct {
 	MetadataProvider      p2p.MetadataProvider
 	GenesisTimeFetcher    blockchain.TimeFetcher
 	HeadFetcher           blockchain.HeadFetcher
+	Maintenance           *maintenance.Mode
 }
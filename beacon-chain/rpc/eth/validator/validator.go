@@ -147,14 +147,27 @@ func (vs *Server) GetProposerDuties(ctx context.Context, req *ethpbv1.ProposerDu
 		return nil, status.Errorf(codes.Internal, "Could not check if slot's block is optimistic: %v", err)
 	}
 
-	s, err = advanceState(ctx, s, req.Epoch, currentEpoch)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not advance state to requested epoch start slot: %v", err)
-	}
-
-	_, proposals, err := helpers.CommitteeAssignments(ctx, s, req.Epoch)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not compute committee assignments: %v", err)
+	var proposals map[types.ValidatorIndex][]types.Slot
+	var root []byte
+	if req.Epoch < slots.ToEpoch(s.Slot()) {
+		// Validator public keys never change, so the head state is used to look them up.
+		proposals, root, err = vs.pastProposerDuties(ctx, s, req.Epoch)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not compute proposer duties of past epoch: %v", err)
+		}
+	} else {
+		s, err = advanceState(ctx, s, req.Epoch, currentEpoch)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not advance state to requested epoch start slot: %v", err)
+		}
+		_, proposals, err = helpers.CommitteeAssignments(ctx, s, req.Epoch)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not compute committee assignments: %v", err)
+		}
+		root, err = vs.proposalDependentRoot(ctx, s, req.Epoch)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get dependent root: %v", err)
+		}
 	}
 
 	duties := make([]*ethpbv1.ProposerDuty, 0)
@@ -177,11 +190,6 @@ func (vs *Server) GetProposerDuties(ctx context.Context, req *ethpbv1.ProposerDu
 		return duties[i].Slot < duties[j].Slot
 	})
 
-	root, err := vs.proposalDependentRoot(ctx, s, req.Epoch)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get dependent root: %v", err)
-	}
-
 	return &ethpbv1.ProposerDutiesResponse{
 		DependentRoot:       root,
 		Data:                duties,
@@ -228,13 +236,12 @@ func (vs *Server) GetSyncCommitteeDuties(ctx context.Context, req *ethpbv2.SyncC
 		return nil, status.Errorf(codes.Internal, "Could not get sync committee state: %v", err)
 	}
 
-	currentSyncCommitteeFirstEpoch, err := slots.SyncCommitteePeriodStartEpoch(requestedEpoch)
+	currentSyncCommitteeLastEpoch, err := slots.SyncCommitteePeriodEndEpoch(requestedEpoch)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Could not get sync committee period start epoch: %v.", err)
+		return nil, status.Errorf(codes.InvalidArgument, "Could not get sync committee period end epoch: %v.", err)
 	}
-	nextSyncCommitteeFirstEpoch := currentSyncCommitteeFirstEpoch + params.BeaconConfig().EpochsPerSyncCommitteePeriod
 	var committee *ethpbalpha.SyncCommittee
-	if req.Epoch >= nextSyncCommitteeFirstEpoch {
+	if req.Epoch > currentSyncCommitteeLastEpoch {
 		committee, err = st.NextSyncCommittee()
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "Could not get sync committee: %v", err)
@@ -426,6 +433,9 @@ func (vs *Server) ProduceBlindedBlock(ctx context.Context, req *ethpbv1.ProduceB
 	ctx, span := trace.StartSpan(ctx, "validator.ProduceBlindedBlock")
 	defer span.End()
 
+	if err := rpchelpers.ValidateBlindedBlockAPI(); err != nil {
+		return nil, err
+	}
 	if err := rpchelpers.ValidateSync(ctx, vs.SyncChecker, vs.HeadFetcher, vs.TimeFetcher, vs.OptimisticModeFetcher); err != nil {
 		// We simply return the error because it's already a gRPC error.
 		return nil, err
@@ -480,6 +490,19 @@ func (vs *Server) ProduceBlindedBlock(ctx context.Context, req *ethpbv1.ProduceB
 			},
 		}, nil
 	}
+	blindedBellatrixBlock, ok := v1alpha1resp.Block.(*ethpbalpha.GenericBeaconBlock_BlindedBellatrix)
+	if ok {
+		block, err := migration.V1Alpha1BlindedBeaconBlockBellatrixToV2Blinded(blindedBellatrixBlock.BlindedBellatrix)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not prepare beacon block: %v", err)
+		}
+		return &ethpbv2.ProduceBlindedBlockResponse{
+			Version: ethpbv2.Version_BELLATRIX,
+			Data: &ethpbv2.BlindedBeaconBlockContainer{
+				Block: &ethpbv2.BlindedBeaconBlockContainer_BellatrixBlock{BellatrixBlock: block},
+			},
+		}, nil
+	}
 	return nil, status.Error(codes.InvalidArgument, "Unsupported block type")
 }
 
@@ -493,6 +516,9 @@ func (vs *Server) ProduceBlindedBlockSSZ(ctx context.Context, req *ethpbv1.Produ
 	ctx, span := trace.StartSpan(ctx, "validator.ProduceBlindedBlockSSZ")
 	defer span.End()
 
+	if err := rpchelpers.ValidateBlindedBlockAPI(); err != nil {
+		return nil, err
+	}
 	if err := rpchelpers.ValidateSync(ctx, vs.SyncChecker, vs.HeadFetcher, vs.TimeFetcher, vs.OptimisticModeFetcher); err != nil {
 		// We simply return the error because it's already a gRPC error.
 		return nil, err
@@ -553,6 +579,21 @@ func (vs *Server) ProduceBlindedBlockSSZ(ctx context.Context, req *ethpbv1.Produ
 			Data:    sszBlock,
 		}, nil
 	}
+	blindedBellatrixBlock, ok := v1alpha1resp.Block.(*ethpbalpha.GenericBeaconBlock_BlindedBellatrix)
+	if ok {
+		block, err := migration.V1Alpha1BlindedBeaconBlockBellatrixToV2Blinded(blindedBellatrixBlock.BlindedBellatrix)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not prepare beacon block: %v", err)
+		}
+		sszBlock, err := block.MarshalSSZ()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not marshal block into SSZ format: %v", err)
+		}
+		return &ethpbv2.SSZContainer{
+			Version: ethpbv2.Version_BELLATRIX,
+			Data:    sszBlock,
+		}, nil
+	}
 	return nil, status.Error(codes.InvalidArgument, "Unsupported block type")
 }
 
@@ -941,6 +982,56 @@ func (vs *Server) proposalDependentRoot(ctx context.Context, s state.BeaconState
 	return root, nil
 }
 
+// pastProposerDuties returns the slots assigned to each proposer of an epoch before the epoch of the
+// head state, along with the dependent root of the epoch. Assignments are computed from the state at
+// the start of the epoch, as the active validators and their balances may have changed since, and are
+// cached by epoch and dependent root.
+func (vs *Server) pastProposerDuties(
+	ctx context.Context,
+	headState state.BeaconState,
+	epoch types.Epoch,
+) (map[types.ValidatorIndex][]types.Slot, []byte, error) {
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not obtain epoch's start slot")
+	}
+	// The dependent root is looked up in the head state when it is still part of its block roots
+	// history, so that cached assignments are served without regenerating the past state.
+	var st state.BeaconState
+	root, err := vs.proposalDependentRoot(ctx, headState, epoch)
+	if err != nil {
+		st, err = vs.StateFetcher.StateBySlot(ctx, startSlot)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not get state at slot %d", startSlot)
+		}
+		root, err = vs.proposalDependentRoot(ctx, st, epoch)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "could not get dependent root")
+		}
+	}
+	rootArray := bytesutil.ToBytes32(root)
+	if vs.ProposerDutiesCache != nil {
+		if proposals := vs.ProposerDutiesCache.ProposerDuties(epoch, rootArray); proposals != nil {
+			return proposals, root, nil
+		}
+	}
+
+	if st == nil {
+		st, err = vs.StateFetcher.StateBySlot(ctx, startSlot)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not get state at slot %d", startSlot)
+		}
+	}
+	_, proposals, err := helpers.CommitteeAssignments(ctx, st, epoch)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not compute committee assignments")
+	}
+	if vs.ProposerDutiesCache != nil {
+		vs.ProposerDutiesCache.AddProposerDuties(epoch, rootArray, proposals)
+	}
+	return proposals, root, nil
+}
+
 // advanceState advances state with empty transitions up to the requested epoch start slot.
 // In case 1 epoch ahead was requested, we take the start slot of the current epoch.
 // Taking the start slot of the next epoch would result in an error inside transition.ProcessSlots.
@@ -29,6 +29,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
@@ -373,6 +374,43 @@ func TestGetProposerDuties(t *testing.T) {
 		assert.ErrorContains(t, fmt.Sprintf("Request epoch %d can not be greater than next epoch %d", currentEpoch+2, currentEpoch+1), err)
 	})
 
+	t.Run("Past epoch", func(t *testing.T) {
+		// Duties of the epoch computed while it was current.
+		want, err := vs.GetProposerDuties(ctx, &ethpbv1.ProposerDutiesRequest{Epoch: 0})
+		require.NoError(t, err)
+
+		headState := bs.Copy()
+		require.NoError(t, headState.SetSlot(params.BeaconConfig().SlotsPerEpoch*2+5))
+		chainSlot := headState.Slot()
+		chain := &mockChain.ChainService{
+			State: headState, Root: genesisRoot[:], Slot: &chainSlot,
+		}
+		vs := &Server{
+			HeadFetcher:           chain,
+			TimeFetcher:           chain,
+			OptimisticModeFetcher: chain,
+			StateFetcher:          &testutil.MockFetcher{BeaconState: bs},
+			SyncChecker:           &mockSync.Sync{IsSyncing: false},
+			ProposerDutiesCache:   cache.NewProposerDutiesCache(),
+		}
+
+		req := &ethpbv1.ProposerDutiesRequest{
+			Epoch: 0,
+		}
+		resp, err := vs.GetProposerDuties(ctx, req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, genesisRoot[:], resp.DependentRoot)
+		assert.Equal(t, 31, len(resp.Data))
+		assert.DeepEqual(t, want.Data, resp.Data)
+		assert.NotNil(t, vs.ProposerDutiesCache.ProposerDuties(0, genesisRoot), "Expected duties to be cached")
+
+		// Cached duties are served without loading the historical state.
+		vs.StateFetcher = &testutil.MockFetcher{}
+		cachedResp, err := vs.GetProposerDuties(ctx, req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, resp, cachedResp)
+	})
+
 	t.Run("execution optimistic", func(t *testing.T) {
 		parentRoot := [32]byte{'a'}
 		blk := util.NewBeaconBlock()
@@ -1713,6 +1751,9 @@ func TestProduceBlockV2SSZ_SyncNotReady(t *testing.T) {
 }
 
 func TestProduceBlindedBlock(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableBlindedBlockAPI: true})
+	defer resetCfg()
+
 	t.Run("Phase 0", func(t *testing.T) {
 		db := dbutil.SetupDB(t)
 		ctx := context.Background()
@@ -2089,6 +2130,9 @@ func TestProduceBlindedBlock(t *testing.T) {
 }
 
 func TestProduceBlindedBlockSSZ(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableBlindedBlockAPI: true})
+	defer resetCfg()
+
 	t.Run("Phase 0", func(t *testing.T) {
 		ctx := context.Background()
 
@@ -2645,6 +2689,9 @@ func TestProduceBlindedBlockSSZ(t *testing.T) {
 }
 
 func TestProduceBlindedBlock_SyncNotReady(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableBlindedBlockAPI: true})
+	defer resetCfg()
+
 	st, err := util.NewBeaconState()
 	require.NoError(t, err)
 	chainService := &mockChain.ChainService{State: st}
@@ -2659,6 +2706,9 @@ func TestProduceBlindedBlock_SyncNotReady(t *testing.T) {
 }
 
 func TestProduceBlindedBlockSSZ_SyncNotReady(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableBlindedBlockAPI: true})
+	defer resetCfg()
+
 	st, err := util.NewBeaconState()
 	require.NoError(t, err)
 	chainService := &mockChain.ChainService{State: st}
@@ -2672,6 +2722,14 @@ func TestProduceBlindedBlockSSZ_SyncNotReady(t *testing.T) {
 	assert.ErrorContains(t, "Syncing to latest head, not ready to respond", err)
 }
 
+func TestProduceBlindedBlock_Disabled(t *testing.T) {
+	vs := &Server{}
+	_, err := vs.ProduceBlindedBlock(context.Background(), &ethpbv1.ProduceBlockRequest{})
+	assert.ErrorContains(t, "Blinded block API is disabled", err)
+	_, err = vs.ProduceBlindedBlockSSZ(context.Background(), &ethpbv1.ProduceBlockRequest{})
+	assert.ErrorContains(t, "Blinded block API is disabled", err)
+}
+
 func TestProduceAttestationData(t *testing.T) {
 	block := util.NewBeaconBlock()
 	block.Block.Slot = 3*params.BeaconConfig().SlotsPerEpoch + 1
@@ -0,0 +1,264 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/api/namespace"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxLoggedParamsLength caps the length of the request parameters logged for a slow request.
+const maxLoggedParamsLength = 512
+
+// Fields whose name contains any of these are redacted from logged request parameters.
+var sensitiveFieldNames = []string{"signature", "private", "secret", "password", "token"}
+
+var (
+	rpcLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rpc_method_latency_seconds",
+		Help:    "Time taken to serve unary RPC requests, by method.",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"method"})
+	rpcRequestSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rpc_request_size_bytes",
+		Help:    "Size of the messages received by RPC methods.",
+		Buckets: prometheus.ExponentialBuckets(64, 4, 10),
+	}, []string{"method"})
+	rpcResponseSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rpc_response_size_bytes",
+		Help:    "Size of the messages sent by RPC methods.",
+		Buckets: prometheus.ExponentialBuckets(64, 4, 10),
+	}, []string{"method"})
+	rpcSlowRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rpc_slow_requests_total",
+		Help: "Number of unary RPC requests which took longer than the slow request threshold, by method.",
+	}, []string{"method"})
+)
+
+// Unary interceptor recording the latency and message sizes of each method, and logging
+// requests slower than the configured threshold.
+func (s *Service) metricsUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	elapsed := time.Since(start)
+
+	rpcLatency.WithLabelValues(info.FullMethod).Observe(elapsed.Seconds())
+	if size, ok := messageSize(req); ok {
+		rpcRequestSize.WithLabelValues(info.FullMethod).Observe(float64(size))
+	}
+	if size, ok := messageSize(resp); ok && err == nil {
+		rpcResponseSize.WithLabelValues(info.FullMethod).Observe(float64(size))
+	}
+	threshold := s.cfg.SlowRequestThreshold
+	if threshold > 0 && elapsed > threshold {
+		rpcSlowRequests.WithLabelValues(info.FullMethod).Inc()
+		fields := logrus.Fields{
+			"method":   info.FullMethod,
+			"duration": elapsed,
+			"params":   sanitizedParams(req),
+			"code":     status.Code(err).String(),
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			fields["peer"] = p.Addr.String()
+		}
+		log.WithFields(fields).Warn("Slow RPC request")
+	}
+	return resp, err
+}
+
+// Stream interceptor recording the size of each message received and sent by a streaming method.
+func (_ *Service) metricsStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(srv, &sizeRecordingStream{ServerStream: ss, method: info.FullMethod})
+}
+
+// sizeRecordingStream wraps a server stream to record the size of the messages going through it.
+type sizeRecordingStream struct {
+	grpc.ServerStream
+	method string
+}
+
+// SendMsg records the size of a sent message.
+func (s *sizeRecordingStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if size, ok := messageSize(m); ok && err == nil {
+		rpcResponseSize.WithLabelValues(s.method).Observe(float64(size))
+	}
+	return err
+}
+
+// RecvMsg records the size of a received message.
+func (s *sizeRecordingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if size, ok := messageSize(m); ok && err == nil {
+		rpcRequestSize.WithLabelValues(s.method).Observe(float64(size))
+	}
+	return err
+}
+
+// Unary interceptor rejecting requests to API namespaces requiring authentication which do not carry
+// the configured bearer token.
+func (s *Service) authUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if err := s.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// Stream interceptor rejecting streams to API namespaces requiring authentication which do not carry
+// the configured bearer token.
+func (s *Service) authStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := s.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (s *Service) authorize(ctx context.Context, method string) error {
+	if len(s.cfg.AuthNamespaces) == 0 {
+		return nil
+	}
+	n, ok := namespace.FromGRPCMethod(method)
+	if !ok || !s.cfg.AuthNamespaces.Contains(n) {
+		return nil
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get("authorization") {
+			if namespace.Authorized(v, s.cfg.AuthToken) {
+				return nil
+			}
+		}
+	}
+	return status.Errorf(codes.Unauthenticated, "API namespace %s requires a valid bearer token", n)
+}
+
+// Unary interceptor rejecting requests to the validator API namespace while the beacon node is in
+// maintenance mode, so that validator clients fail over to other beacon nodes.
+func (s *Service) maintenanceUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if err := s.checkMaintenance(info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// Stream interceptor rejecting streams to the validator API namespace while the beacon node is in
+// maintenance mode, so that validator clients fail over to other beacon nodes.
+func (s *Service) maintenanceStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := s.checkMaintenance(info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (s *Service) checkMaintenance(method string) error {
+	if !s.maintenance.Enabled() {
+		return nil
+	}
+	if n, ok := namespace.FromGRPCMethod(method); ok && n == namespace.Validator {
+		return status.Error(codes.Unavailable, "Beacon node is in maintenance mode")
+	}
+	return nil
+}
+
+func messageSize(m interface{}) (int, bool) {
+	msg, ok := m.(proto.Message)
+	if !ok || msg == nil {
+		return 0, false
+	}
+	return proto.Size(msg), true
+}
+
+// sanitizedParams renders the request parameters for logging, with sensitive fields redacted
+// and the output truncated to a reasonable length.
+func sanitizedParams(req interface{}) string {
+	msg, ok := req.(proto.Message)
+	if !ok || msg == nil {
+		return ""
+	}
+	msg = proto.Clone(msg)
+	redactSensitiveFields(msg.ProtoReflect())
+	enc, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	params := string(enc)
+	if len(params) > maxLoggedParamsLength {
+		params = params[:maxLoggedParamsLength] + "..."
+	}
+	return params
+}
+
+func redactSensitiveFields(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if isSensitiveField(fd) {
+			m.Clear(fd)
+			return true
+		}
+		if fd.Kind() != protoreflect.MessageKind && fd.Kind() != protoreflect.GroupKind {
+			return true
+		}
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redactSensitiveFields(list.Get(i).Message())
+			}
+		case fd.IsMap():
+			// Map values are not expected in requests and are logged as is.
+		default:
+			redactSensitiveFields(v.Message())
+		}
+		return true
+	})
+}
+
+func isSensitiveField(fd protoreflect.FieldDescriptor) bool {
+	name := strings.ToLower(string(fd.Name()))
+	for _, s := range sensitiveFieldNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/api/namespace"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/maintenance"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricsUnaryInterceptor_LogsSlowRequests(t *testing.T) {
+	hook := logTest.NewGlobal()
+	req := &ethpb.SignedVoluntaryExit{
+		Exit:      &ethpb.VoluntaryExit{Epoch: 5, ValidatorIndex: 7},
+		Signature: bytesutil.PadTo([]byte("sig"), 96),
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.v1alpha1.BeaconNodeValidator/ProposeExit"}
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		time.Sleep(10 * time.Millisecond)
+		return &ethpb.ProposeExitResponse{}, nil
+	}
+
+	s := &Service{cfg: &Config{SlowRequestThreshold: time.Second}}
+	_, err := s.metricsUnaryInterceptor(context.Background(), req, info, handler)
+	require.NoError(t, err)
+	assert.LogsDoNotContain(t, hook, "Slow RPC request")
+
+	s.cfg.SlowRequestThreshold = time.Millisecond
+	_, err = s.metricsUnaryInterceptor(context.Background(), req, info, handler)
+	require.NoError(t, err)
+	assert.LogsContain(t, hook, "Slow RPC request")
+	assert.LogsContain(t, hook, "ProposeExit")
+	assert.LogsContain(t, hook, "validator_index")
+	assert.LogsDoNotContain(t, hook, "signature")
+
+	// Disabled when the threshold is not set.
+	hook.Reset()
+	s.cfg.SlowRequestThreshold = 0
+	_, err = s.metricsUnaryInterceptor(context.Background(), req, info, handler)
+	require.NoError(t, err)
+	assert.LogsDoNotContain(t, hook, "Slow RPC request")
+}
+
+func TestSanitizedParams(t *testing.T) {
+	req := &ethpb.SignedAggregateSubmitRequest{
+		SignedAggregateAndProof: &ethpb.SignedAggregateAttestationAndProof{
+			Message: &ethpb.AggregateAttestationAndProof{
+				AggregatorIndex: 3,
+				Aggregate:       &ethpb.Attestation{Signature: make([]byte, 96)},
+				SelectionProof:  make([]byte, 96),
+			},
+			Signature: make([]byte, 96),
+		},
+	}
+	params := sanitizedParams(req)
+	assert.Equal(t, true, strings.Contains(params, `"aggregator_index":"3"`), params)
+	assert.Equal(t, false, strings.Contains(params, "signature"), params)
+	// The original request is left untouched.
+	assert.Equal(t, 96, len(req.SignedAggregateAndProof.Signature))
+
+	long := &ethpb.ValidatorIndexRequest{PublicKey: make([]byte, maxLoggedParamsLength)}
+	assert.Equal(t, maxLoggedParamsLength+len("..."), len(sanitizedParams(long)))
+	assert.Equal(t, "", sanitizedParams("not a proto"))
+}
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	s := &Service{cfg: &Config{
+		AuthNamespaces: namespace.Set{namespace.Validator: true},
+		AuthToken:      "secret",
+	}}
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return &ethpb.ProposeExitResponse{}, nil
+	}
+	validatorMethod := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.v1alpha1.BeaconNodeValidator/ProposeExit"}
+	nodeMethod := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.service.BeaconNode/GetVersion"}
+
+	_, err := s.authUnaryInterceptor(context.Background(), nil, validatorMethod, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong"))
+	_, err = s.authUnaryInterceptor(ctx, nil, validatorMethod, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+	_, err = s.authUnaryInterceptor(ctx, nil, validatorMethod, handler)
+	require.NoError(t, err)
+
+	// Namespaces without authentication are served to anyone.
+	_, err = s.authUnaryInterceptor(context.Background(), nil, nodeMethod, handler)
+	require.NoError(t, err)
+}
+
+func TestMaintenanceUnaryInterceptor(t *testing.T) {
+	s := &Service{cfg: &Config{}, maintenance: &maintenance.Mode{}}
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return &ethpb.ProposeExitResponse{}, nil
+	}
+	validatorMethod := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.v1alpha1.BeaconNodeValidator/ProposeExit"}
+	nodeMethod := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.service.BeaconNode/GetVersion"}
+
+	_, err := s.maintenanceUnaryInterceptor(context.Background(), nil, validatorMethod, handler)
+	require.NoError(t, err)
+
+	s.maintenance.Set(true)
+	_, err = s.maintenanceUnaryInterceptor(context.Background(), nil, validatorMethod, handler)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	// Other namespaces keep being served.
+	_, err = s.maintenanceUnaryInterceptor(context.Background(), nil, nodeMethod, handler)
+	require.NoError(t, err)
+
+	s.maintenance.Set(false)
+	_, err = s.maintenanceUnaryInterceptor(context.Background(), nil, validatorMethod, handler)
+	require.NoError(t, err)
+}
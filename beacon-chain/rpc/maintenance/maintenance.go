@@ -0,0 +1,34 @@
+// Package maintenance tracks whether the beacon node is in maintenance mode, in which it keeps
+// syncing and serving read APIs but reports not being ready to validator clients, so that they
+// fail over to other beacon nodes while operators perform disruptive maintenance.
+package maintenance
+
+import (
+	"github.com/prysmaticlabs/prysm/async/abool"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "rpc/maintenance")
+
+// Mode of the beacon node, disabled by default. A nil mode is never enabled.
+type Mode struct {
+	enabled abool.AtomicBool
+}
+
+// Enabled returns whether the beacon node is in maintenance mode.
+func (m *Mode) Enabled() bool {
+	return m != nil && m.enabled.IsSet()
+}
+
+// Set puts the beacon node in or out of maintenance mode.
+func (m *Mode) Set(enabled bool) {
+	if m.enabled.IsSet() == enabled {
+		return
+	}
+	m.enabled.SetTo(enabled)
+	if enabled {
+		log.Warn("Entered maintenance mode, validator clients will be told this beacon node is not ready")
+	} else {
+		log.Info("Left maintenance mode, serving validator clients again")
+	}
+}
@@ -0,0 +1,27 @@
+package maintenance
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestMode(t *testing.T) {
+	hook := logTest.NewGlobal()
+	var nilMode *Mode
+	assert.Equal(t, false, nilMode.Enabled())
+
+	m := &Mode{}
+	assert.Equal(t, false, m.Enabled())
+	m.Set(true)
+	assert.Equal(t, true, m.Enabled())
+	assert.LogsContain(t, hook, "Entered maintenance mode")
+	hook.Reset()
+
+	m.Set(true)
+	assert.Equal(t, 0, len(hook.Entries))
+	m.Set(false)
+	assert.Equal(t, false, m.Enabled())
+	assert.LogsContain(t, hook, "Left maintenance mode")
+}
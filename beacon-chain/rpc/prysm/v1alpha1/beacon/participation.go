@@ -0,0 +1,192 @@
+package beacon
+
+import (
+	"context"
+	"sort"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetParticipationEstimate aggregates the attestations of the current epoch observed so far, in the
+// attestation pool and in the canonical blocks of the epoch, into the share of the validators which were
+// expected to attest by the current slot, and into the distribution of their head votes across block roots.
+// The estimate is computed at most once per slot.
+func (bs *Server) GetParticipationEstimate(ctx context.Context, _ *emptypb.Empty) (*ethpb.ParticipationEstimate, error) {
+	currentSlot := bs.GenesisTimeFetcher.CurrentSlot()
+
+	bs.participationEstimateLock.Lock()
+	defer bs.participationEstimateLock.Unlock()
+	if bs.participationEstimate != nil && bs.participationEstimate.Slot == currentSlot {
+		return bs.participationEstimate, nil
+	}
+	estimate, err := bs.estimateParticipation(ctx, currentSlot)
+	if err != nil {
+		return nil, err
+	}
+	bs.participationEstimate = estimate
+	return estimate, nil
+}
+
+func (bs *Server) estimateParticipation(ctx context.Context, currentSlot types.Slot) (*ethpb.ParticipationEstimate, error) {
+	epoch := slots.ToEpoch(currentSlot)
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get start slot of epoch %d: %v", epoch, err)
+	}
+	st, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	if st == nil || st.IsNil() {
+		return nil, status.Error(codes.Internal, "Head state is nil")
+	}
+	// Committees of the current epoch are computed from a state of the epoch.
+	if st.Slot() < startSlot {
+		st, err = transition.ProcessSlots(ctx, st, startSlot)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not process slots up to %d: %v", startSlot, err)
+		}
+	}
+
+	expected, err := expectedAttesters(ctx, st, startSlot, currentSlot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not count expected attesters: %v", err)
+	}
+	atts, err := bs.observedAttestations(ctx, epoch, currentSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each validator is counted once, for the head vote of the first attestation it is seen in.
+	seen := make(map[uint64]bool)
+	votes := make(map[[32]byte]uint64)
+	for _, att := range atts {
+		committee, err := helpers.BeaconCommitteeFromState(ctx, st, att.Data.Slot, att.Data.CommitteeIndex)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get committee: %v", err)
+		}
+		indices, err := attestation.AttestingIndices(att.AggregationBits, committee)
+		if err != nil {
+			// Skip attestations whose bits do not fit their committee.
+			continue
+		}
+		root := bytesutil.ToBytes32(att.Data.BeaconBlockRoot)
+		for _, idx := range indices {
+			if seen[idx] {
+				continue
+			}
+			seen[idx] = true
+			votes[root]++
+		}
+	}
+
+	headVotes := make([]*ethpb.ParticipationEstimate_HeadVote, 0, len(votes))
+	for root, count := range votes {
+		canonical, err := bs.CanonicalFetcher.IsCanonical(ctx, root)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not determine if block root %#x is canonical: %v", root, err)
+		}
+		r := root
+		headVotes = append(headVotes, &ethpb.ParticipationEstimate_HeadVote{
+			BlockRoot: r[:],
+			Votes:     count,
+			Canonical: canonical,
+		})
+	}
+	sort.Slice(headVotes, func(i, j int) bool {
+		if headVotes[i].Votes != headVotes[j].Votes {
+			return headVotes[i].Votes > headVotes[j].Votes
+		}
+		return string(headVotes[i].BlockRoot) < string(headVotes[j].BlockRoot)
+	})
+
+	var rate float32
+	if expected > 0 {
+		rate = float32(len(seen)) / float32(expected)
+	}
+	return &ethpb.ParticipationEstimate{
+		Epoch:             epoch,
+		Slot:              currentSlot,
+		ExpectedAttesters: expected,
+		ObservedAttesters: uint64(len(seen)),
+		ParticipationRate: rate,
+		HeadVotes:         headVotes,
+	}, nil
+}
+
+// expectedAttesters returns the number of validators assigned to the committees of the slots in [startSlot, endSlot].
+func expectedAttesters(ctx context.Context, st state.ReadOnlyBeaconState, startSlot, endSlot types.Slot) (uint64, error) {
+	activeCount, err := helpers.ActiveValidatorCount(ctx, st, slots.ToEpoch(startSlot))
+	if err != nil {
+		return 0, err
+	}
+	committeesPerSlot := helpers.SlotCommitteeCount(activeCount)
+	var expected uint64
+	for s := startSlot; s <= endSlot; s++ {
+		for i := uint64(0); i < committeesPerSlot; i++ {
+			committee, err := helpers.BeaconCommitteeFromState(ctx, st, s, types.CommitteeIndex(i))
+			if err != nil {
+				return 0, err
+			}
+			expected += uint64(len(committee))
+		}
+	}
+	return expected, nil
+}
+
+// observedAttestations returns the attestations targeting the given epoch, up to the given slot, which are
+// in the attestation pool or included in a canonical block.
+func (bs *Server) observedAttestations(ctx context.Context, epoch types.Epoch, endSlot types.Slot) ([]*ethpb.Attestation, error) {
+	unaggregated, err := bs.AttestationsPool.UnaggregatedAttestations()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get unaggregated attestations: %v", err)
+	}
+	var pooled []*ethpb.Attestation
+	pooled = append(pooled, bs.AttestationsPool.AggregatedAttestations()...)
+	pooled = append(pooled, unaggregated...)
+	pooled = append(pooled, bs.AttestationsPool.BlockAttestations()...)
+
+	blks, _, err := bs.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartEpoch(epoch).SetEndEpoch(epoch))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get blocks: %v", err)
+	}
+	for _, b := range blks {
+		if b == nil || b.IsNil() {
+			continue
+		}
+		root, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not compute block root: %v", err)
+		}
+		canonical, err := bs.CanonicalFetcher.IsCanonical(ctx, root)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not determine if block is canonical: %v", err)
+		}
+		if canonical {
+			pooled = append(pooled, b.Block().Body().Attestations()...)
+		}
+	}
+
+	atts := make([]*ethpb.Attestation, 0, len(pooled))
+	for _, att := range pooled {
+		if att == nil || att.Data == nil || att.Data.Target == nil {
+			continue
+		}
+		if att.Data.Target.Epoch != epoch || att.Data.Slot > endSlot {
+			continue
+		}
+		atts = append(atts, att)
+	}
+	return atts, nil
+}
@@ -0,0 +1,91 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestServer_GetParticipationEstimate(t *testing.T) {
+	helpers.ClearCache()
+	ctx := context.Background()
+	headState, _ := util.DeterministicGenesisState(t, 64)
+	rootA := bytesutil.ToBytes32([]byte("A"))
+	rootB := bytesutil.ToBytes32([]byte("B"))
+
+	// Full committees of slot 1 and slot 2 vote for different heads.
+	pool := attestations.NewPool()
+	committeeSizes := make(map[types.Slot]uint64)
+	for _, v := range []struct {
+		slot types.Slot
+		root [32]byte
+	}{{1, rootA}, {2, rootB}} {
+		committee, err := helpers.BeaconCommitteeFromState(ctx, headState, v.slot, 0)
+		require.NoError(t, err)
+		committeeSizes[v.slot] = uint64(len(committee))
+		bits := bitfield.NewBitlist(uint64(len(committee)))
+		for i := range committee {
+			bits.SetBitAt(uint64(i), true)
+		}
+		att := &ethpb.Attestation{
+			AggregationBits: bits,
+			Data: &ethpb.AttestationData{
+				Slot:            v.slot,
+				BeaconBlockRoot: bytesutil.SafeCopyBytes(v.root[:]),
+				Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+				Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			},
+			Signature: make([]byte, 96),
+		}
+		require.NoError(t, pool.SaveAggregatedAttestation(att))
+	}
+
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	chain := &chainMock.ChainService{
+		State:          headState,
+		Genesis:        time.Now().Add(-3 * secondsPerSlot),
+		CanonicalRoots: map[[32]byte]bool{rootA: true},
+	}
+	bs := &Server{
+		BeaconDB:           dbTest.SetupDB(t),
+		HeadFetcher:        chain,
+		CanonicalFetcher:   chain,
+		GenesisTimeFetcher: chain,
+		AttestationsPool:   pool,
+	}
+
+	res, err := bs.GetParticipationEstimate(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	perSlot := uint64(64) / uint64(params.BeaconConfig().SlotsPerEpoch)
+	assert.Equal(t, types.Epoch(0), res.Epoch)
+	assert.Equal(t, types.Slot(3), res.Slot)
+	assert.Equal(t, 4*perSlot, res.ExpectedAttesters)
+	assert.Equal(t, committeeSizes[1]+committeeSizes[2], res.ObservedAttesters)
+	assert.Equal(t, float32(res.ObservedAttesters)/float32(res.ExpectedAttesters), res.ParticipationRate)
+	require.Equal(t, 2, len(res.HeadVotes))
+	assert.DeepEqual(t, rootA[:], res.HeadVotes[0].BlockRoot)
+	assert.Equal(t, committeeSizes[1], res.HeadVotes[0].Votes)
+	assert.Equal(t, true, res.HeadVotes[0].Canonical)
+	assert.DeepEqual(t, rootB[:], res.HeadVotes[1].BlockRoot)
+	assert.Equal(t, committeeSizes[2], res.HeadVotes[1].Votes)
+	assert.Equal(t, false, res.HeadVotes[1].Canonical)
+
+	// The estimate is only computed once per slot.
+	again, err := bs.GetParticipationEstimate(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, res, again)
+}
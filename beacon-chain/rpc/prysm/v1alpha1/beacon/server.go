@@ -5,6 +5,7 @@ package beacon
 
 import (
 	"context"
+	gosync "sync"
 	"time"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
@@ -48,4 +49,6 @@ type Server struct {
 	ReplayerBuilder             stategen.ReplayerBuilder
 	HeadUpdater                 blockchain.HeadUpdater
 	OptimisticModeFetcher       blockchain.OptimisticModeFetcher
+	participationEstimateLock   gosync.Mutex
+	participationEstimate       *ethpb.ParticipationEstimate
 }
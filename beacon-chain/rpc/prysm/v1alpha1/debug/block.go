@@ -6,7 +6,6 @@ import (
 	"math"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
-	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
@@ -56,15 +55,15 @@ func (ds *Server) GetInclusionSlot(ctx context.Context, req *pbrpc.InclusionSlot
 	startSlot := req.Slot + params.BeaconConfig().MinAttestationInclusionDelay
 	endSlot := req.Slot + params.BeaconConfig().SlotsPerEpoch
 
-	filter := filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot)
-	blks, _, err := ds.BeaconDB.Blocks(ctx, filter)
+	blks, err := ds.BeaconDB.CanonicalBlocks(ctx, startSlot, endSlot)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not retrieve blocks: %v", err)
 	}
 
 	inclusionSlot := types.Slot(math.MaxUint64)
 	targetStates := make(map[[32]byte]state.ReadOnlyBeaconState)
-	for _, blk := range blks {
+	for blks.Next() {
+		blk := blks.Block()
 		for _, a := range blk.Block().Body().Attestations() {
 			tr := bytesutil.ToBytes32(a.Data.Target.Root)
 			s, ok := targetStates[tr]
@@ -91,6 +90,9 @@ func (ds *Server) GetInclusionSlot(ctx context.Context, req *pbrpc.InclusionSlot
 			}
 		}
 	}
+	if err := blks.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve blocks: %v", err)
+	}
 
 	return &pbrpc.InclusionSlotResponse{Slot: inclusionSlot}, nil
 }
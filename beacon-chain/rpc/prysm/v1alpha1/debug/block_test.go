@@ -77,6 +77,10 @@ func TestServer_GetAttestationInclusionSlot(t *testing.T) {
 	b.Block.Slot = 2
 	b.Block.Body.Attestations = []*ethpb.Attestation{a}
 	util.SaveBlock(t, ctx, bs.BeaconDB, b)
+	r, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: b.Block.Slot, Root: r[:]}))
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, r))
 	res, err := bs.GetInclusionSlot(ctx, &ethpb.InclusionSlotRequest{Slot: 1, Id: uint64(c[0])})
 	require.NoError(t, err)
 	require.Equal(t, b.Block.Slot, res.Slot)
@@ -0,0 +1,34 @@
+package debug
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/prysmaticlabs/prysm/beacon-chain/reload"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReloadConfig re-reads the config file of the beacon node and applies the settings
+// which can be changed at runtime, returning the settings which changed.
+func (ds *Server) ReloadConfig(_ context.Context, _ *empty.Empty) (*pbrpc.ReloadConfigResponse, error) {
+	if ds.ConfigReloader == nil {
+		return nil, status.Error(codes.Unavailable, "Configuration reload is not available")
+	}
+	changes, err := ds.ConfigReloader.Reload(reload.TriggerAPI)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Could not reload configuration: %v", err)
+	}
+	resp := &pbrpc.ReloadConfigResponse{
+		Changes: make([]*pbrpc.ReloadConfigResponse_Change, len(changes)),
+	}
+	for i, c := range changes {
+		resp.Changes[i] = &pbrpc.ReloadConfigResponse_Change{
+			Name:     c.Name,
+			OldValue: c.Old,
+			NewValue: c.New,
+		}
+	}
+	return resp, nil
+}
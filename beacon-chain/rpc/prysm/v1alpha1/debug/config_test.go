@@ -0,0 +1,43 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/prysmaticlabs/prysm/beacon-chain/reload"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+type mockReloader struct {
+	changes []*reload.Change
+	err     error
+	trigger string
+}
+
+func (m *mockReloader) Reload(trigger string) ([]*reload.Change, error) {
+	m.trigger = trigger
+	return m.changes, m.err
+}
+
+func TestServer_ReloadConfig(t *testing.T) {
+	r := &mockReloader{changes: []*reload.Change{{Name: "p2p-max-peers", Old: "45", New: "70"}}}
+	ds := &Server{ConfigReloader: r}
+	resp, err := ds.ReloadConfig(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, reload.TriggerAPI, r.trigger)
+	require.Equal(t, 1, len(resp.Changes))
+	assert.Equal(t, "p2p-max-peers", resp.Changes[0].Name)
+	assert.Equal(t, "45", resp.Changes[0].OldValue)
+	assert.Equal(t, "70", resp.Changes[0].NewValue)
+
+	r.err = errors.New("invalid verbosity")
+	_, err = ds.ReloadConfig(context.Background(), &empty.Empty{})
+	assert.ErrorContains(t, "Could not reload configuration: invalid verbosity", err)
+
+	ds = &Server{}
+	_, err = ds.ReloadConfig(context.Background(), &empty.Empty{})
+	assert.ErrorContains(t, "Configuration reload is not available", err)
+}
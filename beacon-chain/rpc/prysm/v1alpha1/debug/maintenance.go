@@ -0,0 +1,29 @@
+package debug
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetMaintenanceMode returns whether the beacon node is in maintenance mode.
+func (ds *Server) GetMaintenanceMode(_ context.Context, _ *empty.Empty) (*pbrpc.MaintenanceMode, error) {
+	if ds.Maintenance == nil {
+		return nil, status.Error(codes.Unavailable, "Maintenance mode is not available")
+	}
+	return &pbrpc.MaintenanceMode{Enabled: ds.Maintenance.Enabled()}, nil
+}
+
+// SetMaintenanceMode puts the beacon node in or out of maintenance mode. While in maintenance
+// mode, the beacon node keeps syncing and serving read APIs but reports not being ready to
+// validator clients, so that they fail over to other beacon nodes.
+func (ds *Server) SetMaintenanceMode(_ context.Context, req *pbrpc.MaintenanceMode) (*pbrpc.MaintenanceMode, error) {
+	if ds.Maintenance == nil {
+		return nil, status.Error(codes.Unavailable, "Maintenance mode is not available")
+	}
+	ds.Maintenance.Set(req.Enabled)
+	return &pbrpc.MaintenanceMode{Enabled: ds.Maintenance.Enabled()}, nil
+}
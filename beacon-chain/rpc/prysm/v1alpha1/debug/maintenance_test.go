@@ -0,0 +1,37 @@
+package debug
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/maintenance"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestServer_MaintenanceMode(t *testing.T) {
+	ds := &Server{Maintenance: &maintenance.Mode{}}
+	resp, err := ds.GetMaintenanceMode(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, false, resp.Enabled)
+
+	resp, err = ds.SetMaintenanceMode(context.Background(), &pbrpc.MaintenanceMode{Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.Enabled)
+	assert.Equal(t, true, ds.Maintenance.Enabled())
+	resp, err = ds.GetMaintenanceMode(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.Enabled)
+
+	resp, err = ds.SetMaintenanceMode(context.Background(), &pbrpc.MaintenanceMode{Enabled: false})
+	require.NoError(t, err)
+	assert.Equal(t, false, resp.Enabled)
+
+	ds = &Server{}
+	_, err = ds.GetMaintenanceMode(context.Background(), &empty.Empty{})
+	assert.ErrorContains(t, "Maintenance mode is not available", err)
+	_, err = ds.SetMaintenanceMode(context.Background(), &pbrpc.MaintenanceMode{Enabled: true})
+	assert.ErrorContains(t, "Maintenance mode is not available", err)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"google.golang.org/grpc/codes"
@@ -35,6 +36,53 @@ func (ds *Server) ListPeers(_ context.Context, _ *empty.Empty) (*ethpb.DebugPeer
 	return &ethpb.DebugPeerResponses{Responses: responses}, nil
 }
 
+// GetGossipDuplicateStats returns, for every gossip topic, the number of messages received for the first
+// time and the number of duplicate messages ignored, broken down by the connected peers which forwarded them.
+func (ds *Server) GetGossipDuplicateStats(_ context.Context, _ *empty.Empty) (*ethpb.GossipDuplicateStatsResponse, error) {
+	if ds.GossipStatsProvider == nil {
+		return nil, status.Error(codes.Unavailable, "Gossip statistics are not available")
+	}
+	return &ethpb.GossipDuplicateStatsResponse{Topics: ds.GossipStatsProvider.GossipDuplicateStats()}, nil
+}
+
+// GetPeerChurn returns the number of goodbye messages received and sent, and of peers dropped on errors,
+// by reason since the node started, along with the latest of these events.
+func (ds *Server) GetPeerChurn(_ context.Context, _ *empty.Empty) (*ethpb.PeerChurnResponse, error) {
+	return ds.PeersFetcher.Peers().Churn(), nil
+}
+
+// ValidateGossipMessage runs a gossip message through the validator of its topic and returns the
+// verdict, without broadcasting or processing the message.
+func (ds *Server) ValidateGossipMessage(
+	ctx context.Context, req *ethpb.GossipMessageValidationRequest,
+) (*ethpb.GossipMessageValidationResponse, error) {
+	if ds.GossipValidator == nil {
+		return nil, status.Error(codes.Unavailable, "Gossip validation is not available")
+	}
+	var pid peer.ID
+	if req.PeerId != "" {
+		var err error
+		pid, err = peer.Decode(req.PeerId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Unable to parse provided peer id: %v", err)
+		}
+	}
+	res, err := ds.GossipValidator.ValidateGossipMessage(ctx, req.Topic, pid, req.Data)
+	resp := &ethpb.GossipMessageValidationResponse{}
+	switch res {
+	case pubsub.ValidationAccept:
+		resp.Verdict = ethpb.GossipMessageValidationResponse_ACCEPT
+	case pubsub.ValidationReject:
+		resp.Verdict = ethpb.GossipMessageValidationResponse_REJECT
+	default:
+		resp.Verdict = ethpb.GossipMessageValidationResponse_IGNORE
+	}
+	if err != nil {
+		resp.Reason = err.Error()
+	}
+	return resp, nil
+}
+
 func (ds *Server) getPeer(pid peer.ID) (*ethpb.DebugPeerResponse, error) {
 	peers := ds.PeersFetcher.Peers()
 	peerStore := ds.PeerManager.Host().Peerstore()
@@ -164,3 +212,26 @@ func errorToString(err error) string {
 	}
 	return err.Error()
 }
+
+// GetPeerConnectionFilter returns the CIDR subnets peer connections are allowed or denied with, in
+// addition to the ones set by flags.
+func (ds *Server) GetPeerConnectionFilter(_ context.Context, _ *empty.Empty) (*ethpb.PeerConnectionFilter, error) {
+	if ds.ConnectionFilter == nil {
+		return nil, status.Error(codes.Unavailable, "Peer connection filter is not available")
+	}
+	f := ds.ConnectionFilter.ConnectionFilter()
+	return &ethpb.PeerConnectionFilter{AllowList: f.AllowList, DenyList: f.DenyList}, nil
+}
+
+// SetPeerConnectionFilter replaces the CIDR subnets peer connections are allowed or denied with, in
+// addition to the ones set by flags.
+func (ds *Server) SetPeerConnectionFilter(_ context.Context, req *ethpb.PeerConnectionFilter) (*ethpb.PeerConnectionFilter, error) {
+	if ds.ConnectionFilter == nil {
+		return nil, status.Error(codes.Unavailable, "Peer connection filter is not available")
+	}
+	f := &p2p.ConnectionFilter{AllowList: req.AllowList, DenyList: req.DenyList}
+	if err := ds.ConnectionFilter.SetConnectionFilter(f); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Could not set peer connection filter: %v", err)
+	}
+	return ds.GetPeerConnectionFilter(context.Background(), &empty.Empty{})
+}
@@ -2,10 +2,15 @@ package debug
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	mockP2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
@@ -57,3 +62,131 @@ func TestDebugServer_ListPeers(t *testing.T) {
 		t.Errorf("Expected 2nd peer to have a multiaddress, instead they have no addresses")
 	}
 }
+
+type mockGossipStatsProvider struct {
+	stats []*ethpb.GossipTopicDuplicateStats
+}
+
+func (m *mockGossipStatsProvider) GossipDuplicateStats() []*ethpb.GossipTopicDuplicateStats {
+	return m.stats
+}
+
+func TestDebugServer_GetGossipDuplicateStats(t *testing.T) {
+	stats := []*ethpb.GossipTopicDuplicateStats{
+		{
+			Topic:      "/eth2/00000000/beacon_block/ssz_snappy",
+			Received:   3,
+			Duplicates: 5,
+			Peers:      []*ethpb.GossipPeerDuplicateStats{{PeerId: "a", Received: 3, Duplicates: 5}},
+		},
+	}
+	ds := &Server{GossipStatsProvider: &mockGossipStatsProvider{stats: stats}}
+
+	res, err := ds.GetGossipDuplicateStats(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, stats, res.Topics)
+
+	ds = &Server{}
+	_, err = ds.GetGossipDuplicateStats(context.Background(), &empty.Empty{})
+	assert.ErrorContains(t, "Gossip statistics are not available", err)
+}
+
+func TestDebugServer_GetPeerChurn(t *testing.T) {
+	peersProvider := &mockP2p.MockPeersProvider{}
+	ds := &Server{PeersFetcher: peersProvider}
+	pid := peersProvider.Peers().All()[0]
+	peersProvider.Peers().RecordGoodbyeReceived(pid, p2ptypes.GoodbyeCodeTooManyPeers)
+	peersProvider.Peers().RecordGoodbyeSent(pid, p2ptypes.GoodbyeCodeBadScore)
+
+	res, err := ds.GetPeerChurn(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(res.Counts))
+	assert.Equal(t, ethpb.PeerChurnEvent_GOODBYE_RECEIVED, res.Counts[0].Source)
+	assert.Equal(t, "client has too many peers", res.Counts[0].Reason)
+	assert.Equal(t, ethpb.PeerChurnEvent_GOODBYE_SENT, res.Counts[1].Source)
+	assert.Equal(t, "peer score too low", res.Counts[1].Reason)
+	require.Equal(t, 2, len(res.RecentEvents))
+	assert.Equal(t, pid.String(), res.RecentEvents[0].PeerId)
+	assert.Equal(t, uint64(p2ptypes.GoodbyeCodeTooManyPeers), res.RecentEvents[0].GoodbyeCode)
+}
+
+type mockConnectionFilterManager struct {
+	filter *p2p.ConnectionFilter
+}
+
+func (m *mockConnectionFilterManager) ConnectionFilter() *p2p.ConnectionFilter {
+	return m.filter.Copy()
+}
+
+func (m *mockConnectionFilterManager) SetConnectionFilter(f *p2p.ConnectionFilter) error {
+	if len(f.AllowList) > 0 && f.AllowList[0] == "invalid" {
+		return errors.New("invalid CIDR address")
+	}
+	m.filter = f
+	return nil
+}
+
+func TestDebugServer_PeerConnectionFilter(t *testing.T) {
+	ds := &Server{ConnectionFilter: &mockConnectionFilterManager{}}
+	res, err := ds.GetPeerConnectionFilter(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(res.AllowList))
+	assert.Equal(t, 0, len(res.DenyList))
+
+	res, err = ds.SetPeerConnectionFilter(context.Background(), &ethpb.PeerConnectionFilter{
+		AllowList: []string{"10.0.0.0/8"},
+		DenyList:  []string{"10.1.0.0/16"},
+	})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"10.0.0.0/8"}, res.AllowList)
+	assert.DeepEqual(t, []string{"10.1.0.0/16"}, res.DenyList)
+
+	_, err = ds.SetPeerConnectionFilter(context.Background(), &ethpb.PeerConnectionFilter{AllowList: []string{"invalid"}})
+	assert.ErrorContains(t, "Could not set peer connection filter: invalid CIDR address", err)
+	res, err = ds.GetPeerConnectionFilter(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"10.0.0.0/8"}, res.AllowList)
+
+	ds = &Server{}
+	_, err = ds.GetPeerConnectionFilter(context.Background(), &empty.Empty{})
+	assert.ErrorContains(t, "Peer connection filter is not available", err)
+}
+
+type mockGossipValidator struct {
+	res pubsub.ValidationResult
+	err error
+	pid peer.ID
+}
+
+func (m *mockGossipValidator) ValidateGossipMessage(_ context.Context, _ string, pid peer.ID, _ []byte) (pubsub.ValidationResult, error) {
+	m.pid = pid
+	return m.res, m.err
+}
+
+func TestDebugServer_ValidateGossipMessage(t *testing.T) {
+	ds := &Server{}
+	_, err := ds.ValidateGossipMessage(context.Background(), &ethpb.GossipMessageValidationRequest{})
+	require.ErrorContains(t, "Gossip validation is not available", err)
+
+	mP2P := mockP2p.NewTestP2P(t)
+	validator := &mockGossipValidator{res: pubsub.ValidationReject, err: errors.New("bad block")}
+	ds = &Server{GossipValidator: validator}
+	res, err := ds.ValidateGossipMessage(context.Background(), &ethpb.GossipMessageValidationRequest{
+		Topic:  "/eth2/abcdef00/beacon_block/ssz_snappy",
+		PeerId: mP2P.PeerID().String(),
+		Data:   []byte{'a'},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ethpb.GossipMessageValidationResponse_REJECT, res.Verdict)
+	assert.Equal(t, "bad block", res.Reason)
+	assert.Equal(t, mP2P.PeerID(), validator.pid)
+
+	validator.res, validator.err = pubsub.ValidationAccept, nil
+	res, err = ds.ValidateGossipMessage(context.Background(), &ethpb.GossipMessageValidationRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, ethpb.GossipMessageValidationResponse_ACCEPT, res.Verdict)
+	assert.Equal(t, "", res.Reason)
+
+	_, err = ds.ValidateGossipMessage(context.Background(), &ethpb.GossipMessageValidationRequest{PeerId: "foo"})
+	require.ErrorContains(t, "Unable to parse provided peer id", err)
+}
@@ -12,8 +12,12 @@ import (
 	golog "github.com/ipfs/go-log/v2"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/reload"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/maintenance"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
@@ -24,14 +28,20 @@ import (
 // providing RPC endpoints for runtime debugging of a node, this server is
 // gated behind the feature flag --enable-debug-rpc-endpoints.
 type Server struct {
-	BeaconDB           db.NoHeadAccessDatabase
-	GenesisTimeFetcher blockchain.TimeFetcher
-	StateGen           *stategen.State
-	HeadFetcher        blockchain.HeadFetcher
-	ForkFetcher        blockchain.ForkFetcher
-	PeerManager        p2p.PeerManager
-	PeersFetcher       p2p.PeersProvider
-	ReplayerBuilder    stategen.ReplayerBuilder
+	BeaconDB            db.NoHeadAccessDatabase
+	GenesisTimeFetcher  blockchain.TimeFetcher
+	StateGen            *stategen.State
+	HeadFetcher         blockchain.HeadFetcher
+	ForkFetcher         blockchain.ForkFetcher
+	PeerManager         p2p.PeerManager
+	PeersFetcher        p2p.PeersProvider
+	GossipStatsProvider p2p.GossipStatsProvider
+	ConnectionFilter    p2p.ConnectionFilterManager
+	ReplayerBuilder     stategen.ReplayerBuilder
+	SyncCommitteePool   synccommittee.Pool
+	ConfigReloader      reload.Reloader
+	Maintenance         *maintenance.Mode
+	GossipValidator     sync.GossipMessageValidator
 }
 
 // SetLoggingLevel of a beacon node according to a request type,
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"google.golang.org/grpc/codes"
@@ -30,6 +32,9 @@ func (ds *Server) GetBeaconState(
 		}
 
 		st, err := ds.ReplayerBuilder.ReplayerForSlot(q.Slot).ReplayBlocks(ctx)
+		if errors.Is(err, stategen.ErrReconstructionBudgetExceeded) {
+			return nil, status.Errorf(codes.DeadlineExceeded, "Could not reconstruct state at slot %d: %v", q.Slot, err)
+		}
 		if err != nil {
 			return nil, status.Error(codes.Internal, fmt.Sprintf("error replaying blocks for state at slot %d: %v", q.Slot, err))
 		}
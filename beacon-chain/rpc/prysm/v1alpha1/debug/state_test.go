@@ -14,6 +14,8 @@ import (
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func addDefaultReplayerBuilder(s *Server, h stategen.HistoryAccessor) {
@@ -105,3 +107,17 @@ func TestServer_GetBeaconState_RequestFutureSlot(t *testing.T) {
 	_, err := ds.GetBeaconState(context.Background(), req)
 	assert.ErrorContains(t, wanted, err)
 }
+
+func TestServer_GetBeaconState_ReconstructionBudgetExceeded(t *testing.T) {
+	ds := &Server{
+		GenesisTimeFetcher: &mock.ChainService{},
+		ReplayerBuilder:    mockstategen.NewMockReplayerBuilder(mockstategen.WithStateError(0, stategen.ErrReconstructionBudgetExceeded)),
+	}
+	req := &pbrpc.BeaconStateRequest{
+		QueryFilter: &pbrpc.BeaconStateRequest_Slot{
+			Slot: 0,
+		},
+	}
+	_, err := ds.GetBeaconState(context.Background(), req)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
@@ -0,0 +1,48 @@
+package debug
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/config/params"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetSyncCommitteeMessagePool returns the number of sync committee messages held in the pool
+// for the requested slot, broken down by sync committee subnet.
+func (ds *Server) GetSyncCommitteeMessagePool(
+	ctx context.Context, req *pbrpc.SyncCommitteeMessagePoolRequest,
+) (*pbrpc.SyncCommitteeMessagePoolResponse, error) {
+	slot := req.Slot
+	if slot == 0 {
+		slot = ds.GenesisTimeFetcher.CurrentSlot()
+	}
+	msgs, err := ds.SyncCommitteePool.SyncCommitteeMessages(slot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve sync committee messages: %v", err)
+	}
+	counts := make([]uint64, params.BeaconConfig().SyncCommitteeSubnetCount)
+	if len(msgs) > 0 {
+		st, err := ds.HeadFetcher.HeadState(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not retrieve head state: %v", err)
+		}
+		subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+		for _, msg := range msgs {
+			indices, err := helpers.CurrentPeriodSyncSubcommitteeIndices(st, msg.ValidatorIndex)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Could not get sync subcommittee indices: %v", err)
+			}
+			for _, idx := range indices {
+				counts[uint64(idx)/subCommitteeSize]++
+			}
+		}
+	}
+	return &pbrpc.SyncCommitteeMessagePoolResponse{
+		Slot:          slot,
+		TotalMessages: uint64(len(msgs)),
+		SubnetCounts:  counts,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestServer_GetSyncCommitteeMessagePool(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	pool := synccommittee.NewPool()
+	for _, idx := range []types.ValidatorIndex{0, 1} {
+		require.NoError(t, pool.SaveSyncCommitteeMessage(&pbrpc.SyncCommitteeMessage{Slot: 1, ValidatorIndex: idx}))
+	}
+	ds := &Server{
+		HeadFetcher:       &mock.ChainService{State: st},
+		SyncCommitteePool: pool,
+	}
+
+	committee, err := st.CurrentSyncCommittee()
+	require.NoError(t, err)
+	subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	wanted := make([]uint64, params.BeaconConfig().SyncCommitteeSubnetCount)
+	for _, idx := range []types.ValidatorIndex{0, 1} {
+		val, err := st.ValidatorAtIndex(idx)
+		require.NoError(t, err)
+		for i, pubKey := range committee.Pubkeys {
+			if bytes.Equal(pubKey, val.PublicKey) {
+				wanted[uint64(i)/subCommitteeSize]++
+			}
+		}
+	}
+
+	res, err := ds.GetSyncCommitteeMessagePool(context.Background(), &pbrpc.SyncCommitteeMessagePoolRequest{Slot: 1})
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(1), res.Slot)
+	assert.Equal(t, uint64(2), res.TotalMessages)
+	assert.DeepEqual(t, wanted, res.SubnetCounts)
+}
+
+func TestServer_GetSyncCommitteeMessagePool_Empty(t *testing.T) {
+	ds := &Server{
+		GenesisTimeFetcher: &mock.ChainService{},
+		SyncCommitteePool:  synccommittee.NewPool(),
+	}
+	res, err := ds.GetSyncCommitteeMessagePool(context.Background(), &pbrpc.SyncCommitteeMessagePoolRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), res.TotalMessages)
+	assert.Equal(t, params.BeaconConfig().SyncCommitteeSubnetCount, uint64(len(res.SubnetCounts)))
+}
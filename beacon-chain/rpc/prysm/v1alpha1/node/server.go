@@ -9,6 +9,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/libp2p/go-libp2p-core/network"
@@ -17,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/maintenance"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/io/logs"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -43,12 +45,14 @@ type Server struct {
 	POWChainInfoFetcher  powchain.ChainInfoFetcher
 	BeaconMonitoringHost string
 	BeaconMonitoringPort int
+	Maintenance          *maintenance.Mode
 }
 
 // GetSyncStatus checks the current network sync status of the node.
 func (ns *Server) GetSyncStatus(_ context.Context, _ *empty.Empty) (*ethpb.SyncStatus, error) {
 	return &ethpb.SyncStatus{
-		Syncing: ns.SyncChecker.Syncing(),
+		// A node in maintenance mode reports itself as syncing so validator clients fail over.
+		Syncing: ns.SyncChecker.Syncing() || ns.Maintenance.Enabled(),
 	}, nil
 }
 
@@ -123,6 +127,29 @@ func (ns *Server) GetHost(_ context.Context, _ *empty.Empty) (*ethpb.HostData, e
 	}, nil
 }
 
+// GetENR returns the signed node record currently advertised by the local peer.
+func (ns *Server) GetENR(_ context.Context, _ *empty.Empty) (*ethpb.NodeRecord, error) {
+	record := ns.PeerManager.ENR()
+	if record == nil {
+		return nil, status.Error(codes.Unavailable, "Node record is not available, discovery may be disabled")
+	}
+	n, err := enode.New(enode.ValidSchemes, record)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not decode node record: %v", err)
+	}
+	res := &ethpb.NodeRecord{
+		Enr:     n.String(),
+		Seq:     n.Seq(),
+		PeerId:  ns.PeerManager.PeerID().String(),
+		TcpPort: uint32(n.TCP()), // lint:ignore uintcast -- Ports fit in 16 bits.
+		UdpPort: uint32(n.UDP()), // lint:ignore uintcast -- Ports fit in 16 bits.
+	}
+	if n.IP() != nil {
+		res.Ip = n.IP().String()
+	}
+	return res, nil
+}
+
 // GetPeer returns the data known about the peer defined by the provided peer id.
 func (ns *Server) GetPeer(_ context.Context, peerReq *ethpb.PeerRequest) (*ethpb.Peer, error) {
 	pid, err := peer.Decode(peerReq.PeerId)
@@ -3,16 +3,19 @@ package node
 import (
 	"context"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	mockP2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/maintenance"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/testutil"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
@@ -39,6 +42,14 @@ func TestNodeServer_GetSyncStatus(t *testing.T) {
 	res, err = ns.GetSyncStatus(context.Background(), &emptypb.Empty{})
 	require.NoError(t, err)
 	assert.Equal(t, true, res.Syncing)
+
+	// A node in maintenance mode reports itself as syncing.
+	ns.SyncChecker = &mockSync.Sync{IsSyncing: false}
+	ns.Maintenance = &maintenance.Mode{}
+	ns.Maintenance.Set(true)
+	res, err = ns.GetSyncStatus(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, true, res.Syncing)
 }
 
 func TestNodeServer_GetGenesis(t *testing.T) {
@@ -117,6 +128,32 @@ func TestNodeServer_GetHost(t *testing.T) {
 	assert.Equal(t, stringENR, h.Enr)
 }
 
+func TestNodeServer_GetENR(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	lNode := enode.NewLocalNode(db, key)
+	lNode.Set(enr.IP(net.ParseIP("192.0.2.1")))
+	lNode.Set(enr.TCP(13000))
+	lNode.Set(enr.UDP(12000))
+	want := lNode.Node()
+	ns := &Server{
+		PeerManager: &mockP2p.MockPeerManager{Enr: want.Record(), PID: "peer"},
+	}
+	r, err := ns.GetENR(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, want.String(), r.Enr)
+	assert.Equal(t, want.Seq(), r.Seq)
+	assert.Equal(t, "192.0.2.1", r.Ip)
+	assert.Equal(t, uint32(13000), r.TcpPort)
+	assert.Equal(t, uint32(12000), r.UdpPort)
+
+	ns.PeerManager = &mockP2p.MockPeerManager{}
+	_, err = ns.GetENR(context.Background(), &emptypb.Empty{})
+	assert.ErrorContains(t, "Node record is not available", err)
+}
+
 func TestNodeServer_GetPeer(t *testing.T) {
 	server := grpc.NewServer()
 	peersProvider := &mockP2p.MockPeersProvider{}
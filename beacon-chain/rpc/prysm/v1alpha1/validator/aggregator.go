@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
@@ -27,6 +28,43 @@ func (vs *Server) SubmitAggregateSelectionProof(ctx context.Context, req *ethpb.
 	if vs.SyncChecker.Syncing() {
 		return nil, status.Errorf(codes.Unavailable, "Syncing to latest head, not ready to respond")
 	}
+	if err := vs.headStalenessStatus(); err != nil {
+		return nil, err
+	}
+
+	// An optimistic validator MUST NOT participate in attestation. (i.e., sign across the DOMAIN_BEACON_ATTESTER, DOMAIN_SELECTION_PROOF or DOMAIN_AGGREGATE_AND_PROOF domains).
+	if err := vs.optimisticStatus(ctx); err != nil {
+		return nil, err
+	}
+
+	st, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not determine head state: %v", err)
+	}
+
+	a, err := vs.aggregateAndProof(ctx, st, req)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.AggregateSelectionResponse{AggregateAndProof: a}, nil
+}
+
+// SubmitAggregateSelectionProofBatch is called by a validator client at the aggregation deadline with
+// the selection proofs of all its validators attesting in a slot. The beacon node determines which of
+// them are aggregators and responds with the aggregate and proof object to sign over for each of them.
+// Validators which are not aggregators, or whose committee has no attestations in the pool, are omitted
+// from the response.
+func (vs *Server) SubmitAggregateSelectionProofBatch(ctx context.Context, req *ethpb.AggregateSelectionBatchRequest) (*ethpb.AggregateSelectionBatchResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "AggregatorServer.SubmitAggregateSelectionProofBatch")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("numRequests", int64(len(req.Requests))))
+
+	if vs.SyncChecker.Syncing() {
+		return nil, status.Errorf(codes.Unavailable, "Syncing to latest head, not ready to respond")
+	}
+	if err := vs.headStalenessStatus(); err != nil {
+		return nil, err
+	}
 
 	// An optimistic validator MUST NOT participate in attestation. (i.e., sign across the DOMAIN_BEACON_ATTESTER, DOMAIN_SELECTION_PROOF or DOMAIN_AGGREGATE_AND_PROOF domains).
 	if err := vs.optimisticStatus(ctx); err != nil {
@@ -38,6 +76,23 @@ func (vs *Server) SubmitAggregateSelectionProof(ctx context.Context, req *ethpb.
 		return nil, status.Errorf(codes.Internal, "Could not determine head state: %v", err)
 	}
 
+	aggregates := make([]*ethpb.AggregateAttestationAndProof, 0)
+	for _, r := range req.Requests {
+		a, err := vs.aggregateAndProof(ctx, st, r)
+		if err != nil {
+			if s, ok := status.FromError(err); ok && (s.Code() == codes.InvalidArgument || s.Code() == codes.NotFound) {
+				continue
+			}
+			return nil, err
+		}
+		aggregates = append(aggregates, a)
+	}
+	return &ethpb.AggregateSelectionBatchResponse{AggregatesAndProofs: aggregates}, nil
+}
+
+// aggregateAndProof verifies that the requesting validator is an aggregator for its committee and
+// builds the best aggregate and proof object available in the attestation pool for it.
+func (vs *Server) aggregateAndProof(ctx context.Context, st state.ReadOnlyBeaconState, req *ethpb.AggregateSelectionRequest) (*ethpb.AggregateAttestationAndProof, error) {
 	validatorIndex, exists := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(req.PublicKey))
 	if !exists {
 		return nil, status.Error(codes.Internal, "Could not locate validator index in DB")
@@ -105,12 +160,11 @@ func (vs *Server) SubmitAggregateSelectionProof(ctx context.Context, req *ethpb.
 			best = aggregatedAtt
 		}
 	}
-	a := &ethpb.AggregateAttestationAndProof{
+	return &ethpb.AggregateAttestationAndProof{
 		Aggregate:       best,
 		SelectionProof:  req.SlotSignature,
 		AggregatorIndex: validatorIndex,
-	}
-	return &ethpb.AggregateSelectionResponse{AggregateAndProof: a}, nil
+	}, nil
 }
 
 // SubmitSignedAggregateSelectionProof is called by a validator to broadcast a signed
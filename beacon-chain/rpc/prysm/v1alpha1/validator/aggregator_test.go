@@ -17,6 +17,7 @@ import (
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/crypto/bls"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -397,6 +398,89 @@ func TestSubmitAggregateAndProof_SelectsMostBitsWhenOwnAttestationNotPresent(t *
 	assert.DeepSSZEqual(t, att1, res.AggregateAndProof.Aggregate, "Did not receive wanted attestation")
 }
 
+func TestSubmitAggregateSelectionProofBatch_Syncing(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := util.NewBeaconState()
+	require.NoError(t, err)
+
+	aggregatorServer := &Server{
+		HeadFetcher: &mock.ChainService{State: s},
+		SyncChecker: &mockSync.Sync{IsSyncing: true},
+	}
+
+	req := &ethpb.AggregateSelectionBatchRequest{Requests: []*ethpb.AggregateSelectionRequest{{CommitteeIndex: 1}}}
+	_, err = aggregatorServer.SubmitAggregateSelectionProofBatch(ctx, req)
+	assert.ErrorContains(t, "Syncing to latest head", err)
+}
+
+func TestSubmitAggregateSelectionProofBatch_SkipsCommitteesWithoutAtts(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	c := params.MinimalSpecConfig().Copy()
+	c.TargetAggregatorsPerCommittee = 16
+	params.OverrideBeaconConfig(c)
+
+	ctx := context.Background()
+
+	beaconState, privKeys := util.DeterministicGenesisState(t, 32)
+	att0, err := generateAtt(beaconState, 0, privKeys)
+	require.NoError(t, err)
+	err = beaconState.SetSlot(beaconState.Slot() + params.BeaconConfig().MinAttestationInclusionDelay)
+	require.NoError(t, err)
+
+	aggregatorServer := &Server{
+		HeadFetcher: &mock.ChainService{State: beaconState},
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+		AttPool:     attestations.NewPool(),
+		P2P:         &mockp2p.MockBroadcaster{},
+		TimeFetcher: &mock.ChainService{Genesis: time.Now()},
+	}
+	require.NoError(t, aggregatorServer.AttPool.SaveAggregatedAttestation(att0))
+
+	priv, err := bls.RandKey()
+	require.NoError(t, err)
+	sig := priv.Sign([]byte{'B'})
+	v1, err := beaconState.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	v2, err := beaconState.ValidatorAtIndex(2)
+	require.NoError(t, err)
+	req := &ethpb.AggregateSelectionBatchRequest{
+		Requests: []*ethpb.AggregateSelectionRequest{
+			{CommitteeIndex: 1, SlotSignature: sig.Marshal(), PublicKey: v1.PublicKey},
+			// No attestations were seen for this committee, so no aggregate can be built.
+			{CommitteeIndex: 2, SlotSignature: sig.Marshal(), PublicKey: v2.PublicKey},
+		},
+	}
+	res, err := aggregatorServer.SubmitAggregateSelectionProofBatch(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.AggregatesAndProofs))
+	assert.Equal(t, types.ValidatorIndex(1), res.AggregatesAndProofs[0].AggregatorIndex)
+	assert.DeepEqual(t, sig.Marshal(), res.AggregatesAndProofs[0].SelectionProof)
+	assert.DeepEqual(t, att0, res.AggregatesAndProofs[0].Aggregate)
+}
+
+func TestSubmitAggregateSelectionProofBatch_UnknownValidator(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := util.NewBeaconState()
+	require.NoError(t, err)
+
+	aggregatorServer := &Server{
+		HeadFetcher: &mock.ChainService{State: s},
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+		TimeFetcher: &mock.ChainService{Genesis: time.Now()},
+	}
+
+	priv, err := bls.RandKey()
+	require.NoError(t, err)
+	sig := priv.Sign([]byte{'B'})
+	req := &ethpb.AggregateSelectionBatchRequest{
+		Requests: []*ethpb.AggregateSelectionRequest{{CommitteeIndex: 1, SlotSignature: sig.Marshal(), PublicKey: pubKey(3)}},
+	}
+	_, err = aggregatorServer.SubmitAggregateSelectionProofBatch(ctx, req)
+	require.ErrorContains(t, "Could not locate validator index in DB", err)
+}
+
 func TestSubmitSignedAggregateSelectionProof_ZeroHashesSignatures(t *testing.T) {
 	aggregatorServer := &Server{}
 	req := &ethpb.SignedAggregateSubmitRequest{
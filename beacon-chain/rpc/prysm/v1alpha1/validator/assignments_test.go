@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
 	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
@@ -470,6 +471,21 @@ func TestGetDuties_SyncNotReady(t *testing.T) {
 	assert.ErrorContains(t, "Syncing to latest head", err)
 }
 
+func TestGetDuties_HeadStale(t *testing.T) {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	genesis := time.Now().Add(-10 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	chain := &mockChain.ChainService{State: st, Genesis: genesis}
+	vs := &Server{
+		SyncChecker:      &mockSync.Sync{IsSyncing: false},
+		HeadFetcher:      chain,
+		TimeFetcher:      chain,
+		MaxHeadStaleness: 2,
+	}
+	_, err = vs.GetDuties(context.Background(), &ethpb.DutiesRequest{})
+	assert.ErrorContains(t, grpcutil.StaleHeadErrorMessage, err)
+}
+
 func TestStreamDuties_SyncNotReady(t *testing.T) {
 	vs := &Server{
 		SyncChecker: &mockSync.Sync{IsSyncing: true},
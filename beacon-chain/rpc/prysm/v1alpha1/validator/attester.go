@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
@@ -36,6 +37,9 @@ func (vs *Server) GetAttestationData(ctx context.Context, req *ethpb.Attestation
 	if vs.SyncChecker.Syncing() {
 		return nil, status.Errorf(codes.Unavailable, "Syncing to latest head, not ready to respond")
 	}
+	if err := vs.headStalenessStatus(); err != nil {
+		return nil, err
+	}
 
 	// An optimistic validator MUST NOT participate in attestation. (i.e., sign across the DOMAIN_BEACON_ATTESTER, DOMAIN_SELECTION_PROOF or DOMAIN_AGGREGATE_AND_PROOF domains).
 	if err := vs.optimisticStatus(ctx); err != nil {
@@ -47,9 +51,9 @@ func (vs *Server) GetAttestationData(ctx context.Context, req *ethpb.Attestation
 		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid request: %v", err))
 	}
 
-	res, err := vs.AttestationCache.Get(ctx, req)
+	res, cachedHeadRoot, err := vs.cachedAttestationData(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not retrieve data from attestation cache: %v", err)
+		return nil, err
 	}
 	if res != nil {
 		res.CommitteeIndex = req.CommitteeIndex
@@ -58,9 +62,9 @@ func (vs *Server) GetAttestationData(ctx context.Context, req *ethpb.Attestation
 
 	if err := vs.AttestationCache.MarkInProgress(req); err != nil {
 		if errors.Is(err, cache.ErrAlreadyInProgress) {
-			res, err := vs.AttestationCache.Get(ctx, req)
+			res, _, err := vs.cachedAttestationData(ctx, req)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "Could not retrieve data from attestation cache: %v", err)
+				return nil, err
 			}
 			if res == nil {
 				return nil, status.Error(codes.DataLoss, "A request was in progress and resolved to nil")
@@ -84,6 +88,8 @@ func (vs *Server) GetAttestationData(ctx context.Context, req *ethpb.Attestation
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not retrieve head root: %v", err)
 	}
+	// The head may have changed since the cache lookup. The data is cached under the head it is computed from.
+	cachedHeadRoot = bytesutil.ToBytes32(headRoot)
 
 	// In the case that we receive an attestation request after a newer state/block has been processed.
 	if headState.Slot() > req.Slot {
@@ -136,12 +142,45 @@ func (vs *Server) GetAttestationData(ctx context.Context, req *ethpb.Attestation
 		},
 	}
 
-	if err := vs.AttestationCache.Put(ctx, req, res); err != nil {
+	if err := vs.AttestationCache.Put(ctx, req, cachedHeadRoot, res); err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not store attestation data in cache: %v", err)
 	}
 	return res, nil
 }
 
+// cachedAttestationData returns the attestation data cached for the current head, if any, along
+// with the head root. Cached data is only served while the head it was computed from is still the
+// head. The lookup waits for any request of the slot in progress, which caches its data under the
+// head it computed it from. As the head may have changed meanwhile, such as when a block arrives
+// late, the head root is read again after waiting.
+func (vs *Server) cachedAttestationData(ctx context.Context, req *ethpb.AttestationDataRequest) (*ethpb.AttestationData, [32]byte, error) {
+	root, err := vs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, [32]byte{}, status.Errorf(codes.Internal, "Could not retrieve head root: %v", err)
+	}
+	headRoot := bytesutil.ToBytes32(root)
+	res, err := vs.AttestationCache.Get(ctx, req, headRoot)
+	if err != nil {
+		return nil, [32]byte{}, status.Errorf(codes.Internal, "Could not retrieve data from attestation cache: %v", err)
+	}
+	if res != nil {
+		return res, headRoot, nil
+	}
+	root, err = vs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, [32]byte{}, status.Errorf(codes.Internal, "Could not retrieve head root: %v", err)
+	}
+	if bytesutil.ToBytes32(root) == headRoot {
+		return nil, headRoot, nil
+	}
+	headRoot = bytesutil.ToBytes32(root)
+	res, err = vs.AttestationCache.Get(ctx, req, headRoot)
+	if err != nil {
+		return nil, [32]byte{}, status.Errorf(codes.Internal, "Could not retrieve data from attestation cache: %v", err)
+	}
+	return res, headRoot, nil
+}
+
 // ProposeAttestation is a function called by an attester to vote
 // on a block via an attestation object as defined in the Ethereum Serenity specification.
 func (vs *Server) ProposeAttestation(ctx context.Context, att *ethpb.Attestation) (*ethpb.AttestResponse, error) {
@@ -193,6 +232,34 @@ func (vs *Server) ProposeAttestation(ctx context.Context, att *ethpb.Attestation
 	}, nil
 }
 
+// ProposeAttestations receives a stream of attestations from a validator client attesting with
+// many keys. Each attestation is processed as in ProposeAttestation as soon as it is received, and
+// the attestation data roots are sent back once the client closes the stream.
+func (vs *Server) ProposeAttestations(stream ethpb.BeaconNodeValidator_ProposeAttestationsServer) error {
+	ctx, span := trace.StartSpan(stream.Context(), "AttesterServer.ProposeAttestations")
+	defer span.End()
+
+	roots := make([][]byte, 0)
+	for {
+		att, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			span.AddAttributes(trace.Int64Attribute("numAttestations", int64(len(roots))))
+			return stream.SendAndClose(&ethpb.ProposeAttestationsResponse{AttestationDataRoots: roots})
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not receive attestation: %v", err)
+		}
+		if att == nil || att.Data == nil {
+			return status.Errorf(codes.InvalidArgument, "Attestation %d in stream is nil", len(roots))
+		}
+		res, err := vs.ProposeAttestation(ctx, att)
+		if err != nil {
+			return err
+		}
+		roots = append(roots, res.AttestationDataRoot)
+	}
+}
+
 // SubscribeCommitteeSubnets subscribes to the committee ID subnet given subscribe request.
 func (vs *Server) SubscribeCommitteeSubnets(ctx context.Context, req *ethpb.CommitteeSubnetsSubscribeRequest) (*emptypb.Empty, error) {
 	ctx, span := trace.StartSpan(ctx, "AttesterServer.SubscribeCommitteeSubnets")
@@ -2,11 +2,13 @@ package validator
 
 import (
 	"context"
+	"io"
 	"math/rand"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
@@ -21,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
+	mock2 "github.com/prysmaticlabs/prysm/testing/mock"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
@@ -89,6 +92,63 @@ func TestProposeAttestation_IncorrectSignature(t *testing.T) {
 	assert.ErrorContains(t, wanted, err)
 }
 
+func TestProposeAttestations_OK(t *testing.T) {
+	attesterServer := &Server{
+		HeadFetcher:       &mock.ChainService{},
+		P2P:               &mockp2p.MockBroadcaster{},
+		AttestationCache:  cache.NewAttestationCache(),
+		AttPool:           attestations.NewPool(),
+		OperationNotifier: (&mock.ChainService{}).OperationNotifier(),
+	}
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	atts := make([]*ethpb.Attestation, 2)
+	for i := range atts {
+		atts[i] = &ethpb.Attestation{
+			Signature: sk.Sign([]byte("dummy_test_data")).Marshal(),
+			Data: &ethpb.AttestationData{
+				CommitteeIndex:  types.CommitteeIndex(i),
+				BeaconBlockRoot: make([]byte, 32),
+				Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+				Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			},
+		}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	stream := mock2.NewMockBeaconNodeValidator_ProposeAttestationsServer(ctrl)
+	stream.EXPECT().Context().Return(context.Background())
+	gomock.InOrder(
+		stream.EXPECT().Recv().Return(atts[0], nil),
+		stream.EXPECT().Recv().Return(atts[1], nil),
+		stream.EXPECT().Recv().Return(nil, io.EOF),
+	)
+	var resp *ethpb.ProposeAttestationsResponse
+	stream.EXPECT().SendAndClose(gomock.Any()).DoAndReturn(func(r *ethpb.ProposeAttestationsResponse) error {
+		resp = r
+		return nil
+	})
+	require.NoError(t, attesterServer.ProposeAttestations(stream))
+	require.Equal(t, len(atts), len(resp.AttestationDataRoots))
+	for i, att := range atts {
+		root, err := att.Data.HashTreeRoot()
+		require.NoError(t, err)
+		assert.DeepEqual(t, root[:], resp.AttestationDataRoots[i])
+	}
+}
+
+func TestProposeAttestations_NilAttestation(t *testing.T) {
+	attesterServer := &Server{}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	stream := mock2.NewMockBeaconNodeValidator_ProposeAttestationsServer(ctrl)
+	stream.EXPECT().Context().Return(context.Background())
+	stream.EXPECT().Recv().Return(&ethpb.Attestation{}, nil)
+	err := attesterServer.ProposeAttestations(stream)
+	assert.ErrorContains(t, "Attestation 0 in stream is nil", err)
+}
+
 func TestGetAttestationData_OK(t *testing.T) {
 	block := util.NewBeaconBlock()
 	block.Block.Slot = 3*params.BeaconConfig().SlotsPerEpoch + 1
@@ -162,6 +222,44 @@ func TestGetAttestationData_OK(t *testing.T) {
 	}
 }
 
+func TestGetAttestationData_HeadChangeInvalidatesCache(t *testing.T) {
+	slot := 3*params.BeaconConfig().SlotsPerEpoch + 1
+	beaconState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, beaconState.SetSlot(slot))
+	oldHead := bytesutil.PadTo([]byte("old head"), 32)
+	newHead := bytesutil.PadTo([]byte("new head"), 32)
+	headFetcher := &mock.ChainService{State: beaconState, Root: oldHead}
+	offset := int64(slot.Mul(params.BeaconConfig().SecondsPerSlot))
+	attesterServer := &Server{
+		SyncChecker:      &mockSync.Sync{IsSyncing: false},
+		AttestationCache: cache.NewAttestationCache(),
+		HeadFetcher:      headFetcher,
+		TimeFetcher: &mock.ChainService{
+			Genesis: time.Now().Add(time.Duration(-1*offset) * time.Second),
+		},
+	}
+
+	res, err := attesterServer.GetAttestationData(context.Background(), &ethpb.AttestationDataRequest{Slot: slot})
+	require.NoError(t, err)
+	assert.DeepEqual(t, oldHead, res.BeaconBlockRoot)
+
+	// The data of another committee is served from the cache while the head is unchanged.
+	headFetcher.State = nil
+	res, err = attesterServer.GetAttestationData(context.Background(), &ethpb.AttestationDataRequest{Slot: slot, CommitteeIndex: 3})
+	require.NoError(t, err)
+	assert.DeepEqual(t, oldHead, res.BeaconBlockRoot)
+	assert.Equal(t, types.CommitteeIndex(3), res.CommitteeIndex)
+
+	// A new head within the slot invalidates the cached data.
+	headFetcher.State = beaconState
+	headFetcher.Root = newHead
+	res, err = attesterServer.GetAttestationData(context.Background(), &ethpb.AttestationDataRequest{Slot: slot, CommitteeIndex: 3})
+	require.NoError(t, err)
+	assert.DeepEqual(t, newHead, res.BeaconBlockRoot)
+	assert.Equal(t, types.CommitteeIndex(3), res.CommitteeIndex)
+}
+
 func TestGetAttestationData_SyncNotReady(t *testing.T) {
 	as := &Server{
 		SyncChecker: &mockSync.Sync{IsSyncing: true},
@@ -335,7 +433,79 @@ func TestAttestationDataSlot_handlesInProgressRequest(t *testing.T) {
 	go func() {
 		defer wg.Done()
 
-		assert.NoError(t, server.AttestationCache.Put(ctx, req, res))
+		assert.NoError(t, server.AttestationCache.Put(ctx, req, [32]byte{}, res))
+		assert.NoError(t, server.AttestationCache.MarkNotInProgress(req))
+	}()
+
+	wg.Wait()
+}
+
+// changingHeadFetcher is a head fetcher whose head root may change concurrently.
+type changingHeadFetcher struct {
+	*mock.ChainService
+	lock sync.Mutex
+	root []byte
+}
+
+func (c *changingHeadFetcher) HeadRoot(_ context.Context) ([]byte, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.root, nil
+}
+
+func (c *changingHeadFetcher) setHeadRoot(root []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.root = root
+}
+
+func TestAttestationDataSlot_handlesInProgressRequestWithHeadChange(t *testing.T) {
+	s := &ethpb.BeaconState{Slot: 100}
+	state, err := v1.InitializeFromProto(s)
+	require.NoError(t, err)
+	ctx := context.Background()
+	slot := types.Slot(2)
+	offset := int64(slot.Mul(params.BeaconConfig().SecondsPerSlot))
+	oldHead := bytesutil.PadTo([]byte("old head"), 32)
+	newHead := bytesutil.PadTo([]byte("new head"), 32)
+	headFetcher := &changingHeadFetcher{ChainService: &mock.ChainService{State: state}, root: oldHead}
+	server := &Server{
+		HeadFetcher:      headFetcher,
+		AttestationCache: cache.NewAttestationCache(),
+		SyncChecker:      &mockSync.Sync{IsSyncing: false},
+		TimeFetcher:      &mock.ChainService{Genesis: time.Now().Add(time.Duration(-1*offset) * time.Second)},
+	}
+
+	req := &ethpb.AttestationDataRequest{
+		CommitteeIndex: 1,
+		Slot:           slot,
+	}
+	res := &ethpb.AttestationData{
+		CommitteeIndex:  1,
+		BeaconBlockRoot: newHead,
+		Target:          &ethpb.Checkpoint{Epoch: 55, Root: make([]byte, 32)},
+	}
+
+	require.NoError(t, server.AttestationCache.MarkInProgress(req))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		response, err := server.GetAttestationData(ctx, req)
+		require.NoError(t, err)
+		if !proto.Equal(res, response) {
+			t.Error("Expected the response of the request in progress")
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// The head changes while the request is in progress, which caches its data under the new head.
+		time.Sleep(100 * time.Millisecond)
+		headFetcher.setHeadRoot(newHead)
+		assert.NoError(t, server.AttestationCache.Put(ctx, req, bytesutil.ToBytes32(newHead), res))
 		assert.NoError(t, server.AttestationCache.MarkNotInProgress(req))
 	}()
 
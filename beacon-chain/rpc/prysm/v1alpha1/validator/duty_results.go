@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SubmitDutyResults receives the outcomes of the duties performed by a validator client and
+// notifies them on the operation feed, for the validator monitor and the events API.
+func (vs *Server) SubmitDutyResults(_ context.Context, req *ethpb.DutyResultsRequest) (*emptypb.Empty, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "nil request")
+	}
+	for i, r := range req.Results {
+		if r == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "nil duty result at index %d", i)
+		}
+		if len(r.PublicKey) != fieldparams.BLSPubkeyLength {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid public key length of duty result at index %d", i)
+		}
+		if _, ok := ethpb.DutyResult_Duty_name[int32(r.Duty)]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown duty %d of duty result at index %d", r.Duty, i)
+		}
+		if _, ok := ethpb.DutyResult_Outcome_name[int32(r.Outcome)]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown outcome %d of duty result at index %d", r.Outcome, i)
+		}
+	}
+	if len(req.Results) == 0 {
+		return &emptypb.Empty{}, nil
+	}
+
+	vs.OperationNotifier.OperationFeed().Send(&feed.Event{
+		Type: opfeed.DutyResultsReceived,
+		Data: &opfeed.DutyResultsReceivedData{
+			Results: req.Results,
+		},
+	})
+	return &emptypb.Empty{}, nil
+}
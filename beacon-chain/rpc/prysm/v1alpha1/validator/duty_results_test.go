@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestSubmitDutyResults_Notification(t *testing.T) {
+	server := &Server{
+		OperationNotifier: (&mockChain.ChainService{}).OperationNotifier(),
+	}
+	opChannel := make(chan *feed.Event, 1)
+	opSub := server.OperationNotifier.OperationFeed().Subscribe(opChannel)
+	defer opSub.Unsubscribe()
+
+	req := &ethpb.DutyResultsRequest{
+		Results: []*ethpb.DutyResult{
+			{
+				PublicKey:      make([]byte, fieldparams.BLSPubkeyLength),
+				ValidatorIndex: 3,
+				Slot:           5,
+				Duty:           ethpb.DutyResult_ATTESTATION,
+				Outcome:        ethpb.DutyResult_SUBMITTED,
+			},
+			{
+				PublicKey:      make([]byte, fieldparams.BLSPubkeyLength),
+				ValidatorIndex: 4,
+				Slot:           5,
+				Duty:           ethpb.DutyResult_PROPOSAL,
+				Outcome:        ethpb.DutyResult_SKIPPED,
+				Reason:         "could not sign randao reveal",
+			},
+		},
+	}
+	_, err := server.SubmitDutyResults(context.Background(), req)
+	require.NoError(t, err)
+
+	event := <-opChannel
+	require.Equal(t, feed.EventType(opfeed.DutyResultsReceived), event.Type)
+	data, ok := event.Data.(*opfeed.DutyResultsReceivedData)
+	require.Equal(t, true, ok, "Entity is of the wrong type")
+	assert.DeepEqual(t, req.Results, data.Results)
+}
+
+func TestSubmitDutyResults_InvalidResults(t *testing.T) {
+	server := &Server{
+		OperationNotifier: (&mockChain.ChainService{}).OperationNotifier(),
+	}
+
+	_, err := server.SubmitDutyResults(context.Background(), nil)
+	assert.ErrorContains(t, "nil request", err)
+
+	_, err = server.SubmitDutyResults(context.Background(), &ethpb.DutyResultsRequest{
+		Results: []*ethpb.DutyResult{nil},
+	})
+	assert.ErrorContains(t, "nil duty result at index 0", err)
+
+	_, err = server.SubmitDutyResults(context.Background(), &ethpb.DutyResultsRequest{
+		Results: []*ethpb.DutyResult{{PublicKey: []byte{1}}},
+	})
+	assert.ErrorContains(t, "invalid public key length", err)
+
+	_, err = server.SubmitDutyResults(context.Background(), &ethpb.DutyResultsRequest{
+		Results: []*ethpb.DutyResult{{PublicKey: make([]byte, fieldparams.BLSPubkeyLength), Duty: 42}},
+	})
+	assert.ErrorContains(t, "unknown duty 42", err)
+
+	_, err = server.SubmitDutyResults(context.Background(), &ethpb.DutyResultsRequest{
+		Results: []*ethpb.DutyResult{{PublicKey: make([]byte, fieldparams.BLSPubkeyLength), Outcome: 42}},
+	})
+	assert.ErrorContains(t, "unknown outcome 42", err)
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/prysmaticlabs/prysm/crypto/bls"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
-	synccontribution "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation/aggregation/sync_contribution"
 	"go.opencensus.io/trace"
 )
 
@@ -75,19 +74,12 @@ func (vs *Server) getAltairBeaconBlock(ctx context.Context, req *ethpb.BlockRequ
 	return blk, nil
 }
 
-// getSyncAggregate retrieves the sync contributions from the pool to construct the sync aggregate object.
-// The contributions are filtered based on matching of the input root and slot then profitability.
+// getSyncAggregate retrieves the best sync contribution of every subcommittee from the pool to construct
+// the sync aggregate object. The contributions have to match the input root and slot.
 func (vs *Server) getSyncAggregate(ctx context.Context, slot types.Slot, root [32]byte) (*ethpb.SyncAggregate, error) {
 	_, span := trace.StartSpan(ctx, "ProposerServer.getSyncAggregate")
 	defer span.End()
 
-	// Contributions have to match the input root
-	contributions, err := vs.SyncCommitteePool.SyncCommitteeContributions(slot)
-	if err != nil {
-		return nil, err
-	}
-	proposerContributions := proposerSyncContributions(contributions).filterByBlockRoot(root)
-
 	// Each sync subcommittee is 128 bits and the sync committee is 512 bits for mainnet.
 	var bitsHolder [][]byte
 	for i := uint64(0); i < params.BeaconConfig().SyncCommitteeSubnetCount; i++ {
@@ -96,18 +88,11 @@ func (vs *Server) getSyncAggregate(ctx context.Context, slot types.Slot, root [3
 	sigsHolder := make([]bls.Signature, 0, params.BeaconConfig().SyncCommitteeSize/params.BeaconConfig().SyncCommitteeSubnetCount)
 
 	for i := uint64(0); i < params.BeaconConfig().SyncCommitteeSubnetCount; i++ {
-		cs := proposerContributions.filterBySubIndex(i)
-		aggregates, err := synccontribution.Aggregate(cs)
-		if err != nil {
-			return nil, err
-		}
-
 		// Retrieve the most profitable contribution
-		deduped, err := proposerSyncContributions(aggregates).dedup()
+		c, err := vs.SyncCommitteePool.BestSyncCommitteeContribution(slot, i, root)
 		if err != nil {
 			return nil, err
 		}
-		c := deduped.mostProfitable()
 		if c == nil {
 			continue
 		}
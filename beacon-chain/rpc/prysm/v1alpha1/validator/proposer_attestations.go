@@ -2,19 +2,15 @@ package validator
 
 import (
 	"context"
-	"sort"
 
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/config/params"
-	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
-	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation/aggregation"
-	attaggregation "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation/aggregation/attestations"
 	"github.com/prysmaticlabs/prysm/runtime/version"
 	"go.opencensus.io/trace"
 )
@@ -48,33 +44,8 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 		return nil, err
 	}
 
-	attsByDataRoot := make(map[[32]byte][]*ethpb.Attestation, len(atts))
-	for _, att := range atts {
-		attDataRoot, err := att.Data.HashTreeRoot()
-		if err != nil {
-			return nil, err
-		}
-		attsByDataRoot[attDataRoot] = append(attsByDataRoot[attDataRoot], att)
-	}
-
-	attsForInclusion := proposerAtts(make([]*ethpb.Attestation, 0))
-	for _, as := range attsByDataRoot {
-		as, err := attaggregation.Aggregate(as)
-		if err != nil {
-			return nil, err
-		}
-		attsForInclusion = append(attsForInclusion, as...)
-	}
-	deduped, err := attsForInclusion.dedup()
-	if err != nil {
-		return nil, err
-	}
-	sorted, err := deduped.sortByProfitability()
-	if err != nil {
-		return nil, err
-	}
-	atts = sorted.limitToMaxAttestations()
-	return atts, nil
+	// Aggregate attestations and select the ones covering the most attesters not covered yet.
+	return attestations.PackAttestations(atts, params.BeaconConfig().MaxAttestations)
 }
 
 // filter separates attestation list into two groups: valid and invalid attestations.
@@ -111,89 +82,6 @@ func (a proposerAtts) filter(ctx context.Context, st state.BeaconState) (propose
 	return validAtts, invalidAtts
 }
 
-// sortByProfitability orders attestations by highest slot and by highest aggregation bit count.
-func (a proposerAtts) sortByProfitability() (proposerAtts, error) {
-	if len(a) < 2 {
-		return a, nil
-	}
-	return a.sortByProfitabilityUsingMaxCover()
-}
-
-// sortByProfitabilityUsingMaxCover orders attestations by highest slot and by highest aggregation bit count.
-// Duplicate bits are counted only once, using max-cover algorithm.
-func (a proposerAtts) sortByProfitabilityUsingMaxCover() (proposerAtts, error) {
-	// Separate attestations by slot, as slot number takes higher precedence when sorting.
-	var slots []types.Slot
-	attsBySlot := map[types.Slot]proposerAtts{}
-	for _, att := range a {
-		if _, ok := attsBySlot[att.Data.Slot]; !ok {
-			slots = append(slots, att.Data.Slot)
-		}
-		attsBySlot[att.Data.Slot] = append(attsBySlot[att.Data.Slot], att)
-	}
-
-	selectAtts := func(atts proposerAtts) (proposerAtts, error) {
-		if len(atts) < 2 {
-			return atts, nil
-		}
-		candidates := make([]*bitfield.Bitlist64, len(atts))
-		for i := 0; i < len(atts); i++ {
-			var err error
-			candidates[i], err = atts[i].AggregationBits.ToBitlist64()
-			if err != nil {
-				return nil, err
-			}
-		}
-		// Add selected candidates on top, those that are not selected - append at bottom.
-		selectedKeys, _, err := aggregation.MaxCover(candidates, len(candidates), true /* allowOverlaps */)
-		if err == nil {
-			// Pick selected attestations first, leftover attestations will be appended at the end.
-			// Both lists will be sorted by number of bits set.
-			selectedAtts := make(proposerAtts, selectedKeys.Count())
-			leftoverAtts := make(proposerAtts, selectedKeys.Not().Count())
-			for i, key := range selectedKeys.BitIndices() {
-				selectedAtts[i] = atts[key]
-			}
-			for i, key := range selectedKeys.Not().BitIndices() {
-				leftoverAtts[i] = atts[key]
-			}
-			sort.Slice(selectedAtts, func(i, j int) bool {
-				return selectedAtts[i].AggregationBits.Count() > selectedAtts[j].AggregationBits.Count()
-			})
-			sort.Slice(leftoverAtts, func(i, j int) bool {
-				return leftoverAtts[i].AggregationBits.Count() > leftoverAtts[j].AggregationBits.Count()
-			})
-			return append(selectedAtts, leftoverAtts...), nil
-		}
-		return atts, nil
-	}
-
-	// Select attestations. Slots are sorted from higher to lower at this point. Within slots attestations
-	// are sorted to maximize profitability (greedily selected, with previous attestations' bits
-	// evaluated before including any new attestation).
-	var sortedAtts proposerAtts
-	sort.Slice(slots, func(i, j int) bool {
-		return slots[i] > slots[j]
-	})
-	for _, slot := range slots {
-		selected, err := selectAtts(attsBySlot[slot])
-		if err != nil {
-			return nil, err
-		}
-		sortedAtts = append(sortedAtts, selected...)
-	}
-
-	return sortedAtts, nil
-}
-
-// limitToMaxAttestations limits attestations to maximum attestations per block.
-func (a proposerAtts) limitToMaxAttestations() proposerAtts {
-	if uint64(len(a)) > params.BeaconConfig().MaxAttestations {
-		return a[:params.BeaconConfig().MaxAttestations]
-	}
-	return a
-}
-
 // dedup removes duplicate attestations (ones with the same bits set on).
 // Important: not only exact duplicates are removed, but proper subsets are removed too
 // (their known bits are redundant and are already contained in their supersets).
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/sirupsen/logrus"
+)
+
+// includedOperationsLookbackEpochs is how many epochs before the proposal slot are searched for canonical
+// blocks which already include a pool operation.
+const includedOperationsLookbackEpochs = 32
+
+// Operation types used as label of the skipped operations metric.
+const (
+	proposerSlashingOperation = "proposer_slashing"
+	attesterSlashingOperation = "attester_slashing"
+	voluntaryExitOperation    = "voluntary_exit"
+)
+
+// skippedIncludedOperations tracks the pool operations which were not packed into a proposal because a
+// canonical block already includes them.
+var skippedIncludedOperations = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "proposer_skipped_included_operations_total",
+	Help: "The number of pool operations not packed into a proposal because a canonical block already includes them.",
+}, []string{"type"})
+
+type hashableOperation interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+// operationAlreadyIncluded returns true if a canonical block of the recent epochs before the proposal slot
+// includes the operation, in which case it would only waste block space.
+func (vs *Server) operationAlreadyIncluded(ctx context.Context, op hashableOperation, opType string, slot types.Slot) bool {
+	if vs.BeaconDB == nil || vs.CanonicalFetcher == nil {
+		return false
+	}
+	root, err := op.HashTreeRoot()
+	if err != nil {
+		return false
+	}
+	var since types.Slot
+	lookback := params.BeaconConfig().SlotsPerEpoch.Mul(includedOperationsLookbackEpochs)
+	if slot > lookback {
+		since = slot - lookback
+	}
+	blockRoots, err := vs.BeaconDB.BlockRootsIncludingOperation(ctx, root, since)
+	if err != nil {
+		log.WithError(err).Debug("Could not look up blocks including operation")
+		return false
+	}
+	for _, r := range blockRoots {
+		canonical, err := vs.CanonicalFetcher.IsCanonical(ctx, r)
+		if err != nil || !canonical {
+			continue
+		}
+		skippedIncludedOperations.WithLabelValues(opType).Inc()
+		log.WithFields(logrus.Fields{
+			"type":      opType,
+			"root":      fmt.Sprintf("%#x", root),
+			"blockRoot": fmt.Sprintf("%#x", r),
+		}).Debug("Proposer: skipping operation already included on chain")
+		return true
+	}
+	return false
+}
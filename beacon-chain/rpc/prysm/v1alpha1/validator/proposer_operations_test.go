@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestServer_OperationAlreadyIncluded(t *testing.T) {
+	ctx := context.Background()
+	db := dbutil.SetupDB(t)
+
+	included := &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 1}, Signature: make([]byte, 96)}
+	orphaned := &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}, Signature: make([]byte, 96)}
+	pending := &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 3}, Signature: make([]byte, 96)}
+
+	canonical := util.NewBeaconBlock()
+	canonical.Block.Slot = 10
+	canonical.Block.Body.VoluntaryExits = []*ethpb.SignedVoluntaryExit{included}
+	util.SaveBlock(t, ctx, db, canonical)
+	canonicalRoot, err := canonical.Block.HashTreeRoot()
+	require.NoError(t, err)
+	fork := util.NewBeaconBlock()
+	fork.Block.Slot = 11
+	fork.Block.Body.VoluntaryExits = []*ethpb.SignedVoluntaryExit{orphaned}
+	util.SaveBlock(t, ctx, db, fork)
+
+	vs := &Server{
+		BeaconDB:         db,
+		CanonicalFetcher: &mockChain.ChainService{CanonicalRoots: map[[32]byte]bool{canonicalRoot: true}},
+	}
+	assert.Equal(t, true, vs.operationAlreadyIncluded(ctx, included, voluntaryExitOperation, 12))
+	assert.Equal(t, false, vs.operationAlreadyIncluded(ctx, orphaned, voluntaryExitOperation, 12))
+	assert.Equal(t, false, vs.operationAlreadyIncluded(ctx, pending, voluntaryExitOperation, 12))
+
+	// Inclusions older than the lookback window are not considered.
+	late := 10 + params.BeaconConfig().SlotsPerEpoch.Mul(includedOperationsLookbackEpochs) + 1
+	assert.Equal(t, false, vs.operationAlreadyIncluded(ctx, included, voluntaryExitOperation, late))
+}
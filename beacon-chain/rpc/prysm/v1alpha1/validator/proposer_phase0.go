@@ -123,6 +123,9 @@ func (vs *Server) buildPhase0BlockData(ctx context.Context, req *ethpb.BlockRequ
 	proposerSlashings := vs.SlashingsPool.PendingProposerSlashings(ctx, head, false /*noLimit*/)
 	validProposerSlashings := make([]*ethpb.ProposerSlashing, 0, len(proposerSlashings))
 	for _, slashing := range proposerSlashings {
+		if vs.operationAlreadyIncluded(ctx, slashing, proposerSlashingOperation, req.Slot) {
+			continue
+		}
 		_, err := blocks.ProcessProposerSlashing(ctx, head, slashing, v.SlashValidator)
 		if err != nil {
 			log.WithError(err).Warn("Proposer: invalid proposer slashing")
@@ -134,6 +137,9 @@ func (vs *Server) buildPhase0BlockData(ctx context.Context, req *ethpb.BlockRequ
 	attSlashings := vs.SlashingsPool.PendingAttesterSlashings(ctx, head, false /*noLimit*/)
 	validAttSlashings := make([]*ethpb.AttesterSlashing, 0, len(attSlashings))
 	for _, slashing := range attSlashings {
+		if vs.operationAlreadyIncluded(ctx, slashing, attesterSlashingOperation, req.Slot) {
+			continue
+		}
 		_, err := blocks.ProcessAttesterSlashing(ctx, head, slashing, v.SlashValidator)
 		if err != nil {
 			log.WithError(err).Warn("Proposer: invalid attester slashing")
@@ -144,6 +150,9 @@ func (vs *Server) buildPhase0BlockData(ctx context.Context, req *ethpb.BlockRequ
 	exits := vs.ExitPool.PendingExits(head, req.Slot, false /*noLimit*/)
 	validExits := make([]*ethpb.SignedVoluntaryExit, 0, len(exits))
 	for _, exit := range exits {
+		if vs.operationAlreadyIncluded(ctx, exit, voluntaryExitOperation, req.Slot) {
+			continue
+		}
 		val, err := head.ValidatorAtIndexReadOnly(exit.Exit.ValidatorIndex)
 		if err != nil {
 			log.WithError(err).Warn("Proposer: invalid exit")
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	builderTest "github.com/prysmaticlabs/prysm/beacon-chain/builder/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
@@ -188,12 +189,15 @@ func TestProposer_GetBlock_AddsUnaggregatedAtts(t *testing.T) {
 	assert.DeepEqual(t, parentRoot[:], block.ParentRoot, "Expected block to have correct parent root")
 	assert.DeepEqual(t, randaoReveal, block.Body.RandaoReveal, "Expected block to have correct randao reveal")
 	assert.DeepEqual(t, req.Graffiti, block.Body.Graffiti, "Expected block to have correct graffiti")
-	assert.Equal(t, params.BeaconConfig().MaxAttestations, uint64(len(block.Body.Attestations)), "Expected block atts to be aggregated down to 1")
+	// The saved aggregates cover their whole committee, attestations adding no new attester are left out.
+	require.NotEqual(t, 0, len(block.Body.Attestations), "Expected block to have attestations")
+	committees := make(map[types.CommitteeIndex]bool)
 	hasUnaggregatedAtt := false
 	for _, a := range block.Body.Attestations {
+		assert.Equal(t, false, committees[a.Data.CommitteeIndex], "Expected a single attestation per committee")
+		committees[a.Data.CommitteeIndex] = true
 		if !helpers.IsAggregated(a) {
 			hasUnaggregatedAtt = true
-			break
 		}
 	}
 	assert.Equal(t, false, hasUnaggregatedAtt, "Expected block to not have unaggregated attestation")
@@ -2409,6 +2413,19 @@ func TestProposer_GetBeaconBlock_Optimistic(t *testing.T) {
 	require.ErrorContains(t, errOptimisticMode.Error(), err)
 }
 
+func TestProposer_GetBeaconBlock_HeadStale(t *testing.T) {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	genesis := time.Now().Add(-10 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	chain := &mock.ChainService{State: st, Genesis: genesis}
+	proposerServer := &Server{HeadFetcher: chain, TimeFetcher: chain, MaxHeadStaleness: 2}
+	_, err = proposerServer.GetBeaconBlock(context.Background(), &ethpb.BlockRequest{Slot: 10})
+	s, ok := status.FromError(err)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, codes.Unavailable, s.Code())
+	require.Equal(t, true, grpcutil.IsStaleHeadError(err))
+}
+
 func TestProposer_GetSyncAggregate_OK(t *testing.T) {
 	proposerServer := &Server{
 		SyncChecker:       &mockSync.Sync{IsSyncing: false},
@@ -5,13 +5,14 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/config/params"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	aggtesting "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation/aggregation/testing"
 	"github.com/prysmaticlabs/prysm/testing/require"
 )
 
-func BenchmarkProposerAtts_sortByProfitability(b *testing.B) {
+func BenchmarkProposerAtts_PackAttestations(b *testing.B) {
 	bitlistLen := params.BeaconConfig().MaxValidatorsPerCommittee
 
 	tests := []struct {
@@ -45,12 +46,12 @@ func BenchmarkProposerAtts_sortByProfitability(b *testing.B) {
 	}
 
 	runner := func(atts []*ethpb.Attestation) {
-		attsCopy := make(proposerAtts, len(atts))
+		attsCopy := make([]*ethpb.Attestation, len(atts))
 		for i, att := range atts {
 			attsCopy[i] = ethpb.CopyAttestation(att)
 		}
-		_, err := attsCopy.sortByProfitability()
-		require.NoError(b, err, "Could not sort attestations by profitability")
+		_, err := attestations.PackAttestations(attsCopy, params.BeaconConfig().MaxAttestations)
+		require.NoError(b, err, "Could not pack attestations")
 	}
 
 	for _, tt := range tests {
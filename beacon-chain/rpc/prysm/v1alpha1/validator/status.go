@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
@@ -168,6 +170,12 @@ func (vs *Server) CheckDoppelGanger(ctx context.Context, req *ethpb.DoppelGanger
 		return nil, errParticipation
 	}
 
+	// Blocks proposed since the start of the previous state's epoch are also a sign of liveness.
+	proposers, err := vs.recentProposers(ctx, slots.ToEpoch(prevStateSlot), headSlot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get recent block proposers: %v", err)
+	}
+
 	resp = &ethpb.DoppelGangerResponse{
 		Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{},
 	}
@@ -200,6 +208,15 @@ func (vs *Server) CheckDoppelGanger(ctx context.Context, req *ethpb.DoppelGanger
 				})
 			continue
 		}
+		if proposers[valIndex] {
+			log.WithField("ValidatorIndex", valIndex).Infof("Block proposal found")
+			resp.Responses = append(resp.Responses,
+				&ethpb.DoppelGangerResponse_ValidatorResponse{
+					PublicKey:       v.PublicKey,
+					DuplicateExists: true,
+				})
+			continue
+		}
 		// Mark the public key as valid.
 		resp.Responses = append(resp.Responses,
 			&ethpb.DoppelGangerResponse_ValidatorResponse{
@@ -210,6 +227,24 @@ func (vs *Server) CheckDoppelGanger(ctx context.Context, req *ethpb.DoppelGanger
 	return resp, nil
 }
 
+// recentProposers returns the indices of the validators which proposed a block, canonical or not,
+// from the start of the given epoch up to the head slot.
+func (vs *Server) recentProposers(ctx context.Context, epoch types.Epoch, headSlot types.Slot) (map[types.ValidatorIndex]bool, error) {
+	start, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	blks, _, err := vs.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(start).SetEndSlot(headSlot))
+	if err != nil {
+		return nil, err
+	}
+	proposers := make(map[types.ValidatorIndex]bool, len(blks))
+	for _, b := range blks {
+		proposers[b.Block().ProposerIndex()] = true
+	}
+	return proposers, nil
+}
+
 // activationStatus returns the validator status response for the set of validators
 // requested by their pub keys.
 func (vs *Server) activationStatus(
@@ -267,6 +302,22 @@ func (vs *Server) optimisticStatus(ctx context.Context) error {
 
 }
 
+// headStalenessStatus returns an error when the head is more than the configured number of slots behind the
+// wall-clock current slot, so that validators do not perform duties from a stalled node. The error is recognized
+// by validator clients with grpcutil.IsStaleHeadError.
+func (vs *Server) headStalenessStatus() error {
+	if vs.MaxHeadStaleness == 0 {
+		return nil
+	}
+	headSlot := vs.HeadFetcher.HeadSlot()
+	currentSlot := slots.CurrentSlot(uint64(vs.TimeFetcher.GenesisTime().Unix()))
+	if currentSlot <= headSlot+vs.MaxHeadStaleness {
+		return nil
+	}
+	return status.Errorf(codes.Unavailable, "%s: head slot %d is %d slots behind the current slot %d",
+		grpcutil.StaleHeadErrorMessage, headSlot, currentSlot-headSlot, currentSlot)
+}
+
 // validatorStatus searches for the requested validator's state and deposit to retrieve its inclusion estimate. Also returns the validators index.
 func (vs *Server) validatorStatus(
 	ctx context.Context,
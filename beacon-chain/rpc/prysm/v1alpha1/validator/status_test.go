@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/d4l3k/messagediff"
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
 	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	mockstategen "github.com/prysmaticlabs/prysm/beacon-chain/state/stategen/mock"
@@ -624,6 +626,31 @@ func TestOptimisticStatus(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestHeadStalenessStatus(t *testing.T) {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(10))
+	// Genesis is set half a slot before the start of slot 14.
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	genesis := time.Now().Add(-14*secondsPerSlot - secondsPerSlot/2)
+	chain := &mockChain.ChainService{State: st, Genesis: genesis}
+
+	// The gate is disabled by default.
+	server := &Server{HeadFetcher: chain, TimeFetcher: chain}
+	require.NoError(t, server.headStalenessStatus())
+
+	server.MaxHeadStaleness = 4
+	require.NoError(t, server.headStalenessStatus())
+
+	server.MaxHeadStaleness = 3
+	err = server.headStalenessStatus()
+	s, ok := status.FromError(err)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, codes.Unavailable, s.Code())
+	require.ErrorContains(t, "head slot 10 is 4 slots behind the current slot 14", err)
+	require.Equal(t, true, grpcutil.IsStaleHeadError(err))
+}
+
 func TestValidatorStatus_CorrectActivationQueue(t *testing.T) {
 	ctx := context.Background()
 
@@ -996,6 +1023,7 @@ func TestServer_CheckDoppelGanger(t *testing.T) {
 					},
 					SyncChecker:     &mockSync.Sync{IsSyncing: false},
 					ReplayerBuilder: rb,
+					BeaconDB:        dbutil.SetupDB(t),
 				}
 				request := &ethpb.DoppelGangerRequest{
 					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
@@ -1032,6 +1060,7 @@ func TestServer_CheckDoppelGanger(t *testing.T) {
 					},
 					SyncChecker:     &mockSync.Sync{IsSyncing: false},
 					ReplayerBuilder: rb,
+					BeaconDB:        dbutil.SetupDB(t),
 				}
 				request := &ethpb.DoppelGangerRequest{
 					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
@@ -1079,6 +1108,7 @@ func TestServer_CheckDoppelGanger(t *testing.T) {
 					},
 					SyncChecker:     &mockSync.Sync{IsSyncing: false},
 					ReplayerBuilder: rb,
+					BeaconDB:        dbutil.SetupDB(t),
 				}
 				request := &ethpb.DoppelGangerRequest{
 					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
@@ -1133,6 +1163,7 @@ func TestServer_CheckDoppelGanger(t *testing.T) {
 					},
 					SyncChecker:     &mockSync.Sync{IsSyncing: false},
 					ReplayerBuilder: rb,
+					BeaconDB:        dbutil.SetupDB(t),
 				}
 				request := &ethpb.DoppelGangerRequest{
 					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
@@ -1182,6 +1213,7 @@ func TestServer_CheckDoppelGanger(t *testing.T) {
 					},
 					SyncChecker:     &mockSync.Sync{IsSyncing: false},
 					ReplayerBuilder: rb,
+					BeaconDB:        dbutil.SetupDB(t),
 				}
 				request := &ethpb.DoppelGangerRequest{
 					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
@@ -1219,6 +1251,7 @@ func TestServer_CheckDoppelGanger(t *testing.T) {
 					},
 					SyncChecker:     &mockSync.Sync{IsSyncing: false},
 					ReplayerBuilder: rb,
+					BeaconDB:        dbutil.SetupDB(t),
 				}
 				request := &ethpb.DoppelGangerRequest{
 					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
@@ -1239,6 +1272,50 @@ func TestServer_CheckDoppelGanger(t *testing.T) {
 				return vs, request, response
 			},
 		},
+		{
+			name:    "doppelganger exists with block proposal",
+			wantErr: false,
+			svSetup: func(t *testing.T) (*Server, *ethpb.DoppelGangerRequest, *ethpb.DoppelGangerResponse) {
+				hs, ps, keys := createStateSetupAltair(t, 3)
+				rb := mockstategen.NewMockReplayerBuilder()
+				rb.SetMockStateForSlot(ps, 23)
+				beaconDB := dbutil.SetupDB(t)
+				// A block proposed before the previous state's epoch is not taken into account.
+				blk := util.NewBeaconBlock()
+				blk.Block.Slot = 2*params.BeaconConfig().SlotsPerEpoch - 1
+				blk.Block.ProposerIndex = 0
+				util.SaveBlock(t, context.Background(), beaconDB, blk)
+				blk = util.NewBeaconBlock()
+				blk.Block.Slot = hs.Slot() - 1
+				blk.Block.ProposerIndex = 1
+				util.SaveBlock(t, context.Background(), beaconDB, blk)
+
+				vs := &Server{
+					HeadFetcher: &mockChain.ChainService{
+						State: hs,
+					},
+					SyncChecker:     &mockSync.Sync{IsSyncing: false},
+					ReplayerBuilder: rb,
+					BeaconDB:        beaconDB,
+				}
+				request := &ethpb.DoppelGangerRequest{
+					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
+				}
+				response := &ethpb.DoppelGangerResponse{Responses: make([]*ethpb.DoppelGangerResponse_ValidatorResponse, 0)}
+				for i := 0; i < 3; i++ {
+					request.ValidatorRequests = append(request.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{
+						PublicKey:  keys[i].PublicKey().Marshal(),
+						Epoch:      0,
+						SignedRoot: []byte{'A'},
+					})
+					response.Responses = append(response.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
+						PublicKey:       keys[i].PublicKey().Marshal(),
+						DuplicateExists: i == 1,
+					})
+				}
+				return vs, request, response
+			},
+		},
 		{
 			name:    "exit early for Phase 0",
 			wantErr: false,
@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/config/params"
@@ -22,6 +23,9 @@ import (
 func (vs *Server) GetSyncMessageBlockRoot(
 	ctx context.Context, _ *emptypb.Empty,
 ) (*ethpb.SyncMessageBlockRootResponse, error) {
+	if err := vs.headStalenessStatus(); err != nil {
+		return nil, err
+	}
 	// An optimistic validator MUST NOT participate in sync committees
 	// (i.e., sign across the DOMAIN_SYNC_COMMITTEE, DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF or DOMAIN_CONTRIBUTION_AND_PROOF domains).
 	if err := vs.optimisticStatus(ctx); err != nil {
@@ -105,6 +109,14 @@ func (vs *Server) GetSyncCommitteeContribution(
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not get contribution data: %v", err)
 	}
+	// Serve the best contribution received from other aggregators instead when it has a higher participation.
+	best, err := vs.SyncCommitteePool.BestSyncCommitteeContribution(req.Slot, req.SubnetId, bytesutil.ToBytes32(headRoot))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get best sync committee contribution: %v", err)
+	}
+	if best != nil && best.AggregationBits.Count() > bitfield.Bitvector128(bits).Count() {
+		return ethpb.CopySyncCommitteeContribution(best), nil
+	}
 	contribution := &ethpb.SyncCommitteeContribution{
 		Slot:              req.Slot,
 		BlockRoot:         headRoot,
@@ -147,6 +159,42 @@ func (vs *Server) SubmitSignedContributionAndProof(
 	return &emptypb.Empty{}, err
 }
 
+// StreamSyncCommitteeContributions streams the signed sync committee contributions and proofs
+// received by the beacon node, either from gossip or from its own validators.
+func (vs *Server) StreamSyncCommitteeContributions(
+	_ *emptypb.Empty, stream ethpb.BeaconNodeValidator_StreamSyncCommitteeContributionsServer,
+) error {
+	opChannel := make(chan *feed.Event, 1)
+	opSub := vs.OperationNotifier.OperationFeed().Subscribe(opChannel)
+	defer opSub.Unsubscribe()
+	for {
+		select {
+		case event := <-opChannel:
+			if event.Type != opfeed.SyncCommitteeContributionReceived {
+				continue
+			}
+			data, ok := event.Data.(*opfeed.SyncCommitteeContributionReceivedData)
+			if !ok {
+				// Got bad data over the stream.
+				continue
+			}
+			if data.Contribution == nil {
+				// One nil contribution shouldn't stop the stream.
+				continue
+			}
+			if err := stream.Send(data.Contribution); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		case <-opSub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-vs.Ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
 // AggregatedSigAndAggregationBits returns the aggregated signature and aggregation bits
 // associated with a particular set of sync committee messages.
 func (vs *Server) AggregatedSigAndAggregationBits(
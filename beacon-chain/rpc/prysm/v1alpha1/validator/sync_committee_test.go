@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
@@ -17,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/crypto/bls"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
+	mock2 "github.com/prysmaticlabs/prysm/testing/mock"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
 	"google.golang.org/grpc/codes"
@@ -137,6 +139,37 @@ func TestGetSyncCommitteeContribution_FiltersDuplicates(t *testing.T) {
 	assert.DeepEqual(t, sig, contr.Signature)
 }
 
+func TestGetSyncCommitteeContribution_ServesBestFromPool(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateAltair(t, 10)
+	server := &Server{
+		SyncCommitteePool: synccommittee.NewStore(),
+		P2P:               &mockp2p.MockBroadcaster{},
+		HeadFetcher: &mock.ChainService{
+			State:                st,
+			SyncCommitteeIndices: []types.CommitteeIndex{10},
+		},
+		TimeFetcher: &mock.ChainService{Genesis: time.Now()},
+	}
+	cont := &ethpb.SyncCommitteeContribution{
+		Slot:              1,
+		BlockRoot:         make([]byte, 32),
+		SubcommitteeIndex: 1,
+		AggregationBits:   []byte{0b0111},
+		Signature:         bls.NewAggregateSignature().Marshal(),
+	}
+	require.NoError(t, server.SyncCommitteePool.SaveSyncCommitteeContribution(cont))
+	val, err := st.ValidatorAtIndex(2)
+	require.NoError(t, err)
+
+	contr, err := server.GetSyncCommitteeContribution(context.Background(),
+		&ethpb.SyncCommitteeContributionRequest{
+			Slot:      1,
+			PublicKey: val.PublicKey,
+			SubnetId:  1})
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, cont, contr)
+}
+
 func TestSubmitSignedContributionAndProof_OK(t *testing.T) {
 	server := &Server{
 		SyncCommitteePool: synccommittee.NewStore(),
@@ -198,3 +231,73 @@ func TestSubmitSignedContributionAndProof_Notification(t *testing.T) {
 		}
 	}
 }
+
+func TestStreamSyncCommitteeContributions_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := &Server{
+		Ctx:               ctx,
+		OperationNotifier: (&mock.ChainService{}).OperationNotifier(),
+	}
+
+	exitRoutine := make(chan bool)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStream := mock2.NewMockBeaconNodeValidator_StreamSyncCommitteeContributionsServer(ctrl)
+	mockStream.EXPECT().Context().Return(ctx)
+	go func(tt *testing.T) {
+		assert.ErrorContains(tt, "Context canceled", server.StreamSyncCommitteeContributions(&emptypb.Empty{}, mockStream))
+		<-exitRoutine
+	}(t)
+	cancel()
+	exitRoutine <- true
+}
+
+func TestStreamSyncCommitteeContributions_OnContributionReceived(t *testing.T) {
+	exitRoutine := make(chan bool)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	server := &Server{
+		Ctx:               ctx,
+		OperationNotifier: (&mock.ChainService{}).OperationNotifier(),
+	}
+
+	contributions := make([]*ethpb.SignedContributionAndProof, 3)
+	for i := range contributions {
+		contributions[i] = &ethpb.SignedContributionAndProof{
+			Message: &ethpb.ContributionAndProof{
+				Contribution: &ethpb.SyncCommitteeContribution{
+					Slot:              types.Slot(i + 1),
+					SubcommitteeIndex: 2,
+				},
+			},
+		}
+	}
+
+	mockStream := mock2.NewMockBeaconNodeValidator_StreamSyncCommitteeContributionsServer(ctrl)
+	mockStream.EXPECT().Send(contributions[0])
+	mockStream.EXPECT().Send(contributions[1])
+	mockStream.EXPECT().Send(contributions[2]).Do(func(arg0 interface{}) {
+		exitRoutine <- true
+	})
+	mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+
+	go func(tt *testing.T) {
+		assert.NoError(tt, server.StreamSyncCommitteeContributions(&emptypb.Empty{}, mockStream), "Could not call RPC method")
+	}(t)
+	for i := 0; i < len(contributions); i++ {
+		// Send in a loop to ensure it is delivered (busy wait for the service to subscribe to the operation feed).
+		for sent := 0; sent == 0; {
+			sent = server.OperationNotifier.OperationFeed().Send(&feed.Event{
+				Type: opfeed.SyncCommitteeContributionReceived,
+				Data: &opfeed.SyncCommitteeContributionReceivedData{Contribution: contributions[i]},
+			})
+		}
+		// Other operations are not streamed.
+		server.OperationNotifier.OperationFeed().Send(&feed.Event{
+			Type: opfeed.SyncCommitteeMessageReceived,
+			Data: &opfeed.SyncCommitteeMessageReceivedData{Message: &ethpb.SyncCommitteeMessage{}},
+		})
+	}
+	<-exitRoutine
+}
@@ -8,11 +8,13 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpcopentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prysmaticlabs/prysm/api/namespace"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
@@ -21,17 +23,20 @@ import (
 	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/lightclient"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/reload"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/beacon"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/debug"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/events"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/node"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/validator"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/maintenance"
 	beaconv1alpha1 "github.com/prysmaticlabs/prysm/beacon-chain/rpc/prysm/v1alpha1/beacon"
 	debugv1alpha1 "github.com/prysmaticlabs/prysm/beacon-chain/rpc/prysm/v1alpha1/debug"
 	nodev1alpha1 "github.com/prysmaticlabs/prysm/beacon-chain/rpc/prysm/v1alpha1/node"
@@ -42,6 +47,7 @@ import (
 	chainSync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/io/logs"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
 	ethpbservice "github.com/prysmaticlabs/prysm/proto/eth/service"
@@ -67,6 +73,7 @@ type Service struct {
 	credentialError      error
 	connectedRPCClients  map[net.Addr]bool
 	clientConnectionLock sync.Mutex
+	maintenance          *maintenance.Mode
 }
 
 // Config options for the beacon node RPC server.
@@ -98,10 +105,14 @@ type Config struct {
 	SlashingsPool           slashings.PoolManager
 	SlashingChecker         slasherservice.SlashingChecker
 	SyncCommitteeObjectPool synccommittee.Pool
+	LightClientUpdates      lightclient.UpdatesFetcher
 	SyncService             chainSync.Checker
+	GossipValidator         chainSync.GossipMessageValidator
 	Broadcaster             p2p.Broadcaster
 	PeersFetcher            p2p.PeersProvider
 	PeerManager             p2p.PeerManager
+	GossipStatsProvider     p2p.GossipStatsProvider
+	ConnectionFilter        p2p.ConnectionFilterManager
 	MetadataProvider        p2p.MetadataProvider
 	DepositFetcher          depositcache.DepositFetcher
 	PendingDepositFetcher   depositcache.PendingDepositsFetcher
@@ -114,6 +125,14 @@ type Config struct {
 	ProposerIdsCache        *cache.ProposerPayloadIDsCache
 	OptimisticModeFetcher   blockchain.OptimisticModeFetcher
 	BlockBuilder            builder.BlockBuilder
+	ConfigReloader          reload.Reloader
+	SlowRequestThreshold    time.Duration
+	MaxHeadStaleness        types.Slot
+	HistoricalStateBudget   time.Duration
+	HistoricalCacheSize     int
+	Namespaces              namespace.Set
+	AuthNamespaces          namespace.Set
+	AuthToken               string
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -126,6 +145,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		cancel:              cancel,
 		incomingAttestation: make(chan *ethpbv1alpha1.Attestation, params.BeaconConfig().DefaultBufferSize),
 		connectedRPCClients: make(map[net.Addr]bool),
+		maintenance:         &maintenance.Mode{},
 	}
 
 	address := fmt.Sprintf("%s:%s", s.cfg.Host, s.cfg.Port)
@@ -143,6 +163,9 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 				recovery.WithRecoveryHandlerContext(tracing.RecoveryHandlerFunc),
 			),
 			grpcprometheus.StreamServerInterceptor,
+			s.metricsStreamInterceptor,
+			s.authStreamInterceptor,
+			s.maintenanceStreamInterceptor,
 			grpcopentracing.StreamServerInterceptor(),
 			s.validatorStreamConnectionInterceptor,
 		)),
@@ -151,6 +174,9 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 				recovery.WithRecoveryHandlerContext(tracing.RecoveryHandlerFunc),
 			),
 			grpcprometheus.UnaryServerInterceptor,
+			s.metricsUnaryInterceptor,
+			s.authUnaryInterceptor,
+			s.maintenanceUnaryInterceptor,
 			grpcopentracing.UnaryServerInterceptor(),
 			s.validatorUnaryConnectionInterceptor,
 		)),
@@ -195,6 +221,7 @@ func (s *Service) Start() {
 		HeadFetcher:            s.cfg.HeadFetcher,
 		HeadUpdater:            s.cfg.HeadUpdater,
 		ForkFetcher:            s.cfg.ForkFetcher,
+		CanonicalFetcher:       s.cfg.CanonicalFetcher,
 		FinalizationFetcher:    s.cfg.FinalizationFetcher,
 		TimeFetcher:            s.cfg.GenesisTimeFetcher,
 		BlockFetcher:           s.cfg.POWChainService,
@@ -219,6 +246,7 @@ func (s *Service) Start() {
 		BeaconDB:               s.cfg.BeaconDB,
 		ProposerSlotIndexCache: s.cfg.ProposerIdsCache,
 		BlockBuilder:           s.cfg.BlockBuilder,
+		MaxHeadStaleness:       s.cfg.MaxHeadStaleness,
 	}
 	validatorServerV1 := &validator.Server{
 		HeadFetcher:           s.cfg.HeadFetcher,
@@ -237,7 +265,8 @@ func (s *Service) Start() {
 			StateGenService:    s.cfg.StateGen,
 			ReplayerBuilder:    ch,
 		},
-		SyncCommitteePool: s.cfg.SyncCommitteeObjectPool,
+		SyncCommitteePool:   s.cfg.SyncCommitteeObjectPool,
+		ProposerDutiesCache: cache.NewProposerDutiesCache(),
 	}
 
 	nodeServer := &nodev1alpha1.Server{
@@ -253,6 +282,7 @@ func (s *Service) Start() {
 		POWChainInfoFetcher:  s.cfg.POWChainInfoFetcher,
 		BeaconMonitoringHost: s.cfg.BeaconMonitoringHost,
 		BeaconMonitoringPort: s.cfg.BeaconMonitoringPort,
+		Maintenance:          s.maintenance,
 	}
 	nodeServerV1 := &node.Server{
 		BeaconDB:              s.cfg.BeaconDB,
@@ -264,6 +294,7 @@ func (s *Service) Start() {
 		PeerManager:           s.cfg.PeerManager,
 		MetadataProvider:      s.cfg.MetadataProvider,
 		HeadFetcher:           s.cfg.HeadFetcher,
+		Maintenance:           s.maintenance,
 	}
 
 	beaconChainServer := &beaconv1alpha1.Server{
@@ -312,31 +343,49 @@ func (s *Service) Start() {
 		OptimisticModeFetcher:   s.cfg.OptimisticModeFetcher,
 		HeadFetcher:             s.cfg.HeadFetcher,
 		VoluntaryExitsPool:      s.cfg.ExitPool,
+		SyncCommitteePool:       s.cfg.SyncCommitteeObjectPool,
 		V1Alpha1ValidatorServer: validatorServer,
 		SyncChecker:             s.cfg.SyncService,
+		LightClientUpdates:      s.cfg.LightClientUpdates,
 	}
-	ethpbv1alpha1.RegisterNodeServer(s.grpcServer, nodeServer)
-	ethpbservice.RegisterBeaconNodeServer(s.grpcServer, nodeServerV1)
-	ethpbv1alpha1.RegisterHealthServer(s.grpcServer, nodeServer)
-	ethpbv1alpha1.RegisterBeaconChainServer(s.grpcServer, beaconChainServer)
-	ethpbservice.RegisterBeaconChainServer(s.grpcServer, beaconChainServerV1)
-	ethpbservice.RegisterEventsServer(s.grpcServer, &events.Server{
-		Ctx:               s.ctx,
-		StateNotifier:     s.cfg.StateNotifier,
-		BlockNotifier:     s.cfg.BlockNotifier,
-		OperationNotifier: s.cfg.OperationNotifier,
-	})
-	if s.cfg.EnableDebugRPCEndpoints {
+	if s.namespaceEnabled(namespace.Prysm) {
+		ethpbv1alpha1.RegisterNodeServer(s.grpcServer, nodeServer)
+		ethpbv1alpha1.RegisterHealthServer(s.grpcServer, nodeServer)
+		ethpbv1alpha1.RegisterBeaconChainServer(s.grpcServer, beaconChainServer)
+	}
+	if s.namespaceEnabled(namespace.Node) {
+		ethpbservice.RegisterBeaconNodeServer(s.grpcServer, nodeServerV1)
+	}
+	if s.namespaceEnabled(namespace.Beacon) {
+		ethpbservice.RegisterBeaconChainServer(s.grpcServer, beaconChainServerV1)
+		ethpbservice.RegisterEventsServer(s.grpcServer, &events.Server{
+			Ctx:               s.ctx,
+			StateNotifier:     s.cfg.StateNotifier,
+			BlockNotifier:     s.cfg.BlockNotifier,
+			OperationNotifier: s.cfg.OperationNotifier,
+		})
+	}
+	if s.cfg.EnableDebugRPCEndpoints && s.namespaceEnabled(namespace.Debug) {
 		log.Info("Enabled debug gRPC endpoints")
+		// Historical states requested through the debug endpoints are reconstructed within a budget, and cached.
+		reconstructor := stategen.NewHistoricalStateReconstructor(
+			ch, s.cfg.FinalizationFetcher, s.cfg.HistoricalStateBudget, s.cfg.HistoricalCacheSize,
+		)
 		debugServer := &debugv1alpha1.Server{
-			GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
-			BeaconDB:           s.cfg.BeaconDB,
-			StateGen:           s.cfg.StateGen,
-			HeadFetcher:        s.cfg.HeadFetcher,
-			ForkFetcher:        s.cfg.ForkFetcher,
-			PeerManager:        s.cfg.PeerManager,
-			PeersFetcher:       s.cfg.PeersFetcher,
-			ReplayerBuilder:    ch,
+			GenesisTimeFetcher:  s.cfg.GenesisTimeFetcher,
+			BeaconDB:            s.cfg.BeaconDB,
+			StateGen:            s.cfg.StateGen,
+			HeadFetcher:         s.cfg.HeadFetcher,
+			ForkFetcher:         s.cfg.ForkFetcher,
+			PeerManager:         s.cfg.PeerManager,
+			PeersFetcher:        s.cfg.PeersFetcher,
+			GossipStatsProvider: s.cfg.GossipStatsProvider,
+			ConnectionFilter:    s.cfg.ConnectionFilter,
+			ReplayerBuilder:     reconstructor,
+			SyncCommitteePool:   s.cfg.SyncCommitteeObjectPool,
+			ConfigReloader:      s.cfg.ConfigReloader,
+			Maintenance:         s.maintenance,
+			GossipValidator:     s.cfg.GossipValidator,
 		}
 		debugServerV1 := &debug.Server{
 			BeaconDB:    s.cfg.BeaconDB,
@@ -346,15 +395,20 @@ func (s *Service) Start() {
 				ChainInfoFetcher:   s.cfg.ChainInfoFetcher,
 				GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
 				StateGenService:    s.cfg.StateGen,
-				ReplayerBuilder:    ch,
+				ReplayerBuilder:    reconstructor,
 			},
 			OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
 		}
 		ethpbv1alpha1.RegisterDebugServer(s.grpcServer, debugServer)
 		ethpbservice.RegisterBeaconDebugServer(s.grpcServer, debugServerV1)
 	}
-	ethpbv1alpha1.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
-	ethpbservice.RegisterBeaconValidatorServer(s.grpcServer, validatorServerV1)
+	if s.namespaceEnabled(namespace.Validator) {
+		ethpbv1alpha1.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
+		ethpbservice.RegisterBeaconValidatorServer(s.grpcServer, validatorServerV1)
+	}
+	if len(s.cfg.AuthNamespaces) > 0 {
+		log.WithField("namespaces", s.cfg.AuthNamespaces.String()).Info("Requiring a bearer token for API namespaces")
+	}
 	// Register reflection service on gRPC server.
 	reflection.Register(s.grpcServer)
 
@@ -388,6 +442,12 @@ func (s *Service) Status() error {
 	return nil
 }
 
+// namespaceEnabled returns true if the gRPC services of the API namespace are served. Every namespace
+// is served when none were configured.
+func (s *Service) namespaceEnabled(n namespace.Namespace) bool {
+	return s.cfg.Namespaces == nil || s.cfg.Namespaces.Contains(n)
+}
+
 // Stream interceptor for new validator client connections to the beacon node.
 func (s *Service) validatorStreamConnectionInterceptor(
 	srv interface{},
@@ -1,10 +1,10 @@
 package stategen
 
 import (
-	"errors"
 	"strconv"
 	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"k8s.io/client-go/tools/cache"
@@ -51,9 +51,12 @@ func rootKeyFn(obj interface{}) (string, error) {
 }
 
 // epochBoundaryState struct with two queues by looking up beacon state by slot or root.
+// When a spill area is set, states trimmed from memory are written to it instead of being discarded.
 type epochBoundaryState struct {
 	rootStateCache *cache.FIFO
 	slotRootCache  *cache.FIFO
+	memoryCap      uint64
+	spill          *stateSpill
 	lock           sync.RWMutex
 }
 
@@ -63,9 +66,29 @@ func newBoundaryStateCache() *epochBoundaryState {
 	return &epochBoundaryState{
 		rootStateCache: cache.NewFIFO(rootKeyFn),
 		slotRootCache:  cache.NewFIFO(slotKeyFn),
+		memoryCap:      maxCacheSize,
 	}
 }
 
+// enableSpill keeps at most memoryCap states in memory and spills the older ones to the given directory.
+func (e *epochBoundaryState) enableSpill(dir string, memoryCap uint64) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.memoryCap = memoryCap
+	e.spill = newStateSpill(dir, maxSpilledStates)
+}
+
+// has returns true if the epoch boundary state of the block root is held in memory or spilled.
+func (e *epochBoundaryState) has(r [32]byte) (bool, error) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	_, exists, err := e.rootStateCache.GetByKey(string(r[:]))
+	if err != nil {
+		return false, err
+	}
+	return exists || (e.spill != nil && e.spill.has(r)), nil
+}
+
 // ByBlockRoot satisfies the CachedGetter interface
 func (e *epochBoundaryState) ByBlockRoot(r [32]byte) (state.BeaconState, error) {
 	rsi, ok, err := e.getByBlockRoot(r)
@@ -92,7 +115,14 @@ func (e *epochBoundaryState) getByBlockRootLockFree(r [32]byte) (*rootStateInfo,
 		return nil, false, err
 	}
 	if !exists {
-		return nil, false, nil
+		if e.spill == nil {
+			return nil, false, nil
+		}
+		st, ok, err := e.spill.get(r)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		return &rootStateInfo{root: r, state: st}, true, nil
 	}
 	s, ok := obj.(*rootStateInfo)
 	if !ok {
@@ -145,9 +175,33 @@ func (e *epochBoundaryState) put(blockRoot [32]byte, s state.BeaconState) error
 		return err
 	}
 
-	trim(e.rootStateCache, maxCacheSize)
-	trim(e.slotRootCache, maxCacheSize)
+	if e.spill == nil {
+		trim(e.rootStateCache, e.memoryCap)
+		trim(e.slotRootCache, e.memoryCap)
+		return nil
+	}
+	if err := e.trimToSpill(); err != nil {
+		return err
+	}
+	trim(e.slotRootCache, e.memoryCap+maxSpilledStates)
+	return nil
+}
 
+// trimToSpill trims the in-memory states to the memory cap, writing the trimmed states to the spill area.
+func (e *epochBoundaryState) trimToSpill() error {
+	for s := uint64(len(e.rootStateCache.ListKeys())); s > e.memoryCap; s-- {
+		obj, err := e.rootStateCache.Pop(popProcessNoopFunc)
+		if err != nil {
+			return err
+		}
+		info, ok := obj.(*rootStateInfo)
+		if !ok {
+			return errNotRootStateInfo
+		}
+		if err := e.spill.put(info.root, info.state); err != nil {
+			return errors.Wrap(err, "could not spill epoch boundary state")
+		}
+	}
 	return nil
 }
 
@@ -155,6 +209,11 @@ func (e *epochBoundaryState) put(blockRoot [32]byte, s state.BeaconState) error
 func (e *epochBoundaryState) delete(blockRoot [32]byte) error {
 	e.lock.Lock()
 	defer e.lock.Unlock()
+	if e.spill != nil {
+		if err := e.spill.delete(blockRoot); err != nil {
+			return err
+		}
+	}
 	return e.rootStateCache.Delete(&rootStateInfo{
 		root: blockRoot,
 	})
@@ -1,9 +1,11 @@
 package stategen
 
 import (
+	"path/filepath"
 	"testing"
 
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/io/file"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
@@ -80,3 +82,49 @@ func TestEpochBoundaryStateCache_CanTrim(t *testing.T) {
 		}
 	}
 }
+
+func TestEpochBoundaryStateCache_SpillsTrimmedStates(t *testing.T) {
+	e := newBoundaryStateCache()
+	e.enableSpill(filepath.Join(t.TempDir(), "spill"), 2)
+	for i := types.Slot(0); i < 5; i++ {
+		s, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, s.SetSlot(i))
+		require.NoError(t, e.put([32]byte{byte(i)}, s))
+	}
+	assert.Equal(t, 2, len(e.rootStateCache.ListKeys()), "Did not trim to the memory cap")
+	assert.Equal(t, 3, e.spill.order.Len(), "Did not spill the trimmed states")
+
+	for i := types.Slot(0); i < 5; i++ {
+		has, err := e.has([32]byte{byte(i)})
+		require.NoError(t, err)
+		assert.Equal(t, true, has)
+		got, exists, err := e.getBySlot(i)
+		require.NoError(t, err)
+		require.Equal(t, true, exists, "Should exist")
+		assert.Equal(t, i, got.state.Slot())
+	}
+
+	require.NoError(t, e.delete([32]byte{0}))
+	has, err := e.has([32]byte{0})
+	require.NoError(t, err)
+	assert.Equal(t, false, has)
+	assert.Equal(t, false, file.FileExists(e.spill.path([32]byte{0})), "Spilled state was not removed")
+}
+
+func TestStateSpill_EvictsLeastRecentlyNeeded(t *testing.T) {
+	s := newStateSpill(filepath.Join(t.TempDir(), "spill"), 2)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, s.put([32]byte{'a'}, st))
+	require.NoError(t, s.put([32]byte{'b'}, st))
+	_, ok, err := s.get([32]byte{'a'})
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	require.NoError(t, s.put([32]byte{'c'}, st))
+
+	assert.Equal(t, true, s.has([32]byte{'a'}))
+	assert.Equal(t, false, s.has([32]byte{'b'}), "Least recently needed state was not evicted")
+	assert.Equal(t, true, s.has([32]byte{'c'}))
+	assert.Equal(t, false, file.FileExists(s.path([32]byte{'b'})))
+}
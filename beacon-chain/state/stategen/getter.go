@@ -2,6 +2,7 @@ package stategen
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
@@ -32,11 +33,7 @@ func (s *State) HasStateInCache(_ context.Context, blockRoot [32]byte) (bool, er
 	if s.hotStateCache.has(blockRoot) {
 		return true, nil
 	}
-	_, has, err := s.epochBoundaryStateCache.getByBlockRoot(blockRoot)
-	if err != nil {
-		return false, err
-	}
-	return has, nil
+	return s.epochBoundaryStateCache.has(blockRoot)
 }
 
 // StateByRootIfCachedNoCopy retrieves a state using the input block root only if the state is already in the cache.
@@ -76,7 +73,9 @@ func (s *State) StateByRootInitialSync(ctx context.Context, blockRoot [32]byte)
 	defer s.hotStateCache.delete(blockRoot)
 
 	if s.hotStateCache.has(blockRoot) {
-		return s.hotStateCache.getWithoutCopy(blockRoot), nil
+		cachedState := s.hotStateCache.getWithoutCopy(blockRoot)
+		s.traceBaseState(baseSourceHotCache, cachedState.Slot())
+		return cachedState, nil
 	}
 
 	cachedInfo, ok, err := s.epochBoundaryStateCache.getByBlockRoot(blockRoot)
@@ -84,6 +83,7 @@ func (s *State) StateByRootInitialSync(ctx context.Context, blockRoot [32]byte)
 		return nil, err
 	}
 	if ok {
+		s.traceBaseState(baseSourceEpochBoundaryCache, cachedInfo.state.Slot())
 		return cachedInfo.state, nil
 	}
 
@@ -160,6 +160,7 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (state.
 	// First, it checks if the state exists in hot state cache.
 	cachedState := s.hotStateCache.get(blockRoot)
 	if cachedState != nil && !cachedState.IsNil() {
+		s.traceBaseState(baseSourceHotCache, cachedState.Slot())
 		return cachedState, nil
 	}
 
@@ -169,42 +170,46 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (state.
 		return nil, err
 	}
 	if ok {
+		s.traceBaseState(baseSourceEpochBoundaryCache, cachedInfo.state.Slot())
 		return cachedInfo.state, nil
 	}
 
-	// Short circuit if the state is already in the DB.
+	// Short circuit if the state is already in the DB. A stored state that cannot be read is
+	// regenerated instead.
 	if s.beaconDB.HasState(ctx, blockRoot) {
-		return s.beaconDB.State(ctx, blockRoot)
+		st, err := s.beaconDB.State(ctx, blockRoot)
+		if err == nil && st != nil && !st.IsNil() {
+			s.traceBaseState(baseSourceDB, st.Slot())
+			return st, nil
+		}
+		if err == nil {
+			err = errUnknownState
+		}
+		regenFailureCount.WithLabelValues(failureCorruptedState).Inc()
+		log.WithError(err).WithField("root", fmt.Sprintf("%#x", bytesutil.Trunc(blockRoot[:]))).Warn("Could not read state from DB, regenerating it")
 	}
 
 	summary, err := s.stateSummary(ctx, blockRoot)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get state summary")
 	}
-	targetSlot := summary.Slot
 
 	// Since the requested state is not in caches or DB, start replaying using the last
 	// available ancestor state which is retrieved using input block's root.
 	startState, err := s.LastAncestorState(ctx, blockRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get ancestor state")
+		return s.recoverStateByRoot(ctx, summary, summary.Slot+1, errors.Wrap(err, "could not get ancestor state"))
 	}
 	if startState == nil || startState.IsNil() {
-		return nil, errUnknownBoundaryState
-	}
-
-	if startState.Slot() == targetSlot {
-		return startState, nil
+		return s.recoverStateByRoot(ctx, summary, summary.Slot+1, errUnknownBoundaryState)
 	}
 
-	blks, err := s.LoadBlocks(ctx, startState.Slot()+1, targetSlot, bytesutil.ToBytes32(summary.Root))
+	startSlot := startState.Slot()
+	st, err := s.replayFromBase(ctx, startState, summary)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not load blocks for hot state using root")
+		return s.recoverStateByRoot(ctx, summary, startSlot, err)
 	}
-
-	replayBlockCount.Observe(float64(len(blks)))
-
-	return s.ReplayBlocks(ctx, startState, blks, targetSlot)
+	return st, nil
 }
 
 // LastAncestorState returns the highest available ancestor state of the input block root.
@@ -220,7 +225,9 @@ func (s *State) LastAncestorState(ctx context.Context, blockRoot [32]byte) (stat
 	defer span.End()
 
 	if s.isFinalizedRoot(blockRoot) && s.finalizedState() != nil {
-		return s.finalizedState(), nil
+		st := s.finalizedState()
+		s.traceBaseState(baseSourceFinalized, st.Slot())
+		return st, nil
 	}
 
 	b, err := s.beaconDB.Block(ctx, blockRoot)
@@ -239,6 +246,7 @@ func (s *State) LastAncestorState(ctx context.Context, blockRoot [32]byte) (stat
 		// Is the state the genesis state.
 		parentRoot := bytesutil.ToBytes32(b.Block().ParentRoot())
 		if parentRoot == params.BeaconConfig().ZeroHash {
+			s.traceBaseState(baseSourceGenesis, 0)
 			return s.beaconDB.GenesisState(ctx)
 		}
 
@@ -249,12 +257,16 @@ func (s *State) LastAncestorState(ctx context.Context, blockRoot [32]byte) (stat
 		}
 		// Does the state exist in the hot state cache.
 		if s.hotStateCache.has(parentRoot) {
-			return s.hotStateCache.get(parentRoot), nil
+			st := s.hotStateCache.get(parentRoot)
+			s.traceBaseState(baseSourceHotCache, st.Slot())
+			return st, nil
 		}
 
 		// Does the state exist in finalized info cache.
 		if s.isFinalizedRoot(parentRoot) {
-			return s.finalizedState(), nil
+			st := s.finalizedState()
+			s.traceBaseState(baseSourceFinalized, st.Slot())
+			return st, nil
 		}
 
 		// Does the state exist in epoch boundary cache.
@@ -263,12 +275,18 @@ func (s *State) LastAncestorState(ctx context.Context, blockRoot [32]byte) (stat
 			return nil, err
 		}
 		if ok {
+			s.traceBaseState(baseSourceEpochBoundaryCache, cachedInfo.state.Slot())
 			return cachedInfo.state, nil
 		}
 
 		// Does the state exists in DB.
 		if s.beaconDB.HasState(ctx, parentRoot) {
-			return s.beaconDB.State(ctx, parentRoot)
+			st, err := s.beaconDB.State(ctx, parentRoot)
+			if err != nil {
+				return nil, &regenFailure{class: failureCorruptedState, err: err}
+			}
+			s.traceBaseState(baseSourceDB, st.Slot())
+			return st, nil
 		}
 
 		b, err = s.beaconDB.Block(ctx, parentRoot)
@@ -36,14 +36,15 @@ func NewCanonicalHistory(h HistoryAccessor, cc CanonicalChecker, cs CurrentSlott
 }
 
 type CanonicalHistory struct {
-	h     HistoryAccessor
-	cc    CanonicalChecker
-	cs    CurrentSlotter
-	cache CachedGetter
+	h      HistoryAccessor
+	cc     CanonicalChecker
+	cs     CurrentSlotter
+	cache  CachedGetter
+	tracer replayTracer
 }
 
 func (c *CanonicalHistory) ReplayerForSlot(target types.Slot) Replayer {
-	return &stateReplayer{chainer: c, method: forSlot, target: target}
+	return &stateReplayer{chainer: c, method: forSlot, target: target, tracer: c.tracer}
 }
 
 func (c *CanonicalHistory) BlockRootForSlot(ctx context.Context, target types.Slot) ([32]byte, error) {
@@ -123,17 +124,18 @@ func (c *CanonicalHistory) chainForSlot(ctx context.Context, target types.Slot)
 	return s, descendants, nil
 }
 
-func (c *CanonicalHistory) getState(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+func (c *CanonicalHistory) getState(ctx context.Context, blockRoot [32]byte) (state.BeaconState, string, error) {
 	if c.cache != nil {
 		st, err := c.cache.ByBlockRoot(blockRoot)
 		if err == nil {
-			return st, nil
+			return st, baseSourceHistoryCache, nil
 		}
 		if !errors.Is(err, ErrNotInCache) {
-			return nil, errors.Wrap(err, "error reading from state cache during state replay")
+			return nil, "", errors.Wrap(err, "error reading from state cache during state replay")
 		}
 	}
-	return c.h.StateOrError(ctx, blockRoot)
+	st, err := c.h.StateOrError(ctx, blockRoot)
+	return st, baseSourceDB, err
 }
 
 // ancestorChain works backwards through the chain lineage, accumulating blocks and checking for a saved state.
@@ -157,7 +159,7 @@ func (c *CanonicalHistory) ancestorChain(ctx context.Context, tail interfaces.Si
 			msg := fmt.Sprintf("could not compute htr for descendant block at slot=%d", b.Slot())
 			return nil, nil, errors.Wrap(err, msg)
 		}
-		st, err := c.getState(ctx, root)
+		st, source, err := c.getState(ctx, root)
 		// err == nil, we've got a real state - the job is done!
 		// Note: in cases where there are skipped slots we could find a state that is a descendant
 		// of the block we are searching for. We don't want to return a future block, so in this case
@@ -166,6 +168,9 @@ func (c *CanonicalHistory) ancestorChain(ctx context.Context, tail interfaces.Si
 			// we found the state by the root of the head, meaning it has already been applied.
 			// we only want to return the blocks descended from it.
 			reverseChain(chain)
+			if c.tracer != nil {
+				c.tracer.baseState(source, st.Slot())
+			}
 			return st, chain, nil
 		}
 		// ErrNotFoundState errors are fine, but other errors mean something is wrong with the db
@@ -25,8 +25,12 @@ var (
 )
 
 // hotStateCache is used to store the processed beacon state after finalized check point.
+// When a spill area is set, the least recently used states evicted from memory are written to it
+// and transparently reloaded when they are needed again.
 type hotStateCache struct {
 	cache *lru.Cache
+	size  int
+	spill *stateSpill
 	lock  sync.RWMutex
 }
 
@@ -34,22 +38,30 @@ type hotStateCache struct {
 func newHotStateCache() *hotStateCache {
 	return &hotStateCache{
 		cache: lruwrpr.New(hotStateCacheSize),
+		size:  hotStateCacheSize,
 	}
 }
 
+// enableSpill keeps at most memoryCap states in memory and spills the least recently used ones to the
+// given directory, instead of discarding them.
+func (c *hotStateCache) enableSpill(dir string, memoryCap int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cache.Resize(memoryCap)
+	c.size = memoryCap
+	c.spill = newStateSpill(dir, maxSpilledStates)
+	c.spill.countGauge = spilledHotStateCount
+	c.spill.readCounter = spilledHotStateReadCount
+}
+
 // Get returns a cached response via input block root, if any.
 // The response is copied by default.
 func (c *hotStateCache) get(blockRoot [32]byte) state.BeaconState {
-	c.lock.RLock()
-	defer c.lock.RUnlock()
-	item, exists := c.cache.Get(blockRoot)
-
-	if exists && item != nil {
-		hotStateCacheHit.Inc()
-		return item.(state.BeaconState).Copy()
+	st := c.getWithoutCopy(blockRoot)
+	if st == nil {
+		return nil
 	}
-	hotStateCacheMiss.Inc()
-	return nil
+	return st.Copy()
 }
 
 func (c *hotStateCache) ByBlockRoot(r [32]byte) (state.BeaconState, error) {
@@ -63,33 +75,98 @@ func (c *hotStateCache) ByBlockRoot(r [32]byte) (state.BeaconState, error) {
 // GetWithoutCopy returns a non-copied cached response via input block root.
 func (c *hotStateCache) getWithoutCopy(blockRoot [32]byte) state.BeaconState {
 	c.lock.RLock()
-	defer c.lock.RUnlock()
 	item, exists := c.cache.Get(blockRoot)
+	c.lock.RUnlock()
 	if exists && item != nil {
 		hotStateCacheHit.Inc()
 		return item.(state.BeaconState)
 	}
+	if st := c.reload(blockRoot); st != nil {
+		hotStateCacheHit.Inc()
+		return st
+	}
 	hotStateCacheMiss.Inc()
 	return nil
 }
 
+// reload moves a spilled state back in memory, spilling the least recently used state if needed.
+func (c *hotStateCache) reload(blockRoot [32]byte) state.BeaconState {
+	if c.spill == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// The state may have been reloaded while waiting for the lock.
+	if item, exists := c.cache.Get(blockRoot); exists && item != nil {
+		return item.(state.BeaconState)
+	}
+	st, ok, err := c.spill.get(blockRoot)
+	if err != nil {
+		log.WithError(err).Error("Could not reload spilled hot state")
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	c.putLockFree(blockRoot, st)
+	return st
+}
+
 // put the response in the cache.
 func (c *hotStateCache) put(blockRoot [32]byte, state state.BeaconState) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	c.cache.Add(blockRoot, state)
+	c.putLockFree(blockRoot, state)
 }
 
-// has returns true if the key exists in the cache.
+func (c *hotStateCache) putLockFree(blockRoot [32]byte, st state.BeaconState) {
+	if c.spill == nil {
+		c.cache.Add(blockRoot, st)
+		return
+	}
+	if !c.cache.Contains(blockRoot) {
+		for c.cache.Len() > 0 && c.cache.Len() >= c.size {
+			k, v, ok := c.cache.RemoveOldest()
+			if !ok {
+				break
+			}
+			if err := c.spill.put(k.([32]byte), v.(state.BeaconState)); err != nil {
+				log.WithError(err).Error("Could not spill hot state")
+			}
+		}
+	}
+	// The spilled copy of the state, if any, is outdated now that the state is back in memory.
+	if err := c.spill.delete(blockRoot); err != nil {
+		log.WithError(err).Error("Could not delete spilled hot state")
+	}
+	c.cache.Add(blockRoot, st)
+}
+
+// has returns true if the key exists in the cache or is spilled.
 func (c *hotStateCache) has(blockRoot [32]byte) bool {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
-	return c.cache.Contains(blockRoot)
+	return c.cache.Contains(blockRoot) || (c.spill != nil && c.spill.has(blockRoot))
 }
 
-// delete deletes the key exists in the cache.
+// delete deletes the key exists in the cache, along with its spilled state.
 func (c *hotStateCache) delete(blockRoot [32]byte) bool {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	return c.cache.Remove(blockRoot)
+	removed := c.cache.Remove(blockRoot)
+	if c.spill != nil && c.spill.has(blockRoot) {
+		if err := c.spill.delete(blockRoot); err != nil {
+			log.WithError(err).Error("Could not delete spilled hot state")
+		}
+		removed = true
+	}
+	return removed
+}
+
+// resize changes the number of states the cache can hold, evicting the oldest states if needed.
+func (c *hotStateCache) resize(size int) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.size = size
+	return c.cache.Resize(size)
 }
@@ -1,13 +1,17 @@
 package stategen
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/io/file"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
 )
 
 func TestHotStateCache_RoundTrip(t *testing.T) {
@@ -32,3 +36,45 @@ func TestHotStateCache_RoundTrip(t *testing.T) {
 	c.delete(root)
 	assert.Equal(t, false, c.has(root), "Cache not supposed to have the object")
 }
+
+func TestHotStateCache_Resize(t *testing.T) {
+	c := newHotStateCache()
+	for i := byte(0); i < 4; i++ {
+		s, err := v1.InitializeFromProto(&ethpb.BeaconState{Slot: types.Slot(i)})
+		require.NoError(t, err)
+		c.put([32]byte{i}, s)
+	}
+	assert.Equal(t, 2, c.resize(2))
+	assert.Equal(t, false, c.has([32]byte{0}), "Oldest state was not evicted")
+	assert.Equal(t, false, c.has([32]byte{1}), "Oldest state was not evicted")
+	assert.Equal(t, true, c.has([32]byte{3}), "Newest state was evicted")
+}
+
+func TestHotStateCache_Spill(t *testing.T) {
+	c := newHotStateCache()
+	c.enableSpill(filepath.Join(t.TempDir(), "spill"), 2)
+	for i := byte(0); i < 4; i++ {
+		s, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, s.SetSlot(types.Slot(i)))
+		c.put([32]byte{i}, s)
+	}
+	assert.Equal(t, 2, c.cache.Len())
+	assert.Equal(t, true, c.spill.has([32]byte{0}), "Least recently used state was not spilled")
+	assert.Equal(t, true, c.spill.has([32]byte{1}), "Least recently used state was not spilled")
+	for i := byte(0); i < 4; i++ {
+		assert.Equal(t, true, c.has([32]byte{i}))
+	}
+
+	// A spilled state is reloaded in memory, spilling the least recently used one.
+	st := c.get([32]byte{0})
+	require.NotNil(t, st)
+	assert.Equal(t, types.Slot(0), st.Slot())
+	assert.Equal(t, true, c.cache.Contains([32]byte{0}))
+	assert.Equal(t, false, c.spill.has([32]byte{0}))
+	assert.Equal(t, true, c.spill.has([32]byte{2}))
+
+	assert.Equal(t, true, c.delete([32]byte{1}))
+	assert.Equal(t, false, c.has([32]byte{1}))
+	assert.Equal(t, false, file.FileExists(c.spill.path([32]byte{1})), "Spilled state was not removed")
+}
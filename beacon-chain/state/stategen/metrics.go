@@ -13,4 +13,42 @@ var (
 			Buckets: []float64{64, 256, 1024, 2048, 4096},
 		},
 	)
+	regenFailureCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "state_regen_failures_total",
+			Help: "The number of failed state regenerations, by failure class",
+		},
+		[]string{"class"},
+	)
+	regenRecoveryCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "state_regen_recoveries_total",
+			Help: "The number of failed state regenerations recovered from an alternate base state, by failure class",
+		},
+		[]string{"class"},
+	)
+	spilledStateCount = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "state_regen_spilled_states",
+			Help: "The number of epoch boundary states spilled to disk",
+		},
+	)
+	spilledStateReadCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "state_regen_spilled_state_reads_total",
+			Help: "The number of epoch boundary states read back from disk",
+		},
+	)
+	spilledHotStateCount = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "hot_state_cache_spilled_states",
+			Help: "The number of hot states spilled to disk",
+		},
+	)
+	spilledHotStateReadCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "hot_state_cache_spilled_state_reads_total",
+			Help: "The number of hot states read back from disk",
+		},
+	)
 )
@@ -0,0 +1,175 @@
+package stategen
+
+import (
+	"context"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// ErrReconstructionBudgetExceeded is returned when a historical state could not be reconstructed within
+// the execution time budget.
+var ErrReconstructionBudgetExceeded = errors.New("historical state reconstruction exceeded its time budget")
+
+var (
+	reconstructedStateCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "reconstructed_state_cache_hit",
+		Help: "The total number of cache hits on the reconstructed historical state cache.",
+	})
+	reconstructedStateCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "reconstructed_state_cache_miss",
+		Help: "The total number of cache misses on the reconstructed historical state cache.",
+	})
+	reconstructionDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "historical_state_reconstruction_seconds",
+		Help:    "Time taken to reconstruct a historical state on demand",
+		Buckets: []float64{1, 5, 10, 30, 60, 120, 300},
+	})
+)
+
+// FinalizedCheckpointer provides the finalized checkpoint.
+type FinalizedCheckpointer interface {
+	FinalizedCheckpt() *ethpb.Checkpoint
+}
+
+// HistoricalStateReconstructor reconstructs historical states on demand for nodes which do not keep
+// every state, by replaying blocks from the nearest stored state. Reconstructions run one at a time
+// within an execution time budget, and the most recently reconstructed finalized states are cached
+// since their content can not change anymore.
+type HistoricalStateReconstructor struct {
+	rb     ReplayerBuilder
+	fc     FinalizedCheckpointer
+	budget time.Duration
+	cache  *lru.Cache
+	sem    chan struct{}
+}
+
+// NewHistoricalStateReconstructor returns a reconstructor replaying blocks with the given replayer builder.
+// A zero budget lets reconstructions run until the request is canceled, and a zero cache size disables
+// the cache.
+func NewHistoricalStateReconstructor(
+	rb ReplayerBuilder, fc FinalizedCheckpointer, budget time.Duration, cacheSize int,
+) *HistoricalStateReconstructor {
+	r := &HistoricalStateReconstructor{
+		rb:     rb,
+		fc:     fc,
+		budget: budget,
+		sem:    make(chan struct{}, 1),
+	}
+	if cacheSize > 0 {
+		r.cache = lruwrpr.New(cacheSize)
+	}
+	return r
+}
+
+// StateBySlot returns the state at the given slot, with every canonical block up to and including the slot
+// applied.
+func (r *HistoricalStateReconstructor) StateBySlot(ctx context.Context, slot types.Slot) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.HistoricalStateReconstructor.StateBySlot")
+	defer span.End()
+
+	if st := r.cached(slot); st != nil {
+		return st, nil
+	}
+
+	if r.budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.budget)
+		defer cancel()
+	}
+	// Reconstructions are expensive, so they are not run concurrently.
+	select {
+	case r.sem <- struct{}{}:
+		defer func() { <-r.sem }()
+	case <-ctx.Done():
+		return nil, r.budgetError(ctx)
+	}
+	// The state may have been reconstructed while waiting.
+	if st := r.cached(slot); st != nil {
+		return st, nil
+	}
+
+	start := time.Now()
+	st, err := r.rb.ReplayerForSlot(slot).ReplayBlocks(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, r.budgetError(ctx)
+		}
+		return nil, err
+	}
+	reconstructionDuration.Observe(time.Since(start).Seconds())
+
+	if r.cache != nil && r.fc != nil {
+		if finalized := r.fc.FinalizedCheckpt(); finalized != nil && slots.ToEpoch(slot) < finalized.Epoch {
+			r.cache.Add(slot, st.Copy())
+		}
+	}
+	return st, nil
+}
+
+var _ ReplayerBuilder = &HistoricalStateReconstructor{}
+
+// ReplayerForSlot returns a replayer reconstructing the state at the target slot through the reconstructor,
+// so that it can be used in place of the replayer builder it wraps.
+func (r *HistoricalStateReconstructor) ReplayerForSlot(target types.Slot) Replayer {
+	return &reconstructingReplayer{r: r, target: target}
+}
+
+// reconstructingReplayer is a Replayer reconstructing states through a HistoricalStateReconstructor.
+type reconstructingReplayer struct {
+	r      *HistoricalStateReconstructor
+	target types.Slot
+}
+
+// ReplayBlocks reconstructs the state at the target slot of the replayer.
+func (rr *reconstructingReplayer) ReplayBlocks(ctx context.Context) (state.BeaconState, error) {
+	return rr.r.StateBySlot(ctx, rr.target)
+}
+
+// ReplayToSlot reconstructs the state at the target slot of the replayer, then runs process_slots up to
+// the given slot.
+func (rr *reconstructingReplayer) ReplayToSlot(ctx context.Context, replayTo types.Slot) (state.BeaconState, error) {
+	s, err := rr.ReplayBlocks(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to ReplayBlocks")
+	}
+	if replayTo < s.Slot() {
+		return nil, errors.Wrapf(ErrReplayTargetSlotExceeded, "slot desired=%d, state.slot=%d", replayTo, s.Slot())
+	}
+	if replayTo == s.Slot() {
+		return s, nil
+	}
+	return ReplayProcessSlots(ctx, s, replayTo)
+}
+
+// cached returns a copy of the state reconstructed at the given slot, if it is still cached.
+func (r *HistoricalStateReconstructor) cached(slot types.Slot) state.BeaconState {
+	if r.cache == nil {
+		return nil
+	}
+	item, ok := r.cache.Get(slot)
+	if !ok {
+		reconstructedStateCacheMiss.Inc()
+		return nil
+	}
+	reconstructedStateCacheHit.Inc()
+	return item.(state.BeaconState).Copy()
+}
+
+// budgetError returns the error of a reconstruction interrupted by its context, telling apart an exceeded
+// budget from a canceled request.
+func (r *HistoricalStateReconstructor) budgetError(ctx context.Context) error {
+	if r.budget > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return errors.Wrapf(ErrReconstructionBudgetExceeded, "budget of %s", r.budget)
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,125 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+// countingReplayerBuilder replays to a fresh state at the target slot and counts the replays, blocking
+// until the context is done when delay is set.
+type countingReplayerBuilder struct {
+	t       *testing.T
+	delay   bool
+	replays int
+}
+
+func (b *countingReplayerBuilder) ReplayerForSlot(target types.Slot) Replayer {
+	return &countingReplayer{b: b, target: target}
+}
+
+type countingReplayer struct {
+	b      *countingReplayerBuilder
+	target types.Slot
+}
+
+func (r *countingReplayer) ReplayBlocks(ctx context.Context) (state.BeaconState, error) {
+	r.b.replays++
+	if r.b.delay {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	st, err := util.NewBeaconState()
+	require.NoError(r.b.t, err)
+	require.NoError(r.b.t, st.SetSlot(r.target))
+	return st, nil
+}
+
+func (r *countingReplayer) ReplayToSlot(ctx context.Context, _ types.Slot) (state.BeaconState, error) {
+	return r.ReplayBlocks(ctx)
+}
+
+type finalizedCheckpointer struct {
+	epoch types.Epoch
+}
+
+func (f *finalizedCheckpointer) FinalizedCheckpt() *ethpb.Checkpoint {
+	return &ethpb.Checkpoint{Epoch: f.epoch}
+}
+
+func TestHistoricalStateReconstructor_CachesFinalizedStates(t *testing.T) {
+	ctx := context.Background()
+	rb := &countingReplayerBuilder{t: t}
+	r := NewHistoricalStateReconstructor(rb, &finalizedCheckpointer{epoch: 2}, time.Minute, 2)
+
+	st, err := r.StateBySlot(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, types.Slot(10), st.Slot())
+	// The returned state is a copy of the cached one.
+	require.NoError(t, st.SetSlot(11))
+	st, err = r.StateBySlot(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, types.Slot(10), st.Slot())
+	require.Equal(t, 1, rb.replays)
+
+	// States which are not finalized yet may still change, and are reconstructed every time.
+	_, err = r.StateBySlot(ctx, 64)
+	require.NoError(t, err)
+	_, err = r.StateBySlot(ctx, 64)
+	require.NoError(t, err)
+	require.Equal(t, 3, rb.replays)
+
+	// The least recently reconstructed states are evicted.
+	_, err = r.StateBySlot(ctx, 11)
+	require.NoError(t, err)
+	_, err = r.StateBySlot(ctx, 12)
+	require.NoError(t, err)
+	_, err = r.StateBySlot(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, 6, rb.replays)
+}
+
+func TestHistoricalStateReconstructor_NoCache(t *testing.T) {
+	rb := &countingReplayerBuilder{t: t}
+	r := NewHistoricalStateReconstructor(rb, &finalizedCheckpointer{epoch: 2}, 0, 0)
+	for i := 0; i < 2; i++ {
+		_, err := r.StateBySlot(context.Background(), 10)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, rb.replays)
+}
+
+func TestHistoricalStateReconstructor_Budget(t *testing.T) {
+	rb := &countingReplayerBuilder{t: t, delay: true}
+	r := NewHistoricalStateReconstructor(rb, &finalizedCheckpointer{epoch: 2}, 10*time.Millisecond, 2)
+	_, err := r.StateBySlot(context.Background(), 10)
+	require.ErrorIs(t, err, ErrReconstructionBudgetExceeded)
+
+	// A canceled request is not reported as an exceeded budget.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = r.StateBySlot(ctx, 10)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestHistoricalStateReconstructor_ReplayerForSlot(t *testing.T) {
+	ctx := context.Background()
+	rb := &countingReplayerBuilder{t: t}
+	r := NewHistoricalStateReconstructor(rb, &finalizedCheckpointer{epoch: 2}, time.Minute, 2)
+
+	st, err := r.ReplayerForSlot(10).ReplayBlocks(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.Slot(10), st.Slot())
+	st, err = r.ReplayerForSlot(10).ReplayToSlot(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, types.Slot(10), st.Slot())
+	require.Equal(t, 1, rb.replays)
+	_, err = r.ReplayerForSlot(10).ReplayToSlot(ctx, 9)
+	require.ErrorIs(t, err, ErrReplayTargetSlotExceeded)
+}
@@ -0,0 +1,221 @@
+package stategen
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// Classes of state regeneration failures, used to label metrics.
+const (
+	failureMissingBlock    = "missing_block"
+	failureMissingState    = "missing_state"
+	failureCorruptedState  = "corrupted_state"
+	failureReplay          = "replay"
+	failureUnavailableSlot = "unavailable_slot"
+	failureCanceled        = "canceled"
+	failureUnknown         = "unknown"
+)
+
+// maxAlternateBaseStates bounds the number of earlier archive points tried when regenerating a state failed.
+const maxAlternateBaseStates = 3
+
+// regenFailure annotates an error encountered while regenerating a state with its failure class.
+type regenFailure struct {
+	class string
+	err   error
+}
+
+func (f *regenFailure) Error() string {
+	return f.err.Error()
+}
+
+func (f *regenFailure) Unwrap() error {
+	return f.err
+}
+
+// classifyRegenFailure returns the failure class of an error returned while regenerating a state.
+func classifyRegenFailure(err error) string {
+	var f *regenFailure
+	switch {
+	case errors.As(err, &f):
+		return f.class
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return failureCanceled
+	case errors.Is(err, ErrNoDataForSlot):
+		return failureUnavailableSlot
+	case errors.Is(err, errUnknownBlock), errors.Is(err, wrapper.ErrNilSignedBeaconBlock):
+		return failureMissingBlock
+	case errors.Is(err, errUnknownState), errors.Is(err, errUnknownBoundaryState):
+		return failureMissingState
+	default:
+		return failureUnknown
+	}
+}
+
+// retryableRegenFailure returns true if regenerating from a different base state may succeed.
+// Canceled requests are not retried, and neither are slots that are not yet covered by backfill
+// since no earlier state is available for them either.
+func retryableRegenFailure(ctx context.Context, class string) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return class != failureCanceled && class != failureUnavailableSlot
+}
+
+// replayFromBase replays the blocks leading to the summary's block root on top of the base state.
+func (s *State) replayFromBase(ctx context.Context, base state.BeaconState, summary *ethpb.StateSummary) (state.BeaconState, error) {
+	if base.Slot() == summary.Slot {
+		return base, nil
+	}
+	blks, err := s.LoadBlocks(ctx, base.Slot()+1, summary.Slot, bytesutil.ToBytes32(summary.Root))
+	if err != nil {
+		return nil, &regenFailure{class: failureMissingBlock, err: errors.Wrap(err, "could not load blocks for hot state using root")}
+	}
+
+	replayBlockCount.Observe(float64(len(blks)))
+
+	st, err := s.ReplayBlocks(ctx, base, blks, summary.Slot)
+	if err != nil {
+		return nil, &regenFailure{class: failureReplay, err: err}
+	}
+	return st, nil
+}
+
+// recoverStateByRoot is called when regenerating the state of the summary's block root failed. It records the
+// failure and retries from the finalized state and from archive points below the given slot, returning the
+// original error once these alternatives are exhausted.
+func (s *State) recoverStateByRoot(ctx context.Context, summary *ethpb.StateSummary, below types.Slot, cause error) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.recoverStateByRoot")
+	defer span.End()
+
+	class := classifyRegenFailure(cause)
+	regenFailureCount.WithLabelValues(class).Inc()
+	if !retryableRegenFailure(ctx, class) {
+		return nil, cause
+	}
+	logger := log.WithFields(logrus.Fields{
+		"slot":         summary.Slot,
+		"root":         fmt.Sprintf("%#x", bytesutil.Trunc(summary.Root)),
+		"failureClass": class,
+	})
+	logger.WithError(cause).Warn("Could not regenerate state, retrying from alternate base states")
+
+	// Replay mutates the base state, so bases are identified by their root before replaying.
+	tried := make(map[[32]byte]bool)
+	attempt := func(base state.BeaconState) state.BeaconState {
+		if base == nil || base.IsNil() || base.Slot() > summary.Slot {
+			return nil
+		}
+		baseSlot := base.Slot()
+		r, err := base.HashTreeRoot(ctx)
+		if err != nil || tried[r] {
+			return nil
+		}
+		tried[r] = true
+		s.traceBaseState(baseSourceRecovery, baseSlot)
+		st, err := s.replayFromAncestorBase(ctx, base, summary)
+		if err != nil {
+			logger.WithError(err).WithField("baseSlot", baseSlot).Debug("Could not regenerate state from alternate base state")
+			return nil
+		}
+		logger.WithField("baseSlot", baseSlot).Info("Regenerated state from alternate base state")
+		return st
+	}
+
+	s.finalizedInfo.lock.RLock()
+	hasFinalizedState := s.finalizedInfo.state != nil
+	s.finalizedInfo.lock.RUnlock()
+	if hasFinalizedState {
+		if st := attempt(s.finalizedState()); st != nil {
+			regenRecoveryCount.WithLabelValues(class).Inc()
+			return st, nil
+		}
+	}
+
+	for i := 0; i < maxAlternateBaseStates && below > 0; i++ {
+		if ctx.Err() != nil {
+			return nil, cause
+		}
+		bases, err := s.beaconDB.HighestSlotStatesBelow(ctx, below)
+		if err != nil || len(bases) == 0 {
+			break
+		}
+		next := below
+		for _, b := range bases {
+			base, ok := b.(state.BeaconState)
+			if !ok || base.IsNil() {
+				continue
+			}
+			if base.Slot() < next {
+				next = base.Slot()
+			}
+			if st := attempt(base); st != nil {
+				regenRecoveryCount.WithLabelValues(class).Inc()
+				return st, nil
+			}
+		}
+		if next >= below {
+			break
+		}
+		below = next
+	}
+	return nil, cause
+}
+
+// replayFromAncestorBase replays blocks on top of an alternate base state, after checking that the base
+// state is an ancestor of the summary's block root.
+func (s *State) replayFromAncestorBase(ctx context.Context, base state.BeaconState, summary *ethpb.StateSummary) (state.BeaconState, error) {
+	baseRoot, err := latestBlockRoot(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	endRoot := bytesutil.ToBytes32(summary.Root)
+	if base.Slot() == summary.Slot {
+		if baseRoot != endRoot {
+			return nil, errors.New("base state is not an ancestor of the requested state")
+		}
+		return base, nil
+	}
+	blks, err := s.LoadBlocks(ctx, base.Slot()+1, summary.Slot, endRoot)
+	if err != nil {
+		return nil, err
+	}
+	if len(blks) == 0 {
+		if baseRoot != endRoot {
+			return nil, errors.New("base state is not an ancestor of the requested state")
+		}
+	} else if bytesutil.ToBytes32(blks[len(blks)-1].Block().ParentRoot()) != baseRoot {
+		return nil, errors.New("base state is not an ancestor of the requested state")
+	}
+
+	replayBlockCount.Observe(float64(len(blks)))
+
+	return s.ReplayBlocks(ctx, base, blks, summary.Slot)
+}
+
+// latestBlockRoot returns the root of the latest block processed by the state.
+func latestBlockRoot(ctx context.Context, st state.BeaconState) ([32]byte, error) {
+	header := st.LatestBlockHeader()
+	if header == nil {
+		return [32]byte{}, errors.New("nil latest block header")
+	}
+	// The state root of the latest block header is only filled in at the next slot.
+	if bytesutil.ToBytes32(header.StateRoot) == params.BeaconConfig().ZeroHash {
+		stateRoot, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		header.StateRoot = stateRoot[:]
+	}
+	return header.HashTreeRoot()
+}
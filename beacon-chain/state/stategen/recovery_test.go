@@ -0,0 +1,140 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestClassifyRegenFailure(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: &regenFailure{class: failureReplay, err: errors.New("bad block")}, want: failureReplay},
+		{err: errors.Wrap(&regenFailure{class: failureCorruptedState, err: errors.New("bad ssz")}, "wrapped"), want: failureCorruptedState},
+		{err: errors.Wrap(context.Canceled, "wrapped"), want: failureCanceled},
+		{err: errors.Wrapf(ErrNoDataForSlot, "slot %d", 1), want: failureUnavailableSlot},
+		{err: errors.Wrap(errUnknownBlock, "could not get ancestor state"), want: failureMissingBlock},
+		{err: wrapper.ErrNilSignedBeaconBlock, want: failureMissingBlock},
+		{err: errUnknownBoundaryState, want: failureMissingState},
+		{err: errors.New("something else"), want: failureUnknown},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, classifyRegenFailure(tt.err), tt.err.Error())
+	}
+}
+
+// setupRecoveryChain saves a genesis block and two descendants b1 and b2, and returns the genesis state and
+// the roots and post states of b1 and b2.
+func setupRecoveryChain(t *testing.T, service *State, saveGenesisState bool) (state.BeaconState, [][32]byte, []state.BeaconState) {
+	ctx := context.Background()
+	genesisState, pks := util.DeterministicGenesisState(t, 32)
+	genesisStateRoot, err := genesisState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	util.SaveBlock(t, ctx, service.beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, service.beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+	if saveGenesisState {
+		require.NoError(t, service.beaconDB.SaveState(ctx, genesisState, gRoot))
+	}
+
+	var roots [][32]byte
+	var states []state.BeaconState
+	st := genesisState.Copy()
+	for slot := types.Slot(1); slot <= 2; slot++ {
+		b, err := util.GenerateFullBlock(st, pks, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := wrapper.WrappedSignedBeaconBlock(b)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, service.beaconDB, b)
+		require.NoError(t, service.beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: r[:]}))
+		roots = append(roots, r)
+		states = append(states, st.Copy())
+	}
+	return genesisState, roots, states
+}
+
+func TestLoadStateByRoot_RecoversFromArchivePoint(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := New(beaconDB)
+	genesisState, roots, states := setupRecoveryChain(t, service, true)
+
+	// A cached state for b1 that does not match its block makes replaying b2 fail.
+	bad := genesisState.Copy()
+	require.NoError(t, bad.SetSlot(1))
+	service.hotStateCache.put(roots[0], bad)
+
+	loadedState, err := service.loadStateByRoot(ctx, roots[1])
+	require.NoError(t, err)
+	wantRoot, err := states[1].HashTreeRoot(ctx)
+	require.NoError(t, err)
+	gotRoot, err := loadedState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+}
+
+func TestLoadStateByRoot_RecoversFromFinalizedState(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := New(beaconDB)
+	genesisState, roots, states := setupRecoveryChain(t, service, false)
+
+	bad := genesisState.Copy()
+	require.NoError(t, bad.SetSlot(1))
+	service.hotStateCache.put(roots[0], bad)
+	service.finalizedInfo = &finalizedInfo{slot: 1, root: roots[0], state: states[0]}
+
+	loadedState, err := service.loadStateByRoot(ctx, roots[1])
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(2), loadedState.Slot())
+	wantRoot, err := states[1].HashTreeRoot(ctx)
+	require.NoError(t, err)
+	gotRoot, err := loadedState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+}
+
+func TestLoadStateByRoot_AlternativesExhausted(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := New(beaconDB)
+	genesisState, roots, _ := setupRecoveryChain(t, service, false)
+
+	bad := genesisState.Copy()
+	require.NoError(t, bad.SetSlot(1))
+	service.hotStateCache.put(roots[0], bad)
+
+	// Neither a finalized state nor any archive point is available.
+	_, err := service.loadStateByRoot(ctx, roots[1])
+	require.ErrorContains(t, "could not process block", err)
+	assert.Equal(t, failureReplay, classifyRegenFailure(err))
+}
+
+func TestLoadStateByRoot_UnavailableSlotNotRetried(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := New(beaconDB)
+	_, roots, _ := setupRecoveryChain(t, service, true)
+
+	_, err := service.recoverStateByRoot(ctx, &ethpb.StateSummary{Slot: 2, Root: roots[1][:]}, 2, errors.Wrap(ErrNoDataForSlot, "slot 1"))
+	require.ErrorIs(t, err, ErrNoDataForSlot)
+}
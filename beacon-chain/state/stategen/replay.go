@@ -25,7 +25,7 @@ import (
 // ReplayBlocks replays the input blocks on the input state until the target slot is reached.
 //
 // WARNING Blocks passed to the function must be in decreasing slots order.
-func (_ *State) ReplayBlocks(
+func (s *State) ReplayBlocks(
 	ctx context.Context,
 	state state.BeaconState,
 	signed []interfaces.SignedBeaconBlock,
@@ -36,6 +36,8 @@ func (_ *State) ReplayBlocks(
 	var err error
 
 	start := time.Now()
+	baseSlot := state.Slot()
+	replayed := 0
 	log.WithFields(logrus.Fields{
 		"startSlot": state.Slot(),
 		"endSlot":   targetSlot,
@@ -58,6 +60,7 @@ func (_ *State) ReplayBlocks(
 			if err != nil {
 				return nil, err
 			}
+			replayed++
 		}
 	}
 
@@ -69,6 +72,7 @@ func (_ *State) ReplayBlocks(
 		}
 	}
 
+	s.traceReplay(baseSlot, state.Slot(), replayed)
 	duration := time.Since(start)
 	log.WithFields(logrus.Fields{
 		"duration": duration,
@@ -0,0 +1,38 @@
+package stategen
+
+import (
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+)
+
+// Sources a base state can be retrieved from when regenerating a state.
+const (
+	baseSourceHotCache           = "hot_cache"
+	baseSourceEpochBoundaryCache = "epoch_boundary_cache"
+	baseSourceFinalized          = "finalized"
+	baseSourceGenesis            = "genesis"
+	baseSourceDB                 = "db"
+	baseSourceHistoryCache       = "history_cache"
+	baseSourceRecovery           = "recovery"
+)
+
+// replayTracer is notified of the decisions made while serving a state: which base state was picked,
+// and how much replay work was done on top of it. It is only set by tests, which compare the decisions
+// against golden traces so that changes to the hot/cold logic don't silently increase replay work.
+type replayTracer interface {
+	baseState(source string, slot types.Slot)
+	replayed(baseSlot, targetSlot types.Slot, blocks int)
+}
+
+func (s *State) traceBaseState(source string, slot types.Slot) {
+	if s == nil || s.tracer == nil {
+		return
+	}
+	s.tracer.baseState(source, slot)
+}
+
+func (s *State) traceReplay(baseSlot, targetSlot types.Slot, blocks int) {
+	if s == nil || s.tracer == nil {
+		return
+	}
+	s.tracer.replayed(baseSlot, targetSlot, blocks)
+}
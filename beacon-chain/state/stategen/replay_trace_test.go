@@ -0,0 +1,220 @@
+package stategen
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+var updateReplayTraces = flag.Bool("update-replay-traces", false, "rewrite the golden replay traces in testdata/replay_traces")
+
+// traceRecorder is a replayTracer which records every decision as a line of text.
+type traceRecorder struct {
+	lines []string
+}
+
+func (r *traceRecorder) baseState(source string, slot types.Slot) {
+	r.lines = append(r.lines, fmt.Sprintf("base source=%s slot=%d", source, slot))
+}
+
+func (r *traceRecorder) replayed(baseSlot, targetSlot types.Slot, blocks int) {
+	r.lines = append(r.lines, fmt.Sprintf("replay base=%d target=%d blocks=%d slots=%d", baseSlot, targetSlot, blocks, targetSlot-baseSlot))
+}
+
+func (r *traceRecorder) String() string {
+	return strings.Join(r.lines, "\n") + "\n"
+}
+
+// requireGoldenTrace compares the recorded trace with testdata/replay_traces/<name>.golden, or rewrites the
+// golden file when the tests are run with -update-replay-traces.
+func requireGoldenTrace(t *testing.T, name string, r *traceRecorder) {
+	path := filepath.Join("testdata", "replay_traces", name+".golden")
+	if *updateReplayTraces {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, []byte(r.String()), 0600))
+		return
+	}
+	want, err := os.ReadFile(path) // #nosec G304
+	require.NoError(t, err, "missing golden trace, run the test with -update-replay-traces to create it")
+	require.Equal(t, string(want), r.String(), "replay decisions differ from %s", path)
+}
+
+// replayTraceSlots is the chain shared by all trace scenarios. States are saved to the DB at slots 8 and 21.
+var replayTraceSlots = []mockHistorySpec{
+	{slot: 1, canonicalBlock: true},
+	{slot: 2, canonicalBlock: true},
+	{slot: 3, canonicalBlock: true},
+	{slot: 5, canonicalBlock: true},
+	{slot: 8, savedState: true, canonicalBlock: true},
+	{slot: 13, canonicalBlock: true},
+	{slot: 21, savedState: true, canonicalBlock: true},
+	{slot: 34, canonicalBlock: true},
+	{slot: 40, canonicalBlock: true},
+}
+
+// setupReplayTraceState writes the blocks and saved states of the mock history to a test DB, and returns
+// a State backed by it which records its decisions.
+func setupReplayTraceState(t *testing.T, hist *mockHistory) (*State, *traceRecorder) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	for root, b := range hist.blocks {
+		r := root
+		require.NoError(t, beaconDB.SaveBlock(ctx, b))
+		require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: b.Block().Slot(), Root: r[:]}))
+	}
+	for root, st := range hist.states {
+		require.NoError(t, beaconDB.SaveState(ctx, st.Copy(), root))
+	}
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, hist.slotMap[0]))
+
+	rec := &traceRecorder{}
+	s := New(beaconDB)
+	s.tracer = rec
+	return s, rec
+}
+
+// expectedState returns the state of the block at the given slot, whether it was saved or not.
+func (m *mockHistory) expectedState(t *testing.T, slot types.Slot) state.BeaconState {
+	root := m.slotMap[slot]
+	if st, ok := m.states[root]; ok {
+		return st
+	}
+	st, ok := m.hiddenStates[root]
+	require.Equal(t, true, ok, "no state for slot %d", slot)
+	return st
+}
+
+func TestReplayTraces_StateByRoot(t *testing.T) {
+	tests := []struct {
+		name   string
+		target types.Slot
+		setup  func(t *testing.T, s *State, hist *mockHistory)
+		query  func(ctx context.Context, s *State, root [32]byte) (state.BeaconState, error)
+	}{
+		{
+			name:   "hot_cache_hit",
+			target: 34,
+			setup: func(t *testing.T, s *State, hist *mockHistory) {
+				s.hotStateCache.put(hist.slotMap[34], hist.expectedState(t, 34).Copy())
+			},
+		},
+		{
+			name:   "db_state",
+			target: 21,
+		},
+		{
+			name:   "db_ancestor_replay",
+			target: 40,
+		},
+		{
+			name:   "epoch_boundary_ancestor_replay",
+			target: 40,
+			setup: func(t *testing.T, s *State, hist *mockHistory) {
+				require.NoError(t, s.epochBoundaryStateCache.put(hist.slotMap[34], hist.expectedState(t, 34).Copy()))
+			},
+		},
+		{
+			name:   "hot_cache_ancestor_replay",
+			target: 5,
+			setup: func(t *testing.T, s *State, hist *mockHistory) {
+				s.hotStateCache.put(hist.slotMap[3], hist.expectedState(t, 3).Copy())
+			},
+		},
+		{
+			name:   "finalized_ancestor_replay",
+			target: 34,
+			setup: func(t *testing.T, s *State, hist *mockHistory) {
+				s.SaveFinalizedState(21, hist.slotMap[21], hist.expectedState(t, 21).Copy())
+			},
+		},
+		{
+			name:   "genesis_ancestor_replay",
+			target: 5,
+		},
+		{
+			name:   "initial_sync_ancestor_replay",
+			target: 13,
+			query: func(ctx context.Context, s *State, root [32]byte) (state.BeaconState, error) {
+				return s.StateByRootInitialSync(ctx, root)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			hist := newMockHistory(t, replayTraceSlots, 41)
+			s, rec := setupReplayTraceState(t, hist)
+			if tt.setup != nil {
+				tt.setup(t, s, hist)
+			}
+			wantRoot, err := hist.expectedState(t, tt.target).HashTreeRoot(ctx)
+			require.NoError(t, err)
+
+			query := tt.query
+			if query == nil {
+				query = func(ctx context.Context, s *State, root [32]byte) (state.BeaconState, error) {
+					return s.StateByRoot(ctx, root)
+				}
+			}
+			st, err := query(ctx, s, hist.slotMap[tt.target])
+			require.NoError(t, err)
+			gotRoot, err := st.HashTreeRoot(ctx)
+			require.NoError(t, err)
+			require.Equal(t, wantRoot, gotRoot)
+			requireGoldenTrace(t, tt.name, rec)
+		})
+	}
+}
+
+func TestReplayTraces_ReplayerForSlot(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []types.Slot
+		cache   bool
+	}{
+		{
+			name:    "replayer_saved_state",
+			targets: []types.Slot{21, 30},
+		},
+		{
+			name:    "replayer_ancestor_replay",
+			targets: []types.Slot{13, 45},
+		},
+		{
+			name:    "replayer_cached_ancestor_replay",
+			targets: []types.Slot{45},
+			cache:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			hist := newMockHistory(t, replayTraceSlots, 46)
+			rec := &traceRecorder{}
+			var opts []CanonicalHistoryOption
+			if tt.cache {
+				c := newHotStateCache()
+				c.put(hist.slotMap[34], hist.expectedState(t, 34).Copy())
+				opts = append(opts, WithCache(&CombinedCache{getters: []CachedGetter{c}}))
+			}
+			ch := NewCanonicalHistory(hist, hist, hist, opts...)
+			ch.tracer = rec
+			for _, target := range tt.targets {
+				st, err := ch.ReplayerForSlot(target).ReplayBlocks(ctx)
+				require.NoError(t, err)
+				require.Equal(t, target, st.Slot())
+			}
+			requireGoldenTrace(t, tt.name, rec)
+		})
+	}
+}
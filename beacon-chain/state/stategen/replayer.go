@@ -67,6 +67,7 @@ type stateReplayer struct {
 	target  types.Slot
 	method  retrievalMethod
 	chainer chainer
+	tracer  replayTracer
 }
 
 // ReplayBlocks applies all the blocks that were accumulated when building the Replayer.
@@ -100,6 +101,7 @@ func (rs *stateReplayer) ReplayBlocks(ctx context.Context) (state.BeaconState, e
 		"diff":      diff,
 	}).Debug("Replaying canonical blocks from most recent state")
 
+	baseSlot := s.Slot()
 	for _, b := range descendants {
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
@@ -115,6 +117,9 @@ func (rs *stateReplayer) ReplayBlocks(ctx context.Context) (state.BeaconState, e
 			return nil, err
 		}
 	}
+	if rs.tracer != nil {
+		rs.tracer.replayed(baseSlot, s.Slot(), len(descendants))
+	}
 
 	duration := time.Since(start)
 	log.WithFields(logrus.Fields{
@@ -51,6 +51,7 @@ type State struct {
 	epochBoundaryStateCache *epochBoundaryState
 	saveHotStateDB          *saveHotStateDbConfig
 	backfillStatus          *backfill.Status
+	tracer                  replayTracer
 }
 
 // This tracks the config in the event of long non-finality,
@@ -81,6 +82,38 @@ func WithBackfillStatus(bfs *backfill.Status) StateGenOption {
 	}
 }
 
+// WithHotStateCacheSize sets the number of hot states held in memory.
+func WithHotStateCacheSize(size int) StateGenOption {
+	return func(sg *State) {
+		if size > 0 {
+			sg.hotStateCache.resize(size)
+		}
+	}
+}
+
+// WithStateSpill keeps at most memoryCap epoch boundary states in memory while regenerating states.
+// Older epoch boundary states are spilled to the scratch directory instead of being discarded, so
+// long periods of non-finality do not force replays from the finalized state. A memoryCap of 0
+// disables spilling.
+func WithStateSpill(dir string, memoryCap int) StateGenOption {
+	return func(sg *State) {
+		if memoryCap > 0 {
+			sg.epochBoundaryStateCache.enableSpill(dir, uint64(memoryCap))
+		}
+	}
+}
+
+// WithHotStateSpill keeps at most memoryCap hot states in memory. The least recently used hot states
+// are spilled to the scratch directory instead of being discarded, and are reloaded when needed again.
+// It replaces the hot state cache size. A memoryCap of 0 disables spilling.
+func WithHotStateSpill(dir string, memoryCap int) StateGenOption {
+	return func(sg *State) {
+		if memoryCap > 0 {
+			sg.hotStateCache.enableSpill(dir, memoryCap)
+		}
+	}
+}
+
 // New returns a new state management object.
 func New(beaconDB db.NoHeadAccessDatabase, opts ...StateGenOption) *State {
 	s := &State{
@@ -100,6 +133,14 @@ func New(beaconDB db.NoHeadAccessDatabase, opts ...StateGenOption) *State {
 	return s
 }
 
+// SetHotStateCacheSize changes the number of hot states held in memory at runtime.
+// States exceeding the new size are evicted, oldest first.
+func (s *State) SetHotStateCacheSize(size int) {
+	if evicted := s.hotStateCache.resize(size); evicted > 0 {
+		log.WithField("evicted", evicted).Debug("Evicted states from hot state cache")
+	}
+}
+
 // Resume resumes a new state management object from previously saved finalized checkpoint in DB.
 func (s *State) Resume(ctx context.Context, fState state.BeaconState) (state.BeaconState, error) {
 	ctx, span := trace.StartSpan(ctx, "stateGen.Resume")
@@ -0,0 +1,143 @@
+package stategen
+
+import (
+	"container/list"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/io/file"
+)
+
+// maxSpilledStates bounds the number of states kept in the spill directory. The least recently
+// needed states are removed first once the bound is reached.
+const maxSpilledStates = 64
+
+// stateSpill is an on-disk scratch area for states evicted from memory while regenerating states.
+// It keeps long periods of non-finality from either exhausting memory or forcing replays from the
+// finalized state. The content of the directory is only meaningful for the running process.
+type stateSpill struct {
+	dir     string
+	maxSize int
+	lock    sync.Mutex
+	order   *list.List
+	entries map[[32]byte]*list.Element
+	created bool
+	// Metrics of the spilled states, which differ per kind of spilled state.
+	countGauge  prometheus.Gauge
+	readCounter prometheus.Counter
+}
+
+// newStateSpill returns a spill area in the given directory. Leftovers of a previous run are removed.
+func newStateSpill(dir string, maxSize int) *stateSpill {
+	if err := os.RemoveAll(dir); err != nil {
+		log.WithError(err).WithField("dir", dir).Warn("Could not clear state spill directory")
+	}
+	return &stateSpill{
+		dir:     dir,
+		maxSize: maxSize,
+		order:   list.New(),
+		entries: make(map[[32]byte]*list.Element),
+
+		countGauge:  spilledStateCount,
+		readCounter: spilledStateReadCount,
+	}
+}
+
+// put writes the state to disk, evicting the least recently needed spilled states beyond the bound.
+func (s *stateSpill) put(root [32]byte, st state.BeaconState) error {
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal state")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.created {
+		if err := file.MkdirAll(s.dir); err != nil {
+			return errors.Wrap(err, "could not create state spill directory")
+		}
+		s.created = true
+	}
+	if err := file.WriteFile(s.path(root), enc); err != nil {
+		return errors.Wrap(err, "could not write spilled state")
+	}
+	if e, ok := s.entries[root]; ok {
+		s.order.MoveToFront(e)
+	} else {
+		s.entries[root] = s.order.PushFront(root)
+	}
+	for s.order.Len() > s.maxSize {
+		if err := s.removeLockFree(s.order.Back().Value.([32]byte)); err != nil {
+			return err
+		}
+	}
+	s.countGauge.Set(float64(s.order.Len()))
+	return nil
+}
+
+// get reads a spilled state from disk. The returned state is not shared and does not need to be copied.
+func (s *stateSpill) get(root [32]byte) (state.BeaconState, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[root]
+	if !ok {
+		return nil, false, nil
+	}
+	s.order.MoveToFront(e)
+
+	enc, err := file.ReadFileAsBytes(s.path(root))
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not read spilled state")
+	}
+	unmarshaler, err := detect.FromState(enc)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not detect version of spilled state")
+	}
+	st, err := unmarshaler.UnmarshalBeaconState(enc)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not unmarshal spilled state")
+	}
+	s.readCounter.Inc()
+	return st, true, nil
+}
+
+// has returns true if the state of the block root is spilled.
+func (s *stateSpill) has(root [32]byte) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, ok := s.entries[root]
+	return ok
+}
+
+// delete removes the spilled state of the block root, if any.
+func (s *stateSpill) delete(root [32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.entries[root]; !ok {
+		return nil
+	}
+	if err := s.removeLockFree(root); err != nil {
+		return err
+	}
+	s.countGauge.Set(float64(s.order.Len()))
+	return nil
+}
+
+func (s *stateSpill) removeLockFree(root [32]byte) error {
+	s.order.Remove(s.entries[root])
+	delete(s.entries, root)
+	if err := os.Remove(s.path(root)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "could not remove spilled state")
+	}
+	return nil
+}
+
+func (s *stateSpill) path(root [32]byte) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%#x.ssz", root))
+}
@@ -0,0 +1,255 @@
+package backfill
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/crypto/rand"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var _ runtime.Service = (*Service)(nil)
+
+const (
+	// batchSize is the number of slots requested from a peer in a single blocks by range request.
+	batchSize = 64
+	// retryInterval is how long the service waits before retrying a batch which could not be imported.
+	retryInterval = 5 * time.Second
+)
+
+var (
+	errNoPeers          = errors.New("no peers available to backfill from")
+	errUnlinkedBlock    = errors.New("block does not descend from the lowest backfilled block")
+	errMissedGenesis    = errors.New("reached slot 0 without linking to the genesis block")
+	errNonAscendingSlot = errors.New("blocks were not returned in ascending slot order")
+)
+
+// BlockFetcher requests a range of blocks from the given peer over the blocks by range RPC.
+type BlockFetcher func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.SignedBeaconBlock, error)
+
+// ServiceDB describes the set of DB methods the backfill Service needs in addition to those of Status.
+type ServiceDB interface {
+	BackfillDB
+	SaveBlocks(ctx context.Context, blocks []interfaces.SignedBeaconBlock) error
+	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
+}
+
+// Config to set up the backfill service.
+type Config struct {
+	DB     ServiceDB
+	Status *Status
+	P2P    p2p.PeersProvider
+	Fetch  BlockFetcher
+	// RetentionEpochs is the number of epochs before the finalized checkpoint for which blocks are kept
+	// in the database. Blocks older than that would be pruned right away, so they are not backfilled.
+	// 0 backfills up to genesis.
+	RetentionEpochs types.Epoch
+}
+
+// Service retrieves the blocks missing between genesis, or the start of the retention period when one
+// is configured, and the origin checkpoint of a node which was initialized via checkpoint sync. Blocks are requested backwards from the lowest block in the database,
+// each batch is verified to descend from it by following parent roots and is persisted before the
+// backfill Status is advanced, so that the process can be resumed after a restart.
+type Service struct {
+	cfg    *Config
+	ctx    context.Context
+	cancel context.CancelFunc
+	// cursor is the exclusive upper bound of the next slot range to request. It can be lower
+	// than the backfill Status position when the requested ranges only contained skipped slots.
+	cursor types.Slot
+}
+
+// NewService initializes the backfill service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start the backfill process in the background.
+func (s *Service) Start() {
+	if s.cfg.Status.complete() {
+		log.Debug("No blocks to backfill, exiting backfill service")
+		return
+	}
+	s.cursor = s.cfg.Status.StartGap()
+	log.WithFields(logrus.Fields{
+		"startSlot": s.cfg.Status.StartGap(),
+		"endSlot":   s.cfg.Status.EndGap(),
+	}).Info("Backfilling blocks missing before the origin checkpoint")
+	go s.run()
+}
+
+// Stop the backfill process.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the backfill service.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	for {
+		if s.ctx.Err() != nil {
+			return
+		}
+		done, err := s.importBatch(s.ctx)
+		if err != nil {
+			log.WithError(err).Debug("Could not backfill batch of blocks, retrying")
+			s.cursor = s.cfg.Status.StartGap()
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(retryInterval):
+			}
+			continue
+		}
+		if done {
+			if s.cfg.Status.complete() {
+				log.Info("Backfilled all blocks up to genesis")
+			} else {
+				log.WithField("slot", s.cfg.Status.StartGap()).Info("Backfilled all blocks within the retention period")
+			}
+			return
+		}
+	}
+}
+
+// importBatch requests the batch of blocks right below the cursor from a random peer, and saves
+// those which descend from the lowest backfilled block. It returns true once the genesis block, or the start
+// of the retention period, is reached.
+func (s *Service) importBatch(ctx context.Context) (bool, error) {
+	genesisRoot, err := s.cfg.DB.GenesisBlockRoot(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get genesis block root")
+	}
+	lowRoot, err := s.cfg.DB.BackfillBlockRoot(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get backfill block root")
+	}
+	low, err := s.cfg.DB.Block(ctx, lowRoot)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not get backfill block %#x", lowRoot)
+	}
+	if err := wrapper.BeaconBlockIsNil(low); err != nil {
+		return false, err
+	}
+	parent := bytesutil.ToBytes32(low.Block().ParentRoot())
+	if parent == genesisRoot {
+		return true, s.cfg.Status.Advance(ctx, 0, genesisRoot)
+	}
+	horizon, err := s.retentionHorizon(ctx)
+	if err != nil {
+		return false, err
+	}
+	if horizon > 0 && s.cursor <= horizon {
+		return true, nil
+	}
+	if s.cursor == 0 {
+		return false, errMissedGenesis
+	}
+
+	count := types.Slot(batchSize)
+	if s.cursor < horizon+count {
+		count = s.cursor - horizon
+	}
+	req := &ethpb.BeaconBlocksByRangeRequest{
+		StartSlot: s.cursor - count,
+		Count:     uint64(count),
+		Step:      1,
+	}
+	pid, err := s.pickPeer()
+	if err != nil {
+		return false, err
+	}
+	blks, err := s.cfg.Fetch(ctx, pid, req)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not request blocks from peer %s", pid)
+	}
+	if len(blks) == 0 {
+		// The whole range consists of skipped slots, keep searching below it.
+		s.cursor = req.StartSlot
+		return false, nil
+	}
+
+	// Walk the batch from the highest block down, each block must be the parent of the one above it.
+	for i := len(blks) - 1; i >= 0; i-- {
+		if err := wrapper.BeaconBlockIsNil(blks[i]); err != nil {
+			return false, err
+		}
+		if i > 0 && blks[i-1].Block().Slot() >= blks[i].Block().Slot() {
+			return false, errNonAscendingSlot
+		}
+		root, err := blks[i].Block().HashTreeRoot()
+		if err != nil {
+			return false, err
+		}
+		if root != parent {
+			return false, errors.Wrapf(errUnlinkedBlock, "slot=%d, root=%#x", blks[i].Block().Slot(), root)
+		}
+		parent = bytesutil.ToBytes32(blks[i].Block().ParentRoot())
+	}
+	if err := s.cfg.DB.SaveBlocks(ctx, blks); err != nil {
+		return false, errors.Wrap(err, "could not save backfilled blocks")
+	}
+	lowest := blks[0]
+	lowestRoot, err := lowest.Block().HashTreeRoot()
+	if err != nil {
+		return false, err
+	}
+	if err := s.cfg.Status.Advance(ctx, lowest.Block().Slot(), lowestRoot); err != nil {
+		return false, err
+	}
+	s.cursor = lowest.Block().Slot()
+	log.WithFields(logrus.Fields{
+		"slot":   lowest.Block().Slot(),
+		"blocks": len(blks),
+	}).Debug("Backfilled batch of blocks")
+
+	if lowestRoot == genesisRoot || parent == genesisRoot {
+		return true, s.cfg.Status.Advance(ctx, 0, genesisRoot)
+	}
+	return false, nil
+}
+
+// retentionHorizon returns the first slot of the retention period, below which blocks are pruned and are
+// therefore not backfilled. It returns 0 when the full history is kept.
+func (s *Service) retentionHorizon(ctx context.Context) (types.Slot, error) {
+	if s.cfg.RetentionEpochs == 0 {
+		return 0, nil
+	}
+	cp, err := s.cfg.DB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	if cp.Epoch <= s.cfg.RetentionEpochs {
+		return 0, nil
+	}
+	return slots.EpochStart(cp.Epoch - s.cfg.RetentionEpochs)
+}
+
+// pickPeer returns a random connected peer whose finalized checkpoint is not behind the origin checkpoint.
+func (s *Service) pickPeer() (peer.ID, error) {
+	_, pids := s.cfg.P2P.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, slots.ToEpoch(s.cfg.Status.EndGap()))
+	if len(pids) == 0 {
+		return "", errNoPeers
+	}
+	return pids[rand.NewGenerator().Intn(len(pids))], nil
+}
@@ -0,0 +1,201 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	p2pt "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// setupBackfill builds a chain of blocks from genesis up to originSlot, leaving the given slots empty, and
+// initializes a database as checkpoint sync would, with only the genesis and origin blocks saved.
+func setupBackfill(t *testing.T, originSlot types.Slot, skipped func(types.Slot) bool) (*kv.Store, *Status, map[types.Slot]interfaces.SignedBeaconBlock) {
+	ctx := context.Background()
+	store, ok := dbtest.SetupDB(t).(*kv.Store)
+	require.Equal(t, true, ok)
+
+	chain := make(map[types.Slot]interfaces.SignedBeaconBlock)
+	var parent [32]byte
+	for i := types.Slot(0); i <= originSlot; i++ {
+		if i != 0 && i != originSlot && skipped(i) {
+			continue
+		}
+		b := util.NewBeaconBlock()
+		b.Block.Slot = i
+		b.Block.ParentRoot = bytesutil.SafeCopyBytes(parent[:])
+		wsb, err := wrapper.WrappedSignedBeaconBlock(b)
+		require.NoError(t, err)
+		parent, err = wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		chain[i] = wsb
+	}
+
+	genesisRoot, err := chain[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveBlock(ctx, chain[0]))
+	require.NoError(t, store.SaveGenesisBlockRoot(ctx, genesisRoot))
+	require.NoError(t, store.SaveBlock(ctx, chain[originSlot]))
+	require.NoError(t, store.SaveOriginCheckpointBlockRoot(ctx, parent))
+	require.NoError(t, store.SaveBackfillBlockRoot(ctx, parent))
+
+	status := NewStatus(store)
+	require.NoError(t, status.Reload(ctx))
+	return store, status, chain
+}
+
+func testService(t *testing.T, store *kv.Store, status *Status, fetch BlockFetcher) *Service {
+	p := p2pt.NewTestP2P(t)
+	pid := peer.ID("backfill-peer")
+	p.Peers().Add(new(enr.Record), pid, nil, network.DirOutbound)
+	p.Peers().SetConnectionState(pid, peers.PeerConnected)
+	p.Peers().SetChainState(pid, &ethpb.Status{FinalizedEpoch: 1000})
+	s := NewService(context.Background(), &Config{
+		DB:     store,
+		Status: status,
+		P2P:    p,
+		Fetch:  fetch,
+	})
+	s.cursor = status.StartGap()
+	return s
+}
+
+func rangeFetcher(chain map[types.Slot]interfaces.SignedBeaconBlock) BlockFetcher {
+	return func(_ context.Context, _ peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.SignedBeaconBlock, error) {
+		var blks []interfaces.SignedBeaconBlock
+		for i := req.StartSlot; i < req.StartSlot.Add(req.Count); i++ {
+			if b, ok := chain[i]; ok {
+				blks = append(blks, b)
+			}
+		}
+		return blks, nil
+	}
+}
+
+func TestService_ImportBatch(t *testing.T) {
+	ctx := context.Background()
+	originSlot := types.Slot(300)
+	// Leave a gap of more than a batch of empty slots, as well as some scattered skipped slots.
+	skipped := func(sl types.Slot) bool {
+		return sl%7 == 0 || (sl > 100 && sl < 200)
+	}
+	store, status, chain := setupBackfill(t, originSlot, skipped)
+	s := testService(t, store, status, rangeFetcher(chain))
+
+	done := false
+	for i := 0; i < 10 && !done; i++ {
+		var err error
+		done, err = s.importBatch(ctx)
+		require.NoError(t, err)
+	}
+	require.Equal(t, true, done)
+	assert.Equal(t, types.Slot(0), status.StartGap())
+	assert.Equal(t, true, status.complete())
+	for sl, b := range chain {
+		r, err := b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, true, store.HasBlock(ctx, r), "missing block at slot %d", sl)
+	}
+}
+
+func TestService_ImportBatch_Unlinked(t *testing.T) {
+	ctx := context.Background()
+	originSlot := types.Slot(100)
+	store, status, chain := setupBackfill(t, originSlot, func(types.Slot) bool { return false })
+
+	// Replace the block right below the origin with one that is not its parent.
+	b := util.NewBeaconBlock()
+	b.Block.Slot = originSlot - 1
+	b.Block.ProposerIndex = 1
+	forged, err := wrapper.WrappedSignedBeaconBlock(b)
+	require.NoError(t, err)
+	chain[originSlot-1] = forged
+	s := testService(t, store, status, rangeFetcher(chain))
+
+	_, err = s.importBatch(ctx)
+	require.ErrorIs(t, err, errUnlinkedBlock)
+	assert.Equal(t, originSlot, status.StartGap())
+	r, err := chain[originSlot-2].Block().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, false, store.HasBlock(ctx, r))
+}
+
+func TestService_ImportBatch_RetentionPeriod(t *testing.T) {
+	ctx := context.Background()
+	originSlot := types.Slot(300)
+	store, status, chain := setupBackfill(t, originSlot, func(types.Slot) bool { return false })
+	originRoot, err := chain[originSlot].Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: originSlot, Root: originRoot[:]}))
+	finalized := slots.ToEpoch(originSlot)
+	require.NoError(t, store.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: finalized, Root: originRoot[:]}))
+
+	retention := types.Epoch(4)
+	horizon, err := slots.EpochStart(finalized - retention)
+	require.NoError(t, err)
+	s := testService(t, store, status, rangeFetcher(chain))
+	s.cfg.RetentionEpochs = retention
+
+	done := false
+	for i := 0; i < 10 && !done; i++ {
+		done, err = s.importBatch(ctx)
+		require.NoError(t, err)
+	}
+	require.Equal(t, true, done)
+	assert.Equal(t, horizon, status.StartGap())
+	assert.Equal(t, false, status.complete())
+	for sl, b := range chain {
+		r, err := b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, sl == 0 || sl >= horizon, store.HasBlock(ctx, r), "unexpected block presence at slot %d", sl)
+	}
+
+	// Pruning up to the start of the retention period keeps every backfilled block.
+	require.NoError(t, store.PruneBefore(ctx, horizon))
+	for sl, b := range chain {
+		r, err := b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, sl == 0 || sl >= horizon, store.HasBlock(ctx, r), "unexpected block presence at slot %d", sl)
+	}
+
+	// Once the retention period moves forward, a restarted backfill does not request the pruned blocks again.
+	require.NoError(t, store.PruneBefore(ctx, horizon+params.BeaconConfig().SlotsPerEpoch))
+	status = NewStatus(store)
+	require.NoError(t, status.Reload(ctx))
+	s = testService(t, store, status, func(context.Context, peer.ID, *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.SignedBeaconBlock, error) {
+		t.Fatal("unexpected request for blocks beyond the retention period")
+		return nil, nil
+	})
+	s.cfg.RetentionEpochs = retention
+	done, err = s.importBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, done)
+}
+
+func TestService_ImportBatch_NoPeers(t *testing.T) {
+	store, status, _ := setupBackfill(t, 10, func(types.Slot) bool { return false })
+	s := NewService(context.Background(), &Config{
+		DB:     store,
+		Status: status,
+		P2P:    p2pt.NewTestP2P(t),
+		Fetch:  rangeFetcher(nil),
+	})
+	s.cursor = status.StartGap()
+	_, err := s.importBatch(context.Background())
+	require.ErrorIs(t, err, errNoPeers)
+}
@@ -2,6 +2,7 @@ package backfill
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
@@ -23,6 +24,7 @@ func NewStatus(store BackfillDB) *Status {
 // end of the missing block range via the Advance() method, to check whether a Slot is missing from the database
 // via the SlotCovered() method, and to see the current StartGap() and EndGap().
 type Status struct {
+	mu          sync.RWMutex
 	start       types.Slot
 	end         types.Slot
 	store       BackfillDB
@@ -33,11 +35,13 @@ type Status struct {
 // If the slot is <= StartGap(), or >= EndGap(), the result is true.
 // If the slot is between StartGap() and EndGap(), the result is false.
 func (s *Status) SlotCovered(sl types.Slot) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	// short circuit if the node was synced from genesis
 	if s.genesisSync {
 		return true
 	}
-	if s.StartGap() < sl && sl < s.EndGap() {
+	if s.start < sl && sl < s.end {
 		return false
 	}
 	return true
@@ -45,20 +49,34 @@ func (s *Status) SlotCovered(sl types.Slot) bool {
 
 // StartGap returns the slot at the beginning of the range that needs to be backfilled.
 func (s *Status) StartGap() types.Slot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.start
 }
 
 // EndGap returns the slot at the end of the range that needs to be backfilled.
 func (s *Status) EndGap() types.Slot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.end
 }
 
+// complete is true when there is no gap left to backfill, either because the node was synced from genesis
+// or because the backfill process reached genesis.
+func (s *Status) complete() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.genesisSync || s.start == 0
+}
+
 var ErrAdvancePastOrigin = errors.New("cannot advance backfill Status beyond the origin checkpoint slot")
 
 // Advance advances the backfill position to the given slot & root.
 // It updates the backfill block root entry in the database,
 // and also updates the Status value's copy of the backfill position slot.
 func (s *Status) Advance(ctx context.Context, upTo types.Slot, root [32]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if upTo > s.end {
 		return errors.Wrapf(ErrAdvancePastOrigin, "advance slot=%d, origin slot=%d", upTo, s.end)
 	}
@@ -68,6 +86,8 @@ func (s *Status) Advance(ctx context.Context, upTo types.Slot, root [32]byte) er
 
 // Reload queries the database for backfill status, initializing the internal data and validating the database state.
 func (s *Status) Reload(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	cpRoot, err := s.store.OriginCheckpointBlockRoot(ctx)
 	if err != nil {
 		// mark genesis sync and short circuit further lookups
@@ -6,6 +6,7 @@ import (
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/crypto/bls"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
@@ -50,31 +51,44 @@ func (s *Service) verifierRoutine() {
 	}
 }
 
-func (s *Service) validateWithBatchVerifier(ctx context.Context, message string, set *bls.SignatureBatch) (pubsub.ValidationResult, error) {
+func (s *Service) validateWithBatchVerifier(ctx context.Context, message string, msgType signing.MessageType, set *bls.SignatureBatch) (pubsub.ValidationResult, error) {
 	_, span := trace.StartSpan(ctx, "sync.validateWithBatchVerifier")
 	defer span.End()
 
+	// Only message types using the batch path are joined with other messages,
+	// the others are verified right away.
+	if signing.PathFor(msgType) != signing.BatchPath {
+		verified, err := signing.VerifySet(msgType, set)
+		return verificationResult(span, message, verified, err)
+	}
+
 	resChan := make(chan error)
 	verificationSet := &signatureVerifier{set: set.Copy(), resChan: resChan}
 	s.signatureChan <- verificationSet
 
 	resErr := <-resChan
 	close(resChan)
+	if resErr == nil {
+		signing.ObserveVerification(msgType, signing.BatchPath, true, nil)
+		return pubsub.ValidationAccept, nil
+	}
 	// If verification fails we fallback to individual verification
 	// of each signature set.
-	if resErr != nil {
-		log.WithError(resErr).Tracef("Could not perform batch verification of %s", message)
-		verified, err := set.Verify()
-		if err != nil {
-			verErr := errors.Wrapf(err, "Could not verify %s", message)
-			tracing.AnnotateError(span, verErr)
-			return pubsub.ValidationReject, verErr
-		}
-		if !verified {
-			verErr := errors.Errorf("Verification of %s failed", message)
-			tracing.AnnotateError(span, verErr)
-			return pubsub.ValidationReject, verErr
-		}
+	log.WithError(resErr).Tracef("Could not perform batch verification of %s", message)
+	verified, err := signing.VerifyFallback(msgType, signing.BatchPath, set)
+	return verificationResult(span, message, verified, err)
+}
+
+func verificationResult(span *trace.Span, message string, verified bool, err error) (pubsub.ValidationResult, error) {
+	if err != nil {
+		verErr := errors.Wrapf(err, "Could not verify %s", message)
+		tracing.AnnotateError(span, verErr)
+		return pubsub.ValidationReject, verErr
+	}
+	if !verified {
+		verErr := errors.Errorf("Verification of %s failed", message)
+		tracing.AnnotateError(span, verErr)
+		return pubsub.ValidationReject, verErr
 	}
 	return pubsub.ValidationAccept, nil
 }
@@ -2,11 +2,15 @@ package sync
 
 import (
 	"context"
+	gosync "sync"
 	"testing"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/crypto/bls"
 	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
 )
 
@@ -30,6 +34,7 @@ func TestValidateWithBatchVerifier(t *testing.T) {
 		message       string
 		set           *bls.SignatureBatch
 		preFilledSets []*bls.SignatureBatch
+		path          signing.Path
 		want          pubsub.ValidationResult
 	}{
 		{
@@ -58,9 +63,26 @@ func TestValidateWithBatchVerifier(t *testing.T) {
 			preFilledSets: []*bls.SignatureBatch{validSet},
 			want:          pubsub.ValidationReject,
 		},
+		{
+			name:    "valid set verified outside of routine",
+			message: "random",
+			set:     validSet,
+			path:    signing.FastAggregateVerifyPath,
+			want:    pubsub.ValidationAccept,
+		},
+		{
+			name:    "invalid set verified outside of routine",
+			message: "random",
+			set:     invalidSet,
+			path:    signing.AggregateVerifyPath,
+			want:    pubsub.ValidationReject,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.path != "" {
+				defer signing.SetPathFor(signing.AttestationMessage, tt.path)()
+			}
 			ctx, cancel := context.WithCancel(context.Background())
 			svc := &Service{
 				ctx:           ctx,
@@ -69,9 +91,9 @@ func TestValidateWithBatchVerifier(t *testing.T) {
 			}
 			go svc.verifierRoutine()
 			for _, st := range tt.preFilledSets {
-				svc.signatureChan <- &signatureVerifier{set: st, resChan: make(chan error, 10)}
+				svc.signatureChan <- &signatureVerifier{set: st.Copy(), resChan: make(chan error, 10)}
 			}
-			got, err := svc.validateWithBatchVerifier(context.Background(), tt.message, tt.set)
+			got, err := svc.validateWithBatchVerifier(context.Background(), tt.message, signing.AttestationMessage, tt.set)
 			if got != tt.want {
 				t.Errorf("validateWithBatchVerifier() = %v, want %v", got, tt.want)
 			}
@@ -82,3 +104,53 @@ func TestValidateWithBatchVerifier(t *testing.T) {
 		})
 	}
 }
+
+// Sync committee messages are already batch verified: validateSyncCommitteeMessage hands its signature set to
+// validateWithBatchVerifier, whose verifierRoutine buffers sets for signatureVerificationInterval and falls back
+// to individual verification when the batch fails. This only covers that existing path.
+func TestValidateWithBatchVerifier_SyncCommitteeMessages(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableBatchGossipAggregation: true})
+	defer resetCfg()
+
+	_, keys, err := util.DeterministicDepositsAndKeys(16)
+	require.NoError(t, err)
+	// Messages of a subnet sign the same block root, so they are aggregated before being batch verified.
+	root := [32]byte{'r'}
+	sets := make([]*bls.SignatureBatch, len(keys))
+	for i, k := range keys {
+		signer := k
+		if i == len(keys)-1 {
+			signer = keys[0]
+		}
+		sets[i] = &bls.SignatureBatch{
+			Messages:   [][32]byte{root},
+			PublicKeys: []bls.PublicKey{k.PublicKey()},
+			Signatures: [][]byte{signer.Sign(root[:]).Marshal()},
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
+	}
+	go svc.verifierRoutine()
+
+	results := make([]pubsub.ValidationResult, len(sets))
+	var wg gosync.WaitGroup
+	for i, set := range sets {
+		wg.Add(1)
+		go func(i int, set *bls.SignatureBatch) {
+			defer wg.Done()
+			results[i], _ = svc.validateWithBatchVerifier(ctx, "sync committee message", signing.SyncCommitteeMessage, set)
+		}(i, set)
+	}
+	wg.Wait()
+	for i := 0; i < len(sets)-1; i++ {
+		assert.Equal(t, pubsub.ValidationAccept, results[i])
+	}
+	// The invalid message fails the batch, its signature is then rejected on its own.
+	assert.Equal(t, pubsub.ValidationReject, results[len(sets)-1])
+}
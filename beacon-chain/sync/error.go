@@ -17,8 +17,8 @@ var responseCodeSuccess = byte(0x00)
 var responseCodeInvalidRequest = byte(0x01)
 var responseCodeServerError = byte(0x02)
 
-func (s *Service) generateErrorResponse(code byte, reason string) ([]byte, error) {
-	return createErrorResponse(code, reason, s.cfg.p2p)
+func (s *Service) generateErrorResponse(code byte, reason string, encoding encoder.NetworkEncoding) ([]byte, error) {
+	return createErrorResponse(code, reason, encoding)
 }
 
 // ReadStatusCode response from a RPC stream.
@@ -49,7 +49,7 @@ func ReadStatusCode(stream network.Stream, encoding encoder.NetworkEncoding) (ui
 }
 
 func writeErrorResponseToStream(responseCode byte, reason string, stream libp2pcore.Stream, encoder p2p.EncodingProvider) {
-	resp, err := createErrorResponse(responseCode, reason, encoder)
+	resp, err := createErrorResponse(responseCode, reason, p2p.StreamEncoding(encoder, stream))
 	if err != nil {
 		log.WithError(err).Debug("Could not generate a response error")
 	} else if _, err := stream.Write(resp); err != nil {
@@ -60,10 +60,10 @@ func writeErrorResponseToStream(responseCode byte, reason string, stream libp2pc
 	}
 }
 
-func createErrorResponse(code byte, reason string, encoder p2p.EncodingProvider) ([]byte, error) {
+func createErrorResponse(code byte, reason string, encoding encoder.NetworkEncoding) ([]byte, error) {
 	buf := bytes.NewBuffer([]byte{code})
 	errMsg := types.ErrorMessage(reason)
-	if _, err := encoder.Encoding().EncodeWithMaxLength(buf, &errMsg); err != nil {
+	if _, err := encoding.EncodeWithMaxLength(buf, &errMsg); err != nil {
 		return nil, err
 	}
 
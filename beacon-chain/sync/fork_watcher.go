@@ -1,8 +1,13 @@
 package sync
 
 import (
+	"bytes"
+	"math"
+
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/network/forks"
@@ -36,82 +41,57 @@ func (s *Service) forkWatcher() {
 	}
 }
 
-// Checks if there is a fork in the next epoch and if there is
-// it registers the appropriate gossip and rpc topics.
+// Subscribes to the gossip topics, and registers the rpc handlers, of every fork scheduled
+// within the next few epochs so that no message is missed at the fork boundary.
 func (s *Service) registerForUpcomingFork(currEpoch types.Epoch) error {
 	genRoot := s.cfg.chain.GenesisValidatorsRoot()
-	isNextForkEpoch, err := forks.IsForkNextEpoch(s.cfg.chain.GenesisTime(), genRoot[:])
+	fSchedule := params.BeaconConfig().ForkVersionSchedule
+	lastEpoch, err := currEpoch.SafeAdd(uint64(forkTopicsLeadEpochs()))
 	if err != nil {
-		return errors.Wrap(err, "Could not retrieve next fork epoch")
+		lastEpoch = math.MaxUint64
 	}
-	// In preparation for the upcoming fork
-	// in the following epoch, the node
-	// will subscribe the new topics in advance.
-	if isNextForkEpoch {
-		nextEpoch := currEpoch + 1
-		switch nextEpoch {
-		case params.BeaconConfig().AltairForkEpoch:
-			digest, err := forks.ForkDigestFromEpoch(nextEpoch, genRoot[:])
-			if err != nil {
-				return errors.Wrap(err, "Could not retrieve fork digest")
-			}
-			if s.subHandler.digestExists(digest) {
-				return nil
-			}
-			s.registerSubscribers(nextEpoch, digest)
+	for _, forkVersion := range forks.SortedForkVersions(fSchedule) {
+		forkEpoch := fSchedule[forkVersion]
+		if forkEpoch <= currEpoch || forkEpoch > lastEpoch {
+			continue
+		}
+		digest, err := signing.ComputeForkDigest(forkVersion[:], genRoot[:])
+		if err != nil {
+			return errors.Wrap(err, "could not compute fork digest")
+		}
+		if s.subHandler.digestExists(digest) {
+			continue
+		}
+		s.registerSubscribers(forkEpoch, digest)
+		if forkEpoch == params.BeaconConfig().AltairForkEpoch {
 			s.registerRPCHandlersAltair()
-		case params.BeaconConfig().BellatrixForkEpoch:
-			digest, err := forks.ForkDigestFromEpoch(nextEpoch, genRoot[:])
-			if err != nil {
-				return errors.Wrap(err, "could not retrieve fork digest")
-			}
-			if s.subHandler.digestExists(digest) {
-				return nil
-			}
-			s.registerSubscribers(nextEpoch, digest)
 		}
 	}
 	return nil
 }
 
-// Checks if there was a fork in the previous epoch, and if there
-// was then we deregister the topics from that particular fork.
+// Unsubscribes from the gossip topics of every fork superseded by a later fork more than
+// a few epochs ago, along with the rpc handlers of the genesis fork.
 func (s *Service) deregisterFromPastFork(currEpoch types.Epoch) error {
 	genRoot := s.cfg.chain.GenesisValidatorsRoot()
-	// This method takes care of the de-registration of
-	// old gossip pubsub handlers. Once we are at the epoch
-	// after the fork, we de-register from all the outdated topics.
-	currFork, err := forks.Fork(currEpoch)
-	if err != nil {
-		return err
-	}
-	// If we are still in our genesis fork version then
-	// we simply exit early.
-	if currFork.Epoch == params.BeaconConfig().GenesisEpoch {
-		return nil
-	}
-	epochAfterFork := currFork.Epoch + 1
-	// If we are in the epoch after the fork, we start de-registering.
-	if epochAfterFork == currEpoch {
-		// Look at the previous fork's digest.
-		epochBeforeFork := currFork.Epoch - 1
-		prevDigest, err := forks.ForkDigestFromEpoch(epochBeforeFork, genRoot[:])
-		if err != nil {
-			return errors.Wrap(err, "Failed to determine previous epoch fork digest")
-		}
-
-		// Exit early if there are no topics with that particular
-		// digest.
-		if !s.subHandler.digestExists(prevDigest) {
-			return nil
+	fSchedule := params.BeaconConfig().ForkVersionSchedule
+	sortedVersions := forks.SortedForkVersions(fSchedule)
+	for i := 0; i+1 < len(sortedVersions); i++ {
+		// A fork is superseded at the epoch of the next fork in the schedule.
+		supersededEpoch := fSchedule[sortedVersions[i+1]]
+		if !isForkTopicRetentionOver(currEpoch, supersededEpoch) {
+			continue
 		}
-		prevFork, err := forks.Fork(epochBeforeFork)
+		forkVersion := sortedVersions[i]
+		digest, err := signing.ComputeForkDigest(forkVersion[:], genRoot[:])
 		if err != nil {
-			return errors.Wrap(err, "failed to determine previous epoch fork data")
+			return errors.Wrap(err, "could not compute fork digest")
 		}
-
-		switch prevFork.Epoch {
-		case params.BeaconConfig().GenesisEpoch:
+		// Skip the forks which have no topics left.
+		if !s.subHandler.digestExists(digest) {
+			continue
+		}
+		if bytes.Equal(forkVersion[:], params.BeaconConfig().GenesisForkVersion) {
 			s.unregisterPhase0Handlers()
 		}
 		// Run through all our current active topics and see
@@ -122,10 +102,58 @@ func (s *Service) deregisterFromPastFork(currEpoch types.Epoch) error {
 				log.WithError(err).Error("Could not retrieve digest")
 				continue
 			}
-			if retDigest == prevDigest {
+			if retDigest == digest {
 				s.unSubscribeFromTopic(t)
 			}
 		}
 	}
 	return nil
 }
+
+// Determines whether the node should be subscribed to the topics of the fork with the given digest
+// at the provided epoch: from a few epochs before the fork until a few epochs after it is superseded.
+func isForkDigestSubscribable(digest [4]byte, currEpoch types.Epoch, genValRoot [32]byte) (bool, error) {
+	fSchedule := params.BeaconConfig().ForkVersionSchedule
+	sortedVersions := forks.SortedForkVersions(fSchedule)
+	for i, forkVersion := range sortedVersions {
+		forkDigest, err := signing.ComputeForkDigest(forkVersion[:], genValRoot[:])
+		if err != nil {
+			return false, err
+		}
+		if forkDigest != digest {
+			continue
+		}
+		if fSchedule[forkVersion] > currEpoch+forkTopicsLeadEpochs() {
+			return false, nil
+		}
+		if i+1 < len(sortedVersions) && isForkTopicRetentionOver(currEpoch, fSchedule[sortedVersions[i+1]]) {
+			return false, nil
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// Checks whether the topics of a fork superseded at the given epoch are no longer retained.
+func isForkTopicRetentionOver(currEpoch, supersededEpoch types.Epoch) bool {
+	if currEpoch < supersededEpoch {
+		return false
+	}
+	return currEpoch-supersededEpoch >= forkTopicsRetentionEpochs()
+}
+
+// The number of epochs before a fork at which the node subscribes to the topics of the fork.
+// Topics are always subscribed at least an epoch in advance, so that no message is missed at
+// the fork boundary.
+func forkTopicsLeadEpochs() types.Epoch {
+	if lead := flags.Get().ForkTopicsLeadEpochs; lead > 1 {
+		return types.Epoch(lead)
+	}
+	return 1
+}
+
+// The number of epochs after a fork during which the node remains subscribed to the topics of the
+// previous fork.
+func forkTopicsRetentionEpochs() types.Epoch {
+	return types.Epoch(flags.Get().ForkTopicsRetentionEpochs)
+}
@@ -10,10 +10,12 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/network/forks"
 	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
 )
 
 func TestService_CheckForNextEpochFork(t *testing.T) {
@@ -336,6 +338,104 @@ func TestService_CheckForPreviousEpochFork(t *testing.T) {
 	}
 }
 
+func TestService_ForkTopicsWindows(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{ForkTopicsLeadEpochs: 3, ForkTopicsRetentionEpochs: 2})
+	t.Cleanup(func() {
+		flags.Init(resetFlags)
+	})
+	bCfg := params.BeaconConfig().Copy()
+	bCfg.AltairForkEpoch = 5
+	bCfg.BellatrixForkEpoch = 6
+	params.OverrideBeaconConfig(bCfg)
+	params.BeaconConfig().InitializeForkSchedule()
+
+	peer2peer := p2ptest.NewTestP2P(t)
+	chainService := &mockChain.ChainService{
+		Genesis:        time.Now().Add(-oneEpoch()),
+		ValidatorsRoot: [32]byte{'A'},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &Service{
+		ctx:    ctx,
+		cancel: cancel,
+		cfg: &config{
+			p2p:           peer2peer,
+			chain:         chainService,
+			stateNotifier: chainService.StateNotifier(),
+			initialSync:   &mockSync.Sync{IsSyncing: false},
+		},
+		chainStarted: abool.New(),
+		subHandler:   newSubTopicHandler(),
+	}
+	genRoot := s.cfg.chain.GenesisValidatorsRoot()
+	phase0Digest, err := forks.ForkDigestFromEpoch(0, genRoot[:])
+	require.NoError(t, err)
+	altairDigest, err := forks.ForkDigestFromEpoch(5, genRoot[:])
+	require.NoError(t, err)
+	bellatrixDigest, err := forks.ForkDigestFromEpoch(6, genRoot[:])
+	require.NoError(t, err)
+	s.registerSubscribers(0, phase0Digest)
+
+	// Only the forks within the lead epochs are subscribed in advance.
+	require.NoError(t, s.registerForUpcomingFork(2))
+	assert.Equal(t, true, s.subHandler.digestExists(altairDigest))
+	assert.Equal(t, false, s.subHandler.digestExists(bellatrixDigest))
+	require.NoError(t, s.registerForUpcomingFork(3))
+	assert.Equal(t, true, s.subHandler.digestExists(bellatrixDigest))
+
+	// The topics of superseded forks are retained for the retention epochs.
+	require.NoError(t, s.deregisterFromPastFork(6))
+	assert.Equal(t, true, s.subHandler.digestExists(phase0Digest))
+	assert.Equal(t, true, s.subHandler.digestExists(altairDigest))
+	require.NoError(t, s.deregisterFromPastFork(7))
+	assert.Equal(t, false, s.subHandler.digestExists(phase0Digest))
+	assert.Equal(t, true, s.subHandler.digestExists(altairDigest))
+	assert.Equal(t, true, s.subHandler.digestExists(bellatrixDigest))
+	require.NoError(t, s.deregisterFromPastFork(8))
+	assert.Equal(t, false, s.subHandler.digestExists(altairDigest))
+	assert.Equal(t, true, s.subHandler.digestExists(bellatrixDigest))
+}
+
+func TestIsForkDigestSubscribable(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{ForkTopicsLeadEpochs: 2, ForkTopicsRetentionEpochs: 1})
+	t.Cleanup(func() {
+		flags.Init(resetFlags)
+	})
+	bCfg := params.BeaconConfig().Copy()
+	bCfg.AltairForkEpoch = 5
+	params.OverrideBeaconConfig(bCfg)
+	params.BeaconConfig().InitializeForkSchedule()
+	genRoot := [32]byte{'A'}
+	phase0Digest, err := forks.ForkDigestFromEpoch(0, genRoot[:])
+	require.NoError(t, err)
+	altairDigest, err := forks.ForkDigestFromEpoch(5, genRoot[:])
+	require.NoError(t, err)
+
+	tests := []struct {
+		digest    [4]byte
+		currEpoch types.Epoch
+		want      bool
+	}{
+		{digest: phase0Digest, currEpoch: 0, want: true},
+		{digest: phase0Digest, currEpoch: 5, want: true},
+		{digest: phase0Digest, currEpoch: 6, want: false},
+		{digest: altairDigest, currEpoch: 2, want: false},
+		{digest: altairDigest, currEpoch: 3, want: true},
+		{digest: altairDigest, currEpoch: 100, want: true},
+		{digest: [4]byte{'B'}, currEpoch: 5, want: false},
+	}
+	for _, tt := range tests {
+		got, err := isForkDigestSubscribable(tt.digest, tt.currEpoch, genRoot)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "Wrong result for digest %#x at epoch %d", tt.digest, tt.currEpoch)
+	}
+}
+
 func oneEpoch() time.Duration {
 	return time.Duration(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot)) * time.Second
 }
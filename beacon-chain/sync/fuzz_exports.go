@@ -17,12 +17,13 @@ import (
 func NewRegularSyncFuzz(opts ...Option) *Service {
 	ctx, cancel := context.WithCancel(context.Background())
 	r := &Service{
-		cfg:                  &config{},
-		ctx:                  ctx,
-		cancel:               cancel,
-		slotToPendingBlocks:  gcache.New(time.Second, 2*time.Second),
-		seenPendingBlocks:    make(map[[32]byte]bool),
-		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		cfg:                      &config{},
+		ctx:                      ctx,
+		cancel:                   cancel,
+		slotToPendingBlocks:      gcache.New(time.Second, 2*time.Second),
+		seenPendingBlocks:        make(map[[32]byte]bool),
+		blkRootToPendingAtts:     make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		blkRootToPendingSyncMsgs: make(map[[32]byte][]*ethpb.SyncCommitteeMessage),
 	}
 	r.rateLimiter = newRateLimiter(r.cfg.p2p)
 
@@ -0,0 +1,234 @@
+package sync
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/params"
+)
+
+const (
+	// gossipJournalFileName is the name of the journal file currently written to. Rotated files are
+	// suffixed with their age, gossip-journal.jsonl.1 being the most recent.
+	gossipJournalFileName = "gossip-journal.jsonl"
+	// gossipJournalRotatedFiles is the number of rotated files kept besides the current one.
+	gossipJournalRotatedFiles = 4
+)
+
+// GossipJournalEntry is a gossip message rejected or ignored by the validators, as recorded in the
+// gossip journal.
+type GossipJournalEntry struct {
+	Time    time.Time `json:"time"`
+	Topic   string    `json:"topic"`
+	Peer    string    `json:"peer"`
+	Data    []byte    `json:"data"` // The message data as received, SSZ encoded and snappy compressed.
+	Verdict string    `json:"verdict"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// gossipJournal appends the gossip messages failing validation to a rolling set of files, one JSON
+// encoded entry per line.
+type gossipJournal struct {
+	lock    sync.Mutex
+	dir     string
+	maxSize int64
+	file    *os.File
+	size    int64
+}
+
+func newGossipJournal(dir string, maxSize int64) (*gossipJournal, error) {
+	if err := os.MkdirAll(dir, params.BeaconIoConfig().ReadWriteExecutePermissions); err != nil {
+		return nil, errors.Wrap(err, "could not create gossip journal directory")
+	}
+	j := &gossipJournal{dir: dir, maxSize: maxSize}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *gossipJournal) open() error {
+	f, err := os.OpenFile(filepath.Join(j.dir, gossipJournalFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return errors.Wrap(err, "could not open gossip journal")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	j.file = f
+	j.size = info.Size()
+	return nil
+}
+
+// rotate shifts the journal files by one, dropping the oldest, and opens a new current file.
+func (j *gossipJournal) rotate() error {
+	if err := j.file.Close(); err != nil {
+		return err
+	}
+	current := filepath.Join(j.dir, gossipJournalFileName)
+	for i := gossipJournalRotatedFiles - 1; i > 0; i-- {
+		from := fmt.Sprintf("%s.%d", current, i)
+		if _, err := os.Stat(from); err != nil {
+			continue
+		}
+		if err := os.Rename(from, fmt.Sprintf("%s.%d", current, i+1)); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(current, current+".1"); err != nil {
+		return err
+	}
+	return j.open()
+}
+
+func (j *gossipJournal) record(entry *GossipJournalEntry) error {
+	enc, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	enc = append(enc, '\n')
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if j.file == nil {
+		return errors.New("gossip journal is closed")
+	}
+	if j.size > 0 && j.size+int64(len(enc)) > j.maxSize {
+		if err := j.rotate(); err != nil {
+			return errors.Wrap(err, "could not rotate gossip journal")
+		}
+	}
+	n, err := j.file.Write(enc)
+	j.size += int64(n)
+	return err
+}
+
+func (j *gossipJournal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// journalGossipMessage records a gossip message that failed validation, if the journal is enabled.
+func (s *Service) journalGossipMessage(topic string, pid peer.ID, msg *pubsub.Message, verdict pubsub.ValidationResult, err error) {
+	if s.gossipJournal == nil {
+		return
+	}
+	entry := &GossipJournalEntry{
+		Time:    time.Now(),
+		Topic:   topic,
+		Peer:    pid.String(),
+		Data:    msg.Data,
+		Verdict: ValidationResultString(verdict),
+	}
+	if err != nil {
+		entry.Reason = err.Error()
+	}
+	if err := s.gossipJournal.record(entry); err != nil {
+		log.WithError(err).Debug("Could not record gossip message in journal")
+	}
+}
+
+func (s *Service) setGossipValidator(topic string, validator wrappedVal) {
+	s.gossipValidatorsLock.Lock()
+	defer s.gossipValidatorsLock.Unlock()
+	if validator == nil {
+		delete(s.gossipValidators, topic)
+		return
+	}
+	if s.gossipValidators == nil {
+		s.gossipValidators = make(map[string]wrappedVal)
+	}
+	s.gossipValidators[topic] = validator
+}
+
+// ValidateGossipMessage runs a gossip message through the validator of its topic, without
+// broadcasting or processing it. The topic must be currently subscribed to. Validated messages are
+// marked as seen, like messages received from peers.
+func (s *Service) ValidateGossipMessage(ctx context.Context, topic string, pid peer.ID, data []byte) (pubsub.ValidationResult, error) {
+	s.gossipValidatorsLock.RLock()
+	validator, ok := s.gossipValidators[topic]
+	s.gossipValidatorsLock.RUnlock()
+	if !ok {
+		return pubsub.ValidationIgnore, fmt.Errorf("topic %s is not subscribed to", topic)
+	}
+	ctx, cancel := context.WithTimeout(ctx, pubsubMessageTimeout)
+	defer cancel()
+	msg := &pubsub.Message{Message: &pubsubpb.Message{Topic: &topic, Data: data}}
+	return validator(ctx, pid, msg)
+}
+
+// ReadGossipJournal returns the entries of the gossip journal in the given directory, oldest first.
+func ReadGossipJournal(dir string) ([]*GossipJournalEntry, error) {
+	current := filepath.Join(dir, gossipJournalFileName)
+	files := make([]string, 0, gossipJournalRotatedFiles+1)
+	for i := gossipJournalRotatedFiles; i > 0; i-- {
+		files = append(files, fmt.Sprintf("%s.%d", current, i))
+	}
+	files = append(files, current)
+
+	entries := make([]*GossipJournalEntry, 0)
+	found := false
+	for _, name := range files {
+		f, err := os.Open(name) // #nosec G304
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		scanner := bufio.NewScanner(f)
+		// Entries hold whole blocks, which are larger than the default token size.
+		scanner.Buffer(make([]byte, 0, 64*1024), int(params.BeaconNetworkConfig().GossipMaxSizeBellatrix)*2)
+		for scanner.Scan() {
+			entry := &GossipJournalEntry{}
+			if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+				_ = f.Close()
+				return nil, errors.Wrapf(err, "could not decode entry of %s", name)
+			}
+			entries = append(entries, entry)
+		}
+		if err := scanner.Err(); err != nil {
+			_ = f.Close()
+			return nil, errors.Wrapf(err, "could not read %s", name)
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no gossip journal found in %s", dir)
+	}
+	return entries, nil
+}
+
+// ValidationResultString returns the name of a gossip validation result.
+func ValidationResultString(res pubsub.ValidationResult) string {
+	switch res {
+	case pubsub.ValidationAccept:
+		return "accept"
+	case pubsub.ValidationReject:
+		return "reject"
+	case pubsub.ValidationIgnore:
+		return "ignore"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,111 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestGossipJournal_RecordAndRead(t *testing.T) {
+	dir := t.TempDir()
+	j, err := newGossipJournal(dir, 1024*1024)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, j.record(&GossipJournalEntry{
+			Topic:   fmt.Sprintf("topic_%d", i),
+			Data:    []byte{byte(i)},
+			Verdict: ValidationResultString(pubsub.ValidationReject),
+			Reason:  "bad message",
+		}))
+	}
+	require.NoError(t, j.close())
+	require.ErrorContains(t, "gossip journal is closed", j.record(&GossipJournalEntry{}))
+
+	entries, err := ReadGossipJournal(dir)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(entries))
+	for i, entry := range entries {
+		assert.Equal(t, fmt.Sprintf("topic_%d", i), entry.Topic)
+		assert.DeepEqual(t, []byte{byte(i)}, entry.Data)
+		assert.Equal(t, "reject", entry.Verdict)
+		assert.Equal(t, "bad message", entry.Reason)
+	}
+}
+
+func TestGossipJournal_Rotate(t *testing.T) {
+	dir := t.TempDir()
+	// Small enough to hold a single entry per file.
+	j, err := newGossipJournal(dir, 100)
+	require.NoError(t, err)
+	total := gossipJournalRotatedFiles + 3
+	for i := 0; i < total; i++ {
+		require.NoError(t, j.record(&GossipJournalEntry{Topic: fmt.Sprintf("topic_%d", i), Verdict: "ignore"}))
+	}
+	require.NoError(t, j.close())
+
+	_, err = os.Stat(filepath.Join(dir, fmt.Sprintf("%s.%d", gossipJournalFileName, gossipJournalRotatedFiles+1)))
+	assert.Equal(t, true, os.IsNotExist(err), "Expected oldest journal file to be dropped")
+
+	// The current file and the rotated ones are read back, oldest first.
+	entries, err := ReadGossipJournal(dir)
+	require.NoError(t, err)
+	require.Equal(t, gossipJournalRotatedFiles+1, len(entries))
+	for i, entry := range entries {
+		assert.Equal(t, fmt.Sprintf("topic_%d", total-gossipJournalRotatedFiles-1+i), entry.Topic)
+	}
+}
+
+func TestReadGossipJournal_NotFound(t *testing.T) {
+	_, err := ReadGossipJournal(t.TempDir())
+	require.ErrorContains(t, "no gossip journal found", err)
+}
+
+func TestService_ValidateGossipMessage(t *testing.T) {
+	s := &Service{}
+	topic := "/eth2/abcdef00/beacon_block/ssz_snappy"
+
+	_, err := s.ValidateGossipMessage(context.Background(), topic, "", []byte{'a'})
+	require.ErrorContains(t, "is not subscribed to", err)
+
+	s.setGossipValidator(topic, func(_ context.Context, _ peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+		if msg.GetTopic() != topic {
+			return pubsub.ValidationIgnore, errors.New("wrong topic")
+		}
+		return pubsub.ValidationReject, errors.New("bad block")
+	})
+	res, err := s.ValidateGossipMessage(context.Background(), topic, "", []byte{'a'})
+	assert.Equal(t, pubsub.ValidationReject, res)
+	require.ErrorContains(t, "bad block", err)
+
+	s.setGossipValidator(topic, nil)
+	_, err = s.ValidateGossipMessage(context.Background(), topic, "", []byte{'a'})
+	require.ErrorContains(t, "is not subscribed to", err)
+}
+
+func TestService_JournalGossipMessage(t *testing.T) {
+	dir := t.TempDir()
+	j, err := newGossipJournal(dir, 1024*1024)
+	require.NoError(t, err)
+	s := &Service{gossipJournal: j}
+	topic := "/eth2/abcdef00/voluntary_exit/ssz_snappy"
+	msg := &pubsub.Message{Message: &pubsubpb.Message{Topic: &topic, Data: []byte{'b'}}}
+	s.journalGossipMessage(topic, "", msg, pubsub.ValidationIgnore, errors.New("already seen"))
+	require.NoError(t, j.close())
+
+	entries, err := ReadGossipJournal(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	assert.Equal(t, topic, entries[0].Topic)
+	assert.DeepEqual(t, []byte{'b'}, entries[0].Data)
+	assert.Equal(t, "ignore", entries[0].Verdict)
+	assert.Equal(t, "already seen", entries[0].Reason)
+}
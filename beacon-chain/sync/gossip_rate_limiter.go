@@ -0,0 +1,85 @@
+package sync
+
+import (
+	"github.com/kevinms/leakybucket-go"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+)
+
+// gossipRateLimiter bounds the rate of gossip messages validated per peer and per topic, so
+// that a single peer flooding the attestation or sync committee topics is throttled before
+// its messages consume signature verification CPU. A nil collector does not limit its topics.
+type gossipRateLimiter struct {
+	attestations  *leakybucket.Collector
+	syncCommittee *leakybucket.Collector
+}
+
+// newGossipRateLimiter returns a limiter allowing each peer the given number of messages per
+// second on every attestation and sync committee topic, with bursts of up to 5 times these
+// allowances. A rate of zero disables the limit of its topics.
+func newGossipRateLimiter(attestationRate, syncCommitteeRate float64) *gossipRateLimiter {
+	newCollector := func(rate float64) *leakybucket.Collector {
+		if rate <= 0 {
+			return nil
+		}
+		return leakybucket.NewCollector(rate, int64(rate*defaultBurstLimit), true /* deleteEmptyBuckets */)
+	}
+	return &gossipRateLimiter{
+		attestations:  newCollector(attestationRate),
+		syncCommittee: newCollector(syncCommitteeRate),
+	}
+}
+
+// free stops the pruning of the collectors of the limiter.
+func (l *gossipRateLimiter) free() {
+	for _, c := range []*leakybucket.Collector{l.attestations, l.syncCommittee} {
+		if c != nil {
+			c.Free()
+		}
+	}
+}
+
+// attestationCollector returns the collector limiting attestation and aggregate messages, if any.
+func (l *gossipRateLimiter) attestationCollector() *leakybucket.Collector {
+	if l == nil {
+		return nil
+	}
+	return l.attestations
+}
+
+// syncCommitteeCollector returns the collector limiting sync committee messages and
+// contributions, if any.
+func (l *gossipRateLimiter) syncCommitteeCollector() *leakybucket.Collector {
+	if l == nil {
+		return nil
+	}
+	return l.syncCommittee
+}
+
+// throttleGossipMessage counts the message against the allowance of its peer on its topic. It
+// returns true if the allowance is exhausted, in which case the message must be ignored. Like
+// RPC rate limit violations, the violations are forgiven over time, and only peers persistently
+// exceeding their allowances are down-scored.
+func (s *Service) throttleGossipMessage(c *leakybucket.Collector, pid peer.ID, msg *pubsub.Message) bool {
+	if c == nil {
+		return false
+	}
+	var topic string
+	if msg.Topic != nil {
+		topic = *msg.Topic
+	}
+	if c.Add(pid.String()+topic, 1) > 0 {
+		return false
+	}
+	gossipThrottledMessagesCounter.WithLabelValues(topic).Inc()
+	if s.rateLimiter.recordGossipViolation(pid) {
+		s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+	}
+	return true
+}
+
+// gossipRateLimiterFromFlags returns a limiter with the rates given by the beacon node flags.
+func gossipRateLimiterFromFlags() *gossipRateLimiter {
+	return newGossipRateLimiter(flags.Get().GossipAttestationRateLimit, flags.Get().GossipSyncCommitteeRateLimit)
+}
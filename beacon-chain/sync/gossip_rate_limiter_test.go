@@ -0,0 +1,105 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func gossipMessage(topic string) *pubsub.Message {
+	return &pubsub.Message{Message: &pubsubpb.Message{Topic: &topic, Data: []byte("data")}}
+}
+
+func TestGossipRateLimiterFromFlags(t *testing.T) {
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{
+		GossipAttestationRateLimit: 100,
+	})
+	defer flags.Init(resetFlags)
+
+	l := gossipRateLimiterFromFlags()
+	defer l.free()
+	require.NotNil(t, l.attestationCollector())
+	assert.Equal(t, float64(100), l.attestationCollector().Rate())
+	assert.Equal(t, int64(500), l.attestationCollector().Capacity())
+	// A rate of zero disables the limit.
+	assert.Equal(t, true, l.syncCommitteeCollector() == nil)
+
+	var nilLimiter *gossipRateLimiter
+	assert.Equal(t, true, nilLimiter.attestationCollector() == nil)
+	assert.Equal(t, true, nilLimiter.syncCommitteeCollector() == nil)
+}
+
+func TestService_throttleGossipMessage(t *testing.T) {
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{RPCRateLimitMaxViolations: 3})
+	defer flags.Init(resetFlags)
+
+	p := p2ptest.NewTestP2P(t)
+	s := &Service{cfg: &config{p2p: p}, rateLimiter: newRateLimiter(p)}
+	defer s.rateLimiter.free()
+	l := newGossipRateLimiter(1, 0)
+	defer l.free()
+
+	pid := peer.ID("flooder")
+	p.Peers().Add(nil, pid, nil, network.DirInbound)
+	topic := "/eth2/00000000/beacon_attestation_1/ssz_snappy"
+	for i := 0; i < defaultBurstLimit; i++ {
+		assert.Equal(t, false, s.throttleGossipMessage(l.attestationCollector(), pid, gossipMessage(topic)))
+	}
+	// Occasional violations are forgiven, only persistent violators are down-scored.
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, true, s.throttleGossipMessage(l.attestationCollector(), pid, gossipMessage(topic)))
+	}
+	count, err := p.Peers().Scorers().BadResponsesScorer().Count(pid)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, true, s.throttleGossipMessage(l.attestationCollector(), pid, gossipMessage(topic)))
+	count, err = p.Peers().Scorers().BadResponsesScorer().Count(pid)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// Allowances are per peer and per topic.
+	assert.Equal(t, false, s.throttleGossipMessage(l.attestationCollector(), pid, gossipMessage("/eth2/00000000/beacon_attestation_2/ssz_snappy")))
+	assert.Equal(t, false, s.throttleGossipMessage(l.attestationCollector(), "other", gossipMessage(topic)))
+
+	// Topics without a limit are never throttled.
+	for i := 0; i < 2*defaultBurstLimit; i++ {
+		assert.Equal(t, false, s.throttleGossipMessage(l.syncCommitteeCollector(), pid, gossipMessage(topic)))
+	}
+}
+
+func TestService_ValidateSyncCommitteeMessage_RateLimited(t *testing.T) {
+	p := p2ptest.NewTestP2P(t)
+	s := &Service{
+		cfg: &config{
+			p2p:         p,
+			initialSync: &mockSync.Sync{IsSyncing: false},
+			chain:       &mockChain.ChainService{},
+		},
+		gossipRateLimiter: newGossipRateLimiter(0, 1),
+	}
+	defer s.gossipRateLimiter.free()
+
+	pid := peer.ID("flooder")
+	topic := "/eth2/00000000/sync_committee_1/ssz_snappy"
+	for i := 0; i < defaultBurstLimit; i++ {
+		// Messages within the allowance are validated, and rejected as they can't be decoded.
+		result, err := s.validateSyncCommitteeMessage(context.Background(), pid, gossipMessage(topic))
+		assert.NotNil(t, err)
+		assert.Equal(t, pubsub.ValidationReject, result)
+	}
+	result, err := s.validateSyncCommitteeMessage(context.Background(), pid, gossipMessage(topic))
+	require.ErrorIs(t, err, errGossipRateLimited)
+	assert.Equal(t, pubsub.ValidationIgnore, result)
+}
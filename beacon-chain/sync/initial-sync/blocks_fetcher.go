@@ -19,6 +19,7 @@ import (
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/crypto/rand"
 	p2ppb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -68,20 +69,22 @@ type blocksFetcherConfig struct {
 // among available peers (for fair network load distribution).
 type blocksFetcher struct {
 	sync.Mutex
-	ctx             context.Context
-	cancel          context.CancelFunc
-	rand            *rand.Rand
-	chain           blockchainService
-	p2p             p2p.P2P
-	db              db.ReadOnlyDatabase
-	blocksPerSecond uint64
-	rateLimiter     *leakybucket.Collector
-	peerLocks       map[peer.ID]*peerLock
-	fetchRequests   chan *fetchRequestParams
-	fetchResponses  chan *fetchRequestResponse
-	capacityWeight  float64       // how remaining capacity affects peer selection
-	mode            syncMode      // allows to use fetcher in different sync scenarios
-	quit            chan struct{} // termination notifier
+	ctx              context.Context
+	cancel           context.CancelFunc
+	rand             *rand.Rand
+	chain            blockchainService
+	p2p              p2p.P2P
+	db               db.ReadOnlyDatabase
+	blocksPerSecond  uint64
+	rateLimiter      *leakybucket.Collector
+	peerLocks        map[peer.ID]*peerLock
+	fetchRequests    chan *fetchRequestParams
+	fetchResponses   chan *fetchRequestResponse
+	capacityWeight   float64       // how remaining capacity affects peer selection
+	mode             syncMode      // allows to use fetcher in different sync scenarios
+	fanOutPeers      int           // how many peers a request is fanned out to
+	fanOutStealAfter time.Duration // how long a fanned out chunk is in flight before being stolen
+	quit             chan struct{} // termination notifier
 }
 
 // peerLock restricts fetcher actions on per peer basis. Currently, used for rate limiting.
@@ -123,20 +126,22 @@ func newBlocksFetcher(ctx context.Context, cfg *blocksFetcherConfig) *blocksFetc
 
 	ctx, cancel := context.WithCancel(ctx)
 	return &blocksFetcher{
-		ctx:             ctx,
-		cancel:          cancel,
-		rand:            rand.NewGenerator(),
-		chain:           cfg.chain,
-		p2p:             cfg.p2p,
-		db:              cfg.db,
-		blocksPerSecond: uint64(blocksPerSecond),
-		rateLimiter:     rateLimiter,
-		peerLocks:       make(map[peer.ID]*peerLock),
-		fetchRequests:   make(chan *fetchRequestParams, maxPendingRequests),
-		fetchResponses:  make(chan *fetchRequestResponse, maxPendingRequests),
-		capacityWeight:  capacityWeight,
-		mode:            cfg.mode,
-		quit:            make(chan struct{}),
+		ctx:              ctx,
+		cancel:           cancel,
+		rand:             rand.NewGenerator(),
+		chain:            cfg.chain,
+		p2p:              cfg.p2p,
+		db:               cfg.db,
+		blocksPerSecond:  uint64(blocksPerSecond),
+		rateLimiter:      rateLimiter,
+		peerLocks:        make(map[peer.ID]*peerLock),
+		fetchRequests:    make(chan *fetchRequestParams, maxPendingRequests),
+		fetchResponses:   make(chan *fetchRequestResponse, maxPendingRequests),
+		capacityWeight:   capacityWeight,
+		mode:             cfg.mode,
+		fanOutPeers:      flags.Get().BlockBatchFanOutPeers,
+		fanOutStealAfter: fanOutStealAfter,
+		quit:             make(chan struct{}),
 	}
 }
 
@@ -261,8 +266,9 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start types.Slot, cou
 
 	// Short circuit start far exceeding the highest finalized epoch in some infinite loop.
 	if f.mode == modeStopOnFinalizedEpoch {
-		highestFinalizedSlot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(targetEpoch + 1))
-		if start > highestFinalizedSlot {
+		// A target epoch without a next epoch start slot is far beyond any requested slot.
+		highestFinalizedSlot, err := slots.NextEpochStart(targetEpoch)
+		if err == nil && start > highestFinalizedSlot {
 			response.err = fmt.Errorf("%w, slot: %d, highest finalized slot: %d",
 				errSlotIsTooHigh, start, highestFinalizedSlot)
 			return response
@@ -273,7 +279,8 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start types.Slot, cou
 	return response
 }
 
-// fetchBlocksFromPeer fetches blocks from a single randomly selected peer.
+// fetchBlocksFromPeer fetches blocks from a single randomly selected peer, or fans the request
+// out to several peers if it spans more than an epoch and fan out is enabled.
 func (f *blocksFetcher) fetchBlocksFromPeer(
 	ctx context.Context,
 	start types.Slot, count uint64,
@@ -283,6 +290,9 @@ func (f *blocksFetcher) fetchBlocksFromPeer(
 	defer span.End()
 
 	peers = f.filterPeers(ctx, peers, peersPercentagePerRequest)
+	if f.fanOutPeers > 1 && len(peers) > 1 && count > uint64(params.BeaconConfig().SlotsPerEpoch) {
+		return f.fetchBlocksFanOut(ctx, start, count, peers)
+	}
 	req := &p2ppb.BeaconBlocksByRangeRequest{
 		StartSlot: start,
 		Count:     count,
@@ -327,7 +337,10 @@ func (f *blocksFetcher) requestBlocks(
 	}
 	f.rateLimiter.Add(pid.String(), int64(req.Count))
 	l.Unlock()
-	return prysmsync.SendBeaconBlocksByRangeRequest(ctx, f.chain, f.p2p, pid, req, nil)
+	start := time.Now()
+	blocks, err := prysmsync.SendBeaconBlocksByRangeRequest(ctx, f.chain, f.p2p, pid, req, nil)
+	f.recordBlockQuality(pid, blocks, time.Since(start), err)
+	return blocks, err
 }
 
 // requestBlocksByRoot is a wrapper for handling BeaconBlockByRootsReq requests/streams.
@@ -356,7 +369,24 @@ func (f *blocksFetcher) requestBlocksByRoot(
 	f.rateLimiter.Add(pid.String(), int64(len(*req)))
 	l.Unlock()
 
-	return prysmsync.SendBeaconBlocksByRootRequest(ctx, f.chain, f.p2p, pid, req, nil)
+	start := time.Now()
+	blocks, err := prysmsync.SendBeaconBlocksByRootRequest(ctx, f.chain, f.p2p, pid, req, nil)
+	f.recordBlockQuality(pid, blocks, time.Since(start), err)
+	if err == nil && len(blocks) < len(*req) {
+		f.p2p.Peers().Scorers().BlockQualityScorer().RecordMissingBlocks(pid, uint64(len(*req)-len(blocks)))
+	}
+	return blocks, err
+}
+
+// recordBlockQuality updates block quality stats of a peer with the outcome of a blocks request.
+func (f *blocksFetcher) recordBlockQuality(
+	pid peer.ID, blocks []interfaces.SignedBeaconBlock, latency time.Duration, err error,
+) {
+	scorer := f.p2p.Peers().Scorers().BlockQualityScorer()
+	scorer.RecordResponse(pid, uint64(len(blocks)), latency)
+	if errors.Is(err, prysmsync.ErrInvalidFetchedData) {
+		scorer.RecordInvalidBlocks(pid, 1)
+	}
 }
 
 // waitForBandwidth blocks up until peer's bandwidth is restored.
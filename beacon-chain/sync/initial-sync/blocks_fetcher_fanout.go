@@ -0,0 +1,337 @@
+package initialsync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+const (
+	// fanOutStealAfter is how long a chunk may be in flight before an idle peer steals it.
+	fanOutStealAfter = 3 * time.Second
+	// fanOutPollingInterval is how often an idle peer checks for chunks to steal.
+	fanOutPollingInterval = 100 * time.Millisecond
+)
+
+var errChunkNotLinked = errors.New("blocks of chunk do not form a chain")
+
+// fanOutChunk is an epoch aligned part of a fanned out blocks by range request.
+type fanOutChunk struct {
+	start    types.Slot
+	count    uint64
+	blocks   []interfaces.SignedBeaconBlock
+	parent   [32]byte              // parent root of the first block, if any
+	head     [32]byte              // root of the last block, if any
+	pid      peer.ID               // peer whose response was used
+	done     bool                  // whether any peer returned the blocks of the chunk
+	inFlight map[peer.ID]time.Time // peers currently requesting the chunk, with their request time
+}
+
+// linkChunk checks that the blocks returned for a chunk are within the range of the chunk and form
+// a chain, returning the parent root of the first block and the root of the last one. A chunk may
+// have no blocks when all of its slots are skipped.
+func linkChunk(c *fanOutChunk, blocks []interfaces.SignedBeaconBlock) ([32]byte, [32]byte, error) {
+	if len(blocks) == 0 {
+		return [32]byte{}, [32]byte{}, nil
+	}
+	end := c.start.Add(c.count)
+	parent := bytesutil.ToBytes32(blocks[0].Block().ParentRoot())
+	prevRoot := parent
+	var prevSlot types.Slot
+	for i, blk := range blocks {
+		slot := blk.Block().Slot()
+		if slot < c.start || slot >= end || (i > 0 && slot <= prevSlot) {
+			return [32]byte{}, [32]byte{}, errors.Wrapf(errChunkNotLinked, "block at slot %d out of order", slot)
+		}
+		if bytesutil.ToBytes32(blk.Block().ParentRoot()) != prevRoot {
+			return [32]byte{}, [32]byte{}, errors.Wrapf(errChunkNotLinked, "block at slot %d is not a child of the previous block", slot)
+		}
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return [32]byte{}, [32]byte{}, err
+		}
+		prevRoot, prevSlot = root, slot
+	}
+	return parent, prevRoot, nil
+}
+
+// fanOutScheduler hands out the chunks of a request to the peers fetching them. Peers pull
+// chunks until none is left, and a peer done with its chunks steals the chunks which have been
+// in flight for longer than stealAfter, so that a slow peer does not hold up the whole request.
+type fanOutScheduler struct {
+	sync.Mutex
+	chunks     []*fanOutChunk
+	remaining  int
+	stealAfter time.Duration
+}
+
+// newFanOutScheduler splits the requested range into epoch aligned chunks.
+func newFanOutScheduler(start types.Slot, count uint64, stealAfter time.Duration) *fanOutScheduler {
+	s := &fanOutScheduler{stealAfter: stealAfter}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	end := start.Add(count)
+	for chunkStart := start; chunkStart < end; {
+		chunkEnd := chunkStart - chunkStart%slotsPerEpoch + slotsPerEpoch
+		if chunkEnd > end {
+			chunkEnd = end
+		}
+		s.chunks = append(s.chunks, &fanOutChunk{
+			start:    chunkStart,
+			count:    uint64(chunkEnd - chunkStart),
+			inFlight: make(map[peer.ID]time.Time),
+		})
+		chunkStart = chunkEnd
+	}
+	s.remaining = len(s.chunks)
+	return s
+}
+
+// next assigns a chunk to the given peer, returning its index, or -1 if there is no chunk to
+// assign for now. The returned flag is false once every chunk is done.
+func (s *fanOutScheduler) next(pid peer.ID) (int, bool) {
+	s.Lock()
+	defer s.Unlock()
+	if s.remaining == 0 {
+		return -1, false
+	}
+	for i, c := range s.chunks {
+		if !c.done && len(c.inFlight) == 0 {
+			c.inFlight[pid] = time.Now()
+			return i, true
+		}
+	}
+	for i, c := range s.chunks {
+		if c.done {
+			continue
+		}
+		if _, ok := c.inFlight[pid]; ok {
+			continue
+		}
+		for _, requested := range c.inFlight {
+			if time.Since(requested) >= s.stealAfter {
+				c.inFlight[pid] = time.Now()
+				return i, true
+			}
+		}
+	}
+	return -1, true
+}
+
+// complete records the blocks returned by the given peer for a chunk, given the parent root of
+// their first block and the root of their last one. The blocks must link to the blocks of the
+// nearest done chunks with blocks, across the done chunks without blocks in between. If they do
+// not, the response is rejected and the chunks it is checked against are reopened, as either
+// response may be the wrong one. The peers which requested the chunk earlier, but have not
+// returned it yet, are returned as they were outpaced.
+func (s *fanOutScheduler) complete(i int, pid peer.ID, blocks []interfaces.SignedBeaconBlock, parent, head [32]byte) ([]peer.ID, error) {
+	s.Lock()
+	defer s.Unlock()
+	c := s.chunks[i]
+	requested := c.inFlight[pid]
+	delete(c.inFlight, pid)
+	if c.done {
+		return nil, nil
+	}
+	prev, next := s.linkedBefore(i), s.linkedAfter(i)
+	if len(blocks) == 0 {
+		// A chunk without blocks links its neighbours directly.
+		if prev >= 0 && next >= 0 && s.chunks[prev].head != s.chunks[next].parent {
+			s.reopen(prev, next+1)
+			return nil, errors.Wrap(errChunkNotLinked, "previous and next chunks do not link across skipped slots")
+		}
+	} else {
+		if prev >= 0 && s.chunks[prev].head != parent {
+			s.reopen(prev, i)
+			return nil, errors.Wrap(errChunkNotLinked, "first block is not a child of the previous chunk")
+		}
+		if next >= 0 && s.chunks[next].parent != head {
+			s.reopen(i+1, next+1)
+			return nil, errors.Wrap(errChunkNotLinked, "last block is not the parent of the next chunk")
+		}
+	}
+	c.done = true
+	c.blocks = blocks
+	c.parent = parent
+	c.head = head
+	c.pid = pid
+	s.remaining--
+	slow := make([]peer.ID, 0, len(c.inFlight))
+	for p, t := range c.inFlight {
+		if t.Before(requested) {
+			slow = append(slow, p)
+		}
+	}
+	c.inFlight = make(map[peer.ID]time.Time)
+	return slow, nil
+}
+
+// linkedBefore returns the index of the nearest chunk with blocks before the given one, if it and
+// every chunk in between are done, or -1 otherwise.
+func (s *fanOutScheduler) linkedBefore(i int) int {
+	for j := i - 1; j >= 0 && s.chunks[j].done; j-- {
+		if len(s.chunks[j].blocks) > 0 {
+			return j
+		}
+	}
+	return -1
+}
+
+// linkedAfter returns the index of the nearest chunk with blocks after the given one, if it and
+// every chunk in between are done, or -1 otherwise.
+func (s *fanOutScheduler) linkedAfter(i int) int {
+	for j := i + 1; j < len(s.chunks) && s.chunks[j].done; j++ {
+		if len(s.chunks[j].blocks) > 0 {
+			return j
+		}
+	}
+	return -1
+}
+
+// reopen marks the done chunks in the given index range as not done, for them to be requested again.
+func (s *fanOutScheduler) reopen(from, to int) {
+	for _, c := range s.chunks[from:to] {
+		if !c.done {
+			continue
+		}
+		c.done = false
+		c.blocks = nil
+		c.pid = ""
+		s.remaining++
+	}
+}
+
+// fail releases a chunk the given peer could not return, so that another peer picks it up.
+func (s *fanOutScheduler) fail(i int, pid peer.ID) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.chunks[i].inFlight, pid)
+}
+
+// result reassembles the blocks of every chunk in order, along with the peer which returned the
+// most of them. It returns false if a chunk is not done.
+func (s *fanOutScheduler) result() ([]interfaces.SignedBeaconBlock, peer.ID, bool) {
+	s.Lock()
+	defer s.Unlock()
+	if s.remaining != 0 {
+		return nil, "", false
+	}
+	blocks := make([]interfaces.SignedBeaconBlock, 0)
+	served := make(map[peer.ID]int)
+	var pid peer.ID
+	for _, c := range s.chunks {
+		blocks = append(blocks, c.blocks...)
+		served[c.pid] += len(c.blocks)
+		if pid == "" || served[c.pid] > served[pid] {
+			pid = c.pid
+		}
+	}
+	return blocks, pid, true
+}
+
+// fetchBlocksFanOut fetches the requested range from several peers concurrently. The range is
+// split into epoch aligned chunks which every peer requests in turn, and the responses are
+// reassembled in order. The best block providers are requested first, chunks held up by a slow
+// peer are stolen by the others, and peers outpaced on a stolen chunk are penalized.
+func (f *blocksFetcher) fetchBlocksFanOut(
+	ctx context.Context,
+	start types.Slot, count uint64,
+	peers []peer.ID,
+) ([]interfaces.SignedBeaconBlock, peer.ID, error) {
+	ctx, span := trace.StartSpan(ctx, "initialsync.fetchBlocksFanOut")
+	defer span.End()
+
+	s := newFanOutScheduler(start, count, f.fanOutStealAfter)
+	peers = f.p2p.Peers().Scorers().BlockProviderScorer().Sorted(peers, nil)
+	if len(peers) > f.fanOutPeers {
+		peers = peers[:f.fanOutPeers]
+	}
+	if len(peers) > len(s.chunks) {
+		peers = peers[:len(s.chunks)]
+	}
+
+	// Requests still in flight once every chunk is done are cancelled.
+	workersCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, pid := range peers {
+		wg.Add(1)
+		go func(pid peer.ID) {
+			defer wg.Done()
+			f.fanOutWorker(workersCtx, s, pid, cancel)
+		}(pid)
+	}
+	wg.Wait()
+
+	blocks, pid, ok := s.result()
+	if !ok {
+		if ctx.Err() != nil {
+			return nil, "", ctx.Err()
+		}
+		return nil, "", errNoPeersAvailable
+	}
+	return blocks, pid, nil
+}
+
+// fanOutWorker requests chunks from a peer until every chunk is done, or the peer fails to return
+// one. A peer returning blocks which do not link to the rest of the range fails.
+func (f *blocksFetcher) fanOutWorker(ctx context.Context, s *fanOutScheduler, pid peer.ID, done context.CancelFunc) {
+	for {
+		i, ok := s.next(pid)
+		if !ok {
+			done()
+			return
+		}
+		if i < 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(fanOutPollingInterval):
+			}
+			continue
+		}
+		c := s.chunks[i]
+		blocks, err := f.requestBlocks(ctx, &p2ppb.BeaconBlocksByRangeRequest{
+			StartSlot: c.start,
+			Count:     c.count,
+			Step:      1,
+		}, pid)
+		var slow []peer.ID
+		if err == nil {
+			var parent, head [32]byte
+			if parent, head, err = linkChunk(c, blocks); err == nil {
+				slow, err = s.complete(i, pid, blocks, parent, head)
+			}
+		}
+		if err != nil {
+			s.fail(i, pid)
+			if ctx.Err() == nil {
+				log.WithError(err).WithFields(logrus.Fields{
+					"peer":  pid,
+					"start": c.start,
+					"count": c.count,
+				}).Debug("Could not request blocks by range")
+			}
+			return
+		}
+		scorer := f.p2p.Peers().Scorers().BlockProviderScorer()
+		scorer.Touch(pid)
+		for _, p := range slow {
+			scorer.DecrementProcessedBlocks(p, c.count)
+			log.WithFields(logrus.Fields{
+				"peer":  p,
+				"start": c.start,
+				"count": c.count,
+			}).Debug("Peer is penalized for being outpaced on a stolen chunk")
+		}
+	}
+}
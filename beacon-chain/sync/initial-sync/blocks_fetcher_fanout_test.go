@@ -0,0 +1,242 @@
+package initialsync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestFanOutScheduler_Chunks(t *testing.T) {
+	s := newFanOutScheduler(10, 64, time.Minute)
+	require.Equal(t, 3, len(s.chunks))
+	assert.Equal(t, types.Slot(10), s.chunks[0].start)
+	assert.Equal(t, uint64(22), s.chunks[0].count)
+	assert.Equal(t, types.Slot(32), s.chunks[1].start)
+	assert.Equal(t, uint64(32), s.chunks[1].count)
+	assert.Equal(t, types.Slot(64), s.chunks[2].start)
+	assert.Equal(t, uint64(10), s.chunks[2].count)
+}
+
+// chunkBlocks stands for the blocks of a chunk, which the scheduler links by the given roots only.
+func chunkBlocks(t *testing.T) []interfaces.SignedBeaconBlock {
+	wsb, err := wrapper.WrappedSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	return []interfaces.SignedBeaconBlock{wsb}
+}
+
+func TestFanOutScheduler_Steal(t *testing.T) {
+	blocks := chunkBlocks(t)
+	s := newFanOutScheduler(0, 64, time.Minute)
+	i, ok := s.next("a")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 0, i)
+	i, _ = s.next("b")
+	assert.Equal(t, 1, i)
+	// Nothing to steal before chunks are in flight for long enough.
+	i, ok = s.next("c")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, -1, i)
+
+	s.stealAfter = 0
+	i, _ = s.next("c")
+	assert.Equal(t, 0, i)
+	// The chunk is done once, by the first peer returning it, and the peer it was stolen from is outpaced.
+	slow, err := s.complete(0, "c", blocks, [32]byte{}, [32]byte{'a'})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []peer.ID{"a"}, slow)
+	slow, err = s.complete(0, "a", blocks, [32]byte{}, [32]byte{'b'})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(slow))
+	assert.Equal(t, [32]byte{'a'}, s.chunks[0].head)
+	// The peer failing to return a chunk releases it.
+	s.fail(1, "b")
+	i, _ = s.next("c")
+	assert.Equal(t, 1, i)
+	_, err = s.complete(1, "c", blocks, [32]byte{'a'}, [32]byte{'c'})
+	require.NoError(t, err)
+
+	_, ok = s.next("a")
+	assert.Equal(t, false, ok)
+	_, pid, ok := s.result()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, peer.ID("c"), pid)
+}
+
+func TestFanOutScheduler_UnlinkedChunk(t *testing.T) {
+	blocks := chunkBlocks(t)
+	s := newFanOutScheduler(0, 96, time.Minute)
+	_, err := s.complete(0, "a", blocks, [32]byte{}, [32]byte{'a'})
+	require.NoError(t, err)
+	_, err = s.complete(2, "a", blocks, [32]byte{'b'}, [32]byte{'c'})
+	require.NoError(t, err)
+	// A chunk which does not link to a done neighbour is rejected, and the neighbour is reopened.
+	_, err = s.complete(1, "b", blocks, [32]byte{'x'}, [32]byte{'b'})
+	require.ErrorIs(t, err, errChunkNotLinked)
+	assert.Equal(t, false, s.chunks[0].done)
+	assert.Equal(t, true, s.chunks[2].done)
+	assert.Equal(t, 2, s.remaining)
+
+	_, err = s.complete(0, "c", blocks, [32]byte{}, [32]byte{'x'})
+	require.NoError(t, err)
+	_, err = s.complete(1, "b", blocks, [32]byte{'x'}, [32]byte{'b'})
+	require.NoError(t, err)
+	_, _, ok := s.result()
+	assert.Equal(t, true, ok)
+}
+
+func TestFanOutScheduler_SkippedChunk(t *testing.T) {
+	blocks := chunkBlocks(t)
+	s := newFanOutScheduler(0, 128, time.Minute)
+	_, err := s.complete(0, "a", blocks, [32]byte{}, [32]byte{'a'})
+	require.NoError(t, err)
+	_, err = s.complete(2, "a", blocks, [32]byte{'b'}, [32]byte{'c'})
+	require.NoError(t, err)
+	// A chunk without blocks is rejected when its neighbours do not link across it, and they are reopened.
+	_, err = s.complete(1, "b", nil, [32]byte{}, [32]byte{})
+	require.ErrorIs(t, err, errChunkNotLinked)
+	assert.Equal(t, false, s.chunks[0].done)
+	assert.Equal(t, false, s.chunks[2].done)
+	assert.Equal(t, 4, s.remaining)
+
+	// Chunks are linked across the chunks without blocks.
+	_, err = s.complete(0, "a", blocks, [32]byte{}, [32]byte{'a'})
+	require.NoError(t, err)
+	_, err = s.complete(1, "a", nil, [32]byte{}, [32]byte{})
+	require.NoError(t, err)
+	_, err = s.complete(2, "a", nil, [32]byte{}, [32]byte{})
+	require.NoError(t, err)
+	_, err = s.complete(3, "b", blocks, [32]byte{'x'}, [32]byte{'d'})
+	require.ErrorIs(t, err, errChunkNotLinked)
+	assert.Equal(t, false, s.chunks[0].done)
+	assert.Equal(t, false, s.chunks[1].done)
+	assert.Equal(t, false, s.chunks[2].done)
+
+	_, err = s.complete(0, "a", blocks, [32]byte{}, [32]byte{'a'})
+	require.NoError(t, err)
+	_, err = s.complete(3, "b", blocks, [32]byte{'a'}, [32]byte{'d'})
+	require.NoError(t, err)
+	_, err = s.complete(2, "b", nil, [32]byte{}, [32]byte{})
+	require.NoError(t, err)
+	_, err = s.complete(1, "b", nil, [32]byte{}, [32]byte{})
+	require.NoError(t, err)
+	result, _, ok := s.result()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 2, len(result))
+}
+
+func TestLinkChunk(t *testing.T) {
+	c := &fanOutChunk{start: 32, count: 32}
+	_, _, err := linkChunk(c, nil)
+	require.NoError(t, err)
+
+	blk1 := util.NewBeaconBlock()
+	blk1.Block.Slot = 33
+	blk1.Block.ParentRoot = bytesutil.PadTo([]byte("parent"), 32)
+	root1, err := blk1.Block.HashTreeRoot()
+	require.NoError(t, err)
+	blk2 := util.NewBeaconBlock()
+	blk2.Block.Slot = 35
+	blk2.Block.ParentRoot = root1[:]
+	root2, err := blk2.Block.HashTreeRoot()
+	require.NoError(t, err)
+	wsb1, err := wrapper.WrappedSignedBeaconBlock(blk1)
+	require.NoError(t, err)
+	wsb2, err := wrapper.WrappedSignedBeaconBlock(blk2)
+	require.NoError(t, err)
+
+	parent, head, err := linkChunk(c, []interfaces.SignedBeaconBlock{wsb1, wsb2})
+	require.NoError(t, err)
+	assert.Equal(t, bytesutil.ToBytes32(blk1.Block.ParentRoot), parent)
+	assert.Equal(t, root2, head)
+
+	_, _, err = linkChunk(c, []interfaces.SignedBeaconBlock{wsb2, wsb1})
+	require.ErrorIs(t, err, errChunkNotLinked)
+	_, _, err = linkChunk(&fanOutChunk{start: 0, count: 32}, []interfaces.SignedBeaconBlock{wsb1})
+	require.ErrorIs(t, err, errChunkNotLinked)
+}
+
+func TestBlocksFetcher_fetchBlocksFanOut(t *testing.T) {
+	mc, p2p, _ := initializeTestServices(t, makeSequence(1, 320), []*peerData{})
+	fastPeer := connectPeer(t, p2p, &peerData{
+		blocks:         makeSequence(1, 320),
+		finalizedEpoch: 8,
+		headSlot:       320,
+	}, p2p.Peers())
+	slowPeer := connectPeer(t, p2p, &peerData{
+		blocks:         makeSequence(1, 320),
+		finalizedEpoch: 8,
+		headSlot:       320,
+		responseDelay:  2 * time.Second,
+	}, p2p.Peers())
+	failingPeer := connectPeer(t, p2p, &peerData{
+		blocks:         makeSequence(1, 320),
+		finalizedEpoch: 8,
+		headSlot:       320,
+		failureSlots:   makeSequence(1, 320),
+	}, p2p.Peers())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{
+		chain: mc,
+		p2p:   p2p,
+	})
+	fetcher.fanOutPeers = 4
+	fetcher.fanOutStealAfter = 200 * time.Millisecond
+	scorer := p2p.Peers().Scorers().BlockProviderScorer()
+	scorer.IncrementProcessedBlocks(slowPeer, 64)
+
+	blocks, pid, err := fetcher.fetchBlocksFanOut(ctx, 1, 128, []peer.ID{slowPeer, failingPeer, fastPeer})
+	require.NoError(t, err)
+	assert.Equal(t, fastPeer, pid)
+	require.Equal(t, 128, len(blocks))
+	for i, blk := range blocks {
+		assert.Equal(t, types.Slot(i+1), blk.Block().Slot())
+	}
+	// Slow peers are penalized as block providers for being outpaced, but are not considered bad.
+	assert.Equal(t, true, scorer.ProcessedBlocks(slowPeer) < 64)
+	count, err := p2p.Peers().Scorers().BadResponsesScorer().Count(slowPeer)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// The request fails once no peer is able to return a chunk.
+	_, _, err = fetcher.fetchBlocksFanOut(ctx, 1, 64, []peer.ID{failingPeer})
+	assert.ErrorContains(t, errNoPeersAvailable.Error(), err)
+}
+
+func TestBlocksFetcher_fetchBlocksFanOut_SkippedSlots(t *testing.T) {
+	// No block is proposed within the second epoch.
+	chain := append(makeSequence(1, 31), makeSequence(64, 160)...)
+	mc, p2p, _ := initializeTestServices(t, chain, []*peerData{})
+	pids := make([]peer.ID, 0, 2)
+	for i := 0; i < 2; i++ {
+		pids = append(pids, connectPeer(t, p2p, &peerData{
+			blocks:         chain,
+			finalizedEpoch: 4,
+			headSlot:       160,
+		}, p2p.Peers()))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{
+		chain: mc,
+		p2p:   p2p,
+	})
+	fetcher.fanOutPeers = 2
+
+	blocks, _, err := fetcher.fetchBlocksFanOut(ctx, 1, 128, pids)
+	require.NoError(t, err)
+	require.Equal(t, 31+65, len(blocks))
+	assert.Equal(t, types.Slot(31), blocks[30].Block().Slot())
+	assert.Equal(t, types.Slot(64), blocks[31].Block().Slot())
+}
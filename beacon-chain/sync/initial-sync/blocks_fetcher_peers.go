@@ -100,11 +100,12 @@ func (f *blocksFetcher) filterPeers(ctx context.Context, peers []peer.ID, peersP
 		return peers
 	}
 
-	// Sort peers using both block provider score and, custom, capacity based score (see
-	// peerFilterCapacityWeight if you want to give different weights to provider's and capacity
-	// scores).
+	// Sort peers using block provider score scaled by block quality score and, custom, capacity
+	// based score (see peerFilterCapacityWeight if you want to give different weights to provider's
+	// and capacity scores).
 	// Scores produced are used as weights, so peers are ordered probabilistically i.e. peer with
 	// a higher score has higher chance to end up higher in the list.
+	qualityScores := f.p2p.Peers().Scorers().BlockQualityScorer().Scores(peers)
 	scorer := f.p2p.Peers().Scorers().BlockProviderScorer()
 	peers = scorer.WeightSorted(f.rand, peers, func(peerID peer.ID, blockProviderScore float64) float64 {
 		remaining, capacity := float64(f.rateLimiter.Remaining(peerID.String())), float64(f.rateLimiter.Capacity())
@@ -114,11 +115,27 @@ func (f *blocksFetcher) filterPeers(ctx context.Context, peers []peer.ID, peersP
 			return 0.0
 		}
 		capScore := remaining / capacity
-		overallScore := blockProviderScore*(1.0-f.capacityWeight) + capScore*f.capacityWeight
+		providerScore := blockProviderScore * qualityScores[peerID]
+		overallScore := providerScore*(1.0-f.capacityWeight) + capScore*f.capacityWeight
 		return math.Round(overallScore*scorers.ScoreRoundingFactor) / scorers.ScoreRoundingFactor
 	})
 
-	return trimPeers(peers, peersPercentage)
+	return trimPeers(f.preferBlockServingPeers(peers), peersPercentage)
+}
+
+// preferBlockServingPeers moves the peers advertising a role which does not serve blocks by range,
+// such as gossip relays, to the end of the list, keeping the order of the peers otherwise.
+func (f *blocksFetcher) preferBlockServingPeers(pids []peer.ID) []peer.ID {
+	serving := make([]peer.ID, 0, len(pids))
+	var others []peer.ID
+	for _, pid := range pids {
+		if f.p2p.Peers().NodeRole(pid).ServesBlocksByRange() {
+			serving = append(serving, pid)
+		} else {
+			others = append(others, pid)
+		}
+	}
+	return append(serving, others...)
 }
 
 // trimPeers limits peer list, returning only specified percentage of peers.
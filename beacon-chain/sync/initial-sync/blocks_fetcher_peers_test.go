@@ -8,8 +8,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/kevinms/leakybucket-go"
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
@@ -301,6 +304,36 @@ func TestBlocksFetcher_filterPeers(t *testing.T) {
 	}
 }
 
+func TestBlocksFetcher_filterPeers_PrefersBlockServingPeers(t *testing.T) {
+	mc, p2p, _ := initializeTestServices(t, []types.Slot{}, []*peerData{})
+	fetcher := newBlocksFetcher(context.Background(), &blocksFetcherConfig{
+		chain: mc,
+		p2p:   p2p,
+	})
+	for pid, role := range map[peer.ID]peers.NodeRole{
+		"relay":  peers.GossipRelayNode,
+		"server": peers.RPCServerNode,
+		"full":   peers.FullNode,
+	} {
+		record := &enr.Record{}
+		if role != peers.FullNode {
+			record.Set(enr.WithEntry(peers.NodeRoleEnrKey, uint8(role)))
+		}
+		p2p.Peers().Add(record, pid, nil, network.DirOutbound)
+	}
+	assert.Equal(t, peers.GossipRelayNode, p2p.Peers().NodeRole("relay"))
+	assert.Equal(t, peers.RPCServerNode, p2p.Peers().NodeRole("server"))
+	assert.Equal(t, peers.FullNode, p2p.Peers().NodeRole("full"))
+	// Peers whose ENR is unknown are full nodes.
+	assert.Equal(t, peers.FullNode, p2p.Peers().NodeRole("unknown"))
+
+	for i := 0; i < 100; i++ {
+		filtered := fetcher.filterPeers(context.Background(), []peer.ID{"relay", "server", "full", "unknown"}, 1.0)
+		require.Equal(t, 4, len(filtered))
+		assert.Equal(t, peer.ID("relay"), filtered[3])
+	}
+}
+
 func TestBlocksFetcher_removeStalePeerLocks(t *testing.T) {
 	type peerData struct {
 		peerID   peer.ID
@@ -3,6 +3,7 @@ package initialsync
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -294,14 +295,24 @@ func (f *blocksFetcher) bestFinalizedSlot() types.Slot {
 	cp := f.chain.FinalizedCheckpt()
 	finalizedEpoch, _ := f.p2p.Peers().BestFinalized(
 		params.BeaconConfig().MaxPeersToSync, cp.Epoch)
-	return params.BeaconConfig().SlotsPerEpoch.Mul(uint64(finalizedEpoch))
+	return peerEpochStart(finalizedEpoch)
 }
 
 // bestNonFinalizedSlot returns the highest non-finalized slot of enough number of connected peers.
 func (f *blocksFetcher) bestNonFinalizedSlot() types.Slot {
 	headEpoch := slots.ToEpoch(f.chain.HeadSlot())
 	targetEpoch, _ := f.p2p.Peers().BestNonFinalized(flags.Get().MinimumSyncPeers*2, headEpoch)
-	return params.BeaconConfig().SlotsPerEpoch.Mul(uint64(targetEpoch))
+	return peerEpochStart(targetEpoch)
+}
+
+// peerEpochStart returns the start slot of an epoch reported by peers. Epochs too large
+// to have a start slot saturate to the highest slot instead of overflowing.
+func peerEpochStart(epoch types.Epoch) types.Slot {
+	slot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return slot
 }
 
 // calculateHeadAndTargetEpochs return node's current head epoch, along with the best known target
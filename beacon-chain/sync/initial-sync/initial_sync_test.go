@@ -49,6 +49,7 @@ type peerData struct {
 	headSlot       types.Slot
 	failureSlots   []types.Slot // slots at which the peer will return an error
 	forkedPeer     bool
+	responseDelay  time.Duration // delay before the peer responds to a request
 }
 
 func TestMain(m *testing.M) {
@@ -176,6 +177,7 @@ func connectPeer(t *testing.T, host *p2pt.TestP2P, datum *peerData, peerStatus *
 		assert.NoError(t, p.Encoding().DecodeWithMaxLength(stream, req))
 
 		requestedBlocks := makeSequence(req.StartSlot, req.StartSlot.Add((req.Count-1)*req.Step))
+		time.Sleep(datum.responseDelay)
 
 		// Expected failure range
 		if len(slice.IntersectionSlot(datum.failureSlots, requestedBlocks)) > 0 {
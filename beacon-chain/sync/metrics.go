@@ -56,6 +56,47 @@ var (
 		},
 		[]string{"topic"},
 	)
+	rpcThrottledRequestsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_rpc_requests_throttled_total",
+			Help: "Count of inbound RPC requests rejected by the rate limiter.",
+		},
+		[]string{"topic"},
+	)
+	rpcThrottledRequestCostCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_rpc_requests_throttled_cost_total",
+			Help: "Total cost, in requests or blocks, of inbound RPC requests rejected by the rate limiter.",
+		},
+		[]string{"topic"},
+	)
+	gossipThrottledMessagesCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_gossip_messages_throttled_total",
+			Help: "Count of gossip messages ignored because their peer exceeded its rate limit on the topic.",
+		},
+		[]string{"topic"},
+	)
+	rpcRateLimitDisconnectsCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "p2p_rpc_rate_limit_disconnects_total",
+			Help: "Count of peers disconnected for persistently exceeding the RPC rate limits.",
+		},
+	)
+	syncCommitteeMessageVoteCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sync_committee_message_block_root_total",
+			Help: "Count of sync committee messages by the block they vote for: head or non_head, and pending when the block is not known yet.",
+		},
+		[]string{"vote"},
+	)
+	pendingQueueDroppedCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sync_pending_queue_dropped_total",
+			Help: "Count of attestations and sync committee messages for unknown blocks dropped because the pending queue is full.",
+		},
+		[]string{"type"},
+	)
 	numberOfTimesResyncedCounter = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "number_of_times_resynced",
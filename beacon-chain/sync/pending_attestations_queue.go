@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"sync"
+	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/prysmaticlabs/prysm/async"
@@ -21,8 +22,22 @@ import (
 // This defines how often a node cleans up and processes pending attestations in the queue.
 var processPendingAttsPeriod = slots.DivideSlotBy(2 /* twice per slot */)
 
+// This defines how often the newly seen block roots of pending attestations and sync committee
+// messages are requested from peers.
+var pendingRootRequestPeriod = slots.DivideSlotBy(12 /* twelve times per slot */)
+
+const (
+	// maxPendingAtts caps the number of attestations waiting for their block in the pending queue.
+	maxPendingAtts = 8192
+	// pendingRootRequestsSize is the number of newly seen block roots of pending attestations and
+	// sync committee messages that can wait to be requested from peers, before the next
+	// processing of the pending queues.
+	pendingRootRequestsSize = 64
+)
+
 // This processes pending attestation queues on every `processPendingAttsPeriod`.
 func (s *Service) processPendingAttsQueue() {
+	go s.requestPendingRoots()
 	// Prevents multiple queue processing goroutines (invoked by RunEvery) from contending for data.
 	mutex := new(sync.Mutex)
 	async.RunEvery(s.ctx, processPendingAttsPeriod, func() {
@@ -34,6 +49,40 @@ func (s *Service) processPendingAttsQueue() {
 	})
 }
 
+// requestPendingRoots requests the blocks of newly pending attestations and sync committee
+// messages without waiting for the next processing of the pending queues. Roots queued within
+// the same pendingRootRequestPeriod are batched into a single request, so that a burst of
+// messages for unknown blocks does not send a request to a peer for every root.
+func (s *Service) requestPendingRoots() {
+	randGen := rand.NewGenerator()
+	ticker := time.NewTicker(pendingRootRequestPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			var roots [][32]byte
+			for len(s.pendingRootRequests) > 0 {
+				roots = append(roots, <-s.pendingRootRequests)
+			}
+			if err := s.sendBatchRootRequest(s.ctx, roots, randGen); err != nil {
+				log.WithError(err).Debug("Could not request blocks of pending attestations")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// triggerPendingRootRequest schedules a request for a block root newly referenced by
+// the pending queues. If too many requests are already scheduled, the root is requested on the
+// next processing of the pending queues.
+func (s *Service) triggerPendingRootRequest(root [32]byte) {
+	select {
+	case s.pendingRootRequests <- root:
+	default:
+	}
+}
+
 // This defines how pending attestations are processed. It contains features:
 // 1. Clean up invalid pending attestations from the queue.
 // 2. Check if pending attestations can be processed when the block has arrived.
@@ -70,6 +119,7 @@ func (s *Service) processPendingAtts(ctx context.Context) error {
 
 			// Delete the missing block root key from pending attestation queue so a node will not request for the block again.
 			s.pendingAttsLock.Lock()
+			s.pendingAttsCount -= len(s.blkRootToPendingAtts[bRoot])
 			delete(s.blkRootToPendingAtts, bRoot)
 			s.pendingAttsLock.Unlock()
 		} else {
@@ -83,6 +133,7 @@ func (s *Service) processPendingAtts(ctx context.Context) error {
 			pendingRoots = append(pendingRoots, bRoot)
 		}
 	}
+	pendingRoots = append(pendingRoots, s.processPendingSyncCommitteeMessages(ctx)...)
 	return s.sendBatchRootRequest(ctx, pendingRoots, randGen)
 }
 
@@ -157,15 +208,22 @@ func (s *Service) processAttestations(ctx context.Context, attestations []*ethpb
 
 // This defines how pending attestations is saved in the map. The key is the
 // root of the missing block. The value is the list of pending attestations
-// that voted for that block root.
+// that voted for that block root. Attestations are dropped once the queue holds
+// maxPendingAtts attestations.
 func (s *Service) savePendingAtt(att *ethpb.SignedAggregateAttestationAndProof) {
 	root := bytesutil.ToBytes32(att.Message.Aggregate.Data.BeaconBlockRoot)
 
 	s.pendingAttsLock.Lock()
 	defer s.pendingAttsLock.Unlock()
+	if s.pendingAttsCount >= maxPendingAtts {
+		pendingQueueDroppedCount.WithLabelValues("attestation").Inc()
+		return
+	}
 	_, ok := s.blkRootToPendingAtts[root]
 	if !ok {
 		s.blkRootToPendingAtts[root] = []*ethpb.SignedAggregateAttestationAndProof{att}
+		s.pendingAttsCount++
+		s.triggerPendingRootRequest(root)
 		return
 	}
 
@@ -177,6 +235,7 @@ func (s *Service) savePendingAtt(att *ethpb.SignedAggregateAttestationAndProof)
 	}
 
 	s.blkRootToPendingAtts[root] = append(s.blkRootToPendingAtts[root], att)
+	s.pendingAttsCount++
 }
 
 // This validates the pending attestations in the queue are still valid.
@@ -195,6 +254,7 @@ func (s *Service) validatePendingAtts(ctx context.Context, slot types.Slot) {
 			if slot >= atts[i].Message.Aggregate.Data.Slot+params.BeaconConfig().SlotsPerEpoch {
 				// Remove the pending attestation from the list in place.
 				atts = append(atts[:i], atts[i+1:]...)
+				s.pendingAttsCount--
 			}
 		}
 		s.blkRootToPendingAtts[bRoot] = atts
@@ -412,3 +412,30 @@ func TestValidatePendingAtts_NoDuplicatingAggregatorIndex(t *testing.T) {
 	assert.Equal(t, 1, len(s.blkRootToPendingAtts[r1]), "Did not save pending atts")
 	assert.Equal(t, 1, len(s.blkRootToPendingAtts[r2]), "Did not save pending atts")
 }
+
+func TestSavePendingAtts_BoundedAndTriggersRootRequest(t *testing.T) {
+	s := &Service{
+		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		pendingRootRequests:  make(chan [32]byte, pendingRootRequestsSize),
+	}
+
+	r1 := [32]byte{'A'}
+	for i := 0; i < maxPendingAtts+1; i++ {
+		s.savePendingAtt(&ethpb.SignedAggregateAttestationAndProof{
+			Message: &ethpb.AggregateAttestationAndProof{
+				AggregatorIndex: types.ValidatorIndex(i),
+				Aggregate: &ethpb.Attestation{
+					Data: &ethpb.AttestationData{Slot: 1, BeaconBlockRoot: r1[:]}}}})
+	}
+	assert.Equal(t, maxPendingAtts, len(s.blkRootToPendingAtts[r1]), "Unexpected pending atts count")
+
+	assert.Equal(t, maxPendingAtts, s.pendingAttsCount, "Unexpected pending atts count")
+
+	// Only the first attestation of a block root triggers a request of the block.
+	require.Equal(t, 1, len(s.pendingRootRequests))
+	assert.Equal(t, r1, <-s.pendingRootRequests)
+
+	// Pruning the expired attestations frees up the queue.
+	s.validatePendingAtts(context.Background(), 1+params.BeaconConfig().SlotsPerEpoch)
+	assert.Equal(t, 0, s.pendingAttsCount, "Unexpected pending atts count")
+}
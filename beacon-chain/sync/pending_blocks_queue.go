@@ -168,7 +168,7 @@ func (s *Service) processPendingBlocks(ctx context.Context) error {
 			default:
 			}
 
-			if err := s.cfg.chain.ReceiveBlock(ctx, b, blkRoot); err != nil {
+			if err := s.cfg.chain.ReceiveVerifiedBlock(ctx, b, blkRoot, s.blockVerification(blkRoot, b.Signature())); err != nil {
 				if blockchain.IsInvalidBlock(err) {
 					r := blockchain.InvalidBlockRoot(err)
 					if r != [32]byte{} {
@@ -224,9 +224,10 @@ func (s *Service) sendBatchRootRequest(ctx context.Context, roots [][32]byte, ra
 		return nil
 	}
 	roots = s.dedupRoots(roots)
-	// Randomly choose a peer to query from our best peers. If that peer cannot return
-	// all the requested blocks, we randomly select another peer.
-	pid := bestPeers[randGen.Int()%len(bestPeers)]
+	// Query our best peers in order of their block quality, picking randomly among peers of the
+	// same quality. If a peer cannot return all the requested blocks, we move on to the next one.
+	bestPeers = s.cfg.p2p.Peers().Scorers().BlockQualityScorer().Sorted(randGen, bestPeers)
+	pid := bestPeers[0]
 	for i := 0; i < numOfTries; i++ {
 		req := p2ptypes.BeaconBlockByRootsReq(roots)
 		if len(roots) > int(params.BeaconNetworkConfig().MaxRequestBlocks) {
@@ -250,7 +251,7 @@ func (s *Service) sendBatchRootRequest(ctx context.Context, roots [][32]byte, ra
 		// Choosing a new peer with the leftover set of
 		// roots to request.
 		roots = newRoots
-		pid = bestPeers[randGen.Int()%len(bestPeers)]
+		pid = bestPeers[(i+1)%len(bestPeers)]
 	}
 	return nil
 }
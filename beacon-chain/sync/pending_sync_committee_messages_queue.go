@@ -0,0 +1,112 @@
+package sync
+
+import (
+	"context"
+	"encoding/hex"
+
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// maxPendingSyncMsgs caps the number of sync committee messages waiting for their block in the pending queue.
+const maxPendingSyncMsgs = 2048
+
+// This defines how pending sync committee messages are saved in the map. The key is the root
+// of the missing block. The value is the list of pending messages that voted for that block root.
+// Messages are dropped once the queue holds maxPendingSyncMsgs messages.
+func (s *Service) savePendingSyncCommitteeMessage(m *ethpb.SyncCommitteeMessage) {
+	root := bytesutil.ToBytes32(m.BlockRoot)
+
+	s.pendingSyncMsgsLock.Lock()
+	defer s.pendingSyncMsgsLock.Unlock()
+	if s.pendingSyncMsgsCount >= maxPendingSyncMsgs {
+		pendingQueueDroppedCount.WithLabelValues("sync_committee_message").Inc()
+		return
+	}
+	msgs, ok := s.blkRootToPendingSyncMsgs[root]
+	if !ok {
+		s.blkRootToPendingSyncMsgs[root] = []*ethpb.SyncCommitteeMessage{m}
+		s.pendingSyncMsgsCount++
+		s.triggerPendingRootRequest(root)
+		return
+	}
+
+	// Skip if a message of the same validator for the same slot already exists in the pending queue.
+	for _, pm := range msgs {
+		if pm.ValidatorIndex == m.ValidatorIndex && pm.Slot == m.Slot {
+			return
+		}
+	}
+
+	s.blkRootToPendingSyncMsgs[root] = append(msgs, m)
+	s.pendingSyncMsgsCount++
+}
+
+// processPendingSyncCommitteeMessages saves to the pool the pending sync committee messages whose
+// block has arrived, and returns the block roots that are still missing. The messages were already
+// validated and forwarded when they were received.
+func (s *Service) processPendingSyncCommitteeMessages(ctx context.Context) [][32]byte {
+	ctx, span := trace.StartSpan(ctx, "processPendingSyncCommitteeMessages")
+	defer span.End()
+
+	s.validatePendingSyncCommitteeMessages(ctx, s.cfg.chain.CurrentSlot())
+
+	s.pendingSyncMsgsLock.RLock()
+	roots := make([][32]byte, 0, len(s.blkRootToPendingSyncMsgs))
+	for br := range s.blkRootToPendingSyncMsgs {
+		roots = append(roots, br)
+	}
+	s.pendingSyncMsgsLock.RUnlock()
+
+	var pendingRoots [][32]byte
+	for _, bRoot := range roots {
+		if !s.hasBlockAndState(ctx, bRoot) {
+			pendingRoots = append(pendingRoots, bRoot)
+			continue
+		}
+		s.pendingSyncMsgsLock.Lock()
+		msgs := s.blkRootToPendingSyncMsgs[bRoot]
+		// Delete the block root from the pending queue so a node will not request the block again.
+		delete(s.blkRootToPendingSyncMsgs, bRoot)
+		s.pendingSyncMsgsCount -= len(msgs)
+		s.pendingSyncMsgsLock.Unlock()
+
+		for _, m := range msgs {
+			if err := s.cfg.syncCommsPool.SaveSyncCommitteeMessage(m); err != nil {
+				log.WithError(err).Debug("Could not save pending sync committee message")
+			}
+		}
+		log.WithFields(logrus.Fields{
+			"blockRoot":           hex.EncodeToString(bytesutil.Trunc(bRoot[:])),
+			"pendingSyncMsgCount": len(msgs),
+		}).Debug("Processed pending sync committee messages")
+	}
+	return pendingRoots
+}
+
+// This removes the pending sync committee messages that can no longer be accepted. Sync committee
+// messages are only valid for the slot they are produced in.
+func (s *Service) validatePendingSyncCommitteeMessages(ctx context.Context, slot types.Slot) {
+	_, span := trace.StartSpan(ctx, "validatePendingSyncCommitteeMessages")
+	defer span.End()
+
+	s.pendingSyncMsgsLock.Lock()
+	defer s.pendingSyncMsgsLock.Unlock()
+
+	for bRoot, msgs := range s.blkRootToPendingSyncMsgs {
+		for i := len(msgs) - 1; i >= 0; i-- {
+			if msgs[i].Slot < slot {
+				msgs = append(msgs[:i], msgs[i+1:]...)
+				s.pendingSyncMsgsCount--
+			}
+		}
+		if len(msgs) == 0 {
+			delete(s.blkRootToPendingSyncMsgs, bRoot)
+			continue
+		}
+		s.blkRootToPendingSyncMsgs[bRoot] = msgs
+	}
+}
@@ -0,0 +1,138 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
+)
+
+func TestSavePendingSyncCommitteeMessage_NoDuplicates(t *testing.T) {
+	s := &Service{
+		blkRootToPendingSyncMsgs: make(map[[32]byte][]*ethpb.SyncCommitteeMessage),
+		pendingRootRequests:      make(chan [32]byte, pendingRootRequestsSize),
+	}
+
+	r1 := [32]byte{'A'}
+	r2 := [32]byte{'B'}
+	s.savePendingSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 1, ValidatorIndex: 1, BlockRoot: r1[:]})
+	s.savePendingSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 1, ValidatorIndex: 1, BlockRoot: r1[:]})
+	s.savePendingSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 1, ValidatorIndex: 2, BlockRoot: r1[:]})
+	s.savePendingSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 1, ValidatorIndex: 2, BlockRoot: r2[:]})
+
+	assert.Equal(t, 2, len(s.blkRootToPendingSyncMsgs[r1]), "Unexpected pending messages count")
+	assert.Equal(t, 1, len(s.blkRootToPendingSyncMsgs[r2]), "Unexpected pending messages count")
+	assert.Equal(t, 2, len(s.pendingRootRequests), "Unexpected block root requests count")
+}
+
+func TestSavePendingSyncCommitteeMessage_Bounded(t *testing.T) {
+	s := &Service{
+		blkRootToPendingSyncMsgs: make(map[[32]byte][]*ethpb.SyncCommitteeMessage),
+	}
+
+	r1 := [32]byte{'A'}
+	for i := 0; i < maxPendingSyncMsgs+1; i++ {
+		s.savePendingSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 1, ValidatorIndex: types.ValidatorIndex(i), BlockRoot: r1[:]})
+	}
+	assert.Equal(t, maxPendingSyncMsgs, len(s.blkRootToPendingSyncMsgs[r1]), "Unexpected pending messages count")
+	assert.Equal(t, maxPendingSyncMsgs, s.pendingSyncMsgsCount, "Unexpected pending messages count")
+
+	// Pruning the expired messages frees up the queue.
+	s.validatePendingSyncCommitteeMessages(context.Background(), 2)
+	assert.Equal(t, 0, s.pendingSyncMsgsCount, "Unexpected pending messages count")
+	s.savePendingSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 2, BlockRoot: r1[:]})
+	assert.Equal(t, 1, len(s.blkRootToPendingSyncMsgs[r1]), "Unexpected pending messages count")
+}
+
+func TestValidatePendingSyncCommitteeMessages_CanPruneOldMessages(t *testing.T) {
+	s := &Service{
+		blkRootToPendingSyncMsgs: make(map[[32]byte][]*ethpb.SyncCommitteeMessage),
+	}
+
+	r1 := [32]byte{'A'}
+	r2 := [32]byte{'B'}
+	for i := types.Slot(0); i < 4; i++ {
+		s.savePendingSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: i, BlockRoot: r1[:]})
+	}
+	s.savePendingSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 1, BlockRoot: r2[:]})
+
+	s.validatePendingSyncCommitteeMessages(context.Background(), 2)
+	assert.Equal(t, 2, len(s.blkRootToPendingSyncMsgs[r1]), "Did not prune old messages")
+	_, ok := s.blkRootToPendingSyncMsgs[r2]
+	assert.Equal(t, false, ok, "Did not delete block keys")
+}
+
+func TestProcessPendingSyncCommitteeMessages_NoBlockKeepsMessages(t *testing.T) {
+	db := dbtest.SetupDB(t)
+	s := &Service{
+		cfg: &config{
+			p2p:      p2ptest.NewTestP2P(t),
+			beaconDB: db,
+			chain:    &mock.ChainService{Genesis: prysmTime.Now(), DB: db},
+		},
+		blkRootToPendingSyncMsgs: make(map[[32]byte][]*ethpb.SyncCommitteeMessage),
+	}
+
+	r1 := [32]byte{'A'}
+	s.savePendingSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{Slot: 0, BlockRoot: r1[:]})
+	roots := s.processPendingSyncCommitteeMessages(context.Background())
+	require.DeepEqual(t, [][32]byte{r1}, roots)
+	assert.Equal(t, 1, len(s.blkRootToPendingSyncMsgs[r1]), "Unexpected pending messages count")
+}
+
+func TestSyncCommitteeMessageSubscriber_QueuesPoolInsertionForUnknownBlock(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	headRoot := [32]byte{'H'}
+	unknownRoot := [32]byte{'U'}
+	chain := &mock.ChainService{
+		Genesis:            prysmTime.Now(),
+		DB:                 db,
+		Root:               headRoot[:],
+		InitSyncBlockRoots: make(map[[32]byte]bool),
+	}
+	pool := synccommittee.NewStore()
+	s := &Service{
+		cfg: &config{
+			p2p:           p2ptest.NewTestP2P(t),
+			beaconDB:      db,
+			chain:         chain,
+			syncCommsPool: pool,
+		},
+		blkRootToPendingSyncMsgs: make(map[[32]byte][]*ethpb.SyncCommitteeMessage),
+		pendingRootRequests:      make(chan [32]byte, pendingRootRequestsSize),
+	}
+
+	// A message for the head is saved to the pool right away.
+	require.NoError(t, s.syncCommitteeMessageSubscriber(ctx, &ethpb.SyncCommitteeMessage{ValidatorIndex: 1, BlockRoot: headRoot[:]}))
+	msgs, err := pool.SyncCommitteeMessages(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(msgs), "Unexpected pool messages count")
+
+	// A message for an unknown block is only queued, and the block is requested.
+	pending := &ethpb.SyncCommitteeMessage{ValidatorIndex: 2, BlockRoot: unknownRoot[:]}
+	require.NoError(t, s.syncCommitteeMessageSubscriber(ctx, pending))
+	msgs, err = pool.SyncCommitteeMessages(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(msgs), "Unexpected pool messages count")
+	assert.Equal(t, 1, len(s.blkRootToPendingSyncMsgs[unknownRoot]), "Unexpected pending messages count")
+	assert.Equal(t, 1, len(s.pendingRootRequests), "Unexpected block root requests count")
+
+	// The queued message is saved to the pool once the block arrives.
+	chain.InitSyncBlockRoots[unknownRoot] = true
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Root: unknownRoot[:]}))
+	assert.Equal(t, 0, len(s.processPendingSyncCommitteeMessages(ctx)), "Unexpected missing block roots")
+	assert.Equal(t, 0, s.pendingSyncMsgsCount, "Unexpected pending messages count")
+	msgs, err = pool.SyncCommitteeMessages(0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(msgs), "Unexpected pool messages count")
+	require.DeepSSZEqual(t, pending, msgs[1])
+}
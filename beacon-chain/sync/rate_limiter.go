@@ -2,10 +2,12 @@ package sync
 
 import (
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/kevinms/leakybucket-go"
 	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
@@ -16,9 +18,26 @@ import (
 
 const defaultBurstLimit = 5
 
+// Finalized state chunks served per second and burst allowed per peer, bounding the upload
+// bandwidth used by a single peer syncing the state.
+const (
+	stateSyncChunksPerSecond = 8
+	stateSyncChunksBurst     = 32
+)
+
+// Factor by which RPC server nodes raise the rate of blocks served to each peer.
+const rpcServerBlockLimitFactor = 4
+
+// Rate limit violations forgiven per second, so that only peers persistently exceeding
+// their allowances are disconnected.
+const rateLimitViolationsPerSecond = 0.1
+
 // Dummy topic to validate all incoming rpc requests.
 const rpcLimiterTopic = "rpc-limiter-topic"
 
+// Dummy topic to track the rate limit violations of peers.
+const rateLimitViolationsTopic = "rpc-rate-limit-violations-topic"
+
 type limiter struct {
 	limiterMap map[string]*leakybucket.Collector
 	p2p        p2p.P2P
@@ -32,39 +51,76 @@ func newRateLimiter(p2pProvider p2p.P2P) *limiter {
 	addEncoding := func(topic string) string {
 		return topic + p2pProvider.Encoding().ProtocolSuffix()
 	}
-	// Initialize block limits.
-	allowedBlocksPerSecond := float64(flags.Get().BlockBatchLimit)
-	allowedBlocksBurst := int64(flags.Get().BlockBatchLimitBurstFactor * flags.Get().BlockBatchLimit)
-
 	// Set topic map for all rpc topics.
 	topicMap := make(map[string]*leakybucket.Collector, len(p2p.RPCTopicMappings))
 	// Goodbye Message
 	topicMap[addEncoding(p2p.RPCGoodByeTopicV1)] = leakybucket.NewCollector(1, 1, false /* deleteEmptyBuckets */)
-	// MetadataV0 Message
-	topicMap[addEncoding(p2p.RPCMetaDataTopicV1)] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
-	topicMap[addEncoding(p2p.RPCMetaDataTopicV2)] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
+	// Metadata Messages share a collector across versions.
+	metadataLimit := rateOrDefault(flags.Get().RPCMetadataRateLimit, flags.RPCMetadataRateLimit.Value)
+	metadataCollector := leakybucket.NewCollector(metadataLimit, int64(metadataLimit*defaultBurstLimit), false /* deleteEmptyBuckets */)
+	topicMap[addEncoding(p2p.RPCMetaDataTopicV1)] = metadataCollector
+	topicMap[addEncoding(p2p.RPCMetaDataTopicV2)] = metadataCollector
 	// Ping Message
 	topicMap[addEncoding(p2p.RPCPingTopicV1)] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
 	// Status Message
-	topicMap[addEncoding(p2p.RPCStatusTopicV1)] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
+	statusLimit := rateOrDefault(flags.Get().RPCStatusRateLimit, flags.RPCStatusRateLimit.Value)
+	topicMap[addEncoding(p2p.RPCStatusTopicV1)] = leakybucket.NewCollector(statusLimit, int64(statusLimit*defaultBurstLimit), false /* deleteEmptyBuckets */)
+	// Finalized State Sync Messages
+	topicMap[addEncoding(p2p.RPCFinalizedStateManifestTopicV1)] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
+	topicMap[addEncoding(p2p.RPCFinalizedStateChunkTopicV1)] = leakybucket.NewCollector(stateSyncChunksPerSecond, stateSyncChunksBurst, false /* deleteEmptyBuckets */)
 
-	// Use a single collector for block requests
-	blockCollector := leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, false /* deleteEmptyBuckets */)
-	// Collector for V2
-	blockCollectorV2 := leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, false /* deleteEmptyBuckets */)
+	// General topic for all rpc requests.
+	topicMap[rpcLimiterTopic] = leakybucket.NewCollector(5, defaultBurstLimit*2, false /* deleteEmptyBuckets */)
+	// Rate limit violations of all rpc requests.
+	maxViolations := rateOrDefault(float64(flags.Get().RPCRateLimitMaxViolations), float64(flags.RPCRateLimitMaxViolations.Value))
+	topicMap[rateLimitViolationsTopic] = leakybucket.NewCollector(rateLimitViolationsPerSecond, int64(maxViolations), false /* deleteEmptyBuckets */)
 
-	// BlocksByRoots requests
-	topicMap[addEncoding(p2p.RPCBlocksByRootTopicV1)] = blockCollector
-	topicMap[addEncoding(p2p.RPCBlocksByRootTopicV2)] = blockCollectorV2
+	l := &limiter{limiterMap: topicMap, p2p: p2pProvider}
+	l.setBlockCollectors(flags.Get().BlockBatchLimit, flags.Get().BlockBatchLimitBurstFactor)
+	return l
+}
 
-	// BlockByRange requests
-	topicMap[addEncoding(p2p.RPCBlocksByRangeTopicV1)] = blockCollector
-	topicMap[addEncoding(p2p.RPCBlocksByRangeTopicV2)] = blockCollectorV2
+// Returns the configured rate, or the default rate of its flag if the rate is not configured.
+// A rate of zero would reject every request, disconnecting every peer.
+func rateOrDefault(rate, defaultRate float64) float64 {
+	if rate <= 0 {
+		return defaultRate
+	}
+	return rate
+}
 
-	// General topic for all rpc requests.
-	topicMap[rpcLimiterTopic] = leakybucket.NewCollector(5, defaultBurstLimit*2, false /* deleteEmptyBuckets */)
+// Replaces the collectors for block requests with ones using the provided limits, or the
+// default limits if not configured. Any previous block collectors are freed.
+func (l *limiter) setBlockCollectors(blockBatchLimit, burstFactor int) {
+	l.Lock()
+	defer l.Unlock()
+
+	// add encoding suffix
+	addEncoding := func(topic string) string {
+		return topic + l.p2p.Encoding().ProtocolSuffix()
+	}
+	allowedBlocksPerSecond := rateOrDefault(float64(blockBatchLimit), float64(flags.BlockBatchLimit.Value))
+	burst := rateOrDefault(float64(burstFactor), float64(flags.BlockBatchLimitBurstFactor.Value))
+	allowedBlocksBurst := int64(burst * allowedBlocksPerSecond)
+	allowedRootBlocksPerSecond := rateOrDefault(float64(flags.Get().RPCBlocksByRootRateLimit), float64(flags.RPCBlocksByRootRateLimit.Value))
+	allowedRootBlocksBurst := int64(burst * allowedRootBlocksPerSecond)
 
-	return &limiter{limiterMap: topicMap, p2p: p2pProvider}
+	// Each collector is shared across versions, so only free it once.
+	for _, t := range []string{p2p.RPCBlocksByRootTopicV1, p2p.RPCBlocksByRangeTopicV1} {
+		if collector, ok := l.limiterMap[addEncoding(t)]; ok {
+			collector.Free()
+		}
+	}
+
+	// BlocksByRoots requests
+	rootCollector := leakybucket.NewCollector(allowedRootBlocksPerSecond, allowedRootBlocksBurst, false /* deleteEmptyBuckets */)
+	l.limiterMap[addEncoding(p2p.RPCBlocksByRootTopicV1)] = rootCollector
+	l.limiterMap[addEncoding(p2p.RPCBlocksByRootTopicV2)] = rootCollector
+
+	// BlockByRange requests
+	rangeCollector := leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, false /* deleteEmptyBuckets */)
+	l.limiterMap[addEncoding(p2p.RPCBlocksByRangeTopicV1)] = rangeCollector
+	l.limiterMap[addEncoding(p2p.RPCBlocksByRangeTopicV2)] = rangeCollector
 }
 
 // Returns the current topic collector for the provided topic.
@@ -74,12 +130,23 @@ func (l *limiter) topicCollector(topic string) (*leakybucket.Collector, error) {
 	return l.retrieveCollector(topic)
 }
 
+// Returns the topic of the collector for the stream. The collectors are shared by all the
+// encodings a protocol can be negotiated with.
+func (l *limiter) streamTopic(stream network.Stream) string {
+	topic := string(stream.Protocol())
+	suffix := p2p.StreamEncoding(l.p2p, stream).ProtocolSuffix()
+	if !strings.HasSuffix(topic, suffix) {
+		return topic
+	}
+	return strings.TrimSuffix(topic, suffix) + l.p2p.Encoding().ProtocolSuffix()
+}
+
 // validates a request with the accompanying cost.
 func (l *limiter) validateRequest(stream network.Stream, amt uint64) error {
 	l.RLock()
 	defer l.RUnlock()
 
-	topic := string(stream.Protocol())
+	topic := l.streamTopic(stream)
 
 	collector, err := l.retrieveCollector(topic)
 	if err != nil {
@@ -92,8 +159,7 @@ func (l *limiter) validateRequest(stream network.Stream, amt uint64) error {
 		amt = 1
 	}
 	if amt > uint64(remaining) {
-		l.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
-		writeErrorResponseToStream(responseCodeInvalidRequest, p2ptypes.ErrRateLimited.Error(), stream, l.p2p)
+		l.recordViolation(stream, topic, amt)
 		return p2ptypes.ErrRateLimited
 	}
 	return nil
@@ -115,19 +181,64 @@ func (l *limiter) validateRawRpcRequest(stream network.Stream) error {
 	// Treat each request as a minimum of 1.
 	amt := int64(1)
 	if amt > remaining {
-		l.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
-		writeErrorResponseToStream(responseCodeInvalidRequest, p2ptypes.ErrRateLimited.Error(), stream, l.p2p)
+		l.recordViolation(stream, topic, uint64(amt))
 		return p2ptypes.ErrRateLimited
 	}
 	return nil
 }
 
+// downscores the peer of a rate limited request and counts the violation against it.
+// Callers must hold the read lock.
+func (l *limiter) recordViolation(stream network.Stream, topic string, amt uint64) {
+	rpcThrottledRequestsCounter.WithLabelValues(topic).Inc()
+	rpcThrottledRequestCostCounter.WithLabelValues(topic).Add(float64(amt))
+	l.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
+	writeErrorResponseToStream(responseCodeInvalidRequest, p2ptypes.ErrRateLimited.Error(), stream, l.p2p)
+
+	collector, err := l.retrieveCollector(rateLimitViolationsTopic)
+	if err != nil {
+		l.topicLogger(topic).WithError(err).Debug("Could not record rate limit violation")
+		return
+	}
+	collector.Add(stream.Conn().RemotePeer().String(), 1)
+}
+
+// counts a gossip rate limit violation against the allowance of rate limit violations of the
+// peer, returning true if the peer has exhausted it.
+func (l *limiter) recordGossipViolation(pid peer.ID) bool {
+	if l == nil {
+		return false
+	}
+	l.RLock()
+	defer l.RUnlock()
+
+	collector, err := l.retrieveCollector(rateLimitViolationsTopic)
+	if err != nil {
+		l.topicLogger(rateLimitViolationsTopic).WithError(err).Debug("Could not record rate limit violation")
+		return false
+	}
+	collector.Add(pid.String(), 1)
+	return collector.Remaining(pid.String()) <= 0
+}
+
+// Returns true if the peer has exhausted its allowance of rate limit violations.
+func (l *limiter) isPersistentViolator(pid peer.ID) bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	collector, err := l.retrieveCollector(rateLimitViolationsTopic)
+	if err != nil {
+		return false
+	}
+	return collector.Remaining(pid.String()) <= 0
+}
+
 // adds the cost to our leaky bucket for the topic.
 func (l *limiter) add(stream network.Stream, amt int64) {
 	l.Lock()
 	defer l.Unlock()
 
-	topic := string(stream.Protocol())
+	topic := l.streamTopic(stream)
 	log := l.topicLogger(topic)
 
 	collector, err := l.retrieveCollector(topic)
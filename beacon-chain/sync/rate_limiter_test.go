@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
@@ -18,7 +19,7 @@ import (
 
 func TestNewRateLimiter(t *testing.T) {
 	rlimiter := newRateLimiter(mockp2p.NewTestP2P(t))
-	assert.Equal(t, len(rlimiter.limiterMap), 10, "correct number of topics not registered")
+	assert.Equal(t, len(rlimiter.limiterMap), 13, "correct number of topics not registered")
 }
 
 func TestNewRateLimiter_FreeCorrectly(t *testing.T) {
@@ -28,6 +29,86 @@ func TestNewRateLimiter_FreeCorrectly(t *testing.T) {
 
 }
 
+func TestRateLimiter_SetBlockCollectors(t *testing.T) {
+	p1 := mockp2p.NewTestP2P(t)
+	rlimiter := newRateLimiter(p1)
+	topic := p2p.RPCBlocksByRangeTopicV1 + p1.Encoding().ProtocolSuffix()
+	rootTopic := p2p.RPCBlocksByRootTopicV1 + p1.Encoding().ProtocolSuffix()
+
+	rlimiter.setBlockCollectors(10, 3)
+	assert.Equal(t, len(rlimiter.limiterMap), 13, "correct number of topics not registered")
+	collector, ok := rlimiter.limiterMap[topic]
+	require.Equal(t, true, ok)
+	assert.Equal(t, int64(30), collector.Capacity())
+	assert.Equal(t, true, collector == rlimiter.limiterMap[p2p.RPCBlocksByRangeTopicV2+p1.Encoding().ProtocolSuffix()], "block range versions do not share a collector")
+
+	rootCollector, ok := rlimiter.limiterMap[rootTopic]
+	require.Equal(t, true, ok)
+	assert.Equal(t, false, collector == rootCollector, "block range and root requests share a collector")
+	assert.Equal(t, int64(3*flags.Get().RPCBlocksByRootRateLimit), rootCollector.Capacity())
+	assert.Equal(t, true, rootCollector == rlimiter.limiterMap[p2p.RPCBlocksByRootTopicV2+p1.Encoding().ProtocolSuffix()], "block root versions do not share a collector")
+}
+
+func TestNewRateLimiter_ConfiguredAllowances(t *testing.T) {
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{
+		BlockBatchLimit:            64,
+		BlockBatchLimitBurstFactor: 10,
+		RPCBlocksByRootRateLimit:   16,
+		RPCStatusRateLimit:         2,
+		RPCMetadataRateLimit:       0.5,
+		RPCRateLimitMaxViolations:  3,
+	})
+	defer flags.Init(resetFlags)
+
+	p1 := mockp2p.NewTestP2P(t)
+	rlimiter := newRateLimiter(p1)
+	tests := []struct {
+		topic    string
+		rate     float64
+		capacity int64
+	}{
+		{topic: p2p.RPCStatusTopicV1, rate: 2, capacity: 10},
+		{topic: p2p.RPCMetaDataTopicV2, rate: 0.5, capacity: 2},
+		{topic: p2p.RPCBlocksByRootTopicV2, rate: 16, capacity: 160},
+		{topic: p2p.RPCBlocksByRangeTopicV2, rate: 64, capacity: 640},
+	}
+	for _, tt := range tests {
+		collector, ok := rlimiter.limiterMap[tt.topic+p1.Encoding().ProtocolSuffix()]
+		require.Equal(t, true, ok, "no collector for %s", tt.topic)
+		assert.Equal(t, tt.rate, collector.Rate(), "unexpected rate for %s", tt.topic)
+		assert.Equal(t, tt.capacity, collector.Capacity(), "unexpected capacity for %s", tt.topic)
+	}
+	assert.Equal(t, int64(3), rlimiter.limiterMap[rateLimitViolationsTopic].Capacity())
+}
+
+func TestNewRateLimiter_UnconfiguredAllowances(t *testing.T) {
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{})
+	defer flags.Init(resetFlags)
+
+	p1 := mockp2p.NewTestP2P(t)
+	rlimiter := newRateLimiter(p1)
+	// Unconfigured rates fall back to the defaults of their flags, rather than rejecting every request.
+	tests := []struct {
+		topic    string
+		rate     float64
+		capacity int64
+	}{
+		{topic: p2p.RPCStatusTopicV1, rate: 1, capacity: 5},
+		{topic: p2p.RPCMetaDataTopicV2, rate: 1, capacity: 5},
+		{topic: p2p.RPCBlocksByRootTopicV2, rate: 64, capacity: 640},
+		{topic: p2p.RPCBlocksByRangeTopicV2, rate: 64, capacity: 640},
+	}
+	for _, tt := range tests {
+		collector, ok := rlimiter.limiterMap[tt.topic+p1.Encoding().ProtocolSuffix()]
+		require.Equal(t, true, ok, "no collector for %s", tt.topic)
+		assert.Equal(t, tt.rate, collector.Rate(), "unexpected rate for %s", tt.topic)
+		assert.Equal(t, tt.capacity, collector.Capacity(), "unexpected capacity for %s", tt.topic)
+	}
+	assert.Equal(t, int64(10), rlimiter.limiterMap[rateLimitViolationsTopic].Capacity())
+}
+
 func TestRateLimiter_ExceedCapacity(t *testing.T) {
 	p1 := mockp2p.NewTestP2P(t)
 	p2 := mockp2p.NewTestP2P(t)
@@ -106,6 +187,27 @@ func TestRateLimiter_ExceedRawCapacity(t *testing.T) {
 	}
 }
 
+func TestRateLimiter_PersistentViolator(t *testing.T) {
+	p1 := mockp2p.NewTestP2P(t)
+	p2 := mockp2p.NewTestP2P(t)
+	p1.Connect(p2)
+	p1.Peers().Add(nil, p2.PeerID(), p2.BHost.Addrs()[0], network.DirOutbound)
+
+	rlimiter := newRateLimiter(p1)
+	topic := p2p.RPCStatusTopicV1 + p1.Encoding().ProtocolSuffix()
+	p2.BHost.SetStreamHandler(protocol.ID(topic), func(stream network.Stream) {})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.PeerID(), protocol.ID(topic))
+	require.NoError(t, err, "could not create stream")
+
+	maxViolations := flags.Get().RPCRateLimitMaxViolations
+	for i := 0; i < maxViolations; i++ {
+		assert.Equal(t, false, rlimiter.isPersistentViolator(p2.PeerID()), "peer is a persistent violator after %d violations", i)
+		assert.ErrorContains(t, p2ptypes.ErrRateLimited.Error(), rlimiter.validateRequest(stream, defaultBurstLimit+1))
+	}
+	assert.Equal(t, true, rlimiter.isPersistentViolator(p2.PeerID()), "peer is not a persistent violator")
+	require.NoError(t, stream.Close(), "could not close stream")
+}
+
 func Test_limiter_retrieveCollector_requiresLock(t *testing.T) {
 	l := limiter{}
 	_, err := l.retrieveCollector("")
@@ -8,10 +8,11 @@ import (
 
 	libp2pcore "github.com/libp2p/go-libp2p-core"
 	"github.com/libp2p/go-libp2p-core/network"
-	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/pkg/errors"
 	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
 	"github.com/prysmaticlabs/prysm/time"
@@ -50,6 +51,7 @@ func (s *Service) registerRPCHandlers() {
 			s.pingHandler,
 		)
 		s.registerRPCHandlersAltair()
+		s.registerStateSyncHandlers()
 		return
 	}
 	s.registerRPC(
@@ -60,10 +62,12 @@ func (s *Service) registerRPCHandlers() {
 		p2p.RPCGoodByeTopicV1,
 		s.goodbyeRPCHandler,
 	)
-	s.registerRPC(
-		p2p.RPCBlocksByRangeTopicV1,
-		s.beaconBlocksByRangeRPCHandler,
-	)
+	if s.cfg.nodeRole.ServesBlocksByRange() {
+		s.registerRPC(
+			p2p.RPCBlocksByRangeTopicV1,
+			s.beaconBlocksByRangeRPCHandler,
+		)
+	}
 	s.registerRPC(
 		p2p.RPCBlocksByRootTopicV1,
 		s.beaconBlocksRootRPCHandler,
@@ -76,14 +80,32 @@ func (s *Service) registerRPCHandlers() {
 		p2p.RPCMetaDataTopicV1,
 		s.metaDataHandler,
 	)
+	s.registerStateSyncHandlers()
 }
 
-// registerRPCHandlers for altair.
-func (s *Service) registerRPCHandlersAltair() {
+// registerStateSyncHandlers for serving the finalized state, if enabled.
+func (s *Service) registerStateSyncHandlers() {
+	if !flags.Get().EnableStateSyncServing {
+		return
+	}
 	s.registerRPC(
-		p2p.RPCBlocksByRangeTopicV2,
-		s.beaconBlocksByRangeRPCHandler,
+		p2p.RPCFinalizedStateManifestTopicV1,
+		s.finalizedStateManifestRPCHandler,
 	)
+	s.registerRPC(
+		p2p.RPCFinalizedStateChunkTopicV1,
+		s.finalizedStateChunkRPCHandler,
+	)
+}
+
+// registerRPCHandlers for altair.
+func (s *Service) registerRPCHandlersAltair() {
+	if s.cfg.nodeRole.ServesBlocksByRange() {
+		s.registerRPC(
+			p2p.RPCBlocksByRangeTopicV2,
+			s.beaconBlocksByRangeRPCHandler,
+		)
+	}
 	s.registerRPC(
 		p2p.RPCBlocksByRootTopicV2,
 		s.beaconBlocksRootRPCHandler,
@@ -97,18 +119,24 @@ func (s *Service) registerRPCHandlersAltair() {
 // Remove all v1 Stream handlers that are no longer supported
 // from altair onwards.
 func (s *Service) unregisterPhase0Handlers() {
-	fullBlockRangeTopic := p2p.RPCBlocksByRangeTopicV1 + s.cfg.p2p.Encoding().ProtocolSuffix()
-	fullBlockRootTopic := p2p.RPCBlocksByRootTopicV1 + s.cfg.p2p.Encoding().ProtocolSuffix()
-	fullMetadataTopic := p2p.RPCMetaDataTopicV1 + s.cfg.p2p.Encoding().ProtocolSuffix()
-
-	s.cfg.p2p.Host().RemoveStreamHandler(protocol.ID(fullBlockRangeTopic))
-	s.cfg.p2p.Host().RemoveStreamHandler(protocol.ID(fullBlockRootTopic))
-	s.cfg.p2p.Host().RemoveStreamHandler(protocol.ID(fullMetadataTopic))
+	for _, topic := range []string{p2p.RPCBlocksByRangeTopicV1, p2p.RPCBlocksByRootTopicV1, p2p.RPCMetaDataTopicV1} {
+		for _, id := range p2p.RPCProtocolIDs(s.cfg.p2p, topic) {
+			s.cfg.p2p.Host().RemoveStreamHandler(id)
+		}
+	}
 }
 
-// registerRPC for a given topic with an expected protobuf message type.
+// registerRPC for a given topic with an expected protobuf message type. The topic is served with
+// the configured encoding and with ssz_snappy.
 func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
-	topic := baseTopic + s.cfg.p2p.Encoding().ProtocolSuffix()
+	for _, id := range p2p.RPCProtocolIDs(s.cfg.p2p, baseTopic) {
+		s.registerRPCProtocol(baseTopic, string(id), handle)
+	}
+}
+
+// registerRPCProtocol for a protocol ID of the given topic, whose messages are decoded with the
+// encoding of its suffix.
+func (s *Service) registerRPCProtocol(baseTopic, topic string, handle rpcHandler) {
 	log := log.WithField("topic", topic)
 	s.cfg.p2p.SetStreamHandler(topic, func(stream network.Stream) {
 		defer func() {
@@ -146,6 +174,7 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 		// Validate request according to peer limits.
 		if err := s.rateLimiter.validateRawRpcRequest(stream); err != nil {
 			log.Debugf("Could not validate rpc request from peer: %v", err)
+			s.disconnectPersistentRateLimitViolator(ctx, stream.Conn().RemotePeer())
 			return
 		}
 		s.rateLimiter.addRawStream(stream)
@@ -172,6 +201,9 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 		if baseTopic == p2p.RPCMetaDataTopicV1 || baseTopic == p2p.RPCMetaDataTopicV2 {
 			if err := handle(ctx, base, stream); err != nil {
 				messageFailedProcessingCounter.WithLabelValues(topic).Inc()
+				if errors.Is(err, p2ptypes.ErrRateLimited) {
+					s.disconnectPersistentRateLimitViolator(ctx, stream.Conn().RemotePeer())
+				}
 				if err != p2ptypes.ErrWrongForkDigestVersion {
 					log.WithError(err).Debug("Could not handle p2p RPC")
 				}
@@ -189,7 +221,7 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 				log.Errorf("message of %T does not support marshaller interface", msg)
 				return
 			}
-			if err := s.cfg.p2p.Encoding().DecodeWithMaxLength(stream, msg); err != nil {
+			if err := p2p.StreamEncoding(s.cfg.p2p, stream).DecodeWithMaxLength(stream, msg); err != nil {
 				// Debug logs for goodbye/status errors
 				if strings.Contains(topic, p2p.RPCGoodByeTopicV1) || strings.Contains(topic, p2p.RPCStatusTopicV1) {
 					log.WithError(err).Debug("Could not decode goodbye stream message")
@@ -202,6 +234,9 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 			}
 			if err := handle(ctx, msg, stream); err != nil {
 				messageFailedProcessingCounter.WithLabelValues(topic).Inc()
+				if errors.Is(err, p2ptypes.ErrRateLimited) {
+					s.disconnectPersistentRateLimitViolator(ctx, stream.Conn().RemotePeer())
+				}
 				if err != p2ptypes.ErrWrongForkDigestVersion {
 					log.WithError(err).Debug("Could not handle p2p RPC")
 				}
@@ -214,13 +249,16 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 				log.Errorf("message of %T does not support marshaller interface", msg)
 				return
 			}
-			if err := s.cfg.p2p.Encoding().DecodeWithMaxLength(stream, msg); err != nil {
+			if err := p2p.StreamEncoding(s.cfg.p2p, stream).DecodeWithMaxLength(stream, msg); err != nil {
 				log.WithError(err).Debug("Could not decode stream message")
 				tracing.AnnotateError(span, err)
 				return
 			}
 			if err := handle(ctx, nTyp.Elem().Interface(), stream); err != nil {
 				messageFailedProcessingCounter.WithLabelValues(topic).Inc()
+				if errors.Is(err, p2ptypes.ErrRateLimited) {
+					s.disconnectPersistentRateLimitViolator(ctx, stream.Conn().RemotePeer())
+				}
 				if err != p2ptypes.ErrWrongForkDigestVersion {
 					log.WithError(err).Debug("Could not handle p2p RPC")
 				}
@@ -57,7 +57,7 @@ func (s *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 	// The final requested slot from remote peer.
 	endReqSlot := startSlot.Add(m.Step * (m.Count - 1))
 
-	blockLimiter, err := s.rateLimiter.topicCollector(string(stream.Protocol()))
+	blockLimiter, err := s.rateLimiter.topicCollector(s.rateLimiter.streamTopic(stream))
 	if err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@ package sync
 
 import (
 	"context"
+	"time"
 
 	libp2pcore "github.com/libp2p/go-libp2p-core"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -17,7 +18,8 @@ func (s *Service) sendRecentBeaconBlocksRequest(ctx context.Context, blockRoots
 	ctx, cancel := context.WithTimeout(ctx, respTimeout)
 	defer cancel()
 
-	_, err := SendBeaconBlocksByRootRequest(ctx, s.cfg.chain, s.cfg.p2p, id, blockRoots, func(blk interfaces.SignedBeaconBlock) error {
+	start := time.Now()
+	blks, err := SendBeaconBlocksByRootRequest(ctx, s.cfg.chain, s.cfg.p2p, id, blockRoots, func(blk interfaces.SignedBeaconBlock) error {
 		blkRoot, err := blk.Block().HashTreeRoot()
 		if err != nil {
 			return err
@@ -29,6 +31,14 @@ func (s *Service) sendRecentBeaconBlocksRequest(ctx context.Context, blockRoots
 		s.pendingQueueLock.Unlock()
 		return nil
 	})
+	scorer := s.cfg.p2p.Peers().Scorers().BlockQualityScorer()
+	scorer.RecordResponse(id, uint64(len(blks)), time.Since(start))
+	switch {
+	case errors.Is(err, ErrInvalidFetchedData):
+		scorer.RecordInvalidBlocks(id, 1)
+	case err == nil && len(blks) < len(*blockRoots):
+		scorer.RecordMissingBlocks(id, uint64(len(*blockRoots)-len(blks)))
+	}
 	return err
 }
 
@@ -144,6 +144,65 @@ func TestRecentBeaconBlocks_RPCRequestSent(t *testing.T) {
 	}
 }
 
+func TestRecentBeaconBlocks_RecordsMissingBlocks(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+
+	blockA := util.NewBeaconBlock()
+	blockA.Block.Slot = 111
+	blockB := util.NewBeaconBlock()
+	blockB.Block.Slot = 40
+	blockARoot, err := blockA.Block.HashTreeRoot()
+	require.NoError(t, err)
+	blockBRoot, err := blockB.Block.HashTreeRoot()
+	require.NoError(t, err)
+	genesisState, err := transition.GenesisBeaconState(context.Background(), nil, 0, &ethpb.Eth1Data{})
+	require.NoError(t, err)
+	require.NoError(t, genesisState.SetSlot(111))
+
+	r := &Service{
+		cfg: &config{
+			p2p: p1,
+			chain: &mock.ChainService{
+				State:          genesisState,
+				Root:           blockARoot[:],
+				Genesis:        time.Now(),
+				ValidatorsRoot: [32]byte{},
+			},
+		},
+		slotToPendingBlocks: gcache.New(time.Second, 2*time.Second),
+		seenPendingBlocks:   make(map[[32]byte]bool),
+		ctx:                 context.Background(),
+		rateLimiter:         newRateLimiter(p1),
+	}
+
+	pcl := protocol.ID("/eth2/beacon_chain/req/beacon_blocks_by_root/1/ssz_snappy")
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		out := new(p2pTypes.BeaconBlockByRootsReq)
+		assert.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, out))
+		// Only serve one of the two requested blocks.
+		_, err := stream.Write([]byte{responseCodeSuccess})
+		assert.NoError(t, err, "Could not write to stream")
+		_, err = p2.Encoding().EncodeWithMaxLength(stream, blockB)
+		assert.NoError(t, err, "Could not send response back")
+		assert.NoError(t, stream.Close())
+	})
+
+	p1.Connect(p2)
+	roots := p2pTypes.BeaconBlockByRootsReq{blockBRoot, blockARoot}
+	require.NoError(t, r.sendRecentBeaconBlocksRequest(context.Background(), &roots, p2.PeerID()))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	// Half of the requested blocks are missing.
+	score := p1.Peers().Scorers().BlockQualityScorer().Score(p2.PeerID())
+	assert.Equal(t, true, score > 0 && score <= 0.5, "Unexpected block quality score %f", score)
+}
+
 func TestRecentBeaconBlocksRPCHandler_HandleZeroBlocks(t *testing.T) {
 	p1 := p2ptest.NewTestP2P(t)
 	p2 := p2ptest.NewTestP2P(t)
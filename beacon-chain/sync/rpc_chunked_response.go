@@ -4,12 +4,11 @@ import (
 	libp2pcore "github.com/libp2p/go-libp2p-core"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
-	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
-	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/network/forks"
 	"github.com/prysmaticlabs/prysm/runtime/version"
@@ -20,7 +19,7 @@ import (
 // response_chunk  ::= <result> | <context-bytes> | <encoding-dependent-header> | <encoded-payload>
 func (s *Service) chunkBlockWriter(stream libp2pcore.Stream, blk interfaces.SignedBeaconBlock) error {
 	SetStreamWriteDeadline(stream, defaultWriteDuration)
-	return WriteBlockChunk(stream, s.cfg.chain, s.cfg.p2p.Encoding(), blk)
+	return WriteBlockChunk(stream, s.cfg.chain, p2p.StreamEncoding(s.cfg.p2p, stream), blk)
 }
 
 // WriteBlockChunk writes block chunk object to stream.
@@ -63,19 +62,19 @@ func WriteBlockChunk(stream libp2pcore.Stream, chain blockchain.ChainInfoFetcher
 
 // ReadChunkedBlock handles each response chunk that is sent by the
 // peer and converts it into a beacon block.
-func ReadChunkedBlock(stream libp2pcore.Stream, chain blockchain.ChainInfoFetcher, p2p p2p.P2P, isFirstChunk bool) (interfaces.SignedBeaconBlock, error) {
+func ReadChunkedBlock(stream libp2pcore.Stream, chain blockchain.ChainInfoFetcher, p2pProvider p2p.P2P, isFirstChunk bool) (interfaces.SignedBeaconBlock, error) {
 	// Handle deadlines differently for first chunk
 	if isFirstChunk {
-		return readFirstChunkedBlock(stream, chain, p2p)
+		return readFirstChunkedBlock(stream, chain, p2pProvider)
 	}
 
-	return readResponseChunk(stream, chain, p2p)
+	return readResponseChunk(stream, chain, p2pProvider)
 }
 
 // readFirstChunkedBlock reads the first chunked block and applies the appropriate deadlines to
 // it.
-func readFirstChunkedBlock(stream libp2pcore.Stream, chain blockchain.ChainInfoFetcher, p2p p2p.P2P) (interfaces.SignedBeaconBlock, error) {
-	code, errMsg, err := ReadStatusCode(stream, p2p.Encoding())
+func readFirstChunkedBlock(stream libp2pcore.Stream, chain blockchain.ChainInfoFetcher, p2pProvider p2p.P2P) (interfaces.SignedBeaconBlock, error) {
+	code, errMsg, err := ReadStatusCode(stream, p2p.StreamEncoding(p2pProvider, stream))
 	if err != nil {
 		return nil, err
 	}
@@ -90,15 +89,15 @@ func readFirstChunkedBlock(stream libp2pcore.Stream, chain blockchain.ChainInfoF
 	if err != nil {
 		return nil, err
 	}
-	err = p2p.Encoding().DecodeWithMaxLength(stream, blk)
+	err = p2p.StreamEncoding(p2pProvider, stream).DecodeWithMaxLength(stream, blk)
 	return blk, err
 }
 
 // readResponseChunk reads the response from the stream and decodes it into the
 // provided message type.
-func readResponseChunk(stream libp2pcore.Stream, chain blockchain.ChainInfoFetcher, p2p p2p.P2P) (interfaces.SignedBeaconBlock, error) {
+func readResponseChunk(stream libp2pcore.Stream, chain blockchain.ChainInfoFetcher, p2pProvider p2p.P2P) (interfaces.SignedBeaconBlock, error) {
 	SetStreamReadDeadline(stream, respTimeout)
-	code, errMsg, err := readStatusCodeNoDeadline(stream, p2p.Encoding())
+	code, errMsg, err := readStatusCodeNoDeadline(stream, p2p.StreamEncoding(p2pProvider, stream))
 	if err != nil {
 		return nil, err
 	}
@@ -114,30 +113,17 @@ func readResponseChunk(stream libp2pcore.Stream, chain blockchain.ChainInfoFetch
 	if err != nil {
 		return nil, err
 	}
-	err = p2p.Encoding().DecodeWithMaxLength(stream, blk)
+	err = p2p.StreamEncoding(p2pProvider, stream).DecodeWithMaxLength(stream, blk)
 	return blk, err
 }
 
 func extractBlockDataType(digest []byte, chain blockchain.ChainInfoFetcher) (interfaces.SignedBeaconBlock, error) {
 	if len(digest) == 0 {
-		bFunc, ok := types.BlockMap[bytesutil.ToBytes4(params.BeaconConfig().GenesisForkVersion)]
-		if !ok {
-			return nil, errors.New("no block type exists for the genesis fork version.")
-		}
-		return bFunc()
+		return wrapper.NewSignedBeaconBlockForVersion(version.Phase0)
 	}
 	if len(digest) != forkDigestLength {
 		return nil, errors.Errorf("invalid digest returned, wanted a length of %d but received %d", forkDigestLength, len(digest))
 	}
 	vRoot := chain.GenesisValidatorsRoot()
-	for k, blkFunc := range types.BlockMap {
-		rDigest, err := signing.ComputeForkDigest(k[:], vRoot[:])
-		if err != nil {
-			return nil, err
-		}
-		if rDigest == bytesutil.ToBytes4(digest) {
-			return blkFunc()
-		}
-	}
-	return nil, errors.New("no valid digest matched")
+	return wrapper.NewSignedBeaconBlockForDigest(bytesutil.ToBytes4(digest), vRoot[:])
 }
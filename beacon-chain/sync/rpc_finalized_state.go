@@ -0,0 +1,233 @@
+package sync
+
+import (
+	"context"
+	"crypto/sha256"
+
+	libp2pcore "github.com/libp2p/go-libp2p-core"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var errStateNotServed = errors.New("requested state is not the finalized state")
+
+// stateSyncSnapshot is the serialized finalized state served over the state sync protocol,
+// along with its manifest.
+type stateSyncSnapshot struct {
+	manifest *p2ptypes.FinalizedStateManifest
+	state    []byte
+}
+
+// finalizedStateManifestRPCHandler serves the manifest of the finalized state, describing the chunks it is split in.
+func (s *Service) finalizedStateManifestRPCHandler(_ context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "finalized_state_manifest")
+
+	req, ok := msg.(*p2ptypes.FinalizedStateManifestReq)
+	if !ok {
+		return errors.New("message is not type FinalizedStateManifestReq")
+	}
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	snapshot, err := s.finalizedStateSnapshot(*req)
+	if err != nil {
+		s.writeStateSyncError(err, stream)
+		return err
+	}
+	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
+		return errors.Wrap(err, "could not write response code")
+	}
+	if _, err := p2p.StreamEncoding(s.cfg.p2p, stream).EncodeWithMaxLength(stream, snapshot.manifest); err != nil {
+		return errors.Wrap(err, "could not write manifest")
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// finalizedStateChunkRPCHandler serves a chunk of the serialized finalized state.
+func (s *Service) finalizedStateChunkRPCHandler(_ context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "finalized_state_chunk")
+
+	req, ok := msg.(*p2ptypes.FinalizedStateChunkReq)
+	if !ok {
+		return errors.New("message is not type FinalizedStateChunkReq")
+	}
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	snapshot, err := s.finalizedStateSnapshot(req.BlockRoot)
+	if err != nil {
+		s.writeStateSyncError(err, stream)
+		return err
+	}
+	m := snapshot.manifest
+	if req.Index >= uint64(len(m.ChunkHashes)) {
+		s.writeErrorResponseToStream(responseCodeInvalidRequest, "chunk index out of range", stream)
+		return errors.Errorf("chunk index %d out of range, state has %d chunks", req.Index, len(m.ChunkHashes))
+	}
+	start := req.Index * m.ChunkSize
+	chunk := p2ptypes.StateChunk(snapshot.state[start : start+m.ChunkLength(req.Index)])
+	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
+		return errors.Wrap(err, "could not write response code")
+	}
+	if _, err := p2p.StreamEncoding(s.cfg.p2p, stream).EncodeWithMaxLength(stream, &chunk); err != nil {
+		return errors.Wrap(err, "could not write state chunk")
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+func (s *Service) writeStateSyncError(err error, stream libp2pcore.Stream) {
+	if errors.Is(err, errStateNotServed) {
+		s.writeErrorResponseToStream(responseCodeInvalidRequest, errStateNotServed.Error(), stream)
+		return
+	}
+	log.WithError(err).Debug("Could not prepare finalized state for state sync")
+	s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+}
+
+// finalizedStateSnapshot returns the serialized finalized state for the given block root, which must be
+// the root of the current finalized checkpoint. The snapshot is built once per finalized checkpoint.
+func (s *Service) finalizedStateSnapshot(blockRoot [32]byte) (*stateSyncSnapshot, error) {
+	cp := s.cfg.chain.FinalizedCheckpt()
+	if cp == nil || bytesutil.ToBytes32(cp.Root) != blockRoot || blockRoot == [32]byte{} {
+		return nil, errStateNotServed
+	}
+
+	s.stateSyncLock.Lock()
+	defer s.stateSyncLock.Unlock()
+	if s.stateSyncSnapshot != nil && s.stateSyncSnapshot.manifest.BlockRoot == blockRoot {
+		return s.stateSyncSnapshot, nil
+	}
+	// The snapshot outlives the request, so it is built with the service context.
+	st, err := s.cfg.stateGen.StateByRoot(s.ctx, blockRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized state")
+	}
+	stateRoot, err := st.HashTreeRoot(s.ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute finalized state root")
+	}
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal finalized state")
+	}
+	snapshot, err := newStateSyncSnapshot(blockRoot, stateRoot, enc)
+	if err != nil {
+		return nil, err
+	}
+	s.stateSyncSnapshot = snapshot
+	log.WithFields(logrus.Fields{
+		"blockRoot": bytesutil.Trunc(blockRoot[:]),
+		"stateSize": len(enc),
+		"chunks":    len(snapshot.manifest.ChunkHashes),
+	}).Debug("Prepared finalized state for state sync")
+	return snapshot, nil
+}
+
+func newStateSyncSnapshot(blockRoot, stateRoot [32]byte, enc []byte) (*stateSyncSnapshot, error) {
+	m := &p2ptypes.FinalizedStateManifest{
+		BlockRoot: blockRoot,
+		StateRoot: stateRoot,
+		StateSize: uint64(len(enc)),
+		ChunkSize: p2ptypes.StateSyncChunkSize,
+	}
+	for start := uint64(0); start < m.StateSize; start += m.ChunkSize {
+		end := start + m.ChunkSize
+		if end > m.StateSize {
+			end = m.StateSize
+		}
+		m.ChunkHashes = append(m.ChunkHashes, sha256.Sum256(enc[start:end]))
+	}
+	if err := m.Validate(); err != nil {
+		return nil, errors.Wrap(err, "could not split finalized state in chunks")
+	}
+	return &stateSyncSnapshot{manifest: m, state: enc}, nil
+}
+
+// SendFinalizedStateManifestRequest requests the manifest of the finalized state of the given block from a peer.
+// The manifest is checked to be consistent, but it is only authenticated by the state it describes.
+func SendFinalizedStateManifestRequest(
+	ctx context.Context, chain blockchain.ChainInfoFetcher, p2pProvider p2p.P2P, pid peer.ID, blockRoot [32]byte,
+) (*p2ptypes.FinalizedStateManifest, error) {
+	topic, err := p2p.TopicFromMessage(p2p.FinalizedStateManifestMessageName, slots.ToEpoch(chain.CurrentSlot()))
+	if err != nil {
+		return nil, err
+	}
+	req := p2ptypes.FinalizedStateManifestReq(blockRoot)
+	stream, err := p2pProvider.Send(ctx, &req, topic, pid)
+	if err != nil {
+		return nil, err
+	}
+	defer closeStream(stream, log)
+
+	code, errMsg, err := ReadStatusCode(stream, p2p.StreamEncoding(p2pProvider, stream))
+	if err != nil {
+		return nil, err
+	}
+	if code != responseCodeSuccess {
+		return nil, errors.New(errMsg)
+	}
+	m := &p2ptypes.FinalizedStateManifest{}
+	if err := p2p.StreamEncoding(p2pProvider, stream).DecodeWithMaxLength(stream, m); err != nil {
+		return nil, err
+	}
+	if m.BlockRoot != blockRoot {
+		return nil, errors.Wrapf(ErrInvalidFetchedData, "manifest is for block %#x, requested %#x", m.BlockRoot, blockRoot)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, errors.Wrap(ErrInvalidFetchedData, err.Error())
+	}
+	return m, nil
+}
+
+// SendFinalizedStateChunkRequest requests a chunk of the finalized state described by the manifest from a peer,
+// and checks it against its hash in the manifest.
+func SendFinalizedStateChunkRequest(
+	ctx context.Context, chain blockchain.ChainInfoFetcher, p2pProvider p2p.P2P, pid peer.ID,
+	m *p2ptypes.FinalizedStateManifest, index uint64,
+) ([]byte, error) {
+	if index >= uint64(len(m.ChunkHashes)) {
+		return nil, errors.Errorf("chunk index %d out of range, state has %d chunks", index, len(m.ChunkHashes))
+	}
+	topic, err := p2p.TopicFromMessage(p2p.FinalizedStateChunkMessageName, slots.ToEpoch(chain.CurrentSlot()))
+	if err != nil {
+		return nil, err
+	}
+	stream, err := p2pProvider.Send(ctx, &p2ptypes.FinalizedStateChunkReq{BlockRoot: m.BlockRoot, Index: index}, topic, pid)
+	if err != nil {
+		return nil, err
+	}
+	defer closeStream(stream, log)
+
+	code, errMsg, err := ReadStatusCode(stream, p2p.StreamEncoding(p2pProvider, stream))
+	if err != nil {
+		return nil, err
+	}
+	if code != responseCodeSuccess {
+		return nil, errors.New(errMsg)
+	}
+	chunk := p2ptypes.StateChunk{}
+	if err := p2p.StreamEncoding(p2pProvider, stream).DecodeWithMaxLength(stream, &chunk); err != nil {
+		return nil, err
+	}
+	if uint64(len(chunk)) != m.ChunkLength(index) {
+		return nil, errors.Wrapf(ErrInvalidFetchedData, "chunk %d has %d bytes, expected %d", index, len(chunk), m.ChunkLength(index))
+	}
+	if sha256.Sum256(chunk) != m.ChunkHashes[index] {
+		return nil, errors.Wrapf(ErrInvalidFetchedData, "chunk %d does not match its hash in the manifest", index)
+	}
+	return chunk, nil
+}
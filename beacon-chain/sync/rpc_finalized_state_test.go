@@ -0,0 +1,115 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kevinms/leakybucket-go"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	db "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+// setupStateSyncServer starts a peer serving a finalized state over the state sync protocol, and
+// returns it along with the finalized block root and the serialized state.
+func setupStateSyncServer(t *testing.T, ctx context.Context) (*p2ptest.TestP2P, *mock.ChainService, [32]byte, []byte) {
+	resetFlags := flags.Get()
+	stateSyncFlags := *resetFlags
+	stateSyncFlags.EnableStateSyncServing = true
+	flags.Init(&stateSyncFlags)
+	t.Cleanup(func() {
+		flags.Init(resetFlags)
+	})
+
+	d := db.SetupDB(t)
+	st, _ := util.DeterministicGenesisState(t, 64)
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	blk := util.NewBeaconBlock()
+	blk.Block.StateRoot = stateRoot[:]
+	blkRoot, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, d, blk)
+	require.NoError(t, d.SaveState(ctx, st, blkRoot))
+	require.NoError(t, d.SaveStateSummary(ctx, &ethpb.StateSummary{Root: blkRoot[:]}))
+
+	chain := &mock.ChainService{
+		Genesis:             time.Now(),
+		ValidatorsRoot:      [32]byte{},
+		FinalizedCheckPoint: &ethpb.Checkpoint{Root: blkRoot[:]},
+	}
+	server := p2ptest.NewTestP2P(t)
+	s := &Service{
+		ctx:         ctx,
+		cfg:         &config{p2p: server, chain: chain, beaconDB: d, stateGen: stategen.New(d)},
+		rateLimiter: newRateLimiter(server),
+	}
+	s.rateLimiter.limiterMap[rpcLimiterTopic] = leakybucket.NewCollector(10000, 10000, false)
+	s.registerStateSyncHandlers()
+
+	stateBytes, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	return server, chain, blkRoot, stateBytes
+}
+
+func TestFinalizedStateRPCHandlers(t *testing.T) {
+	ctx := context.Background()
+	server, chain, root, wantState := setupStateSyncServer(t, ctx)
+	client := p2ptest.NewTestP2P(t)
+	client.Connect(server)
+
+	m, err := SendFinalizedStateManifestRequest(ctx, chain, client, server.PeerID(), root)
+	require.NoError(t, err)
+	require.Equal(t, true, len(m.ChunkHashes) > 1, "state should be split in several chunks")
+	stateBytes := make([]byte, 0, m.StateSize)
+	for i := uint64(0); i < uint64(len(m.ChunkHashes)); i++ {
+		chunk, err := SendFinalizedStateChunkRequest(ctx, chain, client, server.PeerID(), m, i)
+		require.NoError(t, err)
+		stateBytes = append(stateBytes, chunk...)
+	}
+	assert.DeepEqual(t, wantState, stateBytes)
+}
+
+func TestFinalizedStateRPCHandlers_NotFinalizedRoot(t *testing.T) {
+	ctx := context.Background()
+	server, chain, _, _ := setupStateSyncServer(t, ctx)
+	client := p2ptest.NewTestP2P(t)
+	client.Connect(server)
+
+	_, err := SendFinalizedStateManifestRequest(ctx, chain, client, server.PeerID(), [32]byte{'a'})
+	assert.ErrorContains(t, errStateNotServed.Error(), err)
+	_, err = SendFinalizedStateChunkRequest(ctx, chain, client, server.PeerID(), &p2ptypes.FinalizedStateManifest{
+		BlockRoot:   [32]byte{'a'},
+		ChunkHashes: [][32]byte{{}},
+	}, 0)
+	assert.ErrorContains(t, errStateNotServed.Error(), err)
+}
+
+func TestSendFinalizedStateChunkRequest_InvalidChunk(t *testing.T) {
+	ctx := context.Background()
+	server, chain, root, _ := setupStateSyncServer(t, ctx)
+	client := p2ptest.NewTestP2P(t)
+	client.Connect(server)
+
+	m, err := SendFinalizedStateManifestRequest(ctx, chain, client, server.PeerID(), root)
+	require.NoError(t, err)
+	chunk, err := SendFinalizedStateChunkRequest(ctx, chain, client, server.PeerID(), m, 1)
+	require.NoError(t, err)
+	assert.Equal(t, p2ptypes.StateSyncChunkSize, len(chunk))
+
+	// A chunk not matching the manifest is rejected.
+	m.ChunkHashes[1] = [32]byte{}
+	_, err = SendFinalizedStateChunkRequest(ctx, chain, client, server.PeerID(), m, 1)
+	require.ErrorIs(t, err, ErrInvalidFetchedData)
+
+	_, err = SendFinalizedStateChunkRequest(ctx, chain, client, server.PeerID(), m, uint64(len(m.ChunkHashes)))
+	assert.ErrorContains(t, "out of range", err)
+}
@@ -47,6 +47,7 @@ func (s *Service) goodbyeRPCHandler(_ context.Context, msg interface{}, stream l
 	s.rateLimiter.add(stream, 1)
 	log := log.WithField("Reason", goodbyeMessage(*m))
 	log.WithField("peer", stream.Conn().RemotePeer()).Debug("Peer has sent a goodbye message")
+	s.cfg.p2p.Peers().RecordGoodbyeReceived(stream.Conn().RemotePeer(), *m)
 	s.cfg.p2p.Peers().SetNextValidTime(stream.Conn().RemotePeer(), goodByeBackoff(*m))
 	// closes all streams with the peer
 	return s.cfg.p2p.Disconnect(stream.Conn().RemotePeer())
@@ -68,6 +69,19 @@ func (s *Service) disconnectBadPeer(ctx context.Context, id peer.ID) {
 	}
 }
 
+// disconnectPersistentRateLimitViolator sends a goodbye to a peer which has exhausted
+// its allowance of rate limited requests and disconnects from it.
+func (s *Service) disconnectPersistentRateLimitViolator(ctx context.Context, id peer.ID) {
+	if !s.rateLimiter.isPersistentViolator(id) {
+		return
+	}
+	rpcRateLimitDisconnectsCounter.Inc()
+	log.WithField("peer", id).Debug("Disconnecting peer persistently exceeding rate limits")
+	if err := s.sendGoodByeAndDisconnect(ctx, p2ptypes.GoodbyeCodeBadScore, id); err != nil {
+		log.Debugf("Error when disconnecting with rate limited peer: %v", err)
+	}
+}
+
 // A custom goodbye method that is used by our connection handler, in the
 // event we receive bad peers.
 func (s *Service) sendGoodbye(ctx context.Context, id peer.ID) error {
@@ -83,6 +97,7 @@ func (s *Service) sendGoodByeAndDisconnect(ctx context.Context, code p2ptypes.RP
 	if s.cfg.p2p.Host().Network().Connectedness(id) == network.NotConnected {
 		return nil
 	}
+	s.cfg.p2p.Peers().RecordGoodbyeSent(id, code)
 	if err := s.sendGoodByeMessage(ctx, code, id); err != nil {
 		log.WithFields(logrus.Fields{
 			"error": err,
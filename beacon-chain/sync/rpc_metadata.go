@@ -32,7 +32,7 @@ func (s *Service) metaDataHandler(_ context.Context, _ interface{}, stream libp2
 
 	if s.cfg.p2p.Metadata() == nil || s.cfg.p2p.Metadata().IsNil() {
 		nilErr := errors.New("nil metadata stored for host")
-		resp, err := s.generateErrorResponse(responseCodeServerError, types.ErrGeneric.Error())
+		resp, err := s.generateErrorResponse(responseCodeServerError, types.ErrGeneric.Error(), p2p.StreamEncoding(s.cfg.p2p, stream))
 		if err != nil {
 			log.WithError(err).Debug("Could not generate a response error")
 		} else if _, err := stream.Write(resp); err != nil {
@@ -42,7 +42,7 @@ func (s *Service) metaDataHandler(_ context.Context, _ interface{}, stream libp2
 	}
 	_, _, streamVersion, err := p2p.TopicDeconstructor(string(stream.Protocol()))
 	if err != nil {
-		resp, genErr := s.generateErrorResponse(responseCodeServerError, types.ErrGeneric.Error())
+		resp, genErr := s.generateErrorResponse(responseCodeServerError, types.ErrGeneric.Error(), p2p.StreamEncoding(s.cfg.p2p, stream))
 		if genErr != nil {
 			log.WithError(genErr).Debug("Could not generate a response error")
 		} else if _, wErr := stream.Write(resp); wErr != nil {
@@ -77,7 +77,7 @@ func (s *Service) metaDataHandler(_ context.Context, _ interface{}, stream libp2
 	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
 		return err
 	}
-	_, err = s.cfg.p2p.Encoding().EncodeWithMaxLength(stream, currMd)
+	_, err = p2p.StreamEncoding(s.cfg.p2p, stream).EncodeWithMaxLength(stream, currMd)
 	if err != nil {
 		return err
 	}
@@ -98,7 +98,7 @@ func (s *Service) sendMetaDataRequest(ctx context.Context, id peer.ID) (metadata
 		return nil, err
 	}
 	defer closeStream(stream, log)
-	code, errMsg, err := ReadStatusCode(stream, s.cfg.p2p.Encoding())
+	code, errMsg, err := ReadStatusCode(stream, p2p.StreamEncoding(s.cfg.p2p, stream))
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +126,7 @@ func (s *Service) sendMetaDataRequest(ctx context.Context, id peer.ID) (metadata
 	if err := validateVersion(topicVersion, stream); err != nil {
 		return nil, err
 	}
-	if err := s.cfg.p2p.Encoding().DecodeWithMaxLength(stream, msg); err != nil {
+	if err := p2p.StreamEncoding(s.cfg.p2p, stream).DecodeWithMaxLength(stream, msg); err != nil {
 		return nil, err
 	}
 	return msg, nil
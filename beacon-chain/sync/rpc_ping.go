@@ -40,7 +40,7 @@ func (s *Service) pingHandler(_ context.Context, msg interface{}, stream libp2pc
 		return err
 	}
 	sq := types.SSZUint64(s.cfg.p2p.MetadataSeq())
-	if _, err := s.cfg.p2p.Encoding().EncodeWithMaxLength(stream, &sq); err != nil {
+	if _, err := p2p.StreamEncoding(s.cfg.p2p, stream).EncodeWithMaxLength(stream, &sq); err != nil {
 		return err
 	}
 
@@ -89,7 +89,7 @@ func (s *Service) sendPingRequest(ctx context.Context, id peer.ID) error {
 	currentTime := time.Now()
 	defer closeStream(stream, log)
 
-	code, errMsg, err := ReadStatusCode(stream, s.cfg.p2p.Encoding())
+	code, errMsg, err := ReadStatusCode(stream, p2p.StreamEncoding(s.cfg.p2p, stream))
 	if err != nil {
 		return err
 	}
@@ -101,7 +101,7 @@ func (s *Service) sendPingRequest(ctx context.Context, id peer.ID) error {
 		return errors.New(errMsg)
 	}
 	msg := new(types.SSZUint64)
-	if err := s.cfg.p2p.Encoding().DecodeWithMaxLength(stream, msg); err != nil {
+	if err := p2p.StreamEncoding(s.cfg.p2p, stream).DecodeWithMaxLength(stream, msg); err != nil {
 		return err
 	}
 	valid, err := s.validateSequenceNum(*msg, stream.Conn().RemotePeer())
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	libp2pcore "github.com/libp2p/go-libp2p-core"
@@ -19,6 +21,7 @@ import (
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/network/forks"
 	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
 	"github.com/prysmaticlabs/prysm/time/slots"
@@ -150,7 +153,7 @@ func (s *Service) sendRPCStatusRequest(ctx context.Context, id peer.ID) error {
 	}
 	defer closeStream(stream, log)
 
-	code, errMsg, err := ReadStatusCode(stream, s.cfg.p2p.Encoding())
+	code, errMsg, err := ReadStatusCode(stream, p2p.StreamEncoding(s.cfg.p2p, stream))
 	if err != nil {
 		return err
 	}
@@ -160,12 +163,15 @@ func (s *Service) sendRPCStatusRequest(ctx context.Context, id peer.ID) error {
 		return errors.New(errMsg)
 	}
 	msg := &pb.Status{}
-	if err := s.cfg.p2p.Encoding().DecodeWithMaxLength(stream, msg); err != nil {
+	if err := p2p.StreamEncoding(s.cfg.p2p, stream).DecodeWithMaxLength(stream, msg); err != nil {
 		return err
 	}
 
 	// If validation fails, validation error is logged, and peer status scorer will mark peer as bad.
 	err = s.validateStatusMessage(ctx, msg)
+	if errors.Is(err, p2ptypes.ErrWrongForkDigestVersion) {
+		s.logForkDigestMismatch(id, msg)
+	}
 	s.cfg.p2p.Peers().Scorers().PeerStatusScorer().SetPeerStatus(id, msg, err)
 	if s.cfg.p2p.Peers().IsBad(id) {
 		s.disconnectBadPeer(s.ctx, id)
@@ -213,6 +219,7 @@ func (s *Service) statusRPCHandler(ctx context.Context, msg interface{}, stream
 		case p2ptypes.ErrGeneric:
 			respCode = responseCodeServerError
 		case p2ptypes.ErrWrongForkDigestVersion:
+			s.logForkDigestMismatch(remotePeer, m)
 			// Respond with our status and disconnect with the peer.
 			s.cfg.p2p.Peers().SetChainState(remotePeer, m)
 			if err := s.respondWithStatus(ctx, stream); err != nil {
@@ -230,7 +237,7 @@ func (s *Service) statusRPCHandler(ctx context.Context, msg interface{}, stream
 		}
 
 		originalErr := err
-		resp, err := s.generateErrorResponse(respCode, err.Error())
+		resp, err := s.generateErrorResponse(respCode, err.Error(), p2p.StreamEncoding(s.cfg.p2p, stream))
 		if err != nil {
 			log.WithError(err).Debug("Could not generate a response error")
 		} else if _, err := stream.Write(resp); err != nil {
@@ -274,10 +281,46 @@ func (s *Service) respondWithStatus(ctx context.Context, stream network.Stream)
 	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
 		log.WithError(err).Debug("Could not write to stream")
 	}
-	_, err = s.cfg.p2p.Encoding().EncodeWithMaxLength(stream, resp)
+	_, err = p2p.StreamEncoding(s.cfg.p2p, stream).EncodeWithMaxLength(stream, resp)
 	return err
 }
 
+// logForkDigestMismatch keeps count of the status handshakes failing due to a fork digest mismatch, and
+// on every forkDigestMismatchLogInterval failure logs how the fork data advertised by the peer differs
+// from the local config, so that nodes of misconfigured custom networks can diagnose why they find no peers.
+func (s *Service) logForkDigestMismatch(pid peer.ID, msg *pb.Status) {
+	if atomic.AddUint64(&s.forkDigestMismatches, 1)%forkDigestMismatchLogInterval != 0 {
+		return
+	}
+	// Prefer the fork data of the peer's ENR, which also carries its next fork, over the status digest.
+	peerFork := &pb.ENRForkID{CurrentForkDigest: msg.ForkDigest}
+	if record, err := s.cfg.p2p.Peers().ENR(pid); err == nil && record != nil {
+		if entry, err := p2p.ForkEntry(record); err == nil {
+			peerFork = entry
+		}
+	}
+	localDigest, err := s.currentForkDigest()
+	if err != nil {
+		log.WithError(err).Debug("Could not compute fork digest")
+		return
+	}
+	gvr := s.cfg.chain.GenesisValidatorsRoot()
+	diffs, err := forks.DiffENRForkID(params.BeaconConfig(), peerFork, gvr[:])
+	if err != nil {
+		log.WithError(err).Debug("Could not compare fork data of peer")
+		return
+	}
+	if len(diffs) == 0 {
+		diffs = []string{"no difference found with the local fork schedule"}
+	}
+	log.WithFields(logrus.Fields{
+		"peer":            pid,
+		"peerForkDigest":  fmt.Sprintf("%#x", peerFork.CurrentForkDigest),
+		"localForkDigest": fmt.Sprintf("%#x", localDigest),
+		"diff":            strings.Join(diffs, "; "),
+	}).Warn("Repeated status handshake failures due to fork digest mismatch, peers may run a different chain config")
+}
+
 func (s *Service) validateStatusMessage(ctx context.Context, msg *pb.Status) error {
 	forkDigest, err := s.currentForkDigest()
 	if err != nil {
@@ -30,6 +30,7 @@ import (
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
+	logTest "github.com/sirupsen/logrus/hooks/test"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -966,3 +967,25 @@ func makeBlocks(t *testing.T, i, n uint64, previousRoot [32]byte) []interfaces.S
 	}
 	return ifaceBlocks
 }
+
+func TestLogForkDigestMismatch(t *testing.T) {
+	hook := logTest.NewGlobal()
+	p := p2ptest.NewTestP2P(t)
+	r := &Service{
+		cfg: &config{
+			p2p: p,
+			chain: &mock.ChainService{
+				Genesis:        time.Now(),
+				ValidatorsRoot: [32]byte{'A'},
+			},
+		},
+	}
+	msg := &ethpb.Status{ForkDigest: []byte{1, 2, 3, 4}}
+	for i := 0; i < forkDigestMismatchLogInterval-1; i++ {
+		r.logForkDigestMismatch("peer", msg)
+	}
+	require.LogsDoNotContain(t, hook, "fork digest mismatch")
+	r.logForkDigestMismatch("peer", msg)
+	require.LogsContain(t, hook, "Repeated status handshake failures due to fork digest mismatch")
+	require.LogsContain(t, hook, "current fork digest 0x01020304 of peer does not match any local fork version")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	prysmP2P "github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
@@ -80,3 +81,21 @@ func TestRegisterRPC_ReceivesValidMessage(t *testing.T) {
 		t.Fatal("Did not receive RPC in 1 second")
 	}
 }
+
+func TestRegisterRPCHandlers_GossipRelayNode(t *testing.T) {
+	p2p := p2ptest.NewTestP2P(t)
+	r := &Service{
+		ctx:         context.Background(),
+		cfg:         &config{p2p: p2p, nodeRole: peers.GossipRelayNode},
+		rateLimiter: newRateLimiter(p2p),
+	}
+	r.registerRPCHandlersAltair()
+
+	protocols := make(map[string]bool)
+	for _, p := range p2p.Host().Mux().Protocols() {
+		protocols[p] = true
+	}
+	suffix := p2p.Encoding().ProtocolSuffix()
+	assert.Equal(t, false, protocols[prysmP2P.RPCBlocksByRangeTopicV2+suffix])
+	assert.Equal(t, true, protocols[prysmP2P.RPCBlocksByRootTopicV2+suffix])
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
@@ -50,7 +51,9 @@ const seenSyncContributionSize = 512 // Maximum of SYNC_COMMITTEE_SIZE as specif
 const seenExitSize = 100
 const seenProposerSlashingSize = 100
 const badBlockSize = 1000
+const verifiedBlockSigSize = 1000
 const syncMetricsInterval = 10 * time.Second
+const forkDigestMismatchLogInterval = 10 // Number of handshakes failing on the fork digest between two logs of the fork diff.
 
 var (
 	// Seconds in one epoch.
@@ -61,6 +64,7 @@ var (
 	earlyAttestationProcessingTolerance = params.BeaconNetworkConfig().MaximumGossipClockDisparity
 	errWrongMessage                     = errors.New("wrong pubsub message")
 	errNilMessage                       = errors.New("nil pubsub message")
+	errGossipRateLimited                = errors.New("peer exceeded its gossip rate limit on the topic")
 )
 
 // Common type for functional p2p validation options.
@@ -83,6 +87,7 @@ type config struct {
 	stateGen                *stategen.State
 	slasherAttestationsFeed *event.Feed
 	slasherBlockHeadersFeed *event.Feed
+	nodeRole                peers.NodeRole
 }
 
 // This defines the interface for interacting with block chain service
@@ -108,12 +113,18 @@ type Service struct {
 	slotToPendingBlocks              *gcache.Cache
 	seenPendingBlocks                map[[32]byte]bool
 	blkRootToPendingAtts             map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof
+	blkRootToPendingSyncMsgs         map[[32]byte][]*ethpb.SyncCommitteeMessage
+	pendingRootRequests              chan [32]byte
 	subHandler                       *subTopicHandler
 	pendingAttsLock                  sync.RWMutex
+	pendingSyncMsgsLock              sync.RWMutex
+	pendingAttsCount                 int
+	pendingSyncMsgsCount             int
 	pendingQueueLock                 sync.RWMutex
 	chainStarted                     *abool.AtomicBool
 	validateBlockLock                sync.RWMutex
 	rateLimiter                      *limiter
+	gossipRateLimiter                *gossipRateLimiter
 	seenBlockLock                    sync.RWMutex
 	seenBlockCache                   *lru.Cache
 	seenAggregatedAttestationLock    sync.RWMutex
@@ -132,9 +143,18 @@ type Service struct {
 	seenSyncContributionCache        *lru.Cache
 	badBlockCache                    *lru.Cache
 	badBlockLock                     sync.RWMutex
+	verifiedBlockSigLock             sync.RWMutex
+	verifiedBlockSigCache            *lru.Cache
 	syncContributionBitsOverlapLock  sync.RWMutex
 	syncContributionBitsOverlapCache *lru.Cache
 	signatureChan                    chan *signatureVerifier
+	contributionVerifiers            verifierPool
+	stateSyncLock                    sync.Mutex
+	stateSyncSnapshot                *stateSyncSnapshot
+	forkDigestMismatches             uint64
+	gossipValidatorsLock             sync.RWMutex
+	gossipValidators                 map[string]wrappedVal
+	gossipJournal                    *gossipJournal
 }
 
 // NewService initializes new regular sync service.
@@ -142,22 +162,39 @@ func NewService(ctx context.Context, opts ...Option) *Service {
 	c := gcache.New(pendingBlockExpTime /* exp time */, 2*pendingBlockExpTime /* prune time */)
 	ctx, cancel := context.WithCancel(ctx)
 	r := &Service{
-		ctx:                  ctx,
-		cancel:               cancel,
-		chainStarted:         abool.New(),
-		cfg:                  &config{},
-		slotToPendingBlocks:  c,
-		seenPendingBlocks:    make(map[[32]byte]bool),
-		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
-		signatureChan:        make(chan *signatureVerifier, verifierLimit),
+		ctx:                      ctx,
+		cancel:                   cancel,
+		chainStarted:             abool.New(),
+		cfg:                      &config{},
+		slotToPendingBlocks:      c,
+		seenPendingBlocks:        make(map[[32]byte]bool),
+		blkRootToPendingAtts:     make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		blkRootToPendingSyncMsgs: make(map[[32]byte][]*ethpb.SyncCommitteeMessage),
+		pendingRootRequests:      make(chan [32]byte, pendingRootRequestsSize),
+		signatureChan:            make(chan *signatureVerifier, verifierLimit),
+		contributionVerifiers:    newVerifierPool(),
+		gossipValidators:         make(map[string]wrappedVal),
 	}
 	for _, opt := range opts {
 		if err := opt(r); err != nil {
 			return nil
 		}
 	}
+	if dir := flags.Get().GossipJournalDir; dir != "" {
+		j, err := newGossipJournal(dir, int64(flags.Get().GossipJournalMaxSize)*1024*1024)
+		if err != nil {
+			log.WithError(err).Error("Could not open gossip journal, rejected and ignored gossip messages are not recorded")
+		} else {
+			log.WithField("dir", dir).Info("Recording rejected and ignored gossip messages")
+			r.gossipJournal = j
+		}
+	}
 	r.subHandler = newSubTopicHandler()
 	r.rateLimiter = newRateLimiter(r.cfg.p2p)
+	if r.cfg.nodeRole == peers.RPCServerNode {
+		r.SetBlockRateLimits(flags.Get().BlockBatchLimit, flags.Get().BlockBatchLimitBurstFactor)
+	}
+	r.gossipRateLimiter = gossipRateLimiterFromFlags()
 	r.initCaches()
 
 	go r.registerHandlers()
@@ -185,12 +222,31 @@ func (s *Service) Start() {
 	async.RunEvery(s.ctx, syncMetricsInterval, s.updateMetrics)
 }
 
+// SetBlockRateLimits replaces the rate limits applied to incoming block requests from peers. RPC
+// server nodes serve rpcServerBlockLimitFactor times as many blocks per second to each peer.
+func (s *Service) SetBlockRateLimits(blockBatchLimit, burstFactor int) {
+	if s.cfg.nodeRole == peers.RPCServerNode {
+		blockBatchLimit *= rpcServerBlockLimitFactor
+	}
+	if s.rateLimiter != nil {
+		s.rateLimiter.setBlockCollectors(blockBatchLimit, burstFactor)
+	}
+}
+
 // Stop the regular sync service.
 func (s *Service) Stop() error {
 	defer func() {
 		if s.rateLimiter != nil {
 			s.rateLimiter.free()
 		}
+		if s.gossipRateLimiter != nil {
+			s.gossipRateLimiter.free()
+		}
+		if s.gossipJournal != nil {
+			if err := s.gossipJournal.close(); err != nil {
+				log.WithError(err).Error("Could not close gossip journal")
+			}
+		}
 	}()
 	// Removing RPC Stream handlers.
 	for _, p := range s.cfg.p2p.Host().Mux().Protocols() {
@@ -228,6 +284,7 @@ func (s *Service) initCaches() {
 	s.seenAttesterSlashingCache = make(map[uint64]bool)
 	s.seenProposerSlashingCache = lruwrpr.New(seenProposerSlashingSize)
 	s.badBlockCache = lruwrpr.New(badBlockSize)
+	s.verifiedBlockSigCache = lruwrpr.New(verifiedBlockSigSize)
 }
 
 func (s *Service) registerHandlers() {
@@ -273,6 +330,7 @@ func (s *Service) registerHandlers() {
 				currentEpoch := slots.ToEpoch(slots.CurrentSlot(uint64(s.cfg.chain.GenesisTime().Unix())))
 				s.registerSubscribers(currentEpoch, digest)
 				go s.forkWatcher()
+				go s.pruneSyncCommitteeMessages()
 				return
 			}
 		case <-s.ctx.Done():
@@ -299,3 +357,9 @@ type Checker interface {
 	Status() error
 	Resync() error
 }
+
+// GossipMessageValidator defines a struct which can run gossip messages through the
+// validators of their topic.
+type GossipMessageValidator interface {
+	ValidateGossipMessage(ctx context.Context, topic string, pid peer.ID, data []byte) (pubsub.ValidationResult, error)
+}
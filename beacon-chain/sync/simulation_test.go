@@ -0,0 +1,314 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	gcache "github.com/patrickmn/go-cache"
+	"github.com/prysmaticlabs/prysm/async/abool"
+	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/simulation"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// simulatedNode is a sync service of a simulated network, which records the outcome of the
+// validation of every gossip block and the blocks handed to its subscriber.
+type simulatedNode struct {
+	service *Service
+	p2p     *p2ptest.TestP2P
+
+	lock     sync.Mutex
+	results  map[[32]byte][]pubsub.ValidationResult
+	received map[[32]byte]int
+}
+
+func (n *simulatedNode) validate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	res, err := n.service.validateBeaconBlockPubSub(ctx, pid, msg)
+	if pid == n.p2p.PeerID() {
+		return res, err
+	}
+	root, rootErr := gossipBlockRoot(n.p2p, msg)
+	if rootErr != nil {
+		return res, err
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.results[root] = append(n.results[root], res)
+	return res, err
+}
+
+func (n *simulatedNode) handle(_ context.Context, msg proto.Message) error {
+	blk, ok := msg.(*ethpb.SignedBeaconBlock)
+	if !ok {
+		return errWrongMessage
+	}
+	root, err := blk.Block.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.received[root]++
+	return nil
+}
+
+func (n *simulatedNode) receivedCount(root [32]byte) int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.received[root]
+}
+
+func (n *simulatedNode) validationResults(root [32]byte) []pubsub.ValidationResult {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return append([]pubsub.ValidationResult{}, n.results[root]...)
+}
+
+func gossipBlockRoot(p *p2ptest.TestP2P, msg *pubsub.Message) ([32]byte, error) {
+	blk := &ethpb.SignedBeaconBlock{}
+	if err := p.Encoding().DecodeGossip(msg.Data, blk); err != nil {
+		return [32]byte{}, err
+	}
+	return blk.Block.HashTreeRoot()
+}
+
+// blockSimulation is a simulated network of sync services sharing the same genesis, on which
+// every slot a block is proposed on top of the genesis block.
+type blockSimulation struct {
+	t         *testing.T
+	network   *simulation.Network
+	nodes     []*simulatedNode
+	genesis   state.BeaconState
+	keys      []bls.SecretKey
+	genRoot   [32]byte
+	topic     string
+	published []*ethpb.SignedBeaconBlock
+}
+
+func newBlockSimulation(t *testing.T, cfg simulation.Config) *blockSimulation {
+	ctx := context.Background()
+	genesis, keys := util.DeterministicGenesisState(t, 64)
+	genesisBlock := util.NewBeaconBlock()
+	genRoot, err := genesisBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	// Nodes share a single database, which only holds the genesis block and state since the
+	// subscribers of the simulation do not save the blocks they receive. It is set up before the
+	// network, so that the network is closed first on cleanup.
+	db := dbtest.SetupDB(t)
+	util.SaveBlock(t, ctx, db, genesisBlock)
+	require.NoError(t, db.SaveState(ctx, genesis.Copy(), genRoot))
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Root: genRoot[:]}))
+	network := simulation.NewNetwork(t, cfg)
+
+	sim := &blockSimulation{t: t, network: network, genesis: genesis, keys: keys, genRoot: genRoot}
+	for i := 0; i < network.Size(); i++ {
+		p := p2ptest.NewTestP2PWithHost(t, network.Host(i))
+		chain := &mockChain.ChainService{
+			Genesis:             network.Genesis(),
+			State:               genesis.Copy(),
+			FinalizedCheckPoint: &ethpb.Checkpoint{Root: make([]byte, 32)},
+			DB:                  db,
+		}
+		node := &simulatedNode{
+			p2p: p,
+			service: &Service{
+				ctx: ctx,
+				cfg: &config{
+					beaconDB:      db,
+					p2p:           p,
+					initialSync:   &mockSync.Sync{IsSyncing: false},
+					chain:         chain,
+					blockNotifier: chain.BlockNotifier(),
+					stateGen:      stategen.New(db),
+				},
+				subHandler:            newSubTopicHandler(),
+				chainStarted:          abool.New(),
+				seenBlockCache:        lruwrpr.New(10),
+				badBlockCache:         lruwrpr.New(10),
+				verifiedBlockSigCache: lruwrpr.New(10),
+				slotToPendingBlocks:   gcache.New(time.Second, 2*time.Second),
+				seenPendingBlocks:     make(map[[32]byte]bool),
+			},
+			results:  make(map[[32]byte][]pubsub.ValidationResult),
+			received: make(map[[32]byte]int),
+		}
+		digest, err := node.service.currentForkDigest()
+		require.NoError(t, err)
+		node.service.subscribe(p2p.BlockSubnetTopicFormat, node.validate, node.handle, digest)
+		node.service.markForChainStart()
+		sim.topic = node.service.addDigestToTopic(p2p.BlockSubnetTopicFormat, digest) + p.Encoding().ProtocolSuffix()
+		sim.nodes = append(sim.nodes, node)
+	}
+	return sim
+}
+
+// waitForPeers waits until the gossip of every node knows the given number of peers on the block topic.
+func (sim *blockSimulation) waitForPeers(peers func(i int) int) {
+	for i, node := range sim.nodes {
+		want := peers(i)
+		sim.network.WaitFor(5*time.Second, func() bool {
+			return len(node.p2p.PubSub().ListPeers(sim.topic)) == want
+		}, "node %d to have %d gossip peers", i, want)
+	}
+}
+
+// proposeBlock builds a block of the given slot on top of the genesis block. Blocks with an
+// invalid signature are signed with the key of another validator.
+func (sim *blockSimulation) proposeBlock(slot types.Slot, graffiti byte, validSignature bool) *ethpb.SignedBeaconBlock {
+	st := sim.genesis.Copy()
+	require.NoError(sim.t, st.SetSlot(slot))
+	proposer, err := helpers.BeaconProposerIndex(context.Background(), st)
+	require.NoError(sim.t, err)
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = slot
+	blk.Block.ParentRoot = sim.genRoot[:]
+	blk.Block.ProposerIndex = proposer
+	blk.Block.Body.Graffiti = bytes.Repeat([]byte{graffiti}, 32)
+	signer := proposer
+	if !validSignature {
+		signer = (proposer + 1) % types.ValidatorIndex(len(sim.keys))
+	}
+	blk.Signature, err = signing.ComputeDomainAndSign(sim.genesis, 0, blk.Block, params.BeaconConfig().DomainBeaconProposer, sim.keys[signer])
+	require.NoError(sim.t, err)
+	return blk
+}
+
+func (sim *blockSimulation) publish(from int, blk *ethpb.SignedBeaconBlock) [32]byte {
+	buf := new(bytes.Buffer)
+	_, err := sim.nodes[from].p2p.Encoding().EncodeGossip(buf, blk)
+	require.NoError(sim.t, err)
+	require.NoError(sim.t, sim.nodes[from].p2p.PublishToTopic(context.Background(), sim.topic, buf.Bytes()))
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(sim.t, err)
+	sim.published = append(sim.published, blk)
+	return root
+}
+
+// checkSafety asserts the invariants which hold whatever the topology and the message loss: no
+// block is handed twice to the subscriber of a node, no block which failed validation is handed
+// to it, and every node validates a block at most once.
+func (sim *blockSimulation) checkSafety() {
+	for _, blk := range sim.published {
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(sim.t, err)
+		for i, node := range sim.nodes {
+			results := node.validationResults(root)
+			assert.Equal(sim.t, true, len(results) <= 1, "Node %d validated block %#x %d times", i, root, len(results))
+			received := node.receivedCount(root)
+			assert.Equal(sim.t, true, received <= 1, "Node %d received block %#x %d times", i, root, received)
+			if len(results) == 1 && results[0] != pubsub.ValidationAccept {
+				assert.Equal(sim.t, 0, received, "Node %d received block %#x which failed validation", i, root)
+			}
+		}
+	}
+}
+
+func TestSimulation_BlocksPropagateToAllNodes(t *testing.T) {
+	sim := newBlockSimulation(t, simulation.Config{
+		Nodes:    4,
+		Latency:  10 * time.Millisecond,
+		Seed:     1,
+		MaxSlots: 4,
+	})
+	sim.network.ConnectAll()
+	sim.waitForPeers(func(int) int { return sim.network.Size() - 1 })
+
+	sim.network.OnSlot(func(slot types.Slot) {
+		from := int(slot) % sim.network.Size()
+		root := sim.publish(from, sim.proposeBlock(slot, 'a', true))
+		for i, node := range sim.nodes {
+			// Messages published by a node are not handed to its own subscriber.
+			if i == from {
+				continue
+			}
+			sim.network.WaitFor(5*time.Second, func() bool {
+				return node.receivedCount(root) == 1
+			}, "node %d to receive the block of slot %d", i, slot)
+		}
+	})
+	for i := types.Slot(0); i < 4; i++ {
+		sim.network.AdvanceSlot()
+	}
+	sim.checkSafety()
+}
+
+func TestSimulation_RejectedBlocksAreNotForwarded(t *testing.T) {
+	sim := newBlockSimulation(t, simulation.Config{
+		Nodes:    4,
+		Latency:  10 * time.Millisecond,
+		Seed:     1,
+		MaxSlots: 2,
+	})
+	// Nodes are connected in a line, so that a block only reaches the nodes after the first
+	// hop if it is forwarded.
+	for i := 0; i < sim.network.Size()-1; i++ {
+		sim.network.Connect(i, i+1)
+	}
+	sim.waitForPeers(func(i int) int {
+		if i == 0 || i == sim.network.Size()-1 {
+			return 1
+		}
+		return 2
+	})
+
+	slot := sim.network.AdvanceSlot()
+	invalid := sim.publish(0, sim.proposeBlock(slot, 'b', false))
+	valid := sim.publish(0, sim.proposeBlock(slot, 'a', true))
+	last := sim.nodes[sim.network.Size()-1]
+	sim.network.WaitFor(5*time.Second, func() bool {
+		return last.receivedCount(valid) == 1
+	}, "the valid block to reach the last node")
+
+	assert.DeepEqual(t, []pubsub.ValidationResult{pubsub.ValidationReject}, sim.nodes[1].validationResults(invalid))
+	for i := 2; i < sim.network.Size(); i++ {
+		assert.Equal(t, 0, len(sim.nodes[i].validationResults(invalid)), "Node %d validated a rejected block", i)
+		assert.Equal(t, 1, sim.nodes[i].receivedCount(valid), "Node %d did not receive the valid block", i)
+	}
+	sim.checkSafety()
+}
+
+func TestSimulation_LossyNetworkKeepsSafety(t *testing.T) {
+	sim := newBlockSimulation(t, simulation.Config{
+		Nodes:    5,
+		Latency:  5 * time.Millisecond,
+		LossRate: 0.2,
+		Seed:     7,
+		MaxSlots: 4,
+	})
+	sim.network.ConnectAll()
+	sim.waitForPeers(func(int) int { return sim.network.Size() - 1 })
+
+	sim.network.OnSlot(func(slot types.Slot) {
+		from := int(slot) % sim.network.Size()
+		sim.publish(from, sim.proposeBlock(slot, 'b', false))
+		sim.publish(from, sim.proposeBlock(slot, 'a', true))
+	})
+	for i := types.Slot(0); i < 4; i++ {
+		sim.network.AdvanceSlot()
+	}
+	// Messages may be lost, so only wait for the network to settle before checking the invariants.
+	time.Sleep(500 * time.Millisecond)
+	sim.checkSafety()
+}
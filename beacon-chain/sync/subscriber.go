@@ -55,6 +55,10 @@ func (s *Service) registerSubscribers(epoch types.Epoch, digest [4]byte) {
 		s.beaconBlockSubscriber,
 		digest,
 	)
+	// Nodes only serving syncing peers follow the head of the chain, and relay no other topic.
+	if !s.cfg.nodeRole.RelaysAllTopics() {
+		return
+	}
 	s.subscribe(
 		p2p.AggregateAndProofSubnetTopicFormat,
 		s.validateAggregateAndProof,
@@ -152,6 +156,7 @@ func (s *Service) subscribeWithBase(topic string, validator wrappedVal, handle s
 		log.WithError(err).Error("Could not register validator for topic")
 		return nil
 	}
+	s.setGossipValidator(topic, validator)
 
 	sub, err := s.cfg.p2p.SubscribeToTopic(topic)
 	if err != nil {
@@ -278,6 +283,9 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 			}
 			messageIgnoredValidationCounter.WithLabelValues(topic).Inc()
 		}
+		if b != pubsub.ValidationAccept {
+			s.journalGossipMessage(topic, pid, msg, b, err)
+		}
 		return b
 	}
 }
@@ -627,6 +635,7 @@ func (s *Service) unSubscribeFromTopic(topic string) {
 	if err := s.cfg.p2p.PubSub().UnregisterTopicValidator(topic); err != nil {
 		log.WithError(err).Error("Could not unregister topic validator")
 	}
+	s.setGossipValidator(topic, nil)
 	sub := s.subHandler.subForTopic(topic)
 	if sub != nil {
 		sub.Cancel()
@@ -728,23 +737,18 @@ func (s *Service) currentForkDigest() ([4]byte, error) {
 	return forks.CreateForkDigest(s.cfg.chain.GenesisTime(), genRoot[:])
 }
 
-// Checks if the provided digest matches up with the current supposed digest.
+// Checks if the provided digest is the current one, or the one of a fork whose topics
+// the node remains subscribed to around a fork boundary.
 func isDigestValid(digest [4]byte, genesis time.Time, genValRoot [32]byte) (bool, error) {
 	retDigest, err := forks.CreateForkDigest(genesis, genValRoot[:])
 	if err != nil {
 		return false, err
 	}
-	isNextEpoch, err := forks.IsForkNextEpoch(genesis, genValRoot[:])
-	if err != nil {
-		return false, err
-	}
-	// In the event there is a fork the next epoch,
-	// we skip the check, as we subscribe subnets an
-	// epoch in advance.
-	if isNextEpoch {
+	if retDigest == digest {
 		return true, nil
 	}
-	return retDigest == digest, nil
+	currEpoch := slots.ToEpoch(slots.Since(genesis))
+	return isForkDigestSubscribable(digest, currEpoch, genValRoot)
 }
 
 func agentString(pid peer.ID, hst host.Host) string {
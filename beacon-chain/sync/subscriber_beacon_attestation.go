@@ -6,7 +6,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
-	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/container/slice"
 	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -41,8 +40,7 @@ func (_ *Service) persistentSubnetIndices() []uint64 {
 }
 
 func (_ *Service) aggregatorSubnetIndices(currentSlot types.Slot) []uint64 {
-	endEpoch := slots.ToEpoch(currentSlot) + 1
-	endSlot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(endEpoch))
+	endSlot := subnetIndicesEndSlot(currentSlot)
 	var commIds []uint64
 	for i := currentSlot; i <= endSlot; i++ {
 		commIds = append(commIds, cache.SubnetIDs.GetAggregatorSubnetIDs(i)...)
@@ -51,11 +49,20 @@ func (_ *Service) aggregatorSubnetIndices(currentSlot types.Slot) []uint64 {
 }
 
 func (_ *Service) attesterSubnetIndices(currentSlot types.Slot) []uint64 {
-	endEpoch := slots.ToEpoch(currentSlot) + 1
-	endSlot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(endEpoch))
+	endSlot := subnetIndicesEndSlot(currentSlot)
 	var commIds []uint64
 	for i := currentSlot; i <= endSlot; i++ {
 		commIds = append(commIds, cache.SubnetIDs.GetAttesterSubnetIDs(i)...)
 	}
 	return slice.SetUint64(commIds)
 }
+
+// subnetIndicesEndSlot returns the last slot whose subnets are wanted at the current slot, which
+// is the start slot of the next epoch unless that slot overflows.
+func subnetIndicesEndSlot(currentSlot types.Slot) types.Slot {
+	endSlot, err := slots.NextEpochStart(slots.ToEpoch(currentSlot))
+	if err != nil {
+		return currentSlot
+	}
+	return endSlot
+}
@@ -9,10 +9,12 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
@@ -147,3 +149,38 @@ func TestService_BeaconBlockSubscribe_UndefinedEeError(t *testing.T) {
 	require.Equal(t, 0, len(s.badBlockCache.Keys()))
 	require.Equal(t, 1, len(s.seenBlockCache.Keys()))
 }
+
+func TestService_BeaconBlockSubscribe_PassesVerifiedSignatures(t *testing.T) {
+	chainService := &chainMock.ChainService{ReceiveBlockMockErr: errors.New("not imported")}
+	s := &Service{
+		cfg: &config{
+			chain: chainService,
+		},
+		seenBlockCache:        lruwrpr.New(10),
+		badBlockCache:         lruwrpr.New(10),
+		verifiedBlockSigCache: lruwrpr.New(10),
+	}
+	msg := util.NewBeaconBlock()
+	root, err := msg.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	// Signatures are verified again unless validation verified them.
+	require.ErrorContains(t, "not imported", s.beaconBlockSubscriber(context.Background(), msg))
+	// A copy of the block with another signature is verified again.
+	s.setVerifiedBlockSignature(root, bytesutil.PadTo([]byte{'a'}, 96))
+	require.ErrorContains(t, "not imported", s.beaconBlockSubscriber(context.Background(), msg))
+	s.setVerifiedBlockSignature(root, msg.Signature)
+	require.ErrorContains(t, "not imported", s.beaconBlockSubscriber(context.Background(), msg))
+	require.ErrorContains(t, "not imported", s.beaconBlockSubscriber(context.Background(), msg))
+
+	require.Equal(t, 4, len(chainService.BlockVerifications))
+	assert.Equal(t, (*transition.BlockVerification)(nil), chainService.BlockVerifications[0])
+	assert.Equal(t, (*transition.BlockVerification)(nil), chainService.BlockVerifications[1])
+	require.NotNil(t, chainService.BlockVerifications[2])
+	assert.Equal(t, root, chainService.BlockVerifications[2].BlockRoot)
+	assert.DeepEqual(t, msg.Signature, chainService.BlockVerifications[2].Signature)
+	assert.Equal(t, true, chainService.BlockVerifications[2].ProposerSignature)
+	assert.Equal(t, false, chainService.BlockVerifications[2].RandaoReveal)
+	// A verification is only used once.
+	assert.Equal(t, (*transition.BlockVerification)(nil), chainService.BlockVerifications[3])
+}
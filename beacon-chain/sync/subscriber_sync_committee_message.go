@@ -1,16 +1,20 @@
 package sync
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
 	"google.golang.org/protobuf/proto"
 )
 
 // skipcq: SCC-U1000
-func (s *Service) syncCommitteeMessageSubscriber(_ context.Context, msg proto.Message) error {
+func (s *Service) syncCommitteeMessageSubscriber(ctx context.Context, msg proto.Message) error {
 	m, ok := msg.(*ethpb.SyncCommitteeMessage)
 	if !ok {
 		return fmt.Errorf("message was not type *eth.SyncCommitteeMessage, type=%T", msg)
@@ -20,5 +24,35 @@ func (s *Service) syncCommitteeMessageSubscriber(_ context.Context, msg proto.Me
 		return errors.New("nil sync committee message")
 	}
 
+	// A message voting for a block that is neither the head nor known yet is saved to the pool once
+	// the block arrives, and the block is requested from peers in the meantime.
+	headRoot, err := s.cfg.chain.HeadRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(m.BlockRoot, headRoot) && !s.hasBlockAndState(ctx, bytesutil.ToBytes32(m.BlockRoot)) {
+		syncCommitteeMessageVoteCount.WithLabelValues("pending").Inc()
+		s.savePendingSyncCommitteeMessage(m)
+		return nil
+	}
+
 	return s.cfg.syncCommsPool.SaveSyncCommitteeMessage(m)
 }
+
+// Is a background routine that prunes sync committee messages outside of the
+// pool's retention period at the start of every slot.
+func (s *Service) pruneSyncCommitteeMessages() {
+	slotTicker := slots.NewSlotTicker(s.cfg.chain.GenesisTime(), params.BeaconConfig().SecondsPerSlot)
+	for {
+		select {
+		case currSlot := <-slotTicker.C():
+			if err := s.cfg.syncCommsPool.PruneSyncCommitteeMessages(currSlot); err != nil {
+				log.WithError(err).Error("Could not prune sync committee messages")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			slotTicker.Done()
+			return
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
@@ -709,3 +711,72 @@ func createPeer(t *testing.T, topics ...string) *p2ptest.TestP2P {
 	}
 	return p
 }
+
+func TestRegisterSubscribers_MatchesGossipTopics(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.MainnetConfig().Copy()
+	cfg.AltairForkEpoch = 5
+	params.OverrideBeaconConfig(cfg)
+	params.BeaconConfig().InitializeForkSchedule()
+
+	gFlags := new(flags.GlobalFlags)
+	gFlags.SubscribeToAllSubnets = true
+	flags.Init(gFlags)
+	defer flags.Init(new(flags.GlobalFlags))
+
+	for _, epoch := range []types.Epoch{0, 5} {
+		ctx, cancel := context.WithCancel(context.Background())
+		p := p2ptest.NewTestP2P(t)
+		r := Service{
+			ctx: ctx,
+			cfg: &config{
+				chain: &mockChain.ChainService{
+					Genesis:        time.Now(),
+					ValidatorsRoot: [32]byte{'A'},
+				},
+				p2p:         p,
+				initialSync: &mockSync.Sync{IsSyncing: false},
+			},
+			chainStarted: abool.New(),
+			subHandler:   newSubTopicHandler(),
+		}
+		genRoot := r.cfg.chain.GenesisValidatorsRoot()
+		digest, err := forks.ForkDigestFromEpoch(epoch, genRoot[:])
+		require.NoError(t, err)
+		r.registerSubscribers(epoch, digest)
+
+		wanted := p2p.GossipTopics(epoch, digest, p.Encoding().ProtocolSuffix())
+		topics := r.subHandler.allTopics()
+		sort.Strings(wanted)
+		sort.Strings(topics)
+		assert.DeepEqual(t, wanted, topics)
+		cancel()
+	}
+}
+
+func TestRegisterSubscribers_RPCServerNode(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := p2ptest.NewTestP2P(t)
+	r := Service{
+		ctx: ctx,
+		cfg: &config{
+			chain: &mockChain.ChainService{
+				Genesis:        time.Now(),
+				ValidatorsRoot: [32]byte{'A'},
+			},
+			p2p:         p,
+			initialSync: &mockSync.Sync{IsSyncing: false},
+			nodeRole:    peers.RPCServerNode,
+		},
+		chainStarted: abool.New(),
+		subHandler:   newSubTopicHandler(),
+	}
+	genRoot := r.cfg.chain.GenesisValidatorsRoot()
+	digest, err := forks.ForkDigestFromEpoch(0, genRoot[:])
+	require.NoError(t, err)
+	r.registerSubscribers(0, digest)
+
+	wanted := []string{fmt.Sprintf(p2p.BlockSubnetTopicFormat, digest) + p.Encoding().ProtocolSuffix()}
+	assert.DeepEqual(t, wanted, r.subHandler.allTopics())
+}
@@ -16,6 +16,10 @@ func TestMain(m *testing.M) {
 	flags.Init(&flags.GlobalFlags{
 		BlockBatchLimit:            64,
 		BlockBatchLimitBurstFactor: 10,
+		RPCBlocksByRootRateLimit:   64,
+		RPCStatusRateLimit:         1,
+		RPCMetadataRateLimit:       1,
+		RPCRateLimitMaxViolations:  10,
 	})
 	defer func() {
 		flags.Init(resetFlags)
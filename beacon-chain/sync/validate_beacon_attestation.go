@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/config/features"
@@ -51,6 +52,10 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 		return pubsub.ValidationIgnore, nil
 	}
 
+	if s.throttleGossipMessage(s.gossipRateLimiter.attestationCollector(), pid, msg) {
+		return pubsub.ValidationIgnore, errGossipRateLimited
+	}
+
 	ctx, span := trace.StartSpan(ctx, "sync.validateCommitteeIndexBeaconAttestation")
 	defer span.End()
 
@@ -234,7 +239,7 @@ func (s *Service) validateUnaggregatedAttWithState(ctx context.Context, a *eth.A
 		tracing.AnnotateError(span, err)
 		return pubsub.ValidationReject, err
 	}
-	return s.validateWithBatchVerifier(ctx, "attestation", set)
+	return s.validateWithBatchVerifier(ctx, "attestation", signing.AttestationMessage, set)
 }
 
 // Returns true if the attestation was already seen for the participating validator for the slot.
@@ -1,6 +1,7 @@
 package sync
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"time"
@@ -226,6 +227,7 @@ func (s *Service) validateBeaconBlock(ctx context.Context, blk interfaces.Signed
 		s.setBadBlock(ctx, blockRoot)
 		return err
 	}
+	s.setVerifiedBlockSignature(blockRoot, blk.Signature())
 	// In the event the block is more than an epoch ahead from its
 	// parent state, we have to advance the state forward.
 	parentState, err = transition.ProcessSlotsUsingNextSlotCache(ctx, parentState, blk.Block().ParentRoot(), blk.Block().Slot())
@@ -341,6 +343,38 @@ func (s *Service) setBadBlock(ctx context.Context, root [32]byte) {
 	s.badBlockCache.Add(string(root[:]), true)
 }
 
+// Records the proposer signature of the block verified during validation. The signature is kept
+// along with the block root, as the root does not cover the signature.
+func (s *Service) setVerifiedBlockSignature(root [32]byte, sig []byte) {
+	s.verifiedBlockSigLock.Lock()
+	defer s.verifiedBlockSigLock.Unlock()
+	if s.verifiedBlockSigCache == nil {
+		return
+	}
+	s.verifiedBlockSigCache.Add(string(root[:]), bytesutil.SafeCopyBytes(sig))
+}
+
+// blockVerification returns the signatures of the block verified during validation, or nil if none
+// were. A copy of the block carrying another signature than the verified one is fully verified.
+// A verification is only returned once, so a block received again is fully verified.
+func (s *Service) blockVerification(root [32]byte, sig []byte) *transition.BlockVerification {
+	s.verifiedBlockSigLock.Lock()
+	defer s.verifiedBlockSigLock.Unlock()
+	if s.verifiedBlockSigCache == nil {
+		return nil
+	}
+	v, ok := s.verifiedBlockSigCache.Get(string(root[:]))
+	if !ok {
+		return nil
+	}
+	verifiedSig, ok := v.([]byte)
+	if !ok || !bytes.Equal(verifiedSig, sig) {
+		return nil
+	}
+	s.verifiedBlockSigCache.Remove(string(root[:]))
+	return &transition.BlockVerification{BlockRoot: root, Signature: verifiedSig, ProposerSignature: true}
+}
+
 // This captures metrics for block arrival time by subtracts slot start time.
 func captureArrivalTimeMetric(genesisTime uint64, currentSlot types.Slot) error {
 	startTime, err := slots.ToTime(genesisTime, currentSlot)
@@ -81,8 +81,9 @@ func TestValidateBeaconBlockPubSub_InvalidSignature(t *testing.T) {
 			blockNotifier: chainService.BlockNotifier(),
 			stateGen:      stateGen,
 		},
-		seenBlockCache: lruwrpr.New(10),
-		badBlockCache:  lruwrpr.New(10),
+		seenBlockCache:        lruwrpr.New(10),
+		badBlockCache:         lruwrpr.New(10),
+		verifiedBlockSigCache: lruwrpr.New(10),
 	}
 
 	buf := new(bytes.Buffer)
@@ -102,6 +103,9 @@ func TestValidateBeaconBlockPubSub_InvalidSignature(t *testing.T) {
 	require.ErrorIs(t, err, signing.ErrSigFailedToVerify)
 	result := res == pubsub.ValidationReject
 	assert.Equal(t, true, result)
+	root, err := msg.Block.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, (*transition.BlockVerification)(nil), r.blockVerification(root, msg.Signature))
 }
 
 func TestValidateBeaconBlockPubSub_BlockAlreadyPresentInDB(t *testing.T) {
@@ -315,10 +319,11 @@ func TestValidateBeaconBlockPubSub_ValidProposerSignature(t *testing.T) {
 			blockNotifier: chainService.BlockNotifier(),
 			stateGen:      stateGen,
 		},
-		seenBlockCache:      lruwrpr.New(10),
-		badBlockCache:       lruwrpr.New(10),
-		slotToPendingBlocks: gcache.New(time.Second, 2*time.Second),
-		seenPendingBlocks:   make(map[[32]byte]bool),
+		seenBlockCache:        lruwrpr.New(10),
+		badBlockCache:         lruwrpr.New(10),
+		verifiedBlockSigCache: lruwrpr.New(10),
+		slotToPendingBlocks:   gcache.New(time.Second, 2*time.Second),
+		seenPendingBlocks:     make(map[[32]byte]bool),
 	}
 	buf := new(bytes.Buffer)
 	_, err = p.Encoding().EncodeGossip(buf, msg)
@@ -338,6 +343,15 @@ func TestValidateBeaconBlockPubSub_ValidProposerSignature(t *testing.T) {
 	result := res == pubsub.ValidationAccept
 	assert.Equal(t, true, result)
 	assert.NotNil(t, m.ValidatorData, "Decoded message was not set on the message validator data")
+	root, err := msg.Block.HashTreeRoot()
+	require.NoError(t, err)
+	// A copy of the block with another signature is not covered by the verification.
+	assert.Equal(t, (*transition.BlockVerification)(nil), r.blockVerification(root, make([]byte, len(msg.Signature))))
+	verified := r.blockVerification(root, msg.Signature)
+	require.NotNil(t, verified)
+	assert.Equal(t, root, verified.BlockRoot)
+	assert.Equal(t, true, verified.ProposerSignature)
+	assert.Equal(t, false, verified.RandaoReveal)
 }
 
 func TestValidateBeaconBlockPubSub_WithLookahead(t *testing.T) {
@@ -1,6 +1,7 @@
 package sync
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -10,6 +11,8 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
@@ -67,6 +70,10 @@ func (s *Service) validateSyncCommitteeMessage(
 		return pubsub.ValidationIgnore, nil
 	}
 
+	if s.throttleGossipMessage(s.gossipRateLimiter.syncCommitteeCollector(), pid, msg) {
+		return pubsub.ValidationIgnore, errGossipRateLimited
+	}
+
 	if msg.Topic == nil {
 		return pubsub.ValidationReject, errInvalidTopic
 	}
@@ -107,6 +114,20 @@ func (s *Service) validateSyncCommitteeMessage(
 	}
 
 	s.markSyncCommitteeMessagesSeen(committeeIndices, m)
+	if headRoot, err := s.cfg.chain.HeadRoot(ctx); err == nil && bytes.Equal(m.BlockRoot, headRoot) {
+		syncCommitteeMessageVoteCount.WithLabelValues("head").Inc()
+	} else {
+		syncCommitteeMessageVoteCount.WithLabelValues("non_head").Inc()
+	}
+
+	// Broadcast the sync committee message on a feed to notify other services in the beacon node
+	// of a received message.
+	s.cfg.operationNotifier.OperationFeed().Send(&feed.Event{
+		Type: opfeed.SyncCommitteeMessageReceived,
+		Data: &opfeed.SyncCommitteeMessageReceivedData{
+			Message: m,
+		},
+	})
 
 	msg.ValidatorData = m
 	return pubsub.ValidationAccept, nil
@@ -167,30 +188,34 @@ func (s *Service) rejectIncorrectSyncCommittee(
 	return func(ctx context.Context) (pubsub.ValidationResult, error) {
 		_, span := trace.StartSpan(ctx, "sync.rejectIncorrectSyncCommittee")
 		defer span.End()
-		isValid := false
 		digest, err := s.currentForkDigest()
 		if err != nil {
 			tracing.AnnotateError(span, err)
 			return pubsub.ValidationIgnore, err
 		}
 
-		format := p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.SyncCommitteeMessage{})]
 		// Validate that the validator is in the correct committee.
-		subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
-		for _, idx := range committeeIndices {
-			subnet := uint64(idx) / subCommitteeSize
-			if strings.HasPrefix(topic, fmt.Sprintf(format, digest, subnet)) {
-				isValid = true
-				break
-			}
-		}
-		if !isValid {
+		if _, ok := syncCommitteeTopicSubnet(committeeIndices, topic, digest); !ok {
 			return pubsub.ValidationReject, errors.New("sync committee message references a different subnet")
 		}
 		return pubsub.ValidationAccept, nil
 	}
 }
 
+// syncCommitteeTopicSubnet returns the subnet of the given sync committee topic, if it is one of
+// the subnets of the given committee indices.
+func syncCommitteeTopicSubnet(committeeIndices []types.CommitteeIndex, topic string, digest [4]byte) (uint64, bool) {
+	format := p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.SyncCommitteeMessage{})]
+	subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	for _, idx := range committeeIndices {
+		subnet := uint64(idx) / subCommitteeSize
+		if strings.HasPrefix(topic, fmt.Sprintf(format, digest, subnet)) {
+			return subnet, true
+		}
+	}
+	return 0, false
+}
+
 // There has been no other valid sync committee signature for the declared `slot`, `validator_index`,
 // and `subcommittee_index`. In the event of `validator_index` belongs to multiple subnets, as long
 // as one subnet has not been seen, we should let it in.
@@ -257,7 +282,7 @@ func (s *Service) rejectInvalidSyncCommitteeSignature(m *ethpb.SyncCommitteeMess
 			PublicKeys: []bls.PublicKey{pKey},
 			Signatures: [][]byte{m.Signature},
 		}
-		return s.validateWithBatchVerifier(ctx, "sync committee message", set)
+		return s.validateWithBatchVerifier(ctx, "sync committee message", signing.SyncCommitteeMessage, set)
 	}
 }
 
@@ -329,6 +329,7 @@ func TestService_ValidateSyncCommitteeMessage(t *testing.T) {
 					Genesis:              time.Now().Add(-time.Second * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Duration(hState.Slot()-1)),
 					SyncCommitteeDomain:  d,
 					PublicKey:            bytesutil.ToBytes48(keys[chosenVal].PublicKey().Marshal()),
+					Root:                 headRoot[:],
 				}
 
 				// Set Topic and Subnet
@@ -383,6 +384,7 @@ func TestService_ValidateSyncCommitteeMessage(t *testing.T) {
 					Genesis:              time.Now().Add(-time.Second * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Duration(hState.Slot()-1)),
 					SyncCommitteeDomain:  d,
 					PublicKey:            bytesutil.ToBytes48(keys[chosenVal].PublicKey().Marshal()),
+					Root:                 headRoot[:],
 				}
 
 				msg.Signature = keys[chosenVal].Sign(sigRoot[:]).Marshal()
@@ -409,6 +411,67 @@ func TestService_ValidateSyncCommitteeMessage(t *testing.T) {
 				}},
 			want: pubsub.ValidationAccept,
 		},
+		{
+			name: "Valid Sync Committee Signature For An Unknown Block",
+			svc: NewService(context.Background(),
+				WithP2P(mockp2p.NewTestP2P(t)),
+				WithInitialSync(&mockSync.Sync{IsSyncing: false}),
+				WithChainService(chainService),
+				WithStateNotifier(chainService.StateNotifier()),
+				WithOperationNotifier(chainService.OperationNotifier()),
+			),
+			setupSvc: func(s *Service, msg *ethpb.SyncCommitteeMessage, topic string) (*Service, string) {
+				s.cfg.stateGen = stategen.New(beaconDB)
+				s.cfg.beaconDB = beaconDB
+				s.initCaches()
+				hState, err := beaconDB.State(context.Background(), headRoot)
+				assert.NoError(t, err)
+				subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+
+				numOfVals := hState.NumValidators()
+
+				chosenVal := numOfVals - 10
+				d, err := signing.Domain(hState.Fork(), slots.ToEpoch(hState.Slot()), params.BeaconConfig().DomainSyncCommittee, hState.GenesisValidatorsRoot())
+				assert.NoError(t, err)
+				// The message votes for a block which is neither the head nor known yet.
+				unknownRoot := [32]byte{0xBB}
+				rawBytes := p2ptypes.SSZBytes(unknownRoot[:])
+				sigRoot, err := signing.ComputeSigningRoot(&rawBytes, d)
+				assert.NoError(t, err)
+
+				s.cfg.chain = &mockChain.ChainService{
+					SyncCommitteeIndices: []types.CommitteeIndex{types.CommitteeIndex(subCommitteeSize)},
+					ValidatorsRoot:       [32]byte{'A'},
+					Genesis:              time.Now().Add(-time.Second * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Duration(hState.Slot()-1)),
+					SyncCommitteeDomain:  d,
+					PublicKey:            bytesutil.ToBytes48(keys[chosenVal].PublicKey().Marshal()),
+					Root:                 headRoot[:],
+				}
+
+				msg.Signature = keys[chosenVal].Sign(sigRoot[:]).Marshal()
+				msg.BlockRoot = unknownRoot[:]
+				msg.ValidatorIndex = types.ValidatorIndex(chosenVal)
+				msg.Slot = slots.PrevSlot(hState.Slot())
+
+				// Set Topic and Subnet
+				digest, err := s.currentForkDigest()
+				assert.NoError(t, err)
+				actualTopic := fmt.Sprintf(defaultTopic, digest, 1)
+
+				return s, actualTopic
+			},
+			args: args{
+				ctx:   context.Background(),
+				pid:   "random",
+				topic: defaultTopic,
+				msg: &ethpb.SyncCommitteeMessage{
+					Slot:           1,
+					ValidatorIndex: 1,
+					BlockRoot:      params.BeaconConfig().ZeroHash[:],
+					Signature:      emptySig[:],
+				}},
+			want: pubsub.ValidationAccept,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -62,6 +62,10 @@ func (s *Service) validateSyncContributionAndProof(ctx context.Context, pid peer
 		return pubsub.ValidationIgnore, nil
 	}
 
+	if s.throttleGossipMessage(s.gossipRateLimiter.syncCommitteeCollector(), pid, msg) {
+		return pubsub.ValidationIgnore, errGossipRateLimited
+	}
+
 	m, err := s.readSyncContributionMessage(msg)
 	if err != nil {
 		tracing.AnnotateError(span, err)
@@ -74,17 +78,7 @@ func (s *Service) validateSyncContributionAndProof(ctx context.Context, pid peer
 		return pubsub.ValidationIgnore, err
 	}
 	// Validate the message's data according to the p2p specification.
-	if result, err := validationPipeline(
-		ctx,
-		rejectIncorrectSubcommitteeIndex(m),
-		rejectEmptyContribution(m),
-		s.ignoreSeenSyncContribution(m),
-		rejectInvalidAggregator(m),
-		s.rejectInvalidIndexInSubCommittee(m),
-		s.rejectInvalidSelectionProof(m),
-		s.rejectInvalidContributionSignature(m),
-		s.rejectInvalidSyncAggregateSignature(m),
-	); result != pubsub.ValidationAccept {
+	if result, err := s.syncContributionValidationPipeline(m)(ctx); result != pubsub.ValidationAccept {
 		return result, err
 	}
 
@@ -108,6 +102,28 @@ func (s *Service) validateSyncContributionAndProof(ctx context.Context, pid peer
 	return pubsub.ValidationAccept, nil
 }
 
+// syncContributionValidationPipeline validates a contribution in stages, from the cheapest check to the
+// most expensive one. The structure of the contribution is checked first, as it is cheap and catches most
+// invalid messages. Then the selection proof and the signature of the aggregator are verified, and only
+// then the aggregate signature of the contribution. The aggregate signature is verified on the bounded
+// pool of contribution verifiers, so that bursts of contributions at slot boundaries don't delay the
+// verification of other messages.
+func (s *Service) syncContributionValidationPipeline(m *ethpb.SignedContributionAndProof) validationFn {
+	return func(ctx context.Context) (pubsub.ValidationResult, error) {
+		return validationPipeline(
+			ctx,
+			rejectIncorrectSubcommitteeIndex(m),
+			rejectEmptyContribution(m),
+			s.ignoreSeenSyncContribution(m),
+			rejectInvalidAggregator(m),
+			s.rejectInvalidIndexInSubCommittee(m),
+			s.rejectInvalidSelectionProof(m),
+			s.rejectInvalidContributionSignature(m),
+			s.contributionVerifiers.run(s.rejectInvalidSyncAggregateSignature(m)),
+		)
+	}
+}
+
 // Parse a sync contribution message from a pubsub message.
 func (s *Service) readSyncContributionMessage(msg *pubsub.Message) (*ethpb.SignedContributionAndProof, error) {
 	raw, err := s.decodePubsubMessage(msg)
@@ -250,7 +266,7 @@ func (s *Service) rejectInvalidContributionSignature(m *ethpb.SignedContribution
 			PublicKeys: []bls.PublicKey{publicKey},
 			Signatures: [][]byte{m.Signature},
 		}
-		return s.validateWithBatchVerifier(ctx, "sync contribution signature", set)
+		return s.validateWithBatchVerifier(ctx, "sync contribution signature", signing.SyncContributionMessage, set)
 	}
 }
 
@@ -306,7 +322,10 @@ func (s *Service) rejectInvalidSyncAggregateSignature(m *ethpb.SignedContributio
 			PublicKeys: []bls.PublicKey{aggKey},
 			Signatures: [][]byte{m.Message.Contribution.Signature},
 		}
-		return s.validateWithBatchVerifier(ctx, "sync contribution aggregate signature", set)
+		// The aggregate signature is verified right away, rather than joining the batch of the single
+		// verifier routine shared with attestations.
+		verified, err := signing.VerifySet(signing.SyncContributionMessage, set)
+		return verificationResult(span, "sync contribution aggregate signature", verified, err)
 	}
 }
 
@@ -417,7 +436,7 @@ func (s *Service) verifySyncSelectionData(ctx context.Context, m *ethpb.Contribu
 		PublicKeys: []bls.PublicKey{publicKey},
 		Signatures: [][]byte{m.SelectionProof},
 	}
-	valid, err := s.validateWithBatchVerifier(ctx, "sync contribution selection signature", set)
+	valid, err := s.validateWithBatchVerifier(ctx, "sync contribution selection signature", signing.SyncContributionMessage, set)
 	if err != nil {
 		return err
 	}
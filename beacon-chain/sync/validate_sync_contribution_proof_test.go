@@ -899,22 +899,7 @@ func TestValidateSyncContributionAndProof(t *testing.T) {
 	defaultTopic := p2p.SyncContributionAndProofSubnetTopicFormat
 	defaultTopic = fmt.Sprintf(defaultTopic, []byte{0xAB, 0x00, 0xCC, 0x9E})
 	defaultTopic = defaultTopic + "/" + encoder.ProtocolSuffixSSZSnappy
-	emptySig := [96]byte{}
 	pid := peer.ID("random")
-	msg := &ethpb.SignedContributionAndProof{
-		Message: &ethpb.ContributionAndProof{
-			AggregatorIndex: 1,
-			Contribution: &ethpb.SyncCommitteeContribution{
-				Slot:              0,
-				SubcommitteeIndex: 1,
-				BlockRoot:         params.BeaconConfig().ZeroHash[:],
-				AggregationBits:   bitfield.NewBitvector128(),
-				Signature:         emptySig[:],
-			},
-			SelectionProof: emptySig[:],
-		},
-		Signature: emptySig[:],
-	}
 	chainService := &mockChain.ChainService{
 		Genesis:        time.Now(),
 		ValidatorsRoot: [32]byte{'A'},
@@ -928,68 +913,9 @@ func TestValidateSyncContributionAndProof(t *testing.T) {
 	)
 	go s.verifierRoutine()
 	s.cfg.stateGen = stategen.New(database)
-	msg.Message.Contribution.BlockRoot = headRoot[:]
 	s.cfg.beaconDB = database
-	hState, err := database.State(context.Background(), headRoot)
-	assert.NoError(t, err)
-	sc, err := hState.CurrentSyncCommittee()
-	assert.NoError(t, err)
-	cd, err := signing.Domain(hState.Fork(), slots.ToEpoch(slots.PrevSlot(hState.Slot())), params.BeaconConfig().DomainContributionAndProof, hState.GenesisValidatorsRoot())
-	assert.NoError(t, err)
-	d, err := signing.Domain(hState.Fork(), slots.ToEpoch(hState.Slot()), params.BeaconConfig().DomainSyncCommittee, hState.GenesisValidatorsRoot())
-	assert.NoError(t, err)
-	var pubkeys [][]byte
-	for i := uint64(0); i < params.BeaconConfig().SyncCommitteeSubnetCount; i++ {
-		coms, err := altair.SyncSubCommitteePubkeys(sc, types.CommitteeIndex(i))
-		pubkeys = coms
-		assert.NoError(t, err)
-		for _, p := range coms {
-			idx, ok := hState.ValidatorIndexByPubkey(bytesutil.ToBytes48(p))
-			assert.Equal(t, true, ok)
-			rt, err := syncSelectionProofSigningRoot(hState, slots.PrevSlot(hState.Slot()), types.CommitteeIndex(i))
-			assert.NoError(t, err)
-			sig := keys[idx].Sign(rt[:])
-			isAggregator, err := altair.IsSyncCommitteeAggregator(sig.Marshal())
-			require.NoError(t, err)
-			if isAggregator {
-				msg.Message.AggregatorIndex = idx
-				msg.Message.SelectionProof = sig.Marshal()
-				msg.Message.Contribution.Slot = slots.PrevSlot(hState.Slot())
-				msg.Message.Contribution.SubcommitteeIndex = i
-				msg.Message.Contribution.BlockRoot = headRoot[:]
-				msg.Message.Contribution.AggregationBits = bitfield.NewBitvector128()
-				// Only Sign for 1 validator.
-				rawBytes := p2ptypes.SSZBytes(headRoot[:])
-				sigRoot, err := signing.ComputeSigningRoot(&rawBytes, d)
-				assert.NoError(t, err)
-				valIdx, ok := hState.ValidatorIndexByPubkey(bytesutil.ToBytes48(coms[0]))
-				assert.Equal(t, true, ok)
-				sig = keys[valIdx].Sign(sigRoot[:])
-				msg.Message.Contribution.AggregationBits.SetBitAt(uint64(0), true)
-				msg.Message.Contribution.Signature = sig.Marshal()
-
-				sigRoot, err = signing.ComputeSigningRoot(msg.Message, cd)
-				assert.NoError(t, err)
-				contrSig := keys[idx].Sign(sigRoot[:])
-				msg.Signature = contrSig.Marshal()
-				break
-			}
-		}
-	}
-
-	pd, err := signing.Domain(hState.Fork(), slots.ToEpoch(slots.PrevSlot(hState.Slot())), params.BeaconConfig().DomainSyncCommitteeSelectionProof, hState.GenesisValidatorsRoot())
-	require.NoError(t, err)
-	subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
-	s.cfg.chain = &mockChain.ChainService{
-		ValidatorsRoot:              [32]byte{'A'},
-		Genesis:                     time.Now().Add(-time.Second * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Duration(msg.Message.Contribution.Slot)),
-		SyncCommitteeIndices:        []types.CommitteeIndex{types.CommitteeIndex(msg.Message.Contribution.SubcommitteeIndex * subCommitteeSize)},
-		PublicKey:                   bytesutil.ToBytes48(keys[msg.Message.AggregatorIndex].PublicKey().Marshal()),
-		SyncSelectionProofDomain:    pd,
-		SyncContributionProofDomain: cd,
-		SyncCommitteeDomain:         d,
-		SyncCommitteePubkeys:        pubkeys,
-	}
+	msg, chain := validSyncContribution(t, database, headRoot, keys)
+	s.cfg.chain = chain
 	s.initCaches()
 
 	marshalledObj, err := msg.MarshalSSZ()
@@ -1029,6 +955,84 @@ func TestValidateSyncContributionAndProof(t *testing.T) {
 	}
 }
 
+// BenchmarkSyncContributionValidation compares the throughput of validating contributions with the
+// aggregate signature verified right away, and on the bounded pool of contribution verifiers.
+func BenchmarkSyncContributionValidation(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	database := testingdb.SetupDB(b)
+	headRoot, keys := fillUpBlocksAndState(ctx, b, database)
+	msg, chain := validSyncContribution(b, database, headRoot, keys)
+	s := &Service{
+		ctx:                   ctx,
+		cfg:                   &config{chain: chain},
+		signatureChan:         make(chan *signatureVerifier, verifierLimit),
+		contributionVerifiers: newVerifierPool(),
+	}
+	s.initCaches()
+	go s.verifierRoutine()
+
+	unbounded := func(ctx context.Context) (pubsub.ValidationResult, error) {
+		return validationPipeline(
+			ctx,
+			rejectIncorrectSubcommitteeIndex(msg),
+			rejectEmptyContribution(msg),
+			s.ignoreSeenSyncContribution(msg),
+			rejectInvalidAggregator(msg),
+			s.rejectInvalidIndexInSubCommittee(msg),
+			s.rejectInvalidSelectionProof(msg),
+			s.rejectInvalidContributionSignature(msg),
+			s.rejectInvalidSyncAggregateSignature(msg),
+		)
+	}
+	for _, bm := range []struct {
+		name string
+		fn   validationFn
+	}{
+		{name: "unbounded", fn: unbounded},
+		{name: "pooled", fn: s.syncContributionValidationPipeline(msg)},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if result, err := bm.fn(ctx); result != pubsub.ValidationAccept {
+						b.Errorf("Contribution not accepted: %v", err)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestSyncContributionValidationPipeline_InvalidSignatureSkipsAggregate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	database := testingdb.SetupDB(t)
+	headRoot, keys := fillUpBlocksAndState(ctx, t, database)
+	msg, chain := validSyncContribution(t, database, headRoot, keys)
+	// The selection proof is a valid signature, of another message than the contribution.
+	msg.Signature = msg.Message.SelectionProof
+	s := &Service{
+		ctx:                   ctx,
+		cfg:                   &config{chain: chain},
+		signatureChan:         make(chan *signatureVerifier, verifierLimit),
+		contributionVerifiers: make(verifierPool, 1),
+	}
+	s.initCaches()
+	go s.verifierRoutine()
+
+	// Every contribution verifier is busy, so the aggregate signature can't be verified.
+	s.contributionVerifiers <- struct{}{}
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer timeoutCancel()
+	result, err := s.syncContributionValidationPipeline(msg)(timeoutCtx)
+	assert.Equal(t, pubsub.ValidationReject, result)
+	assert.NotNil(t, err)
+	assert.NoError(t, timeoutCtx.Err())
+}
+
 func TestValidateSyncContributionAndProof_Optimistic(t *testing.T) {
 	p := mockp2p.NewTestP2P(t)
 	ctx := context.Background()
@@ -1060,28 +1064,111 @@ func TestValidateSyncContributionAndProof_Optimistic(t *testing.T) {
 	assert.Equal(t, true, valid, "Should have ignore this message")
 }
 
-func fillUpBlocksAndState(ctx context.Context, t *testing.T, beaconDB db.Database) ([32]byte, []bls.SecretKey) {
-	gs, keys := util.DeterministicGenesisStateAltair(t, 64)
+// validSyncContribution returns a valid sync contribution for the slot before the head state, and a chain
+// service providing the data needed to validate it.
+func validSyncContribution(
+	tb testing.TB, database db.Database, headRoot [32]byte, keys []bls.SecretKey,
+) (*ethpb.SignedContributionAndProof, *mockChain.ChainService) {
+	emptySig := [96]byte{}
+	msg := &ethpb.SignedContributionAndProof{
+		Message: &ethpb.ContributionAndProof{
+			AggregatorIndex: 1,
+			Contribution: &ethpb.SyncCommitteeContribution{
+				Slot:              0,
+				SubcommitteeIndex: 1,
+				BlockRoot:         headRoot[:],
+				AggregationBits:   bitfield.NewBitvector128(),
+				Signature:         emptySig[:],
+			},
+			SelectionProof: emptySig[:],
+		},
+		Signature: emptySig[:],
+	}
+	hState, err := database.State(context.Background(), headRoot)
+	assert.NoError(tb, err)
+	sc, err := hState.CurrentSyncCommittee()
+	assert.NoError(tb, err)
+	cd, err := signing.Domain(hState.Fork(), slots.ToEpoch(slots.PrevSlot(hState.Slot())), params.BeaconConfig().DomainContributionAndProof, hState.GenesisValidatorsRoot())
+	assert.NoError(tb, err)
+	d, err := signing.Domain(hState.Fork(), slots.ToEpoch(hState.Slot()), params.BeaconConfig().DomainSyncCommittee, hState.GenesisValidatorsRoot())
+	assert.NoError(tb, err)
+	var pubkeys [][]byte
+	for i := uint64(0); i < params.BeaconConfig().SyncCommitteeSubnetCount; i++ {
+		coms, err := altair.SyncSubCommitteePubkeys(sc, types.CommitteeIndex(i))
+		pubkeys = coms
+		assert.NoError(tb, err)
+		for _, p := range coms {
+			idx, ok := hState.ValidatorIndexByPubkey(bytesutil.ToBytes48(p))
+			assert.Equal(tb, true, ok)
+			rt, err := syncSelectionProofSigningRoot(hState, slots.PrevSlot(hState.Slot()), types.CommitteeIndex(i))
+			assert.NoError(tb, err)
+			sig := keys[idx].Sign(rt[:])
+			isAggregator, err := altair.IsSyncCommitteeAggregator(sig.Marshal())
+			require.NoError(tb, err)
+			if isAggregator {
+				msg.Message.AggregatorIndex = idx
+				msg.Message.SelectionProof = sig.Marshal()
+				msg.Message.Contribution.Slot = slots.PrevSlot(hState.Slot())
+				msg.Message.Contribution.SubcommitteeIndex = i
+				msg.Message.Contribution.BlockRoot = headRoot[:]
+				msg.Message.Contribution.AggregationBits = bitfield.NewBitvector128()
+				// Only Sign for 1 validator.
+				rawBytes := p2ptypes.SSZBytes(headRoot[:])
+				sigRoot, err := signing.ComputeSigningRoot(&rawBytes, d)
+				assert.NoError(tb, err)
+				valIdx, ok := hState.ValidatorIndexByPubkey(bytesutil.ToBytes48(coms[0]))
+				assert.Equal(tb, true, ok)
+				sig = keys[valIdx].Sign(sigRoot[:])
+				msg.Message.Contribution.AggregationBits.SetBitAt(uint64(0), true)
+				msg.Message.Contribution.Signature = sig.Marshal()
+
+				sigRoot, err = signing.ComputeSigningRoot(msg.Message, cd)
+				assert.NoError(tb, err)
+				contrSig := keys[idx].Sign(sigRoot[:])
+				msg.Signature = contrSig.Marshal()
+				break
+			}
+		}
+	}
+
+	pd, err := signing.Domain(hState.Fork(), slots.ToEpoch(slots.PrevSlot(hState.Slot())), params.BeaconConfig().DomainSyncCommitteeSelectionProof, hState.GenesisValidatorsRoot())
+	require.NoError(tb, err)
+	subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	chain := &mockChain.ChainService{
+		ValidatorsRoot:              [32]byte{'A'},
+		Genesis:                     time.Now().Add(-time.Second * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Duration(msg.Message.Contribution.Slot)),
+		SyncCommitteeIndices:        []types.CommitteeIndex{types.CommitteeIndex(msg.Message.Contribution.SubcommitteeIndex * subCommitteeSize)},
+		PublicKey:                   bytesutil.ToBytes48(keys[msg.Message.AggregatorIndex].PublicKey().Marshal()),
+		SyncSelectionProofDomain:    pd,
+		SyncContributionProofDomain: cd,
+		SyncCommitteeDomain:         d,
+		SyncCommitteePubkeys:        pubkeys,
+	}
+	return msg, chain
+}
+
+func fillUpBlocksAndState(ctx context.Context, tb testing.TB, beaconDB db.Database) ([32]byte, []bls.SecretKey) {
+	gs, keys := util.DeterministicGenesisStateAltair(tb, 64)
 	sCom, err := altair.NextSyncCommittee(ctx, gs)
-	assert.NoError(t, err)
-	assert.NoError(t, gs.SetCurrentSyncCommittee(sCom))
-	assert.NoError(t, beaconDB.SaveGenesisData(context.Background(), gs))
+	assert.NoError(tb, err)
+	assert.NoError(tb, gs.SetCurrentSyncCommittee(sCom))
+	assert.NoError(tb, beaconDB.SaveGenesisData(context.Background(), gs))
 
 	testState := gs.Copy()
 	hRoot := [32]byte{}
 	for i := types.Slot(1); i <= params.BeaconConfig().SlotsPerEpoch; i++ {
 		blk, err := util.GenerateFullBlockAltair(testState, keys, util.DefaultBlockGenConfig(), i)
-		require.NoError(t, err)
+		require.NoError(tb, err)
 		r, err := blk.Block.HashTreeRoot()
-		require.NoError(t, err)
+		require.NoError(tb, err)
 		wsb, err := wrapper.WrappedSignedBeaconBlock(blk)
-		require.NoError(t, err)
+		require.NoError(tb, err)
 		_, testState, err = transition.ExecuteStateTransitionNoVerifyAnySig(ctx, testState, wsb)
-		assert.NoError(t, err)
-		assert.NoError(t, beaconDB.SaveBlock(ctx, wsb))
-		assert.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: i, Root: r[:]}))
-		assert.NoError(t, beaconDB.SaveState(ctx, testState, r))
-		require.NoError(t, beaconDB.SaveHeadBlockRoot(ctx, r))
+		assert.NoError(tb, err)
+		assert.NoError(tb, beaconDB.SaveBlock(ctx, wsb))
+		assert.NoError(tb, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: i, Root: r[:]}))
+		assert.NoError(tb, beaconDB.SaveState(ctx, testState, r))
+		require.NoError(tb, beaconDB.SaveHeadBlockRoot(ctx, r))
 		hRoot = r
 	}
 	return hRoot, keys
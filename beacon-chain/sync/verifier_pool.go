@@ -0,0 +1,39 @@
+package sync
+
+import (
+	"context"
+	"runtime"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// verifierPool bounds the number of validation functions running at once, to keep the
+// signature verifications of a message type from using every core. A nil pool is unbounded.
+type verifierPool chan struct{}
+
+// newVerifierPool returns a pool running at most half as many validation functions as there
+// are usable cores at once, and at least one.
+func newVerifierPool() verifierPool {
+	size := runtime.GOMAXPROCS(0) / 2
+	if size < 1 {
+		size = 1
+	}
+	return make(verifierPool, size)
+}
+
+// run returns a validation function which waits for a free worker of the pool before running fn.
+// The message is ignored if the context is done first.
+func (p verifierPool) run(fn validationFn) validationFn {
+	return func(ctx context.Context) (pubsub.ValidationResult, error) {
+		if p == nil {
+			return fn(ctx)
+		}
+		select {
+		case p <- struct{}{}:
+			defer func() { <-p }()
+		case <-ctx.Done():
+			return pubsub.ValidationIgnore, ctx.Err()
+		}
+		return fn(ctx)
+	}
+}
@@ -0,0 +1,63 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestVerifierPool_BoundsConcurrency(t *testing.T) {
+	p := make(verifierPool, 2)
+	var running, maxRunning int32
+	fn := p.run(func(_ context.Context) (pubsub.ValidationResult, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return pubsub.ValidationAccept, nil
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := fn(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, pubsub.ValidationAccept, result)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+}
+
+func TestVerifierPool_ContextDone(t *testing.T) {
+	p := make(verifierPool, 1)
+	p <- struct{}{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := p.run(func(_ context.Context) (pubsub.ValidationResult, error) {
+		t.Error("Validation function should not run")
+		return pubsub.ValidationAccept, nil
+	})(ctx)
+	assert.Equal(t, pubsub.ValidationIgnore, result)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// A nil pool runs the validation function right away.
+	var nilPool verifierPool
+	result, err = nilPool.run(func(_ context.Context) (pubsub.ValidationResult, error) {
+		return pubsub.ValidationAccept, nil
+	})(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationAccept, result)
+}
@@ -0,0 +1,5 @@
+package telemetry
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "telemetry")
@@ -0,0 +1,182 @@
+// Package telemetry implements an opt-in reporter of anonymized node health metrics, used to
+// build network health dashboards. Reports only contain coarse health indicators and the client
+// version, and identify the node by a random ID which is generated locally and never derived
+// from the node's keys, addresses or validators.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+// NodeIDFileName is the name of the file, within the data directory, holding the random node ID.
+const NodeIDFileName = "telemetry-node-id"
+
+const (
+	nodeIDLength  = 16
+	reportTimeout = 10 * time.Second
+)
+
+// Report is the payload posted to the telemetry endpoint.
+type Report struct {
+	NodeID        string `json:"node_id"`
+	ClientVersion string `json:"client_version"`
+	Timestamp     int64  `json:"timestamp"`
+	SyncDistance  uint64 `json:"sync_distance"`
+	PeerCount     int    `json:"peer_count"`
+	MemoryBytes   uint64 `json:"memory_bytes"`
+}
+
+// Config for the telemetry service.
+type Config struct {
+	Endpoint      string
+	Interval      time.Duration
+	DataDir       string
+	HeadFetcher   blockchain.HeadFetcher
+	TimeFetcher   blockchain.TimeFetcher
+	PeersProvider p2p.PeersProvider
+	Client        *http.Client
+}
+
+// Service periodically posts a Report to the configured endpoint.
+type Service struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	cfg    *Config
+	nodeID string
+}
+
+// NewService initializes the telemetry service, loading the node ID from the data directory
+// or generating a new one.
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("no telemetry endpoint provided")
+	}
+	if cfg.Interval <= 0 {
+		return nil, errors.New("telemetry interval must be positive")
+	}
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	nodeID, err := loadOrCreateNodeID(filepath.Join(cfg.DataDir, NodeIDFileName))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:    ctx,
+		cancel: cancel,
+		cfg:    cfg,
+		nodeID: nodeID,
+	}, nil
+}
+
+// Start reporting to the telemetry endpoint.
+func (s *Service) Start() {
+	log.WithFields(logrus.Fields{
+		"endpoint": s.cfg.Endpoint,
+		"interval": s.cfg.Interval,
+		"nodeID":   s.nodeID,
+		"fields":   "sync_distance, peer_count, memory_bytes, client_version",
+	}).Warn("Anonymized telemetry is enabled, node health metrics will be reported to the telemetry endpoint")
+	async.RunEvery(s.ctx, s.cfg.Interval, func() {
+		if err := s.report(s.ctx); err != nil {
+			log.WithError(err).Debug("Could not send telemetry report")
+		}
+	})
+}
+
+// Stop the service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the service.
+func (_ *Service) Status() error {
+	return nil
+}
+
+// buildReport collects the current health metrics of the node.
+func (s *Service) buildReport() *Report {
+	var syncDistance uint64
+	if current, head := s.cfg.TimeFetcher.CurrentSlot(), s.cfg.HeadFetcher.HeadSlot(); current > head {
+		syncDistance = uint64(current - head)
+	}
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return &Report{
+		NodeID:        s.nodeID,
+		ClientVersion: "prysm/" + version.SemanticVersion(),
+		Timestamp:     time.Now().Unix(),
+		SyncDistance:  syncDistance,
+		PeerCount:     len(s.cfg.PeersProvider.Peers().Connected()),
+		MemoryBytes:   mem.Sys,
+	}
+}
+
+func (s *Service) report(ctx context.Context) error {
+	body, err := json.Marshal(s.buildReport())
+	if err != nil {
+		return errors.Wrap(err, "could not marshal telemetry report")
+	}
+	ctx, cancel := context.WithTimeout(ctx, reportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "could not create telemetry request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not post telemetry report")
+	}
+	if err := resp.Body.Close(); err != nil {
+		log.WithError(err).Debug("Could not close telemetry response body")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("telemetry endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// loadOrCreateNodeID reads the node ID from the given file, or generates a random one and
+// persists it so that the node keeps the same ID across restarts.
+func loadOrCreateNodeID(path string) (string, error) {
+	if file.FileExists(path) {
+		enc, err := file.ReadFileAsBytes(path)
+		if err != nil {
+			return "", errors.Wrap(err, "could not read telemetry node id")
+		}
+		id := strings.TrimSpace(string(enc))
+		if b, err := hex.DecodeString(id); err == nil && len(b) == nodeIDLength {
+			return id, nil
+		}
+		log.WithField("path", path).Warn("Invalid telemetry node id, generating a new one")
+	}
+	b := make([]byte, nodeIDLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "could not generate telemetry node id")
+	}
+	id := hex.EncodeToString(b)
+	if err := file.WriteFile(path, []byte(id)); err != nil {
+		return "", errors.Wrap(err, "could not save telemetry node id")
+	}
+	return id, nil
+}
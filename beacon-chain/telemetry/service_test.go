@@ -0,0 +1,96 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestNewService_InvalidConfig(t *testing.T) {
+	_, err := NewService(context.Background(), &Config{Interval: time.Hour})
+	assert.ErrorContains(t, "no telemetry endpoint provided", err)
+	_, err = NewService(context.Background(), &Config{Endpoint: "http://localhost"})
+	assert.ErrorContains(t, "telemetry interval must be positive", err)
+}
+
+func TestNewService_StableNodeID(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{Endpoint: "http://localhost", Interval: time.Hour, DataDir: dir}
+	s1, err := NewService(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 2*nodeIDLength, len(s1.nodeID))
+	s2, err := NewService(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, s1.nodeID, s2.nodeID)
+
+	// A corrupted node id is replaced.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, NodeIDFileName), []byte("not hex"), 0600))
+	s3, err := NewService(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.NotEqual(t, s1.nodeID, s3.nodeID)
+	assert.Equal(t, 2*nodeIDLength, len(s3.nodeID))
+}
+
+func TestService_Report(t *testing.T) {
+	reports := make(chan *Report, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		rep := &Report{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(rep))
+		reports <- rep
+	}))
+	defer srv.Close()
+
+	st, _ := util.DeterministicGenesisState(t, 1)
+	require.NoError(t, st.SetSlot(90))
+	currentSlot := types.Slot(100)
+	chain := &mock.ChainService{State: st, Slot: &currentSlot}
+	s, err := NewService(context.Background(), &Config{
+		Endpoint:      srv.URL,
+		Interval:      time.Hour,
+		DataDir:       t.TempDir(),
+		HeadFetcher:   chain,
+		TimeFetcher:   chain,
+		PeersProvider: &p2ptest.MockPeersProvider{},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.report(context.Background()))
+	rep := <-reports
+	assert.Equal(t, s.nodeID, rep.NodeID)
+	assert.Equal(t, uint64(10), rep.SyncDistance)
+	assert.Equal(t, 2, rep.PeerCount)
+	assert.NotEqual(t, uint64(0), rep.MemoryBytes)
+	assert.NotEqual(t, "", rep.ClientVersion)
+}
+
+func TestService_Report_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	chain := &mock.ChainService{Genesis: time.Now()}
+	s, err := NewService(context.Background(), &Config{
+		Endpoint:      srv.URL,
+		Interval:      time.Hour,
+		DataDir:       t.TempDir(),
+		HeadFetcher:   chain,
+		TimeFetcher:   chain,
+		PeersProvider: &p2ptest.MockPeersProvider{},
+	})
+	require.NoError(t, err)
+	assert.ErrorContains(t, "telemetry endpoint responded with status 503", s.report(context.Background()))
+}
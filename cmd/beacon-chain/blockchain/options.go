@@ -1,10 +1,13 @@
 package blockchaincmd
 
 import (
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/cmd"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/urfave/cli/v2"
 )
 
@@ -15,10 +18,38 @@ func FlagOptions(c *cli.Context) ([]blockchain.Option, error) {
 	if err != nil {
 		return nil, err
 	}
+	retention, err := blocksRetentionEpochs(c)
+	if err != nil {
+		return nil, err
+	}
 	maxRoutines := c.Int(cmd.MaxGoroutines.Name)
 	opts := []blockchain.Option{
 		blockchain.WithMaxGoroutines(maxRoutines),
 		blockchain.WithWeakSubjectivityCheckpoint(wsCheckpt),
+		blockchain.WithBlocksRetentionEpochs(retention),
+		blockchain.WithEpochBoundaryBranches(c.Int(flags.EpochBoundaryBranches.Name)),
 	}
 	return opts, nil
 }
+
+// blocksRetentionEpochs reads the retention period of the --blocks-retention-epochs flag, rejecting
+// periods shorter than MIN_EPOCHS_FOR_BLOCK_REQUESTS, the number of epochs of blocks a node is
+// required to serve to its peers.
+func blocksRetentionEpochs(c *cli.Context) (types.Epoch, error) {
+	retention := types.Epoch(c.Uint64(flags.BlocksRetentionEpochs.Name))
+	if retention == 0 {
+		return 0, nil
+	}
+	if minEpochs := MinEpochsForBlockRequests(); retention < minEpochs {
+		return 0, errors.Errorf("--%s=%d is lower than the minimum of %d epochs of blocks peers may request (MIN_EPOCHS_FOR_BLOCK_REQUESTS)",
+			flags.BlocksRetentionEpochs.Name, retention, minEpochs)
+	}
+	return retention, nil
+}
+
+// MinEpochsForBlockRequests returns MIN_EPOCHS_FOR_BLOCK_REQUESTS as defined by the p2p specification:
+// MIN_VALIDATOR_WITHDRAWABILITY_DELAY + CHURN_LIMIT_QUOTIENT // 2, which is 33024 epochs on mainnet.
+func MinEpochsForBlockRequests() types.Epoch {
+	cfg := params.BeaconConfig()
+	return cfg.MinValidatorWithdrawabilityDelay + types.Epoch(cfg.ChurnLimitQuotient/2)
+}
@@ -0,0 +1,44 @@
+package blockchaincmd
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestMinEpochsForBlockRequests(t *testing.T) {
+	require.Equal(t, types.Epoch(33024), MinEpochsForBlockRequests())
+}
+
+func TestBlocksRetentionEpochs(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     uint64
+		want      types.Epoch
+		wantedErr string
+	}{
+		{name: "full history", value: 0, want: 0},
+		{name: "below minimum", value: 1000, wantedErr: "lower than the minimum of 33024 epochs"},
+		{name: "minimum", value: 33024, want: 33024},
+		{name: "above minimum", value: 50000, want: 50000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := cli.App{}
+			set := flag.NewFlagSet("test", 0)
+			set.Uint64(flags.BlocksRetentionEpochs.Name, tt.value, "")
+			ctx := cli.NewContext(&app, set, nil)
+			got, err := blocksRetentionEpochs(ctx)
+			if tt.wantedErr != "" {
+				require.ErrorContains(t, tt.wantedErr, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -4,7 +4,9 @@ package flags
 
 import (
 	"strings"
+	"time"
 
+	"github.com/prysmaticlabs/prysm/api/namespace"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/urfave/cli/v2"
 )
@@ -63,6 +65,12 @@ var (
 		Usage: "Port used to listening and respond metrics for prometheus.",
 		Value: 8080,
 	}
+	// EnableDebugUI serves a simple HTML view of recent blocks, peers, fork choice head and pool sizes
+	// on the monitoring port.
+	EnableDebugUI = &cli.BoolFlag{
+		Name:  "enable-debug-ui",
+		Usage: "Serves simple HTML pages with recent blocks, peers, fork choice head and operation pool sizes at /debug/ui on the monitoring port. Meant for local debugging and devnets.",
+	}
 	// CertFlag defines a flag for the node's TLS certificate.
 	CertFlag = &cli.StringFlag{
 		Name:  "tls-cert",
@@ -103,6 +111,37 @@ var (
 			"(browser enforced). This flag has no effect if not used with --grpc-gateway-port.",
 		Value: "http://localhost:4200,http://localhost:7500,http://127.0.0.1:4200,http://127.0.0.1:7500,http://0.0.0.0:4200,http://0.0.0.0:7500,http://localhost:3000,http://0.0.0.0:3000,http://127.0.0.1:3000",
 	}
+	// GRPCGatewayCorsDisableCredentials disallows credentials in cross origin requests to the gRPC gateway.
+	GRPCGatewayCorsDisableCredentials = &cli.BoolFlag{
+		Name: "grpc-gateway-cors-disable-credentials",
+		Usage: "Disallow credentials such as cookies in cross origin requests to the gRPC gateway. Required to accept " +
+			"cross origin requests from any domain with --grpc-gateway-corsdomain=*",
+	}
+	// GRPCNamespaces defines the API namespaces served over gRPC.
+	GRPCNamespaces = &cli.StringFlag{
+		Name: "grpc-namespaces",
+		Usage: "Comma-separated list of API namespaces served over gRPC. Validator clients require the validator, " +
+			"node and prysm namespaces. Possible values: `" + namespace.Names() + "`.",
+		Value: namespace.Names(),
+	}
+	// HTTPNamespaces defines the API namespaces served by the gRPC gateway.
+	HTTPNamespaces = &cli.StringFlag{
+		Name: "http-namespaces",
+		Usage: "Comma-separated list of API namespaces served by the gRPC gateway, which must also be served over " +
+			"gRPC. Possible values: `" + namespace.Names() + "`.",
+		Value: namespace.Names(),
+	}
+	// APIAuthNamespaces defines the API namespaces requiring a bearer token.
+	APIAuthNamespaces = &cli.StringFlag{
+		Name: "api-auth-namespaces",
+		Usage: "Comma-separated list of API namespaces whose gRPC and HTTP endpoints require the bearer token read " +
+			"from --api-auth-token-file. Possible values: `" + namespace.Names() + "`.",
+	}
+	// APIAuthTokenFile specifies the file holding the bearer token of authenticated API namespaces.
+	APIAuthTokenFile = &cli.StringFlag{
+		Name:  "api-auth-token-file",
+		Usage: "Path to a file holding the bearer token required by the namespaces set with --api-auth-namespaces.",
+	}
 	// MinSyncPeers specifies the required number of successful peer handshakes in order
 	// to start syncing with external peers.
 	MinSyncPeers = &cli.IntFlag{
@@ -142,6 +181,49 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the beaconDB.",
 		Value: 2048,
 	}
+	// HotStateCacheSize specifies the number of hot states held in memory by the state generator.
+	HotStateCacheSize = &cli.IntFlag{
+		Name:  "hot-state-cache-size",
+		Usage: "The number of hot states held in memory for regenerating states. Can be changed without a restart by reloading the config file.",
+		Value: 32,
+	}
+	// RegenMemoryCap specifies the number of epoch boundary states held in memory by the state generator
+	// before spilling them to disk.
+	RegenMemoryCap = &cli.IntFlag{
+		Name: "regen-memory-cap",
+		Usage: "The number of epoch boundary states held in memory for regenerating states during long periods of non-finality. " +
+			"Older epoch boundary states are spilled to a scratch directory in the data directory instead of being discarded. 0 disables spilling.",
+		Value: 0,
+	}
+	// HotStateMemoryCap specifies the number of hot states held in memory by the state generator before
+	// spilling the least recently used ones to disk.
+	HotStateMemoryCap = &cli.IntFlag{
+		Name: "hot-state-memory-cap",
+		Usage: "The number of hot states held in memory during long periods of non-finality. The least recently used hot states " +
+			"are spilled to a scratch directory in the data directory and reloaded when needed. Overrides --hot-state-cache-size. 0 disables spilling.",
+		Value: 0,
+	}
+	// BlocksRetentionEpochs specifies the number of finalized epochs of blocks and states kept in the beacon DB.
+	BlocksRetentionEpochs = &cli.Uint64Flag{
+		Name: "blocks-retention-epochs",
+		Usage: "The number of epochs before the finalized checkpoint for which blocks and states are kept in the beaconDB. " +
+			"Older blocks and states are pruned on every new finalized checkpoint, and are not backfilled after checkpoint sync. " +
+			"Must be at least MIN_EPOCHS_FOR_BLOCK_REQUESTS (33024 epochs on mainnet). 0 keeps the full history.",
+		Value: 0,
+	}
+	// EpochBoundaryBranches specifies the number of heaviest fork choice branches advanced ahead of every epoch boundary.
+	EpochBoundaryBranches = &cli.IntFlag{
+		Name: "epoch-boundary-branches",
+		Usage: "The number of heaviest fork choice branches whose state is advanced to the next epoch during the last slot of every epoch, " +
+			"so that attestations are produced and validated without processing the epoch transition on the spot. 0 disables it.",
+		Value: 2,
+	}
+	// GossipScoringPolicyFile specifies a YAML file overriding the peer score parameters of gossip topics.
+	GossipScoringPolicyFile = &cli.StringFlag{
+		Name: "gossip-scoring-policy-file",
+		Usage: "The path to a YAML file overriding the peer score parameters of gossip topics, keyed by gossip message name " +
+			"(e.g. sync_committee). Useful for private networks and testnets with different validator counts or committee sizes.",
+	}
 	// DisableDiscv5 disables running discv5.
 	DisableDiscv5 = &cli.BoolFlag{
 		Name:  "disable-discv5",
@@ -159,6 +241,53 @@ var (
 		Usage: "The factor by which block batch limit may increase on burst.",
 		Value: 10,
 	}
+	// RPCBlocksByRootRateLimit specifies the rate of blocks served to a peer requesting blocks by root.
+	RPCBlocksByRootRateLimit = &cli.IntFlag{
+		Name: "rpc-blocks-by-root-rate-limit",
+		Usage: "The number of blocks per second served to a single peer requesting blocks by root. " +
+			"Peers may burst up to the block batch limit burst factor times this allowance.",
+		Value: 64,
+	}
+	// RPCStatusRateLimit specifies the rate of status requests served to a peer.
+	RPCStatusRateLimit = &cli.Float64Flag{
+		Name:  "rpc-status-rate-limit",
+		Usage: "The number of status requests per second served to a single peer. Peers may burst up to 5 times this allowance.",
+		Value: 1,
+	}
+	// RPCMetadataRateLimit specifies the rate of metadata requests served to a peer.
+	RPCMetadataRateLimit = &cli.Float64Flag{
+		Name:  "rpc-metadata-rate-limit",
+		Usage: "The number of metadata requests per second served to a single peer. Peers may burst up to 5 times this allowance.",
+		Value: 1,
+	}
+	// RPCRateLimitMaxViolations specifies how many rate limited requests a peer may send before being disconnected.
+	RPCRateLimitMaxViolations = &cli.IntFlag{
+		Name: "rpc-rate-limit-max-violations",
+		Usage: "The number of rate limited requests a peer may accumulate before it is sent a goodbye and disconnected. " +
+			"One violation is forgiven every 10 seconds.",
+		Value: 10,
+	}
+	// GossipAttestationRateLimit specifies the rate of attestations validated per peer on a topic.
+	GossipAttestationRateLimit = &cli.Float64Flag{
+		Name: "gossip-attestation-rate-limit",
+		Usage: "The number of attestations and aggregates per second validated from a single peer on each topic. " +
+			"Peers may burst up to 5 times this allowance, further messages are ignored and peers persistently exceeding it are down-scored. Set to 0 to disable.",
+		Value: 256,
+	}
+	// GossipSyncCommitteeRateLimit specifies the rate of sync committee messages validated per peer on a topic.
+	GossipSyncCommitteeRateLimit = &cli.Float64Flag{
+		Name: "gossip-sync-committee-rate-limit",
+		Usage: "The number of sync committee messages and contributions per second validated from a single peer on each topic. " +
+			"Peers may burst up to 5 times this allowance, further messages are ignored and peers persistently exceeding it are down-scored. Set to 0 to disable.",
+		Value: 64,
+	}
+	// BlockBatchFanOutPeers specifies how many peers a block batch request is split across during initial sync.
+	BlockBatchFanOutPeers = &cli.IntFlag{
+		Name: "block-batch-fan-out-peers",
+		Usage: "The number of peers a block batch request is split across, one epoch at a time, during initial sync. " +
+			"Chunks held up by slow peers are requested from the others. Set to 1 to request every batch from a single peer.",
+		Value: 1,
+	}
 	// DisableSync disables a node from syncing at start-up. Instead the node enters regular sync
 	// immediately.
 	DisableSync = &cli.BoolFlag{
@@ -170,11 +299,89 @@ var (
 		Name:  "enable-debug-rpc-endpoints",
 		Usage: "Enables the debug rpc service, containing utility endpoints such as /eth/v1alpha1/beacon/state.",
 	}
+	// RPCSlowRequestThreshold defines the duration after which an RPC request is logged as slow.
+	RPCSlowRequestThreshold = &cli.DurationFlag{
+		Name:  "rpc-slow-request-threshold",
+		Usage: "Logs RPC requests which take longer than this duration to be served, along with their parameters. 0 disables slow request logging.",
+		Value: 2 * time.Second,
+	}
+	// MaxHeadStalenessSlots defines how many slots the head may lag behind the current slot for validator duties to be served.
+	MaxHeadStalenessSlots = &cli.Uint64Flag{
+		Name: "max-head-staleness-slots",
+		Usage: "Refuses validator duty, block, attestation and aggregation requests when the head is more than this number of " +
+			"slots behind the current slot. Validator clients configured with several beacon node endpoints switch to the next " +
+			"one, others suspend their duties until the head catches up. 0 disables the check.",
+	}
+	// HistoricalStateReconstructionBudget defines the time after which reconstructing a historical state is given up.
+	HistoricalStateReconstructionBudget = &cli.DurationFlag{
+		Name: "historical-state-reconstruction-budget",
+		Usage: "Gives up reconstructing a historical state requested through the debug endpoints after this duration. " +
+			"States are reconstructed one at a time by replaying blocks from the nearest stored state. 0 disables the budget.",
+		Value: 2 * time.Minute,
+	}
+	// HistoricalStateCacheSize defines the number of reconstructed historical states kept in memory.
+	HistoricalStateCacheSize = &cli.IntFlag{
+		Name:  "historical-state-cache-size",
+		Usage: "The number of most recently reconstructed finalized states kept in memory by the debug endpoints. 0 disables the cache.",
+		Value: 8,
+	}
+	// AttestationAggregationOffset defines the time into each slot at which the attestation pool is aggregated.
+	AttestationAggregationOffset = &cli.DurationFlag{
+		Name: "attestation-aggregation-offset",
+		Usage: "The time into each slot at which the unaggregated attestations of the pool are aggregated, so that block " +
+			"proposals and aggregation duties read from an already aggregated pool. Must be less than the slot duration. " +
+			"Defaults to a twelfth of a slot before aggregates are due, at two thirds of the slot.",
+	}
+	// ReadReplica runs the beacon node as a read replica serving API queries from an existing database.
+	ReadReplica = &cli.BoolFlag{
+		Name: "read-replica",
+		Usage: "Opens an existing database read-only and only serves the beacon, node, debug and prysm API namespaces " +
+			"from it, without connecting to peers or an execution node. The database is served as it was when opened, " +
+			"and can't be the database of a running beacon node, use a copy such as a backup from /db/backup.",
+	}
+	// DBPath sets the directory of the database opened by a read replica.
+	DBPath = &cli.StringFlag{
+		Name:  "db-path",
+		Usage: "Directory holding the beaconchain.db file opened by --read-replica. Defaults to the beaconchaindata directory of --datadir.",
+	}
+	// EnableStateSyncServing enables serving the finalized state to peers over the experimental state sync protocol.
+	EnableStateSyncServing = &cli.BoolFlag{
+		Name: "enable-state-sync-serving",
+		Usage: "(Experimental) Serves the finalized beacon state to peers in chunks over p2p, which they " +
+			"request by the root of the finalized block. Serializing the state increases memory usage on finalization.",
+	}
+	// LightClientServer enables producing and serving light client updates.
+	LightClientServer = &cli.BoolFlag{
+		Name: "light-client-server",
+		Usage: "Produces light client updates from the sync aggregates of processed blocks, serving them over the API " +
+			"and the light client gossip topics so Altair light clients can follow the chain.",
+	}
+	// TelemetryEndpoint enables the anonymized telemetry reporter and defines the endpoint it reports to.
+	TelemetryEndpoint = &cli.StringFlag{
+		Name: "telemetry-endpoint",
+		Usage: "Opts in to periodically reporting anonymized node health metrics (sync distance, peer count, memory usage " +
+			"and client version), identified only by a random node ID, to this HTTP endpoint. Disabled by default.",
+	}
+	// TelemetryInterval defines the period between two telemetry reports.
+	TelemetryInterval = &cli.DurationFlag{
+		Name:  "telemetry-interval",
+		Usage: "Period between two anonymized telemetry reports, when a telemetry endpoint is set.",
+		Value: time.Hour,
+	}
 	// SubscribeToAllSubnets defines a flag to specify whether to subscribe to all possible attestation/sync subnets or not.
 	SubscribeToAllSubnets = &cli.BoolFlag{
 		Name:  "subscribe-all-subnets",
 		Usage: "Subscribe to all possible attestation and sync subnets.",
 	}
+	// NodeRole specifies the part of the networking protocol served by the node.
+	NodeRole = &cli.StringFlag{
+		Name: "node-role",
+		Usage: "The part of the networking protocol served by the node, advertised to peers in its ENR: full, " +
+			"gossip-relay (relays every gossip topic, does not serve blocks by range to syncing peers) or " +
+			"rpc-server (serves blocks to syncing peers with raised limits, only subscribes to the block topic). " +
+			"Syncing nodes request blocks from gossip relays last.",
+		Value: "full",
+	}
 	// HistoricalSlasherNode is a set of beacon node flags required for performing historical detection with a slasher.
 	HistoricalSlasherNode = &cli.BoolFlag{
 		Name:  "historical-slasher-node",
@@ -210,6 +417,36 @@ var (
 		Usage: "Sets the minimum number of peers that a node will attempt to peer with that are subscribed to a subnet.",
 		Value: 6,
 	}
+	// ForkTopicsLeadEpochs defines a flag to set how many epochs before a fork the node subscribes to the gossip topics of the fork.
+	ForkTopicsLeadEpochs = &cli.Uint64Flag{
+		Name:  "fork-topics-lead-epochs",
+		Usage: "Sets the number of epochs before a fork at which the node subscribes to the gossip topics of the fork. Topics are always subscribed at least an epoch in advance.",
+		Value: 1,
+	}
+	// ForkTopicsRetentionEpochs defines a flag to set how many epochs after a fork the node remains subscribed to the gossip topics of the previous fork.
+	ForkTopicsRetentionEpochs = &cli.Uint64Flag{
+		Name:  "fork-topics-retention-epochs",
+		Usage: "Sets the number of epochs after a fork during which the node remains subscribed to the gossip topics of the previous fork.",
+		Value: 1,
+	}
+	// GossipJournalDir defines a flag to record the gossip messages failing validation in a journal.
+	GossipJournalDir = &cli.StringFlag{
+		Name: "gossip-journal-dir",
+		Usage: "Records every gossip message rejected or ignored by the validators, with its topic, peer, data and verdict, " +
+			"in a rolling journal in this directory. The journal can be replayed with `prysmctl gossip replay`.",
+	}
+	// GossipJournalMaxSize defines a flag to set the size of each file of the gossip journal.
+	GossipJournalMaxSize = &cli.Uint64Flag{
+		Name:  "gossip-journal-max-size",
+		Usage: "Sets the size in megabytes at which the gossip journal file is rotated. The 4 most recently rotated files are kept.",
+		Value: 64,
+	}
+	// SyncCommitteeMessageGracePeriod defines the number of slots before the current slot for which sync committee messages are kept in the pool.
+	SyncCommitteeMessageGracePeriod = &cli.Uint64Flag{
+		Name:  "sync-committee-message-grace-slots",
+		Usage: "Sets the number of slots before the current slot for which unaggregated sync committee messages are retained in the pool.",
+		Value: 0,
+	}
 	// SuggestedFeeRecipient specifies the fee recipient for the transaction fees.
 	SuggestedFeeRecipient = &cli.StringFlag{
 		Name:  "suggested-fee-recipient",
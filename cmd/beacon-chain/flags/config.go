@@ -8,14 +8,26 @@ import (
 // GlobalFlags specifies all the global flags for the
 // beacon node.
 type GlobalFlags struct {
-	HeadSync                   bool
-	DisableSync                bool
-	DisableDiscv5              bool
-	SubscribeToAllSubnets      bool
-	MinimumSyncPeers           int
-	MinimumPeersPerSubnet      int
-	BlockBatchLimit            int
-	BlockBatchLimitBurstFactor int
+	HeadSync                     bool
+	DisableSync                  bool
+	DisableDiscv5                bool
+	SubscribeToAllSubnets        bool
+	MinimumSyncPeers             int
+	MinimumPeersPerSubnet        int
+	BlockBatchLimit              int
+	BlockBatchLimitBurstFactor   int
+	RPCBlocksByRootRateLimit     int
+	RPCStatusRateLimit           float64
+	RPCMetadataRateLimit         float64
+	RPCRateLimitMaxViolations    int
+	GossipAttestationRateLimit   float64
+	GossipSyncCommitteeRateLimit float64
+	BlockBatchFanOutPeers        int
+	EnableStateSyncServing       bool
+	ForkTopicsLeadEpochs         uint64
+	ForkTopicsRetentionEpochs    uint64
+	GossipJournalDir             string
+	GossipJournalMaxSize         uint64
 }
 
 var globalConfig *GlobalFlags
@@ -49,10 +61,25 @@ func ConfigureGlobalFlags(ctx *cli.Context) {
 		log.Warn("Subscribing to All Attestation Subnets")
 		cfg.SubscribeToAllSubnets = true
 	}
+	if ctx.Bool(EnableStateSyncServing.Name) {
+		log.Warn("Serving the finalized state to peers over the experimental state sync protocol")
+		cfg.EnableStateSyncServing = true
+	}
 	cfg.DisableDiscv5 = ctx.Bool(DisableDiscv5.Name)
 	cfg.BlockBatchLimit = ctx.Int(BlockBatchLimit.Name)
 	cfg.BlockBatchLimitBurstFactor = ctx.Int(BlockBatchLimitBurstFactor.Name)
+	cfg.RPCBlocksByRootRateLimit = ctx.Int(RPCBlocksByRootRateLimit.Name)
+	cfg.RPCStatusRateLimit = ctx.Float64(RPCStatusRateLimit.Name)
+	cfg.RPCMetadataRateLimit = ctx.Float64(RPCMetadataRateLimit.Name)
+	cfg.RPCRateLimitMaxViolations = ctx.Int(RPCRateLimitMaxViolations.Name)
+	cfg.GossipAttestationRateLimit = ctx.Float64(GossipAttestationRateLimit.Name)
+	cfg.GossipSyncCommitteeRateLimit = ctx.Float64(GossipSyncCommitteeRateLimit.Name)
+	cfg.BlockBatchFanOutPeers = ctx.Int(BlockBatchFanOutPeers.Name)
 	cfg.MinimumPeersPerSubnet = ctx.Int(MinPeersPerSubnet.Name)
+	cfg.ForkTopicsLeadEpochs = ctx.Uint64(ForkTopicsLeadEpochs.Name)
+	cfg.ForkTopicsRetentionEpochs = ctx.Uint64(ForkTopicsRetentionEpochs.Name)
+	cfg.GossipJournalDir = ctx.String(GossipJournalDir.Name)
+	cfg.GossipJournalMaxSize = ctx.Uint64(GossipJournalMaxSize.Name)
 	configureMinimumPeers(ctx, cfg)
 
 	Init(cfg)
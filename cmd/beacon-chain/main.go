@@ -18,13 +18,16 @@ import (
 	dbcommands "github.com/prysmaticlabs/prysm/cmd/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	jwtcommands "github.com/prysmaticlabs/prysm/cmd/beacon-chain/jwt"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/p2pkey"
 	powchaincmd "github.com/prysmaticlabs/prysm/cmd/beacon-chain/powchain"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/preflight"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/sync/checkpoint"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/sync/genesis"
 	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/io/file"
 	"github.com/prysmaticlabs/prysm/io/logs"
 	"github.com/prysmaticlabs/prysm/monitoring/journald"
+	"github.com/prysmaticlabs/prysm/runtime/crash"
 	"github.com/prysmaticlabs/prysm/runtime/debug"
 	"github.com/prysmaticlabs/prysm/runtime/fdlimits"
 	_ "github.com/prysmaticlabs/prysm/runtime/maxprocs"
@@ -49,27 +52,62 @@ var appFlags = []cli.Flag{
 	flags.GRPCGatewayHost,
 	flags.GRPCGatewayPort,
 	flags.GPRCGatewayCorsDomain,
+	flags.GRPCGatewayCorsDisableCredentials,
+	flags.GRPCNamespaces,
+	flags.HTTPNamespaces,
+	flags.APIAuthNamespaces,
+	flags.APIAuthTokenFile,
 	flags.MinSyncPeers,
 	flags.ContractDeploymentBlock,
 	flags.SetGCPercent,
 	flags.HeadSync,
 	flags.DisableSync,
 	flags.DisableDiscv5,
+	flags.GossipScoringPolicyFile,
 	flags.BlockBatchLimit,
 	flags.BlockBatchLimitBurstFactor,
+	flags.RPCBlocksByRootRateLimit,
+	flags.RPCStatusRateLimit,
+	flags.RPCMetadataRateLimit,
+	flags.RPCRateLimitMaxViolations,
+	flags.GossipAttestationRateLimit,
+	flags.GossipSyncCommitteeRateLimit,
+	flags.BlockBatchFanOutPeers,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.InteropGenesisStateFlag,
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.HotStateCacheSize,
+	flags.RegenMemoryCap,
+	flags.HotStateMemoryCap,
+	flags.BlocksRetentionEpochs,
+	flags.EpochBoundaryBranches,
 	flags.EnableDebugRPCEndpoints,
+	flags.RPCSlowRequestThreshold,
+	flags.MaxHeadStalenessSlots,
+	flags.HistoricalStateReconstructionBudget,
+	flags.HistoricalStateCacheSize,
+	flags.AttestationAggregationOffset,
+	flags.ReadReplica,
+	flags.DBPath,
 	flags.SubscribeToAllSubnets,
+	flags.NodeRole,
+	flags.EnableStateSyncServing,
+	flags.LightClientServer,
+	flags.TelemetryEndpoint,
+	flags.TelemetryInterval,
 	flags.HistoricalSlasherNode,
 	flags.ChainID,
 	flags.NetworkID,
 	flags.WeakSubjectivityCheckpoint,
 	flags.Eth1HeaderReqLimit,
 	flags.MinPeersPerSubnet,
+	flags.ForkTopicsLeadEpochs,
+	flags.ForkTopicsRetentionEpochs,
+	flags.GossipJournalDir,
+	flags.GossipJournalMaxSize,
+	flags.SyncCommitteeMessageGracePeriod,
 	flags.SuggestedFeeRecipient,
 	flags.TerminalTotalDifficultyOverride,
 	flags.TerminalBlockHashOverride,
@@ -94,6 +132,9 @@ var appFlags = []cli.Flag{
 	cmd.P2PMetadata,
 	cmd.P2PAllowList,
 	cmd.P2PDenyList,
+	cmd.P2PFilterFile,
+	cmd.P2PInboundDialRate,
+	cmd.P2PInboundDialBurst,
 	cmd.DataDirFlag,
 	cmd.VerbosityFlag,
 	cmd.EnableTracingFlag,
@@ -102,6 +143,7 @@ var appFlags = []cli.Flag{
 	cmd.TraceSampleFractionFlag,
 	cmd.MonitoringHostFlag,
 	flags.MonitoringPortFlag,
+	flags.EnableDebugUI,
 	cmd.DisableMonitoringFlag,
 	cmd.ClearDB,
 	cmd.ForceClearDB,
@@ -146,6 +188,8 @@ func main() {
 	app.Commands = []*cli.Command{
 		dbcommands.Commands,
 		jwtcommands.Commands,
+		p2pkey.Commands,
+		preflight.Command(appFlags),
 	}
 
 	app.Flags = appFlags
@@ -188,6 +232,7 @@ func main() {
 				log.WithError(err).Error("Failed to configuring logging to disk.")
 			}
 		}
+		crash.Configure(ctx)
 		if err := cmd.ExpandSingleEndpointIfFile(ctx, flags.HTTPWeb3ProviderFlag); err != nil {
 			return err
 		}
@@ -207,12 +252,7 @@ func main() {
 		return cmd.ValidateNoArgs(ctx)
 	}
 
-	defer func() {
-		if x := recover(); x != nil {
-			log.Errorf("Runtime panic: %v\n%v", x, string(runtimeDebug.Stack()))
-			panic(x)
-		}
-	}()
+	defer crash.Recover()
 
 	if err := app.Run(os.Args); err != nil {
 		log.Error(err.Error())
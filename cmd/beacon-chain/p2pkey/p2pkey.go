@@ -0,0 +1,190 @@
+// Package p2pkey defines commands to manage the network key and the node record of a beacon node, which
+// helps deploying bootnodes and sentries.
+package p2pkey
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var log = logrus.WithField("prefix", "p2p-key")
+
+// GracePeriodFlag defines how long the current network key stays in use after a rotation.
+var GracePeriodFlag = &cli.DurationFlag{
+	Name:  "grace-period",
+	Usage: "How long the current network key stays in use after the rotation. The new key becomes active on the first start of the node after this period",
+	Value: 24 * time.Hour,
+}
+
+// Commands for managing the network key and node record of a beacon node.
+var Commands = &cli.Command{
+	Name:     "p2p-key",
+	Category: "p2p",
+	Usage:    "defines commands for managing the network key and node record of the beacon node",
+	Subcommands: []*cli.Command{
+		{
+			Name:        "export",
+			Description: `prints the peer ID and ENR of the node using its network key and advertised address`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.P2PPrivKey,
+				cmd.P2PHost,
+				cmd.P2PTCPPort,
+				cmd.P2PUDPPort,
+			}),
+			Action: export,
+		},
+		{
+			Name:        "rotate",
+			Description: `generates a new network key which replaces the current one after a grace period`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.P2PPrivKey,
+				cmd.P2PHost,
+				cmd.P2PTCPPort,
+				cmd.P2PUDPPort,
+				GracePeriodFlag,
+			}),
+			Action: rotate,
+		},
+		{
+			Name:        "set-static-ip",
+			Description: `pins the IP address and ports advertised in the node record`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.P2PHost,
+				cmd.P2PTCPPort,
+				cmd.P2PUDPPort,
+			}),
+			Action: setStaticIP,
+		},
+	},
+}
+
+func export(cliCtx *cli.Context) error {
+	keyFile := p2p.NetworkKeyPath(cliCtx.String(cmd.DataDirFlag.Name), cliCtx.String(cmd.P2PPrivKey.Name))
+	if !file.FileExists(keyFile) {
+		return errors.Errorf("no network key found at %s, the node generates one on its first start", keyFile)
+	}
+	key, err := p2p.LoadNetworkKey(keyFile)
+	if err != nil {
+		return errors.Wrap(err, "could not load network key")
+	}
+	if err := printIdentity(cliCtx, "Current", key); err != nil {
+		return err
+	}
+	pending, activateAt, err := p2p.PendingNetworkKey(keyFile)
+	if err != nil {
+		return errors.Wrap(err, "could not load pending network key")
+	}
+	if pending != nil {
+		fmt.Printf("Pending rotation, active after %s\n", activateAt.Format(time.RFC3339))
+		return printIdentity(cliCtx, "Pending", pending)
+	}
+	return nil
+}
+
+func rotate(cliCtx *cli.Context) error {
+	keyFile := p2p.NetworkKeyPath(cliCtx.String(cmd.DataDirFlag.Name), cliCtx.String(cmd.P2PPrivKey.Name))
+	if !file.FileExists(keyFile) {
+		return errors.Errorf("no network key found at %s, there is nothing to rotate", keyFile)
+	}
+	key, activateAt, err := p2p.RotateNetworkKey(keyFile, cliCtx.Duration(GracePeriodFlag.Name), time.Now())
+	if err != nil {
+		return errors.Wrap(err, "could not rotate network key")
+	}
+	log.WithField("activateAt", activateAt.Format(time.RFC3339)).Info(
+		"Generated new network key, the node uses it on its first start after the grace period")
+	return printIdentity(cliCtx, "New", key)
+}
+
+func setStaticIP(cliCtx *cli.Context) error {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	a, err := p2p.LoadAdvertisement(dataDir)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		a = &p2p.Advertisement{}
+	}
+	if !cliCtx.IsSet(cmd.P2PHost.Name) && !cliCtx.IsSet(cmd.P2PTCPPort.Name) && !cliCtx.IsSet(cmd.P2PUDPPort.Name) {
+		return errors.Errorf("at least one of --%s, --%s or --%s is required", cmd.P2PHost.Name, cmd.P2PTCPPort.Name, cmd.P2PUDPPort.Name)
+	}
+	if cliCtx.IsSet(cmd.P2PHost.Name) {
+		a.IP = cliCtx.String(cmd.P2PHost.Name)
+	}
+	if cliCtx.IsSet(cmd.P2PTCPPort.Name) {
+		a.TCPPort = uint(cliCtx.Int(cmd.P2PTCPPort.Name)) // lint:ignore uintcast -- Negative ports are rejected as out of range.
+	}
+	if cliCtx.IsSet(cmd.P2PUDPPort.Name) {
+		a.UDPPort = uint(cliCtx.Int(cmd.P2PUDPPort.Name)) // lint:ignore uintcast -- Negative ports are rejected as out of range.
+	}
+	if err := p2p.SaveAdvertisement(dataDir, a); err != nil {
+		return errors.Wrap(err, "could not pin advertised address")
+	}
+	log.WithFields(logrus.Fields{
+		"ip":      a.IP,
+		"tcpPort": a.TCPPort,
+		"udpPort": a.UDPPort,
+	}).Info("Pinned advertised address, it is used from the next start of the node")
+	return nil
+}
+
+// printIdentity prints the peer ID and ENR of the given network key. The advertised address is taken from
+// the flags, then from the address pinned in the data directory.
+func printIdentity(cliCtx *cli.Context, label string, key *ecdsa.PrivateKey) error {
+	pid, err := p2p.PeerIDFromNetworkKey(key)
+	if err != nil {
+		return errors.Wrap(err, "could not derive peer ID")
+	}
+	ip, tcpPort, udpPort, err := advertisedAddress(cliCtx)
+	if err != nil {
+		return err
+	}
+	n, err := p2p.NodeRecord(key, ip, tcpPort, udpPort)
+	if err != nil {
+		return errors.Wrap(err, "could not build node record")
+	}
+	fmt.Printf("%s peer ID: %s\n", label, pid)
+	fmt.Printf("%s ENR: %s\n", label, n.String())
+	return nil
+}
+
+func advertisedAddress(cliCtx *cli.Context) (net.IP, int, int, error) {
+	ipAddr := cliCtx.String(cmd.P2PHost.Name)
+	tcpPort := cliCtx.Int(cmd.P2PTCPPort.Name)
+	udpPort := cliCtx.Int(cmd.P2PUDPPort.Name)
+	pinned, err := p2p.LoadAdvertisement(cliCtx.String(cmd.DataDirFlag.Name))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if pinned != nil {
+		if ipAddr == "" {
+			ipAddr = pinned.IP
+		}
+		if !cliCtx.IsSet(cmd.P2PTCPPort.Name) && pinned.TCPPort != 0 {
+			tcpPort = int(pinned.TCPPort)
+		}
+		if !cliCtx.IsSet(cmd.P2PUDPPort.Name) && pinned.UDPPort != 0 {
+			udpPort = int(pinned.UDPPort)
+		}
+	}
+	if ipAddr == "" {
+		ipAddr = "127.0.0.1"
+		log.Warnf("No advertised IP address given with --%s, the exported ENR uses %s", cmd.P2PHost.Name, ipAddr)
+	}
+	ip := net.ParseIP(ipAddr)
+	if ip == nil {
+		return nil, 0, 0, errors.Errorf("invalid IP address %q", ipAddr)
+	}
+	return ip, tcpPort, udpPort, nil
+}
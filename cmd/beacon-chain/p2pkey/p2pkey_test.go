@@ -0,0 +1,75 @@
+package p2pkey
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/urfave/cli/v2"
+)
+
+func cliContext(t *testing.T, dataDir string, args map[string]string) *cli.Context {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dataDir, "")
+	set.String(cmd.P2PPrivKey.Name, "", "")
+	set.String(cmd.P2PHost.Name, "", "")
+	set.Int(cmd.P2PTCPPort.Name, 13000, "")
+	set.Int(cmd.P2PUDPPort.Name, 12000, "")
+	set.Duration(GracePeriodFlag.Name, time.Hour, "")
+	for k, v := range args {
+		require.NoError(t, set.Set(k, v))
+	}
+	return cli.NewContext(&app, set, nil)
+}
+
+func TestSetStaticIP(t *testing.T) {
+	dir := t.TempDir()
+	assert.ErrorContains(t, "at least one of", setStaticIP(cliContext(t, dir, nil)))
+
+	require.NoError(t, setStaticIP(cliContext(t, dir, map[string]string{cmd.P2PHost.Name: "192.0.2.1"})))
+	require.NoError(t, setStaticIP(cliContext(t, dir, map[string]string{cmd.P2PTCPPort.Name: "9000"})))
+	a, err := p2p.LoadAdvertisement(dir)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &p2p.Advertisement{IP: "192.0.2.1", TCPPort: 9000}, a)
+
+	ip, tcpPort, udpPort, err := advertisedAddress(cliContext(t, dir, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.1", ip.String())
+	assert.Equal(t, 9000, tcpPort)
+	assert.Equal(t, 12000, udpPort)
+
+	// Flags take precedence over the pinned address.
+	ip, tcpPort, _, err = advertisedAddress(cliContext(t, dir, map[string]string{cmd.P2PHost.Name: "192.0.2.2", cmd.P2PTCPPort.Name: "9001"}))
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.2", ip.String())
+	assert.Equal(t, 9001, tcpPort)
+
+	assert.ErrorContains(t, "invalid IP address", setStaticIP(cliContext(t, dir, map[string]string{cmd.P2PHost.Name: "foo"})))
+}
+
+func TestExportAndRotate(t *testing.T) {
+	dir := t.TempDir()
+	ctx := cliContext(t, dir, nil)
+	assert.ErrorContains(t, "no network key found", export(ctx))
+	assert.ErrorContains(t, "nothing to rotate", rotate(ctx))
+
+	current, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keyFile := p2p.NetworkKeyPath(dir, "")
+	require.NoError(t, p2p.SaveNetworkKey(keyFile, current))
+	require.NoError(t, export(ctx))
+
+	require.NoError(t, rotate(ctx))
+	pending, activateAt, err := p2p.PendingNetworkKey(keyFile)
+	require.NoError(t, err)
+	require.NotNil(t, pending)
+	assert.Equal(t, true, activateAt.After(time.Now().Add(59*time.Minute)))
+	assert.Equal(t, false, pending.Equal(current))
+	require.NoError(t, export(ctx))
+}
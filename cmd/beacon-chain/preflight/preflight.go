@@ -0,0 +1,415 @@
+// Package preflight defines the preflight command, which checks that a beacon node can start with the given
+// flags without starting any of its services, for example from an init container.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	stategenesis "github.com/prysmaticlabs/prysm/beacon-chain/state/genesis"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/sync/genesis"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/io/file"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var log = logrus.WithField("prefix", "preflight")
+
+const (
+	// endpointTimeout bounds the time waited for the execution endpoint to answer.
+	endpointTimeout = 5 * time.Second
+	// maxClockOffset is the largest difference tolerated between the local clock and the clock of the
+	// execution endpoint. The reference clock has a one second resolution.
+	maxClockOffset = 2 * time.Second
+)
+
+// status is the outcome of a preflight check.
+type status string
+
+const (
+	statusOK   status = "OK"
+	statusWarn status = "WARN"
+	statusFail status = "FAIL"
+)
+
+// result is the outcome of a preflight check, along with what to do about it when it did not pass.
+type result struct {
+	check  string
+	status status
+	detail string
+}
+
+// checker runs the preflight checks, keeping what later checks need from the earlier ones.
+type checker struct {
+	cliCtx    *cli.Context
+	ctx       context.Context
+	nodeFlags []cli.Flag
+	// genesisTime is the genesis time of the network, zero when unknown.
+	genesisTime time.Time
+	// referenceTime is the time reported by the execution endpoint, zero when unknown.
+	referenceTime time.Time
+	results       []result
+}
+
+// Command returns the preflight command. It accepts the given flags of the beacon node, so that it can be run
+// with the exact same flags as the node it checks.
+func Command(nodeFlags []cli.Flag) *cli.Command {
+	return &cli.Command{
+		Name:     "preflight",
+		Category: "preflight",
+		Usage:    "checks that the beacon node can start with the given flags, without starting it",
+		Description: `validates the data directory and database, the configuration files, the availability of the ports, ` +
+			`the reachability of the execution endpoint, the presence of a genesis state and the system clock, then ` +
+			`prints a report. It exits with an error when any check fails.`,
+		Flags: nodeFlags,
+		Action: func(cliCtx *cli.Context) error {
+			return run(cliCtx, nodeFlags)
+		},
+	}
+}
+
+func run(cliCtx *cli.Context, nodeFlags []cli.Flag) error {
+	results := runChecks(cliCtx, nodeFlags)
+	if err := printReport(cliCtx.App.Writer, results); err != nil {
+		return err
+	}
+	failed := 0
+	for _, r := range results {
+		if r.status == statusFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d preflight check(s) failed", failed)
+	}
+	return nil
+}
+
+// runChecks runs every preflight check in order.
+func runChecks(cliCtx *cli.Context, nodeFlags []cli.Flag) []result {
+	c := &checker{cliCtx: cliCtx, ctx: cliCtx.Context, nodeFlags: nodeFlags}
+	if c.ctx == nil {
+		c.ctx = context.Background()
+	}
+	c.checkConfigFile()
+	c.checkChainConfig()
+	c.checkDataDir()
+	c.checkDatabase()
+	c.checkPorts()
+	c.checkExecutionEndpoint()
+	c.checkClock()
+	return c.results
+}
+
+func (c *checker) add(check string, s status, format string, args ...interface{}) {
+	c.results = append(c.results, result{check: check, status: s, detail: fmt.Sprintf(format, args...)})
+}
+
+// checkConfigFile loads the flag values of the configuration file, if any.
+func (c *checker) checkConfigFile() {
+	const check = "config file"
+	if !c.cliCtx.IsSet(cmd.ConfigFileFlag.Name) {
+		c.add(check, statusOK, "no --%s given", cmd.ConfigFileFlag.Name)
+		return
+	}
+	path := c.cliCtx.String(cmd.ConfigFileFlag.Name)
+	if err := cmd.LoadFlagsFromConfig(c.cliCtx, c.nodeFlags); err != nil {
+		c.add(check, statusFail, "could not load %s: %v. Fix the file, which must be a YAML map of flag names to values", path, err)
+		return
+	}
+	c.add(check, statusOK, "loaded %s", path)
+}
+
+// checkChainConfig selects the network and loads the chain configuration file, if any.
+func (c *checker) checkChainConfig() {
+	const check = "chain config"
+	if err := features.ConfigureBeaconChain(c.cliCtx); err != nil {
+		c.add(check, statusFail, "could not configure the network: %v", err)
+		return
+	}
+	if !c.cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		c.add(check, statusOK, "using the %s network configuration", params.BeaconConfig().ConfigName)
+		return
+	}
+	path := c.cliCtx.String(cmd.ChainConfigFileFlag.Name)
+	if err := params.LoadChainConfigFile(path, nil); err != nil {
+		c.add(check, statusFail, "could not load %s: %v. Check the file against the configuration of the network", path, err)
+		return
+	}
+	c.add(check, statusOK, "loaded %s for the %s network", path, params.BeaconConfig().ConfigName)
+}
+
+// checkDataDir checks that the data directory can be written to.
+func (c *checker) checkDataDir() {
+	const check = "data directory"
+	dir := c.cliCtx.String(cmd.DataDirFlag.Name)
+	hasDir, err := file.HasDir(dir)
+	if err != nil {
+		c.add(check, statusFail, "could not access %s: %v. Check the permissions of its parent directories", dir, err)
+		return
+	}
+	if !hasDir {
+		c.add(check, statusWarn, "%s does not exist and is created on start. Check --%s if the node was expected to reuse existing data",
+			dir, cmd.DataDirFlag.Name)
+		return
+	}
+	f, err := os.CreateTemp(dir, ".preflight-*")
+	if err != nil {
+		c.add(check, statusFail, "%s is not writable: %v. Give the user running the node write access to it", dir, err)
+		return
+	}
+	if err := f.Close(); err != nil {
+		c.add(check, statusFail, "could not write to %s: %v", dir, err)
+		return
+	}
+	if err := os.Remove(f.Name()); err != nil {
+		c.add(check, statusWarn, "could not remove %s: %v", f.Name(), err)
+		return
+	}
+	c.add(check, statusOK, "%s is writable", dir)
+}
+
+// checkDatabase opens the database read-only, checks that its schema is supported and looks for the
+// genesis state.
+func (c *checker) checkDatabase() {
+	const check = "database"
+	dbPath := filepath.Join(c.cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	var st state.BeaconState
+	if !file.FileExists(kv.KVStoreDatafilePath(dbPath)) {
+		c.add(check, statusOK, "no database at %s yet, a new one is created on start", dbPath)
+	} else if d, err := kv.NewKVStore(c.ctx, dbPath, &kv.Config{ReadOnly: true}); err != nil {
+		c.add(check, statusFail, "could not open the database at %s: %v. Stop any node using it, or restore it from a backup "+
+			"if it is corrupted", dbPath, err)
+	} else {
+		st = c.checkSchema(d, dbPath)
+		if err := d.Close(); err != nil {
+			c.add(check, statusWarn, "could not close the database: %v", err)
+		}
+	}
+	c.checkGenesis(st)
+}
+
+// checkSchema checks that the schema of an open database is supported, and returns its genesis state if any.
+func (c *checker) checkSchema(d *kv.Store, dbPath string) state.BeaconState {
+	const check = "database"
+	completed, err := d.CompletedMigrations(c.ctx)
+	if err != nil {
+		c.add(check, statusFail, "could not read the schema version: %v", err)
+		return nil
+	}
+	unknown, err := d.UnknownMigrations(c.ctx)
+	if err != nil {
+		c.add(check, statusFail, "could not read the schema version: %v", err)
+		return nil
+	}
+	if len(unknown) > 0 {
+		c.add(check, statusFail, "the database at %s was upgraded by a more recent version (migrations %s). "+
+			"Run a version at least as recent, or resync with a new data directory", dbPath, strings.Join(unknown, ", "))
+	} else {
+		c.add(check, statusOK, "opened %s, %d migration(s) applied", dbPath, len(completed))
+	}
+	st, err := d.GenesisState(c.ctx)
+	if err != nil {
+		c.add("genesis state", statusFail, "could not read the genesis state of the database: %v", err)
+		return nil
+	}
+	if st == nil || st.IsNil() {
+		return nil
+	}
+	return st
+}
+
+// checkGenesis checks that a genesis state is available, from the database, the flags or the ones embedded
+// in the binary.
+func (c *checker) checkGenesis(dbState state.BeaconState) {
+	const check = "genesis state"
+	if dbState != nil {
+		c.genesisTime = time.Unix(int64(dbState.GenesisTime()), 0)
+		c.add(check, statusOK, "found in the database")
+		return
+	}
+	if path := c.cliCtx.Path(genesis.StatePath.Name); path != "" {
+		if !file.FileExists(path) {
+			c.add(check, statusFail, "%s given with --%s does not exist", path, genesis.StatePath.Name)
+			return
+		}
+		c.add(check, statusOK, "loaded from %s on start", path)
+		return
+	}
+	if u := c.cliCtx.String(genesis.BeaconAPIURL.Name); u != "" {
+		c.add(check, statusOK, "fetched from %s on start", u)
+		return
+	}
+	st, err := stategenesis.State(params.BeaconConfig().ConfigName)
+	if err != nil {
+		c.add(check, statusFail, "could not load the embedded genesis state: %v", err)
+		return
+	}
+	if st == nil {
+		c.add(check, statusFail, "no genesis state for the %s network. Provide one with --%s or --%s",
+			params.BeaconConfig().ConfigName, genesis.StatePath.Name, genesis.BeaconAPIURL.Name)
+		return
+	}
+	c.genesisTime = time.Unix(int64(st.GenesisTime()), 0)
+	c.add(check, statusOK, "embedded genesis state of the %s network", params.BeaconConfig().ConfigName)
+}
+
+// checkPorts checks that the ports the node listens on are free.
+func (c *checker) checkPorts() {
+	type listener struct {
+		flag    string
+		network string
+		host    string
+		port    int
+	}
+	listeners := []listener{
+		{cmd.P2PTCPPort.Name, "tcp", c.cliCtx.String(cmd.P2PIP.Name), c.cliCtx.Int(cmd.P2PTCPPort.Name)},
+		{cmd.P2PUDPPort.Name, "udp", c.cliCtx.String(cmd.P2PIP.Name), c.cliCtx.Int(cmd.P2PUDPPort.Name)},
+		{flags.RPCPort.Name, "tcp", c.cliCtx.String(flags.RPCHost.Name), c.cliCtx.Int(flags.RPCPort.Name)},
+		{flags.MonitoringPortFlag.Name, "tcp", c.cliCtx.String(cmd.MonitoringHostFlag.Name), c.cliCtx.Int(flags.MonitoringPortFlag.Name)},
+	}
+	if !c.cliCtx.Bool(flags.DisableGRPCGateway.Name) {
+		listeners = append(listeners, listener{
+			flags.GRPCGatewayPort.Name, "tcp", c.cliCtx.String(flags.GRPCGatewayHost.Name), c.cliCtx.Int(flags.GRPCGatewayPort.Name),
+		})
+	}
+	for _, l := range listeners {
+		check := fmt.Sprintf("port %s", l.flag)
+		addr := net.JoinHostPort(l.host, strconv.Itoa(l.port))
+		if err := portAvailable(l.network, addr); err != nil {
+			c.add(check, statusFail, "cannot listen on %s/%s: %v. Stop the process using it or change --%s", addr, l.network, err, l.flag)
+			continue
+		}
+		c.add(check, statusOK, "%s/%s is available", addr, l.network)
+	}
+}
+
+// portAvailable returns an error if the given address can't be listened on.
+func portAvailable(network, addr string) error {
+	if network == "udp" {
+		conn, err := net.ListenPacket(network, addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	l, err := net.Listen(network, addr)
+	if err != nil {
+		return err
+	}
+	return l.Close()
+}
+
+// checkExecutionEndpoint checks that the execution endpoint answers HTTP requests, and keeps the time it
+// reports to check the local clock.
+func (c *checker) checkExecutionEndpoint() {
+	const check = "execution endpoint"
+	endpoint := c.cliCtx.String(flags.HTTPWeb3ProviderFlag.Name)
+	if endpoint == "" {
+		c.add(check, statusFail, "no endpoint given. Set --%s", flags.HTTPWeb3ProviderFlag.Name)
+		return
+	}
+	// The endpoint may be followed by an authorization header.
+	endpoint = strings.TrimSpace(strings.Split(endpoint, ",")[0])
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		if file.FileExists(endpoint) {
+			c.add(check, statusOK, "IPC endpoint %s exists", endpoint)
+			return
+		}
+		c.add(check, statusFail, "%s is neither an http(s) URL nor an existing IPC path. Check --%s",
+			endpoint, flags.HTTPWeb3ProviderFlag.Name)
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, endpointTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(),
+		strings.NewReader(`{"jsonrpc":"2.0","method":"eth_chainId","params":[],"id":1}`))
+	if err != nil {
+		c.add(check, statusFail, "could not build a request to %s: %v", u.Redacted(), err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.add(check, statusFail, "%s is unreachable: %v. Check that the execution client is running and listening on this address",
+			u.Redacted(), err)
+		return
+	}
+	defer func() {
+		if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+			log.WithError(err).Debug("Could not read response body")
+		}
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		c.referenceTime = date
+	}
+	// The engine API answers unauthenticated requests with 401, which still proves that it is reachable.
+	if resp.StatusCode == http.StatusUnauthorized && !c.cliCtx.IsSet(flags.ExecutionJWTSecretFlag.Name) {
+		c.add(check, statusWarn, "%s requires authentication. Set --%s to the JWT secret shared with the execution client",
+			u.Redacted(), flags.ExecutionJWTSecretFlag.Name)
+		return
+	}
+	c.add(check, statusOK, "%s answered with HTTP status %d", u.Redacted(), resp.StatusCode)
+}
+
+// checkClock checks the local clock against the clock of the execution endpoint and the genesis time.
+func (c *checker) checkClock() {
+	const check = "system clock"
+	now := prysmTime.Now()
+	if !c.genesisTime.IsZero() && now.Before(c.genesisTime) {
+		c.add(check, statusWarn, "the local time %s is before the genesis time %s. Check the clock if the network has launched",
+			now.UTC().Format(time.RFC3339), c.genesisTime.UTC().Format(time.RFC3339))
+		return
+	}
+	if c.referenceTime.IsZero() {
+		c.add(check, statusWarn, "could not compare the local clock with a reference clock. Make sure it is synchronized with NTP")
+		return
+	}
+	offset := now.Sub(c.referenceTime)
+	if offset < 0 {
+		offset = -offset
+	}
+	// The reference time is truncated to the second.
+	if offset > maxClockOffset+time.Second {
+		c.add(check, statusFail, "the local clock is %s away from the clock of the execution endpoint. "+
+			"Synchronize the clock with NTP", offset.Truncate(time.Millisecond))
+		return
+	}
+	c.add(check, statusOK, "within %s of the clock of the execution endpoint", maxClockOffset)
+}
+
+// printReport writes the results as a table.
+func printReport(w io.Writer, results []result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "CHECK\tSTATUS\tDETAIL"); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", r.check, r.status, r.detail); err != nil {
+			return err
+		}
+	}
+	return errors.Wrap(tw.Flush(), "could not print the preflight report")
+}
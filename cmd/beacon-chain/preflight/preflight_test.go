@@ -0,0 +1,199 @@
+package preflight
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/urfave/cli/v2"
+)
+
+var testFlags = cmd.WrapFlags([]cli.Flag{
+	cmd.DataDirFlag,
+	cmd.ConfigFileFlag,
+	cmd.ChainConfigFileFlag,
+	cmd.P2PIP,
+	cmd.P2PTCPPort,
+	cmd.P2PUDPPort,
+	cmd.MonitoringHostFlag,
+	flags.RPCHost,
+	flags.RPCPort,
+	flags.MonitoringPortFlag,
+	flags.GRPCGatewayHost,
+	flags.GRPCGatewayPort,
+	flags.DisableGRPCGateway,
+	flags.HTTPWeb3ProviderFlag,
+	flags.ExecutionJWTSecretFlag,
+})
+
+// freePort returns a port which is free at the time of the call.
+func freePort(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+	return strconv.Itoa(port)
+}
+
+// cliContext returns a context with free local ports and the given execution endpoint, overridden by args.
+// Flags given an empty value are left unset.
+func cliContext(t *testing.T, endpoint string, args map[string]string) *cli.Context {
+	set := flag.NewFlagSet("test", 0)
+	for _, f := range testFlags {
+		require.NoError(t, f.Apply(set))
+	}
+	values := map[string]string{
+		cmd.DataDirFlag.Name:              t.TempDir(),
+		cmd.P2PIP.Name:                    "127.0.0.1",
+		cmd.P2PTCPPort.Name:               freePort(t),
+		cmd.P2PUDPPort.Name:               freePort(t),
+		cmd.MonitoringHostFlag.Name:       "127.0.0.1",
+		flags.RPCPort.Name:                freePort(t),
+		flags.MonitoringPortFlag.Name:     freePort(t),
+		flags.GRPCGatewayPort.Name:        freePort(t),
+		flags.HTTPWeb3ProviderFlag.Name:   endpoint,
+		flags.ExecutionJWTSecretFlag.Name: "jwt.hex",
+	}
+	for k, v := range args {
+		values[k] = v
+	}
+	for k, v := range values {
+		if v == "" {
+			continue
+		}
+		require.NoError(t, set.Set(k, v))
+	}
+	app := &cli.App{Writer: new(bytes.Buffer)}
+	return cli.NewContext(app, set, nil)
+}
+
+func executionEndpoint(t *testing.T, handler http.HandlerFunc) string {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// statuses returns the status of each check, by check name.
+func statuses(results []result) map[string]status {
+	m := make(map[string]status, len(results))
+	for _, r := range results {
+		m[r.check] = r.status
+	}
+	return m
+}
+
+func TestRunChecks_OK(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	endpoint := executionEndpoint(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	cliCtx := cliContext(t, endpoint, nil)
+	require.NoError(t, run(cliCtx, testFlags))
+
+	results := runChecks(cliCtx, testFlags)
+	for _, r := range results {
+		assert.Equal(t, statusOK, r.status, "%s: %s", r.check, r.detail)
+	}
+	s := statuses(results)
+	for _, check := range []string{"config file", "chain config", "data directory", "database", "genesis state",
+		"port p2p-tcp-port", "port p2p-udp-port", "port rpc-port", "port monitoring-port", "port grpc-gateway-port",
+		"execution endpoint", "system clock"} {
+		_, ok := s[check]
+		assert.Equal(t, true, ok, "missing check %s", check)
+	}
+	assert.Equal(t, true, strings.Contains(cliCtx.App.Writer.(*bytes.Buffer).String(), "execution endpoint"))
+}
+
+func TestRunChecks_Database(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	endpoint := executionEndpoint(t, func(w http.ResponseWriter, _ *http.Request) {})
+	dataDir := t.TempDir()
+	d, err := kv.NewKVStore(context.Background(), filepath.Join(dataDir, kv.BeaconNodeDbDirName), &kv.Config{})
+	require.NoError(t, err)
+	require.NoError(t, d.RunMigrations(context.Background()))
+
+	// The database can't be opened while another process has it open for writing.
+	results := runChecks(cliContext(t, endpoint, map[string]string{cmd.DataDirFlag.Name: dataDir}), testFlags)
+	assert.Equal(t, statusFail, statuses(results)["database"])
+
+	require.NoError(t, d.Close())
+	results = runChecks(cliContext(t, endpoint, map[string]string{cmd.DataDirFlag.Name: dataDir}), testFlags)
+	assert.Equal(t, statusOK, statuses(results)["database"])
+	assert.Equal(t, statusOK, statuses(results)["genesis state"])
+}
+
+func TestRunChecks_Failures(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	endpoint := executionEndpoint(t, func(w http.ResponseWriter, _ *http.Request) {})
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, l.Close())
+	}()
+	busyPort := strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("not: [valid"), 0600))
+
+	cliCtx := cliContext(t, endpoint, map[string]string{
+		flags.RPCPort.Name:            busyPort,
+		cmd.ConfigFileFlag.Name:       configFile,
+		flags.DisableGRPCGateway.Name: "true",
+	})
+	results := runChecks(cliCtx, testFlags)
+	s := statuses(results)
+	assert.Equal(t, statusFail, s["config file"])
+	assert.Equal(t, statusFail, s["port rpc-port"])
+	assert.Equal(t, statusOK, s["port monitoring-port"])
+	_, ok := s["port grpc-gateway-port"]
+	assert.Equal(t, false, ok)
+	assert.ErrorContains(t, "2 preflight check(s) failed", run(cliCtx, testFlags))
+}
+
+func TestCheckExecutionEndpoint(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+
+	// An unreachable endpoint leaves the clock unchecked.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	srv.Close()
+	s := statuses(runChecks(cliContext(t, srv.URL, nil), testFlags))
+	assert.Equal(t, statusFail, s["execution endpoint"])
+	assert.Equal(t, statusWarn, s["system clock"])
+
+	// The engine API requires a JWT secret.
+	endpoint := executionEndpoint(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	s = statuses(runChecks(cliContext(t, endpoint, map[string]string{flags.ExecutionJWTSecretFlag.Name: ""}), testFlags))
+	assert.Equal(t, statusWarn, s["execution endpoint"])
+	s = statuses(runChecks(cliContext(t, endpoint+",Bearer xxx", nil), testFlags))
+	assert.Equal(t, statusOK, s["execution endpoint"])
+
+	assert.Equal(t, statusFail, statuses(runChecks(cliContext(t, "/no/such/ipc", nil), testFlags))["execution endpoint"])
+}
+
+func TestCheckClock(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	endpoint := executionEndpoint(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Date", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	})
+	s := statuses(runChecks(cliContext(t, endpoint, nil), testFlags))
+	assert.Equal(t, statusOK, s["execution endpoint"])
+	assert.Equal(t, statusFail, s["system clock"])
+}
@@ -64,6 +64,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.BackupWebhookOutputDir,
 			cmd.EnableBackupWebhookFlag,
 			flags.MonitoringPortFlag,
+			flags.EnableDebugUI,
 			cmd.DisableMonitoringFlag,
 			cmd.MaxGoroutines,
 			cmd.ForceClearDB,
@@ -108,6 +109,11 @@ var appHelpFlagGroups = []flagGroup{
 			flags.GRPCGatewayHost,
 			flags.GRPCGatewayPort,
 			flags.GPRCGatewayCorsDomain,
+			flags.GRPCGatewayCorsDisableCredentials,
+			flags.GRPCNamespaces,
+			flags.HTTPNamespaces,
+			flags.APIAuthNamespaces,
+			flags.APIAuthTokenFile,
 			flags.HTTPWeb3ProviderFlag,
 			flags.ExecutionJWTSecretFlag,
 			flags.FallbackWeb3ProviderFlag,
@@ -115,17 +121,47 @@ var appHelpFlagGroups = []flagGroup{
 			flags.HeadSync,
 			flags.DisableSync,
 			flags.SlotsPerArchivedPoint,
+			flags.HotStateCacheSize,
+			flags.RegenMemoryCap,
+			flags.HotStateMemoryCap,
+			flags.BlocksRetentionEpochs,
+			flags.EpochBoundaryBranches,
 			flags.DisableDiscv5,
+			flags.GossipScoringPolicyFile,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
+			flags.RPCBlocksByRootRateLimit,
+			flags.RPCStatusRateLimit,
+			flags.RPCMetadataRateLimit,
+			flags.RPCRateLimitMaxViolations,
+			flags.GossipAttestationRateLimit,
+			flags.GossipSyncCommitteeRateLimit,
+			flags.BlockBatchFanOutPeers,
 			flags.EnableDebugRPCEndpoints,
+			flags.RPCSlowRequestThreshold,
+			flags.MaxHeadStalenessSlots,
+			flags.HistoricalStateReconstructionBudget,
+			flags.HistoricalStateCacheSize,
+			flags.AttestationAggregationOffset,
+			flags.ReadReplica,
+			flags.DBPath,
 			flags.SubscribeToAllSubnets,
+			flags.NodeRole,
+			flags.EnableStateSyncServing,
+			flags.LightClientServer,
+			flags.TelemetryEndpoint,
+			flags.TelemetryInterval,
 			flags.HistoricalSlasherNode,
 			flags.ChainID,
 			flags.NetworkID,
 			flags.WeakSubjectivityCheckpoint,
 			flags.Eth1HeaderReqLimit,
 			flags.MinPeersPerSubnet,
+			flags.ForkTopicsLeadEpochs,
+			flags.ForkTopicsRetentionEpochs,
+			flags.GossipJournalDir,
+			flags.GossipJournalMaxSize,
+			flags.SyncCommitteeMessageGracePeriod,
 			flags.MevRelayEndpoint,
 			checkpoint.BlockPath,
 			checkpoint.StatePath,
@@ -154,6 +190,9 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PMetadata,
 			cmd.P2PAllowList,
 			cmd.P2PDenyList,
+			cmd.P2PFilterFile,
+			cmd.P2PInboundDialRate,
+			cmd.P2PInboundDialBurst,
 			cmd.StaticPeers,
 			cmd.EnableUPnPFlag,
 			flags.MinSyncPeers,
@@ -171,6 +171,25 @@ var (
 			"192.168.0.0/16 would deny connections from peers on your local network only. The " +
 			"default is to accept all connections.",
 	}
+	// P2PFilterFile defines a YAML file with CIDR subnets to allow or deny connections with.
+	P2PFilterFile = &cli.StringFlag{
+		Name: "p2p-filter-file",
+		Usage: "Path to a YAML file with allowlist and denylist entries of CIDR subnets for peer connections, " +
+			"applied in addition to --p2p-allowlist and --p2p-denylist. The file is read again when the " +
+			"configuration is reloaded.",
+	}
+	// P2PInboundDialRate defines the rate of inbound dials accepted from a single IP address.
+	P2PInboundDialRate = &cli.IntFlag{
+		Name:  "p2p-inbound-dial-rate",
+		Usage: "The number of inbound dials per second accepted from a single IP address, beyond the burst.",
+		Value: 4,
+	}
+	// P2PInboundDialBurst defines the number of inbound dials accepted at once from a single IP address.
+	P2PInboundDialBurst = &cli.IntFlag{
+		Name:  "p2p-inbound-dial-burst",
+		Usage: "The number of inbound dials accepted at once from a single IP address before they are rate limited.",
+		Value: 8,
+	}
 	// ForceClearDB removes any previously stored data at the data directory.
 	ForceClearDB = &cli.BoolFlag{
 		Name:  "force-clear-db",
@@ -0,0 +1,13 @@
+package benchmark
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "benchmark",
+		Usage: "commands for benchmarking the implementations of the cryptographic primitives used by Prysm",
+		Subcommands: []*cli.Command{
+			blsCmd,
+		},
+	},
+}
@@ -0,0 +1,167 @@
+package benchmark
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/urfave/cli/v2"
+)
+
+var blsFlags = struct {
+	Backends      cli.StringSlice
+	Iterations    int
+	AggregateSize int
+	BatchSize     int
+}{}
+
+var blsCmd = &cli.Command{
+	Name:   "bls",
+	Usage:  "Benchmark signing, verification, aggregation and batch verification of BLS signatures across backends.",
+	Action: cliActionBLS,
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:        "backends",
+			Usage:       fmt.Sprintf("BLS backends to benchmark, among: %s. Defaults to all of them", strings.Join(bls.Backends(), ", ")),
+			Destination: &blsFlags.Backends,
+		},
+		&cli.IntFlag{
+			Name:        "iterations",
+			Usage:       "number of times each operation is run",
+			Destination: &blsFlags.Iterations,
+			Value:       100,
+		},
+		&cli.IntFlag{
+			Name:        "aggregate-size",
+			Usage:       "number of signatures aggregated, and of public keys verified against the aggregate",
+			Destination: &blsFlags.AggregateSize,
+			Value:       128,
+		},
+		&cli.IntFlag{
+			Name:        "batch-size",
+			Usage:       "number of signatures over distinct messages verified in a single batch",
+			Destination: &blsFlags.BatchSize,
+			Value:       64,
+		},
+	},
+}
+
+// blsOperation is a benchmarked operation, run is called once per iteration.
+type blsOperation struct {
+	name string
+	run  func() error
+}
+
+func cliActionBLS(_ *cli.Context) error {
+	f := blsFlags
+	if f.Iterations <= 0 || f.AggregateSize <= 0 || f.BatchSize <= 0 {
+		return errors.New("--iterations, --aggregate-size and --batch-size must be positive")
+	}
+	backends := f.Backends.Value()
+	if len(backends) == 0 {
+		backends = bls.Backends()
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "BACKEND\tOPERATION\tITERATIONS\tTIME/OP\tOPS/S"); err != nil {
+		return err
+	}
+	for _, backend := range backends {
+		if err := bls.SetBackend(backend); err != nil {
+			return err
+		}
+		ops, err := blsOperations(f.AggregateSize, f.BatchSize)
+		if err != nil {
+			return errors.Wrapf(err, "could not prepare the %s benchmark", backend)
+		}
+		for _, op := range ops {
+			start := time.Now()
+			for i := 0; i < f.Iterations; i++ {
+				if err := op.run(); err != nil {
+					return errors.Wrapf(err, "%s %s failed", backend, op.name)
+				}
+			}
+			perOp := time.Since(start) / time.Duration(f.Iterations)
+			if _, err := fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%.1f\n", backend, op.name, f.Iterations, perOp, float64(time.Second)/float64(perOp)); err != nil {
+				return err
+			}
+		}
+	}
+	return w.Flush()
+}
+
+// blsOperations generates the keys and signatures used by the operations with the backend in use, so
+// that their generation is not part of the measurements.
+func blsOperations(aggregateSize, batchSize int) ([]blsOperation, error) {
+	size := aggregateSize
+	if batchSize > size {
+		size = batchSize
+	}
+	msg := [32]byte{'p', 'r', 'y', 's', 'm'}
+	keys := make([]bls.SecretKey, size)
+	pubs := make([]bls.PublicKey, size)
+	sigs := make([]bls.Signature, size)
+	msgs := make([][32]byte, size)
+	batchSigs := make([][]byte, size)
+	for i := 0; i < size; i++ {
+		sk, err := bls.RandKey()
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = sk
+		pubs[i] = sk.PublicKey()
+		sigs[i] = sk.Sign(msg[:])
+		copy(msgs[i][:], fmt.Sprintf("message %d", i))
+		batchSigs[i] = sk.Sign(msgs[i][:]).Marshal()
+	}
+	aggSig := bls.AggregateSignatures(sigs[:aggregateSize])
+
+	return []blsOperation{
+		{
+			name: "sign",
+			run: func() error {
+				keys[0].Sign(msg[:])
+				return nil
+			},
+		},
+		{
+			name: "verify",
+			run: func() error {
+				return verified(sigs[0].Verify(pubs[0], msg[:]))
+			},
+		},
+		{
+			name: fmt.Sprintf("aggregate-%d", aggregateSize),
+			run: func() error {
+				bls.AggregateSignatures(sigs[:aggregateSize])
+				return nil
+			},
+		},
+		{
+			name: fmt.Sprintf("fast-aggregate-verify-%d", aggregateSize),
+			run: func() error {
+				return verified(aggSig.FastAggregateVerify(pubs[:aggregateSize], msg))
+			},
+		},
+		{
+			name: fmt.Sprintf("batch-verify-%d", batchSize),
+			run: func() error {
+				valid, err := bls.VerifyMultipleSignatures(batchSigs[:batchSize], msgs[:batchSize], pubs[:batchSize])
+				if err != nil {
+					return err
+				}
+				return verified(valid)
+			},
+		},
+	}, nil
+}
+
+func verified(valid bool) error {
+	if !valid {
+		return errors.New("signature did not verify")
+	}
+	return nil
+}
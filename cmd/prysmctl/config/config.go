@@ -0,0 +1,13 @@
+package config
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "config",
+		Usage: "commands for inspecting the chain config",
+		Subcommands: []*cli.Command{
+			diffCmd,
+		},
+	},
+}
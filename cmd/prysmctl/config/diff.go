@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/network/forks"
+	"github.com/urfave/cli/v2"
+)
+
+var diffFlags = struct {
+	ENR                   string
+	Network               string
+	ChainConfigFile       string
+	GenesisValidatorsRoot string
+}{}
+
+var diffCmd = &cli.Command{
+	Name:   "diff",
+	Usage:  "Compare the fork data advertised in the ENR of a peer with the fork schedule of a local chain config.",
+	Action: cliActionDiff,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "enr",
+			Usage:       "ENR of the peer, ex: enr:-Iu4QG...",
+			Destination: &diffFlags.ENR,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "network",
+			Usage:       "name of the known network config to compare against, ex: mainnet, prater. Ignored when --chain-config-file is set",
+			Destination: &diffFlags.Network,
+			Value:       params.MainnetName,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to the yaml chain config of a custom network to compare against",
+			Destination: &diffFlags.ChainConfigFile,
+		},
+		&cli.StringFlag{
+			Name:        "genesis-validators-root",
+			Usage:       "hex encoded genesis validators root of the local network, required to compare fork digests",
+			Destination: &diffFlags.GenesisValidatorsRoot,
+		},
+	},
+}
+
+func cliActionDiff(_ *cli.Context) error {
+	f := diffFlags
+
+	node, err := enode.Parse(enode.ValidSchemes, f.ENR)
+	if err != nil {
+		return errors.Wrap(err, "could not parse ENR")
+	}
+	peerFork, err := p2p.ForkEntry(node.Record())
+	if err != nil {
+		return errors.Wrap(err, "could not read eth2 field of ENR")
+	}
+
+	var cfg *params.BeaconChainConfig
+	if f.ChainConfigFile != "" {
+		cfg, err = params.UnmarshalConfigFile(f.ChainConfigFile, nil)
+		if err != nil {
+			return err
+		}
+		cfg.InitializeForkSchedule()
+	} else {
+		cfg, err = params.ByName(f.Network)
+		if err != nil {
+			return errors.Wrapf(err, "unknown network %s", f.Network)
+		}
+	}
+
+	var gvr []byte
+	if f.GenesisValidatorsRoot != "" {
+		gvr, err = hexutil.Decode(f.GenesisValidatorsRoot)
+		if err != nil {
+			return errors.Wrap(err, "could not decode genesis validators root")
+		}
+		if len(gvr) != 32 {
+			return fmt.Errorf("genesis validators root must be 32 bytes, got %d", len(gvr))
+		}
+	}
+
+	fmt.Printf("Peer fork data: current fork digest=%#x, next fork version=%#x, next fork epoch=%d\n",
+		peerFork.CurrentForkDigest, peerFork.NextForkVersion, peerFork.NextForkEpoch)
+	diffs, err := forks.DiffENRForkID(cfg, peerFork, gvr)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		fmt.Printf("No difference found with the fork schedule of the %s config\n", cfg.ConfigName)
+		return nil
+	}
+	fmt.Printf("Differences with the fork schedule of the %s config:\n", cfg.ConfigName)
+	for _, d := range diffs {
+		fmt.Printf("  - %s\n", d)
+	}
+	return nil
+}
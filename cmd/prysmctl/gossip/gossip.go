@@ -0,0 +1,13 @@
+package gossip
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "gossip",
+		Usage: "commands for inspecting gossip messages recorded by a beacon node",
+		Subcommands: []*cli.Command{
+			replayCmd,
+		},
+	},
+}
@@ -0,0 +1,100 @@
+package gossip
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+)
+
+var replayFlags = struct {
+	JournalDir     string
+	BeaconNodeHost string
+	Timeout        time.Duration
+}{}
+
+var replayCmd = &cli.Command{
+	Name:   "replay",
+	Usage:  "Replay the gossip messages of a journal written with --gossip-journal-dir through the validators of a beacon node, and report the messages whose verdict changed.",
+	Action: cliActionReplay,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "journal-dir",
+			Usage:       "directory of the gossip journal to replay",
+			Destination: &replayFlags.JournalDir,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node gRPC connection",
+			Destination: &replayFlags.BeaconNodeHost,
+			Value:       "localhost:4000",
+		},
+		&cli.DurationFlag{
+			Name:        "grpc-timeout",
+			Usage:       "timeout for each validation request made to the beacon node (uses duration format, ex: 2m31s). default: 10s",
+			Destination: &replayFlags.Timeout,
+			Value:       time.Second * 10,
+		},
+	},
+}
+
+func cliActionReplay(_ *cli.Context) error {
+	ctx := context.Background()
+	f := replayFlags
+
+	entries, err := sync.ReadGossipJournal(f.JournalDir)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.DialContext(ctx, f.BeaconNodeHost, grpc.WithInsecure())
+	if err != nil {
+		return errors.Wrapf(err, "could not dial beacon node at %s", f.BeaconNodeHost)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.WithError(err).Error("Could not close connection to beacon node")
+		}
+	}()
+	client := ethpb.NewDebugClient(conn)
+
+	changed := 0
+	for _, entry := range entries {
+		reqCtx, cancel := context.WithTimeout(ctx, f.Timeout)
+		resp, err := client.ValidateGossipMessage(reqCtx, &ethpb.GossipMessageValidationRequest{
+			Topic:  entry.Topic,
+			PeerId: entry.Peer,
+			Data:   entry.Data,
+		})
+		cancel()
+		if err != nil {
+			return errors.Wrapf(err, "could not replay message of topic %s received at %s", entry.Topic, entry.Time)
+		}
+		verdict := strings.ToLower(resp.Verdict.String())
+		if verdict == entry.Verdict {
+			continue
+		}
+		changed++
+		log.WithFields(log.Fields{
+			"time":            entry.Time,
+			"topic":           entry.Topic,
+			"peer":            entry.Peer,
+			"recordedVerdict": entry.Verdict,
+			"recordedReason":  entry.Reason,
+			"replayedVerdict": verdict,
+			"replayedReason":  resp.Reason,
+		}).Info("Gossip message verdict changed")
+	}
+	log.WithFields(log.Fields{
+		"replayed": len(entries),
+		"changed":  changed,
+	}).Info("Replayed gossip journal")
+	return nil
+}
@@ -3,7 +3,11 @@ package main
 import (
 	"os"
 
+	"github.com/prysmaticlabs/prysm/cmd/prysmctl/benchmark"
 	"github.com/prysmaticlabs/prysm/cmd/prysmctl/checkpoint"
+	"github.com/prysmaticlabs/prysm/cmd/prysmctl/config"
+	"github.com/prysmaticlabs/prysm/cmd/prysmctl/gossip"
+	"github.com/prysmaticlabs/prysm/cmd/prysmctl/ssz"
 	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
@@ -21,5 +25,9 @@ func main() {
 }
 
 func init() {
+	prysmctlCommands = append(prysmctlCommands, benchmark.Commands...)
 	prysmctlCommands = append(prysmctlCommands, checkpoint.Commands...)
+	prysmctlCommands = append(prysmctlCommands, config.Commands...)
+	prysmctlCommands = append(prysmctlCommands, gossip.Commands...)
+	prysmctlCommands = append(prysmctlCommands, ssz.Commands...)
 }
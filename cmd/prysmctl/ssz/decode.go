@@ -0,0 +1,119 @@
+package ssz
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// snappyStreamIdentifier is the first chunk of a snappy framed stream, as used by req/resp messages.
+var snappyStreamIdentifier = []byte{0xff, 0x06, 0x00, 0x00, 's', 'N', 'a', 'P', 'p', 'Y'}
+
+var decodeFlags = struct {
+	Type         string
+	File         string
+	Format       string
+	HashTreeRoot bool
+}{}
+
+var decodeCmd = &cli.Command{
+	Name:   "decode",
+	Usage:  "Pretty-print an SSZ encoded consensus object, optionally snappy compressed as in spectests and gossip captures.",
+	Action: cliActionDecode,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "type",
+			Usage:       "name of the consensus type of the object, ex: SignedBeaconBlockAltair. Use `prysmctl ssz types` to list them",
+			Destination: &decodeFlags.Type,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "file",
+			Usage:       "path to the SSZ file. Snappy block and framed compression are detected automatically",
+			Destination: &decodeFlags.File,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "output format, json or yaml",
+			Destination: &decodeFlags.Format,
+			Value:       "json",
+		},
+		&cli.BoolFlag{
+			Name:        "hash-tree-root",
+			Usage:       "also compute the hash tree root of the object",
+			Destination: &decodeFlags.HashTreeRoot,
+		},
+	},
+}
+
+func cliActionDecode(_ *cli.Context) error {
+	f := decodeFlags
+	newObj, ok := sszTypes[f.Type]
+	if !ok {
+		return fmt.Errorf("unknown type %s, known types are: %s", f.Type, strings.Join(sszTypeNames(), ", "))
+	}
+	raw, err := os.ReadFile(f.File) // #nosec G304
+	if err != nil {
+		return errors.Wrapf(err, "could not read %s", f.File)
+	}
+	obj := newObj()
+	if err := unmarshalSSZ(raw, obj); err != nil {
+		return errors.Wrapf(err, "could not decode %s as %s", f.File, f.Type)
+	}
+
+	var out interface{} = protoToObject(obj.ProtoReflect())
+	if f.HashTreeRoot {
+		root, err := obj.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not compute hash tree root")
+		}
+		out = object{
+			{key: "hash_tree_root", value: hexutil.Encode(root[:])},
+			{key: "data", value: out},
+		}
+	}
+
+	var enc []byte
+	switch f.Format {
+	case "json":
+		enc, err = json.MarshalIndent(out, "", "  ")
+		enc = append(enc, '\n')
+	case "yaml":
+		enc, err = yaml.Marshal(out)
+	default:
+		return fmt.Errorf("unknown format %s, expected json or yaml", f.Format)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not encode object")
+	}
+	_, err = os.Stdout.Write(enc)
+	return err
+}
+
+// unmarshalSSZ decodes raw into obj, decompressing it first when it is snappy framed or snappy
+// block compressed. Data which decompresses but does not decode is decoded uncompressed instead.
+func unmarshalSSZ(raw []byte, obj sszObject) error {
+	if bytes.HasPrefix(raw, snappyStreamIdentifier) {
+		dec, err := io.ReadAll(snappy.NewReader(bytes.NewReader(raw)))
+		if err != nil {
+			return errors.Wrap(err, "could not decompress snappy framed data")
+		}
+		return obj.UnmarshalSSZ(dec)
+	}
+	if dec, err := snappy.Decode(nil, raw); err == nil {
+		if err := obj.UnmarshalSSZ(dec); err == nil {
+			return nil
+		}
+	}
+	return obj.UnmarshalSSZ(raw)
+}
@@ -0,0 +1,95 @@
+package ssz
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v2"
+)
+
+// object is a JSON or YAML object which keeps the field order of the consensus type.
+type object []field
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON writes the fields of the object in order.
+func (o object) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString("{")
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML writes the fields of the object in order.
+func (o object) MarshalYAML() (interface{}, error) {
+	m := make(yaml.MapSlice, len(o))
+	for i, f := range o {
+		m[i] = yaml.MapItem{Key: f.key, Value: f.value}
+	}
+	return m, nil
+}
+
+// protoToObject converts a consensus type to an object following the conventions of the beacon API:
+// snake case field names, 0x-prefixed hex byte strings and integers as decimal strings.
+func protoToObject(m protoreflect.Message) object {
+	fields := m.Descriptor().Fields()
+	o := make(object, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		var v interface{}
+		switch {
+		case fd.IsList():
+			list := m.Get(fd).List()
+			items := make([]interface{}, list.Len())
+			for j := 0; j < list.Len(); j++ {
+				items[j] = protoValue(fd, list.Get(j))
+			}
+			v = items
+		default:
+			v = protoValue(fd, m.Get(fd))
+		}
+		o = append(o, field{key: string(fd.Name()), value: v})
+	}
+	return o
+}
+
+func protoValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return protoToObject(v.Message())
+	case protoreflect.BytesKind:
+		return hexutil.Encode(v.Bytes())
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(v.Uint(), 10)
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(v.Int(), 10)
+	case protoreflect.EnumKind:
+		return string(fd.Enum().Values().ByNumber(v.Enum()).Name())
+	default:
+		return v.String()
+	}
+}
@@ -0,0 +1,14 @@
+package ssz
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "ssz",
+		Usage: "commands for inspecting SSZ encoded consensus objects",
+		Subcommands: []*cli.Command{
+			decodeCmd,
+			typesCmd,
+		},
+	},
+}
@@ -0,0 +1,98 @@
+package ssz
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	fssz "github.com/prysmaticlabs/fastssz"
+	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// sszObject is a consensus type which can be decoded from SSZ and printed.
+type sszObject interface {
+	proto.Message
+	fssz.Unmarshaler
+	fssz.HashRoot
+}
+
+// sszTypes maps the names accepted by --type to constructors of the consensus types of every fork.
+var sszTypes = map[string]func() sszObject{
+	"AggregateAttestationAndProof":       func() sszObject { return &ethpb.AggregateAttestationAndProof{} },
+	"Attestation":                        func() sszObject { return &ethpb.Attestation{} },
+	"AttestationData":                    func() sszObject { return &ethpb.AttestationData{} },
+	"AttesterSlashing":                   func() sszObject { return &ethpb.AttesterSlashing{} },
+	"BeaconBlock":                        func() sszObject { return &ethpb.BeaconBlock{} },
+	"BeaconBlockAltair":                  func() sszObject { return &ethpb.BeaconBlockAltair{} },
+	"BeaconBlockBellatrix":               func() sszObject { return &ethpb.BeaconBlockBellatrix{} },
+	"BeaconBlockBody":                    func() sszObject { return &ethpb.BeaconBlockBody{} },
+	"BeaconBlockBodyAltair":              func() sszObject { return &ethpb.BeaconBlockBodyAltair{} },
+	"BeaconBlockBodyBellatrix":           func() sszObject { return &ethpb.BeaconBlockBodyBellatrix{} },
+	"BeaconBlockHeader":                  func() sszObject { return &ethpb.BeaconBlockHeader{} },
+	"BeaconBlocksByRangeRequest":         func() sszObject { return &ethpb.BeaconBlocksByRangeRequest{} },
+	"BeaconState":                        func() sszObject { return &ethpb.BeaconState{} },
+	"BeaconStateAltair":                  func() sszObject { return &ethpb.BeaconStateAltair{} },
+	"BeaconStateBellatrix":               func() sszObject { return &ethpb.BeaconStateBellatrix{} },
+	"BlindedBeaconBlockBellatrix":        func() sszObject { return &ethpb.BlindedBeaconBlockBellatrix{} },
+	"BlindedBeaconBlockBodyBellatrix":    func() sszObject { return &ethpb.BlindedBeaconBlockBodyBellatrix{} },
+	"Checkpoint":                         func() sszObject { return &ethpb.Checkpoint{} },
+	"ContributionAndProof":               func() sszObject { return &ethpb.ContributionAndProof{} },
+	"Deposit":                            func() sszObject { return &ethpb.Deposit{} },
+	"DepositData":                        func() sszObject { return &ethpb.Deposit_Data{} },
+	"DepositMessage":                     func() sszObject { return &ethpb.DepositMessage{} },
+	"ENRForkID":                          func() sszObject { return &ethpb.ENRForkID{} },
+	"Eth1Data":                           func() sszObject { return &ethpb.Eth1Data{} },
+	"ExecutionPayload":                   func() sszObject { return &enginev1.ExecutionPayload{} },
+	"ExecutionPayloadHeader":             func() sszObject { return &enginev1.ExecutionPayloadHeader{} },
+	"Fork":                               func() sszObject { return &ethpb.Fork{} },
+	"ForkData":                           func() sszObject { return &ethpb.ForkData{} },
+	"HistoricalBatch":                    func() sszObject { return &ethpb.HistoricalBatch{} },
+	"IndexedAttestation":                 func() sszObject { return &ethpb.IndexedAttestation{} },
+	"MetaDataV0":                         func() sszObject { return &ethpb.MetaDataV0{} },
+	"MetaDataV1":                         func() sszObject { return &ethpb.MetaDataV1{} },
+	"PendingAttestation":                 func() sszObject { return &ethpb.PendingAttestation{} },
+	"PowBlock":                           func() sszObject { return &ethpb.PowBlock{} },
+	"ProposerSlashing":                   func() sszObject { return &ethpb.ProposerSlashing{} },
+	"SignedAggregateAttestationAndProof": func() sszObject { return &ethpb.SignedAggregateAttestationAndProof{} },
+	"SignedBeaconBlock":                  func() sszObject { return &ethpb.SignedBeaconBlock{} },
+	"SignedBeaconBlockAltair":            func() sszObject { return &ethpb.SignedBeaconBlockAltair{} },
+	"SignedBeaconBlockBellatrix":         func() sszObject { return &ethpb.SignedBeaconBlockBellatrix{} },
+	"SignedBeaconBlockHeader":            func() sszObject { return &ethpb.SignedBeaconBlockHeader{} },
+	"SignedBlindedBeaconBlockBellatrix":  func() sszObject { return &ethpb.SignedBlindedBeaconBlockBellatrix{} },
+	"SignedContributionAndProof":         func() sszObject { return &ethpb.SignedContributionAndProof{} },
+	"SignedValidatorRegistrationV1":      func() sszObject { return &ethpb.SignedValidatorRegistrationV1{} },
+	"SignedVoluntaryExit":                func() sszObject { return &ethpb.SignedVoluntaryExit{} },
+	"SigningData":                        func() sszObject { return &ethpb.SigningData{} },
+	"Status":                             func() sszObject { return &ethpb.Status{} },
+	"SyncAggregate":                      func() sszObject { return &ethpb.SyncAggregate{} },
+	"SyncAggregatorSelectionData":        func() sszObject { return &ethpb.SyncAggregatorSelectionData{} },
+	"SyncCommittee":                      func() sszObject { return &ethpb.SyncCommittee{} },
+	"SyncCommitteeContribution":          func() sszObject { return &ethpb.SyncCommitteeContribution{} },
+	"SyncCommitteeMessage":               func() sszObject { return &ethpb.SyncCommitteeMessage{} },
+	"Validator":                          func() sszObject { return &ethpb.Validator{} },
+	"ValidatorRegistrationV1":            func() sszObject { return &ethpb.ValidatorRegistrationV1{} },
+	"VoluntaryExit":                      func() sszObject { return &ethpb.VoluntaryExit{} },
+}
+
+var typesCmd = &cli.Command{
+	Name:   "types",
+	Usage:  "List the consensus types which can be decoded.",
+	Action: cliActionTypes,
+}
+
+func cliActionTypes(_ *cli.Context) error {
+	fmt.Println(strings.Join(sszTypeNames(), "\n"))
+	return nil
+}
+
+func sszTypeNames() []string {
+	names := make([]string, 0, len(sszTypes))
+	for name := range sszTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -150,6 +150,39 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name: "derive",
+			Description: "derives the accounts at the given EIP-2334 derivation paths from the mnemonic of an HD wallet " +
+				"and adds them to the wallet, to recover accounts which were not created at sequential indices",
+			Flags: cmd.WrapFlags([]cli.Flag{
+				flags.WalletDirFlag,
+				flags.WalletPasswordFileFlag,
+				flags.MnemonicFileFlag,
+				flags.Mnemonic25thWordFileFlag,
+				flags.SkipMnemonic25thWordCheckFlag,
+				flags.DerivationPathsFlag,
+				features.Mainnet,
+				features.PraterTestnet,
+				features.RopstenTestnet,
+				features.SepoliaTestnet,
+				cmd.AcceptTosFlag,
+			}),
+			Before: func(cliCtx *cli.Context) error {
+				if err := cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags); err != nil {
+					return err
+				}
+				if err := tos.VerifyTosAcceptedOrPrompt(cliCtx); err != nil {
+					return err
+				}
+				return features.ConfigureValidator(cliCtx)
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := accountsDerive(cliCtx); err != nil {
+					log.Fatalf("Could not derive accounts: %v", err)
+				}
+				return nil
+			},
+		},
 		{
 			Name:        "voluntary-exit",
 			Description: "Performs a voluntary exit on selected accounts",
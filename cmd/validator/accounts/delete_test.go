@@ -85,6 +85,8 @@ type testWalletConfig struct {
 	backupDir               string
 	passwordsDir            string
 	walletDir               string
+	mnemonicFile            string
+	derivationPaths         []string
 }
 
 func setupWalletCtx(
@@ -108,6 +110,8 @@ func setupWalletCtx(
 	set.Bool(flags.SkipMnemonic25thWordCheckFlag.Name, true, "")
 	set.Bool(flags.ExitAllFlag.Name, cfg.exitAll, "")
 	set.String(flags.GrpcHeadersFlag.Name, cfg.grpcHeaders, "")
+	set.String(flags.MnemonicFileFlag.Name, cfg.mnemonicFile, "")
+	set.Var(cli.NewStringSlice(), flags.DerivationPathsFlag.Name, "")
 
 	if cfg.privateKeyFile != "" {
 		set.String(flags.ImportPrivateKeyFileFlag.Name, cfg.privateKeyFile, "")
@@ -128,6 +132,12 @@ func setupWalletCtx(
 	assert.NoError(tb, set.Set(flags.SkipDepositConfirmationFlag.Name, strconv.FormatBool(cfg.skipDepositConfirm)))
 	assert.NoError(tb, set.Set(flags.ExitAllFlag.Name, strconv.FormatBool(cfg.exitAll)))
 	assert.NoError(tb, set.Set(flags.GrpcHeadersFlag.Name, cfg.grpcHeaders))
+	if cfg.mnemonicFile != "" {
+		assert.NoError(tb, set.Set(flags.MnemonicFileFlag.Name, cfg.mnemonicFile))
+	}
+	for _, path := range cfg.derivationPaths {
+		assert.NoError(tb, set.Set(flags.DerivationPathsFlag.Name, path))
+	}
 	return cli.NewContext(&app, set, nil)
 }
 
@@ -0,0 +1,45 @@
+package accounts
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/container/slice"
+	"github.com/prysmaticlabs/prysm/validator/accounts"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/derived"
+	"github.com/urfave/cli/v2"
+)
+
+func accountsDerive(c *cli.Context) error {
+	paths := slice.SplitCommaSeparated(c.StringSlice(flags.DerivationPathsFlag.Name))
+	if len(paths) == 0 {
+		return errors.Errorf("at least one derivation path must be given with --%s", flags.DerivationPathsFlag.Name)
+	}
+	for _, path := range paths {
+		if err := derived.ValidateDerivationPath(path); err != nil {
+			return err
+		}
+	}
+	w, km, err := walletWithKeymanager(c)
+	if err != nil {
+		return err
+	}
+	if _, ok := km.(*derived.Keymanager); !ok {
+		return errors.Errorf("can only derive accounts for an HD wallet, not a %s wallet", w.KeymanagerKind())
+	}
+	mnemonic, mnemonic25thWord, err := accounts.InputMnemonic(c)
+	if err != nil {
+		return err
+	}
+
+	acc, err := accounts.NewCLIManager(
+		accounts.WithWallet(w),
+		accounts.WithKeymanager(km),
+		accounts.WithMnemonic(mnemonic),
+		accounts.WithMnemonic25thWord(mnemonic25thWord),
+		accounts.WithDerivationPaths(paths),
+	)
+	if err != nil {
+		return err
+	}
+	return acc.Derive(c.Context)
+}
@@ -0,0 +1,77 @@
+package accounts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/accounts"
+	"github.com/prysmaticlabs/prysm/validator/accounts/iface"
+	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	constant "github.com/prysmaticlabs/prysm/validator/testing"
+	"github.com/tyler-smith/go-bip39"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestDeriveAccounts_Noninteractive(t *testing.T) {
+	walletDir, _, passwordFilePath := setupWalletAndPasswordsDir(t)
+	mnemonicFile := filepath.Join(t.TempDir(), "mnemonic.txt")
+	require.NoError(t, os.WriteFile(mnemonicFile, []byte(constant.TestMnemonic), os.ModePerm))
+
+	cliCtx := setupWalletCtx(t, &testWalletConfig{
+		walletDir:          walletDir,
+		keymanagerKind:     keymanager.Derived,
+		walletPasswordFile: passwordFilePath,
+		mnemonicFile:       mnemonicFile,
+		derivationPaths:    []string{"m/12381/3600/2/0/0", "m/12381/3600/7/0/0,m/12381/3600/9/0/0"},
+	})
+	w, err := accounts.CreateWalletWithKeymanager(cliCtx.Context, &accounts.CreateWalletConfig{
+		WalletCfg: &wallet.Config{
+			WalletDir:      walletDir,
+			KeymanagerKind: keymanager.Derived,
+			WalletPassword: password,
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, accountsDerive(cliCtx))
+
+	km, err := w.InitializeKeymanager(cliCtx.Context, iface.InitKeymanagerConfig{ListenForChanges: false})
+	require.NoError(t, err)
+	pubKeys, err := km.FetchValidatingPublicKeys(cliCtx.Context)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(pubKeys))
+	seed := bip39.NewSeed(constant.TestMnemonic, "")
+	for i, path := range []string{"m/12381/3600/2/0/0", "m/12381/3600/7/0/0", "m/12381/3600/9/0/0"} {
+		privKey, err := util.PrivateKeyFromSeedAndPath(seed, path)
+		require.NoError(t, err)
+		assert.DeepEqual(t, privKey.PublicKey().Marshal(), pubKeys[i][:])
+	}
+}
+
+func TestDeriveAccounts_Errors(t *testing.T) {
+	walletDir, _, passwordFilePath := setupWalletAndPasswordsDir(t)
+	cfg := &testWalletConfig{
+		walletDir:          walletDir,
+		keymanagerKind:     keymanager.Local,
+		walletPasswordFile: passwordFilePath,
+	}
+	assert.ErrorContains(t, "at least one derivation path", accountsDerive(setupWalletCtx(t, cfg)))
+
+	cfg.derivationPaths = []string{"m/44/60/0/0/0"}
+	assert.ErrorContains(t, "does not start with m/12381/3600", accountsDerive(setupWalletCtx(t, cfg)))
+
+	_, err := accounts.CreateWalletWithKeymanager(context.Background(), &accounts.CreateWalletConfig{
+		WalletCfg: &wallet.Config{
+			WalletDir:      walletDir,
+			KeymanagerKind: keymanager.Local,
+			WalletPassword: password,
+		},
+	})
+	require.NoError(t, err)
+	cfg.derivationPaths = []string{"m/12381/3600/0/0/0"}
+	assert.ErrorContains(t, "can only derive accounts for an HD wallet", accountsDerive(setupWalletCtx(t, cfg)))
+}
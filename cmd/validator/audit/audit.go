@@ -0,0 +1,34 @@
+package auditcmd
+
+import (
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Commands for the signing audit log.
+var Commands = &cli.Command{
+	Name:     "signing-audit",
+	Category: "signing-audit",
+	Usage:    "defines commands for interacting with your validator's signing audit log",
+	Subcommands: []*cli.Command{
+		{
+			Name:        "export",
+			Description: `verifies the hash chain of your validator's signing audit log and exports its entries into a JSON file`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				flags.SigningAuditLogDirFlag,
+				flags.SigningAuditExportFileFlag,
+			}),
+			Before: func(cliCtx *cli.Context) error {
+				return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := exportAuditLog(cliCtx); err != nil {
+					logrus.Fatalf("Could not export signing audit log: %v", err)
+				}
+				return nil
+			},
+		},
+	},
+}
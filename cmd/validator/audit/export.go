@@ -0,0 +1,40 @@
+package auditcmd
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/validator/audit"
+	"github.com/urfave/cli/v2"
+)
+
+// Reads every entry of the signing audit log, verifies that the entries form an
+// unbroken hash chain and writes them to the specified JSON file.
+func exportAuditLog(cliCtx *cli.Context) error {
+	dir := cliCtx.String(flags.SigningAuditLogDirFlag.Name)
+	if dir == "" {
+		return errors.New("signing audit log directory not specified")
+	}
+	outputFile := cliCtx.String(flags.SigningAuditExportFileFlag.Name)
+	if outputFile == "" {
+		return errors.New("export file not specified")
+	}
+	entries, err := audit.ReadAll(dir)
+	if err != nil {
+		return errors.Wrap(err, "could not read signing audit log")
+	}
+	if err := audit.Verify(entries); err != nil {
+		return errors.Wrap(err, "signing audit log failed verification")
+	}
+	encoded, err := json.MarshalIndent(entries, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "could not JSON marshal signing audit log")
+	}
+	if err := file.WriteFile(outputFile, encoded); err != nil {
+		return errors.Wrapf(err, "could not write file to path %s", outputFile)
+	}
+	log.WithField("entries", len(entries)).Infof("Successfully exported verified signing audit log to %s", outputFile)
+	return nil
+}
@@ -0,0 +1,73 @@
+package auditcmd
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/audit"
+	"github.com/urfave/cli/v2"
+)
+
+func setupCliCtx(tb testing.TB, auditDir, outputFile string) *cli.Context {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(flags.SigningAuditLogDirFlag.Name, auditDir, "")
+	set.String(flags.SigningAuditExportFileFlag.Name, outputFile, "")
+	require.NoError(tb, set.Set(flags.SigningAuditLogDirFlag.Name, auditDir))
+	require.NoError(tb, set.Set(flags.SigningAuditExportFileFlag.Name, outputFile))
+	return cli.NewContext(&app, set, nil)
+}
+
+func TestExportAuditLog(t *testing.T) {
+	auditDir := filepath.Join(t.TempDir(), "audit")
+	l, err := audit.Open(auditDir, 0)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.Append(audit.NewEntry(&validatorpb.SignRequest{
+			Object: &validatorpb.SignRequest_Epoch{Epoch: 1},
+		}, nil)))
+	}
+	require.NoError(t, l.Close())
+
+	outputFile := filepath.Join(t.TempDir(), "audit.json")
+	require.NoError(t, exportAuditLog(setupCliCtx(t, auditDir, outputFile)))
+
+	enc, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	var entries []*audit.Entry
+	require.NoError(t, json.Unmarshal(enc, &entries))
+	require.Equal(t, 3, len(entries))
+	assert.Equal(t, "randao_reveal", entries[2].Type)
+	require.NoError(t, audit.Verify(entries))
+}
+
+func TestExportAuditLog_TamperedLog(t *testing.T) {
+	auditDir := filepath.Join(t.TempDir(), "audit")
+	l, err := audit.Open(auditDir, 0)
+	require.NoError(t, err)
+	require.NoError(t, l.Append(audit.NewEntry(&validatorpb.SignRequest{
+		Object: &validatorpb.SignRequest_Epoch{Epoch: 1},
+	}, nil)))
+	require.NoError(t, l.Close())
+
+	p := filepath.Join(auditDir, audit.LogFileName)
+	enc, err := os.ReadFile(p)
+	require.NoError(t, err)
+	var e audit.Entry
+	require.NoError(t, json.Unmarshal(enc, &e))
+	e.Outcome = audit.OutcomeFailed
+	enc, err = json.Marshal(&e)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(p, enc, 0600))
+
+	outputFile := filepath.Join(t.TempDir(), "audit.json")
+	err = exportAuditLog(setupCliCtx(t, auditDir, outputFile))
+	assert.ErrorContains(t, "failed verification", err)
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/runtime/tos"
 	validatordb "github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/sirupsen/logrus"
@@ -31,6 +32,43 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:        "export-history",
+			Description: `exports the signed attestation and proposal history of every validator from the database, as CSV or parquet files`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				flags.HistoryExportDirFlag,
+				flags.HistoryExportFormatFlag,
+				flags.DBEncryptionKeyFileFlag,
+				flags.DBEncryptionWithWalletPasswordFlag,
+				flags.WalletPasswordFileFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := validatordb.ExportHistory(cliCtx); err != nil {
+					log.Fatalf("Could not export signing history: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name: "prune-slashing-protection",
+			Description: `removes the attestation and proposal history older than the weak subjectivity period from ` +
+				`the slashing protection database, the validator client must be stopped`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				flags.DBEncryptionKeyFileFlag,
+				flags.DBEncryptionWithWalletPasswordFlag,
+				flags.WalletPasswordFileFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := validatordb.PruneSlashingProtection(cliCtx); err != nil {
+					log.Fatalf("Could not prune slashing protection history: %v", err)
+				}
+				return nil
+			},
+		},
 		{
 			Name:     "migrate",
 			Category: "db",
@@ -41,6 +79,9 @@ var Commands = &cli.Command{
 					Usage: "Runs up migrations for the validator database",
 					Flags: cmd.WrapFlags([]cli.Flag{
 						cmd.DataDirFlag,
+						flags.DBEncryptionKeyFileFlag,
+						flags.DBEncryptionWithWalletPasswordFlag,
+						flags.WalletPasswordFileFlag,
 					}),
 					Before: tos.VerifyTosAcceptedOrPrompt,
 					Action: func(cliCtx *cli.Context) error {
@@ -55,6 +96,9 @@ var Commands = &cli.Command{
 					Usage: "Runs down migrations for the validator database",
 					Flags: cmd.WrapFlags([]cli.Flag{
 						cmd.DataDirFlag,
+						flags.DBEncryptionKeyFileFlag,
+						flags.DBEncryptionWithWalletPasswordFlag,
+						flags.WalletPasswordFileFlag,
 					}),
 					Before: tos.VerifyTosAcceptedOrPrompt,
 					Action: func(cliCtx *cli.Context) error {
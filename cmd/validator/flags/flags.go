@@ -27,6 +27,12 @@ var (
 			"of validating keys may wish to disable granular prometheus metrics as it increases " +
 			"the data cardinality.",
 	}
+	// RedactValidatorIdentitiesFlag replaces validator public keys and indices in logs and metrics with opaque identifiers.
+	RedactValidatorIdentitiesFlag = &cli.BoolFlag{
+		Name: "redact-validator-identities",
+		Usage: "Replace validator public keys and indices in logs and metrics with opaque identifiers, which are stable " +
+			"for as long as the redaction.key file of the data directory is kept, so logs and dashboards can be shared publicly",
+	}
 	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
 	BeaconRPCProviderFlag = &cli.StringFlag{
 		Name:  "beacon-rpc-provider",
@@ -81,7 +87,7 @@ var (
 	// GraffitiFlag defines the graffiti value included in proposed blocks
 	GraffitiFlag = &cli.StringFlag{
 		Name:  "graffiti",
-		Usage: "String to include in proposed blocks",
+		Usage: "String to include in proposed blocks. Graffiti set for individual validators in the --graffiti-file takes precedence",
 	}
 	// GrpcRetriesFlag defines the number of times to retry a failed gRPC request.
 	GrpcRetriesFlag = &cli.UintFlag{
@@ -161,6 +167,12 @@ var (
 		Name:  "mnemonic-file",
 		Usage: "File to retrieve mnemonic for non-interactively passing a mnemonic phrase into wallet recover.",
 	}
+	// DerivationPathsFlag defines the EIP-2334 derivation paths of the accounts to derive from the mnemonic of an HD wallet.
+	DerivationPathsFlag = &cli.StringSliceFlag{
+		Name: "path",
+		Usage: "EIP-2334 derivation path of an account to derive from the mnemonic of an HD wallet, such as m/12381/3600/5/0/0. " +
+			"Can be given several times, or as a comma-separated list",
+	}
 	// ShowDepositDataFlag for accounts.
 	ShowDepositDataFlag = &cli.BoolFlag{
 		Name:  "show-deposit-data",
@@ -312,10 +324,30 @@ var (
 		Usage: "Allows users to specify the output directory to export their slashing protection EIP-3076 standard JSON File",
 		Value: "",
 	}
+	// SlashingProtectionExportFormatFlag specifies the EIP-3076 interchange format of the exported
+	// slashing protection history, either complete or minimal.
+	SlashingProtectionExportFormatFlag = &cli.StringFlag{
+		Name:  "slashing-protection-export-format",
+		Usage: "The EIP-3076 interchange format of the exported slashing protection history, either complete, with every signed block and attestation, or minimal, with only the highest signed slot and epochs of each validator",
+		Value: "complete",
+	}
+	// HistoryExportDirFlag specifies the output directory of the signing history exported from the validator database.
+	HistoryExportDirFlag = &cli.StringFlag{
+		Name:  "history-export-dir",
+		Usage: "The output directory of the per validator signed attestation and proposal history exported from the validator database",
+		Value: "",
+	}
+	// HistoryExportFormatFlag specifies the file format of the signing history exported from the validator database.
+	HistoryExportFormatFlag = &cli.StringFlag{
+		Name:  "history-export-format",
+		Usage: "The file format of the exported signing history, either csv or parquet",
+		Value: "csv",
+	}
 	// GraffitiFileFlag specifies the file path to load graffiti values.
 	GraffitiFileFlag = &cli.StringFlag{
-		Name:  "graffiti-file",
-		Usage: "The path to a YAML file with graffiti values",
+		Name: "graffiti-file",
+		Usage: "The path to a YAML file with graffiti values, including graffiti for individual validator public keys. " +
+			"The file is reloaded when it changes",
 	}
 	// EnableDutyCountDown enables more verbose logging for counting down to duty.
 	EnableDutyCountDown = &cli.BoolFlag{
@@ -323,6 +355,13 @@ var (
 		Usage: "Enables more verbose logging for counting down to duty",
 		Value: false,
 	}
+	// DoppelGangerEpochsFlag specifies the number of epochs the validator listens for its keys before signing.
+	DoppelGangerEpochsFlag = &cli.Uint64Flag{
+		Name: "doppelganger-epochs",
+		Usage: "The number of epochs the validator listens for attestations and blocks of its keys on startup before " +
+			"signing, refusing to start if any is found. Ignored if the doppelganger check is disabled.",
+		Value: 2,
+	}
 
 	// FeeRecipientConfigFileFlag defines the path or URL to a file with proposer config.
 	FeeRecipientConfigFileFlag = &cli.StringFlag{
@@ -365,6 +404,87 @@ var (
 		Usage: "Enables validator registration APIs (MEV Builder APIs) for the validator client to update settings such as fee recipient and gas limit",
 		Value: false,
 	}
+
+	// SigningAuditLogDirFlag enables the signing audit log and defines the directory it is written to.
+	SigningAuditLogDirFlag = &cli.StringFlag{
+		Name:  "signing-audit-log-dir",
+		Usage: "Enables an append-only, hash-chained log of every signing request made by the validator client, written to the specified directory",
+		Value: "",
+	}
+	// SigningAuditLogMaxSizeFlag defines the size at which the signing audit log file is rotated.
+	SigningAuditLogMaxSizeFlag = &cli.Uint64Flag{
+		Name:  "signing-audit-log-max-size-mb",
+		Usage: "Maximum size in megabytes of the signing audit log file before it is rotated. 0 disables rotation",
+		Value: 100,
+	}
+	// SigningAuditExportFileFlag defines the output file for a signing audit log export.
+	SigningAuditExportFileFlag = &cli.StringFlag{
+		Name:  "signing-audit-export-file",
+		Usage: "Path of the JSON file the verified signing audit log is exported to",
+		Value: "",
+	}
+	// ReadinessOutputFileFlag defines the output file for a validator readiness report.
+	ReadinessOutputFileFlag = &cli.StringFlag{
+		Name:  "readiness-output-file",
+		Usage: "Path of the JSON file the validator readiness report is written to. Written to the standard output if not set",
+		Value: "",
+	}
+	// PerformanceWebhookURLFlag enables the performance reporter and defines the webhook it posts to.
+	PerformanceWebhookURLFlag = &cli.StringFlag{
+		Name:  "performance-webhook-url",
+		Usage: "Enables periodic reports of the performance of the validator keys, posted to this Discord or Slack compatible webhook url",
+		Value: "",
+	}
+	// PerformanceReportIntervalFlag defines the period covered by each performance report.
+	PerformanceReportIntervalFlag = &cli.DurationFlag{
+		Name:  "performance-report-interval",
+		Usage: "Period covered by each performance report posted to the performance webhook",
+		Value: 24 * time.Hour,
+	}
+	// PerformanceReportTemplateFlag defines a custom template for performance reports.
+	PerformanceReportTemplateFlag = &cli.StringFlag{
+		Name:  "performance-report-template",
+		Usage: "Path to a Go text/template file used to render performance reports instead of the default one",
+		Value: "",
+	}
+	// ExternalBeaconAPIURLFlag enables cross-checking validator states with an external beacon API.
+	ExternalBeaconAPIURLFlag = &cli.StringFlag{
+		Name: "external-beacon-api-url",
+		Usage: "Url of an external beacon API serving the standard /eth/v1/beacon endpoints. Every epoch, the statuses and " +
+			"balances of the validator keys reported by the beacon node are compared with this API, and divergences are logged as warnings",
+		Value: "",
+	}
+	// ExternalBeaconAPIBalanceToleranceFlag defines the balance difference tolerated by the external beacon API cross-check.
+	ExternalBeaconAPIBalanceToleranceFlag = &cli.Uint64Flag{
+		Name:  "external-beacon-api-balance-tolerance-gwei",
+		Usage: "Largest balance difference, in gwei, between the beacon node and the external beacon API not reported as a divergence",
+		Value: 0,
+	}
+	// ReportDutyResultsFlag enables reporting duty outcomes back to the beacon node.
+	ReportDutyResultsFlag = &cli.BoolFlag{
+		Name:  "report-duty-results",
+		Usage: "Reports the outcome of every duty (signed, submitted or skipped with a reason) back to the beacon node, which feeds them to its validator monitor and events API",
+	}
+	// TenantsConfigFileFlag defines the path to a file listing additional wallets run by the validator client.
+	TenantsConfigFileFlag = &cli.StringFlag{
+		Name: "tenants-config-file",
+		Usage: "Path to a YAML file listing additional wallets to run in this process, each with its own beacon node endpoint, " +
+			"fee recipient and slashing protection database. The keys of each wallet are reported under a tenant label in metrics",
+		Value: "",
+	}
+	// DBEncryptionKeyFileFlag defines a key file the values of the validator database are encrypted with.
+	DBEncryptionKeyFileFlag = &cli.StringFlag{
+		Name: "db-encryption-key-file",
+		Usage: "Encrypts the slashing protection database at rest with a key derived from the contents of this file. " +
+			"An existing plaintext database is encrypted when first opened with the key",
+		Value: "",
+	}
+	// DBEncryptionWithWalletPasswordFlag encrypts the values of the validator database with the wallet password.
+	DBEncryptionWithWalletPasswordFlag = &cli.BoolFlag{
+		Name: "db-encryption-with-wallet-password",
+		Usage: "Encrypts the slashing protection database at rest with a key derived from the wallet password. " +
+			"An existing plaintext database is encrypted when first opened with the password",
+	}
 )
 
 // DefaultValidatorDir returns OS-specific default validator directory.
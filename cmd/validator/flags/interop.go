@@ -18,4 +18,10 @@ var (
 			"Example: --interop-start-index=5 --interop-num-validators=3 would generate " +
 			"keys from index 5 to 7.",
 	}
+	InteropKeyRanges = &cli.StringFlag{
+		Name: "interop-key-ranges",
+		Usage: "Comma separated offset:count ranges of validator keys to deterministically generate, used instead of " +
+			"--interop-start-index and --interop-num-validators to run non contiguous parts of an interop validator set. " +
+			"Example: --interop-key-ranges=0:64,128:64 would generate keys from index 0 to 63 and from 128 to 191.",
+	}
 )
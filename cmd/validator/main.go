@@ -8,13 +8,14 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	runtimeDebug "runtime/debug"
 
 	joonix "github.com/joonix/log"
 	"github.com/prysmaticlabs/prysm/cmd"
 	accountcommands "github.com/prysmaticlabs/prysm/cmd/validator/accounts"
+	auditcommands "github.com/prysmaticlabs/prysm/cmd/validator/audit"
 	dbcommands "github.com/prysmaticlabs/prysm/cmd/validator/db"
 	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	readinesscommands "github.com/prysmaticlabs/prysm/cmd/validator/readiness"
 	slashingprotectioncommands "github.com/prysmaticlabs/prysm/cmd/validator/slashing-protection"
 	walletcommands "github.com/prysmaticlabs/prysm/cmd/validator/wallet"
 	"github.com/prysmaticlabs/prysm/cmd/validator/web"
@@ -22,11 +23,13 @@ import (
 	"github.com/prysmaticlabs/prysm/io/file"
 	"github.com/prysmaticlabs/prysm/io/logs"
 	"github.com/prysmaticlabs/prysm/monitoring/journald"
+	"github.com/prysmaticlabs/prysm/runtime/crash"
 	"github.com/prysmaticlabs/prysm/runtime/debug"
 	_ "github.com/prysmaticlabs/prysm/runtime/maxprocs"
 	"github.com/prysmaticlabs/prysm/runtime/tos"
 	"github.com/prysmaticlabs/prysm/runtime/version"
 	"github.com/prysmaticlabs/prysm/validator/node"
+	"github.com/prysmaticlabs/prysm/validator/redact"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 	prefixed "github.com/x-cray/logrus-prefixed-formatter"
@@ -54,6 +57,7 @@ var appFlags = []cli.Flag{
 	flags.DisablePenaltyRewardLogFlag,
 	flags.InteropStartIndex,
 	flags.InteropNumValidators,
+	flags.InteropKeyRanges,
 	flags.EnableRPCFlag,
 	flags.RPCHost,
 	flags.RPCPort,
@@ -64,6 +68,7 @@ var appFlags = []cli.Flag{
 	flags.GrpcHeadersFlag,
 	flags.GPRCGatewayCorsDomain,
 	flags.DisableAccountMetricsFlag,
+	flags.RedactValidatorIdentitiesFlag,
 	flags.MonitoringPortFlag,
 	flags.SlasherRPCProviderFlag,
 	flags.SlasherCertFlag,
@@ -72,6 +77,7 @@ var appFlags = []cli.Flag{
 	flags.EnableWebFlag,
 	flags.GraffitiFileFlag,
 	flags.EnableDutyCountDown,
+	flags.DoppelGangerEpochsFlag,
 	// Consensys' Web3Signer flags
 	flags.Web3SignerURLFlag,
 	flags.Web3SignerPublicValidatorKeysFlag,
@@ -81,6 +87,17 @@ var appFlags = []cli.Flag{
 	flags.ProposerSettingsURLFlag,
 	flags.ProposerSettingsFlag,
 	flags.EnableValidatorRegistrationFlag,
+	flags.SigningAuditLogDirFlag,
+	flags.SigningAuditLogMaxSizeFlag,
+	flags.PerformanceWebhookURLFlag,
+	flags.PerformanceReportIntervalFlag,
+	flags.PerformanceReportTemplateFlag,
+	flags.ExternalBeaconAPIURLFlag,
+	flags.ExternalBeaconAPIBalanceToleranceFlag,
+	flags.ReportDutyResultsFlag,
+	flags.TenantsConfigFileFlag,
+	flags.DBEncryptionKeyFileFlag,
+	flags.DBEncryptionWithWalletPasswordFlag,
 	////////////////////
 	cmd.DisableMonitoringFlag,
 	cmd.MonitoringHostFlag,
@@ -128,7 +145,9 @@ func main() {
 		walletcommands.Commands,
 		accountcommands.Commands,
 		slashingprotectioncommands.Commands,
+		auditcommands.Commands,
 		dbcommands.Commands,
+		readinesscommands.Commands,
 		web.Commands,
 	}
 
@@ -140,6 +159,15 @@ func main() {
 			return err
 		}
 
+		// Redact log entries before any other hook sees them.
+		if ctx.Bool(flags.RedactValidatorIdentitiesFlag.Name) {
+			secret, err := redact.LoadOrCreateSecret(ctx.String(cmd.DataDirFlag.Name))
+			if err != nil {
+				return fmt.Errorf("could not load redaction secret: %w", err)
+			}
+			logrus.AddHook(redact.NewLogHook(redact.New(secret)))
+		}
+
 		format := ctx.String(cmd.LogFormat.Name)
 		switch format {
 		case "text":
@@ -172,6 +200,7 @@ func main() {
 				log.WithError(err).Error("Failed to configuring logging to disk.")
 			}
 		}
+		crash.Configure(ctx)
 
 		// Fix data dir for Windows users.
 		outdatedDataDir := filepath.Join(file.HomeDir(), "AppData", "Roaming", "Eth2Validators")
@@ -192,12 +221,7 @@ func main() {
 		return nil
 	}
 
-	defer func() {
-		if x := recover(); x != nil {
-			log.Errorf("Runtime panic: %v\n%v", x, string(runtimeDebug.Stack()))
-			panic(x)
-		}
-	}()
+	defer crash.Recover()
 
 	if err := app.Run(os.Args); err != nil {
 		log.Error(err.Error())
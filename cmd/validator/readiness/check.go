@@ -0,0 +1,119 @@
+package readinesscmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/validator/accounts"
+	"github.com/prysmaticlabs/prysm/validator/accounts/iface"
+	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/validator/client"
+	validatordb "github.com/prysmaticlabs/prysm/validator/db"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/validator/node"
+	"github.com/prysmaticlabs/prysm/validator/readiness"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+)
+
+// Opens the wallet, the slashing protection database and a connection to the beacon node,
+// scores the readiness of every key and outputs the report as JSON, either to the specified
+// file or to the standard output. Returns whether every key is ready.
+func checkReadiness(cliCtx *cli.Context) (bool, error) {
+	ctx := cliCtx.Context
+	w, err := wallet.OpenWalletOrElseCli(cliCtx, func(cliCtx *cli.Context) (*wallet.Wallet, error) {
+		return nil, wallet.ErrNoWalletFound
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "could not open wallet")
+	}
+	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false})
+	if err != nil && strings.Contains(err.Error(), keymanager.IncorrectPasswordErrMsg) {
+		return false, errors.New("wrong wallet password entered")
+	}
+	if err != nil {
+		return false, errors.Wrap(err, accounts.ErrCouldNotInitializeKeymanager)
+	}
+
+	proposerSettings, err := node.ProposerSettings(cliCtx)
+	if err != nil {
+		return false, errors.Wrap(err, "could not read proposer settings")
+	}
+
+	cfg := &readiness.Config{
+		Keymanager:       km,
+		ProposerSettings: proposerSettings,
+	}
+
+	dataDir := w.AccountsDir()
+	if cliCtx.String(cmd.DataDirFlag.Name) != cmd.DefaultDataDir() {
+		dataDir = cliCtx.String(cmd.DataDirFlag.Name)
+	}
+	if file.FileExists(filepath.Join(dataDir, kv.ProtectionDbFileName)) {
+		encryptionSecret, err := validatordb.EncryptionSecret(cliCtx, w.Password())
+		if err != nil {
+			return false, errors.Wrap(err, "could not get database encryption secret")
+		}
+		validatorDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{EncryptionSecret: encryptionSecret})
+		if err != nil {
+			return false, errors.Wrapf(err, "could not access validator database at path %s", dataDir)
+		}
+		defer func() {
+			if err := validatorDB.Close(); err != nil {
+				log.WithError(err).Error("Could not close validator DB")
+			}
+		}()
+		cfg.ValidatorDB = validatorDB
+	}
+
+	dialOpts := client.ConstructDialOptions(
+		cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name),
+		cliCtx.String(flags.CertFlag.Name),
+		cliCtx.Uint(flags.GrpcRetriesFlag.Name),
+		cliCtx.Duration(flags.GrpcRetryDelayFlag.Name),
+	)
+	if dialOpts == nil {
+		return false, errors.New("could not construct dial options for the beacon node")
+	}
+	beaconRPCProvider := cliCtx.String(flags.BeaconRPCProviderFlag.Name)
+	ctx = grpcutil.AppendHeaders(ctx, strings.Split(cliCtx.String(flags.GrpcHeadersFlag.Name), ","))
+	conn, err := grpc.DialContext(ctx, beaconRPCProvider, dialOpts...)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not dial endpoint %s", beaconRPCProvider)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.WithError(err).Error("Could not close connection to the beacon node")
+		}
+	}()
+	cfg.ValidatorClient = ethpb.NewBeaconNodeValidatorClient(conn)
+	cfg.NodeClient = ethpb.NewNodeClient(conn)
+
+	report, err := readiness.Run(ctx, cfg)
+	if err != nil {
+		return false, err
+	}
+	encoded, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return false, errors.Wrap(err, "could not JSON marshal readiness report")
+	}
+	outputFile := cliCtx.String(flags.ReadinessOutputFileFlag.Name)
+	if outputFile == "" {
+		fmt.Println(string(encoded))
+	} else {
+		if err := file.WriteFile(outputFile, encoded); err != nil {
+			return false, errors.Wrapf(err, "could not write file to path %s", outputFile)
+		}
+		log.WithField("ready", report.Ready).Infof("Wrote validator readiness report to %s", outputFile)
+	}
+	return report.Ready, nil
+}
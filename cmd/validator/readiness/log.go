@@ -0,0 +1,5 @@
+package readinesscmd
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "readinesscmd")
@@ -0,0 +1,56 @@
+package readinesscmd
+
+import (
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Commands for the validator readiness check.
+var Commands = &cli.Command{
+	Name:     "readiness",
+	Category: "readiness",
+	Usage: "scores the readiness of every validator key, checking beacon node connectivity and sync status, duties, " +
+		"fee recipient, signing and slashing protection history, and outputs a JSON report. " +
+		"Exits with a non-zero code if any key is not ready",
+	Flags: cmd.WrapFlags([]cli.Flag{
+		flags.WalletDirFlag,
+		flags.WalletPasswordFileFlag,
+		cmd.DataDirFlag,
+		flags.BeaconRPCProviderFlag,
+		cmd.GrpcMaxCallRecvMsgSizeFlag,
+		flags.CertFlag,
+		flags.GrpcHeadersFlag,
+		flags.GrpcRetriesFlag,
+		flags.GrpcRetryDelayFlag,
+		flags.ProposerSettingsFlag,
+		flags.ProposerSettingsURLFlag,
+		flags.SuggestedFeeRecipientFlag,
+		flags.EnableValidatorRegistrationFlag,
+		flags.ReadinessOutputFileFlag,
+		flags.DBEncryptionKeyFileFlag,
+		flags.DBEncryptionWithWalletPasswordFlag,
+		features.Mainnet,
+		features.PraterTestnet,
+		features.RopstenTestnet,
+		features.SepoliaTestnet,
+	}),
+	Before: func(cliCtx *cli.Context) error {
+		if err := cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags); err != nil {
+			return err
+		}
+		return features.ConfigureValidator(cliCtx)
+	},
+	Action: func(cliCtx *cli.Context) error {
+		ready, err := checkReadiness(cliCtx)
+		if err != nil {
+			logrus.Fatalf("Could not check validator readiness: %v", err)
+		}
+		if !ready {
+			return cli.Exit("Some validator keys are not ready", 1)
+		}
+		return nil
+	},
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/io/file"
 	"github.com/prysmaticlabs/prysm/validator/accounts/userprompt"
+	validatordb "github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/db/kv"
 	slashingprotection "github.com/prysmaticlabs/prysm/validator/slashing-protection-history"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection-history/format"
 	"github.com/urfave/cli/v2"
 )
 
@@ -34,6 +36,15 @@ func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 		"This command exports your validator's attestation and proposal history into " +
 			"a file that can then be imported into any other Prysm setup across computers",
 	)
+	exportFormat := cliCtx.String(flags.SlashingProtectionExportFormatFlag.Name)
+	if exportFormat != format.CompleteFormat && exportFormat != format.MinimalFormat {
+		return fmt.Errorf(
+			"unsupported slashing protection export format %s, expected %s or %s",
+			exportFormat,
+			format.CompleteFormat,
+			format.MinimalFormat,
+		)
+	}
 	var err error
 	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
 	if !cliCtx.IsSet(cmd.DataDirFlag.Name) {
@@ -54,7 +65,11 @@ func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 		)
 	}
 
-	validatorDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{})
+	encryptionSecret, err := validatordb.EncryptionSecret(cliCtx, "" /* read from --wallet-password-file */)
+	if err != nil {
+		return errors.Wrap(err, "could not get database encryption secret")
+	}
+	validatorDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{EncryptionSecret: encryptionSecret})
 	if err != nil {
 		return errors.Wrapf(err, "could not access validator database at path %s", dataDir)
 	}
@@ -63,7 +78,13 @@ func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 			log.WithError(err).Errorf("Could not close validator DB")
 		}
 	}()
-	eipJSON, err := slashingprotection.ExportStandardProtectionJSON(cliCtx.Context, validatorDB)
+	var eipJSON *format.EIPSlashingProtectionFormat
+	switch exportFormat {
+	case format.CompleteFormat:
+		eipJSON, err = slashingprotection.ExportStandardProtectionJSON(cliCtx.Context, validatorDB)
+	case format.MinimalFormat:
+		eipJSON, err = slashingprotection.ExportMinimalProtectionJSON(cliCtx.Context, validatorDB)
+	}
 	if err != nil {
 		return errors.Wrap(err, "could not export slashing protection history")
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/io/file"
 	"github.com/prysmaticlabs/prysm/validator/accounts/userprompt"
+	validatordb "github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/db/kv"
 	slashingprotection "github.com/prysmaticlabs/prysm/validator/slashing-protection-history"
 	"github.com/urfave/cli/v2"
@@ -45,7 +46,11 @@ func importSlashingProtectionJSON(cliCtx *cli.Context) error {
 	} else {
 		log.Infof("Found existing validator.db inside of %s", dataDir)
 	}
-	valDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{})
+	encryptionSecret, err := validatordb.EncryptionSecret(cliCtx, "" /* read from --wallet-password-file */)
+	if err != nil {
+		return errors.Wrap(err, "could not get database encryption secret")
+	}
+	valDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{EncryptionSecret: encryptionSecret})
 	if err != nil {
 		return errors.Wrapf(err, "could not access validator database at path: %s", dataDir)
 	}
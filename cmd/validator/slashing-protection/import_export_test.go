@@ -29,6 +29,7 @@ func setupCliCtx(
 	set.String(cmd.DataDirFlag.Name, dbPath, "")
 	set.String(flags.SlashingProtectionJSONFileFlag.Name, protectionFilePath, "")
 	set.String(flags.SlashingProtectionExportDirFlag.Name, outputDir, "")
+	set.String(flags.SlashingProtectionExportFormatFlag.Name, format.CompleteFormat, "")
 	require.NoError(tb, set.Set(flags.SlashingProtectionJSONFileFlag.Name, protectionFilePath))
 	assert.NoError(tb, set.Set(cmd.DataDirFlag.Name, dbPath))
 	assert.NoError(tb, set.Set(flags.SlashingProtectionExportDirFlag.Name, outputDir))
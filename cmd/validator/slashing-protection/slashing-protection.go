@@ -21,10 +21,14 @@ var Commands = &cli.Command{
 			Flags: cmd.WrapFlags([]cli.Flag{
 				cmd.DataDirFlag,
 				flags.SlashingProtectionExportDirFlag,
+				flags.SlashingProtectionExportFormatFlag,
 				features.Mainnet,
 				features.PraterTestnet,
 				features.RopstenTestnet,
 				features.SepoliaTestnet,
+				flags.DBEncryptionKeyFileFlag,
+				flags.DBEncryptionWithWalletPasswordFlag,
+				flags.WalletPasswordFileFlag,
 				cmd.AcceptTosFlag,
 			}),
 			Before: func(cliCtx *cli.Context) error {
@@ -53,6 +57,9 @@ var Commands = &cli.Command{
 				features.PraterTestnet,
 				features.RopstenTestnet,
 				features.SepoliaTestnet,
+				flags.DBEncryptionKeyFileFlag,
+				flags.DBEncryptionWithWalletPasswordFlag,
+				flags.WalletPasswordFileFlag,
 				cmd.AcceptTosFlag,
 			}),
 			Before: func(cliCtx *cli.Context) error {
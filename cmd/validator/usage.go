@@ -103,10 +103,12 @@ var appHelpFlagGroups = []flagGroup{
 			flags.SlasherRPCProviderFlag,
 			flags.SlasherCertFlag,
 			flags.DisableAccountMetricsFlag,
+			flags.RedactValidatorIdentitiesFlag,
 			flags.WalletDirFlag,
 			flags.WalletPasswordFileFlag,
 			flags.GraffitiFileFlag,
 			flags.EnableDutyCountDown,
+			flags.DoppelGangerEpochsFlag,
 			flags.Web3SignerURLFlag,
 			flags.Web3SignerPublicValidatorKeysFlag,
 			flags.FeeRecipientConfigFileFlag,
@@ -115,6 +117,17 @@ var appHelpFlagGroups = []flagGroup{
 			flags.ProposerSettingsURLFlag,
 			flags.SuggestedFeeRecipientFlag,
 			flags.EnableValidatorRegistrationFlag,
+			flags.SigningAuditLogDirFlag,
+			flags.SigningAuditLogMaxSizeFlag,
+			flags.PerformanceWebhookURLFlag,
+			flags.PerformanceReportIntervalFlag,
+			flags.PerformanceReportTemplateFlag,
+			flags.ExternalBeaconAPIURLFlag,
+			flags.ExternalBeaconAPIBalanceToleranceFlag,
+			flags.ReportDutyResultsFlag,
+			flags.TenantsConfigFileFlag,
+			flags.DBEncryptionKeyFileFlag,
+			flags.DBEncryptionWithWalletPasswordFlag,
 		},
 	},
 	{
@@ -125,6 +138,7 @@ var appHelpFlagGroups = []flagGroup{
 		Name: "interop",
 		Flags: []cli.Flag{
 			flags.InteropNumValidators,
+			flags.InteropKeyRanges,
 			flags.InteropStartIndex,
 		},
 	},
@@ -44,8 +44,11 @@ type Flags struct {
 	EnableLargerGossipHistory           bool // EnableLargerGossipHistory increases the gossip history we store in our caches.
 	WriteWalletPasswordOnWebOnboarding  bool // WriteWalletPasswordOnWebOnboarding writes the password to disk after Prysm web signup.
 	DisableAttestingHistoryDBCache      bool // DisableAttestingHistoryDBCache for the validator client increases disk reads/writes.
-	EnableDoppelGanger                  bool // EnableDoppelGanger enables doppelganger protection on startup for the validator.
+	DisableDoppelGanger                 bool // DisableDoppelGanger disables doppelganger protection on startup for the validator.
+	EnableBeaconComputedAggregation     bool // EnableBeaconComputedAggregation lets the beacon node determine aggregators and build their aggregates in a single request.
+	EnableBatchedAttestations           bool // EnableBatchedAttestations signs and submits the attestations of all attesters in a slot as a batch.
 	EnableHistoricalSpaceRepresentation bool // EnableHistoricalSpaceRepresentation enables the saving of registry validators in separate buckets to save space
+	EnableStateDiffs                    bool // EnableStateDiffs enables the saving of archived states as diffs against periodic full snapshots to save space.
 	// Logging related toggles.
 	DisableGRPCConnectionLogs bool // Disables logging when a new grpc client has connected.
 
@@ -65,6 +68,14 @@ type Flags struct {
 	EnableVectorizedHTR              bool // EnableVectorizedHTR specifies whether the beacon state will use the optimized sha256 routines.
 	EnableForkChoiceDoublyLinkedTree bool // EnableForkChoiceDoublyLinkedTree specifies whether fork choice store will use a doubly linked tree.
 	EnableBatchGossipAggregation     bool // EnableBatchGossipAggregation specifies whether to further aggregate our gossip batches before verifying them.
+	EnableBlindedBlockAPI            bool // EnableBlindedBlockAPI enables the beacon API endpoints producing and publishing blinded blocks.
+
+	// P2PCompressionCodec compresses gossip and req/resp messages with a codec other than snappy, for private
+	// networks trading CPU for bandwidth.
+	P2PCompressionCodec string
+
+	// BLSBackend selects the BLS implementation, blst or herumi, to diagnose issues in the default blst.
+	BLSBackend string
 
 	// KeystoreImportDebounceInterval specifies the time duration the validator waits to reload new keys if they have
 	// changed on disk. This feature is for advanced use cases only.
@@ -207,6 +218,10 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		log.WithField(enableHistoricalSpaceRepresentation.Name, enableHistoricalSpaceRepresentation.Usage).Warn(enabledFeatureFlag)
 		cfg.EnableHistoricalSpaceRepresentation = true
 	}
+	if ctx.Bool(enableStateDiffs.Name) {
+		log.WithField(enableStateDiffs.Name, enableStateDiffs.Usage).Warn(enabledFeatureFlag)
+		cfg.EnableStateDiffs = true
+	}
 	cfg.EnableNativeState = true
 	if ctx.Bool(disableNativeState.Name) {
 		logDisabled(disableNativeState)
@@ -228,6 +243,18 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(enableGossipBatchAggregation)
 		cfg.EnableBatchGossipAggregation = true
 	}
+	if ctx.Bool(enableBlindedBlockAPI.Name) {
+		logEnabled(enableBlindedBlockAPI)
+		cfg.EnableBlindedBlockAPI = true
+	}
+	if ctx.IsSet(p2pCompressionCodec.Name) {
+		logEnabled(p2pCompressionCodec)
+		cfg.P2PCompressionCodec = ctx.String(p2pCompressionCodec.Name)
+	}
+	if ctx.IsSet(blsBackend.Name) {
+		logEnabled(blsBackend)
+		cfg.BLSBackend = ctx.String(blsBackend.Name)
+	}
 	Init(cfg)
 	return nil
 }
@@ -262,9 +289,21 @@ func ConfigureValidator(ctx *cli.Context) error {
 		logEnabled(enableSlashingProtectionPruning)
 		cfg.EnableSlashingProtectionPruning = true
 	}
-	if ctx.Bool(enableDoppelGangerProtection.Name) {
-		logEnabled(enableDoppelGangerProtection)
-		cfg.EnableDoppelGanger = true
+	if ctx.Bool(disableDoppelGangerProtection.Name) {
+		logDisabled(disableDoppelGangerProtection)
+		cfg.DisableDoppelGanger = true
+	}
+	if ctx.Bool(enableBeaconComputedAggregation.Name) {
+		logEnabled(enableBeaconComputedAggregation)
+		cfg.EnableBeaconComputedAggregation = true
+	}
+	if ctx.Bool(enableBatchedAttestations.Name) {
+		logEnabled(enableBatchedAttestations)
+		cfg.EnableBatchedAttestations = true
+	}
+	if ctx.IsSet(blsBackend.Name) {
+		logEnabled(blsBackend)
+		cfg.BLSBackend = ctx.String(blsBackend.Name)
 	}
 	cfg.KeystoreImportDebounceInterval = ctx.Duration(dynamicKeyReloadDebounceInterval.Name)
 	Init(cfg)
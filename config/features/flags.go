@@ -86,14 +86,25 @@ var (
 		Usage: "Enables a slasher in the beacon node for detecting slashable offenses",
 	}
 	enableSlashingProtectionPruning = &cli.BoolFlag{
-		Name:  "enable-slashing-protection-history-pruning",
-		Usage: "Enables the pruning of the validator client's slashing protection database",
-	}
-	enableDoppelGangerProtection = &cli.BoolFlag{
-		Name: "enable-doppelganger",
-		Usage: "Enables the validator to perform a doppelganger check. (Warning): This is not " +
-			"a foolproof method to find duplicate instances in the network. Your validator will still be" +
-			" vulnerable if it is being run in unsafe configurations.",
+		Name: "enable-slashing-protection-history-pruning",
+		Usage: "Enables the pruning of the validator client's slashing protection database, on startup and " +
+			"periodically in the background for the history older than the weak subjectivity period",
+	}
+	disableDoppelGangerProtection = &cli.BoolFlag{
+		Name: "disable-doppelganger",
+		Usage: "Disables the doppelganger check, which makes the validator listen for attestations and blocks of its keys " +
+			"for a few epochs on startup before signing, and refuse to start if any is found. (Warning): The check is not " +
+			"a foolproof method to find duplicate instances in the network, but disabling it removes a safety net.",
+	}
+	enableBeaconComputedAggregation = &cli.BoolFlag{
+		Name: "enable-beacon-computed-aggregation",
+		Usage: "Enables the validator to submit the selection proofs of all its attesters in a single request " +
+			"at the aggregation deadline, letting the beacon node determine the aggregators and build their aggregates.",
+	}
+	enableBatchedAttestations = &cli.BoolFlag{
+		Name: "enable-batched-attestations",
+		Usage: "Enables the validator to sign the attestations of all its attesters sharing the same data in a single " +
+			"keymanager request and to submit them to the beacon node over a single stream.",
 	}
 	enableHistoricalSpaceRepresentation = &cli.BoolFlag{
 		Name: "enable-historical-state-representation",
@@ -101,6 +112,22 @@ var (
 			" (Warning): Once enabled, this feature migrates your database in to a new schema and " +
 			"there is no going back. At worst, your entire database might get corrupted.",
 	}
+	enableStateDiffs = &cli.BoolFlag{
+		Name: "enable-state-diffs",
+		Usage: "Enables the beacon chain to save archived states as diffs against periodic full snapshots, " +
+			"requires --enable-historical-state-representation. (Warning): Once enabled, this feature migrates " +
+			"the archived states of your database in to a new schema and there is no going back.",
+	}
+	p2pCompressionCodec = &cli.StringFlag{
+		Name: "p2p-compression-codec",
+		Usage: "Compresses p2p messages with the given codec (snappy, zstd) to trade CPU for bandwidth. " +
+			"(Warning): Gossip is only exchanged with peers using the same codec, use it on private networks only.",
+	}
+	blsBackend = &cli.StringFlag{
+		Name: "bls-backend",
+		Usage: "Selects the BLS implementation (blst, herumi) used to sign and verify, for diagnosing issues in " +
+			"the default blst implementation. (Warning): herumi is slower and not meant for production use.",
+	}
 	disableNativeState = &cli.BoolFlag{
 		Name:  "disable-native-state",
 		Usage: "Disables representing the beacon state as a pure Go struct.",
@@ -123,6 +150,11 @@ var (
 		Name:  "enable-gossip-batch-aggregation",
 		Usage: "Enables new methods to further aggregate our gossip batches before verifying them.",
 	}
+	enableBlindedBlockAPI = &cli.BoolFlag{
+		Name: "enable-blinded-block-api",
+		Usage: "Enables the beacon API endpoints producing and publishing blinded blocks, meant for external " +
+			"builder workflows on devnets.",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -145,13 +177,14 @@ var ValidatorFlags = append(deprecatedFlags, []cli.Flag{
 	dynamicKeyReloadDebounceInterval,
 	attestTimely,
 	enableSlashingProtectionPruning,
-	enableDoppelGangerProtection,
+	disableDoppelGangerProtection,
+	enableBeaconComputedAggregation,
+	enableBatchedAttestations,
+	blsBackend,
 }...)
 
 // E2EValidatorFlags contains a list of the validator feature flags to be tested in E2E.
-var E2EValidatorFlags = []string{
-	"--enable-doppelganger",
-}
+var E2EValidatorFlags = []string{}
 
 // BeaconChainFlags contains a list of all the feature flags that apply to the beacon-chain client.
 var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
@@ -168,11 +201,15 @@ var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
 	disableBroadcastSlashingFlag,
 	enableSlasherFlag,
 	enableHistoricalSpaceRepresentation,
+	enableStateDiffs,
+	p2pCompressionCodec,
+	blsBackend,
 	disableNativeState,
 	enablePullTips,
 	enableVecHTR,
 	enableForkChoiceDoublyLinkedTree,
 	enableGossipBatchAggregation,
+	enableBlindedBlockAPI,
 }...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
@@ -0,0 +1,126 @@
+package wrapper
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// ErrUnknownForkDigest is returned when a fork digest does not match the digest of any fork of the
+// beacon config.
+var ErrUnknownForkDigest = errors.New("fork digest does not match any known fork")
+
+// blockVersions lists the block versions, oldest first.
+var blockVersions = []int{version.Phase0, version.Altair, version.Bellatrix}
+
+// forkVersion returns the fork version of the beacon config under which blocks of the given
+// version are produced.
+func forkVersion(v int) ([]byte, error) {
+	cfg := params.BeaconConfig()
+	switch v {
+	case version.Phase0:
+		return cfg.GenesisForkVersion, nil
+	case version.Altair:
+		return cfg.AltairForkVersion, nil
+	case version.Bellatrix:
+		return cfg.BellatrixForkVersion, nil
+	default:
+		return nil, errors.Wrapf(ErrUnsupportedSignedBeaconBlock, "no fork version for block version %s", version.String(v))
+	}
+}
+
+// VersionAtSlot returns the version of the blocks of the given slot, following the fork schedule
+// of the beacon config.
+func VersionAtSlot(slot types.Slot) int {
+	epoch := slots.ToEpoch(slot)
+	cfg := params.BeaconConfig()
+	switch {
+	case epoch >= cfg.BellatrixForkEpoch:
+		return version.Bellatrix
+	case epoch >= cfg.AltairForkEpoch:
+		return version.Altair
+	default:
+		return version.Phase0
+	}
+}
+
+// VersionForDigest returns the version of the blocks sent under the given fork digest, on the chain
+// with the given genesis validators root.
+func VersionForDigest(digest [4]byte, genesisValidatorsRoot []byte) (int, error) {
+	for _, v := range blockVersions {
+		fv, err := forkVersion(v)
+		if err != nil {
+			return 0, err
+		}
+		// The fork digest is the first 4 bytes of the fork data root, as computed by signing.ComputeForkDigest.
+		root, err := (&ethpb.ForkData{CurrentVersion: fv, GenesisValidatorsRoot: genesisValidatorsRoot}).HashTreeRoot()
+		if err != nil {
+			return 0, err
+		}
+		if bytesutil.ToBytes4(root[:]) == digest {
+			return v, nil
+		}
+	}
+	return 0, errors.Wrapf(ErrUnknownForkDigest, "digest %#x", digest)
+}
+
+// NewSignedBeaconBlockForVersion returns an empty signed beacon block of the given version, to
+// be unmarshaled into.
+func NewSignedBeaconBlockForVersion(v int) (interfaces.SignedBeaconBlock, error) {
+	switch v {
+	case version.Phase0:
+		return WrappedSignedBeaconBlock(&ethpb.SignedBeaconBlock{})
+	case version.Altair:
+		return WrappedSignedBeaconBlock(&ethpb.SignedBeaconBlockAltair{Block: &ethpb.BeaconBlockAltair{}})
+	case version.Bellatrix:
+		return WrappedSignedBeaconBlock(&ethpb.SignedBeaconBlockBellatrix{Block: &ethpb.BeaconBlockBellatrix{}})
+	default:
+		return nil, errors.Wrapf(ErrUnsupportedSignedBeaconBlock, "unknown block version %s", version.String(v))
+	}
+}
+
+// NewSignedBeaconBlockForSlot returns an empty signed beacon block of the fork of the given slot.
+func NewSignedBeaconBlockForSlot(slot types.Slot) (interfaces.SignedBeaconBlock, error) {
+	return NewSignedBeaconBlockForVersion(VersionAtSlot(slot))
+}
+
+// NewSignedBeaconBlockForDigest returns an empty signed beacon block of the fork of the given
+// fork digest, on the chain with the given genesis validators root.
+func NewSignedBeaconBlockForDigest(digest [4]byte, genesisValidatorsRoot []byte) (interfaces.SignedBeaconBlock, error) {
+	v, err := VersionForDigest(digest, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+	return NewSignedBeaconBlockForVersion(v)
+}
+
+// UnmarshalSSZForSlot unmarshals the ssz encoded signed beacon block of the given slot into a
+// block of the fork of the slot.
+func UnmarshalSSZForSlot(enc []byte, slot types.Slot) (interfaces.SignedBeaconBlock, error) {
+	blk, err := NewSignedBeaconBlockForSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+	if err := blk.UnmarshalSSZ(enc); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal %s block", version.String(blk.Version()))
+	}
+	return blk, nil
+}
+
+// UnmarshalSSZForDigest unmarshals the ssz encoded signed beacon block sent under the given fork
+// digest into a block of the fork of the digest.
+func UnmarshalSSZForDigest(enc []byte, digest [4]byte, genesisValidatorsRoot []byte) (interfaces.SignedBeaconBlock, error) {
+	blk, err := NewSignedBeaconBlockForDigest(digest, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+	if err := blk.UnmarshalSSZ(enc); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal %s block", version.String(blk.Version()))
+	}
+	return blk, nil
+}
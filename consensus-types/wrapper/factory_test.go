@@ -0,0 +1,99 @@
+package wrapper_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func setupForkEpochs(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 1
+	cfg.BellatrixForkEpoch = 2
+	params.OverrideBeaconConfig(cfg)
+}
+
+func TestVersionAtSlot(t *testing.T) {
+	setupForkEpochs(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	tests := []struct {
+		slot types.Slot
+		want int
+	}{
+		{slot: 0, want: version.Phase0},
+		{slot: slotsPerEpoch - 1, want: version.Phase0},
+		{slot: slotsPerEpoch, want: version.Altair},
+		{slot: 2*slotsPerEpoch - 1, want: version.Altair},
+		{slot: 2 * slotsPerEpoch, want: version.Bellatrix},
+		{slot: 100 * slotsPerEpoch, want: version.Bellatrix},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, wrapper.VersionAtSlot(tt.slot), "slot %d", tt.slot)
+		blk, err := wrapper.NewSignedBeaconBlockForSlot(tt.slot)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, blk.Version(), "slot %d", tt.slot)
+	}
+}
+
+func TestVersionForDigest(t *testing.T) {
+	setupForkEpochs(t)
+	root := bytesutil.PadTo([]byte("genesis validators root"), 32)
+	for v, fv := range map[int][]byte{
+		version.Phase0:    params.BeaconConfig().GenesisForkVersion,
+		version.Altair:    params.BeaconConfig().AltairForkVersion,
+		version.Bellatrix: params.BeaconConfig().BellatrixForkVersion,
+	} {
+		digest, err := signing.ComputeForkDigest(fv, root)
+		require.NoError(t, err)
+		got, err := wrapper.VersionForDigest(digest, root)
+		require.NoError(t, err)
+		assert.Equal(t, v, got)
+		blk, err := wrapper.NewSignedBeaconBlockForDigest(digest, root)
+		require.NoError(t, err)
+		assert.Equal(t, v, blk.Version())
+	}
+
+	_, err := wrapper.VersionForDigest([4]byte{1, 2, 3, 4}, root)
+	require.ErrorIs(t, err, wrapper.ErrUnknownForkDigest)
+}
+
+func TestNewSignedBeaconBlockForVersion_Unknown(t *testing.T) {
+	_, err := wrapper.NewSignedBeaconBlockForVersion(100)
+	require.ErrorIs(t, err, wrapper.ErrUnsupportedSignedBeaconBlock)
+}
+
+func TestUnmarshalSSZForSlot(t *testing.T) {
+	setupForkEpochs(t)
+	altairBlock := util.NewBeaconBlockAltair()
+	altairBlock.Block.Slot = params.BeaconConfig().SlotsPerEpoch + 1
+	enc, err := altairBlock.MarshalSSZ()
+	require.NoError(t, err)
+
+	blk, err := wrapper.UnmarshalSSZForSlot(enc, altairBlock.Block.Slot)
+	require.NoError(t, err)
+	assert.Equal(t, version.Altair, blk.Version())
+	assert.Equal(t, altairBlock.Block.Slot, blk.Block().Slot())
+	pb, err := blk.PbAltairBlock()
+	require.NoError(t, err)
+	assert.DeepEqual(t, altairBlock, pb)
+
+	// The encoding of an altair block can't be unmarshaled into a bellatrix block.
+	_, err = wrapper.UnmarshalSSZForSlot(enc, 2*params.BeaconConfig().SlotsPerEpoch)
+	assert.ErrorContains(t, "could not unmarshal bellatrix block", err)
+
+	root := bytesutil.PadTo([]byte("genesis validators root"), 32)
+	digest, err := signing.ComputeForkDigest(params.BeaconConfig().AltairForkVersion, root)
+	require.NoError(t, err)
+	blk, err = wrapper.UnmarshalSSZForDigest(enc, digest, root)
+	require.NoError(t, err)
+	assert.Equal(t, version.Altair, blk.Version())
+}
@@ -163,6 +163,16 @@ func (pq *PriorityQueue) RetrieveByKey(key string) *Item {
 	return pq.data[item.index]
 }
 
+// Items returns the items of the queue, in no particular order.
+func (pq *PriorityQueue) Items() []*Item {
+	pq.lock.RLock()
+	defer pq.lock.RUnlock()
+
+	items := make([]*Item, len(pq.data))
+	copy(items, pq.data)
+	return items
+}
+
 // Len returns the number of items in the queue data structure. Do not use this
 // method directly on the queue, use PriorityQueue.Len() instead.
 func (q queue) Len() int { return len(q) }
@@ -267,3 +267,26 @@ func testValidateInternalData(t *testing.T, pq *PriorityQueue, expectedSize int,
 		}
 	}
 }
+
+func TestPriorityQueue_Items(t *testing.T) {
+	pq := New()
+	require.Equal(t, 0, len(pq.Items()))
+
+	tc := testCases()
+	for _, i := range tc {
+		require.NoError(t, pq.Push(i))
+	}
+	items := pq.Items()
+	require.Equal(t, len(tc), len(items))
+	keys := make(map[string]bool)
+	for _, i := range items {
+		keys[i.Key] = true
+	}
+	for _, i := range tc {
+		require.Equal(t, true, keys[i.Key])
+	}
+
+	// The returned slice is a copy, the queue is left untouched.
+	items[0] = nil
+	testValidateInternalData(t, pq, len(tc), false)
+}
@@ -0,0 +1,107 @@
+package bls
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/crypto/bls/blst"
+	"github.com/prysmaticlabs/prysm/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/crypto/bls/herumi"
+)
+
+// Names of the BLS implementations which can be selected with SetBackend.
+const (
+	BlstBackend   = "blst"
+	HerumiBackend = "herumi"
+)
+
+// backend holds the functions of a BLS implementation which are exposed by this package.
+type backend struct {
+	name                          string
+	secretKeyFromBytes            func(privKey []byte) (common.SecretKey, error)
+	publicKeyFromBytes            func(pubKey []byte) (common.PublicKey, error)
+	signatureFromBytes            func(sig []byte) (common.Signature, error)
+	multipleSignaturesFromBytes   func(sigs [][]byte) ([]common.Signature, error)
+	aggregatePublicKeys           func(pubs [][]byte) (common.PublicKey, error)
+	aggregateMultiplePubkeys      func(pubs []common.PublicKey) common.PublicKey
+	aggregateSignatures           func(sigs []common.Signature) common.Signature
+	aggregateCompressedSignatures func(multiSigs [][]byte) (common.Signature, error)
+	verifyMultipleSignatures      func(sigs [][]byte, msgs [][32]byte, pubKeys []common.PublicKey) (bool, error)
+	newAggregateSignature         func() common.Signature
+	randKey                       func() (common.SecretKey, error)
+}
+
+var backends = map[string]*backend{
+	BlstBackend: {
+		name: BlstBackend,
+		// The blst functions returning keys and signatures are wrapped as their stubs built with the
+		// blst_disabled tag return concrete types.
+		secretKeyFromBytes: func(privKey []byte) (common.SecretKey, error) {
+			return blst.SecretKeyFromBytes(privKey)
+		},
+		publicKeyFromBytes: func(pubKey []byte) (common.PublicKey, error) {
+			return blst.PublicKeyFromBytes(pubKey)
+		},
+		signatureFromBytes: func(sig []byte) (common.Signature, error) {
+			return blst.SignatureFromBytes(sig)
+		},
+		multipleSignaturesFromBytes: blst.MultipleSignaturesFromBytes,
+		aggregatePublicKeys: func(pubs [][]byte) (common.PublicKey, error) {
+			return blst.AggregatePublicKeys(pubs)
+		},
+		aggregateMultiplePubkeys:      blst.AggregateMultiplePubkeys,
+		aggregateSignatures:           blst.AggregateSignatures,
+		aggregateCompressedSignatures: blst.AggregateCompressedSignatures,
+		verifyMultipleSignatures:      blst.VerifyMultipleSignatures,
+		newAggregateSignature:         blst.NewAggregateSignature,
+		randKey:                       blst.RandKey,
+	},
+	HerumiBackend: {
+		name:                          HerumiBackend,
+		secretKeyFromBytes:            herumi.SecretKeyFromBytes,
+		publicKeyFromBytes:            herumi.PublicKeyFromBytes,
+		signatureFromBytes:            herumi.SignatureFromBytes,
+		multipleSignaturesFromBytes:   herumi.MultipleSignaturesFromBytes,
+		aggregatePublicKeys:           herumi.AggregatePublicKeys,
+		aggregateMultiplePubkeys:      herumi.AggregateMultiplePubkeys,
+		aggregateSignatures:           herumi.AggregateSignatures,
+		aggregateCompressedSignatures: herumi.AggregateCompressedSignatures,
+		verifyMultipleSignatures:      herumi.VerifyMultipleSignatures,
+		newAggregateSignature:         herumi.NewAggregateSignature,
+		randKey:                       herumi.RandKey,
+	},
+}
+
+// current is the backend used by the package level functions, blst unless another one is selected.
+var current = backends[BlstBackend]
+
+// SetBackend selects the BLS implementation used by this package, an empty name selects blst. Keys and
+// signatures of one backend cannot be used with another, so this must be called at startup before any
+// of them are created. It is meant to diagnose issues in a backend, blst is the only one used in production.
+func SetBackend(name string) error {
+	if name == "" {
+		name = BlstBackend
+	}
+	b, ok := backends[name]
+	if !ok {
+		return fmt.Errorf("unknown BLS backend %s, supported backends are: %s", name, strings.Join(Backends(), ", "))
+	}
+	current = b
+	return nil
+}
+
+// Backend returns the name of the BLS implementation in use.
+func Backend() string {
+	return current.name
+}
+
+// Backends returns the names of the supported BLS implementations, sorted.
+func Backends() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
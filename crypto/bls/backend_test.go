@@ -0,0 +1,97 @@
+package bls
+
+import (
+	"crypto/subtle"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// useBackend selects a BLS backend for the duration of the test.
+func useBackend(t *testing.T, name string) {
+	require.NoError(t, SetBackend(name))
+	t.Cleanup(func() {
+		require.NoError(t, SetBackend(BlstBackend))
+	})
+}
+
+func TestSetBackend(t *testing.T) {
+	assert.Equal(t, BlstBackend, Backend())
+	useBackend(t, HerumiBackend)
+	assert.Equal(t, HerumiBackend, Backend())
+	require.NoError(t, SetBackend(""))
+	assert.Equal(t, BlstBackend, Backend())
+	require.ErrorContains(t, "unknown BLS backend milagro, supported backends are: blst, herumi", SetBackend("milagro"))
+	assert.Equal(t, BlstBackend, Backend())
+}
+
+func TestBackends_Interoperable(t *testing.T) {
+	msg := [32]byte{'h', 'e', 'l', 'l', 'o'}
+	for _, signer := range Backends() {
+		for _, verifier := range Backends() {
+			t.Run(signer+" to "+verifier, func(t *testing.T) {
+				useBackend(t, signer)
+				keys := make([][]byte, 3)
+				sigs := make([][]byte, 3)
+				for i := range keys {
+					sk, err := RandKey()
+					require.NoError(t, err)
+					keys[i] = sk.Marshal()
+					sigs[i] = sk.Sign(msg[:]).Marshal()
+				}
+
+				require.NoError(t, SetBackend(verifier))
+				pubs := make([]PublicKey, len(keys))
+				for i, k := range keys {
+					sk, err := SecretKeyFromBytes(k)
+					require.NoError(t, err)
+					pubs[i] = sk.PublicKey()
+					sig, err := SignatureFromBytes(sigs[i])
+					require.NoError(t, err)
+					assert.Equal(t, true, sig.Verify(pubs[i], msg[:]))
+				}
+				aggSig, err := AggregateCompressedSignatures(sigs)
+				require.NoError(t, err)
+				assert.Equal(t, true, aggSig.FastAggregateVerify(pubs, msg))
+				valid, err := VerifyMultipleSignatures(sigs, [][32]byte{msg, msg, msg}, pubs)
+				require.NoError(t, err)
+				assert.Equal(t, true, valid)
+			})
+		}
+	}
+}
+
+func TestSecretKeyHandling_ConstantTime(t *testing.T) {
+	for _, name := range Backends() {
+		t.Run(name, func(t *testing.T) {
+			useBackend(t, name)
+			sk, err := RandKey()
+			require.NoError(t, err)
+
+			// Keys are compared in constant time, and marshaling returns a copy so that callers zeroing
+			// the bytes do not alter the key.
+			b := sk.Marshal()
+			sk2, err := SecretKeyFromBytes(b)
+			require.NoError(t, err)
+			assert.Equal(t, 1, subtle.ConstantTimeCompare(b, sk2.Marshal()))
+			for i := range b {
+				b[i] = 0
+			}
+			assert.Equal(t, 1, subtle.ConstantTimeCompare(sk2.Marshal(), sk.Marshal()))
+			assert.Equal(t, 0, subtle.ConstantTimeCompare(b, sk.Marshal()))
+
+			// The zero check reads the whole key, a key with any single bit set is not zero.
+			var key [32]byte
+			assert.Equal(t, true, common.IsZero(key[:]))
+			for i := range key {
+				for bit := 0; bit < 8; bit++ {
+					key[i] = 1 << bit
+					require.Equal(t, false, common.IsZero(key[:]), "byte %d bit %d", i, bit)
+					key[i] = 0
+				}
+			}
+		})
+	}
+}
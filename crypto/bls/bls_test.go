@@ -13,6 +13,11 @@ func TestDisallowZeroSecretKeys(t *testing.T) {
 		_, err := SecretKeyFromBytes(common.ZeroSecretKey[:])
 		require.Equal(t, common.ErrSecretUnmarshal, err)
 	})
+	t.Run("herumi", func(t *testing.T) {
+		useBackend(t, HerumiBackend)
+		_, err := SecretKeyFromBytes(common.ZeroSecretKey[:])
+		require.Equal(t, common.ErrZeroKey, err)
+	})
 }
 
 func TestDisallowZeroPublicKeys(t *testing.T) {
@@ -20,6 +25,11 @@ func TestDisallowZeroPublicKeys(t *testing.T) {
 		_, err := PublicKeyFromBytes(common.InfinitePublicKey[:])
 		require.Equal(t, common.ErrInfinitePubKey, err)
 	})
+	t.Run("herumi", func(t *testing.T) {
+		useBackend(t, HerumiBackend)
+		_, err := PublicKeyFromBytes(common.InfinitePublicKey[:])
+		require.Equal(t, common.ErrInfinitePubKey, err)
+	})
 }
 
 func TestDisallowZeroPublicKeys_AggregatePubkeys(t *testing.T) {
@@ -27,4 +37,9 @@ func TestDisallowZeroPublicKeys_AggregatePubkeys(t *testing.T) {
 		_, err := AggregatePublicKeys([][]byte{common.InfinitePublicKey[:], common.InfinitePublicKey[:]})
 		require.Equal(t, common.ErrInfinitePubKey, err)
 	})
+	t.Run("herumi", func(t *testing.T) {
+		useBackend(t, HerumiBackend)
+		_, err := AggregatePublicKeys([][]byte{common.InfinitePublicKey[:], common.InfinitePublicKey[:]})
+		require.Equal(t, common.ErrInfinitePubKey, err)
+	})
 }
@@ -3,7 +3,6 @@
 package blst
 
 import (
-	"crypto/subtle"
 	"fmt"
 
 	"github.com/prysmaticlabs/prysm/config/features"
@@ -57,11 +56,7 @@ func (s *bls12SecretKey) PublicKey() common.PublicKey {
 
 // IsZero checks if the secret key is a zero key.
 func IsZero(sKey []byte) bool {
-	b := byte(0)
-	for _, s := range sKey {
-		b |= s
-	}
-	return subtle.ConstantTimeByteEq(b, 0) == 1
+	return common.IsZero(sKey)
 }
 
 // Sign a message using a secret key - in a beacon/validator client.
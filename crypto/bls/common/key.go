@@ -0,0 +1,13 @@
+package common
+
+import "crypto/subtle"
+
+// IsZero checks in constant time if the secret key is a zero key, every byte of the key is read
+// regardless of its contents so that the check does not leak where the key first differs from zero.
+func IsZero(sKey []byte) bool {
+	b := byte(0)
+	for _, s := range sKey {
+		b |= s
+	}
+	return subtle.ConstantTimeByteEq(b, 0) == 1
+}
@@ -0,0 +1,7 @@
+// Package herumi implements a go-wrapper around a library implementing the
+// the BLS12-381 curve and signature scheme. This package exposes a public API for
+// verifying and aggregating BLS signatures used by Ethereum.
+//
+// This implementation uses the library written by Herumi, bls-eth-go-binary. It is
+// kept as a secondary backend to diagnose issues in the default blst implementation.
+package herumi
@@ -0,0 +1,110 @@
+package herumi
+
+import (
+	"fmt"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
+	"github.com/prysmaticlabs/prysm/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/bls/common"
+)
+
+var maxKeys = 1000000
+var pubkeyCache = lruwrpr.New(maxKeys)
+
+// PublicKey used in the BLS signature scheme.
+type PublicKey struct {
+	p *bls.PublicKey
+}
+
+// PublicKeyFromBytes creates a BLS public key from a  BigEndian byte slice.
+func PublicKeyFromBytes(pubKey []byte) (common.PublicKey, error) {
+	if features.Get().SkipBLSVerify {
+		return &PublicKey{}, nil
+	}
+	if len(pubKey) != params.BeaconConfig().BLSPubkeyLength {
+		return nil, fmt.Errorf("public key must be %d bytes", params.BeaconConfig().BLSPubkeyLength)
+	}
+	newKey := (*[fieldparams.BLSPubkeyLength]byte)(pubKey)
+	if cv, ok := pubkeyCache.Get(*newKey); ok {
+		return cv.(*PublicKey).Copy(), nil
+	}
+	// The subgroup check is done when deserializing, see HerumiInit.
+	p := &bls.PublicKey{}
+	if err := p.Deserialize(pubKey); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal bytes into public key")
+	}
+	if p.IsZero() {
+		return nil, common.ErrInfinitePubKey
+	}
+	pubKeyObj := &PublicKey{p: p}
+	copiedKey := pubKeyObj.Copy()
+	cacheKey := *newKey
+	pubkeyCache.Add(cacheKey, copiedKey)
+	return pubKeyObj, nil
+}
+
+// AggregatePublicKeys aggregates the provided raw public keys into a single key.
+func AggregatePublicKeys(pubs [][]byte) (common.PublicKey, error) {
+	if features.Get().SkipBLSVerify {
+		return &PublicKey{}, nil
+	}
+	if len(pubs) == 0 {
+		return nil, errors.New("nil or empty public keys")
+	}
+	agg := &bls.PublicKey{}
+	for _, pubkey := range pubs {
+		pubKeyObj, err := PublicKeyFromBytes(pubkey)
+		if err != nil {
+			return nil, err
+		}
+		agg.Add(pubKeyObj.(*PublicKey).p)
+	}
+	return &PublicKey{p: agg}, nil
+}
+
+// Marshal a public key into a LittleEndian byte slice.
+func (p *PublicKey) Marshal() []byte {
+	return p.p.Serialize()
+}
+
+// Copy the public key to a new pointer reference.
+func (p *PublicKey) Copy() common.PublicKey {
+	np := *p.p
+	return &PublicKey{p: &np}
+}
+
+// IsInfinite checks if the public key is infinite.
+func (p *PublicKey) IsInfinite() bool {
+	return p.p.IsZero()
+}
+
+// Equals checks if the provided public key is equal to
+// the current one.
+func (p *PublicKey) Equals(p2 common.PublicKey) bool {
+	return p.p.IsEqual(p2.(*PublicKey).p)
+}
+
+// Aggregate two public keys.
+func (p *PublicKey) Aggregate(p2 common.PublicKey) common.PublicKey {
+	if features.Get().SkipBLSVerify {
+		return p
+	}
+	p.p.Add(p2.(*PublicKey).p)
+	return p
+}
+
+// AggregateMultiplePubkeys aggregates the provided decompressed keys into a single key.
+func AggregateMultiplePubkeys(pubkeys []common.PublicKey) common.PublicKey {
+	if features.Get().SkipBLSVerify {
+		return &PublicKey{}
+	}
+	agg := &bls.PublicKey{}
+	for _, pubkey := range pubkeys {
+		agg.Add(pubkey.(*PublicKey).p)
+	}
+	return &PublicKey{p: agg}
+}
@@ -0,0 +1,73 @@
+package herumi
+
+import (
+	"fmt"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/bls/common"
+)
+
+// bls12SecretKey used in the BLS signature scheme.
+type bls12SecretKey struct {
+	p *bls.SecretKey
+}
+
+// RandKey creates a new private key using a random method provided as an io.Reader.
+func RandKey() (common.SecretKey, error) {
+	secKey := &bls.SecretKey{}
+	secKey.SetByCSPRNG()
+	wrappedKey := &bls12SecretKey{p: secKey}
+	// Defensive check, that we have not generated a zero secret key.
+	if common.IsZero(wrappedKey.Marshal()) {
+		return nil, common.ErrZeroKey
+	}
+	return wrappedKey, nil
+}
+
+// SecretKeyFromBytes creates a BLS private key from a BigEndian byte slice.
+func SecretKeyFromBytes(privKey []byte) (common.SecretKey, error) {
+	if len(privKey) != params.BeaconConfig().BLSSecretKeyLength {
+		return nil, fmt.Errorf("secret key must be %d bytes", params.BeaconConfig().BLSSecretKeyLength)
+	}
+	// Unlike blst, herumi accepts a zero key when deserializing.
+	if common.IsZero(privKey) {
+		return nil, common.ErrZeroKey
+	}
+	secKey := &bls.SecretKey{}
+	if err := secKey.Deserialize(privKey); err != nil {
+		return nil, common.ErrSecretUnmarshal
+	}
+	return &bls12SecretKey{p: secKey}, nil
+}
+
+// PublicKey obtains the public key corresponding to the BLS secret key.
+func (s *bls12SecretKey) PublicKey() common.PublicKey {
+	return &PublicKey{p: s.p.GetPublicKey()}
+}
+
+// Sign a message using a secret key - in a beacon/validator client.
+//
+// In IETF draft BLS specification:
+// Sign(SK, message) -> signature: a signing algorithm that generates
+//      a deterministic signature given a secret key SK and a message.
+//
+// In Ethereum proof of stake specification:
+// def Sign(SK: int, message: Bytes) -> BLSSignature
+func (s *bls12SecretKey) Sign(msg []byte) common.Signature {
+	if features.Get().SkipBLSVerify {
+		return &Signature{}
+	}
+	return &Signature{s: s.p.SignByte(msg)}
+}
+
+// Marshal a secret key into a BigEndian byte slice, left padded to the secret key length.
+func (s *bls12SecretKey) Marshal() []byte {
+	keyBytes := s.p.Serialize()
+	if len(keyBytes) < params.BeaconConfig().BLSSecretKeyLength {
+		emptyBytes := make([]byte, params.BeaconConfig().BLSSecretKeyLength-len(keyBytes))
+		keyBytes = append(emptyBytes, keyBytes...)
+	}
+	return keyBytes
+}
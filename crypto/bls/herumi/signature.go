@@ -0,0 +1,232 @@
+package herumi
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/crypto/bls/common"
+)
+
+// Signature used in the BLS signature scheme.
+type Signature struct {
+	s *bls.Sign
+}
+
+// SignatureFromBytes creates a BLS signature from a LittleEndian byte slice.
+func SignatureFromBytes(sig []byte) (common.Signature, error) {
+	if features.Get().SkipBLSVerify {
+		return &Signature{}, nil
+	}
+	if len(sig) != fieldparams.BLSSignatureLength {
+		return nil, fmt.Errorf("signature must be %d bytes", fieldparams.BLSSignatureLength)
+	}
+	// The subgroup check is done when deserializing, see HerumiInit. Infinity is not rejected since
+	// an aggregated signature could be infinite.
+	signature := &bls.Sign{}
+	if err := signature.Deserialize(sig); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal bytes into signature")
+	}
+	return &Signature{s: signature}, nil
+}
+
+// AggregateCompressedSignatures converts a list of compressed signatures into a single, aggregated sig.
+func AggregateCompressedSignatures(multiSigs [][]byte) (common.Signature, error) {
+	rawSigs := make([]bls.Sign, len(multiSigs))
+	for i, sig := range multiSigs {
+		if err := rawSigs[i].Deserialize(sig); err != nil {
+			return nil, errors.New("provided signatures fail the group check and cannot be compressed")
+		}
+	}
+	signature := &bls.Sign{}
+	signature.Aggregate(rawSigs)
+	return &Signature{s: signature}, nil
+}
+
+// MultipleSignaturesFromBytes creates a group of BLS signatures from a LittleEndian 2d-byte slice.
+func MultipleSignaturesFromBytes(multiSigs [][]byte) ([]common.Signature, error) {
+	if features.Get().SkipBLSVerify {
+		return []common.Signature{}, nil
+	}
+	if len(multiSigs) == 0 {
+		return nil, fmt.Errorf("0 signatures provided to the method")
+	}
+	wrappedSigs := make([]common.Signature, len(multiSigs))
+	for i, sig := range multiSigs {
+		signature, err := SignatureFromBytes(sig)
+		if err != nil {
+			return nil, err
+		}
+		wrappedSigs[i] = signature
+	}
+	return wrappedSigs, nil
+}
+
+// Verify a bls signature given a public key, a message.
+//
+// In IETF draft BLS specification:
+// Verify(PK, message, signature) -> VALID or INVALID: a verification
+//      algorithm that outputs VALID if signature is a valid signature of
+//      message under public key PK, and INVALID otherwise.
+//
+// In the Ethereum proof of stake specification:
+// def Verify(PK: BLSPubkey, message: Bytes, signature: BLSSignature) -> bool
+func (s *Signature) Verify(pubKey common.PublicKey, msg []byte) bool {
+	if features.Get().SkipBLSVerify {
+		return true
+	}
+	return s.s.VerifyByte(pubKey.(*PublicKey).p, msg)
+}
+
+// AggregateVerify verifies each public key against its respective message. This is vulnerable to
+// rogue public-key attack. Each user must provide a proof-of-knowledge of the public key.
+//
+// Note: The msgs must be distinct. For maximum performance, this method does not ensure distinct
+// messages.
+//
+// In IETF draft BLS specification:
+// AggregateVerify((PK_1, message_1), ..., (PK_n, message_n),
+//      signature) -> VALID or INVALID: an aggregate verification
+//      algorithm that outputs VALID if signature is a valid aggregated
+//      signature for a collection of public keys and messages, and
+//      outputs INVALID otherwise.
+//
+// In the Ethereum proof of stake specification:
+// def AggregateVerify(pairs: Sequence[PK: BLSPubkey, message: Bytes], signature: BLSSignature) -> bool
+//
+// Deprecated: Use FastAggregateVerify or use this method in spectests only.
+func (s *Signature) AggregateVerify(pubKeys []common.PublicKey, msgs [][32]byte) bool {
+	if features.Get().SkipBLSVerify {
+		return true
+	}
+	size := len(pubKeys)
+	if size == 0 {
+		return false
+	}
+	if size != len(msgs) {
+		return false
+	}
+	msgSlices := make([]byte, 0, 32*size)
+	rawKeys := make([]bls.PublicKey, size)
+	for i := 0; i < size; i++ {
+		msgSlices = append(msgSlices, msgs[i][:]...)
+		rawKeys[i] = *pubKeys[i].(*PublicKey).p
+	}
+	return s.s.AggregateVerifyNoCheck(rawKeys, msgSlices)
+}
+
+// FastAggregateVerify verifies all the provided public keys with their aggregated signature.
+//
+// In IETF draft BLS specification:
+// FastAggregateVerify(PK_1, ..., PK_n, message, signature) -> VALID
+//      or INVALID: a verification algorithm for the aggregate of multiple
+//      signatures on the same message.  This function is faster than
+//      AggregateVerify.
+//
+// In the Ethereum proof of stake specification:
+// def FastAggregateVerify(PKs: Sequence[BLSPubkey], message: Bytes, signature: BLSSignature) -> bool
+func (s *Signature) FastAggregateVerify(pubKeys []common.PublicKey, msg [32]byte) bool {
+	if features.Get().SkipBLSVerify {
+		return true
+	}
+	if len(pubKeys) == 0 {
+		return false
+	}
+	rawKeys := make([]bls.PublicKey, len(pubKeys))
+	for i := 0; i < len(pubKeys); i++ {
+		rawKeys[i] = *pubKeys[i].(*PublicKey).p
+	}
+	return s.s.FastAggregateVerify(rawKeys, msg[:])
+}
+
+// Eth2FastAggregateVerify implements a wrapper on top of bls's FastAggregateVerify. It accepts G2_POINT_AT_INFINITY signature
+// when pubkeys empty.
+//
+// Spec code:
+// def eth2_fast_aggregate_verify(pubkeys: Sequence[BLSPubkey], message: Bytes32, signature: BLSSignature) -> bool:
+//    """
+//    Wrapper to ``bls.FastAggregateVerify`` accepting the ``G2_POINT_AT_INFINITY`` signature when ``pubkeys`` is empty.
+//    """
+//    if len(pubkeys) == 0 and signature == G2_POINT_AT_INFINITY:
+//        return True
+//    return bls.FastAggregateVerify(pubkeys, message, signature)
+func (s *Signature) Eth2FastAggregateVerify(pubKeys []common.PublicKey, msg [32]byte) bool {
+	if features.Get().SkipBLSVerify {
+		return true
+	}
+	if len(pubKeys) == 0 && bytes.Equal(s.Marshal(), common.InfiniteSignature[:]) {
+		return true
+	}
+	return s.FastAggregateVerify(pubKeys, msg)
+}
+
+// NewAggregateSignature creates a blank aggregate signature.
+func NewAggregateSignature() common.Signature {
+	return &Signature{s: bls.HashAndMapToSignature([]byte{'m', 'o', 'c', 'k'})}
+}
+
+// AggregateSignatures converts a list of signatures into a single, aggregated sig.
+func AggregateSignatures(sigs []common.Signature) common.Signature {
+	if len(sigs) == 0 {
+		return nil
+	}
+	if features.Get().SkipBLSVerify {
+		return sigs[0]
+	}
+
+	rawSigs := make([]bls.Sign, len(sigs))
+	for i := 0; i < len(sigs); i++ {
+		rawSigs[i] = *sigs[i].(*Signature).s
+	}
+	signature := &bls.Sign{}
+	signature.Aggregate(rawSigs)
+	return &Signature{s: signature}
+}
+
+// VerifyMultipleSignatures verifies a non-singular set of signatures and its respective pubkeys and messages.
+// Each signature is multiplied by a random scalar before the pairings are checked, which allows verifying
+// multiple signatures safely, see the blst implementation for the details of the scheme.
+func VerifyMultipleSignatures(sigs [][]byte, msgs [][32]byte, pubKeys []common.PublicKey) (bool, error) {
+	if features.Get().SkipBLSVerify {
+		return true, nil
+	}
+	if len(sigs) == 0 || len(pubKeys) == 0 {
+		return false, nil
+	}
+	length := len(sigs)
+	if length != len(pubKeys) || length != len(msgs) {
+		return false, errors.Errorf("provided signatures, pubkeys and messages have differing lengths. S: %d, P: %d,M %d",
+			length, len(pubKeys), len(msgs))
+	}
+	rawSigs := make([]bls.Sign, length)
+	rawKeys := make([]bls.PublicKey, length)
+	rawMsgs := make([]byte, 0, 32*length)
+	for i := 0; i < length; i++ {
+		// Like blst, a signature which cannot be deserialized makes the set invalid rather than
+		// returning an error.
+		if err := rawSigs[i].Deserialize(sigs[i]); err != nil {
+			return false, nil
+		}
+		rawKeys[i] = *pubKeys[i].(*PublicKey).p
+		rawMsgs = append(rawMsgs, msgs[i][:]...)
+	}
+	return bls.MultiVerify(rawSigs, rawKeys, rawMsgs), nil
+}
+
+// Marshal a signature into a LittleEndian byte slice.
+func (s *Signature) Marshal() []byte {
+	if features.Get().SkipBLSVerify {
+		return make([]byte, fieldparams.BLSSignatureLength)
+	}
+
+	return s.s.Serialize()
+}
+
+// Copy returns a full deep copy of a signature.
+func (s *Signature) Copy() common.Signature {
+	sign := *s.s
+	return &Signature{s: &sign}
+}
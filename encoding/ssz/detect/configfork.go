@@ -149,23 +149,16 @@ func (cf *VersionedUnmarshaler) UnmarshalBeaconBlock(marshaled []byte) (interfac
 		return nil, err
 	}
 
-	var blk ssz.Unmarshaler
-	switch cf.Fork {
-	case version.Phase0:
-		blk = &ethpb.SignedBeaconBlock{}
-	case version.Altair:
-		blk = &ethpb.SignedBeaconBlockAltair{}
-	case version.Bellatrix:
-		blk = &ethpb.SignedBeaconBlockBellatrix{}
-	default:
+	blk, err := wrapper.NewSignedBeaconBlockForVersion(cf.Fork)
+	if err != nil {
 		forkName := version.String(cf.Fork)
-		return nil, fmt.Errorf("unable to initialize BeaconBlock for fork version=%s at slot=%d", forkName, slot)
+		return nil, errors.Wrapf(err, "unable to initialize BeaconBlock for fork version=%s at slot=%d", forkName, slot)
 	}
 	err = blk.UnmarshalSSZ(marshaled)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to unmarshal SignedBeaconBlock in UnmarshalSSZ")
 	}
-	return wrapper.WrappedSignedBeaconBlock(blk)
+	return blk, nil
 }
 
 // UnmarshalBlindedBeaconBlock uses internal knowledge in the VersionedUnmarshaler to pick the right concrete blinded SignedBeaconBlock type,
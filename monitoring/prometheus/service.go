@@ -37,10 +37,16 @@ type Handler struct {
 // NewService sets up a new instance for a given address host:port.
 // An empty host will match with any IP so an address like ":2121" is perfectly acceptable.
 func NewService(addr string, svcRegistry *runtime.ServiceRegistry, additionalHandlers ...Handler) *Service {
+	return NewServiceWithGatherer(addr, svcRegistry, prometheus.DefaultGatherer, additionalHandlers...)
+}
+
+// NewServiceWithGatherer sets up a new instance for a given address host:port, serving the
+// metrics of the given gatherer instead of those of the Prometheus DefaultGatherer.
+func NewServiceWithGatherer(addr string, svcRegistry *runtime.ServiceRegistry, gatherer prometheus.Gatherer, additionalHandlers ...Handler) *Service {
 	s := &Service{svcRegistry: svcRegistry}
 
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{
 		MaxRequestsInFlight: 5,
 		Timeout:             30 * time.Second,
 	}))
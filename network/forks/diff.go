@@ -0,0 +1,79 @@
+package forks
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
+
+// DiffENRForkID compares the fork data advertised by a peer in the eth2 field of its ENR with the fork
+// schedule of the given config, and returns a human-readable description of every difference found.
+// The current fork digest can only be matched to a fork version when the genesis validators root is known,
+// so that comparison is skipped when genesisValidatorsRoot is empty. Likewise, the next fork comparison is
+// skipped when the peer's next fork version is unknown, e.g. when the fork data comes from a status message.
+func DiffENRForkID(cfg *params.BeaconChainConfig, peer *ethpb.ENRForkID, genesisValidatorsRoot []byte) ([]string, error) {
+	var diffs []string
+	if len(genesisValidatorsRoot) > 0 && len(peer.CurrentForkDigest) > 0 {
+		found := false
+		for _, v := range SortedForkVersions(cfg.ForkVersionSchedule) {
+			digest, err := signing.ComputeForkDigest(v[:], genesisValidatorsRoot)
+			if err != nil {
+				return nil, err
+			}
+			if digest == bytesutil.ToBytes4(peer.CurrentForkDigest) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diffs = append(diffs, fmt.Sprintf(
+				"current fork digest %#x of peer does not match any local fork version with genesis validators root %#x",
+				peer.CurrentForkDigest, genesisValidatorsRoot,
+			))
+		}
+	}
+	if len(peer.NextForkVersion) == 0 {
+		return diffs, nil
+	}
+
+	version := bytesutil.ToBytes4(peer.NextForkVersion)
+	localEpoch, ok := cfg.ForkVersionSchedule[version]
+	if !ok {
+		diff := fmt.Sprintf("fork version %#x of peer is not in the local fork schedule", version)
+		if other, err := params.ByVersion(version); err == nil {
+			diff += fmt.Sprintf(", it belongs to the %s config", other.ConfigName)
+		}
+		return append(diffs, diff), nil
+	}
+	// The far future epoch is advertised both by a peer without any upcoming fork, along with its current
+	// fork version, and by a peer whose next fork is not scheduled yet, so there is nothing to compare.
+	if peer.NextForkEpoch == math.MaxUint64 {
+		return diffs, nil
+	}
+	if peer.NextForkEpoch != localEpoch {
+		diffs = append(diffs, fmt.Sprintf(
+			"fork %s (version %#x) is scheduled at epoch %s by the peer, and at epoch %s locally",
+			forkName(cfg, version), version, formatEpoch(cfg, peer.NextForkEpoch), formatEpoch(cfg, localEpoch),
+		))
+	}
+	return diffs, nil
+}
+
+func forkName(cfg *params.BeaconChainConfig, version [4]byte) string {
+	if name, ok := cfg.ForkVersionNames[version]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+func formatEpoch(cfg *params.BeaconChainConfig, e types.Epoch) string {
+	if e == cfg.FarFutureEpoch {
+		return "far future"
+	}
+	return fmt.Sprintf("%d", e)
+}
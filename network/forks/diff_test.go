@@ -0,0 +1,85 @@
+package forks
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestDiffENRForkID(t *testing.T) {
+	cfg := params.MainnetConfig().Copy()
+	cfg.InitializeForkSchedule()
+	gvr := bytesutil.PadTo([]byte{'A'}, 32)
+	altairDigest, err := signing.ComputeForkDigest(cfg.AltairForkVersion, gvr)
+	require.NoError(t, err)
+	praterAltair := params.PraterConfig().AltairForkVersion
+
+	tests := []struct {
+		name  string
+		peer  *ethpb.ENRForkID
+		gvr   []byte
+		diffs []string
+	}{
+		{
+			name: "same schedule",
+			peer: &ethpb.ENRForkID{
+				CurrentForkDigest: altairDigest[:],
+				NextForkVersion:   cfg.BellatrixForkVersion,
+				NextForkEpoch:     cfg.BellatrixForkEpoch,
+			},
+			gvr: gvr,
+		},
+		{
+			name: "unknown digest",
+			peer: &ethpb.ENRForkID{
+				CurrentForkDigest: []byte{1, 2, 3, 4},
+			},
+			gvr:   gvr,
+			diffs: []string{"current fork digest 0x01020304 of peer does not match any local fork version with genesis validators root 0x4100000000000000000000000000000000000000000000000000000000000000"},
+		},
+		{
+			name: "digest ignored without genesis validators root",
+			peer: &ethpb.ENRForkID{
+				CurrentForkDigest: []byte{1, 2, 3, 4},
+			},
+		},
+		{
+			name: "different fork epoch",
+			peer: &ethpb.ENRForkID{
+				CurrentForkDigest: altairDigest[:],
+				NextForkVersion:   cfg.BellatrixForkVersion,
+				NextForkEpoch:     100,
+			},
+			gvr:   gvr,
+			diffs: []string{"fork bellatrix (version 0x02000000) is scheduled at epoch 100 by the peer, and at epoch far future locally"},
+		},
+		{
+			name: "version of another network",
+			peer: &ethpb.ENRForkID{
+				NextForkVersion: praterAltair,
+				NextForkEpoch:   math.MaxUint64,
+			},
+			diffs: []string{"fork version 0x01001020 of peer is not in the local fork schedule, it belongs to the prater config"},
+		},
+		{
+			name: "peer without scheduled fork",
+			peer: &ethpb.ENRForkID{
+				NextForkVersion: cfg.BellatrixForkVersion,
+				NextForkEpoch:   math.MaxUint64,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs, err := DiffENRForkID(cfg, tt.peer, tt.gvr)
+			require.NoError(t, err)
+			assert.DeepEqual(t, tt.diffs, diffs)
+		})
+	}
+}
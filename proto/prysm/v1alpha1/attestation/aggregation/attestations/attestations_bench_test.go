@@ -0,0 +1,90 @@
+package attestations
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/crypto/bls/common"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	aggtesting "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation/aggregation/testing"
+)
+
+func BenchmarkAggregateAttestations_Aggregate(b *testing.B) {
+	// Override expensive BLS aggregation method with cheap no-op such that this benchmark profiles
+	// the logic of aggregation selection rather than BLS logic.
+	aggregateSignatures = func(sigs []common.Signature) common.Signature {
+		return sigs[0]
+	}
+	signatureFromBytes = func(_ []byte) (common.Signature, error) {
+		return bls.NewAggregateSignature(), nil
+	}
+	defer func() {
+		aggregateSignatures = bls.AggregateSignatures
+		signatureFromBytes = bls.SignatureFromBytes
+	}()
+
+	bitlistLen := params.BeaconConfig().MaxValidatorsPerCommittee
+	tests := []struct {
+		numAtts       uint64
+		numMarkedBits uint64
+	}{
+		{
+			numAtts:       32,
+			numMarkedBits: 1,
+		},
+		{
+			numAtts:       256,
+			numMarkedBits: 1,
+		},
+		{
+			numAtts:       256,
+			numMarkedBits: 8,
+		},
+		{
+			numAtts:       1024,
+			numMarkedBits: 8,
+		},
+		{
+			numAtts:       1024,
+			numMarkedBits: 64,
+		},
+		{
+			numAtts:       2048,
+			numMarkedBits: 64,
+		},
+		{
+			numAtts:       2048,
+			numMarkedBits: 512,
+		},
+	}
+	for _, tt := range tests {
+		name := fmt.Sprintf("%d_attestations_with_%d_bit(s)_set", tt.numAtts, tt.numMarkedBits)
+		b.Run(name, func(b *testing.B) {
+			b.StopTimer()
+			var bitlists []bitfield.Bitlist
+			if tt.numMarkedBits == 1 {
+				bitlists = aggtesting.BitlistsWithSingleBitSet(tt.numAtts, bitlistLen)
+			} else {
+				bitlists = aggtesting.BitlistsWithMultipleBitSet(b, tt.numAtts, bitlistLen, tt.numMarkedBits)
+			}
+			atts := aggtesting.MakeAttestationsFromBitlists(bitlists)
+			b.StartTimer()
+			for i := 0; i < b.N; i++ {
+				// Aggregation happens in place, so each iteration works on a copy of the attestations.
+				b.StopTimer()
+				clonedAtts := make([]*ethpb.Attestation, len(atts))
+				for j, a := range atts {
+					clonedAtts[j] = ethpb.CopyAttestation(a)
+				}
+				b.StartTimer()
+				_, err := Aggregate(clonedAtts)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Package crash writes a crash report bundle to the data directory of the process when it panics.
+// A bundle holds the stack traces of all goroutines, the most recent log entries, a snapshot of
+// the configuration and any section registered by the running node, such as its database schema.
+package crash
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/io/logs"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var log = logrus.WithField("prefix", "crash")
+
+const (
+	bundleDirPrefix = "crash-"
+	// The number of log entries to keep in memory for crash bundles.
+	logBufferSize = 1000
+)
+
+var (
+	lock           sync.Mutex
+	dataDir        string
+	configSnapshot string
+	sections       = make(map[string]func() (string, error))
+	logBuffer      = newLogRing(logBufferSize)
+	hookOnce       sync.Once
+)
+
+// Configure records the data directory crash bundles are written to and a snapshot of the
+// command line configuration, and starts keeping the most recent log entries in memory.
+func Configure(cliCtx *cli.Context) {
+	lock.Lock()
+	defer lock.Unlock()
+	dataDir = cliCtx.String(cmd.DataDirFlag.Name)
+	configSnapshot = snapshotConfig(cliCtx)
+	hookOnce.Do(func() {
+		logrus.AddHook(logBuffer)
+	})
+}
+
+// SetDataDir overrides the directory crash bundles are written to, for processes which
+// only know where their data lives once they are running.
+func SetDataDir(dir string) {
+	lock.Lock()
+	defer lock.Unlock()
+	dataDir = dir
+}
+
+// RegisterSection adds a named section to crash bundles, whose content is produced by the
+// given function when a bundle is written.
+func RegisterSection(name string, fn func() (string, error)) {
+	lock.Lock()
+	defer lock.Unlock()
+	sections[name] = fn
+}
+
+// MigrationsSection returns a section listing the migrations applied to a database, which
+// describe the version of its schema.
+func MigrationsSection(ctx context.Context, completedMigrations func(context.Context) ([]string, error)) func() (string, error) {
+	return func() (string, error) {
+		migrations, err := completedMigrations(ctx)
+		if err != nil {
+			return "", err
+		}
+		sort.Strings(migrations)
+		return fmt.Sprintf("completed migrations:\n%s\n", strings.Join(migrations, "\n")), nil
+	}
+}
+
+// Recover writes a crash bundle for a panic of the calling goroutine, then panics again with
+// the same value. It must be deferred directly, as in `defer crash.Recover()`.
+func Recover() {
+	if x := recover(); x != nil {
+		stack := debug.Stack()
+		log.Errorf("Runtime panic: %v\n%v", x, string(stack))
+		bundlePath, err := WriteBundle(x, stack)
+		if err != nil {
+			log.WithError(err).Error("Could not write crash bundle")
+		} else {
+			log.WithField("path", bundlePath).Error("Wrote crash bundle, please attach it to your bug report")
+		}
+		panic(x)
+	}
+}
+
+// WriteBundle writes a crash bundle for the given panic value and stack trace to the data
+// directory, and returns the path of the bundle.
+func WriteBundle(panicValue interface{}, stack []byte) (string, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	bundlePath := filepath.Join(dataDir, bundleDirPrefix+time.Now().UTC().Format("20060102T150405.000Z"))
+	if err := file.MkdirAll(bundlePath); err != nil {
+		return "", errors.Wrapf(err, "could not create crash bundle directory %s", bundlePath)
+	}
+
+	var goroutines bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&goroutines, 2); err != nil {
+		return "", errors.Wrap(err, "could not dump goroutine stack traces")
+	}
+	contents := map[string][]byte{
+		"panic.txt":      []byte(fmt.Sprintf("%v\n\n%s", panicValue, stack)),
+		"goroutines.txt": goroutines.Bytes(),
+		"logs.txt":       []byte(strings.Join(logBuffer.lines(), "")),
+		"config.txt":     []byte(configSnapshot),
+	}
+	for name, fn := range sections {
+		contents[name+".txt"] = []byte(renderSection(fn))
+	}
+	for name, content := range contents {
+		if err := file.WriteFile(filepath.Join(bundlePath, name), content); err != nil {
+			return "", errors.Wrapf(err, "could not write %s to crash bundle", name)
+		}
+	}
+	return bundlePath, nil
+}
+
+// renderSection produces a registered section, making sure a failing section
+// does not prevent the rest of the bundle from being written.
+func renderSection(fn func() (string, error)) (content string) {
+	defer func() {
+		if x := recover(); x != nil {
+			content = fmt.Sprintf("panic producing section: %v", x)
+		}
+	}()
+	content, err := fn()
+	if err != nil {
+		return fmt.Sprintf("error producing section: %v", err)
+	}
+	return content
+}
+
+// snapshotConfig lists the version of the process, the chain configuration and the value of every
+// flag set on the command line. URLs are masked as in logs, as they may hold credentials.
+func snapshotConfig(cliCtx *cli.Context) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "version: %s\n", version.Version())
+	fmt.Fprintf(&b, "config: %s\n", params.BeaconConfig().ConfigName)
+	names := make(map[string]bool)
+	for _, c := range cliCtx.Lineage() {
+		for _, name := range c.LocalFlagNames() {
+			names[name] = true
+		}
+	}
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+	b.WriteString("flags:\n")
+	for _, name := range sortedNames {
+		value := fmt.Sprintf("%v", cliCtx.Value(name))
+		if strings.Contains(value, "://") {
+			value = logs.MaskCredentialsLogging(value)
+		}
+		fmt.Fprintf(&b, "  %s: %s\n", name, value)
+	}
+	return b.String()
+}
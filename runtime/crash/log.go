@@ -0,0 +1,54 @@
+package crash
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logRing is a log hook keeping the most recent log entries of the process, formatted as text.
+type logRing struct {
+	lock      sync.Mutex
+	formatter logrus.Formatter
+	entries   []string
+	next      int
+	full      bool
+}
+
+func newLogRing(size int) *logRing {
+	return &logRing{
+		formatter: &logrus.TextFormatter{DisableColors: true, FullTimestamp: true},
+		entries:   make([]string, size),
+	}
+}
+
+// Levels of the log entries kept by the ring.
+func (r *logRing) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire adds a log entry to the ring, replacing the oldest one when the ring is full.
+func (r *logRing) Fire(entry *logrus.Entry) error {
+	line, err := r.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.entries[r.next] = string(line)
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+	return nil
+}
+
+// lines returns the log entries of the ring, from oldest to newest.
+func (r *logRing) lines() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.full {
+		return append([]string{}, r.entries[:r.next]...)
+	}
+	return append(append([]string{}, r.entries[r.next:]...), r.entries[:r.next]...)
+}
@@ -0,0 +1,114 @@
+package interop
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+)
+
+// KeyRange is a contiguous range of deterministic interop key indices.
+type KeyRange struct {
+	Offset uint64
+	Count  uint64
+}
+
+// String returns the range in the offset:count format understood by ParseKeyRanges.
+func (r KeyRange) String() string {
+	return fmt.Sprintf("%d:%d", r.Offset, r.Count)
+}
+
+// ParseKeyRanges parses a comma separated list of offset:count key ranges, such as "0:64,128:64".
+// Ranges must not be empty and must not overlap.
+func ParseKeyRanges(s string) ([]KeyRange, error) {
+	var ranges []KeyRange
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid key range %q, expected offset:count", item)
+		}
+		offset, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid offset in key range %q", item)
+		}
+		count, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid count in key range %q", item)
+		}
+		ranges = append(ranges, KeyRange{Offset: offset, Count: count})
+	}
+	if err := ValidateKeyRanges(ranges); err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
+
+// ValidateKeyRanges checks that the ranges are not empty and do not overlap.
+func ValidateKeyRanges(ranges []KeyRange) error {
+	if len(ranges) == 0 {
+		return errors.New("no key ranges provided")
+	}
+	sorted := make([]KeyRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+	for i, r := range sorted {
+		if r.Count == 0 {
+			return errors.Errorf("key range %s is empty", r)
+		}
+		if r.Offset+r.Count < r.Offset {
+			return errors.Errorf("key range %s overflows", r)
+		}
+		if i > 0 && sorted[i-1].Offset+sorted[i-1].Count > r.Offset {
+			return errors.Errorf("key ranges %s and %s overlap", sorted[i-1], r)
+		}
+	}
+	return nil
+}
+
+// SplitKeyRange splits a range in the given number of contiguous parts, which differ in size by
+// at most one key. The split only depends on its inputs, so that several processes can each
+// generate their own part of a validator set without coordination.
+func SplitKeyRange(r KeyRange, parts uint64) ([]KeyRange, error) {
+	if parts == 0 {
+		return nil, errors.New("cannot split a key range in 0 parts")
+	}
+	if parts > r.Count {
+		return nil, errors.Errorf("cannot split %d keys in %d parts", r.Count, parts)
+	}
+	split := make([]KeyRange, parts)
+	offset := r.Offset
+	for i := uint64(0); i < parts; i++ {
+		count := r.Count / parts
+		if i < r.Count%parts {
+			count++
+		}
+		split[i] = KeyRange{Offset: offset, Count: count}
+		offset += count
+	}
+	return split, nil
+}
+
+// DeterministicallyGenerateKeysInRanges generates the interop keys of each range, in the order
+// the ranges are given.
+func DeterministicallyGenerateKeysInRanges(ranges []KeyRange) ([]bls.SecretKey, []bls.PublicKey, error) {
+	if err := ValidateKeyRanges(ranges); err != nil {
+		return nil, nil, err
+	}
+	var secretKeys []bls.SecretKey
+	var publicKeys []bls.PublicKey
+	for _, r := range ranges {
+		secs, pubs, err := DeterministicallyGenerateKeys(r.Offset, r.Count)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not generate keys in range %s", r)
+		}
+		secretKeys = append(secretKeys, secs...)
+		publicKeys = append(publicKeys, pubs...)
+	}
+	return secretKeys, publicKeys, nil
+}
@@ -0,0 +1,59 @@
+package interop_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/runtime/interop"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestParseKeyRanges(t *testing.T) {
+	ranges, err := interop.ParseKeyRanges("128:64, 0:64")
+	require.NoError(t, err)
+	assert.DeepEqual(t, []interop.KeyRange{{Offset: 128, Count: 64}, {Offset: 0, Count: 64}}, ranges)
+
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{input: "", err: "expected offset:count"},
+		{input: "5", err: "expected offset:count"},
+		{input: "a:5", err: "invalid offset"},
+		{input: "5:b", err: "invalid count"},
+		{input: "5:0", err: "is empty"},
+		{input: "0:10,5:10", err: "key ranges 0:10 and 5:10 overlap"},
+		{input: "18446744073709551615:2", err: "overflows"},
+	}
+	for _, tt := range tests {
+		_, err := interop.ParseKeyRanges(tt.input)
+		assert.ErrorContains(t, tt.err, err, tt.input)
+	}
+}
+
+func TestSplitKeyRange(t *testing.T) {
+	split, err := interop.SplitKeyRange(interop.KeyRange{Offset: 10, Count: 11}, 3)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []interop.KeyRange{{Offset: 10, Count: 4}, {Offset: 14, Count: 4}, {Offset: 18, Count: 3}}, split)
+
+	_, err = interop.SplitKeyRange(interop.KeyRange{Count: 2}, 0)
+	assert.ErrorContains(t, "0 parts", err)
+	_, err = interop.SplitKeyRange(interop.KeyRange{Count: 2}, 3)
+	assert.ErrorContains(t, "cannot split 2 keys in 3 parts", err)
+}
+
+func TestDeterministicallyGenerateKeysInRanges(t *testing.T) {
+	all, _, err := interop.DeterministicallyGenerateKeys(0, 12)
+	require.NoError(t, err)
+	secs, pubs, err := interop.DeterministicallyGenerateKeysInRanges([]interop.KeyRange{{Offset: 8, Count: 4}, {Offset: 1, Count: 2}})
+	require.NoError(t, err)
+	require.Equal(t, 6, len(secs))
+	require.Equal(t, 6, len(pubs))
+	for i, want := range []int{8, 9, 10, 11, 1, 2} {
+		assert.DeepEqual(t, all[want].Marshal(), secs[i].Marshal())
+		assert.DeepEqual(t, all[want].PublicKey().Marshal(), pubs[i].Marshal())
+	}
+
+	_, _, err = interop.DeterministicallyGenerateKeysInRanges(nil)
+	assert.ErrorContains(t, "no key ranges provided", err)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/prysmaticlabs/prysm/runtime/crash"
 	"github.com/sirupsen/logrus"
 )
 
@@ -43,7 +44,10 @@ func (s *ServiceRegistry) StartAll() {
 	log.Debugf("Starting %d services: %v", len(s.serviceTypes), s.serviceTypes)
 	for _, kind := range s.serviceTypes {
 		log.Debugf("Starting service type %v", kind)
-		go s.services[kind].Start()
+		go func(service Service) {
+			defer crash.Recover()
+			service.Start()
+		}(s.services[kind])
 	}
 }
 
@@ -161,6 +161,7 @@ type ValidatorNode struct {
 	validatorNum int
 	index        int
 	offset       int
+	duplicate    bool
 	cmd          *exec.Cmd
 }
 
@@ -175,6 +176,16 @@ func NewValidatorNode(config *e2etypes.E2EConfig, validatorNum, index, offset in
 	}
 }
 
+// NewDuplicateValidatorNode creates and returns a validator node running the same keys as another validator client,
+// as a misconfigured duplicate instance would. The node keeps the slashing protection database found in its data
+// directory and does not run the doppelganger check, so only its local slashing protection stands between it and
+// a double signature.
+func NewDuplicateValidatorNode(config *e2etypes.E2EConfig, validatorNum, index, offset int) *ValidatorNode {
+	v := NewValidatorNode(config, validatorNum, index, offset)
+	v.duplicate = true
+	return v
+}
+
 // Start starts a validator client.
 func (v *ValidatorNode) Start(ctx context.Context) error {
 	validatorHexPubKeys := make([]string, 0)
@@ -224,12 +235,16 @@ func (v *ValidatorNode) Start(ctx context.Context) error {
 		fmt.Sprintf("--%s=localhost:%d", flags.BeaconRPCProviderFlag.Name, beaconRPCPort),
 		fmt.Sprintf("--%s=%s", flags.GrpcHeadersFlag.Name, "dummy=value,foo=bar"), // Sending random headers shouldn't break anything.
 		fmt.Sprintf("--%s=%s", cmdshared.VerbosityFlag.Name, "debug"),
-		"--" + cmdshared.ForceClearDB.Name,
 		"--" + cmdshared.E2EConfigFlag.Name,
 		"--" + cmdshared.AcceptTosFlag.Name,
 	}
+	if v.duplicate {
+		args = append(args, "--disable-doppelganger")
+	} else {
+		args = append(args, "--"+cmdshared.ForceClearDB.Name)
+	}
 	// Only apply e2e flags to the current branch. New flags may not exist in previous release.
-	if !v.config.UsePrysmShValidator {
+	if !v.config.UsePrysmShValidator && !v.duplicate {
 		args = append(args, features.E2EValidatorFlags...)
 	}
 	if v.config.UseWeb3RemoteSigner {
@@ -57,9 +57,10 @@ func init() {
 
 // testRunner abstracts E2E test configuration and running.
 type testRunner struct {
-	t          *testing.T
-	config     *e2etypes.E2EConfig
-	comHandler *componentHandler
+	t                  *testing.T
+	config             *e2etypes.E2EConfig
+	comHandler         *componentHandler
+	duplicateValidator *components.ValidatorNode
 }
 
 // newTestRunner creates E2E test runner.
@@ -654,6 +655,40 @@ func (r *testRunner) eeOffline(epoch uint64, _ []*grpc.ClientConn) bool {
 	return false
 }
 
+// This interceptor starts a duplicate of validator client 0, running the same keys on a copy of its slashing
+// protection database in which conflicting messages are recorded for epochs 3 to 6. The duplicate attempts to sign
+// a conflicting message for each of its duties within these epochs, and its local slashing protection must refuse
+// every one of them. The duplicate is stopped before the end of the seeded epochs, so it never signs anything.
+func (r *testRunner) doubleSignAttempt(epoch uint64, conns []*grpc.ClientConn) bool {
+	validatorsPerNode := int(params.BeaconConfig().MinGenesisActiveValidatorCount) / e2e.TestParams.BeaconNodeCount
+	dupIndex := e2e.TestParams.BeaconNodeCount + 1
+	switch epoch {
+	case 3:
+		require.NoError(r.t, r.comHandler.validatorNodes.PauseAtIndex(0))
+		require.NoError(r.t, ev.SeedConflictingProtectionHistory(0, dupIndex, validatorsPerNode, 0, 3, 6))
+		require.NoError(r.t, r.comHandler.validatorNodes.ResumeAtIndex(0))
+
+		r.duplicateValidator = components.NewDuplicateValidatorNode(r.config, validatorsPerNode, dupIndex, 0)
+		go func() {
+			// The duplicate is killed once the seeded epochs are over, which ends the process with an error.
+			if err := r.duplicateValidator.Start(r.comHandler.ctx); err != nil {
+				log.WithError(err).Info("Duplicate validator client exited")
+			}
+		}()
+		ctx, cancel := context.WithTimeout(r.comHandler.ctx, allNodesStartTimeout)
+		defer cancel()
+		require.NoError(r.t, helpers.ComponentsStarted(ctx, []e2etypes.ComponentRunner{r.duplicateValidator}))
+		return true
+	case 6:
+		r.executeProvidedEvaluators(epoch, conns, []e2etypes.Evaluator{
+			ev.DuplicateValidatorRefusedToSign(dupIndex),
+		})
+		require.NoError(r.t, r.duplicateValidator.Stop())
+		return true
+	}
+	return false
+}
+
 // This interceptor will define the multi scenario run for our minimal tests.
 // 1) In the first scenario we will be taking a single node and its validator offline.
 // After 1 epoch we will then attempt to bring it online again.
@@ -0,0 +1,171 @@
+package evaluators
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/io/file"
+	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime/interop"
+	e2e "github.com/prysmaticlabs/prysm/testing/endtoend/params"
+	"github.com/prysmaticlabs/prysm/testing/endtoend/policies"
+	e2eTypes "github.com/prysmaticlabs/prysm/testing/endtoend/types"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"google.golang.org/grpc"
+)
+
+// Log messages of the validator client when its local slashing protection refuses to sign.
+const (
+	attestationRefusedLog = "rejected by local slashing protection"
+	proposalRefusedLog    = "block rejected by local protection"
+)
+
+// DuplicateValidatorRefusedToSign ensures the local slashing protection of the duplicate validator client with
+// the given index refused to sign both attestations and blocks.
+var DuplicateValidatorRefusedToSign = func(index int) e2eTypes.Evaluator {
+	return e2eTypes.Evaluator{
+		Name:   "duplicate_validator_refused_to_sign_epoch_%d",
+		Policy: policies.AllEpochs,
+		Evaluation: func(_ ...*grpc.ClientConn) error {
+			return duplicateValidatorRefusedToSign(index)
+		},
+	}
+}
+
+// ValidatorsNotSlashedAfterEpoch ensures no validator was slashed.
+var ValidatorsNotSlashedAfterEpoch = func(n types.Epoch) e2eTypes.Evaluator {
+	return e2eTypes.Evaluator{
+		Name:       "validators_not_slashed_epoch_%d",
+		Policy:     policies.AfterNthEpoch(n),
+		Evaluation: validatorsNotSlashed,
+	}
+}
+
+// SeedConflictingProtectionHistory copies the slashing protection database of the validator client srcIndex into
+// the data directory of the validator client dstIndex. For every key of the validator client srcIndex, it then records
+// in the copy a vote for every target epoch and a proposal for every slot from the start to the end epoch which the
+// key has not signed yet, with a signing root no honest validator signs. A duplicate instance started on the copy
+// thus attempts to sign conflicting messages for all of its duties within these epochs. The validator client srcIndex
+// must not be running while its database is copied.
+func SeedConflictingProtectionHistory(srcIndex, dstIndex, validatorNum, offset int, start, end types.Epoch) (err error) {
+	ctx := context.Background()
+	src := path.Join(e2e.TestParams.TestPath, fmt.Sprintf("eth2-val-%d", srcIndex), kv.ProtectionDbFileName)
+	dstDir := path.Join(e2e.TestParams.TestPath, fmt.Sprintf("eth2-val-%d", dstIndex))
+	if err := file.MkdirAll(dstDir); err != nil {
+		return err
+	}
+	if err := file.CopyFile(src, path.Join(dstDir, kv.ProtectionDbFileName)); err != nil {
+		return errors.Wrapf(err, "could not copy slashing protection db of validator client %d", srcIndex)
+	}
+	store, err := kv.NewKVStore(ctx, dstDir, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open copied slashing protection db")
+	}
+	defer func() {
+		if closeErr := store.Close(); closeErr != nil && err == nil {
+			err = errors.Wrap(closeErr, "could not close copied slashing protection db")
+		}
+	}()
+
+	_, pubKeys, err := interop.DeterministicallyGenerateKeys(uint64(offset), uint64(validatorNum))
+	if err != nil {
+		return err
+	}
+	conflictingRoot := bytesutil.ToBytes32([]byte("conflicting signing root"))
+	startSlot, err := slots.EpochStart(start)
+	if err != nil {
+		return err
+	}
+	endSlot, err := slots.EpochEnd(end)
+	if err != nil {
+		return err
+	}
+	for i, pub := range pubKeys {
+		pubKey := bytesutil.ToBytes48(pub.Marshal())
+
+		var roots [][32]byte
+		var atts []*eth.IndexedAttestation
+		for epoch := start; epoch <= end; epoch++ {
+			signed, err := store.SigningRootAtTargetEpoch(ctx, pubKey, epoch)
+			if err != nil {
+				return errors.Wrap(err, "could not get attestation history")
+			}
+			if signed != params.BeaconConfig().ZeroHash {
+				continue
+			}
+			slot, err := slots.EpochStart(epoch)
+			if err != nil {
+				return err
+			}
+			roots = append(roots, conflictingRoot)
+			atts = append(atts, &eth.IndexedAttestation{
+				AttestingIndices: []uint64{uint64(offset + i)},
+				Data: &eth.AttestationData{
+					Slot:            slot,
+					BeaconBlockRoot: conflictingRoot[:],
+					Source:          &eth.Checkpoint{Epoch: epoch.Sub(1), Root: conflictingRoot[:]},
+					Target:          &eth.Checkpoint{Epoch: epoch, Root: conflictingRoot[:]},
+				},
+				Signature: make([]byte, fieldparams.BLSSignatureLength),
+			})
+		}
+		if err := store.SaveAttestationsForPubKey(ctx, pubKey, roots, atts); err != nil {
+			return errors.Wrap(err, "could not save conflicting attestation history")
+		}
+
+		for slot := startSlot; slot <= endSlot; slot++ {
+			_, exists, err := store.ProposalHistoryForSlot(ctx, pubKey, slot)
+			if err != nil {
+				return errors.Wrap(err, "could not get proposal history")
+			}
+			if exists {
+				continue
+			}
+			if err := store.SaveProposalHistoryForSlot(ctx, pubKey, slot, conflictingRoot[:]); err != nil {
+				return errors.Wrap(err, "could not save conflicting proposal history")
+			}
+		}
+	}
+	return nil
+}
+
+func duplicateValidatorRefusedToSign(index int) error {
+	logs, err := os.ReadFile(path.Join(e2e.TestParams.LogPath, fmt.Sprintf(e2e.ValidatorLogFileName, index)))
+	if err != nil {
+		return errors.Wrapf(err, "could not read logs of validator client %d", index)
+	}
+	for _, want := range []string{attestationRefusedLog, proposalRefusedLog} {
+		if !strings.Contains(string(logs), want) {
+			return fmt.Errorf("expected the local slashing protection of duplicate validator client %d to log %q", index, want)
+		}
+	}
+	return nil
+}
+
+func validatorsNotSlashed(conns ...*grpc.ClientConn) error {
+	ctx := context.Background()
+	for _, conn := range conns {
+		client := eth.NewBeaconChainClient(conn)
+		resp, err := client.ListValidators(ctx, &eth.ListValidatorsRequest{
+			PageSize: int32(params.BeaconConfig().MinGenesisActiveValidatorCount),
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not list validators")
+		}
+		for _, v := range resp.ValidatorList {
+			if v.Validator.Slashed {
+				return fmt.Errorf("expected no validator to be slashed, validator %d was", v.Index)
+			}
+		}
+	}
+	return nil
+}
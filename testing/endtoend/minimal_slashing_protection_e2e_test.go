@@ -0,0 +1,42 @@
+package endtoend
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	ev "github.com/prysmaticlabs/prysm/testing/endtoend/evaluators"
+	e2eParams "github.com/prysmaticlabs/prysm/testing/endtoend/params"
+	"github.com/prysmaticlabs/prysm/testing/endtoend/types"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestEndToEnd_SlashingProtection_MinimalConfig(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.E2ETestConfig().Copy())
+	require.NoError(t, e2eParams.Init(t, e2eParams.StandardBeaconCount))
+
+	tracingPort := e2eParams.TestParams.Ports.JaegerTracingPort
+	tracingEndpoint := fmt.Sprintf("127.0.0.1:%d", tracingPort)
+
+	testConfig := &types.E2EConfig{
+		BeaconFlags: []string{
+			"--slasher",
+		},
+		ValidatorFlags: []string{},
+		EpochsToRun:    9,
+		TestSync:       false,
+		TestFeature:    false,
+		TestDeposits:   false,
+		Evaluators: []types.Evaluator{
+			ev.PeersConnect,
+			ev.HealthzCheck,
+			ev.ValidatorsNotSlashedAfterEpoch(4),
+		},
+		TracingSinkEndpoint: tracingEndpoint,
+	}
+
+	runner := newTestRunner(t, testConfig)
+	runner.config.EvalInterceptor = runner.doubleSignAttempt
+	runner.run()
+}
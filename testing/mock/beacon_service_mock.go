@@ -118,6 +118,26 @@ func (mr *MockBeaconChainClientMockRecorder) GetIndividualVotes(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndividualVotes", reflect.TypeOf((*MockBeaconChainClient)(nil).GetIndividualVotes), varargs...)
 }
 
+// GetParticipationEstimate mocks base method.
+func (m *MockBeaconChainClient) GetParticipationEstimate(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.ParticipationEstimate, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetParticipationEstimate", varargs...)
+	ret0, _ := ret[0].(*eth.ParticipationEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParticipationEstimate indicates an expected call of GetParticipationEstimate.
+func (mr *MockBeaconChainClientMockRecorder) GetParticipationEstimate(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParticipationEstimate", reflect.TypeOf((*MockBeaconChainClient)(nil).GetParticipationEstimate), varargs...)
+}
+
 // GetValidator mocks base method.
 func (m *MockBeaconChainClient) GetValidator(arg0 context.Context, arg1 *eth.GetValidatorRequest, arg2 ...grpc.CallOption) (*eth.Validator, error) {
 	m.ctrl.T.Helper()
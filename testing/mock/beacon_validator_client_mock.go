@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1 (interfaces: BeaconNodeValidatorClient,BeaconNodeValidator_WaitForChainStartClient,BeaconNodeValidator_WaitForActivationClient,BeaconNodeValidator_StreamDutiesClient)
+// Source: github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1 (interfaces: BeaconNodeValidatorClient,BeaconNodeValidator_WaitForChainStartClient,BeaconNodeValidator_WaitForActivationClient,BeaconNodeValidator_StreamDutiesClient,BeaconNodeValidator_ProposeAttestationsClient,BeaconNodeValidator_StreamSyncCommitteeContributionsClient)
 
 // Package mock is a generated GoMock package.
 package mock
@@ -278,6 +278,26 @@ func (mr *MockBeaconNodeValidatorClientMockRecorder) ProposeAttestation(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeAttestation", reflect.TypeOf((*MockBeaconNodeValidatorClient)(nil).ProposeAttestation), varargs...)
 }
 
+// ProposeAttestations mocks base method.
+func (m *MockBeaconNodeValidatorClient) ProposeAttestations(arg0 context.Context, arg1 ...grpc.CallOption) (eth.BeaconNodeValidator_ProposeAttestationsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProposeAttestations", varargs...)
+	ret0, _ := ret[0].(eth.BeaconNodeValidator_ProposeAttestationsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProposeAttestations indicates an expected call of ProposeAttestations.
+func (mr *MockBeaconNodeValidatorClientMockRecorder) ProposeAttestations(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeAttestations", reflect.TypeOf((*MockBeaconNodeValidatorClient)(nil).ProposeAttestations), varargs...)
+}
+
 // ProposeBeaconBlock mocks base method.
 func (m *MockBeaconNodeValidatorClient) ProposeBeaconBlock(arg0 context.Context, arg1 *eth.GenericSignedBeaconBlock, arg2 ...grpc.CallOption) (*eth.ProposeResponse, error) {
 	m.ctrl.T.Helper()
@@ -378,6 +398,26 @@ func (mr *MockBeaconNodeValidatorClientMockRecorder) StreamDuties(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamDuties", reflect.TypeOf((*MockBeaconNodeValidatorClient)(nil).StreamDuties), varargs...)
 }
 
+// StreamSyncCommitteeContributions mocks base method.
+func (m *MockBeaconNodeValidatorClient) StreamSyncCommitteeContributions(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (eth.BeaconNodeValidator_StreamSyncCommitteeContributionsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamSyncCommitteeContributions", varargs...)
+	ret0, _ := ret[0].(eth.BeaconNodeValidator_StreamSyncCommitteeContributionsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamSyncCommitteeContributions indicates an expected call of StreamSyncCommitteeContributions.
+func (mr *MockBeaconNodeValidatorClientMockRecorder) StreamSyncCommitteeContributions(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSyncCommitteeContributions", reflect.TypeOf((*MockBeaconNodeValidatorClient)(nil).StreamSyncCommitteeContributions), varargs...)
+}
+
 // SubmitAggregateSelectionProof mocks base method.
 func (m *MockBeaconNodeValidatorClient) SubmitAggregateSelectionProof(arg0 context.Context, arg1 *eth.AggregateSelectionRequest, arg2 ...grpc.CallOption) (*eth.AggregateSelectionResponse, error) {
 	m.ctrl.T.Helper()
@@ -398,6 +438,46 @@ func (mr *MockBeaconNodeValidatorClientMockRecorder) SubmitAggregateSelectionPro
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAggregateSelectionProof", reflect.TypeOf((*MockBeaconNodeValidatorClient)(nil).SubmitAggregateSelectionProof), varargs...)
 }
 
+// SubmitAggregateSelectionProofBatch mocks base method.
+func (m *MockBeaconNodeValidatorClient) SubmitAggregateSelectionProofBatch(arg0 context.Context, arg1 *eth.AggregateSelectionBatchRequest, arg2 ...grpc.CallOption) (*eth.AggregateSelectionBatchResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SubmitAggregateSelectionProofBatch", varargs...)
+	ret0, _ := ret[0].(*eth.AggregateSelectionBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitAggregateSelectionProofBatch indicates an expected call of SubmitAggregateSelectionProofBatch.
+func (mr *MockBeaconNodeValidatorClientMockRecorder) SubmitAggregateSelectionProofBatch(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAggregateSelectionProofBatch", reflect.TypeOf((*MockBeaconNodeValidatorClient)(nil).SubmitAggregateSelectionProofBatch), varargs...)
+}
+
+// SubmitDutyResults mocks base method.
+func (m *MockBeaconNodeValidatorClient) SubmitDutyResults(arg0 context.Context, arg1 *eth.DutyResultsRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SubmitDutyResults", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitDutyResults indicates an expected call of SubmitDutyResults.
+func (mr *MockBeaconNodeValidatorClientMockRecorder) SubmitDutyResults(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitDutyResults", reflect.TypeOf((*MockBeaconNodeValidatorClient)(nil).SubmitDutyResults), varargs...)
+}
+
 // SubmitSignedAggregateSelectionProof mocks base method.
 func (m *MockBeaconNodeValidatorClient) SubmitSignedAggregateSelectionProof(arg0 context.Context, arg1 *eth.SignedAggregateSubmitRequest, arg2 ...grpc.CallOption) (*eth.SignedAggregateSubmitResponse, error) {
 	m.ctrl.T.Helper()
@@ -946,3 +1026,263 @@ func (mr *MockBeaconNodeValidator_StreamDutiesClientMockRecorder) Trailer() *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockBeaconNodeValidator_StreamDutiesClient)(nil).Trailer))
 }
+
+// MockBeaconNodeValidator_ProposeAttestationsClient is a mock of BeaconNodeValidator_ProposeAttestationsClient interface.
+type MockBeaconNodeValidator_ProposeAttestationsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder
+}
+
+// MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder is the mock recorder for MockBeaconNodeValidator_ProposeAttestationsClient.
+type MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder struct {
+	mock *MockBeaconNodeValidator_ProposeAttestationsClient
+}
+
+// NewMockBeaconNodeValidator_ProposeAttestationsClient creates a new mock instance.
+func NewMockBeaconNodeValidator_ProposeAttestationsClient(ctrl *gomock.Controller) *MockBeaconNodeValidator_ProposeAttestationsClient {
+	mock := &MockBeaconNodeValidator_ProposeAttestationsClient{ctrl: ctrl}
+	mock.recorder = &MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBeaconNodeValidator_ProposeAttestationsClient) EXPECT() *MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsClient) CloseAndRecv() (*eth.ProposeAttestationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*eth.ProposeAttestationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsClient) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsClient)(nil).RecvMsg), arg0)
+}
+
+// Send mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsClient) Send(arg0 *eth.Attestation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsClient) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsClient)(nil).SendMsg), arg0)
+}
+
+// Trailer mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsClient)(nil).Trailer))
+}
+
+// MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient is a mock of BeaconNodeValidator_StreamSyncCommitteeContributionsClient interface.
+type MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder
+}
+
+// MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder is the mock recorder for MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient.
+type MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder struct {
+	mock *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient
+}
+
+// NewMockBeaconNodeValidator_StreamSyncCommitteeContributionsClient creates a new mock instance.
+func NewMockBeaconNodeValidator_StreamSyncCommitteeContributionsClient(ctrl *gomock.Controller) *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient {
+	mock := &MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient{ctrl: ctrl}
+	mock.recorder = &MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient) EXPECT() *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient) Recv() (*eth.SignedContributionAndProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*eth.SignedContributionAndProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient)(nil).RecvMsg), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient)(nil).SendMsg), arg0)
+}
+
+// Trailer mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsClient)(nil).Trailer))
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1 (interfaces: BeaconNodeValidatorServer,BeaconNodeValidator_WaitForActivationServer,BeaconNodeValidator_WaitForChainStartServer,BeaconNodeValidator_StreamDutiesServer)
+// Source: github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1 (interfaces: BeaconNodeValidatorServer,BeaconNodeValidator_WaitForActivationServer,BeaconNodeValidator_WaitForChainStartServer,BeaconNodeValidator_StreamDutiesServer,BeaconNodeValidator_ProposeAttestationsServer,BeaconNodeValidator_StreamSyncCommitteeContributionsServer)
 
 // Package mock is a generated GoMock package.
 package mock
@@ -217,6 +217,20 @@ func (mr *MockBeaconNodeValidatorServerMockRecorder) ProposeAttestation(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeAttestation", reflect.TypeOf((*MockBeaconNodeValidatorServer)(nil).ProposeAttestation), arg0, arg1)
 }
 
+// ProposeAttestations mocks base method.
+func (m *MockBeaconNodeValidatorServer) ProposeAttestations(arg0 eth.BeaconNodeValidator_ProposeAttestationsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeAttestations", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProposeAttestations indicates an expected call of ProposeAttestations.
+func (mr *MockBeaconNodeValidatorServerMockRecorder) ProposeAttestations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeAttestations", reflect.TypeOf((*MockBeaconNodeValidatorServer)(nil).ProposeAttestations), arg0)
+}
+
 // ProposeBeaconBlock mocks base method.
 func (m *MockBeaconNodeValidatorServer) ProposeBeaconBlock(arg0 context.Context, arg1 *eth.GenericSignedBeaconBlock) (*eth.ProposeResponse, error) {
 	m.ctrl.T.Helper()
@@ -290,6 +304,20 @@ func (mr *MockBeaconNodeValidatorServerMockRecorder) StreamDuties(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamDuties", reflect.TypeOf((*MockBeaconNodeValidatorServer)(nil).StreamDuties), arg0, arg1)
 }
 
+// StreamSyncCommitteeContributions mocks base method.
+func (m *MockBeaconNodeValidatorServer) StreamSyncCommitteeContributions(arg0 *emptypb.Empty, arg1 eth.BeaconNodeValidator_StreamSyncCommitteeContributionsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSyncCommitteeContributions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamSyncCommitteeContributions indicates an expected call of StreamSyncCommitteeContributions.
+func (mr *MockBeaconNodeValidatorServerMockRecorder) StreamSyncCommitteeContributions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSyncCommitteeContributions", reflect.TypeOf((*MockBeaconNodeValidatorServer)(nil).StreamSyncCommitteeContributions), arg0, arg1)
+}
+
 // SubmitAggregateSelectionProof mocks base method.
 func (m *MockBeaconNodeValidatorServer) SubmitAggregateSelectionProof(arg0 context.Context, arg1 *eth.AggregateSelectionRequest) (*eth.AggregateSelectionResponse, error) {
 	m.ctrl.T.Helper()
@@ -305,6 +333,36 @@ func (mr *MockBeaconNodeValidatorServerMockRecorder) SubmitAggregateSelectionPro
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAggregateSelectionProof", reflect.TypeOf((*MockBeaconNodeValidatorServer)(nil).SubmitAggregateSelectionProof), arg0, arg1)
 }
 
+// SubmitAggregateSelectionProofBatch mocks base method.
+func (m *MockBeaconNodeValidatorServer) SubmitAggregateSelectionProofBatch(arg0 context.Context, arg1 *eth.AggregateSelectionBatchRequest) (*eth.AggregateSelectionBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAggregateSelectionProofBatch", arg0, arg1)
+	ret0, _ := ret[0].(*eth.AggregateSelectionBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitAggregateSelectionProofBatch indicates an expected call of SubmitAggregateSelectionProofBatch.
+func (mr *MockBeaconNodeValidatorServerMockRecorder) SubmitAggregateSelectionProofBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAggregateSelectionProofBatch", reflect.TypeOf((*MockBeaconNodeValidatorServer)(nil).SubmitAggregateSelectionProofBatch), arg0, arg1)
+}
+
+// SubmitDutyResults mocks base method.
+func (m *MockBeaconNodeValidatorServer) SubmitDutyResults(arg0 context.Context, arg1 *eth.DutyResultsRequest) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitDutyResults", arg0, arg1)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitDutyResults indicates an expected call of SubmitDutyResults.
+func (mr *MockBeaconNodeValidatorServerMockRecorder) SubmitDutyResults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitDutyResults", reflect.TypeOf((*MockBeaconNodeValidatorServer)(nil).SubmitDutyResults), arg0, arg1)
+}
+
 // SubmitSignedAggregateSelectionProof mocks base method.
 func (m *MockBeaconNodeValidatorServer) SubmitSignedAggregateSelectionProof(arg0 context.Context, arg1 *eth.SignedAggregateSubmitRequest) (*eth.SignedAggregateSubmitResponse, error) {
 	m.ctrl.T.Helper()
@@ -794,3 +852,256 @@ func (mr *MockBeaconNodeValidator_StreamDutiesServerMockRecorder) SetTrailer(arg
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockBeaconNodeValidator_StreamDutiesServer)(nil).SetTrailer), arg0)
 }
+
+// MockBeaconNodeValidator_ProposeAttestationsServer is a mock of BeaconNodeValidator_ProposeAttestationsServer interface.
+type MockBeaconNodeValidator_ProposeAttestationsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder
+}
+
+// MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder is the mock recorder for MockBeaconNodeValidator_ProposeAttestationsServer.
+type MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder struct {
+	mock *MockBeaconNodeValidator_ProposeAttestationsServer
+}
+
+// NewMockBeaconNodeValidator_ProposeAttestationsServer creates a new mock instance.
+func NewMockBeaconNodeValidator_ProposeAttestationsServer(ctrl *gomock.Controller) *MockBeaconNodeValidator_ProposeAttestationsServer {
+	mock := &MockBeaconNodeValidator_ProposeAttestationsServer{ctrl: ctrl}
+	mock.recorder = &MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBeaconNodeValidator_ProposeAttestationsServer) EXPECT() *MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsServer) Recv() (*eth.Attestation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*eth.Attestation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsServer) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsServer)(nil).RecvMsg), arg0)
+}
+
+// SendAndClose mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsServer) SendAndClose(arg0 *eth.ProposeAttestationsResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsServer) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsServer)(nil).SendMsg), arg0)
+}
+
+// SetHeader mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockBeaconNodeValidator_ProposeAttestationsServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockBeaconNodeValidator_ProposeAttestationsServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockBeaconNodeValidator_ProposeAttestationsServer)(nil).SetTrailer), arg0)
+}
+
+// MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer is a mock of BeaconNodeValidator_StreamSyncCommitteeContributionsServer interface.
+type MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder
+}
+
+// MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder is the mock recorder for MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer.
+type MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder struct {
+	mock *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer
+}
+
+// NewMockBeaconNodeValidator_StreamSyncCommitteeContributionsServer creates a new mock instance.
+func NewMockBeaconNodeValidator_StreamSyncCommitteeContributionsServer(ctrl *gomock.Controller) *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer {
+	mock := &MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer{ctrl: ctrl}
+	mock.recorder = &MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer) EXPECT() *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer)(nil).RecvMsg), arg0)
+}
+
+// Send mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer) Send(arg0 *eth.SignedContributionAndProof) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer)(nil).SendMsg), arg0)
+}
+
+// SetHeader mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockBeaconNodeValidator_StreamSyncCommitteeContributionsServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockBeaconNodeValidator_StreamSyncCommitteeContributionsServer)(nil).SetTrailer), arg0)
+}
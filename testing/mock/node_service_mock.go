@@ -37,6 +37,26 @@ func (m *MockNodeClient) EXPECT() *MockNodeClientMockRecorder {
 	return m.recorder
 }
 
+// GetENR mocks base method.
+func (m *MockNodeClient) GetENR(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.NodeRecord, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetENR", varargs...)
+	ret0, _ := ret[0].(*eth.NodeRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetENR indicates an expected call of GetENR.
+func (mr *MockNodeClientMockRecorder) GetENR(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetENR", reflect.TypeOf((*MockNodeClient)(nil).GetENR), varargs...)
+}
+
 // GetETH1ConnectionStatus mocks base method.
 func (m *MockNodeClient) GetETH1ConnectionStatus(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.ETH1ConnectionStatus, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,122 @@
+package simulation
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+// maxFrameSize bounds the length prefix of a gossip RPC read from a lossy stream.
+const maxFrameSize = 1 << 22
+
+var errFrameTooLarge = errors.New("gossip frame too large")
+
+// lossyHost drops a fraction of the gossip messages received by its pubsub router. Subscriptions
+// and control messages are always delivered, so that the gossip topology does not depend on the loss.
+type lossyHost struct {
+	host.Host
+	network *Network
+}
+
+// SetStreamHandler registers the handler, with incoming gossip streams made lossy.
+func (h *lossyHost) SetStreamHandler(pid protocol.ID, handler network.StreamHandler) {
+	if !isGossipProtocol(pid) {
+		h.Host.SetStreamHandler(pid, handler)
+		return
+	}
+	h.Host.SetStreamHandler(pid, func(s network.Stream) {
+		handler(h.newLossyStream(s))
+	})
+}
+
+func isGossipProtocol(pid protocol.ID) bool {
+	return pid == pubsub.FloodSubID || pid == pubsub.GossipSubID_v10 || pid == pubsub.GossipSubID_v11
+}
+
+// newLossyStream wraps the stream so that its gossip messages are dropped at the configured rate.
+// The dropped messages only depend on the seed and on the two hosts of the link.
+func (h *lossyHost) newLossyStream(s network.Stream) network.Stream {
+	local := h.network.index[s.Conn().LocalPeer()]
+	remote := h.network.index[s.Conn().RemotePeer()]
+	seed := h.network.cfg.Seed + int64(local*h.network.Size()+remote)
+	r, w := io.Pipe()
+	ls := &lossyStream{Stream: s, reader: r}
+	go ls.forward(w, rand.New(rand.NewSource(seed)), h.network.cfg.LossRate) // #nosec G404 -- Reproducible loss is intended.
+	return ls
+}
+
+type lossyStream struct {
+	network.Stream
+	reader *io.PipeReader
+}
+
+// Read returns the gossip RPCs of the stream without the dropped messages.
+func (s *lossyStream) Read(b []byte) (int, error) {
+	return s.reader.Read(b)
+}
+
+// forward copies the length prefixed gossip RPCs of the stream to the pipe, after dropping some
+// of their published messages.
+func (s *lossyStream) forward(w *io.PipeWriter, gen *rand.Rand, lossRate float64) {
+	r := bufio.NewReader(s.Stream)
+	for {
+		size, err := binary.ReadUvarint(r)
+		if err != nil {
+			w.CloseWithError(err)
+			return
+		}
+		if size > maxFrameSize {
+			w.CloseWithError(errFrameTooLarge)
+			return
+		}
+		frame := make([]byte, size)
+		if _, err := io.ReadFull(r, frame); err != nil {
+			w.CloseWithError(err)
+			return
+		}
+		rpc := &pubsubpb.RPC{}
+		if err := rpc.Unmarshal(frame); err != nil {
+			w.CloseWithError(err)
+			return
+		}
+		kept := rpc.Publish[:0]
+		for _, msg := range rpc.Publish {
+			if gen.Float64() >= lossRate {
+				kept = append(kept, msg)
+			}
+		}
+		if len(kept) == len(rpc.Publish) {
+			if err := writeFrame(w, frame); err != nil {
+				return
+			}
+			continue
+		}
+		rpc.Publish = kept
+		frame, err = rpc.Marshal()
+		if err != nil {
+			w.CloseWithError(err)
+			return
+		}
+		if err := writeFrame(w, frame); err != nil {
+			return
+		}
+	}
+}
+
+func writeFrame(w io.Writer, frame []byte) error {
+	prefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(prefix, uint64(len(frame)))
+	if _, err := w.Write(prefix[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(frame)
+	return err
+}
@@ -0,0 +1,162 @@
+// Package simulation runs several beacon node components in a single process, connected by an
+// in-memory libp2p network with configurable latency and message loss, and driven by a
+// deterministic slot clock. It allows tests to assert gossip validation and propagation
+// invariants across nodes, which single service tests cannot observe.
+package simulation
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+)
+
+// Config of a simulated network.
+type Config struct {
+	// Nodes is the number of hosts in the network. Every host is connected to every other host.
+	Nodes int
+	// Latency is added to every message sent over a link between two hosts.
+	Latency time.Duration
+	// LossRate is the fraction, between 0 and 1, of gossip messages dropped by the receiving host.
+	LossRate float64
+	// Seed makes the dropped frames of every link reproducible.
+	Seed int64
+	// MaxSlots is the number of slots the network can be driven through. The genesis time is set
+	// so that all of them have already started, so that no message is early for its slot.
+	MaxSlots types.Slot
+}
+
+// Network is a simulated network of libp2p hosts and its slot clock.
+type Network struct {
+	t       *testing.T
+	cfg     Config
+	mn      mocknet.Mocknet
+	hosts   []host.Host
+	index   map[peer.ID]int
+	genesis time.Time
+
+	lock      sync.RWMutex
+	slot      types.Slot
+	slotHooks []func(types.Slot)
+}
+
+// NewNetwork creates and connects the hosts of a simulated network. The network is closed when
+// the test ends.
+func NewNetwork(t *testing.T, cfg Config) *Network {
+	if cfg.Nodes < 1 {
+		t.Fatalf("A simulated network needs at least one node, got %d", cfg.Nodes)
+	}
+	if cfg.LossRate < 0 || cfg.LossRate >= 1 {
+		t.Fatalf("Loss rate must be in [0, 1), got %f", cfg.LossRate)
+	}
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	n := &Network{
+		t:       t,
+		cfg:     cfg,
+		mn:      mocknet.New(),
+		index:   make(map[peer.ID]int, cfg.Nodes),
+		genesis: time.Now().Add(-time.Duration(cfg.MaxSlots+1) * secondsPerSlot),
+	}
+	t.Cleanup(func() {
+		if err := n.mn.Close(); err != nil {
+			t.Log(err)
+		}
+	})
+	for i := 0; i < cfg.Nodes; i++ {
+		h, err := n.mn.GenPeer()
+		if err != nil {
+			t.Fatal(err)
+		}
+		n.index[h.ID()] = i
+		if cfg.LossRate > 0 {
+			h = &lossyHost{Host: h, network: n}
+		}
+		n.hosts = append(n.hosts, h)
+	}
+	n.mn.SetLinkDefaults(mocknet.LinkOptions{Latency: cfg.Latency})
+	if err := n.mn.LinkAll(); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// Host returns the host of the i-th node.
+func (n *Network) Host(i int) host.Host {
+	return n.hosts[i]
+}
+
+// Size returns the number of nodes in the network.
+func (n *Network) Size() int {
+	return len(n.hosts)
+}
+
+// ConnectAll connects every host to every other host. Protocol handlers, such as gossip, should
+// be registered on the hosts first.
+func (n *Network) ConnectAll() {
+	if err := n.mn.ConnectAllButSelf(); err != nil {
+		n.t.Fatal(err)
+	}
+}
+
+// Connect connects the hosts of the i-th and j-th nodes, to build topologies other than a full mesh.
+func (n *Network) Connect(i, j int) {
+	if _, err := n.mn.ConnectPeers(n.hosts[i].ID(), n.hosts[j].ID()); err != nil {
+		n.t.Fatal(err)
+	}
+}
+
+// Genesis returns the genesis time nodes of the network should use.
+func (n *Network) Genesis() time.Time {
+	return n.genesis
+}
+
+// Slot returns the current slot of the simulation.
+func (n *Network) Slot() types.Slot {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.slot
+}
+
+// OnSlot registers a hook called with every new slot of the simulation. Hooks are called in the
+// order they were registered.
+func (n *Network) OnSlot(hook func(types.Slot)) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.slotHooks = append(n.slotHooks, hook)
+}
+
+// AdvanceSlot moves the simulation to the next slot and calls the slot hooks.
+func (n *Network) AdvanceSlot() types.Slot {
+	n.lock.Lock()
+	if n.slot >= n.cfg.MaxSlots {
+		n.lock.Unlock()
+		n.t.Fatalf("Simulation cannot go past slot %d", n.cfg.MaxSlots)
+	}
+	n.slot++
+	slot := n.slot
+	hooks := make([]func(types.Slot), len(n.slotHooks))
+	copy(hooks, n.slotHooks)
+	n.lock.Unlock()
+
+	for _, hook := range hooks {
+		hook(slot)
+	}
+	return slot
+}
+
+// WaitFor polls the condition until it holds, failing the test after the timeout.
+func (n *Network) WaitFor(timeout time.Duration, condition func() bool, format string, args ...interface{}) {
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			n.t.Fatalf("Timed out after %v waiting for %s", timeout, fmt.Sprintf(format, args...))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,33 @@
+package recorded
+
+import (
+	"os"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/testing/spectest/shared/common/forkchoice"
+)
+
+// fixturesEnv names the environment variable pointing to the chain segments recorded with
+// tools/record-chain-segment.
+const fixturesEnv = "RECORDED_CHAIN_FIXTURES"
+
+func TestMainnet_RecordedChain(t *testing.T) {
+	fixturesPath := os.Getenv(fixturesEnv)
+	if fixturesPath == "" {
+		t.Skipf("%s is not set", fixturesEnv)
+	}
+	forkchoice.RunRecorded(t, fixturesPath)
+}
+
+func TestMainnet_RecordedChain_DoublyLinkTree(t *testing.T) {
+	fixturesPath := os.Getenv(fixturesEnv)
+	if fixturesPath == "" {
+		t.Skipf("%s is not set", fixturesEnv)
+	}
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableForkChoiceDoublyLinkedTree: true,
+	})
+	defer resetCfg()
+	forkchoice.RunRecorded(t, fixturesPath)
+}
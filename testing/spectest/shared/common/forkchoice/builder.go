@@ -74,6 +74,12 @@ func (bb *Builder) Attestation(t testing.TB, a *ethpb.Attestation) {
 	require.NoError(t, bb.service.OnAttestation(context.TODO(), a))
 }
 
+// InvalidAttestation receives the invalid attestation, such as an attestation of the current
+// slot, and checks it is rejected by forkchoice.
+func (bb *Builder) InvalidAttestation(t testing.TB, a *ethpb.Attestation) {
+	require.Equal(t, true, bb.service.OnAttestation(context.TODO(), a) != nil)
+}
+
 // AttesterSlashing receives an attester slashing and feeds it to forkchoice.
 func (bb *Builder) AttesterSlashing(s *ethpb.AttesterSlashing) {
 	slashings := []*ethpb.AttesterSlashing{s}
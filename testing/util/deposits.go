@@ -91,6 +91,24 @@ func DeterministicDepositsAndKeys(numDeposits uint64) ([]*ethpb.Deposit, []bls.S
 	return requestedDeposits, privKeys[0:numDeposits], nil
 }
 
+// DeterministicKeysInRange returns the secret keys of validators offset to offset+count-1. They match
+// the keys returned by DeterministicDepositsAndKeys, but the keys preceding the range are not
+// generated, so that large simulated validator sets can be split across processes.
+func DeterministicKeysInRange(offset, count uint64) ([]bls.SecretKey, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if offset+count <= uint64(len(privKeys)) {
+		keys := make([]bls.SecretKey, count)
+		copy(keys, privKeys[offset:offset+count])
+		return keys, nil
+	}
+	secretKeys, _, err := interop.DeterministicallyGenerateKeys(offset, count)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create deterministic keys")
+	}
+	return secretKeys, nil
+}
+
 // DepositsWithBalance generates N amount of deposits with the balances taken from the passed in balances array.
 // If an empty array is passed,
 func DepositsWithBalance(balances []uint64) ([]*ethpb.Deposit, *trie.SparseMerkleTrie, error) {
@@ -267,6 +267,30 @@ func TestDeterministicGenesisState_100Validators(t *testing.T) {
 	}
 }
 
+func TestDeterministicKeysInRange(t *testing.T) {
+	_, privKeys, err := DeterministicDepositsAndKeys(16)
+	require.NoError(t, err)
+
+	// Served from the cache.
+	keys, err := DeterministicKeysInRange(4, 8)
+	require.NoError(t, err)
+	require.Equal(t, 8, len(keys))
+	for i, key := range keys {
+		require.DeepEqual(t, privKeys[4+i].Marshal(), key.Marshal())
+	}
+
+	// Beyond the cache.
+	keys, err = DeterministicKeysInRange(12, 8)
+	require.NoError(t, err)
+	require.Equal(t, 8, len(keys))
+	for i := 0; i < 4; i++ {
+		require.DeepEqual(t, privKeys[12+i].Marshal(), keys[i].Marshal())
+	}
+	_, privKeys, err = DeterministicDepositsAndKeys(20)
+	require.NoError(t, err)
+	require.DeepEqual(t, privKeys[19].Marshal(), keys[7].Marshal())
+}
+
 func TestDepositTrieFromDeposits(t *testing.T) {
 	deposits, _, err := DeterministicDepositsAndKeys(100)
 	require.NoError(t, err)
@@ -0,0 +1,103 @@
+package util
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/crypto/hash"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+)
+
+// SyncCommitteeFixture is a deterministic sync committee selection test vector, mapping a seed and
+// the effective balances of the active validators to the selected sync committee indices.
+type SyncCommitteeFixture struct {
+	Preset            string                 `json:"preset"`
+	Seed              string                 `json:"seed"`
+	EffectiveBalances []uint64               `json:"effective_balances"`
+	Indices           []types.ValidatorIndex `json:"indices"`
+}
+
+// ComputeSyncCommitteeIndices selects sync committee members from the active validators as in the
+// spec's get_next_sync_committee_indices, given the seed of the sync committee domain. Effective
+// balances are indexed by validator index. It is written independently of the consensus code
+// so it can be used to check it.
+//
+// Spec pseudocode definition:
+//    i = 0
+//    sync_committee_indices: List[ValidatorIndex] = []
+//    while len(sync_committee_indices) < SYNC_COMMITTEE_SIZE:
+//        shuffled_index = compute_shuffled_index(uint64(i % active_validator_count), active_validator_count, seed)
+//        candidate_index = active_validator_indices[shuffled_index]
+//        random_byte = hash(seed + uint_to_bytes(uint64(i // 32)))[i % 32]
+//        effective_balance = state.validators[candidate_index].effective_balance
+//        if effective_balance * MAX_RANDOM_BYTE >= MAX_EFFECTIVE_BALANCE * random_byte:
+//            sync_committee_indices.append(candidate_index)
+//        i += 1
+//    return sync_committee_indices
+func ComputeSyncCommitteeIndices(seed [32]byte, activeIndices []types.ValidatorIndex, effectiveBalances []uint64) ([]types.ValidatorIndex, error) {
+	const maxRandomByte = uint64(1<<8 - 1)
+	cfg := params.BeaconConfig()
+	count := uint64(len(activeIndices))
+	if count == 0 {
+		return nil, errors.New("no active validators")
+	}
+	indices := make([]types.ValidatorIndex, 0, cfg.SyncCommitteeSize)
+	for i := uint64(0); uint64(len(indices)) < cfg.SyncCommitteeSize; i++ {
+		shuffled, err := helpers.ComputeShuffledIndex(types.ValidatorIndex(i%count), count, seed, true /* shuffle */)
+		if err != nil {
+			return nil, err
+		}
+		candidate := activeIndices[shuffled]
+		if uint64(candidate) >= uint64(len(effectiveBalances)) {
+			return nil, errors.Errorf("no effective balance for validator %d", candidate)
+		}
+		randomByte := hash.Hash(append(seed[:], bytesutil.Bytes8(i/32)...))[i%32]
+		if effectiveBalances[candidate]*maxRandomByte >= cfg.MaxEffectiveBalance*uint64(randomByte) {
+			indices = append(indices, candidate)
+		}
+	}
+	return indices, nil
+}
+
+// FixtureEffectiveBalances deterministically derives effective balances for a number of validators
+// from a seed. Balances range from half the max effective balance to the max effective balance,
+// in steps of the effective balance increment, so that balance weighting affects selection.
+func FixtureEffectiveBalances(seed [32]byte, validatorCount uint64) []uint64 {
+	cfg := params.BeaconConfig()
+	steps := cfg.MaxEffectiveBalance / cfg.EffectiveBalanceIncrement / 2
+	balances := make([]uint64, validatorCount)
+	for i := uint64(0); i < validatorCount; i++ {
+		h := hash.Hash(append(seed[:], bytesutil.Bytes8(i)...))
+		balances[i] = cfg.MaxEffectiveBalance - (bytesutil.FromBytes8(h[:8])%(steps+1))*cfg.EffectiveBalanceIncrement
+	}
+	return balances
+}
+
+// GenerateSyncCommitteeFixtures generates sync committee selection fixtures for the active config preset.
+// Seeds are derived from the preset name and the fixture's position, so the output is deterministic.
+func GenerateSyncCommitteeFixtures(count, validatorCount uint64) ([]*SyncCommitteeFixture, error) {
+	preset := params.BeaconConfig().PresetBase
+	activeIndices := make([]types.ValidatorIndex, validatorCount)
+	for i := range activeIndices {
+		activeIndices[i] = types.ValidatorIndex(i)
+	}
+	fixtures := make([]*SyncCommitteeFixture, count)
+	for i := uint64(0); i < count; i++ {
+		seed := hash.Hash(append([]byte(preset), bytesutil.Bytes8(i)...))
+		balances := FixtureEffectiveBalances(seed, validatorCount)
+		indices, err := ComputeSyncCommitteeIndices(seed, activeIndices, balances)
+		if err != nil {
+			return nil, err
+		}
+		fixtures[i] = &SyncCommitteeFixture{
+			Preset:            preset,
+			Seed:              hex.EncodeToString(seed[:]),
+			EffectiveBalances: balances,
+			Indices:           indices,
+		}
+	}
+	return fixtures, nil
+}
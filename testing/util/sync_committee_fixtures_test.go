@@ -0,0 +1,49 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestGenerateSyncCommitteeFixtures_Deterministic(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig())
+
+	first, err := GenerateSyncCommitteeFixtures(3, 64)
+	require.NoError(t, err)
+	second, err := GenerateSyncCommitteeFixtures(3, 64)
+	require.NoError(t, err)
+	assert.DeepEqual(t, first, second)
+
+	for _, f := range first {
+		assert.Equal(t, "minimal", f.Preset)
+		assert.Equal(t, params.BeaconConfig().SyncCommitteeSize, uint64(len(f.Indices)))
+		for _, b := range f.EffectiveBalances {
+			assert.Equal(t, true, b >= params.BeaconConfig().MaxEffectiveBalance/2 && b <= params.BeaconConfig().MaxEffectiveBalance)
+			assert.Equal(t, uint64(0), b%params.BeaconConfig().EffectiveBalanceIncrement)
+		}
+	}
+	assert.NotEqual(t, first[0].Seed, first[1].Seed)
+}
+
+func TestComputeSyncCommitteeIndices_ZeroBalanceNeverSelected(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig())
+
+	active := []types.ValidatorIndex{0, 1, 2, 3}
+	balances := []uint64{params.BeaconConfig().MaxEffectiveBalance, 0, params.BeaconConfig().MaxEffectiveBalance, 0}
+	indices, err := ComputeSyncCommitteeIndices([32]byte{'a'}, active, balances)
+	require.NoError(t, err)
+	for _, i := range indices {
+		assert.Equal(t, true, i == 0 || i == 2, "selected validator %d without balance", i)
+	}
+
+	_, err = ComputeSyncCommitteeIndices([32]byte{}, nil, nil)
+	assert.ErrorContains(t, "no active validators", err)
+	_, err = ComputeSyncCommitteeIndices([32]byte{}, []types.ValidatorIndex{5}, balances)
+	assert.ErrorContains(t, "no effective balance for validator 5", err)
+}
@@ -104,6 +104,16 @@ func EpochEnd(epoch types.Epoch) (types.Slot, error) {
 	return slot - 1, nil
 }
 
+// NextEpochStart returns the first slot number of the epoch
+// following the given epoch.
+func NextEpochStart(epoch types.Epoch) (types.Slot, error) {
+	next, err := epoch.SafeAdd(1)
+	if err != nil {
+		return 0, errors.Errorf("next epoch calculation overflows: %v", err)
+	}
+	return EpochStart(next)
+}
+
 // IsEpochStart returns true if the given slot number is an epoch starting slot
 // number.
 func IsEpochStart(slot types.Slot) bool {
@@ -209,6 +219,14 @@ func PrevSlot(slot types.Slot) types.Slot {
 	return 0
 }
 
+// PrevEpoch returns previous epoch, with an exception in epoch 0 to prevent underflow.
+func PrevEpoch(epoch types.Epoch) types.Epoch {
+	if epoch > 0 {
+		return epoch.Sub(1)
+	}
+	return 0
+}
+
 // SyncCommitteePeriod returns the sync committee period of input epoch `e`.
 //
 // Spec code:
@@ -227,3 +245,16 @@ func SyncCommitteePeriodStartEpoch(e types.Epoch) (types.Epoch, error) {
 	}
 	return types.Epoch(startEpoch), nil
 }
+
+// SyncCommitteePeriodEndEpoch returns the last epoch of a sync committee period.
+func SyncCommitteePeriodEndEpoch(e types.Epoch) (types.Epoch, error) {
+	startEpoch, err := SyncCommitteePeriodStartEpoch(e)
+	if err != nil {
+		return 0, err
+	}
+	endEpoch, err := mathutil.Add64(uint64(startEpoch), uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod-1))
+	if err != nil {
+		return 0, err
+	}
+	return types.Epoch(endEpoch), nil
+}
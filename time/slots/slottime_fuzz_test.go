@@ -0,0 +1,98 @@
+//go:build fuzz && go1.18
+
+package slots_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+func FuzzEpochStartEnd(f *testing.F) {
+	f.Add(uint64(0))
+	f.Add(uint64(1 << 59))
+	f.Add(^uint64(0))
+
+	f.Fuzz(func(t *testing.T, e uint64) {
+		epoch := types.Epoch(e)
+		start, err := slots.EpochStart(epoch)
+		if err != nil {
+			return
+		}
+		if slots.ToEpoch(start) != epoch || !slots.IsEpochStart(start) {
+			t.Fatalf("start slot %d is not the first slot of epoch %d", start, epoch)
+		}
+		end, err := slots.EpochEnd(epoch)
+		if err != nil {
+			// The epoch end only overflows for the last epoch with a start slot.
+			if _, nextErr := slots.NextEpochStart(epoch); nextErr == nil {
+				t.Fatalf("epoch end of %d overflows while the next epoch start does not", epoch)
+			}
+			return
+		}
+		if slots.ToEpoch(end) != epoch || !slots.IsEpochEnd(end) {
+			t.Fatalf("end slot %d is not the last slot of epoch %d", end, epoch)
+		}
+		next, err := slots.NextEpochStart(epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next != end+1 {
+			t.Fatalf("next epoch start %d does not follow end slot %d", next, end)
+		}
+	})
+}
+
+func FuzzPrevSlotEpoch(f *testing.F) {
+	f.Add(uint64(0))
+	f.Add(^uint64(0))
+
+	f.Fuzz(func(t *testing.T, i uint64) {
+		slot := types.Slot(i)
+		prev := slots.PrevSlot(slot)
+		if prev > slot || (slot > 0 && prev != slot-1) {
+			t.Fatalf("unexpected previous slot %d of slot %d", prev, slot)
+		}
+		epoch := types.Epoch(i)
+		prevEpoch := slots.PrevEpoch(epoch)
+		if prevEpoch > epoch || (epoch > 0 && prevEpoch != epoch-1) {
+			t.Fatalf("unexpected previous epoch %d of epoch %d", prevEpoch, epoch)
+		}
+		if slots.ToEpoch(prev) > slots.ToEpoch(slot) || slots.ToEpoch(slot)-slots.ToEpoch(prev) > 1 {
+			t.Fatalf("slots %d and %d are not in the same or adjacent epochs", prev, slot)
+		}
+	})
+}
+
+func FuzzSyncCommitteePeriodBoundaries(f *testing.F) {
+	f.Add(uint64(0))
+	f.Add(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	f.Add(^uint64(0))
+
+	f.Fuzz(func(t *testing.T, e uint64) {
+		epoch := types.Epoch(e)
+		period := slots.SyncCommitteePeriod(epoch)
+		start, err := slots.SyncCommitteePeriodStartEpoch(epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		end, err := slots.SyncCommitteePeriodEndEpoch(epoch)
+		if err != nil {
+			return
+		}
+		if start > epoch || epoch > end {
+			t.Fatalf("epoch %d is not within its sync committee period [%d, %d]", epoch, start, end)
+		}
+		if slots.SyncCommitteePeriod(start) != period || slots.SyncCommitteePeriod(end) != period {
+			t.Fatalf("period boundaries [%d, %d] are not in period %d", start, end, period)
+		}
+		if uint64(end-start+1) != uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod) {
+			t.Fatalf("period [%d, %d] does not span a full sync committee period", start, end)
+		}
+		if end < ^types.Epoch(0) && slots.SyncCommitteePeriod(end+1) != period+1 {
+			t.Fatalf("epoch after period end %d is not in the next period", end)
+		}
+	})
+}
@@ -177,6 +177,29 @@ func TestEpochEndSlot_OK(t *testing.T) {
 	}
 }
 
+func TestNextEpochStart(t *testing.T) {
+	tests := []struct {
+		epoch     types.Epoch
+		startSlot types.Slot
+		error     string
+	}{
+		{epoch: 0, startSlot: params.BeaconConfig().SlotsPerEpoch},
+		{epoch: 1, startSlot: 2 * params.BeaconConfig().SlotsPerEpoch},
+		{epoch: 10, startSlot: 11 * params.BeaconConfig().SlotsPerEpoch},
+		{epoch: 1 << 59, error: "start slot calculation overflow"},
+		{epoch: math.MaxUint64, error: "next epoch calculation overflow"},
+	}
+	for _, tt := range tests {
+		ss, err := NextEpochStart(tt.epoch)
+		if tt.error == "" {
+			require.NoError(t, err)
+			assert.Equal(t, tt.startSlot, ss, "NextEpochStart(%d)", tt.epoch)
+		} else {
+			require.ErrorContains(t, tt.error, err)
+		}
+	}
+}
+
 func TestIsEpochStart(t *testing.T) {
 	epochLength := params.BeaconConfig().SlotsPerEpoch
 
@@ -429,6 +452,37 @@ func TestPrevSlot(t *testing.T) {
 	}
 }
 
+func TestPrevEpoch(t *testing.T) {
+	tests := []struct {
+		name  string
+		epoch types.Epoch
+		want  types.Epoch
+	}{
+		{
+			name:  "no underflow",
+			epoch: 0,
+			want:  0,
+		},
+		{
+			name:  "epoch 1",
+			epoch: 1,
+			want:  0,
+		},
+		{
+			name:  "max",
+			epoch: math.MaxUint64,
+			want:  math.MaxUint64 - 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PrevEpoch(tt.epoch); got != tt.want {
+				t.Errorf("PrevEpoch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSyncCommitteePeriod(t *testing.T) {
 	tests := []struct {
 		epoch  types.Epoch
@@ -460,3 +514,22 @@ func TestSyncCommitteePeriodStartEpoch(t *testing.T) {
 		require.Equal(t, test.wanted, e)
 	}
 }
+
+func TestSyncCommitteePeriodEndEpoch(t *testing.T) {
+	period := params.BeaconConfig().EpochsPerSyncCommitteePeriod
+	tests := []struct {
+		epoch  types.Epoch
+		wanted types.Epoch
+	}{
+		{epoch: 0, wanted: period - 1},
+		{epoch: period - 1, wanted: period - 1},
+		{epoch: period, wanted: period*2 - 1},
+		{epoch: period*2 + 100, wanted: period*3 - 1},
+		{epoch: math.MaxUint64, wanted: math.MaxUint64},
+	}
+	for _, test := range tests {
+		e, err := SyncCommitteePeriodEndEpoch(test.epoch)
+		require.NoError(t, err)
+		require.Equal(t, test.wanted, e)
+	}
+}
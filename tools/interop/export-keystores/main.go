@@ -0,0 +1,122 @@
+// Package main provides a tool named export-keystores which deterministically generates interop validator
+// keys and exports them as EIP-2335 keystores, split across any number of directories. This is useful to run
+// a large simulated validator set across several machines reproducibly: each machine imports one directory,
+// or equivalently runs its validator client with the matching --interop-key-ranges printed by the tool.
+//
+// For example, to spread keys 0 to 1023 across 4 directories, each containing 256 keys:
+//
+// ./main -key-ranges=0:1024 -num-splits=4 -out-dir=/path/to/keys -keystore-password-file=/path/to/password.txt
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/runtime/interop"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+var (
+	keyRangesFlag    = flag.String("key-ranges", "", "Comma separated offset:count ranges of interop keys to export, such as 0:1024")
+	numSplitsFlag    = flag.Uint64("num-splits", 1, "Number of directories to split each key range across")
+	outDirFlag       = flag.String("out-dir", "", "Output directory for the keystore directories")
+	passwordFileFlag = flag.String("keystore-password-file", "", "File containing the password to encrypt all keystores")
+)
+
+func main() {
+	flag.Parse()
+	if *outDirFlag == "" {
+		log.Fatal("Please specify an -out-dir to write the keystores to")
+	}
+	ranges, err := interop.ParseKeyRanges(*keyRangesFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	password, err := file.ReadFileAsBytes(*passwordFileFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	splits, err := splitKeyRanges(ranges, *numSplitsFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for i, split := range splits {
+		dir := filepath.Join(*outDirFlag, fmt.Sprintf("split-%d", i))
+		if err := exportKeystores(dir, split, strings.TrimSpace(string(password))); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Exported keys %s to %s\n", formatKeyRanges(split), dir)
+	}
+	log.Println("Done")
+}
+
+// splitKeyRanges splits each range across the given number of parts, and returns the ranges of each part.
+func splitKeyRanges(ranges []interop.KeyRange, numSplits uint64) ([][]interop.KeyRange, error) {
+	splits := make([][]interop.KeyRange, numSplits)
+	for _, r := range ranges {
+		parts, err := interop.SplitKeyRange(r, numSplits)
+		if err != nil {
+			return nil, err
+		}
+		for i, part := range parts {
+			splits[i] = append(splits[i], part)
+		}
+	}
+	return splits, nil
+}
+
+// formatKeyRanges formats ranges as the value of the validator client --interop-key-ranges flag.
+func formatKeyRanges(ranges []interop.KeyRange) string {
+	formatted := make([]string, len(ranges))
+	for i, r := range ranges {
+		formatted[i] = r.String()
+	}
+	return strings.Join(formatted, ",")
+}
+
+// exportKeystores writes the keys of the ranges to dir as keystore-<index>.json files, encrypted with password.
+func exportKeystores(dir string, ranges []interop.KeyRange, password string) error {
+	if err := file.MkdirAll(dir); err != nil {
+		return errors.Wrapf(err, "could not create directory %s", dir)
+	}
+	encryptor := keystorev4.New()
+	for _, r := range ranges {
+		secretKeys, _, err := interop.DeterministicallyGenerateKeys(r.Offset, r.Count)
+		if err != nil {
+			return err
+		}
+		for i, sk := range secretKeys {
+			cryptoFields, err := encryptor.Encrypt(sk.Marshal(), password)
+			if err != nil {
+				return errors.Wrap(err, "could not encrypt secret key")
+			}
+			id, err := uuid.NewRandom()
+			if err != nil {
+				return errors.Wrap(err, "could not generate new random uuid")
+			}
+			encoded, err := json.MarshalIndent(&keymanager.Keystore{
+				Crypto:  cryptoFields,
+				ID:      id.String(),
+				Pubkey:  fmt.Sprintf("%x", sk.PublicKey().Marshal()),
+				Version: encryptor.Version(),
+				Name:    encryptor.Name(),
+			}, "", "\t")
+			if err != nil {
+				return errors.Wrap(err, "could not marshal keystore")
+			}
+			path := filepath.Join(dir, fmt.Sprintf("keystore-%d.json", r.Offset+uint64(i)))
+			if err := file.WriteFile(path, encoded); err != nil {
+				return errors.Wrapf(err, "could not write keystore %s", path)
+			}
+		}
+	}
+	return nil
+}
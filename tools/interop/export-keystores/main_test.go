@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/runtime/interop"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+func TestSplitKeyRanges(t *testing.T) {
+	splits, err := splitKeyRanges([]interop.KeyRange{{Offset: 0, Count: 4}, {Offset: 100, Count: 3}}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(splits))
+	assert.Equal(t, "0:2,100:2", formatKeyRanges(splits[0]))
+	assert.Equal(t, "2:2,102:1", formatKeyRanges(splits[1]))
+
+	_, err = splitKeyRanges([]interop.KeyRange{{Offset: 0, Count: 1}}, 2)
+	assert.ErrorContains(t, "cannot split 1 keys in 2 parts", err)
+}
+
+func TestExportKeystores(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "split-0")
+	require.NoError(t, exportKeystores(dir, []interop.KeyRange{{Offset: 5, Count: 1}, {Offset: 9, Count: 1}}, "password"))
+
+	secretKeys, _, err := interop.DeterministicallyGenerateKeysInRanges([]interop.KeyRange{{Offset: 5, Count: 1}, {Offset: 9, Count: 1}})
+	require.NoError(t, err)
+	for i, name := range []string{"keystore-5.json", "keystore-9.json"} {
+		enc, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		ks := &keymanager.Keystore{}
+		require.NoError(t, json.Unmarshal(enc, ks))
+		sk, err := keystorev4.New().Decrypt(ks.Crypto, "password")
+		require.NoError(t, err)
+		assert.DeepEqual(t, secretKeys[i].Marshal(), sk)
+	}
+}
@@ -0,0 +1,255 @@
+/**
+ * Record chain segment
+ *
+ * Given a beacon-chain DB synced past the requested epochs, this tool records the finalized
+ * blocks of the epochs, along with the head, justified and finalized checkpoints reached at each
+ * epoch boundary, as a "forkchoice" test case anchored at the checkpoint of the first epoch. The recorded segments are replayed by the
+ * recorded chain conformance test in testing/spectest/mainnet/recorded, which checks that the
+ * node reaches the same checkpoints without any execution client.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	datadir    = flag.String("datadir", "", "Path to data directory.")
+	startEpoch = flag.Uint64("start-epoch", 0, "First epoch of the recorded segment.")
+	numEpochs  = flag.Uint64("epochs", 4, "Number of epochs to record, which must include the finalization of the first one.")
+	output     = flag.String("output", "", "Directory to write the recorded segment to.")
+)
+
+// step, check, slotRoot and epochRoot mirror the steps of the "forkchoice" test format.
+type step struct {
+	Tick  *uint64 `json:"tick,omitempty"`
+	Block *string `json:"block,omitempty"`
+	Check *check  `json:"checks,omitempty"`
+}
+
+type check struct {
+	Head                *slotRoot  `json:"head,omitempty"`
+	JustifiedCheckpoint *epochRoot `json:"justified_checkpoint,omitempty"`
+	FinalizedCheckpoint *epochRoot `json:"finalized_checkpoint,omitempty"`
+}
+
+type slotRoot struct {
+	Slot types.Slot `json:"slot"`
+	Root string     `json:"root"`
+}
+
+type epochRoot struct {
+	Epoch types.Epoch `json:"epoch"`
+	Root  string      `json:"root"`
+}
+
+func main() {
+	flag.Parse()
+	if *datadir == "" || *output == "" {
+		log.Fatal("Please specify --datadir and --output")
+	}
+	if *numEpochs == 0 {
+		log.Fatal("Please specify a positive number of --epochs")
+	}
+
+	ctx := context.Background()
+	database, err := db.NewDB(ctx, *datadir, &kv.Config{})
+	if err != nil {
+		log.WithError(err).Fatal("Could not open database")
+	}
+	defer func() {
+		if err := database.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	start := types.Epoch(*startEpoch)
+	end := start.Add(*numEpochs)
+	segmentPath, err := record(ctx, database, start, end, *output)
+	if err != nil {
+		log.WithError(err).Fatal("Could not record chain segment")
+	}
+	log.WithField("path", segmentPath).Info("Recorded chain segment")
+}
+
+// record writes the finalized blocks of epochs [start, end) as a "forkchoice" test case under
+// <output>/<fork>/, and returns the path of the test case.
+func record(ctx context.Context, database db.Database, start, end types.Epoch, output string) (string, error) {
+	finalized, err := database.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	if end > finalized.Epoch {
+		return "", fmt.Errorf("segment must be finalized, but ends at epoch %d after finalized epoch %d", end, finalized.Epoch)
+	}
+	startSlot, err := slots.EpochStart(start)
+	if err != nil {
+		return "", err
+	}
+	endSlot, err := slots.EpochStart(end)
+	if err != nil {
+		return "", err
+	}
+
+	// Walk the canonical chain back from the finalized checkpoint. The anchor is the last block
+	// at or before the start of the segment.
+	var blocks []interfaces.SignedBeaconBlock
+	var roots [][32]byte
+	var anchor interfaces.SignedBeaconBlock
+	var anchorRoot [32]byte
+	root := bytesutil.ToBytes32(finalized.Root)
+	for anchor == nil {
+		blk, err := database.Block(ctx, root)
+		if err != nil {
+			return "", errors.Wrapf(err, "could not get block %#x", root)
+		}
+		if blk == nil || blk.IsNil() {
+			return "", fmt.Errorf("block %#x is missing from the database", root)
+		}
+		if blk.Block().IsBlinded() {
+			return "", fmt.Errorf("block %#x is stored blinded", root)
+		}
+		slot := blk.Block().Slot()
+		switch {
+		case slot <= startSlot:
+			anchor, anchorRoot = blk, root
+		case slot < endSlot:
+			blocks = append([]interfaces.SignedBeaconBlock{blk}, blocks...)
+			roots = append([][32]byte{root}, roots...)
+		}
+		root = bytesutil.ToBytes32(blk.Block().ParentRoot())
+	}
+
+	gen := stategen.New(database)
+	anchorState, err := gen.StateByRoot(ctx, anchorRoot)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get anchor state")
+	}
+	// Fork choice starts with the anchor as its checkpoint of the anchor state's epoch, which is
+	// only consistent when the anchor state is at the start of the epoch.
+	if anchorState.Slot() < startSlot {
+		anchorState, err = transition.ProcessSlots(ctx, anchorState, startSlot)
+		if err != nil {
+			return "", errors.Wrap(err, "could not advance anchor state")
+		}
+	}
+	fork := anchorState.Version()
+	for i, blk := range blocks {
+		if blk.Version() != fork {
+			return "", fmt.Errorf("block %#x at slot %d crosses the %s fork boundary", roots[i], blk.Block().Slot(), version.String(fork))
+		}
+	}
+
+	segmentPath := filepath.Join(output, version.String(fork), fmt.Sprintf("epochs_%d_%d", start, end-1))
+	if err := os.MkdirAll(segmentPath, os.ModePerm); err != nil {
+		return "", err
+	}
+	anchorStateSSZ, err := anchorState.MarshalSSZ()
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal anchor state")
+	}
+	if err := writeSnappy(segmentPath, "anchor_state", anchorStateSSZ); err != nil {
+		return "", err
+	}
+	anchorSSZ, err := anchor.Block().MarshalSSZ()
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal anchor block")
+	}
+	if err := writeSnappy(segmentPath, "anchor_block", anchorSSZ); err != nil {
+		return "", err
+	}
+
+	// Fork choice only considers blocks for head once their state finalizes the anchor, so the
+	// checkpoints are checked from then on.
+	anchorFinalized := false
+	steps := make([]*step, 0)
+	for i, blk := range blocks {
+		slot := blk.Block().Slot()
+		name := fmt.Sprintf("block_%#x", roots[i])
+		blkSSZ, err := blk.MarshalSSZ()
+		if err != nil {
+			return "", errors.Wrapf(err, "could not marshal block %#x", roots[i])
+		}
+		if err := writeSnappy(segmentPath, name, blkSSZ); err != nil {
+			return "", err
+		}
+		steps = append(steps, &step{Tick: tick(slot)}, &step{Block: &name})
+
+		// The checkpoints are checked at the start of the epoch following the last block of each
+		// epoch, once fork choice has applied the justification seen during the epoch.
+		if i+1 < len(blocks) && slots.ToEpoch(blocks[i+1].Block().Slot()) == slots.ToEpoch(slot) {
+			continue
+		}
+		st, err := gen.StateByRoot(ctx, roots[i])
+		if err != nil {
+			return "", errors.Wrapf(err, "could not get post state of block %#x", roots[i])
+		}
+		if st.FinalizedCheckpoint().Epoch < start {
+			continue
+		}
+		anchorFinalized = true
+		nextEpochStart, err := slots.EpochStart(slots.ToEpoch(slot) + 1)
+		if err != nil {
+			return "", err
+		}
+		steps = append(steps, &step{Tick: tick(nextEpochStart)}, &step{Check: checkpoints(slot, roots[i], st)})
+	}
+	if !anchorFinalized {
+		return "", fmt.Errorf("epoch %d is not finalized within the segment, record more epochs", start)
+	}
+
+	stepsJSON, err := json.MarshalIndent(steps, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	// JSON is valid YAML.
+	if err := os.WriteFile(filepath.Join(segmentPath, "steps.yaml"), stepsJSON, params.BeaconIoConfig().ReadWritePermissions); err != nil {
+		return "", err
+	}
+	return segmentPath, nil
+}
+
+// checkpoints returns the checks for the head block and its post state.
+func checkpoints(slot types.Slot, root [32]byte, st state.BeaconState) *check {
+	return &check{
+		Head:                &slotRoot{Slot: slot, Root: fmt.Sprintf("%#x", root)},
+		JustifiedCheckpoint: toEpochRoot(st.CurrentJustifiedCheckpoint()),
+		FinalizedCheckpoint: toEpochRoot(st.FinalizedCheckpoint()),
+	}
+}
+
+func toEpochRoot(cp *ethpb.Checkpoint) *epochRoot {
+	return &epochRoot{Epoch: cp.Epoch, Root: fmt.Sprintf("%#x", cp.Root)}
+}
+
+// tick returns the number of seconds from genesis to the start of the slot.
+func tick(slot types.Slot) *uint64 {
+	t := uint64(slot) * params.BeaconConfig().SecondsPerSlot
+	return &t
+}
+
+func writeSnappy(dir, name string, ssz []byte) error {
+	return os.WriteFile(filepath.Join(dir, name+".ssz_snappy"), snappy.Encode(nil /* dst */, ssz), params.BeaconIoConfig().ReadWritePermissions)
+}
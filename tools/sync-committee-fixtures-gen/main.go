@@ -0,0 +1,50 @@
+// This tool generates deterministic sync committee selection fixtures, mapping seeds and
+// effective balances to the sync committee indices selected by the spec's
+// get_next_sync_committee_indices, for a given config preset.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+var (
+	configName    = flag.String("config-name", params.MinimalName, "Name of the config whose preset is used to select the committees, e.g. mainnet or minimal")
+	numFixtures   = flag.Uint64("num-fixtures", 8, "Number of fixtures to generate")
+	numValidators = flag.Uint64("num-validators", 256, "Number of active validators in each fixture")
+	outputFile    = flag.String("output-json", "", "Output filename of the generated fixtures")
+)
+
+func main() {
+	flag.Parse()
+	if *outputFile == "" {
+		log.Fatal("Expected --output-json to have been provided")
+	}
+	if *numValidators == 0 {
+		log.Fatal("Expected --num-validators to be greater than 0")
+	}
+	cfg, err := params.ByName(*configName)
+	if err != nil {
+		log.Fatalf("unable to find config using name %s, err=%s", *configName, err.Error())
+	}
+	if err := params.SetActive(cfg.Copy()); err != nil {
+		log.Fatalf("unable to set %s config active, err=%s", cfg.ConfigName, err.Error())
+	}
+	fixtures, err := util.GenerateSyncCommitteeFixtures(*numFixtures, *numValidators)
+	if err != nil {
+		log.Fatalf("Could not generate fixtures: %v", err)
+	}
+	enc, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		log.Fatalf("Could not marshal fixtures: %v", err)
+	}
+	if err := file.WriteFile(*outputFile, enc); err != nil {
+		log.Fatalf("Could not write fixtures to %s: %v", *outputFile, err)
+	}
+	log.Printf("Wrote %d %s fixtures to %s", len(fixtures), cfg.PresetBase, *outputFile)
+}
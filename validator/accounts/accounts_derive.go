@@ -0,0 +1,34 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/derived"
+)
+
+// Derive the accounts at the requested EIP-2334 derivation paths from the mnemonic of an HD wallet,
+// and add those not already in the wallet to it.
+func (acm *AccountsCLIManager) Derive(ctx context.Context) error {
+	km, ok := acm.keymanager.(*derived.Keymanager)
+	if !ok {
+		return errors.New("can only derive accounts for an HD wallet")
+	}
+	if len(acm.derivationPaths) == 0 {
+		return errors.New("no derivation path given")
+	}
+	pubKeys, err := km.RecoverAccountsFromMnemonicAtPaths(ctx, acm.mnemonic, acm.mnemonic25thWord, acm.derivationPaths)
+	if err != nil {
+		return errors.Wrap(err, "could not derive accounts")
+	}
+	for i, pubKey := range pubKeys {
+		fmt.Printf("%s %s\n", au.BrightCyan(acm.derivationPaths[i]).Bold(), au.BrightGreen(fmt.Sprintf("%#x", bytesutil.Trunc(pubKey))))
+	}
+	log.WithField("wallet-path", acm.wallet.AccountsDir()).Infof(
+		"Successfully derived %d accounts. Please use `accounts list` to view details for your accounts",
+		len(pubKeys),
+	)
+	return nil
+}
@@ -169,3 +169,27 @@ func WithFormattedPubKeys(formattedPubKeys []string) Option {
 		return nil
 	}
 }
+
+// WithMnemonic provides the mnemonic phrase of an HD wallet to derive accounts from.
+func WithMnemonic(mnemonic string) Option {
+	return func(acc *AccountsCLIManager) error {
+		acc.mnemonic = mnemonic
+		return nil
+	}
+}
+
+// WithMnemonic25thWord provides the '25th word' passphrase of the mnemonic of an HD wallet.
+func WithMnemonic25thWord(mnemonic25thWord string) Option {
+	return func(acc *AccountsCLIManager) error {
+		acc.mnemonic25thWord = mnemonic25thWord
+		return nil
+	}
+}
+
+// WithDerivationPaths provides the EIP-2334 derivation paths of the accounts to derive.
+func WithDerivationPaths(paths []string) Option {
+	return func(acc *AccountsCLIManager) error {
+		acc.derivationPaths = paths
+		return nil
+	}
+}
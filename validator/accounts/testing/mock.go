@@ -85,6 +85,10 @@ type MockValidator struct {
 
 func (_ MockValidator) LogSyncCommitteeMessagesSubmitted() {}
 
+func (_ MockValidator) ReportDutyResults(_ context.Context) {}
+
+func (_ MockValidator) CrossCheckValidators(_ context.Context, _ types.Slot) {}
+
 func (_ MockValidator) Done() {
 	panic("implement me")
 }
@@ -137,6 +141,14 @@ func (_ MockValidator) SubmitAggregateAndProof(_ context.Context, _ types.Slot,
 	panic("implement me")
 }
 
+func (_ MockValidator) SubmitAttestations(_ context.Context, _ types.Slot, _ [][48]byte) {
+	panic("implement me")
+}
+
+func (_ MockValidator) SubmitAggregatesAndProofs(_ context.Context, _ types.Slot, _ [][48]byte) {
+	panic("implement me")
+}
+
 func (_ MockValidator) SubmitSyncCommitteeMessage(_ context.Context, _ types.Slot, _ [48]byte) {
 	panic("implement me")
 }
@@ -57,34 +57,9 @@ func RecoverWalletCli(cliCtx *cli.Context) error {
 	config := &RecoverWalletConfig{
 		Mnemonic: mnemonic,
 	}
-	skipMnemonic25thWord := cliCtx.IsSet(flags.SkipMnemonic25thWordCheckFlag.Name)
-	has25thWordFile := cliCtx.IsSet(flags.Mnemonic25thWordFileFlag.Name)
-	if !skipMnemonic25thWord && !has25thWordFile {
-		resp, err := prompt.ValidatePrompt(
-			os.Stdin, mnemonicPassphraseYesNoText, prompt.ValidateYesOrNo,
-		)
-		if err != nil {
-			return errors.Wrap(err, "could not validate choice")
-		}
-		if strings.EqualFold(resp, "y") {
-			mnemonicPassphrase, err := prompt.InputPassword(
-				cliCtx,
-				flags.Mnemonic25thWordFileFlag,
-				mnemonicPassphrasePromptText,
-				"Confirm mnemonic passphrase",
-				false, /* Should confirm password */
-				func(input string) error {
-					if strings.TrimSpace(input) == "" {
-						return errors.New("input cannot be empty")
-					}
-					return nil
-				},
-			)
-			if err != nil {
-				return err
-			}
-			config.Mnemonic25thWord = mnemonicPassphrase
-		}
+	config.Mnemonic25thWord, err = inputMnemonic25thWord(cliCtx)
+	if err != nil {
+		return err
 	}
 	walletDir, err := userprompt.InputDirectory(cliCtx, userprompt.WalletDirPromptText, flags.WalletDirFlag)
 	if err != nil {
@@ -153,6 +128,20 @@ func RecoverWallet(ctx context.Context, cfg *RecoverWalletConfig) (*wallet.Walle
 	return w, nil
 }
 
+// InputMnemonic asks for the mnemonic phrase of an HD wallet and its optional '25th word'
+// passphrase, unless given by the mnemonic-file and mnemonic-25th-word-file flags.
+func InputMnemonic(cliCtx *cli.Context) (mnemonic, mnemonic25thWord string, err error) {
+	mnemonic, err = inputMnemonic(cliCtx)
+	if err != nil {
+		return "", "", errors.Wrap(err, "could not get mnemonic phrase")
+	}
+	mnemonic25thWord, err = inputMnemonic25thWord(cliCtx)
+	if err != nil {
+		return "", "", err
+	}
+	return mnemonic, mnemonic25thWord, nil
+}
+
 func inputMnemonic(cliCtx *cli.Context) (mnemonicPhrase string, err error) {
 	if cliCtx.IsSet(flags.MnemonicFileFlag.Name) {
 		mnemonicFilePath := cliCtx.String(flags.MnemonicFileFlag.Name)
@@ -206,6 +195,36 @@ func inputMnemonic(cliCtx *cli.Context) (mnemonicPhrase string, err error) {
 	return mnemonicPhrase, nil
 }
 
+func inputMnemonic25thWord(cliCtx *cli.Context) (string, error) {
+	if cliCtx.IsSet(flags.SkipMnemonic25thWordCheckFlag.Name) && !cliCtx.IsSet(flags.Mnemonic25thWordFileFlag.Name) {
+		return "", nil
+	}
+	if !cliCtx.IsSet(flags.Mnemonic25thWordFileFlag.Name) {
+		resp, err := prompt.ValidatePrompt(
+			os.Stdin, mnemonicPassphraseYesNoText, prompt.ValidateYesOrNo,
+		)
+		if err != nil {
+			return "", errors.Wrap(err, "could not validate choice")
+		}
+		if !strings.EqualFold(resp, "y") {
+			return "", nil
+		}
+	}
+	return prompt.InputPassword(
+		cliCtx,
+		flags.Mnemonic25thWordFileFlag,
+		mnemonicPassphrasePromptText,
+		"Confirm mnemonic passphrase",
+		false, /* Should confirm password */
+		func(input string) error {
+			if strings.TrimSpace(input) == "" {
+				return errors.New("input cannot be empty")
+			}
+			return nil
+		},
+	)
+}
+
 func inputNumAccounts(cliCtx *cli.Context) (int64, error) {
 	if cliCtx.IsSet(flags.NumAccountsFlag.Name) {
 		numAccounts := cliCtx.Int64(flags.NumAccountsFlag.Name)
@@ -0,0 +1,314 @@
+// Package audit implements an append-only, hash-chained log of every signing
+// request made by the validator client. Each entry commits to the hash of the
+// previous entry so that any removal or modification of past records can be
+// detected when the log is exported.
+package audit
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/hash"
+	"github.com/prysmaticlabs/prysm/io/file"
+)
+
+const (
+	// LogFileName is the name of the active audit log file within the audit directory.
+	LogFileName       = "signing-audit.log"
+	rotatedFilePrefix = "signing-audit-"
+	rotatedFileSuffix = ".log"
+)
+
+const (
+	// OutcomeSigned is recorded when the keymanager produced a signature.
+	OutcomeSigned = "signed"
+	// OutcomeFailed is recorded when the keymanager returned an error.
+	OutcomeFailed = "failed"
+)
+
+// Log is an append-only audit log of signing requests. Once the active file
+// exceeds the configured maximum size it is rotated, and the hash chain
+// continues in the new file.
+type Log struct {
+	lock           sync.Mutex
+	dir            string
+	maxSize        int64
+	file           *os.File
+	size           int64
+	fileFirstIndex uint64
+	nextIndex      uint64
+	lastHash       string
+}
+
+// Open opens, or creates, the audit log in the given directory. The hash chain
+// is resumed from the last entry found on disk. A maxSize of 0 disables rotation.
+func Open(dir string, maxSize int64) (*Log, error) {
+	if err := file.MkdirAll(dir); err != nil {
+		return nil, errors.Wrap(err, "could not create audit log directory")
+	}
+	l := &Log{
+		dir:     dir,
+		maxSize: maxSize,
+	}
+	last, err := lastEntry(dir)
+	if err != nil {
+		return nil, err
+	}
+	if last != nil {
+		l.nextIndex = last.Index + 1
+		l.lastHash = last.Hash
+	}
+	if err := l.openActiveFile(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Append adds a new entry to the log, filling in its index, timestamp and hash chain fields.
+func (l *Log) Append(e *Entry) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.file == nil {
+		return errors.New("audit log is closed")
+	}
+	e.Index = l.nextIndex
+	e.Timestamp = time.Now().UTC()
+	e.PrevHash = l.lastHash
+	h, err := e.computeHash()
+	if err != nil {
+		return err
+	}
+	e.Hash = h
+	enc, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal audit log entry")
+	}
+	enc = append(enc, '\n')
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(enc)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	if _, err := l.file.Write(enc); err != nil {
+		return errors.Wrap(err, "could not write audit log entry")
+	}
+	if err := l.file.Sync(); err != nil {
+		return errors.Wrap(err, "could not sync audit log")
+	}
+	l.size += int64(len(enc))
+	l.nextIndex++
+	l.lastHash = e.Hash
+	return nil
+}
+
+// Close closes the active audit log file.
+func (l *Log) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+func (l *Log) openActiveFile() error {
+	p := filepath.Join(l.dir, LogFileName)
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_APPEND|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions) // #nosec G304
+	if err != nil {
+		return errors.Wrap(err, "could not open audit log")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Wrap(err, "could not stat audit log")
+	}
+	l.file = f
+	l.size = info.Size()
+	if l.size == 0 {
+		l.fileFirstIndex = l.nextIndex
+	} else {
+		first, err := firstEntry(p)
+		if err != nil {
+			return err
+		}
+		l.fileFirstIndex = first.Index
+	}
+	return nil
+}
+
+// rotate renames the active file after the index of its first entry and opens a fresh one.
+func (l *Log) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return errors.Wrap(err, "could not close audit log")
+	}
+	l.file = nil
+	rotated := filepath.Join(l.dir, rotatedFileName(l.fileFirstIndex))
+	if err := os.Rename(filepath.Join(l.dir, LogFileName), rotated); err != nil {
+		return errors.Wrap(err, "could not rotate audit log")
+	}
+	return l.openActiveFile()
+}
+
+func rotatedFileName(firstIndex uint64) string {
+	return fmt.Sprintf("%s%020d%s", rotatedFilePrefix, firstIndex, rotatedFileSuffix)
+}
+
+// logFiles returns the audit log files in the directory, oldest first.
+func logFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read audit log directory")
+	}
+	var rotated []string
+	active := ""
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		switch {
+		case name == LogFileName:
+			active = filepath.Join(dir, name)
+		case strings.HasPrefix(name, rotatedFilePrefix) && strings.HasSuffix(name, rotatedFileSuffix):
+			rotated = append(rotated, filepath.Join(dir, name))
+		}
+	}
+	// Rotated file names are zero padded, so lexicographic order matches log order.
+	sort.Strings(rotated)
+	if active != "" {
+		rotated = append(rotated, active)
+	}
+	return rotated, nil
+}
+
+func readFile(p string, fn func(e *Entry) error) error {
+	f, err := os.Open(p) // #nosec G304
+	if err != nil {
+		return errors.Wrapf(err, "could not open %s", p)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close audit log file")
+		}
+	}()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		e := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			return errors.Wrapf(err, "could not decode entry at %s:%d", p, line)
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func firstEntry(p string) (*Entry, error) {
+	var first *Entry
+	errStop := errors.New("stop")
+	if err := readFile(p, func(e *Entry) error {
+		first = e
+		return errStop
+	}); err != nil && err != errStop {
+		return nil, err
+	}
+	if first == nil {
+		return nil, fmt.Errorf("no entries in %s", p)
+	}
+	return first, nil
+}
+
+func lastEntry(dir string) (*Entry, error) {
+	files, err := logFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	// Walk backwards to skip an empty active file left behind by a rotation.
+	for i := len(files) - 1; i >= 0; i-- {
+		var last *Entry
+		if err := readFile(files[i], func(e *Entry) error {
+			last = e
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		if last != nil {
+			return last, nil
+		}
+	}
+	return nil, nil
+}
+
+// ReadAll returns every entry in the audit log directory in the order they were written.
+func ReadAll(dir string) ([]*Entry, error) {
+	files, err := logFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*Entry
+	for _, p := range files {
+		if err := readFile(p, func(e *Entry) error {
+			entries = append(entries, e)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// Verify checks that entries form an unbroken hash chain. The first entry may
+// reference a predecessor that is no longer present, allowing old rotated files
+// to be archived or removed.
+func Verify(entries []*Entry) error {
+	for i, e := range entries {
+		h, err := e.computeHash()
+		if err != nil {
+			return err
+		}
+		if h != e.Hash {
+			return fmt.Errorf("entry %d has been modified: hash mismatch", e.Index)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := entries[i-1]
+		if e.Index != prev.Index+1 {
+			return fmt.Errorf("entry %d follows entry %d: entries are missing", e.Index, prev.Index)
+		}
+		if e.PrevHash != prev.Hash {
+			return fmt.Errorf("entry %d does not chain to entry %d", e.Index, prev.Index)
+		}
+	}
+	return nil
+}
+
+// computeHash returns the hex encoded hash of the entry's contents, excluding its own hash.
+func (e *Entry) computeHash() (string, error) {
+	cpy := *e
+	cpy.Hash = ""
+	enc, err := json.Marshal(&cpy)
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal audit log entry")
+	}
+	h := hash.Hash(enc)
+	return hex.EncodeToString(h[:]), nil
+}
@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func attestationRequest(slot types.Slot, epoch types.Epoch) *validatorpb.SignRequest {
+	return &validatorpb.SignRequest{
+		PublicKey:   []byte{1, 2, 3},
+		SigningRoot: []byte{4, 5, 6},
+		Object: &validatorpb.SignRequest_AttestationData{
+			AttestationData: &ethpb.AttestationData{
+				Slot:   slot,
+				Target: &ethpb.Checkpoint{Epoch: epoch},
+			},
+		},
+	}
+}
+
+func TestLog_AppendAndVerify(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "audit")
+	l, err := Open(dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, l.Append(NewEntry(attestationRequest(33, 1), nil)))
+	require.NoError(t, l.Append(NewEntry(&validatorpb.SignRequest{
+		Object: &validatorpb.SignRequest_Epoch{Epoch: 2},
+	}, errors.New("remote signer unavailable"))))
+	require.NoError(t, l.Close())
+
+	entries, err := ReadAll(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	require.NoError(t, Verify(entries))
+
+	assert.Equal(t, uint64(0), entries[0].Index)
+	assert.Equal(t, "attestation", entries[0].Type)
+	assert.Equal(t, types.Slot(33), entries[0].Slot)
+	assert.Equal(t, types.Epoch(1), entries[0].Epoch)
+	assert.Equal(t, "0x010203", entries[0].PublicKey)
+	assert.Equal(t, "0x040506", entries[0].SigningRoot)
+	assert.Equal(t, OutcomeSigned, entries[0].Outcome)
+	assert.Equal(t, "", entries[0].PrevHash)
+
+	assert.Equal(t, "randao_reveal", entries[1].Type)
+	assert.Equal(t, types.Epoch(2), entries[1].Epoch)
+	assert.Equal(t, OutcomeFailed, entries[1].Outcome)
+	assert.Equal(t, "remote signer unavailable", entries[1].Error)
+	assert.Equal(t, entries[0].Hash, entries[1].PrevHash)
+}
+
+func TestLog_ResumesChainOnReopen(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "audit")
+	l, err := Open(dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, l.Append(NewEntry(attestationRequest(1, 0), nil)))
+	require.NoError(t, l.Close())
+
+	l, err = Open(dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, l.Append(NewEntry(attestationRequest(2, 0), nil)))
+	require.NoError(t, l.Close())
+
+	entries, err := ReadAll(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	assert.Equal(t, uint64(1), entries[1].Index)
+	require.NoError(t, Verify(entries))
+}
+
+func TestLog_Rotation(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "audit")
+	// Small enough that every entry lands in its own file.
+	l, err := Open(dir, 100)
+	require.NoError(t, err)
+	for i := types.Slot(0); i < 5; i++ {
+		require.NoError(t, l.Append(NewEntry(attestationRequest(i, 0), nil)))
+	}
+	require.NoError(t, l.Close())
+
+	files, err := logFiles(dir)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(files))
+	assert.Equal(t, filepath.Join(dir, rotatedFileName(0)), files[0])
+	assert.Equal(t, filepath.Join(dir, LogFileName), files[4])
+
+	entries, err := ReadAll(dir)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(entries))
+	require.NoError(t, Verify(entries))
+
+	// Archiving the oldest file keeps the remaining chain verifiable.
+	require.NoError(t, os.Remove(files[0]))
+	entries, err = ReadAll(dir)
+	require.NoError(t, err)
+	require.NoError(t, Verify(entries))
+
+	// Removing a file from the middle breaks the chain.
+	require.NoError(t, os.Remove(files[2]))
+	entries, err = ReadAll(dir)
+	require.NoError(t, err)
+	assert.ErrorContains(t, "entries are missing", Verify(entries))
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "audit")
+	l, err := Open(dir, 0)
+	require.NoError(t, err)
+	for i := types.Slot(0); i < 3; i++ {
+		require.NoError(t, l.Append(NewEntry(attestationRequest(i, 0), nil)))
+	}
+	require.NoError(t, l.Close())
+
+	entries, err := ReadAll(dir)
+	require.NoError(t, err)
+	entries[1].Slot = 100
+	assert.ErrorContains(t, "entry 1 has been modified", Verify(entries))
+
+	entries, err = ReadAll(dir)
+	require.NoError(t, err)
+	entries[2].PrevHash = entries[0].Hash
+	h, err := entries[2].computeHash()
+	require.NoError(t, err)
+	entries[2].Hash = h
+	assert.ErrorContains(t, "entry 2 does not chain to entry 1", Verify(entries))
+}
+
+func TestNewEntry_SigningSlotFallback(t *testing.T) {
+	e := NewEntry(&validatorpb.SignRequest{
+		Object:      &validatorpb.SignRequest_SyncMessageBlockRoot{SyncMessageBlockRoot: make([]byte, 32)},
+		SigningSlot: 64,
+	}, nil)
+	assert.Equal(t, "sync_committee_message", e.Type)
+	assert.Equal(t, types.Slot(64), e.Slot)
+	assert.Equal(t, types.Epoch(2), e.Epoch)
+}
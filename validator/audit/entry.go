@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"encoding/hex"
+	"time"
+
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// Entry is a single record of the audit log.
+type Entry struct {
+	Index       uint64      `json:"index"`
+	Timestamp   time.Time   `json:"timestamp"`
+	Type        string      `json:"type"`
+	Slot        types.Slot  `json:"slot"`
+	Epoch       types.Epoch `json:"epoch"`
+	PublicKey   string      `json:"public_key"`
+	SigningRoot string      `json:"signing_root"`
+	Outcome     string      `json:"outcome"`
+	Error       string      `json:"error,omitempty"`
+	PrevHash    string      `json:"prev_hash"`
+	Hash        string      `json:"hash"`
+}
+
+// NewEntry describes a signing request and the result returned by the keymanager.
+func NewEntry(req *validatorpb.SignRequest, signErr error) *Entry {
+	typ, slot, epoch := describe(req)
+	e := &Entry{
+		Type:        typ,
+		Slot:        slot,
+		Epoch:       epoch,
+		PublicKey:   "0x" + hex.EncodeToString(req.PublicKey),
+		SigningRoot: "0x" + hex.EncodeToString(req.SigningRoot),
+		Outcome:     OutcomeSigned,
+	}
+	if signErr != nil {
+		e.Outcome = OutcomeFailed
+		e.Error = signErr.Error()
+	}
+	return e
+}
+
+// describe returns the kind of object being signed along with the slot and epoch it refers to.
+func describe(req *validatorpb.SignRequest) (string, types.Slot, types.Epoch) {
+	var typ string
+	var slot types.Slot
+	var epoch types.Epoch
+	switch o := req.Object.(type) {
+	case *validatorpb.SignRequest_Block:
+		typ, slot = "block", o.Block.GetSlot()
+	case *validatorpb.SignRequest_BlockV2:
+		typ, slot = "block", o.BlockV2.GetSlot()
+	case *validatorpb.SignRequest_BlockV3:
+		typ, slot = "block", o.BlockV3.GetSlot()
+	case *validatorpb.SignRequest_BlindedBlockV3:
+		typ, slot = "blinded_block", o.BlindedBlockV3.GetSlot()
+	case *validatorpb.SignRequest_AttestationData:
+		typ, slot = "attestation", o.AttestationData.GetSlot()
+		epoch = o.AttestationData.GetTarget().GetEpoch()
+	case *validatorpb.SignRequest_AggregateAttestationAndProof:
+		typ, slot = "aggregate_and_proof", o.AggregateAttestationAndProof.GetAggregate().GetData().GetSlot()
+	case *validatorpb.SignRequest_Slot:
+		typ, slot = "aggregation_slot", o.Slot
+	case *validatorpb.SignRequest_Epoch:
+		typ, epoch = "randao_reveal", o.Epoch
+	case *validatorpb.SignRequest_Exit:
+		typ, epoch = "voluntary_exit", o.Exit.GetEpoch()
+	case *validatorpb.SignRequest_SyncMessageBlockRoot:
+		typ = "sync_committee_message"
+	case *validatorpb.SignRequest_SyncAggregatorSelectionData:
+		typ, slot = "sync_committee_selection_proof", o.SyncAggregatorSelectionData.GetSlot()
+	case *validatorpb.SignRequest_ContributionAndProof:
+		typ, slot = "sync_committee_contribution_and_proof", o.ContributionAndProof.GetContribution().GetSlot()
+	case *validatorpb.SignRequest_Registration:
+		typ = "validator_registration"
+	default:
+		typ = "unknown"
+	}
+	if slot == 0 {
+		slot = req.SigningSlot
+	}
+	if epoch == 0 && slot != 0 {
+		epoch = slots.ToEpoch(slot)
+	}
+	return typ, slot, epoch
+}
@@ -30,6 +30,8 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot types.Slot
 
 	span.AddAttributes(trace.StringAttribute("validator", fmt.Sprintf("%#x", pubKey)))
 	fmtKey := fmt.Sprintf("%#x", pubKey[:])
+	result := v.trackDuty(ethpb.DutyResult_AGGREGATION, slot, pubKey)
+	defer v.recordDutyResult(result)
 
 	duty, err := v.duty(pubKey)
 	if err != nil {
@@ -45,6 +47,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot types.Slot
 	v.aggregatedSlotCommitteeIDCacheLock.Lock()
 	if v.aggregatedSlotCommitteeIDCache.Contains(k) {
 		v.aggregatedSlotCommitteeIDCacheLock.Unlock()
+		result.skip("committee already aggregated")
 		return
 	}
 	v.aggregatedSlotCommitteeIDCache.Add(k, true)
@@ -53,6 +56,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot types.Slot
 	slotSig, err := v.signSlotWithSelectionProof(ctx, pubKey, slot)
 	if err != nil {
 		log.Errorf("Could not sign slot: %v", err)
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -74,8 +78,10 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot types.Slot
 		s, ok := status.FromError(err)
 		if ok && s.Code() == codes.NotFound {
 			log.WithField("slot", slot).WithError(err).Warn("No attestations to aggregate")
+			result.skip("no attestations to aggregate")
 		} else {
 			log.WithField("slot", slot).WithError(err).Error("Could not submit slot signature to beacon node")
+			result.fail(err)
 			if v.emitAccountMetrics {
 				ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
 			}
@@ -84,24 +90,127 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot types.Slot
 		return
 	}
 
-	sig, err := v.aggregateAndProofSig(ctx, pubKey, res.AggregateAndProof, slot)
+	v.signAndSubmitAggregateAndProof(ctx, slot, pubKey, duty, res.AggregateAndProof, result)
+}
+
+// SubmitAggregatesAndProofs submits the signed slot signatures of all the given validators to the
+// beacon node in a single request at the aggregation deadline. Beacon node determines which of the
+// validators are aggregators and responds with an aggregate and proof for each of them, which is then
+// signed and submitted for broadcast.
+func (v *validator) SubmitAggregatesAndProofs(ctx context.Context, slot types.Slot, pubKeys [][fieldparams.BLSPubkeyLength]byte) {
+	ctx, span := trace.StartSpan(ctx, "validator.SubmitAggregatesAndProofs")
+	defer span.End()
+
+	span.AddAttributes(trace.Int64Attribute("numValidators", int64(len(pubKeys))))
+
+	requests := make([]*ethpb.AggregateSelectionRequest, 0, len(pubKeys))
+	pubKeysByIndex := make(map[types.ValidatorIndex][fieldparams.BLSPubkeyLength]byte, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		fmtKey := fmt.Sprintf("%#x", pubKey[:])
+		duty, err := v.duty(pubKey)
+		if err != nil {
+			log.Errorf("Could not fetch validator assignment: %v", err)
+			if v.emitAccountMetrics {
+				ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			continue
+		}
+		slotSig, err := v.signSlotWithSelectionProof(ctx, pubKey, slot)
+		if err != nil {
+			log.Errorf("Could not sign slot: %v", err)
+			if v.emitAccountMetrics {
+				ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			continue
+		}
+		pubKeysByIndex[duty.ValidatorIndex] = pubKey
+		requests = append(requests, &ethpb.AggregateSelectionRequest{
+			Slot:           slot,
+			CommitteeIndex: duty.CommitteeIndex,
+			PublicKey:      pubKey[:],
+			SlotSignature:  slotSig,
+		})
+	}
+	if len(requests) == 0 {
+		return
+	}
+
+	// As specified in spec, an aggregator should wait until two thirds of the way through slot
+	// to broadcast the best aggregate to the global aggregate channel.
+	v.waitToSlotTwoThirds(ctx, slot)
+
+	res, err := v.validatorClient.SubmitAggregateSelectionProofBatch(ctx, &ethpb.AggregateSelectionBatchRequest{
+		Requests: requests,
+	})
+	if err != nil {
+		log.WithField("slot", slot).WithError(err).Error("Could not submit slot signatures to beacon node")
+		return
+	}
+
+	for _, agg := range res.AggregatesAndProofs {
+		if agg == nil || agg.Aggregate == nil || agg.Aggregate.Data == nil {
+			continue
+		}
+		pubKey, ok := pubKeysByIndex[agg.AggregatorIndex]
+		if !ok {
+			log.WithField("validatorIndex", agg.AggregatorIndex).Warn("Beacon node returned an aggregate for an unknown validator")
+			continue
+		}
+		duty, err := v.duty(pubKey)
+		if err != nil {
+			log.Errorf("Could not fetch validator assignment: %v", err)
+			continue
+		}
+
+		// Avoid broadcasting more than one aggregate per committee.
+		k := validatorSubscribeKey(slot, duty.CommitteeIndex)
+		v.aggregatedSlotCommitteeIDCacheLock.Lock()
+		if v.aggregatedSlotCommitteeIDCache.Contains(k) {
+			v.aggregatedSlotCommitteeIDCacheLock.Unlock()
+			continue
+		}
+		v.aggregatedSlotCommitteeIDCache.Add(k, true)
+		v.aggregatedSlotCommitteeIDCacheLock.Unlock()
+
+		result := v.trackDuty(ethpb.DutyResult_AGGREGATION, slot, pubKey)
+		v.signAndSubmitAggregateAndProof(ctx, slot, pubKey, duty, agg, result)
+		v.recordDutyResult(result)
+	}
+}
+
+// signAndSubmitAggregateAndProof signs over the aggregate and proof object built by the beacon node
+// and submits it back for broadcast, updating the tracked result of the aggregation duty.
+func (v *validator) signAndSubmitAggregateAndProof(
+	ctx context.Context,
+	slot types.Slot,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	duty *ethpb.DutiesResponse_Duty,
+	agg *ethpb.AggregateAttestationAndProof,
+	result *dutyTracker,
+) {
+	fmtKey := fmt.Sprintf("%#x", pubKey[:])
+	sig, err := v.aggregateAndProofSig(ctx, pubKey, agg, slot)
 	if err != nil {
 		log.Errorf("Could not sign aggregate and proof: %v", err)
+		result.fail(err)
 		return
 	}
+	result.signed()
 	_, err = v.validatorClient.SubmitSignedAggregateSelectionProof(ctx, &ethpb.SignedAggregateSubmitRequest{
 		SignedAggregateAndProof: &ethpb.SignedAggregateAttestationAndProof{
-			Message:   res.AggregateAndProof,
+			Message:   agg,
 			Signature: sig,
 		},
 	})
 	if err != nil {
 		log.Errorf("Could not submit signed aggregate and proof to beacon node: %v", err)
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
 		}
 		return
 	}
+	result.submitted()
 
 	if err := v.addIndicesToLog(duty); err != nil {
 		log.Errorf("Could not add aggregator indices to logs: %v", err)
@@ -113,7 +222,6 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot types.Slot
 	if v.emitAccountMetrics {
 		ValidatorAggSuccessVec.WithLabelValues(fmtKey).Inc()
 	}
-
 }
 
 // Signs input slot with domain selection proof. This is used to create the signature for aggregator selection.
@@ -129,7 +237,7 @@ func (v *validator) signSlotWithSelectionProof(ctx context.Context, pubKey [fiel
 	if err != nil {
 		return nil, err
 	}
-	sig, err = v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	sig, err = v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -183,7 +291,7 @@ func (v *validator) aggregateAndProofSig(ctx context.Context, pubKey [fieldparam
 	if err != nil {
 		return nil, err
 	}
-	sig, err = v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	sig, err = v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: d.SignatureDomain,
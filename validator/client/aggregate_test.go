@@ -18,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/time"
 	"github.com/prysmaticlabs/prysm/time/slots"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
 )
 
 func TestSubmitAggregateAndProof_GetDutiesRequestFailure(t *testing.T) {
@@ -116,6 +117,85 @@ func TestSubmitAggregateAndProof_Ok(t *testing.T) {
 	validator.SubmitAggregateAndProof(context.Background(), 0, pubKey)
 }
 
+func TestSubmitAggregatesAndProofs_Ok(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+				ValidatorIndex: 5,
+			},
+		},
+	}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	m.validatorClient.EXPECT().SubmitAggregateSelectionProofBatch(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.AggregateSelectionBatchRequest{}),
+	).DoAndReturn(func(_ context.Context, req *ethpb.AggregateSelectionBatchRequest, _ ...grpc.CallOption) (*ethpb.AggregateSelectionBatchResponse, error) {
+		require.Equal(t, 1, len(req.Requests))
+		assert.DeepEqual(t, pubKey[:], req.Requests[0].PublicKey)
+		agg := func(index types.ValidatorIndex) *ethpb.AggregateAttestationAndProof {
+			return &ethpb.AggregateAttestationAndProof{
+				AggregatorIndex: index,
+				Aggregate: util.NewAttestationUtil().HydrateAttestation(&ethpb.Attestation{
+					AggregationBits: make([]byte, 1),
+				}),
+				SelectionProof: make([]byte, 96),
+			}
+		}
+		// The aggregate for an unknown validator is ignored.
+		return &ethpb.AggregateSelectionBatchResponse{AggregatesAndProofs: []*ethpb.AggregateAttestationAndProof{agg(5), agg(9)}}, nil
+	})
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	m.validatorClient.EXPECT().SubmitSignedAggregateSelectionProof(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.SignedAggregateSubmitRequest{}),
+	).Return(&ethpb.SignedAggregateSubmitResponse{AttestationDataRoot: make([]byte, 32)}, nil).Times(1)
+
+	validator.SubmitAggregatesAndProofs(context.Background(), 0, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+}
+
+func TestSubmitAggregatesAndProofs_BatchRequestFailure(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey: validatorKey.PublicKey().Marshal(),
+			},
+		},
+	}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	m.validatorClient.EXPECT().SubmitAggregateSelectionProofBatch(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.AggregateSelectionBatchRequest{}),
+	).Return(nil, errors.New("bad"))
+
+	validator.SubmitAggregatesAndProofs(context.Background(), 0, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	require.LogsContain(t, hook, "Could not submit slot signatures to beacon node")
+}
+
 func TestWaitForSlotTwoThird_WaitCorrectly(t *testing.T) {
 	validator, _, _, finish := setup(t)
 	defer finish()
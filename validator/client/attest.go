@@ -16,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
 	"github.com/prysmaticlabs/prysm/crypto/hash"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
@@ -24,6 +25,7 @@ import (
 	prysmTime "github.com/prysmaticlabs/prysm/time"
 	"github.com/prysmaticlabs/prysm/time/slots"
 	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -57,6 +59,9 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 
 	fmtKey := fmt.Sprintf("%#x", pubKey[:])
 	log := log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:]))).WithField("slot", slot)
+	result := v.trackDuty(ethpb.DutyResult_ATTESTATION, slot, pubKey)
+	defer v.recordDutyResult(result)
+
 	duty, err := v.duty(pubKey)
 	if err != nil {
 		log.WithError(err).Error("Could not fetch validator assignment")
@@ -68,6 +73,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 	}
 	if len(duty.Committee) == 0 {
 		log.Debug("Empty committee for validator duty, not attesting")
+		result.skip("empty committee")
 		return
 	}
 
@@ -78,6 +84,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 	data, err := v.validatorClient.GetAttestationData(ctx, req)
 	if err != nil {
 		log.WithError(err).Error("Could not request attestation to sign at slot")
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -93,6 +100,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 	_, signingRoot, err := v.getDomainAndSigningRoot(ctx, indexedAtt.Data)
 	if err != nil {
 		log.WithError(err).Error("Could not get domain and signing root from attestation")
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -103,12 +111,14 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 	sig, _, err := v.signAtt(ctx, pubKey, data, slot)
 	if err != nil {
 		log.WithError(err).Error("Could not sign attestation")
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
 		tracing.AnnotateError(span, err)
 		return
 	}
+	result.signed()
 
 	var indexInCommittee uint64
 	var found bool
@@ -121,6 +131,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 	}
 	if !found {
 		log.Errorf("Validator ID %d not found in committee of %v", duty.ValidatorIndex, duty.Committee)
+		result.fail(fmt.Errorf("validator ID %d not found in committee", duty.ValidatorIndex))
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -139,6 +150,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 	indexedAtt.Signature = sig
 	if err := v.slashableAttestationCheck(ctx, indexedAtt, pubKey, signingRoot); err != nil {
 		log.WithError(err).Error("Failed attestation slashing protection check")
+		result.fail(err)
 		log.WithFields(
 			attestationLogFields(pubKey, indexedAtt),
 		).Debug("Attempted slashable attestation details")
@@ -148,12 +160,14 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 	attResp, err := v.validatorClient.ProposeAttestation(ctx, attestation)
 	if err != nil {
 		log.WithError(err).Error("Could not submit attestation to beacon node")
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
 		tracing.AnnotateError(span, err)
 		return
 	}
+	result.submitted()
 
 	if err := v.saveAttesterIndexToData(data, duty.ValidatorIndex); err != nil {
 		log.WithError(err).Error("Could not save validator index for logging")
@@ -180,6 +194,245 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 	}
 }
 
+// batchedAttestation tracks an attestation of a single validator through batched signing and submission.
+type batchedAttestation struct {
+	pubKey      [fieldparams.BLSPubkeyLength]byte
+	duty        *ethpb.DutiesResponse_Duty
+	data        *ethpb.AttestationData
+	signingRoot [32]byte
+	bits        bitfield.Bitlist
+}
+
+// SubmitAttestations completes the attester responsibility of several validators at a given slot.
+// Attestation data is requested once per committee, the signatures of all validators are requested
+// from the keymanager in a single batch when supported, and the resulting attestations are submitted
+// to the beacon node over a single stream.
+func (v *validator) SubmitAttestations(ctx context.Context, slot types.Slot, pubKeys [][fieldparams.BLSPubkeyLength]byte) {
+	ctx, span := trace.StartSpan(ctx, "validator.SubmitAttestations")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("numValidators", int64(len(pubKeys))))
+
+	if len(pubKeys) == 0 {
+		return
+	}
+	v.waitOneThirdOrValidBlock(ctx, slot)
+
+	lockKeys := make([]string, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		lockKeys[i] = string(append([]byte{byte(iface.RoleAttester)}, pubKey[:]...))
+	}
+	lock := async.NewMultilock(lockKeys...)
+	lock.Lock()
+	defer lock.Unlock()
+
+	log := log.WithField("slot", slot)
+	results := make(map[[fieldparams.BLSPubkeyLength]byte]*dutyTracker, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		results[pubKey] = v.trackDuty(ethpb.DutyResult_ATTESTATION, slot, pubKey)
+	}
+	defer func() {
+		for _, pubKey := range pubKeys {
+			v.recordDutyResult(results[pubKey])
+		}
+	}()
+	failed := func(pubKey [fieldparams.BLSPubkeyLength]byte, err error) {
+		results[pubKey].fail(err)
+		if v.emitAccountMetrics {
+			ValidatorAttestFailVec.WithLabelValues(fmt.Sprintf("%#x", pubKey[:])).Inc()
+		}
+	}
+
+	dataByCommittee := make(map[types.CommitteeIndex]*ethpb.AttestationData)
+	dataErrByCommittee := make(map[types.CommitteeIndex]error)
+	pending := make([]*batchedAttestation, 0, len(pubKeys))
+	reqs := make([]*validatorpb.SignRequest, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		duty, err := v.duty(pubKey)
+		if err != nil {
+			log.WithError(err).Error("Could not fetch validator assignment")
+			failed(pubKey, err)
+			continue
+		}
+		if len(duty.Committee) == 0 {
+			log.Debug("Empty committee for validator duty, not attesting")
+			results[pubKey].skip("empty committee")
+			continue
+		}
+		data, ok := dataByCommittee[duty.CommitteeIndex]
+		dataErr := dataErrByCommittee[duty.CommitteeIndex]
+		if !ok {
+			data, err = v.validatorClient.GetAttestationData(ctx, &ethpb.AttestationDataRequest{
+				Slot:           slot,
+				CommitteeIndex: duty.CommitteeIndex,
+			})
+			if err != nil {
+				log.WithError(err).WithField("committeeIndex", duty.CommitteeIndex).Error("Could not request attestation to sign at slot")
+				tracing.AnnotateError(span, err)
+			}
+			dataByCommittee[duty.CommitteeIndex] = data
+			dataErrByCommittee[duty.CommitteeIndex] = err
+			dataErr = err
+		}
+		if data == nil {
+			failed(pubKey, dataErr)
+			continue
+		}
+		domain, signingRoot, err := v.getDomainAndSigningRoot(ctx, data)
+		if err != nil {
+			log.WithError(err).Error("Could not get domain and signing root from attestation")
+			failed(pubKey, err)
+			continue
+		}
+
+		indexInCommittee := -1
+		for i, vID := range duty.Committee {
+			if vID == duty.ValidatorIndex {
+				indexInCommittee = i
+				break
+			}
+		}
+		if indexInCommittee < 0 {
+			log.Errorf("Validator ID %d not found in committee of %v", duty.ValidatorIndex, duty.Committee)
+			failed(pubKey, fmt.Errorf("validator ID %d not found in committee", duty.ValidatorIndex))
+			continue
+		}
+		bits := bitfield.NewBitlist(uint64(len(duty.Committee)))
+		bits.SetBitAt(uint64(indexInCommittee), true)
+
+		att := &batchedAttestation{
+			pubKey:      pubKey,
+			duty:        duty,
+			data:        data,
+			signingRoot: signingRoot,
+			bits:        bits,
+		}
+		pending = append(pending, att)
+		reqs = append(reqs, &validatorpb.SignRequest{
+			PublicKey:       att.pubKey[:],
+			SigningRoot:     signingRoot[:],
+			SignatureDomain: domain.SignatureDomain,
+			Object:          &validatorpb.SignRequest_AttestationData{AttestationData: data},
+			SigningSlot:     slot,
+		})
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	sigs, err := v.signBatch(ctx, reqs)
+	if err != nil {
+		log.WithError(err).Error("Could not sign attestations")
+		for _, p := range pending {
+			failed(p.pubKey, err)
+		}
+		tracing.AnnotateError(span, err)
+		return
+	}
+
+	attestations := make([]*ethpb.Attestation, 0, len(pending))
+	submitted := make([]*batchedAttestation, 0, len(pending))
+	for i, p := range pending {
+		sig := sigs[i].Marshal()
+		results[p.pubKey].signed()
+		indexedAtt := &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{uint64(p.duty.ValidatorIndex)},
+			Data:             p.data,
+			Signature:        sig,
+		}
+		if err := v.slashableAttestationCheck(ctx, indexedAtt, p.pubKey, p.signingRoot); err != nil {
+			log.WithError(err).Error("Failed attestation slashing protection check")
+			results[p.pubKey].fail(err)
+			log.WithFields(
+				attestationLogFields(p.pubKey, indexedAtt),
+			).Debug("Attempted slashable attestation details")
+			tracing.AnnotateError(span, err)
+			continue
+		}
+		attestations = append(attestations, &ethpb.Attestation{
+			Data:            p.data,
+			AggregationBits: p.bits,
+			Signature:       sig,
+		})
+		submitted = append(submitted, p)
+	}
+	if len(attestations) == 0 {
+		return
+	}
+
+	if err := v.proposeAttestations(ctx, attestations); err != nil {
+		log.WithError(err).Error("Could not submit attestations to beacon node")
+		for _, p := range submitted {
+			failed(p.pubKey, err)
+		}
+		tracing.AnnotateError(span, err)
+		return
+	}
+
+	for _, p := range submitted {
+		results[p.pubKey].submitted()
+		if err := v.saveAttesterIndexToData(p.data, p.duty.ValidatorIndex); err != nil {
+			log.WithError(err).Error("Could not save validator index for logging")
+			failed(p.pubKey, nil)
+			continue
+		}
+		if v.emitAccountMetrics {
+			fmtKey := fmt.Sprintf("%#x", p.pubKey[:])
+			ValidatorAttestSuccessVec.WithLabelValues(fmtKey).Inc()
+			ValidatorAttestedSlotsGaugeVec.WithLabelValues(fmtKey).Set(float64(slot))
+		}
+	}
+	span.AddAttributes(trace.Int64Attribute("numAttestations", int64(len(attestations))))
+}
+
+// signBatch signs the requests with a single keymanager call if the keymanager supports it,
+// and falls back to signing each request individually otherwise.
+func (v *validator) signBatch(ctx context.Context, reqs []*validatorpb.SignRequest) ([]bls.Signature, error) {
+	if batchSigner, ok := v.keyManager.(keymanager.BatchSigner); ok {
+		sigs, err := batchSigner.SignBatch(ctx, reqs)
+		for _, req := range reqs {
+			v.recordSigningRequest(req, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(sigs) != len(reqs) {
+			return nil, fmt.Errorf("received %d signatures for %d sign requests", len(sigs), len(reqs))
+		}
+		return sigs, nil
+	}
+	sigs := make([]bls.Signature, len(reqs))
+	for i, req := range reqs {
+		sig, err := v.sign(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		sigs[i] = sig
+	}
+	return sigs, nil
+}
+
+// proposeAttestations submits the attestations to the beacon node over a single client stream.
+func (v *validator) proposeAttestations(ctx context.Context, atts []*ethpb.Attestation) error {
+	stream, err := v.validatorClient.ProposeAttestations(ctx)
+	if err != nil {
+		return err
+	}
+	for _, att := range atts {
+		if err := stream.Send(att); err != nil {
+			// The cause of the failure is surfaced when closing the stream.
+			break
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	if len(resp.AttestationDataRoots) != len(atts) {
+		return fmt.Errorf("beacon node accepted %d of %d attestations", len(resp.AttestationDataRoots), len(atts))
+	}
+	return nil
+}
+
 // Given the validator public key, this gets the validator assignment.
 func (v *validator) duty(pubKey [fieldparams.BLSPubkeyLength]byte) (*ethpb.DutiesResponse_Duty, error) {
 	if v.duties == nil {
@@ -201,7 +454,7 @@ func (v *validator) signAtt(ctx context.Context, pubKey [fieldparams.BLSPubkeyLe
 	if err != nil {
 		return nil, [32]byte{}, err
 	}
-	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -23,6 +23,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/mock"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
@@ -555,3 +556,106 @@ func TestServer_WaitToSlotOneThird_ReceiveBlockSlot(t *testing.T) {
 		t.Errorf("Wanted %d time for slot one third but got %d", uint64(time.Now().Unix()), currentTime)
 	}
 }
+
+func TestSubmitAttestations_SharesDataAndStreamsAttestations(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	hook := logTest.NewGlobal()
+	otherKey, err := bls.RandKey()
+	require.NoError(t, err)
+	km, ok := validator.keyManager.(*mockKeymanager)
+	require.Equal(t, true, ok)
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 2)
+	copy(pubKeys[0][:], validatorKey.PublicKey().Marshal())
+	copy(pubKeys[1][:], otherKey.PublicKey().Marshal())
+	km.keysMap[pubKeys[1]] = otherKey
+	committee := []types.ValidatorIndex{0, 3, 4, 2, 7, 6, 8, 9, 10}
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{
+		{
+			PublicKey:      pubKeys[0][:],
+			CommitteeIndex: 5,
+			Committee:      committee,
+			ValidatorIndex: 7,
+		},
+		{
+			PublicKey:      pubKeys[1][:],
+			CommitteeIndex: 5,
+			Committee:      committee,
+			ValidatorIndex: 3,
+		},
+	}}
+
+	beaconBlockRoot := bytesutil.ToBytes32([]byte("A"))
+	targetRoot := bytesutil.ToBytes32([]byte("B"))
+	sourceRoot := bytesutil.ToBytes32([]byte("C"))
+	m.validatorClient.EXPECT().GetAttestationData(
+		gomock.Any(), // ctx
+		&ethpb.AttestationDataRequest{Slot: 30, CommitteeIndex: 5},
+	).Times(1).Return(&ethpb.AttestationData{
+		BeaconBlockRoot: beaconBlockRoot[:],
+		Target:          &ethpb.Checkpoint{Root: targetRoot[:]},
+		Source:          &ethpb.Checkpoint{Root: sourceRoot[:], Epoch: 3},
+	}, nil)
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).AnyTimes().Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	stream := mock.NewMockBeaconNodeValidator_ProposeAttestationsClient(gomock.NewController(t))
+	m.validatorClient.EXPECT().ProposeAttestations(gomock.Any()).Return(stream, nil)
+	var generated []*ethpb.Attestation
+	stream.EXPECT().Send(gomock.AssignableToTypeOf(&ethpb.Attestation{})).Times(2).DoAndReturn(func(att *ethpb.Attestation) error {
+		generated = append(generated, att)
+		return nil
+	})
+	stream.EXPECT().CloseAndRecv().Return(&ethpb.ProposeAttestationsResponse{
+		AttestationDataRoots: [][]byte{make([]byte, 32), make([]byte, 32)},
+	}, nil)
+
+	validator.SubmitAttestations(context.Background(), 30, pubKeys)
+	require.LogsDoNotContain(t, hook, "Could not")
+	require.Equal(t, 2, len(generated))
+
+	root, err := signing.ComputeSigningRoot(generated[0].Data, make([]byte, 32))
+	require.NoError(t, err)
+	for i, key := range []bls.SecretKey{validatorKey, otherKey} {
+		sig, err := bls.SignatureFromBytes(generated[i].Signature)
+		require.NoError(t, err)
+		assert.Equal(t, true, sig.Verify(key.PublicKey(), root[:]), "index %d", i)
+	}
+	assert.Equal(t, true, generated[0].AggregationBits.BitAt(4))
+	assert.Equal(t, true, generated[1].AggregationBits.BitAt(1))
+	assert.Equal(t, uint64(1), generated[1].AggregationBits.Count())
+}
+
+func TestSubmitAttestations_StreamFailure(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	hook := logTest.NewGlobal()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{
+		{
+			PublicKey:      pubKey[:],
+			CommitteeIndex: 5,
+			Committee:      []types.ValidatorIndex{0, 7},
+			ValidatorIndex: 7,
+		},
+	}}
+	m.validatorClient.EXPECT().GetAttestationData(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.AttestationDataRequest{}),
+	).Return(&ethpb.AttestationData{
+		BeaconBlockRoot: make([]byte, 32),
+		Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+	}, nil)
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).AnyTimes().Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+	m.validatorClient.EXPECT().ProposeAttestations(gomock.Any()).Return(nil, errors.New("bad"))
+
+	validator.SubmitAttestations(context.Background(), 30, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	require.LogsContain(t, hook, "Could not submit attestations to beacon node")
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// CrossCheckValidators compares, at the end of every epoch, the statuses and balances of the
+// managed keys at the start of the epoch with the ones reported by the external beacon API.
+// Divergences are logged as warnings, so that a beacon node following the wrong chain or
+// serving stale data is noticed even though duties look healthy.
+func (v *validator) CrossCheckValidators(ctx context.Context, slot types.Slot) {
+	if v.crossChecker == nil || !slots.IsEpochEnd(slot) || slot <= params.BeaconConfig().SlotsPerEpoch {
+		return
+	}
+	epoch := slots.ToEpoch(slot)
+	pks, err := v.keyManager.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not fetch validating keys to cross-check")
+		return
+	}
+	if len(pks) == 0 {
+		return
+	}
+
+	local := &ethpb.ValidatorBalances{}
+	req := &ethpb.ListValidatorBalancesRequest{
+		QueryFilter: &ethpb.ListValidatorBalancesRequest_Epoch{Epoch: epoch},
+		PublicKeys:  bytesutil.FromBytes48Array(pks),
+	}
+	for {
+		resp, err := v.beaconClient.ListValidatorBalances(ctx, req)
+		if err != nil {
+			log.WithError(err).Error("Could not get validator balances from beacon node to cross-check")
+			return
+		}
+		local.Balances = append(local.Balances, resp.Balances...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	external, err := v.crossChecker.Validators(ctx, epoch, pks)
+	if err != nil {
+		log.WithError(err).Warn("Could not get validators from external beacon API to cross-check")
+		return
+	}
+
+	divergences := v.crossChecker.Compare(pks, local, external)
+	for _, d := range divergences {
+		fmtKey := fmt.Sprintf("%#x", d.PublicKey)
+		ValidatorCrossCheckDivergencesVec.WithLabelValues(fmtKey, d.Field).Inc()
+		log.WithFields(logrus.Fields{
+			"epoch":    epoch,
+			"pubKey":   fmt.Sprintf("%#x", bytesutil.Trunc(d.PublicKey[:])),
+			"field":    d.Field,
+			"local":    d.Local,
+			"external": d.External,
+		}).Warn("Beacon node disagrees with external beacon API on validator state")
+	}
+	if len(divergences) == 0 {
+		log.WithFields(logrus.Fields{
+			"epoch":      epoch,
+			"validators": len(pks),
+		}).Debug("Beacon node agrees with external beacon API on validator states")
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	mock2 "github.com/prysmaticlabs/prysm/testing/mock"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/crosscheck"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestCrossCheckValidators_Divergence(t *testing.T) {
+	hook := logTest.NewGlobal()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprintf(w, `{"data":[{"index":"1","balance":"31000000000","status":"active_ongoing","validator":{"pubkey":"%#x"}}]}`, pubKey)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	checker, err := crosscheck.New(&crosscheck.Config{URL: srv.URL})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock2.NewMockBeaconChainClient(ctrl)
+	v := &validator{
+		beaconClient: client,
+		keyManager:   &mockKeymanager{keysMap: map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey{pubKey: nil}},
+		crossChecker: checker,
+	}
+	client.EXPECT().ListValidatorBalances(gomock.Any(), &ethpb.ListValidatorBalancesRequest{
+		QueryFilter: &ethpb.ListValidatorBalancesRequest_Epoch{Epoch: 2},
+		PublicKeys:  [][]byte{pubKey[:]},
+	}).Return(&ethpb.ValidatorBalances{
+		Epoch: 2,
+		Balances: []*ethpb.ValidatorBalances_Balance{
+			{PublicKey: pubKey[:], Index: 1, Balance: 32000000000, Status: "ACTIVE"},
+		},
+	}, nil)
+
+	// Nothing is checked before the end of the epoch.
+	v.CrossCheckValidators(context.Background(), 3*params.BeaconConfig().SlotsPerEpoch-2)
+	v.CrossCheckValidators(context.Background(), 3*params.BeaconConfig().SlotsPerEpoch-1)
+	require.LogsContain(t, hook, "Beacon node disagrees with external beacon API on validator state")
+	require.LogsContain(t, hook, "external=31000000000")
+}
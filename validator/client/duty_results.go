@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
+
+// dutyTracker follows a duty of a validator through signing and submission, so that its outcome
+// can be reported back to the beacon node. A nil tracker ignores all updates.
+type dutyTracker struct {
+	result *ethpb.DutyResult
+}
+
+// trackDuty starts tracking a duty of the validator. It returns nil when duty results are not
+// reported, or when the validator has no known assignment to attribute the duty to.
+func (v *validator) trackDuty(duty ethpb.DutyResult_Duty, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) *dutyTracker {
+	if !v.reportDutyResults {
+		return nil
+	}
+	assignment, err := v.duty(pubKey)
+	if err != nil {
+		return nil
+	}
+	return &dutyTracker{
+		result: &ethpb.DutyResult{
+			PublicKey:      pubKey[:],
+			ValidatorIndex: assignment.ValidatorIndex,
+			Slot:           slot,
+			Duty:           duty,
+			Outcome:        ethpb.DutyResult_SKIPPED,
+		},
+	}
+}
+
+// signed marks the duty as signed.
+func (t *dutyTracker) signed() {
+	if t != nil {
+		t.result.Outcome = ethpb.DutyResult_SIGNED
+	}
+}
+
+// submitted marks the duty as signed and accepted by the beacon node.
+func (t *dutyTracker) submitted() {
+	if t != nil {
+		t.result.Outcome = ethpb.DutyResult_SUBMITTED
+		t.result.Reason = ""
+	}
+}
+
+// fail records the error that stopped the duty before its submission.
+func (t *dutyTracker) fail(err error) {
+	if t != nil && err != nil {
+		t.result.Reason = err.Error()
+	}
+}
+
+// skip records why the duty was left out before its submission.
+func (t *dutyTracker) skip(reason string) {
+	if t != nil {
+		t.result.Reason = reason
+	}
+}
+
+// recordDutyResult queues the outcome of the tracked duty for the next report to the beacon node.
+func (v *validator) recordDutyResult(t *dutyTracker) {
+	if t == nil {
+		return
+	}
+	v.dutyResultsLock.Lock()
+	defer v.dutyResultsLock.Unlock()
+	v.dutyResults = append(v.dutyResults, t.result)
+}
+
+// ReportDutyResults sends the duty outcomes recorded since the previous report to the beacon node.
+func (v *validator) ReportDutyResults(ctx context.Context) {
+	v.dutyResultsLock.Lock()
+	results := v.dutyResults
+	v.dutyResults = nil
+	v.dutyResultsLock.Unlock()
+	if len(results) == 0 {
+		return
+	}
+	if _, err := v.validatorClient.SubmitDutyResults(ctx, &ethpb.DutyResultsRequest{Results: results}); err != nil {
+		log.WithError(err).WithField("numResults", len(results)).Warn("Could not report duty results to beacon node")
+	}
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/empty"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestReportDutyResults_Disabled(t *testing.T) {
+	validator, _, validatorKey, finish := setup(t)
+	defer finish()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{
+		{PublicKey: pubKey[:], ValidatorIndex: 3},
+	}}
+
+	assert.Equal(t, (*dutyTracker)(nil), validator.trackDuty(ethpb.DutyResult_ATTESTATION, 1, pubKey))
+	// No call to the beacon node is expected.
+	validator.ReportDutyResults(context.Background())
+}
+
+func TestReportDutyResults_UnknownValidator(t *testing.T) {
+	validator, _, validatorKey, finish := setup(t)
+	defer finish()
+	validator.reportDutyResults = true
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{}}
+	pubKey := [fieldparams.BLSPubkeyLength]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+
+	assert.Equal(t, (*dutyTracker)(nil), validator.trackDuty(ethpb.DutyResult_ATTESTATION, 1, pubKey))
+}
+
+func TestReportDutyResults_SubmitsRecordedResults(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	validator.reportDutyResults = true
+	pubKey := [fieldparams.BLSPubkeyLength]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{
+		{PublicKey: pubKey[:], ValidatorIndex: 3},
+	}}
+
+	attestation := validator.trackDuty(ethpb.DutyResult_ATTESTATION, 5, pubKey)
+	attestation.signed()
+	attestation.submitted()
+	validator.recordDutyResult(attestation)
+	proposal := validator.trackDuty(ethpb.DutyResult_PROPOSAL, 5, pubKey)
+	proposal.fail(errors.New("could not sign"))
+	validator.recordDutyResult(proposal)
+
+	m.validatorClient.EXPECT().SubmitDutyResults(
+		gomock.Any(), // ctx
+		&ethpb.DutyResultsRequest{Results: []*ethpb.DutyResult{
+			{
+				PublicKey:      pubKey[:],
+				ValidatorIndex: 3,
+				Slot:           5,
+				Duty:           ethpb.DutyResult_ATTESTATION,
+				Outcome:        ethpb.DutyResult_SUBMITTED,
+			},
+			{
+				PublicKey:      pubKey[:],
+				ValidatorIndex: 3,
+				Slot:           5,
+				Duty:           ethpb.DutyResult_PROPOSAL,
+				Outcome:        ethpb.DutyResult_SKIPPED,
+				Reason:         "could not sign",
+			},
+		}},
+	).Return(&empty.Empty{}, nil)
+
+	validator.ReportDutyResults(context.Background())
+	// Reported results are not sent again.
+	validator.ReportDutyResults(context.Background())
+}
+
+func TestReportDutyResults_SubmitFailure(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	validator.reportDutyResults = true
+	pubKey := [fieldparams.BLSPubkeyLength]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{
+		{PublicKey: pubKey[:], ValidatorIndex: 3},
+	}}
+	validator.recordDutyResult(validator.trackDuty(ethpb.DutyResult_ATTESTATION, 5, pubKey))
+
+	m.validatorClient.EXPECT().SubmitDutyResults(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.DutyResultsRequest{}),
+	).Return(nil, errors.New("something went wrong"))
+
+	validator.ReportDutyResults(context.Background())
+	require.LogsContain(t, hook, "Could not report duty results to beacon node")
+}
+
+func TestSubmitAttestation_RecordsDutyResult(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	validator.reportDutyResults = true
+	pubKey := [fieldparams.BLSPubkeyLength]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{
+		{
+			PublicKey:      pubKey[:],
+			CommitteeIndex: 5,
+			Committee:      make([]types.ValidatorIndex, 111),
+			ValidatorIndex: 0,
+		}}}
+	m.validatorClient.EXPECT().GetAttestationData(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.AttestationDataRequest{}),
+	).Return(&ethpb.AttestationData{
+		BeaconBlockRoot: make([]byte, fieldparams.RootLength),
+		Target:          &ethpb.Checkpoint{Root: make([]byte, fieldparams.RootLength)},
+		Source:          &ethpb.Checkpoint{Root: make([]byte, fieldparams.RootLength)},
+	}, nil)
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Times(2).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+	m.validatorClient.EXPECT().ProposeAttestation(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.Attestation{}),
+	).Return(nil, errors.New("something went wrong"))
+
+	validator.SubmitAttestation(context.Background(), 30, pubKey)
+
+	require.Equal(t, 1, len(validator.dutyResults))
+	assert.DeepEqual(t, &ethpb.DutyResult{
+		PublicKey:      pubKey[:],
+		ValidatorIndex: 0,
+		Slot:           30,
+		Duty:           ethpb.DutyResult_ATTESTATION,
+		Outcome:        ethpb.DutyResult_SIGNED,
+		Reason:         "something went wrong",
+	}, validator.dutyResults[0])
+}
@@ -3,8 +3,10 @@ package client
 import (
 	"context"
 
+	"github.com/pkg/errors"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
 	"go.opencensus.io/trace"
 )
 
@@ -33,9 +35,15 @@ func (v *validator) HandleKeyReload(ctx context.Context, newKeys [][fieldparams.
 		}
 	}
 	anyActive = v.checkAndLogValidatorStatus(statuses)
-	if anyActive {
-		logActiveValidatorStatus(statuses)
+	if !anyActive {
+		return false, nil
 	}
+	logActiveValidatorStatus(statuses)
 
-	return anyActive, nil
+	// Fetch the duties of the new keys right away instead of at the next epoch, so that keys
+	// in the current sync committee start contributing to it without missing the rest of the epoch.
+	if err := v.updateDuties(ctx, slots.CurrentSlot(v.genesisTime)); err != nil {
+		return true, errors.Wrap(err, "could not update duties for reloaded keys")
+	}
+	return true, nil
 }
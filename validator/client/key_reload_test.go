@@ -3,17 +3,24 @@ package client
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/mock"
 	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	"github.com/prysmaticlabs/prysm/validator/client/testutil"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func TestValidator_HandleKeyReload(t *testing.T) {
@@ -53,6 +60,15 @@ func TestValidator_HandleKeyReload(t *testing.T) {
 			},
 		).Return(resp, nil)
 
+		client.EXPECT().GetDuties(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(&ethpb.DutiesResponse{}, nil)
+		client.EXPECT().SubscribeCommitteeSubnets(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(&emptypb.Empty{}, nil).AnyTimes()
+
 		anyActive, err := v.HandleKeyReload(context.Background(), [][fieldparams.BLSPubkeyLength]byte{inactivePubKey, activePubKey})
 		require.NoError(t, err)
 		assert.Equal(t, true, anyActive)
@@ -123,3 +139,82 @@ func TestValidator_HandleKeyReload(t *testing.T) {
 		assert.ErrorContains(t, "error", err)
 	})
 }
+
+func TestValidator_HandleKeyReload_MidPeriodSyncCommitteeDuties(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	existingPrivKey, err := bls.RandKey()
+	require.NoError(t, err)
+	existingPubKey := bytesutil.ToBytes48(existingPrivKey.PublicKey().Marshal())
+	importedPrivKey, err := bls.RandKey()
+	require.NoError(t, err)
+	importedPubKey := bytesutil.ToBytes48(importedPrivKey.PublicKey().Marshal())
+	km := &mockKeymanager{
+		keysMap: map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey{
+			existingPubKey: existingPrivKey,
+			importedPubKey: importedPrivKey,
+		},
+	}
+
+	// The keys are imported a few slots into an epoch in the middle of a sync committee period.
+	epoch := params.BeaconConfig().EpochsPerSyncCommitteePeriod/2 + 1
+	startSlot, err := slots.EpochStart(epoch)
+	require.NoError(t, err)
+	currentSlot := startSlot + 3
+	genesisTime := time.Now().Add(-time.Duration(uint64(currentSlot)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+
+	client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	v := validator{
+		validatorClient: client,
+		keyManager:      km,
+		genesisTime:     uint64(genesisTime.Unix()),
+		duties: &ethpb.DutiesResponse{
+			Duties: []*ethpb.DutiesResponse_Duty{
+				{PublicKey: existingPubKey[:], AttesterSlot: startSlot, Status: ethpb.ValidatorStatus_ACTIVE},
+			},
+		},
+	}
+
+	statusResp := testutil.GenerateMultipleValidatorStatusResponse([][]byte{importedPubKey[:]})
+	statusResp.Statuses[0].Status = ethpb.ValidatorStatus_ACTIVE
+	client.EXPECT().MultipleValidatorStatus(
+		gomock.Any(),
+		&ethpb.MultipleValidatorStatusRequest{PublicKeys: [][]byte{importedPubKey[:]}},
+	).Return(statusResp, nil)
+	client.EXPECT().GetDuties(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *ethpb.DutiesRequest, _ ...grpc.CallOption) (*ethpb.DutiesResponse, error) {
+			// The duties of the current epoch are requested for every key, including the imported one.
+			assert.Equal(t, epoch, req.Epoch)
+			assert.Equal(t, 2, len(req.PublicKeys))
+			return &ethpb.DutiesResponse{
+				Duties: []*ethpb.DutiesResponse_Duty{
+					{PublicKey: existingPubKey[:], AttesterSlot: startSlot, Status: ethpb.ValidatorStatus_ACTIVE},
+					{PublicKey: importedPubKey[:], AttesterSlot: startSlot, Status: ethpb.ValidatorStatus_ACTIVE, IsSyncCommittee: true},
+				},
+				CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+					{PublicKey: existingPubKey[:], AttesterSlot: startSlot, Status: ethpb.ValidatorStatus_ACTIVE},
+					{PublicKey: importedPubKey[:], AttesterSlot: startSlot, Status: ethpb.ValidatorStatus_ACTIVE, IsSyncCommittee: true},
+				},
+			}, nil
+		})
+	client.EXPECT().DomainData(gomock.Any(), gomock.Any()).Return(
+		&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil,
+	).AnyTimes()
+	client.EXPECT().SubscribeCommitteeSubnets(gomock.Any(), gomock.Any()).Return(&emptypb.Empty{}, nil).AnyTimes()
+	client.EXPECT().GetSyncSubcommitteeIndex(
+		gomock.Any(),
+		&ethpb.SyncSubcommitteeIndexRequest{PublicKey: importedPubKey[:], Slot: currentSlot},
+	).Return(&ethpb.SyncSubcommitteeIndexResponse{}, nil)
+
+	anyActive, err := v.HandleKeyReload(context.Background(), [][fieldparams.BLSPubkeyLength]byte{importedPubKey})
+	require.NoError(t, err)
+	assert.Equal(t, true, anyActive)
+
+	// The imported key contributes to the sync committee from the current slot on,
+	// without waiting for the duties of the next epoch.
+	roles, err := v.RolesAt(context.Background(), currentSlot)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []iface.ValidatorRole{iface.RoleSyncCommittee}, roles[importedPubKey])
+	assert.DeepEqual(t, []iface.ValidatorRole{iface.RoleUnknown}, roles[existingPubKey])
+}
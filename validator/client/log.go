@@ -94,7 +94,7 @@ func (v *validator) LogNextDutyTimeLeft(slot types.Slot) error {
 					"dutySlot":    nextDutySlot,
 					"attesting":   attestingCounts[nextDutySlot],
 					"proposing":   proposingCounts[nextDutySlot],
-					"slotInEpoch": slot % params.BeaconConfig().SlotsPerEpoch,
+					"slotInEpoch": slots.SinceEpochStarts(slot),
 					"secondsLeft": timeLeft,
 				}).Info("Next duty")
 		}
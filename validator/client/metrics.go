@@ -27,6 +27,18 @@ var (
 			"pubkey",
 		},
 	)
+	// ValidatorTenantGaugeVec maps the public keys of a multi-tenant validator client to their tenant.
+	ValidatorTenantGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "tenant",
+			Help:      "Set to 1 for each public key run on behalf of the tenant, to be joined with the per public key metrics",
+		},
+		[]string{
+			"pubkey",
+			"tenant",
+		},
+	)
 	// ValidatorAggSuccessVec used to count successful aggregations.
 	ValidatorAggSuccessVec = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -201,6 +213,18 @@ var (
 			"pubkey",
 		},
 	)
+	// ValidatorCrossCheckDivergencesVec used to count divergences with the external beacon API.
+	ValidatorCrossCheckDivergencesVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "external_api_divergences_total",
+			Help:      "Number of times the beacon node and the external beacon API disagreed on a validator status or balance",
+		},
+		[]string{
+			"pubkey",
+			"field",
+		},
+	)
 	// ValidatorInactivityScoreGaugeVec used to track validator inactivity scores.
 	ValidatorInactivityScoreGaugeVec = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -223,7 +247,7 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot types.S
 		// Do nothing unless we are at the end of the epoch, and not in the first epoch.
 		return nil
 	}
-	if !v.logValidatorBalances {
+	if !v.logValidatorBalances && v.perfReporter == nil {
 		return nil
 	}
 
@@ -243,6 +267,13 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot types.S
 		return err
 	}
 
+	if v.perfReporter != nil {
+		v.recordEpochPerformance(slots.ToEpoch(slot)-1, resp)
+	}
+	if !v.logValidatorBalances {
+		return nil
+	}
+
 	if v.emitAccountMetrics {
 		for _, missingPubKey := range resp.MissingValidators {
 			fmtKey := fmt.Sprintf("%#x", missingPubKey)
@@ -252,7 +283,7 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot types.S
 
 	prevEpoch := types.Epoch(0)
 	if slot >= params.BeaconConfig().SlotsPerEpoch {
-		prevEpoch = types.Epoch(slot/params.BeaconConfig().SlotsPerEpoch) - 1
+		prevEpoch = slots.PrevEpoch(slots.ToEpoch(slot))
 		if uint64(v.voteStats.startEpoch) == ^uint64(0) { // Handles unknown first epoch.
 			v.voteStats.startEpoch = prevEpoch
 		}
@@ -392,7 +423,7 @@ func (v *validator) logForEachValidator(index int, pubKey []byte, resp *ethpb.Va
 // UpdateLogAggregateStats updates and logs the voteStats struct of a validator using the RPC response obtained from LogValidatorGainsAndLosses.
 func (v *validator) UpdateLogAggregateStats(resp *ethpb.ValidatorPerformanceResponse, slot types.Slot) {
 	summary := &v.voteStats
-	currentEpoch := types.Epoch(slot / params.BeaconConfig().SlotsPerEpoch)
+	currentEpoch := slots.ToEpoch(slot)
 	var attested, correctSource, correctTarget, correctHead, inactivityScore int
 
 	for i := range resp.PublicKeys {
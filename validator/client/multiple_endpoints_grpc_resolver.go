@@ -2,6 +2,7 @@ package client
 
 import (
 	"strings"
+	"sync"
 
 	"google.golang.org/grpc/resolver"
 )
@@ -12,15 +13,22 @@ import (
 // It can be used with any grpc load balancer (pick_first, round_robin). Default is pick_first.
 // Round robin can be used by adding the following option:
 // grpc.WithDefaultServiceConfig("{\"loadBalancingConfig\":[{\"round_robin\":{}}]}")
-type multipleEndpointsGrpcResolverBuilder struct{}
+type multipleEndpointsGrpcResolverBuilder struct {
+	lock     sync.Mutex
+	resolver *multipleEndpointsGrpcResolver
+}
 
 // Build creates and starts multiple endpoints resolver.
-func (*multipleEndpointsGrpcResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+func (b *multipleEndpointsGrpcResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
 	r := &multipleEndpointsGrpcResolver{
-		target: target,
-		cc:     cc,
+		target:    target,
+		cc:        cc,
+		endpoints: strings.Split(target.Endpoint, ","),
 	}
 	r.start()
+	b.lock.Lock()
+	b.resolver = r
+	b.lock.Unlock()
 	return r, nil
 }
 
@@ -29,13 +37,50 @@ func (*multipleEndpointsGrpcResolverBuilder) Scheme() string {
 	return resolver.GetDefaultScheme()
 }
 
+// failover switches the connection built by the builder to the next endpoint. It returns false when
+// no connection was built yet or when a single endpoint is configured.
+func (b *multipleEndpointsGrpcResolverBuilder) failover() bool {
+	b.lock.Lock()
+	r := b.resolver
+	b.lock.Unlock()
+	return r != nil && r.failover()
+}
+
 type multipleEndpointsGrpcResolver struct {
-	target resolver.Target
-	cc     resolver.ClientConn
+	target    resolver.Target
+	cc        resolver.ClientConn
+	lock      sync.Mutex
+	endpoints []string
+	// current is the index of the endpoint listed first, which the pick_first load balancer connects to
+	// when it is reachable.
+	current int
 }
 
 func (r *multipleEndpointsGrpcResolver) start() {
-	endpoints := strings.Split(r.target.Endpoint, ",")
+	r.update(r.endpoints)
+}
+
+// failover publishes the endpoints starting from the one after the current endpoint, leaving the current
+// endpoint out so that the pick_first load balancer drops its connection to it. The endpoint is listed
+// again on the next failover.
+func (r *multipleEndpointsGrpcResolver) failover() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.endpoints) < 2 {
+		return false
+	}
+	excluded := r.current
+	r.current = (r.current + 1) % len(r.endpoints)
+	endpoints := make([]string, 0, len(r.endpoints)-1)
+	for i := r.current; i != excluded; i = (i + 1) % len(r.endpoints) {
+		endpoints = append(endpoints, r.endpoints[i])
+	}
+	log.WithField("endpoint", endpoints[0]).Info("Connecting to the next beacon node endpoint")
+	r.update(endpoints)
+	return true
+}
+
+func (r *multipleEndpointsGrpcResolver) update(endpoints []string) {
 	var addrs []resolver.Address
 	for _, endpoint := range endpoints {
 		addrs = append(addrs, resolver.Address{Addr: endpoint})
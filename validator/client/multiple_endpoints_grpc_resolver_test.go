@@ -0,0 +1,54 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"google.golang.org/grpc/resolver"
+)
+
+type recordingClientConn struct {
+	resolver.ClientConn
+	states []resolver.State
+}
+
+func (cc *recordingClientConn) UpdateState(s resolver.State) error {
+	cc.states = append(cc.states, s)
+	return nil
+}
+
+func (cc *recordingClientConn) lastAddresses() []string {
+	var addrs []string
+	for _, a := range cc.states[len(cc.states)-1].Addresses {
+		addrs = append(addrs, a.Addr)
+	}
+	return addrs
+}
+
+func TestMultipleEndpointsGrpcResolver_Failover(t *testing.T) {
+	b := &multipleEndpointsGrpcResolverBuilder{}
+	assert.Equal(t, false, b.failover(), "no connection was built yet")
+
+	cc := &recordingClientConn{}
+	_, err := b.Build(resolver.Target{Endpoint: "a:4000,b:4000,c:4000"}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"a:4000", "b:4000", "c:4000"}, cc.lastAddresses())
+
+	// The current endpoint is left out so that the connection to it is dropped.
+	require.Equal(t, true, b.failover())
+	assert.DeepEqual(t, []string{"b:4000", "c:4000"}, cc.lastAddresses())
+	require.Equal(t, true, b.failover())
+	assert.DeepEqual(t, []string{"c:4000", "a:4000"}, cc.lastAddresses())
+	require.Equal(t, true, b.failover())
+	assert.DeepEqual(t, []string{"a:4000", "b:4000"}, cc.lastAddresses())
+}
+
+func TestMultipleEndpointsGrpcResolver_FailoverSingleEndpoint(t *testing.T) {
+	b := &multipleEndpointsGrpcResolverBuilder{}
+	cc := &recordingClientConn{}
+	_, err := b.Build(resolver.Target{Endpoint: "a:4000"}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, false, b.failover())
+	assert.Equal(t, 1, len(cc.states))
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// recordEpochPerformance adds the performance of the managed keys in the given epoch to the
+// performance report, and posts the report in the background once its period is over.
+func (v *validator) recordEpochPerformance(epoch types.Epoch, resp *ethpb.ValidatorPerformanceResponse) {
+	v.perfReporter.RecordEpoch(epoch, resp)
+	go func() {
+		if err := v.perfReporter.ReportIfDue(context.Background(), time.Now()); err != nil {
+			log.WithError(err).Error("Could not post validator performance report")
+		}
+	}()
+}
+
+// recordProposal adds the outcome of a block proposal duty to the performance report.
+func (v *validator) recordProposal(slot types.Slot, success bool) {
+	if v.perfReporter != nil {
+		v.perfReporter.RecordProposal(slots.ToEpoch(slot), success)
+	}
+}
+
+// recordSyncCommitteeMessage adds the outcome of a sync committee message duty to the
+// performance report.
+func (v *validator) recordSyncCommitteeMessage(slot types.Slot, success bool) {
+	if v.perfReporter != nil {
+		v.perfReporter.RecordSyncCommitteeMessage(slots.ToEpoch(slot), success)
+	}
+}
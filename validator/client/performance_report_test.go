@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	mock2 "github.com/prysmaticlabs/prysm/testing/mock"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/reporter"
+)
+
+func TestLogValidatorGainsAndLosses_PerformanceReport(t *testing.T) {
+	posted := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Content string `json:"content"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		posted <- payload.Content
+	}))
+	defer srv.Close()
+
+	r, err := reporter.New(&reporter.Config{
+		WebhookURL: srv.URL,
+		Template:   "{{.ToEpoch}}: {{.AttestationsIncluded}}/{{.AttestationsExpected}}, {{.ProposalsSucceeded}}/{{.ProposalsAttempted}}",
+		Interval:   time.Nanosecond,
+	})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock2.NewMockBeaconChainClient(ctrl)
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	v := &validator{
+		beaconClient: client,
+		keyManager:   &mockKeymanager{keysMap: map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey{pubKey: nil}},
+		perfReporter: r,
+	}
+	client.EXPECT().GetValidatorPerformance(gomock.Any(), gomock.Any()).Return(&ethpb.ValidatorPerformanceResponse{
+		PublicKeys:           [][]byte{pubKey[:], {2}},
+		CorrectlyVotedSource: []bool{true, false},
+		CorrectlyVotedTarget: []bool{true, false},
+		CorrectlyVotedHead:   []bool{true, false},
+	}, nil)
+
+	// Performance is reported even when balance logging is disabled.
+	v.recordProposal(params.BeaconConfig().SlotsPerEpoch, true)
+	slot := 3*params.BeaconConfig().SlotsPerEpoch - 1
+	require.NoError(t, v.LogValidatorGainsAndLosses(context.Background(), slot))
+	select {
+	case content := <-posted:
+		assert.Equal(t, "1: 1/2, 1/1", content)
+	case <-time.After(5 * time.Second):
+		t.Fatal("performance report was not posted")
+	}
+}
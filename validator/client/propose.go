@@ -20,8 +20,9 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/runtime/version"
-	prysmTime "github.com/prysmaticlabs/prysm/time"
+	"github.com/prysmaticlabs/prysm/time/slots"
 	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/validator/graffiti"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -50,15 +51,23 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 	lock.Lock()
 	defer lock.Unlock()
 
+	var proposed bool
+	defer func() {
+		v.recordProposal(slot, proposed)
+	}()
+
 	fmtKey := fmt.Sprintf("%#x", pubKey[:])
 	span.AddAttributes(trace.StringAttribute("validator", fmtKey))
 	log := log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])))
+	result := v.trackDuty(ethpb.DutyResult_PROPOSAL, slot, pubKey)
+	defer v.recordDutyResult(result)
 
 	// Sign randao reveal, it's used to request block from beacon node
-	epoch := types.Epoch(slot / params.BeaconConfig().SlotsPerEpoch)
+	epoch := slots.ToEpoch(slot)
 	randaoReveal, err := v.signRandaoReveal(ctx, pubKey, epoch, slot)
 	if err != nil {
 		log.WithError(err).Error("Failed to sign randao reveal")
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -81,6 +90,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 	})
 	if err != nil {
 		log.WithField("blockSlot", slot).WithError(err).Error("Failed to request block from beacon node")
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -91,6 +101,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 	wb, err := wrapper.WrappedBeaconBlock(b.Block)
 	if err != nil {
 		log.WithError(err).Error("Failed to wrap block")
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -100,15 +111,18 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 	sig, signingRoot, err := v.signBlock(ctx, pubKey, epoch, slot, wb)
 	if err != nil {
 		log.WithError(err).Error("Failed to sign block")
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
 		return
 	}
+	result.signed()
 
 	blk, err := wrapper.BuildSignedBeaconBlock(wb, sig)
 	if err != nil {
 		log.WithError(err).Error("Failed to build signed beacon block")
+		result.fail(err)
 		return
 	}
 
@@ -116,6 +130,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 		log.WithFields(
 			blockLogFields(pubKey, wb, nil),
 		).WithError(err).Error("Failed block slashing protection check")
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -126,6 +141,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 	proposal, err := blk.PbGenericBlock()
 	if err != nil {
 		log.WithError(err).Error("Failed to create proposal request")
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -134,11 +150,14 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 	blkResp, err := v.validatorClient.ProposeBeaconBlock(ctx, proposal)
 	if err != nil {
 		log.WithError(err).Error("Failed to propose block")
+		result.fail(err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
 		return
 	}
+	proposed = true
+	result.submitted()
 
 	span.AddAttributes(
 		trace.StringAttribute("blockRoot", fmt.Sprintf("%#x", blkResp.BlockRoot)),
@@ -198,8 +217,7 @@ func ProposeExit(
 	if err != nil {
 		return errors.Wrap(err, "gRPC call to get genesis time failed")
 	}
-	totalSecondsPassed := prysmTime.Now().Unix() - genesisResponse.GenesisTime.Seconds
-	currentEpoch := types.Epoch(uint64(totalSecondsPassed) / uint64(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot)))
+	currentEpoch := slots.ToEpoch(slots.CurrentSlot(uint64(genesisResponse.GenesisTime.Seconds)))
 
 	exit := &ethpb.VoluntaryExit{Epoch: currentEpoch, ValidatorIndex: indexResponse.Index}
 	sig, err := signVoluntaryExit(ctx, validatorClient, signer, pubKey, exit)
@@ -236,7 +254,7 @@ func (v *validator) signRandaoReveal(ctx context.Context, pubKey [fieldparams.BL
 	if err != nil {
 		return nil, err
 	}
-	randaoReveal, err = v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	randaoReveal, err = v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -264,7 +282,7 @@ func (v *validator) signBlock(ctx context.Context, pubKey [fieldparams.BLSPubkey
 	if err != nil {
 		return nil, [32]byte{}, errors.Wrap(err, signingRootErr)
 	}
-	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     blockRoot[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -317,28 +335,43 @@ func signVoluntaryExit(
 
 // Gets the graffiti from cli or file for the validator public key.
 func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error) {
-	// When specified, default graffiti from the command line takes the first priority.
+	// The graffiti file is replaced as a whole when it changes, so the current one is read under
+	// the lock, which is not held while the validator index is requested from the beacon node.
+	v.graffitiLock.Lock()
+	g := v.graffitiStruct
+	v.graffitiLock.Unlock()
+
+	// When specified, individual validator specified graffiti, by public key or else by index,
+	// takes the first priority.
+	if g != nil {
+		if graffiti, ok := g.ForPubkey(pubKey); ok {
+			return []byte(graffiti), nil
+		}
+		idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
+		if err != nil {
+			return []byte{}, err
+		}
+		if graffiti, ok := g.Specific[idx.Index]; ok {
+			return []byte(graffiti), nil
+		}
+	}
+
+	// When specified, default graffiti from the command line takes the second priority.
 	if len(v.graffiti) != 0 {
 		return v.graffiti, nil
 	}
-
-	if v.graffitiStruct == nil {
+	if g == nil {
 		return nil, errors.New("graffitiStruct can't be nil")
 	}
 
-	// When specified, individual validator specified graffiti takes the second priority.
-	idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
-	if err != nil {
-		return []byte{}, err
-	}
-	g, ok := v.graffitiStruct.Specific[idx.Index]
-	if ok {
-		return []byte(g), nil
-	}
+	v.graffitiLock.Lock()
+	defer v.graffitiLock.Unlock()
+	// The ordered index tracks the current graffiti file, which may have been reloaded in the meantime.
+	g = v.graffitiStruct
 
 	// When specified, a graffiti from the ordered list in the file take third priority.
-	if v.graffitiOrderedIndex < uint64(len(v.graffitiStruct.Ordered)) {
-		graffiti := v.graffitiStruct.Ordered[v.graffitiOrderedIndex]
+	if v.graffitiOrderedIndex < uint64(len(g.Ordered)) {
+		graffiti := g.Ordered[v.graffitiOrderedIndex]
 		v.graffitiOrderedIndex = v.graffitiOrderedIndex + 1
 		err := v.db.SaveGraffitiOrderedIndex(ctx, v.graffitiOrderedIndex)
 		if err != nil {
@@ -348,17 +381,31 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 	}
 
 	// When specified, a graffiti from the random list in the file take fourth priority.
-	if len(v.graffitiStruct.Random) != 0 {
+	if len(g.Random) != 0 {
 		r := rand.NewGenerator()
 		r.Seed(time.Now().Unix())
-		i := r.Uint64() % uint64(len(v.graffitiStruct.Random))
-		return []byte(v.graffitiStruct.Random[i]), nil
+		i := r.Uint64() % uint64(len(g.Random))
+		return []byte(g.Random[i]), nil
 	}
 
 	// Finally, default graffiti if specified in the file will be used.
-	if v.graffitiStruct.Default != "" {
-		return []byte(v.graffitiStruct.Default), nil
+	if g.Default != "" {
+		return []byte(g.Default), nil
 	}
 
 	return []byte{}, nil
 }
+
+// setGraffitiStruct replaces the graffiti read from the graffiti file. The ordered list of graffiti
+// starts over when the file content changed.
+func (v *validator) setGraffitiStruct(ctx context.Context, g *graffiti.Graffiti) error {
+	orderedIndex, err := v.db.GraffitiOrderedIndex(ctx, g.Hash)
+	if err != nil {
+		return errors.Wrap(err, "could not read graffiti ordered index")
+	}
+	v.graffitiLock.Lock()
+	defer v.graffitiLock.Unlock()
+	v.graffitiStruct = g
+	v.graffitiOrderedIndex = orderedIndex
+	return nil
+}
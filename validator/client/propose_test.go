@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
-	"strings"
 	"testing"
 	"time"
 
@@ -906,18 +905,32 @@ func TestGetGraffiti_Ok(t *testing.T) {
 	}{
 		{name: "use default cli graffiti",
 			v: &validator{
-				graffiti: []byte{'b'},
+				validatorClient: m.validatorClient,
+				graffiti:        []byte{'b'},
 				graffitiStruct: &graffiti.Graffiti{
 					Default: "c",
 					Random:  []string{"d", "e"},
 					Specific: map[types.ValidatorIndex]string{
 						1: "f",
-						2: "g",
 					},
 				},
 			},
 			want: []byte{'b'},
 		},
+		{name: "use validator file graffiti over default cli graffiti",
+			v: &validator{
+				validatorClient: m.validatorClient,
+				graffiti:        []byte{'b'},
+				graffitiStruct: &graffiti.Graffiti{
+					Default: "c",
+					Specific: map[types.ValidatorIndex]string{
+						1: "f",
+						2: "g",
+					},
+				},
+			},
+			want: []byte{'g'},
+		},
 		{name: "use default file graffiti",
 			v: &validator{
 				validatorClient: m.validatorClient,
@@ -962,11 +975,9 @@ func TestGetGraffiti_Ok(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if !strings.Contains(tt.name, "use default cli graffiti") {
-				m.validatorClient.EXPECT().
-					ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
-					Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
-			}
+			m.validatorClient.EXPECT().
+				ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
+				Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
 			got, err := tt.v.getGraffiti(context.Background(), pubKey)
 			require.NoError(t, err)
 			require.DeepEqual(t, tt.want, got)
@@ -974,6 +985,72 @@ func TestGetGraffiti_Ok(t *testing.T) {
 	}
 }
 
+func TestGetGraffiti_PubkeyTakesPriorityOverIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := &mocks{
+		validatorClient: mock.NewMockBeaconNodeValidatorClient(ctrl),
+	}
+	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+	v := &validator{
+		validatorClient: m.validatorClient,
+		graffitiStruct: &graffiti.Graffiti{
+			Default: "c",
+			Specific: map[types.ValidatorIndex]string{
+				2: "g",
+			},
+			Pubkeys: map[string]string{
+				"0x" + hex.EncodeToString(pubKey[:]): "h",
+			},
+		},
+	}
+	// The validator index is not requested when the public key has a graffiti.
+	got, err := v.getGraffiti(context.Background(), pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'h'}, got)
+
+	// The public key graffiti also takes priority over the default cli graffiti.
+	v.graffiti = []byte{'b'}
+	got, err = v.getGraffiti(context.Background(), pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'h'}, got)
+}
+
+func TestSetGraffitiStruct(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+	valDB := testing2.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	ctrl := gomock.NewController(t)
+	m := &mocks{
+		validatorClient: mock.NewMockBeaconNodeValidatorClient(ctrl),
+	}
+	m.validatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
+		Times(3).
+		Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
+
+	v := &validator{
+		db:              valDB,
+		validatorClient: m.validatorClient,
+		graffitiStruct: &graffiti.Graffiti{
+			Hash:    [32]byte{'a'},
+			Ordered: []string{"a", "b"},
+		},
+	}
+	got, err := v.getGraffiti(context.Background(), pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'a'}, got)
+
+	// The ordered list of the new file starts over.
+	require.NoError(t, v.setGraffitiStruct(context.Background(), &graffiti.Graffiti{
+		Hash:    [32]byte{'b'},
+		Ordered: []string{"c", "d"},
+	}))
+	for _, want := range [][]byte{{'c'}, {'d'}} {
+		got, err := v.getGraffiti(context.Background(), pubKey)
+		require.NoError(t, err)
+		require.DeepEqual(t, want, got)
+	}
+}
+
 func TestGetGraffitiOrdered_Ok(t *testing.T) {
 	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
 	valDB := testing2.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
+	"github.com/prysmaticlabs/prysm/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
@@ -45,7 +47,7 @@ func run(ctx context.Context, v iface.Validator) {
 	connectionErrorChannel := make(chan error, 1)
 	go v.ReceiveBlocks(ctx, connectionErrorChannel)
 	if err := v.UpdateDuties(ctx, headSlot); err != nil {
-		handleAssignmentError(err, headSlot)
+		handleAssignmentError(v, err, headSlot)
 	}
 
 	accountsChangedChan := make(chan [][fieldparams.BLSPubkeyLength]byte, 1)
@@ -108,7 +110,7 @@ func run(ctx context.Context, v iface.Validator) {
 			// Keep trying to update assignments if they are nil or if we are past an
 			// epoch transition in the beacon node's state.
 			if err := v.UpdateDuties(ctx, slot); err != nil {
-				handleAssignmentError(err, slot)
+				handleAssignmentError(v, err, slot)
 				cancel()
 				span.End()
 				continue
@@ -222,9 +224,20 @@ func waitForActivation(ctx context.Context, v iface.Validator) (types.Slot, erro
 }
 
 func performRoles(slotCtx context.Context, allRoles map[[48]byte][]iface.ValidatorRole, v iface.Validator, slot types.Slot, wg *sync.WaitGroup, span *trace.Span) {
+	batchAggregation := features.Get().EnableBeaconComputedAggregation
+	batchAttestations := features.Get().EnableBatchedAttestations
+	var aggregators, attesters [][fieldparams.BLSPubkeyLength]byte
 	for pubKey, roles := range allRoles {
-		wg.Add(len(roles))
 		for _, role := range roles {
+			if batchAggregation && role == iface.RoleAggregator {
+				aggregators = append(aggregators, pubKey)
+				continue
+			}
+			if batchAttestations && role == iface.RoleAttester {
+				attesters = append(attesters, pubKey)
+				continue
+			}
+			wg.Add(1)
 			go func(role iface.ValidatorRole, pubKey [fieldparams.BLSPubkeyLength]byte) {
 				defer wg.Done()
 				switch role {
@@ -246,6 +259,20 @@ func performRoles(slotCtx context.Context, allRoles map[[48]byte][]iface.Validat
 			}(role, pubKey)
 		}
 	}
+	if len(attesters) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v.SubmitAttestations(slotCtx, slot, attesters)
+		}()
+	}
+	if len(aggregators) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v.SubmitAggregatesAndProofs(slotCtx, slot, aggregators)
+		}()
+	}
 
 	// Wait for all processes to complete, then report span complete.
 	go func() {
@@ -261,9 +288,11 @@ func performRoles(slotCtx context.Context, allRoles map[[48]byte][]iface.Validat
 		// Log this client performance in the previous epoch
 		v.LogAttestationsSubmitted()
 		v.LogSyncCommitteeMessagesSubmitted()
+		v.ReportDutyResults(slotCtx)
 		if err := v.LogValidatorGainsAndLosses(slotCtx, slot); err != nil {
 			log.WithError(err).Error("Could not report validator's rewards/penalties")
 		}
+		v.CrossCheckValidators(slotCtx, slot)
 		if err := v.LogNextDutyTimeLeft(slot); err != nil {
 			log.WithError(err).Error("Could not report next count down")
 		}
@@ -274,11 +303,20 @@ func isConnectionError(err error) bool {
 	return err != nil && errors.Is(err, iface.ErrConnectionIssue)
 }
 
-func handleAssignmentError(err error, slot types.Slot) {
+// handleAssignmentError logs the reason assignments could not be updated. The duties are cleared in that case,
+// so that none are performed until the assignments are fetched again. When the beacon node reports a stale head,
+// the connection is switched to the next beacon node endpoint if several are configured.
+func handleAssignmentError(v iface.Validator, err error, slot types.Slot) {
 	if errCode, ok := status.FromError(err); ok && errCode.Code() == codes.NotFound {
 		log.WithField(
 			"epoch", slot/params.BeaconConfig().SlotsPerEpoch,
 		).Warn("Validator not yet assigned to epoch")
+	} else if grpcutil.IsStaleHeadError(err) {
+		if v.FailoverBeaconNode() {
+			log.WithField("error", err).Warn("Beacon node head is stale, switching to the next beacon node endpoint")
+			return
+		}
+		log.WithField("error", err).Error("Beacon node head is stale, suspending validator duties until it catches up")
 	} else {
 		log.WithField("error", err).Error("Failed to update assignments")
 	}
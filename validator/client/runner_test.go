@@ -6,7 +6,9 @@ import (
 	"testing"
 	"time"
 
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
 	"github.com/prysmaticlabs/prysm/async/event"
+	"github.com/prysmaticlabs/prysm/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
@@ -16,6 +18,8 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/client/testutil"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/remote/mock"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func cancelledContext() context.Context {
@@ -102,6 +106,50 @@ func TestUpdateDuties_HandlesError(t *testing.T) {
 	require.LogsContain(t, hook, "Failed to update assignments")
 }
 
+func TestUpdateDuties_HandlesStaleHeadError(t *testing.T) {
+	hook := logTest.NewGlobal()
+	v := &testutil.FakeValidator{Km: &mockKeymanager{accountsChangedFeed: &event.Feed{}}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	slot := types.Slot(55)
+	ticker := make(chan types.Slot)
+	v.NextSlotRet = ticker
+	go func() {
+		ticker <- slot
+
+		cancel()
+	}()
+	v.UpdateDutiesRet = status.Errorf(codes.Unavailable, "%s: head slot 10 is 45 slots behind the current slot 55", grpcutil.StaleHeadErrorMessage)
+
+	run(ctx, v)
+
+	require.LogsContain(t, hook, "Beacon node head is stale, suspending validator duties")
+	require.LogsDoNotContain(t, hook, "Failed to update assignments")
+	assert.Equal(t, false, v.RoleAtCalled)
+}
+
+func TestUpdateDuties_FailsOverOnStaleHeadError(t *testing.T) {
+	hook := logTest.NewGlobal()
+	v := &testutil.FakeValidator{Km: &mockKeymanager{accountsChangedFeed: &event.Feed{}}, FailoverBeaconNodeRet: true}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	slot := types.Slot(55)
+	ticker := make(chan types.Slot)
+	v.NextSlotRet = ticker
+	go func() {
+		ticker <- slot
+
+		cancel()
+	}()
+	v.UpdateDutiesRet = status.Errorf(codes.Unavailable, "%s: head slot 10 is 45 slots behind the current slot 55", grpcutil.StaleHeadErrorMessage)
+
+	run(ctx, v)
+
+	require.LogsContain(t, hook, "switching to the next beacon node endpoint")
+	require.LogsDoNotContain(t, hook, "suspending validator duties")
+	assert.Equal(t, true, v.FailoverBeaconNodeCalled > 0)
+}
+
 func TestRoleAt_NextSlot(t *testing.T) {
 	v := &testutil.FakeValidator{Km: &mockKeymanager{accountsChangedFeed: &event.Feed{}}}
 	ctx, cancel := context.WithCancel(context.Background())
@@ -183,6 +231,52 @@ func TestBothProposesAndAttests_NextSlot(t *testing.T) {
 	assert.Equal(t, uint64(slot), v.ProposeBlockArg1, "ProposeBlock was called with wrong arg")
 }
 
+func TestBatchedAggregation_NextSlot(t *testing.T) {
+	reset := features.InitWithReset(&features.Flags{EnableBeaconComputedAggregation: true})
+	defer reset()
+	v := &testutil.FakeValidator{Km: &mockKeymanager{accountsChangedFeed: &event.Feed{}}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	slot := types.Slot(55)
+	ticker := make(chan types.Slot)
+	v.NextSlotRet = ticker
+	v.RolesAtRet = []iface.ValidatorRole{iface.RoleAttester, iface.RoleAggregator}
+	go func() {
+		ticker <- slot
+
+		cancel()
+	}()
+	timer := time.NewTimer(200 * time.Millisecond)
+	run(ctx, v)
+	<-timer.C
+	require.Equal(t, true, v.AttestToBlockHeadCalled, "SubmitAttestation(%d) was not called", slot)
+	require.Equal(t, true, v.SubmitAggregatesAndProofsCalled, "SubmitAggregatesAndProofs(%d) was not called", slot)
+	assert.Equal(t, uint64(slot), v.SubmitAggregatesAndProofsArg1, "SubmitAggregatesAndProofs was called with wrong arg")
+}
+
+func TestBatchedAttestations_NextSlot(t *testing.T) {
+	reset := features.InitWithReset(&features.Flags{EnableBatchedAttestations: true})
+	defer reset()
+	v := &testutil.FakeValidator{Km: &mockKeymanager{accountsChangedFeed: &event.Feed{}}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	slot := types.Slot(55)
+	ticker := make(chan types.Slot)
+	v.NextSlotRet = ticker
+	v.RolesAtRet = []iface.ValidatorRole{iface.RoleAttester}
+	go func() {
+		ticker <- slot
+
+		cancel()
+	}()
+	timer := time.NewTimer(200 * time.Millisecond)
+	run(ctx, v)
+	<-timer.C
+	require.Equal(t, false, v.AttestToBlockHeadCalled, "SubmitAttestation(%d) was called", slot)
+	require.Equal(t, true, v.SubmitAttestationsCalled, "SubmitAttestations(%d) was not called", slot)
+	assert.Equal(t, uint64(slot), v.SubmitAttestationsArg1, "SubmitAttestations was called with wrong arg")
+}
+
 func TestAllValidatorsAreExited_NextSlot(t *testing.T) {
 	v := &testutil.FakeValidator{Km: &mockKeymanager{accountsChangedFeed: &event.Feed{}}}
 	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), testutil.AllValidatorsAreExitedCtxKey, true))
@@ -14,6 +14,7 @@ import (
 	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
 	"github.com/prysmaticlabs/prysm/async/event"
 	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
+	"github.com/prysmaticlabs/prysm/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
@@ -21,12 +22,15 @@ import (
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/validator/audit"
 	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/validator/crosscheck"
 	"github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/graffiti"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/local"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/validator/reporter"
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -54,6 +58,7 @@ type ValidatorService struct {
 	logDutyCountDown      bool
 	interopKeysConfig     *local.InteropKeymanagerConfig
 	conn                  *grpc.ClientConn
+	endpointResolver      *multipleEndpointsGrpcResolverBuilder
 	grpcRetryDelay        time.Duration
 	grpcRetries           uint
 	maxCallRecvMsgSize    int
@@ -61,6 +66,7 @@ type ValidatorService struct {
 	walletInitializedFeed *event.Feed
 	wallet                *wallet.Wallet
 	graffitiStruct        *graffiti.Graffiti
+	graffitiFile          string
 	dataDir               string
 	withCert              string
 	endpoint              string
@@ -71,6 +77,12 @@ type ValidatorService struct {
 	graffiti              []byte
 	Web3SignerConfig      *remoteweb3signer.SetupConfig
 	ProposerSettings      *validatorserviceconfig.ProposerSettings
+	auditLog              *audit.Log
+	perfReporter          *reporter.Reporter
+	crossChecker          *crosscheck.Checker
+	tenant                string
+	doppelGangerEpochs    types.Epoch
+	reportDutyResults     bool
 }
 
 // Config for the validator service.
@@ -86,6 +98,7 @@ type Config struct {
 	GrpcMaxCallRecvMsgSizeFlag int
 	GrpcRetryDelay             time.Duration
 	GraffitiStruct             *graffiti.Graffiti
+	GraffitiFile               string
 	Validator                  iface.Validator
 	ValDB                      db.Database
 	CertFlag                   string
@@ -95,6 +108,12 @@ type Config struct {
 	Endpoint                   string
 	Web3SignerConfig           *remoteweb3signer.SetupConfig
 	ProposerSettings           *validatorserviceconfig.ProposerSettings
+	AuditLog                   *audit.Log
+	PerformanceReporter        *reporter.Reporter
+	CrossChecker               *crosscheck.Checker
+	Tenant                     string
+	DoppelGangerEpochs         types.Epoch
+	ReportDutyResults          bool
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -121,16 +140,25 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		useWeb:                cfg.UseWeb,
 		interopKeysConfig:     cfg.InteropKeysConfig,
 		graffitiStruct:        cfg.GraffitiStruct,
+		graffitiFile:          cfg.GraffitiFile,
 		logDutyCountDown:      cfg.LogDutyCountDown,
 		Web3SignerConfig:      cfg.Web3SignerConfig,
 		ProposerSettings:      cfg.ProposerSettings,
+		auditLog:              cfg.AuditLog,
+		perfReporter:          cfg.PerformanceReporter,
+		crossChecker:          cfg.CrossChecker,
+		tenant:                cfg.Tenant,
+		doppelGangerEpochs:    cfg.DoppelGangerEpochs,
+		reportDutyResults:     cfg.ReportDutyResults,
 	}
 
-	dialOpts := ConstructDialOptions(
+	s.endpointResolver = &multipleEndpointsGrpcResolverBuilder{}
+	dialOpts := constructDialOptions(
 		s.maxCallRecvMsgSize,
 		s.withCert,
 		s.grpcRetries,
 		s.grpcRetryDelay,
+		s.endpointResolver,
 	)
 	if dialOpts == nil {
 		return s, nil
@@ -209,6 +237,13 @@ func (v *ValidatorService) Start() {
 		Web3SignerConfig:               v.Web3SignerConfig,
 		ProposerSettings:               v.ProposerSettings,
 		walletIntializedChannel:        make(chan *wallet.Wallet, 1),
+		auditLog:                       v.auditLog,
+		perfReporter:                   v.perfReporter,
+		crossChecker:                   v.crossChecker,
+		tenant:                         v.tenant,
+		doppelGangerEpochs:             v.doppelGangerEpochs,
+		reportDutyResults:              v.reportDutyResults,
+		endpointResolver:               v.endpointResolver,
 	}
 	// To resolve a race condition at startup due to the interface
 	// nature of the abstracted block type. We initialize
@@ -221,6 +256,17 @@ func (v *ValidatorService) Start() {
 	close(tempChan)
 
 	v.validator = valStruct
+	if features.Get().EnableSlashingProtectionPruning {
+		// Keep pruning the history older than the weak subjectivity period while the validator runs.
+		v.db.StartSlashingProtectionPruning(v.ctx)
+	}
+	if v.graffitiFile != "" {
+		go graffiti.WatchGraffitiFile(v.ctx, v.graffitiFile, v.graffitiStruct, func(g *graffiti.Graffiti) {
+			if err := valStruct.setGraffitiStruct(v.ctx, g); err != nil {
+				log.WithError(err).Error("Could not apply reloaded graffiti file")
+			}
+		})
+	}
 	go run(v.ctx, v.validator)
 }
 
@@ -228,6 +274,11 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
+	if v.auditLog != nil {
+		if err := v.auditLog.Close(); err != nil {
+			log.WithError(err).Error("Could not close signing audit log")
+		}
+	}
 	if v.conn != nil {
 		return v.conn.Close()
 	}
@@ -258,6 +309,19 @@ func ConstructDialOptions(
 	grpcRetries uint,
 	grpcRetryDelay time.Duration,
 	extraOpts ...grpc.DialOption,
+) []grpc.DialOption {
+	return constructDialOptions(maxCallRecvMsgSize, withCert, grpcRetries, grpcRetryDelay, &multipleEndpointsGrpcResolverBuilder{}, extraOpts...)
+}
+
+// constructDialOptions constructs a list of grpc dial options resolving the endpoints with the given resolver builder,
+// through which the connection can be switched to another endpoint.
+func constructDialOptions(
+	maxCallRecvMsgSize int,
+	withCert string,
+	grpcRetries uint,
+	grpcRetryDelay time.Duration,
+	endpointResolver *multipleEndpointsGrpcResolverBuilder,
+	extraOpts ...grpc.DialOption,
 ) []grpc.DialOption {
 	var transportSecurity grpc.DialOption
 	if withCert != "" {
@@ -298,7 +362,7 @@ func ConstructDialOptions(
 			grpcprometheus.StreamClientInterceptor,
 			grpcretry.StreamClientInterceptor(),
 		),
-		grpc.WithResolvers(&multipleEndpointsGrpcResolverBuilder{}),
+		grpc.WithResolvers(endpointResolver),
 	}
 
 	dialOpts = append(dialOpts, extraOpts...)
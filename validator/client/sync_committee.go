@@ -27,11 +27,19 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 	defer span.End()
 	span.AddAttributes(trace.StringAttribute("validator", fmt.Sprintf("%#x", pubKey)))
 
+	var submitted bool
+	defer func() {
+		v.recordSyncCommitteeMessage(slot, submitted)
+	}()
+	result := v.trackDuty(ethpb.DutyResult_SYNC_COMMITTEE_MESSAGE, slot, pubKey)
+	defer v.recordDutyResult(result)
+
 	v.waitOneThirdOrValidBlock(ctx, slot)
 
 	res, err := v.validatorClient.GetSyncMessageBlockRoot(ctx, &emptypb.Empty{})
 	if err != nil {
 		log.WithError(err).Error("Could not request sync message block root to sign")
+		result.fail(err)
 		tracing.AnnotateError(span, err)
 		return
 	}
@@ -45,16 +53,18 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 	d, err := v.domainData(ctx, slots.ToEpoch(slot), params.BeaconConfig().DomainSyncCommittee[:])
 	if err != nil {
 		log.WithError(err).Error("Could not get sync committee domain data")
+		result.fail(err)
 		return
 	}
 	sszRoot := types.SSZBytes(res.Root)
 	r, err := signing.ComputeSigningRoot(&sszRoot, d.SignatureDomain)
 	if err != nil {
 		log.WithError(err).Error("Could not get sync committee message signing root")
+		result.fail(err)
 		return
 	}
 
-	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     r[:],
 		SignatureDomain: d.SignatureDomain,
@@ -65,8 +75,10 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 	})
 	if err != nil {
 		log.WithError(err).Error("Could not sign sync committee message")
+		result.fail(err)
 		return
 	}
+	result.signed()
 
 	msg := &ethpb.SyncCommitteeMessage{
 		Slot:           slot,
@@ -76,8 +88,11 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 	}
 	if _, err := v.validatorClient.SubmitSyncMessage(ctx, msg); err != nil {
 		log.WithError(err).Error("Could not submit sync committee message")
+		result.fail(err)
 		return
 	}
+	submitted = true
+	result.submitted()
 
 	msgSlot := msg.Slot
 	slotTime := time.Unix(int64(v.genesisTime+uint64(msgSlot)*params.BeaconConfig().SecondsPerSlot), 0)
@@ -133,6 +148,7 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 		if !isAggregator {
 			continue
 		}
+		result := v.trackDuty(ethpb.DutyResult_SYNC_COMMITTEE_CONTRIBUTION, slot, pubKey)
 		subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
 		subnet := uint64(comIdx) / subCommitteeSize
 		contribution, err := v.validatorClient.GetSyncCommitteeContribution(ctx, &ethpb.SyncCommitteeContributionRequest{
@@ -142,6 +158,8 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 		})
 		if err != nil {
 			log.Errorf("Could not get sync committee contribution: %v", err)
+			result.fail(err)
+			v.recordDutyResult(result)
 			return
 		}
 		if contribution.AggregationBits.Count() == 0 {
@@ -150,6 +168,8 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 				"pubkey": pubKey,
 				"subnet": subnet,
 			}).Warn("Sync contribution for validator has no bits set.")
+			result.skip("empty contribution")
+			v.recordDutyResult(result)
 			continue
 		}
 
@@ -161,16 +181,23 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 		sig, err := v.signContributionAndProof(ctx, pubKey, contributionAndProof, slot)
 		if err != nil {
 			log.Errorf("Could not sign contribution and proof: %v", err)
+			result.fail(err)
+			v.recordDutyResult(result)
 			return
 		}
+		result.signed()
 
 		if _, err := v.validatorClient.SubmitSignedContributionAndProof(ctx, &ethpb.SignedContributionAndProof{
 			Message:   contributionAndProof,
 			Signature: sig,
 		}); err != nil {
 			log.Errorf("Could not submit signed contribution and proof: %v", err)
+			result.fail(err)
+			v.recordDutyResult(result)
 			return
 		}
+		result.submitted()
+		v.recordDutyResult(result)
 
 		contributionSlot := contributionAndProof.Contribution.Slot
 		slotTime := time.Unix(int64(v.genesisTime+uint64(contributionSlot)*params.BeaconConfig().SecondsPerSlot), 0)
@@ -218,7 +245,7 @@ func (v *validator) signSyncSelectionData(ctx context.Context, pubKey [fieldpara
 	if err != nil {
 		return nil, err
 	}
-	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -241,7 +268,7 @@ func (v *validator) signContributionAndProof(ctx context.Context, pubKey [fieldp
 	if err != nil {
 		return nil, err
 	}
-	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: d.SignatureDomain,
@@ -27,11 +27,14 @@ type FakeValidator struct {
 	RoleAtCalled                      bool
 	AttestToBlockHeadCalled           bool
 	ProposeBlockCalled                bool
+	SubmitAggregatesAndProofsCalled   bool
+	SubmitAttestationsCalled          bool
 	LogValidatorGainsAndLossesCalled  bool
 	SaveProtectionsCalled             bool
 	DeleteProtectionCalled            bool
 	SlotDeadlineCalled                bool
 	HandleKeyReloadCalled             bool
+	FailoverBeaconNodeCalled          int
 	WaitForChainStartCalled           int
 	WaitForSyncCalled                 int
 	WaitForActivationCalled           int
@@ -40,11 +43,14 @@ type FakeValidator struct {
 	RetryTillSuccess                  int
 	ProposeBlockArg1                  uint64
 	AttestToBlockHeadArg1             uint64
+	SubmitAggregatesAndProofsArg1     uint64
+	SubmitAttestationsArg1            uint64
 	RoleAtArg1                        uint64
 	UpdateDutiesArg1                  uint64
 	NextSlotRet                       <-chan types.Slot
 	PublicKey                         string
 	UpdateDutiesRet                   error
+	FailoverBeaconNodeRet             bool
 	RolesAtRet                        []iface.ValidatorRole
 	Balances                          map[[fieldparams.BLSPubkeyLength]byte]uint64
 	IndexToPubkeyMap                  map[uint64][fieldparams.BLSPubkeyLength]byte
@@ -72,6 +78,12 @@ func (fv *FakeValidator) WaitForKeymanagerInitialization(_ context.Context) erro
 // LogSyncCommitteeMessagesSubmitted --
 func (fv *FakeValidator) LogSyncCommitteeMessagesSubmitted() {}
 
+// ReportDutyResults --
+func (fv *FakeValidator) ReportDutyResults(_ context.Context) {}
+
+// CrossCheckValidators --
+func (fv *FakeValidator) CrossCheckValidators(_ context.Context, _ types.Slot) {}
+
 // WaitForChainStart for mocking.
 func (fv *FakeValidator) WaitForChainStart(_ context.Context) error {
 	fv.WaitForChainStartCalled++
@@ -133,6 +145,12 @@ func (fv *FakeValidator) UpdateDuties(_ context.Context, slot types.Slot) error
 	return fv.UpdateDutiesRet
 }
 
+// FailoverBeaconNode for mocking.
+func (fv *FakeValidator) FailoverBeaconNode() bool {
+	fv.FailoverBeaconNodeCalled++
+	return fv.FailoverBeaconNodeRet
+}
+
 // UpdateProtections for mocking.
 func (fv *FakeValidator) UpdateProtections(_ context.Context, _ uint64) error {
 	fv.UpdateProtectionsCalled = true
@@ -175,6 +193,18 @@ func (fv *FakeValidator) ProposeBlock(_ context.Context, slot types.Slot, _ [fie
 func (_ *FakeValidator) SubmitAggregateAndProof(_ context.Context, _ types.Slot, _ [fieldparams.BLSPubkeyLength]byte) {
 }
 
+// SubmitAttestations for mocking.
+func (fv *FakeValidator) SubmitAttestations(_ context.Context, slot types.Slot, _ [][fieldparams.BLSPubkeyLength]byte) {
+	fv.SubmitAttestationsCalled = true
+	fv.SubmitAttestationsArg1 = uint64(slot)
+}
+
+// SubmitAggregatesAndProofs for mocking.
+func (fv *FakeValidator) SubmitAggregatesAndProofs(_ context.Context, slot types.Slot, _ [][fieldparams.BLSPubkeyLength]byte) {
+	fv.SubmitAggregatesAndProofsCalled = true
+	fv.SubmitAggregatesAndProofsArg1 = uint64(slot)
+}
+
 // SubmitSyncCommitteeMessage for mocking.
 func (_ *FakeValidator) SubmitSyncCommitteeMessage(_ context.Context, _ types.Slot, _ [fieldparams.BLSPubkeyLength]byte) {
 }
@@ -29,19 +29,24 @@ import (
 	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
 	"github.com/prysmaticlabs/prysm/crypto/hash"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/time/slots"
 	accountsiface "github.com/prysmaticlabs/prysm/validator/accounts/iface"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/validator/audit"
 	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/validator/crosscheck"
 	vdb "github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/db/kv"
 	"github.com/prysmaticlabs/prysm/validator/graffiti"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/local"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/validator/reporter"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 	"google.golang.org/protobuf/proto"
@@ -70,6 +75,7 @@ type validator struct {
 	highestValidSlotLock               sync.Mutex
 	prevBalanceLock                    sync.RWMutex
 	slashableKeysLock                  sync.RWMutex
+	graffitiLock                       sync.Mutex
 	eipImportBlacklistedPublicKeys     map[[fieldparams.BLSPubkeyLength]byte]bool
 	walletInitializedFeed              *event.Feed
 	attLogs                            map[[32]byte]*attSubmitted
@@ -99,6 +105,15 @@ type validator struct {
 	Web3SignerConfig                   *remoteweb3signer.SetupConfig
 	ProposerSettings                   *validatorserviceconfig.ProposerSettings
 	walletIntializedChannel            chan *wallet.Wallet
+	auditLog                           *audit.Log
+	perfReporter                       *reporter.Reporter
+	crossChecker                       *crosscheck.Checker
+	tenant                             string
+	doppelGangerEpochs                 types.Epoch
+	reportDutyResults                  bool
+	dutyResultsLock                    sync.Mutex
+	dutyResults                        []*ethpb.DutyResult
+	endpointResolver                   *multipleEndpointsGrpcResolverBuilder
 }
 
 type validatorStatus struct {
@@ -107,6 +122,30 @@ type validatorStatus struct {
 	index     types.ValidatorIndex
 }
 
+// sign signs the request with the validator's keymanager, recording the outcome in the signing audit log.
+func (v *validator) sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	return v.auditSign(v.keyManager.Sign)(ctx, req)
+}
+
+// auditSign wraps a signing function so that every request it handles is recorded in the signing audit log.
+func (v *validator) auditSign(sign signingFunc) signingFunc {
+	return func(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+		sig, err := sign(ctx, req)
+		v.recordSigningRequest(req, err)
+		return sig, err
+	}
+}
+
+// recordSigningRequest appends the signing request and its outcome to the audit log, if one is configured.
+func (v *validator) recordSigningRequest(req *validatorpb.SignRequest, signErr error) {
+	if v.auditLog == nil {
+		return
+	}
+	if err := v.auditLog.Append(audit.NewEntry(req, signErr)); err != nil {
+		log.WithError(err).Error("Could not record signing request in audit log")
+	}
+}
+
 // Done cleans up the validator.
 func (v *validator) Done() {
 	v.ticker.Done()
@@ -129,7 +168,12 @@ func (v *validator) WaitForKeymanagerInitialization(ctx context.Context) error {
 		v.keyManager = km
 	} else {
 		if v.interopKeysConfig != nil {
-			keyManager, err := local.NewInteropKeymanager(ctx, v.interopKeysConfig.Offset, v.interopKeysConfig.NumValidatorKeys)
+			var keyManager *local.Keymanager
+			if len(v.interopKeysConfig.KeyRanges) > 0 {
+				keyManager, err = local.NewInteropKeymanagerFromRanges(ctx, v.interopKeysConfig.KeyRanges)
+			} else {
+				keyManager, err = local.NewInteropKeymanager(ctx, v.interopKeysConfig.Offset, v.interopKeysConfig.NumValidatorKeys)
+			}
 			if err != nil {
 				return errors.Wrap(err, "could not generate interop keys for key manager")
 			}
@@ -379,10 +423,16 @@ func (v *validator) checkAndLogValidatorStatus(statuses []*validatorStatus) bool
 		if status.index != nonexistentIndex {
 			fields["index"] = status.index
 		}
+		if v.tenant != "" {
+			fields["tenant"] = v.tenant
+		}
 		log := log.WithFields(fields)
 		if v.emitAccountMetrics {
 			fmtKey := fmt.Sprintf("%#x", status.publicKey)
 			ValidatorStatusesGaugeVec.WithLabelValues(fmtKey).Set(float64(status.status.Status))
+			if v.tenant != "" {
+				ValidatorTenantGaugeVec.WithLabelValues(fmtKey, v.tenant).Set(1)
+			}
 		}
 		switch status.status.Status {
 		case ethpb.ValidatorStatus_UNKNOWN_STATUS:
@@ -454,9 +504,11 @@ func (v *validator) SlotDeadline(slot types.Slot) time.Time {
 }
 
 // CheckDoppelGanger checks if the current actively provided keys have
-// any duplicates active in the network.
+// any duplicates active in the network. Unless the chain is in its genesis epoch,
+// the validator then listens for attestations and blocks of its keys for the configured
+// number of epochs, checking again at the start of every epoch, before it is allowed to sign.
 func (v *validator) CheckDoppelGanger(ctx context.Context) error {
-	if !features.Get().EnableDoppelGanger {
+	if features.Get().DisableDoppelGanger {
 		return nil
 	}
 	pubkeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
@@ -497,6 +549,36 @@ func (v *validator) CheckDoppelGanger(ctx context.Context) error {
 				SignedRoot: r.SigningRoot[:],
 			})
 	}
+	if err := v.requestDoppelGangerCheck(ctx, req); err != nil {
+		return err
+	}
+
+	// Nobody can have signed with the keys before genesis, so there is nothing to listen for.
+	currentEpoch := slots.ToEpoch(slots.CurrentSlot(v.genesisTime))
+	if currentEpoch == params.BeaconConfig().GenesisEpoch || v.doppelGangerEpochs == 0 {
+		return nil
+	}
+	log.WithField("epochs", v.doppelGangerEpochs).Info("Listening for attestations and blocks of the validating keys before signing")
+	for epoch := currentEpoch + 1; epoch <= currentEpoch+v.doppelGangerEpochs; epoch++ {
+		start, err := slots.EpochStart(epoch)
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(slots.StartTime(v.genesisTime, start))):
+		}
+		if err := v.requestDoppelGangerCheck(ctx, req); err != nil {
+			return err
+		}
+		log.WithField("epoch", epoch).Info("No doppelganger found")
+	}
+	return nil
+}
+
+// requestDoppelGangerCheck asks the beacon node whether the requested keys are active in the network.
+func (v *validator) requestDoppelGangerCheck(ctx context.Context, req *ethpb.DoppelGangerRequest) error {
 	resp, err := v.validatorClient.CheckDoppelGanger(ctx, req)
 	if err != nil {
 		return err
@@ -555,6 +637,19 @@ func (v *validator) UpdateDuties(ctx context.Context, slot types.Slot) error {
 		// Do nothing if not epoch start AND assignments already exist.
 		return nil
 	}
+	return v.updateDuties(ctx, slot)
+}
+
+// FailoverBeaconNode switches the connection to the next beacon node endpoint when several are configured.
+// It returns false when there is no other endpoint to switch to.
+func (v *validator) FailoverBeaconNode() bool {
+	return v.endpointResolver != nil && v.endpointResolver.failover()
+}
+
+// updateDuties fetches the assignments of the validating keys for the epoch of the slot, which
+// also has the beacon node subscribe to the sync committee subnets of the keys, and subscribes
+// to the attestation subnets of the assignments.
+func (v *validator) updateDuties(ctx context.Context, slot types.Slot) error {
 	// Set deadline to end of epoch.
 	ss, err := slots.EpochStart(slots.ToEpoch(slot) + 1)
 	if err != nil {
@@ -586,7 +681,7 @@ func (v *validator) UpdateDuties(ctx context.Context, slot types.Slot) error {
 	v.slashableKeysLock.RUnlock()
 
 	req := &ethpb.DutiesRequest{
-		Epoch:      types.Epoch(slot / params.BeaconConfig().SlotsPerEpoch),
+		Epoch:      slots.ToEpoch(slot),
 		PublicKeys: bytesutil.FromBytes48Array(filteredKeys),
 	}
 
@@ -699,12 +794,18 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldpar
 		if duty.AttesterSlot == slot {
 			roles = append(roles, iface.RoleAttester)
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, slot, bytesutil.ToBytes48(duty.PublicKey))
-			if err != nil {
-				return nil, errors.Wrap(err, "could not check if a validator is an aggregator")
-			}
-			if aggregator {
+			// With beacon computed aggregation, every attester is a candidate aggregator and
+			// the beacon node determines the actual aggregators at the aggregation deadline.
+			if features.Get().EnableBeaconComputedAggregation {
 				roles = append(roles, iface.RoleAggregator)
+			} else {
+				aggregator, err := v.isAggregator(ctx, duty.Committee, slot, bytesutil.ToBytes48(duty.PublicKey))
+				if err != nil {
+					return nil, errors.Wrap(err, "could not check if a validator is an aggregator")
+				}
+				if aggregator {
+					roles = append(roles, iface.RoleAggregator)
+				}
 			}
 
 		}
@@ -888,7 +989,7 @@ func (v *validator) logDuties(slot types.Slot, duties []*ethpb.DutiesResponse_Du
 		attesterKeys[i] = make([]string, 0)
 	}
 	proposerKeys := make([]string, params.BeaconConfig().SlotsPerEpoch)
-	slotOffset := slot - (slot % params.BeaconConfig().SlotsPerEpoch)
+	slotOffset := slot - slots.SinceEpochStarts(slot)
 	var totalAttestingKeys uint64
 	for _, duty := range duties {
 		validatorNotTruncatedKey := fmt.Sprintf("%#x", duty.PublicKey)
@@ -930,7 +1031,7 @@ func (v *validator) logDuties(slot types.Slot, duties []*ethpb.DutiesResponse_Du
 		if len(attesterKeys[i]) > 0 {
 			log.WithFields(logrus.Fields{
 				"slot":                  slotOffset + i,
-				"slotInEpoch":           (slotOffset + i) % params.BeaconConfig().SlotsPerEpoch,
+				"slotInEpoch":           slots.SinceEpochStarts(slotOffset + i),
 				"attesterDutiesAtSlot":  len(attesterKeys[i]),
 				"totalAttestersInEpoch": totalAttestingKeys,
 				"pubKeys":               attesterKeys[i],
@@ -989,7 +1090,7 @@ func (v *validator) PushProposerSettings(ctx context.Context, km keymanager.IKey
 		if len(registerValidatorRequests) != len(pubkeys) {
 			log.Warnf("%d public key(s) will not be included in validator registration until a validator index is assigned", len(pubkeys)-len(registerValidatorRequests))
 		}
-		if err := SubmitValidatorRegistration(ctx, v.validatorClient, km.Sign, registerValidatorRequests); err != nil {
+		if err := SubmitValidatorRegistration(ctx, v.validatorClient, v.auditSign(km.Sign), registerValidatorRequests); err != nil {
 			return err
 		}
 		log.Infoln("Submitted builder validator registration settings for custom builders")
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -31,6 +32,7 @@ import (
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/validator/audit"
 	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	dbTest "github.com/prysmaticlabs/prysm/validator/db/testing"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
@@ -733,6 +735,32 @@ func TestRolesAt_OK(t *testing.T) {
 	assert.Equal(t, iface.RoleSyncCommittee, roleMap[bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())][0])
 }
 
+func TestRolesAt_BeaconComputedAggregation(t *testing.T) {
+	reset := features.InitWithReset(&features.Flags{EnableBeaconComputedAggregation: true})
+	defer reset()
+	v, _, validatorKey, finish := setup(t)
+	defer finish()
+
+	v.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				CommitteeIndex: 1,
+				AttesterSlot:   1,
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+			},
+		},
+	}
+
+	// No selection proof is signed to determine the aggregators, the beacon node does so.
+	roleMap, err := v.RolesAt(context.Background(), 1)
+	require.NoError(t, err)
+
+	roles := roleMap[bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())]
+	require.Equal(t, 2, len(roles))
+	assert.Equal(t, iface.RoleAttester, roles[0])
+	assert.Equal(t, iface.RoleAggregator, roles[1])
+}
+
 func TestRolesAt_DoesNotAssignProposer_Slot0(t *testing.T) {
 	v, m, validatorKey, finish := setup(t)
 	defer finish()
@@ -1077,10 +1105,6 @@ func (m *doppelGangerRequestMatcher) String() string {
 func TestValidator_CheckDoppelGanger(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	flgs := features.Get()
-	flgs.EnableDoppelGanger = true
-	reset := features.InitWithReset(flgs)
-	defer reset()
 	tests := []struct {
 		name            string
 		validatorSetter func(t *testing.T) *validator
@@ -1264,6 +1288,76 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 	}
 }
 
+func TestValidator_CheckDoppelGanger_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	resetCfg := features.InitWithReset(&features.Flags{
+		DisableDoppelGanger: true,
+	})
+	defer resetCfg()
+	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	km := genMockKeymanager(1)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	v := &validator{
+		validatorClient: client,
+		keyManager:      km,
+		db:              dbTest.SetupDB(t, keys),
+	}
+	client.EXPECT().CheckDoppelGanger(gomock.Any(), gomock.Any()).Times(0)
+	require.NoError(t, v.CheckDoppelGanger(context.Background()))
+}
+
+func TestValidator_CheckDoppelGanger_ListensForEpochs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	km := genMockKeymanager(1)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	// The next epoch starts one second from now.
+	epochDuration := time.Duration(uint64(params.BeaconConfig().SlotsPerEpoch)*params.BeaconConfig().SecondsPerSlot) * time.Second
+	genesis := time.Now().Add(-2*epochDuration + time.Second)
+	v := &validator{
+		validatorClient:    client,
+		keyManager:         km,
+		db:                 dbTest.SetupDB(t, keys),
+		genesisTime:        uint64(genesis.Unix()),
+		doppelGangerEpochs: 1,
+	}
+	gomock.InOrder(
+		client.EXPECT().CheckDoppelGanger(gomock.Any(), gomock.Any()).Return(&ethpb.DoppelGangerResponse{
+			Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{{PublicKey: keys[0][:], DuplicateExists: false}},
+		}, nil),
+		client.EXPECT().CheckDoppelGanger(gomock.Any(), gomock.Any()).Return(&ethpb.DoppelGangerResponse{
+			Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{{PublicKey: keys[0][:], DuplicateExists: true}},
+		}, nil),
+	)
+	require.ErrorContains(t, "Duplicate instances exists in the network for validator keys", v.CheckDoppelGanger(context.Background()))
+}
+
+func TestValidator_CheckDoppelGanger_ContextCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	km := genMockKeymanager(1)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	v := &validator{
+		validatorClient:    client,
+		keyManager:         km,
+		db:                 dbTest.SetupDB(t, keys),
+		genesisTime:        uint64(time.Now().Add(-time.Hour).Unix()),
+		doppelGangerEpochs: 2,
+	}
+	client.EXPECT().CheckDoppelGanger(gomock.Any(), gomock.Any()).Return(&ethpb.DoppelGangerResponse{
+		Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{{PublicKey: keys[0][:], DuplicateExists: false}},
+	}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorContains(t, "context deadline exceeded", v.CheckDoppelGanger(ctx))
+}
+
 func TestValidatorAttestationsAreOrdered(t *testing.T) {
 	km := genMockKeymanager(10)
 	keys, err := km.FetchValidatingPublicKeys(context.Background())
@@ -2025,3 +2119,46 @@ func TestValidator_PushProposerSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_SignRecordsAuditLog(t *testing.T) {
+	validatorKey, err := bls.RandKey()
+	require.NoError(t, err)
+	pubKey := [fieldparams.BLSPubkeyLength]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	auditDir := filepath.Join(t.TempDir(), "audit")
+	auditLog, err := audit.Open(auditDir, 0)
+	require.NoError(t, err)
+	v := &validator{
+		keyManager: &mockKeymanager{
+			keysMap: map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey{
+				pubKey: validatorKey,
+			},
+		},
+		auditLog: auditLog,
+	}
+
+	_, err = v.sign(context.Background(), &validatorpb.SignRequest{
+		PublicKey:   pubKey[:],
+		SigningRoot: make([]byte, 32),
+		Object:      &validatorpb.SignRequest_Epoch{Epoch: 3},
+	})
+	require.NoError(t, err)
+	_, err = v.sign(context.Background(), &validatorpb.SignRequest{
+		PublicKey:   make([]byte, fieldparams.BLSPubkeyLength),
+		SigningRoot: make([]byte, 32),
+		Object:      &validatorpb.SignRequest_Slot{Slot: 100},
+	})
+	require.ErrorContains(t, "not found", err)
+	require.NoError(t, auditLog.Close())
+
+	entries, err := audit.ReadAll(auditDir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	require.NoError(t, audit.Verify(entries))
+	assert.Equal(t, "randao_reveal", entries[0].Type)
+	assert.Equal(t, audit.OutcomeSigned, entries[0].Outcome)
+	assert.Equal(t, hexutil.Encode(pubKey[:]), entries[0].PublicKey)
+	assert.Equal(t, "aggregation_slot", entries[1].Type)
+	assert.Equal(t, types.Slot(100), entries[1].Slot)
+	assert.Equal(t, audit.OutcomeFailed, entries[1].Outcome)
+}
@@ -0,0 +1,274 @@
+// Package crosscheck compares the statuses and balances of the keys managed by the validator
+// client, as reported by the connected beacon node, with the ones reported by an external beacon
+// API. A divergence means that one of them follows a different chain or serves stale data, which
+// cannot be detected by looking at the connected beacon node alone.
+package crosscheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+const requestTimeout = 10 * time.Second
+
+// maxKeysPerRequest bounds the number of public keys sent in a single query string.
+const maxKeysPerRequest = 64
+
+const (
+	statusUnknown = "unknown"
+	statusPending = "pending"
+	statusActive  = "active"
+	statusExited  = "exited"
+)
+
+// Config for a cross-check Checker.
+type Config struct {
+	// URL of the external beacon API, serving the standard /eth/v1/beacon endpoints.
+	URL string
+	// BalanceTolerance is the largest balance difference, in gwei, not reported as a divergence.
+	BalanceTolerance uint64
+	// Client is the HTTP client used to query the external API. http.DefaultClient is used when nil.
+	Client *http.Client
+}
+
+// Checker queries an external beacon API and compares its answers with the connected beacon node.
+type Checker struct {
+	url              string
+	balanceTolerance uint64
+	client           *http.Client
+}
+
+// Validator is the state of a validator as reported by the external beacon API.
+type Validator struct {
+	Index   types.ValidatorIndex
+	Balance uint64
+	Status  string
+}
+
+// Divergence between the connected beacon node and the external beacon API for a validator.
+type Divergence struct {
+	PublicKey [fieldparams.BLSPubkeyLength]byte
+	Field     string
+	Local     string
+	External  string
+}
+
+// New creates a Checker from the config, validating the URL.
+func New(cfg *Config) (*Checker, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse external beacon API url")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("external beacon API url %s must use http or https", cfg.URL)
+	}
+	client := cfg.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Checker{
+		url:              strings.TrimSuffix(cfg.URL, "/"),
+		balanceTolerance: cfg.BalanceTolerance,
+		client:           client,
+	}, nil
+}
+
+type validatorsResponseJson struct {
+	Data []*validatorContainerJson `json:"data"`
+}
+
+type validatorContainerJson struct {
+	Index     string         `json:"index"`
+	Balance   string         `json:"balance"`
+	Status    string         `json:"status"`
+	Validator *validatorJson `json:"validator"`
+}
+
+type validatorJson struct {
+	PublicKey string `json:"pubkey"`
+}
+
+// Validators returns the validators of the given public keys known to the external beacon API, in
+// the state at the start of the given epoch. This is the state the connected beacon node uses to
+// answer balance requests for that epoch.
+func (c *Checker) Validators(
+	ctx context.Context,
+	epoch types.Epoch,
+	pubKeys [][fieldparams.BLSPubkeyLength]byte,
+) (map[[fieldparams.BLSPubkeyLength]byte]*Validator, error) {
+	slot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	vals := make(map[[fieldparams.BLSPubkeyLength]byte]*Validator, len(pubKeys))
+	for i := 0; i < len(pubKeys); i += maxKeysPerRequest {
+		end := i + maxKeysPerRequest
+		if end > len(pubKeys) {
+			end = len(pubKeys)
+		}
+		ids := make([]string, 0, end-i)
+		for _, pubKey := range pubKeys[i:end] {
+			ids = append(ids, hexutil.Encode(pubKey[:]))
+		}
+		resp, err := c.fetch(ctx, slot, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range resp.Data {
+			if d.Validator == nil {
+				continue
+			}
+			pubKey, err := hexutil.Decode(d.Validator.PublicKey)
+			if err != nil || len(pubKey) != fieldparams.BLSPubkeyLength {
+				return nil, fmt.Errorf("invalid public key %s in external beacon API response", d.Validator.PublicKey)
+			}
+			index, err := strconv.ParseUint(d.Index, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid index %s in external beacon API response", d.Index)
+			}
+			balance, err := strconv.ParseUint(d.Balance, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid balance %s in external beacon API response", d.Balance)
+			}
+			vals[bytesutil.ToBytes48(pubKey)] = &Validator{
+				Index:   types.ValidatorIndex(index),
+				Balance: balance,
+				Status:  d.Status,
+			}
+		}
+	}
+	return vals, nil
+}
+
+func (c *Checker) fetch(ctx context.Context, slot types.Slot, ids []string) (*validatorsResponseJson, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	u := fmt.Sprintf("%s/eth/v1/beacon/states/%d/validators?id=%s", c.url, slot, strings.Join(ids, ","))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create external beacon API request")
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not query external beacon API")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close external beacon API response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		msg, err := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if err != nil {
+			return nil, errors.Wrapf(err, "external beacon API responded with status %d", resp.StatusCode)
+		}
+		return nil, errors.Errorf("external beacon API responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	res := &validatorsResponseJson{}
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return nil, errors.Wrap(err, "could not decode external beacon API response")
+	}
+	return res, nil
+}
+
+// Compare the balances and statuses returned by the connected beacon node with the validators
+// returned by the external beacon API, for the given public keys. Statuses are compared at the
+// granularity of pending, active and exited, as both APIs do not draw the same finer lines.
+func (c *Checker) Compare(
+	pubKeys [][fieldparams.BLSPubkeyLength]byte,
+	local *ethpb.ValidatorBalances,
+	external map[[fieldparams.BLSPubkeyLength]byte]*Validator,
+) []*Divergence {
+	localBalances := make(map[[fieldparams.BLSPubkeyLength]byte]*ethpb.ValidatorBalances_Balance, len(local.Balances))
+	for _, b := range local.Balances {
+		// Validators unknown to the beacon node are returned without public key.
+		if len(b.PublicKey) == 0 {
+			continue
+		}
+		localBalances[bytesutil.ToBytes48(b.PublicKey)] = b
+	}
+
+	var divergences []*Divergence
+	for _, pubKey := range pubKeys {
+		l, lok := localBalances[pubKey]
+		e, eok := external[pubKey]
+		localStatus, externalStatus := statusUnknown, statusUnknown
+		if lok {
+			localStatus = localStatusGroup(l.Status)
+		}
+		if eok {
+			externalStatus = externalStatusGroup(e.Status)
+		}
+		if localStatus != externalStatus {
+			d := &Divergence{PublicKey: pubKey, Field: "status", Local: statusUnknown, External: statusUnknown}
+			if lok {
+				d.Local = l.Status
+			}
+			if eok {
+				d.External = e.Status
+			}
+			divergences = append(divergences, d)
+			continue
+		}
+		if !lok || !eok {
+			continue
+		}
+		diff := l.Balance - e.Balance
+		if e.Balance > l.Balance {
+			diff = e.Balance - l.Balance
+		}
+		if diff > c.balanceTolerance {
+			divergences = append(divergences, &Divergence{
+				PublicKey: pubKey,
+				Field:     "balance",
+				Local:     strconv.FormatUint(l.Balance, 10),
+				External:  strconv.FormatUint(e.Balance, 10),
+			})
+		}
+	}
+	return divergences
+}
+
+// localStatusGroup maps a validator status of the connected beacon node to its coarse group.
+func localStatusGroup(status string) string {
+	switch status {
+	case ethpb.ValidatorStatus_DEPOSITED.String(), ethpb.ValidatorStatus_PENDING.String(), ethpb.ValidatorStatus_PARTIALLY_DEPOSITED.String():
+		return statusPending
+	case ethpb.ValidatorStatus_ACTIVE.String(), ethpb.ValidatorStatus_EXITING.String(), ethpb.ValidatorStatus_SLASHING.String():
+		return statusActive
+	case ethpb.ValidatorStatus_EXITED.String():
+		return statusExited
+	default:
+		return statusUnknown
+	}
+}
+
+// externalStatusGroup maps a validator status of the standard beacon API to its coarse group.
+func externalStatusGroup(status string) string {
+	switch {
+	case strings.HasPrefix(status, "pending"):
+		return statusPending
+	case strings.HasPrefix(status, "active"):
+		return statusActive
+	case strings.HasPrefix(status, "exited"), strings.HasPrefix(status, "withdrawal"):
+		return statusExited
+	default:
+		return statusUnknown
+	}
+}
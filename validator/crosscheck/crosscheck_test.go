@@ -0,0 +1,110 @@
+package crosscheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestNew_InvalidURL(t *testing.T) {
+	_, err := New(&Config{URL: "localhost:3500"})
+	require.ErrorContains(t, "must use http or https", err)
+}
+
+func TestChecker_Validators(t *testing.T) {
+	pubKey1 := [fieldparams.BLSPubkeyLength]byte{1}
+	pubKey2 := [fieldparams.BLSPubkeyLength]byte{2}
+	wantedPath := fmt.Sprintf("/eth/v1/beacon/states/%d/validators", 2*params.BeaconConfig().SlotsPerEpoch)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, wantedPath, r.URL.Path)
+		assert.Equal(t, hexutil.Encode(pubKey1[:])+","+hexutil.Encode(pubKey2[:]), r.URL.Query().Get("id"))
+		// Only the first validator is known to the external beacon API.
+		resp := &validatorsResponseJson{Data: []*validatorContainerJson{
+			{
+				Index:     "7",
+				Balance:   "32000000000",
+				Status:    "active_ongoing",
+				Validator: &validatorJson{PublicKey: hexutil.Encode(pubKey1[:])},
+			},
+		}}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	c, err := New(&Config{URL: srv.URL + "/"})
+	require.NoError(t, err)
+	vals, err := c.Validators(context.Background(), 2, [][fieldparams.BLSPubkeyLength]byte{pubKey1, pubKey2})
+	require.NoError(t, err)
+	require.DeepEqual(t, map[[fieldparams.BLSPubkeyLength]byte]*Validator{
+		pubKey1: {Index: 7, Balance: 32000000000, Status: "active_ongoing"},
+	}, vals)
+}
+
+func TestChecker_Validators_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "state not found", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c, err := New(&Config{URL: srv.URL})
+	require.NoError(t, err)
+	_, err = c.Validators(context.Background(), 2, [][fieldparams.BLSPubkeyLength]byte{{1}})
+	require.ErrorContains(t, "external beacon API responded with status 404: state not found", err)
+}
+
+func TestChecker_Compare(t *testing.T) {
+	c, err := New(&Config{URL: "http://localhost:3500", BalanceTolerance: 10})
+	require.NoError(t, err)
+
+	keys := make([][fieldparams.BLSPubkeyLength]byte, 6)
+	for i := range keys {
+		keys[i] = [fieldparams.BLSPubkeyLength]byte{byte(i + 1)}
+	}
+	local := &ethpb.ValidatorBalances{Balances: []*ethpb.ValidatorBalances_Balance{
+		{PublicKey: keys[0][:], Balance: 100, Status: "ACTIVE"},
+		{PublicKey: keys[1][:], Balance: 100, Status: "EXITING"},
+		{PublicKey: keys[2][:], Balance: 100, Status: "ACTIVE"},
+		{PublicKey: keys[3][:], Balance: 100, Status: "ACTIVE"},
+		{PublicKey: keys[4][:], Balance: 100, Status: "DEPOSITED"},
+		// Unknown to the beacon node.
+		{Status: "UNKNOWN"},
+	}}
+	external := map[[fieldparams.BLSPubkeyLength]byte]*Validator{
+		keys[0]: {Balance: 105, Status: "active_ongoing"},
+		keys[1]: {Balance: 100, Status: "active_exiting"},
+		keys[2]: {Balance: 200, Status: "active_ongoing"},
+		keys[3]: {Balance: 100, Status: "exited_unslashed"},
+		keys[5]: {Balance: 100, Status: "pending_queued"},
+	}
+
+	got := c.Compare(keys, local, external)
+	want := []*Divergence{
+		{PublicKey: keys[2], Field: "balance", Local: "100", External: "200"},
+		{PublicKey: keys[3], Field: "status", Local: "ACTIVE", External: "exited_unslashed"},
+		{PublicKey: keys[4], Field: "status", Local: "DEPOSITED", External: "unknown"},
+		{PublicKey: keys[5], Field: "status", Local: "unknown", External: "pending_queued"},
+	}
+	require.DeepEqual(t, want, got)
+}
+
+func TestExternalStatusGroup(t *testing.T) {
+	for status, want := range map[string]string{
+		"pending_initialized": statusPending,
+		"active_slashed":      statusActive,
+		"exited_slashed":      statusExited,
+		"withdrawal_done":     statusExited,
+		"":                    statusUnknown,
+	} {
+		assert.Equal(t, want, externalStatusGroup(status), status)
+	}
+}
@@ -0,0 +1,5 @@
+package crosscheck
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "crosscheck")
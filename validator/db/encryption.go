@@ -0,0 +1,55 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/urfave/cli/v2"
+)
+
+// EncryptionSecret returns the secret the values of the validator database are encrypted with, as
+// configured by the --db-encryption-key-file or --db-encryption-with-wallet-password flags, or nil
+// if the database is not encrypted. When no wallet password is given, it is read from the file of
+// the --wallet-password-file flag.
+func EncryptionSecret(cliCtx *cli.Context, walletPassword string) ([]byte, error) {
+	keyFile := cliCtx.String(flags.DBEncryptionKeyFileFlag.Name)
+	withWalletPassword := cliCtx.Bool(flags.DBEncryptionWithWalletPasswordFlag.Name)
+	switch {
+	case keyFile != "" && withWalletPassword:
+		return nil, fmt.Errorf(
+			"cannot use both --%s and --%s",
+			flags.DBEncryptionKeyFileFlag.Name,
+			flags.DBEncryptionWithWalletPasswordFlag.Name,
+		)
+	case keyFile != "":
+		secret, err := file.ReadFileAsBytes(keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read database encryption key file")
+		}
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("database encryption key file %s is empty", keyFile)
+		}
+		return secret, nil
+	case withWalletPassword:
+		if walletPassword == "" && cliCtx.IsSet(flags.WalletPasswordFileFlag.Name) {
+			enc, err := file.ReadFileAsBytes(cliCtx.String(flags.WalletPasswordFileFlag.Name))
+			if err != nil {
+				return nil, errors.Wrap(err, "could not read wallet password file")
+			}
+			walletPassword = strings.TrimRight(string(enc), "\r\n")
+		}
+		if walletPassword == "" {
+			return nil, fmt.Errorf(
+				"--%s requires a wallet password, please provide --%s",
+				flags.DBEncryptionWithWalletPasswordFlag.Name,
+				flags.WalletPasswordFileFlag.Name,
+			)
+		}
+		return []byte(walletPassword), nil
+	default:
+		return nil, nil
+	}
+}
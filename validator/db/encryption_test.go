@@ -0,0 +1,91 @@
+package db
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestEncryptionSecret(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("key"), os.ModePerm))
+	passwordFile := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), os.ModePerm))
+	emptyFile := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(emptyFile, nil, os.ModePerm))
+
+	tests := []struct {
+		name               string
+		keyFile            string
+		withWalletPassword bool
+		passwordFile       string
+		walletPassword     string
+		want               []byte
+		wantErr            string
+	}{
+		{
+			name: "not encrypted",
+		},
+		{
+			name:    "key file",
+			keyFile: keyFile,
+			want:    []byte("key"),
+		},
+		{
+			name:    "empty key file",
+			keyFile: emptyFile,
+			wantErr: "is empty",
+		},
+		{
+			name:               "wallet password",
+			withWalletPassword: true,
+			passwordFile:       passwordFile,
+			walletPassword:     "opened",
+			want:               []byte("opened"),
+		},
+		{
+			name:               "wallet password file",
+			withWalletPassword: true,
+			passwordFile:       passwordFile,
+			want:               []byte("password"),
+		},
+		{
+			name:               "no wallet password",
+			withWalletPassword: true,
+			wantErr:            "requires a wallet password",
+		},
+		{
+			name:               "both",
+			keyFile:            keyFile,
+			withWalletPassword: true,
+			wantErr:            "cannot use both",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := cli.App{}
+			set := flag.NewFlagSet("test", 0)
+			set.String(flags.DBEncryptionKeyFileFlag.Name, tt.keyFile, "")
+			set.Bool(flags.DBEncryptionWithWalletPasswordFlag.Name, tt.withWalletPassword, "")
+			set.String(flags.WalletPasswordFileFlag.Name, "", "")
+			if tt.passwordFile != "" {
+				require.NoError(t, set.Set(flags.WalletPasswordFileFlag.Name, tt.passwordFile))
+			}
+			cliCtx := cli.NewContext(&app, set, nil)
+			secret, err := EncryptionSecret(cliCtx, tt.walletPassword)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.DeepEqual(t, tt.want, secret)
+		})
+	}
+}
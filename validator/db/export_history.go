@@ -0,0 +1,231 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/validator/db/iface"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	csvFormat                = "csv"
+	parquetFormat            = "parquet"
+	attestationHistoryPrefix = "attestation_history"
+	proposalHistoryPrefix    = "proposal_history"
+)
+
+// historyColumn is a column of exported signing history, holding either text or numbers.
+type historyColumn struct {
+	name    string
+	numeric bool
+	text    []string
+	numbers []uint64
+}
+
+func (c *historyColumn) value(row int) string {
+	if c.numeric {
+		return strconv.FormatUint(c.numbers[row], 10)
+	}
+	return c.text[row]
+}
+
+func (c *historyColumn) parquetType() int32 {
+	if c.numeric {
+		return parquetInt64
+	}
+	return parquetByteArray
+}
+
+func (c *historyColumn) parquetConvertedType() int32 {
+	if c.numeric {
+		return parquetUint64
+	}
+	return parquetUTF8
+}
+
+// historyTable is exported signing history, stored by column.
+type historyTable struct {
+	columns []*historyColumn
+	rows    int
+}
+
+// ExportHistory writes the signed attestation and proposal history of every validator in the
+// validator database to the output directory, as CSV or parquet files.
+func ExportHistory(cliCtx *cli.Context) error {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	outputDir := cliCtx.String(flags.HistoryExportDirFlag.Name)
+	format := cliCtx.String(flags.HistoryExportFormatFlag.Name)
+	if format != csvFormat && format != parquetFormat {
+		return fmt.Errorf("unsupported history export format %s, expected %s or %s", format, csvFormat, parquetFormat)
+	}
+	if outputDir == "" {
+		return errors.New("output directory not specified")
+	}
+	if !file.FileExists(path.Join(dataDir, kv.ProtectionDbFileName)) {
+		return errors.New("No validator db found at path, nothing to export")
+	}
+
+	ctx := context.Background()
+	log.Info("Opening DB")
+	encryptionSecret, err := EncryptionSecret(cliCtx, "" /* read from --wallet-password-file */)
+	if err != nil {
+		return errors.Wrap(err, "could not get database encryption secret")
+	}
+	validatorDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{EncryptionSecret: encryptionSecret})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := validatorDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close validator DB")
+		}
+	}()
+
+	attestations, err := attestationHistoryTable(ctx, validatorDB)
+	if err != nil {
+		return err
+	}
+	proposals, err := proposalHistoryTable(ctx, validatorDB)
+	if err != nil {
+		return err
+	}
+	exists, err := file.HasDir(outputDir)
+	if err != nil {
+		return errors.Wrapf(err, "could not check if output directory %s already exists", outputDir)
+	}
+	if !exists {
+		if err := file.MkdirAll(outputDir); err != nil {
+			return errors.Wrapf(err, "could not create output directory %s", outputDir)
+		}
+	}
+	for prefix, table := range map[string]*historyTable{
+		attestationHistoryPrefix: attestations,
+		proposalHistoryPrefix:    proposals,
+	} {
+		outputFile := filepath.Join(outputDir, prefix+"."+format)
+		if err := writeHistoryTable(outputFile, format, table); err != nil {
+			return errors.Wrapf(err, "could not write file to path %s", outputFile)
+		}
+		log.WithField("rows", table.rows).Infof("Wrote signing history to %s", outputFile)
+	}
+	return nil
+}
+
+// attestationHistoryTable returns the signed attestations of every validator, ordered by public
+// key then as stored in the database. The signing root is left empty when it is not known.
+func attestationHistoryTable(ctx context.Context, validatorDB iface.ValidatorDB) (*historyTable, error) {
+	pubKeys, err := validatorDB.AttestedPublicKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve attested public keys from DB")
+	}
+	sortPubKeys(pubKeys)
+	pubKeyColumn := &historyColumn{name: "public_key"}
+	sourceColumn := &historyColumn{name: "source_epoch", numeric: true}
+	targetColumn := &historyColumn{name: "target_epoch", numeric: true}
+	rootColumn := &historyColumn{name: "signing_root"}
+	for _, pubKey := range pubKeys {
+		history, err := validatorDB.AttestationHistoryForPubKey(ctx, pubKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get attestation history for public key %#x", pubKey)
+		}
+		for _, att := range history {
+			pubKeyColumn.text = append(pubKeyColumn.text, fmt.Sprintf("%#x", pubKey))
+			sourceColumn.numbers = append(sourceColumn.numbers, uint64(att.Source))
+			targetColumn.numbers = append(targetColumn.numbers, uint64(att.Target))
+			rootColumn.text = append(rootColumn.text, signingRootString(att.SigningRoot[:]))
+		}
+	}
+	return &historyTable{
+		columns: []*historyColumn{pubKeyColumn, sourceColumn, targetColumn, rootColumn},
+		rows:    len(pubKeyColumn.text),
+	}, nil
+}
+
+// proposalHistoryTable returns the signed proposals of every validator, ordered by public key
+// then slot.
+func proposalHistoryTable(ctx context.Context, validatorDB iface.ValidatorDB) (*historyTable, error) {
+	pubKeys, err := validatorDB.ProposedPublicKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve proposer public keys from DB")
+	}
+	sortPubKeys(pubKeys)
+	pubKeyColumn := &historyColumn{name: "public_key"}
+	slotColumn := &historyColumn{name: "slot", numeric: true}
+	rootColumn := &historyColumn{name: "signing_root"}
+	for _, pubKey := range pubKeys {
+		history, err := validatorDB.ProposalHistoryForPubKey(ctx, pubKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get proposal history for public key %#x", pubKey)
+		}
+		sort.Slice(history, func(i, j int) bool {
+			return history[i].Slot < history[j].Slot
+		})
+		for _, proposal := range history {
+			pubKeyColumn.text = append(pubKeyColumn.text, fmt.Sprintf("%#x", pubKey))
+			slotColumn.numbers = append(slotColumn.numbers, uint64(proposal.Slot))
+			rootColumn.text = append(rootColumn.text, signingRootString(proposal.SigningRoot))
+		}
+	}
+	return &historyTable{
+		columns: []*historyColumn{pubKeyColumn, slotColumn, rootColumn},
+		rows:    len(pubKeyColumn.text),
+	}, nil
+}
+
+func sortPubKeys(pubKeys [][fieldparams.BLSPubkeyLength]byte) {
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) < 0
+	})
+}
+
+func signingRootString(root []byte) string {
+	if len(root) == 0 || bytes.Equal(root, params.BeaconConfig().ZeroHash[:]) {
+		return ""
+	}
+	return fmt.Sprintf("%#x", root)
+}
+
+func writeHistoryTable(outputFile, format string, table *historyTable) error {
+	var buf bytes.Buffer
+	if format == parquetFormat {
+		if err := writeParquet(&buf, table.columns, table.rows); err != nil {
+			return err
+		}
+	} else {
+		w := csv.NewWriter(&buf)
+		record := make([]string, len(table.columns))
+		for i, c := range table.columns {
+			record[i] = c.name
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+		for r := 0; r < table.rows; r++ {
+			for i, c := range table.columns {
+				record[i] = c.value(r)
+			}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return err
+		}
+	}
+	return file.WriteFile(outputFile, buf.Bytes())
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/urfave/cli/v2"
+)
+
+func setupExportHistoryDB(t *testing.T) (string, [][fieldparams.BLSPubkeyLength]byte) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	pubKeys := [][fieldparams.BLSPubkeyLength]byte{{2}, {1}}
+	validatorDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{PubKeys: pubKeys})
+	require.NoError(t, err)
+	for i, pubKey := range pubKeys {
+		require.NoError(t, validatorDB.SaveAttestationForPubKey(ctx, pubKey, [32]byte{byte(i + 1)}, &ethpb.IndexedAttestation{
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: 1},
+				Target: &ethpb.Checkpoint{Epoch: 2},
+			},
+		}))
+	}
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKeys[0], 20, bytesutil.PadTo([]byte{3}, 32)))
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKeys[0], 10, nil))
+	require.NoError(t, validatorDB.Close())
+	return dataDir, pubKeys
+}
+
+func exportHistoryContext(dataDir, outputDir, format string) *cli.Context {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dataDir, "")
+	set.String(flags.HistoryExportDirFlag.Name, outputDir, "")
+	set.String(flags.HistoryExportFormatFlag.Name, format, "")
+	return cli.NewContext(&app, set, nil)
+}
+
+func TestExportHistory_CSV(t *testing.T) {
+	dataDir, pubKeys := setupExportHistoryDB(t)
+	outputDir := filepath.Join(t.TempDir(), "history")
+	require.NoError(t, ExportHistory(exportHistoryContext(dataDir, outputDir, csvFormat)))
+
+	attestations, err := os.ReadFile(filepath.Join(outputDir, attestationHistoryPrefix+".csv"))
+	require.NoError(t, err)
+	signingRoot := func(b byte) string {
+		return fmt.Sprintf("%#x", [32]byte{b})
+	}
+	assert.Equal(t, "public_key,source_epoch,target_epoch,signing_root\n"+
+		fmt.Sprintf("%#x,1,2,%s\n", pubKeys[1], signingRoot(2))+
+		fmt.Sprintf("%#x,1,2,%s\n", pubKeys[0], signingRoot(1)), string(attestations))
+
+	proposals, err := os.ReadFile(filepath.Join(outputDir, proposalHistoryPrefix+".csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "public_key,slot,signing_root\n"+
+		fmt.Sprintf("%#x,10,\n", pubKeys[0])+
+		fmt.Sprintf("%#x,20,%s\n", pubKeys[0], signingRoot(3)), string(proposals))
+}
+
+func TestExportHistory_Parquet(t *testing.T) {
+	dataDir, pubKeys := setupExportHistoryDB(t)
+	outputDir := t.TempDir()
+	require.NoError(t, ExportHistory(exportHistoryContext(dataDir, outputDir, parquetFormat)))
+
+	signingRoot := func(b byte) string {
+		return fmt.Sprintf("%#x", [32]byte{b})
+	}
+	tests := []struct {
+		prefix  string
+		columns []*historyColumn
+	}{
+		{
+			prefix: attestationHistoryPrefix,
+			columns: []*historyColumn{
+				{name: "public_key", text: []string{fmt.Sprintf("%#x", pubKeys[1]), fmt.Sprintf("%#x", pubKeys[0])}},
+				{name: "source_epoch", numeric: true, numbers: []uint64{1, 1}},
+				{name: "target_epoch", numeric: true, numbers: []uint64{2, 2}},
+				{name: "signing_root", text: []string{signingRoot(2), signingRoot(1)}},
+			},
+		},
+		{
+			prefix: proposalHistoryPrefix,
+			columns: []*historyColumn{
+				{name: "public_key", text: []string{fmt.Sprintf("%#x", pubKeys[0]), fmt.Sprintf("%#x", pubKeys[0])}},
+				{name: "slot", numeric: true, numbers: []uint64{10, 20}},
+				{name: "signing_root", text: []string{"", signingRoot(3)}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		b, err := os.ReadFile(filepath.Join(outputDir, tt.prefix+".parquet"))
+		require.NoError(t, err)
+		numRows, columns := readParquet(t, b)
+		assertParquetColumns(t, tt.columns, 2, numRows, columns)
+	}
+}
+
+func TestExportHistory_InvalidFormat(t *testing.T) {
+	dataDir, _ := setupExportHistoryDB(t)
+	err := ExportHistory(exportHistoryContext(dataDir, t.TempDir(), "json"))
+	assert.ErrorContains(t, "unsupported history export format json", err)
+}
@@ -23,7 +23,9 @@ type ValidatorDB interface {
 	ClearDB() error
 	RunUpMigrations(ctx context.Context) error
 	RunDownMigrations(ctx context.Context) error
+	CompletedMigrations(ctx context.Context) ([]string, error)
 	UpdatePublicKeysBuckets(publicKeys [][fieldparams.BLSPubkeyLength]byte) error
+	StartSlashingProtectionPruning(ctx context.Context)
 
 	// Genesis information related methods.
 	GenesisValidatorsRoot(ctx context.Context) ([]byte, error)
@@ -106,7 +106,11 @@ func (s *Store) AttestationHistoryForPubKey(ctx context.Context, pubKey [fieldpa
 		signingRootsBucket := pkBucket.Bucket(attestationSigningRootsBucket)
 		sourceEpochsBucket := pkBucket.Bucket(attestationSourceEpochsBucket)
 
-		return sourceEpochsBucket.ForEach(func(sourceBytes, targetEpochsList []byte) error {
+		return sourceEpochsBucket.ForEach(func(sourceBytes, enc []byte) error {
+			targetEpochsList, err := s.decode(enc)
+			if err != nil {
+				return err
+			}
 			targetEpochs := make([]types.Epoch, 0)
 			for i := 0; i < len(targetEpochsList); i += 8 {
 				epoch := bytesutil.BytesToEpochBigEndian(targetEpochsList[i : i+8])
@@ -119,7 +123,10 @@ func (s *Store) AttestationHistoryForPubKey(ctx context.Context, pubKey [fieldpa
 					Source: sourceEpoch,
 					Target: targetEpoch,
 				}
-				signingRoot := signingRootsBucket.Get(bytesutil.EpochToBytesBigEndian(targetEpoch))
+				signingRoot, err := s.get(signingRootsBucket, bytesutil.EpochToBytesBigEndian(targetEpoch))
+				if err != nil {
+					return err
+				}
 				if signingRoot != nil {
 					copy(record.SigningRoot[:], signingRoot)
 				}
@@ -153,7 +160,10 @@ func (s *Store) CheckSlashableAttestation(
 		signingRootsBucket := pkBucket.Bucket(attestationSigningRootsBucket)
 		if signingRootsBucket != nil {
 			targetEpochBytes := bytesutil.EpochToBytesBigEndian(att.Data.Target.Epoch)
-			existingSigningRoot := signingRootsBucket.Get(targetEpochBytes)
+			existingSigningRoot, err := s.get(signingRootsBucket, targetEpochBytes)
+			if err != nil {
+				return err
+			}
 			if existingSigningRoot != nil {
 				var existing [32]byte
 				copy(existing[:], existingSigningRoot)
@@ -193,15 +203,19 @@ func (s *Store) CheckSlashableAttestation(
 }
 
 // Iterate from the back of the bucket since we are looking for target_epoch > att.target_epoch
-func (_ *Store) checkSurroundedVote(
+func (s *Store) checkSurroundedVote(
 	targetEpochsBucket *bolt.Bucket, att *ethpb.IndexedAttestation,
 ) (SlashingKind, error) {
 	c := targetEpochsBucket.Cursor()
-	for k, v := c.Last(); k != nil; k, v = c.Prev() {
+	for k, enc := c.Last(); k != nil; k, enc = c.Prev() {
 		existingTargetEpoch := bytesutil.BytesToEpochBigEndian(k)
 		if existingTargetEpoch <= att.Data.Target.Epoch {
 			break
 		}
+		v, err := s.decode(enc)
+		if err != nil {
+			return NotSlashable, err
+		}
 
 		// There can be multiple source epochs attested per target epoch.
 		attestedSourceEpochs := make([]types.Epoch, 0, len(v)/8)
@@ -233,15 +247,19 @@ func (_ *Store) checkSurroundedVote(
 }
 
 // Iterate from the back of the bucket since we are looking for source_epoch > att.source_epoch
-func (_ *Store) checkSurroundingVote(
+func (s *Store) checkSurroundingVote(
 	sourceEpochsBucket *bolt.Bucket, att *ethpb.IndexedAttestation,
 ) (SlashingKind, error) {
 	c := sourceEpochsBucket.Cursor()
-	for k, v := c.Last(); k != nil; k, v = c.Prev() {
+	for k, enc := c.Last(); k != nil; k, enc = c.Prev() {
 		existingSourceEpoch := bytesutil.BytesToEpochBigEndian(k)
 		if existingSourceEpoch <= att.Data.Source.Epoch {
 			break
 		}
+		v, err := s.decode(enc)
+		if err != nil {
+			return NotSlashable, err
+		}
 
 		// There can be multiple target epochs attested per source epoch.
 		attestedTargetEpochs := make([]types.Epoch, 0, len(v)/8)
@@ -422,7 +440,7 @@ func (s *Store) saveAttestationRecords(ctx context.Context, atts []*AttestationR
 			if err != nil {
 				return errors.Wrap(err, "could not create signing roots bucket")
 			}
-			if err := signingRootsBucket.Put(targetEpochBytes, att.SigningRoot[:]); err != nil {
+			if err := s.put(signingRootsBucket, targetEpochBytes, att.SigningRoot[:]); err != nil {
 				return errors.Wrapf(err, "could not save signing signing root for epoch %d", att.Target)
 			}
 			sourceEpochsBucket, err := pkBucket.CreateBucketIfNotExists(attestationSourceEpochsBucket)
@@ -433,14 +451,18 @@ func (s *Store) saveAttestationRecords(ctx context.Context, atts []*AttestationR
 			// There can be multiple attested target epochs per source epoch.
 			// If a previous list exists, we append to that list with the incoming target epoch.
 			// Otherwise, we initialize it using the incoming target epoch.
+			existing, err := s.get(sourceEpochsBucket, sourceEpochBytes)
+			if err != nil {
+				return err
+			}
 			var existingAttestedTargetsBytes []byte
-			if existing := sourceEpochsBucket.Get(sourceEpochBytes); existing != nil {
+			if existing != nil {
 				existingAttestedTargetsBytes = append(existing, targetEpochBytes...)
 			} else {
 				existingAttestedTargetsBytes = targetEpochBytes
 			}
 
-			if err := sourceEpochsBucket.Put(sourceEpochBytes, existingAttestedTargetsBytes); err != nil {
+			if err := s.put(sourceEpochsBucket, sourceEpochBytes, existingAttestedTargetsBytes); err != nil {
 				return errors.Wrapf(err, "could not save source epoch %d for epoch %d", att.Source, att.Target)
 			}
 
@@ -448,40 +470,50 @@ func (s *Store) saveAttestationRecords(ctx context.Context, atts []*AttestationR
 			if err != nil {
 				return errors.Wrap(err, "could not create target epochs bucket")
 			}
+			existing, err = s.get(targetEpochsBucket, targetEpochBytes)
+			if err != nil {
+				return err
+			}
 			var existingAttestedSourceBytes []byte
-			if existing := targetEpochsBucket.Get(targetEpochBytes); existing != nil {
+			if existing != nil {
 				existingAttestedSourceBytes = append(existing, sourceEpochBytes...)
 			} else {
 				existingAttestedSourceBytes = sourceEpochBytes
 			}
 
-			if err := targetEpochsBucket.Put(targetEpochBytes, existingAttestedSourceBytes); err != nil {
+			if err := s.put(targetEpochsBucket, targetEpochBytes, existingAttestedSourceBytes); err != nil {
 				return errors.Wrapf(err, "could not save target epoch %d for epoch %d", att.Target, att.Source)
 			}
 
 			// If the incoming source epoch is lower than the lowest signed source epoch, override.
-			lowestSignedSourceBytes := lowestSourceBucket.Get(att.PubKey[:])
+			lowestSignedSourceBytes, err := s.get(lowestSourceBucket, att.PubKey[:])
+			if err != nil {
+				return err
+			}
 			var lowestSignedSourceEpoch types.Epoch
 			if len(lowestSignedSourceBytes) >= 8 {
 				lowestSignedSourceEpoch = bytesutil.BytesToEpochBigEndian(lowestSignedSourceBytes)
 			}
 			if len(lowestSignedSourceBytes) == 0 || att.Source < lowestSignedSourceEpoch {
-				if err := lowestSourceBucket.Put(
-					att.PubKey[:], bytesutil.EpochToBytesBigEndian(att.Source),
+				if err := s.put(
+					lowestSourceBucket, att.PubKey[:], bytesutil.EpochToBytesBigEndian(att.Source),
 				); err != nil {
 					return err
 				}
 			}
 
 			// If the incoming target epoch is lower than the lowest signed target epoch, override.
-			lowestSignedTargetBytes := lowestTargetBucket.Get(att.PubKey[:])
+			lowestSignedTargetBytes, err := s.get(lowestTargetBucket, att.PubKey[:])
+			if err != nil {
+				return err
+			}
 			var lowestSignedTargetEpoch types.Epoch
 			if len(lowestSignedTargetBytes) >= 8 {
 				lowestSignedTargetEpoch = bytesutil.BytesToEpochBigEndian(lowestSignedTargetBytes)
 			}
 			if len(lowestSignedTargetBytes) == 0 || att.Target < lowestSignedTargetEpoch {
-				if err := lowestTargetBucket.Put(
-					att.PubKey[:], bytesutil.EpochToBytesBigEndian(att.Target),
+				if err := s.put(
+					lowestTargetBucket, att.PubKey[:], bytesutil.EpochToBytesBigEndian(att.Target),
 				); err != nil {
 					return err
 				}
@@ -525,7 +557,10 @@ func (s *Store) SigningRootAtTargetEpoch(ctx context.Context, pubKey [fieldparam
 		if signingRootsBucket == nil {
 			return nil
 		}
-		sr := signingRootsBucket.Get(bytesutil.EpochToBytesBigEndian(target))
+		sr, err := s.get(signingRootsBucket, bytesutil.EpochToBytesBigEndian(target))
+		if err != nil {
+			return err
+		}
 		copy(signingRoot[:], sr)
 		return nil
 	})
@@ -543,7 +578,10 @@ func (s *Store) LowestSignedSourceEpoch(ctx context.Context, publicKey [fieldpar
 	var exists bool
 	err = s.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(lowestSignedSourceBucket)
-		lowestSignedSourceBytes := bucket.Get(publicKey[:])
+		lowestSignedSourceBytes, err := s.get(bucket, publicKey[:])
+		if err != nil {
+			return err
+		}
 		// 8 because bytesutil.BytesToEpochBigEndian will return 0 if input is less than 8 bytes.
 		if len(lowestSignedSourceBytes) < 8 {
 			return nil
@@ -566,7 +604,10 @@ func (s *Store) LowestSignedTargetEpoch(ctx context.Context, publicKey [fieldpar
 	var exists bool
 	err = s.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(lowestSignedTargetBucket)
-		lowestSignedTargetBytes := bucket.Get(publicKey[:])
+		lowestSignedTargetBytes, err := s.get(bucket, publicKey[:])
+		if err != nil {
+			return err
+		}
 		// 8 because bytesutil.BytesToEpochBigEndian will return 0 if input is less than 8 bytes.
 		if len(lowestSignedTargetBytes) < 8 {
 			return nil
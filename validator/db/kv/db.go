@@ -3,8 +3,10 @@ package kv
 
 import (
 	"context"
+	"crypto/cipher"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -55,6 +57,11 @@ var blockedBuckets = [][]byte{
 type Config struct {
 	PubKeys         [][fieldparams.BLSPubkeyLength]byte
 	InitialMMapSize int
+	// Tenant labels the metrics of the database of a tenant of a multi-tenant validator client.
+	Tenant string
+	// EncryptionSecret, if set, is the secret the values of the database are encrypted with at rest,
+	// such as the wallet password or the contents of a key file.
+	EncryptionSecret []byte
 }
 
 // Store defines an implementation of the Prysm Database interface
@@ -66,11 +73,16 @@ type Store struct {
 	batchedAttestationsChan            chan *AttestationRecord
 	batchAttestationsFlushedFeed       *event.Feed
 	batchedAttestationsFlushInProgress abool.AtomicBool
+	tenant                             string
+	cipher                             cipher.AEAD
+	pruningLock                        sync.Mutex
+	stopPruning                        func()
 }
 
 // Close closes the underlying boltdb database.
 func (s *Store) Close() error {
-	prometheus.Unregister(createBoltCollector(s.db))
+	s.stopSlashingProtectionPruning()
+	s.metricsRegisterer().Unregister(s.boltCollector())
 	return s.db.Close()
 }
 
@@ -86,7 +98,7 @@ func (s *Store) ClearDB() error {
 	if _, err := os.Stat(s.databasePath); os.IsNotExist(err) {
 		return nil
 	}
-	prometheus.Unregister(createBoltCollector(s.db))
+	s.metricsRegisterer().Unregister(s.boltCollector())
 	return os.Remove(filepath.Join(s.databasePath, ProtectionDbFileName))
 }
 
@@ -135,6 +147,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		batchedAttestations:          NewQueuedAttestationRecords(),
 		batchedAttestationsChan:      make(chan *AttestationRecord, attestationBatchCapacity),
 		batchAttestationsFlushedFeed: new(event.Feed),
+		tenant:                       config.Tenant,
 	}
 
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
@@ -151,11 +164,19 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			pubKeysBucket,
 			migrationsBucket,
 			graffitiBucket,
+			encryptionBucket,
 		)
 	}); err != nil {
 		return nil, err
 	}
 
+	if err := kv.setupEncryption(config.EncryptionSecret); err != nil {
+		if closeErr := kv.db.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Could not close database")
+		}
+		return nil, err
+	}
+
 	// Initialize the required public keys into the DB to ensure they're not empty.
 	if config != nil {
 		if err := kv.UpdatePublicKeysBuckets(config.PubKeys); err != nil {
@@ -174,7 +195,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 	// intervals to our database.
 	go kv.batchAttestationWrites(ctx)
 
-	return kv, prometheus.Register(createBoltCollector(kv.db))
+	return kv, kv.metricsRegisterer().Register(kv.boltCollector())
 }
 
 // UpdatePublicKeysBuckets for a specified list of keys.
@@ -200,7 +221,20 @@ func (s *Store) Size() (int64, error) {
 	return size, err
 }
 
-// createBoltCollector returns a prometheus collector specifically configured for boltdb.
-func createBoltCollector(db *bolt.DB) prometheus.Collector {
-	return prombolt.New("boltDB", db, blockedBuckets...)
+// boltCollector returns a prometheus collector specifically configured for boltdb.
+func (s *Store) boltCollector() prometheus.Collector {
+	return prombolt.New("boltDB", s.db, blockedBuckets...)
+}
+
+// metricsRegisterer returns the registerer of the database metrics. The metrics of a tenant's database
+// are prefixed and labeled with the tenant name, so they can be registered next to the validator
+// client's database.
+func (s *Store) metricsRegisterer() prometheus.Registerer {
+	if s.tenant == "" {
+		return prometheus.DefaultRegisterer
+	}
+	return prometheus.WrapRegistererWithPrefix("tenant_", prometheus.WrapRegistererWith(
+		prometheus.Labels{"tenant": s.tenant},
+		prometheus.DefaultRegisterer,
+	))
 }
@@ -39,7 +39,7 @@ func (s *Store) SaveEIPImportBlacklistedPublicKeys(ctx context.Context, publicKe
 		for _, pubKey := range publicKeys {
 			// We write the public key to disk in the bucket. The value written for the key does not
 			// matter as we'll only be looking at the keys in the bucket when fetching from disk.
-			if err := bkt.Put(pubKey[:], []byte{1}); err != nil {
+			if err := s.put(bkt, pubKey[:], []byte{1}); err != nil {
 				return err
 			}
 		}
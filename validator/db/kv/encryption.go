@@ -0,0 +1,181 @@
+package kv
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/crypto/scrypt"
+)
+
+// The values of an encrypted database are sealed with AES-256-GCM, under a key derived with scrypt
+// from the encryption secret and a random salt stored in the database. Bucket names and keys, which
+// are public keys, epochs and slots, are kept in plaintext so that buckets can still be iterated in
+// order.
+const (
+	encryptionSaltLength = 32
+	encryptionKeyLength  = 32
+	// The secret is either the wallet password, whose keystores already use a slow key derivation,
+	// or the contents of a key file, so a moderate cost keeps opening the database fast.
+	encryptionScryptN = 1 << 15
+	encryptionScryptR = 8
+	encryptionScryptP = 1
+)
+
+var (
+	// encryptionCheckValue is stored encrypted to detect a wrong secret when opening the database.
+	encryptionCheckValue = []byte("prysm-validator-db")
+
+	errEncryptionSecretRequired = errors.New("database is encrypted, please provide its encryption secret")
+	errWrongEncryptionSecret    = errors.New("could not decrypt database, the encryption secret is wrong")
+)
+
+// setupEncryption prepares the cipher of the database values. A plaintext database is encrypted in
+// place when opened with a secret for the first time.
+func (s *Store) setupEncryption(secret []byte) error {
+	return s.update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(encryptionBucket)
+		salt := bkt.Get(encryptionSaltKey)
+		if salt == nil {
+			if len(secret) == 0 {
+				return nil
+			}
+			return s.encryptDatabase(tx, secret)
+		}
+		if len(secret) == 0 {
+			return errEncryptionSecretRequired
+		}
+		aead, err := newValueCipher(secret, salt)
+		if err != nil {
+			return err
+		}
+		s.cipher = aead
+		if _, err := s.get(bkt, encryptionCheckKey); err != nil {
+			s.cipher = nil
+			return errWrongEncryptionSecret
+		}
+		return nil
+	})
+}
+
+// encryptDatabase stores a new salt and encrypts every value of the database with the secret.
+func (s *Store) encryptDatabase(tx *bolt.Tx, secret []byte) error {
+	salt := make([]byte, encryptionSaltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return errors.Wrap(err, "could not generate encryption salt")
+	}
+	aead, err := newValueCipher(secret, salt)
+	if err != nil {
+		return err
+	}
+	s.cipher = aead
+	if err := tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+		if string(name) == string(encryptionBucket) {
+			return nil
+		}
+		return s.encryptBucket(b)
+	}); err != nil {
+		s.cipher = nil
+		return errors.Wrap(err, "could not encrypt database")
+	}
+	bkt := tx.Bucket(encryptionBucket)
+	if err := bkt.Put(encryptionSaltKey, salt); err != nil {
+		s.cipher = nil
+		return err
+	}
+	if err := s.put(bkt, encryptionCheckKey, encryptionCheckValue); err != nil {
+		s.cipher = nil
+		return err
+	}
+	log.Info("Encrypted validator database")
+	return nil
+}
+
+// encryptBucket encrypts the values of a bucket and of its nested buckets.
+func (s *Store) encryptBucket(b *bolt.Bucket) error {
+	var keys, values, nested [][]byte
+	if err := b.ForEach(func(k, v []byte) error {
+		if v == nil {
+			nested = append(nested, bytesutil.SafeCopyBytes(k))
+			return nil
+		}
+		keys = append(keys, bytesutil.SafeCopyBytes(k))
+		values = append(values, bytesutil.SafeCopyBytes(v))
+		return nil
+	}); err != nil {
+		return err
+	}
+	for i, k := range keys {
+		if err := s.put(b, k, values[i]); err != nil {
+			return err
+		}
+	}
+	for _, k := range nested {
+		if err := s.encryptBucket(b.Bucket(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newValueCipher(secret, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(secret, salt, encryptionScryptN, encryptionScryptR, encryptionScryptP, encryptionKeyLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not derive encryption key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// get returns the decrypted value of a key, or nil if the key does not exist.
+func (s *Store) get(bkt *bolt.Bucket, key []byte) ([]byte, error) {
+	return s.decode(bkt.Get(key))
+}
+
+// put encrypts a value and writes it to a key.
+func (s *Store) put(bkt *bolt.Bucket, key, value []byte) error {
+	enc, err := s.encode(value)
+	if err != nil {
+		return err
+	}
+	return bkt.Put(key, enc)
+}
+
+// encode seals a value if the database is encrypted, prefixing it with its random nonce.
+func (s *Store) encode(value []byte) ([]byte, error) {
+	if s.cipher == nil {
+		return value, nil
+	}
+	nonce := make([]byte, s.cipher.NonceSize(), s.cipher.NonceSize()+len(value)+s.cipher.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "could not generate nonce")
+	}
+	return s.cipher.Seal(nonce, nonce, value, nil /* additional data */), nil
+}
+
+// decode opens a value read from the database if it is encrypted. Iterated nested buckets, whose
+// value is nil, are returned as is.
+func (s *Store) decode(value []byte) ([]byte, error) {
+	if s.cipher == nil || value == nil {
+		return value, nil
+	}
+	nonceSize := s.cipher.NonceSize()
+	if len(value) < nonceSize {
+		return nil, errors.New("encrypted value is too short")
+	}
+	plaintext, err := s.cipher.Open(nil, value[:nonceSize], value[nonceSize:], nil /* additional data */)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt value")
+	}
+	if plaintext == nil {
+		plaintext = []byte{}
+	}
+	return plaintext, nil
+}
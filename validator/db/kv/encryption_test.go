@@ -0,0 +1,92 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStore_Encryption_MigratesPlaintext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	genesisValidatorsRoot := bytesutil.PadTo([]byte("genesis"), 32)
+	signingRoot := [32]byte{2}
+
+	db, err := NewKVStore(ctx, dir, &Config{PubKeys: [][fieldparams.BLSPubkeyLength]byte{pubKey}})
+	require.NoError(t, err)
+	require.NoError(t, db.SaveGenesisValidatorsRoot(ctx, genesisValidatorsRoot))
+	require.NoError(t, db.SaveAttestationForPubKey(ctx, pubKey, signingRoot, createAttestation(1, 2)))
+	require.NoError(t, db.SaveProposalHistoryForSlot(ctx, pubKey, 10, signingRoot[:]))
+	require.NoError(t, db.Close())
+
+	secret := []byte("password")
+	db, err = NewKVStore(ctx, dir, &Config{EncryptionSecret: secret})
+	require.NoError(t, err)
+
+	// Values are readable through the store, but not stored in plaintext.
+	root, err := db.GenesisValidatorsRoot(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, genesisValidatorsRoot, root)
+	require.NoError(t, db.view(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(genesisInfoBucket).Get(genesisValidatorsRootKey)
+		assert.NotEqual(t, string(genesisValidatorsRoot), string(raw))
+		return nil
+	}))
+	sr, err := db.SigningRootAtTargetEpoch(ctx, pubKey, 2)
+	require.NoError(t, err)
+	assert.Equal(t, signingRoot, sr)
+	proposalRoot, exists, err := db.ProposalHistoryForSlot(ctx, pubKey, 10)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, signingRoot, proposalRoot)
+	lowestTarget, exists, err := db.LowestSignedTargetEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, uint64(2), uint64(lowestTarget))
+
+	// Slashing protection keeps working on encrypted values.
+	slashingKind, err := db.CheckSlashableAttestation(ctx, pubKey, [32]byte{3}, createAttestation(1, 2))
+	require.ErrorContains(t, "double vote", err)
+	assert.Equal(t, DoubleVote, slashingKind)
+	require.NoError(t, db.SaveAttestationForPubKey(ctx, pubKey, [32]byte{4}, createAttestation(2, 3)))
+	_, err = db.CheckSlashableAttestation(ctx, pubKey, [32]byte{5}, createAttestation(0, 4))
+	require.ErrorContains(t, "surrounds", err)
+	require.NoError(t, db.Close())
+
+	db, err = NewKVStore(ctx, dir, &Config{EncryptionSecret: secret})
+	require.NoError(t, err)
+	history, err := db.AttestationHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(history))
+	require.NoError(t, db.Close())
+}
+
+func TestStore_Encryption_RequiresSecret(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+
+	db, err := NewKVStore(ctx, dir, &Config{EncryptionSecret: []byte("password")})
+	require.NoError(t, err)
+	require.NoError(t, db.SaveGenesisValidatorsRoot(ctx, bytesutil.PadTo([]byte("genesis"), 32)))
+	require.NoError(t, db.Close())
+
+	_, err = NewKVStore(ctx, dir, &Config{})
+	require.ErrorIs(t, err, errEncryptionSecretRequired)
+	_, err = NewKVStore(ctx, dir, &Config{EncryptionSecret: []byte("wrong")})
+	require.ErrorIs(t, err, errWrongEncryptionSecret)
+
+	db, err = NewKVStore(ctx, dir, &Config{EncryptionSecret: []byte("password")})
+	require.NoError(t, err)
+	root, err := db.GenesisValidatorsRoot(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte("genesis"), 32), root)
+	require.NoError(t, db.Close())
+}
@@ -11,11 +11,14 @@ import (
 func (s *Store) SaveGenesisValidatorsRoot(_ context.Context, genValRoot []byte) error {
 	err := s.db.Update(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(genesisInfoBucket)
-		enc := bkt.Get(genesisValidatorsRootKey)
+		enc, err := s.get(bkt, genesisValidatorsRootKey)
+		if err != nil {
+			return err
+		}
 		if len(enc) != 0 {
 			return fmt.Errorf("cannot overwite existing genesis validators root: %#x", enc)
 		}
-		return bkt.Put(genesisValidatorsRootKey, genValRoot)
+		return s.put(bkt, genesisValidatorsRootKey, genValRoot)
 	})
 	return err
 }
@@ -25,7 +28,10 @@ func (s *Store) GenesisValidatorsRoot(_ context.Context) ([]byte, error) {
 	var genValRoot []byte
 	err := s.db.View(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(genesisInfoBucket)
-		enc := bkt.Get(genesisValidatorsRootKey)
+		enc, err := s.get(bkt, genesisValidatorsRootKey)
+		if err != nil {
+			return err
+		}
 		if len(enc) == 0 {
 			return nil
 		}
@@ -13,7 +13,7 @@ func (s *Store) SaveGraffitiOrderedIndex(_ context.Context, index uint64) error
 	return s.db.Update(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(graffitiBucket)
 		indexBytes := bytesutil.Uint64ToBytesBigEndian(index)
-		return bkt.Put(graffitiOrderedIndexKey, indexBytes)
+		return s.put(bkt, graffitiOrderedIndexKey, indexBytes)
 	})
 }
 
@@ -22,16 +22,22 @@ func (s *Store) GraffitiOrderedIndex(_ context.Context, fileHash [32]byte) (uint
 	orderedIndex := uint64(0)
 	err := s.db.Update(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(graffitiBucket)
-		dbFileHash := bkt.Get(graffitiFileHashKey)
+		dbFileHash, err := s.get(bkt, graffitiFileHashKey)
+		if err != nil {
+			return err
+		}
 		if bytes.Equal(dbFileHash, fileHash[:]) {
-			indexBytes := bkt.Get(graffitiOrderedIndexKey)
+			indexBytes, err := s.get(bkt, graffitiOrderedIndexKey)
+			if err != nil {
+				return err
+			}
 			orderedIndex = bytesutil.BytesToUint64BigEndian(indexBytes)
 		} else {
 			indexBytes := bytesutil.Uint64ToBytesBigEndian(0)
-			if err := bkt.Put(graffitiOrderedIndexKey, indexBytes); err != nil {
+			if err := s.put(bkt, graffitiOrderedIndexKey, indexBytes); err != nil {
 				return err
 			}
-			return bkt.Put(graffitiFileHashKey, fileHash[:])
+			return s.put(bkt, graffitiFileHashKey, fileHash[:])
 		}
 		return nil
 	})
@@ -1,6 +1,7 @@
 package kv
 
 import (
+	"bytes"
 	"context"
 
 	bolt "go.etcd.io/bbolt"
@@ -57,3 +58,22 @@ func (s *Store) RunDownMigrations(ctx context.Context) error {
 	}
 	return nil
 }
+
+// CompletedMigrations returns the keys of the migrations applied to the database,
+// which describe the version of its schema.
+func (s *Store) CompletedMigrations(_ context.Context) ([]string, error) {
+	completed := make([]string, 0)
+	err := s.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(migrationsBucket).ForEach(func(k, enc []byte) error {
+			v, err := s.decode(enc)
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(v, migrationCompleted) {
+				completed = append(completed, string(k))
+			}
+			return nil
+		})
+	})
+	return completed, err
+}
@@ -24,16 +24,24 @@ func (s *Store) migrateOptimalAttesterProtectionUp(_ context.Context) error {
 	numKeys := 0
 	err := s.db.Update(func(tx *bolt.Tx) error {
 		mb := tx.Bucket(migrationsBucket)
-		if b := mb.Get(migrationOptimalAttesterProtectionKey); bytes.Equal(b, migrationCompleted) {
+		b, err := s.get(mb, migrationOptimalAttesterProtectionKey)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(b, migrationCompleted) {
 			return nil // Migration already completed.
 		}
 
 		bkt := tx.Bucket(deprecatedAttestationHistoryBucket)
 		numKeys = bkt.Stats().KeyN
-		return bkt.ForEach(func(k, v []byte) error {
-			if v == nil {
+		return bkt.ForEach(func(k, enc []byte) error {
+			if enc == nil {
 				return nil
 			}
+			v, err := s.decode(enc)
+			if err != nil {
+				return err
+			}
 			bucket := tx.Bucket(pubKeysBucket)
 			pkBucket, err := bucket.CreateBucketIfNotExists(k)
 			if err != nil {
@@ -92,10 +100,10 @@ func (s *Store) migrateOptimalAttesterProtectionUp(_ context.Context) error {
 				}
 				targetEpochBytes := bytesutil.EpochToBytesBigEndian(targetEpoch)
 				sourceEpochBytes := bytesutil.EpochToBytesBigEndian(historicalAtt.Source)
-				if err := sourceEpochsBucket.Put(sourceEpochBytes, targetEpochBytes); err != nil {
+				if err := s.put(sourceEpochsBucket, sourceEpochBytes, targetEpochBytes); err != nil {
 					return err
 				}
-				if err := signingRootsBucket.Put(targetEpochBytes, historicalAtt.SigningRoot); err != nil {
+				if err := s.put(signingRootsBucket, targetEpochBytes, historicalAtt.SigningRoot); err != nil {
 					return err
 				}
 			}
@@ -108,7 +116,7 @@ func (s *Store) migrateOptimalAttesterProtectionUp(_ context.Context) error {
 
 	return s.db.Update(func(tx *bolt.Tx) error {
 		mb := tx.Bucket(migrationsBucket)
-		return mb.Put(migrationOptimalAttesterProtectionKey, migrationCompleted)
+		return s.put(mb, migrationOptimalAttesterProtectionKey, migrationCompleted)
 	})
 }
 
@@ -137,7 +145,11 @@ func (s *Store) migrateOptimalAttesterProtectionDown(_ context.Context) error {
 			sourceEpochsBucket := pubKeyBkt.Bucket(attestationSourceEpochsBucket)
 			signingRootsBucket := pubKeyBkt.Bucket(attestationSigningRootsBucket)
 			// Extract signing roots.
-			if err := signingRootsBucket.ForEach(func(targetBytes, signingRoot []byte) error {
+			if err := signingRootsBucket.ForEach(func(targetBytes, enc []byte) error {
+				signingRoot, err := s.decode(enc)
+				if err != nil {
+					return err
+				}
 				var sr [32]byte
 				copy(sr[:], signingRoot)
 				signingRootsByTarget[bytesutil.BytesToEpochBigEndian(targetBytes)] = sr[:]
@@ -146,7 +158,11 @@ func (s *Store) migrateOptimalAttesterProtectionDown(_ context.Context) error {
 				return err
 			}
 			// Next up, extract the target epochs by source.
-			if err := sourceEpochsBucket.ForEach(func(sourceBytes, targetEpochsBytes []byte) error {
+			if err := sourceEpochsBucket.ForEach(func(sourceBytes, enc []byte) error {
+				targetEpochsBytes, err := s.decode(enc)
+				if err != nil {
+					return err
+				}
 				targetEpochs := make([]types.Epoch, 0)
 				for i := 0; i < len(targetEpochsBytes); i += 8 {
 					targetEpochs = append(targetEpochs, bytesutil.BytesToEpochBigEndian(targetEpochsBytes[i:i+8]))
@@ -205,7 +221,7 @@ func (s *Store) migrateOptimalAttesterProtectionDown(_ context.Context) error {
 			if err != nil {
 				return err
 			}
-			if err := deprecatedBkt.Put(pubKey[:], history); err != nil {
+			if err := s.put(deprecatedBkt, pubKey[:], history); err != nil {
 				return err
 			}
 			if err := bar.Add(1); err != nil {
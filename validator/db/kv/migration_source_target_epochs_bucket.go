@@ -21,7 +21,11 @@ func (s *Store) migrateSourceTargetEpochsBucketUp(_ context.Context) error {
 	publicKeyBytes := make([][]byte, 0)
 	err := s.db.View(func(tx *bolt.Tx) error {
 		mb := tx.Bucket(migrationsBucket)
-		if b := mb.Get(migrationSourceTargetEpochsBucketKey); bytes.Equal(b, migrationCompleted) {
+		b, err := s.get(mb, migrationSourceTargetEpochsBucketKey)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(b, migrationCompleted) {
 			return nil // Migration already completed.
 		}
 		bkt := tx.Bucket(pubKeysBucket)
@@ -62,9 +66,13 @@ func (s *Store) migrateSourceTargetEpochsBucketUp(_ context.Context) error {
 				if err != nil {
 					return err
 				}
-				err = sourceBucket.ForEach(func(sourceEpochBytes, targetEpochsBytes []byte) error {
+				err = sourceBucket.ForEach(func(sourceEpochBytes, enc []byte) error {
+					targetEpochsBytes, err := s.decode(enc)
+					if err != nil {
+						return err
+					}
 					for i := 0; i < len(targetEpochsBytes); i += 8 {
-						if err := insertTargetSource(
+						if err := s.insertTargetSource(
 							targetBucket,
 							targetEpochsBytes[i:i+8],
 							sourceEpochBytes,
@@ -91,7 +99,7 @@ func (s *Store) migrateSourceTargetEpochsBucketUp(_ context.Context) error {
 	// Finally we mark the migration as completed.
 	return s.db.Update(func(tx *bolt.Tx) error {
 		mb := tx.Bucket(migrationsBucket)
-		return mb.Put(migrationSourceTargetEpochsBucketKey, migrationCompleted)
+		return s.put(mb, migrationSourceTargetEpochsBucketKey, migrationCompleted)
 	})
 }
 
@@ -116,14 +124,18 @@ func (s *Store) migrateSourceTargetEpochsBucketDown(_ context.Context) error {
 	})
 }
 
-func insertTargetSource(bkt *bolt.Bucket, targetEpochBytes, sourceEpochBytes []byte) error {
+func (s *Store) insertTargetSource(bkt *bolt.Bucket, targetEpochBytes, sourceEpochBytes []byte) error {
+	existing, err := s.get(bkt, targetEpochBytes)
+	if err != nil {
+		return err
+	}
 	var existingAttestedSourceBytes []byte
-	if existing := bkt.Get(targetEpochBytes); existing != nil {
+	if existing != nil {
 		existingAttestedSourceBytes = append(existing, sourceEpochBytes...)
 	} else {
 		existingAttestedSourceBytes = sourceEpochBytes
 	}
-	return bkt.Put(targetEpochBytes, existingAttestedSourceBytes)
+	return s.put(bkt, targetEpochBytes, existingAttestedSourceBytes)
 }
 
 func batchPublicKeys(publicKeys [][]byte, batchSize int) [][][]byte {
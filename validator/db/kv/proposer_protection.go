@@ -59,7 +59,10 @@ func (s *Store) ProposalHistoryForSlot(ctx context.Context, publicKey [fieldpara
 		if valBucket == nil {
 			return nil
 		}
-		signingRootBytes := valBucket.Get(bytesutil.SlotToBytesBigEndian(slot))
+		signingRootBytes, err := s.get(valBucket, bytesutil.SlotToBytesBigEndian(slot))
+		if err != nil {
+			return err
+		}
 		if signingRootBytes == nil {
 			return nil
 		}
@@ -82,7 +85,11 @@ func (s *Store) ProposalHistoryForPubKey(ctx context.Context, publicKey [fieldpa
 		if valBucket == nil {
 			return nil
 		}
-		return valBucket.ForEach(func(slotKey, signingRootBytes []byte) error {
+		return valBucket.ForEach(func(slotKey, enc []byte) error {
+			signingRootBytes, err := s.decode(enc)
+			if err != nil {
+				return err
+			}
 			slot := bytesutil.BytesToSlotBigEndian(slotKey)
 			sr := make([]byte, fieldparams.RootLength)
 			copy(sr, signingRootBytes)
@@ -112,31 +119,37 @@ func (s *Store) SaveProposalHistoryForSlot(ctx context.Context, pubKey [fieldpar
 
 		// If the incoming slot is lower than the lowest signed proposal slot, override.
 		lowestSignedBkt := tx.Bucket(lowestSignedProposalsBucket)
-		lowestSignedProposalBytes := lowestSignedBkt.Get(pubKey[:])
+		lowestSignedProposalBytes, err := s.get(lowestSignedBkt, pubKey[:])
+		if err != nil {
+			return err
+		}
 		var lowestSignedProposalSlot types.Slot
 		if len(lowestSignedProposalBytes) >= 8 {
 			lowestSignedProposalSlot = bytesutil.BytesToSlotBigEndian(lowestSignedProposalBytes)
 		}
 		if len(lowestSignedProposalBytes) == 0 || slot < lowestSignedProposalSlot {
-			if err := lowestSignedBkt.Put(pubKey[:], bytesutil.SlotToBytesBigEndian(slot)); err != nil {
+			if err := s.put(lowestSignedBkt, pubKey[:], bytesutil.SlotToBytesBigEndian(slot)); err != nil {
 				return err
 			}
 		}
 
 		// If the incoming slot is higher than the highest signed proposal slot, override.
 		highestSignedBkt := tx.Bucket(highestSignedProposalsBucket)
-		highestSignedProposalBytes := highestSignedBkt.Get(pubKey[:])
+		highestSignedProposalBytes, err := s.get(highestSignedBkt, pubKey[:])
+		if err != nil {
+			return err
+		}
 		var highestSignedProposalSlot types.Slot
 		if len(highestSignedProposalBytes) >= 8 {
 			highestSignedProposalSlot = bytesutil.BytesToSlotBigEndian(highestSignedProposalBytes)
 		}
 		if len(highestSignedProposalBytes) == 0 || slot > highestSignedProposalSlot {
-			if err := highestSignedBkt.Put(pubKey[:], bytesutil.SlotToBytesBigEndian(slot)); err != nil {
+			if err := s.put(highestSignedBkt, pubKey[:], bytesutil.SlotToBytesBigEndian(slot)); err != nil {
 				return err
 			}
 		}
 
-		if err := valBucket.Put(bytesutil.SlotToBytesBigEndian(slot), signingRoot); err != nil {
+		if err := s.put(valBucket, bytesutil.SlotToBytesBigEndian(slot), signingRoot); err != nil {
 			return err
 		}
 		return pruneProposalHistoryBySlot(valBucket, slot)
@@ -155,7 +168,10 @@ func (s *Store) LowestSignedProposal(ctx context.Context, publicKey [fieldparams
 	var exists bool
 	err = s.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(lowestSignedProposalsBucket)
-		lowestSignedProposalBytes := bucket.Get(publicKey[:])
+		lowestSignedProposalBytes, err := s.get(bucket, publicKey[:])
+		if err != nil {
+			return err
+		}
 		// 8 because bytesutil.BytesToUint64BigEndian will return 0 if input is less than 8 bytes.
 		if len(lowestSignedProposalBytes) < 8 {
 			return nil
@@ -178,7 +194,10 @@ func (s *Store) HighestSignedProposal(ctx context.Context, publicKey [fieldparam
 	var exists bool
 	err = s.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(highestSignedProposalsBucket)
-		highestSignedProposalBytes := bucket.Get(publicKey[:])
+		highestSignedProposalBytes, err := s.get(bucket, publicKey[:])
+		if err != nil {
+			return err
+		}
 		// 8 because bytesutil.BytesToUint64BigEndian will return 0 if input is less than 8 bytes.
 		if len(highestSignedProposalBytes) < 8 {
 			return nil
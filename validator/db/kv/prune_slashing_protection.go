@@ -0,0 +1,325 @@
+package kv
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// Time interval after which the slashing protection history is pruned again in the background.
+var slashingProtectionPruningInterval = 24 * time.Hour
+
+// PruneSlashingProtectionHistory removes, for every validator, the attestations and proposals older than
+// the weak subjectivity period counted back from the most recent one of the validator. Their lowest signed
+// source, target and proposal are raised to the highest pruned ones, so that anything which could be
+// slashable with the removed records is refused. Every validator is pruned in its own transaction, so that
+// the slashing protection of the others is not held up meanwhile. It returns the number of pruned
+// attestations and proposals.
+func (s *Store) PruneSlashingProtectionHistory(ctx context.Context) (int, int, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.PruneSlashingProtectionHistory")
+	defer span.End()
+
+	var attPubKeys, proposalPubKeys [][]byte
+	if err := s.view(func(tx *bolt.Tx) error {
+		attPubKeys = bucketKeys(tx.Bucket(pubKeysBucket))
+		proposalPubKeys = bucketKeys(tx.Bucket(historicProposalsBucket))
+		return nil
+	}); err != nil {
+		return 0, 0, err
+	}
+
+	var prunedAtts, prunedProposals int
+	for _, pubKey := range attPubKeys {
+		if ctx.Err() != nil {
+			return prunedAtts, prunedProposals, ctx.Err()
+		}
+		var n int
+		if err := s.update(func(tx *bolt.Tx) error {
+			pkBucket := tx.Bucket(pubKeysBucket).Bucket(pubKey)
+			if pkBucket == nil {
+				return nil
+			}
+			var err error
+			n, err = s.pruneAttestationHistory(tx, pubKey, pkBucket)
+			return err
+		}); err != nil {
+			return prunedAtts, prunedProposals, err
+		}
+		prunedAtts += n
+	}
+	for _, pubKey := range proposalPubKeys {
+		if ctx.Err() != nil {
+			return prunedAtts, prunedProposals, ctx.Err()
+		}
+		var n int
+		if err := s.update(func(tx *bolt.Tx) error {
+			valBucket := tx.Bucket(historicProposalsBucket).Bucket(pubKey)
+			if valBucket == nil {
+				return nil
+			}
+			var err error
+			n, err = s.pruneProposalHistory(tx, pubKey, valBucket)
+			return err
+		}); err != nil {
+			return prunedAtts, prunedProposals, err
+		}
+		prunedProposals += n
+	}
+	return prunedAtts, prunedProposals, nil
+}
+
+// pruneAttestationHistory removes the attestations of a validator with a target epoch older than the
+// weak subjectivity period, counted back from its highest target epoch.
+func (s *Store) pruneAttestationHistory(tx *bolt.Tx, pubKey []byte, pkBucket *bolt.Bucket) (int, error) {
+	signingRootsBucket := pkBucket.Bucket(attestationSigningRootsBucket)
+	if signingRootsBucket == nil {
+		return 0, nil
+	}
+	highestTargetBytes, _ := signingRootsBucket.Cursor().Last()
+	if highestTargetBytes == nil {
+		return 0, nil
+	}
+	cutoff := weakSubjectivityCutoff(bytesutil.BytesToEpochBigEndian(highestTargetBytes))
+	if cutoff == 0 {
+		return 0, nil
+	}
+
+	var pruned int
+	var highestPrunedSource, highestPrunedTarget types.Epoch
+	c := signingRootsBucket.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.First() {
+		target := bytesutil.BytesToEpochBigEndian(k)
+		if target >= cutoff {
+			break
+		}
+		if err := c.Delete(); err != nil {
+			return 0, errors.Wrapf(err, "could not prune signing root at target epoch %d", target)
+		}
+		highestPrunedTarget = target
+		pruned++
+	}
+
+	// Sources are keyed by target epoch, and targets by source epoch: only the targets older than the
+	// cutoff are removed from the latter since a source older than the cutoff may have a recent target.
+	if targetEpochsBucket := pkBucket.Bucket(attestationTargetEpochsBucket); targetEpochsBucket != nil {
+		c := targetEpochsBucket.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.First() {
+			target := bytesutil.BytesToEpochBigEndian(k)
+			if target >= cutoff {
+				break
+			}
+			sources, err := s.get(targetEpochsBucket, k)
+			if err != nil {
+				return 0, err
+			}
+			for i := 0; i+8 <= len(sources); i += 8 {
+				if source := bytesutil.BytesToEpochBigEndian(sources[i : i+8]); source > highestPrunedSource {
+					highestPrunedSource = source
+				}
+			}
+			if err := c.Delete(); err != nil {
+				return 0, errors.Wrapf(err, "could not prune sources at target epoch %d", target)
+			}
+			if target > highestPrunedTarget {
+				highestPrunedTarget = target
+			}
+		}
+	}
+	if sourceEpochsBucket := pkBucket.Bucket(attestationSourceEpochsBucket); sourceEpochsBucket != nil {
+		var emptied [][]byte
+		updated := make(map[string][]byte)
+		c := sourceEpochsBucket.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			source := bytesutil.BytesToEpochBigEndian(k)
+			if source >= cutoff {
+				break
+			}
+			targets, err := s.get(sourceEpochsBucket, k)
+			if err != nil {
+				return 0, err
+			}
+			kept := make([]byte, 0, len(targets))
+			for i := 0; i+8 <= len(targets); i += 8 {
+				if bytesutil.BytesToEpochBigEndian(targets[i:i+8]) >= cutoff {
+					kept = append(kept, targets[i:i+8]...)
+				}
+			}
+			if len(kept) == len(targets) {
+				continue
+			}
+			if source > highestPrunedSource {
+				highestPrunedSource = source
+			}
+			key := make([]byte, len(k))
+			copy(key, k)
+			if len(kept) == 0 {
+				emptied = append(emptied, key)
+			} else {
+				updated[string(key)] = kept
+			}
+		}
+		// Keys are not modified while iterating with the cursor, as bolt does not support it.
+		for _, k := range emptied {
+			if err := sourceEpochsBucket.Delete(k); err != nil {
+				return 0, err
+			}
+		}
+		for k, targets := range updated {
+			if err := s.put(sourceEpochsBucket, []byte(k), targets); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+	if err := s.raiseLowestEpoch(tx.Bucket(lowestSignedSourceBucket), pubKey, highestPrunedSource); err != nil {
+		return 0, errors.Wrap(err, "could not update lowest signed source epoch")
+	}
+	if err := s.raiseLowestEpoch(tx.Bucket(lowestSignedTargetBucket), pubKey, highestPrunedTarget); err != nil {
+		return 0, errors.Wrap(err, "could not update lowest signed target epoch")
+	}
+	return pruned, nil
+}
+
+// pruneProposalHistory removes the proposals of a validator with an epoch older than the weak
+// subjectivity period, counted back from its highest proposal.
+func (s *Store) pruneProposalHistory(tx *bolt.Tx, pubKey []byte, valBucket *bolt.Bucket) (int, error) {
+	highestSlotBytes, _ := valBucket.Cursor().Last()
+	if highestSlotBytes == nil {
+		return 0, nil
+	}
+	cutoff := weakSubjectivityCutoff(slots.ToEpoch(bytesutil.BytesToSlotBigEndian(highestSlotBytes)))
+	if cutoff == 0 {
+		return 0, nil
+	}
+
+	var pruned int
+	var highestPrunedSlot types.Slot
+	c := valBucket.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.First() {
+		slot := bytesutil.BytesToSlotBigEndian(k)
+		if slots.ToEpoch(slot) >= cutoff {
+			break
+		}
+		if err := c.Delete(); err != nil {
+			return 0, errors.Wrapf(err, "could not prune proposal at slot %d", slot)
+		}
+		highestPrunedSlot = slot
+		pruned++
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+	lowestBucket := tx.Bucket(lowestSignedProposalsBucket)
+	lowestBytes, err := s.get(lowestBucket, pubKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(lowestBytes) >= 8 && bytesutil.BytesToSlotBigEndian(lowestBytes) >= highestPrunedSlot {
+		return pruned, nil
+	}
+	if err := s.put(lowestBucket, pubKey, bytesutil.SlotToBytesBigEndian(highestPrunedSlot)); err != nil {
+		return 0, errors.Wrap(err, "could not update lowest signed proposal")
+	}
+	return pruned, nil
+}
+
+// bucketKeys copies the keys of a bucket, so that its nested buckets can be modified once iterated.
+func bucketKeys(bkt *bolt.Bucket) [][]byte {
+	var keys [][]byte
+	c := bkt.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		key := make([]byte, len(k))
+		copy(key, k)
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// raiseLowestEpoch sets the lowest signed epoch of a validator to the given epoch if it is higher.
+func (s *Store) raiseLowestEpoch(bkt *bolt.Bucket, pubKey []byte, epoch types.Epoch) error {
+	if bkt == nil {
+		return nil
+	}
+	lowestBytes, err := s.get(bkt, pubKey)
+	if err != nil {
+		return err
+	}
+	if len(lowestBytes) >= 8 && bytesutil.BytesToEpochBigEndian(lowestBytes) >= epoch {
+		return nil
+	}
+	return s.put(bkt, pubKey, bytesutil.EpochToBytesBigEndian(epoch))
+}
+
+// weakSubjectivityCutoff returns the epoch before which records are older than the weak subjectivity
+// period counted back from the given epoch, zero if there are none.
+func weakSubjectivityCutoff(epoch types.Epoch) types.Epoch {
+	if epoch <= params.BeaconConfig().WeakSubjectivityPeriod {
+		return 0
+	}
+	return epoch - params.BeaconConfig().WeakSubjectivityPeriod
+}
+
+// StartSlashingProtectionPruning prunes the slashing protection history at regular intervals in the
+// background, so that the records of long running validators do not slow down the slashing protection
+// lookups. The pruning stops when the context is cancelled or the store is closed.
+func (s *Store) StartSlashingProtectionPruning(ctx context.Context) {
+	s.pruningLock.Lock()
+	defer s.pruningLock.Unlock()
+	if s.stopPruning != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	s.stopPruning = func() {
+		cancel()
+		<-done
+	}
+	go func() {
+		defer close(done)
+		s.pruneSlashingProtectionHistoryPeriodically(ctx)
+	}()
+}
+
+// stopSlashingProtectionPruning stops the background pruning, if any, and waits for it to return.
+func (s *Store) stopSlashingProtectionPruning() {
+	s.pruningLock.Lock()
+	defer s.pruningLock.Unlock()
+	if s.stopPruning == nil {
+		return
+	}
+	s.stopPruning()
+	s.stopPruning = nil
+}
+
+func (s *Store) pruneSlashingProtectionHistoryPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(slashingProtectionPruningInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			prunedAtts, prunedProposals, err := s.PruneSlashingProtectionHistory(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.WithError(err).Error("Could not prune slashing protection history")
+				}
+				continue
+			}
+			log.WithFields(logrus.Fields{
+				"prunedAttestations": prunedAtts,
+				"prunedProposals":    prunedProposals,
+			}).Debug("Pruned slashing protection history")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
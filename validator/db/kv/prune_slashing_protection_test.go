@@ -0,0 +1,133 @@
+package kv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestPruneSlashingProtectionHistory_Attestations(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.WeakSubjectivityPeriod = 10
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	validatorDB := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+
+	// Attest to every (source, source + 1) pair up to target 30, and from source 2 to target 29 as well.
+	var records []*AttestationRecord
+	for source := types.Epoch(0); source < 30; source++ {
+		records = append(records, &AttestationRecord{PubKey: pubKey, Source: source, Target: source + 1, SigningRoot: [32]byte{byte(source + 1)}})
+	}
+	records = append(records, &AttestationRecord{PubKey: pubKey, Source: 2, Target: 29, SigningRoot: [32]byte{29}})
+	require.NoError(t, validatorDB.saveAttestationRecords(ctx, records))
+
+	prunedAtts, prunedProposals, err := validatorDB.PruneSlashingProtectionHistory(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 19, prunedAtts)
+	assert.Equal(t, 0, prunedProposals)
+
+	// Targets older than 30 - 10 are pruned, and the lowest signed epochs refuse anything which could be
+	// slashable with them.
+	for target := types.Epoch(1); target <= 30; target++ {
+		root, err := validatorDB.SigningRootAtTargetEpoch(ctx, pubKey, target)
+		require.NoError(t, err)
+		assert.Equal(t, target >= 20, root != [32]byte{}, "target %d", target)
+	}
+	lowestSource, exists, err := validatorDB.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, true, exists)
+	assert.Equal(t, types.Epoch(18), lowestSource)
+	lowestTarget, exists, err := validatorDB.LowestSignedTargetEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, true, exists)
+	assert.Equal(t, types.Epoch(19), lowestTarget)
+
+	// The recent target of an old source is kept.
+	require.NoError(t, validatorDB.view(func(tx *bolt.Tx) error {
+		sourceEpochs := tx.Bucket(pubKeysBucket).Bucket(pubKey[:]).Bucket(attestationSourceEpochsBucket)
+		targets, err := validatorDB.get(sourceEpochs, bytesutil.EpochToBytesBigEndian(2))
+		require.NoError(t, err)
+		assert.DeepEqual(t, bytesutil.EpochToBytesBigEndian(29), targets)
+		for source := types.Epoch(0); source < 30; source++ {
+			if source == 2 {
+				continue
+			}
+			assert.Equal(t, source >= 19, sourceEpochs.Get(bytesutil.EpochToBytesBigEndian(source)) != nil, "source %d", source)
+		}
+		return nil
+	}))
+
+	// Nothing is left to prune.
+	prunedAtts, _, err = validatorDB.PruneSlashingProtectionHistory(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, prunedAtts)
+}
+
+func TestPruneSlashingProtectionHistory_Proposals(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.WeakSubjectivityPeriod = 10
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	validatorDB := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+
+	// Proposals are written directly, as saving them through the database already prunes the old ones.
+	epochs := []types.Epoch{0, 5, 9, 15, 20}
+	require.NoError(t, validatorDB.update(func(tx *bolt.Tx) error {
+		valBucket := tx.Bucket(historicProposalsBucket).Bucket(pubKey[:])
+		for _, epoch := range epochs {
+			slot := types.Slot(uint64(epoch) * uint64(params.BeaconConfig().SlotsPerEpoch))
+			if err := validatorDB.put(valBucket, bytesutil.SlotToBytesBigEndian(slot), []byte{byte(epoch)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	prunedAtts, prunedProposals, err := validatorDB.PruneSlashingProtectionHistory(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, prunedAtts)
+	assert.Equal(t, 3, prunedProposals)
+
+	proposals, err := validatorDB.ProposalHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(proposals))
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	assert.Equal(t, 15*slotsPerEpoch, proposals[0].Slot)
+	assert.Equal(t, 20*slotsPerEpoch, proposals[1].Slot)
+	lowest, exists, err := validatorDB.LowestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, true, exists)
+	assert.Equal(t, 9*slotsPerEpoch, lowest)
+}
+
+func TestStore_StartSlashingProtectionPruning(t *testing.T) {
+	interval := slashingProtectionPruningInterval
+	slashingProtectionPruningInterval = time.Millisecond
+	defer func() {
+		slashingProtectionPruningInterval = interval
+	}()
+
+	validatorDB, err := NewKVStore(context.Background(), t.TempDir(), &Config{})
+	require.NoError(t, err)
+	validatorDB.StartSlashingProtectionPruning(context.Background())
+	require.Equal(t, true, validatorDB.stopPruning != nil)
+
+	// Closing the store stops the pruning before the database is closed.
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, validatorDB.Close())
+	assert.Equal(t, true, validatorDB.stopPruning == nil)
+}
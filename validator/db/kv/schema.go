@@ -37,4 +37,9 @@ var (
 	// Graffiti ordered index and hash keys
 	graffitiOrderedIndexKey = []byte("graffiti-ordered-index")
 	graffitiFileHashKey     = []byte("graffiti-file-hash")
+
+	// Encryption of the values at rest, and its salt and check value keys.
+	encryptionBucket   = []byte("encryption")
+	encryptionSaltKey  = []byte("salt")
+	encryptionCheckKey = []byte("check")
 )
@@ -21,7 +21,11 @@ func MigrateUp(cliCtx *cli.Context) error {
 
 	ctx := context.Background()
 	log.Info("Opening DB")
-	validatorDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{})
+	encryptionSecret, err := EncryptionSecret(cliCtx, "" /* read from --wallet-password-file */)
+	if err != nil {
+		return errors.Wrap(err, "could not get database encryption secret")
+	}
+	validatorDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{EncryptionSecret: encryptionSecret})
 	if err != nil {
 		return err
 	}
@@ -39,7 +43,11 @@ func MigrateDown(cliCtx *cli.Context) error {
 
 	ctx := context.Background()
 	log.Info("Opening DB")
-	validatorDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{})
+	encryptionSecret, err := EncryptionSecret(cliCtx, "" /* read from --wallet-password-file */)
+	if err != nil {
+		return errors.Wrap(err, "could not get database encryption secret")
+	}
+	validatorDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{EncryptionSecret: encryptionSecret})
 	if err != nil {
 		return err
 	}
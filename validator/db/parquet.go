@@ -0,0 +1,212 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// The parquet files written here hold a single row group of required columns, each column chunk
+// being a single uncompressed and plain encoded data page. This is enough for the exported signing
+// history, which is read by analysis tools, and saves a dependency on a full parquet library.
+// See https://github.com/apache/parquet-format for the file layout.
+
+var parquetMagic = []byte("PAR1")
+
+// Parquet physical types, converted types, encodings and page types.
+const (
+	parquetInt64            = 2
+	parquetByteArray        = 6
+	parquetUTF8             = 0
+	parquetUint64           = 14
+	parquetRequired         = 0
+	parquetPlainEncoding    = 0
+	parquetRLEEncoding      = 3
+	parquetDataPage         = 0
+	parquetUncompressed     = 0
+	parquetFormatVersion    = 1
+	parquetCreatedBy        = "prysm"
+	parquetLengthPrefixSize = 4
+)
+
+// Thrift compact protocol field types.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes thrift structs with the compact protocol, which parquet uses for its metadata.
+type thriftWriter struct {
+	buf    bytes.Buffer
+	fields []int16 // last field id written, for every struct being written
+}
+
+func (w *thriftWriter) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	w.buf.Write(b[:n])
+}
+
+func (w *thriftWriter) zigzag(v int64) {
+	w.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (w *thriftWriter) field(id int16, typ byte) {
+	last := w.fields[len(w.fields)-1]
+	if delta := id - last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.zigzag(int64(id))
+	}
+	w.fields[len(w.fields)-1] = id
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.zigzag(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.zigzag(v)
+}
+
+func (w *thriftWriter) binary(id int16, b []byte) {
+	w.field(id, thriftBinary)
+	w.varint(uint64(len(b)))
+	w.buf.Write(b)
+}
+
+func (w *thriftWriter) list(id int16, elemType byte, size int) {
+	w.field(id, thriftList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		w.buf.WriteByte(0xF0 | elemType)
+		w.varint(uint64(size))
+	}
+}
+
+// beginStruct starts a struct, either as the field of the current struct or, given a zero field
+// id, as the top level struct or an element of a list.
+func (w *thriftWriter) beginStruct(id int16) {
+	if id != 0 {
+		w.field(id, thriftStruct)
+	}
+	w.fields = append(w.fields, 0)
+}
+
+func (w *thriftWriter) endStruct() {
+	w.buf.WriteByte(0)
+	w.fields = w.fields[:len(w.fields)-1]
+}
+
+// parquetColumnChunk is the location of a column chunk written in the file.
+type parquetColumnChunk struct {
+	offset int64
+	size   int64
+}
+
+// writeParquet writes the given columns, all holding the given number of rows, as a parquet file.
+func writeParquet(out io.Writer, columns []*historyColumn, rows int) error {
+	var file bytes.Buffer
+	file.Write(parquetMagic)
+
+	chunks := make([]parquetColumnChunk, len(columns))
+	for i, c := range columns {
+		var page bytes.Buffer
+		for r := 0; r < rows; r++ {
+			if c.numeric {
+				if err := binary.Write(&page, binary.LittleEndian, c.numbers[r]); err != nil {
+					return err
+				}
+				continue
+			}
+			var length [parquetLengthPrefixSize]byte
+			binary.LittleEndian.PutUint32(length[:], uint32(len(c.text[r])))
+			page.Write(length[:])
+			page.WriteString(c.text[r])
+		}
+
+		header := &thriftWriter{}
+		header.beginStruct(0)
+		header.i32(1, parquetDataPage)
+		header.i32(2, int32(page.Len()))
+		header.i32(3, int32(page.Len()))
+		header.beginStruct(5)
+		header.i32(1, int32(rows))
+		header.i32(2, parquetPlainEncoding)
+		header.i32(3, parquetRLEEncoding)
+		header.i32(4, parquetRLEEncoding)
+		header.endStruct()
+		header.endStruct()
+
+		chunks[i] = parquetColumnChunk{
+			offset: int64(file.Len()),
+			size:   int64(header.buf.Len() + page.Len()),
+		}
+		file.Write(header.buf.Bytes())
+		file.Write(page.Bytes())
+	}
+
+	var totalSize int64
+	for _, c := range chunks {
+		totalSize += c.size
+	}
+	meta := &thriftWriter{}
+	meta.beginStruct(0)
+	meta.i32(1, parquetFormatVersion)
+	meta.list(2, thriftStruct, len(columns)+1)
+	meta.beginStruct(0)
+	meta.binary(4, []byte("schema"))
+	meta.i32(5, int32(len(columns)))
+	meta.endStruct()
+	for _, c := range columns {
+		meta.beginStruct(0)
+		meta.i32(1, c.parquetType())
+		meta.i32(3, parquetRequired)
+		meta.binary(4, []byte(c.name))
+		meta.i32(6, c.parquetConvertedType())
+		meta.endStruct()
+	}
+	meta.i64(3, int64(rows))
+	meta.list(4, thriftStruct, 1)
+	meta.beginStruct(0)
+	meta.list(1, thriftStruct, len(columns))
+	for i, c := range columns {
+		meta.beginStruct(0)
+		meta.i64(2, chunks[i].offset)
+		meta.beginStruct(3)
+		meta.i32(1, c.parquetType())
+		meta.list(2, thriftI32, 2)
+		meta.zigzag(parquetPlainEncoding)
+		meta.zigzag(parquetRLEEncoding)
+		meta.list(3, thriftBinary, 1)
+		meta.varint(uint64(len(c.name)))
+		meta.buf.WriteString(c.name)
+		meta.i32(4, parquetUncompressed)
+		meta.i64(5, int64(rows))
+		meta.i64(6, chunks[i].size)
+		meta.i64(7, chunks[i].size)
+		meta.i64(9, chunks[i].offset)
+		meta.endStruct()
+		meta.endStruct()
+	}
+	meta.i64(2, totalSize)
+	meta.i64(3, int64(rows))
+	meta.endStruct()
+	meta.binary(6, []byte(parquetCreatedBy))
+	meta.endStruct()
+
+	file.Write(meta.buf.Bytes())
+	var metaLength [parquetLengthPrefixSize]byte
+	binary.LittleEndian.PutUint32(metaLength[:], uint32(meta.buf.Len()))
+	file.Write(metaLength[:])
+	file.Write(parquetMagic)
+	_, err := out.Write(file.Bytes())
+	return err
+}
@@ -0,0 +1,296 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// thriftReader decodes thrift structs encoded with the compact protocol into maps of field ids to
+// values, to read back the parquet metadata independently of the writer.
+type thriftReader struct {
+	b   []byte
+	pos int
+}
+
+func (r *thriftReader) byte() (byte, error) {
+	if r.pos >= len(r.b) {
+		return 0, errors.New("unexpected end of thrift data")
+	}
+	r.pos++
+	return r.b[r.pos-1], nil
+}
+
+func (r *thriftReader) varint() (uint64, error) {
+	v, n := binary.Uvarint(r.b[r.pos:])
+	if n <= 0 {
+		return 0, errors.New("invalid varint")
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *thriftReader) zigzag() (int64, error) {
+	v, err := r.varint()
+	if err != nil {
+		return 0, err
+	}
+	return int64(v>>1) ^ -int64(v&1), nil
+}
+
+func (r *thriftReader) value(typ byte) (interface{}, error) {
+	switch typ {
+	case thriftI32, thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		n, err := r.varint()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(r.b)-r.pos) < n {
+			return nil, errors.New("binary value out of bounds")
+		}
+		r.pos += int(n)
+		return r.b[r.pos-int(n) : r.pos], nil
+	case thriftList:
+		header, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		size := uint64(header >> 4)
+		if size == 15 {
+			if size, err = r.varint(); err != nil {
+				return nil, err
+			}
+		}
+		list := make([]interface{}, 0, size)
+		for i := uint64(0); i < size; i++ {
+			v, err := r.value(header & 0x0F)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case thriftStruct:
+		return r.readStruct()
+	default:
+		return nil, fmt.Errorf("unsupported thrift type %d", typ)
+	}
+}
+
+func (r *thriftReader) readStruct() (map[int16]interface{}, error) {
+	fields := make(map[int16]interface{})
+	var id int16
+	for {
+		header, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		if header == 0 {
+			return fields, nil
+		}
+		if delta := int16(header >> 4); delta != 0 {
+			id += delta
+		} else {
+			v, err := r.zigzag()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		if _, ok := fields[id]; ok {
+			return nil, fmt.Errorf("duplicate field %d", id)
+		}
+		if fields[id], err = r.value(header & 0x0F); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parquetReadColumn is a column read back from a parquet file, with its schema and values.
+type parquetReadColumn struct {
+	name          string
+	typ           int64
+	convertedType int64
+	repetition    int64
+	values        []interface{}
+}
+
+// readParquet parses the footer, schema and row group of a parquet file and decodes the values of
+// every column chunk, checking the metadata describing the chunks against their content.
+func readParquet(t *testing.T, b []byte) (int64, []*parquetReadColumn) {
+	footerSize := parquetLengthPrefixSize + len(parquetMagic)
+	require.Equal(t, true, len(b) >= len(parquetMagic)+footerSize, "file too short")
+	require.DeepEqual(t, parquetMagic, b[:len(parquetMagic)])
+	require.DeepEqual(t, parquetMagic, b[len(b)-len(parquetMagic):])
+	metaLength := int(binary.LittleEndian.Uint32(b[len(b)-footerSize:]))
+	metaStart := len(b) - footerSize - metaLength
+	require.Equal(t, true, metaStart >= len(parquetMagic), "metadata length out of bounds")
+
+	metaReader := &thriftReader{b: b[metaStart : len(b)-footerSize]}
+	meta, err := metaReader.readStruct()
+	require.NoError(t, err)
+	require.Equal(t, metaLength, metaReader.pos, "metadata does not span its declared length")
+	require.Equal(t, int64(parquetFormatVersion), meta[1])
+	require.DeepEqual(t, []byte(parquetCreatedBy), meta[6])
+	numRows, ok := meta[3].(int64)
+	require.Equal(t, true, ok)
+
+	schema, ok := meta[2].([]interface{})
+	require.Equal(t, true, ok && len(schema) > 0)
+	root, ok := schema[0].(map[int16]interface{})
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, []byte("schema"), root[4])
+	require.Equal(t, int64(len(schema)-1), root[5])
+	columns := make([]*parquetReadColumn, 0, len(schema)-1)
+	for _, e := range schema[1:] {
+		element, ok := e.(map[int16]interface{})
+		require.Equal(t, true, ok)
+		name, ok := element[4].([]byte)
+		require.Equal(t, true, ok)
+		c := &parquetReadColumn{name: string(name)}
+		c.typ, ok = element[1].(int64)
+		require.Equal(t, true, ok)
+		c.repetition, ok = element[3].(int64)
+		require.Equal(t, true, ok)
+		c.convertedType, ok = element[6].(int64)
+		require.Equal(t, true, ok)
+		columns = append(columns, c)
+	}
+
+	rowGroups, ok := meta[4].([]interface{})
+	require.Equal(t, true, ok)
+	require.Equal(t, 1, len(rowGroups))
+	rowGroup, ok := rowGroups[0].(map[int16]interface{})
+	require.Equal(t, true, ok)
+	require.Equal(t, numRows, rowGroup[3])
+	chunks, ok := rowGroup[1].([]interface{})
+	require.Equal(t, true, ok)
+	require.Equal(t, len(columns), len(chunks))
+	var totalSize int64
+	for i, ch := range chunks {
+		c := columns[i]
+		chunk, ok := ch.(map[int16]interface{})
+		require.Equal(t, true, ok)
+		chunkMeta, ok := chunk[3].(map[int16]interface{})
+		require.Equal(t, true, ok)
+		require.Equal(t, c.typ, chunkMeta[1])
+		require.DeepEqual(t, []interface{}{int64(parquetPlainEncoding), int64(parquetRLEEncoding)}, chunkMeta[2])
+		require.DeepEqual(t, []interface{}{[]byte(c.name)}, chunkMeta[3])
+		require.Equal(t, int64(parquetUncompressed), chunkMeta[4])
+		require.Equal(t, numRows, chunkMeta[5])
+		require.Equal(t, chunkMeta[6], chunkMeta[7])
+		offset, ok := chunkMeta[9].(int64)
+		require.Equal(t, true, ok)
+		require.Equal(t, offset, chunk[2])
+		size, ok := chunkMeta[7].(int64)
+		require.Equal(t, true, ok)
+		require.Equal(t, true, offset >= int64(len(parquetMagic)) && offset+size <= int64(metaStart), "column chunk out of bounds")
+		totalSize += size
+
+		pageReader := &thriftReader{b: b[offset : offset+size]}
+		header, err := pageReader.readStruct()
+		require.NoError(t, err)
+		require.Equal(t, int64(parquetDataPage), header[1])
+		require.Equal(t, header[2], header[3])
+		pageSize, ok := header[3].(int64)
+		require.Equal(t, true, ok)
+		require.Equal(t, size, int64(pageReader.pos)+pageSize, "column chunk size does not match its page")
+		dataPage, ok := header[5].(map[int16]interface{})
+		require.Equal(t, true, ok)
+		require.Equal(t, numRows, dataPage[1])
+		require.Equal(t, int64(parquetPlainEncoding), dataPage[2])
+
+		page := bytes.NewReader(pageReader.b[pageReader.pos:])
+		for r := int64(0); r < numRows; r++ {
+			if c.typ == parquetInt64 {
+				var v uint64
+				require.NoError(t, binary.Read(page, binary.LittleEndian, &v))
+				c.values = append(c.values, v)
+				continue
+			}
+			var length uint32
+			require.NoError(t, binary.Read(page, binary.LittleEndian, &length))
+			v := make([]byte, length)
+			_, err := io.ReadFull(page, v)
+			require.NoError(t, err)
+			c.values = append(c.values, string(v))
+		}
+		require.Equal(t, 0, page.Len(), "unread bytes left in page of column %s", c.name)
+	}
+	require.Equal(t, totalSize, rowGroup[2])
+	return numRows, columns
+}
+
+// assertParquetColumns checks that the columns read back from a parquet file hold the given columns.
+func assertParquetColumns(t *testing.T, want []*historyColumn, rows int, numRows int64, got []*parquetReadColumn) {
+	require.Equal(t, int64(rows), numRows)
+	require.Equal(t, len(want), len(got))
+	for i, c := range want {
+		assert.Equal(t, c.name, got[i].name)
+		assert.Equal(t, int64(c.parquetType()), got[i].typ)
+		assert.Equal(t, int64(c.parquetConvertedType()), got[i].convertedType)
+		assert.Equal(t, int64(parquetRequired), got[i].repetition)
+		require.Equal(t, rows, len(got[i].values))
+		for r := 0; r < rows; r++ {
+			if c.numeric {
+				assert.Equal(t, c.numbers[r], got[i].values[r], "column %s, row %d", c.name, r)
+			} else {
+				assert.Equal(t, c.text[r], got[i].values[r], "column %s, row %d", c.name, r)
+			}
+		}
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []*historyColumn
+		rows    int
+	}{
+		{
+			name: "text and numbers",
+			columns: []*historyColumn{
+				{name: "public_key", text: []string{"0xaa", "0xbb", ""}},
+				{name: "slot", numeric: true, numbers: []uint64{1, 2, 1<<64 - 1}},
+			},
+			rows: 3,
+		},
+		{
+			name:    "no rows",
+			columns: []*historyColumn{{name: "public_key"}, {name: "slot", numeric: true}},
+		},
+		{
+			// More than 15 schema elements and values spanning several varint bytes use the long forms
+			// of the thrift list header and field sizes.
+			name: "many columns",
+			columns: func() []*historyColumn {
+				columns := make([]*historyColumn, 20)
+				for i := range columns {
+					columns[i] = &historyColumn{name: fmt.Sprintf("column_%d", i), numeric: i%2 == 0}
+					for r := 0; r < 200; r++ {
+						columns[i].numbers = append(columns[i].numbers, uint64(i*r))
+						columns[i].text = append(columns[i].text, fmt.Sprintf("%d-%d", i, r))
+					}
+				}
+				return columns
+			}(),
+			rows: 200,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeParquet(&buf, tt.columns, tt.rows))
+			numRows, columns := readParquet(t, buf.Bytes())
+			assertParquetColumns(t, tt.columns, tt.rows, numRows, columns)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// PruneSlashingProtection removes the attestation and proposal history older than the weak subjectivity
+// period from a validator database.
+func PruneSlashingProtection(cliCtx *cli.Context) error {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+
+	if !file.FileExists(path.Join(dataDir, kv.ProtectionDbFileName)) {
+		return errors.New("No validator db found at path, nothing to prune")
+	}
+
+	ctx := context.Background()
+	log.Info("Opening DB")
+	encryptionSecret, err := EncryptionSecret(cliCtx, "" /* read from --wallet-password-file */)
+	if err != nil {
+		return errors.Wrap(err, "could not get database encryption secret")
+	}
+	validatorDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{EncryptionSecret: encryptionSecret})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := validatorDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	log.Info("Pruning slashing protection history")
+	prunedAtts, prunedProposals, err := validatorDB.PruneSlashingProtectionHistory(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not prune slashing protection history")
+	}
+	log.WithFields(logrus.Fields{
+		"prunedAttestations": prunedAtts,
+		"prunedProposals":    prunedProposals,
+	}).Info("Pruned slashing protection history")
+	return nil
+}
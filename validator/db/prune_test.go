@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/cmd"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	dbtest "github.com/prysmaticlabs/prysm/validator/db/testing"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/urfave/cli/v2"
+)
+
+func TestPruneSlashingProtection_NoDBFound(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, "", "")
+	require.NoError(t, set.Set(cmd.DataDirFlag.Name, ""))
+	cliCtx := cli.NewContext(&app, set, nil)
+	err := PruneSlashingProtection(cliCtx)
+	assert.ErrorContains(t, "No validator db found at path", err)
+}
+
+func TestPruneSlashingProtection_OK(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.WeakSubjectivityPeriod = 10
+	params.OverrideBeaconConfig(cfg)
+	hook := logTest.NewGlobal()
+
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	validatorDB := dbtest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	var signingRoots [][32]byte
+	var atts []*ethpb.IndexedAttestation
+	for source := types.Epoch(0); source < 20; source++ {
+		signingRoots = append(signingRoots, [32]byte{byte(source + 1)})
+		atts = append(atts, &ethpb.IndexedAttestation{
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: source},
+				Target: &ethpb.Checkpoint{Epoch: source + 1},
+			},
+		})
+	}
+	require.NoError(t, validatorDB.SaveAttestationsForPubKey(ctx, pubKey, signingRoots, atts))
+	dbPath := validatorDB.DatabasePath()
+	require.NoError(t, validatorDB.Close())
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dbPath, "")
+	require.NoError(t, set.Set(cmd.DataDirFlag.Name, dbPath))
+	cliCtx := cli.NewContext(&app, set, nil)
+	require.NoError(t, PruneSlashingProtection(cliCtx))
+	assert.LogsContain(t, hook, "prunedAttestations=9")
+}
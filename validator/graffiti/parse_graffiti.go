@@ -2,9 +2,11 @@ package graffiti
 
 import (
 	"encoding/hex"
+	"fmt"
 	"os"
 	"strings"
 
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/crypto/hash"
 	"gopkg.in/yaml.v2"
@@ -22,6 +24,8 @@ type Graffiti struct {
 	Ordered  []string                        `yaml:"ordered,omitempty"`
 	Random   []string                        `yaml:"random,omitempty"`
 	Specific map[types.ValidatorIndex]string `yaml:"specific,omitempty"`
+	// Pubkeys maps 0x-prefixed, lowercase hex encoded validator public keys to their graffiti.
+	Pubkeys map[string]string `yaml:"pubkeys,omitempty"`
 }
 
 // ParseGraffitiFile parses the graffiti file and returns the graffiti struct.
@@ -43,6 +47,18 @@ func ParseGraffitiFile(f string) (*Graffiti, error) {
 		g.Specific[i] = ParseHexGraffiti(o)
 	}
 
+	if len(g.Pubkeys) != 0 {
+		pubkeys := make(map[string]string, len(g.Pubkeys))
+		for k, v := range g.Pubkeys {
+			key, err := normalizePubkey(k)
+			if err != nil {
+				return nil, err
+			}
+			pubkeys[key] = ParseHexGraffiti(v)
+		}
+		g.Pubkeys = pubkeys
+	}
+
 	for i, v := range g.Ordered {
 		g.Ordered[i] = ParseHexGraffiti(v)
 	}
@@ -57,6 +73,22 @@ func ParseGraffitiFile(f string) (*Graffiti, error) {
 	return g, nil
 }
 
+// ForPubkey returns the graffiti configured for the given validator public key, if any.
+func (g *Graffiti) ForPubkey(pubKey [fieldparams.BLSPubkeyLength]byte) (string, bool) {
+	graffiti, ok := g.Pubkeys[hex0xPrefix+hex.EncodeToString(pubKey[:])]
+	return graffiti, ok
+}
+
+// normalizePubkey returns the 0x-prefixed, lowercase form of a hex encoded validator public key.
+func normalizePubkey(pubKey string) (string, error) {
+	key := strings.TrimPrefix(strings.ToLower(pubKey), hex0xPrefix)
+	b, err := hex.DecodeString(key)
+	if err != nil || len(b) != fieldparams.BLSPubkeyLength {
+		return "", fmt.Errorf("%s is not a valid validator public key", pubKey)
+	}
+	return hex0xPrefix + key, nil
+}
+
 // ParseHexGraffiti checks if a graffiti input is being represented in hex and converts it to ASCII if so
 func ParseHexGraffiti(rawGraffiti string) string {
 	splitGraffiti := strings.SplitN(rawGraffiti, ":", 2)
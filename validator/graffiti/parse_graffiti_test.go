@@ -1,10 +1,13 @@
 package graffiti
 
 import (
+	"encoding/hex"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/crypto/hash"
 	"github.com/prysmaticlabs/prysm/testing/assert"
@@ -158,6 +161,52 @@ specific:
 	require.DeepEqual(t, wanted, got)
 }
 
+func TestParseGraffitiFile_Pubkeys(t *testing.T) {
+	pubKey1 := [fieldparams.BLSPubkeyLength]byte{0xab, 0xcd}
+	pubKey2 := [fieldparams.BLSPubkeyLength]byte{0x12, 0x34}
+	input := []byte(`pubkeys:
+  "0x` + strings.ToUpper(hex.EncodeToString(pubKey1[:])) + `": "Mr T was here"
+  "` + hex.EncodeToString(pubKey2[:]) + `": "hex:0x686f6c61206d756e646f21"`)
+
+	dirName := t.TempDir() + "somedir"
+	err := os.MkdirAll(dirName, os.ModePerm)
+	require.NoError(t, err)
+	someFileName := filepath.Join(dirName, "somefile.txt")
+	require.NoError(t, os.WriteFile(someFileName, input, os.ModePerm))
+
+	got, err := ParseGraffitiFile(someFileName)
+	require.NoError(t, err)
+
+	wanted := &Graffiti{
+		Hash: hash.Hash(input),
+		Pubkeys: map[string]string{
+			"0x" + hex.EncodeToString(pubKey1[:]): "Mr T was here",
+			"0x" + hex.EncodeToString(pubKey2[:]): "hola mundo!",
+		},
+	}
+	require.DeepEqual(t, wanted, got)
+
+	graffiti, ok := got.ForPubkey(pubKey1)
+	require.Equal(t, true, ok)
+	assert.Equal(t, "Mr T was here", graffiti)
+	_, ok = got.ForPubkey([fieldparams.BLSPubkeyLength]byte{0x01})
+	assert.Equal(t, false, ok)
+}
+
+func TestParseGraffitiFile_InvalidPubkey(t *testing.T) {
+	input := []byte(`pubkeys:
+  "0xabcd": "Mr T was here"`)
+
+	dirName := t.TempDir() + "somedir"
+	err := os.MkdirAll(dirName, os.ModePerm)
+	require.NoError(t, err)
+	someFileName := filepath.Join(dirName, "somefile.txt")
+	require.NoError(t, os.WriteFile(someFileName, input, os.ModePerm))
+
+	_, err = ParseGraffitiFile(someFileName)
+	require.ErrorContains(t, "0xabcd is not a valid validator public key", err)
+}
+
 func TestParseHexGraffiti(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,56 @@
+package graffiti
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchGraffitiFile parses the graffiti file again every time it changes, and calls onChange with
+// the result when its content differs from the previous one. The parent directory is watched,
+// so that files replaced by editors or configuration management are picked up as well.
+func WatchGraffitiFile(ctx context.Context, f string, current *Graffiti, onChange func(*Graffiti)) {
+	f = filepath.Clean(f)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.WithError(err).Error("Could not initialize file watcher")
+		return
+	}
+	defer func() {
+		if err := watcher.Close(); err != nil {
+			log.WithError(err).Error("Could not close file watcher")
+		}
+	}()
+	if err := watcher.Add(filepath.Dir(f)); err != nil {
+		log.WithError(err).Errorf("Could not add directory of %s to file watcher", f)
+		return
+	}
+	var lastHash [32]byte
+	if current != nil {
+		lastHash = current.Hash
+	}
+	for {
+		select {
+		case ev := <-watcher.Events:
+			if filepath.Clean(ev.Name) != f || ev.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			g, err := ParseGraffitiFile(f)
+			if err != nil {
+				log.WithError(err).Errorf("Could not reload graffiti file %s, keeping the previous graffiti", f)
+				continue
+			}
+			if g.Hash == lastHash {
+				continue
+			}
+			lastHash = g.Hash
+			log.WithField("file", f).Info("Reloaded graffiti file")
+			onChange(g)
+		case err := <-watcher.Errors:
+			log.WithError(err).Errorf("Could not watch for file changes for: %s", f)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
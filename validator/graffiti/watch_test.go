@@ -0,0 +1,36 @@
+package graffiti
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestWatchGraffitiFile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	someFileName := filepath.Join(t.TempDir(), "graffiti.yaml")
+	require.NoError(t, os.WriteFile(someFileName, []byte(`default: "Mr T was here"`), os.ModePerm))
+	current, err := ParseGraffitiFile(someFileName)
+	require.NoError(t, err)
+
+	changes := make(chan *Graffiti, 1)
+	go WatchGraffitiFile(ctx, someFileName, current, func(g *Graffiti) {
+		changes <- g
+	})
+	// Give the watcher time to start before modifying the file.
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, os.WriteFile(someFileName, []byte(`default: "Mr A was here"`), os.ModePerm))
+
+	select {
+	case g := <-changes:
+		require.Equal(t, "Mr A was here", g.Default)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Graffiti file was not reloaded")
+	}
+}
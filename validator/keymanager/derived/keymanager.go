@@ -3,6 +3,8 @@ package derived
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/logrusorgru/aurora"
 	"github.com/pkg/errors"
@@ -61,23 +63,61 @@ func NewKeymanager(
 func (km *Keymanager) RecoverAccountsFromMnemonic(
 	ctx context.Context, mnemonic, mnemonicPassphrase string, numAccounts int,
 ) error {
+	paths := make([]string, numAccounts)
+	for i := 0; i < numAccounts; i++ {
+		paths[i] = fmt.Sprintf(ValidatingKeyDerivationPathTemplate, i)
+	}
+	_, err := km.RecoverAccountsFromMnemonicAtPaths(ctx, mnemonic, mnemonicPassphrase, paths)
+	return err
+}
+
+// RecoverAccountsFromMnemonicAtPaths given a mnemonic phrase, regenerates the accounts at the
+// given EIP-2334 derivation paths, such as m/12381/3600/5/0/0, and writes those not already in
+// the wallet to disk. It returns the public keys of the accounts, in the order of the paths.
+func (km *Keymanager) RecoverAccountsFromMnemonicAtPaths(
+	ctx context.Context, mnemonic, mnemonicPassphrase string, paths []string,
+) ([][]byte, error) {
+	for _, path := range paths {
+		if err := ValidateDerivationPath(path); err != nil {
+			return nil, err
+		}
+	}
 	seed, err := seedFromMnemonic(mnemonic, mnemonicPassphrase)
 	if err != nil {
-		return errors.Wrap(err, "could not initialize new wallet seed file")
+		return nil, errors.Wrap(err, "could not initialize new wallet seed file")
 	}
-	privKeys := make([][]byte, numAccounts)
-	pubKeys := make([][]byte, numAccounts)
-	for i := 0; i < numAccounts; i++ {
-		privKey, err := util.PrivateKeyFromSeedAndPath(
-			seed, fmt.Sprintf(ValidatingKeyDerivationPathTemplate, i),
-		)
+	privKeys := make([][]byte, len(paths))
+	pubKeys := make([][]byte, len(paths))
+	for i, path := range paths {
+		privKey, err := util.PrivateKeyFromSeedAndPath(seed, path)
 		if err != nil {
-			return err
+			return nil, errors.Wrapf(err, "could not derive key at path %s", path)
 		}
 		privKeys[i] = privKey.Marshal()
 		pubKeys[i] = privKey.PublicKey().Marshal()
 	}
-	return km.localKM.ImportKeypairs(ctx, privKeys, pubKeys)
+	if err := km.localKM.ImportKeypairs(ctx, privKeys, pubKeys); err != nil {
+		return nil, err
+	}
+	return pubKeys, nil
+}
+
+// ValidateDerivationPath checks the given path is an EIP-2334 path of at least an account, of the
+// form m/12381/3600/account_index[/...], where every index is a decimal number below 2^32.
+func ValidateDerivationPath(path string) error {
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 || parts[0] != "m" {
+		return fmt.Errorf("derivation path %q is not of the form %s", path, DerivationPathFormat)
+	}
+	for _, part := range parts[1:] {
+		if _, err := strconv.ParseUint(part, 10, 32); err != nil {
+			return fmt.Errorf("derivation path %q has an invalid index %q", path, part)
+		}
+	}
+	if parts[1] != "12381" || parts[2] != "3600" {
+		return fmt.Errorf("derivation path %q does not start with m/12381/3600", path)
+	}
+	return nil
 }
 
 // ExtractKeystores retrieves the secret keys for specified public keys
@@ -99,6 +139,11 @@ func (km *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (b
 	return km.localKM.Sign(ctx, req)
 }
 
+// SignBatch signs a list of messages using the underlying local keymanager.
+func (km *Keymanager) SignBatch(ctx context.Context, reqs []*validatorpb.SignRequest) ([]bls.Signature, error) {
+	return km.localKM.SignBatch(ctx, reqs)
+}
+
 // FetchValidatingPublicKeys fetches the list of validating public keys from the keymanager.
 func (km *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
 	return km.localKM.FetchValidatingPublicKeys(ctx)
@@ -61,6 +61,73 @@ func TestDerivedKeymanager_MnemnonicPassphrase_DifferentResults(t *testing.T) {
 	}
 }
 
+func TestDerivedKeymanager_RecoverAccountsFromMnemonicAtPaths(t *testing.T) {
+	ctx := context.Background()
+	wallet := &mock.Wallet{
+		Files:            make(map[string]map[string][]byte),
+		AccountPasswords: make(map[string]string),
+		WalletPassword:   password,
+	}
+	km, err := NewKeymanager(ctx, &SetupConfig{
+		Wallet:           wallet,
+		ListenForChanges: false,
+	})
+	require.NoError(t, err)
+	derivedSeed, err := seedFromMnemonic(constant.TestMnemonic, "")
+	require.NoError(t, err)
+
+	paths := []string{"m/12381/3600/0/0/0", "m/12381/3600/5/0/0", "m/12381/3600/9/0"}
+	pubKeys, err := km.RecoverAccountsFromMnemonicAtPaths(ctx, constant.TestMnemonic, "", paths)
+	require.NoError(t, err)
+	require.Equal(t, len(paths), len(pubKeys))
+	for i, path := range paths {
+		privKey, err := util.PrivateKeyFromSeedAndPath(derivedSeed, path)
+		require.NoError(t, err)
+		assert.DeepEqual(t, privKey.PublicKey().Marshal(), pubKeys[i])
+	}
+
+	// Deriving into the same wallet again only adds the new keys.
+	_, err = km.RecoverAccountsFromMnemonicAtPaths(ctx, constant.TestMnemonic, "", []string{"m/12381/3600/5/0/0", "m/12381/3600/7/0/0"})
+	require.NoError(t, err)
+	publicKeys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, len(publicKeys))
+
+	_, err = km.RecoverAccountsFromMnemonicAtPaths(ctx, constant.TestMnemonic, "", []string{"m/12381/3600/8/0/0", "m/44/60/0"})
+	assert.ErrorContains(t, "does not start with m/12381/3600", err)
+	publicKeys, err = km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, len(publicKeys))
+}
+
+func TestValidateDerivationPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr string
+	}{
+		{path: "m/12381/3600/0/0/0"},
+		{path: "m/12381/3600/4294967295/0"},
+		{path: "m/12381/3600/1"},
+		{path: "", wantErr: "is not of the form"},
+		{path: "m/12381/3600", wantErr: "is not of the form"},
+		{path: "12381/3600/0/0/0", wantErr: "is not of the form"},
+		{path: "m/12381/3600/4294967296/0/0", wantErr: "invalid index"},
+		{path: "m/12381/3600/0'/0/0", wantErr: "invalid index"},
+		{path: "m/12381/3600//0/0", wantErr: "invalid index"},
+		{path: "m/12381/60/0/0/0", wantErr: "does not start with m/12381/3600"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := ValidateDerivationPath(tt.path)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, tt.wantErr, err)
+		})
+	}
+}
+
 func TestDerivedKeymanager_RecoverSeedRoundTrip(t *testing.T) {
 	mnemonicEntropy := make([]byte, 32)
 	n, err := rand.NewGenerator().Read(mnemonicEntropy)
@@ -97,28 +97,38 @@ func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
 }
 
 // InteropKeymanagerConfig is used on validator launch to initialize the keymanager.
-// InteropKeys are used for testing purposes.
+// InteropKeys are used for testing purposes. When KeyRanges is set, it takes precedence
+// over Offset and NumValidatorKeys.
 type InteropKeymanagerConfig struct {
 	Offset           uint64
 	NumValidatorKeys uint64
+	KeyRanges        []interop.KeyRange
 }
 
 // NewInteropKeymanager instantiates a new imported keymanager with the deterministically generated interop keys.
 // InteropKeys are used for testing purposes.
-func NewInteropKeymanager(_ context.Context, offset, numValidatorKeys uint64) (*Keymanager, error) {
+func NewInteropKeymanager(ctx context.Context, offset, numValidatorKeys uint64) (*Keymanager, error) {
+	if numValidatorKeys == 0 {
+		return &Keymanager{
+			accountsChangedFeed: new(event.Feed),
+		}, nil
+	}
+	return NewInteropKeymanagerFromRanges(ctx, []interop.KeyRange{{Offset: offset, Count: numValidatorKeys}})
+}
+
+// NewInteropKeymanagerFromRanges instantiates a new imported keymanager with the deterministically
+// generated interop keys of each of the given ranges.
+func NewInteropKeymanagerFromRanges(_ context.Context, ranges []interop.KeyRange) (*Keymanager, error) {
 	k := &Keymanager{
 		accountsChangedFeed: new(event.Feed),
 	}
-	if numValidatorKeys == 0 {
-		return k, nil
-	}
-	secretKeys, publicKeys, err := interop.DeterministicallyGenerateKeys(offset, numValidatorKeys)
+	secretKeys, publicKeys, err := interop.DeterministicallyGenerateKeysInRanges(ranges)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not generate interop keys")
 	}
 	lock.Lock()
-	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, numValidatorKeys)
-	for i := uint64(0); i < numValidatorKeys; i++ {
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, len(publicKeys))
+	for i := range publicKeys {
 		publicKey := bytesutil.ToBytes48(publicKeys[i].Marshal())
 		pubKeys[i] = publicKey
 		secretKeysCache[publicKey] = secretKeys[i]
@@ -216,6 +226,33 @@ func (_ *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (bl
 	return secretKey.Sign(req.SigningRoot), nil
 }
 
+// SignBatch signs a list of messages, acquiring the keys cache lock only once.
+func (_ *Keymanager) SignBatch(ctx context.Context, reqs []*validatorpb.SignRequest) ([]bls.Signature, error) {
+	_, span := trace.StartSpan(ctx, "keymanager.SignBatch")
+	defer span.End()
+
+	secretKeys := make([]bls.SecretKey, len(reqs))
+	lock.RLock()
+	for i, req := range reqs {
+		if req.PublicKey == nil {
+			lock.RUnlock()
+			return nil, errors.New("nil public key in request")
+		}
+		secretKey, ok := secretKeysCache[bytesutil.ToBytes48(req.PublicKey)]
+		if !ok {
+			lock.RUnlock()
+			return nil, errors.Errorf("no signing key found in keys cache for %#x", req.PublicKey)
+		}
+		secretKeys[i] = secretKey
+	}
+	lock.RUnlock()
+	sigs := make([]bls.Signature, len(reqs))
+	for i, req := range reqs {
+		sigs[i] = secretKeys[i].Sign(req.SigningRoot)
+	}
+	return sigs, nil
+}
+
 func (km *Keymanager) initializeAccountKeystore(ctx context.Context) error {
 	encoded, err := km.wallet.ReadFileAtPath(ctx, AccountsPath, AccountsKeystoreFileName)
 	if err != nil && strings.Contains(err.Error(), "no files found") {
@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/crypto/bls"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/runtime/interop"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	mock "github.com/prysmaticlabs/prysm/validator/accounts/testing"
@@ -168,3 +169,48 @@ func TestLocalKeymanager_Sign_NoPublicKeyInCache(t *testing.T) {
 	_, err := dr.Sign(context.Background(), req)
 	assert.ErrorContains(t, "no signing key found in keys cache", err)
 }
+
+func TestLocalKeymanager_SignBatch(t *testing.T) {
+	secretKeysCache = make(map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey)
+	reqs := make([]*validatorpb.SignRequest, 3)
+	pubKeys := make([]bls.PublicKey, len(reqs))
+	for i := range reqs {
+		secretKey, err := bls.RandKey()
+		require.NoError(t, err)
+		pubKeys[i] = secretKey.PublicKey()
+		secretKeysCache[bytesutil.ToBytes48(pubKeys[i].Marshal())] = secretKey
+		reqs[i] = &validatorpb.SignRequest{
+			PublicKey:   pubKeys[i].Marshal(),
+			SigningRoot: []byte{byte(i)},
+		}
+	}
+	dr := &Keymanager{}
+	sigs, err := dr.SignBatch(context.Background(), reqs)
+	require.NoError(t, err)
+	require.Equal(t, len(reqs), len(sigs))
+	for i, sig := range sigs {
+		assert.Equal(t, true, sig.Verify(pubKeys[i], reqs[i].SigningRoot))
+	}
+
+	reqs = append(reqs, &validatorpb.SignRequest{PublicKey: []byte("hello world")})
+	_, err = dr.SignBatch(context.Background(), reqs)
+	assert.ErrorContains(t, "no signing key found in keys cache", err)
+}
+
+func TestNewInteropKeymanagerFromRanges(t *testing.T) {
+	ctx := context.Background()
+	ranges := []interop.KeyRange{{Offset: 10, Count: 2}, {Offset: 3, Count: 1}}
+	km, err := NewInteropKeymanagerFromRanges(ctx, ranges)
+	require.NoError(t, err)
+	pubKeys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	_, wanted, err := interop.DeterministicallyGenerateKeysInRanges(ranges)
+	require.NoError(t, err)
+	require.Equal(t, len(wanted), len(pubKeys))
+	for i := range wanted {
+		assert.DeepEqual(t, wanted[i].Marshal(), pubKeys[i][:])
+	}
+
+	_, err = NewInteropKeymanagerFromRanges(ctx, []interop.KeyRange{{Offset: 0, Count: 2}, {Offset: 1, Count: 2}})
+	assert.ErrorContains(t, "overlap", err)
+}
@@ -38,6 +38,11 @@ type Signer interface {
 	Sign(context.Context, *validatorpb.SignRequest) (bls.Signature, error)
 }
 
+// BatchSigner allows signing many messages at once, returning signatures in request order.
+type BatchSigner interface {
+	SignBatch(context.Context, []*validatorpb.SignRequest) ([]bls.Signature, error)
+}
+
 // Importer can import new keystores into the keymanager.
 type Importer interface {
 	ImportKeystores(
@@ -31,6 +31,8 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	validatorServiceConfig "github.com/prysmaticlabs/prysm/config/validator/service"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/io/file"
 	"github.com/prysmaticlabs/prysm/monitoring/backup"
@@ -40,15 +42,22 @@ import (
 	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/runtime"
+	"github.com/prysmaticlabs/prysm/runtime/crash"
 	"github.com/prysmaticlabs/prysm/runtime/debug"
+	"github.com/prysmaticlabs/prysm/runtime/interop"
 	"github.com/prysmaticlabs/prysm/runtime/prereqs"
 	"github.com/prysmaticlabs/prysm/runtime/version"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/validator/audit"
 	"github.com/prysmaticlabs/prysm/validator/client"
+	"github.com/prysmaticlabs/prysm/validator/crosscheck"
+	validatordb "github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/db/kv"
 	g "github.com/prysmaticlabs/prysm/validator/graffiti"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/local"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/validator/redact"
+	"github.com/prysmaticlabs/prysm/validator/reporter"
 	"github.com/prysmaticlabs/prysm/validator/rpc"
 	validatormiddleware "github.com/prysmaticlabs/prysm/validator/rpc/apimiddleware"
 	"github.com/prysmaticlabs/prysm/validator/web"
@@ -109,6 +118,9 @@ func NewValidatorClient(cliCtx *cli.Context) (*ValidatorClient, error) {
 	if err := features.ConfigureValidator(cliCtx); err != nil {
 		return nil, err
 	}
+	if err := bls.SetBackend(features.Get().BLSBackend); err != nil {
+		return nil, err
+	}
 	if err := cmd.ConfigureValidator(cliCtx); err != nil {
 		return nil, err
 	}
@@ -190,7 +202,7 @@ func (c *ValidatorClient) Close() {
 func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 	var err error
 	dataDir := cliCtx.String(flags.WalletDirFlag.Name)
-	if !cliCtx.IsSet(flags.InteropNumValidators.Name) {
+	if !cliCtx.IsSet(flags.InteropNumValidators.Name) && !cliCtx.IsSet(flags.InteropKeyRanges.Name) {
 		// Custom Check For Web3Signer
 		if cliCtx.IsSet(flags.Web3SignerURLFlag.Name) {
 			c.wallet = wallet.NewWalletForWeb3Signer()
@@ -213,6 +225,10 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 	if cliCtx.String(cmd.DataDirFlag.Name) != cmd.DefaultDataDir() {
 		dataDir = cliCtx.String(cmd.DataDirFlag.Name)
 	}
+	encryptionSecret, err := dbEncryptionSecret(cliCtx, c.wallet)
+	if err != nil {
+		return err
+	}
 	clearFlag := cliCtx.Bool(cmd.ClearDB.Name)
 	forceClearFlag := cliCtx.Bool(cmd.ForceClearDB.Name)
 	if clearFlag || forceClearFlag {
@@ -226,7 +242,7 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 			}
 
 		}
-		if err := clearDB(cliCtx.Context, dataDir, forceClearFlag); err != nil {
+		if err := clearDB(cliCtx.Context, dataDir, encryptionSecret, forceClearFlag); err != nil {
 			return err
 		}
 	} else {
@@ -240,8 +256,9 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 	log.WithField("databasePath", dataDir).Info("Checking DB")
 
 	valDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{
-		PubKeys:         nil,
-		InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+		PubKeys:          nil,
+		InitialMMapSize:  cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+		EncryptionSecret: encryptionSecret,
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize db")
@@ -250,6 +267,8 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 	if err := valDB.RunUpMigrations(cliCtx.Context); err != nil {
 		return errors.Wrap(err, "could not run database migration")
 	}
+	crash.SetDataDir(dataDir)
+	crash.RegisterSection("database", crash.MigrationsSection(cliCtx.Context, valDB.CompletedMigrations))
 
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		if err := c.registerPrometheusService(cliCtx); err != nil {
@@ -259,6 +278,11 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 	if err := c.registerValidatorService(cliCtx); err != nil {
 		return err
 	}
+	if cliCtx.IsSet(flags.TenantsConfigFileFlag.Name) {
+		if err := c.registerTenantsService(cliCtx, dataDir); err != nil {
+			return err
+		}
+	}
 	if cliCtx.Bool(flags.EnableRPCFlag.Name) {
 		if err := c.registerRPCService(cliCtx); err != nil {
 			return err
@@ -297,6 +321,10 @@ func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context) error {
 	if cliCtx.String(cmd.DataDirFlag.Name) != cmd.DefaultDataDir() {
 		dataDir = cliCtx.String(cmd.DataDirFlag.Name)
 	}
+	encryptionSecret, err := dbEncryptionSecret(cliCtx, c.wallet)
+	if err != nil {
+		return err
+	}
 	clearFlag := cliCtx.Bool(cmd.ClearDB.Name)
 	forceClearFlag := cliCtx.Bool(cmd.ForceClearDB.Name)
 
@@ -311,14 +339,15 @@ func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context) error {
 			}
 
 		}
-		if err := clearDB(cliCtx.Context, dataDir, forceClearFlag); err != nil {
+		if err := clearDB(cliCtx.Context, dataDir, encryptionSecret, forceClearFlag); err != nil {
 			return err
 		}
 	}
 	log.WithField("databasePath", dataDir).Info("Checking DB")
 	valDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{
-		PubKeys:         nil,
-		InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+		PubKeys:          nil,
+		InitialMMapSize:  cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+		EncryptionSecret: encryptionSecret,
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize db")
@@ -327,6 +356,8 @@ func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context) error {
 	if err := valDB.RunUpMigrations(cliCtx.Context); err != nil {
 		return errors.Wrap(err, "could not run database migration")
 	}
+	crash.SetDataDir(dataDir)
+	crash.RegisterSection("database", crash.MigrationsSection(cliCtx.Context, valDB.CompletedMigrations))
 
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		if err := c.registerPrometheusService(cliCtx); err != nil {
@@ -362,11 +393,17 @@ func (c *ValidatorClient) registerPrometheusService(cliCtx *cli.Context) error {
 			},
 		)
 	}
-	service := prometheus.NewService(
-		fmt.Sprintf("%s:%d", c.cliCtx.String(cmd.MonitoringHostFlag.Name), c.cliCtx.Int(flags.MonitoringPortFlag.Name)),
-		c.services,
-		additionalHandlers...,
-	)
+	addr := fmt.Sprintf("%s:%d", c.cliCtx.String(cmd.MonitoringHostFlag.Name), c.cliCtx.Int(flags.MonitoringPortFlag.Name))
+	var service *prometheus.Service
+	if cliCtx.Bool(flags.RedactValidatorIdentitiesFlag.Name) {
+		secret, err := redact.LoadOrCreateSecret(cliCtx.String(cmd.DataDirFlag.Name))
+		if err != nil {
+			return errors.Wrap(err, "could not load redaction secret")
+		}
+		service = prometheus.NewServiceWithGatherer(addr, c.services, redact.NewGatherer(redact.New(secret)), additionalHandlers...)
+	} else {
+		service = prometheus.NewService(addr, c.services, additionalHandlers...)
+	}
 	logrus.AddHook(prometheus.NewLogrusCollector())
 	return c.services.RegisterService(service)
 }
@@ -383,7 +420,18 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 	grpcRetries := c.cliCtx.Uint(flags.GrpcRetriesFlag.Name)
 	grpcRetryDelay := c.cliCtx.Duration(flags.GrpcRetryDelayFlag.Name)
 	var interopKeysConfig *local.InteropKeymanagerConfig
-	if c.cliCtx.IsSet(flags.InteropNumValidators.Name) {
+	if c.cliCtx.IsSet(flags.InteropKeyRanges.Name) {
+		if c.cliCtx.IsSet(flags.InteropNumValidators.Name) {
+			return fmt.Errorf("--%s cannot be used with --%s", flags.InteropKeyRanges.Name, flags.InteropNumValidators.Name)
+		}
+		ranges, err := interop.ParseKeyRanges(c.cliCtx.String(flags.InteropKeyRanges.Name))
+		if err != nil {
+			return errors.Wrapf(err, "could not parse --%s", flags.InteropKeyRanges.Name)
+		}
+		interopKeysConfig = &local.InteropKeymanagerConfig{
+			KeyRanges: ranges,
+		}
+	} else if c.cliCtx.IsSet(flags.InteropNumValidators.Name) {
 		interopKeysConfig = &local.InteropKeymanagerConfig{
 			Offset:           cliCtx.Uint64(flags.InteropStartIndex.Name),
 			NumValidatorKeys: cliCtx.Uint64(flags.InteropNumValidators.Name),
@@ -405,7 +453,28 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		return err
 	}
 
-	bpc, err := proposerSettings(c.cliCtx)
+	bpc, err := ProposerSettings(c.cliCtx)
+	if err != nil {
+		return err
+	}
+
+	var auditLog *audit.Log
+	if c.cliCtx.IsSet(flags.SigningAuditLogDirFlag.Name) {
+		auditDir := c.cliCtx.String(flags.SigningAuditLogDirFlag.Name)
+		maxSize := int64(c.cliCtx.Uint64(flags.SigningAuditLogMaxSizeFlag.Name)) * 1024 * 1024
+		auditLog, err = audit.Open(auditDir, maxSize)
+		if err != nil {
+			return errors.Wrap(err, "could not open signing audit log")
+		}
+		log.WithField("dir", auditDir).Info("Recording signing requests in audit log")
+	}
+
+	perfReporter, err := performanceReporter(c.cliCtx)
+	if err != nil {
+		return err
+	}
+
+	checker, err := crossChecker(c.cliCtx)
 	if err != nil {
 		return err
 	}
@@ -427,9 +496,15 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		Wallet:                     c.wallet,
 		WalletInitializedFeed:      c.walletInitialized,
 		GraffitiStruct:             gStruct,
+		GraffitiFile:               c.cliCtx.String(flags.GraffitiFileFlag.Name),
 		LogDutyCountDown:           c.cliCtx.Bool(flags.EnableDutyCountDown.Name),
 		Web3SignerConfig:           wsc,
 		ProposerSettings:           bpc,
+		AuditLog:                   auditLog,
+		PerformanceReporter:        perfReporter,
+		CrossChecker:               checker,
+		DoppelGangerEpochs:         types.Epoch(c.cliCtx.Uint64(flags.DoppelGangerEpochsFlag.Name)),
+		ReportDutyResults:          c.cliCtx.Bool(flags.ReportDutyResultsFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -438,6 +513,45 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 	return c.services.RegisterService(v)
 }
 
+func performanceReporter(cliCtx *cli.Context) (*reporter.Reporter, error) {
+	if !cliCtx.IsSet(flags.PerformanceWebhookURLFlag.Name) {
+		return nil, nil
+	}
+	var tmpl string
+	if cliCtx.IsSet(flags.PerformanceReportTemplateFlag.Name) {
+		enc, err := file.ReadFileAsBytes(cliCtx.String(flags.PerformanceReportTemplateFlag.Name))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read performance report template")
+		}
+		tmpl = string(enc)
+	}
+	r, err := reporter.New(&reporter.Config{
+		WebhookURL: cliCtx.String(flags.PerformanceWebhookURLFlag.Name),
+		Template:   tmpl,
+		Interval:   cliCtx.Duration(flags.PerformanceReportIntervalFlag.Name),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize performance reporter")
+	}
+	log.WithField("interval", cliCtx.Duration(flags.PerformanceReportIntervalFlag.Name)).Info("Posting validator performance reports to webhook")
+	return r, nil
+}
+
+func crossChecker(cliCtx *cli.Context) (*crosscheck.Checker, error) {
+	if !cliCtx.IsSet(flags.ExternalBeaconAPIURLFlag.Name) {
+		return nil, nil
+	}
+	c, err := crosscheck.New(&crosscheck.Config{
+		URL:              cliCtx.String(flags.ExternalBeaconAPIURLFlag.Name),
+		BalanceTolerance: cliCtx.Uint64(flags.ExternalBeaconAPIBalanceToleranceFlag.Name),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize external beacon API cross-check")
+	}
+	log.Info("Cross-checking validator statuses and balances with external beacon API")
+	return c, nil
+}
+
 func web3SignerConfig(cliCtx *cli.Context) (*remoteweb3signer.SetupConfig, error) {
 	var web3signerConfig *remoteweb3signer.SetupConfig
 	if cliCtx.IsSet(flags.Web3SignerURLFlag.Name) {
@@ -474,7 +588,9 @@ func web3SignerConfig(cliCtx *cli.Context) (*remoteweb3signer.SetupConfig, error
 	return web3signerConfig, nil
 }
 
-func proposerSettings(cliCtx *cli.Context) (*validatorServiceConfig.ProposerSettings, error) {
+// ProposerSettings builds the proposer settings of the validator client from the fee recipient
+// and proposer settings flags, returning nil if none of them is set.
+func ProposerSettings(cliCtx *cli.Context) (*validatorServiceConfig.ProposerSettings, error) {
 	var fileConfig *validatorServiceConfig.ProposerSettingsPayload
 	//TODO(10809): remove when fully deprecated
 	if cliCtx.IsSet(flags.FeeRecipientConfigFileFlag.Name) && cliCtx.IsSet(flags.FeeRecipientConfigURLFlag.Name) {
@@ -763,7 +879,7 @@ func setWalletPasswordFilePath(cliCtx *cli.Context) error {
 	return nil
 }
 
-func clearDB(ctx context.Context, dataDir string, force bool) error {
+func clearDB(ctx context.Context, dataDir string, encryptionSecret []byte, force bool) error {
 	var err error
 	clearDBConfirmed := force
 
@@ -778,7 +894,7 @@ func clearDB(ctx context.Context, dataDir string, force bool) error {
 	}
 
 	if clearDBConfirmed {
-		valDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{})
+		valDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{EncryptionSecret: encryptionSecret})
 		if err != nil {
 			return errors.Wrapf(err, "Could not create DB in dir %s", dataDir)
 		}
@@ -795,6 +911,19 @@ func clearDB(ctx context.Context, dataDir string, force bool) error {
 	return nil
 }
 
+// dbEncryptionSecret returns the secret the validator database is encrypted with, if any.
+func dbEncryptionSecret(cliCtx *cli.Context, w *wallet.Wallet) ([]byte, error) {
+	var walletPassword string
+	if w != nil {
+		walletPassword = w.Password()
+	}
+	secret, err := validatordb.EncryptionSecret(cliCtx, walletPassword)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get database encryption secret")
+	}
+	return secret, nil
+}
+
 func unmarshalFromURL(ctx context.Context, from string, to interface{}) error {
 	u, err := url.ParseRequestURI(from)
 	if err != nil {
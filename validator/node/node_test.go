@@ -68,7 +68,7 @@ func TestNode_Builds(t *testing.T) {
 func TestClearDB(t *testing.T) {
 	hook := logtest.NewGlobal()
 	tmp := filepath.Join(t.TempDir(), "datadirtest")
-	require.NoError(t, clearDB(context.Background(), tmp, true))
+	require.NoError(t, clearDB(context.Background(), tmp, nil, true))
 	require.LogsContain(t, hook, "Removing database")
 }
 
@@ -506,7 +506,7 @@ func TestProposerSettings(t *testing.T) {
 				set.Bool(flags.EnableValidatorRegistrationFlag.Name, true, "")
 			}
 			cliCtx := cli.NewContext(&app, set, nil)
-			got, err := proposerSettings(cliCtx)
+			got, err := ProposerSettings(cliCtx)
 			if tt.wantErr != "" {
 				require.ErrorContains(t, tt.wantErr, err)
 				return
@@ -0,0 +1,324 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	validatorServiceConfig "github.com/prysmaticlabs/prysm/config/validator/service"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/io/file"
+	accountsiface "github.com/prysmaticlabs/prysm/validator/accounts/iface"
+	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/validator/client"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	g "github.com/prysmaticlabs/prysm/validator/graffiti"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// tenantConfig defines a wallet run by the validator client on behalf of a tenant.
+type tenantConfig struct {
+	Name                  string `yaml:"name"`
+	WalletDir             string `yaml:"wallet_dir"`
+	WalletPasswordFile    string `yaml:"wallet_password_file"`
+	DataDir               string `yaml:"datadir"`
+	BeaconRPCProvider     string `yaml:"beacon_rpc_provider"`
+	SuggestedFeeRecipient string `yaml:"suggested_fee_recipient"`
+}
+
+// tenantsConfig is the content of the tenants config file, e.g.
+//
+//	tenants:
+//	  - name: acme
+//	    wallet_dir: /var/lib/prysm/acme/wallet
+//	    wallet_password_file: /var/lib/prysm/acme/password.txt
+//	    beacon_rpc_provider: beacon-a.internal:4000
+//	    suggested_fee_recipient: "0x50155530FCE8a85ec7055A5F8b2bE214B3DaeFd3"
+//
+// The slashing protection database of a tenant is stored in its wallet directory unless a datadir is set.
+type tenantsConfig struct {
+	Tenants []*tenantConfig `yaml:"tenants"`
+}
+
+func loadTenantsConfig(path string) (*tenantsConfig, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read tenants config file")
+	}
+	cfg := &tenantsConfig{}
+	if err := yaml.UnmarshalStrict(enc, cfg); err != nil {
+		return nil, errors.Wrap(err, "could not parse tenants config file")
+	}
+	names := make(map[string]bool, len(cfg.Tenants))
+	for i, t := range cfg.Tenants {
+		if t == nil || t.Name == "" {
+			return nil, fmt.Errorf("tenant %d has no name", i)
+		}
+		if names[t.Name] {
+			return nil, fmt.Errorf("tenant %s is defined more than once", t.Name)
+		}
+		names[t.Name] = true
+		if t.WalletDir == "" {
+			return nil, fmt.Errorf("tenant %s has no wallet_dir", t.Name)
+		}
+		if t.BeaconRPCProvider == "" {
+			return nil, fmt.Errorf("tenant %s has no beacon_rpc_provider", t.Name)
+		}
+		if t.SuggestedFeeRecipient != "" {
+			if !common.IsHexAddress(t.SuggestedFeeRecipient) {
+				return nil, fmt.Errorf("fee recipient of tenant %s is not a valid eth1 address", t.Name)
+			}
+			if err := warnNonChecksummedAddress(t.SuggestedFeeRecipient); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// tenant is a wallet run with its own beacon node connection and slashing protection database.
+type tenant struct {
+	name      string
+	wallet    *wallet.Wallet
+	db        *kv.Store
+	validator *client.ValidatorService
+}
+
+// tenantsService runs the validator services of all tenants as a single service of the registry.
+type tenantsService struct {
+	tenants []*tenant
+}
+
+// Start the validator services of the tenants.
+func (s *tenantsService) Start() {
+	for _, t := range s.tenants {
+		log.WithField("tenant", t.name).Info("Starting tenant validator service")
+		t.validator.Start()
+	}
+}
+
+// Stop the validator services of the tenants and close their databases.
+func (s *tenantsService) Stop() error {
+	var failed []string
+	for _, t := range s.tenants {
+		if err := t.validator.Stop(); err != nil {
+			log.WithError(err).WithField("tenant", t.name).Error("Could not stop tenant validator service")
+			failed = append(failed, t.name)
+		}
+	}
+	if err := s.closeDBs(); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("could not stop validator service of tenants %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Status returns an error if the validator service of any tenant is unhealthy.
+func (s *tenantsService) Status() error {
+	for _, t := range s.tenants {
+		if err := t.validator.Status(); err != nil {
+			return errors.Wrapf(err, "tenant %s", t.name)
+		}
+	}
+	return nil
+}
+
+func (s *tenantsService) closeDBs() error {
+	var failed []string
+	for _, t := range s.tenants {
+		if t.db == nil {
+			continue
+		}
+		if err := t.db.Close(); err != nil {
+			log.WithError(err).WithField("tenant", t.name).Error("Could not close tenant database")
+			failed = append(failed, t.name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("could not close database of tenants %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// registerTenantsService sets up the tenants of the tenants config file next to the wallet of the validator
+// client, whose slashing protection database is stored in dataDir.
+func (c *ValidatorClient) registerTenantsService(cliCtx *cli.Context, dataDir string) error {
+	cfg, err := loadTenantsConfig(cliCtx.String(flags.TenantsConfigFileFlag.Name))
+	if err != nil {
+		return err
+	}
+	svc := &tenantsService{}
+	if err := c.setupTenants(cliCtx, cfg, dataDir, svc); err != nil {
+		if closeErr := svc.closeDBs(); closeErr != nil {
+			log.WithError(closeErr).Error("Could not close tenant databases")
+		}
+		return err
+	}
+	return c.services.RegisterService(svc)
+}
+
+func (c *ValidatorClient) setupTenants(cliCtx *cli.Context, cfg *tenantsConfig, dataDir string, svc *tenantsService) error {
+	ctx := cliCtx.Context
+	dataDirs := map[string]string{filepath.Clean(dataDir): "the validator client"}
+	for _, tc := range cfg.Tenants {
+		w, err := openTenantWallet(ctx, tc)
+		if err != nil {
+			return err
+		}
+		t := &tenant{name: tc.Name, wallet: w}
+		svc.tenants = append(svc.tenants, t)
+
+		tenantDataDir := tc.DataDir
+		if tenantDataDir == "" {
+			tenantDataDir = w.AccountsDir()
+		}
+		if owner, ok := dataDirs[filepath.Clean(tenantDataDir)]; ok {
+			return fmt.Errorf("tenant %s cannot share the slashing protection database in %s with %s", tc.Name, tenantDataDir, owner)
+		}
+		dataDirs[filepath.Clean(tenantDataDir)] = "tenant " + tc.Name
+
+		// The database of a tenant is encrypted like the one of the validator client, with the
+		// password of the tenant wallet rather than the one of --wallet-password-file.
+		if cliCtx.Bool(flags.DBEncryptionWithWalletPasswordFlag.Name) && w.Password() == "" {
+			return fmt.Errorf("tenant %s has no wallet password to encrypt its database with", tc.Name)
+		}
+		encryptionSecret, err := dbEncryptionSecret(cliCtx, w)
+		if err != nil {
+			return err
+		}
+		t.db, err = kv.NewKVStore(ctx, tenantDataDir, &kv.Config{
+			InitialMMapSize:  cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+			Tenant:           tc.Name,
+			EncryptionSecret: encryptionSecret,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not initialize db of tenant %s", tc.Name)
+		}
+		if err := t.db.RunUpMigrations(ctx); err != nil {
+			return errors.Wrapf(err, "could not run database migration of tenant %s", tc.Name)
+		}
+
+		t.validator, err = client.NewValidatorService(ctx, &client.Config{
+			Endpoint:                   tc.BeaconRPCProvider,
+			DataDir:                    tenantDataDir,
+			LogValidatorBalances:       !cliCtx.Bool(flags.DisablePenaltyRewardLogFlag.Name),
+			EmitAccountMetrics:         !cliCtx.Bool(flags.DisableAccountMetricsFlag.Name),
+			CertFlag:                   cliCtx.String(flags.CertFlag.Name),
+			GraffitiFlag:               g.ParseHexGraffiti(cliCtx.String(flags.GraffitiFlag.Name)),
+			GrpcMaxCallRecvMsgSizeFlag: cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name),
+			GrpcRetriesFlag:            cliCtx.Uint(flags.GrpcRetriesFlag.Name),
+			GrpcRetryDelay:             cliCtx.Duration(flags.GrpcRetryDelayFlag.Name),
+			GrpcHeadersFlag:            cliCtx.String(flags.GrpcHeadersFlag.Name),
+			ValDB:                      t.db,
+			Wallet:                     w,
+			GraffitiStruct:             &g.Graffiti{},
+			LogDutyCountDown:           cliCtx.Bool(flags.EnableDutyCountDown.Name),
+			ProposerSettings:           tenantProposerSettings(cliCtx, tc),
+			Tenant:                     tc.Name,
+			DoppelGangerEpochs:         types.Epoch(cliCtx.Uint64(flags.DoppelGangerEpochsFlag.Name)),
+			ReportDutyResults:          cliCtx.Bool(flags.ReportDutyResultsFlag.Name),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not initialize validator service of tenant %s", tc.Name)
+		}
+		log.WithFields(logrus.Fields{
+			"tenant":          tc.Name,
+			"wallet":          w.AccountsDir(),
+			"keymanager-kind": w.KeymanagerKind().String(),
+			"endpoint":        tc.BeaconRPCProvider,
+			"databasePath":    tenantDataDir,
+		}).Info("Opened tenant wallet")
+	}
+	return checkTenantKeys(ctx, c.wallet, svc.tenants)
+}
+
+func openTenantWallet(ctx context.Context, tc *tenantConfig) (*wallet.Wallet, error) {
+	var password string
+	if tc.WalletPasswordFile != "" {
+		enc, err := file.ReadFileAsBytes(tc.WalletPasswordFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read wallet password file of tenant %s", tc.Name)
+		}
+		password = strings.TrimRight(string(enc), "\r\n")
+	}
+	w, err := wallet.OpenWallet(ctx, &wallet.Config{
+		WalletDir:      tc.WalletDir,
+		WalletPassword: password,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open wallet of tenant %s", tc.Name)
+	}
+	return w, nil
+}
+
+// tenantProposerSettings returns the proposer settings of a tenant, falling back to the suggested fee
+// recipient of the validator client.
+func tenantProposerSettings(cliCtx *cli.Context, tc *tenantConfig) *validatorServiceConfig.ProposerSettings {
+	feeRecipient := tc.SuggestedFeeRecipient
+	if feeRecipient == "" {
+		if !cliCtx.IsSet(flags.SuggestedFeeRecipientFlag.Name) {
+			return nil
+		}
+		feeRecipient = cliCtx.String(flags.SuggestedFeeRecipientFlag.Name)
+	}
+	var vr *validatorServiceConfig.ValidatorRegistration
+	if cliCtx.Bool(flags.EnableValidatorRegistrationFlag.Name) {
+		vr = &validatorServiceConfig.ValidatorRegistration{
+			Enable:   true,
+			GasLimit: reviewGasLimit(params.BeaconConfig().DefaultBuilderGasLimit),
+		}
+	}
+	return &validatorServiceConfig.ProposerSettings{
+		DefaultConfig: &validatorServiceConfig.ProposerOption{
+			FeeRecipient:          common.HexToAddress(feeRecipient),
+			ValidatorRegistration: vr,
+		},
+	}
+}
+
+// checkTenantKeys ensures no validating key of a local or derived wallet is run by more than one tenant, or
+// by a tenant and the validator client itself, as each of them keeps its own slashing protection history.
+func checkTenantKeys(ctx context.Context, own *wallet.Wallet, tenants []*tenant) error {
+	owners := make(map[[fieldparams.BLSPubkeyLength]byte]string)
+	addKeys := func(name string, w *wallet.Wallet) error {
+		if w == nil || (w.KeymanagerKind() != keymanager.Local && w.KeymanagerKind() != keymanager.Derived) {
+			return nil
+		}
+		km, err := w.InitializeKeymanager(ctx, accountsiface.InitKeymanagerConfig{ListenForChanges: false})
+		if err != nil {
+			return errors.Wrapf(err, "could not initialize keymanager of %s", name)
+		}
+		keys, err := km.FetchValidatingPublicKeys(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "could not fetch validating keys of %s", name)
+		}
+		for _, k := range keys {
+			if owner, ok := owners[k]; ok {
+				return fmt.Errorf("validating key %#x is run by both %s and %s", k, owner, name)
+			}
+			owners[k] = name
+		}
+		return nil
+	}
+	if err := addKeys("the validator client", own); err != nil {
+		return err
+	}
+	for _, t := range tenants {
+		if err := addKeys("tenant "+t.name, t.wallet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
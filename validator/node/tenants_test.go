@@ -0,0 +1,220 @@
+package node
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/runtime"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/accounts"
+	accountsiface "github.com/prysmaticlabs/prysm/validator/accounts/iface"
+	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/local"
+	"github.com/urfave/cli/v2"
+)
+
+const tenantWalletPassword = "$$Passw0rdz2$$"
+
+// createTenantWallet creates a local wallet with the given keys and returns its directory and password file.
+func createTenantWallet(t *testing.T, keys ...bls.SecretKey) (string, string) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "wallet")
+	passwordFile := filepath.Join(t.TempDir(), "password.txt")
+	require.NoError(t, file.WriteFile(passwordFile, []byte(tenantWalletPassword+"\n")))
+	w, err := accounts.CreateWalletWithKeymanager(ctx, &accounts.CreateWalletConfig{
+		WalletCfg: &wallet.Config{
+			WalletDir:      dir,
+			KeymanagerKind: keymanager.Local,
+			WalletPassword: tenantWalletPassword,
+		},
+	})
+	require.NoError(t, err)
+	if len(keys) > 0 {
+		km, err := w.InitializeKeymanager(ctx, accountsiface.InitKeymanagerConfig{ListenForChanges: false})
+		require.NoError(t, err)
+		localKm, ok := km.(*local.Keymanager)
+		require.Equal(t, true, ok)
+		var privKeys, pubKeys [][]byte
+		for _, k := range keys {
+			privKeys = append(privKeys, k.Marshal())
+			pubKeys = append(pubKeys, k.PublicKey().Marshal())
+		}
+		require.NoError(t, localKm.ImportKeypairs(ctx, privKeys, pubKeys))
+	}
+	return dir, passwordFile
+}
+
+func tenantsCliContext(t *testing.T, config string) *cli.Context {
+	configFile := filepath.Join(t.TempDir(), "tenants.yaml")
+	require.NoError(t, file.WriteFile(configFile, []byte(config)))
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(flags.TenantsConfigFileFlag.Name, configFile, "")
+	set.String(flags.BeaconRPCProviderFlag.Name, "localhost:4000", "")
+	return cli.NewContext(&app, set, nil)
+}
+
+func TestLoadTenantsConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			config: `tenants:
+  - name: a
+    wallet_dir: /tmp/a
+    beacon_rpc_provider: localhost:4000
+    suggested_fee_recipient: "0x046Fb65722E7b2455012BFEBf6177F1D2e9738D9"
+`,
+		},
+		{
+			name: "missing name",
+			config: `tenants:
+  - wallet_dir: /tmp/a
+    beacon_rpc_provider: localhost:4000
+`,
+			wantErr: "tenant 0 has no name",
+		},
+		{
+			name: "duplicate name",
+			config: `tenants:
+  - name: a
+    wallet_dir: /tmp/a
+    beacon_rpc_provider: localhost:4000
+  - name: a
+    wallet_dir: /tmp/b
+    beacon_rpc_provider: localhost:4000
+`,
+			wantErr: "tenant a is defined more than once",
+		},
+		{
+			name: "missing endpoint",
+			config: `tenants:
+  - name: a
+    wallet_dir: /tmp/a
+`,
+			wantErr: "tenant a has no beacon_rpc_provider",
+		},
+		{
+			name: "bad fee recipient",
+			config: `tenants:
+  - name: a
+    wallet_dir: /tmp/a
+    beacon_rpc_provider: localhost:4000
+    suggested_fee_recipient: "0x01"
+`,
+			wantErr: "fee recipient of tenant a is not a valid eth1 address",
+		},
+		{
+			name: "unknown field",
+			config: `tenants:
+  - name: a
+    wallet_dir: /tmp/a
+    beacon_rpc_provider: localhost:4000
+    graffiti: hello
+`,
+			wantErr: "could not parse tenants config file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tenants.yaml")
+			require.NoError(t, file.WriteFile(path, []byte(tt.config)))
+			cfg, err := loadTenantsConfig(path)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, len(cfg.Tenants))
+			assert.Equal(t, "localhost:4000", cfg.Tenants[0].BeaconRPCProvider)
+		})
+	}
+}
+
+func TestRegisterTenantsService(t *testing.T) {
+	keyA, err := bls.RandKey()
+	require.NoError(t, err)
+	keyB, err := bls.RandKey()
+	require.NoError(t, err)
+	dirA, passwordA := createTenantWallet(t, keyA)
+	dirB, passwordB := createTenantWallet(t, keyB)
+	cliCtx := tenantsCliContext(t, fmt.Sprintf(`tenants:
+  - name: a
+    wallet_dir: %s
+    wallet_password_file: %s
+    beacon_rpc_provider: localhost:4001
+    suggested_fee_recipient: "0x046Fb65722E7b2455012BFEBf6177F1D2e9738D9"
+  - name: b
+    wallet_dir: %s
+    wallet_password_file: %s
+    beacon_rpc_provider: localhost:4002
+`, dirA, passwordA, dirB, passwordB))
+
+	c := &ValidatorClient{services: runtime.NewServiceRegistry()}
+	require.NoError(t, c.registerTenantsService(cliCtx, t.TempDir()))
+	var svc *tenantsService
+	require.NoError(t, c.services.FetchService(&svc))
+	require.Equal(t, 2, len(svc.tenants))
+	assert.Equal(t, "a", svc.tenants[0].name)
+	assert.Equal(t, "b", svc.tenants[1].name)
+	assert.NotEqual(t, svc.tenants[0].db.DatabasePath(), svc.tenants[1].db.DatabasePath())
+	require.NoError(t, svc.closeDBs())
+
+	settings := tenantProposerSettings(cliCtx, &tenantConfig{SuggestedFeeRecipient: "0x046Fb65722E7b2455012BFEBf6177F1D2e9738D9"})
+	assert.Equal(t, "0x046Fb65722E7b2455012BFEBf6177F1D2e9738D9", settings.DefaultConfig.FeeRecipient.Hex())
+	assert.Equal(t, true, tenantProposerSettings(cliCtx, &tenantConfig{}) == nil)
+}
+
+func TestRegisterTenantsService_SharedDataDir(t *testing.T) {
+	dirA, passwordA := createTenantWallet(t)
+	dirB, passwordB := createTenantWallet(t)
+	dataDir := t.TempDir()
+	cliCtx := tenantsCliContext(t, fmt.Sprintf(`tenants:
+  - name: a
+    wallet_dir: %s
+    wallet_password_file: %s
+    datadir: %s
+    beacon_rpc_provider: localhost:4001
+  - name: b
+    wallet_dir: %s
+    wallet_password_file: %s
+    datadir: %s
+    beacon_rpc_provider: localhost:4002
+`, dirA, passwordA, dataDir, dirB, passwordB, dataDir))
+
+	c := &ValidatorClient{services: runtime.NewServiceRegistry()}
+	err := c.registerTenantsService(cliCtx, t.TempDir())
+	require.ErrorContains(t, "tenant b cannot share the slashing protection database", err)
+}
+
+func TestRegisterTenantsService_SharedKey(t *testing.T) {
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	dirA, passwordA := createTenantWallet(t, key)
+	dirB, passwordB := createTenantWallet(t, key)
+	cliCtx := tenantsCliContext(t, fmt.Sprintf(`tenants:
+  - name: a
+    wallet_dir: %s
+    wallet_password_file: %s
+    beacon_rpc_provider: localhost:4001
+  - name: b
+    wallet_dir: %s
+    wallet_password_file: %s
+    beacon_rpc_provider: localhost:4002
+`, dirA, passwordA, dirB, passwordB))
+
+	c := &ValidatorClient{services: runtime.NewServiceRegistry()}
+	err = c.registerTenantsService(cliCtx, t.TempDir())
+	require.ErrorContains(t, "is run by both tenant a and tenant b", err)
+}
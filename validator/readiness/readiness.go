@@ -0,0 +1,334 @@
+// Package readiness scores how ready each validator key is to perform its duties, so that a
+// setup can be checked before its keys are activated. Every key goes through the same checks:
+// beacon node connectivity and sync status, duties retrieval, fee recipient configuration,
+// signing through the keymanager and consistency of its slashing protection history.
+package readiness
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/prysmaticlabs/prysm/validator/db/iface"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Names of the checks every key goes through.
+const (
+	CheckBeaconConnectivity = "beacon_connectivity"
+	CheckBeaconSynced       = "beacon_synced"
+	CheckDuties             = "duties"
+	CheckFeeRecipient       = "fee_recipient"
+	CheckSigning            = "signing"
+	CheckSlashingProtection = "slashing_protection"
+)
+
+// Config for a readiness run. The validator database may be nil if the validator has no
+// slashing protection history yet.
+type Config struct {
+	ValidatorClient  ethpb.BeaconNodeValidatorClient
+	NodeClient       ethpb.NodeClient
+	Keymanager       keymanager.IKeymanager
+	ValidatorDB      iface.ValidatorDB
+	ProposerSettings *validatorserviceconfig.ProposerSettings
+}
+
+// Check is the outcome of a single readiness check for a key.
+type Check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// KeyReport holds the outcome of every check for a key, along with its score, the percentage
+// of checks it passed.
+type KeyReport struct {
+	PublicKey string   `json:"public_key"`
+	Score     uint64   `json:"score"`
+	Ready     bool     `json:"ready"`
+	Checks    []*Check `json:"checks"`
+}
+
+// Report of a readiness run. The validator is ready if every check passed for every key.
+type Report struct {
+	Ready bool         `json:"ready"`
+	Keys  []*KeyReport `json:"keys"`
+}
+
+// beaconStatus holds what is learned from the beacon node once, and shared by the checks of every key.
+type beaconStatus struct {
+	connectivity *Check
+	synced       *Check
+	genesis      *ethpb.Genesis
+	epoch        types.Epoch
+	duties       map[[fieldparams.BLSPubkeyLength]byte]*ethpb.DutiesResponse_Duty
+	dutiesErr    error
+}
+
+// Run checks every key of the keymanager and reports its readiness.
+func Run(ctx context.Context, cfg *Config) (*Report, error) {
+	pubKeys, err := cfg.Keymanager.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch validating public keys")
+	}
+	if len(pubKeys) == 0 {
+		return nil, errors.New("no validating public keys found")
+	}
+	bs := fetchBeaconStatus(ctx, cfg, pubKeys)
+
+	report := &Report{Ready: true, Keys: make([]*KeyReport, 0, len(pubKeys))}
+	for _, pubKey := range pubKeys {
+		checks := []*Check{
+			bs.connectivity,
+			bs.synced,
+			checkDuties(bs, pubKey),
+			checkFeeRecipient(cfg.ProposerSettings, pubKey),
+			checkSigning(ctx, cfg, bs, pubKey),
+			checkSlashingProtection(ctx, cfg.ValidatorDB, bs, pubKey),
+		}
+		kr := &KeyReport{
+			PublicKey: fmt.Sprintf("%#x", pubKey),
+			Ready:     true,
+			Checks:    checks,
+		}
+		var passed uint64
+		for _, c := range checks {
+			if c.Passed {
+				passed++
+			} else {
+				kr.Ready = false
+			}
+		}
+		kr.Score = passed * 100 / uint64(len(checks))
+		report.Ready = report.Ready && kr.Ready
+		report.Keys = append(report.Keys, kr)
+	}
+	return report, nil
+}
+
+func fetchBeaconStatus(ctx context.Context, cfg *Config, pubKeys [][fieldparams.BLSPubkeyLength]byte) *beaconStatus {
+	bs := &beaconStatus{
+		connectivity: &Check{Name: CheckBeaconConnectivity},
+		synced:       &Check{Name: CheckBeaconSynced},
+	}
+	syncStatus, err := cfg.NodeClient.GetSyncStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		bs.connectivity.Detail = fmt.Sprintf("could not reach beacon node: %v", err)
+		bs.synced.Detail = "beacon node unreachable"
+		bs.dutiesErr = errors.New("beacon node unreachable")
+		return bs
+	}
+	bs.connectivity.Passed = true
+	if syncStatus.Syncing {
+		bs.synced.Detail = "beacon node is syncing"
+	} else {
+		bs.synced.Passed = true
+	}
+
+	genesis, err := cfg.NodeClient.GetGenesis(ctx, &emptypb.Empty{})
+	if err != nil {
+		bs.connectivity.Passed = false
+		bs.connectivity.Detail = fmt.Sprintf("could not fetch genesis: %v", err)
+		bs.dutiesErr = errors.New("beacon node genesis unknown")
+		return bs
+	}
+	bs.genesis = genesis
+	bs.epoch = slots.ToEpoch(slots.CurrentSlot(uint64(genesis.GenesisTime.AsTime().Unix())))
+
+	req := &ethpb.DutiesRequest{Epoch: bs.epoch, PublicKeys: make([][]byte, len(pubKeys))}
+	for i := range pubKeys {
+		req.PublicKeys[i] = pubKeys[i][:]
+	}
+	resp, err := cfg.ValidatorClient.GetDuties(ctx, req)
+	if err != nil {
+		bs.dutiesErr = err
+		return bs
+	}
+	bs.duties = make(map[[fieldparams.BLSPubkeyLength]byte]*ethpb.DutiesResponse_Duty, len(resp.CurrentEpochDuties))
+	for _, d := range resp.CurrentEpochDuties {
+		var pubKey [fieldparams.BLSPubkeyLength]byte
+		copy(pubKey[:], d.PublicKey)
+		bs.duties[pubKey] = d
+	}
+	return bs
+}
+
+// checkDuties passes if the beacon node returned the duties of the key, whatever its status.
+func checkDuties(bs *beaconStatus, pubKey [fieldparams.BLSPubkeyLength]byte) *Check {
+	c := &Check{Name: CheckDuties}
+	if bs.dutiesErr != nil {
+		c.Detail = fmt.Sprintf("could not fetch duties: %v", bs.dutiesErr)
+		return c
+	}
+	d, ok := bs.duties[pubKey]
+	if !ok {
+		c.Detail = "no duties returned by the beacon node"
+		return c
+	}
+	c.Passed = true
+	c.Detail = fmt.Sprintf("status %s at epoch %d", d.Status, bs.epoch)
+	return c
+}
+
+// checkFeeRecipient passes if a non-zero fee recipient is configured for the key, either
+// specifically or through the default proposer settings.
+func checkFeeRecipient(settings *validatorserviceconfig.ProposerSettings, pubKey [fieldparams.BLSPubkeyLength]byte) *Check {
+	c := &Check{Name: CheckFeeRecipient}
+	var option *validatorserviceconfig.ProposerOption
+	if settings != nil {
+		if o, ok := settings.ProposeConfig[pubKey]; ok && o != nil {
+			option = o
+		} else {
+			option = settings.DefaultConfig
+		}
+	}
+	if option == nil {
+		c.Detail = "no fee recipient configured"
+		return c
+	}
+	if option.FeeRecipient == params.BeaconConfig().DefaultFeeRecipient {
+		c.Detail = "fee recipient is the zero address"
+		return c
+	}
+	c.Passed = true
+	c.Detail = option.FeeRecipient.Hex()
+	return c
+}
+
+// checkSigning has the keymanager sign a randao reveal for the current epoch, which is not
+// slashable, and verifies the signature.
+func checkSigning(ctx context.Context, cfg *Config, bs *beaconStatus, pubKey [fieldparams.BLSPubkeyLength]byte) *Check {
+	c := &Check{Name: CheckSigning}
+	if bs.genesis == nil {
+		c.Detail = "signing domain unknown, beacon node unreachable"
+		return c
+	}
+	domain, err := cfg.ValidatorClient.DomainData(ctx, &ethpb.DomainRequest{
+		Epoch:  bs.epoch,
+		Domain: params.BeaconConfig().DomainRandao[:],
+	})
+	if err != nil {
+		c.Detail = fmt.Sprintf("could not fetch signing domain: %v", err)
+		return c
+	}
+	epoch := types.SSZUint64(bs.epoch)
+	root, err := signing.ComputeSigningRoot(&epoch, domain.SignatureDomain)
+	if err != nil {
+		c.Detail = fmt.Sprintf("could not compute signing root: %v", err)
+		return c
+	}
+	sig, err := cfg.Keymanager.Sign(ctx, &validatorpb.SignRequest{
+		PublicKey:       pubKey[:],
+		SigningRoot:     root[:],
+		SignatureDomain: domain.SignatureDomain,
+		Object:          &validatorpb.SignRequest_Epoch{Epoch: bs.epoch},
+		SigningSlot:     slots.CurrentSlot(uint64(bs.genesis.GenesisTime.AsTime().Unix())),
+	})
+	if err != nil {
+		c.Detail = fmt.Sprintf("could not sign: %v", err)
+		return c
+	}
+	pk, err := bls.PublicKeyFromBytes(pubKey[:])
+	if err != nil {
+		c.Detail = fmt.Sprintf("invalid public key: %v", err)
+		return c
+	}
+	if !sig.Verify(pk, root[:]) {
+		c.Detail = "signature does not verify"
+		return c
+	}
+	c.Passed = true
+	return c
+}
+
+// checkSlashingProtection passes if the slashing protection history of the key belongs to the
+// chain of the beacon node and agrees with the lowest signed epochs and slot it is checked against.
+// A key without history passes, as is the case of any key before its activation.
+func checkSlashingProtection(
+	ctx context.Context, db iface.ValidatorDB, bs *beaconStatus, pubKey [fieldparams.BLSPubkeyLength]byte,
+) *Check {
+	c := &Check{Name: CheckSlashingProtection}
+	if db == nil {
+		c.Passed = true
+		c.Detail = "no slashing protection database found"
+		return c
+	}
+	if err := slashingProtectionConsistency(ctx, db, bs, pubKey); err != nil {
+		c.Detail = err.Error()
+		return c
+	}
+	c.Passed = true
+	return c
+}
+
+func slashingProtectionConsistency(
+	ctx context.Context, db iface.ValidatorDB, bs *beaconStatus, pubKey [fieldparams.BLSPubkeyLength]byte,
+) error {
+	genesisValidatorsRoot, err := db.GenesisValidatorsRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read genesis validators root")
+	}
+	if len(genesisValidatorsRoot) != 0 && bs.genesis != nil &&
+		!bytes.Equal(genesisValidatorsRoot, bs.genesis.GenesisValidatorsRoot) {
+		return fmt.Errorf(
+			"genesis validators root %#x of the database does not match %#x of the beacon node",
+			genesisValidatorsRoot, bs.genesis.GenesisValidatorsRoot,
+		)
+	}
+
+	attestations, err := db.AttestationHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not read attestation history")
+	}
+	lowestSource, sourceExists, err := db.LowestSignedSourceEpoch(ctx, pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not read lowest signed source epoch")
+	}
+	lowestTarget, targetExists, err := db.LowestSignedTargetEpoch(ctx, pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not read lowest signed target epoch")
+	}
+	if len(attestations) != 0 && (!sourceExists || !targetExists) {
+		return errors.New("attestation history has no lowest signed source and target epochs")
+	}
+	for _, a := range attestations {
+		if a.Source > a.Target {
+			return fmt.Errorf("attestation with source epoch %d greater than its target epoch %d", a.Source, a.Target)
+		}
+		if a.Source < lowestSource || a.Target < lowestTarget {
+			return fmt.Errorf(
+				"attestation with source epoch %d and target epoch %d below the lowest signed source epoch %d and target epoch %d",
+				a.Source, a.Target, lowestSource, lowestTarget,
+			)
+		}
+	}
+
+	proposals, err := db.ProposalHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not read proposal history")
+	}
+	lowestProposal, proposalExists, err := db.LowestSignedProposal(ctx, pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not read lowest signed proposal")
+	}
+	if len(proposals) != 0 && !proposalExists {
+		return errors.New("proposal history has no lowest signed proposal")
+	}
+	for _, p := range proposals {
+		if p.Slot < lowestProposal {
+			return fmt.Errorf("proposal at slot %d below the lowest signed proposal %d", p.Slot, lowestProposal)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package readiness
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/mock"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	dbtest "github.com/prysmaticlabs/prysm/validator/db/testing"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testKeymanager signs with the secret key of every public key, except for the keys mapped to
+// another secret key.
+type testKeymanager struct {
+	keymanager.IKeymanager
+	pubKeys [][fieldparams.BLSPubkeyLength]byte
+	keys    map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey
+}
+
+func (m *testKeymanager) FetchValidatingPublicKeys(_ context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	return m.pubKeys, nil
+}
+
+func (m *testKeymanager) Sign(_ context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	return m.keys[bytesutil.ToBytes48(req.PublicKey)].Sign(req.SigningRoot), nil
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	km := &testKeymanager{keys: make(map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey)}
+	for i := 0; i < 3; i++ {
+		sk, err := bls.RandKey()
+		require.NoError(t, err)
+		pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+		km.pubKeys = append(km.pubKeys, pubKey)
+		km.keys[pubKey] = sk
+	}
+	// The second key signs with a key which is not its own.
+	wrongKey, err := bls.RandKey()
+	require.NoError(t, err)
+	km.keys[km.pubKeys[1]] = wrongKey
+
+	genesisValidatorsRoot := bytesutil.PadTo([]byte("genesis"), 32)
+	db := dbtest.SetupDB(t, km.pubKeys)
+	require.NoError(t, db.SaveGenesisValidatorsRoot(ctx, genesisValidatorsRoot))
+	// The third key has an attestation whose source is greater than its target.
+	require.NoError(t, db.SaveAttestationForPubKey(ctx, km.pubKeys[2], [32]byte{1}, &ethpb.IndexedAttestation{
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: 5},
+			Target: &ethpb.Checkpoint{Epoch: 3},
+		},
+	}))
+
+	nodeClient := mock.NewMockNodeClient(ctrl)
+	nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: false}, nil)
+	nodeClient.EXPECT().GetGenesis(gomock.Any(), gomock.Any()).Return(&ethpb.Genesis{
+		GenesisTime:           timestamppb.New(time.Now().Add(-time.Hour)),
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}, nil)
+	validatorClient := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	// No duties are returned for the second key.
+	validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(&ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{PublicKey: km.pubKeys[0][:], Status: ethpb.ValidatorStatus_ACTIVE},
+			{PublicKey: km.pubKeys[2][:], Status: ethpb.ValidatorStatus_PENDING},
+		},
+	}, nil)
+	validatorClient.EXPECT().DomainData(gomock.Any(), gomock.Any()).Return(&ethpb.DomainResponse{
+		SignatureDomain: make([]byte, 32),
+	}, nil).Times(3)
+
+	report, err := Run(ctx, &Config{
+		ValidatorClient: validatorClient,
+		NodeClient:      nodeClient,
+		Keymanager:      km,
+		ValidatorDB:     db,
+		ProposerSettings: &validatorserviceconfig.ProposerSettings{
+			DefaultConfig: &validatorserviceconfig.ProposerOption{
+				FeeRecipient: common.HexToAddress("0x046Fb65722E7b2455012BFEBf6177F1D2e9738D9"),
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, false, report.Ready)
+	require.Equal(t, 3, len(report.Keys))
+
+	failed := func(kr *KeyReport) []string {
+		names := make([]string, 0)
+		for _, c := range kr.Checks {
+			if !c.Passed {
+				names = append(names, c.Name)
+			}
+		}
+		return names
+	}
+	assert.Equal(t, true, report.Keys[0].Ready)
+	assert.Equal(t, uint64(100), report.Keys[0].Score)
+	assert.DeepEqual(t, []string{}, failed(report.Keys[0]))
+	assert.Equal(t, false, report.Keys[1].Ready)
+	assert.Equal(t, uint64(66), report.Keys[1].Score)
+	assert.DeepEqual(t, []string{CheckDuties, CheckSigning}, failed(report.Keys[1]))
+	assert.Equal(t, uint64(83), report.Keys[2].Score)
+	assert.DeepEqual(t, []string{CheckSlashingProtection}, failed(report.Keys[2]))
+}
+
+func TestRun_BeaconNodeUnreachable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+	km := &testKeymanager{
+		pubKeys: [][fieldparams.BLSPubkeyLength]byte{pubKey},
+		keys:    map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey{pubKey: sk},
+	}
+	nodeClient := mock.NewMockNodeClient(ctrl)
+	nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	report, err := Run(context.Background(), &Config{
+		ValidatorClient: mock.NewMockBeaconNodeValidatorClient(ctrl),
+		NodeClient:      nodeClient,
+		Keymanager:      km,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, false, report.Ready)
+	require.Equal(t, 1, len(report.Keys))
+	// Only the slashing protection check passes, as there is no history.
+	assert.Equal(t, uint64(16), report.Keys[0].Score)
+	assert.Equal(t, true, strings.Contains(report.Keys[0].Checks[0].Detail, "connection refused"))
+}
+
+func TestCheckFeeRecipient(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	defaultRecipient := common.HexToAddress("0x046Fb65722E7b2455012BFEBf6177F1D2e9738D9")
+	keyRecipient := common.HexToAddress("0x50155530FCE8a85ec7055A5F8b2bE214B3DaeFd3")
+	tests := []struct {
+		name     string
+		settings *validatorserviceconfig.ProposerSettings
+		passed   bool
+		detail   string
+	}{
+		{
+			name:   "no settings",
+			detail: "no fee recipient configured",
+		},
+		{
+			name: "default",
+			settings: &validatorserviceconfig.ProposerSettings{
+				DefaultConfig: &validatorserviceconfig.ProposerOption{FeeRecipient: defaultRecipient},
+			},
+			passed: true,
+			detail: defaultRecipient.Hex(),
+		},
+		{
+			name: "key specific",
+			settings: &validatorserviceconfig.ProposerSettings{
+				ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+					pubKey: {FeeRecipient: keyRecipient},
+				},
+				DefaultConfig: &validatorserviceconfig.ProposerOption{FeeRecipient: defaultRecipient},
+			},
+			passed: true,
+			detail: keyRecipient.Hex(),
+		},
+		{
+			name: "zero address",
+			settings: &validatorserviceconfig.ProposerSettings{
+				DefaultConfig: &validatorserviceconfig.ProposerOption{},
+			},
+			detail: "fee recipient is the zero address",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := checkFeeRecipient(tt.settings, pubKey)
+			assert.Equal(t, tt.passed, c.Passed)
+			assert.Equal(t, tt.detail, c.Detail)
+		})
+	}
+}
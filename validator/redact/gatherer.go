@@ -0,0 +1,41 @@
+package redact
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// pubKeyLabel is the name of the label of the validator metrics holding a public key.
+const pubKeyLabel = "pubkey"
+
+// Gatherer wraps a Prometheus gatherer, replacing the public keys labelling its metrics with
+// their identifiers.
+type Gatherer struct {
+	gatherer prometheus.Gatherer
+	redactor *Redactor
+}
+
+// NewGatherer returns a gatherer redacting the metrics of the Prometheus DefaultGatherer.
+func NewGatherer(r *Redactor) *Gatherer {
+	return &Gatherer{gatherer: prometheus.DefaultGatherer, redactor: r}
+}
+
+// Gather the metrics of the wrapped gatherer and relabel them. Label pairs may be shared with
+// the collectors of the metrics, so relabelled pairs are copies.
+func (g *Gatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	for _, family := range families {
+		for _, m := range family.Metric {
+			labels := make([]*dto.LabelPair, len(m.Label))
+			for i, label := range m.Label {
+				labels[i] = label
+				if label.GetName() == pubKeyLabel {
+					name, value := label.GetName(), g.redactor.PubKey(label.GetValue())
+					labels[i] = &dto.LabelPair{Name: &name, Value: &value}
+				}
+			}
+			m.Label = labels
+		}
+	}
+	return families, err
+}
@@ -0,0 +1,80 @@
+package redact
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Keys of the log fields holding validator public keys.
+var pubKeyFields = map[string]bool{
+	"pubKey":            true,
+	"pubkey":            true,
+	"pubKeys":           true,
+	"pubkeys":           true,
+	"publicKey":         true,
+	"publicKeys":        true,
+	"proposerPublicKey": true,
+	"attesterPublicKey": true,
+}
+
+// Keys of the log fields holding validator indices.
+var indexFields = map[string]bool{
+	"index":            true,
+	"validatorIndex":   true,
+	"validatorIndices": true,
+	"proposerIndex":    true,
+	"aggregatorIndex":  true,
+}
+
+// LogHook is a logrus hook replacing validator public keys and indices in log entries with
+// their identifiers. Public keys are redacted from the known public key fields in every form,
+// and from the message and error of the entry when they are written in full.
+type LogHook struct {
+	redactor *Redactor
+}
+
+// NewLogHook returns a log hook redacting with the given redactor.
+func NewLogHook(r *Redactor) *LogHook {
+	return &LogHook{redactor: r}
+}
+
+// Levels of the log entries redacted by the hook.
+func (*LogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire redacts a log entry. Entries are copies owned by the logger call, so they are modified
+// in place.
+func (h *LogHook) Fire(entry *logrus.Entry) error {
+	entry.Message = h.redactor.Text(entry.Message)
+	for k, v := range entry.Data {
+		switch {
+		case pubKeyFields[k]:
+			entry.Data[k] = h.redactor.PubKeys(formatHex(v))
+		case indexFields[k]:
+			entry.Data[k] = h.redactor.Indices(fmt.Sprint(v))
+		case k == logrus.ErrorKey:
+			if err, ok := v.(error); ok {
+				entry.Data[k] = h.redactor.Text(err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// formatHex formats byte slices and arrays, which are how public keys are held, as hex strings.
+func formatHex(v interface{}) string {
+	switch b := v.(type) {
+	case []byte:
+		return fmt.Sprintf("%#x", b)
+	case [48]byte:
+		return fmt.Sprintf("%#x", b)
+	case [][]byte:
+		return fmt.Sprintf("%#x", b)
+	case [][48]byte:
+		return fmt.Sprintf("%#x", b)
+	default:
+		return fmt.Sprint(v)
+	}
+}
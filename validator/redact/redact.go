@@ -0,0 +1,109 @@
+// Package redact replaces the public keys and indices of validators in logs and metrics with
+// opaque identifiers, so operators can share them publicly without revealing which validators
+// they run. The identifiers are keyed hashes, stable for as long as the secret key is kept.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/crypto/rand"
+	"github.com/prysmaticlabs/prysm/io/file"
+)
+
+const (
+	// SecretFileName is the name of the file, in the data directory, holding the secret key of
+	// the redaction identifiers.
+	SecretFileName = "redaction.key"
+	secretLength   = 32
+	// The number of leading hex characters of a public key identifying it, the length of the
+	// public keys truncated by bytesutil.Trunc, so full and truncated keys get the same identifier.
+	pubKeyPrefixLength = 12
+)
+
+var (
+	pubKeyRegex     = regexp.MustCompile(`(0x)?[0-9a-fA-F]{12,}`)
+	fullPubKeyRegex = regexp.MustCompile(`0x[0-9a-fA-F]{96}\b`)
+	indexRegex      = regexp.MustCompile(`[0-9]+`)
+)
+
+// Redactor computes the opaque identifiers of validator public keys and indices.
+type Redactor struct {
+	secret []byte
+}
+
+// New returns a redactor whose identifiers are keyed with the given secret.
+func New(secret []byte) *Redactor {
+	return &Redactor{secret: secret}
+}
+
+// LoadOrCreateSecret reads the secret key of the redaction identifiers from the given data
+// directory, creating it first if it does not exist.
+func LoadOrCreateSecret(dataDir string) ([]byte, error) {
+	path := filepath.Join(dataDir, SecretFileName)
+	if file.FileExists(path) {
+		secret, err := file.ReadFileAsBytes(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read redaction secret")
+		}
+		if len(secret) != secretLength {
+			return nil, fmt.Errorf("redaction secret %s is %d bytes long, expected %d", path, len(secret), secretLength)
+		}
+		return secret, nil
+	}
+	secret := make([]byte, secretLength)
+	if _, err := rand.NewGenerator().Read(secret); err != nil {
+		return nil, err
+	}
+	if err := file.MkdirAll(dataDir); err != nil {
+		return nil, errors.Wrap(err, "could not create data directory")
+	}
+	if err := file.WriteFile(path, secret); err != nil {
+		return nil, errors.Wrap(err, "could not write redaction secret")
+	}
+	return secret, nil
+}
+
+// PubKey returns the identifier of a hex encoded public key, with or without 0x prefix, and
+// either full or truncated.
+func (r *Redactor) PubKey(pubKey string) string {
+	key := strings.TrimPrefix(strings.ToLower(pubKey), "0x")
+	if len(key) > pubKeyPrefixLength {
+		key = key[:pubKeyPrefixLength]
+	}
+	return "pk-" + r.hash("pubkey:"+key)
+}
+
+// Index returns the identifier of a decimal validator index.
+func (r *Redactor) Index(index string) string {
+	return "vi-" + r.hash("index:"+index)
+}
+
+// PubKeys replaces every hex string in the given text with the identifier of the public key.
+func (r *Redactor) PubKeys(text string) string {
+	return pubKeyRegex.ReplaceAllStringFunc(text, r.PubKey)
+}
+
+// Indices replaces every decimal number in the given text with the identifier of the index.
+func (r *Redactor) Indices(text string) string {
+	return indexRegex.ReplaceAllStringFunc(text, r.Index)
+}
+
+// Text replaces the full 0x prefixed public keys found in free text with their identifiers.
+// Other hex strings, such as roots, are left as they are.
+func (r *Redactor) Text(text string) string {
+	return fullPubKeyRegex.ReplaceAllStringFunc(text, r.PubKey)
+}
+
+func (r *Redactor) hash(s string) string {
+	mac := hmac.New(sha256.New, r.secret)
+	// Writes to a hash never fail.
+	_, _ = mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil)[:6])
+}
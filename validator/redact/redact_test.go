@@ -0,0 +1,127 @@
+package redact
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/sirupsen/logrus"
+)
+
+var testPubKey = bytesutil.ToBytes48(bytes.Repeat([]byte{0xab, 0x12, 0x7f}, 16))
+
+func TestRedactor_PubKey(t *testing.T) {
+	r := New([]byte("secret"))
+	full := fmt.Sprintf("%#x", testPubKey)
+	id := r.PubKey(full)
+	assert.Equal(t, true, strings.HasPrefix(id, "pk-"))
+	assert.Equal(t, 15, len(id))
+	// Full, truncated, unprefixed and upper case forms of a key share its identifier.
+	assert.Equal(t, id, r.PubKey(fmt.Sprintf("%#x", bytesutil.Trunc(testPubKey[:]))))
+	assert.Equal(t, id, r.PubKey(fmt.Sprintf("%x", testPubKey)))
+	assert.Equal(t, id, r.PubKey(strings.ToUpper(full)))
+	assert.Equal(t, false, strings.Contains(id, "ab127f"))
+
+	other := testPubKey
+	other[0] = 0
+	assert.NotEqual(t, id, r.PubKey(fmt.Sprintf("%#x", other)))
+	// Identifiers depend on the secret.
+	assert.NotEqual(t, id, New([]byte("other secret")).PubKey(full))
+	assert.NotEqual(t, r.Index("1"), r.Index("2"))
+	assert.Equal(t, r.Index("1"), New([]byte("secret")).Index("1"))
+}
+
+func TestRedactor_Text(t *testing.T) {
+	r := New([]byte("secret"))
+	full := fmt.Sprintf("%#x", testPubKey)
+	root := fmt.Sprintf("%#x", bytes.Repeat([]byte{0xcd}, 32))
+	signature := fmt.Sprintf("%#x", bytes.Repeat([]byte{0xef}, 96))
+	text := fmt.Sprintf("key %s, root %s, signature %s", full, root, signature)
+	assert.Equal(t, fmt.Sprintf("key %s, root %s, signature %s", r.PubKey(full), root, signature), r.Text(text))
+
+	assert.Equal(t, fmt.Sprintf("[%s %s]", r.Index("3"), r.Index("14")), r.Indices("[3 14]"))
+}
+
+func TestLoadOrCreateSecret(t *testing.T) {
+	dataDir := filepath.Join(t.TempDir(), "validator")
+	secret, err := LoadOrCreateSecret(dataDir)
+	require.NoError(t, err)
+	assert.Equal(t, secretLength, len(secret))
+	loaded, err := LoadOrCreateSecret(dataDir)
+	require.NoError(t, err)
+	assert.DeepEqual(t, secret, loaded)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, SecretFileName), []byte("short"), 0600))
+	_, err = LoadOrCreateSecret(dataDir)
+	assert.ErrorContains(t, "is 5 bytes long", err)
+}
+
+func TestLogHook(t *testing.T) {
+	r := New([]byte("secret"))
+	buf := new(bytes.Buffer)
+	logger := logrus.New()
+	logger.SetOutput(buf)
+	logger.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true, DisableQuote: true})
+	logger.AddHook(NewLogHook(r))
+
+	full := fmt.Sprintf("%#x", testPubKey)
+	entry := logger.WithField("prefix", "validator")
+	entry.WithFields(logrus.Fields{
+		"pubKey":         fmt.Sprintf("%#x", bytesutil.Trunc(testPubKey[:])),
+		"publicKey":      testPubKey,
+		"pubKeys":        [][]byte{testPubKey[:]},
+		"validatorIndex": types.ValidatorIndex(42),
+		"slot":           42,
+	}).WithError(errors.New("no duties for " + full)).Info("Submitted attestation for " + full)
+
+	line := buf.String()
+	assert.Equal(t, false, strings.Contains(line, "ab127f"), line)
+	assert.Equal(t, false, strings.Contains(line, "AB127F"), line)
+	id := r.PubKey(full)
+	for _, want := range []string{
+		"msg=Submitted attestation for " + id,
+		"error=no duties for " + id,
+		"pubKey=" + id,
+		"publicKey=" + id,
+		"pubKeys=[" + id + "]",
+		"validatorIndex=" + r.Index("42"),
+		"slot=42",
+		"prefix=validator",
+	} {
+		assert.Equal(t, true, strings.Contains(line, want), "missing %s in %s", want, line)
+	}
+	// The fields of the parent entry are left untouched.
+	assert.Equal(t, 1, len(entry.Data))
+}
+
+func TestGatherer(t *testing.T) {
+	r := New([]byte("secret"))
+	registry := prometheus.NewRegistry()
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_total"}, []string{"pubkey", "tenant"})
+	registry.MustRegister(vec)
+	full := fmt.Sprintf("%#x", testPubKey)
+	vec.WithLabelValues(full, "a").Inc()
+
+	g := &Gatherer{gatherer: registry, redactor: r}
+	families, err := g.Gather()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(families))
+	labels := families[0].Metric[0].Label
+	require.Equal(t, 2, len(labels))
+	assert.Equal(t, r.PubKey(full), labels[0].GetValue())
+	assert.Equal(t, "a", labels[1].GetValue())
+
+	// Gathering again starts from the original labels.
+	families, err = g.Gather()
+	require.NoError(t, err)
+	assert.Equal(t, r.PubKey(full), families[0].Metric[0].Label[0].GetValue())
+}
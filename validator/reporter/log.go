@@ -0,0 +1,5 @@
+package reporter
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "reporter")
@@ -0,0 +1,292 @@
+// Package reporter aggregates the performance of the keys managed by the validator
+// client over a reporting period, and posts a human readable summary of it to a
+// webhook. The payload is compatible with both Discord and Slack incoming webhooks.
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultInterval is the period covered by each performance report.
+const DefaultInterval = 24 * time.Hour
+
+const postTimeout = 10 * time.Second
+
+// DefaultTemplate is the text/template used to render a Summary when no custom
+// template is configured.
+const DefaultTemplate = `**Validator performance report** (epochs {{.FromEpoch}}-{{.ToEpoch}}, {{.Validators}} validators)
+Attestations included: {{.AttestationsIncluded}}/{{.AttestationsExpected}} ({{printf "%.2f" .AttestationSuccessPct}}%)
+Correct source/target/head: {{printf "%.2f" .CorrectSourcePct}}% / {{printf "%.2f" .CorrectTargetPct}}% / {{printf "%.2f" .CorrectHeadPct}}%
+{{- if .InclusionDistanceCount}}
+Average inclusion distance: {{printf "%.2f" .AverageInclusionDistance}}
+{{- end}}
+Blocks proposed: {{.ProposalsSucceeded}}/{{.ProposalsAttempted}}
+Sync committee messages: {{.SyncMessagesSucceeded}}/{{.SyncMessagesAttempted}}`
+
+// Config for a performance Reporter.
+type Config struct {
+	// WebhookURL receives the rendered summary as a JSON payload.
+	WebhookURL string
+	// Template is a text/template rendering a Summary. DefaultTemplate is used when empty.
+	Template string
+	// Interval between two reports. DefaultInterval is used when zero.
+	Interval time.Duration
+	// Client is the HTTP client used to post reports. http.DefaultClient is used when nil.
+	Client *http.Client
+}
+
+// Summary of the performance of the managed keys over a reporting period.
+type Summary struct {
+	Start                  time.Time
+	End                    time.Time
+	FromEpoch              types.Epoch
+	ToEpoch                types.Epoch
+	Epochs                 uint64
+	Validators             int
+	AttestationsExpected   uint64
+	AttestationsIncluded   uint64
+	CorrectSource          uint64
+	CorrectTarget          uint64
+	CorrectHead            uint64
+	TotalInclusionDistance uint64
+	InclusionDistanceCount uint64
+	ProposalsAttempted     uint64
+	ProposalsSucceeded     uint64
+	SyncMessagesAttempted  uint64
+	SyncMessagesSucceeded  uint64
+}
+
+// AttestationSuccessPct is the percentage of expected attestations which were included.
+func (s *Summary) AttestationSuccessPct() float64 {
+	return pct(s.AttestationsIncluded, s.AttestationsExpected)
+}
+
+// CorrectSourcePct is the percentage of included attestations with a correct source vote.
+func (s *Summary) CorrectSourcePct() float64 {
+	return pct(s.CorrectSource, s.AttestationsIncluded)
+}
+
+// CorrectTargetPct is the percentage of included attestations with a correct target vote.
+func (s *Summary) CorrectTargetPct() float64 {
+	return pct(s.CorrectTarget, s.AttestationsIncluded)
+}
+
+// CorrectHeadPct is the percentage of included attestations with a correct head vote.
+func (s *Summary) CorrectHeadPct() float64 {
+	return pct(s.CorrectHead, s.AttestationsIncluded)
+}
+
+// AverageInclusionDistance of the included attestations. Inclusion distances are only
+// reported by the beacon node before Altair.
+func (s *Summary) AverageInclusionDistance() float64 {
+	if s.InclusionDistanceCount == 0 {
+		return 0
+	}
+	return float64(s.TotalInclusionDistance) / float64(s.InclusionDistanceCount)
+}
+
+func pct(n, d uint64) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d) * 100
+}
+
+// Reporter accumulates the performance of the managed keys and periodically posts it.
+type Reporter struct {
+	lock     sync.Mutex
+	url      string
+	tmpl     *template.Template
+	interval time.Duration
+	client   *http.Client
+	current  *Summary
+}
+
+// New creates a Reporter from the config, validating the template.
+func New(cfg *Config) (*Reporter, error) {
+	if cfg.WebhookURL == "" {
+		return nil, errors.New("no webhook url provided")
+	}
+	text := cfg.Template
+	if text == "" {
+		text = DefaultTemplate
+	}
+	tmpl, err := template.New("report").Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse report template")
+	}
+	// Render an empty summary so that errors in the template surface at startup
+	// rather than when the first report is due.
+	if err := tmpl.Execute(io.Discard, &Summary{}); err != nil {
+		return nil, errors.Wrap(err, "could not render report template")
+	}
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+	client := cfg.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Reporter{
+		url:      cfg.WebhookURL,
+		tmpl:     tmpl,
+		interval: interval,
+		client:   client,
+	}, nil
+}
+
+// summary returns the summary of the current period, starting one if needed.
+// The caller must hold the lock.
+func (r *Reporter) summary(epoch types.Epoch) *Summary {
+	if r.current == nil {
+		r.current = &Summary{Start: time.Now(), FromEpoch: epoch, ToEpoch: epoch}
+	}
+	return r.current
+}
+
+// RecordEpoch records the attestation performance of the managed keys in the given epoch,
+// as returned by the beacon node.
+func (r *Reporter) RecordEpoch(epoch types.Epoch, resp *ethpb.ValidatorPerformanceResponse) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	s := r.summary(epoch)
+	if epoch > s.ToEpoch {
+		s.ToEpoch = epoch
+	}
+	s.Epochs++
+	if len(resp.PublicKeys) > s.Validators {
+		s.Validators = len(resp.PublicKeys)
+	}
+	for i := range resp.PublicKeys {
+		s.AttestationsExpected++
+		source := i < len(resp.CorrectlyVotedSource) && resp.CorrectlyVotedSource[i]
+		target := i < len(resp.CorrectlyVotedTarget) && resp.CorrectlyVotedTarget[i]
+		head := i < len(resp.CorrectlyVotedHead) && resp.CorrectlyVotedHead[i]
+		if !source && !target {
+			continue
+		}
+		s.AttestationsIncluded++
+		if source {
+			s.CorrectSource++
+		}
+		if target {
+			s.CorrectTarget++
+		}
+		if head {
+			s.CorrectHead++
+		}
+		if i < len(resp.InclusionDistances) && i < len(resp.InclusionSlots) && uint64(resp.InclusionSlots[i]) != ^uint64(0) {
+			s.TotalInclusionDistance += uint64(resp.InclusionDistances[i])
+			s.InclusionDistanceCount++
+		}
+	}
+}
+
+// RecordProposal records a block proposal duty and whether the block was submitted.
+func (r *Reporter) RecordProposal(epoch types.Epoch, success bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	s := r.summary(epoch)
+	s.ProposalsAttempted++
+	if success {
+		s.ProposalsSucceeded++
+	}
+}
+
+// RecordSyncCommitteeMessage records a sync committee message duty and whether the
+// message was submitted.
+func (r *Reporter) RecordSyncCommitteeMessage(epoch types.Epoch, success bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	s := r.summary(epoch)
+	s.SyncMessagesAttempted++
+	if success {
+		s.SyncMessagesSucceeded++
+	}
+}
+
+// ReportIfDue posts the summary of the current period if it has lasted for at least the
+// configured interval, then starts a new period. The summary is dropped if it cannot be
+// posted, so that a failing webhook does not make reports grow unbounded.
+func (r *Reporter) ReportIfDue(ctx context.Context, now time.Time) error {
+	r.lock.Lock()
+	s := r.current
+	if s == nil || now.Sub(s.Start) < r.interval {
+		r.lock.Unlock()
+		return nil
+	}
+	r.current = nil
+	r.lock.Unlock()
+
+	s.End = now
+	return r.post(ctx, s)
+}
+
+// Render the summary with the configured template.
+func (r *Reporter) Render(s *Summary) (string, error) {
+	var buf bytes.Buffer
+	if err := r.tmpl.Execute(&buf, s); err != nil {
+		return "", errors.Wrap(err, "could not render report template")
+	}
+	return buf.String(), nil
+}
+
+// webhookPayload is understood by both Discord, which reads the content field, and
+// Slack, which reads the text field.
+type webhookPayload struct {
+	Content string `json:"content"`
+	Text    string `json:"text"`
+}
+
+func (r *Reporter) post(ctx context.Context, s *Summary) error {
+	text, err := r.Render(s)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(&webhookPayload{Content: text, Text: text})
+	if err != nil {
+		return errors.Wrap(err, "could not marshal webhook payload")
+	}
+	ctx, cancel := context.WithTimeout(ctx, postTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "could not create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not post performance report")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close webhook response body")
+		}
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, err := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if err != nil {
+			return errors.Wrapf(err, "webhook responded with status %d", resp.StatusCode)
+		}
+		return errors.Errorf("webhook responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	log.WithFields(logrus.Fields{
+		"fromEpoch": s.FromEpoch,
+		"toEpoch":   s.ToEpoch,
+	}).Info("Posted validator performance report")
+	return nil
+}
@@ -0,0 +1,110 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestNew_InvalidConfig(t *testing.T) {
+	_, err := New(&Config{})
+	assert.ErrorContains(t, "no webhook url provided", err)
+	_, err = New(&Config{WebhookURL: "http://localhost", Template: "{{.Unknown"})
+	assert.ErrorContains(t, "could not parse report template", err)
+	_, err = New(&Config{WebhookURL: "http://localhost", Template: "{{.Unknown}}"})
+	assert.ErrorContains(t, "could not render report template", err)
+}
+
+func TestReporter_RecordAndRender(t *testing.T) {
+	r, err := New(&Config{WebhookURL: "http://localhost"})
+	require.NoError(t, err)
+	r.RecordEpoch(10, &ethpb.ValidatorPerformanceResponse{
+		PublicKeys:           [][]byte{{1}, {2}, {3}, {4}},
+		CorrectlyVotedSource: []bool{true, true, true, false},
+		CorrectlyVotedTarget: []bool{true, true, false, false},
+		CorrectlyVotedHead:   []bool{true, false, false, false},
+		InclusionSlots:       []types.Slot{321, 322, 323, ^types.Slot(0)},
+		InclusionDistances:   []types.Slot{1, 2, 3, 0},
+	})
+	r.RecordEpoch(11, &ethpb.ValidatorPerformanceResponse{
+		PublicKeys:           [][]byte{{1}, {2}, {3}, {4}},
+		CorrectlyVotedSource: []bool{true, true, true, true},
+		CorrectlyVotedTarget: []bool{true, true, true, true},
+		CorrectlyVotedHead:   []bool{true, true, true, true},
+	})
+	r.RecordProposal(11, true)
+	r.RecordProposal(11, false)
+	r.RecordSyncCommitteeMessage(11, true)
+
+	s := r.current
+	assert.Equal(t, types.Epoch(10), s.FromEpoch)
+	assert.Equal(t, types.Epoch(11), s.ToEpoch)
+	assert.Equal(t, uint64(2), s.Epochs)
+	assert.Equal(t, uint64(8), s.AttestationsExpected)
+	assert.Equal(t, uint64(7), s.AttestationsIncluded)
+	assert.Equal(t, uint64(7), s.CorrectSource)
+	assert.Equal(t, uint64(6), s.CorrectTarget)
+	assert.Equal(t, uint64(5), s.CorrectHead)
+	assert.Equal(t, 2.0, s.AverageInclusionDistance())
+
+	text, err := r.Render(s)
+	require.NoError(t, err)
+	assert.Equal(t, true, strings.Contains(text, "epochs 10-11, 4 validators"), text)
+	assert.Equal(t, true, strings.Contains(text, "Attestations included: 7/8 (87.50%)"), text)
+	assert.Equal(t, true, strings.Contains(text, "Average inclusion distance: 2.00"), text)
+	assert.Equal(t, true, strings.Contains(text, "Blocks proposed: 1/2"), text)
+	assert.Equal(t, true, strings.Contains(text, "Sync committee messages: 1/1"), text)
+}
+
+func TestReporter_ReportIfDue(t *testing.T) {
+	var payloads []webhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var p webhookPayload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		payloads = append(payloads, p)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	r, err := New(&Config{WebhookURL: srv.URL, Template: "{{.ProposalsSucceeded}} blocks", Interval: time.Hour})
+	require.NoError(t, err)
+
+	// Nothing is posted without any recorded duty.
+	require.NoError(t, r.ReportIfDue(ctx, time.Now().Add(2*time.Hour)))
+	r.RecordProposal(1, true)
+	require.NoError(t, r.ReportIfDue(ctx, time.Now()))
+	assert.Equal(t, 0, len(payloads))
+
+	require.NoError(t, r.ReportIfDue(ctx, time.Now().Add(time.Hour)))
+	require.Equal(t, 1, len(payloads))
+	assert.Equal(t, "1 blocks", payloads[0].Content)
+	assert.Equal(t, "1 blocks", payloads[0].Text)
+
+	// A new period starts after a report.
+	require.NoError(t, r.ReportIfDue(ctx, time.Now().Add(time.Hour)))
+	assert.Equal(t, 1, len(payloads))
+}
+
+func TestReporter_ReportIfDue_WebhookError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	r, err := New(&Config{WebhookURL: srv.URL, Interval: time.Hour})
+	require.NoError(t, err)
+	r.RecordSyncCommitteeMessage(1, false)
+	err = r.ReportIfDue(context.Background(), time.Now().Add(time.Hour))
+	assert.ErrorContains(t, "webhook responded with status 400: invalid payload", err)
+	assert.Equal(t, true, r.current == nil, "summary should be dropped after a failed report")
+}
@@ -121,8 +121,9 @@ func (s *Server) refreshAuthTokenFromFileChanges(ctx context.Context, authTokenP
 		select {
 		case <-watcher.Events:
 			// If a file was modified, we attempt to read that file
-			// and parse it into our accounts store.
-			token, err := s.initializeAuthToken(s.walletDir)
+			// and parse it into our accounts store. The token is read from the
+			// directory of the watched file rather than the server's wallet dir.
+			token, err := s.initializeAuthToken(filepath.Dir(authTokenPath))
 			if err != nil {
 				log.WithError(err).Errorf("Could not watch for file changes for: %s", authTokenPath)
 				continue
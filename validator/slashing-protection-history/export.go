@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/monitoring/progress"
 	"github.com/prysmaticlabs/prysm/validator/db"
@@ -131,6 +132,71 @@ func ExportStandardProtectionJSON(
 	return interchangeJSON, nil
 }
 
+// ExportMinimalProtectionJSON extracts all slashing protection data from a validator database
+// and packages it into the minimal EIP-3076 format, which only keeps the highest signed block slot
+// and the highest signed source and target epochs of each validator.
+func ExportMinimalProtectionJSON(
+	ctx context.Context,
+	validatorDB db.Database,
+	filteredKeys ...[]byte,
+) (*format.EIPSlashingProtectionFormat, error) {
+	interchangeJSON, err := ExportStandardProtectionJSON(ctx, validatorDB, filteredKeys...)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range interchangeJSON.Data {
+		if err := minimizeProtectionData(item); err != nil {
+			return nil, errors.Wrapf(err, "could not minimize slashing protection data for public key %s", item.Pubkey)
+		}
+	}
+	return interchangeJSON, nil
+}
+
+// minimizeProtectionData replaces the signing history of a validator with a single block at the
+// highest signed slot and a single attestation made of the highest signed source and target epochs.
+// Signing roots are dropped, so importing clients refuse to sign anything at or below these values.
+func minimizeProtectionData(item *format.ProtectionData) error {
+	if len(item.SignedBlocks) > 0 {
+		var highestSlot types.Slot
+		for _, blk := range item.SignedBlocks {
+			slot, err := SlotFromString(blk.Slot)
+			if err != nil {
+				return fmt.Errorf("%s is not a valid slot: %w", blk.Slot, err)
+			}
+			if slot > highestSlot {
+				highestSlot = slot
+			}
+		}
+		item.SignedBlocks = []*format.SignedBlock{{
+			Slot: fmt.Sprintf("%d", highestSlot),
+		}}
+	}
+	if len(item.SignedAttestations) > 0 {
+		var highestSource, highestTarget types.Epoch
+		for _, att := range item.SignedAttestations {
+			source, err := EpochFromString(att.SourceEpoch)
+			if err != nil {
+				return fmt.Errorf("%s is not a valid epoch: %w", att.SourceEpoch, err)
+			}
+			target, err := EpochFromString(att.TargetEpoch)
+			if err != nil {
+				return fmt.Errorf("%s is not a valid epoch: %w", att.TargetEpoch, err)
+			}
+			if source > highestSource {
+				highestSource = source
+			}
+			if target > highestTarget {
+				highestTarget = target
+			}
+		}
+		item.SignedAttestations = []*format.SignedAttestation{{
+			SourceEpoch: fmt.Sprintf("%d", highestSource),
+			TargetEpoch: fmt.Sprintf("%d", highestTarget),
+		}}
+	}
+	return nil
+}
+
 func signedAttestationsByPubKey(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte) ([]*format.SignedAttestation, error) {
 	// If a key does not have an attestation history in our database, we return nil.
 	// This way, a user will be able to export their slashing protection history
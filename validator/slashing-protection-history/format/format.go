@@ -4,6 +4,14 @@ package format
 // The version Prysm supports is version 5.
 const InterchangeFormatVersion = "5"
 
+const (
+	// CompleteFormat is the interchange format holding every signed block and attestation of a validator.
+	CompleteFormat = "complete"
+	// MinimalFormat is the interchange format holding only the highest signed block slot and the highest
+	// signed source and target epochs of a validator, without signing roots.
+	MinimalFormat = "minimal"
+)
+
 // EIPSlashingProtectionFormat string representation of a standard
 // format for representing validator slashing protection db data.
 type EIPSlashingProtectionFormat struct {
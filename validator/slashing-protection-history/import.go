@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -84,6 +85,12 @@ func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database,
 		return errors.Wrap(err, "could not filter slashable attester public keys from JSON data")
 	}
 
+	conflictingProposerKeys, err := filterConflictingProposerKeys(ctx, validatorDB, proposalHistoryByPubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not filter conflicting proposer public keys from JSON data")
+	}
+	slashableProposerKeys = append(slashableProposerKeys, conflictingProposerKeys...)
+
 	slashablePublicKeys := make([][fieldparams.BLSPubkeyLength]byte, 0, len(slashableAttesterKeys)+len(slashableProposerKeys))
 	for _, pubKey := range slashableProposerKeys {
 		delete(proposalHistoryByPubKey, pubKey)
@@ -98,6 +105,12 @@ func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database,
 		return errors.Wrap(err, "could not save slashable public keys to database")
 	}
 
+	// Entries our database already covers are not saved again, so that importing
+	// the same history twice does not overwrite any of our signing roots.
+	if err := pruneRecordedHistory(ctx, validatorDB, proposalHistoryByPubKey, attestingHistoryByPubKey); err != nil {
+		return errors.Wrap(err, "could not compare JSON data with the history in our database")
+	}
+
 	// We save the histories to disk as atomic operations, ensuring that this only occurs
 	// until after we successfully parse all data from the JSON file. If there is any error
 	// in parsing the JSON proposal and attesting histories, we will not reach this point.
@@ -295,22 +308,149 @@ func filterSlashablePubKeysFromAttestations(
 	}
 	// Then, we need to find attestations that are slashable with respect to our database.
 	for pubKey, signedAtts := range signedAttsByPubKey {
+		recordedRoots, err := recordedAttestationRoots(ctx, validatorDB, pubKey)
+		if err != nil {
+			return nil, err
+		}
 		for _, att := range signedAtts {
+			// A vote we already recorded only conflicts with ours if both signing roots are known and differ.
+			if root, ok := recordedRoots[attestationVote{source: att.Source, target: att.Target}]; ok {
+				if signingRootsConflict(root, att.SigningRoot) {
+					slashablePubKeys = append(slashablePubKeys, pubKey)
+					break
+				}
+				continue
+			}
 			indexedAtt := createAttestation(att.Source, att.Target)
 			slashable, err := validatorDB.CheckSlashableAttestation(ctx, pubKey, att.SigningRoot, indexedAtt)
-			if err != nil {
-				return nil, err
-			}
-			// Malformed data should not prevent us from completing this function.
+			// Slashable attestations are reported along with an error describing them,
+			// so the kind is checked first.
 			if slashable != kv.NotSlashable {
 				slashablePubKeys = append(slashablePubKeys, pubKey)
 				break
 			}
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 	return slashablePubKeys, nil
 }
 
+// Conflict resolution between an imported block and a block we recorded at the same slot,
+// or an imported attestation and one we recorded with the same source and target epochs:
+//   If our record has no signing root or the same one, it already covers the imported entry,
+//   which is skipped.
+//   If only the imported entry has no signing root, it replaces our record, so that we refuse
+//   to sign anything for that slot or vote from then on.
+//   If both signing roots are known and differ, the public key is slashable.
+func filterConflictingProposerKeys(
+	ctx context.Context,
+	validatorDB db.Database,
+	historyByPubKey map[[fieldparams.BLSPubkeyLength]byte]kv.ProposalHistoryForPubkey,
+) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	conflictingPubKeys := make([][fieldparams.BLSPubkeyLength]byte, 0)
+	for pubKey, proposals := range historyByPubKey {
+		recordedRoots, err := recordedProposalRoots(ctx, validatorDB, pubKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, blk := range proposals.Proposals {
+			root, ok := recordedRoots[blk.Slot]
+			if ok && signingRootsConflict(root, bytesutil.ToBytes32(blk.SigningRoot)) {
+				conflictingPubKeys = append(conflictingPubKeys, pubKey)
+				break
+			}
+		}
+	}
+	return conflictingPubKeys, nil
+}
+
+// pruneRecordedHistory drops the imported blocks and attestations which our own records already cover.
+func pruneRecordedHistory(
+	ctx context.Context,
+	validatorDB db.Database,
+	proposalHistoryByPubKey map[[fieldparams.BLSPubkeyLength]byte]kv.ProposalHistoryForPubkey,
+	attestingHistoryByPubKey map[[fieldparams.BLSPubkeyLength]byte][]*kv.AttestationRecord,
+) error {
+	for pubKey, proposalHistory := range proposalHistoryByPubKey {
+		recordedRoots, err := recordedProposalRoots(ctx, validatorDB, pubKey)
+		if err != nil {
+			return err
+		}
+		proposals := make([]kv.Proposal, 0, len(proposalHistory.Proposals))
+		for _, proposal := range proposalHistory.Proposals {
+			root, ok := recordedRoots[proposal.Slot]
+			if ok && recordCovers(root, bytesutil.ToBytes32(proposal.SigningRoot)) {
+				continue
+			}
+			proposals = append(proposals, proposal)
+		}
+		proposalHistoryByPubKey[pubKey] = kv.ProposalHistoryForPubkey{Proposals: proposals}
+	}
+	for pubKey, attestations := range attestingHistoryByPubKey {
+		recordedRoots, err := recordedAttestationRoots(ctx, validatorDB, pubKey)
+		if err != nil {
+			return err
+		}
+		records := make([]*kv.AttestationRecord, 0, len(attestations))
+		for _, att := range attestations {
+			root, ok := recordedRoots[attestationVote{source: att.Source, target: att.Target}]
+			if ok && recordCovers(root, att.SigningRoot) {
+				continue
+			}
+			records = append(records, att)
+		}
+		attestingHistoryByPubKey[pubKey] = records
+	}
+	return nil
+}
+
+// attestationVote identifies an attestation by its source and target epochs.
+type attestationVote struct {
+	source types.Epoch
+	target types.Epoch
+}
+
+func recordedProposalRoots(
+	ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte,
+) (map[types.Slot][32]byte, error) {
+	proposals, err := validatorDB.ProposalHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get proposal history for public key %#x", pubKey)
+	}
+	roots := make(map[types.Slot][32]byte, len(proposals))
+	for _, proposal := range proposals {
+		roots[proposal.Slot] = bytesutil.ToBytes32(proposal.SigningRoot)
+	}
+	return roots, nil
+}
+
+func recordedAttestationRoots(
+	ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte,
+) (map[attestationVote][32]byte, error) {
+	history, err := validatorDB.AttestationHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get attestation history for public key %#x", pubKey)
+	}
+	roots := make(map[attestationVote][32]byte, len(history))
+	for _, att := range history {
+		roots[attestationVote{source: att.Source, target: att.Target}] = att.SigningRoot
+	}
+	return roots, nil
+}
+
+// recordCovers returns true if our record at a slot or vote makes the imported one redundant.
+func recordCovers(recorded, imported [32]byte) bool {
+	return recorded == params.BeaconConfig().ZeroHash || recorded == imported
+}
+
+// signingRootsConflict returns true if our record and the imported one signed different known roots.
+func signingRootsConflict(recorded, imported [32]byte) bool {
+	zeroHash := params.BeaconConfig().ZeroHash
+	return recorded != zeroHash && imported != zeroHash && recorded != imported
+}
+
 func transformSignedBlocks(_ context.Context, signedBlocks []*format.SignedBlock) (*kv.ProposalHistoryForPubkey, error) {
 	proposals := make([]kv.Proposal, len(signedBlocks))
 	for i, proposal := range signedBlocks {
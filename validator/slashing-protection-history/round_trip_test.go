@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"testing"
 
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/validator/db/kv"
@@ -373,3 +375,123 @@ func TestStore_ImportInterchangeData_BadFormat_PreventsDBWrites(t *testing.T) {
 		)
 	}
 }
+
+func TestImportExport_Minimal(t *testing.T) {
+	ctx := context.Background()
+	numValidators := 10
+	publicKeys, err := slashtest.CreateRandomPubKeys(numValidators)
+	require.NoError(t, err)
+	validatorDB := dbtest.SetupDB(t, publicKeys)
+
+	attestingHistory, proposalHistory := slashtest.MockAttestingAndProposalHistories(publicKeys)
+	wanted, err := slashtest.MockSlashingProtectionJSON(publicKeys, attestingHistory, proposalHistory)
+	require.NoError(t, err)
+	blob, err := json.Marshal(wanted)
+	require.NoError(t, err)
+	require.NoError(t, history.ImportStandardProtectionJSON(ctx, validatorDB, bytes.NewBuffer(blob)))
+
+	minimal, err := history.ExportMinimalProtectionJSON(ctx, validatorDB)
+	require.NoError(t, err)
+	require.Equal(t, wanted.Metadata, minimal.Metadata)
+	require.Equal(t, numValidators, len(minimal.Data))
+
+	wantedByPubKey := make(map[string]int, numValidators)
+	for i, pubKey := range publicKeys {
+		wantedByPubKey[fmt.Sprintf("%#x", pubKey)] = i
+	}
+	for _, item := range minimal.Data {
+		i, ok := wantedByPubKey[item.Pubkey]
+		require.Equal(t, true, ok)
+		proposals := proposalHistory[i].Proposals
+		require.DeepEqual(t, []*format.SignedBlock{{
+			Slot: fmt.Sprintf("%d", proposals[len(proposals)-1].Slot),
+		}}, item.SignedBlocks)
+		atts := attestingHistory[i]
+		if len(atts) == 0 {
+			require.Equal(t, 0, len(item.SignedAttestations))
+			continue
+		}
+		require.DeepEqual(t, []*format.SignedAttestation{{
+			SourceEpoch: fmt.Sprintf("%d", atts[len(atts)-1].Source),
+			TargetEpoch: fmt.Sprintf("%d", atts[len(atts)-1].Target),
+		}}, item.SignedAttestations)
+	}
+
+	// Importing the minimal history into the database it was exported from is a no-op
+	// and must not mark any key as slashable.
+	blob, err = json.Marshal(minimal)
+	require.NoError(t, err)
+	require.NoError(t, history.ImportStandardProtectionJSON(ctx, validatorDB, bytes.NewBuffer(blob)))
+	slashableKeys, err := validatorDB.EIPImportBlacklistedPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(slashableKeys))
+}
+
+func TestImportInterchangeData_ConflictResolution(t *testing.T) {
+	ctx := context.Background()
+	publicKeys, err := slashtest.CreateRandomPubKeys(3)
+	require.NoError(t, err)
+	validatorDB := dbtest.SetupDB(t, publicKeys)
+
+	// Every validator signed a block at slot 10 and an attestation with source 1 and target 2.
+	recordedRoot := [32]byte{'a'}
+	for _, pubKey := range publicKeys {
+		require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 10, recordedRoot[:]))
+		require.NoError(t, validatorDB.SaveAttestationForPubKey(ctx, pubKey, recordedRoot, createAttestation(1, 2)))
+	}
+	conflictingRoot := fmt.Sprintf("%#x", [32]byte{'b'})
+	interchange := &format.EIPSlashingProtectionFormat{
+		Data: []*format.ProtectionData{
+			{
+				// Identical entries are skipped.
+				Pubkey:             fmt.Sprintf("%#x", publicKeys[0]),
+				SignedBlocks:       []*format.SignedBlock{{Slot: "10", SigningRoot: fmt.Sprintf("%#x", recordedRoot)}},
+				SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: fmt.Sprintf("%#x", recordedRoot)}},
+			},
+			{
+				// Entries without signing roots replace ours.
+				Pubkey:             fmt.Sprintf("%#x", publicKeys[1]),
+				SignedBlocks:       []*format.SignedBlock{{Slot: "10"}},
+				SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "2"}},
+			},
+			{
+				// Entries with different signing roots are slashable.
+				Pubkey:             fmt.Sprintf("%#x", publicKeys[2]),
+				SignedBlocks:       []*format.SignedBlock{{Slot: "10", SigningRoot: conflictingRoot}},
+				SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: conflictingRoot}},
+			},
+		},
+	}
+	interchange.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	interchange.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", [32]byte{1})
+	blob, err := json.Marshal(interchange)
+	require.NoError(t, err)
+	require.NoError(t, history.ImportStandardProtectionJSON(ctx, validatorDB, bytes.NewBuffer(blob)))
+
+	slashableKeys, err := validatorDB.EIPImportBlacklistedPublicKeys(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{publicKeys[2]}, slashableKeys)
+
+	for i, wantedRoot := range [][32]byte{recordedRoot, {}, recordedRoot} {
+		root, exists, err := validatorDB.ProposalHistoryForSlot(ctx, publicKeys[i], 10)
+		require.NoError(t, err)
+		require.Equal(t, true, exists)
+		assert.Equal(t, wantedRoot, root)
+		root, err = validatorDB.SigningRootAtTargetEpoch(ctx, publicKeys[i], 2)
+		require.NoError(t, err)
+		assert.Equal(t, wantedRoot, root)
+	}
+}
+
+func createAttestation(source, target types.Epoch) *ethpb.IndexedAttestation {
+	return &ethpb.IndexedAttestation{
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{
+				Epoch: source,
+			},
+			Target: &ethpb.Checkpoint{
+				Epoch: target,
+			},
+		},
+	}
+}